@@ -37,9 +37,12 @@ import (
 	"antrea.io/antrea/pkg/agent/cniserver"
 	"antrea.io/antrea/pkg/agent/cniserver/ipam"
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/controller/bgp"
 	"antrea.io/antrea/pkg/agent/controller/egress"
 	"antrea.io/antrea/pkg/agent/controller/ipseccertificate"
+	"antrea.io/antrea/pkg/agent/controller/l7flowexporter"
 	"antrea.io/antrea/pkg/agent/controller/networkpolicy"
+	"antrea.io/antrea/pkg/agent/controller/networkpolicy/l7engine"
 	"antrea.io/antrea/pkg/agent/controller/noderoute"
 	"antrea.io/antrea/pkg/agent/controller/serviceexternalip"
 	"antrea.io/antrea/pkg/agent/controller/traceflow"
@@ -55,6 +58,7 @@ import (
 	"antrea.io/antrea/pkg/agent/nodeip"
 	npl "antrea.io/antrea/pkg/agent/nodeportlocal"
 	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/packetcapture"
 	"antrea.io/antrea/pkg/agent/proxy"
 	proxytypes "antrea.io/antrea/pkg/agent/proxy/types"
 	"antrea.io/antrea/pkg/agent/querier"
@@ -115,6 +119,7 @@ func run(o *Options) error {
 	externalIPPoolInformer := crdInformerFactory.Crd().V1beta1().ExternalIPPools()
 	trafficControlInformer := crdInformerFactory.Crd().V1alpha2().TrafficControls()
 	ipPoolInformer := crdInformerFactory.Crd().V1alpha2().IPPools()
+	ipAllocationInformer := crdInformerFactory.Crd().V1alpha2().IPAllocations()
 	nodeInformer := informerFactory.Core().V1().Nodes()
 	serviceInformer := informerFactory.Core().V1().Services()
 	endpointsInformer := informerFactory.Core().V1().Endpoints()
@@ -140,9 +145,11 @@ func run(o *Options) error {
 	enableAntreaIPAM := features.DefaultFeatureGate.Enabled(features.AntreaIPAM)
 	enableBridgingMode := enableAntreaIPAM && o.config.EnableBridgingMode
 	l7NetworkPolicyEnabled := features.DefaultFeatureGate.Enabled(features.L7NetworkPolicy)
+	nodeNetworkPolicyEnabled := features.DefaultFeatureGate.Enabled(features.NodeNetworkPolicy)
 	enableMulticlusterGW := features.DefaultFeatureGate.Enabled(features.Multicluster) && o.config.Multicluster.EnableGateway
 	enableMulticlusterNP := features.DefaultFeatureGate.Enabled(features.Multicluster) && o.config.Multicluster.EnableStretchedNetworkPolicy
 	enableFlowExporter := features.DefaultFeatureGate.Enabled(features.FlowExporter) && o.config.FlowExporter.Enable
+	l7FlowExporterEnabled := features.DefaultFeatureGate.Enabled(features.L7FlowExporter)
 	var nodeIPTracker *nodeip.Tracker
 	if o.nodeType == config.K8sNode {
 		nodeIPTracker = nodeip.NewTracker(nodeInformer)
@@ -161,6 +168,7 @@ func run(o *Options) error {
 		o.enableAntreaProxy,
 		features.DefaultFeatureGate.Enabled(features.AntreaPolicy),
 		l7NetworkPolicyEnabled,
+		l7FlowExporterEnabled,
 		o.enableEgress,
 		features.DefaultFeatureGate.Enabled(features.EgressTrafficShaping),
 		enableFlowExporter,
@@ -209,7 +217,8 @@ func run(o *Options) error {
 	}
 
 	wireguardConfig := &config.WireGuardConfig{
-		Port: o.config.WireGuard.Port,
+		Port:                o.config.WireGuard.Port,
+		KeyRotationInterval: o.wireGuardKeyRotationInterval,
 	}
 	exceptCIDRs := []net.IPNet{}
 	for _, cidr := range o.config.Egress.ExceptCIDRs {
@@ -219,7 +228,7 @@ func run(o *Options) error {
 	egressConfig := &config.EgressConfig{
 		ExceptCIDRs: exceptCIDRs,
 	}
-	routeClient, err := route.NewClient(networkConfig, o.config.NoSNAT, o.config.AntreaProxy.ProxyAll, connectUplinkToBridge, multicastEnabled, serviceCIDRProvider)
+	routeClient, err := route.NewClient(networkConfig, o.config.NoSNAT, o.config.AntreaProxy.ProxyAll, connectUplinkToBridge, nodeNetworkPolicyEnabled, multicastEnabled, serviceCIDRProvider)
 	if err != nil {
 		return fmt.Errorf("error creating route client: %v", err)
 	}
@@ -299,6 +308,7 @@ func run(o *Options) error {
 	var nodeRouteController *noderoute.Controller
 	if o.nodeType == config.K8sNode {
 		nodeRouteController = noderoute.NewNodeRouteController(
+			k8sClient,
 			nodeInformer,
 			ofClient,
 			ovsctl.NewClient(o.config.OVSBridge),
@@ -420,6 +430,7 @@ func run(o *Options) error {
 			nodePortAddressesIPv6,
 			o.config.AntreaProxy,
 			o.defaultLoadBalancerMode,
+			o.defaultLoadBalancerAlgorithm,
 			v4GroupCounter,
 			v6GroupCounter,
 			enableMulticlusterGW)
@@ -456,9 +467,14 @@ func run(o *Options) error {
 	if o.nodeType == config.ExternalNode {
 		nodeKey = k8s.NamespacedName(o.config.ExternalNode.ExternalNodeNamespace, nodeKey)
 	}
+	var l7Reconciler *l7engine.Reconciler
+	if l7NetworkPolicyEnabled {
+		l7Reconciler = l7engine.NewReconciler(l7FlowExporterEnabled)
+	}
 	networkPolicyController, err := networkpolicy.NewNetworkPolicyController(
 		antreaClientProvider,
 		ofClient,
+		routeClient,
 		ifaceStore,
 		afero.NewOsFs(),
 		nodeKey,
@@ -468,12 +484,15 @@ func run(o *Options) error {
 		groupIDUpdates,
 		antreaPolicyEnabled,
 		l7NetworkPolicyEnabled,
+		l7Reconciler,
+		nodeNetworkPolicyEnabled,
 		o.enableAntreaProxy,
 		statusManagerEnabled,
 		multicastEnabled,
 		auditLoggerOptions,
 		asyncRuleDeleteInterval,
 		o.dnsServerOverride,
+		o.config.EnableFQDNCacheSharing,
 		o.nodeType,
 		v4Enabled,
 		v6Enabled,
@@ -513,7 +532,7 @@ func run(o *Options) error {
 	if o.enableEgress {
 		egressController, err = egress.NewEgressController(
 			ofClient, antreaClientProvider, crdClient, ifaceStore, routeClient, nodeConfig.Name, nodeConfig.NodeTransportInterfaceName,
-			memberlistCluster, egressInformer, nodeInformer, podUpdateChannel, serviceCIDRProvider, o.config.Egress.MaxEgressIPsPerNode,
+			memberlistCluster, egressInformer, externalIPPoolInformer, nodeInformer, podUpdateChannel, serviceCIDRProvider, o.config.Egress.MaxEgressIPsPerNode,
 			features.DefaultFeatureGate.Enabled(features.EgressTrafficShaping),
 		)
 		if err != nil {
@@ -601,6 +620,28 @@ func run(o *Options) error {
 			o.enableAntreaProxy)
 	}
 
+	var packetCaptureController *packetcapture.Controller
+	if features.DefaultFeatureGate.Enabled(features.PacketCapture) {
+		packetCaptureController = packetcapture.NewPacketCaptureController(
+			nodeConfig.Name,
+			k8sClient,
+			crdClient,
+			crdInformerFactory.Crd().V1alpha1().PacketCaptures(),
+			ifaceStore,
+			ovsBridgeClient)
+	}
+
+	var bgpController *bgp.Controller
+	if features.DefaultFeatureGate.Enabled(features.BGPPolicy) {
+		bgpController = bgp.NewBGPPolicyController(
+			nodeConfig,
+			nodeInformer,
+			crdInformerFactory.Crd().V1alpha1().BGPPolicies(),
+			serviceInformer,
+			endpointSliceInformer,
+			egressInformer)
+	}
+
 	// TODO: we should call this after installing flows for initial node routes
 	//  and initial NetworkPolicies so that no packets will be mishandled.
 	if err := agentInitializer.FlowRestoreComplete(); err != nil {
@@ -629,7 +670,8 @@ func run(o *Options) error {
 			IdleFlowTimeout:        o.idleFlowTimeout,
 			StaleConnectionTimeout: o.staleConnectionTimeout,
 			PollInterval:           o.pollInterval,
-			ConnectUplinkToBridge:  connectUplinkToBridge}
+			ConnectUplinkToBridge:  connectUplinkToBridge,
+			L7FlowExporterEnabled:  l7FlowExporterEnabled}
 		flowExporter, err = exporter.NewFlowExporter(
 			podStore,
 			proxier,
@@ -690,7 +732,7 @@ func run(o *Options) error {
 	// Antrea IPAM is needed by bridging mode and secondary network IPAM.
 	if enableAntreaIPAM {
 		ipamController, err := ipam.InitializeAntreaIPAMController(
-			crdClient, namespaceInformer, ipPoolInformer, localPodInformer.Get(), enableBridgingMode)
+			crdClient, namespaceInformer, ipPoolInformer, ipAllocationInformer, localPodInformer.Get(), enableBridgingMode)
 		if err != nil {
 			return fmt.Errorf("failed to start Antrea IPAM agent: %v", err)
 		}
@@ -700,7 +742,7 @@ func run(o *Options) error {
 	if features.DefaultFeatureGate.Enabled(features.SecondaryNetwork) {
 		if err := secondarynetwork.Initialize(
 			o.config.ClientConnection, o.config.KubeAPIServerOverride,
-			k8sClient, localPodInformer.Get(), nodeConfig.Name, cniPodInfoStore,
+			k8sClient, localPodInformer.Get(), nodeInformer, nodeConfig.Name, cniPodInfoStore,
 			stopCh,
 			&o.config.SecondaryNetwork, ovsdbConnection); err != nil {
 			return fmt.Errorf("failed to initialize secondary network: %v", err)
@@ -719,6 +761,15 @@ func run(o *Options) error {
 		go tcController.Run(stopCh)
 	}
 
+	if l7FlowExporterEnabled {
+		l7FlowExporterController := l7flowexporter.NewL7FlowExporterController(ofClient,
+			ifaceStore,
+			l7Reconciler,
+			localPodInformer.Get(),
+			podUpdateChannel)
+		go l7FlowExporterController.Run(stopCh)
+	}
+
 	//  Start the localPodInformer
 	if localPodInformer.Evaluated() {
 		go localPodInformer.Get().Run(stopCh)
@@ -740,6 +791,14 @@ func run(o *Options) error {
 		go traceflowController.Run(stopCh)
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.PacketCapture) {
+		go packetCaptureController.Run(stopCh)
+	}
+
+	if features.DefaultFeatureGate.Enabled(features.BGPPolicy) {
+		go bgpController.Run(stopCh)
+	}
+
 	if o.enableAntreaProxy {
 		go proxier.GetProxyProvider().Run(stopCh)
 
@@ -771,6 +830,13 @@ func run(o *Options) error {
 		if err != nil {
 			return fmt.Errorf("failed to create multicast socket")
 		}
+		var ipv6MulticastSocket multicast.RouteInterface
+		if v6Enabled {
+			ipv6MulticastSocket, err = multicast.CreateIPv6MulticastSocket()
+			if err != nil {
+				return fmt.Errorf("failed to create IPv6 multicast socket: %w", err)
+			}
+		}
 		var validator agenttypes.McastNetworkPolicyController
 		if antreaPolicyEnabled {
 			validator = networkPolicyController
@@ -781,6 +847,7 @@ func run(o *Options) error {
 			nodeConfig,
 			ifaceStore,
 			multicastSocket,
+			ipv6MulticastSocket,
 			sets.New[string](append(o.config.Multicast.MulticastInterfaces, nodeConfig.NodeTransportInterfaceName)...),
 			podUpdateChannel,
 			o.igmpQueryInterval,
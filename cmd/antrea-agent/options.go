@@ -61,6 +61,9 @@ const (
 	defaultAuditLogsMaxAge         = 28
 	defaultAuditLogsCompressed     = true
 	defaultPacketInRate            = 500
+	// minWireGuardKeyRotationInterval is the minimum WireGuard key rotation interval, which must be longer than the
+	// maximum time to wait for the peers to accept the next key.
+	minWireGuardKeyRotationInterval = 10 * time.Minute
 )
 
 var defaultIGMPQueryVersions = []int{1, 2, 3}
@@ -103,7 +106,10 @@ type Options struct {
 	// was promoted to GA in v1.14
 	enableNodePortLocal bool
 
-	defaultLoadBalancerMode config.LoadBalancerMode
+	defaultLoadBalancerMode      config.LoadBalancerMode
+	defaultLoadBalancerAlgorithm config.LoadBalancerAlgorithm
+
+	wireGuardKeyRotationInterval time.Duration
 }
 
 func newOptions() *Options {
@@ -263,6 +269,11 @@ func (o *Options) validateAntreaProxyConfig(encapMode config.TrafficEncapModeTyp
 		}
 	}
 	o.defaultLoadBalancerMode = defaultLoadBalancerMode
+	ok, defaultLoadBalancerAlgorithm := config.GetLoadBalancerAlgorithmFromStr(o.config.AntreaProxy.DefaultLoadBalancerAlgorithm)
+	if !ok {
+		return fmt.Errorf("LoadBalancerAlgorithm %s is unknown", o.config.AntreaProxy.DefaultLoadBalancerAlgorithm)
+	}
+	o.defaultLoadBalancerAlgorithm = defaultLoadBalancerAlgorithm
 	return nil
 }
 
@@ -317,6 +328,14 @@ func (o *Options) validateFlowExporterConfig() error {
 	} else if o.config.FlowExporter.Enable {
 		klog.InfoS("The FlowExporter.enable config option is set to true, but it will be ignored because the FlowExporter feature gate is disabled")
 	}
+	if features.DefaultFeatureGate.Enabled(features.L7FlowExporter) {
+		if !features.DefaultFeatureGate.Enabled(features.L7NetworkPolicy) {
+			return fmt.Errorf("L7FlowExporter requires the L7NetworkPolicy feature gate to be enabled")
+		}
+		if !features.DefaultFeatureGate.Enabled(features.FlowExporter) || !o.config.FlowExporter.Enable {
+			return fmt.Errorf("L7FlowExporter requires the FlowExporter feature gate to be enabled and the FlowExporter.enable config option to be set to true")
+		}
+	}
 	return nil
 }
 
@@ -385,9 +404,30 @@ func (o *Options) validateMulticlusterConfig(encapMode config.TrafficEncapModeTy
 	if encapMode.SupportsEncap() && encryptionMode == config.TrafficEncryptionModeWireGuard {
 		return fmt.Errorf("Multi-cluster Gateway doesn't support in-cluster WireGuard encryption")
 	}
+	if multiclusterEncryptionMode == config.TrafficEncryptionModeWireGuard {
+		if _, err := validateWireGuardKeyRotationInterval(o.config.Multicluster.WireGuard.KeyRotationInterval); err != nil {
+			return fmt.Errorf("failed to validate Multi-cluster WireGuard config: %v", err)
+		}
+	}
 	return nil
 }
 
+// validateWireGuardKeyRotationInterval parses the WireGuard key rotation interval. It returns 0 if key rotation is
+// disabled.
+func validateWireGuardKeyRotationInterval(keyRotationInterval string) (time.Duration, error) {
+	if keyRotationInterval == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(keyRotationInterval)
+	if err != nil {
+		return 0, fmt.Errorf("keyRotationInterval %s is invalid: %v", keyRotationInterval, err)
+	}
+	if interval != 0 && interval < minWireGuardKeyRotationInterval {
+		return 0, fmt.Errorf("keyRotationInterval %s must be at least %s", keyRotationInterval, minWireGuardKeyRotationInterval)
+	}
+	return interval, nil
+}
+
 func (o *Options) setK8sNodeDefaultOptions() {
 	if o.config.CNISocket == "" {
 		o.config.CNISocket = cni.AntreaCNISocketAddr
@@ -420,6 +460,9 @@ func (o *Options) setK8sNodeDefaultOptions() {
 	if o.config.AntreaProxy.DefaultLoadBalancerMode == "" {
 		o.config.AntreaProxy.DefaultLoadBalancerMode = config.LoadBalancerModeNAT.String()
 	}
+	if o.config.AntreaProxy.DefaultLoadBalancerAlgorithm == "" {
+		o.config.AntreaProxy.DefaultLoadBalancerAlgorithm = config.LoadBalancerAlgorithmRandom.String()
+	}
 	if o.config.ClusterMembershipPort == 0 {
 		o.config.ClusterMembershipPort = apis.AntreaAgentClusterMembershipPort
 	}
@@ -593,6 +636,13 @@ func (o *Options) validateK8sNodeOptions() error {
 	if err := o.validateMulticlusterConfig(encapMode, encryptionMode); err != nil {
 		return err
 	}
+	if encryptionMode == config.TrafficEncryptionModeWireGuard {
+		interval, err := validateWireGuardKeyRotationInterval(o.config.WireGuard.KeyRotationInterval)
+		if err != nil {
+			return fmt.Errorf("failed to validate WireGuard config: %v", err)
+		}
+		o.wireGuardKeyRotationInterval = interval
+	}
 	if err := o.validateNodePortLocalConfig(); err != nil {
 		return fmt.Errorf("failed to validate nodePortLocal config: %v", err)
 	}
@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -82,31 +83,36 @@ func TestOptionsValidateTLSOptions(t *testing.T) {
 
 func TestOptionsValidateAntreaProxyConfig(t *testing.T) {
 	tests := []struct {
-		name                            string
-		enabledDSR                      bool
-		trafficEncapMode                config.TrafficEncapModeType
-		antreaProxyConfig               agentconfig.AntreaProxyConfig
-		expectedErr                     string
-		expectedDefaultLoadBalancerMode config.LoadBalancerMode
+		name                                 string
+		enabledDSR                           bool
+		trafficEncapMode                     config.TrafficEncapModeType
+		antreaProxyConfig                    agentconfig.AntreaProxyConfig
+		expectedErr                          string
+		expectedDefaultLoadBalancerMode      config.LoadBalancerMode
+		expectedDefaultLoadBalancerAlgorithm config.LoadBalancerAlgorithm
 	}{
 		{
 			name:             "default",
 			trafficEncapMode: config.TrafficEncapModeEncap,
 			antreaProxyConfig: agentconfig.AntreaProxyConfig{
-				Enable:                  pointer.Bool(true),
-				DefaultLoadBalancerMode: config.LoadBalancerModeNAT.String(),
+				Enable:                       pointer.Bool(true),
+				DefaultLoadBalancerMode:      config.LoadBalancerModeNAT.String(),
+				DefaultLoadBalancerAlgorithm: config.LoadBalancerAlgorithmRandom.String(),
 			},
-			expectedDefaultLoadBalancerMode: config.LoadBalancerModeNAT,
+			expectedDefaultLoadBalancerMode:      config.LoadBalancerModeNAT,
+			expectedDefaultLoadBalancerAlgorithm: config.LoadBalancerAlgorithmRandom,
 		},
 		{
 			name:             "DSR enabled",
 			enabledDSR:       true,
 			trafficEncapMode: config.TrafficEncapModeEncap,
 			antreaProxyConfig: agentconfig.AntreaProxyConfig{
-				Enable:                  pointer.Bool(true),
-				DefaultLoadBalancerMode: config.LoadBalancerModeDSR.String(),
+				Enable:                       pointer.Bool(true),
+				DefaultLoadBalancerMode:      config.LoadBalancerModeDSR.String(),
+				DefaultLoadBalancerAlgorithm: config.LoadBalancerAlgorithmRandom.String(),
 			},
-			expectedDefaultLoadBalancerMode: config.LoadBalancerModeDSR,
+			expectedDefaultLoadBalancerMode:      config.LoadBalancerModeDSR,
+			expectedDefaultLoadBalancerAlgorithm: config.LoadBalancerAlgorithmRandom,
 		},
 		{
 			name: "LoadBalancerModeDSR disabled",
@@ -136,6 +142,28 @@ func TestOptionsValidateAntreaProxyConfig(t *testing.T) {
 			},
 			expectedErr: "LoadBalancerMode drs is unknown",
 		},
+		{
+			name:             "Maglev algorithm",
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			antreaProxyConfig: agentconfig.AntreaProxyConfig{
+				Enable:                       pointer.Bool(true),
+				DefaultLoadBalancerMode:      config.LoadBalancerModeNAT.String(),
+				DefaultLoadBalancerAlgorithm: config.LoadBalancerAlgorithmMaglev.String(),
+			},
+			expectedDefaultLoadBalancerMode:      config.LoadBalancerModeNAT,
+			expectedDefaultLoadBalancerAlgorithm: config.LoadBalancerAlgorithmMaglev,
+		},
+		{
+			name:             "invalid LoadBalancerAlgorithm",
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			antreaProxyConfig: agentconfig.AntreaProxyConfig{
+				Enable:                       pointer.Bool(true),
+				DefaultLoadBalancerMode:      config.LoadBalancerModeNAT.String(),
+				DefaultLoadBalancerAlgorithm: "ring",
+			},
+			expectedDefaultLoadBalancerMode: config.LoadBalancerModeNAT,
+			expectedErr:                     "LoadBalancerAlgorithm ring is unknown",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -151,6 +179,7 @@ func TestOptionsValidateAntreaProxyConfig(t *testing.T) {
 				require.ErrorContains(t, err, tt.expectedErr)
 			}
 			assert.Equal(t, tt.expectedDefaultLoadBalancerMode, o.defaultLoadBalancerMode)
+			assert.Equal(t, tt.expectedDefaultLoadBalancerAlgorithm, o.defaultLoadBalancerAlgorithm)
 		})
 	}
 }
@@ -249,6 +278,52 @@ func TestOptionsValidateMulticastConfig(t *testing.T) {
 	}
 }
 
+func TestValidateWireGuardKeyRotationInterval(t *testing.T) {
+	tests := []struct {
+		name             string
+		interval         string
+		expectedErr      string
+		expectedInterval time.Duration
+	}{
+		{
+			name:             "disabled",
+			interval:         "",
+			expectedInterval: 0,
+		},
+		{
+			name:             "disabled with zero interval",
+			interval:         "0",
+			expectedInterval: 0,
+		},
+		{
+			name:             "valid interval",
+			interval:         "24h",
+			expectedInterval: 24 * time.Hour,
+		},
+		{
+			name:        "invalid interval",
+			interval:    "1d",
+			expectedErr: "keyRotationInterval 1d is invalid",
+		},
+		{
+			name:        "too short interval",
+			interval:    "5m",
+			expectedErr: "keyRotationInterval 5m must be at least 10m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval, err := validateWireGuardKeyRotationInterval(tt.interval)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInterval, interval)
+			}
+		})
+	}
+}
+
 func TestOptionsValidateSecondaryNetworkConfig(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -150,6 +150,7 @@ func run(o *Options) error {
 	externalIPPoolInformer := crdInformerFactory.Crd().V1beta1().ExternalIPPools()
 	externalNodeInformer := crdInformerFactory.Crd().V1alpha1().ExternalNodes()
 	ipPoolInformer := crdInformerFactory.Crd().V1alpha2().IPPools()
+	ipAllocationInformer := crdInformerFactory.Crd().V1alpha2().IPAllocations()
 	adminNPInformer := policyInformerFactory.Policy().V1alpha1().AdminNetworkPolicies()
 	banpInformer := policyInformerFactory.Policy().V1alpha1().BaselineAdminNetworkPolicies()
 
@@ -277,6 +278,7 @@ func run(o *Options) error {
 	if features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
 		antreaIPAMController = antreaipam.NewAntreaIPAMController(crdClient,
 			ipPoolInformer,
+			ipAllocationInformer,
 			namespaceInformer,
 			podInformer,
 			statefulSetInformer)
@@ -295,12 +297,14 @@ func run(o *Options) error {
 		egressGroupStore,
 		bundleCollectionStore,
 		podInformer,
+		nodeInformer,
 		eeInformer,
 		controllerQuerier,
 		endpointQuerier,
 		networkPolicyController,
 		networkPolicyStatusController,
 		egressController,
+		antreaIPAMController,
 		statsAggregator,
 		bundleCollectionController,
 		traceflowController,
@@ -488,12 +492,14 @@ func createAPIServerConfig(kubeconfig string,
 	egressGroupStore storage.Interface,
 	supportBundleCollectionStore storage.Interface,
 	podInformer coreinformers.PodInformer,
+	nodeInformer coreinformers.NodeInformer,
 	eeInformer crdv1a2informers.ExternalEntityInformer,
 	controllerQuerier querier.ControllerQuerier,
 	endpointQuerier networkpolicy.EndpointQuerier,
 	npController *networkpolicy.NetworkPolicyController,
 	networkPolicyStatusController *networkpolicy.StatusController,
 	egressController *egress.EgressController,
+	antreaIPAMController *antreaipam.AntreaIPAMController,
 	statsAggregator *stats.Aggregator,
 	bundleCollectionStore *supportbundlecollection.Controller,
 	traceflowController *traceflow.Controller,
@@ -555,6 +561,7 @@ func createAPIServerConfig(kubeconfig string,
 		egressGroupStore,
 		supportBundleCollectionStore,
 		podInformer,
+		nodeInformer,
 		eeInformer,
 		caCertController,
 		statsAggregator,
@@ -563,6 +570,7 @@ func createAPIServerConfig(kubeconfig string,
 		endpointQuerier,
 		npController,
 		egressController,
+		antreaIPAMController,
 		bundleCollectionStore,
 		traceflowController), nil
 }
@@ -26,6 +26,7 @@ import (
 
 	aggregator "antrea.io/antrea/pkg/flowaggregator"
 	"antrea.io/antrea/pkg/flowaggregator/apiserver"
+	"antrea.io/antrea/pkg/flowaggregator/metrics"
 	"antrea.io/antrea/pkg/log"
 	"antrea.io/antrea/pkg/signals"
 	"antrea.io/antrea/pkg/util/cipher"
@@ -43,6 +44,8 @@ func run(configFile string) error {
 
 	log.StartLogFileNumberMonitor(stopCh)
 
+	metrics.InitializePrometheusMetrics()
+
 	k8sClient, err := createK8sClient()
 	if err != nil {
 		return fmt.Errorf("error when creating K8s client: %v", err)
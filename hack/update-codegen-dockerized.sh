@@ -37,6 +37,7 @@ ANTREA_PKG="antrea.io/antrea"
 ANTREA_PROTO_PKG="antrea_io.antrea"
 
 MOCKGEN_TARGETS=(
+  "pkg/agent/bgp Interface testing"
   "pkg/agent/cniserver SriovNet testing"
   "pkg/agent/cniserver/ipam IPAMDriver testing"
   "pkg/agent/flowexporter/connections ConnTrackDumper,NetFilterConnTrack testing"
@@ -62,6 +63,8 @@ MOCKGEN_TARGETS=(
   "pkg/controller/networkpolicy EndpointQuerier testing"
   "pkg/controller/querier ControllerQuerier testing"
   "pkg/flowaggregator/exporter Interface testing"
+  "pkg/flowaggregator/kafkaproducer KafkaProducerAPI testing"
+  "pkg/flowaggregator/otlpclient OTLPClientAPI testing"
   "pkg/ipfix IPFIXExportingProcess,IPFIXRegistry,IPFIXCollectingProcess,IPFIXAggregationProcess testing"
   "pkg/ovs/openflow Bridge,Table,Flow,Action,CTAction,FlowBuilder,Group,BucketBuilder,PacketOutBuilder,Meter,MeterBandBuilder testing"
   "pkg/ovs/ovsconfig OVSBridgeClient testing"
@@ -85,6 +88,8 @@ fi
 function generate_antrea_client_code {
   # Generate protobuf code for CNI gRPC service with protoc.
   protoc --go_out=plugins=grpc:. pkg/apis/cni/v1beta1/cni.proto
+  # Generate protobuf code for the flow records produced to Kafka.
+  protoc --go_out=paths=source_relative:. pkg/flowaggregator/kafkaproducer/protobuf/flowrecord.proto
 
   # Generate clientset and apis code with K8s codegen tools.
   $GOPATH/bin/client-gen \
@@ -29,6 +29,13 @@ type GatewayInfo struct {
 type WireGuardInfo struct {
 	// Public key of the WireGuard tunnel.
 	PublicKey string `json:"publicKey,omitempty"`
+	// Public key which will be used after the WireGuard key is rotated.
+	NextPublicKey string `json:"nextPublicKey,omitempty"`
+	// Port of the WireGuard next-key interface, which accepts the traffic encrypted with the next public key during
+	// the key rotation.
+	NextKeyPort int32 `json:"nextKeyPort,omitempty"`
+	// Next public keys of the peer clusters which have been accepted by the WireGuard tunnel.
+	AcceptedPublicKeys []string `json:"acceptedPublicKeys,omitempty"`
 }
 
 // +genclient
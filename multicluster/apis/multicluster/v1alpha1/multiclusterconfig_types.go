@@ -57,6 +57,10 @@ type MultiClusterConfig struct {
 	// ClusterSet and allow Antrea-native policies to select peers from other clusters
 	// in a ClusterSet.
 	EnableStretchedNetworkPolicy bool `json:"enableStretchedNetworkPolicy,omitempty"`
+	// Enable active/active Gateways which will create a Gateway for every ready Node
+	// with annotation `multicluster.antrea.io/gateway:true`, and allow cross-cluster
+	// traffic to be distributed across all Gateways of a member cluster.
+	EnableActiveActiveGateways bool `json:"enableActiveActiveGateways,omitempty"`
 }
 
 func init() {
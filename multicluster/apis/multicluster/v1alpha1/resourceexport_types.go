@@ -32,6 +32,18 @@ type ServiceExport struct {
 // EndpointsExport exports Endpoints.
 type EndpointsExport struct {
 	Subsets []v1.EndpointSubset `json:"subsets,omitempty"`
+	// Weight is the relative weight of the exporting cluster when load balancing
+	// the traffic of the multi-cluster Service across member clusters. If not set,
+	// the default weight 100 is used.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+	// PreferLocal indicates that the exporting cluster prefers its own Endpoints when
+	// importing the multi-cluster Service, and uses the Endpoints of other member
+	// clusters only when it has no ready Endpoints.
+	// +optional
+	PreferLocal bool `json:"preferLocal,omitempty"`
 }
 
 // ExternalEntityExport exports ExternalEntity.
@@ -28,6 +28,23 @@ import (
 // EndpointsImport imports Endpoints.
 type EndpointsImport struct {
 	Subsets []v1.EndpointSubset `json:"subsets,omitempty"`
+	// ClusterEndpoints are the Endpoints of each member cluster which exports the
+	// Service. Subsets includes the Endpoints of all of them.
+	// +optional
+	ClusterEndpoints []ClusterEndpoints `json:"clusterEndpoints,omitempty"`
+}
+
+// ClusterEndpoints includes the Endpoints exported by a member cluster.
+type ClusterEndpoints struct {
+	// ClusterID of the member cluster exporting the Endpoints.
+	ClusterID string              `json:"clusterID,omitempty"`
+	Subsets   []v1.EndpointSubset `json:"subsets,omitempty"`
+	// Weight of the member cluster, copied from the Endpoints kind of ResourceExport.
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+	// PreferLocal of the member cluster, copied from the Endpoints kind of ResourceExport.
+	// +optional
+	PreferLocal bool `json:"preferLocal,omitempty"`
 }
 
 // ExternalEntityImport imports ExternalEntity.
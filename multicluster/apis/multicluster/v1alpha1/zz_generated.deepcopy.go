@@ -43,6 +43,33 @@ func (in *ClusterCondition) DeepCopy() *ClusterCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEndpoints) DeepCopyInto(out *ClusterEndpoints) {
+	*out = *in
+	if in.Subsets != nil {
+		in, out := &in.Subsets, &out.Subsets
+		*out = make([]v1.EndpointSubset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEndpoints.
+func (in *ClusterEndpoints) DeepCopy() *ClusterEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterInfo) DeepCopyInto(out *ClusterInfo) {
 	*out = *in
@@ -59,7 +86,7 @@ func (in *ClusterInfo) DeepCopyInto(out *ClusterInfo) {
 	if in.WireGuard != nil {
 		in, out := &in.WireGuard, &out.WireGuard
 		*out = new(WireGuardInfo)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -315,6 +342,11 @@ func (in *EndpointsExport) DeepCopyInto(out *EndpointsExport) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointsExport.
@@ -337,6 +369,13 @@ func (in *EndpointsImport) DeepCopyInto(out *EndpointsImport) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClusterEndpoints != nil {
+		in, out := &in.ClusterEndpoints, &out.ClusterEndpoints
+		*out = make([]ClusterEndpoints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointsImport.
@@ -393,7 +432,7 @@ func (in *Gateway) DeepCopyInto(out *Gateway) {
 	if in.WireGuard != nil {
 		in, out := &in.WireGuard, &out.WireGuard
 		*out = new(WireGuardInfo)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1048,6 +1087,11 @@ func (in *ServiceExport) DeepCopy() *ServiceExport {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WireGuardInfo) DeepCopyInto(out *WireGuardInfo) {
 	*out = *in
+	if in.AcceptedPublicKeys != nil {
+		in, out := &in.AcceptedPublicKeys, &out.AcceptedPublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuardInfo.
@@ -123,6 +123,7 @@ func runMember(o *Options) error {
 		podNamespace,
 		opts.ServiceCIDR,
 		opts.GatewayIPPrecedence,
+		opts.EnableActiveActiveGateways,
 		commonAreaGetter)
 	if err = nodeReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("error creating Node controller: %v", err)
@@ -44,6 +44,8 @@ type Options struct {
 	// Enable StretchedNetworkPolicy to exchange labelIdentities info among the whole
 	// ClusterSet.
 	EnableStretchedNetworkPolicy bool
+	// Enable active/active Gateways to create a Gateway for every ready Gateway Node.
+	EnableActiveActiveGateways bool
 	// Watch EndpointSlice API for exported Service if EndpointSlice API is available.
 	EnableEndpointSlice bool
 	// ClusterCalimCRDAvailable indicates if the ClusterClaim CRD is available or not
@@ -96,6 +98,7 @@ func (o *Options) complete(args []string) error {
 			o.EndpointIPType = ctrlConfig.EndpointIPType
 		}
 		o.EnableStretchedNetworkPolicy = ctrlConfig.EnableStretchedNetworkPolicy
+		o.EnableActiveActiveGateways = ctrlConfig.EnableActiveActiveGateways
 		klog.InfoS("Using config from file", "config", o.configFile)
 	} else {
 		klog.InfoS("Using default config")
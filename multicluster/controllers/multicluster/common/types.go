@@ -25,6 +25,26 @@ const (
 	GatewayAnnotation         = "multicluster.antrea.io/gateway"
 	GatewayIPAnnotation       = "multicluster.antrea.io/gateway-ip"
 
+	// ServiceExportWeightAnnotation is the key of the ServiceExport annotation that
+	// specifies the relative weight of the local cluster when load balancing the
+	// traffic of the multi-cluster Service across member clusters.
+	ServiceExportWeightAnnotation = "multicluster.antrea.io/weight"
+	// ServiceExportPreferLocalAnnotation is the key of the ServiceExport annotation
+	// that makes the multi-cluster Service imported in the local cluster prefer the
+	// local Endpoints over the Endpoints of other member clusters.
+	ServiceExportPreferLocalAnnotation = "multicluster.antrea.io/prefer-local"
+	// ServiceEndpointWeightsAnnotation is the key of the Service annotation that
+	// specifies the weights of the Service's Endpoints, which is consumed by
+	// AntreaProxy. It must be kept consistent with ServiceEndpointWeightsAnnotationKey
+	// in antrea.io/antrea/pkg/agent/types.
+	ServiceEndpointWeightsAnnotation = "service.antrea.io/endpoint-weights"
+
+	// DefaultServiceExportWeight is the weight of a member cluster which doesn't
+	// specify the weight of its exported Service.
+	DefaultServiceExportWeight = 100
+	// MaxServiceExportWeight is the maximum weight of a member cluster.
+	MaxServiceExportWeight = 100
+
 	AntreaMCSPrefix = "antrea-mc-"
 
 	InvalidClusterID    = ClusterID("invalid")
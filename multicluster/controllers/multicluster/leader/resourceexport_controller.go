@@ -367,27 +367,41 @@ func (r *ResourceExportReconciler) refreshEndpointsResourceImport(
 
 	if createResImport {
 		newResImport.Spec.Endpoints = &mcsv1alpha1.EndpointsImport{
-			Subsets: resExport.Spec.Endpoints.Subsets,
+			Subsets:          resExport.Spec.Endpoints.Subsets,
+			ClusterEndpoints: []mcsv1alpha1.ClusterEndpoints{getClusterEndpoints(resExport)},
 		}
 		return newResImport, true, nil
 	}
 	// check all matched Endpoints ResourceExport and generate a new EndpointSubset
 	var newSubsets []corev1.EndpointSubset
+	var newClusterEndpoints []mcsv1alpha1.ClusterEndpoints
 	undeleteItems, err := r.getNotDeletedResourceExports(resExport)
 	if err != nil {
 		klog.ErrorS(err, "Failed to list ResourceExports, retry later")
 		return newResImport, false, err
 	}
-	for _, re := range undeleteItems {
-		newSubsets = append(newSubsets, re.Spec.Endpoints.Subsets...)
+	for i := range undeleteItems {
+		newSubsets = append(newSubsets, undeleteItems[i].Spec.Endpoints.Subsets...)
+		newClusterEndpoints = append(newClusterEndpoints, getClusterEndpoints(&undeleteItems[i]))
 	}
-	newResImport.Spec.Endpoints = &mcsv1alpha1.EndpointsImport{Subsets: newSubsets}
+	newResImport.Spec.Endpoints = &mcsv1alpha1.EndpointsImport{Subsets: newSubsets, ClusterEndpoints: newClusterEndpoints}
 	if apiequality.Semantic.DeepEqual(newResImport.Spec.Endpoints, resImport.Spec.Endpoints) {
 		return newResImport, false, nil
 	}
 	return newResImport, true, nil
 }
 
+// getClusterEndpoints returns the Endpoints of the member cluster exporting the
+// Endpoints kind of ResourceExport, together with its load balancing preferences.
+func getClusterEndpoints(resExport *mcsv1alpha1.ResourceExport) mcsv1alpha1.ClusterEndpoints {
+	return mcsv1alpha1.ClusterEndpoints{
+		ClusterID:   resExport.Spec.ClusterID,
+		Subsets:     resExport.Spec.Endpoints.Subsets,
+		Weight:      resExport.Spec.Endpoints.Weight,
+		PreferLocal: resExport.Spec.Endpoints.PreferLocal,
+	}
+}
+
 func (r *ResourceExportReconciler) refreshACNPResourceImport(
 	resExport *mcsv1alpha1.ResourceExport,
 	resImport *mcsv1alpha1.ResourceImport,
@@ -25,6 +25,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	mcs "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
@@ -143,11 +144,13 @@ func TestResourceExportReconciler_handleEndpointsExportDeleteEvent(t *testing.T)
 			Finalizers: []string{constants.ResourceExportFinalizer},
 		},
 		Spec: mcsv1alpha1.ResourceExportSpec{
+			ClusterID: "cluster-b",
 			Namespace: "default",
 			Name:      "nginx",
 			Kind:      constants.EndpointsKind,
 			Endpoints: &mcsv1alpha1.EndpointsExport{
 				Subsets: common.EPNginxSubset2,
+				Weight:  pointer.Int32(20),
 			},
 		},
 	}
@@ -166,6 +169,13 @@ func TestResourceExportReconciler_handleEndpointsExportDeleteEvent(t *testing.T)
 		},
 	}
 	expectedSubsets := common.EPNginxSubset2
+	expectedClusterEndpoints := []mcsv1alpha1.ClusterEndpoints{
+		{
+			ClusterID: "cluster-b",
+			Subsets:   common.EPNginxSubset2,
+			Weight:    pointer.Int32(20),
+		},
+	}
 	namespacedName := types.NamespacedName{Namespace: "default", Name: "default-nginx-endpoints"}
 	fakeClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects(existingResExport1, existingResExport2, existResImport).Build()
 	r := NewResourceExportReconciler(fakeClient, common.TestScheme)
@@ -176,8 +186,11 @@ func TestResourceExportReconciler_handleEndpointsExportDeleteEvent(t *testing.T)
 		err := fakeClient.Get(common.TestCtx, namespacedName, resImport)
 		if err != nil {
 			t.Errorf("failed to get ResourceImport, got error = %v", err)
-		} else if !reflect.DeepEqual(resImport.Spec.Endpoints.Subsets, expectedSubsets) {
-			t.Errorf("expected ResourceImport Subsets are %v, but got %v", expectedSubsets, resImport.Spec.Endpoints.Subsets)
+		} else {
+			if !reflect.DeepEqual(resImport.Spec.Endpoints.Subsets, expectedSubsets) {
+				t.Errorf("expected ResourceImport Subsets are %v, but got %v", expectedSubsets, resImport.Spec.Endpoints.Subsets)
+			}
+			assert.Equal(t, expectedClusterEndpoints, resImport.Spec.Endpoints.ClusterEndpoints)
 		}
 	}
 }
@@ -238,11 +251,14 @@ func TestResourceExportReconciler_handleEndpointExportCreateEvent(t *testing.T)
 			},
 		},
 		Spec: mcsv1alpha1.ResourceExportSpec{
+			ClusterID: "cluster-a",
 			Namespace: "default",
 			Name:      "nginx",
 			Kind:      constants.EndpointsKind,
 			Endpoints: &mcsv1alpha1.EndpointsExport{
-				Subsets: common.EPNginxSubset,
+				Subsets:     common.EPNginxSubset,
+				Weight:      pointer.Int32(50),
+				PreferLocal: true,
 			},
 		},
 	}
@@ -269,6 +285,14 @@ func TestResourceExportReconciler_handleEndpointExportCreateEvent(t *testing.T)
 		Kind:      constants.EndpointsKind,
 		Endpoints: &mcsv1alpha1.EndpointsImport{
 			Subsets: existEPResExport.Spec.Endpoints.Subsets,
+			ClusterEndpoints: []mcsv1alpha1.ClusterEndpoints{
+				{
+					ClusterID:   "cluster-a",
+					Subsets:     existEPResExport.Spec.Endpoints.Subsets,
+					Weight:      existEPResExport.Spec.Endpoints.Weight,
+					PreferLocal: true,
+				},
+			},
 		},
 	}
 	namespacedName := types.NamespacedName{Namespace: "default", Name: "default-nginx-endpoints"}
@@ -18,6 +18,7 @@ package member
 
 import (
 	"context"
+	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -54,7 +55,8 @@ type (
 )
 
 // NewGatewayReconciler creates a GatewayReconciler which will watch Gateway events
-// and create a ClusterInfo kind of ResourceExport in the leader cluster.
+// and create a ClusterInfo kind of ResourceExport in the leader cluster. The
+// ClusterInfo includes the information of all Gateways in the member cluster.
 func NewGatewayReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
@@ -103,52 +105,54 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		},
 	}
 
-	createOrUpdate := func(gateway *mcv1alpha1.Gateway) error {
+	createOrUpdate := func(gateways []mcv1alpha1.Gateway) error {
 		existingResExport := &mcv1alpha1.ResourceExport{}
 		err := commonArea.Get(ctx, resExportNamespacedName, existingResExport)
 		if err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
 		if apierrors.IsNotFound(err) || !existingResExport.DeletionTimestamp.IsZero() {
-			if err = r.createResourceExport(ctx, req, commonArea, gateway); err != nil {
+			if err = r.createResourceExport(ctx, req, commonArea, gateways); err != nil {
 				return err
 			}
 			return nil
 		}
 		// updateResourceExport will update latest Gateway information with the existing ResourceExport's resourceVersion.
 		// It will return an error and retry when there is a version conflict.
-		if err = r.updateResourceExport(ctx, req, commonArea, existingResExport, gateway); err != nil {
+		if err = r.updateResourceExport(ctx, req, commonArea, existingResExport, gateways); err != nil {
 			return err
 		}
 		return nil
 	}
 
-	gw := &mcv1alpha1.Gateway{}
-	if err := r.Client.Get(ctx, req.NamespacedName, gw); err != nil {
-		if !apierrors.IsNotFound(err) {
-			return ctrl.Result{}, err
-		}
+	// There can be multiple Gateways when active/active Gateways are enabled, and
+	// all of them are included in the ClusterInfo.
+	gwList := &mcv1alpha1.GatewayList{}
+	if err := r.Client.List(ctx, gwList, &client.ListOptions{Namespace: r.namespace}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(gwList.Items) == 0 {
 		if err := commonArea.Delete(ctx, resExport, &client.DeleteOptions{}); err != nil {
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
 		return ctrl.Result{}, nil
 	}
 
-	if err := createOrUpdate(gw); err != nil {
+	if err := createOrUpdate(gwList.Items); err != nil {
 		return ctrl.Result{}, err
 	}
 	return ctrl.Result{}, nil
 }
 
 func (r *GatewayReconciler) updateResourceExport(ctx context.Context, req ctrl.Request,
-	commonArea commonarea.RemoteCommonArea, existingResExport *mcv1alpha1.ResourceExport, gw *mcv1alpha1.Gateway) error {
+	commonArea commonarea.RemoteCommonArea, existingResExport *mcv1alpha1.ResourceExport, gateways []mcv1alpha1.Gateway) error {
 	resExportSpec := mcv1alpha1.ResourceExportSpec{
 		Kind:      constants.ClusterInfoKind,
 		ClusterID: r.localClusterID,
 		Name:      r.localClusterID,
 		Namespace: r.namespace,
 	}
-	resExportSpec.ClusterInfo = r.getClusterInfo(gw)
+	resExportSpec.ClusterInfo = r.getClusterInfo(gateways)
 	klog.V(2).InfoS("Updating ClusterInfo kind of ResourceExport", "clusterinfo", klog.KObj(existingResExport),
 		"gateway", req.NamespacedName)
 	existingResExport.Spec = resExportSpec
@@ -159,14 +163,14 @@ func (r *GatewayReconciler) updateResourceExport(ctx context.Context, req ctrl.R
 }
 
 func (r *GatewayReconciler) createResourceExport(ctx context.Context, req ctrl.Request,
-	commonArea commonarea.RemoteCommonArea, gateway *mcv1alpha1.Gateway) error {
+	commonArea commonarea.RemoteCommonArea, gateways []mcv1alpha1.Gateway) error {
 	resExportSpec := mcv1alpha1.ResourceExportSpec{
 		Kind:      constants.ClusterInfoKind,
 		ClusterID: r.localClusterID,
 		Name:      r.localClusterID,
 		Namespace: r.namespace,
 	}
-	resExportSpec.ClusterInfo = r.getClusterInfo(gateway)
+	resExportSpec.ClusterInfo = r.getClusterInfo(gateways)
 	resExport := &mcv1alpha1.ResourceExport{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: r.leaderNamespace,
@@ -222,21 +226,29 @@ func (r *GatewayReconciler) clusterSetMapFunc(a client.Object) []reconcile.Reque
 	return requests
 }
 
-func (r *GatewayReconciler) getClusterInfo(gateway *mcv1alpha1.Gateway) *mcv1alpha1.ClusterInfo {
+// getClusterInfo generates the ClusterInfo from the given Gateways. The GatewayInfos
+// are sorted by the Gateway names, and the ServiceCIDR and WireGuard information
+// are taken from the first Gateway.
+func (r *GatewayReconciler) getClusterInfo(gateways []mcv1alpha1.Gateway) *mcv1alpha1.ClusterInfo {
+	sortedGateways := make([]*mcv1alpha1.Gateway, 0, len(gateways))
+	for i := range gateways {
+		sortedGateways = append(sortedGateways, &gateways[i])
+	}
+	sort.Slice(sortedGateways, func(i, j int) bool {
+		return sortedGateways[i].Name < sortedGateways[j].Name
+	})
 	clusterInfo := &mcv1alpha1.ClusterInfo{
 		ClusterID:   r.localClusterID,
-		ServiceCIDR: gateway.ServiceCIDR,
+		ServiceCIDR: sortedGateways[0].ServiceCIDR,
 		PodCIDRs:    r.podCIDRs,
-		GatewayInfos: []mcv1alpha1.GatewayInfo{
-			{
-				GatewayIP: gateway.GatewayIP,
-			},
-		},
 	}
-	if gateway.WireGuard != nil && gateway.WireGuard.PublicKey != "" {
-		clusterInfo.WireGuard = &mcv1alpha1.WireGuardInfo{
-			PublicKey: gateway.WireGuard.PublicKey,
-		}
+	for _, gateway := range sortedGateways {
+		clusterInfo.GatewayInfos = append(clusterInfo.GatewayInfos, mcv1alpha1.GatewayInfo{
+			GatewayIP: gateway.GatewayIP,
+		})
+	}
+	if wireGuard := sortedGateways[0].WireGuard; wireGuard != nil && wireGuard.PublicKey != "" {
+		clusterInfo.WireGuard = wireGuard.DeepCopy()
 	}
 
 	return clusterInfo
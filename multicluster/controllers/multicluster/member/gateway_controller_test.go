@@ -54,6 +54,16 @@ var (
 		InternalIP: "172.11.10.1",
 	}
 
+	gwNode2 = mcv1alpha1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "node-2",
+			Namespace:         "default",
+			CreationTimestamp: gw1CreationTime,
+		},
+		GatewayIP:  "10.10.10.11",
+		InternalIP: "172.11.10.2",
+	}
+
 	existingResExport = &mcv1alpha1.ResourceExport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "cluster-a-clusterinfo",
@@ -132,6 +142,26 @@ func TestGatewayReconciler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update a ResourceExport successfully with multiple Gateways",
+			namespacedName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "node-2",
+			},
+			gateway: []mcv1alpha1.Gateway{
+				gwNode2,
+				gwNode1,
+			},
+			resExport: existingResExport,
+			expectedInfo: []mcv1alpha1.GatewayInfo{
+				{
+					GatewayIP: "10.10.10.10",
+				},
+				{
+					GatewayIP: "10.10.10.11",
+				},
+			},
+		},
 		{
 			name: "delete a ResourceExport successfully by deleting an existing Gateway",
 			namespacedName: types.NamespacedName{
@@ -191,15 +221,25 @@ func TestGatewayReconciler(t *testing.T) {
 func TestGetClusterInfo(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects().Build()
 	r := NewGatewayReconciler(fakeClient, common.TestScheme, "default", []string{"10.200.1.1/16"}, nil)
-	gw := &mcv1alpha1.Gateway{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "gw",
+	gws := []mcv1alpha1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "gw-b",
+			},
+			ServiceCIDR: "10.100.0.0/16",
+			GatewayIP:   "10.10.1.2",
+			InternalIP:  "10.10.1.2",
 		},
-		ServiceCIDR: "10.100.0.0/16",
-		GatewayIP:   "10.10.1.1",
-		InternalIP:  "10.10.1.1",
-		WireGuard: &mcv1alpha1.WireGuardInfo{
-			PublicKey: "key",
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "gw-a",
+			},
+			ServiceCIDR: "10.100.0.0/16",
+			GatewayIP:   "10.10.1.1",
+			InternalIP:  "10.10.1.1",
+			WireGuard: &mcv1alpha1.WireGuardInfo{
+				PublicKey: "key",
+			},
 		},
 	}
 	expectedClusterInfo := &mcv1alpha1.ClusterInfo{
@@ -207,6 +247,9 @@ func TestGetClusterInfo(t *testing.T) {
 			{
 				GatewayIP: "10.10.1.1",
 			},
+			{
+				GatewayIP: "10.10.1.2",
+			},
 		},
 		ServiceCIDR: "10.100.0.0/16",
 		PodCIDRs:    []string{"10.200.1.1/16"},
@@ -215,7 +258,7 @@ func TestGetClusterInfo(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, expectedClusterInfo, r.getClusterInfo(gw))
+	assert.Equal(t, expectedClusterInfo, r.getClusterInfo(gws))
 }
 
 func TestClusterSetMapFunc_Gateway(t *testing.T) {
@@ -84,6 +84,9 @@ type (
 		activeGateway      string
 		serviceCIDR        string
 		initialized        bool
+		// enableActiveActiveGateways indicates whether a Gateway is created for
+		// every ready Gateway Node instead of only one of them.
+		enableActiveActiveGateways bool
 	}
 )
 
@@ -91,13 +94,16 @@ type (
 // It's responsible for creating a Gateway for the first ready Node with
 // annotation `multicluster.antrea.io/gateway:true` if there is no existing Gateway.
 // It guarantees there is always only one Gateway CR when there are multiple Nodes
-// with annotation `multicluster.antrea.io/gateway:true`.
+// with annotation `multicluster.antrea.io/gateway:true`, unless active/active
+// Gateways are enabled, in which case a Gateway is created for every ready Node
+// with the annotation.
 func NewNodeReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	namespace string,
 	serviceCIDR string,
 	precedence mcv1alpha1.Precedence,
+	enableActiveActiveGateways bool,
 	commonAreaGetter commonarea.RemoteCommonAreaGetter) *NodeReconciler {
 	if string(precedence) == "" {
 		precedence = mcv1alpha1.PrecedenceInternal
@@ -110,6 +116,8 @@ func NewNodeReconciler(
 		precedence:        precedence,
 		gatewayCandidates: make(map[string]bool),
 		commonAreaGetter:  commonAreaGetter,
+
+		enableActiveActiveGateways: enableActiveActiveGateways,
 	}
 	return reconciler
 }
@@ -176,6 +184,19 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		isValidGateway = err == nil
 	}
 
+	if r.enableActiveActiveGateways {
+		if isValidGateway && isReadyNode(node) {
+			if err := r.createOrUpdateGateway(ctx, gw); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		if err := r.Client.Delete(ctx, gw, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	if isActiveGateway {
 		if !isValidGateway || !isReadyNode(node) {
 			if err := r.recreateActiveGateway(ctx, gw); err != nil {
@@ -198,7 +219,9 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 }
 
 // initialize initializes 'activeGateway' and 'gatewayCandidates' and removes
-// stale Gateway during controller startup.
+// stale Gateways during controller startup. A Gateway is stale if its Node no
+// longer exists, or if it is not the active Gateway when active/active Gateways
+// are disabled.
 func (r *NodeReconciler) initialize() error {
 	ctx := context.Background()
 	nodeList := &corev1.NodeList{}
@@ -210,25 +233,26 @@ func (r *NodeReconciler) initialize() error {
 	if err := r.Client.List(ctx, gwList, &client.ListOptions{}); err != nil {
 		return err
 	}
-	// Gateway webhook guarantees that there is at most one Gateway in the member cluster.
-	if len(gwList.Items) > 0 {
-		existingGWName := gwList.Items[0].Name
+	for _, gw := range gwList.Items {
 		node := &corev1.Node{}
-		if err := r.Client.Get(ctx, types.NamespacedName{Name: existingGWName}, node); err != nil {
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: gw.Name}, node); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return err
 			}
-			staleGateway := &mcv1alpha1.Gateway{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: r.namespace,
-					Name:      existingGWName},
-			}
-			err := r.Client.Delete(ctx, staleGateway, &client.DeleteOptions{})
-			if err != nil && !apierrors.IsNotFound(err) {
-				return err
-			}
-		} else {
-			r.activeGateway = existingGWName
+		} else if r.enableActiveActiveGateways {
+			continue
+		} else if r.activeGateway == "" || r.activeGateway == gw.Name {
+			r.activeGateway = gw.Name
+			continue
+		}
+		staleGateway := &mcv1alpha1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.namespace,
+				Name:      gw.Name},
+		}
+		err := r.Client.Delete(ctx, staleGateway, &client.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
 		}
 	}
 	for _, n := range nodeList.Items {
@@ -250,6 +274,28 @@ func (r *NodeReconciler) updateActiveGateway(ctx context.Context, newGateway *mc
 		}
 		return err
 	}
+	return r.updateGateway(ctx, existingGW, newGateway)
+}
+
+// createOrUpdateGateway creates a Gateway for a Gateway Node, or updates the
+// existing one if the Node's IPs have changed. It's used when active/active
+// Gateways are enabled.
+func (r *NodeReconciler) createOrUpdateGateway(ctx context.Context, newGateway *mcv1alpha1.Gateway) error {
+	existingGW := &mcv1alpha1.Gateway{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: newGateway.Name, Namespace: r.namespace}, existingGW); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err := r.Client.Create(ctx, newGateway, &client.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		klog.InfoS("Created Gateway", "gateway", klog.KObj(newGateway))
+		return nil
+	}
+	return r.updateGateway(ctx, existingGW, newGateway)
+}
+
+func (r *NodeReconciler) updateGateway(ctx context.Context, existingGW, newGateway *mcv1alpha1.Gateway) error {
 	if existingGW.GatewayIP == newGateway.GatewayIP && existingGW.InternalIP == newGateway.InternalIP &&
 		existingGW.ServiceCIDR == newGateway.ServiceCIDR {
 		return nil
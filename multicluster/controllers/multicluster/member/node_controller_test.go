@@ -235,7 +235,7 @@ func TestNodeReconciler(t *testing.T) {
 			mcReconciler := NewMemberClusterSetReconciler(fakeClient, common.TestScheme, "default", false, false, make(chan struct{}))
 			mcReconciler.SetRemoteCommonArea(commonArea)
 			commonAreaGetter := mcReconciler
-			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", tt.precedence, commonAreaGetter)
+			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", tt.precedence, false, commonAreaGetter)
 			r.activeGateway = tt.activeGateway
 			if _, err := r.Reconcile(common.TestCtx, tt.req); err != nil {
 				t.Errorf("Node Reconciler should handle Node events successfully but got error = %v", err)
@@ -317,7 +317,7 @@ func TestInitialize(t *testing.T) {
 			mcReconciler := NewMemberClusterSetReconciler(fakeClient, common.TestScheme, "default", false, false, make(chan struct{}))
 			mcReconciler.SetRemoteCommonArea(commonArea)
 			commonAreaGetter := mcReconciler
-			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", mcv1alpha1.PrecedencePublic, commonAreaGetter)
+			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", mcv1alpha1.PrecedencePublic, false, commonAreaGetter)
 			if err := r.initialize(); err != nil {
 				t.Errorf("Expected initialize() successfully but got err: %v", err)
 			} else {
@@ -336,6 +336,47 @@ func TestInitialize(t *testing.T) {
 	}
 }
 
+func TestNodeReconcilerWithActiveActiveGateways(t *testing.T) {
+	initializeCommonData()
+	fakeClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects(node1, node2, node3, node4, gateway3).Build()
+	fakeRemoteClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects().Build()
+	commonArea := commonarea.NewFakeRemoteCommonArea(fakeRemoteClient, "leader-cluster", common.LocalClusterID, common.LeaderNamespace, nil)
+	mcReconciler := NewMemberClusterSetReconciler(fakeClient, common.TestScheme, "default", false, false, make(chan struct{}))
+	mcReconciler.SetRemoteCommonArea(commonArea)
+	r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", mcv1alpha1.PrecedencePublic, true, mcReconciler)
+
+	reconcileNode := func(name string) {
+		_, err := r.Reconcile(common.TestCtx, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+		assert.NoError(t, err)
+	}
+	getGatewayIPs := func() map[string]string {
+		gwList := &mcv1alpha1.GatewayList{}
+		assert.NoError(t, fakeClient.List(common.TestCtx, gwList, &client.ListOptions{Namespace: "default"}))
+		gatewayIPs := map[string]string{}
+		for _, gw := range gwList.Items {
+			gatewayIPs[gw.Name] = gw.GatewayIP
+		}
+		return gatewayIPs
+	}
+
+	for _, name := range []string{"node-1", "node-2", "node-3", "node-4"} {
+		reconcileNode(name)
+	}
+	// A Gateway is created for every ready Gateway Node with valid IPs, and the
+	// Gateway of the not ready Node is deleted.
+	assert.Equal(t, map[string]string{"node-1": "10.10.10.10", "node-2": "10.10.10.12"}, getGatewayIPs())
+
+	updatedNode2 := node2.DeepCopy()
+	updatedNode2.Status.Addresses[0].Address = "10.10.10.13"
+	assert.NoError(t, fakeClient.Update(common.TestCtx, updatedNode2))
+	reconcileNode("node-2")
+	assert.Equal(t, map[string]string{"node-1": "10.10.10.10", "node-2": "10.10.10.13"}, getGatewayIPs())
+
+	assert.NoError(t, fakeClient.Delete(common.TestCtx, node1))
+	reconcileNode("node-1")
+	assert.Equal(t, map[string]string{"node-2": "10.10.10.13"}, getGatewayIPs())
+}
+
 func TestClusterSetMapFunc(t *testing.T) {
 	clusterSet := &mcv1alpha2.ClusterSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -374,14 +415,14 @@ func TestClusterSetMapFunc(t *testing.T) {
 		},
 	}
 	fakeClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects(clusterSet, node1).Build()
-	r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.200.1.1/16", "", nil)
+	r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.200.1.1/16", "", false, nil)
 	requests := r.clusterSetMapFunc(clusterSet)
 	assert.Equal(t, expectedReqs, requests)
 
 	requests = r.clusterSetMapFunc(deletedClusterSet)
 	assert.Equal(t, []reconcile.Request{}, requests)
 
-	r = NewNodeReconciler(fakeClient, common.TestScheme, "mismatch_ns", "10.200.1.1/16", "", nil)
+	r = NewNodeReconciler(fakeClient, common.TestScheme, "mismatch_ns", "10.200.1.1/16", "", false, nil)
 	requests = r.clusterSetMapFunc(clusterSet)
 	assert.Equal(t, []reconcile.Request{}, requests)
 }
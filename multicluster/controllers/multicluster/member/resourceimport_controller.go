@@ -20,6 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -43,6 +46,9 @@ import (
 const (
 	// cached indexer
 	resImportIndexer = "name.kind"
+	// endpointWeightScale is the sum of the weights of the Endpoints of a multi-cluster Service which are load
+	// balanced by the weights of the member clusters.
+	endpointWeightScale = 10000
 )
 
 func resImportIndexerFunc(obj interface{}) ([]string, error) {
@@ -274,7 +280,10 @@ func (r *ResourceImportReconciler) handleResImpUpdateForEndpoints(ctx context.Co
 		}
 	}
 
-	newSubsets := resImp.Spec.Endpoints.Subsets
+	newSubsets, endpointWeights := getImportedEndpoints(r.localClusterID, resImp.Spec.Endpoints)
+	if err := r.updateMCServiceEndpointWeights(ctx, resImp, endpointWeights); err != nil {
+		return ctrl.Result{}, err
+	}
 	mcsEpObj := &corev1.Endpoints{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        epName,
@@ -330,6 +339,120 @@ func (r *ResourceImportReconciler) handleResImpDeleteForEndpoints(ctx context.Co
 	return ctrl.Result{}, nil
 }
 
+// updateMCServiceEndpointWeights sets the weights of the imported Endpoints in the annotation of the multi-cluster
+// Service, or removes the annotation when the Endpoints should be load balanced evenly.
+func (r *ResourceImportReconciler) updateMCServiceEndpointWeights(ctx context.Context, resImp *multiclusterv1alpha1.ResourceImport, endpointWeights map[string]uint16) error {
+	svcNamespaced := types.NamespacedName{Namespace: resImp.Spec.Namespace, Name: common.ToMCResourceName(resImp.Spec.Name)}
+	svc := &corev1.Service{}
+	if err := r.localClusterClient.Get(ctx, svcNamespaced, svc); err != nil {
+		if apierrors.IsNotFound(err) && len(endpointWeights) == 0 {
+			return nil
+		}
+		// Retry until the multi-cluster Service is imported so the weights are not lost.
+		return err
+	}
+	if _, ok := svc.Annotations[common.AntreaMCServiceAnnotation]; !ok {
+		return nil
+	}
+	value := formatEndpointWeights(endpointWeights)
+	if svc.Annotations[common.ServiceEndpointWeightsAnnotation] == value {
+		return nil
+	}
+	if value == "" {
+		delete(svc.Annotations, common.ServiceEndpointWeightsAnnotation)
+	} else {
+		svc.Annotations[common.ServiceEndpointWeightsAnnotation] = value
+	}
+	if err := r.localClusterClient.Update(ctx, svc, &client.UpdateOptions{}); err != nil {
+		klog.ErrorS(err, "Failed to update Endpoint weights of MC Service", "service", svcNamespaced.String())
+		return err
+	}
+	return nil
+}
+
+// getImportedEndpoints returns the Endpoint subsets to be imported for a multi-cluster Service, and the weights of
+// the Endpoints keyed by IP. When the local member cluster prefers its own Endpoints and has ready ones, only they are
+// imported. Otherwise, Endpoints of all member clusters are imported, and if the member clusters don't have the same
+// weight, the traffic is distributed across member clusters in proportion to their weights, then evenly across the
+// Endpoints of each member cluster. Nil weights are returned when the Endpoints should be load balanced evenly.
+func getImportedEndpoints(localClusterID string, epImport *multiclusterv1alpha1.EndpointsImport) ([]corev1.EndpointSubset, map[string]uint16) {
+	// ClusterEndpoints is not set by the leader cluster of older versions.
+	if len(epImport.ClusterEndpoints) == 0 {
+		return epImport.Subsets, nil
+	}
+	for _, clusterEps := range epImport.ClusterEndpoints {
+		if clusterEps.ClusterID == localClusterID && clusterEps.PreferLocal && len(getReadyIPs(clusterEps.Subsets)) > 0 {
+			return clusterEps.Subsets, nil
+		}
+	}
+
+	type clusterWeight struct {
+		weight int32
+		ips    []string
+	}
+	var clusterWeights []clusterWeight
+	var totalWeight int32
+	sameWeight := true
+	for i, clusterEps := range epImport.ClusterEndpoints {
+		weight := int32(common.DefaultServiceExportWeight)
+		if clusterEps.Weight != nil {
+			weight = *clusterEps.Weight
+		}
+		if i > 0 && weight != clusterWeights[0].weight {
+			sameWeight = false
+		}
+		clusterWeights = append(clusterWeights, clusterWeight{weight: weight, ips: getReadyIPs(clusterEps.Subsets)})
+	}
+	if sameWeight {
+		return epImport.Subsets, nil
+	}
+	for _, cw := range clusterWeights {
+		if cw.weight > 0 && len(cw.ips) > 0 {
+			totalWeight += cw.weight
+		}
+	}
+	if totalWeight == 0 {
+		return epImport.Subsets, nil
+	}
+
+	weights := map[string]uint16{}
+	for _, cw := range clusterWeights {
+		if len(cw.ips) == 0 {
+			continue
+		}
+		weight := uint16(0)
+		if cw.weight > 0 {
+			weight = uint16(math.Max(math.Round(float64(cw.weight)*endpointWeightScale/float64(totalWeight*int32(len(cw.ips)))), 1))
+		}
+		for _, ip := range cw.ips {
+			// An IP exported by several member clusters receives the traffic of all of them.
+			weights[ip] = uint16(math.Min(float64(weights[ip])+float64(weight), math.MaxUint16))
+		}
+	}
+	return epImport.Subsets, weights
+}
+
+func getReadyIPs(subsets []corev1.EndpointSubset) []string {
+	var ips []string
+	for _, subset := range subsets {
+		for _, addr := range subset.Addresses {
+			ips = append(ips, addr.IP)
+		}
+	}
+	return ips
+}
+
+// formatEndpointWeights formats the Endpoint weights as the value of the annotation
+// "service.antrea.io/endpoint-weights", e.g. "10.10.0.1=5000,10.10.0.2=5000".
+func formatEndpointWeights(weights map[string]uint16) string {
+	items := make([]string, 0, len(weights))
+	for ip, weight := range weights {
+		items = append(items, fmt.Sprintf("%s=%d", ip, weight))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
 func getMCService(resImp *multiclusterv1alpha1.ResourceImport) *corev1.Service {
 	var mcsPorts []corev1.ServicePort
 	for _, p := range resImp.Spec.ServiceImport.Spec.Ports {
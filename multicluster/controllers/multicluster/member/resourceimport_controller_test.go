@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -164,6 +165,154 @@ func TestResourceImportReconciler_handleCreateEvent(t *testing.T) {
 	}
 }
 
+func newTestEndpointSubsets(ips ...string) []corev1.EndpointSubset {
+	subset := corev1.EndpointSubset{
+		Ports: []corev1.EndpointPort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}},
+	}
+	for _, ip := range ips {
+		subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{IP: ip})
+	}
+	return []corev1.EndpointSubset{subset}
+}
+
+func TestGetImportedEndpoints(t *testing.T) {
+	localSubsets := newTestEndpointSubsets("10.10.0.1")
+	remoteSubsets := newTestEndpointSubsets("10.20.0.1", "10.20.0.2")
+	allSubsets := append(localSubsets, remoteSubsets...)
+	notReadySubsets := []corev1.EndpointSubset{{
+		NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.10.0.1"}},
+		Ports:             []corev1.EndpointPort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}},
+	}}
+
+	tests := []struct {
+		name             string
+		clusterEndpoints []mcv1alpha1.ClusterEndpoints
+		expectedSubsets  []corev1.EndpointSubset
+		expectedWeights  map[string]uint16
+	}{
+		{
+			name:            "no cluster Endpoints",
+			expectedSubsets: allSubsets,
+		},
+		{
+			name: "default weights",
+			clusterEndpoints: []mcv1alpha1.ClusterEndpoints{
+				{ClusterID: localClusterID, Subsets: localSubsets},
+				{ClusterID: "cluster-b", Subsets: remoteSubsets, Weight: pointer.Int32(100)},
+			},
+			expectedSubsets: allSubsets,
+		},
+		{
+			name: "weighted clusters",
+			clusterEndpoints: []mcv1alpha1.ClusterEndpoints{
+				{ClusterID: localClusterID, Subsets: localSubsets, Weight: pointer.Int32(75)},
+				{ClusterID: "cluster-b", Subsets: remoteSubsets, Weight: pointer.Int32(25)},
+				{ClusterID: "cluster-c", Weight: pointer.Int32(50)},
+			},
+			expectedSubsets: allSubsets,
+			expectedWeights: map[string]uint16{"10.10.0.1": 7500, "10.20.0.1": 1250, "10.20.0.2": 1250},
+		},
+		{
+			name: "cluster with zero weight",
+			clusterEndpoints: []mcv1alpha1.ClusterEndpoints{
+				{ClusterID: localClusterID, Subsets: localSubsets, Weight: pointer.Int32(0)},
+				{ClusterID: "cluster-b", Subsets: remoteSubsets},
+			},
+			expectedSubsets: allSubsets,
+			expectedWeights: map[string]uint16{"10.10.0.1": 0, "10.20.0.1": 5000, "10.20.0.2": 5000},
+		},
+		{
+			name: "prefer local Endpoints",
+			clusterEndpoints: []mcv1alpha1.ClusterEndpoints{
+				{ClusterID: localClusterID, Subsets: localSubsets, PreferLocal: true},
+				{ClusterID: "cluster-b", Subsets: remoteSubsets, PreferLocal: true, Weight: pointer.Int32(10)},
+			},
+			expectedSubsets: localSubsets,
+		},
+		{
+			name: "fall back to remote Endpoints when local Endpoints are not ready",
+			clusterEndpoints: []mcv1alpha1.ClusterEndpoints{
+				{ClusterID: localClusterID, Subsets: notReadySubsets, PreferLocal: true},
+				{ClusterID: "cluster-b", Subsets: remoteSubsets, PreferLocal: true},
+			},
+			expectedSubsets: allSubsets,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epImport := &mcv1alpha1.EndpointsImport{Subsets: allSubsets, ClusterEndpoints: tt.clusterEndpoints}
+			subsets, weights := getImportedEndpoints(localClusterID, epImport)
+			assert.Equal(t, tt.expectedSubsets, subsets)
+			assert.Equal(t, tt.expectedWeights, weights)
+		})
+	}
+}
+
+func TestResourceImportReconciler_handleEndpointWeights(t *testing.T) {
+	mcSvc := getMCService(svcResImport)
+	weightedEpResImport := epResImport.DeepCopy()
+	weightedEpResImport.Spec.Endpoints = &mcv1alpha1.EndpointsImport{
+		Subsets: append(newTestEndpointSubsets("10.10.0.1"), newTestEndpointSubsets("10.20.0.1")...),
+		ClusterEndpoints: []mcv1alpha1.ClusterEndpoints{
+			{ClusterID: localClusterID, Subsets: newTestEndpointSubsets("10.10.0.1"), Weight: pointer.Int32(80)},
+			{ClusterID: "cluster-b", Subsets: newTestEndpointSubsets("10.20.0.1"), Weight: pointer.Int32(20)},
+		},
+	}
+	weightedMCSvc := mcSvc.DeepCopy()
+	weightedMCSvc.Annotations[common.ServiceEndpointWeightsAnnotation] = "10.10.0.1=8000,10.20.0.1=2000"
+
+	tests := []struct {
+		name                string
+		existingService     *corev1.Service
+		resImport           *mcv1alpha1.ResourceImport
+		expectedErr         bool
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:            "add Endpoint weights",
+			existingService: mcSvc,
+			resImport:       weightedEpResImport,
+			expectedAnnotations: map[string]string{
+				common.AntreaMCServiceAnnotation:        "true",
+				common.ServiceEndpointWeightsAnnotation: "10.10.0.1=8000,10.20.0.1=2000",
+			},
+		},
+		{
+			name:                "remove Endpoint weights",
+			existingService:     weightedMCSvc,
+			resImport:           epResImport,
+			expectedAnnotations: map[string]string{common.AntreaMCServiceAnnotation: "true"},
+		},
+		{
+			name:        "retry when the MC Service is not imported",
+			resImport:   weightedEpResImport,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientBuilder := fake.NewClientBuilder().WithScheme(common.TestScheme)
+			if tt.existingService != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.existingService)
+			}
+			fakeClient := clientBuilder.Build()
+			fakeRemoteClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects(tt.resImport).Build()
+			remoteCluster := commonarea.NewFakeRemoteCommonArea(fakeRemoteClient, "leader-cluster", localClusterID, "default", nil)
+			r := newResourceImportReconciler(fakeClient, localClusterID, "default", remoteCluster)
+			_, err := r.Reconcile(ctx, epImportReq)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			svc := &corev1.Service{}
+			if assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "antrea-mc-nginx"}, svc)) {
+				assert.Equal(t, tt.expectedAnnotations, svc.Annotations)
+			}
+		})
+	}
+}
+
 func TestResourceImportReconciler_handleDeleteEvent(t *testing.T) {
 	existSvc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
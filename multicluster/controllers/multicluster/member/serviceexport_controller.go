@@ -20,6 +20,7 @@ import (
 	"context"
 	"net"
 	"reflect"
+	"strconv"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,9 +61,11 @@ type (
 	}
 
 	epInfo struct {
-		name      string
-		namespace string
-		subsets   []corev1.EndpointSubset
+		name        string
+		namespace   string
+		subsets     []corev1.EndpointSubset
+		weight      *int32
+		preferLocal bool
 	}
 
 	// ServiceExportReconciler reconciles a ServiceExport object in the member cluster.
@@ -306,9 +310,11 @@ func (r *ServiceExportReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	weight, preferLocal := getLoadBalancingPreferences(&svcExport)
 	if epsInstalled {
 		installedEp := epsObj.(*epInfo)
-		if apiequality.Semantic.DeepEqual(newSubsets, installedEp.subsets) {
+		if apiequality.Semantic.DeepEqual(newSubsets, installedEp.subsets) &&
+			reflect.DeepEqual(weight, installedEp.weight) && preferLocal == installedEp.preferLocal {
 			// When the EndpointIPType is EndpointIPTypeClusterIP, skipUpdateEPResourceExport should be false only
 			// when there is a ClusterIP/Port change or the recreation flag is true.
 			skipUpdateEPResourceExport = true
@@ -356,7 +362,7 @@ func (r *ServiceExportReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		eps.Subsets = newSubsets
 		klog.InfoS("Endpoints or EndpointSlices has new changes, update ResourceExport", "Service",
 			req.String(), "resourceexport", epExportNSName)
-		err = r.endpointsHandler(ctx, req, eps, epResExportName, re, r.remoteCommonArea)
+		err = r.endpointsHandler(ctx, req, eps, weight, preferLocal, epResExportName, re, r.remoteCommonArea)
 		if err != nil {
 			klog.ErrorS(err, "Failed to handle Endpoints or EndpointSlices change", "service", req.String())
 			return ctrl.Result{}, err
@@ -627,21 +633,28 @@ func (r *ServiceExportReconciler) serviceHandler(
 }
 
 // endpointsHandler handles Endpoints related change.
-// - update corresponding ResourceExport only when Ports or Addresses IP change.
+// - update corresponding ResourceExport only when Ports or Addresses IP, or load
+// balancing preferences change.
 func (r *ServiceExportReconciler) endpointsHandler(
 	ctx context.Context,
 	req ctrl.Request,
 	eps *corev1.Endpoints,
+	weight *int32,
+	preferLocal bool,
 	resName string,
 	re mcv1alpha1.ResourceExport,
 	rc commonarea.RemoteCommonArea) error {
 	kind := constants.EndpointsKind
 	epInfo := &epInfo{
-		name:      eps.Name,
-		namespace: eps.Namespace,
-		subsets:   eps.Subsets,
+		name:        eps.Name,
+		namespace:   eps.Namespace,
+		subsets:     eps.Subsets,
+		weight:      weight,
+		preferLocal: preferLocal,
 	}
 	r.resetResourceExport(resName, kind, nil, eps, &re)
+	re.Spec.Endpoints.Weight = weight
+	re.Spec.Endpoints.PreferLocal = preferLocal
 	existingResExport := &mcv1alpha1.ResourceExport{}
 	resNamespaced := types.NamespacedName{Namespace: rc.GetNamespace(), Name: resName}
 	err := rc.Get(ctx, resNamespaced, existingResExport)
@@ -812,6 +825,24 @@ func ipsToEndpointAddresses(ips []string) []corev1.EndpointAddress {
 	return addresses
 }
 
+// getLoadBalancingPreferences returns the weight of the local cluster and whether
+// the local Endpoints are preferred, which are specified in the annotations of the
+// ServiceExport. An invalid weight is ignored.
+func getLoadBalancingPreferences(svcExport *k8smcsv1alpha1.ServiceExport) (*int32, bool) {
+	var weight *int32
+	if value, ok := svcExport.Annotations[common.ServiceExportWeightAnnotation]; ok {
+		w, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || w < 0 || w > common.MaxServiceExportWeight {
+			klog.ErrorS(err, "Invalid weight annotation of ServiceExport, ignore it", "serviceexport", klog.KObj(svcExport),
+				"weight", value)
+		} else {
+			weight = pointer.Int32(int32(w))
+		}
+	}
+	preferLocal := svcExport.Annotations[common.ServiceExportPreferLocalAnnotation] == "true"
+	return weight, preferLocal
+}
+
 func getResourceExportName(clusterID string, req ctrl.Request, kind string) string {
 	return clusterID + "-" + req.Namespace + "-" + req.Name + "-" + kind
 }
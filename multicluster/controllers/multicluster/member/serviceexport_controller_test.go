@@ -26,6 +26,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -567,6 +568,52 @@ func TestServiceExportReconciler_handleUpdateEvent(t *testing.T) {
 	}
 }
 
+func TestGetLoadBalancingPreferences(t *testing.T) {
+	tests := []struct {
+		name                string
+		annotations         map[string]string
+		expectedWeight      *int32
+		expectedPreferLocal bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name: "weight and locality preference",
+			annotations: map[string]string{
+				common.ServiceExportWeightAnnotation:      "30",
+				common.ServiceExportPreferLocalAnnotation: "true",
+			},
+			expectedWeight:      pointer.Int32(30),
+			expectedPreferLocal: true,
+		},
+		{
+			name: "zero weight",
+			annotations: map[string]string{
+				common.ServiceExportWeightAnnotation: "0",
+			},
+			expectedWeight: pointer.Int32(0),
+		},
+		{
+			name: "invalid weight",
+			annotations: map[string]string{
+				common.ServiceExportWeightAnnotation:      "101",
+				common.ServiceExportPreferLocalAnnotation: "yes",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcExport := &k8smcv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", Annotations: tt.annotations},
+			}
+			weight, preferLocal := getLoadBalancingPreferences(svcExport)
+			assert.Equal(t, tt.expectedWeight, weight)
+			assert.Equal(t, tt.expectedPreferLocal, preferLocal)
+		})
+	}
+}
+
 func Test_objectMapFunc(t *testing.T) {
 	tests := []struct {
 		name string
@@ -329,6 +329,10 @@ func (i *Initializer) initInterfaceStore() error {
 				intf = cniserver.ParseOVSPortInterfaceConfig(port, ovsPort)
 			case interfacestore.AntreaTrafficControl:
 				intf = trafficcontrol.ParseTrafficControlInterfaceConfig(port, ovsPort)
+			case interfacestore.AntreaPacketCapture:
+				// The capture ports are deleted by the PacketCapture
+				// controller when it starts.
+				intf = nil
 			default:
 				klog.InfoS("Unknown Antrea interface type", "type", interfaceType)
 			}
@@ -1112,10 +1116,15 @@ func (i *Initializer) initializeWireGuard() error {
 		return err
 	}
 
+	// The next public key and the accepted public keys are in-memory states of the previous agent process, remove
+	// them and they will be published again when needed.
 	patch, _ := json.Marshal(map[string]interface{}{
 		"metadata": map[string]interface{}{
-			"annotations": map[string]string{
-				types.NodeWireGuardPublicAnnotationKey: publicKey,
+			"annotations": map[string]interface{}{
+				types.NodeWireGuardPublicAnnotationKey:             publicKey,
+				types.NodeWireGuardNextPublicKeyAnnotationKey:      nil,
+				types.NodeWireGuardNextKeyPortAnnotationKey:        nil,
+				types.NodeWireGuardAcceptedPublicKeysAnnotationKey: nil,
 			},
 		},
 	})
@@ -0,0 +1,79 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpointhealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	"antrea.io/antrea/pkg/agent/querier"
+	"antrea.io/antrea/pkg/antctl/transform/common"
+)
+
+// Response describes the response struct of endpointhealth command.
+type Response struct {
+	types.EndpointHealthStatus
+}
+
+// HandleFunc returns the function which can handle queries issued by the endpointhealth command.
+func HandleFunc(aq querier.AgentQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		ns := r.URL.Query().Get("namespace")
+		proxier := aq.GetProxier()
+		if proxier == nil {
+			http.Error(w, "AntreaProxy is not enabled", http.StatusServiceUnavailable)
+			return
+		}
+		var response []Response
+		for _, status := range proxier.GetEndpointHealthStatus() {
+			if (len(name) == 0 || name == status.ServiceName) && (len(ns) == 0 || ns == status.Namespace) {
+				response = append(response, Response{status})
+			}
+		}
+		if len(name) > 0 && len(response) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			http.Error(w, "Failed to encode response: "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+var _ common.TableOutput = (*Response)(nil)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"NAMESPACE", "NAME", "PORT", "ENDPOINT", "STATUS", "FAILURES", "LAST-PROBE", "LAST-ERROR"}
+}
+
+func (r Response) GetTableRow(_ int) []string {
+	status := "Healthy"
+	if !r.Healthy {
+		status = "Unhealthy"
+	}
+	lastProbe := "<none>"
+	if !r.LastProbeTime.IsZero() {
+		lastProbe = r.LastProbeTime.Format(time.RFC3339)
+	}
+	return []string{r.Namespace, r.ServiceName, r.Port, r.Endpoint, status, strconv.Itoa(r.ConsecutiveFailures), lastProbe, r.LastError}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
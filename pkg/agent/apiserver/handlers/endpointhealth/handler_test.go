@@ -0,0 +1,87 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpointhealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	proxytest "antrea.io/antrea/pkg/agent/proxy/testing"
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	queriertest "antrea.io/antrea/pkg/agent/querier/testing"
+)
+
+func TestEndpointHealthQuery(t *testing.T) {
+	status1 := types.EndpointHealthStatus{Namespace: "ns1", ServiceName: "svc1", Port: "http", Endpoint: "10.10.0.1:80", Healthy: true}
+	status2 := types.EndpointHealthStatus{Namespace: "ns1", ServiceName: "svc1", Port: "http", Endpoint: "10.10.0.2:80", Healthy: false, ConsecutiveFailures: 3, LastError: "connection refused"}
+	status3 := types.EndpointHealthStatus{Namespace: "ns2", ServiceName: "svc2", Endpoint: "10.10.0.3:443", Healthy: true}
+
+	tests := []struct {
+		name             string
+		query            string
+		expectedStatus   int
+		expectedResponse []Response
+	}{
+		{
+			name:             "all",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{{status1}, {status2}, {status3}},
+		},
+		{
+			name:             "filter by Namespace",
+			query:            "?namespace=ns2",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{{status3}},
+		},
+		{
+			name:             "filter by name",
+			query:            "?namespace=ns1&name=svc1",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{{status1}, {status2}},
+		},
+		{
+			name:           "not found",
+			query:          "?namespace=ns1&name=svc2",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			q := queriertest.NewMockAgentQuerier(ctrl)
+			proxier := proxytest.NewMockProxier(ctrl)
+			q.EXPECT().GetProxier().Return(proxier)
+			proxier.EXPECT().GetEndpointHealthStatus().Return([]types.EndpointHealthStatus{status1, status2, status3})
+
+			req, err := http.NewRequest(http.MethodGet, tt.query, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			HandleFunc(q).ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var received []Response
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+			assert.Equal(t, tt.expectedResponse, received)
+		})
+	}
+}
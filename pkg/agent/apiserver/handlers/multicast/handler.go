@@ -16,9 +16,11 @@ package multicast
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"antrea.io/antrea/pkg/agent/multicast"
 	"antrea.io/antrea/pkg/antctl/transform/common"
@@ -30,14 +32,17 @@ type Response struct {
 	PodNamespace string `json:"podNamespace,omitempty"`
 	Inbound      string `json:"inbound,omitempty"`
 	Outbound     string `json:"outbound,omitempty"`
+	// Groups are the multicast groups joined by the Pod, with the source filter of the Pod in each group.
+	Groups []multicast.PodGroupMembership `json:"groups,omitempty"`
 }
 
-func generateResponse(podName string, podNamespace string, trafficStats *multicast.PodTrafficStats) Response {
+func generateResponse(podName string, podNamespace string, trafficStats *multicast.PodTrafficStats, groups []multicast.PodGroupMembership) Response {
 	return Response{
 		PodName:      podName,
 		PodNamespace: podNamespace,
 		Inbound:      strconv.FormatUint(trafficStats.Inbound, 10),
 		Outbound:     strconv.FormatUint(trafficStats.Outbound, 10),
+		Groups:       groups,
 	}
 }
 
@@ -59,7 +64,7 @@ func HandleFunc(mq querier.AgentMulticastInfoQuerier) http.HandlerFunc {
 				w.WriteHeader(http.StatusNotFound)
 				return
 			}
-			responses = append(responses, generateResponse(name, ns, podStats))
+			responses = append(responses, generateResponse(name, ns, podStats, mq.GetPodGroupMemberships(name, ns)))
 		} else if ns == "" && name != "" {
 			http.Error(w, "name option should be used with namespace option", http.StatusServiceUnavailable)
 			return
@@ -67,7 +72,7 @@ func HandleFunc(mq querier.AgentMulticastInfoQuerier) http.HandlerFunc {
 			allPodStats := mq.GetAllPodsStats()
 			for iface, trafficStats := range allPodStats {
 				if ns == "" || ns == iface.PodNamespace {
-					responses = append(responses, generateResponse(iface.PodName, iface.PodNamespace, trafficStats))
+					responses = append(responses, generateResponse(iface.PodName, iface.PodNamespace, trafficStats, mq.GetPodGroupMemberships(iface.PodName, iface.PodNamespace)))
 				}
 			}
 		}
@@ -82,11 +87,24 @@ func HandleFunc(mq querier.AgentMulticastInfoQuerier) http.HandlerFunc {
 var _ common.TableOutput = new(Response)
 
 func (r Response) GetTableHeader() []string {
-	return []string{"NAMESPACE", "NAME", "INBOUND", "OUTBOUND"}
+	return []string{"NAMESPACE", "NAME", "INBOUND", "OUTBOUND", "GROUPS"}
 }
 
-func (r Response) GetTableRow(_ int) []string {
-	return []string{r.PodNamespace, r.PodName, r.Inbound, r.Outbound}
+// formatGroup returns the group with the source filter of the Pod. The source filter is omitted if the Pod receives the
+// traffic from all sources.
+func formatGroup(membership multicast.PodGroupMembership) string {
+	if len(membership.Sources) == 0 && membership.FilterMode != "Include" {
+		return membership.Group
+	}
+	return fmt.Sprintf("%s %s(%s)", membership.Group, membership.FilterMode, strings.Join(membership.Sources, " "))
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	groups := make([]string, 0, len(r.Groups))
+	for _, membership := range r.Groups {
+		groups = append(groups, formatGroup(membership))
+	}
+	return []string{r.PodNamespace, r.PodName, r.Inbound, r.Outbound, common.GenerateTableElementWithSummary(groups, maxColumnLength)}
 }
 
 func (r Response) SortRows() bool {
@@ -43,6 +43,7 @@ func TestPodMulticastStatsQuery(t *testing.T) {
 		expectedContent        []Response
 		getPodStatsResult      *multicast.PodTrafficStats
 		gettAllPodsStatsResult map[*interfacestore.InterfaceConfig]*multicast.PodTrafficStats
+		podGroupMemberships    map[string][]multicast.PodGroupMembership
 	}{
 		"Hit PodMulticastStats query, namespace provided": {
 			name:           "pod1",
@@ -54,9 +55,19 @@ func TestPodMulticastStatsQuery(t *testing.T) {
 					PodNamespace: "namespaceA",
 					Inbound:      "22",
 					Outbound:     "33",
+					Groups: []multicast.PodGroupMembership{
+						{Group: "225.1.2.3", FilterMode: "Exclude"},
+						{Group: "232.1.1.1", FilterMode: "Include", Sources: []string{"10.10.0.1", "10.10.0.2"}},
+					},
 				},
 			},
 			getPodStatsResult: &multicast.PodTrafficStats{Inbound: 22, Outbound: 33},
+			podGroupMemberships: map[string][]multicast.PodGroupMembership{
+				"namespaceA/pod1": {
+					{Group: "225.1.2.3", FilterMode: "Exclude"},
+					{Group: "232.1.1.1", FilterMode: "Include", Sources: []string{"10.10.0.1", "10.10.0.2"}},
+				},
+			},
 		},
 		"Miss PodMulticastStats query, namespace and name provided": {
 			name:              "pod1",
@@ -115,6 +126,9 @@ func TestPodMulticastStatsQuery(t *testing.T) {
 		q := queriertest.NewMockAgentMulticastInfoQuerier(ctrl)
 		q.EXPECT().GetPodStats(tc.name, tc.namespace).Return(tc.getPodStatsResult).AnyTimes()
 		q.EXPECT().GetAllPodsStats().Return(tc.gettAllPodsStatsResult).AnyTimes()
+		q.EXPECT().GetPodGroupMemberships(gomock.Any(), gomock.Any()).DoAndReturn(func(name, namespace string) []multicast.PodGroupMembership {
+			return tc.podGroupMemberships[namespace+"/"+name]
+		}).AnyTimes()
 		handler := HandleFunc(q)
 		query := fmt.Sprintf("?name=%s&&namespace=%s", tc.name, tc.namespace)
 		req, err := http.NewRequest(http.MethodGet, query, nil)
@@ -132,3 +146,18 @@ func TestPodMulticastStatsQuery(t *testing.T) {
 		}
 	}
 }
+
+func TestResponseGetTableRow(t *testing.T) {
+	r := Response{
+		PodName:      "pod1",
+		PodNamespace: "namespaceA",
+		Inbound:      "22",
+		Outbound:     "33",
+		Groups: []multicast.PodGroupMembership{
+			{Group: "232.1.1.1", FilterMode: "Include", Sources: []string{"10.10.0.1", "10.10.0.2"}},
+			{Group: "225.1.2.3", FilterMode: "Exclude"},
+			{Group: "225.1.2.4", FilterMode: "Exclude", Sources: []string{"10.10.0.3"}},
+		},
+	}
+	assert.Equal(t, []string{"namespaceA", "pod1", "22", "33", "225.1.2.3,225.1.2.4 Exclude(10.10.0.3),232.1.1.1 Include(10.10.0.1 10.10.0.2)"}, r.GetTableRow(100))
+}
@@ -0,0 +1,322 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gobgp implements bgp.Interface with the BGP server of GoBGP. The
+// server only advertises routes, antrea-agent doesn't install the routes received
+// from the peers.
+package gobgp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	gobgpapi "github.com/osrg/gobgp/v3/api"
+	"github.com/osrg/gobgp/v3/pkg/apiutil"
+	gobgplog "github.com/osrg/gobgp/v3/pkg/log"
+	"github.com/osrg/gobgp/v3/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v3/pkg/server"
+	"k8s.io/klog/v2"
+
+	antreabgp "antrea.io/antrea/pkg/agent/bgp"
+)
+
+var (
+	familyIPv4Unicast = &gobgpapi.Family{Afi: gobgpapi.Family_AFI_IP, Safi: gobgpapi.Family_SAFI_UNICAST}
+	familyIPv6Unicast = &gobgpapi.Family{Afi: gobgpapi.Family_AFI_IP6, Safi: gobgpapi.Family_SAFI_UNICAST}
+
+	sessionStates = map[gobgpapi.PeerState_SessionState]antreabgp.SessionState{
+		gobgpapi.PeerState_UNKNOWN:     antreabgp.SessionIdle,
+		gobgpapi.PeerState_IDLE:        antreabgp.SessionIdle,
+		gobgpapi.PeerState_CONNECT:     antreabgp.SessionConnect,
+		gobgpapi.PeerState_ACTIVE:      antreabgp.SessionActive,
+		gobgpapi.PeerState_OPENSENT:    antreabgp.SessionOpenSent,
+		gobgpapi.PeerState_OPENCONFIRM: antreabgp.SessionOpenConfirm,
+		gobgpapi.PeerState_ESTABLISHED: antreabgp.SessionEstablished,
+	}
+)
+
+var (
+	// The main loop of a GoBGP server, BgpServer.Serve, never returns, so the
+	// GoBGP servers are reused across the restarts of the BGP speaker, instead of
+	// starting a new loop every time the speaker is started.
+	idleServersMutex sync.Mutex
+	idleServers      []*server.BgpServer
+)
+
+// getBgpServer returns an idle GoBGP server, or a new one if there is none.
+func getBgpServer() *server.BgpServer {
+	idleServersMutex.Lock()
+	defer idleServersMutex.Unlock()
+	if n := len(idleServers); n > 0 {
+		bgpServer := idleServers[n-1]
+		idleServers = idleServers[:n-1]
+		return bgpServer
+	}
+	bgpServer := server.NewBgpServer(server.LoggerOption(&logger{}))
+	go bgpServer.Serve()
+	return bgpServer
+}
+
+// putBgpServer releases a stopped GoBGP server for reuse.
+func putBgpServer(bgpServer *server.BgpServer) {
+	idleServersMutex.Lock()
+	defer idleServersMutex.Unlock()
+	idleServers = append(idleServers, bgpServer)
+}
+
+// Server is a BGP speaker implementing bgp.Interface with GoBGP. The other
+// methods must only be called after Start succeeds and before Stop is called.
+type Server struct {
+	globalConfig *antreabgp.GlobalConfig
+	server       *server.BgpServer
+}
+
+var _ antreabgp.Interface = &Server{}
+
+// NewGoBGPServer returns a BGP speaker with the provided global configuration.
+func NewGoBGPServer(globalConfig *antreabgp.GlobalConfig) *Server {
+	return &Server{
+		globalConfig: globalConfig,
+	}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	bgpServer := getBgpServer()
+	// GoBGP doesn't listen if the port is -1.
+	listenPort := s.globalConfig.ListenPort
+	if listenPort == 0 {
+		listenPort = -1
+	}
+	if err := bgpServer.StartBgp(ctx, &gobgpapi.StartBgpRequest{
+		Global: &gobgpapi.Global{
+			Asn:        s.globalConfig.ASN,
+			RouterId:   s.globalConfig.RouterID,
+			ListenPort: listenPort,
+		},
+	}); err != nil {
+		// Stop the server in case some of the listeners were created.
+		bgpServer.StopBgp(ctx, &gobgpapi.StopBgpRequest{})
+		putBgpServer(bgpServer)
+		return fmt.Errorf("failed to start BGP server: %w", err)
+	}
+	s.server = bgpServer
+	klog.InfoS("Started BGP server", "asn", s.globalConfig.ASN, "routerID", s.globalConfig.RouterID, "listenPort", s.globalConfig.ListenPort)
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+	if err := s.server.StopBgp(ctx, &gobgpapi.StopBgpRequest{}); err != nil {
+		return fmt.Errorf("failed to stop BGP server: %w", err)
+	}
+	putBgpServer(s.server)
+	s.server = nil
+	klog.InfoS("Stopped BGP server")
+	return nil
+}
+
+func (s *Server) AddPeer(ctx context.Context, peerConf antreabgp.PeerConfig) error {
+	peer, err := convertPeerConfig(peerConf)
+	if err != nil {
+		return err
+	}
+	if err := s.server.AddPeer(ctx, &gobgpapi.AddPeerRequest{Peer: peer}); err != nil {
+		return fmt.Errorf("failed to add BGP peer %s: %w", peerConf.Address, err)
+	}
+	klog.InfoS("Added BGP peer", "address", peerConf.Address, "port", peerConf.Port, "asn", peerConf.ASN)
+	return nil
+}
+
+// UpdatePeer removes the peer and adds it with the new configuration, as GoBGP
+// doesn't reset the session when some of the configuration, e.g. the port of the
+// peer, changes.
+func (s *Server) UpdatePeer(ctx context.Context, peerConf antreabgp.PeerConfig) error {
+	peer, err := convertPeerConfig(peerConf)
+	if err != nil {
+		return err
+	}
+	if err := s.server.DeletePeer(ctx, &gobgpapi.DeletePeerRequest{Address: peerConf.Address}); err != nil {
+		return fmt.Errorf("failed to remove BGP peer %s: %w", peerConf.Address, err)
+	}
+	if err := s.server.AddPeer(ctx, &gobgpapi.AddPeerRequest{Peer: peer}); err != nil {
+		return fmt.Errorf("failed to add BGP peer %s: %w", peerConf.Address, err)
+	}
+	klog.InfoS("Updated BGP peer", "address", peerConf.Address, "port", peerConf.Port, "asn", peerConf.ASN)
+	return nil
+}
+
+func (s *Server) RemovePeer(ctx context.Context, peerConf antreabgp.PeerConfig) error {
+	if err := s.server.DeletePeer(ctx, &gobgpapi.DeletePeerRequest{Address: peerConf.Address}); err != nil {
+		return fmt.Errorf("failed to remove BGP peer %s: %w", peerConf.Address, err)
+	}
+	klog.InfoS("Removed BGP peer", "address", peerConf.Address)
+	return nil
+}
+
+func (s *Server) GetPeers(ctx context.Context) ([]antreabgp.PeerStatus, error) {
+	var peers []antreabgp.PeerStatus
+	if err := s.server.ListPeer(ctx, &gobgpapi.ListPeerRequest{}, func(peer *gobgpapi.Peer) {
+		status := antreabgp.PeerStatus{
+			Address:      peer.Conf.NeighborAddress,
+			ASN:          int32(peer.Conf.PeerAsn),
+			SessionState: antreabgp.SessionIdle,
+		}
+		if peer.Transport != nil {
+			status.Port = int32(peer.Transport.RemotePort)
+		}
+		if peer.State != nil {
+			status.SessionState = sessionStates[peer.State.SessionState]
+		}
+		peers = append(peers, status)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list BGP peers: %w", err)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Address < peers[j].Address
+	})
+	return peers, nil
+}
+
+func (s *Server) AdvertiseRoutes(ctx context.Context, routes []antreabgp.Route) error {
+	paths, err := convertRoutes(routes, false)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if _, err := s.server.AddPath(ctx, &gobgpapi.AddPathRequest{TableType: gobgpapi.TableType_GLOBAL, Path: path}); err != nil {
+			return fmt.Errorf("failed to advertise BGP route: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) WithdrawRoutes(ctx context.Context, routes []antreabgp.Route) error {
+	paths, err := convertRoutes(routes, true)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := s.server.DeletePath(ctx, &gobgpapi.DeletePathRequest{TableType: gobgpapi.TableType_GLOBAL, Path: path}); err != nil {
+			return fmt.Errorf("failed to withdraw BGP route: %w", err)
+		}
+	}
+	return nil
+}
+
+func convertPeerConfig(peerConf antreabgp.PeerConfig) (*gobgpapi.Peer, error) {
+	if net.ParseIP(peerConf.Address) == nil {
+		return nil, fmt.Errorf("invalid peer address %q", peerConf.Address)
+	}
+	peer := &gobgpapi.Peer{
+		Conf: &gobgpapi.PeerConf{
+			NeighborAddress: peerConf.Address,
+			PeerAsn:         uint32(peerConf.ASN),
+		},
+		Transport: &gobgpapi.Transport{
+			RemotePort: uint32(peerConf.Port),
+		},
+		// Pod CIDRs, Service IPs and Egress IPs of both families are
+		// advertised to the peer, regardless of the family of the peer.
+		AfiSafis: []*gobgpapi.AfiSafi{
+			{Config: &gobgpapi.AfiSafiConfig{Family: familyIPv4Unicast, Enabled: true}},
+			{Config: &gobgpapi.AfiSafiConfig{Family: familyIPv6Unicast, Enabled: true}},
+		},
+	}
+	if peerConf.MultihopTTL != nil {
+		peer.EbgpMultihop = &gobgpapi.EbgpMultihop{
+			Enabled:     true,
+			MultihopTtl: uint32(*peerConf.MultihopTTL),
+		}
+	}
+	return peer, nil
+}
+
+func convertRoutes(routes []antreabgp.Route, isWithdraw bool) ([]*gobgpapi.Path, error) {
+	paths := make([]*gobgpapi.Path, 0, len(routes))
+	origin := bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP)
+	for _, route := range routes {
+		ip, ipNet, err := net.ParseCIDR(route.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route prefix %q", route.Prefix)
+		}
+		prefixLen, _ := ipNet.Mask.Size()
+		var nlri bgp.AddrPrefixInterface
+		var attrs []bgp.PathAttributeInterface
+		// The next hop is set to the local address of the session by
+		// GoBGP when it's unspecified.
+		if ip.To4() != nil {
+			nlri = bgp.NewIPAddrPrefix(uint8(prefixLen), ipNet.IP.String())
+			attrs = []bgp.PathAttributeInterface{origin, bgp.NewPathAttributeNextHop(net.IPv4zero.String())}
+		} else {
+			nlri = bgp.NewIPv6AddrPrefix(uint8(prefixLen), ipNet.IP.String())
+			attrs = []bgp.PathAttributeInterface{origin, bgp.NewPathAttributeMpReachNLRI(net.IPv6zero.String(), []bgp.AddrPrefixInterface{nlri})}
+		}
+		path, err := apiutil.NewPath(nlri, isWithdraw, attrs, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to build BGP path for route %s: %w", route.Prefix, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// logger implements the logger interface of GoBGP with klog.
+type logger struct{}
+
+func fieldsToKeysAndValues(fields gobgplog.Fields) []interface{} {
+	keysAndValues := make([]interface{}, 0, 2*len(fields))
+	for k, v := range fields {
+		keysAndValues = append(keysAndValues, k, v)
+	}
+	return keysAndValues
+}
+
+func (l *logger) Panic(msg string, fields gobgplog.Fields) {
+	klog.ErrorS(nil, msg, fieldsToKeysAndValues(fields)...)
+	panic(msg)
+}
+
+func (l *logger) Fatal(msg string, fields gobgplog.Fields) {
+	klog.ErrorS(nil, msg, fieldsToKeysAndValues(fields)...)
+	klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+}
+
+func (l *logger) Error(msg string, fields gobgplog.Fields) {
+	klog.ErrorS(nil, msg, fieldsToKeysAndValues(fields)...)
+}
+
+func (l *logger) Warn(msg string, fields gobgplog.Fields) {
+	klog.InfoS(msg, fieldsToKeysAndValues(fields)...)
+}
+
+func (l *logger) Info(msg string, fields gobgplog.Fields) {
+	klog.V(2).InfoS(msg, fieldsToKeysAndValues(fields)...)
+}
+
+func (l *logger) Debug(msg string, fields gobgplog.Fields) {
+	klog.V(4).InfoS(msg, fieldsToKeysAndValues(fields)...)
+}
+
+// The log level is controlled by the verbosity of klog.
+func (l *logger) SetLevel(level gobgplog.LogLevel) {}
+
+func (l *logger) GetLevel() gobgplog.LogLevel {
+	return gobgplog.DebugLevel
+}
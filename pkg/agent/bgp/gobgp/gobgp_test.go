@@ -0,0 +1,141 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gobgp
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	gobgpapi "github.com/osrg/gobgp/v3/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	antreabgp "antrea.io/antrea/pkg/agent/bgp"
+)
+
+const (
+	localASN = 64512
+	peerASN  = 64513
+)
+
+func getFreePort(t *testing.T) int32 {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return int32(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func startServer(t *testing.T, globalConfig *antreabgp.GlobalConfig) *Server {
+	s := NewGoBGPServer(globalConfig)
+	require.NoError(t, s.Start(context.Background()))
+	t.Cleanup(func() { s.Stop(context.Background()) })
+	return s
+}
+
+func routes(prefixes ...string) []antreabgp.Route {
+	var routes []antreabgp.Route
+	for _, prefix := range prefixes {
+		routes = append(routes, antreabgp.Route{Prefix: prefix})
+	}
+	return routes
+}
+
+// listReceivedRoutes returns the prefixes received by s.
+func listReceivedRoutes(t assert.TestingT, s *Server) []string {
+	var prefixes []string
+	for _, family := range []*gobgpapi.Family{familyIPv4Unicast, familyIPv6Unicast} {
+		err := s.server.ListPath(context.Background(), &gobgpapi.ListPathRequest{
+			TableType: gobgpapi.TableType_GLOBAL,
+			Family:    family,
+		}, func(d *gobgpapi.Destination) {
+			prefixes = append(prefixes, d.Prefix)
+		})
+		assert.NoError(t, err)
+	}
+	return prefixes
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	localPort, remotePort := getFreePort(t), getFreePort(t)
+	local := startServer(t, &antreabgp.GlobalConfig{ASN: localASN, RouterID: "10.0.0.1", ListenPort: localPort})
+	remote := startServer(t, &antreabgp.GlobalConfig{ASN: peerASN, RouterID: "10.0.0.2", ListenPort: remotePort})
+
+	require.NoError(t, local.AdvertiseRoutes(ctx, routes("10.10.0.0/24", "fd00:10:244::/64")))
+	require.NoError(t, local.AddPeer(ctx, antreabgp.PeerConfig{Address: "127.0.0.1", Port: remotePort, ASN: peerASN}))
+	require.NoError(t, remote.AddPeer(ctx, antreabgp.PeerConfig{Address: "127.0.0.1", Port: localPort, ASN: localASN}))
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		peers, err := local.GetPeers(ctx)
+		require.NoError(c, err)
+		assert.Equal(c, []antreabgp.PeerStatus{{
+			Address:      "127.0.0.1",
+			Port:         remotePort,
+			ASN:          peerASN,
+			SessionState: antreabgp.SessionEstablished,
+		}}, peers)
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.ElementsMatch(c, []string{"10.10.0.0/24", "fd00:10:244::/64"}, listReceivedRoutes(c, remote))
+	}, 5*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, local.AdvertiseRoutes(ctx, routes("10.96.0.10/32")))
+	require.NoError(t, local.WithdrawRoutes(ctx, routes("10.10.0.0/24")))
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.ElementsMatch(c, []string{"10.96.0.10/32", "fd00:10:244::/64"}, listReceivedRoutes(c, remote))
+	}, 5*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, local.RemovePeer(ctx, antreabgp.PeerConfig{Address: "127.0.0.1"}))
+	peers, err := local.GetPeers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Empty(c, listReceivedRoutes(c, remote))
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestServerInvalidConfig(t *testing.T) {
+	ctx := context.Background()
+	s := startServer(t, &antreabgp.GlobalConfig{ASN: localASN, RouterID: "10.0.0.1"})
+	assert.ErrorContains(t, s.AddPeer(ctx, antreabgp.PeerConfig{Address: "invalid", ASN: peerASN}), "invalid peer address")
+	require.NoError(t, s.AddPeer(ctx, antreabgp.PeerConfig{Address: "192.0.2.1", ASN: peerASN}))
+	assert.Error(t, s.AddPeer(ctx, antreabgp.PeerConfig{Address: "192.0.2.1", ASN: peerASN}))
+	assert.Error(t, s.RemovePeer(ctx, antreabgp.PeerConfig{Address: "192.0.2.2"}))
+	assert.ErrorContains(t, s.AdvertiseRoutes(ctx, routes("10.0.0.0")), "invalid route prefix")
+}
+
+func TestServerRestart(t *testing.T) {
+	ctx := context.Background()
+	listenPort := getFreePort(t)
+	s := NewGoBGPServer(&antreabgp.GlobalConfig{ASN: localASN, RouterID: "10.0.0.1", ListenPort: listenPort})
+	require.NoError(t, s.Start(ctx))
+	bgpServer := s.server
+	require.NoError(t, s.AddPeer(ctx, antreabgp.PeerConfig{Address: "192.0.2.1", ASN: peerASN}))
+	require.NoError(t, s.AdvertiseRoutes(ctx, routes("10.10.0.0/24")))
+	require.NoError(t, s.Stop(ctx))
+	assert.Nil(t, s.server)
+
+	// The stopped GoBGP server is reused instead of starting a new one, and
+	// its state is reset.
+	restarted := startServer(t, &antreabgp.GlobalConfig{ASN: peerASN, RouterID: "10.0.0.2", ListenPort: listenPort})
+	assert.Same(t, bgpServer, restarted.server)
+	peers, err := restarted.GetPeers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+	assert.Empty(t, listReceivedRoutes(t, restarted))
+	require.NoError(t, restarted.AddPeer(ctx, antreabgp.PeerConfig{Address: "192.0.2.1", ASN: localASN}))
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"context"
+)
+
+// Interface is the interface of a BGP speaker, which establishes sessions with
+// BGP peers and advertises routes to them.
+type Interface interface {
+	// Start starts the BGP speaker.
+	Start(ctx context.Context) error
+	// Stop stops the BGP speaker, closing all the sessions.
+	Stop(ctx context.Context) error
+	// AddPeer adds a BGP peer and starts establishing a session with it.
+	AddPeer(ctx context.Context, peerConf PeerConfig) error
+	// UpdatePeer updates the configuration of a BGP peer, which resets the
+	// session with it.
+	UpdatePeer(ctx context.Context, peerConf PeerConfig) error
+	// RemovePeer closes the session with a BGP peer and removes it.
+	RemovePeer(ctx context.Context, peerConf PeerConfig) error
+	// GetPeers returns the status of all the BGP peers.
+	GetPeers(ctx context.Context) ([]PeerStatus, error)
+	// AdvertiseRoutes advertises routes to all the BGP peers.
+	AdvertiseRoutes(ctx context.Context, routes []Route) error
+	// WithdrawRoutes withdraws routes from all the BGP peers.
+	WithdrawRoutes(ctx context.Context, routes []Route) error
+}
+
+// GlobalConfig is the configuration of a BGP speaker.
+type GlobalConfig struct {
+	// ASN is the local Autonomous System Number.
+	ASN uint32
+	// RouterID is the BGP identifier of the speaker, an IPv4 address.
+	RouterID string
+	// ListenPort is the port on which the speaker accepts the connections
+	// from its peers. The speaker doesn't listen if it's 0.
+	ListenPort int32
+}
+
+// PeerConfig is the configuration of a BGP peer.
+type PeerConfig struct {
+	// Address is the IP address of the peer.
+	Address string
+	// Port is the TCP port of the peer.
+	Port int32
+	// ASN is the Autonomous System Number of the peer.
+	ASN int32
+	// MultihopTTL is the TTL of the packets sent to an external peer. It
+	// defaults to 1 if not set, i.e. the peer is directly connected.
+	MultihopTTL *int32
+}
+
+// SessionState is the state of the BGP session with a peer, see RFC 4271
+// Section 8.
+type SessionState string
+
+const (
+	SessionIdle        SessionState = "Idle"
+	SessionConnect     SessionState = "Connect"
+	SessionActive      SessionState = "Active"
+	SessionOpenSent    SessionState = "OpenSent"
+	SessionOpenConfirm SessionState = "OpenConfirm"
+	SessionEstablished SessionState = "Established"
+)
+
+// PeerStatus is the status of a BGP peer.
+type PeerStatus struct {
+	Address      string
+	Port         int32
+	ASN          int32
+	SessionState SessionState
+}
+
+// Route is a route advertised to BGP peers.
+type Route struct {
+	// Prefix is the destination of the route in CIDR notation.
+	Prefix string
+}
@@ -37,7 +37,8 @@ import (
 
 const (
 	controllerName = "AntreaIPAMController"
-	// Pod index name for IPPool cache.
+	// Pod index name for IPPool cache, used to find the IP addresses recorded in the IPPool status
+	// by earlier versions.
 	podIndex = "pod"
 )
 
@@ -46,13 +47,15 @@ const (
 // this controller can be used to store annotations for other objects,
 // such as Statefulsets.
 type AntreaIPAMController struct {
-	crdClient         clientsetversioned.Interface
-	ipPoolInformer    crdinformers.IPPoolInformer
-	ipPoolLister      crdlisters.IPPoolLister
-	namespaceInformer coreinformers.NamespaceInformer
-	namespaceLister   corelisters.NamespaceLister
-	podInformer       cache.SharedIndexInformer
-	podLister         corelisters.PodLister
+	crdClient            clientsetversioned.Interface
+	ipPoolInformer       crdinformers.IPPoolInformer
+	ipPoolLister         crdlisters.IPPoolLister
+	ipAllocationInformer crdinformers.IPAllocationInformer
+	ipAllocationCache    *poolallocator.IPAllocationCache
+	namespaceInformer    coreinformers.NamespaceInformer
+	namespaceLister      corelisters.NamespaceLister
+	podInformer          cache.SharedIndexInformer
+	podLister            corelisters.PodLister
 }
 
 func podIndexFunc(obj interface{}) ([]string, error) {
@@ -72,6 +75,7 @@ func podIndexFunc(obj interface{}) ([]string, error) {
 func InitializeAntreaIPAMController(crdClient clientsetversioned.Interface,
 	namespaceInformer coreinformers.NamespaceInformer,
 	ipPoolInformer crdinformers.IPPoolInformer,
+	ipAllocationInformer crdinformers.IPAllocationInformer,
 	podInformer cache.SharedIndexInformer, ipamAnnotations bool) (*AntreaIPAMController, error) {
 	// Order of init causes antreaIPAMDriver to be initialized first
 	// After controller is initialized by agent init, we need to make it
@@ -82,24 +86,33 @@ func InitializeAntreaIPAMController(crdClient clientsetversioned.Interface,
 
 	var antreaIPAMController *AntreaIPAMController
 	ipPoolInformer.Informer().AddIndexers(cache.Indexers{podIndex: podIndexFunc})
+	ipAllocationInformer.Informer().AddIndexers(cache.Indexers{
+		poolallocator.IPPoolIndex:    poolallocator.IPPoolIndexFunc,
+		poolallocator.ContainerIndex: poolallocator.ContainerIndexFunc,
+	})
+	ipAllocationCache := poolallocator.NewIPAllocationCache(ipAllocationInformer)
 
 	// Create podInformer/Lister and namespaceInformer/Lister if need to read the AntreaIPAM
 	// annotation on Pods and Namespaces.
 	if ipamAnnotations {
 		antreaIPAMController = &AntreaIPAMController{
-			crdClient:         crdClient,
-			ipPoolInformer:    ipPoolInformer,
-			ipPoolLister:      ipPoolInformer.Lister(),
-			namespaceInformer: namespaceInformer,
-			namespaceLister:   namespaceInformer.Lister(),
-			podInformer:       podInformer,
-			podLister:         corelisters.NewPodLister(podInformer.GetIndexer()),
+			crdClient:            crdClient,
+			ipPoolInformer:       ipPoolInformer,
+			ipPoolLister:         ipPoolInformer.Lister(),
+			ipAllocationInformer: ipAllocationInformer,
+			ipAllocationCache:    ipAllocationCache,
+			namespaceInformer:    namespaceInformer,
+			namespaceLister:      namespaceInformer.Lister(),
+			podInformer:          podInformer,
+			podLister:            corelisters.NewPodLister(podInformer.GetIndexer()),
 		}
 	} else {
 		antreaIPAMController = &AntreaIPAMController{
-			crdClient:      crdClient,
-			ipPoolInformer: ipPoolInformer,
-			ipPoolLister:   ipPoolInformer.Lister(),
+			crdClient:            crdClient,
+			ipPoolInformer:       ipPoolInformer,
+			ipPoolLister:         ipPoolInformer.Lister(),
+			ipAllocationInformer: ipAllocationInformer,
+			ipAllocationCache:    ipAllocationCache,
 		}
 	}
 	return antreaIPAMController, nil
@@ -113,7 +126,7 @@ func (c *AntreaIPAMController) Run(stopCh <-chan struct{}) {
 	}()
 
 	klog.InfoS("Starting", "controller", controllerName)
-	cacheSyncs := []cache.InformerSynced{c.ipPoolInformer.Informer().HasSynced}
+	cacheSyncs := []cache.InformerSynced{c.ipPoolInformer.Informer().HasSynced, c.ipAllocationInformer.Informer().HasSynced}
 	if c.podInformer != nil && c.namespaceInformer != nil {
 		cacheSyncs = append(cacheSyncs, c.podInformer.HasSynced, c.namespaceInformer.Informer().HasSynced)
 	}
@@ -200,7 +213,7 @@ func (c *AntreaIPAMController) getPoolAllocatorByPod(namespace, podName string)
 
 	var allocator *poolallocator.IPPoolAllocator
 	for _, p := range poolNames {
-		allocator, err = poolallocator.NewIPPoolAllocator(p, c.crdClient, c.ipPoolLister)
+		allocator, err = poolallocator.NewIPPoolAllocator(p, c.crdClient, c.ipPoolLister, c.ipAllocationCache)
 		if err != nil {
 			if !errors.IsNotFound(err) {
 				err = fmt.Errorf("failed to get IPPool %s: %v", p, err)
@@ -223,6 +236,13 @@ func (c *AntreaIPAMController) getPoolAllocatorByPod(namespace, podName string)
 // Look up IPPools by matching PodOwnder.
 func (c *AntreaIPAMController) getPoolAllocatorsByOwner(podOwner *crdv1a2.PodOwner) ([]*poolallocator.IPPoolAllocator, error) {
 	var allocators []*poolallocator.IPPoolAllocator
+	var poolNames []string
+	ipAllocations, _ := c.ipAllocationInformer.Informer().GetIndexer().ByIndex(poolallocator.ContainerIndex,
+		poolallocator.ContainerIndexKey(podOwner.ContainerID, podOwner.IFName))
+	for _, item := range ipAllocations {
+		poolNames = append(poolNames, item.(*crdv1a2.IPAllocation).Spec.IPPool)
+	}
+	// The IPPools which have not been migrated may still record the IP addresses in the status.
 	ipPools, _ := c.ipPoolInformer.Informer().GetIndexer().ByIndex(podIndex,
 		k8s.NamespacedName(podOwner.Namespace, podOwner.Name))
 	for _, item := range ipPools {
@@ -230,17 +250,20 @@ func (c *AntreaIPAMController) getPoolAllocatorsByOwner(podOwner *crdv1a2.PodOwn
 		for _, ipAddress := range ipPool.Status.IPAddresses {
 			savedPod := ipAddress.Owner.Pod
 			if savedPod != nil && savedPod.ContainerID == podOwner.ContainerID && savedPod.IFName == podOwner.IFName {
-				allocator, err := poolallocator.NewIPPoolAllocator(ipPool.Name, c.crdClient, c.ipPoolLister)
-				if err != nil {
-					return nil, err
-				}
-				allocators = append(allocators, allocator)
+				poolNames = append(poolNames, ipPool.Name)
 			}
 		}
 	}
+	for _, poolName := range poolNames {
+		allocator, err := poolallocator.NewIPPoolAllocator(poolName, c.crdClient, c.ipPoolLister, c.ipAllocationCache)
+		if err != nil {
+			return nil, err
+		}
+		allocators = append(allocators, allocator)
+	}
 	return allocators, nil
 }
 
 func (c *AntreaIPAMController) getPoolAllocatorByName(poolName string) (*poolallocator.IPPoolAllocator, error) {
-	return poolallocator.NewIPPoolAllocator(poolName, c.crdClient, c.ipPoolLister)
+	return poolallocator.NewIPPoolAllocator(poolName, c.crdClient, c.ipPoolLister, c.ipAllocationCache)
 }
@@ -15,7 +15,9 @@
 package ipam
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"regexp"
 	"sync"
 	"testing"
@@ -42,6 +44,7 @@ import (
 	crdv1a2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
 	annotations "antrea.io/antrea/pkg/ipam"
+	"antrea.io/antrea/pkg/ipam/poolallocator"
 	fakepoolclient "antrea.io/antrea/pkg/ipam/poolallocator/testing"
 )
 
@@ -116,39 +119,53 @@ func createIPPools(crdClient *fakepoolclient.IPPoolClientset) {
 			IPRanges:  []crdv1a2.SubnetIPRange{subnetRangePear},
 			IPVersion: crdv1a2.IPv4,
 		},
-		Status: crdv1a2.IPPoolStatus{IPAddresses: []crdv1a2.IPAddressState{{
-			IPAddress: "10.2.3.198",
-			Phase:     crdv1a2.IPAddressPhaseReserved,
-			Owner: crdv1a2.IPAddressOwner{StatefulSet: &crdv1a2.StatefulSetOwner{
+	})
+	for _, state := range []crdv1a2.IPAddressState{{
+		IPAddress: "10.2.3.198",
+		Phase:     crdv1a2.IPAddressPhaseReserved,
+		Owner: crdv1a2.IPAddressOwner{StatefulSet: &crdv1a2.StatefulSetOwner{
+			Name:      "pear-sts",
+			Namespace: testPear,
+			Index:     8,
+		}},
+	}, {
+		IPAddress: "10.2.3.197",
+		Phase:     crdv1a2.IPAddressPhaseReserved,
+		Owner: crdv1a2.IPAddressOwner{
+			Pod: &crdv1a2.PodOwner{
+				Name:        "pear-sts-9",
+				Namespace:   testPear,
+				ContainerID: "pear-sts-9-container",
+			},
+			StatefulSet: &crdv1a2.StatefulSetOwner{
 				Name:      "pear-sts",
 				Namespace: testPear,
-				Index:     8,
+				Index:     9,
 			}},
-		}, {
-			IPAddress: "10.2.3.197",
-			Phase:     crdv1a2.IPAddressPhaseReserved,
-			Owner: crdv1a2.IPAddressOwner{
-				Pod: &crdv1a2.PodOwner{
-					Name:        "pear-sts-9",
-					Namespace:   testPear,
-					ContainerID: "pear-sts-9-container",
-				},
-				StatefulSet: &crdv1a2.StatefulSetOwner{
-					Name:      "pear-sts",
-					Namespace: testPear,
-					Index:     9,
-				}},
-		}, {
-			IPAddress: "10.2.3.196",
-			Phase:     crdv1a2.IPAddressPhaseReserved,
-			Owner: crdv1a2.IPAddressOwner{
-				Pod: &crdv1a2.PodOwner{
-					Name:        "pear10",
-					Namespace:   testPear,
-					ContainerID: "pear10-container",
-				}},
-		}}},
-	})
+	}, {
+		IPAddress: "10.2.3.196",
+		Phase:     crdv1a2.IPAddressPhaseReserved,
+		Owner: crdv1a2.IPAddressOwner{
+			Pod: &crdv1a2.PodOwner{
+				Name:        "pear10",
+				Namespace:   testPear,
+				ContainerID: "pear10-container",
+			}},
+	}} {
+		crdClient.CrdV1alpha2().IPAllocations().Create(context.TODO(), &crdv1a2.IPAllocation{
+			ObjectMeta: metav1.ObjectMeta{Name: poolallocator.IPAllocationName(testPear, net.ParseIP(state.IPAddress))},
+			Spec:       crdv1a2.IPAllocationSpec{IPPool: testPear, IPAddressState: state},
+		}, metav1.CreateOptions{})
+	}
+}
+
+func getIPAddressStates(controller *AntreaIPAMController, poolName string) []crdv1a2.IPAddressState {
+	var states []crdv1a2.IPAddressState
+	allocations, _ := controller.ipAllocationInformer.Informer().GetIndexer().ByIndex(poolallocator.IPPoolIndex, poolName)
+	for _, obj := range allocations {
+		states = append(states, obj.(*crdv1a2.IPAllocation).Spec.IPAddressState)
+	}
+	return states
 }
 
 func initTestClients() (*fake.Clientset, *fakepoolclient.IPPoolClientset) {
@@ -326,7 +343,7 @@ func TestAntreaIPAMDriver(t *testing.T) {
 		listOptions,
 	)
 
-	antreaIPAMController, err := InitializeAntreaIPAMController(crdClient, informerFactory.Core().V1().Namespaces(), crdInformerFactory.Crd().V1alpha2().IPPools(), localPodInformer, true)
+	antreaIPAMController, err := InitializeAntreaIPAMController(crdClient, informerFactory.Core().V1().Namespaces(), crdInformerFactory.Crd().V1alpha2().IPPools(), crdInformerFactory.Crd().V1alpha2().IPAllocations(), localPodInformer, true)
 	require.NoError(t, err, "Expected no error in initialization for Antrea IPAM Controller")
 	informerFactory.Start(stopCh)
 	go localPodInformer.Run(stopCh)
@@ -377,9 +394,8 @@ func TestAntreaIPAMDriver(t *testing.T) {
 		podNamespace := string(k8sArgsMap[test].K8S_POD_NAMESPACE)
 		podName := string(k8sArgsMap[test].K8S_POD_NAME)
 		err = wait.Poll(time.Millisecond*200, time.Second, func() (bool, error) {
-			ipPool, _ := antreaIPAMController.ipPoolLister.Get(podNamespace)
 			found := false
-			for _, ipAddress := range ipPool.Status.IPAddresses {
+			for _, ipAddress := range getIPAddressStates(antreaIPAMController, podNamespace) {
 				if expectedIP == ipAddress.IPAddress {
 					assert.Equal(t, ipAddress.Owner.StatefulSet != nil, isReserved)
 					if ipAddress.Owner.StatefulSet != nil {
@@ -411,9 +427,8 @@ func TestAntreaIPAMDriver(t *testing.T) {
 		podNamespace := string(k8sArgsMap[test].K8S_POD_NAMESPACE)
 		podName := string(k8sArgsMap[test].K8S_POD_NAME)
 		err = wait.Poll(time.Millisecond*200, time.Second, func() (bool, error) {
-			ipPool, _ := antreaIPAMController.ipPoolLister.Get(podNamespace)
 			found := false
-			for _, ipAddress := range ipPool.Status.IPAddresses {
+			for _, ipAddress := range getIPAddressStates(antreaIPAMController, podNamespace) {
 				if ipAddress.Owner.Pod != nil && ipAddress.Owner.Pod.Name == podName && ipAddress.Owner.Pod.Namespace == podNamespace {
 					t.Logf("IP allocation is not removed")
 					return false, nil
@@ -607,6 +622,7 @@ func TestSecondaryNetworkAdd(t *testing.T) {
 				antreaIPAMController, err := InitializeAntreaIPAMController(crdClient,
 					informerFactory.Core().V1().Namespaces(),
 					crdInformerFactory.Crd().V1alpha2().IPPools(),
+					crdInformerFactory.Crd().V1alpha2().IPAllocations(),
 					localPodInformer,
 					true,
 				)
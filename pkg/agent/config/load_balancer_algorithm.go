@@ -0,0 +1,51 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "strings"
+
+type LoadBalancerAlgorithm int
+
+const (
+	LoadBalancerAlgorithmRandom LoadBalancerAlgorithm = iota
+	LoadBalancerAlgorithmMaglev
+	LoadBalancerAlgorithmInvalid = -1
+)
+
+var (
+	loadBalancerAlgorithmStrs = [...]string{
+		"Random",
+		"Maglev",
+	}
+)
+
+// GetLoadBalancerAlgorithmFromStr returns true and LoadBalancerAlgorithm corresponding to input string.
+// Otherwise, false and undefined value is returned
+func GetLoadBalancerAlgorithmFromStr(str string) (bool, LoadBalancerAlgorithm) {
+	for idx, as := range loadBalancerAlgorithmStrs {
+		if strings.EqualFold(as, str) {
+			return true, LoadBalancerAlgorithm(idx)
+		}
+	}
+	return false, LoadBalancerAlgorithmInvalid
+}
+
+// String returns value in string.
+func (a LoadBalancerAlgorithm) String() string {
+	if a == LoadBalancerAlgorithmInvalid {
+		return "invalid"
+	}
+	return loadBalancerAlgorithmStrs[a]
+}
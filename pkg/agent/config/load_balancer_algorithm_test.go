@@ -0,0 +1,69 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLoadBalancerAlgorithmFromStr(t *testing.T) {
+	tests := []struct {
+		name              string
+		str               string
+		expectedOK        bool
+		expectedAlgorithm LoadBalancerAlgorithm
+	}{
+		{
+			name:              "lowercase random",
+			str:               "random",
+			expectedOK:        true,
+			expectedAlgorithm: LoadBalancerAlgorithmRandom,
+		},
+		{
+			name:              "lowercase maglev",
+			str:               "maglev",
+			expectedOK:        true,
+			expectedAlgorithm: LoadBalancerAlgorithmMaglev,
+		},
+		{
+			name:              "capitalized maglev",
+			str:               "Maglev",
+			expectedOK:        true,
+			expectedAlgorithm: LoadBalancerAlgorithmMaglev,
+		},
+		{
+			name:       "invalid",
+			str:        "roundrobin",
+			expectedOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOK, gotAlgorithm := GetLoadBalancerAlgorithmFromStr(tt.str)
+			assert.Equal(t, tt.expectedOK, gotOK)
+			if tt.expectedOK {
+				assert.Equal(t, tt.expectedAlgorithm, gotAlgorithm)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerAlgorithmString(t *testing.T) {
+	assert.Equal(t, "Random", LoadBalancerAlgorithmRandom.String())
+	assert.Equal(t, "Maglev", LoadBalancerAlgorithmMaglev.String())
+	assert.Equal(t, "invalid", LoadBalancerAlgorithm(LoadBalancerAlgorithmInvalid).String())
+}
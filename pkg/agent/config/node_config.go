@@ -17,6 +17,7 @@ package config
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 )
@@ -48,6 +49,24 @@ const (
 const (
 	L7NetworkPolicyTargetPortName = "antrea-l7-tap0"
 	L7NetworkPolicyReturnPortName = "antrea-l7-tap1"
+	// L7FlowExporterVLANID is the VLAN ID reserved to tag the packets mirrored to the L7 engine for L7 flow export, so
+	// that they are not mixed up with the packets redirected for L7 NetworkPolicy rules, whose VLAN IDs are allocated
+	// from 1.
+	L7FlowExporterVLANID = 4094
+	// L7EngineEventSocketPath is the path of the Unix socket to which the L7 engine writes HTTP and TLS events when L7
+	// flow export is enabled.
+	L7EngineEventSocketPath = "/var/run/antrea/l7engine-events.sock"
+)
+
+const (
+	// NodeNetworkPolicyIngressRulesChain is the iptables chain in filter table holding the ingress rules of
+	// NodeNetworkPolicy, ordered by priority.
+	NodeNetworkPolicyIngressRulesChain = "ANTREA-POL-INGRESS-RULES"
+	// NodeNetworkPolicyEgressRulesChain is the iptables chain in filter table holding the egress rules of
+	// NodeNetworkPolicy, ordered by priority.
+	NodeNetworkPolicyEgressRulesChain = "ANTREA-POL-EGRESS-RULES"
+	// NodeNetworkPolicyPrefix is the name prefix of the iptables chains and ipsets created for NodeNetworkPolicy.
+	NodeNetworkPolicyPrefix = "ANTREA-POL"
 )
 
 var (
@@ -126,6 +145,13 @@ type WireGuardConfig struct {
 	Port int
 	// The MTU of WireGuard interface.
 	MTU int
+	// NextKeyName is the name of the WireGuard interface which keeps the next key usable during key rotations. e.g. antrea-wg1.
+	NextKeyName string
+	// NextKeyRouteTable is the route table which sends the traffic to the peers via the next-key interface during key
+	// rotations.
+	NextKeyRouteTable int
+	// KeyRotationInterval is the interval at which the private key is rotated. Key rotation is disabled if it is 0.
+	KeyRotationInterval time.Duration
 }
 
 type EgressConfig struct {
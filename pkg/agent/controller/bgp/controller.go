@@ -0,0 +1,530 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/bgp"
+	"antrea.io/antrea/pkg/agent/bgp/gobgp"
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	crdinformersv1alpha1 "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha1"
+	crdinformersv1beta1 "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1beta1"
+	crdlistersv1alpha1 "antrea.io/antrea/pkg/client/listers/crd/v1alpha1"
+	crdlistersv1beta1 "antrea.io/antrea/pkg/client/listers/crd/v1beta1"
+)
+
+const (
+	controllerName = "BGPPolicyController"
+	// Set resyncPeriod to 0 to disable resyncing.
+	resyncPeriod = 0 * time.Second
+	// How long to wait before retrying the processing of a BGPPolicy change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+
+	// All the changes are processed by a full sync, which computes the BGP
+	// configuration of the Node from scratch.
+	workerItemKey = "key"
+
+	defaultBGPListenPort int32 = 179
+)
+
+var (
+	// newBGPServerFn is the function to create a BGP speaker, which can be
+	// overridden in tests.
+	newBGPServerFn = func(globalConfig *bgp.GlobalConfig) bgp.Interface {
+		return gobgp.NewGoBGPServer(globalConfig)
+	}
+)
+
+// bgpPolicyState is the BGP configuration applied to the Node.
+type bgpPolicyState struct {
+	// bgpPolicyName is the name of the BGPPolicy applied to the Node.
+	bgpPolicyName string
+	// The global configuration of the BGP speaker. The BGP speaker is
+	// restarted when any of them changes.
+	localASN   int32
+	routerID   string
+	listenPort int32
+	bgpServer  bgp.Interface
+	// peerConfigs are the BGP peers configured in the BGP speaker, keyed by
+	// the peer addresses.
+	peerConfigs map[string]bgp.PeerConfig
+	// routes are the routes advertised by the BGP speaker.
+	routes sets.Set[bgp.Route]
+}
+
+// Controller watches BGPPolicies and the resources whose IPs can be advertised,
+// and configures the BGP speaker of the Node accordingly.
+type Controller struct {
+	nodeName   string
+	nodeConfig *config.NodeConfig
+
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
+
+	bgpPolicyInformer     cache.SharedIndexInformer
+	bgpPolicyLister       crdlistersv1alpha1.BGPPolicyLister
+	bgpPolicyListerSynced cache.InformerSynced
+
+	serviceLister       corelisters.ServiceLister
+	serviceListerSynced cache.InformerSynced
+
+	endpointSliceLister       discoverylisters.EndpointSliceLister
+	endpointSliceListerSynced cache.InformerSynced
+
+	egressLister       crdlistersv1beta1.EgressLister
+	egressListerSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	// bgpPolicyState is only accessed by the single worker, so it doesn't
+	// need to be protected by a mutex.
+	bgpPolicyState *bgpPolicyState
+}
+
+func NewBGPPolicyController(
+	nodeConfig *config.NodeConfig,
+	nodeInformer coreinformers.NodeInformer,
+	bgpPolicyInformer crdinformersv1alpha1.BGPPolicyInformer,
+	serviceInformer coreinformers.ServiceInformer,
+	endpointSliceInformer discoveryinformers.EndpointSliceInformer,
+	egressInformer crdinformersv1beta1.EgressInformer,
+) *Controller {
+	c := &Controller{
+		nodeName:                  nodeConfig.Name,
+		nodeConfig:                nodeConfig,
+		nodeLister:                nodeInformer.Lister(),
+		nodeListerSynced:          nodeInformer.Informer().HasSynced,
+		bgpPolicyInformer:         bgpPolicyInformer.Informer(),
+		bgpPolicyLister:           bgpPolicyInformer.Lister(),
+		bgpPolicyListerSynced:     bgpPolicyInformer.Informer().HasSynced,
+		serviceLister:             serviceInformer.Lister(),
+		serviceListerSynced:       serviceInformer.Informer().HasSynced,
+		endpointSliceLister:       endpointSliceInformer.Lister(),
+		endpointSliceListerSynced: endpointSliceInformer.Informer().HasSynced,
+		egressLister:              egressInformer.Lister(),
+		egressListerSynced:        egressInformer.Informer().HasSynced,
+		queue:                     workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "bgpPolicy"),
+	}
+	enqueue := func(obj interface{}) {
+		c.queue.Add(workerItemKey)
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(old, cur interface{}) {
+			enqueue(cur)
+		},
+		DeleteFunc: enqueue,
+	}
+	nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueueNode,
+			UpdateFunc: func(old, cur interface{}) {
+				c.enqueueNode(cur)
+			},
+		},
+		resyncPeriod,
+	)
+	c.bgpPolicyInformer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	serviceInformer.Informer().AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	endpointSliceInformer.Informer().AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	egressInformer.Informer().AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	return c
+}
+
+// enqueueNode only handles the events of the local Node, whose labels decide
+// the BGPPolicy applied to it.
+func (c *Controller) enqueueNode(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok || node.Name != c.nodeName {
+		return
+	}
+	c.queue.Add(workerItemKey)
+}
+
+// Run will create a worker (go routine) which will process the changes from
+// the workqueue.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting controller", "controller", controllerName)
+	defer klog.InfoS("Shutting down controller", "controller", controllerName)
+
+	cacheSyncs := []cache.InformerSynced{c.nodeListerSynced, c.bgpPolicyListerSynced, c.serviceListerSynced, c.endpointSliceListerSynced, c.egressListerSynced}
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, cacheSyncs...) {
+		return
+	}
+
+	go wait.Until(c.worker, time.Second, stopCh)
+	<-stopCh
+
+	c.stopBGPServer()
+}
+
+// worker is a long-running function that will continually call the
+// processNextWorkItem function in order to read and process a message on the
+// workqueue.
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if err := c.syncBGPPolicy(); err == nil {
+		// If no error occurs we Forget this item so it does not get queued again until
+		// another change happens.
+		c.queue.Forget(obj)
+	} else {
+		// Put the item back on the workqueue to handle any transient errors.
+		c.queue.AddRateLimited(obj)
+		klog.ErrorS(err, "Error syncing BGPPolicy")
+	}
+	return true
+}
+
+func (c *Controller) stopBGPServer() {
+	if c.bgpPolicyState == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := c.bgpPolicyState.bgpServer.Stop(ctx); err != nil {
+		klog.ErrorS(err, "Failed to stop BGP server", "bgpPolicy", c.bgpPolicyState.bgpPolicyName)
+	}
+	c.bgpPolicyState = nil
+}
+
+func (c *Controller) syncBGPPolicy() error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).InfoS("Finished syncing BGPPolicy", "durationTime", time.Since(startTime))
+	}()
+
+	node, err := c.nodeLister.Get(c.nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get Node %s: %w", c.nodeName, err)
+	}
+	bgpPolicy, err := c.getEffectiveBGPPolicy(node)
+	if err != nil {
+		return err
+	}
+	if bgpPolicy == nil {
+		if c.bgpPolicyState != nil {
+			klog.InfoS("No BGPPolicy applies to the Node, stopping BGP server", "bgpPolicy", c.bgpPolicyState.bgpPolicyName)
+			c.stopBGPServer()
+		}
+		return nil
+	}
+
+	routerID, err := c.getRouterID(node)
+	if err != nil {
+		return err
+	}
+	listenPort := defaultBGPListenPort
+	if bgpPolicy.Spec.ListenPort != nil {
+		listenPort = *bgpPolicy.Spec.ListenPort
+	}
+
+	ctx := context.Background()
+	state := c.bgpPolicyState
+	if state == nil || state.bgpPolicyName != bgpPolicy.Name || state.localASN != bgpPolicy.Spec.LocalASN ||
+		state.routerID != routerID || state.listenPort != listenPort {
+		// The BGP speaker must be restarted to apply the new global
+		// configuration.
+		c.stopBGPServer()
+		bgpServer := newBGPServerFn(&bgp.GlobalConfig{
+			ASN:        uint32(bgpPolicy.Spec.LocalASN),
+			RouterID:   routerID,
+			ListenPort: listenPort,
+		})
+		if err := bgpServer.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start BGP server: %w", err)
+		}
+		klog.InfoS("Started BGP server", "bgpPolicy", bgpPolicy.Name, "localASN", bgpPolicy.Spec.LocalASN, "routerID", routerID, "listenPort", listenPort)
+		state = &bgpPolicyState{
+			bgpPolicyName: bgpPolicy.Name,
+			localASN:      bgpPolicy.Spec.LocalASN,
+			routerID:      routerID,
+			listenPort:    listenPort,
+			bgpServer:     bgpServer,
+			peerConfigs:   map[string]bgp.PeerConfig{},
+			routes:        sets.New[bgp.Route](),
+		}
+		c.bgpPolicyState = state
+	}
+
+	if err := c.reconcileBGPPeers(ctx, bgpPolicy); err != nil {
+		return err
+	}
+	routes, err := c.getRoutes(bgpPolicy)
+	if err != nil {
+		return err
+	}
+	return c.reconcileRoutes(ctx, routes)
+}
+
+// getEffectiveBGPPolicy returns the oldest BGPPolicy selecting the Node, or nil
+// if none selects it.
+func (c *Controller) getEffectiveBGPPolicy(node *corev1.Node) (*v1alpha1.BGPPolicy, error) {
+	bgpPolicies, err := c.bgpPolicyLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var effective *v1alpha1.BGPPolicy
+	for _, bgpPolicy := range bgpPolicies {
+		selector, err := metav1.LabelSelectorAsSelector(&bgpPolicy.Spec.NodeSelector)
+		if err != nil {
+			klog.ErrorS(err, "Invalid nodeSelector in BGPPolicy", "bgpPolicy", bgpPolicy.Name)
+			continue
+		}
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if effective == nil || bgpPolicy.CreationTimestamp.Before(&effective.CreationTimestamp) ||
+			(bgpPolicy.CreationTimestamp.Equal(&effective.CreationTimestamp) && bgpPolicy.Name < effective.Name) {
+			effective = bgpPolicy
+		}
+	}
+	return effective, nil
+}
+
+// getRouterID returns the BGP router ID of the Node. It's the IPv4 address in
+// the Node annotation "node.antrea.io/bgp-router-id" if present, otherwise the
+// IPv4 address of the Node.
+func (c *Controller) getRouterID(node *corev1.Node) (string, error) {
+	if routerID, ok := node.Annotations[types.NodeBGPRouterIDAnnotationKey]; ok {
+		ip := net.ParseIP(routerID)
+		if ip == nil || ip.To4() == nil {
+			return "", fmt.Errorf("invalid BGP router ID %q in the annotation of Node %s, it must be an IPv4 address", routerID, node.Name)
+		}
+		return ip.String(), nil
+	}
+	if c.nodeConfig.NodeIPv4Addr == nil {
+		return "", fmt.Errorf("the Node %s has no IPv4 address, the BGP router ID must be set with annotation %s", node.Name, types.NodeBGPRouterIDAnnotationKey)
+	}
+	return c.nodeConfig.NodeIPv4Addr.IP.String(), nil
+}
+
+func (c *Controller) reconcileBGPPeers(ctx context.Context, bgpPolicy *v1alpha1.BGPPolicy) error {
+	state := c.bgpPolicyState
+	desiredPeerConfigs := make(map[string]bgp.PeerConfig, len(bgpPolicy.Spec.BGPPeers))
+	for _, peer := range bgpPolicy.Spec.BGPPeers {
+		port := defaultBGPListenPort
+		if peer.Port != nil {
+			port = *peer.Port
+		}
+		desiredPeerConfigs[peer.Address] = bgp.PeerConfig{
+			Address:     peer.Address,
+			Port:        port,
+			ASN:         peer.ASN,
+			MultihopTTL: peer.MultihopTTL,
+		}
+	}
+	for address, peerConfig := range state.peerConfigs {
+		if _, exists := desiredPeerConfigs[address]; !exists {
+			if err := state.bgpServer.RemovePeer(ctx, peerConfig); err != nil {
+				return fmt.Errorf("failed to remove BGP peer %s: %w", address, err)
+			}
+			delete(state.peerConfigs, address)
+		}
+	}
+	for address, desiredPeerConfig := range desiredPeerConfigs {
+		peerConfig, exists := state.peerConfigs[address]
+		if !exists {
+			if err := state.bgpServer.AddPeer(ctx, desiredPeerConfig); err != nil {
+				return fmt.Errorf("failed to add BGP peer %s: %w", address, err)
+			}
+		} else if !reflect.DeepEqual(peerConfig, desiredPeerConfig) {
+			if err := state.bgpServer.UpdatePeer(ctx, desiredPeerConfig); err != nil {
+				return fmt.Errorf("failed to update BGP peer %s: %w", address, err)
+			}
+		} else {
+			continue
+		}
+		state.peerConfigs[address] = desiredPeerConfig
+	}
+	return nil
+}
+
+func (c *Controller) reconcileRoutes(ctx context.Context, routes sets.Set[bgp.Route]) error {
+	state := c.bgpPolicyState
+	if staleRoutes := state.routes.Difference(routes); staleRoutes.Len() > 0 {
+		if err := state.bgpServer.WithdrawRoutes(ctx, sortedRoutes(staleRoutes)); err != nil {
+			return fmt.Errorf("failed to withdraw routes: %w", err)
+		}
+		state.routes = state.routes.Difference(staleRoutes)
+	}
+	if newRoutes := routes.Difference(state.routes); newRoutes.Len() > 0 {
+		if err := state.bgpServer.AdvertiseRoutes(ctx, sortedRoutes(newRoutes)); err != nil {
+			return fmt.Errorf("failed to advertise routes: %w", err)
+		}
+		state.routes = state.routes.Union(newRoutes)
+	}
+	return nil
+}
+
+func sortedRoutes(routes sets.Set[bgp.Route]) []bgp.Route {
+	list := routes.UnsortedList()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Prefix < list[j].Prefix
+	})
+	return list
+}
+
+// getRoutes returns the routes to advertise according to the BGPPolicy.
+func (c *Controller) getRoutes(bgpPolicy *v1alpha1.BGPPolicy) (sets.Set[bgp.Route], error) {
+	routes := sets.New[bgp.Route]()
+	advertisements := bgpPolicy.Spec.Advertisements
+	if advertisements.Pod != nil {
+		for _, podCIDR := range []*net.IPNet{c.nodeConfig.PodIPv4CIDR, c.nodeConfig.PodIPv6CIDR} {
+			if podCIDR != nil {
+				routes.Insert(bgp.Route{Prefix: podCIDR.String()})
+			}
+		}
+	}
+	if advertisements.Service != nil && len(advertisements.Service.IPTypes) > 0 {
+		serviceRoutes, err := c.getServiceRoutes(advertisements.Service.IPTypes)
+		if err != nil {
+			return nil, err
+		}
+		routes = routes.Union(serviceRoutes)
+	}
+	if advertisements.Egress != nil {
+		egressRoutes, err := c.getEgressRoutes()
+		if err != nil {
+			return nil, err
+		}
+		routes = routes.Union(egressRoutes)
+	}
+	return routes, nil
+}
+
+func ipToRoute(ipStr string) (bgp.Route, bool) {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return bgp.Route{}, false
+	}
+	if ip.To4() != nil {
+		return bgp.Route{Prefix: ip.String() + "/32"}, true
+	}
+	return bgp.Route{Prefix: ip.String() + "/128"}, true
+}
+
+func (c *Controller) getServiceRoutes(ipTypes []v1alpha1.ServiceIPType) (sets.Set[bgp.Route], error) {
+	routes := sets.New[bgp.Route]()
+	ipTypeSet := sets.New[v1alpha1.ServiceIPType](ipTypes...)
+	services, err := c.serviceLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	addRoutes := func(ips ...string) {
+		for _, ip := range ips {
+			if route, ok := ipToRoute(ip); ok {
+				routes.Insert(route)
+			}
+		}
+	}
+	for _, service := range services {
+		if ipTypeSet.Has(v1alpha1.ServiceIPTypeClusterIP) && service.Spec.Type != corev1.ServiceTypeExternalName {
+			internalLocal := service.Spec.InternalTrafficPolicy != nil && *service.Spec.InternalTrafficPolicy == corev1.ServiceInternalTrafficPolicyLocal
+			if !internalLocal || c.hasLocalEndpoints(service) {
+				addRoutes(service.Spec.ClusterIPs...)
+			}
+		}
+		externalLocal := service.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal
+		if externalLocal && !c.hasLocalEndpoints(service) {
+			continue
+		}
+		if ipTypeSet.Has(v1alpha1.ServiceIPTypeExternalIP) {
+			addRoutes(service.Spec.ExternalIPs...)
+		}
+		// Only the LoadBalancer IPs allocated from ExternalIPPools are
+		// advertised, the other ones may not be routable to the Nodes.
+		if ipTypeSet.Has(v1alpha1.ServiceIPTypeLoadBalancerIP) && service.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+			service.Annotations[types.ServiceExternalIPPoolAnnotationKey] != "" {
+			for _, ingress := range service.Status.LoadBalancer.Ingress {
+				addRoutes(ingress.IP)
+			}
+		}
+	}
+	return routes, nil
+}
+
+// hasLocalEndpoints returns whether the Service has ready endpoints running on
+// the Node.
+func (c *Controller) hasLocalEndpoints(service *corev1.Service) bool {
+	selector := labels.Set{discovery.LabelServiceName: service.Name}.AsSelector()
+	endpointSlices, err := c.endpointSliceLister.EndpointSlices(service.Namespace).List(selector)
+	if err != nil {
+		return false
+	}
+	for _, endpointSlice := range endpointSlices {
+		for _, endpoint := range endpointSlice.Endpoints {
+			if endpoint.NodeName == nil || *endpoint.NodeName != c.nodeName {
+				continue
+			}
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getEgressRoutes returns the routes of the Egress IPs assigned to the Node.
+func (c *Controller) getEgressRoutes() (sets.Set[bgp.Route], error) {
+	routes := sets.New[bgp.Route]()
+	egresses, err := c.egressLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, egress := range egresses {
+		if egress.Status.EgressNode != c.nodeName {
+			continue
+		}
+		if route, ok := ipToRoute(egress.Status.EgressIP); ok {
+			routes.Insert(route)
+		}
+	}
+	return routes, nil
+}
@@ -0,0 +1,357 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	"antrea.io/antrea/pkg/agent/bgp"
+	bgptest "antrea.io/antrea/pkg/agent/bgp/testing"
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/apis/crd/v1beta1"
+	fakeversioned "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
+)
+
+const (
+	localNodeName = "node1"
+)
+
+var (
+	podIPv4CIDR = mustParseCIDR("10.10.0.0/24")
+	podIPv6CIDR = mustParseCIDR("fd00:10:10::/64")
+	nodeIPv4    = mustParseCIDR("192.168.77.100/24")
+
+	localNode = &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   localNodeName,
+			Labels: map[string]string{"bgp": "enabled"},
+		},
+	}
+)
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	ip, ipNet, _ := net.ParseCIDR(cidr)
+	ipNet.IP = ip
+	return ipNet
+}
+
+func generateBGPPolicy(name string, creationTime time.Time, localASN int32, advertisements v1alpha1.Advertisements, peers ...v1alpha1.BGPPeer) *v1alpha1.BGPPolicy {
+	return &v1alpha1.BGPPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(creationTime),
+		},
+		Spec: v1alpha1.BGPPolicySpec{
+			NodeSelector:   metav1.LabelSelector{MatchLabels: map[string]string{"bgp": "enabled"}},
+			LocalASN:       localASN,
+			Advertisements: advertisements,
+			BGPPeers:       peers,
+		},
+	}
+}
+
+func generateService(name string, svcType corev1.ServiceType, clusterIP, externalIP, lbIP string, local bool) *corev1.Service {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Annotations: map[string]string{types.ServiceExternalIPPoolAnnotationKey: "pool1"},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:       svcType,
+			ClusterIP:  clusterIP,
+			ClusterIPs: []string{clusterIP},
+		},
+	}
+	if externalIP != "" {
+		service.Spec.ExternalIPs = []string{externalIP}
+	}
+	if lbIP != "" {
+		service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: lbIP}}
+	}
+	if local {
+		service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+		internalTrafficPolicy := corev1.ServiceInternalTrafficPolicyLocal
+		service.Spec.InternalTrafficPolicy = &internalTrafficPolicy
+	}
+	return service
+}
+
+func generateEndpointSlice(serviceName, nodeName string) *discovery.EndpointSlice {
+	return &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      serviceName + "-abcde",
+			Labels:    map[string]string{discovery.LabelServiceName: serviceName},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses:  []string{"10.10.0.5"},
+				Conditions: discovery.EndpointConditions{Ready: pointer.Bool(true)},
+				NodeName:   &nodeName,
+			},
+		},
+	}
+}
+
+func generateEgress(name, egressIP, egressNode string) *v1beta1.Egress {
+	return &v1beta1.Egress{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1beta1.EgressSpec{EgressIP: egressIP},
+		Status:     v1beta1.EgressStatus{EgressIP: egressIP, EgressNode: egressNode},
+	}
+}
+
+func routes(prefixes ...string) []bgp.Route {
+	var routes []bgp.Route
+	for _, prefix := range prefixes {
+		routes = append(routes, bgp.Route{Prefix: prefix})
+	}
+	return routes
+}
+
+type fakeController struct {
+	*Controller
+	mockController *gomock.Controller
+	nodeStore      cache.Store
+	bgpPolicyStore cache.Store
+	serviceStore   cache.Store
+	sliceStore     cache.Store
+	egressStore    cache.Store
+	// servers are the mock BGP servers, in the order they are created.
+	servers []*bgptest.MockInterface
+	// globalConfigs are the global configurations of the BGP servers.
+	globalConfigs []bgp.GlobalConfig
+}
+
+func newFakeController(t *testing.T, nodeConfig *config.NodeConfig, expectedServers int) *fakeController {
+	mockController := gomock.NewController(t)
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(fakeversioned.NewSimpleClientset(), 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	bgpPolicyInformer := crdInformerFactory.Crd().V1alpha1().BGPPolicies()
+	serviceInformer := informerFactory.Core().V1().Services()
+	endpointSliceInformer := informerFactory.Discovery().V1().EndpointSlices()
+	egressInformer := crdInformerFactory.Crd().V1beta1().Egresses()
+
+	c := &fakeController{
+		Controller:     NewBGPPolicyController(nodeConfig, nodeInformer, bgpPolicyInformer, serviceInformer, endpointSliceInformer, egressInformer),
+		mockController: mockController,
+		nodeStore:      nodeInformer.Informer().GetStore(),
+		bgpPolicyStore: bgpPolicyInformer.Informer().GetStore(),
+		serviceStore:   serviceInformer.Informer().GetStore(),
+		sliceStore:     endpointSliceInformer.Informer().GetStore(),
+		egressStore:    egressInformer.Informer().GetStore(),
+	}
+	for i := 0; i < expectedServers; i++ {
+		c.servers = append(c.servers, bgptest.NewMockInterface(mockController))
+	}
+	originalNewBGPServerFn := newBGPServerFn
+	newBGPServerFn = func(globalConfig *bgp.GlobalConfig) bgp.Interface {
+		require.Less(t, len(c.globalConfigs), len(c.servers), "Unexpected BGP server creation")
+		server := c.servers[len(c.globalConfigs)]
+		c.globalConfigs = append(c.globalConfigs, *globalConfig)
+		return server
+	}
+	t.Cleanup(func() { newBGPServerFn = originalNewBGPServerFn })
+	return c
+}
+
+func defaultNodeConfig() *config.NodeConfig {
+	return &config.NodeConfig{
+		Name:         localNodeName,
+		PodIPv4CIDR:  podIPv4CIDR,
+		PodIPv6CIDR:  podIPv6CIDR,
+		NodeIPv4Addr: nodeIPv4,
+	}
+}
+
+func TestSyncBGPPolicy(t *testing.T) {
+	c := newFakeController(t, defaultNodeConfig(), 1)
+	server := c.servers[0]
+	now := time.Now()
+	peer1 := v1alpha1.BGPPeer{Address: "192.168.77.1", ASN: 65001}
+	peer2 := v1alpha1.BGPPeer{Address: "192.168.77.2", ASN: 65002, Port: pointer.Int32(1179), MultihopTTL: pointer.Int32(2)}
+	policy := generateBGPPolicy("policy1", now, 65000, v1alpha1.Advertisements{
+		Pod:     &v1alpha1.PodAdvertisement{},
+		Service: &v1alpha1.ServiceAdvertisement{IPTypes: []v1alpha1.ServiceIPType{v1alpha1.ServiceIPTypeClusterIP, v1alpha1.ServiceIPTypeLoadBalancerIP}},
+		Egress:  &v1alpha1.EgressAdvertisement{},
+	}, peer1, peer2)
+	require.NoError(t, c.nodeStore.Add(localNode))
+	require.NoError(t, c.bgpPolicyStore.Add(policy))
+	require.NoError(t, c.serviceStore.Add(generateService("svc1", corev1.ServiceTypeLoadBalancer, "10.96.0.10", "", "172.18.0.10", false)))
+	require.NoError(t, c.egressStore.Add(generateEgress("egress1", "172.18.1.1", localNodeName)))
+	require.NoError(t, c.egressStore.Add(generateEgress("egress2", "172.18.1.2", "node2")))
+
+	server.EXPECT().Start(gomock.Any())
+	server.EXPECT().AddPeer(gomock.Any(), bgp.PeerConfig{Address: "192.168.77.1", Port: 179, ASN: 65001})
+	server.EXPECT().AddPeer(gomock.Any(), bgp.PeerConfig{Address: "192.168.77.2", Port: 1179, ASN: 65002, MultihopTTL: pointer.Int32(2)})
+	server.EXPECT().AdvertiseRoutes(gomock.Any(), routes("10.10.0.0/24", "10.96.0.10/32", "172.18.0.10/32", "172.18.1.1/32", "fd00:10:10::/64"))
+	require.NoError(t, c.syncBGPPolicy())
+	assert.Equal(t, []bgp.GlobalConfig{{ASN: 65000, RouterID: "192.168.77.100", ListenPort: 179}}, c.globalConfigs)
+
+	// Update a peer, remove a peer, and move an Egress IP to another Node.
+	updatedPolicy := policy.DeepCopy()
+	updatedPolicy.Spec.BGPPeers = []v1alpha1.BGPPeer{{Address: "192.168.77.1", ASN: 65003}}
+	require.NoError(t, c.bgpPolicyStore.Update(updatedPolicy))
+	require.NoError(t, c.egressStore.Update(generateEgress("egress1", "172.18.1.1", "node2")))
+	require.NoError(t, c.egressStore.Update(generateEgress("egress2", "172.18.1.2", localNodeName)))
+	server.EXPECT().RemovePeer(gomock.Any(), bgp.PeerConfig{Address: "192.168.77.2", Port: 1179, ASN: 65002, MultihopTTL: pointer.Int32(2)})
+	server.EXPECT().UpdatePeer(gomock.Any(), bgp.PeerConfig{Address: "192.168.77.1", Port: 179, ASN: 65003})
+	gomock.InOrder(
+		server.EXPECT().WithdrawRoutes(gomock.Any(), routes("172.18.1.1/32")),
+		server.EXPECT().AdvertiseRoutes(gomock.Any(), routes("172.18.1.2/32")),
+	)
+	require.NoError(t, c.syncBGPPolicy())
+
+	// Nothing changes.
+	require.NoError(t, c.syncBGPPolicy())
+
+	// The Node is no longer selected.
+	require.NoError(t, c.nodeStore.Update(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: localNodeName}}))
+	server.EXPECT().Stop(gomock.Any())
+	require.NoError(t, c.syncBGPPolicy())
+	assert.Nil(t, c.bgpPolicyState)
+}
+
+func TestSyncBGPPolicyGlobalConfigChange(t *testing.T) {
+	c := newFakeController(t, defaultNodeConfig(), 3)
+	now := time.Now()
+	peer := v1alpha1.BGPPeer{Address: "192.168.77.1", ASN: 65001}
+	policy1 := generateBGPPolicy("policy1", now, 65000, v1alpha1.Advertisements{Pod: &v1alpha1.PodAdvertisement{}}, peer)
+	policy2 := generateBGPPolicy("policy2", now.Add(-time.Minute), 65010, v1alpha1.Advertisements{Pod: &v1alpha1.PodAdvertisement{}}, peer)
+	require.NoError(t, c.nodeStore.Add(localNode))
+	require.NoError(t, c.bgpPolicyStore.Add(policy1))
+
+	expectServer := func(server *bgptest.MockInterface) {
+		server.EXPECT().Start(gomock.Any())
+		server.EXPECT().AddPeer(gomock.Any(), bgp.PeerConfig{Address: "192.168.77.1", Port: 179, ASN: 65001})
+		server.EXPECT().AdvertiseRoutes(gomock.Any(), routes("10.10.0.0/24", "fd00:10:10::/64"))
+	}
+	expectServer(c.servers[0])
+	require.NoError(t, c.syncBGPPolicy())
+
+	// An older BGPPolicy takes precedence.
+	require.NoError(t, c.bgpPolicyStore.Add(policy2))
+	c.servers[0].EXPECT().Stop(gomock.Any())
+	expectServer(c.servers[1])
+	require.NoError(t, c.syncBGPPolicy())
+
+	// The router ID is changed by the Node annotation.
+	node := localNode.DeepCopy()
+	node.Annotations = map[string]string{types.NodeBGPRouterIDAnnotationKey: "10.0.0.1"}
+	require.NoError(t, c.nodeStore.Update(node))
+	c.servers[1].EXPECT().Stop(gomock.Any())
+	expectServer(c.servers[2])
+	require.NoError(t, c.syncBGPPolicy())
+
+	assert.Equal(t, []bgp.GlobalConfig{
+		{ASN: 65000, RouterID: "192.168.77.100", ListenPort: 179},
+		{ASN: 65010, RouterID: "192.168.77.100", ListenPort: 179},
+		{ASN: 65010, RouterID: "10.0.0.1", ListenPort: 179},
+	}, c.globalConfigs)
+}
+
+func TestSyncBGPPolicyRouterID(t *testing.T) {
+	nodeConfig := defaultNodeConfig()
+	nodeConfig.NodeIPv4Addr = nil
+	c := newFakeController(t, nodeConfig, 0)
+	require.NoError(t, c.bgpPolicyStore.Add(generateBGPPolicy("policy1", time.Now(), 65000, v1alpha1.Advertisements{})))
+
+	require.NoError(t, c.nodeStore.Add(localNode))
+	assert.ErrorContains(t, c.syncBGPPolicy(), "the BGP router ID must be set with annotation")
+
+	node := localNode.DeepCopy()
+	node.Annotations = map[string]string{types.NodeBGPRouterIDAnnotationKey: "fd00::1"}
+	require.NoError(t, c.nodeStore.Update(node))
+	assert.ErrorContains(t, c.syncBGPPolicy(), "invalid BGP router ID")
+}
+
+func TestGetServiceRoutes(t *testing.T) {
+	allIPTypes := []v1alpha1.ServiceIPType{v1alpha1.ServiceIPTypeClusterIP, v1alpha1.ServiceIPTypeExternalIP, v1alpha1.ServiceIPTypeLoadBalancerIP}
+	noPoolService := generateService("svc4", corev1.ServiceTypeLoadBalancer, "10.96.0.13", "", "172.18.0.13", false)
+	noPoolService.Annotations = nil
+	tests := []struct {
+		name           string
+		ipTypes        []v1alpha1.ServiceIPType
+		services       []*corev1.Service
+		endpointSlices []*discovery.EndpointSlice
+		expectedRoutes []bgp.Route
+	}{
+		{
+			name:    "all IP types",
+			ipTypes: allIPTypes,
+			services: []*corev1.Service{
+				generateService("svc1", corev1.ServiceTypeLoadBalancer, "10.96.0.10", "172.17.0.10", "172.18.0.10", false),
+				generateService("svc2", corev1.ServiceTypeClusterIP, "fd00:10:96::a", "", "", false),
+			},
+			expectedRoutes: routes("10.96.0.10/32", "172.17.0.10/32", "172.18.0.10/32", "fd00:10:96::a/128"),
+		},
+		{
+			name:    "LoadBalancerIP only",
+			ipTypes: []v1alpha1.ServiceIPType{v1alpha1.ServiceIPTypeLoadBalancerIP},
+			services: []*corev1.Service{
+				generateService("svc1", corev1.ServiceTypeLoadBalancer, "10.96.0.10", "172.17.0.10", "172.18.0.10", false),
+				noPoolService,
+			},
+			expectedRoutes: routes("172.18.0.10/32"),
+		},
+		{
+			name:    "local traffic policy",
+			ipTypes: allIPTypes,
+			services: []*corev1.Service{
+				generateService("svc1", corev1.ServiceTypeLoadBalancer, "10.96.0.10", "", "172.18.0.10", true),
+				generateService("svc2", corev1.ServiceTypeLoadBalancer, "10.96.0.11", "", "172.18.0.11", true),
+				generateService("svc3", corev1.ServiceTypeLoadBalancer, "10.96.0.12", "", "172.18.0.12", true),
+			},
+			endpointSlices: []*discovery.EndpointSlice{
+				generateEndpointSlice("svc1", localNodeName),
+				generateEndpointSlice("svc2", "node2"),
+			},
+			expectedRoutes: routes("10.96.0.10/32", "172.18.0.10/32"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, defaultNodeConfig(), 0)
+			for _, service := range tt.services {
+				require.NoError(t, c.serviceStore.Add(service))
+			}
+			for _, endpointSlice := range tt.endpointSlices {
+				require.NoError(t, c.sliceStore.Add(endpointSlice))
+			}
+			routes, err := c.getServiceRoutes(tt.ipTypes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRoutes, sortedRoutes(routes))
+		})
+	}
+}
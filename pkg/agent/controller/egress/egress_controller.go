@@ -69,6 +69,11 @@ const (
 	minEgressMark = 1
 	// maxEgressMark is the maximum mark of Egress IPs can be configured on a Node.
 	maxEgressMark = 255
+	// minEgressTableID is the minimum ID of the route tables installed for the subnets of Egress IPs.
+	minEgressTableID = 101
+	// maxEgressTableID is the maximum ID of the route tables installed for the subnets of Egress IPs. There can't be
+	// more subnets than Egress IPs on a Node.
+	maxEgressTableID = minEgressTableID + maxEgressMark - minEgressMark
 
 	egressIPIndex = "egressIP"
 
@@ -124,6 +129,19 @@ type egressIPState struct {
 	flowsInstalled bool
 	// Whether its iptables rule has been installed.
 	ruleInstalled bool
+	// The route table that the traffic of this Egress IP is routed with. nil if the Egress IP has no subnet info or
+	// it's not a local IP.
+	routeTable *egressRouteTable
+}
+
+// egressRouteTable keeps the actual state of a route table installed for the subnet of local Egress IPs. The route
+// table routes traffic to the gateway of the subnet, and the traffic of each Egress IP is directed to it by an IP rule
+// matching the mark of the Egress IP.
+type egressRouteTable struct {
+	tableID    uint32
+	subnetInfo crdv1b1.SubnetInfo
+	// The marks of the Egress IPs whose traffic is routed with this table.
+	marks sets.Set[uint32]
 }
 
 // egressBinding keeps the Egresses applying to a Pod.
@@ -144,6 +162,9 @@ type EgressController struct {
 	egressListerSynced cache.InformerSynced
 	queue              workqueue.RateLimitingInterface
 
+	externalIPPoolLister       crdlisters.ExternalIPPoolLister
+	externalIPPoolListerSynced cache.InformerSynced
+
 	// Use an interface for IP detector to enable testing.
 	localIPDetector ipassigner.LocalIPDetector
 	ifaceStore      interfacestore.InterfaceStore
@@ -164,6 +185,11 @@ type EgressController struct {
 	egressIPStates      map[string]*egressIPState
 	egressIPStatesMutex sync.Mutex
 
+	// egressRouteTables stores the route tables installed for the subnets of local Egress IPs, keyed by the subnet
+	// info. It's protected by egressIPStatesMutex.
+	egressRouteTables map[crdv1b1.SubnetInfo]*egressRouteTable
+	tableIDAllocator  *idAllocator
+
 	cluster    memberlist.Interface
 	ipAssigner ipassigner.IPAssigner
 
@@ -187,6 +213,7 @@ func NewEgressController(
 	nodeTransportInterface string,
 	cluster memberlist.Interface,
 	egressInformer crdinformers.EgressInformer,
+	externalIPPoolInformer crdinformers.ExternalIPPoolInformer,
 	nodeInformers coreinformers.NodeInformer,
 	podUpdateSubscriber channel.Subscriber,
 	serviceCIDRInterface servicecidr.Interface,
@@ -197,24 +224,28 @@ func NewEgressController(
 		klog.Info("EgressTrafficShaping feature gate is enabled, but it is ignored because OVS meters are not supported.")
 	}
 	c := &EgressController{
-		ofClient:             ofClient,
-		routeClient:          routeClient,
-		antreaClientProvider: antreaClientGetter,
-		crdClient:            crdClient,
-		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "egressgroup"),
-		egressInformer:       egressInformer.Informer(),
-		egressLister:         egressInformer.Lister(),
-		egressListerSynced:   egressInformer.Informer().HasSynced,
-		nodeName:             nodeName,
-		ifaceStore:           ifaceStore,
-		egressGroups:         map[string]sets.Set[string]{},
-		egressStates:         map[string]*egressState{},
-		egressIPStates:       map[string]*egressIPState{},
-		egressBindings:       map[string]*egressBinding{},
-		localIPDetector:      ipassigner.NewLocalIPDetector(),
-		idAllocator:          newIDAllocator(minEgressMark, maxEgressMark),
-		cluster:              cluster,
-		serviceCIDRInterface: serviceCIDRInterface,
+		ofClient:                   ofClient,
+		routeClient:                routeClient,
+		antreaClientProvider:       antreaClientGetter,
+		crdClient:                  crdClient,
+		queue:                      workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "egressgroup"),
+		egressInformer:             egressInformer.Informer(),
+		egressLister:               egressInformer.Lister(),
+		egressListerSynced:         egressInformer.Informer().HasSynced,
+		externalIPPoolLister:       externalIPPoolInformer.Lister(),
+		externalIPPoolListerSynced: externalIPPoolInformer.Informer().HasSynced,
+		nodeName:                   nodeName,
+		ifaceStore:                 ifaceStore,
+		egressGroups:               map[string]sets.Set[string]{},
+		egressStates:               map[string]*egressState{},
+		egressIPStates:             map[string]*egressIPState{},
+		egressBindings:             map[string]*egressBinding{},
+		egressRouteTables:          map[crdv1b1.SubnetInfo]*egressRouteTable{},
+		localIPDetector:            ipassigner.NewLocalIPDetector(),
+		idAllocator:                newIDAllocator(minEgressMark, maxEgressMark),
+		tableIDAllocator:           newIDAllocator(minEgressTableID, maxEgressTableID),
+		cluster:                    cluster,
+		serviceCIDRInterface:       serviceCIDRInterface,
 		// One buffer is enough as we just use it to ensure the target handler is executed once.
 		serviceCIDRUpdateCh:         make(chan struct{}, 1),
 		serviceCIDRUpdateRetryDelay: 10 * time.Second,
@@ -257,15 +288,37 @@ func NewEgressController(
 		},
 		resyncPeriod,
 	)
+	externalIPPoolInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: c.updateExternalIPPool,
+		},
+		resyncPeriod,
+	)
 	// Subscribe Pod update events from CNIServer to enforce Egress earlier, instead of waiting for their IPs are
 	// reported to kube-apiserver and processed by antrea-controller.
 	podUpdateSubscriber.Subscribe(c.processPodUpdate)
 	c.localIPDetector.AddEventHandler(c.onLocalIPUpdate)
 	c.egressIPScheduler.AddEventHandler(c.onEgressIPSchedule)
 	c.serviceCIDRInterface.AddEventHandler(c.onServiceCIDRUpdate)
+	c.cluster.AddClusterEventHandler(c.onClusterEvent)
 	return c, nil
 }
 
+// onClusterEvent will be called when the consistent hash ring of an ExternalIPPool is updated. If the ExternalIPPool
+// has health checks, the Egresses using it are enqueued to update their UplinkHealthy conditions, as the health state
+// of a Node may change without moving any Egress IP.
+func (c *EgressController) onClusterEvent(poolName string) {
+	if _, ok := c.cluster.UnhealthyNodes(poolName); !ok {
+		return
+	}
+	egresses, _ := c.egressLister.List(labels.Everything())
+	for _, egress := range egresses {
+		if egress.Spec.ExternalIPPool == poolName {
+			c.queue.Add(egress.Name)
+		}
+	}
+}
+
 // onEgressIPSchedule will be called when EgressIPScheduler reschedules an Egress's IP.
 func (c *EgressController) onEgressIPSchedule(egress string) {
 	c.queue.Add(egress)
@@ -364,6 +417,36 @@ func (c *EgressController) deleteEgress(obj interface{}) {
 	klog.V(2).InfoS("Processed Egress DELETE event", "egress", klog.KObj(egress))
 }
 
+// updateExternalIPPool processes ExternalIPPool UPDATE events. The Egresses using the ExternalIPPool are enqueued when
+// its SubnetInfo changes, as their IPs need to be assigned and routed according to the new SubnetInfo.
+func (c *EgressController) updateExternalIPPool(old, cur interface{}) {
+	oldPool := old.(*crdv1b1.ExternalIPPool)
+	curPool := cur.(*crdv1b1.ExternalIPPool)
+	if reflect.DeepEqual(oldPool.Spec.SubnetInfo, curPool.Spec.SubnetInfo) {
+		return
+	}
+	egresses, _ := c.egressLister.List(labels.Everything())
+	for _, egress := range egresses {
+		if egress.Spec.ExternalIPPool == curPool.Name {
+			c.queue.Add(egress.Name)
+		}
+	}
+	klog.V(2).InfoS("Processed ExternalIPPool UPDATE event", "externalIPPool", klog.KObj(curPool))
+}
+
+// getEgressSubnetInfo returns the SubnetInfo of the ExternalIPPool the Egress IP is allocated from. It returns nil if
+// the Egress doesn't use any ExternalIPPool or the ExternalIPPool has no SubnetInfo.
+func (c *EgressController) getEgressSubnetInfo(egress *crdv1b1.Egress) *crdv1b1.SubnetInfo {
+	if egress.Spec.ExternalIPPool == "" {
+		return nil
+	}
+	pool, err := c.externalIPPoolLister.Get(egress.Spec.ExternalIPPool)
+	if err != nil {
+		return nil
+	}
+	return pool.Spec.SubnetInfo
+}
+
 func (c *EgressController) onLocalIPUpdate(ip string, added bool) {
 	egresses, _ := c.egressInformer.GetIndexer().ByIndex(egressIPIndex, ip)
 	if len(egresses) == 0 {
@@ -391,10 +474,15 @@ func (c *EgressController) Run(stopCh <-chan struct{}) {
 	go c.localIPDetector.Run(stopCh)
 	go c.egressIPScheduler.Run(stopCh)
 	go c.ipAssigner.Run(stopCh)
-	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.egressListerSynced, c.localIPDetector.HasSynced, c.egressIPScheduler.HasScheduled) {
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.egressListerSynced, c.externalIPPoolListerSynced, c.localIPDetector.HasSynced, c.egressIPScheduler.HasScheduled) {
 		return
 	}
 
+	// The route tables and IP rules of the Egress subnets will be installed again when the Egresses are processed.
+	if err := c.routeClient.ClearEgressRoutesAndRules(minEgressTableID, maxEgressTableID); err != nil {
+		klog.ErrorS(err, "Failed to clear Egress routes and rules")
+	}
+
 	if err := c.replaceEgressIPs(); err != nil {
 		klog.ErrorS(err, "failed to replace Egress IPs")
 	}
@@ -413,11 +501,11 @@ func (c *EgressController) Run(stopCh <-chan struct{}) {
 // on this node. The unassigned IPs are from Egresses that were either deleted from the Kubernetes API or migrated
 // to other Nodes when the agent on this Node was not running.
 func (c *EgressController) replaceEgressIPs() error {
-	desiredLocalEgressIPs := sets.New[string]()
+	desiredLocalEgressIPs := map[string]*crdv1b1.SubnetInfo{}
 	egresses, _ := c.egressLister.List(labels.Everything())
 	for _, egress := range egresses {
 		if isEgressSchedulable(egress) && egress.Status.EgressNode == c.nodeName && egress.Status.EgressIP != "" {
-			desiredLocalEgressIPs.Insert(egress.Status.EgressIP)
+			desiredLocalEgressIPs[egress.Status.EgressIP] = c.getEgressSubnetInfo(egress)
 			// Record the Egress's state as we assign their IPs to this Node in the following call. It makes sure these
 			// Egress IPs will be unassigned when the Egresses are deleted.
 			c.newEgressState(egress.Name, egress.Status.EgressIP)
@@ -465,10 +553,12 @@ func (c *EgressController) processNextWorkItem() bool {
 
 // realizeEgressIP realizes an Egress IP. Multiple Egresses can share the same Egress IP.
 // If it's called the first time for a local Egress IP, it allocates a locally-unique mark for the IP and installs flows
-// and iptables rule for this IP and the mark.
-// If the Egress IP is changed from local to non local, it uninstalls flows and iptables rule and releases the mark.
+// and iptables rule for this IP and the mark. If the IP has subnet info, it also ensures the traffic with the mark is
+// routed with the route table of the subnet.
+// If the Egress IP is changed from local to non local, it uninstalls flows, iptables rule and IP rule and releases the
+// mark.
 // The method returns the mark on success. Non local Egresses use 0 as the mark.
-func (c *EgressController) realizeEgressIP(egressName, egressIP string) (uint32, error) {
+func (c *EgressController) realizeEgressIP(egressName, egressIP string, subnetInfo *crdv1b1.SubnetInfo) (uint32, error) {
 	isLocalIP := c.localIPDetector.IsLocalIP(egressIP)
 
 	c.egressIPStatesMutex.Lock()
@@ -508,8 +598,14 @@ func (c *EgressController) realizeEgressIP(egressName, egressIP string) (uint32,
 			}
 			ipState.ruleInstalled = true
 		}
+		if err := c.ensureEgressIPRoute(ipState, subnetInfo); err != nil {
+			return 0, fmt.Errorf("error installing routes for IP %s: %v", ipState.egressIP, err)
+		}
 	} else {
 		// Ensure datapath is uninstalled properly.
+		if err := c.deleteEgressIPRoute(ipState); err != nil {
+			return 0, fmt.Errorf("error uninstalling routes for IP %s: %v", ipState.egressIP, err)
+		}
 		if ipState.ruleInstalled {
 			if err := c.routeClient.DeleteSNATRule(ipState.mark); err != nil {
 				return 0, fmt.Errorf("error uninstalling SNAT rule for IP %s: %v", ipState.egressIP, err)
@@ -607,6 +703,9 @@ func (c *EgressController) unrealizeEgressIP(egressName, egressIP string) error
 		return nil
 	}
 	if ipState.mark != 0 {
+		if err := c.deleteEgressIPRoute(ipState); err != nil {
+			return err
+		}
 		if ipState.ruleInstalled {
 			if err := c.routeClient.DeleteSNATRule(ipState.mark); err != nil {
 				return err
@@ -625,6 +724,74 @@ func (c *EgressController) unrealizeEgressIP(egressName, egressIP string) error
 	return nil
 }
 
+// ensureEgressIPRoute ensures the traffic of the local Egress IP is routed with the route table of the provided subnet,
+// or with the main route table if the subnet is nil. The route table is installed when the first Egress IP of the
+// subnet is routed with it. The caller must hold egressIPStatesMutex.
+func (c *EgressController) ensureEgressIPRoute(ipState *egressIPState, subnetInfo *crdv1b1.SubnetInfo) error {
+	if ipState.routeTable != nil {
+		if subnetInfo != nil && ipState.routeTable.subnetInfo == *subnetInfo {
+			return nil
+		}
+		// The subnet of the Egress IP has changed.
+		if err := c.deleteEgressIPRoute(ipState); err != nil {
+			return err
+		}
+	}
+	if subnetInfo == nil {
+		return nil
+	}
+	table, exists := c.egressRouteTables[*subnetInfo]
+	if !exists {
+		dev, found := c.ipAssigner.GetInterfaceID(subnetInfo)
+		if !found {
+			return fmt.Errorf("interface of subnet %+v not found", *subnetInfo)
+		}
+		tableID, err := c.tableIDAllocator.allocate()
+		if err != nil {
+			return fmt.Errorf("error allocating route table ID: %v", err)
+		}
+		if err := c.routeClient.AddEgressRoutes(tableID, uint32(dev), net.ParseIP(subnetInfo.Gateway), int(subnetInfo.PrefixLength)); err != nil {
+			c.tableIDAllocator.release(tableID)
+			return err
+		}
+		table = &egressRouteTable{
+			tableID:    tableID,
+			subnetInfo: *subnetInfo,
+			marks:      sets.New[uint32](),
+		}
+		c.egressRouteTables[*subnetInfo] = table
+	}
+	if err := c.routeClient.AddEgressRule(table.tableID, ipState.mark); err != nil {
+		return err
+	}
+	table.marks.Insert(ipState.mark)
+	ipState.routeTable = table
+	return nil
+}
+
+// deleteEgressIPRoute reverts what ensureEgressIPRoute does. The route table is uninstalled when the last Egress IP of
+// the subnet is removed from it. The caller must hold egressIPStatesMutex.
+func (c *EgressController) deleteEgressIPRoute(ipState *egressIPState) error {
+	table := ipState.routeTable
+	if table == nil {
+		return nil
+	}
+	if err := c.routeClient.DeleteEgressRule(table.tableID, ipState.mark); err != nil {
+		return err
+	}
+	table.marks.Delete(ipState.mark)
+	ipState.routeTable = nil
+	if table.marks.Len() > 0 {
+		return nil
+	}
+	if err := c.routeClient.DeleteEgressRoutes(table.tableID); err != nil {
+		return err
+	}
+	c.tableIDAllocator.release(table.tableID)
+	delete(c.egressRouteTables, table.subnetInfo)
+	return nil
+}
+
 func (c *EgressController) getEgressState(egressName string) (*egressState, bool) {
 	c.egressStatesMutex.RLock()
 	defer c.egressStatesMutex.RUnlock()
@@ -750,6 +917,11 @@ func (c *EgressController) updateEgressStatus(egress *crdv1b1.Egress, egressIP s
 		// The Egress IP is assigned to a Node (egressIP != "") but it's not this Node (isLocal == false), do nothing.
 		return nil
 	}
+	if len(desiredStatus.Conditions) > 0 {
+		if condition := c.getUplinkHealthyCondition(egress); condition != nil {
+			desiredStatus.Conditions = append(desiredStatus.Conditions, *condition)
+		}
+	}
 
 	toUpdate := egress.DeepCopy()
 	var updateErr, getErr error
@@ -760,9 +932,9 @@ func (c *EgressController) updateEgressStatus(egress *crdv1b1.Egress, egressIP s
 		// Must make a copy here as we will append more conditions. If it's appended to desiredStatus directly, there
 		// would be duplicate conditions when the function retries.
 		statusToUpdate := desiredStatus.DeepCopy()
-		// Copy conditions other than crdv1b1.IPAssigned to statusToUpdate.
+		// Copy conditions other than the ones managed by the agent to statusToUpdate.
 		for _, c := range toUpdate.Status.Conditions {
-			if c.Type != crdv1b1.IPAssigned {
+			if c.Type != crdv1b1.IPAssigned && c.Type != crdv1b1.UplinkHealthy {
 				statusToUpdate.Conditions = append(statusToUpdate.Conditions, c)
 			}
 		}
@@ -785,6 +957,34 @@ func (c *EgressController) updateEgressStatus(egress *crdv1b1.Egress, egressIP s
 	return nil
 }
 
+// getUplinkHealthyCondition returns the UplinkHealthy condition of the Egress if its ExternalIPPool has health checks,
+// otherwise returns nil.
+func (c *EgressController) getUplinkHealthyCondition(egress *crdv1b1.Egress) *crdv1b1.EgressCondition {
+	if !isEgressSchedulable(egress) {
+		return nil
+	}
+	unhealthyNodes, ok := c.cluster.UnhealthyNodes(egress.Spec.ExternalIPPool)
+	if !ok {
+		return nil
+	}
+	if unhealthyNodes.Len() == 0 {
+		return &crdv1b1.EgressCondition{
+			Type:               crdv1b1.UplinkHealthy,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "Healthy",
+			Message:            "All eligible Nodes pass the health checks of the ExternalIPPool",
+		}
+	}
+	return &crdv1b1.EgressCondition{
+		Type:               crdv1b1.UplinkHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "Unhealthy",
+		Message:            fmt.Sprintf("Nodes failing the health checks of the ExternalIPPool: %s", strings.Join(sets.List(unhealthyNodes), ", ")),
+	}
+}
+
 func (c *EgressController) syncEgress(egressName string) error {
 	startTime := time.Now()
 	defer func() {
@@ -811,6 +1011,7 @@ func (c *EgressController) syncEgress(egressName string) error {
 	var desiredEgressIP string
 	var desiredNode string
 	var scheduleErr error
+	var subnetInfo *crdv1b1.SubnetInfo
 	// Only check whether the Egress IP should be assigned to this Node when the Egress is schedulable.
 	// Otherwise, users are responsible for assigning the Egress IP to Nodes.
 	if isEgressSchedulable(egress) {
@@ -818,6 +1019,7 @@ func (c *EgressController) syncEgress(egressName string) error {
 		if scheduled {
 			desiredEgressIP = egressIP
 			desiredNode = egressNode
+			subnetInfo = c.getEgressSubnetInfo(egress)
 		} else {
 			scheduleErr = err
 		}
@@ -848,7 +1050,7 @@ func (c *EgressController) syncEgress(egressName string) error {
 		// Ensure the Egress IP is assigned to the system. Force advertising the IP if it was previously assigned to
 		// another Node in the Egress API. This could force refreshing other peers' neighbor cache when the Egress IP is
 		// obtained by this Node and another Node at the same time in some situations, e.g. split brain.
-		if err := c.ipAssigner.AssignIP(desiredEgressIP, egress.Status.EgressNode != c.nodeName); err != nil {
+		if err := c.ipAssigner.AssignIP(desiredEgressIP, subnetInfo, egress.Status.EgressNode != c.nodeName); err != nil {
 			return err
 		}
 	} else {
@@ -859,7 +1061,7 @@ func (c *EgressController) syncEgress(egressName string) error {
 	}
 
 	// Realize the latest EgressIP and get the desired mark.
-	mark, err := c.realizeEgressIP(egressName, desiredEgressIP)
+	mark, err := c.realizeEgressIP(egressName, desiredEgressIP, subnetInfo)
 	if err != nil {
 		return err
 	}
@@ -1172,7 +1374,8 @@ func isEgressSchedulable(egress *crdv1b1.Egress) bool {
 	return egress.Spec.EgressIP != "" && egress.Spec.ExternalIPPool != ""
 }
 
-// compareEgressStatus compares two Egress Statuses, ignoring LastTransitionTime and conditions other than IPAssigned, returns true if they are equal.
+// compareEgressStatus compares two Egress Statuses, ignoring LastTransitionTime and conditions other than IPAssigned
+// and UplinkHealthy, returns true if they are equal.
 func compareEgressStatus(currentStatus, desiredStatus *crdv1b1.EgressStatus) bool {
 	if currentStatus == nil && desiredStatus == nil {
 		return true
@@ -1183,13 +1386,18 @@ func compareEgressStatus(currentStatus, desiredStatus *crdv1b1.EgressStatus) boo
 	if currentStatus.EgressIP != desiredStatus.EgressIP || currentStatus.EgressNode != desiredStatus.EgressNode {
 		return false
 	}
-	currentIPAssignedCondition := crdv1b1.GetEgressCondition(currentStatus.Conditions, crdv1b1.IPAssigned)
-	desiredIPAssignedCondition := crdv1b1.GetEgressCondition(desiredStatus.Conditions, crdv1b1.IPAssigned)
-	if currentIPAssignedCondition == nil && desiredIPAssignedCondition == nil {
+	return compareEgressCondition(currentStatus, desiredStatus, crdv1b1.IPAssigned) &&
+		compareEgressCondition(currentStatus, desiredStatus, crdv1b1.UplinkHealthy)
+}
+
+func compareEgressCondition(currentStatus, desiredStatus *crdv1b1.EgressStatus, conditionType crdv1b1.EgressConditionType) bool {
+	currentCondition := crdv1b1.GetEgressCondition(currentStatus.Conditions, conditionType)
+	desiredCondition := crdv1b1.GetEgressCondition(desiredStatus.Conditions, conditionType)
+	if currentCondition == nil && desiredCondition == nil {
 		return true
 	}
-	if currentIPAssignedCondition == nil || desiredIPAssignedCondition == nil {
+	if currentCondition == nil || desiredCondition == nil {
 		return false
 	}
-	return currentIPAssignedCondition.Status == desiredIPAssignedCondition.Status && currentIPAssignedCondition.Reason == desiredIPAssignedCondition.Reason && currentIPAssignedCondition.Message == desiredIPAssignedCondition.Message
+	return currentCondition.Status == desiredCondition.Status && currentCondition.Reason == desiredCondition.Reason && currentCondition.Message == desiredCondition.Message
 }
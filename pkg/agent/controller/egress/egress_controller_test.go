@@ -129,6 +129,10 @@ func (c *fakeSingleNodeCluster) AliveNodes() sets.Set[string] {
 	return sets.New[string](c.node)
 }
 
+func (c *fakeSingleNodeCluster) UnhealthyNodes(externalIPPool string) (sets.Set[string], bool) {
+	return nil, false
+}
+
 func (c *fakeSingleNodeCluster) AddClusterEventHandler(handler memberlist.ClusterNodeEventHandler) {}
 
 func mockNewIPAssigner(ipAssigner ipassigner.IPAssigner) func() {
@@ -190,6 +194,7 @@ func newFakeController(t *testing.T, initObjects []runtime.Object) *fakeControll
 		"eth0",
 		mockCluster,
 		egressInformer,
+		crdInformerFactory.Crd().V1beta1().ExternalIPPools(),
 		nodeInformer,
 		podUpdateChannel,
 		mockServiceCIDRProvider,
@@ -619,9 +624,9 @@ func TestSyncEgress(t *testing.T) {
 				mockOFClient.EXPECT().InstallPodSNATFlows(uint32(1), net.ParseIP(fakeLocalEgressIP1), uint32(1))
 				mockOFClient.EXPECT().InstallPodSNATFlows(uint32(2), net.ParseIP(fakeLocalEgressIP1), uint32(1))
 				mockRouteClient.EXPECT().AddSNATRule(net.ParseIP(fakeLocalEgressIP1), uint32(1))
-				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP2, true)
+				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP2, nil, true)
 				// forceAdvertise depends on how fast the Egress status update is reflected in the informer cache, which doesn't really matter.
-				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP2, gomock.Any())
+				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP2, nil, gomock.Any())
 				mockOFClient.EXPECT().InstallSNATMarkFlows(net.ParseIP(fakeLocalEgressIP2), uint32(2))
 				mockOFClient.EXPECT().InstallPodSNATFlows(uint32(3), net.ParseIP(fakeLocalEgressIP2), uint32(2))
 				mockRouteClient.EXPECT().AddSNATRule(net.ParseIP(fakeLocalEgressIP2), uint32(2))
@@ -668,7 +673,7 @@ func TestSyncEgress(t *testing.T) {
 				},
 			},
 			expectedCalls: func(mockOFClient *openflowtest.MockClient, mockRouteClient *routetest.MockInterface, mockIPAssigner *ipassignertest.MockIPAssigner) {
-				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP1, true)
+				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP1, nil, true)
 				mockOFClient.EXPECT().InstallSNATMarkFlows(net.ParseIP(fakeLocalEgressIP1), uint32(1))
 				mockOFClient.EXPECT().InstallPodSNATFlows(uint32(1), net.ParseIP(fakeLocalEgressIP1), uint32(1))
 				mockOFClient.EXPECT().InstallPodSNATFlows(uint32(2), net.ParseIP(fakeLocalEgressIP1), uint32(1))
@@ -708,7 +713,7 @@ func TestSyncEgress(t *testing.T) {
 				},
 			},
 			expectedCalls: func(mockOFClient *openflowtest.MockClient, mockRouteClient *routetest.MockInterface, mockIPAssigner *ipassignertest.MockIPAssigner) {
-				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP1, true)
+				mockIPAssigner.EXPECT().AssignIP(fakeLocalEgressIP1, nil, true)
 				mockOFClient.EXPECT().InstallSNATMarkFlows(net.ParseIP(fakeLocalEgressIP1), uint32(1))
 				mockOFClient.EXPECT().InstallPodSNATFlows(uint32(1), net.ParseIP(fakeLocalEgressIP1), uint32(1))
 				mockOFClient.EXPECT().InstallPodSNATFlows(uint32(2), net.ParseIP(fakeLocalEgressIP1), uint32(1))
@@ -887,6 +892,48 @@ func TestSyncEgress(t *testing.T) {
 	}
 }
 
+func TestRealizeEgressIPWithSubnetInfo(t *testing.T) {
+	c := newFakeController(t, nil)
+	subnetInfo := &crdv1b1.SubnetInfo{Gateway: "1.1.1.254", PrefixLength: 24, VLAN: 10}
+	egressIP1, egressIP2 := net.ParseIP(fakeLocalEgressIP1), net.ParseIP(fakeLocalEgressIP2)
+
+	// The route table is installed for the first Egress IP of the subnet.
+	c.mockOFClient.EXPECT().InstallSNATMarkFlows(egressIP1, uint32(1))
+	c.mockRouteClient.EXPECT().AddSNATRule(egressIP1, uint32(1))
+	c.mockIPAssigner.EXPECT().GetInterfaceID(subnetInfo).Return(20, true)
+	c.mockRouteClient.EXPECT().AddEgressRoutes(uint32(minEgressTableID), uint32(20), net.ParseIP("1.1.1.254"), 24)
+	c.mockRouteClient.EXPECT().AddEgressRule(uint32(minEgressTableID), uint32(1))
+	mark, err := c.realizeEgressIP("egressA", fakeLocalEgressIP1, subnetInfo)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), mark)
+	// It should be idempotent.
+	_, err = c.realizeEgressIP("egressA", fakeLocalEgressIP1, subnetInfo)
+	require.NoError(t, err)
+
+	// The route table is shared by the Egress IPs of the same subnet.
+	c.mockOFClient.EXPECT().InstallSNATMarkFlows(egressIP2, uint32(2))
+	c.mockRouteClient.EXPECT().AddSNATRule(egressIP2, uint32(2))
+	c.mockRouteClient.EXPECT().AddEgressRule(uint32(minEgressTableID), uint32(2))
+	mark, err = c.realizeEgressIP("egressB", fakeLocalEgressIP2, subnetInfo.DeepCopy())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), mark)
+	require.Len(t, c.egressRouteTables, 1)
+	assert.Equal(t, sets.New[uint32](1, 2), c.egressRouteTables[*subnetInfo].marks)
+
+	c.mockRouteClient.EXPECT().DeleteEgressRule(uint32(minEgressTableID), uint32(1))
+	c.mockRouteClient.EXPECT().DeleteSNATRule(uint32(1))
+	c.mockOFClient.EXPECT().UninstallSNATMarkFlows(uint32(1))
+	require.NoError(t, c.unrealizeEgressIP("egressA", fakeLocalEgressIP1))
+
+	// The route table is uninstalled when the last Egress IP of the subnet is removed from it.
+	c.mockRouteClient.EXPECT().DeleteEgressRule(uint32(minEgressTableID), uint32(2))
+	c.mockRouteClient.EXPECT().DeleteEgressRoutes(uint32(minEgressTableID))
+	c.mockRouteClient.EXPECT().DeleteSNATRule(uint32(2))
+	c.mockOFClient.EXPECT().UninstallSNATMarkFlows(uint32(2))
+	require.NoError(t, c.unrealizeEgressIP("egressB", fakeLocalEgressIP2))
+	assert.Empty(t, c.egressRouteTables)
+}
+
 func TestPodUpdateShouldSyncEgress(t *testing.T) {
 	egress := &crdv1b1.Egress{
 		ObjectMeta: metav1.ObjectMeta{Name: "egressA", UID: "uidA"},
@@ -1090,6 +1137,7 @@ func TestUpdateEgressStatus(t *testing.T) {
 		updateError          error
 		getErrorNum          int
 		selectedNodeForIP    string
+		unhealthyNodes       map[string]sets.Set[string]
 		expectedUpdateCalled int
 		expectedGetCalled    int
 		expectedError        error
@@ -1180,6 +1228,50 @@ func TestUpdateEgressStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "updating HA Egress with local IP and healthy uplinks",
+			egress: &crdv1b1.Egress{
+				ObjectMeta: metav1.ObjectMeta{Name: "egressA", UID: "uidA", ResourceVersion: "fake-ResourceVersion"},
+				Spec:       crdv1b1.EgressSpec{EgressIP: fakeLocalEgressIP1, ExternalIPPool: "external-ip-pool"},
+			},
+			egressIP:             fakeLocalEgressIP1,
+			unhealthyNodes:       map[string]sets.Set[string]{"external-ip-pool": sets.New[string]()},
+			expectedUpdateCalled: 1,
+			expectedEgressStatus: crdv1b1.EgressStatus{
+				EgressNode: fakeNode,
+				EgressIP:   fakeLocalEgressIP1,
+				Conditions: []crdv1b1.EgressCondition{
+					{Type: crdv1b1.IPAssigned, Status: v1.ConditionTrue, Reason: "Assigned", Message: "EgressIP is successfully assigned to EgressNode"},
+					{Type: crdv1b1.UplinkHealthy, Status: v1.ConditionTrue, Reason: "Healthy", Message: "All eligible Nodes pass the health checks of the ExternalIPPool"},
+				},
+			},
+		},
+		{
+			name: "updating HA Egress with local IP and unhealthy uplinks",
+			egress: &crdv1b1.Egress{
+				ObjectMeta: metav1.ObjectMeta{Name: "egressA", UID: "uidA", ResourceVersion: "fake-ResourceVersion"},
+				Spec:       crdv1b1.EgressSpec{EgressIP: fakeLocalEgressIP1, ExternalIPPool: "external-ip-pool"},
+				Status: crdv1b1.EgressStatus{
+					EgressNode: fakeNode,
+					EgressIP:   fakeLocalEgressIP1,
+					Conditions: []crdv1b1.EgressCondition{
+						{Type: crdv1b1.IPAssigned, Status: v1.ConditionTrue, Reason: "Assigned", Message: "EgressIP is successfully assigned to EgressNode"},
+						{Type: crdv1b1.UplinkHealthy, Status: v1.ConditionTrue, Reason: "Healthy", Message: "All eligible Nodes pass the health checks of the ExternalIPPool"},
+					},
+				},
+			},
+			egressIP:             fakeLocalEgressIP1,
+			unhealthyNodes:       map[string]sets.Set[string]{"external-ip-pool": sets.New[string](fakeNode2)},
+			expectedUpdateCalled: 1,
+			expectedEgressStatus: crdv1b1.EgressStatus{
+				EgressNode: fakeNode,
+				EgressIP:   fakeLocalEgressIP1,
+				Conditions: []crdv1b1.EgressCondition{
+					{Type: crdv1b1.IPAssigned, Status: v1.ConditionTrue, Reason: "Assigned", Message: "EgressIP is successfully assigned to EgressNode"},
+					{Type: crdv1b1.UplinkHealthy, Status: v1.ConditionFalse, Reason: "Unhealthy", Message: "Nodes failing the health checks of the ExternalIPPool: " + fakeNode2},
+				},
+			},
+		},
 		{
 			name: "updating HA Egress with remote IP does nothing",
 			egress: &crdv1b1.Egress{
@@ -1285,6 +1377,7 @@ func TestUpdateEgressStatus(t *testing.T) {
 
 			localIPDetector := &fakeLocalIPDetector{localIPs: sets.New[string](fakeLocalEgressIP1)}
 			cluster := newFakeMemberlistCluster([]string{tt.selectedNodeForIP})
+			cluster.unhealthyNodes = tt.unhealthyNodes
 			c := &EgressController{crdClient: fakeClient, nodeName: fakeNode, localIPDetector: localIPDetector, cluster: cluster}
 			err := c.updateEgressStatus(tt.egress, tt.egressIP, tt.scheduleErr)
 			if err != tt.expectedError {
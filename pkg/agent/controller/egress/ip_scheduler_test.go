@@ -43,6 +43,9 @@ type fakeMemberlistCluster struct {
 	nodes         []string
 	hashMap       *consistenthash.Map
 	eventHandlers []memberlist.ClusterNodeEventHandler
+	// unhealthyNodes holds the Nodes failing the health checks, indexed by the names of the ExternalIPPools which
+	// have health checks.
+	unhealthyNodes map[string]sets.Set[string]
 }
 
 func newFakeMemberlistCluster(nodes []string) *fakeMemberlistCluster {
@@ -71,6 +74,11 @@ func (f *fakeMemberlistCluster) AliveNodes() sets.Set[string] {
 	return sets.New[string](f.nodes...)
 }
 
+func (f *fakeMemberlistCluster) UnhealthyNodes(externalIPPool string) (sets.Set[string], bool) {
+	nodes, ok := f.unhealthyNodes[externalIPPool]
+	return nodes, ok
+}
+
 func (f *fakeMemberlistCluster) SelectNodeForIP(ip, externalIPPool string, filters ...func(string) bool) (string, error) {
 	node := f.hashMap.GetWithFilters(ip, filters...)
 	if node == "" {
@@ -0,0 +1,251 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7flowexporter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/util/channel"
+	"antrea.io/antrea/pkg/util/k8s"
+)
+
+const (
+	controllerName = "L7FlowExporterController"
+	// How long to wait before retrying the processing of a Pod change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing a Pod change.
+	defaultWorkers = 4
+	// Disable resyncing.
+	resyncPeriod time.Duration = 0
+)
+
+// L7EngineReconciler is the interface of the L7 engine used by the controller.
+type L7EngineReconciler interface {
+	// StartSuricataOnce starts the L7 engine if it has not been started yet.
+	StartSuricataOnce()
+}
+
+// Controller installs the flows to mirror the traffic of the local Pods annotated with
+// types.L7FlowExporterAnnotationKey to the L7 engine, so that the L7 metadata of their connections can be exported by
+// the FlowExporter.
+type Controller struct {
+	ofClient       openflow.Client
+	interfaceStore interfacestore.InterfaceStore
+	l7Reconciler   L7EngineReconciler
+
+	podInformer     cache.SharedIndexInformer
+	podLister       corelisters.PodLister
+	podListerSynced cache.InformerSynced
+
+	// podOFPorts keeps the openflow ports of the Pods for which flows have been installed.
+	podOFPorts      map[string]uint32
+	podOFPortsMutex sync.RWMutex
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewL7FlowExporterController(ofClient openflow.Client,
+	interfaceStore interfacestore.InterfaceStore,
+	l7Reconciler L7EngineReconciler,
+	podInformer cache.SharedIndexInformer,
+	podUpdateSubscriber channel.Subscriber) *Controller {
+	c := &Controller{
+		ofClient:        ofClient,
+		interfaceStore:  interfaceStore,
+		l7Reconciler:    l7Reconciler,
+		podInformer:     podInformer,
+		podLister:       corelisters.NewPodLister(podInformer.GetIndexer()),
+		podListerSynced: podInformer.HasSynced,
+		podOFPorts:      map[string]uint32{},
+		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "l7FlowExporter"),
+	}
+	c.podInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addPod,
+			UpdateFunc: c.updatePod,
+			DeleteFunc: c.deletePod,
+		},
+		resyncPeriod,
+	)
+	podUpdateSubscriber.Subscribe(c.processPodUpdate)
+	return c
+}
+
+// processPodUpdate will be called when CNIServer publishes a Pod update event. The Pod is resynced, as its openflow
+// port may have been allocated or changed.
+func (c *Controller) processPodUpdate(e interface{}) {
+	podEvent := e.(types.PodUpdate)
+	c.queue.Add(k8s.NamespacedName(podEvent.PodNamespace, podEvent.PodName))
+}
+
+func isL7FlowExporterRequested(pod *v1.Pod) bool {
+	_, ok := flowexporter.GetL7FlowExporterDirection(pod)
+	return ok
+}
+
+func (c *Controller) addPod(obj interface{}) {
+	pod := obj.(*v1.Pod)
+	if pod.Spec.HostNetwork || !isL7FlowExporterRequested(pod) {
+		return
+	}
+	klog.V(2).InfoS("Processing Pod ADD event", "Pod", klog.KObj(pod))
+	c.queue.Add(k8s.NamespacedName(pod.Namespace, pod.Name))
+}
+
+func (c *Controller) updatePod(oldObj interface{}, obj interface{}) {
+	oldPod := oldObj.(*v1.Pod)
+	pod := obj.(*v1.Pod)
+	if pod.Spec.HostNetwork {
+		return
+	}
+	if oldPod.Annotations[types.L7FlowExporterAnnotationKey] == pod.Annotations[types.L7FlowExporterAnnotationKey] {
+		return
+	}
+	klog.V(2).InfoS("Processing Pod UPDATE event", "Pod", klog.KObj(pod))
+	c.queue.Add(k8s.NamespacedName(pod.Namespace, pod.Name))
+}
+
+func (c *Controller) deletePod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Received unexpected object", "object", obj)
+			return
+		}
+		pod, ok = deletedState.Obj.(*v1.Pod)
+		if !ok {
+			klog.ErrorS(nil, "DeletedFinalStateUnknown contains non-Pod object", "object", deletedState.Obj)
+			return
+		}
+	}
+	if pod.Spec.HostNetwork || !isL7FlowExporterRequested(pod) {
+		return
+	}
+	klog.V(2).InfoS("Processing Pod DELETE event", "Pod", klog.KObj(pod))
+	c.queue.Add(k8s.NamespacedName(pod.Namespace, pod.Name))
+}
+
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.podListerSynced) {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if key, ok := obj.(string); !ok {
+		// As the item in the work queue is actually invalid, we call Forget here else we'd
+		// go into a loop of attempting to process a work item that is invalid.
+		// This should not happen.
+		c.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := c.syncPod(key); err == nil {
+		// If no error occurs we Forget this item, so it does not get queued again until
+		// another change happens.
+		c.queue.Forget(key)
+	} else {
+		// Put the item back on the work queue to handle any transient errors.
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Syncing Pod for L7 flow export failed, requeue", "Pod", key)
+	}
+	return true
+}
+
+func (c *Controller) syncPod(podKey string) error {
+	namespace, name := k8s.SplitNamespacedName(podKey)
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	c.podOFPortsMutex.RLock()
+	installedOFPort, installed := c.podOFPorts[podKey]
+	c.podOFPortsMutex.RUnlock()
+
+	// Get the openflow port of the Pod. The Pod may not have been set up by the CNIServer yet, in which case its flows
+	// will be installed when the Pod update event is received.
+	var ofPort uint32
+	if pod != nil && isL7FlowExporterRequested(pod) {
+		if ifaces := c.interfaceStore.GetContainerInterfacesByPod(name, namespace); len(ifaces) > 0 {
+			ofPort = uint32(ifaces[0].OFPort)
+		}
+	}
+
+	if ofPort == 0 {
+		if !installed {
+			return nil
+		}
+		if err := c.ofClient.UninstallL7FlowExporterPodFlows(podKey); err != nil {
+			return fmt.Errorf("failed to uninstall L7 flow exporter flows for Pod %s: %w", podKey, err)
+		}
+		c.podOFPortsMutex.Lock()
+		delete(c.podOFPorts, podKey)
+		c.podOFPortsMutex.Unlock()
+		klog.V(2).InfoS("Uninstalled L7 flow exporter flows", "Pod", podKey)
+		return nil
+	}
+
+	if installed && installedOFPort == ofPort {
+		return nil
+	}
+	// The L7 engine is only started when there is at least one Pod requesting L7 flow export.
+	c.l7Reconciler.StartSuricataOnce()
+	if err := c.ofClient.InstallL7FlowExporterPodFlows(podKey, ofPort); err != nil {
+		return fmt.Errorf("failed to install L7 flow exporter flows for Pod %s: %w", podKey, err)
+	}
+	c.podOFPortsMutex.Lock()
+	c.podOFPorts[podKey] = ofPort
+	c.podOFPortsMutex.Unlock()
+	klog.V(2).InfoS("Installed L7 flow exporter flows", "Pod", podKey, "ofPort", ofPort)
+	return nil
+}
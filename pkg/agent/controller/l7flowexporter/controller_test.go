@@ -0,0 +1,138 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7flowexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/util/channel"
+	"antrea.io/antrea/pkg/util/k8s"
+)
+
+type fakeL7Reconciler struct {
+	started int
+}
+
+func (r *fakeL7Reconciler) StartSuricataOnce() {
+	r.started++
+}
+
+type fakeController struct {
+	*Controller
+	mockOFClient *openflowtest.MockClient
+	l7Reconciler *fakeL7Reconciler
+}
+
+func newFakeController(t *testing.T, interfaces []*interfacestore.InterfaceConfig) *fakeController {
+	ctrl := gomock.NewController(t)
+	mockOFClient := openflowtest.NewMockClient(ctrl)
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	podInformer := informerFactory.Core().V1().Pods().Informer()
+	ifaceStore := interfacestore.NewInterfaceStore()
+	for _, itf := range interfaces {
+		ifaceStore.AddInterface(itf)
+	}
+	l7Reconciler := &fakeL7Reconciler{}
+	podUpdateChannel := channel.NewSubscribableChannel("PodUpdate", 100)
+	c := NewL7FlowExporterController(mockOFClient, ifaceStore, l7Reconciler, podInformer, podUpdateChannel)
+	return &fakeController{
+		Controller:   c,
+		mockOFClient: mockOFClient,
+		l7Reconciler: l7Reconciler,
+	}
+}
+
+func newPod(namespace, name, l7ExportDirection string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if l7ExportDirection != "" {
+		pod.Annotations = map[string]string{types.L7FlowExporterAnnotationKey: l7ExportDirection}
+	}
+	return pod
+}
+
+func newPodInterface(podNamespace, podName string, ofPort int32) *interfacestore.InterfaceConfig {
+	containerName := k8s.NamespacedName(podNamespace, podName)
+	return &interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName(podName, podNamespace, containerName),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: podName, PodNamespace: podNamespace, ContainerID: containerName},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: ofPort},
+	}
+}
+
+func TestSyncPod(t *testing.T) {
+	podKey := k8s.NamespacedName("ns1", "pod1")
+	c := newFakeController(t, []*interfacestore.InterfaceConfig{newPodInterface("ns1", "pod1", 10)})
+	podIndexer := c.podInformer.GetIndexer()
+
+	// A Pod without the annotation is ignored.
+	require.NoError(t, podIndexer.Add(newPod("ns1", "pod1", "")))
+	require.NoError(t, c.syncPod(podKey))
+	assert.Equal(t, 0, c.l7Reconciler.started)
+
+	// The flows are installed when the annotation is added.
+	require.NoError(t, podIndexer.Update(newPod("ns1", "pod1", "both")))
+	c.mockOFClient.EXPECT().InstallL7FlowExporterPodFlows(podKey, uint32(10)).Return(nil).Times(1)
+	require.NoError(t, c.syncPod(podKey))
+	assert.Equal(t, 1, c.l7Reconciler.started)
+	assert.Equal(t, map[string]uint32{podKey: 10}, c.podOFPorts)
+
+	// Syncing the Pod again is a no-op.
+	require.NoError(t, c.syncPod(podKey))
+
+	// The flows are uninstalled when the annotation value becomes invalid.
+	require.NoError(t, podIndexer.Update(newPod("ns1", "pod1", "invalid")))
+	c.mockOFClient.EXPECT().UninstallL7FlowExporterPodFlows(podKey).Return(nil).Times(1)
+	require.NoError(t, c.syncPod(podKey))
+	assert.Empty(t, c.podOFPorts)
+
+	// The flows are uninstalled when the Pod is deleted.
+	require.NoError(t, podIndexer.Update(newPod("ns1", "pod1", "ingress")))
+	c.mockOFClient.EXPECT().InstallL7FlowExporterPodFlows(podKey, uint32(10)).Return(nil).Times(1)
+	require.NoError(t, c.syncPod(podKey))
+	require.NoError(t, podIndexer.Delete(newPod("ns1", "pod1", "ingress")))
+	c.mockOFClient.EXPECT().UninstallL7FlowExporterPodFlows(podKey).Return(nil).Times(1)
+	require.NoError(t, c.syncPod(podKey))
+	assert.Empty(t, c.podOFPorts)
+}
+
+func TestSyncPodWithoutInterface(t *testing.T) {
+	podKey := k8s.NamespacedName("ns1", "pod2")
+	c := newFakeController(t, nil)
+	require.NoError(t, c.podInformer.GetIndexer().Add(newPod("ns1", "pod2", "egress")))
+
+	// The flows are not installed until the Pod interface is available.
+	require.NoError(t, c.syncPod(podKey))
+	assert.Empty(t, c.podOFPorts)
+
+	c.interfaceStore.AddInterface(newPodInterface("ns1", "pod2", 20))
+	c.processPodUpdate(types.PodUpdate{PodNamespace: "ns1", PodName: "pod2", IsAdd: true})
+	assert.Equal(t, 1, c.queue.Len())
+	c.mockOFClient.EXPECT().InstallL7FlowExporterPodFlows(podKey, uint32(20)).Return(nil).Times(1)
+	require.NoError(t, c.syncPod(podKey))
+	assert.Equal(t, map[string]uint32{podKey: 20}, c.podOFPorts)
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 
@@ -100,6 +101,10 @@ type rule struct {
 	EnableLogging bool
 	// LogLabel is a string associated to the NetworkPolicy rule. Used for logging.
 	LogLabel string
+	// EnforcementMode indicates how the rule is enforced. Empty for K8s NetworkPolicy.
+	EnforcementMode crdv1beta1.EnforcementMode
+	// RateLimit specifies the rate limits of the traffic matching the rule. Only set for rules with RateLimit action.
+	RateLimit *v1beta.RuleRateLimit
 }
 
 func (r *rule) Less(r2 *rule) bool {
@@ -171,6 +176,26 @@ func (r *CompletedRule) isAntreaNetworkPolicyRule() bool {
 	return r.SourceRef.Type != v1beta.K8sNetworkPolicy
 }
 
+// isAuditRule returns true if the rule is part of an Antrea policy in Audit enforcement mode.
+func (r *CompletedRule) isAuditRule() bool {
+	return r.EnforcementMode == crdv1beta1.EnforcementModeAudit
+}
+
+// isRateLimitRule returns true if the rule has RateLimit action.
+func (r *CompletedRule) isRateLimitRule() bool {
+	return r.Action != nil && *r.Action == crdv1beta1.RuleActionRateLimit
+}
+
+// isNodeNetworkPolicyRule returns true if the rule is applied to Nodes.
+func (r *CompletedRule) isNodeNetworkPolicyRule() bool {
+	for _, member := range r.TargetMembers {
+		if member.Node != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *CompletedRule) isIGMPEgressPolicyRule() bool {
 	if r.Direction == v1beta.DirectionOut {
 		for _, svc := range r.Services {
@@ -393,7 +418,7 @@ func toIGMPReportGroupAddressIndexFunc(obj interface{}) ([]string, error) {
 		for _, svc := range rule.Services {
 			if svc.Protocol != nil && *svc.Protocol == v1beta.ProtocolIGMP && svc.IGMPType == nil ||
 				svc.IGMPType != nil && (*svc.IGMPType == crdv1beta1.IGMPReportV1 || *svc.IGMPType == crdv1beta1.IGMPReportV2 || *svc.IGMPType == crdv1beta1.IGMPReportV3) {
-				mcastGroupAddresses.Insert(svc.GroupAddress)
+				mcastGroupAddresses.Insert(normalizeGroupAddress(svc.GroupAddress))
 			}
 		}
 	}
@@ -401,6 +426,16 @@ func toIGMPReportGroupAddressIndexFunc(obj interface{}) ([]string, error) {
 	return mcastGroupAddresses.UnsortedList(), nil
 }
 
+// normalizeGroupAddress returns the canonical string representation of a multicast group address, so that IPv6
+// groupAddresses written in different forms (e.g. "FF05::1:3" and "ff05:0::1:3") match the address parsed from an MLD
+// report.
+func normalizeGroupAddress(groupAddress string) string {
+	if ip := net.ParseIP(groupAddress); ip != nil {
+		return ip.String()
+	}
+	return groupAddress
+}
+
 // newRuleCache returns a new *ruleCache.
 func newRuleCache(dirtyRuleHandler func(string), podUpdateSubscriber channel.Subscriber, externalEntityUpdateSubscriber channel.Subscriber,
 	serviceGroupIDUpdate <-chan string, nodeType config.NodeType) *ruleCache {
@@ -710,6 +745,8 @@ func toRule(r *v1beta.NetworkPolicyRule, policy *v1beta.NetworkPolicy, maxPriori
 		SourceRef:       policy.SourceRef,
 		EnableLogging:   r.EnableLogging,
 		LogLabel:        r.LogLabel,
+		EnforcementMode: r.EnforcementMode,
+		RateLimit:       r.RateLimit,
 	}
 	rule.ID = hashRule(rule)
 	rule.PolicyName = policy.Name
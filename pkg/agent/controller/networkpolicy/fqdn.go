@@ -129,6 +129,21 @@ type fqdnController struct {
 	ruleSyncTracker *ruleSyncTracker
 	// FQDN names this controller is tracking, with their corresponding dnsMeta.
 	dnsEntryCache map[string]dnsMeta
+	// localDNSEntryCache stores the FQDN resolution results learned from the DNS responses received by this Node.
+	// Unlike dnsEntryCache, it doesn't include the results learned from other Nodes, so that a Node only reports
+	// the results it has learned by itself.
+	localDNSEntryCache map[string]dnsMeta
+	// localDNSEntryCacheGeneration is increased every time localDNSEntryCache is updated. It's used to determine
+	// whether localDNSEntryCache needs to be saved to file or shared with other Nodes.
+	localDNSEntryCacheGeneration uint64
+	// dnsCacheStore persists localDNSEntryCache so that it can be restored after agent restarts. It's nil if the cache
+	// is not persisted.
+	dnsCacheStore *fqdnCacheStore
+	// dnsCacheSavedGeneration is the generation of localDNSEntryCache saved to file last time.
+	dnsCacheSavedGeneration uint64
+	// dnsCacheSharer shares localDNSEntryCache with other Nodes via antrea-controller. It's nil if the cache is not
+	// shared.
+	dnsCacheSharer *fqdnCacheSharer
 	// FQDN names that needs to be re-queried after their respective TTLs.
 	dnsQueryQueue workqueue.RateLimitingInterface
 	// idAllocator provides interfaces to allocateForRule and release uint32 id.
@@ -138,7 +153,7 @@ type fqdnController struct {
 	// The mapping between FQDN rule IDs and the Pod's ofPort IDs that the rule selects.
 	fqdnRuleToSelectedPods map[string]sets.Set[int32]
 
-	// Mutex for fqdnToSelectorItem, selectorItemToFQDN and selectorItemToRuleIDs.
+	// Mutex for dnsEntryCache, localDNSEntryCache, fqdnToSelectorItem, selectorItemToFQDN and selectorItemToRuleIDs.
 	fqdnSelectorMutex sync.Mutex
 	// fqdnToSelectorItem stores known FQDNSelectorItems that selects the FQDN, for each
 	// FQDN tracked by this controller.
@@ -161,6 +176,7 @@ func newFQDNController(client openflow.Client, allocator *idAllocator, dnsServer
 		idAllocator:            allocator,
 		dnsQueryQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "fqdn"),
 		dnsEntryCache:          map[string]dnsMeta{},
+		localDNSEntryCache:     map[string]dnsMeta{},
 		fqdnRuleToSelectedPods: map[string]sets.Set[int32]{},
 		fqdnToSelectorItem:     map[string]map[fqdnSelectorItem]struct{}{},
 		selectorItemToFQDN:     map[fqdnSelectorItem]sets.Set[string]{},
@@ -360,6 +376,7 @@ func (f *fqdnController) cleanupFQDNSelectorItem(fs fqdnSelectorItem) {
 				// tracked by the fqdnController.
 				delete(f.fqdnToSelectorItem, fqdn)
 				delete(f.dnsEntryCache, fqdn)
+				f.deleteLocalDNSEntry(fqdn)
 			}
 			delete(selectors, fs)
 		}
@@ -402,6 +419,19 @@ func (f *fqdnController) onDNSResponse(
 	lowestTTL uint32,
 	lookupTime time.Time,
 	waitCh chan error,
+) {
+	f.updateDNSEntry(fqdn, responseIPs, lookupTime.Add(time.Duration(lowestTTL)*time.Second), true, waitCh)
+}
+
+// updateDNSEntry updates the cached IP addresses of a FQDN with the given resolution results, which expire at
+// recordTTL, and syncs the rules affected by the FQDN. learnedLocally indicates whether the results are learned from
+// a DNS response received by this Node, in which case they are also recorded in localDNSEntryCache.
+func (f *fqdnController) updateDNSEntry(
+	fqdn string,
+	responseIPs map[string]net.IP,
+	recordTTL time.Time,
+	learnedLocally bool,
+	waitCh chan error,
 ) {
 	if len(responseIPs) == 0 {
 		klog.V(4).InfoS("FQDN was not resolved to any addresses, skip updating DNS cache", "fqdn", fqdn)
@@ -415,7 +445,12 @@ func (f *fqdnController) onDNSResponse(
 	// addressUpdate is only true if there has been an update in IP addresses
 	// corresponded with the FQDN.
 	mustCacheResponse, addressUpdate := false, false
-	recordTTL := lookupTime.Add(time.Duration(lowestTTL) * time.Second)
+	// Keep a copy of the resolution results before they are merged with the cached ones.
+	localResponseIPs := make(map[string]net.IP, len(responseIPs))
+	for ipStr, ip := range responseIPs {
+		localResponseIPs[ipStr] = ip
+	}
+	localRecordTTL := recordTTL
 
 	f.fqdnSelectorMutex.Lock()
 	defer f.fqdnSelectorMutex.Unlock()
@@ -458,6 +493,9 @@ func (f *fqdnController) onDNSResponse(
 			responseIPs:    responseIPs,
 		}
 		f.dnsQueryQueue.AddAfter(fqdn, recordTTL.Sub(time.Now()))
+		if learnedLocally {
+			f.updateLocalDNSEntry(fqdn, localResponseIPs, localRecordTTL)
+		}
 	}
 	f.syncDirtyRules(fqdn, waitCh, addressUpdate)
 }
@@ -632,6 +670,11 @@ func (f *fqdnController) processNextWorkItem() bool {
 	}
 	defer f.dnsQueryQueue.Done(key)
 
+	if !f.pruneUntrackedFQDN(key.(string)) {
+		// The FQDN is no longer selected by any rule, there is no need to query it.
+		f.dnsQueryQueue.Forget(key)
+		return true
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), dnsRequestTimeout)
 	defer cancel()
 	err := f.makeDNSRequest(ctx, key.(string))
@@ -639,6 +682,19 @@ func (f *fqdnController) processNextWorkItem() bool {
 	return true
 }
 
+// pruneUntrackedFQDN returns whether the FQDN is selected by at least one fqdnSelectorItem. If it's not, its cached
+// entry is removed, which may have been restored from file or learned from other Nodes.
+func (f *fqdnController) pruneUntrackedFQDN(fqdn string) bool {
+	f.fqdnSelectorMutex.Lock()
+	defer f.fqdnSelectorMutex.Unlock()
+	if _, ok := f.fqdnToSelectorItem[fqdn]; ok {
+		return true
+	}
+	delete(f.dnsEntryCache, fqdn)
+	f.deleteLocalDNSEntry(fqdn)
+	return false
+}
+
 func (f *fqdnController) handleErr(err error, key interface{}) {
 	if err == nil {
 		f.dnsQueryQueue.Forget(key)
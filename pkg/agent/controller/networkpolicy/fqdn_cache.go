@@ -0,0 +1,298 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent"
+	"antrea.io/antrea/pkg/apiserver/handlers/fqdncache"
+)
+
+const (
+	// fqdnCacheFile is the file storing localDNSEntryCache, relative to dataPath.
+	fqdnCacheFile = "fqdn-cache.json"
+	// How often localDNSEntryCache is saved to file and exchanged with other Nodes.
+	fqdnCacheSyncInterval = 10 * time.Second
+	// How often localDNSEntryCache is reported to antrea-controller even if it hasn't changed, in case antrea-controller
+	// has restarted and lost the reported entries.
+	fqdnCacheReportResyncInterval = 5 * time.Minute
+	fqdnCacheSharingTimeout       = 5 * time.Second
+	// fqdnCachePath is the path of the antrea-controller API aggregating the FQDN caches of all Nodes.
+	fqdnCachePath = "/fqdncache"
+)
+
+// fqdnCacheStore stores the FQDN resolution results in a file, so that they can be restored after agent restarts.
+type fqdnCacheStore struct {
+	fs   afero.Fs
+	path string
+}
+
+func newFQDNCacheStore(fs afero.Fs, path string) *fqdnCacheStore {
+	return &fqdnCacheStore{fs: fs, path: path}
+}
+
+// save overwrites the file with the given entries. The entries are written to a temporary file first, so that the
+// file is never left partially written.
+func (s *fqdnCacheStore) save(entries []fqdncache.Entry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("error encoding FQDN cache: %w", err)
+	}
+	tmpPath := s.path + ".tmp"
+	if err := afero.WriteFile(s.fs, tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("error writing FQDN cache to file: %w", err)
+	}
+	return s.fs.Rename(tmpPath, s.path)
+}
+
+// load returns the entries stored in the file. It returns no entries if the file doesn't exist.
+func (s *fqdnCacheStore) load() ([]fqdncache.Entry, error) {
+	data, err := afero.ReadFile(s.fs, s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading FQDN cache from file: %w", err)
+	}
+	var entries []fqdncache.Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("error decoding FQDN cache: %w", err)
+	}
+	return entries, nil
+}
+
+// fqdnCacheSharer exchanges the FQDN resolution results with other Nodes via antrea-controller.
+type fqdnCacheSharer struct {
+	antreaClientProvider agent.AntreaClientProvider
+	nodeName             string
+	// The generation of localDNSEntryCache and the time when it was reported last time.
+	reportedGeneration uint64
+	reportedTime       time.Time
+}
+
+// report sends the entries learned by this Node to antrea-controller, replacing the ones reported previously.
+func (s *fqdnCacheSharer) report(ctx context.Context, entries []fqdncache.Entry) error {
+	antreaClient, err := s.antreaClientProvider.GetAntreaClient()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(&fqdncache.Report{NodeName: s.nodeName, Entries: entries})
+	if err != nil {
+		return err
+	}
+	return antreaClient.ControlplaneV1beta2().RESTClient().Post().
+		AbsPath(fqdnCachePath).
+		SetHeader("Content-Type", "application/json").
+		Body(data).
+		Do(ctx).
+		Error()
+}
+
+// list returns the entries learned by the other Nodes from antrea-controller.
+func (s *fqdnCacheSharer) list(ctx context.Context) ([]fqdncache.Entry, error) {
+	antreaClient, err := s.antreaClientProvider.GetAntreaClient()
+	if err != nil {
+		return nil, err
+	}
+	data, err := antreaClient.ControlplaneV1beta2().RESTClient().Get().
+		AbsPath(fqdnCachePath).
+		Param("node", s.nodeName).
+		SetHeader("Accept", "application/json").
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	var entries []fqdncache.Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("error decoding FQDN cache entries: %w", err)
+	}
+	return entries, nil
+}
+
+// entryToResponseIPs converts the IPs of an entry to the format of dnsMeta.responseIPs, skipping the ones of the
+// disabled IP families.
+func (f *fqdnController) entryToResponseIPs(entry *fqdncache.Entry) map[string]net.IP {
+	responseIPs := map[string]net.IP{}
+	for _, ipStr := range entry.IPs {
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			continue
+		}
+		if (ip.To4() != nil && f.ipv4Enabled) || (ip.To4() == nil && f.ipv6Enabled) {
+			responseIPs[ip.String()] = ip
+		}
+	}
+	return responseIPs
+}
+
+// updateLocalDNSEntry merges the resolution results learned by this Node into localDNSEntryCache, keeping the
+// unexpired addresses learned previously. It must be called with fqdnSelectorMutex held.
+func (f *fqdnController) updateLocalDNSEntry(fqdn string, responseIPs map[string]net.IP, recordTTL time.Time) {
+	oldDNSMeta, exist := f.localDNSEntryCache[fqdn]
+	if exist && oldDNSMeta.expirationTime.After(time.Now()) {
+		for oldIPStr, oldIP := range oldDNSMeta.responseIPs {
+			if _, ok := responseIPs[oldIPStr]; !ok {
+				responseIPs[oldIPStr] = oldIP
+				if oldDNSMeta.expirationTime.Before(recordTTL) {
+					recordTTL = oldDNSMeta.expirationTime
+				}
+			}
+		}
+	}
+	if exist && oldDNSMeta.expirationTime.Equal(recordTTL) && sets.KeySet(oldDNSMeta.responseIPs).Equal(sets.KeySet(responseIPs)) {
+		return
+	}
+	f.localDNSEntryCache[fqdn] = dnsMeta{
+		expirationTime: recordTTL,
+		responseIPs:    responseIPs,
+	}
+	f.localDNSEntryCacheGeneration++
+}
+
+// deleteLocalDNSEntry removes the resolution results learned by this Node for the FQDN from localDNSEntryCache. It
+// must be called with fqdnSelectorMutex held.
+func (f *fqdnController) deleteLocalDNSEntry(fqdn string) {
+	if _, ok := f.localDNSEntryCache[fqdn]; ok {
+		delete(f.localDNSEntryCache, fqdn)
+		f.localDNSEntryCacheGeneration++
+	}
+}
+
+// getDNSCacheEntries returns the unexpired entries of localDNSEntryCache sorted by FQDN, and the generation of
+// localDNSEntryCache.
+func (f *fqdnController) getDNSCacheEntries() ([]fqdncache.Entry, uint64) {
+	now := time.Now()
+	f.fqdnSelectorMutex.Lock()
+	defer f.fqdnSelectorMutex.Unlock()
+	entries := make([]fqdncache.Entry, 0, len(f.localDNSEntryCache))
+	for fqdn, meta := range f.localDNSEntryCache {
+		if !meta.expirationTime.After(now) || len(meta.responseIPs) == 0 {
+			continue
+		}
+		ips := make([]string, 0, len(meta.responseIPs))
+		for ipStr := range meta.responseIPs {
+			ips = append(ips, ipStr)
+		}
+		sort.Strings(ips)
+		entries = append(entries, fqdncache.Entry{FQDN: fqdn, IPs: ips, ExpirationTime: meta.expirationTime})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FQDN < entries[j].FQDN
+	})
+	return entries, f.localDNSEntryCacheGeneration
+}
+
+// restoreDNSCache restores localDNSEntryCache from file. The unexpired entries are also added to dnsEntryCache, so
+// that the rules selecting the FQDNs can be realized with the cached IPs immediately, without waiting for the Pods to
+// query the FQDNs again. The FQDNs are re-queried when the entries expire.
+func (f *fqdnController) restoreDNSCache() error {
+	entries, err := f.dnsCacheStore.load()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	f.fqdnSelectorMutex.Lock()
+	defer f.fqdnSelectorMutex.Unlock()
+	restored := 0
+	for i := range entries {
+		entry := &entries[i]
+		if !entry.ExpirationTime.After(now) {
+			continue
+		}
+		responseIPs := f.entryToResponseIPs(entry)
+		if len(responseIPs) == 0 {
+			continue
+		}
+		f.dnsEntryCache[entry.FQDN] = dnsMeta{
+			expirationTime: entry.ExpirationTime,
+			responseIPs:    responseIPs,
+		}
+		f.localDNSEntryCache[entry.FQDN] = dnsMeta{
+			expirationTime: entry.ExpirationTime,
+			responseIPs:    f.entryToResponseIPs(entry),
+		}
+		// The entry will be removed when it expires if it's not selected by any rule by then.
+		f.dnsQueryQueue.AddAfter(entry.FQDN, entry.ExpirationTime.Sub(now))
+		restored++
+	}
+	klog.InfoS("Restored FQDN cache from file", "entries", restored)
+	return nil
+}
+
+// runDNSCacheSyncer periodically saves localDNSEntryCache to file and exchanges it with other Nodes if enabled.
+func (f *fqdnController) runDNSCacheSyncer(stopCh <-chan struct{}) {
+	wait.Until(f.syncDNSCache, fqdnCacheSyncInterval, stopCh)
+	// Save the latest entries before exiting.
+	entries, generation := f.getDNSCacheEntries()
+	f.saveDNSCache(entries, generation)
+}
+
+func (f *fqdnController) syncDNSCache() {
+	ctx, cancel := context.WithTimeout(context.Background(), fqdnCacheSharingTimeout)
+	defer cancel()
+	if f.dnsCacheSharer != nil {
+		// Apply the entries learned by other Nodes first. They only take effect for the FQDNs selected by the rules
+		// enforced on this Node, and are not added to localDNSEntryCache, so they are not reported as the entries
+		// learned by this Node.
+		if sharedEntries, err := f.dnsCacheSharer.list(ctx); err != nil {
+			klog.ErrorS(err, "Failed to get the FQDN cache entries learned by other Nodes")
+		} else {
+			for i := range sharedEntries {
+				entry := &sharedEntries[i]
+				if entry.ExpirationTime.After(time.Now()) {
+					f.updateDNSEntry(entry.FQDN, f.entryToResponseIPs(entry), entry.ExpirationTime, false, nil)
+				}
+			}
+		}
+	}
+	entries, generation := f.getDNSCacheEntries()
+	f.saveDNSCache(entries, generation)
+	if f.dnsCacheSharer != nil {
+		if generation == f.dnsCacheSharer.reportedGeneration && time.Since(f.dnsCacheSharer.reportedTime) < fqdnCacheReportResyncInterval {
+			return
+		}
+		if err := f.dnsCacheSharer.report(ctx, entries); err != nil {
+			klog.ErrorS(err, "Failed to report the FQDN cache entries to antrea-controller")
+			return
+		}
+		f.dnsCacheSharer.reportedGeneration = generation
+		f.dnsCacheSharer.reportedTime = time.Now()
+	}
+}
+
+// saveDNSCache saves the entries to file if localDNSEntryCache has been updated since it was saved last time.
+func (f *fqdnController) saveDNSCache(entries []fqdncache.Entry, generation uint64) {
+	if f.dnsCacheStore == nil || generation == f.dnsCacheSavedGeneration {
+		return
+	}
+	if err := f.dnsCacheStore.save(entries); err != nil {
+		klog.ErrorS(err, "Failed to save FQDN cache to file")
+		return
+	}
+	f.dnsCacheSavedGeneration = generation
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"antrea.io/antrea/pkg/apiserver/handlers/fqdncache"
+	"antrea.io/antrea/pkg/client/clientset/versioned"
+	"antrea.io/antrea/pkg/util/env"
+)
+
+func TestFQDNCacheStore(t *testing.T) {
+	store := newFQDNCacheStore(afero.NewMemMapFs(), fqdnCacheFile)
+	entries, err := store.load()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	expirationTime := time.Now().Add(time.Minute).UTC().Round(time.Second)
+	expectedEntries := []fqdncache.Entry{
+		{FQDN: "test.antrea.io", IPs: []string{"1.1.1.1", "2.2.2.2"}, ExpirationTime: expirationTime},
+	}
+	require.NoError(t, store.save(expectedEntries))
+	entries, err = store.load()
+	require.NoError(t, err)
+	assert.Equal(t, expectedEntries, entries)
+}
+
+func TestRestoreDNSCache(t *testing.T) {
+	controller := gomock.NewController(t)
+	f, c := newMockFQDNController(t, controller, nil)
+	f.dnsCacheStore = newFQDNCacheStore(afero.NewMemMapFs(), fqdnCacheFile)
+	now := time.Now()
+	require.NoError(t, f.dnsCacheStore.save([]fqdncache.Entry{
+		{FQDN: "test.antrea.io", IPs: []string{"1.1.1.1", "fd00::1"}, ExpirationTime: now.Add(time.Minute)},
+		{FQDN: "expired.antrea.io", IPs: []string{"2.2.2.2"}, ExpirationTime: now.Add(-time.Minute)},
+		// IPv6 is disabled in the controller.
+		{FQDN: "ipv6.antrea.io", IPs: []string{"fd00::3"}, ExpirationTime: now.Add(time.Minute)},
+		{FQDN: "unused.antrea.io", IPs: []string{"4.4.4.4"}, ExpirationTime: now.Add(time.Minute)},
+	}))
+	require.NoError(t, f.restoreDNSCache())
+	assert.ElementsMatch(t, []string{"test.antrea.io", "unused.antrea.io"}, sets.List(sets.KeySet(f.dnsEntryCache)))
+	assert.ElementsMatch(t, []string{"test.antrea.io", "unused.antrea.io"}, sets.List(sets.KeySet(f.localDNSEntryCache)))
+
+	// The IPs restored from file are provisioned as soon as a rule selecting the FQDN is added.
+	c.EXPECT().AddAddressToDNSConjunction(dnsInterceptRuleID, gomock.Any()).Times(1)
+	require.NoError(t, f.addFQDNRule("mockRule1", []string{"test.antrea.io"}, sets.New[int32](1)))
+	assert.Equal(t, []net.IP{net.ParseIP("1.1.1.1")}, f.getIPsForFQDNSelectors([]string{"test.antrea.io"}))
+
+	// The FQDN not selected by any rule is removed instead of being queried.
+	assert.True(t, f.pruneUntrackedFQDN("test.antrea.io"))
+	assert.False(t, f.pruneUntrackedFQDN("unused.antrea.io"))
+	assert.NotContains(t, f.dnsEntryCache, "unused.antrea.io")
+	assert.NotContains(t, f.localDNSEntryCache, "unused.antrea.io")
+}
+
+func TestSyncDNSCache(t *testing.T) {
+	controller := gomock.NewController(t)
+	f, c := newMockFQDNController(t, controller, nil)
+	var dirtyRules []string
+	f.dirtyRuleHandler = func(rule string) {
+		dirtyRules = append(dirtyRules, rule)
+	}
+	fs := afero.NewMemMapFs()
+	f.dnsCacheStore = newFQDNCacheStore(fs, fqdnCacheFile)
+
+	k8sClient := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: env.GetAntreaNamespace(), Name: "antrea-agent-1", UID: "uid-1"},
+			Spec:       corev1.PodSpec{NodeName: "node1"},
+		},
+	)
+	informerFactory := informers.NewSharedInformerFactory(k8sClient, 0)
+	sharedStore := fqdncache.NewStore(informerFactory.Core().V1().Nodes())
+	handler := fqdncache.HandleFunc(sharedStore, informerFactory.Core().V1().Pods().Lister())
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	// The requests are authenticated as the antrea-agent running on node1.
+	agentUser := &user.DefaultInfo{
+		Name: fmt.Sprintf("system:serviceaccount:%s:antrea-agent", env.GetAntreaNamespace()),
+		Extra: map[string][]string{
+			serviceaccount.PodNameKey: {"antrea-agent-1"},
+			serviceaccount.PodUIDKey:  {"uid-1"},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r.WithContext(request.WithUser(r.Context(), agentUser)))
+	}))
+	defer server.Close()
+	clientset, err := versioned.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	f.dnsCacheSharer = &fqdnCacheSharer{antreaClientProvider: &antreaClientGetter{clientset}, nodeName: "node1"}
+
+	c.EXPECT().AddAddressToDNSConjunction(dnsInterceptRuleID, gomock.Any()).Times(1)
+	require.NoError(t, f.addFQDNRule("mockRule1", []string{"test.antrea.io"}, sets.New[int32](1)))
+	now := time.Now()
+	f.onDNSResponse("test.antrea.io", map[string]net.IP{"1.1.1.1": net.ParseIP("1.1.1.1")}, 60, now, nil)
+	assert.Equal(t, []string{"mockRule1"}, dirtyRules)
+
+	// The IPs learned by node2 for the FQDN selected by the rule are provisioned on node1, while the ones for other
+	// FQDNs are ignored.
+	sharedExpirationTime := now.Add(30 * time.Second).UTC()
+	require.NoError(t, sharedStore.Update(&fqdncache.Report{NodeName: "node2", Entries: []fqdncache.Entry{
+		{FQDN: "test.antrea.io", IPs: []string{"2.2.2.2"}, ExpirationTime: sharedExpirationTime},
+		{FQDN: "other.antrea.io", IPs: []string{"3.3.3.3"}, ExpirationTime: sharedExpirationTime},
+	}}))
+	f.syncDNSCache()
+	assert.Equal(t, []string{"mockRule1", "mockRule1"}, dirtyRules)
+	assert.ElementsMatch(t, []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("2.2.2.2")}, f.getIPsForFQDNSelectors([]string{"test.antrea.io"}))
+	assert.NotContains(t, f.dnsEntryCache, "other.antrea.io")
+
+	// Only the entries learned by node1 itself are saved to file and reported to antrea-controller.
+	expectedEntries, generation := f.getDNSCacheEntries()
+	require.Len(t, expectedEntries, 1)
+	assert.Equal(t, []string{"1.1.1.1"}, expectedEntries[0].IPs)
+	savedEntries, err := f.dnsCacheStore.load()
+	require.NoError(t, err)
+	assert.Equal(t, generation, f.dnsCacheSavedGeneration)
+	require.Len(t, savedEntries, 1)
+	assert.Equal(t, expectedEntries[0].IPs, savedEntries[0].IPs)
+	assert.True(t, expectedEntries[0].ExpirationTime.Equal(savedEntries[0].ExpirationTime))
+	reportedEntries := sharedStore.List("node2")
+	require.Len(t, reportedEntries, 1)
+	assert.Equal(t, expectedEntries[0].IPs, reportedEntries[0].IPs)
+	assert.Equal(t, generation, f.dnsCacheSharer.reportedGeneration)
+
+	// Syncing again doesn't update the cache.
+	f.syncDNSCache()
+	assert.Equal(t, []string{"mockRule1", "mockRule1"}, dirtyRules)
+	_, newGeneration := f.getDNSCacheEntries()
+	assert.Equal(t, generation, newGeneration)
+}
@@ -90,13 +90,18 @@ type Reconciler struct {
 	suricataTenantCache        *threadSafeInt32Set
 	suricataTenantHandlerCache *threadSafeInt32Set
 
+	// l7FlowExporterEnabled indicates whether HTTP and TLS events should be written to the event socket of the
+	// FlowExporter, in addition to the alert log.
+	l7FlowExporterEnabled bool
+
 	once sync.Once
 }
 
-func NewReconciler() *Reconciler {
+func NewReconciler(l7FlowExporterEnabled bool) *Reconciler {
 	return &Reconciler{
-		suricataScFn:    suricataSc,
-		startSuricataFn: startSuricata,
+		l7FlowExporterEnabled: l7FlowExporterEnabled,
+		suricataScFn:          suricataSc,
+		startSuricataFn:       startSuricata,
 		suricataTenantCache: &threadSafeInt32Set{
 			cached: sets.New[int32](),
 		},
@@ -210,9 +215,7 @@ func (r *Reconciler) AddRule(ruleID, policyName string, vlanID uint32, l7Protoco
 		klog.V(5).Infof("AddRule took %v", time.Since(start))
 	}()
 
-	r.once.Do(func() {
-		r.startSuricata()
-	})
+	r.StartSuricataOnce()
 
 	// Generate the keyword part used in Suricata rules.
 	protoKeywords := make(map[string]sets.Set[string])
@@ -402,7 +405,29 @@ func (r *Reconciler) unregisterSuricataTenantHandler(tenantID, vlanID uint32) (*
 	return r.suricataScFn(scCmd)
 }
 
+// StartSuricataOnce starts the Suricata instance if it has not been started yet. It is called when the first L7 rule
+// is added, or when the first Pod requests its L7 flows to be exported.
+func (r *Reconciler) StartSuricataOnce() {
+	r.once.Do(func() {
+		r.startSuricata()
+	})
+}
+
 func (r *Reconciler) startSuricata() {
+	var l7FlowExporterEveLog string
+	if r.l7FlowExporterEnabled {
+		// Suricata connects to the Unix socket of the FlowExporter and writes one event per line.
+		l7FlowExporterEveLog = fmt.Sprintf(`  - eve-log:
+      enabled: yes
+      filetype: unix_stream
+      filename: %s
+      types:
+        - http:
+            extended: yes
+        - tls:
+            extended: yes
+`, config.L7EngineEventSocketPath)
+	}
 	// Create the config file /etc/suricata/antrea.yaml for Antrea which will be included in the default Suricata config file
 	// /etc/suricata/suricata.yaml.
 	suricataAntreaConfigData := fmt.Sprintf(`%%YAML 1.1
@@ -421,7 +446,7 @@ outputs:
       types:
         - alert:
             tagged-packets: yes
-af-packet:
+%[3]saf-packet:
   - interface: %[1]s
     threads: auto
     cluster-id: 80
@@ -445,7 +470,7 @@ af-packet:
 multi-detect:
   enabled: yes
   selector: vlan
-`, config.L7NetworkPolicyTargetPortName, config.L7NetworkPolicyReturnPortName)
+`, config.L7NetworkPolicyTargetPortName, config.L7NetworkPolicyReturnPortName, l7FlowExporterEveLog)
 	f, err := defaultFS.Create(antreaSuricataConfigPath)
 	if err != nil {
 		klog.ErrorS(err, "Failed to create Suricata config file", "FilePath", antreaSuricataConfigPath)
@@ -116,22 +116,14 @@ func TestConvertProtocolTLS(t *testing.T) {
 }
 
 func TestStartSuricata(t *testing.T) {
-	defaultFS = afero.NewMemMapFs()
-	defer func() {
-		defaultFS = afero.NewOsFs()
-	}()
-
-	_, err := defaultFS.Create(defaultSuricataConfigPath)
-	assert.NoError(t, err)
-
-	fe := NewReconciler()
-	fs := newFakeSuricata()
-	fe.suricataScFn = fs.suricataScFunc
-	fe.startSuricataFn = fs.startSuricataFn
-
-	fe.startSuricata()
-
-	ok, err := afero.FileContainsBytes(defaultFS, antreaSuricataConfigPath, []byte(`---
+	testCases := []struct {
+		name                  string
+		l7FlowExporterEnabled bool
+		expectedEveLogs       string
+	}{
+		{
+			name: "L7 flow exporter disabled",
+			expectedEveLogs: `---
 outputs:
   - eve-log:
       enabled: yes
@@ -147,6 +139,48 @@ outputs:
         - alert:
             tagged-packets: yes
 af-packet:
+`,
+		},
+		{
+			name:                  "L7 flow exporter enabled",
+			l7FlowExporterEnabled: true,
+			expectedEveLogs: `        - alert:
+            tagged-packets: yes
+  - eve-log:
+      enabled: yes
+      filetype: unix_stream
+      filename: /var/run/antrea/l7engine-events.sock
+      types:
+        - http:
+            extended: yes
+        - tls:
+            extended: yes
+af-packet:
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defaultFS = afero.NewMemMapFs()
+			defer func() {
+				defaultFS = afero.NewOsFs()
+			}()
+
+			_, err := defaultFS.Create(defaultSuricataConfigPath)
+			assert.NoError(t, err)
+
+			fe := NewReconciler(tc.l7FlowExporterEnabled)
+			fs := newFakeSuricata()
+			fe.suricataScFn = fs.suricataScFunc
+			fe.startSuricataFn = fs.startSuricataFn
+
+			fe.StartSuricataOnce()
+
+			ok, err := afero.FileContainsBytes(defaultFS, antreaSuricataConfigPath, []byte(tc.expectedEveLogs))
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = afero.FileContainsBytes(defaultFS, antreaSuricataConfigPath, []byte(`af-packet:
   - interface: antrea-l7-tap0
     threads: auto
     cluster-id: 80
@@ -170,12 +204,14 @@ af-packet:
 multi-detect:
   enabled: yes
   selector: vlan`))
-	assert.NoError(t, err)
-	assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.True(t, ok)
 
-	ok, err = afero.FileContainsBytes(defaultFS, defaultSuricataConfigPath, []byte("include: /etc/suricata/antrea.yaml"))
-	assert.NoError(t, err)
-	assert.True(t, ok)
+			ok, err = afero.FileContainsBytes(defaultFS, defaultSuricataConfigPath, []byte("include: /etc/suricata/antrea.yaml"))
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
 }
 
 func TestRuleLifecycle(t *testing.T) {
@@ -221,7 +257,7 @@ func TestRuleLifecycle(t *testing.T) {
 			_, err := defaultFS.Create(defaultSuricataConfigPath)
 			assert.NoError(t, err)
 
-			fe := NewReconciler()
+			fe := NewReconciler(false)
 			fs := newFakeSuricata()
 			fe.suricataScFn = fs.suricataScFunc
 			fe.startSuricataFn = fs.startSuricataFn
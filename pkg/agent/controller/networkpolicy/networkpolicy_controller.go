@@ -16,6 +16,7 @@ package networkpolicy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -41,6 +42,7 @@ import (
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/openflow"
 	proxytypes "antrea.io/antrea/pkg/agent/proxy/types"
+	"antrea.io/antrea/pkg/agent/route"
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/controlplane/install"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
@@ -76,6 +78,9 @@ type L7RuleReconciler interface {
 
 var emptyWatch = watch.NewEmptyWatch()
 
+// errRateLimitNotSupported is reported for the rules with RateLimit action when OVS meters are not supported.
+var errRateLimitNotSupported = errors.New("RateLimit action is not supported as OVS meters are not supported on the Node, the traffic is allowed without limit")
+
 var (
 	scheme = runtime.NewScheme()
 	codecs = serializer.NewCodecFactory(scheme)
@@ -102,12 +107,17 @@ type Controller struct {
 	// ClusterNetworkPolicy are enabled.
 	antreaPolicyEnabled    bool
 	l7NetworkPolicyEnabled bool
+	// nodeNetworkPolicyEnabled indicates whether NodeNetworkPolicy is enabled.
+	nodeNetworkPolicyEnabled bool
 	// antreaProxyEnabled indicates whether Antrea proxy is enabled.
 	antreaProxyEnabled bool
 	// statusManagerEnabled indicates whether a statusManager is configured.
 	statusManagerEnabled bool
 	// multicastEnabled indicates whether multicast is enabled.
 	multicastEnabled bool
+	// ovsMetersAreSupported indicates whether the OVS datapath supports OpenFlow meters, which are required by the
+	// rules with RateLimit action.
+	ovsMetersAreSupported bool
 	// nodeType indicates type of the Node where Antrea Agent is running on.
 	nodeType config.NodeType
 	// antreaClientProvider provides interfaces to get antreaClient, which can be
@@ -125,6 +135,10 @@ type Controller struct {
 	// reconciler provides interfaces to reconcile the desired state of
 	// NetworkPolicy rules with the actual state of Openflow entries.
 	reconciler Reconciler
+	// nodeReconciler provides interfaces to reconcile the desired state of
+	// NetworkPolicy rules applied to the Node with the actual state of iptables
+	// rules and ipsets.
+	nodeReconciler *nodeReconciler
 	// l7RuleReconciler provides interfaces to reconcile the desired state of
 	// NetworkPolicy rules which have L7 rules with the actual state of Suricata rules.
 	l7RuleReconciler L7RuleReconciler
@@ -162,6 +176,7 @@ type Controller struct {
 // NewNetworkPolicyController returns a new *Controller.
 func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	ofClient openflow.Client,
+	routeClient route.Interface,
 	ifaceStore interfacestore.InterfaceStore,
 	fs afero.Fs,
 	nodeName string,
@@ -171,12 +186,15 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	groupIDUpdates <-chan string,
 	antreaPolicyEnabled bool,
 	l7NetworkPolicyEnabled bool,
+	l7Reconciler *l7engine.Reconciler,
+	nodeNetworkPolicyEnabled bool,
 	antreaProxyEnabled bool,
 	statusManagerEnabled bool,
 	multicastEnabled bool,
 	loggerOptions *AuditLoggerOptions, // use nil to disable logging
 	asyncRuleDeleteInterval time.Duration,
 	dnsServerOverride string,
+	fqdnCacheSharingEnabled bool,
 	nodeType config.NodeType,
 	v4Enabled bool,
 	v6Enabled bool,
@@ -184,22 +202,24 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	nodeConfig *config.NodeConfig) (*Controller, error) {
 	idAllocator := newIDAllocator(asyncRuleDeleteInterval, dnsInterceptRuleID)
 	c := &Controller{
-		antreaClientProvider:   antreaClientGetter,
-		queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicyrule"),
-		ofClient:               ofClient,
-		nodeType:               nodeType,
-		antreaPolicyEnabled:    antreaPolicyEnabled,
-		l7NetworkPolicyEnabled: l7NetworkPolicyEnabled,
-		antreaProxyEnabled:     antreaProxyEnabled,
-		statusManagerEnabled:   statusManagerEnabled,
-		multicastEnabled:       multicastEnabled,
-		gwPort:                 gwPort,
-		tunPort:                tunPort,
-		nodeConfig:             nodeConfig,
+		antreaClientProvider:     antreaClientGetter,
+		queue:                    workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicyrule"),
+		ofClient:                 ofClient,
+		nodeType:                 nodeType,
+		antreaPolicyEnabled:      antreaPolicyEnabled,
+		l7NetworkPolicyEnabled:   l7NetworkPolicyEnabled,
+		nodeNetworkPolicyEnabled: nodeNetworkPolicyEnabled,
+		antreaProxyEnabled:       antreaProxyEnabled,
+		statusManagerEnabled:     statusManagerEnabled,
+		multicastEnabled:         multicastEnabled,
+		ovsMetersAreSupported:    openflow.OVSMetersAreSupported(),
+		gwPort:                   gwPort,
+		tunPort:                  tunPort,
+		nodeConfig:               nodeConfig,
 	}
 
 	if l7NetworkPolicyEnabled {
-		c.l7RuleReconciler = l7engine.NewReconciler()
+		c.l7RuleReconciler = l7Reconciler
 		c.l7VlanIDAllocator = newL7VlanIDAllocator()
 	}
 
@@ -215,6 +235,9 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	}
 	c.reconciler = newReconciler(ofClient, ifaceStore, idAllocator, c.fqdnController, groupCounters,
 		v4Enabled, v6Enabled, antreaPolicyEnabled, multicastEnabled)
+	if nodeNetworkPolicyEnabled {
+		c.nodeReconciler = newNodeReconciler(routeClient, idAllocator, v4Enabled, v6Enabled)
+	}
 	c.ruleCache = newRuleCache(c.enqueueRule, podUpdateSubscriber, externalEntityUpdateSubscriber, groupIDUpdates, nodeType)
 
 	serializer := protobuf.NewSerializer(scheme, scheme)
@@ -232,6 +255,17 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	if err != nil {
 		return nil, fmt.Errorf("error creating file store for AddressGroup: %w", err)
 	}
+	if c.fqdnController != nil {
+		c.fqdnController.dnsCacheStore = newFQDNCacheStore(fs, fqdnCacheFile)
+		// Failing to restore the FQDN cache only delays the realization of FQDN rules until the FQDNs are queried
+		// again, so it shouldn't prevent the agent from starting.
+		if err := c.fqdnController.restoreDNSCache(); err != nil {
+			klog.ErrorS(err, "Failed to restore FQDN cache from file")
+		}
+		if fqdnCacheSharingEnabled {
+			c.fqdnController.dnsCacheSharer = &fqdnCacheSharer{antreaClientProvider: antreaClientGetter, nodeName: nodeName}
+		}
+	}
 
 	if statusManagerEnabled {
 		c.statusManager = newStatusController(antreaClientGetter, nodeName, c.ruleCache)
@@ -563,6 +597,20 @@ func (c *Controller) GetRuleByFlowID(ruleFlowID uint32) *types.PolicyRule {
 	return rule
 }
 
+// NodeNetworkPolicyMetrics returns the statistics of the NetworkPolicy rules applied to the Node, keyed by the IDs
+// allocated for the rules, like the ones returned by openflow.Client.NetworkPolicyMetrics.
+func (c *Controller) NodeNetworkPolicyMetrics() map[uint32]*types.RuleMetric {
+	if c.nodeReconciler == nil {
+		return nil
+	}
+	metrics, err := c.nodeReconciler.getRuleMetrics()
+	if err != nil {
+		klog.ErrorS(err, "Failed to get NodeNetworkPolicy metrics")
+		return nil
+	}
+	return metrics
+}
+
 func (c *Controller) GetControllerConnectionStatus() bool {
 	// When the watchers are connected, controller connection status is true. Otherwise, it is false.
 	return c.addressGroupWatcher.isConnected() && c.appliedToGroupWatcher.isConnected() && c.networkPolicyWatcher.isConnected()
@@ -604,6 +652,7 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 			go wait.Until(c.fqdnController.worker, time.Second, stopCh)
 		}
 		go c.fqdnController.runRuleSyncTracker(stopCh)
+		go c.fqdnController.runDNSCacheSyncer(stopCh)
 	}
 	klog.Infof("Waiting for all watchers to complete full sync")
 	c.fullSyncGroup.Wait()
@@ -624,12 +673,17 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 		go c.statusManager.Run(stopCh)
 	}
 
+	if c.nodeReconciler != nil && c.auditLogger != nil {
+		klog.Infof("Starting audit logging for NodeNetworkPolicy")
+		go c.runNodeNetworkPolicyAuditLogging(stopCh)
+	}
+
 	<-stopCh
 }
 
 func (c *Controller) matchIGMPType(r *rule, igmpType uint8, groupAddress string) bool {
 	for _, s := range r.Services {
-		if (s.IGMPType == nil || uint8(*s.IGMPType) == igmpType) && (s.GroupAddress == "" || s.GroupAddress == groupAddress) {
+		if (s.IGMPType == nil || uint8(*s.IGMPType) == igmpType) && (s.GroupAddress == "" || normalizeGroupAddress(s.GroupAddress) == groupAddress) {
 			return true
 		}
 	}
@@ -731,6 +785,11 @@ func (c *Controller) syncRule(key string) error {
 		if err := c.reconciler.Forget(key); err != nil {
 			return err
 		}
+		if c.nodeReconciler != nil {
+			if err := c.nodeReconciler.Forget(key); err != nil {
+				return err
+			}
+		}
 		if c.statusManagerEnabled {
 			// We don't know whether this is a rule owned by Antrea Policy, but
 			// harmless to delete it.
@@ -763,7 +822,20 @@ func (c *Controller) syncRule(key string) error {
 		}
 	}
 
-	err := c.reconciler.Reconcile(rule)
+	var err error
+	if rule.isNodeNetworkPolicyRule() {
+		if c.nodeReconciler == nil {
+			klog.InfoS("Ignore NodeNetworkPolicy rule since NodeNetworkPolicy feature gate is not enabled", "ruleID", key)
+			return nil
+		}
+		if rule.isAuditRule() {
+			klog.InfoS("Ignore NodeNetworkPolicy rule since Audit enforcement mode is not supported for it", "ruleID", key)
+			return nil
+		}
+		err = c.nodeReconciler.Reconcile(rule)
+	} else {
+		err = c.reconciler.Reconcile(rule)
+	}
 	if c.fqdnController != nil {
 		// No matter whether the rule reconciliation succeeds or not, fqdnController
 		// needs to be notified of the status.
@@ -773,12 +845,23 @@ func (c *Controller) syncRule(key string) error {
 	if err != nil {
 		return err
 	}
-	if c.statusManagerEnabled && v1beta2.IsSourceAntreaNativePolicy(rule.SourceRef) {
-		c.statusManager.SetRuleRealization(key, rule.PolicyUID)
-	}
+	c.setRuleRealization(rule)
 	return nil
 }
 
+// setRuleRealization reports the realization of an Antrea policy rule to the statusManager. A rule with RateLimit
+// action is reported as failed if OVS meters are not supported, as the traffic matching it is allowed without limit.
+func (c *Controller) setRuleRealization(rule *CompletedRule) {
+	if !c.statusManagerEnabled || !v1beta2.IsSourceAntreaNativePolicy(rule.SourceRef) {
+		return
+	}
+	if rule.isRateLimitRule() && !rule.isAuditRule() && !c.ovsMetersAreSupported {
+		c.statusManager.SetRuleRealizationFailure(rule.ID, rule.PolicyUID, errRateLimitNotSupported)
+		return
+	}
+	c.statusManager.SetRuleRealization(rule.ID, rule.PolicyUID)
+}
+
 // syncRules calls the reconciler to sync all the rules after watchers complete full sync.
 // After flows for those init events are installed, subsequent rules will be handled asynchronously
 // by the syncRule() function.
@@ -788,7 +871,7 @@ func (c *Controller) syncRules(keys []string) error {
 		klog.V(4).Infof("Finished syncing all rules before bookmark event (%v)", time.Since(startTime))
 	}()
 
-	var allRules []*CompletedRule
+	var allRules, allNodeRules []*CompletedRule
 	for _, key := range keys {
 		rule, effective, realizable := c.ruleCache.GetCompletedRule(key)
 		// It's normal that a rule is not effective on this Node but abnormal that it is not realizable after watchers
@@ -807,19 +890,32 @@ func (c *Controller) syncRules(keys []string) error {
 					return err
 				}
 			}
-			allRules = append(allRules, rule)
+			if rule.isNodeNetworkPolicyRule() {
+				if c.nodeReconciler == nil {
+					klog.InfoS("Ignore NodeNetworkPolicy rule since NodeNetworkPolicy feature gate is not enabled", "ruleID", key)
+					continue
+				}
+				if rule.isAuditRule() {
+					klog.InfoS("Ignore NodeNetworkPolicy rule since Audit enforcement mode is not supported for it", "ruleID", key)
+					continue
+				}
+				allNodeRules = append(allNodeRules, rule)
+			} else {
+				allRules = append(allRules, rule)
+			}
 		}
 	}
 	if err := c.reconciler.BatchReconcile(allRules); err != nil {
 		return err
 	}
-	if c.statusManagerEnabled {
-		for _, rule := range allRules {
-			if v1beta2.IsSourceAntreaNativePolicy(rule.SourceRef) {
-				c.statusManager.SetRuleRealization(rule.ID, rule.PolicyUID)
-			}
+	if len(allNodeRules) > 0 {
+		if err := c.nodeReconciler.BatchReconcile(allNodeRules); err != nil {
+			return err
 		}
 	}
+	for _, rule := range append(allRules, allNodeRules...) {
+		c.setRuleRealization(rule)
+	}
 	return nil
 }
 
@@ -37,6 +37,7 @@ import (
 	"k8s.io/component-base/metrics/legacyregistry"
 
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/controller/networkpolicy/l7engine"
 	"antrea.io/antrea/pkg/agent/metrics"
 	"antrea.io/antrea/pkg/agent/openflow"
 	proxytypes "antrea.io/antrea/pkg/agent/proxy/types"
@@ -76,7 +77,7 @@ func newTestController() (*Controller, *fake.Clientset, *mockReconciler) {
 	groupIDAllocator := openflow.NewGroupAllocator()
 	groupCounters := []proxytypes.GroupCounter{proxytypes.NewGroupCounter(groupIDAllocator, ch2)}
 	fs := afero.NewMemMapFs()
-	controller, _ := NewNetworkPolicyController(&antreaClientGetter{clientset}, nil, nil, fs, "node1", podUpdateChannel, nil, groupCounters, ch2, true, true, true, true, false, nil, testAsyncDeleteInterval, "8.8.8.8:53", config.K8sNode, true, false, config.HostGatewayOFPort, config.DefaultTunOFPort, &config.NodeConfig{})
+	controller, _ := NewNetworkPolicyController(&antreaClientGetter{clientset}, nil, nil, nil, fs, "node1", podUpdateChannel, nil, groupCounters, ch2, true, true, l7engine.NewReconciler(false), false, true, true, false, nil, testAsyncDeleteInterval, "8.8.8.8:53", false, config.K8sNode, true, false, config.HostGatewayOFPort, config.DefaultTunOFPort, &config.NodeConfig{})
 	reconciler := newMockReconciler()
 	controller.reconciler = reconciler
 	controller.auditLogger = nil
@@ -872,4 +873,92 @@ func TestValidate(t *testing.T) {
 	if item.RuleAction != v1beta1.RuleActionDrop {
 		t.Fatalf("groupAddress %s expect %v, but got %v", groupAddress2, v1beta1.RuleActionDrop, item.RuleAction)
 	}
+	// An IPv6 groupAddress is matched with the group address carried in MLD reports regardless of its textual form.
+	mldReportType := int32(0x16)
+	rule3 := &rule{
+		ID:   "rule3",
+		Name: "rule03",
+		SourceRef: &v1beta2.NetworkPolicyReference{
+			Type: v1beta2.AntreaClusterNetworkPolicy,
+		},
+		Services: []v1beta2.Service{
+			{
+				Protocol:     &proto,
+				IGMPType:     &mldReportType,
+				GroupAddress: "FF05:0::1:3",
+			},
+		},
+		Action:          &actionDrop,
+		AppliedToGroups: []string{"appliedToGroup01"},
+		Priority:        2,
+		TierPriority:    &tierPriority01,
+		PolicyPriority:  &policyPriority01,
+		Direction:       v1beta2.DirectionOut,
+	}
+	controller.ruleCache.rules.Add(rule3)
+	groupAddress3 := "ff05::1:3"
+	item, err = controller.GetIGMPNPRuleInfo("pod1", "ns1", net.ParseIP(groupAddress3), 0x16)
+	if err != nil {
+		t.Fatalf("failed to validate group %s %+v", groupAddress3, err)
+	}
+	if item == nil || item.RuleAction != v1beta1.RuleActionDrop {
+		t.Fatalf("groupAddress %s expect %v, but got %+v", groupAddress3, v1beta1.RuleActionDrop, item)
+	}
+}
+
+func TestSetRuleRealization(t *testing.T) {
+	actionAllow := v1beta1.RuleActionAllow
+	actionRateLimit := v1beta1.RuleActionRateLimit
+	anpRef := &v1beta2.NetworkPolicyReference{Type: v1beta2.AntreaNetworkPolicy, Namespace: "ns1", Name: "anp1"}
+	k8sNPRef := &v1beta2.NetworkPolicyReference{Type: v1beta2.K8sNetworkPolicy, Namespace: "ns1", Name: "np1"}
+	tests := []struct {
+		name                  string
+		rule                  *rule
+		ovsMetersAreSupported bool
+		expectedRealized      bool
+		expectedFailure       string
+	}{
+		{
+			name:             "allow rule",
+			rule:             &rule{ID: "rule1", SourceRef: anpRef, Action: &actionAllow},
+			expectedRealized: true,
+		},
+		{
+			name:                  "rate limit rule with meters",
+			rule:                  &rule{ID: "rule1", SourceRef: anpRef, Action: &actionRateLimit},
+			ovsMetersAreSupported: true,
+			expectedRealized:      true,
+		},
+		{
+			name:             "rate limit rule without meters",
+			rule:             &rule{ID: "rule1", SourceRef: anpRef, Action: &actionRateLimit},
+			expectedRealized: true,
+			expectedFailure:  errRateLimitNotSupported.Error(),
+		},
+		{
+			name:             "audit rate limit rule without meters",
+			rule:             &rule{ID: "rule1", SourceRef: anpRef, Action: &actionRateLimit, EnforcementMode: v1beta1.EnforcementModeAudit},
+			expectedRealized: true,
+		},
+		{
+			name: "K8s NetworkPolicy rule",
+			rule: &rule{ID: "rule1", SourceRef: k8sNPRef},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusController, _, _ := newTestStatusController()
+			c := &Controller{
+				statusManagerEnabled:  true,
+				statusManager:         statusController,
+				ovsMetersAreSupported: tt.ovsMetersAreSupported,
+			}
+			c.setRuleRealization(&CompletedRule{rule: tt.rule})
+			obj, exists, _ := statusController.realizedRules.GetByKey(tt.rule.ID)
+			assert.Equal(t, tt.expectedRealized, exists)
+			if exists {
+				assert.Equal(t, tt.expectedFailure, obj.(*realizedRule).failureMessage)
+			}
+		})
+	}
 }
@@ -0,0 +1,566 @@
+//go:build linux
+// +build linux
+
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/route"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/util/iptables"
+	"antrea.io/antrea/pkg/agent/util/nflog"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	secv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+	"antrea.io/antrea/pkg/util/ip"
+)
+
+const (
+	// nodeNetworkPolicyNFLOGGroup is the NFLOG group used by the iptables rules of NodeNetworkPolicy to send the
+	// packets to be logged to antrea-agent.
+	nodeNetworkPolicyNFLOGGroup = 41
+
+	ipv4AnyCIDR = "0.0.0.0/0"
+	ipv6AnyCIDR = "::/0"
+)
+
+// nodePolicyLastRealized is the struct cached by nodeReconciler. It's used to track the actual state of the iptables
+// chains and ipsets created for a NodeNetworkPolicy rule.
+type nodePolicyLastRealized struct {
+	// ruleID is the ID of the CompletedRule.
+	ruleID string
+	// direction is the direction of the rule, which determines the core chain the rule chain is attached to.
+	direction v1beta2.Direction
+	// priority is used to sort the rule chains in the core chains.
+	priority types.Priority
+	// policyName is the string representation of the policy the rule belongs to.
+	policyName string
+	// flowID is the ID allocated by idAllocator for the rule. It's used to identify the rule in statistics and audit
+	// logs, like the Openflow-based rules.
+	flowID uint32
+	// ipsets stores the names of the ipsets created for the rule, keyed by IP family (true for IPv6).
+	ipsets map[bool]string
+	// installedFamilies stores the IP families (true for IPv6) the rule chain has been installed for.
+	installedFamilies sets.Set[bool]
+}
+
+// nodeReconciler implements Reconciler for the NetworkPolicy rules applied to the Node. The rules are realized with
+// iptables and ipsets in the host network namespace instead of Openflow entries, as the traffic sent to or from the
+// Node itself doesn't always go through OVS.
+//
+// For each rule, an ipset is created per IP family to hold the IPs of the peers, and a chain is created to hold the
+// iptables rules matching the Services of the rule. The rule chains are referenced by the core chains in priority
+// order, which are hooked to the INPUT and OUTPUT chains of the filter table.
+type nodeReconciler struct {
+	ipv4Enabled bool
+	ipv6Enabled bool
+	routeClient route.Interface
+	idAllocator *idAllocator
+	// lastRealizeds caches the last realized rules. It's a mapping from ruleID to *nodePolicyLastRealized.
+	lastRealizeds sync.Map
+	// mutex serializes the updates of the core chains, which are shared by all rules.
+	mutex sync.Mutex
+}
+
+// newNodeReconciler returns a new *nodeReconciler.
+func newNodeReconciler(routeClient route.Interface, idAllocator *idAllocator, ipv4Enabled, ipv6Enabled bool) *nodeReconciler {
+	return &nodeReconciler{
+		ipv4Enabled: ipv4Enabled,
+		ipv6Enabled: ipv6Enabled,
+		routeClient: routeClient,
+		idAllocator: idAllocator,
+	}
+}
+
+// RunIDAllocatorWorker is a no-op as the worker of the shared idAllocator is run by the Pod reconciler.
+func (r *nodeReconciler) RunIDAllocatorWorker(stopCh <-chan struct{}) {
+}
+
+// GetRuleByFlowID returns the rule from the async rule cache in idAllocator cache.
+func (r *nodeReconciler) GetRuleByFlowID(ruleFlowID uint32) (*types.PolicyRule, bool, error) {
+	return r.idAllocator.getRuleFromAsyncCache(ruleFlowID)
+}
+
+// Reconcile checks whether the provided rule has been enforced or not, and creates or updates the iptables chains
+// and ipsets of the rule accordingly.
+func (r *nodeReconciler) Reconcile(rule *CompletedRule) error {
+	klog.InfoS("Reconciling NodeNetworkPolicy rule", "rule", rule.ID, "policy", rule.SourceRef.ToString())
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.reconcileLocked(rule)
+}
+
+// BatchReconcile reconciles the provided rules one by one. The number of rules applied to the Node is expected to be
+// small, so there is no need to batch the iptables operations.
+func (r *nodeReconciler) BatchReconcile(rules []*CompletedRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, rule := range rules {
+		if err := r.reconcileLocked(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *nodeReconciler) reconcileLocked(rule *CompletedRule) error {
+	var lastRealized *nodePolicyLastRealized
+	if value, exists := r.lastRealizeds.Load(rule.ID); exists {
+		lastRealized = value.(*nodePolicyLastRealized)
+	} else {
+		policyRule := &types.PolicyRule{
+			Direction:     rule.Direction,
+			Service:       rule.Services,
+			Action:        rule.Action,
+			Name:          rule.Name,
+			PolicyRef:     rule.SourceRef,
+			EnableLogging: rule.EnableLogging,
+			LogLabel:      rule.LogLabel,
+		}
+		if err := r.idAllocator.allocateForRule(policyRule); err != nil {
+			return fmt.Errorf("error allocating ID for NodeNetworkPolicy rule %s: %v", rule.ID, err)
+		}
+		lastRealized = &nodePolicyLastRealized{
+			ruleID:            rule.ID,
+			direction:         rule.Direction,
+			priority:          *nodeRulePriority(rule),
+			policyName:        rule.SourceRef.ToString(),
+			flowID:            policyRule.FlowID,
+			ipsets:            make(map[bool]string),
+			installedFamilies: sets.New[bool](),
+		}
+		r.lastRealizeds.Store(rule.ID, lastRealized)
+	}
+
+	for _, isIPv6 := range r.ipFamilies() {
+		if err := r.reconcileFamily(rule, lastRealized, isIPv6); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileFamily installs the ipset and the iptables chain of the rule for the provided IP family. As the ruleID is
+// computed from all the fields of the rule except the peer members, the content of the rule chain never changes once
+// it's installed, and only the ipset needs to be updated afterwards.
+func (r *nodeReconciler) reconcileFamily(rule *CompletedRule, lastRealized *nodePolicyLastRealized, isIPv6 bool) error {
+	peerEntries, matchAny := r.computePeerEntries(rule, isIPv6)
+	ipsetName := ""
+	if !matchAny {
+		ipsetName = getNodePolicyIPSetName(rule.ID, isIPv6)
+		if err := r.routeClient.AddOrUpdateNodeNetworkPolicyIPSet(ipsetName, peerEntries, isIPv6); err != nil {
+			return fmt.Errorf("error updating ipset %s for NodeNetworkPolicy rule %s: %v", ipsetName, rule.ID, err)
+		}
+		lastRealized.ipsets[isIPv6] = ipsetName
+	}
+	if lastRealized.installedFamilies.Has(isIPv6) {
+		return nil
+	}
+
+	ruleChain := getNodePolicyRuleChainName(rule.ID)
+	ruleChainRules := buildNodePolicyRuleChainRules(rule, ipsetName, lastRealized.flowID, isIPv6)
+	// Mark the family as installed before computing the core chain, so that the rule chain is referenced by it. It's
+	// reverted if the iptables rules fail to be installed.
+	lastRealized.installedFamilies.Insert(isIPv6)
+	coreChain, coreChainRules := r.buildCoreChainRules(rule.Direction, isIPv6)
+	if err := r.routeClient.AddOrUpdateNodeNetworkPolicyIPTables([]string{ruleChain, coreChain}, [][]string{ruleChainRules, coreChainRules}, isIPv6); err != nil {
+		lastRealized.installedFamilies.Delete(isIPv6)
+		return fmt.Errorf("error installing iptables rules for NodeNetworkPolicy rule %s: %v", rule.ID, err)
+	}
+	return nil
+}
+
+// Forget removes the iptables chains and ipsets of the provided rule.
+func (r *nodeReconciler) Forget(ruleID string) error {
+	klog.InfoS("Forgetting NodeNetworkPolicy rule", "rule", ruleID)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	value, exists := r.lastRealizeds.Load(ruleID)
+	if !exists {
+		// No-op if the rule was not realized before.
+		return nil
+	}
+	lastRealized := value.(*nodePolicyLastRealized)
+	ruleChain := getNodePolicyRuleChainName(ruleID)
+	for _, isIPv6 := range lastRealized.installedFamilies.UnsortedList() {
+		// Remove the reference to the rule chain from the core chain before deleting the rule chain.
+		lastRealized.installedFamilies.Delete(isIPv6)
+		coreChain, coreChainRules := r.buildCoreChainRules(lastRealized.direction, isIPv6)
+		if err := r.routeClient.AddOrUpdateNodeNetworkPolicyIPTables([]string{coreChain}, [][]string{coreChainRules}, isIPv6); err != nil {
+			lastRealized.installedFamilies.Insert(isIPv6)
+			return fmt.Errorf("error updating iptables chain %s: %v", coreChain, err)
+		}
+		if err := r.routeClient.DeleteNodeNetworkPolicyIPTables([]string{ruleChain}, isIPv6); err != nil {
+			return fmt.Errorf("error deleting iptables chain %s: %v", ruleChain, err)
+		}
+	}
+	for isIPv6, ipsetName := range lastRealized.ipsets {
+		if err := r.routeClient.DeleteNodeNetworkPolicyIPSet(ipsetName, isIPv6); err != nil {
+			return fmt.Errorf("error deleting ipset %s: %v", ipsetName, err)
+		}
+		delete(lastRealized.ipsets, isIPv6)
+	}
+	r.idAllocator.forgetRule(lastRealized.flowID)
+	r.lastRealizeds.Delete(ruleID)
+	return nil
+}
+
+// getRuleMetrics maps the counters of the rule chains to the flowIDs of the rules. Only the first packet of a
+// connection is evaluated by the rule chains, as established connections are accepted by the core chains, so the
+// number of packets is also the number of sessions.
+func (r *nodeReconciler) getRuleMetrics() (map[uint32]*types.RuleMetric, error) {
+	counters, err := r.routeClient.GetNodeNetworkPolicyIPTablesCounters()
+	if err != nil {
+		return nil, err
+	}
+	metrics := make(map[uint32]*types.RuleMetric)
+	r.lastRealizeds.Range(func(_, value interface{}) bool {
+		lastRealized := value.(*nodePolicyLastRealized)
+		if counter, ok := counters[getNodePolicyRuleChainName(lastRealized.ruleID)]; ok {
+			metrics[lastRealized.flowID] = &types.RuleMetric{
+				Bytes:    counter.Bytes,
+				Packets:  counter.Packets,
+				Sessions: counter.Packets,
+			}
+		}
+		return true
+	})
+	return metrics, nil
+}
+
+func (r *nodeReconciler) ipFamilies() []bool {
+	var families []bool
+	if r.ipv4Enabled {
+		families = append(families, false)
+	}
+	if r.ipv6Enabled {
+		families = append(families, true)
+	}
+	return families
+}
+
+// computePeerEntries returns the ipset entries of the peers of the rule for the provided IP family. If the rule
+// matches any address of the IP family, true is returned and the ipset is not needed.
+func (r *nodeReconciler) computePeerEntries(rule *CompletedRule, isIPv6 bool) (sets.Set[string], bool) {
+	peer, members := rule.From, rule.FromAddresses
+	if rule.Direction == v1beta2.DirectionOut {
+		peer, members = rule.To, rule.ToAddresses
+	}
+	// An empty peer means any address.
+	if len(peer.AddressGroups) == 0 && len(peer.IPBlocks) == 0 {
+		return nil, true
+	}
+
+	entries := sets.New[string]()
+	for _, member := range members {
+		for _, memberIP := range member.IPs {
+			ipAddr := net.IP(memberIP)
+			if (ipAddr.To4() == nil) == isIPv6 {
+				entries.Insert(ipAddr.String())
+			}
+		}
+	}
+	for _, b := range peer.IPBlocks {
+		blockCIDR := ip.IPNetToNetIPNet(&b.CIDR)
+		if (blockCIDR.IP.To4() == nil) != isIPv6 {
+			continue
+		}
+		exceptIPNets := make([]*net.IPNet, 0, len(b.Except))
+		for i := range b.Except {
+			exceptIPNets = append(exceptIPNets, ip.IPNetToNetIPNet(&b.Except[i]))
+		}
+		diffCIDRs, err := ip.DiffFromCIDRs(blockCIDR, exceptIPNets)
+		if err != nil {
+			klog.ErrorS(err, "Error when computing effective CIDRs by removing except IPNets from IPBlock")
+			continue
+		}
+		for _, d := range diffCIDRs {
+			// ipset of type hash:net doesn't accept a CIDR with zero prefix length, which means any address.
+			if cidr := d.String(); cidr == ipv4AnyCIDR || cidr == ipv6AnyCIDR {
+				return nil, true
+			}
+			entries.Insert(d.String())
+		}
+	}
+	return entries, false
+}
+
+// buildCoreChainRules returns the core chain of the provided direction and its rules, which return established
+// connections to the caller and jump to the installed rule chains of the IP family in priority order. Established
+// connections are returned instead of accepted, so that they are still evaluated by the other rules of the host.
+func (r *nodeReconciler) buildCoreChainRules(direction v1beta2.Direction, isIPv6 bool) (string, []string) {
+	coreChain := config.NodeNetworkPolicyIngressRulesChain
+	if direction == v1beta2.DirectionOut {
+		coreChain = config.NodeNetworkPolicyEgressRulesChain
+	}
+	var lastRealizeds []*nodePolicyLastRealized
+	r.lastRealizeds.Range(func(_, value interface{}) bool {
+		lastRealized := value.(*nodePolicyLastRealized)
+		if lastRealized.direction == direction && lastRealized.installedFamilies.Has(isIPv6) {
+			lastRealizeds = append(lastRealizeds, lastRealized)
+		}
+		return true
+	})
+	sort.Slice(lastRealizeds, func(i, j int) bool {
+		// Priority.Less returns true if the priority has lower precedence.
+		if lastRealizeds[i].priority.Equals(lastRealizeds[j].priority) {
+			return lastRealizeds[i].ruleID < lastRealizeds[j].ruleID
+		}
+		return lastRealizeds[j].priority.Less(lastRealizeds[i].priority)
+	})
+
+	coreChainRules := []string{
+		strings.Join([]string{"-m", "conntrack", "--ctstate", "ESTABLISHED,RELATED", "-j", iptables.ReturnTarget}, " "),
+	}
+	for _, lastRealized := range lastRealizeds {
+		coreChainRules = append(coreChainRules, strings.Join([]string{
+			"-m", "comment", "--comment", fmt.Sprintf(`"Antrea: %s"`, lastRealized.policyName),
+			"-j", getNodePolicyRuleChainName(lastRealized.ruleID),
+		}, " "))
+	}
+	return coreChain, coreChainRules
+}
+
+// buildNodePolicyRuleChainRules returns the rules of the rule chain. Each Service of the rule is translated to an
+// iptables rule, which is preceded by an NFLOG rule if logging is enabled.
+func buildNodePolicyRuleChainRules(rule *CompletedRule, ipsetName string, flowID uint32, isIPv6 bool) []string {
+	var peerMatch []string
+	if ipsetName != "" {
+		ipsetDirection := "src"
+		if rule.Direction == v1beta2.DirectionOut {
+			ipsetDirection = "dst"
+		}
+		peerMatch = []string{"-m", "set", "--match-set", ipsetName, ipsetDirection}
+	}
+	serviceMatches := buildServiceMatches(rule.Services, isIPv6)
+	target := getNodePolicyRuleTarget(rule.Action)
+
+	var rules []string
+	for _, serviceMatch := range serviceMatches {
+		match := append(append([]string{}, peerMatch...), serviceMatch...)
+		if rule.EnableLogging {
+			rules = append(rules, strings.Join(append(append([]string{}, match...),
+				"-j", iptables.NFLOGTarget,
+				"--nflog-group", strconv.Itoa(nodeNetworkPolicyNFLOGGroup),
+				"--nflog-prefix", strconv.FormatUint(uint64(flowID), 10)), " "))
+		}
+		rules = append(rules, strings.Join(append(match, "-j", target), " "))
+	}
+	return rules
+}
+
+// buildServiceMatches translates the Services of a rule to iptables matches. An empty list of Services means any
+// Service. Services which cannot be matched with iptables, e.g. named ports, are skipped.
+func buildServiceMatches(services []v1beta2.Service, isIPv6 bool) [][]string {
+	if len(services) == 0 {
+		return [][]string{{}}
+	}
+	var matches [][]string
+	for _, svc := range services {
+		protocol := v1beta2.ProtocolTCP
+		if svc.Protocol != nil {
+			protocol = *svc.Protocol
+		}
+		switch protocol {
+		case v1beta2.ProtocolTCP, v1beta2.ProtocolUDP, v1beta2.ProtocolSCTP:
+			match := []string{"-p", strings.ToLower(string(protocol))}
+			if svc.Port != nil {
+				if svc.Port.Type == intstr.String {
+					klog.V(2).InfoS("Named port is not supported by NodeNetworkPolicy, skipping it", "port", svc.Port.StrVal)
+					continue
+				}
+				match = append(match, "--dport", portRange(svc.Port.IntVal, svc.EndPort))
+			}
+			if svc.SrcPort != nil {
+				match = append(match, "--sport", portRange(*svc.SrcPort, svc.SrcEndPort))
+			}
+			matches = append(matches, match)
+		case v1beta2.ProtocolICMP:
+			match := []string{"-p", "icmp"}
+			typeOption := "--icmp-type"
+			if isIPv6 {
+				match = []string{"-p", "ipv6-icmp"}
+				typeOption = "--icmpv6-type"
+			}
+			if svc.ICMPType != nil {
+				icmpType := strconv.Itoa(int(*svc.ICMPType))
+				if svc.ICMPCode != nil {
+					icmpType = fmt.Sprintf("%s/%d", icmpType, *svc.ICMPCode)
+				}
+				match = append(match, typeOption, icmpType)
+			}
+			matches = append(matches, match)
+		case v1beta2.ProtocolIGMP:
+			if !isIPv6 {
+				matches = append(matches, []string{"-p", "igmp"})
+			}
+		}
+	}
+	return matches
+}
+
+func portRange(port int32, endPort *int32) string {
+	if endPort != nil && *endPort > port {
+		return fmt.Sprintf("%d:%d", port, *endPort)
+	}
+	return strconv.Itoa(int(port))
+}
+
+func getNodePolicyRuleTarget(action *secv1beta1.RuleAction) string {
+	if action != nil {
+		switch *action {
+		case secv1beta1.RuleActionDrop:
+			return iptables.DROPTarget
+		case secv1beta1.RuleActionReject:
+			return iptables.RejectTarget
+		}
+	}
+	return iptables.AcceptTarget
+}
+
+func nodeRulePriority(rule *CompletedRule) *types.Priority {
+	p := &types.Priority{RulePriority: rule.Priority}
+	if rule.TierPriority != nil {
+		p.TierPriority = *rule.TierPriority
+	}
+	if rule.PolicyPriority != nil {
+		p.PolicyPriority = *rule.PolicyPriority
+	}
+	return p
+}
+
+func getNodePolicyRuleChainName(ruleID string) string {
+	return fmt.Sprintf("%s-%s", config.NodeNetworkPolicyPrefix, strings.ToUpper(ruleID))
+}
+
+func getNodePolicyIPSetName(ruleID string, isIPv6 bool) string {
+	if isIPv6 {
+		return fmt.Sprintf("%s-%s-6", config.NodeNetworkPolicyPrefix, strings.ToUpper(ruleID))
+	}
+	return fmt.Sprintf("%s-%s-4", config.NodeNetworkPolicyPrefix, strings.ToUpper(ruleID))
+}
+
+// runNodeNetworkPolicyAuditLogging receives the packets logged by the NFLOG rules of NodeNetworkPolicy and writes them
+// to the audit log. It will not return until stopCh is closed.
+func (c *Controller) runNodeNetworkPolicyAuditLogging(stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := nflog.Run(nodeNetworkPolicyNFLOGGroup, c.logNodePacket, stopCh); err != nil {
+			klog.ErrorS(err, "Error when receiving packets logged by NodeNetworkPolicy")
+		}
+	}, 5*time.Second, stopCh)
+}
+
+// logNodePacket retrieves the rule from the flowID carried by the prefix of the logged packet, and writes the
+// information of the rule and the packet to the audit log.
+func (c *Controller) logNodePacket(nflogPacket *nflog.Packet) {
+	flowID, err := strconv.ParseUint(nflogPacket.Prefix, 10, 32)
+	if err != nil {
+		klog.ErrorS(err, "Invalid prefix of packet logged by NodeNetworkPolicy", "prefix", nflogPacket.Prefix)
+		return
+	}
+	rule, exists, err := c.nodeReconciler.GetRuleByFlowID(uint32(flowID))
+	if err != nil || !exists {
+		klog.V(2).InfoS("Rule not found for packet logged by NodeNetworkPolicy", "flowID", flowID)
+		return
+	}
+	packet, err := parseIPPacket(nflogPacket.Payload)
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse packet logged by NodeNetworkPolicy")
+		return
+	}
+
+	ob := &logInfo{
+		npRef:    rule.PolicyRef.ToString(),
+		ruleName: rule.Name,
+		logLabel: rule.LogLabel,
+	}
+	if rule.Direction == v1beta2.DirectionIn {
+		ob.tableName = config.NodeNetworkPolicyIngressRulesChain
+		ob.direction = "Ingress"
+	} else {
+		ob.tableName = config.NodeNetworkPolicyEgressRulesChain
+		ob.direction = "Egress"
+	}
+	if rule.Action != nil {
+		ob.disposition = string(*rule.Action)
+	}
+	if c.nodeConfig != nil {
+		ob.appliedToRef = c.nodeConfig.Name
+	}
+	fillLogInfoPlaceholders([]*string{&ob.ruleName, &ob.logLabel, &ob.disposition, &ob.ofPriority, &ob.appliedToRef})
+	getPacketInfo(packet, ob)
+	c.auditLogger.LogDedupPacket(ob)
+}
+
+// parseIPPacket parses the IP header and the transport ports of a packet starting from the network header. IPv6
+// extension headers are not parsed, in which case the ports are left empty.
+func parseIPPacket(data []byte) (*binding.Packet, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty packet")
+	}
+	packet := &binding.Packet{}
+	var l4Data []byte
+	switch data[0] >> 4 {
+	case 4:
+		if len(data) < 20 {
+			return nil, fmt.Errorf("invalid IPv4 packet length %d", len(data))
+		}
+		headerLength := int(data[0]&0x0f) * 4
+		packet.IPLength = binary.BigEndian.Uint16(data[2:4])
+		packet.IPProto = data[9]
+		packet.SourceIP = net.IP(data[12:16])
+		packet.DestinationIP = net.IP(data[16:20])
+		if len(data) > headerLength {
+			l4Data = data[headerLength:]
+		}
+	case 6:
+		if len(data) < 40 {
+			return nil, fmt.Errorf("invalid IPv6 packet length %d", len(data))
+		}
+		packet.IsIPv6 = true
+		packet.IPLength = binary.BigEndian.Uint16(data[4:6]) + 40
+		packet.IPProto = data[6]
+		packet.SourceIP = net.IP(data[8:24])
+		packet.DestinationIP = net.IP(data[24:40])
+		l4Data = data[40:]
+	default:
+		return nil, fmt.Errorf("unknown IP version %d", data[0]>>4)
+	}
+	switch packet.IPProto {
+	case ip.TCPProtocol, ip.UDPProtocol, ip.SCTPProtocol:
+		if len(l4Data) >= 4 {
+			packet.SourcePort = binary.BigEndian.Uint16(l4Data[0:2])
+			packet.DestinationPort = binary.BigEndian.Uint16(l4Data[2:4])
+		}
+	}
+	return packet, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"antrea.io/antrea/pkg/agent/config"
+	routetest "antrea.io/antrea/pkg/agent/route/testing"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	secv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+)
+
+var (
+	nodeMember1     = &v1beta2.GroupMember{Node: &v1beta2.NodeReference{Name: "node1"}}
+	nodeTierHigh    = int32(50)
+	nodeTierLow     = int32(250)
+	nodePolicyPrio  = float64(1)
+	ruleActionDrop  = secv1beta1.RuleActionDrop
+	ruleActionAllow = secv1beta1.RuleActionAllow
+)
+
+func newTestNodeReconciler(mockRouteClient *routetest.MockInterface, ipv4Enabled, ipv6Enabled bool) *nodeReconciler {
+	return newNodeReconciler(mockRouteClient, newIDAllocator(testAsyncDeleteInterval), ipv4Enabled, ipv6Enabled)
+}
+
+func TestNodeReconcilerReconcileAndForget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRouteClient := routetest.NewMockInterface(ctrl)
+	r := newTestNodeReconciler(mockRouteClient, true, false)
+
+	_, ipNet, _ := net.ParseCIDR("10.10.0.0/16")
+	rule1 := &CompletedRule{
+		rule: &rule{
+			ID:        "rule1",
+			Direction: v1beta2.DirectionIn,
+			From: v1beta2.NetworkPolicyPeer{
+				AddressGroups: []string{"addressGroup1"},
+				IPBlocks:      []v1beta2.IPBlock{{CIDR: v1beta2.IPNet{IP: v1beta2.IPAddress(ipNet.IP), PrefixLength: 16}}},
+			},
+			Services:       []v1beta2.Service{serviceTCP80},
+			Action:         &ruleActionDrop,
+			Priority:       0,
+			PolicyPriority: &nodePolicyPrio,
+			TierPriority:   &nodeTierLow,
+			SourceRef:      &cnp1,
+		},
+		FromAddresses: addressGroup1,
+		TargetMembers: v1beta2.NewGroupMemberSet(nodeMember1),
+	}
+	rule2 := &CompletedRule{
+		rule: &rule{
+			ID:             "rule2",
+			Direction:      v1beta2.DirectionIn,
+			Services:       []v1beta2.Service{serviceTCP443},
+			Action:         &ruleActionAllow,
+			Priority:       0,
+			PolicyPriority: &nodePolicyPrio,
+			TierPriority:   &nodeTierHigh,
+			SourceRef:      &cnp1,
+			EnableLogging:  true,
+		},
+		TargetMembers: v1beta2.NewGroupMemberSet(nodeMember1),
+	}
+	establishedRule := "-m conntrack --ctstate ESTABLISHED,RELATED -j RETURN"
+	jumpRule1 := `-m comment --comment "Antrea: AntreaClusterNetworkPolicy:name1" -j ANTREA-POL-RULE1`
+	jumpRule2 := `-m comment --comment "Antrea: AntreaClusterNetworkPolicy:name1" -j ANTREA-POL-RULE2`
+
+	mockRouteClient.EXPECT().AddOrUpdateNodeNetworkPolicyIPSet("ANTREA-POL-RULE1-4", sets.New[string]("1.1.1.1", "10.10.0.0/16"), false)
+	mockRouteClient.EXPECT().AddOrUpdateNodeNetworkPolicyIPTables(
+		[]string{"ANTREA-POL-RULE1", config.NodeNetworkPolicyIngressRulesChain},
+		[][]string{
+			{"-m set --match-set ANTREA-POL-RULE1-4 src -p tcp --dport 80 -j DROP"},
+			{establishedRule, jumpRule1},
+		}, false)
+	require.NoError(t, r.Reconcile(rule1))
+
+	// rule2 has higher precedence than rule1 as it's in a Tier with higher priority.
+	mockRouteClient.EXPECT().AddOrUpdateNodeNetworkPolicyIPTables(
+		[]string{"ANTREA-POL-RULE2", config.NodeNetworkPolicyIngressRulesChain},
+		[][]string{
+			{"-p tcp --dport 443 -j NFLOG --nflog-group 41 --nflog-prefix 2", "-p tcp --dport 443 -j ACCEPT"},
+			{establishedRule, jumpRule2, jumpRule1},
+		}, false)
+	require.NoError(t, r.Reconcile(rule2))
+
+	// Only the ipset is updated when the peers of an installed rule change.
+	rule1.FromAddresses = addressGroup2
+	mockRouteClient.EXPECT().AddOrUpdateNodeNetworkPolicyIPSet("ANTREA-POL-RULE1-4", sets.New[string]("1.1.1.2", "10.10.0.0/16"), false)
+	require.NoError(t, r.Reconcile(rule1))
+
+	mockRouteClient.EXPECT().AddOrUpdateNodeNetworkPolicyIPTables(
+		[]string{config.NodeNetworkPolicyIngressRulesChain},
+		[][]string{{establishedRule, jumpRule2}}, false)
+	mockRouteClient.EXPECT().DeleteNodeNetworkPolicyIPTables([]string{"ANTREA-POL-RULE1"}, false)
+	mockRouteClient.EXPECT().DeleteNodeNetworkPolicyIPSet("ANTREA-POL-RULE1-4", false)
+	require.NoError(t, r.Forget("rule1"))
+	_, exists := r.lastRealizeds.Load("rule1")
+	assert.False(t, exists)
+	// Forgetting a rule that is not realized is a no-op.
+	require.NoError(t, r.Forget("rule1"))
+
+	mockRouteClient.EXPECT().GetNodeNetworkPolicyIPTablesCounters().Return(map[string]*types.RuleMetric{
+		"ANTREA-POL-RULE2": {Packets: 3, Bytes: 180},
+	}, nil)
+	metrics, err := r.getRuleMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]*types.RuleMetric{2: {Packets: 3, Bytes: 180, Sessions: 3}}, metrics)
+}
+
+func TestBuildServiceMatches(t *testing.T) {
+	protocolICMP := v1beta2.ProtocolICMP
+	icmpType, icmpCode := int32(8), int32(0)
+	endPort := int32(8080)
+	tests := []struct {
+		name     string
+		services []v1beta2.Service
+		isIPv6   bool
+		expected [][]string
+	}{
+		{
+			name:     "any service",
+			expected: [][]string{{}},
+		},
+		{
+			name:     "port range and named port",
+			services: []v1beta2.Service{{Protocol: &protocolTCP, Port: &port80, EndPort: &endPort}, serviceHTTP},
+			expected: [][]string{{"-p", "tcp", "--dport", "80:8080"}},
+		},
+		{
+			name:     "ICMP",
+			services: []v1beta2.Service{{Protocol: &protocolICMP, ICMPType: &icmpType, ICMPCode: &icmpCode}},
+			expected: [][]string{{"-p", "icmp", "--icmp-type", "8/0"}},
+		},
+		{
+			name:     "ICMPv6",
+			services: []v1beta2.Service{{Protocol: &protocolICMP}},
+			isIPv6:   true,
+			expected: [][]string{{"-p", "ipv6-icmp"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildServiceMatches(tt.services, tt.isIPv6))
+		})
+	}
+}
+
+func TestParseIPPacket(t *testing.T) {
+	// An IPv4 TCP packet from 10.0.0.1:12345 to 10.0.0.2:22.
+	data := []byte{
+		0x45, 0x00, 0x00, 0x3c, 0x00, 0x00, 0x40, 0x00, 0x40, 0x06, 0x00, 0x00,
+		10, 0, 0, 1,
+		10, 0, 0, 2,
+		0x30, 0x39, 0x00, 0x16,
+	}
+	packet, err := parseIPPacket(data)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", packet.SourceIP.String())
+	assert.Equal(t, "10.0.0.2", packet.DestinationIP.String())
+	assert.Equal(t, uint16(60), packet.IPLength)
+	assert.Equal(t, uint8(6), packet.IPProto)
+	assert.Equal(t, uint16(12345), packet.SourcePort)
+	assert.Equal(t, uint16(22), packet.DestinationPort)
+
+	_, err = parseIPPacket([]byte{0x45, 0x00})
+	assert.Error(t, err)
+}
@@ -73,8 +73,8 @@ func getMatchRegField(matchers *ofctrl.Matchers, field *binding.RegField) *ofctr
 // getMatch receives ofctrl matchers and table id, match field.
 // Modifies match field to Ingress/Egress register based on tableID.
 func getMatch(matchers *ofctrl.Matchers, tableID uint8, disposition uint32) *ofctrl.MatchField {
-	// Get match from CNPDenyConjIDReg if disposition is Drop or Reject.
-	if disposition == openflow.DispositionDrop || disposition == openflow.DispositionRej {
+	// Get match from CNPDenyConjIDReg if disposition is Drop or Reject, or the rule is in Audit enforcement mode.
+	if disposition == openflow.DispositionDrop || disposition == openflow.DispositionRej || isAntreaPolicyAuditTable(tableID) {
 		return getMatchRegField(matchers, openflow.APConjIDField)
 	}
 	// Get match from ingress/egress reg if disposition is Allow or Pass.
@@ -197,7 +197,7 @@ func (c *Controller) storeDenyConnection(pktIn *ofctrl.PacketIn) error {
 }
 
 func isAntreaPolicyIngressTable(tableID uint8) bool {
-	for _, table := range openflow.GetAntreaPolicyIngressTables() {
+	for _, table := range append(openflow.GetAntreaPolicyIngressTables(), openflow.AntreaPolicyIngressAuditTable) {
 		if table.IsInitialized() && table.GetID() == tableID {
 			return true
 		}
@@ -206,7 +206,16 @@ func isAntreaPolicyIngressTable(tableID uint8) bool {
 }
 
 func isAntreaPolicyEgressTable(tableID uint8) bool {
-	for _, table := range openflow.GetAntreaPolicyEgressTables() {
+	for _, table := range append(openflow.GetAntreaPolicyEgressTables(), openflow.AntreaPolicyEgressAuditTable) {
+		if table.IsInitialized() && table.GetID() == tableID {
+			return true
+		}
+	}
+	return false
+}
+
+func isAntreaPolicyAuditTable(tableID uint8) bool {
+	for _, table := range openflow.GetAntreaPolicyAuditTables() {
 		if table.IsInitialized() && table.GetID() == tableID {
 			return true
 		}
@@ -255,6 +255,13 @@ func newReconciler(ofClient openflow.Client,
 				assigner: newPriorityAssigner(false),
 			}
 		}
+		// The rules of all Tiers in Audit enforcement mode share the audit tables, in which they are ordered by Tier
+		// priority first, like the rules of the multi-tier tables.
+		for _, table := range openflow.GetAntreaPolicyAuditTables() {
+			priorityAssigners[table.GetID()] = &tablePriorityAssigner{
+				assigner: newPriorityAssigner(false),
+			}
+		}
 		if multicastEnabled {
 			for _, table := range openflow.GetAntreaMulticastEgressTables() {
 				priorityAssigners[table.GetID()] = &tablePriorityAssigner{
@@ -361,6 +368,12 @@ func (r *reconciler) getOFRuleTable(rule *CompletedRule) uint8 {
 			}
 			return openflow.EgressRuleTable.GetID()
 		}
+		if rule.isAuditRule() {
+			if rule.Direction == v1beta2.DirectionIn {
+				return openflow.AntreaPolicyIngressAuditTable.GetID()
+			}
+			return openflow.AntreaPolicyEgressAuditTable.GetID()
+		}
 		if rule.Direction == v1beta2.DirectionIn {
 			ruleTables = openflow.GetAntreaPolicyIngressTables()
 		} else {
@@ -371,14 +384,15 @@ func (r *reconciler) getOFRuleTable(rule *CompletedRule) uint8 {
 		}
 		tableID = ruleTables[1].GetID()
 	case igmp:
-		if rule.Direction == v1beta2.DirectionIn {
+		// Audit enforcement mode is not supported for IGMP and multicast rules, we leave tableID as 0 for them.
+		if rule.Direction == v1beta2.DirectionIn && !rule.isAuditRule() {
 			ruleTables = openflow.GetAntreaIGMPIngressTables()
 			tableID = ruleTables[0].GetID()
 		}
 	case multicast:
 		// Multicast NetworkPolicy only supports egress so far, we leave tableID as 0
 		// for ingress rules for multicast, later we will return empty flows for it.
-		if rule.Direction == v1beta2.DirectionOut {
+		if rule.Direction == v1beta2.DirectionOut && !rule.isAuditRule() {
 			ruleTables = openflow.GetAntreaMulticastEgressTables()
 			tableID = ruleTables[0].GetID()
 		}
@@ -583,6 +597,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 				PolicyRef:     rule.SourceRef,
 				EnableLogging: rule.EnableLogging,
 				LogLabel:      rule.LogLabel,
+				RateLimit:     rule.RateLimit,
 			}
 		}
 	} else {
@@ -614,6 +629,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 				PolicyRef:     rule.SourceRef,
 				EnableLogging: rule.EnableLogging,
 				LogLabel:      rule.LogLabel,
+				RateLimit:     rule.RateLimit,
 			}
 		}
 
@@ -639,6 +655,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 					PolicyRef:     rule.SourceRef,
 					EnableLogging: rule.EnableLogging,
 					LogLabel:      rule.LogLabel,
+					RateLimit:     rule.RateLimit,
 				}
 				ofRuleByServicesMap[svcKey] = ofRule
 			}
@@ -17,6 +17,7 @@ package networkpolicy
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,12 +38,16 @@ const (
 
 // StatusManager keeps track of the realized NetworkPolicy rules. It syncs the status of a NetworkPolicy to the
 // antrea-controller once it is realized. A policy is considered realized when all of its desired rules have been
-// realized and all of its undesired rules have been removed.
-// For each new policy, SetRuleRealization is supposed to be called for each of its desired rules while
-// DeleteRuleRealization is supposed to be called for the removed rules.
+// realized and all of its undesired rules have been removed. The realization of a policy is reported as failed if any
+// of its rules cannot be realized as desired.
+// For each new policy, SetRuleRealization or SetRuleRealizationFailure is supposed to be called for each of its desired
+// rules while DeleteRuleRealization is supposed to be called for the removed rules.
 type StatusManager interface {
 	// SetRuleRealization updates the actual status for the given NetworkPolicy rule.
 	SetRuleRealization(ruleID string, policyID types.UID)
+	// SetRuleRealizationFailure updates the actual status for the given NetworkPolicy rule which has been processed
+	// but cannot be realized as desired, with the error describing why.
+	SetRuleRealizationFailure(ruleID string, policyID types.UID, err error)
 	// DeleteRuleRealization deletes the actual status for the given NetworkPolicy rule.
 	DeleteRuleRealization(ruleID string)
 	// Resync triggers syncing status with the antrea-controller for the given NetworkPolicy.
@@ -71,6 +76,8 @@ type StatusController struct {
 type realizedRule struct {
 	ruleID   string
 	policyID types.UID
+	// failureMessage describes why the rule cannot be realized as desired. It is empty if the rule is realized.
+	failureMessage string
 }
 
 func realizedRuleKeyFunc(obj interface{}) (string, error) {
@@ -95,13 +102,21 @@ func newStatusController(antreaClientProvider agent.AntreaClientProvider, nodeNa
 }
 
 func (c *StatusController) SetRuleRealization(ruleID string, policyID types.UID) {
-	_, exists, _ := c.realizedRules.GetByKey(ruleID)
-	// This rule has been realized before. The current call must be triggered by group member updates, which doesn't
-	// affect the policy's realization status.
-	if exists {
+	c.setRuleRealization(ruleID, policyID, "")
+}
+
+func (c *StatusController) SetRuleRealizationFailure(ruleID string, policyID types.UID, err error) {
+	c.setRuleRealization(ruleID, policyID, err.Error())
+}
+
+func (c *StatusController) setRuleRealization(ruleID string, policyID types.UID, failureMessage string) {
+	obj, exists, _ := c.realizedRules.GetByKey(ruleID)
+	// This rule has been processed before with the same result. The current call must be triggered by group member
+	// updates, which doesn't affect the policy's realization status.
+	if exists && obj.(*realizedRule).failureMessage == failureMessage {
 		return
 	}
-	c.realizedRules.Add(&realizedRule{ruleID: ruleID, policyID: policyID})
+	c.realizedRules.Add(&realizedRule{ruleID: ruleID, policyID: policyID, failureMessage: failureMessage})
 	c.queue.Add(policyID)
 }
 
@@ -178,19 +193,25 @@ func (c *StatusController) syncHandler(uid types.UID) error {
 	for _, r := range desiredRules {
 		desiredRuleSet.Insert(r.ID)
 	}
+	failureMessages := sets.New[string]()
 	for _, r := range actualRules {
 		ruleID := r.(*realizedRule).ruleID
 		if !desiredRuleSet.Has(ruleID) {
 			return nil
 		}
 		desiredRuleSet.Delete(ruleID)
+		if failureMessage := r.(*realizedRule).failureMessage; failureMessage != "" {
+			failureMessages.Insert(failureMessage)
+		}
 	}
 	if len(desiredRuleSet) > 0 {
 		return nil
 	}
 
-	// At this point, all desired rules have been realized and all undesired rules have been removed, report it to the antrea-controller.
+	// At this point, all desired rules have been processed and all undesired rules have been removed, report it to the antrea-controller.
 	klog.V(2).Infof("Syncing NetworkPolicyStatus for %s, generation: %v", uid, policy.Generation)
+	// sets.List returns the sorted messages, so the status doesn't change with the order of the rules.
+	messages := sets.List(failureMessages)
 	status := &v1beta2.NetworkPolicyStatus{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: policy.Name,
@@ -199,7 +220,8 @@ func (c *StatusController) syncHandler(uid types.UID) error {
 			{
 				NodeName:           c.nodeName,
 				Generation:         policy.Generation,
-				RealizationFailure: false,
+				RealizationFailure: len(messages) > 0,
+				Message:            strings.Join(messages, "; "),
 			},
 		},
 	}
@@ -15,6 +15,7 @@
 package networkpolicy
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -178,6 +179,36 @@ func TestSyncStatusUpForUpdatedPolicy(t *testing.T) {
 	assert.NoError(t, matchGeneration(policy.Generation), "The generation should be updated to %v but was not updated", policy.Generation)
 }
 
+func TestSyncStatusForUnrealizableRule(t *testing.T) {
+	statusController, ruleCache, statusControl := newTestStatusController()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go statusController.Run(stopCh)
+
+	ruleCache.AddAppliedToGroup(newAppliedToGroup("appliedToGroup1", []v1beta2.GroupMember{*newAppliedToGroupMemberPod("pod1", "ns1")}))
+	policy := newNetworkPolicyWithMultipleRules("policy1", "uid1", []string{"addressGroup1"}, []string{}, []string{"appliedToGroup1"}, nil)
+	policy.Generation = 1
+	ruleCache.AddNetworkPolicy(policy)
+	rules := ruleCache.getEffectiveRulesByNetworkPolicy(string(policy.UID))
+	statusController.SetRuleRealization(rules[0].ID, policy.UID)
+	statusController.SetRuleRealizationFailure(rules[1].ID, policy.UID, errors.New("rule cannot be realized"))
+
+	matchStatus := func(realizationFailure bool, message string) error {
+		return wait.PollImmediate(100*time.Millisecond, 1*time.Second, func() (done bool, err error) {
+			status := statusControl.getNetworkPolicyStatus()
+			if status == nil {
+				return false, nil
+			}
+			return status.Nodes[0].RealizationFailure == realizationFailure && status.Nodes[0].Message == message, nil
+		})
+	}
+	assert.NoError(t, matchStatus(true, "rule cannot be realized"), "The realization failure should be reported")
+
+	// The rule is realized after it is processed again.
+	statusController.SetRuleRealization(rules[1].ID, policy.UID)
+	assert.NoError(t, matchStatus(false, ""), "The realization failure should be cleared")
+}
+
 // BenchmarkSyncHandler benchmarks syncHandler when the policy has 100 rules. Its current result is:
 // 47754 ns/op           15320 B/op         23 allocs/op
 func BenchmarkSyncHandler(b *testing.B) {
@@ -17,6 +17,7 @@ package noderoute
 import (
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/containernetworking/plugins/pkg/ip"
@@ -24,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -60,6 +62,7 @@ const (
 
 // Controller is responsible for setting up necessary IP routes and Openflow entries for inter-node traffic.
 type Controller struct {
+	kubeClient       clientset.Interface
 	ovsBridgeClient  ovsconfig.OVSBridgeClient
 	ofClient         openflow.Client
 	ovsCtlClient     ovsctl.OVSCtlClient
@@ -80,11 +83,16 @@ type Controller struct {
 	// or not when IPsec is enabled with "cert" mode. The NodeRouteController must wait for the certificate
 	// to be configured before installing routes/flows to peer Nodes to prevent unencrypted traffic across Nodes.
 	ipsecCertificateManager ipseccertificate.Manager
+	// acceptedWireGuardKeys saves the next WireGuard public keys of the peer Nodes which are rotating their keys and
+	// have been configured as WireGuard peers. The key is the Node name, and the value is the next public key.
+	acceptedWireGuardKeys      map[string]string
+	acceptedWireGuardKeysMutex sync.Mutex
 }
 
 // NewNodeRouteController instantiates a new Controller object which will process Node events
 // and ensure connectivity between different Nodes.
 func NewNodeRouteController(
+	kubeClient clientset.Interface,
 	nodeInformer coreinformers.NodeInformer,
 	client openflow.Client,
 	ovsCtlClient ovsctl.OVSCtlClient,
@@ -97,6 +105,7 @@ func NewNodeRouteController(
 	ipsecCertificateManager ipseccertificate.Manager,
 ) *Controller {
 	controller := &Controller{
+		kubeClient:              kubeClient,
 		ovsBridgeClient:         ovsBridgeClient,
 		ofClient:                client,
 		ovsCtlClient:            ovsCtlClient,
@@ -111,6 +120,7 @@ func NewNodeRouteController(
 		installedNodes:          cache.NewIndexer(nodeRouteInfoKeyFunc, cache.Indexers{nodeRouteInfoPodCIDRIndexName: nodeRouteInfoPodCIDRIndexFunc}),
 		wireGuardClient:         wireguardClient,
 		ipsecCertificateManager: ipsecCertificateManager,
+		acceptedWireGuardKeys:   make(map[string]string),
 	}
 	nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
@@ -143,12 +153,14 @@ func nodeRouteInfoPodCIDRIndexFunc(obj interface{}) ([]string, error) {
 
 // nodeRouteInfo is the route related information extracted from corev1.Node.
 type nodeRouteInfo struct {
-	nodeName           string
-	podCIDRs           []*net.IPNet
-	nodeIPs            *utilip.DualStackIPs
-	gatewayIPs         *utilip.DualStackIPs
-	nodeMAC            net.HardwareAddr
-	wireGuardPublicKey string
+	nodeName               string
+	podCIDRs               []*net.IPNet
+	nodeIPs                *utilip.DualStackIPs
+	gatewayIPs             *utilip.DualStackIPs
+	nodeMAC                net.HardwareAddr
+	wireGuardPublicKey     string
+	wireGuardNextPublicKey string
+	wireGuardNextKeyPort   string
 }
 
 // enqueueNode adds an object to the controller work queue
@@ -352,6 +364,11 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	for i := 0; i < defaultWorkers; i++ {
 		go wait.Until(c.worker, time.Second, stopCh)
 	}
+	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeWireGuard &&
+		c.nodeConfig.WireGuardConfig != nil && c.nodeConfig.WireGuardConfig.KeyRotationInterval > 0 {
+		keyRotator := wireguard.NewKeyRotator(c.wireGuardClient, newNodeWireGuardKeyPublisher(c.kubeClient, c.nodeLister, c.nodeConfig.Name), c.nodeConfig.WireGuardConfig.KeyRotationInterval)
+		go keyRotator.Run(stopCh)
+	}
 	<-stopCh
 }
 
@@ -465,6 +482,9 @@ func (c *Controller) deleteNodeRoute(nodeName string) error {
 		if err := c.wireGuardClient.DeletePeer(nodeName); err != nil {
 			return fmt.Errorf("delete WireGuard peer %s failed: %v", nodeName, err)
 		}
+		if err := c.updateAcceptedWireGuardKey(nodeName, ""); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -481,13 +501,17 @@ func (c *Controller) addNodeRoute(nodeName string, node *corev1.Node) error {
 		return err
 	}
 	peerWireGuardPublicKey := node.Annotations[types.NodeWireGuardPublicAnnotationKey]
+	peerWireGuardNextPublicKey := node.Annotations[types.NodeWireGuardNextPublicKeyAnnotationKey]
+	peerWireGuardNextKeyPort := node.Annotations[types.NodeWireGuardNextKeyPortAnnotationKey]
 
 	nrInfo, installed, _ := c.installedNodes.GetByKey(nodeName)
 	// Route is already added for this Node and Node MAC, transport IP
-	// and WireGuard public key are not changed.
+	// and WireGuard public keys are not changed.
 	if installed && nrInfo.(*nodeRouteInfo).nodeMAC.String() == peerNodeMAC.String() &&
 		peerNodeIPs.Equal(*nrInfo.(*nodeRouteInfo).nodeIPs) &&
-		nrInfo.(*nodeRouteInfo).wireGuardPublicKey == peerWireGuardPublicKey {
+		nrInfo.(*nodeRouteInfo).wireGuardPublicKey == peerWireGuardPublicKey &&
+		nrInfo.(*nodeRouteInfo).wireGuardNextPublicKey == peerWireGuardNextPublicKey &&
+		nrInfo.(*nodeRouteInfo).wireGuardNextKeyPort == peerWireGuardNextKeyPort {
 		return nil
 	}
 
@@ -593,13 +617,21 @@ func (c *Controller) addNodeRoute(nodeName string, node *corev1.Node) error {
 		}
 	}
 
+	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeWireGuard && peerWireGuardPublicKey != "" {
+		if err := c.acceptWireGuardNextKey(nodeName, peerWireGuardNextPublicKey, peerWireGuardNextKeyPort, peerNodeIPs, peerPodCIDRs); err != nil {
+			return err
+		}
+	}
+
 	c.installedNodes.Add(&nodeRouteInfo{
-		nodeName:           nodeName,
-		podCIDRs:           peerPodCIDRs,
-		nodeIPs:            peerNodeIPs,
-		gatewayIPs:         peerGatewayIPs,
-		nodeMAC:            peerNodeMAC,
-		wireGuardPublicKey: peerWireGuardPublicKey,
+		nodeName:               nodeName,
+		podCIDRs:               peerPodCIDRs,
+		nodeIPs:                peerNodeIPs,
+		gatewayIPs:             peerGatewayIPs,
+		nodeMAC:                peerNodeMAC,
+		wireGuardPublicKey:     peerWireGuardPublicKey,
+		wireGuardNextPublicKey: peerWireGuardNextPublicKey,
+		wireGuardNextKeyPort:   peerWireGuardNextKeyPort,
 	})
 
 	return err
@@ -0,0 +1,199 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderoute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/retry"
+
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/wireguard"
+	utilip "antrea.io/antrea/pkg/util/ip"
+)
+
+// nodeWireGuardKeyPublisher publishes the WireGuard public keys of the local Node via the annotations of the Node, and
+// tracks the peer Nodes which have accepted the next public key via the annotations of the peer Nodes.
+type nodeWireGuardKeyPublisher struct {
+	kubeClient clientset.Interface
+	nodeLister corelisters.NodeLister
+	nodeName   string
+}
+
+var _ wireguard.KeyPublisher = &nodeWireGuardKeyPublisher{}
+
+func newNodeWireGuardKeyPublisher(kubeClient clientset.Interface, nodeLister corelisters.NodeLister, nodeName string) *nodeWireGuardKeyPublisher {
+	return &nodeWireGuardKeyPublisher{
+		kubeClient: kubeClient,
+		nodeLister: nodeLister,
+		nodeName:   nodeName,
+	}
+}
+
+func (p *nodeWireGuardKeyPublisher) PublishNextKey(nextPublicKey string, nextKeyPort int) error {
+	return patchNodeAnnotations(p.kubeClient, p.nodeName, map[string]interface{}{
+		types.NodeWireGuardNextPublicKeyAnnotationKey: nextPublicKey,
+		types.NodeWireGuardNextKeyPortAnnotationKey:   strconv.Itoa(nextKeyPort),
+	})
+}
+
+func (p *nodeWireGuardKeyPublisher) GetPeersPendingNextKey(nextPublicKey string) ([]string, error) {
+	nodes, err := p.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var pendingPeers []string
+	for _, node := range nodes {
+		if node.Name == p.nodeName {
+			continue
+		}
+		// Nodes without a WireGuard public key are not WireGuard peers of the local Node.
+		if node.Annotations[types.NodeWireGuardPublicAnnotationKey] == "" {
+			continue
+		}
+		if !acceptedWireGuardKeysFromString(node.Annotations[types.NodeWireGuardAcceptedPublicKeysAnnotationKey]).Has(nextPublicKey) {
+			pendingPeers = append(pendingPeers, node.Name)
+		}
+	}
+	sort.Strings(pendingPeers)
+	return pendingPeers, nil
+}
+
+func (p *nodeWireGuardKeyPublisher) GetPeersRotatingKey() ([]string, error) {
+	nodes, err := p.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var rotatingPeers []string
+	for _, node := range nodes {
+		if node.Name == p.nodeName {
+			continue
+		}
+		if node.Annotations[types.NodeWireGuardNextPublicKeyAnnotationKey] != "" {
+			rotatingPeers = append(rotatingPeers, node.Name)
+		}
+	}
+	sort.Strings(rotatingPeers)
+	return rotatingPeers, nil
+}
+
+func (p *nodeWireGuardKeyPublisher) PublishKey(publicKey string) error {
+	return patchNodeAnnotations(p.kubeClient, p.nodeName, map[string]interface{}{
+		types.NodeWireGuardPublicAnnotationKey: publicKey,
+	})
+}
+
+func (p *nodeWireGuardKeyPublisher) WithdrawNextKey() error {
+	return patchNodeAnnotations(p.kubeClient, p.nodeName, map[string]interface{}{
+		types.NodeWireGuardNextPublicKeyAnnotationKey: nil,
+		types.NodeWireGuardNextKeyPortAnnotationKey:   nil,
+	})
+}
+
+// acceptWireGuardNextKey adds the next public key published by the peer Node before it rotates its key as a peer of
+// the WireGuard next-key device, and lets the peer Node know it has been accepted. The next-key device keeps decrypting
+// the traffic encrypted with the next key until the peer Node withdraws it, after all Nodes have switched to it. An
+// empty nextPublicKey removes the next public key of the peer Node.
+func (c *Controller) acceptWireGuardNextKey(nodeName, nextPublicKey, nextKeyPort string, peerNodeIPs *utilip.DualStackIPs, peerPodCIDRs []*net.IPNet) error {
+	var port int
+	if nextPublicKey != "" {
+		var err error
+		if port, err = strconv.Atoi(nextKeyPort); err != nil {
+			return fmt.Errorf("invalid WireGuard next-key port %q of Node %s: %w", nextKeyPort, nodeName, err)
+		}
+	}
+	peerNodeIP := peerNodeIPs.IPv4
+	if peerNodeIP == nil {
+		peerNodeIP = peerNodeIPs.IPv6
+	}
+	if err := c.wireGuardClient.UpdatePeerNextKey(nodeName, nextPublicKey, port, peerNodeIP, peerPodCIDRs); err != nil {
+		return err
+	}
+	return c.updateAcceptedWireGuardKey(nodeName, nextPublicKey)
+}
+
+// updateAcceptedWireGuardKey records that the next public key of the given peer Node has been configured as a
+// WireGuard peer, and updates the accepted public keys annotation of the local Node accordingly. An empty
+// nextPublicKey removes the record of the peer Node.
+func (c *Controller) updateAcceptedWireGuardKey(nodeName, nextPublicKey string) error {
+	c.acceptedWireGuardKeysMutex.Lock()
+	defer c.acceptedWireGuardKeysMutex.Unlock()
+	oldNextPublicKey, exists := c.acceptedWireGuardKeys[nodeName]
+	if oldNextPublicKey == nextPublicKey && (exists || nextPublicKey == "") {
+		return nil
+	}
+	if nextPublicKey == "" {
+		delete(c.acceptedWireGuardKeys, nodeName)
+	} else {
+		c.acceptedWireGuardKeys[nodeName] = nextPublicKey
+	}
+	keys := sets.New[string]()
+	for _, key := range c.acceptedWireGuardKeys {
+		keys.Insert(key)
+	}
+	var value interface{}
+	if keys.Len() > 0 {
+		value = strings.Join(sets.List(keys), ",")
+	}
+	if err := patchNodeAnnotations(c.kubeClient, c.nodeConfig.Name, map[string]interface{}{
+		types.NodeWireGuardAcceptedPublicKeysAnnotationKey: value,
+	}); err != nil {
+		if exists {
+			c.acceptedWireGuardKeys[nodeName] = oldNextPublicKey
+		} else {
+			delete(c.acceptedWireGuardKeys, nodeName)
+		}
+		return err
+	}
+	return nil
+}
+
+func acceptedWireGuardKeysFromString(value string) sets.Set[string] {
+	keys := sets.New[string]()
+	for _, key := range strings.Split(value, ",") {
+		if key != "" {
+			keys.Insert(key)
+		}
+	}
+	return keys
+}
+
+// patchNodeAnnotations patches the annotations of the Node. A nil value removes the annotation.
+func patchNodeAnnotations(kubeClient clientset.Interface, nodeName string, annotations map[string]interface{}) error {
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, err := kubeClient.CoreV1().Nodes().Patch(context.TODO(), nodeName, apitypes.MergePatchType, patch, metav1.PatchOptions{}, "status")
+		return err
+	}); err != nil {
+		return fmt.Errorf("error when patching the annotations of Node %s: %w", nodeName, err)
+	}
+	return nil
+}
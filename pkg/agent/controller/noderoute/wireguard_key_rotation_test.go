@@ -0,0 +1,148 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderoute
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/types"
+	utilip "antrea.io/antrea/pkg/util/ip"
+)
+
+const localNodeName = "localNode"
+
+func getNodeAnnotations(t *testing.T, c *fakeController, nodeName string) map[string]string {
+	node, err := c.clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	return node.Annotations
+}
+
+func TestUpdateAcceptedWireGuardKey(t *testing.T) {
+	localNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: localNodeName}}
+	c := newController(t, &config.NetworkConfig{
+		TrafficEncryptionMode: config.TrafficEncryptionModeWireGuard,
+	}, localNode)
+	defer c.queue.ShutDown()
+	c.nodeConfig.Name = localNodeName
+
+	require.NoError(t, c.updateAcceptedWireGuardKey("node1", "key1"))
+	require.NoError(t, c.updateAcceptedWireGuardKey("node2", "key2"))
+	assert.Equal(t, "key1,key2", getNodeAnnotations(t, c, localNodeName)[types.NodeWireGuardAcceptedPublicKeysAnnotationKey])
+
+	require.NoError(t, c.updateAcceptedWireGuardKey("node1", "key3"))
+	assert.Equal(t, "key2,key3", getNodeAnnotations(t, c, localNodeName)[types.NodeWireGuardAcceptedPublicKeysAnnotationKey])
+
+	require.NoError(t, c.updateAcceptedWireGuardKey("node1", ""))
+	require.NoError(t, c.updateAcceptedWireGuardKey("node2", ""))
+	assert.NotContains(t, getNodeAnnotations(t, c, localNodeName), types.NodeWireGuardAcceptedPublicKeysAnnotationKey)
+	assert.Empty(t, c.acceptedWireGuardKeys)
+}
+
+func TestNodeWireGuardKeyPublisher(t *testing.T) {
+	localNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        localNodeName,
+			Annotations: map[string]string{types.NodeWireGuardPublicAnnotationKey: "oldKey"},
+		},
+	}
+	acceptingNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acceptingNode",
+			Annotations: map[string]string{
+				types.NodeWireGuardPublicAnnotationKey:             "key1",
+				types.NodeWireGuardAcceptedPublicKeysAnnotationKey: "key3,newKey",
+			},
+		},
+	}
+	pendingNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pendingNode",
+			Annotations: map[string]string{
+				types.NodeWireGuardPublicAnnotationKey:             "key2",
+				types.NodeWireGuardNextPublicKeyAnnotationKey:      "key3",
+				types.NodeWireGuardNextKeyPortAnnotationKey:        "23456",
+				types.NodeWireGuardAcceptedPublicKeysAnnotationKey: "key3",
+			},
+		},
+	}
+	nodeWithoutWireGuard := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "nodeWithoutWireGuard"}}
+	c := newController(t, &config.NetworkConfig{
+		TrafficEncryptionMode: config.TrafficEncryptionModeWireGuard,
+	}, localNode, acceptingNode, pendingNode, nodeWithoutWireGuard)
+	defer c.queue.ShutDown()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+
+	publisher := newNodeWireGuardKeyPublisher(c.clientset, c.nodeLister, localNodeName)
+	require.NoError(t, publisher.PublishNextKey("newKey", 12345))
+	assert.Equal(t, map[string]string{
+		types.NodeWireGuardPublicAnnotationKey:        "oldKey",
+		types.NodeWireGuardNextPublicKeyAnnotationKey: "newKey",
+		types.NodeWireGuardNextKeyPortAnnotationKey:   "12345",
+	}, getNodeAnnotations(t, c, localNodeName))
+
+	pendingPeers, err := publisher.GetPeersPendingNextKey("newKey")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pendingNode"}, pendingPeers)
+
+	rotatingPeers, err := publisher.GetPeersRotatingKey()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pendingNode"}, rotatingPeers)
+
+	// The next public key is kept after the key rotation, until the peers have switched to the new key.
+	require.NoError(t, publisher.PublishKey("newKey"))
+	assert.Equal(t, map[string]string{
+		types.NodeWireGuardPublicAnnotationKey:        "newKey",
+		types.NodeWireGuardNextPublicKeyAnnotationKey: "newKey",
+		types.NodeWireGuardNextKeyPortAnnotationKey:   "12345",
+	}, getNodeAnnotations(t, c, localNodeName))
+
+	require.NoError(t, publisher.WithdrawNextKey())
+	assert.Equal(t, map[string]string{
+		types.NodeWireGuardPublicAnnotationKey: "newKey",
+	}, getNodeAnnotations(t, c, localNodeName))
+}
+
+func TestAcceptWireGuardNextKey(t *testing.T) {
+	localNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: localNodeName}}
+	c := newController(t, &config.NetworkConfig{
+		TrafficEncryptionMode: config.TrafficEncryptionModeWireGuard,
+	}, localNode)
+	defer c.queue.ShutDown()
+	c.nodeConfig.Name = localNodeName
+	peerNodeIPs := &utilip.DualStackIPs{IPv4: nodeIP1}
+
+	require.Error(t, c.acceptWireGuardNextKey("node1", "key1", "", peerNodeIPs, []*net.IPNet{podCIDR}))
+
+	c.wireguardClient.EXPECT().UpdatePeerNextKey("node1", "key1", 23456, nodeIP1, []*net.IPNet{podCIDR})
+	require.NoError(t, c.acceptWireGuardNextKey("node1", "key1", "23456", peerNodeIPs, []*net.IPNet{podCIDR}))
+	assert.Equal(t, "key1", getNodeAnnotations(t, c, localNodeName)[types.NodeWireGuardAcceptedPublicKeysAnnotationKey])
+
+	// The peer Node withdraws the next public key after the key rotation.
+	c.wireguardClient.EXPECT().UpdatePeerNextKey("node1", "", 0, nodeIP1, []*net.IPNet{podCIDR})
+	require.NoError(t, c.acceptWireGuardNextKey("node1", "", "", peerNodeIPs, []*net.IPNet{podCIDR}))
+	assert.NotContains(t, getNodeAnnotations(t, c, localNodeName), types.NodeWireGuardAcceptedPublicKeysAnnotationKey)
+}
@@ -80,6 +80,10 @@ func (f *fakeMemberlistCluster) AliveNodes() sets.Set[string] {
 	return sets.New[string](f.nodes...)
 }
 
+func (f *fakeMemberlistCluster) UnhealthyNodes(externalIPPool string) (sets.Set[string], bool) {
+	return nil, false
+}
+
 func (f *fakeMemberlistCluster) SelectNodeForIP(ip, externalIPPool string, filters ...func(string) bool) (string, error) {
 	var selectNode string
 	for _, n := range f.hashFn(f.nodes) {
@@ -228,7 +232,7 @@ func TestCreateService(t *testing.T) {
 			serviceToCreate:          servicePolicyCluster,
 			healthyNodes:             []string{fakeNode1, fakeNode2},
 			expectedCalls: func(mockIPAssigner *ipassignertest.MockIPAssigner) {
-				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP1, true)
+				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP1, nil, true)
 			},
 			expectedExternalIPStates: map[apimachinerytypes.NamespacedName]externalIPState{
 				keyFor(servicePolicyCluster): {
@@ -269,7 +273,7 @@ func TestCreateService(t *testing.T) {
 			serviceToCreate: servicePolicyLocal,
 			healthyNodes:    []string{fakeNode1, fakeNode2},
 			expectedCalls: func(mockIPAssigner *ipassignertest.MockIPAssigner) {
-				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP1, true)
+				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP1, nil, true)
 			},
 			expectedExternalIPStates: map[apimachinerytypes.NamespacedName]externalIPState{
 				keyFor(servicePolicyLocal): {
@@ -454,7 +458,7 @@ func TestUpdateService(t *testing.T) {
 			healthyNodes: []string{fakeNode1, fakeNode2},
 			expectedCalls: func(mockIPAssigner *ipassignertest.MockIPAssigner) {
 				mockIPAssigner.EXPECT().UnassignIP(fakeServiceExternalIP1)
-				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP2, true)
+				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP2, nil, true)
 			},
 			expectError: false,
 		},
@@ -472,7 +476,7 @@ func TestUpdateService(t *testing.T) {
 			},
 			healthyNodes: []string{fakeNode1, fakeNode2},
 			expectedCalls: func(mockIPAssigner *ipassignertest.MockIPAssigner) {
-				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP2, true)
+				mockIPAssigner.EXPECT().AssignIP(fakeServiceExternalIP2, nil, true)
 			},
 			expectError: false,
 		},
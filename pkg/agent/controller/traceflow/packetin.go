@@ -169,7 +169,15 @@ func (c *Controller) parsePacketIn(pktIn *ofctrl.PacketIn) (*crdv1beta1.Traceflo
 
 	obs := []crdv1beta1.Observation{}
 	tableID := pktIn.TableId
-	if tfState.isSender {
+	if tfState.isSender && tfState.externalSource {
+		// The packet from the external source is received from the
+		// Antrea gateway and classified as external traffic.
+		ob := new(crdv1beta1.Observation)
+		ob.Component = crdv1beta1.ComponentForwarding
+		ob.ComponentInfo = openflow.ClassifierTable.GetName()
+		ob.Action = crdv1beta1.ActionReceived
+		obs = append(obs, *ob)
+	} else if tfState.isSender {
 		ob := new(crdv1beta1.Observation)
 		ob.Component = crdv1beta1.ComponentSpoofGuard
 		ob.Action = crdv1beta1.ActionForwarded
@@ -186,7 +194,13 @@ func (c *Controller) parsePacketIn(pktIn *ofctrl.PacketIn) (*crdv1beta1.Traceflo
 	// - For packet is both DNATed and SNATed, the first state is also ipDst != ctNwDst (in DNAT CT zone), but the final
 	//   state is that ipSrc != ctNwSrc (in SNAT CT zone). The state in DNAT CT zone cannot be recognized in SNAT CT zone.
 	if !tfState.receiverOnly {
-		if isValidCtNw(ctNwDst) && ipDst != ctNwDst || isValidCtNw(ctNwSrc) && ipSrc != ctNwSrc {
+		if tfState.isSender && tfState.externalSource {
+			serviceObs, err := getExternalServiceObservations(matchers, ipSrc, ipDst, ctNwSrc, ctNwDst)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			obs = append(obs, serviceObs...)
+		} else if isValidCtNw(ctNwDst) && ipDst != ctNwDst || isValidCtNw(ctNwSrc) && ipSrc != ctNwSrc {
 			ob := &crdv1beta1.Observation{
 				Component:       crdv1beta1.ComponentLB,
 				Action:          crdv1beta1.ActionForwarded,
@@ -342,6 +356,76 @@ func (c *Controller) parsePacketIn(pktIn *ofctrl.PacketIn) (*crdv1beta1.Traceflo
 	return tf, &nodeResult, capturedPacket, nil
 }
 
+// getExternalServiceObservations returns the observations of the Service tables for a packet from an external source,
+// on the Node where it enters the cluster: whether it is destined for a NodePort, the Endpoint selected by ServiceLB,
+// whether the Service works in DSR mode, and whether the connection is SNATed. When the selected Endpoint is on a
+// remote Node, the remote Node is reported by the tunnel destination IP of the output observation.
+func getExternalServiceObservations(matchers *ofctrl.Matchers, ipSrc, ipDst, ctNwSrc, ctNwDst string) ([]crdv1beta1.Observation, error) {
+	var obs []crdv1beta1.Observation
+	toNodePort, err := hasRegMark(matchers, openflow.ToNodePortAddressRegMark)
+	if err != nil {
+		return nil, err
+	}
+	if toNodePort {
+		obs = append(obs, crdv1beta1.Observation{
+			Component:     crdv1beta1.ComponentLB,
+			ComponentInfo: openflow.NodePortMarkTable.GetName(),
+			Action:        crdv1beta1.ActionForwarded,
+		})
+	}
+	// When the packet is also SNATed, the CT fields are the ones of the SNAT CT zone, whose destination is already
+	// translated, so the Endpoint selection mark is used to know whether the packet was DNATed.
+	epSelected, err := hasRegMark(matchers, openflow.EpSelectedRegMark)
+	if err != nil {
+		return nil, err
+	}
+	isDNATed := epSelected || isValidCtNw(ctNwDst) && ipDst != ctNwDst
+	isSNATed := isValidCtNw(ctNwSrc) && ipSrc != ctNwSrc
+	if isDNATed {
+		obs = append(obs, crdv1beta1.Observation{
+			Component:       crdv1beta1.ComponentLB,
+			ComponentInfo:   openflow.ServiceLBTable.GetName(),
+			Action:          crdv1beta1.ActionForwarded,
+			TranslatedDstIP: ipDst,
+		})
+	}
+	// The packet of a Service working in DSR mode is forwarded to the Node of the selected Endpoint without being
+	// DNATed, and the Endpoint Node reports the selected Endpoint.
+	isDSR, err := hasRegMark(matchers, openflow.DSRServiceRegMark)
+	if err != nil {
+		return nil, err
+	}
+	if isDSR {
+		obs = append(obs, crdv1beta1.Observation{
+			Component:     crdv1beta1.ComponentLB,
+			ComponentInfo: openflow.DSRServiceMarkTable.GetName(),
+			Action:        crdv1beta1.ActionForwarded,
+		})
+	}
+	if isSNATed {
+		obs = append(obs, crdv1beta1.Observation{
+			Component:       crdv1beta1.ComponentLB,
+			ComponentInfo:   openflow.SNATTable.GetName(),
+			Action:          crdv1beta1.ActionForwarded,
+			TranslatedSrcIP: ipSrc,
+		})
+	}
+	return obs, nil
+}
+
+// hasRegMark returns whether the RegMark is loaded in the register of the packet.
+func hasRegMark(matchers *ofctrl.Matchers, mark *binding.RegMark) (bool, error) {
+	match := getMatchRegField(matchers, mark.GetField())
+	if match == nil {
+		return false, nil
+	}
+	value, err := getRegValue(match, mark.GetField().GetRange().ToNXRange())
+	if err != nil {
+		return false, err
+	}
+	return value == mark.GetValue(), nil
+}
+
 func getMatchPktMarkField(matchers *ofctrl.Matchers) *ofctrl.MatchField {
 	return matchers.GetMatchByName("NXM_NX_PKT_MARK")
 }
@@ -479,6 +563,8 @@ func parseCapturedPacket(pktIn *ofctrl.PacketIn) *crdv1beta1.Packet {
 		capturedPacket.TransportHeader.TCP = &crdv1beta1.TCPHeader{SrcPort: int32(pkt.SourcePort), DstPort: int32(pkt.DestinationPort), Flags: pointer.Int32(int32(pkt.TCPFlags))}
 	} else if pkt.IPProto == protocol.Type_UDP {
 		capturedPacket.TransportHeader.UDP = &crdv1beta1.UDPHeader{SrcPort: int32(pkt.SourcePort), DstPort: int32(pkt.DestinationPort)}
+	} else if pkt.IPProto == binding.IPProtocolSCTP {
+		capturedPacket.TransportHeader.SCTP = &crdv1beta1.SCTPHeader{SrcPort: int32(pkt.SourcePort), DstPort: int32(pkt.DestinationPort)}
 	} else if pkt.IPProto == protocol.Type_ICMP || pkt.IPProto == protocol.Type_IPv6ICMP {
 		capturedPacket.TransportHeader.ICMP = &crdv1beta1.ICMPEchoRequestHeader{ID: int32(pkt.ICMPEchoID), Sequence: int32(pkt.ICMPEchoSeq)}
 	}
@@ -35,6 +35,7 @@ import (
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
 	queriertest "antrea.io/antrea/pkg/querier/testing"
 )
 
@@ -170,6 +171,21 @@ func TestParseCapturedPacket(t *testing.T) {
 		TransportHeader: crdv1beta1.TransportHeader{ICMP: &crdv1beta1.ICMPEchoRequestHeader{ID: 1, Sequence: 123}},
 	}
 
+	sctpv6PktIn := protocol.IPv6{Length: 32, HopLimit: 64, NWSrc: srcIPv6, NWDst: dstIPv6, NextHeader: binding.IPProtocolSCTP}
+	sctp := binding.SCTP{PortSrc: 1080, PortDst: 36412}
+	bytes, _ = sctp.MarshalBinary()
+	bf = new(util.Buffer)
+	bf.UnmarshalBinary(bytes)
+	sctpv6PktIn.Data = bf
+	sctpNextHdr := int32(sctpv6PktIn.NextHeader)
+	sctpv6PktCap := crdv1beta1.Packet{
+		SrcIP: sctpv6PktIn.NWSrc.String(), DstIP: sctpv6PktIn.NWDst.String(), Length: int32(sctpv6PktIn.Length) + 40,
+		IPv6Header: &crdv1beta1.IPv6Header{NextHeader: &sctpNextHdr, HopLimit: int32(sctpv6PktIn.HopLimit)},
+		TransportHeader: crdv1beta1.TransportHeader{
+			SCTP: &crdv1beta1.SCTPHeader{SrcPort: int32(sctp.PortSrc), DstPort: int32(sctp.PortDst)},
+		},
+	}
+
 	tests := []struct {
 		name      string
 		pktInData util.Message
@@ -179,6 +195,7 @@ func TestParseCapturedPacket(t *testing.T) {
 		{"tcp", &tcpPktIn, &tcpPktCap, false},
 		{"udp", &udpPktIn, &udpPktCap, false},
 		{"icmpv6", &icmpv6PktIn, &icmpv6PktCap, true},
+		{"sctpv6", &sctpv6PktIn, &sctpv6PktCap, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -219,6 +236,24 @@ func getTestPacketBytes(dstIP string) []byte {
 	return pktBytes
 }
 
+func getTestPacketBytesWithSrc(srcIP, dstIP string) []byte {
+	ipPacket := &protocol.IPv4{
+		Version:  0x4,
+		IHL:      5,
+		Protocol: uint8(8),
+		DSCP:     1,
+		Length:   20,
+		NWSrc:    net.ParseIP(srcIP).To4(),
+		NWDst:    net.ParseIP(dstIP).To4(),
+	}
+	ethernetPkt := protocol.NewEthernet()
+	ethernetPkt.HWSrc = gatewayMAC
+	ethernetPkt.Ethertype = protocol.IPv4_MSG
+	ethernetPkt.Data = ipPacket
+	pktBytes, _ := ethernetPkt.MarshalBinary()
+	return pktBytes
+}
+
 func TestParsePacketIn(t *testing.T) {
 	xreg0 := make([]byte, 8)
 	binary.BigEndian.PutUint32(xreg0[0:4], openflow.RemoteSNATRegMark.GetValue()<<openflow.RemoteSNATRegMark.GetField().GetRange().Offset()) // RemoteSNATRegMark in 32bit reg0
@@ -264,8 +299,50 @@ func TestParsePacketIn(t *testing.T) {
 		},
 	}
 
+	xreg1 := make([]byte, 8)
+	binary.BigEndian.PutUint32(xreg1[4:8], 1) // tunnel port in 32bit reg1
+	matchOutTunPort := &openflow15.MatchField{
+		Class: openflow15.OXM_CLASS_PACKET_REGS,
+		Field: openflow15.NXM_NX_REG0,
+		Value: &openflow15.ByteArrayField{
+			Data: xreg1,
+		},
+	}
+	xreg2 := make([]byte, 8)
+	binary.BigEndian.PutUint32(xreg2[0:4], openflow.ToNodePortAddressRegMark.GetValue()<<openflow.ToNodePortAddressRegMark.GetField().GetRange().Offset()|
+		openflow.FromExternalRegMark.GetValue()<<openflow.FromExternalRegMark.GetField().GetRange().Offset()|
+		openflow.EpSelectedRegMark.GetValue()<<openflow.EpSelectedRegMark.GetField().GetRange().Offset()) // marks in 32bit reg4
+	matchNodePort := &openflow15.MatchField{
+		Class: openflow15.OXM_CLASS_PACKET_REGS,
+		Field: uint8(openflow.ToNodePortAddressRegMark.GetField().GetRegID() / 2),
+		Value: &openflow15.ByteArrayField{
+			Data: xreg2,
+		},
+	}
+	xreg2NoEndpoint := make([]byte, 8)
+	binary.BigEndian.PutUint32(xreg2NoEndpoint[0:4], openflow.FromExternalRegMark.GetValue()<<openflow.FromExternalRegMark.GetField().GetRange().Offset()) // marks in 32bit reg4
+	matchFromExternal := &openflow15.MatchField{
+		Class: openflow15.OXM_CLASS_PACKET_REGS,
+		Field: uint8(openflow.FromExternalRegMark.GetField().GetRegID() / 2),
+		Value: &openflow15.ByteArrayField{
+			Data: xreg2NoEndpoint,
+		},
+	}
+	matchCTNwSrc := &openflow15.MatchField{
+		Class: openflow15.OXM_CLASS_NXM_1,
+		Field: openflow15.NXM_NX_CT_NW_SRC,
+		Value: &openflow15.Ipv4SrcField{Ipv4Src: net.ParseIP(externalIPv4)},
+	}
+	matchCTNwDst := &openflow15.MatchField{
+		Class: openflow15.OXM_CLASS_NXM_1,
+		Field: openflow15.NXM_NX_CT_NW_DST,
+		Value: &openflow15.Ipv4DstField{Ipv4Dst: net.ParseIP(pod2IPv4)},
+	}
+	matchTunDstNode := openflow15.NewTunnelIpv4DstField(net.ParseIP(remoteNodeIPv4), nil)
+
 	pktBytesPodToIP := getTestPacketBytes(dstIPv4)
 	pktBytesPodToPod := getTestPacketBytes(pod2IPv4)
+	pktBytesExternalToPod := getTestPacketBytesWithSrc(gatewayIPv4, pod2IPv4)
 
 	tests := []struct {
 		name               string
@@ -667,6 +744,153 @@ func TestParsePacketIn(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "packet from external source at source Node for NodePort with remote Endpoint",
+			networkConfig: &config.NetworkConfig{
+				TrafficEncapMode: 0,
+			},
+			nodeConfig: &config.NodeConfig{
+				TunnelOFPort: 1,
+				GatewayConfig: &config.GatewayConfig{
+					OFPort: 2,
+				},
+			},
+			tfState: &traceflowState{
+				name:           "traceflow-external-to-nodeport",
+				tag:            1,
+				isSender:       true,
+				externalSource: true,
+			},
+			pktIn: &ofctrl.PacketIn{
+				PacketIn: &openflow15.PacketIn{
+					TableId: openflow.OutputTable.GetID(),
+					Match: openflow15.Match{
+						Fields: []openflow15.MatchField{*matchOutTunPort, *matchNodePort, *matchCTNwSrc, *matchCTNwDst, *matchTunDstNode},
+					},
+					Data: util.NewBuffer(pktBytesExternalToPod),
+				},
+			},
+			expectedCalls: func(npQuerier *queriertest.MockAgentNetworkPolicyInfoQuerier, egressQuerier *queriertest.MockEgressQuerier) {
+			},
+			expectedTf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "traceflow-external-to-nodeport",
+				},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						IP:   externalIPv4,
+						Node: "node1",
+					},
+					Destination: crdv1beta1.Destination{
+						IP: nodeIPv4,
+					},
+				},
+				Status: crdv1beta1.TraceflowStatus{
+					Phase:        crdv1beta1.Running,
+					DataplaneTag: 1,
+				},
+			},
+			expectedNodeResult: &crdv1beta1.NodeResult{
+				Observations: []crdv1beta1.Observation{
+					{
+						Component:     crdv1beta1.ComponentForwarding,
+						ComponentInfo: openflow.ClassifierTable.GetName(),
+						Action:        crdv1beta1.ActionReceived,
+					},
+					{
+						Component:     crdv1beta1.ComponentLB,
+						ComponentInfo: openflow.NodePortMarkTable.GetName(),
+						Action:        crdv1beta1.ActionForwarded,
+					},
+					{
+						Component:       crdv1beta1.ComponentLB,
+						ComponentInfo:   openflow.ServiceLBTable.GetName(),
+						Action:          crdv1beta1.ActionForwarded,
+						TranslatedDstIP: pod2IPv4,
+					},
+					{
+						Component:       crdv1beta1.ComponentLB,
+						ComponentInfo:   openflow.SNATTable.GetName(),
+						Action:          crdv1beta1.ActionForwarded,
+						TranslatedSrcIP: gatewayIPv4,
+					},
+					{
+						Component:     crdv1beta1.ComponentForwarding,
+						ComponentInfo: openflow.OutputTable.GetName(),
+						Action:        crdv1beta1.ActionForwarded,
+						TunnelDstIP:   remoteNodeIPv4,
+					},
+				},
+			},
+		},
+		{
+			name: "packet from external source at source Node SNATed without Service",
+			networkConfig: &config.NetworkConfig{
+				TrafficEncapMode: 0,
+			},
+			nodeConfig: &config.NodeConfig{
+				TunnelOFPort: 1,
+				GatewayConfig: &config.GatewayConfig{
+					OFPort: 2,
+				},
+			},
+			tfState: &traceflowState{
+				name:           "traceflow-external-to-pod",
+				tag:            1,
+				isSender:       true,
+				externalSource: true,
+			},
+			pktIn: &ofctrl.PacketIn{
+				PacketIn: &openflow15.PacketIn{
+					TableId: openflow.OutputTable.GetID(),
+					Match: openflow15.Match{
+						Fields: []openflow15.MatchField{*matchOutTunPort, *matchFromExternal, *matchCTNwSrc, *matchCTNwDst, *matchTunDstNode},
+					},
+					Data: util.NewBuffer(pktBytesExternalToPod),
+				},
+			},
+			expectedCalls: func(npQuerier *queriertest.MockAgentNetworkPolicyInfoQuerier, egressQuerier *queriertest.MockEgressQuerier) {
+			},
+			expectedTf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "traceflow-external-to-pod",
+				},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						IP:   externalIPv4,
+						Node: "node1",
+					},
+					Destination: crdv1beta1.Destination{
+						IP: pod2IPv4,
+					},
+				},
+				Status: crdv1beta1.TraceflowStatus{
+					Phase:        crdv1beta1.Running,
+					DataplaneTag: 1,
+				},
+			},
+			expectedNodeResult: &crdv1beta1.NodeResult{
+				Observations: []crdv1beta1.Observation{
+					{
+						Component:     crdv1beta1.ComponentForwarding,
+						ComponentInfo: openflow.ClassifierTable.GetName(),
+						Action:        crdv1beta1.ActionReceived,
+					},
+					{
+						Component:       crdv1beta1.ComponentLB,
+						ComponentInfo:   openflow.SNATTable.GetName(),
+						Action:          crdv1beta1.ActionForwarded,
+						TranslatedSrcIP: gatewayIPv4,
+					},
+					{
+						Component:     crdv1beta1.ComponentForwarding,
+						ComponentInfo: openflow.OutputTable.GetName(),
+						Action:        crdv1beta1.ActionForwarded,
+						TunnelDstIP:   remoteNodeIPv4,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -77,6 +77,9 @@ type traceflowState struct {
 	// Live-traffic Traceflow with only destination Pod specified.
 	receiverOnly bool
 	isSender     bool
+	// Traceflow with the packet from an external source IP injected
+	// through the Antrea gateway of the source Node.
+	externalSource bool
 	// Agent received the first Traceflow packet from OVS.
 	receivedPacket bool
 }
@@ -299,11 +302,12 @@ func (c *Controller) startTraceflow(tf *crdv1beta1.Traceflow) error {
 	}
 
 	receiverOnly := false
+	externalSource := tf.Spec.Source.Node != ""
 	var pod, ns string
 	if tf.Spec.Source.Pod != "" {
 		pod = tf.Spec.Source.Pod
 		ns = tf.Spec.Source.Namespace
-	} else {
+	} else if !externalSource {
 		// Live-traffic Traceflow with only the Destination Pod specified.
 		pod = tf.Spec.Destination.Pod
 		ns = tf.Spec.Destination.Namespace
@@ -312,18 +316,33 @@ func (c *Controller) startTraceflow(tf *crdv1beta1.Traceflow) error {
 
 	// TODO: let controller compute the sender/receiver Node, and the sender
 	// /receiver Node can just return an error, if fails to find the Pod.
-	podInterfaces := c.interfaceStore.GetContainerInterfacesByPod(pod, ns)
-	isSender := len(podInterfaces) > 0 && !receiverOnly
+	var srcInterfaces []*interfacestore.InterfaceConfig
+	if externalSource {
+		// The packet from the external source is injected through the
+		// Antrea gateway of the source Node, as if it was received on
+		// the uplink and forwarded to OVS by the host network stack.
+		if tf.Spec.Source.Node == c.nodeConfig.Name {
+			gatewayInterface, ok := c.interfaceStore.GetInterfaceByName(c.nodeConfig.GatewayConfig.Name)
+			if !ok {
+				err = fmt.Errorf("gateway interface %s not found", c.nodeConfig.GatewayConfig.Name)
+				return err
+			}
+			srcInterfaces = append(srcInterfaces, gatewayInterface)
+		}
+	} else {
+		srcInterfaces = c.interfaceStore.GetContainerInterfacesByPod(pod, ns)
+	}
+	isSender := len(srcInterfaces) > 0 && !receiverOnly
 
 	liveTraffic := tf.Spec.LiveTraffic
 	var packet, matchPacket *binding.Packet
 	var ofPort uint32
-	if len(podInterfaces) > 0 {
-		packet, err = c.preparePacket(tf, podInterfaces[0], receiverOnly)
+	if len(srcInterfaces) > 0 {
+		packet, err = c.preparePacket(tf, srcInterfaces[0], receiverOnly)
 		if err != nil {
 			return err
 		}
-		ofPort = uint32(podInterfaces[0].OFPort)
+		ofPort = uint32(srcInterfaces[0].OFPort)
 		// On the sender or receiver (the receiverOnly case) Node, trace
 		// the first packet of the first connection that matches the
 		// Traceflow spec.
@@ -338,7 +357,7 @@ func (c *Controller) startTraceflow(tf *crdv1beta1.Traceflow) error {
 	tfState := traceflowState{
 		name: tf.Name, tag: tf.Status.DataplaneTag,
 		liveTraffic: liveTraffic, droppedOnly: tf.Spec.DroppedOnly && liveTraffic,
-		receiverOnly: receiverOnly, isSender: isSender}
+		receiverOnly: receiverOnly, isSender: isSender, externalSource: externalSource}
 	c.runningTraceflows[tfState.tag] = &tfState
 	c.runningTraceflowsMutex.Unlock()
 
@@ -353,12 +372,13 @@ func (c *Controller) startTraceflow(tf *crdv1beta1.Traceflow) error {
 		return err
 	}
 
-	// Skip packet injection if the source Pod is not found on the local Node.
+	// Skip packet injection if the source Pod is not found on the local Node,
+	// or if the local Node is not the source Node of an external source.
 	if !liveTraffic && isSender {
-		if packet.DestinationMAC == nil {
-			// If the destination is Service/IP or the packet will
-			// be sent to remote Node, wait a small period for other
-			// Nodes.
+		if packet.DestinationMAC == nil || externalSource {
+			// If the destination is Service/IP, the packet will be
+			// sent to remote Node, or the packet is from an external
+			// source, wait a small period for other Nodes.
 			time.Sleep(time.Duration(injectPacketDelay) * time.Millisecond)
 		} else {
 			// Issue #2116
@@ -391,8 +411,30 @@ func (c *Controller) preparePacket(tf *crdv1beta1.Traceflow, intf *interfacestor
 	isICMP := false
 	packet := new(binding.Packet)
 	packet.IsIPv6 = tf.Spec.Packet.IPv6Header != nil
+	if tf.Spec.Packet.IPHeader == nil && tf.Spec.Packet.IPv6Header == nil {
+		// When no IP header is provided, the IP family is determined by the
+		// peer IP of the Traceflow if any, and defaults to IPv4.
+		peerIP := tf.Spec.Destination.IP
+		if receiverOnly || tf.Spec.Source.Node != "" {
+			peerIP = tf.Spec.Source.IP
+		}
+		if ip := net.ParseIP(peerIP); ip != nil && ip.To4() == nil {
+			packet.IsIPv6 = true
+		}
+	}
 	if !liveTraffic {
-		if packet.IsIPv6 {
+		if tf.Spec.Source.Node != "" {
+			// The packet comes from the external source IP, and intf is
+			// the Antrea gateway through which it is injected.
+			packet.SourceIP = net.ParseIP(tf.Spec.Source.IP)
+			if packet.SourceIP == nil {
+				return nil, errors.New("invalid source IP address")
+			}
+			isIPv6 := packet.SourceIP.To4() == nil
+			if isIPv6 != packet.IsIPv6 {
+				return nil, errors.New("source IP does not match the IP header family")
+			}
+		} else if packet.IsIPv6 {
 			packet.SourceIP = intf.GetIPv6Addr()
 			if packet.SourceIP == nil {
 				return nil, errors.New("source Pod does not have an IPv6 address")
@@ -485,6 +527,12 @@ func (c *Controller) preparePacket(tf *crdv1beta1.Traceflow, intf *interfacestor
 	} else if !liveTraffic {
 		return nil, errors.New("destination is not specified")
 	}
+	if tf.Spec.Source.Node != "" && packet.DestinationMAC == nil {
+		// The host network stack forwards the packets destined for
+		// Service IPs and remote Pod CIDRs to the Antrea gateway with the
+		// global virtual MAC as the destination MAC.
+		packet.DestinationMAC = openflow.GlobalVirtualMAC
+	}
 
 	if tf.Spec.Packet.IPv6Header != nil {
 		// IP Protocol 0 (IPv6 Hop-by-Hop Option) is not supported by
@@ -508,7 +556,7 @@ func (c *Controller) preparePacket(tf *crdv1beta1.Traceflow, intf *interfacestor
 		packet.TTL = defaultTTL
 	}
 
-	// TCP > UDP > ICMP > other IP protocol.
+	// TCP > UDP > SCTP > ICMP > other IP protocol.
 	if tf.Spec.Packet.TransportHeader.TCP != nil {
 		packet.IPProto = protocol.Type_TCP
 		packet.SourcePort = uint16(tf.Spec.Packet.TransportHeader.TCP.SrcPort)
@@ -527,6 +575,10 @@ func (c *Controller) preparePacket(tf *crdv1beta1.Traceflow, intf *interfacestor
 		packet.IPProto = protocol.Type_UDP
 		packet.SourcePort = uint16(tf.Spec.Packet.TransportHeader.UDP.SrcPort)
 		packet.DestinationPort = uint16(tf.Spec.Packet.TransportHeader.UDP.DstPort)
+	} else if tf.Spec.Packet.TransportHeader.SCTP != nil {
+		packet.IPProto = binding.IPProtocolSCTP
+		packet.SourcePort = uint16(tf.Spec.Packet.TransportHeader.SCTP.SrcPort)
+		packet.DestinationPort = uint16(tf.Spec.Packet.TransportHeader.SCTP.DstPort)
 	} else if tf.Spec.Packet.TransportHeader.ICMP != nil {
 		isICMP = true
 		if !liveTraffic {
@@ -35,6 +35,7 @@ import (
 
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/openflow"
 	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
 	"antrea.io/antrea/pkg/agent/util"
 	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
@@ -54,6 +55,12 @@ var (
 	ofPortPod1     = uint32(1)
 	ofPortPod2     = uint32(2)
 	protocolICMPv6 = int32(58)
+	gatewayIPv4    = "192.168.10.1"
+	gatewayMAC, _  = net.ParseMAC("aa:bb:cc:dd:ee:01")
+	ofPortGateway  = uint32(3)
+	externalIPv4   = "10.10.10.100"
+	nodeIPv4       = "172.18.0.2"
+	remoteNodeIPv4 = "172.18.0.3"
 
 	pod1 = v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -105,6 +112,10 @@ func newFakeTraceflowController(t *testing.T, initObjects []runtime.Object, netw
 	ifaceStore := interfacestore.NewInterfaceStore()
 	addPodInterface(ifaceStore, pod1.Namespace, pod1.Name, pod1IPv4, pod1MAC.String(), int32(ofPortPod1))
 	addPodInterface(ifaceStore, pod2.Namespace, pod2.Name, pod2IPv4, pod2MAC.String(), int32(ofPortPod2))
+	gatewayInterface := interfacestore.NewGatewayInterface("antrea-gw0", gatewayMAC)
+	gatewayInterface.IPs = []net.IP{net.ParseIP(gatewayIPv4)}
+	gatewayInterface.OVSPortConfig = &interfacestore.OVSPortConfig{OFPort: int32(ofPortGateway)}
+	ifaceStore.AddInterface(gatewayInterface)
 
 	_, serviceCIDRNet, _ := net.ParseCIDR("10.96.0.0/12")
 
@@ -473,6 +484,159 @@ func TestPreparePacket(t *testing.T) {
 				IPProto:       protocol.Type_IPv6ICMP,
 			},
 		},
+		{
+			name: "sctp packet",
+			tf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf15", UID: "uid15"},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						Namespace: pod1.Namespace,
+						Pod:       pod1.Name,
+					},
+					Destination: crdv1beta1.Destination{
+						Namespace: pod2.Namespace,
+						Pod:       pod2.Name,
+					},
+					Packet: crdv1beta1.Packet{
+						TransportHeader: crdv1beta1.TransportHeader{
+							SCTP: &crdv1beta1.SCTPHeader{
+								SrcPort: 2905,
+								DstPort: 36412,
+							},
+						},
+					},
+				},
+			},
+			expectedPacket: &binding.Packet{
+				SourceIP:        net.ParseIP(pod1IPv4),
+				SourceMAC:       pod1MAC,
+				DestinationIP:   net.ParseIP(pod2IPv4),
+				DestinationMAC:  pod2MAC,
+				IPProto:         binding.IPProtocolSCTP,
+				SourcePort:      2905,
+				DestinationPort: 36412,
+				TTL:             64,
+			},
+		},
+		{
+			name: "IPv6 family inferred from destination IP",
+			tf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf16", UID: "uid16"},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						Namespace: pod1.Namespace,
+						Pod:       pod1.Name,
+					},
+					Destination: crdv1beta1.Destination{
+						IP: "2001:db8::68",
+					},
+					LiveTraffic: true,
+					Packet: crdv1beta1.Packet{
+						TransportHeader: crdv1beta1.TransportHeader{
+							SCTP: &crdv1beta1.SCTPHeader{
+								DstPort: 36412,
+							},
+						},
+					},
+				},
+			},
+			expectedPacket: &binding.Packet{
+				IsIPv6:          true,
+				DestinationIP:   net.ParseIP("2001:db8::68"),
+				IPProto:         binding.IPProtocolSCTP,
+				DestinationPort: 36412,
+			},
+		},
+		{
+			name: "ICMPv6 echo request to IPv6 destination",
+			tf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf17", UID: "uid17"},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						Namespace: pod1.Namespace,
+						Pod:       pod1.Name,
+					},
+					Destination: crdv1beta1.Destination{
+						IP: "2001:db8::68",
+					},
+					Packet: crdv1beta1.Packet{
+						TransportHeader: crdv1beta1.TransportHeader{
+							ICMP: &crdv1beta1.ICMPEchoRequestHeader{
+								ID:       10,
+								Sequence: 20,
+							},
+						},
+					},
+				},
+			},
+			intf: &interfacestore.InterfaceConfig{
+				IPs: []net.IP{net.ParseIP(pod1IPv4), net.ParseIP("2001:db8::11")},
+				MAC: pod1MAC,
+			},
+			expectedPacket: &binding.Packet{
+				IsIPv6:        true,
+				SourceIP:      net.ParseIP("2001:db8::11"),
+				SourceMAC:     pod1MAC,
+				DestinationIP: net.ParseIP("2001:db8::68"),
+				IPProto:       protocol.Type_IPv6ICMP,
+				ICMPType:      128,
+				ICMPEchoID:    10,
+				ICMPEchoSeq:   20,
+				TTL:           64,
+			},
+		},
+		{
+			name: "external source to NodePort",
+			tf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf18", UID: "uid18"},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						IP:   externalIPv4,
+						Node: "node1",
+					},
+					Destination: crdv1beta1.Destination{
+						IP: nodeIPv4,
+					},
+					Packet: crdv1beta1.Packet{
+						TransportHeader: crdv1beta1.TransportHeader{
+							TCP: &crdv1beta1.TCPHeader{
+								DstPort: 30080,
+							},
+						},
+					},
+				},
+			},
+			intf: &interfacestore.InterfaceConfig{
+				IPs: []net.IP{net.ParseIP(gatewayIPv4)},
+				MAC: gatewayMAC,
+			},
+			expectedPacket: &binding.Packet{
+				SourceIP:        net.ParseIP(externalIPv4),
+				SourceMAC:       gatewayMAC,
+				DestinationIP:   net.ParseIP(nodeIPv4),
+				DestinationMAC:  openflow.GlobalVirtualMAC,
+				IPProto:         protocol.Type_TCP,
+				DestinationPort: 30080,
+				TCPFlags:        2,
+				TTL:             64,
+			},
+		},
+		{
+			name: "external source of a different IP family",
+			tf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf19", UID: "uid19"},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						IP:   "2001:db8::100",
+						Node: "node1",
+					},
+					Destination: crdv1beta1.Destination{
+						IP: nodeIPv4,
+					},
+				},
+			},
+			expectedErr: "destination IP does not match the IP header family",
+		},
 	}
 
 	for _, tt := range tcs {
@@ -642,6 +806,67 @@ func TestStartTraceflow(t *testing.T) {
 				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), true, false, true, &binding.Packet{DestinationMAC: pod2MAC}, ofPortPod2, uint16(crdv1beta1.DefaultTraceflowTimeout))
 			},
 		},
+		{
+			name: "external source traceflow on source Node",
+			tf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf7", UID: "uid7"},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						IP:   externalIPv4,
+						Node: "node1",
+					},
+					Destination: crdv1beta1.Destination{
+						IP: nodeIPv4,
+					},
+				},
+				Status: crdv1beta1.TraceflowStatus{
+					Phase:        crdv1beta1.Running,
+					DataplaneTag: 1,
+				},
+			},
+			nodeConfig: &config.NodeConfig{
+				Name:          "node1",
+				GatewayConfig: &config.GatewayConfig{Name: "antrea-gw0"},
+			},
+			expectedCalls: func(mockOFClient *openflowtest.MockClient) {
+				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), false, false, false, nil, ofPortGateway, uint16(crdv1beta1.DefaultTraceflowTimeout))
+				mockOFClient.EXPECT().SendTraceflowPacket(uint8(1), &binding.Packet{
+					SourceIP:       net.ParseIP(externalIPv4),
+					SourceMAC:      gatewayMAC,
+					DestinationIP:  net.ParseIP(nodeIPv4),
+					DestinationMAC: openflow.GlobalVirtualMAC,
+					IPProto:        1,
+					TTL:            64,
+					ICMPType:       8,
+				}, ofPortGateway, int32(-1))
+			},
+		},
+		{
+			name: "external source traceflow on other Node",
+			tf: &crdv1beta1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf8", UID: "uid8"},
+				Spec: crdv1beta1.TraceflowSpec{
+					Source: crdv1beta1.Source{
+						IP:   externalIPv4,
+						Node: "node1",
+					},
+					Destination: crdv1beta1.Destination{
+						IP: nodeIPv4,
+					},
+				},
+				Status: crdv1beta1.TraceflowStatus{
+					Phase:        crdv1beta1.Running,
+					DataplaneTag: 1,
+				},
+			},
+			nodeConfig: &config.NodeConfig{
+				Name:          "node2",
+				GatewayConfig: &config.GatewayConfig{Name: "antrea-gw0"},
+			},
+			expectedCalls: func(mockOFClient *openflowtest.MockClient) {
+				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), false, false, false, nil, uint32(0), uint16(crdv1beta1.DefaultTraceflowTimeout))
+			},
+		},
 	}
 
 	for _, tt := range tcs {
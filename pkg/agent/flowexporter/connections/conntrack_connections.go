@@ -16,6 +16,7 @@ package connections
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -45,6 +46,7 @@ type ConntrackConnectionStore struct {
 	networkPolicyQuerier  querier.AgentNetworkPolicyInfoQuerier
 	pollInterval          time.Duration
 	connectUplinkToBridge bool
+	l7EventMapGetter      L7EventMapGetter
 	connectionStore
 }
 
@@ -56,6 +58,7 @@ func NewConntrackConnectionStore(
 	podStore podstore.Interface,
 	proxier proxy.Proxier,
 	o *flowexporter.FlowExporterOptions,
+	l7EventMapGetter L7EventMapGetter,
 ) *ConntrackConnectionStore {
 	return &ConntrackConnectionStore{
 		connDumper:            connTrackDumper,
@@ -65,6 +68,7 @@ func NewConntrackConnectionStore(
 		pollInterval:          o.PollInterval,
 		connectionStore:       NewConnectionStore(podStore, proxier, o),
 		connectUplinkToBridge: o.ConnectUplinkToBridge,
+		l7EventMapGetter:      l7EventMapGetter,
 	}
 }
 
@@ -163,6 +167,9 @@ func (cs *ConntrackConnectionStore) Poll() ([]int, error) {
 	for _, conn := range filteredConnsList {
 		cs.AddOrUpdateConn(conn)
 	}
+	if cs.l7EventMapGetter != nil {
+		cs.fillL7EventInfo(cs.l7EventMapGetter.ConsumeL7EventMap())
+	}
 
 	cs.ReleaseConnStoreLock()
 
@@ -217,6 +224,40 @@ func (cs *ConntrackConnectionStore) addNetworkPolicyMetadata(conn *flowexporter.
 	}
 }
 
+// fillL7EventInfo adds the L7 events collected by the L7 engine to the corresponding connections. Events of connections
+// which are not in the connection store are dropped. The caller is expected to grab the lock.
+func (cs *ConntrackConnectionStore) fillL7EventInfo(l7EventMap map[flowexporter.ConnectionKey]L7ProtocolFields) {
+	for connKey, l7Fields := range l7EventMap {
+		conn, exists := cs.connections[connKey]
+		if !exists {
+			klog.V(4).InfoS("Dropping L7 event of unknown connection", "key", connKey)
+			continue
+		}
+		if len(l7Fields.HTTP) > 0 {
+			httpVals := make(map[int32]*HTTPEvent)
+			if conn.HttpVals != "" {
+				if err := json.Unmarshal([]byte(conn.HttpVals), &httpVals); err != nil {
+					klog.ErrorS(err, "Failed to decode HTTP metadata of connection", "key", connKey)
+				}
+			}
+			for txID, event := range l7Fields.HTTP {
+				httpVals[txID] = event
+			}
+			data, err := json.Marshal(httpVals)
+			if err != nil {
+				klog.ErrorS(err, "Failed to encode HTTP metadata of connection", "key", connKey)
+				continue
+			}
+			conn.AppProtocolName = eventTypeHTTP
+			conn.HttpVals = string(data)
+		}
+		if l7Fields.TLS != nil {
+			conn.AppProtocolName = eventTypeTLS
+			conn.TLSServerName = l7Fields.TLS.SNI
+		}
+	}
+}
+
 // AddOrUpdateConn updates the connection if it is already present, i.e., update timestamp, counters etc.,
 // or adds a new connection with the resolved K8s metadata.
 func (cs *ConntrackConnectionStore) AddOrUpdateConn(conn *flowexporter.Connection) {
@@ -294,6 +335,8 @@ func (cs *ConntrackConnectionStore) GetExpiredConns(expiredConns []flowexporter.
 			break
 		}
 		expiredConns = append(expiredConns, *pqItem.Conn)
+		// HTTP transactions are only exported once.
+		pqItem.Conn.HttpVals = ""
 		if flowexporter.IsConnectionDying(pqItem.Conn) {
 			// If a conntrack connection is in dying state or connection is not
 			// in the conntrack table, we set the ReadyToDelete flag to true to
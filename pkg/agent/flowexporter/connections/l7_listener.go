@@ -0,0 +1,235 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+	crdv1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	"antrea.io/antrea/pkg/util/podstore"
+)
+
+const (
+	eventTypeHTTP = "http"
+	eventTypeTLS  = "tls"
+
+	// maxEventSize is the maximum size of a single event written by the L7 engine. Events are written as one JSON
+	// object per line.
+	maxEventSize = 1024 * 1024
+)
+
+// HTTPEvent is the metadata of an HTTP transaction, as reported by the L7 engine.
+type HTTPEvent struct {
+	Hostname        string `json:"hostname"`
+	URL             string `json:"url"`
+	HTTPUserAgent   string `json:"http_user_agent,omitempty"`
+	HTTPContentType string `json:"http_content_type,omitempty"`
+	HTTPMethod      string `json:"http_method"`
+	Protocol        string `json:"protocol"`
+	Status          int32  `json:"status"`
+	Length          int32  `json:"length"`
+}
+
+// TLSEvent is the metadata of a TLS handshake, as reported by the L7 engine.
+type TLSEvent struct {
+	SNI     string `json:"sni"`
+	Version string `json:"version,omitempty"`
+}
+
+// L7ProtocolFields are the L7 events collected for a connection.
+type L7ProtocolFields struct {
+	// HTTP maps the IDs of the HTTP transactions to their metadata.
+	HTTP map[int32]*HTTPEvent
+	TLS  *TLSEvent
+}
+
+// eveEvent is an event of the EVE JSON format used by the L7 engine. Only the fields which are relevant to flow export
+// are decoded.
+type eveEvent struct {
+	EventType string     `json:"event_type"`
+	SrcIP     string     `json:"src_ip"`
+	SrcPort   uint16     `json:"src_port"`
+	DestIP    string     `json:"dest_ip"`
+	DestPort  uint16     `json:"dest_port"`
+	Proto     string     `json:"proto"`
+	TxID      int32      `json:"tx_id"`
+	HTTP      *HTTPEvent `json:"http"`
+	TLS       *TLSEvent  `json:"tls"`
+}
+
+// L7EventMapGetter is the interface to retrieve the L7 events collected for connections.
+type L7EventMapGetter interface {
+	// ConsumeL7EventMap returns the L7 events collected since the last call, keyed by connection.
+	ConsumeL7EventMap() map[flowexporter.ConnectionKey]L7ProtocolFields
+}
+
+// L7Listener collects the HTTP and TLS events written by the L7 engine to a Unix socket, for the connections of the
+// Pods annotated with types.L7FlowExporterAnnotationKey.
+type L7Listener struct {
+	l7Events   map[flowexporter.ConnectionKey]L7ProtocolFields
+	l7mut      sync.Mutex
+	socketPath string
+	podStore   podstore.Interface
+}
+
+func NewL7Listener(podStore podstore.Interface, socketPath string) *L7Listener {
+	return &L7Listener{
+		l7Events:   make(map[flowexporter.ConnectionKey]L7ProtocolFields),
+		socketPath: socketPath,
+		podStore:   podStore,
+	}
+}
+
+func (l *L7Listener) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting L7 event listener", "socket", l.socketPath)
+	wait.Until(func() {
+		if err := l.listenAndAcceptConn(stopCh); err != nil {
+			klog.ErrorS(err, "Error when listening for L7 events", "socket", l.socketPath)
+		}
+	}, 5*time.Second, stopCh)
+}
+
+func (l *L7Listener) listenAndAcceptConn(stopCh <-chan struct{}) error {
+	// Remove the socket file left over by a previous run, otherwise Listen fails.
+	if err := os.Remove(l.socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket file %s: %w", l.socketPath, err)
+	}
+	listener, err := net.Listen("unix", l.socketPath)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-stopCh
+		listener.Close()
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-stopCh:
+				return nil
+			default:
+			}
+			listener.Close()
+			return err
+		}
+		go l.handleConn(conn)
+	}
+}
+
+func (l *L7Listener) handleConn(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+	for scanner.Scan() {
+		if err := l.processLog(scanner.Bytes()); err != nil {
+			klog.V(2).ErrorS(err, "Failed to process L7 event")
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		klog.ErrorS(err, "Error when reading L7 events")
+	}
+}
+
+func (l *L7Listener) processLog(data []byte) error {
+	var event eveEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("failed to decode event: %w", err)
+	}
+	if event.EventType != eventTypeHTTP && event.EventType != eventTypeTLS {
+		return nil
+	}
+	// HTTP and TLS events are only reported for TCP connections.
+	if !strings.EqualFold(event.Proto, "tcp") {
+		return nil
+	}
+	srcIP, err := netip.ParseAddr(event.SrcIP)
+	if err != nil {
+		return fmt.Errorf("invalid source IP %s: %w", event.SrcIP, err)
+	}
+	dstIP, err := netip.ParseAddr(event.DestIP)
+	if err != nil {
+		return fmt.Errorf("invalid destination IP %s: %w", event.DestIP, err)
+	}
+	if !l.isExportRequested(srcIP, dstIP) {
+		return nil
+	}
+	connKey := flowexporter.ConnectionKey{
+		SourceAddress:      srcIP,
+		DestinationAddress: dstIP,
+		Protocol:           6,
+		SourcePort:         event.SrcPort,
+		DestinationPort:    event.DestPort,
+	}
+
+	l.l7mut.Lock()
+	defer l.l7mut.Unlock()
+	fields := l.l7Events[connKey]
+	switch event.EventType {
+	case eventTypeHTTP:
+		if event.HTTP == nil {
+			return nil
+		}
+		if fields.HTTP == nil {
+			fields.HTTP = make(map[int32]*HTTPEvent)
+		}
+		fields.HTTP[event.TxID] = event.HTTP
+	case eventTypeTLS:
+		if event.TLS == nil {
+			return nil
+		}
+		fields.TLS = event.TLS
+	}
+	l.l7Events[connKey] = fields
+	return nil
+}
+
+// isExportRequested returns whether the L7 metadata of the connection should be exported: the source Pod requests it
+// for its egress connections, or the destination Pod requests it for its ingress connections.
+func (l *L7Listener) isExportRequested(srcIP, dstIP netip.Addr) bool {
+	now := time.Now()
+	if pod, ok := l.podStore.GetPodByIPAndTime(srcIP.String(), now); ok {
+		if direction, ok := flowexporter.GetL7FlowExporterDirection(pod); ok && direction != crdv1alpha2.DirectionIngress {
+			return true
+		}
+	}
+	if pod, ok := l.podStore.GetPodByIPAndTime(dstIP.String(), now); ok {
+		if direction, ok := flowexporter.GetL7FlowExporterDirection(pod); ok && direction != crdv1alpha2.DirectionEgress {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *L7Listener) ConsumeL7EventMap() map[flowexporter.ConnectionKey]L7ProtocolFields {
+	l.l7mut.Lock()
+	defer l.l7mut.Unlock()
+	l7Events := l.l7Events
+	l.l7Events = make(map[flowexporter.ConnectionKey]L7ProtocolFields)
+	return l7Events
+}
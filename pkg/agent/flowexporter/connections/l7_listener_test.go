@@ -0,0 +1,167 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"net"
+	"net/netip"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
+	podstoretest "antrea.io/antrea/pkg/util/podstore/testing"
+)
+
+const (
+	testHTTPEvent = `{"timestamp":"2024-01-01T00:00:00.000000+0000","flow_id":1,"event_type":"http","vlan":[4094],"src_ip":"10.10.0.1","src_port":59920,"dest_ip":"10.10.0.2","dest_port":80,"proto":"TCP","tx_id":0,"http":{"hostname":"10.10.0.2","url":"/public/","http_user_agent":"curl/7.74.0","http_content_type":"text/html","http_method":"GET","protocol":"HTTP/1.1","status":200,"length":153}}`
+	testTLSEvent  = `{"timestamp":"2024-01-01T00:00:01.000000+0000","flow_id":2,"event_type":"tls","vlan":[4094],"src_ip":"10.10.0.1","src_port":59921,"dest_ip":"10.10.0.2","dest_port":443,"proto":"TCP","tls":{"sni":"www.example.com","version":"TLS 1.3"}}`
+	testFlowEvent = `{"timestamp":"2024-01-01T00:00:02.000000+0000","flow_id":3,"event_type":"flow","src_ip":"10.10.0.1","src_port":59922,"dest_ip":"10.10.0.2","dest_port":80,"proto":"TCP"}`
+)
+
+var (
+	testHTTPConnKey = flowexporter.ConnectionKey{SourceAddress: netip.MustParseAddr("10.10.0.1"), DestinationAddress: netip.MustParseAddr("10.10.0.2"), Protocol: 6, SourcePort: 59920, DestinationPort: 80}
+	testTLSConnKey  = flowexporter.ConnectionKey{SourceAddress: netip.MustParseAddr("10.10.0.1"), DestinationAddress: netip.MustParseAddr("10.10.0.2"), Protocol: 6, SourcePort: 59921, DestinationPort: 443}
+)
+
+func newL7TestPod(name, l7ExportDirection string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name}}
+	if l7ExportDirection != "" {
+		pod.Annotations = map[string]string{agenttypes.L7FlowExporterAnnotationKey: l7ExportDirection}
+	}
+	return pod
+}
+
+func TestL7ListenerProcessLog(t *testing.T) {
+	expectedHTTPEvent := &HTTPEvent{
+		Hostname:        "10.10.0.2",
+		URL:             "/public/",
+		HTTPUserAgent:   "curl/7.74.0",
+		HTTPContentType: "text/html",
+		HTTPMethod:      "GET",
+		Protocol:        "HTTP/1.1",
+		Status:          200,
+		Length:          153,
+	}
+	for _, tc := range []struct {
+		name           string
+		srcPod         *v1.Pod
+		dstPod         *v1.Pod
+		events         []string
+		expectedEvents map[flowexporter.ConnectionKey]L7ProtocolFields
+	}{
+		{
+			name:   "egress export requested by source Pod",
+			srcPod: newL7TestPod("client", "egress"),
+			events: []string{testHTTPEvent, testTLSEvent, testFlowEvent},
+			expectedEvents: map[flowexporter.ConnectionKey]L7ProtocolFields{
+				testHTTPConnKey: {HTTP: map[int32]*HTTPEvent{0: expectedHTTPEvent}},
+				testTLSConnKey:  {TLS: &TLSEvent{SNI: "www.example.com", Version: "TLS 1.3"}},
+			},
+		},
+		{
+			name:   "ingress export requested by destination Pod",
+			srcPod: newL7TestPod("client", ""),
+			dstPod: newL7TestPod("server", "Both"),
+			events: []string{testHTTPEvent},
+			expectedEvents: map[flowexporter.ConnectionKey]L7ProtocolFields{
+				testHTTPConnKey: {HTTP: map[int32]*HTTPEvent{0: expectedHTTPEvent}},
+			},
+		},
+		{
+			name:           "export not requested for the direction",
+			srcPod:         newL7TestPod("client", "ingress"),
+			dstPod:         newL7TestPod("server", "egress"),
+			events:         []string{testHTTPEvent},
+			expectedEvents: map[flowexporter.ConnectionKey]L7ProtocolFields{},
+		},
+		{
+			name:           "invalid event",
+			events:         []string{"{invalid"},
+			expectedEvents: map[flowexporter.ConnectionKey]L7ProtocolFields{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPodStore := podstoretest.NewMockInterface(ctrl)
+			mockPodStore.EXPECT().GetPodByIPAndTime("10.10.0.1", gomock.Any()).Return(tc.srcPod, tc.srcPod != nil).AnyTimes()
+			mockPodStore.EXPECT().GetPodByIPAndTime("10.10.0.2", gomock.Any()).Return(tc.dstPod, tc.dstPod != nil).AnyTimes()
+			l := NewL7Listener(mockPodStore, "")
+			for _, event := range tc.events {
+				l.processLog([]byte(event))
+			}
+			assert.Equal(t, tc.expectedEvents, l.ConsumeL7EventMap())
+			assert.Empty(t, l.ConsumeL7EventMap())
+		})
+	}
+}
+
+func TestL7ListenerRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPodStore := podstoretest.NewMockInterface(ctrl)
+	mockPodStore.EXPECT().GetPodByIPAndTime("10.10.0.1", gomock.Any()).Return(newL7TestPod("client", "egress"), true).AnyTimes()
+	socketPath := filepath.Join(t.TempDir(), "l7engine-events.sock")
+	l := NewL7Listener(mockPodStore, socketPath)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go l.Run(stopCh)
+
+	var conn net.Conn
+	require.Eventually(t, func() bool {
+		var err error
+		conn, err = net.Dial("unix", socketPath)
+		return err == nil
+	}, 2*time.Second, 50*time.Millisecond)
+	defer conn.Close()
+	_, err := conn.Write([]byte(testTLSEvent + "\n"))
+	require.NoError(t, err)
+
+	collected := make(map[flowexporter.ConnectionKey]L7ProtocolFields)
+	assert.Eventually(t, func() bool {
+		for k, v := range l.ConsumeL7EventMap() {
+			collected[k] = v
+		}
+		return len(collected) == 1
+	}, 2*time.Second, 50*time.Millisecond)
+	assert.Equal(t, "www.example.com", collected[testTLSConnKey].TLS.SNI)
+}
+
+func TestFillL7EventInfo(t *testing.T) {
+	conn := &flowexporter.Connection{
+		FlowKey:         testHTTPConnKey,
+		AppProtocolName: "http",
+		HttpVals:        `{"0":{"hostname":"10.10.0.2","url":"/public/","http_method":"GET","protocol":"HTTP/1.1","status":200,"length":153}}`,
+	}
+	cs := &ConntrackConnectionStore{
+		connectionStore: connectionStore{
+			connections: map[flowexporter.ConnectionKey]*flowexporter.Connection{testHTTPConnKey: conn},
+		},
+	}
+	cs.fillL7EventInfo(map[flowexporter.ConnectionKey]L7ProtocolFields{
+		testHTTPConnKey: {HTTP: map[int32]*HTTPEvent{1: {Hostname: "10.10.0.2", URL: "/private/", HTTPMethod: "POST", Protocol: "HTTP/1.1", Status: 403}}},
+		testTLSConnKey:  {TLS: &TLSEvent{SNI: "www.example.com"}},
+	})
+	assert.Equal(t, "http", conn.AppProtocolName)
+	assert.JSONEq(t, `{"0":{"hostname":"10.10.0.2","url":"/public/","http_method":"GET","protocol":"HTTP/1.1","status":200,"length":153},"1":{"hostname":"10.10.0.2","url":"/private/","http_method":"POST","protocol":"HTTP/1.1","status":403,"length":0}}`, conn.HttpVals)
+	// The event of a connection which is not in the store is dropped.
+	assert.Len(t, cs.connections, 1)
+}
@@ -101,6 +101,9 @@ var (
 		"flowType",
 		"egressName",
 		"egressIP",
+		"appProtocolName",
+		"httpVals",
+		"tlsServerName",
 	}
 	AntreaInfoElementsIPv4 = append(antreaInfoElementsCommon, []string{"destinationClusterIPv4"}...)
 	AntreaInfoElementsIPv6 = append(antreaInfoElementsCommon, []string{"destinationClusterIPv6"}...)
@@ -130,6 +133,7 @@ type FlowExporter struct {
 	expiredConns           []flowexporter.Connection
 	egressQuerier          querier.EgressQuerier
 	podStore               podstore.Interface
+	l7Listener             *connections.L7Listener
 }
 
 func genObservationID(nodeName string) uint32 {
@@ -171,7 +175,13 @@ func NewFlowExporter(podStore podstore.Interface, proxier proxy.Proxier, k8sClie
 
 	connTrackDumper := connections.InitializeConnTrackDumper(nodeConfig, serviceCIDRNet, serviceCIDRNetv6, ovsDatapathType, proxyEnabled)
 	denyConnStore := connections.NewDenyConnectionStore(podStore, proxier, o)
-	conntrackConnStore := connections.NewConntrackConnectionStore(connTrackDumper, v4Enabled, v6Enabled, npQuerier, podStore, proxier, o)
+	var l7Listener *connections.L7Listener
+	var l7EventMapGetter connections.L7EventMapGetter
+	if o.L7FlowExporterEnabled {
+		l7Listener = connections.NewL7Listener(podStore, config.L7EngineEventSocketPath)
+		l7EventMapGetter = l7Listener
+	}
+	conntrackConnStore := connections.NewConntrackConnectionStore(connTrackDumper, v4Enabled, v6Enabled, npQuerier, podStore, proxier, o, l7EventMapGetter)
 
 	if nodeRouteController == nil {
 		klog.InfoS("NodeRouteController is nil, will not be able to determine flow type for connections")
@@ -195,6 +205,7 @@ func NewFlowExporter(podStore podstore.Interface, proxier proxy.Proxier, k8sClie
 		expiredConns:           make([]flowexporter.Connection, 0, maxConnsToExport*2),
 		egressQuerier:          egressQuerier,
 		podStore:               podStore,
+		l7Listener:             l7Listener,
 	}, nil
 }
 
@@ -207,6 +218,11 @@ func (exp *FlowExporter) Run(stopCh <-chan struct{}) {
 	// Start the goroutine to periodically delete stale deny connections.
 	go exp.denyConnStore.RunPeriodicDeletion(stopCh)
 
+	if exp.l7Listener != nil {
+		// Start the goroutine to collect the L7 events written by the L7 engine.
+		go exp.l7Listener.Run(stopCh)
+	}
+
 	// Start the goroutine to poll conntrack flows.
 	go exp.conntrackConnStore.Run(stopCh)
 
@@ -571,6 +587,12 @@ func (exp *FlowExporter) addConnToSet(conn *flowexporter.Connection) error {
 			ie.SetStringValue(conn.EgressName)
 		case "egressIP":
 			ie.SetStringValue(conn.EgressIP)
+		case "appProtocolName":
+			ie.SetStringValue(conn.AppProtocolName)
+		case "httpVals":
+			ie.SetStringValue(conn.HttpVals)
+		case "tlsServerName":
+			ie.SetStringValue(conn.TLSServerName)
 		}
 	}
 	err := exp.ipfixSet.AddRecord(eL, templateID)
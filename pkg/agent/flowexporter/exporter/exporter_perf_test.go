@@ -170,7 +170,7 @@ func NewFlowExporterForTest(o *flowexporter.FlowExporterOptions) *FlowExporter {
 	v6Enabled := testWithIPv6
 
 	denyConnStore := connections.NewDenyConnectionStore(nil, nil, o)
-	conntrackConnStore := connections.NewConntrackConnectionStore(nil, v4Enabled, v6Enabled, nil, nil, nil, o)
+	conntrackConnStore := connections.NewConntrackConnectionStore(nil, v4Enabled, v6Enabled, nil, nil, nil, o, nil)
 
 	return &FlowExporter{
 		collectorAddr:          o.FlowCollectorAddr,
@@ -84,6 +84,12 @@ type Connection struct {
 	FlowType                             uint8
 	EgressName                           string
 	EgressIP                             string
+	// Fields specific to L7 flow export
+	AppProtocolName string
+	// HttpVals is a JSON object mapping the IDs of the HTTP transactions observed since the connection was last
+	// exported to their metadata.
+	HttpVals      string
+	TLSServerName string
 }
 
 type ItemToExpire struct {
@@ -102,4 +108,5 @@ type FlowExporterOptions struct {
 	StaleConnectionTimeout time.Duration
 	PollInterval           time.Duration
 	ConnectUplinkToBridge  bool
+	L7FlowExporterEnabled  bool
 }
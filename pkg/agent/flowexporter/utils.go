@@ -15,9 +15,14 @@
 package flowexporter
 
 import (
+	"strings"
+
 	"github.com/vmware/go-ipfix/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
 
+	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 )
 
 const (
@@ -84,3 +89,19 @@ func PolicyTypeToUint8(policyType v1beta2.NetworkPolicyType) uint8 {
 		return registry.PolicyTypeK8sNetworkPolicy
 	}
 }
+
+// GetL7FlowExporterDirection returns the direction of the connections of the Pod whose L7 metadata should be exported,
+// as specified by the L7FlowExporterAnnotationKey annotation of the Pod. The value is case-insensitive. The second
+// return value is false if the annotation is absent or invalid.
+func GetL7FlowExporterDirection(pod *corev1.Pod) (crdv1alpha2.Direction, bool) {
+	value, ok := pod.Annotations[types.L7FlowExporterAnnotationKey]
+	if !ok {
+		return "", false
+	}
+	for _, direction := range []crdv1alpha2.Direction{crdv1alpha2.DirectionIngress, crdv1alpha2.DirectionEgress, crdv1alpha2.DirectionBoth} {
+		if strings.EqualFold(value, string(direction)) {
+			return direction, true
+		}
+	}
+	return "", false
+}
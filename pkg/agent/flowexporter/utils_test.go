@@ -17,8 +17,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 )
 
 func TestIsConnectionDying(t *testing.T) {
@@ -96,3 +100,22 @@ func TestPolicyTypeToUint8(t *testing.T) {
 		assert.Equal(t, tc.expectedResult, result)
 	}
 }
+
+func TestGetL7FlowExporterDirection(t *testing.T) {
+	for _, tc := range []struct {
+		annotations       map[string]string
+		expectedDirection crdv1alpha2.Direction
+		expectedOK        bool
+	}{
+		{nil, "", false},
+		{map[string]string{types.L7FlowExporterAnnotationKey: "ingress"}, crdv1alpha2.DirectionIngress, true},
+		{map[string]string{types.L7FlowExporterAnnotationKey: "Egress"}, crdv1alpha2.DirectionEgress, true},
+		{map[string]string{types.L7FlowExporterAnnotationKey: "both"}, crdv1alpha2.DirectionBoth, true},
+		{map[string]string{types.L7FlowExporterAnnotationKey: "all"}, "", false},
+	} {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+		direction, ok := GetL7FlowExporterDirection(pod)
+		assert.Equal(t, tc.expectedDirection, direction)
+		assert.Equal(t, tc.expectedOK, ok)
+	}
+}
@@ -14,18 +14,22 @@
 
 package ipassigner
 
-import "k8s.io/apimachinery/pkg/util/sets"
+import crdv1b1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
 
 // IPAssigner provides methods to assign or unassign IP.
 type IPAssigner interface {
-	// AssignIP ensures the provided IP is assigned to the system.
-	AssignIP(ip string, forceAdvertise bool) error
+	// AssignIP ensures the provided IP is assigned to the system. If subnetInfo is not nil, the IP is assigned in the
+	// subnet, e.g. to the VLAN sub-interface of the subnet.
+	AssignIP(ip string, subnetInfo *crdv1b1.SubnetInfo, forceAdvertise bool) error
 	// UnassignIP ensures the provided IP is not assigned to the system.
 	UnassignIP(ip string) error
-	// AssignedIPs return the IPs that are assigned to the system by this IPAssigner.
-	AssignedIPs() sets.Set[string]
+	// AssignedIPs return the IPs that are assigned to the system by this IPAssigner, and their subnets.
+	AssignedIPs() map[string]*crdv1b1.SubnetInfo
 	// InitIPs ensures the IPs that are assigned to the system match the given IPs.
-	InitIPs(sets.Set[string]) error
+	InitIPs(map[string]*crdv1b1.SubnetInfo) error
+	// GetInterfaceID returns the index of the network interface through which the traffic sourced from the IPs of the
+	// provided subnet should be sent. The second return value is false if there is no such interface.
+	GetInterfaceID(subnetInfo *crdv1b1.SubnetInfo) (int, bool)
 	// Run starts the IP assigner.
 	Run(<-chan struct{})
 }
@@ -18,6 +18,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/vishvananda/netlink"
@@ -31,25 +33,192 @@ import (
 	"antrea.io/antrea/pkg/agent/util/arping"
 	"antrea.io/antrea/pkg/agent/util/ndp"
 	"antrea.io/antrea/pkg/agent/util/sysctl"
+	crdv1b1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
 )
 
+const (
+	// vlanInterfacePrefix is the name prefix of the VLAN sub-interfaces created by the IP assigner. The VLAN ID is
+	// appended to it, e.g. "antrea-ext.10".
+	vlanInterfacePrefix = "antrea-ext."
+)
+
+// assignee is the unit that IPs are assigned to. The IPs of an assignee are configured on the same link and advertised
+// through the same interface.
+type assignee struct {
+	// logicalInterface is the interface the IPs are logically assigned to. GARP (IPv4) and Unsolicited NA (IPv6) are
+	// sent from it.
+	logicalInterface *net.Interface
+	// link is the device that IPs will be assigned to. It's nil if the IPs needn't be configured on any device.
+	link netlink.Link
+	// arpResponder and ndpResponder reply to ARP and NS requests for the IPs. They are nil if the kernel replies to
+	// the requests.
+	arpResponder responder.Responder
+	ndpResponder responder.Responder
+	// ips caches the IPs that are assigned to the assignee.
+	ips sets.Set[string]
+}
+
+func (as *assignee) linkName() string {
+	if as.link == nil {
+		return ""
+	}
+	return as.link.Attrs().Name
+}
+
+// assign assigns the IP to the link and the ARP/NDP responders of the assignee.
+func (as *assignee) assign(ipNet *net.IPNet) error {
+	ip := ipNet.IP
+	if as.link != nil {
+		if err := netlink.AddrAdd(as.link, &netlink.Addr{IPNet: ipNet}); err != nil {
+			if !errors.Is(err, unix.EEXIST) {
+				return fmt.Errorf("failed to add IP %v to interface %s: %v", ipNet, as.linkName(), err)
+			} else {
+				klog.InfoS("IP was already assigned to interface", "ip", ip, "interface", as.linkName())
+			}
+		} else {
+			klog.InfoS("Assigned IP to interface", "ip", ip, "interface", as.linkName())
+		}
+	}
+	if utilnet.IsIPv4(ip) && as.arpResponder != nil {
+		if err := as.arpResponder.AddIP(ip); err != nil {
+			return fmt.Errorf("failed to assign IP %v to ARP responder: %v", ip, err)
+		}
+	}
+	if utilnet.IsIPv6(ip) && as.ndpResponder != nil {
+		if err := as.ndpResponder.AddIP(ip); err != nil {
+			return fmt.Errorf("failed to assign IP %v to NDP responder: %v", ip, err)
+		}
+	}
+	as.ips.Insert(ip.String())
+	return nil
+}
+
+// unassign removes the IP from the link and the ARP/NDP responders of the assignee.
+func (as *assignee) unassign(ipNet *net.IPNet) error {
+	ip := ipNet.IP
+	if as.link != nil {
+		if err := netlink.AddrDel(as.link, &netlink.Addr{IPNet: ipNet}); err != nil {
+			if !errors.Is(err, unix.EADDRNOTAVAIL) {
+				return fmt.Errorf("failed to delete IP %v from interface %s: %v", ipNet, as.linkName(), err)
+			} else {
+				klog.InfoS("IP does not exist on interface", "ip", ip, "interface", as.linkName())
+			}
+		}
+		klog.InfoS("Deleted IP from interface", "ip", ip, "interface", as.linkName())
+	}
+	if utilnet.IsIPv4(ip) && as.arpResponder != nil {
+		if err := as.arpResponder.RemoveIP(ip); err != nil {
+			return fmt.Errorf("failed to remove IP %v from ARP responder: %v", ip, err)
+		}
+	}
+	if utilnet.IsIPv6(ip) && as.ndpResponder != nil {
+		if err := as.ndpResponder.RemoveIP(ip); err != nil {
+			return fmt.Errorf("failed to remove IP %v from NDP responder: %v", ip, err)
+		}
+	}
+	as.ips.Delete(ip.String())
+	return nil
+}
+
+func (as *assignee) advertise(ip net.IP) {
+	if utilnet.IsIPv4(ip) {
+		klog.V(2).InfoS("Sending gratuitous ARP", "ip", ip, "interface", as.logicalInterface.Name)
+		if err := arping.GratuitousARPOverIface(ip, as.logicalInterface); err != nil {
+			klog.ErrorS(err, "Failed to send gratuitous ARP", "ip", ip)
+		}
+	} else {
+		klog.V(2).InfoS("Sending neighbor advertisement", "ip", ip, "interface", as.logicalInterface.Name)
+		if err := ndp.NeighborAdvertisement(ip, as.logicalInterface); err != nil {
+			klog.ErrorS(err, "Failed to send neighbor advertisement", "ip", ip)
+		}
+	}
+}
+
+// loadIPAddresses gets the IP addresses on the link of the assignee, keyed by the string representation of the
+// addresses including their prefix lengths. Link-local addresses are ignored as they are not assigned by the assignee.
+func (as *assignee) loadIPAddresses() (map[string]*net.IPNet, error) {
+	addresses, err := netlink.AddrList(as.link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	ipNets := map[string]*net.IPNet{}
+	for _, address := range addresses {
+		if address.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		ipNets[address.IPNet.String()] = address.IPNet
+	}
+	return ipNets, nil
+}
+
+// init replaces the IPs assigned to the assignee with the given ones, and advertises them.
+func (as *assignee) init(ipNets map[string]*net.IPNet) error {
+	if as.link != nil {
+		assigned, err := as.loadIPAddresses()
+		if err != nil {
+			return fmt.Errorf("error when loading IP addresses from interface %s: %v", as.linkName(), err)
+		}
+		for key, ipNet := range ipNets {
+			if _, exists := assigned[key]; exists {
+				continue
+			}
+			if err := netlink.AddrAdd(as.link, &netlink.Addr{IPNet: ipNet}); err != nil {
+				if !errors.Is(err, unix.EEXIST) {
+					return fmt.Errorf("failed to add IP %v to interface %s: %v", ipNet, as.linkName(), err)
+				}
+			}
+		}
+		for key, ipNet := range assigned {
+			if _, exists := ipNets[key]; exists {
+				continue
+			}
+			if err := netlink.AddrDel(as.link, &netlink.Addr{IPNet: ipNet}); err != nil {
+				if !errors.Is(err, unix.EADDRNOTAVAIL) {
+					return fmt.Errorf("failed to delete IP %v from interface %s: %v", ipNet, as.linkName(), err)
+				}
+			}
+		}
+	}
+	as.ips = sets.New[string]()
+	for _, ipNet := range ipNets {
+		ip := ipNet.IP
+		var err error
+		if utilnet.IsIPv4(ip) && as.arpResponder != nil {
+			err = as.arpResponder.AddIP(ip)
+		}
+		if utilnet.IsIPv6(ip) && as.ndpResponder != nil {
+			err = as.ndpResponder.AddIP(ip)
+		}
+		if err != nil {
+			return err
+		}
+		as.advertise(ip)
+		as.ips.Insert(ip.String())
+	}
+	return nil
+}
+
 // ipAssigner creates a dummy device and assigns IPs to it.
 // It's supposed to be used in the cases that external IPs should be configured on the system so that they can be used
 // for SNAT (egress scenario) or DNAT (ingress scenario). A dummy device is used because the IPs just need to be present
 // in any device to be functional, and using dummy device avoids touching system managed devices and is easy to know IPs
 // that are assigned by antrea-agent.
+// IPs in a subnet with a VLAN ID are assigned to a VLAN sub-interface of the transport interface instead, which is
+// created on demand and deleted when no IP is assigned to it.
 type ipAssigner struct {
-	// externalInterface is the device that GARP (IPv4) and Unsolicited NA (IPv6) will be sent from.
+	// externalInterface is the device that GARP (IPv4) and Unsolicited NA (IPv6) will be sent from, and the parent
+	// device of the VLAN sub-interfaces.
 	externalInterface *net.Interface
-	// dummyDevice is the device that IPs will be assigned to.
-	dummyDevice netlink.Link
-	// assignIPs caches the IPs that are assigned to the dummy device.
-	// TODO: Add a goroutine to ensure that the cache is in sync with the IPs assigned to the dummy device in case the
+	// defaultAssignee is used for the IPs that are not in any VLAN. The IPs are assigned to the dummy device if it's
+	// configured.
+	defaultAssignee *assignee
+	// vlanAssignees stores the assignees of the VLAN sub-interfaces, keyed by VLAN ID.
+	vlanAssignees map[int32]*assignee
+	// assignedIPs caches the IPs that are assigned by the ipAssigner and their subnets.
+	// TODO: Add a goroutine to ensure that the cache is in sync with the IPs assigned to the devices in case the
 	// IPs are removed by users accidentally.
-	assignedIPs  sets.Set[string]
-	mutex        sync.RWMutex
-	arpResponder responder.Responder
-	ndpResponder responder.Responder
+	assignedIPs map[string]*crdv1b1.SubnetInfo
+	mutex       sync.RWMutex
 }
 
 // NewIPAssigner returns an *ipAssigner.
@@ -58,9 +227,15 @@ func NewIPAssigner(nodeTransportInterface string, dummyDeviceName string) (IPAss
 	if err != nil {
 		return nil, fmt.Errorf("get IPNetDevice from name %s error: %+v", nodeTransportInterface, err)
 	}
+	defaultAssignee := &assignee{
+		logicalInterface: externalInterface,
+		ips:              sets.New[string](),
+	}
 	a := &ipAssigner{
 		externalInterface: externalInterface,
-		assignedIPs:       sets.New[string](),
+		defaultAssignee:   defaultAssignee,
+		vlanAssignees:     map[int32]*assignee{},
+		assignedIPs:       map[string]*crdv1b1.SubnetInfo{},
 	}
 	if ipv4 != nil {
 		// For the Egress scenario, the external IPs should always be present on the dummy
@@ -77,7 +252,7 @@ func NewIPAssigner(nodeTransportInterface string, dummyDeviceName string) (IPAss
 			if err != nil {
 				return nil, fmt.Errorf("failed to create ARP responder for link %s: %v", externalInterface.Name, err)
 			}
-			a.arpResponder = arpResponder
+			defaultAssignee.arpResponder = arpResponder
 		}
 	}
 	if ipv6 != nil {
@@ -85,14 +260,14 @@ func NewIPAssigner(nodeTransportInterface string, dummyDeviceName string) (IPAss
 		if err != nil {
 			return nil, fmt.Errorf("failed to create NDP responder for link %s: %v", externalInterface.Name, err)
 		}
-		a.ndpResponder = ndpResponder
+		defaultAssignee.ndpResponder = ndpResponder
 	}
 	if dummyDeviceName != "" {
 		dummyDevice, err := ensureDummyDevice(dummyDeviceName)
 		if err != nil {
 			return nil, fmt.Errorf("error when ensuring dummy device exists: %v", err)
 		}
-		a.dummyDevice = dummyDevice
+		defaultAssignee.link = dummyDevice
 	}
 	return a, nil
 }
@@ -129,21 +304,107 @@ func ensureDummyDevice(deviceName string) (netlink.Link, error) {
 	return dummy, nil
 }
 
-// loadIPAddresses gets the IP addresses on the dummy device and caches them in memory.
-func (a *ipAssigner) loadIPAddresses() (sets.Set[string], error) {
-	addresses, err := netlink.AddrList(a.dummyDevice, netlink.FAMILY_ALL)
+// getVLANInterfaceName returns the name of the VLAN sub-interface for the given VLAN ID.
+func getVLANInterfaceName(vlan int32) string {
+	return fmt.Sprintf("%s%d", vlanInterfacePrefix, vlan)
+}
+
+// ensureVLANAssignee returns the assignee of the given VLAN, and creates the VLAN sub-interface if it doesn't exist.
+func (a *ipAssigner) ensureVLANAssignee(vlan int32) (*assignee, error) {
+	if as, exists := a.vlanAssignees[vlan]; exists {
+		return as, nil
+	}
+	name := getVLANInterfaceName(vlan)
+	link, err := netlink.LinkByName(name)
 	if err != nil {
-		return nil, err
+		if !errors.As(err, &netlink.LinkNotFoundError{}) {
+			return nil, fmt.Errorf("failed to get VLAN sub-interface %s: %v", name, err)
+		}
+		vlanLink := &netlink.Vlan{
+			LinkAttrs: netlink.LinkAttrs{
+				Name:        name,
+				ParentIndex: a.externalInterface.Index,
+			},
+			VlanId: int(vlan),
+		}
+		if err := netlink.LinkAdd(vlanLink); err != nil {
+			return nil, fmt.Errorf("failed to create VLAN sub-interface %s: %v", name, err)
+		}
+		if link, err = netlink.LinkByName(name); err != nil {
+			return nil, fmt.Errorf("failed to get VLAN sub-interface %s: %v", name, err)
+		}
+		klog.InfoS("Created VLAN sub-interface", "interface", name, "parent", a.externalInterface.Name, "vlan", vlan)
 	}
-	newAssignIPs := sets.New[string]()
-	for _, address := range addresses {
-		newAssignIPs.Insert(address.IP.String())
+	if err := netlink.LinkSetUp(link); err != nil {
+		return nil, fmt.Errorf("failed to set VLAN sub-interface %s up: %v", name, err)
+	}
+	// The replies of the traffic sent out from the VLAN sub-interface are received from it, while the main routing
+	// table may route their source IPs via other interfaces. Loose mode reverse path filtering is required to accept
+	// them.
+	if err := sysctl.EnsureSysctlNetValue(fmt.Sprintf("ipv4/conf/%s/rp_filter", name), 2); err != nil {
+		return nil, fmt.Errorf("failed to set rp_filter for VLAN sub-interface %s: %v", name, err)
+	}
+	iface, err := net.InterfaceByIndex(link.Attrs().Index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VLAN sub-interface %s: %v", name, err)
+	}
+	// The IPs are assigned to the VLAN sub-interface on which the ARP/NS requests are received, so the kernel can
+	// reply to the requests without ARP/NDP responders.
+	as := &assignee{
+		logicalInterface: iface,
+		link:             link,
+		ips:              sets.New[string](),
+	}
+	a.vlanAssignees[vlan] = as
+	return as, nil
+}
+
+// deleteVLANAssignee deletes the VLAN sub-interface of the given VLAN.
+func (a *ipAssigner) deleteVLANAssignee(vlan int32) error {
+	as, exists := a.vlanAssignees[vlan]
+	if !exists {
+		return nil
+	}
+	if err := netlink.LinkDel(as.link); err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return fmt.Errorf("failed to delete VLAN sub-interface %s: %v", as.linkName(), err)
+	}
+	klog.InfoS("Deleted VLAN sub-interface", "interface", as.linkName(), "vlan", vlan)
+	delete(a.vlanAssignees, vlan)
+	return nil
+}
+
+// getVLAN returns the VLAN ID of the subnet, 0 means no VLAN.
+func getVLAN(subnetInfo *crdv1b1.SubnetInfo) int32 {
+	if subnetInfo == nil {
+		return 0
+	}
+	return subnetInfo.VLAN
+}
+
+// getIPNet returns the address that should be configured for the IP. The IPs assigned to the VLAN sub-interfaces have
+// the prefix length of their subnets, so that the gateways of the subnets are reachable via the sub-interfaces. The IPs
+// assigned to the dummy device always have a full-length prefix.
+func getIPNet(ip net.IP, subnetInfo *crdv1b1.SubnetInfo) *net.IPNet {
+	if getVLAN(subnetInfo) == 0 {
+		return util.NewIPNet(ip)
+	}
+	bits := net.IPv6len * 8
+	if utilnet.IsIPv4(ip) {
+		bits = net.IPv4len * 8
 	}
-	return newAssignIPs, nil
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(int(subnetInfo.PrefixLength), bits)}
 }
 
-// AssignIP ensures the provided IP is assigned to the dummy device and the ARP/NDP responders.
-func (a *ipAssigner) AssignIP(ip string, forceAdvertise bool) error {
+func subnetInfoEqual(s1, s2 *crdv1b1.SubnetInfo) bool {
+	if s1 == nil || s2 == nil {
+		return s1 == s2
+	}
+	return *s1 == *s2
+}
+
+// AssignIP ensures the provided IP is assigned to the dummy device or the VLAN sub-interface of its subnet, and the
+// ARP/NDP responders.
+func (a *ipAssigner) AssignIP(ip string, subnetInfo *crdv1b1.SubnetInfo, forceAdvertise bool) error {
 	parsedIP := net.ParseIP(ip)
 	if parsedIP == nil {
 		return fmt.Errorf("invalid IP %s", ip)
@@ -151,58 +412,45 @@ func (a *ipAssigner) AssignIP(ip string, forceAdvertise bool) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	if a.assignedIPs.Has(ip) {
-		klog.V(2).InfoS("The IP is already assigned", "ip", ip)
-		if forceAdvertise {
-			a.advertise(parsedIP)
-		}
-		return nil
-	}
-
-	if a.dummyDevice != nil {
-		addr := util.NewIPNet(parsedIP)
-		if err := netlink.AddrAdd(a.dummyDevice, &netlink.Addr{IPNet: addr}); err != nil {
-			if !errors.Is(err, unix.EEXIST) {
-				return fmt.Errorf("failed to add IP %v to interface %s: %v", ip, a.dummyDevice.Attrs().Name, err)
-			} else {
-				klog.InfoS("IP was already assigned to interface", "ip", parsedIP, "interface", a.dummyDevice.Attrs().Name)
+	if oldSubnetInfo, exists := a.assignedIPs[ip]; exists {
+		if subnetInfoEqual(oldSubnetInfo, subnetInfo) {
+			klog.V(2).InfoS("The IP is already assigned", "ip", ip)
+			if as := a.getAssignee(subnetInfo); forceAdvertise && as != nil {
+				as.advertise(parsedIP)
 			}
-		} else {
-			klog.InfoS("Assigned IP to interface", "ip", parsedIP, "interface", a.dummyDevice.Attrs().Name)
+			return nil
+		}
+		// The subnet of the IP has changed, unassign it from the previous assignee first.
+		if err := a.unassignIP(parsedIP, oldSubnetInfo); err != nil {
+			return err
 		}
 	}
 
-	if utilnet.IsIPv4(parsedIP) && a.arpResponder != nil {
-		if err := a.arpResponder.AddIP(parsedIP); err != nil {
-			return fmt.Errorf("failed to assign IP %v to ARP responder: %v", ip, err)
+	as := a.defaultAssignee
+	if vlan := getVLAN(subnetInfo); vlan != 0 {
+		var err error
+		if as, err = a.ensureVLANAssignee(vlan); err != nil {
+			return err
 		}
 	}
-	if utilnet.IsIPv6(parsedIP) && a.ndpResponder != nil {
-		if err := a.ndpResponder.AddIP(parsedIP); err != nil {
-			return fmt.Errorf("failed to assign IP %v to NDP responder: %v", ip, err)
-		}
+	if err := as.assign(getIPNet(parsedIP, subnetInfo)); err != nil {
+		return err
 	}
 	// Always advertise the IP when the IP is newly assigned to this Node.
-	a.advertise(parsedIP)
-	a.assignedIPs.Insert(ip)
+	as.advertise(parsedIP)
+	a.assignedIPs[ip] = subnetInfo.DeepCopy()
 	return nil
 }
 
-func (a *ipAssigner) advertise(ip net.IP) {
-	if utilnet.IsIPv4(ip) {
-		klog.V(2).InfoS("Sending gratuitous ARP", "ip", ip)
-		if err := arping.GratuitousARPOverIface(ip, a.externalInterface); err != nil {
-			klog.ErrorS(err, "Failed to send gratuitous ARP", "ip", ip)
-		}
-	} else {
-		klog.V(2).InfoS("Sending neighbor advertisement", "ip", ip)
-		if err := ndp.NeighborAdvertisement(ip, a.externalInterface); err != nil {
-			klog.ErrorS(err, "Failed to send neighbor advertisement", "ip", ip)
-		}
+// getAssignee returns the existing assignee of the subnet. The caller must hold the lock.
+func (a *ipAssigner) getAssignee(subnetInfo *crdv1b1.SubnetInfo) *assignee {
+	if vlan := getVLAN(subnetInfo); vlan != 0 {
+		return a.vlanAssignees[vlan]
 	}
+	return a.defaultAssignee
 }
 
-// UnassignIP ensures the provided IP is not assigned to the dummy device.
+// UnassignIP ensures the provided IP is not assigned to the dummy device or any VLAN sub-interface.
 func (a *ipAssigner) UnassignIP(ip string) error {
 	parsedIP := net.ParseIP(ip)
 	if parsedIP == nil {
@@ -211,99 +459,130 @@ func (a *ipAssigner) UnassignIP(ip string) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	if !a.assignedIPs.Has(ip) {
+	subnetInfo, exists := a.assignedIPs[ip]
+	if !exists {
 		klog.V(2).InfoS("The IP is not assigned", "ip", ip)
 		return nil
 	}
+	return a.unassignIP(parsedIP, subnetInfo)
+}
 
-	if a.dummyDevice != nil {
-		addr := util.NewIPNet(parsedIP)
-		if err := netlink.AddrDel(a.dummyDevice, &netlink.Addr{IPNet: addr}); err != nil {
-			if !errors.Is(err, unix.EADDRNOTAVAIL) {
-				return fmt.Errorf("failed to delete IP %v from interface %s: %v", ip, a.dummyDevice.Attrs().Name, err)
-			} else {
-				klog.InfoS("IP does not exist on interface", "ip", parsedIP, "interface", a.dummyDevice.Attrs().Name)
-			}
-		}
-		klog.InfoS("Deleted IP from interface", "ip", ip, "interface", a.dummyDevice.Attrs().Name)
-	}
-
-	if utilnet.IsIPv4(parsedIP) && a.arpResponder != nil {
-		if err := a.arpResponder.RemoveIP(parsedIP); err != nil {
-			return fmt.Errorf("failed to remove IP %v from ARP responder: %v", ip, err)
+// unassignIP unassigns the IP from its assignee, and deletes the VLAN sub-interface if no IP is assigned to it anymore.
+// The caller must hold the lock.
+func (a *ipAssigner) unassignIP(ip net.IP, subnetInfo *crdv1b1.SubnetInfo) error {
+	as := a.getAssignee(subnetInfo)
+	if as != nil {
+		if err := as.unassign(getIPNet(ip, subnetInfo)); err != nil {
+			return err
 		}
-	}
-	if utilnet.IsIPv6(parsedIP) && a.ndpResponder != nil {
-		if err := a.ndpResponder.RemoveIP(parsedIP); err != nil {
-			return fmt.Errorf("failed to remove IP %v from NDP responder: %v", ip, err)
+		if as != a.defaultAssignee && as.ips.Len() == 0 {
+			if err := a.deleteVLANAssignee(getVLAN(subnetInfo)); err != nil {
+				return err
+			}
 		}
 	}
-
-	a.assignedIPs.Delete(ip)
+	delete(a.assignedIPs, ip.String())
 	return nil
 }
 
-// AssignedIPs return the IPs that are assigned to the dummy device.
-func (a *ipAssigner) AssignedIPs() sets.Set[string] {
+// AssignedIPs return the IPs that are assigned by the ipAssigner and their subnets.
+func (a *ipAssigner) AssignedIPs() map[string]*crdv1b1.SubnetInfo {
 	a.mutex.RLock()
 	defer a.mutex.RUnlock()
 	// Return a copy.
-	return a.assignedIPs.Union(nil)
+	ips := make(map[string]*crdv1b1.SubnetInfo, len(a.assignedIPs))
+	for ip, subnetInfo := range a.assignedIPs {
+		ips[ip] = subnetInfo.DeepCopy()
+	}
+	return ips
 }
 
-// InitIPs loads the IPs from the dummy device and replaces the IPs that are assigned to it
-// with the given ones. This function also adds the given IPs to the ARP/NDP responder if
-// applicable. It can be used to recover the IP assigner to the desired state after Agent restarts.
-func (a *ipAssigner) InitIPs(ips sets.Set[string]) error {
+// InitIPs loads the IPs from the dummy device and the VLAN sub-interfaces, and replaces the IPs that are assigned to
+// them with the given ones. The VLAN sub-interfaces that are not needed by the given IPs are deleted. This function
+// also adds the given IPs to the ARP/NDP responder if applicable. It can be used to recover the IP assigner to the
+// desired state after Agent restarts.
+func (a *ipAssigner) InitIPs(ips map[string]*crdv1b1.SubnetInfo) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
-	if a.dummyDevice != nil {
-		assigned, err := a.loadIPAddresses()
-		if err != nil {
-			return fmt.Errorf("error when loading IP addresses from the system: %v", err)
-		}
-		for ip := range ips.Difference(assigned) {
-			addr := util.NewIPNet(net.ParseIP(ip))
-			if err := netlink.AddrAdd(a.dummyDevice, &netlink.Addr{IPNet: addr}); err != nil {
-				if !errors.Is(err, unix.EEXIST) {
-					return fmt.Errorf("failed to add IP %v to interface %s: %v", ip, a.dummyDevice.Attrs().Name, err)
-				}
-			}
+	desiredIPNets := map[int32]map[string]*net.IPNet{}
+	for ip, subnetInfo := range ips {
+		parsedIP := net.ParseIP(ip)
+		if parsedIP == nil {
+			return fmt.Errorf("invalid IP %s", ip)
 		}
-		for ip := range assigned.Difference(ips) {
-			addr := util.NewIPNet(net.ParseIP(ip))
-			if err := netlink.AddrDel(a.dummyDevice, &netlink.Addr{IPNet: addr}); err != nil {
-				if !errors.Is(err, unix.EADDRNOTAVAIL) {
-					return fmt.Errorf("failed to delete IP %v from interface %s: %v", ip, a.dummyDevice.Attrs().Name, err)
-				}
-			}
+		vlan := getVLAN(subnetInfo)
+		if desiredIPNets[vlan] == nil {
+			desiredIPNets[vlan] = map[string]*net.IPNet{}
 		}
+		ipNet := getIPNet(parsedIP, subnetInfo)
+		desiredIPNets[vlan][ipNet.String()] = ipNet
 	}
-	for ipStr := range ips {
-		ip := net.ParseIP(ipStr)
-		var err error
-		if utilnet.IsIPv4(ip) && a.arpResponder != nil {
-			err = a.arpResponder.AddIP(ip)
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return fmt.Errorf("error when listing network interfaces: %v", err)
+	}
+	for _, link := range links {
+		name := link.Attrs().Name
+		if !strings.HasPrefix(name, vlanInterfacePrefix) {
+			continue
+		}
+		vlan, err := strconv.ParseInt(strings.TrimPrefix(name, vlanInterfacePrefix), 10, 32)
+		if err == nil && desiredIPNets[int32(vlan)] != nil {
+			continue
 		}
-		if utilnet.IsIPv6(ip) && a.ndpResponder != nil {
-			err = a.ndpResponder.AddIP(ip)
+		if err := netlink.LinkDel(link); err != nil {
+			return fmt.Errorf("failed to delete VLAN sub-interface %s: %v", name, err)
 		}
+		klog.InfoS("Deleted stale VLAN sub-interface", "interface", name)
+	}
+	a.vlanAssignees = map[int32]*assignee{}
+
+	if err := a.defaultAssignee.init(desiredIPNets[0]); err != nil {
+		return err
+	}
+	for vlan, ipNets := range desiredIPNets {
+		if vlan == 0 {
+			continue
+		}
+		as, err := a.ensureVLANAssignee(vlan)
 		if err != nil {
 			return err
 		}
-		a.advertise(ip)
+		if err := as.init(ipNets); err != nil {
+			return err
+		}
+	}
+	a.assignedIPs = map[string]*crdv1b1.SubnetInfo{}
+	for ip, subnetInfo := range ips {
+		a.assignedIPs[ip] = subnetInfo.DeepCopy()
 	}
-	a.assignedIPs = ips.Union(nil)
 	return nil
 }
 
+// GetInterfaceID returns the index of the VLAN sub-interface of the subnet if the subnet has a VLAN ID, or the index
+// of the transport interface otherwise.
+func (a *ipAssigner) GetInterfaceID(subnetInfo *crdv1b1.SubnetInfo) (int, bool) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	vlan := getVLAN(subnetInfo)
+	if vlan == 0 {
+		return a.externalInterface.Index, true
+	}
+	as, exists := a.vlanAssignees[vlan]
+	if !exists {
+		return 0, false
+	}
+	return as.logicalInterface.Index, true
+}
+
 // Run starts the ARP responder and NDP responder.
 func (a *ipAssigner) Run(ch <-chan struct{}) {
-	if a.arpResponder != nil {
-		go a.arpResponder.Run(ch)
+	if a.defaultAssignee.arpResponder != nil {
+		go a.defaultAssignee.arpResponder.Run(ch)
 	}
-	if a.ndpResponder != nil {
-		go a.ndpResponder.Run(ch)
+	if a.defaultAssignee.ndpResponder != nil {
+		go a.defaultAssignee.ndpResponder.Run(ch)
 	}
 	<-ch
 }
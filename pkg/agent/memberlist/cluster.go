@@ -90,6 +90,7 @@ type Interface interface {
 	ShouldSelectIP(ip string, pool string, filters ...func(node string) bool) (bool, error)
 	SelectNodeForIP(ip, externalIPPool string, filters ...func(string) bool) (string, error)
 	AliveNodes() sets.Set[string]
+	UnhealthyNodes(externalIPPool string) (sets.Set[string], bool)
 	AddClusterEventHandler(handler ClusterNodeEventHandler)
 }
 
@@ -97,6 +98,7 @@ type Memberlist interface {
 	Join(existing []string) (int, error)
 	Members() []*memberlist.Node
 	Leave(timeout time.Duration) error
+	UpdateNode(timeout time.Duration) error
 	Shutdown() error
 }
 
@@ -132,6 +134,16 @@ type Cluster struct {
 
 	// queue maintains the ExternalIPPool names that need to be synced.
 	queue workqueue.RateLimitingInterface
+
+	// healthCheckers holds the running health checkers of the ExternalIPPools which have health checks and for which
+	// the local Node is eligible, indexed by ExternalIPPool name.
+	healthCheckers map[string]*poolHealthChecker
+	// unhealthyPools holds the names of the ExternalIPPools for which the local Node fails the health checks. It's
+	// advertised to other Nodes via the Node metadata of memberlist.
+	unhealthyPools sets.Set[string]
+	healthMutex    sync.RWMutex
+	// healthProbeFn runs a health probe. It's a field to make testing easier.
+	healthProbeFn healthProbeFunc
 }
 
 // NewCluster returns a new *Cluster.
@@ -158,6 +170,9 @@ func NewCluster(
 		externalIPPoolLister:            externalIPPoolInformer.Lister(),
 		externalIPPoolInformerHasSynced: externalIPPoolInformer.Informer().HasSynced,
 		queue:                           workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "externalIPPool"),
+		healthCheckers:                  map[string]*poolHealthChecker{},
+		unhealthyPools:                  sets.New[string](),
+		healthProbeFn:                   runHealthProbe,
 	}
 
 	if ml == nil {
@@ -169,6 +184,8 @@ func NewCluster(
 		// Setting it to a non-zero value to allow reclaiming Nodes with different addresses for Node IP update case.
 		conf.DeadNodeReclaimTime = 10 * time.Millisecond
 		conf.Events = &memberlist.ChannelEventDelegate{Ch: nodeEventCh}
+		// The delegate advertises the health state of the local Node via the Node metadata.
+		conf.Delegate = &nodeDelegate{cluster: c}
 		conf.LogOutput = io.Discard
 		klog.V(1).InfoS("New memberlist cluster", "config", conf)
 
@@ -193,7 +210,8 @@ func NewCluster(
 			UpdateFunc: func(oldObj, newObj interface{}) {
 				oldExternalIPPool := oldObj.(*v1beta1.ExternalIPPool)
 				curExternalIPPool := newObj.(*v1beta1.ExternalIPPool)
-				if !reflect.DeepEqual(oldExternalIPPool.Spec.NodeSelector, curExternalIPPool.Spec.NodeSelector) {
+				if !reflect.DeepEqual(oldExternalIPPool.Spec.NodeSelector, curExternalIPPool.Spec.NodeSelector) ||
+					!reflect.DeepEqual(oldExternalIPPool.Spec.HealthCheck, curExternalIPPool.Spec.HealthCheck) {
 					c.enqueueExternalIPPool(newObj)
 				}
 			},
@@ -322,11 +340,22 @@ func (c *Cluster) filterEIPsFromNodeLabels(node *corev1.Node) sets.Set[string] {
 	return pools
 }
 
+func (c *Cluster) filterEIPsWithHealthCheckFromNodeLabels(node *corev1.Node) sets.Set[string] {
+	pools := sets.New[string]()
+	for eipName := range c.filterEIPsFromNodeLabels(node) {
+		if eip, err := c.externalIPPoolLister.Get(eipName); err == nil && eip.Spec.HealthCheck != nil {
+			pools.Insert(eipName)
+		}
+	}
+	return pools
+}
+
 // Run will join all the other K8s Nodes in a memberlist cluster
 // and will create defaultWorkers workers (go routines) which will process the ExternalIPPool or Node events
 // from the work queue.
 func (c *Cluster) Run(stopCh <-chan struct{}) {
 	defer c.queue.ShutDown()
+	defer c.stopHealthCheckers()
 	// In order to exit the cluster more gracefully, call Leave prior to shutting down.
 	defer close(c.nodeEventsCh)
 	defer c.mList.Shutdown()
@@ -465,6 +494,7 @@ func (c *Cluster) syncConsistentHash(eipName string) error {
 	eip, err := c.externalIPPoolLister.Get(eipName)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			c.syncHealthChecker(eipName, nil)
 			c.consistentHashRWMutex.Lock()
 			defer c.consistentHashRWMutex.Unlock()
 			delete(c.consistentHashMap, eipName)
@@ -473,6 +503,8 @@ func (c *Cluster) syncConsistentHash(eipName string) error {
 		return err
 	}
 
+	c.syncHealthChecker(eipName, eip)
+
 	// updateConsistentHash refreshes the consistentHashMap.
 	updateConsistentHash := func(eip *v1beta1.ExternalIPPool) error {
 		nodeSel, err := metav1.LabelSelectorAsSelector(&eip.Spec.NodeSelector)
@@ -484,16 +516,31 @@ func (c *Cluster) syncConsistentHash(eipName string) error {
 			return fmt.Errorf("listing Nodes error: %v", err)
 		}
 		aliveNodes := c.AliveNodes()
-		// Node alive and Node labels match ExternalIPPool nodeSelector.
-		var aliveAndMatchedNodes []string
+		// The Nodes failing the health checks of the ExternalIPPool are excluded even if they are alive, as they can't
+		// forward the traffic of the Egress IPs.
+		unhealthyNodes := sets.New[string]()
+		if eip.Spec.HealthCheck != nil {
+			unhealthyNodes = c.unhealthyNodes(eip.Name)
+		}
+		// Node alive, healthy and Node labels match ExternalIPPool nodeSelector.
+		var aliveAndMatchedNodes, healthyNodes []string
 		for _, node := range nodes {
 			nodeName := node.Name
 			if aliveNodes.Has(nodeName) {
 				aliveAndMatchedNodes = append(aliveAndMatchedNodes, nodeName)
+				if !unhealthyNodes.Has(nodeName) {
+					healthyNodes = append(healthyNodes, nodeName)
+				}
 			}
 		}
+		// If all Nodes fail the health checks, e.g. because the probe target is down, fall back to all alive Nodes,
+		// instead of unassigning all Egress IPs of the ExternalIPPool.
+		if len(healthyNodes) == 0 && len(aliveAndMatchedNodes) > 0 {
+			klog.InfoS("All Nodes fail the health checks of the ExternalIPPool, ignoring the health checks", "ExternalIPPool", eip.Name)
+			healthyNodes = aliveAndMatchedNodes
+		}
 		consistentHashMap := NewNodeConsistentHashMap()
-		consistentHashMap.Add(aliveAndMatchedNodes...)
+		consistentHashMap.Add(healthyNodes...)
 		c.consistentHashRWMutex.Lock()
 		defer c.consistentHashRWMutex.Unlock()
 		c.consistentHashMap[eip.Name] = consistentHashMap
@@ -529,6 +576,17 @@ func (c *Cluster) handleClusterNodeEvents(nodeEvent *memberlist.NodeEvent) {
 		affectedEIPs := c.filterEIPsFromNodeLabels(coreNode)
 		c.enqueueExternalIPPools(affectedEIPs.Insert(allNodesConsistentHashMapKey))
 		klog.InfoS("Processed Node event", "eventType", mapNodeEventType[event], "nodeName", node.Name, "affectedExternalIPPoolNum", len(affectedEIPs))
+	case memberlist.NodeUpdate:
+		// The metadata of a Node is updated when its health state changes, the ExternalIPPools with health checks
+		// the Node is eligible for should be enqueued.
+		coreNode, err := c.nodeLister.Get(node.Name)
+		if err != nil {
+			klog.InfoS("Received a Node event but did not find the Node object", "eventType", mapNodeEventType[event], "nodeName", node.Name)
+			return
+		}
+		affectedEIPs := c.filterEIPsWithHealthCheckFromNodeLabels(coreNode)
+		c.enqueueExternalIPPools(affectedEIPs)
+		klog.V(2).InfoS("Processed Node event", "eventType", mapNodeEventType[event], "nodeName", node.Name, "affectedExternalIPPoolNum", len(affectedEIPs))
 	default:
 		klog.InfoS("Processed Node event", "eventType", mapNodeEventType[event], "nodeName", node.Name)
 	}
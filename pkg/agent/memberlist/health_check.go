@@ -0,0 +1,398 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memberlist
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/apis/crd/v1beta1"
+)
+
+const (
+	defaultHealthCheckPeriodSeconds    = 10
+	defaultHealthCheckTimeoutSeconds   = 1
+	defaultHealthCheckFailureThreshold = 3
+	defaultHealthCheckSuccessThreshold = 1
+
+	// nodeMetaVersion is the version of the format of the metadata advertised by the local Node. The metadata consists
+	// of the version byte followed by the 32-bit hashes of the names of the ExternalIPPools for which the local Node
+	// fails the health checks.
+	nodeMetaVersion byte = 1
+	// How long to wait for the updated metadata to be propagated to other Nodes.
+	nodeMetaUpdateTimeout = 5 * time.Second
+
+	protocolICMP   = 1
+	protocolICMPv6 = 58
+)
+
+// icmpEchoSeq is the sequence number of the last ICMP echo request sent by the health probes.
+var icmpEchoSeq atomic.Uint32
+
+type healthProbeFunc func(probe *v1beta1.HealthProbe, timeout time.Duration) error
+
+// poolHealthChecker runs the health probes of an ExternalIPPool periodically on the local Node, and reports when the
+// Node transitions between healthy and unhealthy.
+type poolHealthChecker struct {
+	pool        string
+	healthCheck *v1beta1.ExternalIPPoolHealthCheck
+	probeFn     healthProbeFunc
+	// onHealthChanged is called with the checker itself when the health state changes.
+	onHealthChanged func(checker *poolHealthChecker, healthy bool)
+	stopCh          chan struct{}
+
+	// The Node is considered healthy until the probes fail failureThreshold times in a row, so that a restart of the
+	// agent doesn't cause the Egress IPs to be moved.
+	healthy   bool
+	successes int32
+	failures  int32
+}
+
+func newPoolHealthChecker(pool string, healthCheck *v1beta1.ExternalIPPoolHealthCheck, probeFn healthProbeFunc, onHealthChanged func(*poolHealthChecker, bool)) *poolHealthChecker {
+	return &poolHealthChecker{
+		pool:            pool,
+		healthCheck:     healthCheck.DeepCopy(),
+		probeFn:         probeFn,
+		onHealthChanged: onHealthChanged,
+		stopCh:          make(chan struct{}),
+		healthy:         true,
+	}
+}
+
+func valueOrDefault(value, defaultValue int32) int32 {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *poolHealthChecker) run() {
+	period := time.Duration(valueOrDefault(h.healthCheck.PeriodSeconds, defaultHealthCheckPeriodSeconds)) * time.Second
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		h.check()
+		select {
+		case <-h.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *poolHealthChecker) stop() {
+	close(h.stopCh)
+}
+
+// check runs all the probes once and updates the health state. The Node passes the check only if all probes succeed.
+func (h *poolHealthChecker) check() {
+	timeout := time.Duration(valueOrDefault(h.healthCheck.TimeoutSeconds, defaultHealthCheckTimeoutSeconds)) * time.Second
+	var probeErr error
+	for i := range h.healthCheck.Probes {
+		if probeErr = h.probeFn(&h.healthCheck.Probes[i], timeout); probeErr != nil {
+			break
+		}
+	}
+	if probeErr == nil {
+		h.failures = 0
+		h.successes++
+		if !h.healthy && h.successes >= valueOrDefault(h.healthCheck.SuccessThreshold, defaultHealthCheckSuccessThreshold) {
+			h.healthy = true
+			klog.InfoS("Health check of ExternalIPPool succeeded, marking Node as healthy", "ExternalIPPool", h.pool)
+			h.onHealthChanged(h, true)
+		}
+		return
+	}
+	h.successes = 0
+	h.failures++
+	klog.V(2).InfoS("Health probe of ExternalIPPool failed", "ExternalIPPool", h.pool, "failures", h.failures, "err", probeErr)
+	if h.healthy && h.failures >= valueOrDefault(h.healthCheck.FailureThreshold, defaultHealthCheckFailureThreshold) {
+		h.healthy = false
+		klog.InfoS("Health check of ExternalIPPool failed, marking Node as unhealthy", "ExternalIPPool", h.pool, "err", probeErr)
+		h.onHealthChanged(h, false)
+	}
+}
+
+// runHealthProbe runs a single health probe and returns an error if it fails.
+func runHealthProbe(probe *v1beta1.HealthProbe, timeout time.Duration) error {
+	switch {
+	case probe.ICMP != nil:
+		return probeICMP(probe.ICMP.Target, timeout)
+	case probe.TCP != nil:
+		return probeTCP(probe.TCP.Target, probe.TCP.Port, timeout)
+	case probe.Interface != nil:
+		return probeInterface(probe.Interface.Name)
+	}
+	return fmt.Errorf("no probe specified")
+}
+
+func probeICMP(target string, timeout time.Duration) error {
+	ip := net.ParseIP(target)
+	if ip == nil {
+		return fmt.Errorf("invalid target %s", target)
+	}
+	network, protocol := "ip4:icmp", protocolICMP
+	var requestType, replyType icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	if ip.To4() == nil {
+		network, protocol = "ip6:ipv6-icmp", protocolICMPv6
+		requestType, replyType = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	conn, err := icmp.ListenPacket(network, "")
+	if err != nil {
+		return fmt.Errorf("error creating ICMP socket: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	id, seq := os.Getpid()&0xffff, int(icmpEchoSeq.Add(1)&0xffff)
+	request := icmp.Message{
+		Type: requestType,
+		Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("antrea-health-check")},
+	}
+	data, err := request.Marshal(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.WriteTo(data, &net.IPAddr{IP: ip}); err != nil {
+		return fmt.Errorf("error sending ICMP echo request to %s: %w", target, err)
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			return fmt.Errorf("error receiving ICMP echo reply from %s: %w", target, err)
+		}
+		peerAddr, ok := peer.(*net.IPAddr)
+		if !ok || !peerAddr.IP.Equal(ip) {
+			continue
+		}
+		reply, err := icmp.ParseMessage(protocol, buf[:n])
+		if err != nil || reply.Type != replyType {
+			continue
+		}
+		if echo, ok := reply.Body.(*icmp.Echo); ok && echo.ID == id && echo.Seq == seq {
+			return nil
+		}
+	}
+}
+
+func probeTCP(target string, port int32, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(target, strconv.Itoa(int(port))), timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func probeInterface(name string) error {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return err
+	}
+	if iface.Flags&net.FlagUp == 0 {
+		return fmt.Errorf("interface %s is down", name)
+	}
+	if iface.Flags&net.FlagRunning == 0 {
+		return fmt.Errorf("interface %s has no carrier", name)
+	}
+	return nil
+}
+
+func hashPoolName(pool string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(pool))
+	return h.Sum32()
+}
+
+// encodeNodeMeta encodes the names of the unhealthy ExternalIPPools into the metadata advertised by the local Node.
+// Only the hashes of the names are included to fit as many ExternalIPPools as possible into the size limit.
+func encodeNodeMeta(unhealthyPools sets.Set[string], limit int) []byte {
+	meta := []byte{nodeMetaVersion}
+	for _, pool := range sets.List(unhealthyPools) {
+		if len(meta)+4 > limit {
+			klog.InfoS("Node metadata exceeded size limit, some unhealthy ExternalIPPools will not be advertised", "limit", limit)
+			break
+		}
+		meta = binary.BigEndian.AppendUint32(meta, hashPoolName(pool))
+	}
+	return meta
+}
+
+// nodeMetaHasUnhealthyPool returns whether the metadata advertised by a Node indicates that the Node fails the health
+// checks of the provided ExternalIPPool.
+func nodeMetaHasUnhealthyPool(meta []byte, pool string) bool {
+	if len(meta) == 0 || meta[0] != nodeMetaVersion {
+		return false
+	}
+	hash := hashPoolName(pool)
+	for i := 1; i+4 <= len(meta); i += 4 {
+		if binary.BigEndian.Uint32(meta[i:]) == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeDelegate implements memberlist.Delegate to advertise the health state of the local Node to other Nodes.
+type nodeDelegate struct {
+	cluster *Cluster
+}
+
+func (d *nodeDelegate) NodeMeta(limit int) []byte {
+	d.cluster.healthMutex.RLock()
+	defer d.cluster.healthMutex.RUnlock()
+	return encodeNodeMeta(d.cluster.unhealthyPools, limit)
+}
+
+func (d *nodeDelegate) NotifyMsg([]byte) {}
+
+func (d *nodeDelegate) GetBroadcasts(overhead, limit int) [][]byte {
+	return nil
+}
+
+func (d *nodeDelegate) LocalState(join bool) []byte {
+	return nil
+}
+
+func (d *nodeDelegate) MergeRemoteState(buf []byte, join bool) {}
+
+// syncHealthChecker starts, restarts or stops the health checker of the ExternalIPPool on the local Node according to
+// whether the ExternalIPPool has health checks and whether the local Node is eligible for it.
+func (c *Cluster) syncHealthChecker(eipName string, eip *v1beta1.ExternalIPPool) {
+	var healthCheck *v1beta1.ExternalIPPoolHealthCheck
+	if eip != nil && eip.Spec.HealthCheck != nil {
+		if node, err := c.nodeLister.Get(c.nodeName); err == nil {
+			nodeSel, err := metav1.LabelSelectorAsSelector(&eip.Spec.NodeSelector)
+			if err == nil && nodeSel.Matches(labels.Set(node.GetLabels())) {
+				healthCheck = eip.Spec.HealthCheck
+			}
+		}
+	}
+
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	checker, exists := c.healthCheckers[eipName]
+	if exists {
+		if healthCheck != nil && reflect.DeepEqual(checker.healthCheck, healthCheck) {
+			return
+		}
+		checker.stop()
+		delete(c.healthCheckers, eipName)
+		c.setPoolHealthLocked(eipName, true)
+		klog.InfoS("Stopped health checker of ExternalIPPool", "ExternalIPPool", eipName)
+	}
+	if healthCheck == nil {
+		return
+	}
+	checker = newPoolHealthChecker(eipName, healthCheck, c.healthProbeFn, c.handlePoolHealthChange)
+	c.healthCheckers[eipName] = checker
+	go checker.run()
+	klog.InfoS("Started health checker of ExternalIPPool", "ExternalIPPool", eipName)
+}
+
+// stopHealthCheckers stops all running health checkers.
+func (c *Cluster) stopHealthCheckers() {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	for name, checker := range c.healthCheckers {
+		checker.stop()
+		delete(c.healthCheckers, name)
+	}
+}
+
+// handlePoolHealthChange is called by a health checker when the health state of the local Node changes. The new
+// state is advertised to other Nodes and the consistent hash ring of the ExternalIPPool is recomputed.
+func (c *Cluster) handlePoolHealthChange(checker *poolHealthChecker, healthy bool) {
+	c.healthMutex.Lock()
+	// Ignore the result of a checker which has been stopped.
+	if c.healthCheckers[checker.pool] != checker {
+		c.healthMutex.Unlock()
+		return
+	}
+	changed := c.setPoolHealthLocked(checker.pool, healthy)
+	c.healthMutex.Unlock()
+	if !changed {
+		return
+	}
+	c.queue.Add(checker.pool)
+}
+
+// setPoolHealthLocked updates the health state of the local Node for the ExternalIPPool and advertises it to other
+// Nodes if it changes. It returns whether the state changed. healthMutex must be held by the caller.
+func (c *Cluster) setPoolHealthLocked(pool string, healthy bool) bool {
+	if healthy != c.unhealthyPools.Has(pool) {
+		return false
+	}
+	if healthy {
+		c.unhealthyPools.Delete(pool)
+	} else {
+		c.unhealthyPools.Insert(pool)
+	}
+	// UpdateNode calls NodeMeta, which acquires healthMutex, and waits for the update to be propagated, so it must
+	// run asynchronously.
+	go func() {
+		if err := c.mList.UpdateNode(nodeMetaUpdateTimeout); err != nil {
+			klog.ErrorS(err, "Failed to advertise the health state of the local Node", "ExternalIPPool", pool)
+		}
+	}()
+	return true
+}
+
+// unhealthyNodes returns the alive Nodes which fail the health checks of the ExternalIPPool. The health state of the
+// local Node is determined locally while the ones of other Nodes are learned from their metadata.
+func (c *Cluster) unhealthyNodes(pool string) sets.Set[string] {
+	nodes := sets.New[string]()
+	for _, member := range c.mList.Members() {
+		if member.Name == c.nodeName {
+			continue
+		}
+		if nodeMetaHasUnhealthyPool(member.Meta, pool) {
+			nodes.Insert(member.Name)
+		}
+	}
+	c.healthMutex.RLock()
+	defer c.healthMutex.RUnlock()
+	if c.unhealthyPools.Has(pool) {
+		nodes.Insert(c.nodeName)
+	}
+	return nodes
+}
+
+// UnhealthyNodes returns the Nodes which fail the health checks of the ExternalIPPool. The second return value is
+// false if the ExternalIPPool doesn't exist or doesn't have health checks.
+func (c *Cluster) UnhealthyNodes(externalIPPool string) (sets.Set[string], bool) {
+	eip, err := c.externalIPPoolLister.Get(externalIPPool)
+	if err != nil || eip.Spec.HealthCheck == nil {
+		return nil, false
+	}
+	return c.unhealthyNodes(externalIPPool), true
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memberlist
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/consistenthash"
+	crdv1b1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	"antrea.io/antrea/pkg/util/ip"
+)
+
+// selectedNodes returns the Nodes selected for a range of IPs by the consistent hash ring.
+func selectedNodes(m *consistenthash.Map) sets.Set[string] {
+	nodes := sets.New[string]()
+	for i := 0; i < 256; i++ {
+		nodes.Insert(m.Get(fmt.Sprintf("10.10.10.%d", i)))
+	}
+	return nodes
+}
+
+func TestNodeMeta(t *testing.T) {
+	meta := encodeNodeMeta(sets.New[string]("pool1", "pool2"), memberlist.MetaMaxSize)
+	assert.Len(t, meta, 9)
+	assert.True(t, nodeMetaHasUnhealthyPool(meta, "pool1"))
+	assert.True(t, nodeMetaHasUnhealthyPool(meta, "pool2"))
+	assert.False(t, nodeMetaHasUnhealthyPool(meta, "pool3"))
+	assert.False(t, nodeMetaHasUnhealthyPool(nil, "pool1"))
+
+	// The ExternalIPPools which don't fit into the size limit are not advertised.
+	meta = encodeNodeMeta(sets.New[string]("pool1", "pool2"), 5)
+	assert.True(t, nodeMetaHasUnhealthyPool(meta, "pool1"))
+	assert.False(t, nodeMetaHasUnhealthyPool(meta, "pool2"))
+}
+
+func TestPoolHealthCheckerCheck(t *testing.T) {
+	var probeErr error
+	var healthChanges []bool
+	checker := newPoolHealthChecker("pool1", &crdv1b1.ExternalIPPoolHealthCheck{
+		Probes:           []crdv1b1.HealthProbe{{Interface: &crdv1b1.InterfaceHealthProbe{Name: "eth1"}}},
+		FailureThreshold: 2,
+		SuccessThreshold: 2,
+	}, func(probe *crdv1b1.HealthProbe, timeout time.Duration) error {
+		assert.Equal(t, defaultHealthCheckTimeoutSeconds*time.Second, timeout)
+		return probeErr
+	}, func(_ *poolHealthChecker, healthy bool) {
+		healthChanges = append(healthChanges, healthy)
+	})
+
+	checker.check()
+	assert.True(t, checker.healthy)
+	probeErr = fmt.Errorf("interface eth1 is down")
+	checker.check()
+	assert.True(t, checker.healthy, "The Node should be healthy before reaching failureThreshold")
+	checker.check()
+	assert.False(t, checker.healthy)
+	checker.check()
+	probeErr = nil
+	checker.check()
+	assert.False(t, checker.healthy, "The Node should be unhealthy before reaching successThreshold")
+	checker.check()
+	assert.True(t, checker.healthy)
+	assert.Equal(t, []bool{false, true}, healthChanges)
+}
+
+func TestCluster_HealthCheck(t *testing.T) {
+	localNodeConfig := &config.NodeConfig{
+		Name:         "node1",
+		NodeIPv4Addr: ip.MustParseCIDR("10.0.0.1/24"),
+	}
+	var nodes []*v1.Node
+	for i := 1; i <= 3; i++ {
+		nodes = append(nodes, &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node%d", i), Labels: labelsLinuxOS},
+			Status:     v1.NodeStatus{Addresses: []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: fmt.Sprintf("10.0.0.%d", i)}}},
+		})
+	}
+	eip := &crdv1b1.ExternalIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool1"},
+		Spec: crdv1b1.ExternalIPPoolSpec{
+			NodeSelector: metav1.LabelSelector{MatchLabels: labelsLinuxOS},
+			HealthCheck: &crdv1b1.ExternalIPPoolHealthCheck{
+				Probes:           []crdv1b1.HealthProbe{{ICMP: &crdv1b1.ICMPHealthProbe{Target: "192.168.1.1"}}},
+				PeriodSeconds:    1,
+				FailureThreshold: 1,
+			},
+		},
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	controller := gomock.NewController(t)
+	mockMemberlist := NewMockMemberlist(controller)
+	mockMemberlist.EXPECT().Join(gomock.Any()).AnyTimes()
+	fakeCluster, err := newFakeCluster(localNodeConfig, stopCh, mockMemberlist, nodes[0], nodes[1], nodes[2])
+	require.NoError(t, err)
+	cluster := fakeCluster.cluster
+	var probeFailed atomic.Bool
+	cluster.healthProbeFn = func(probe *crdv1b1.HealthProbe, timeout time.Duration) error {
+		if probeFailed.Load() {
+			return fmt.Errorf("target unreachable")
+		}
+		return nil
+	}
+	defer cluster.stopHealthCheckers()
+	require.NoError(t, createExternalIPPool(fakeCluster.crdClient, eip))
+	require.Eventually(t, func() bool {
+		_, err := cluster.externalIPPoolLister.Get(eip.Name)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// node2 advertises that it fails the health checks of the ExternalIPPool.
+	mockMemberlist.EXPECT().Members().Return([]*memberlist.Node{
+		{Name: "node1"},
+		{Name: "node2", Meta: encodeNodeMeta(sets.New[string](eip.Name), memberlist.MetaMaxSize)},
+		{Name: "node3"},
+	}).AnyTimes()
+	require.NoError(t, cluster.syncConsistentHash(eip.Name))
+	assert.Contains(t, cluster.healthCheckers, eip.Name)
+	unhealthyNodes, ok := cluster.UnhealthyNodes(eip.Name)
+	assert.True(t, ok)
+	assert.Equal(t, sets.New[string]("node2"), unhealthyNodes)
+	assert.Equal(t, sets.New[string]("node1", "node3"), selectedNodes(cluster.consistentHashMap[eip.Name]))
+
+	// The local Node fails the health checks and advertises it.
+	updateNodeCalled := make(chan struct{}, 2)
+	mockMemberlist.EXPECT().UpdateNode(nodeMetaUpdateTimeout).Do(func(time.Duration) {
+		updateNodeCalled <- struct{}{}
+	}).Times(2)
+	probeFailed.Store(true)
+	select {
+	case <-updateNodeCalled:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timed out waiting for the Node metadata to be updated")
+	}
+	assert.True(t, nodeMetaHasUnhealthyPool((&nodeDelegate{cluster: cluster}).NodeMeta(memberlist.MetaMaxSize), eip.Name))
+	require.NoError(t, cluster.syncConsistentHash(eip.Name))
+	assert.Equal(t, sets.New[string]("node3"), selectedNodes(cluster.consistentHashMap[eip.Name]))
+	node, err := cluster.SelectNodeForIP("10.10.10.1", eip.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "node3", node)
+
+	// Removing the health checks of the ExternalIPPool stops the checker and marks the local Node healthy again.
+	cluster.syncHealthChecker(eip.Name, nil)
+	select {
+	case <-updateNodeCalled:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timed out waiting for the Node metadata to be updated")
+	}
+	assert.NotContains(t, cluster.healthCheckers, eip.Name)
+	assert.Empty(t, cluster.unhealthyPools)
+}
+
+func TestCluster_AllNodesUnhealthy(t *testing.T) {
+	localNodeConfig := &config.NodeConfig{
+		Name:         "node1",
+		NodeIPv4Addr: ip.MustParseCIDR("10.0.0.1/24"),
+	}
+	var nodes []*v1.Node
+	for i := 1; i <= 3; i++ {
+		nodes = append(nodes, &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node%d", i), Labels: labelsLinuxOS},
+			Status:     v1.NodeStatus{Addresses: []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: fmt.Sprintf("10.0.0.%d", i)}}},
+		})
+	}
+	eip := &crdv1b1.ExternalIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool1"},
+		Spec: crdv1b1.ExternalIPPoolSpec{
+			NodeSelector: metav1.LabelSelector{MatchLabels: labelsLinuxOS},
+			HealthCheck: &crdv1b1.ExternalIPPoolHealthCheck{
+				Probes: []crdv1b1.HealthProbe{{ICMP: &crdv1b1.ICMPHealthProbe{Target: "192.168.1.1"}}},
+			},
+		},
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	controller := gomock.NewController(t)
+	mockMemberlist := NewMockMemberlist(controller)
+	mockMemberlist.EXPECT().Join(gomock.Any()).AnyTimes()
+	mockMemberlist.EXPECT().UpdateNode(nodeMetaUpdateTimeout).AnyTimes()
+	fakeCluster, err := newFakeCluster(localNodeConfig, stopCh, mockMemberlist, nodes[0], nodes[1], nodes[2])
+	require.NoError(t, err)
+	cluster := fakeCluster.cluster
+	cluster.healthProbeFn = func(probe *crdv1b1.HealthProbe, timeout time.Duration) error {
+		return fmt.Errorf("target unreachable")
+	}
+	defer cluster.stopHealthCheckers()
+	require.NoError(t, createExternalIPPool(fakeCluster.crdClient, eip))
+	require.Eventually(t, func() bool {
+		_, err := cluster.externalIPPoolLister.Get(eip.Name)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// All Nodes, including the local one, fail the health checks of the ExternalIPPool.
+	mockMemberlist.EXPECT().Members().Return([]*memberlist.Node{
+		{Name: "node1"},
+		{Name: "node2", Meta: encodeNodeMeta(sets.New[string](eip.Name), memberlist.MetaMaxSize)},
+		{Name: "node3", Meta: encodeNodeMeta(sets.New[string](eip.Name), memberlist.MetaMaxSize)},
+	}).AnyTimes()
+	cluster.healthMutex.Lock()
+	cluster.unhealthyPools.Insert(eip.Name)
+	cluster.healthMutex.Unlock()
+	require.NoError(t, cluster.syncConsistentHash(eip.Name))
+	unhealthyNodes, ok := cluster.UnhealthyNodes(eip.Name)
+	assert.True(t, ok)
+	assert.Equal(t, sets.New[string]("node1", "node2", "node3"), unhealthyNodes)
+	// The health checks are ignored, so that the Egress IPs are still assigned to the alive Nodes.
+	assert.Equal(t, sets.New[string]("node1", "node2", "node3"), selectedNodes(cluster.consistentHashMap[eip.Name]))
+	_, err = cluster.SelectNodeForIP("10.10.10.1", eip.Name)
+	assert.NoError(t, err)
+}
@@ -119,3 +119,18 @@ func (mr *MockInterfaceMockRecorder) ShouldSelectIP(arg0, arg1 any, arg2 ...any)
 	varargs := append([]any{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldSelectIP", reflect.TypeOf((*MockInterface)(nil).ShouldSelectIP), varargs...)
 }
+
+// UnhealthyNodes mocks base method.
+func (m *MockInterface) UnhealthyNodes(arg0 string) (sets.Set[string], bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnhealthyNodes", arg0)
+	ret0, _ := ret[0].(sets.Set[string])
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// UnhealthyNodes indicates an expected call of UnhealthyNodes.
+func (mr *MockInterfaceMockRecorder) UnhealthyNodes(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhealthyNodes", reflect.TypeOf((*MockInterface)(nil).UnhealthyNodes), arg0)
+}
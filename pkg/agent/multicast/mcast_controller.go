@@ -16,6 +16,7 @@ package multicast
 
 import (
 	"net"
+	"sort"
 	"sync"
 	"time"
 
@@ -70,6 +71,11 @@ type mcastGroupEvent struct {
 	iface *interfacestore.InterfaceConfig
 	// srcNode is the Node IP where the IGMP report message is sent from. It is set only with encap mode.
 	srcNode net.IP
+	// recordType is the type of the IGMPv3 or MLDv2 group record which generates the event. It is 0 if the event is
+	// generated from an IGMPv1, IGMPv2 or MLDv1 message.
+	recordType uint8
+	// sources is the source list in the IGMPv3 or MLDv2 group record.
+	sources []net.IP
 }
 
 type GroupMemberStatus struct {
@@ -77,6 +83,9 @@ type GroupMemberStatus struct {
 	// localMembers is a map for the local Pod member and its last update time, key is the Pod's interface name,
 	// and value is its last update time.
 	localMembers map[string]time.Time
+	// localMemberFilters is a map for the local Pod member and its source filter, key is the Pod's interface name.
+	// A local member which is not in the map receives the traffic from all sources.
+	localMemberFilters map[string]*sourceFilter
 	// remoteMembers is a set for Nodes which have joined the multicast group in the cluster. The Node's IP is
 	// added in the set.
 	remoteMembers  sets.Set[string]
@@ -103,10 +112,11 @@ func (c *Controller) eventHandler(stopCh <-chan struct{}) {
 // addGroupMemberStatus adds the new group into groupCache.
 func (c *Controller) addGroupMemberStatus(e *mcastGroupEvent) {
 	status := &GroupMemberStatus{
-		group:         e.group,
-		ofGroupID:     c.v4GroupAllocator.Allocate(),
-		remoteMembers: sets.New[string](),
-		localMembers:  make(map[string]time.Time),
+		group:              e.group,
+		ofGroupID:          c.v4GroupAllocator.Allocate(),
+		remoteMembers:      sets.New[string](),
+		localMembers:       make(map[string]time.Time),
+		localMemberFilters: make(map[string]*sourceFilter),
 	}
 	status = addGroupMember(status, e)
 	c.groupCache.Add(status)
@@ -116,22 +126,26 @@ func (c *Controller) addGroupMemberStatus(e *mcastGroupEvent) {
 }
 
 // updateGroupMemberStatus updates the group status in groupCache. If a "join" message is sent from an existing member,
-// only updates the lastIGMPReport time. If a "join" message is sent from an "unknown" member, updates the lastIGMPReport time and
-// adds the new member into the group's local member set. If a "leave" message is sent from an existing member, removes
+// only updates the lastIGMPReport time and its source filter. If a "join" message is sent from an "unknown" member, updates
+// the lastIGMPReport time and adds the new member into the group's local member set. If a "leave" message is sent from an existing member, removes
 // it from the group's local member set, and if the member is the last one in local cache, a query message on the group
 // is sent out to check if there are still local members in the group.
 func (c *Controller) updateGroupMemberStatus(obj interface{}, e *mcastGroupEvent) {
 	status := obj.(*GroupMemberStatus)
 	newStatus := &GroupMemberStatus{
-		group:          status.group,
-		localMembers:   make(map[string]time.Time),
-		remoteMembers:  status.remoteMembers.Union(nil),
-		lastIGMPReport: status.lastIGMPReport,
-		ofGroupID:      status.ofGroupID,
+		group:              status.group,
+		localMembers:       make(map[string]time.Time),
+		localMemberFilters: make(map[string]*sourceFilter),
+		remoteMembers:      status.remoteMembers.Union(nil),
+		lastIGMPReport:     status.lastIGMPReport,
+		ofGroupID:          status.ofGroupID,
 	}
 	for m, t := range status.localMembers {
 		newStatus.localMembers[m] = t
 	}
+	for m, f := range status.localMemberFilters {
+		newStatus.localMemberFilters[m] = f
+	}
 	exist := memberExists(status, e)
 	switch e.eType {
 	case groupJoin:
@@ -140,6 +154,10 @@ func (c *Controller) updateGroupMemberStatus(obj interface{}, e *mcastGroupEvent
 		if !exist {
 			klog.InfoS("Added member to multicast group", "group", e.group.String(), "member", e.iface.InterfaceName)
 			c.queue.Add(newStatus.group.String())
+		} else if e.iface.Type == interfacestore.ContainerInterface &&
+			!status.localMemberFilters[e.iface.InterfaceName].equal(newStatus.localMemberFilters[e.iface.InterfaceName]) {
+			klog.InfoS("Updated source filter of member in multicast group", "group", e.group.String(), "member", e.iface.InterfaceName)
+			c.queue.Add(newStatus.group.String())
 		}
 	case groupLeave:
 		if exist {
@@ -167,9 +185,9 @@ func (c *Controller) updateGroupMemberStatus(obj interface{}, e *mcastGroupEvent
 // checkLastMember sends out a query message on the group to check if there are still members in the group. If no new
 // membership report is received in the max response time, the group is removed from groupCache.
 func (c *Controller) checkLastMember(group net.IP) {
-	err := c.igmpSnooper.queryIGMP(group)
+	err := c.igmpSnooper.queryGroup(group)
 	if err != nil {
-		klog.ErrorS(err, "Failed to send IGMP query message", "group", group.String())
+		klog.ErrorS(err, "Failed to send query message", "group", group.String())
 		return
 	}
 	c.queue.AddAfter(group.String(), igmpMaxResponseTime)
@@ -252,7 +270,11 @@ type Controller struct {
 	// include the multicast groups that local Pod members join.
 	installedLocalGroups      sets.Set[string]
 	installedLocalGroupsMutex sync.RWMutex
-	mRouteClient              *MRouteClient
+	// installedSourceGroups saves the OpenFlow groups which are configured on OVS for the sources of the SSM groups.
+	// The key is the multicast group, and the value is a map from the source IP to the OpenFlow group ID.
+	installedSourceGroups      map[string]map[string]binding.GroupIDType
+	installedSourceGroupsMutex sync.Mutex
+	mRouteClient               *MRouteClient
 	// queryInterval is the interval to send IGMP query messages.
 	queryInterval time.Duration
 	// mcastGroupTimeout is the timeout to detect a group as stale if no IGMP report is received within the time.
@@ -264,6 +286,8 @@ type Controller struct {
 	// installedNodes is the installed Node set that the IGMP report message is sent to.
 	installedNodes sets.Set[string]
 	encapEnabled   bool
+	// ipv6Enabled indicates whether IPv6 multicast groups are discovered with MLD and routed.
+	ipv6Enabled bool
 }
 
 func NewMulticastController(ofClient openflow.Client,
@@ -271,6 +295,7 @@ func NewMulticastController(ofClient openflow.Client,
 	nodeConfig *config.NodeConfig,
 	ifaceStore interfacestore.InterfaceStore,
 	multicastSocket RouteInterface,
+	ipv6MulticastSocket RouteInterface,
 	multicastInterfaces sets.Set[string],
 	podUpdateSubscriber channel.Subscriber,
 	igmpQueryInterval time.Duration,
@@ -279,27 +304,31 @@ func NewMulticastController(ofClient openflow.Client,
 	isEncap bool,
 	nodeInformer coreinformers.NodeInformer) *Controller {
 	eventCh := make(chan *mcastGroupEvent, workerCount)
-	groupSnooper := newSnooper(ofClient, ifaceStore, eventCh, igmpQueryInterval, igmpQueryVersions, validator, isEncap)
+	// IPv6 multicast is enabled only when the IPv6 multicast socket is provided.
+	ipv6Enabled := ipv6MulticastSocket != nil
+	groupSnooper := newSnooper(ofClient, ifaceStore, eventCh, igmpQueryInterval, igmpQueryVersions, validator, isEncap, ipv6Enabled, nodeConfig.GatewayConfig)
 	groupCache := cache.NewIndexer(getGroupEventKey, cache.Indexers{
 		podInterfaceIndex: podInterfaceIndexFunc,
 	})
-	multicastRouteClient := newRouteClient(nodeConfig, groupCache, multicastSocket, multicastInterfaces, isEncap)
+	multicastRouteClient := newRouteClient(nodeConfig, groupCache, multicastSocket, ipv6MulticastSocket, multicastInterfaces, isEncap)
 	c := &Controller{
-		ofClient:             ofClient,
-		ifaceStore:           ifaceStore,
-		v4GroupAllocator:     v4GroupAllocator,
-		nodeConfig:           nodeConfig,
-		igmpSnooper:          groupSnooper,
-		groupEventCh:         eventCh,
-		groupCache:           groupCache,
-		installedGroups:      sets.New[string](),
-		installedLocalGroups: sets.New[string](),
-		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "multicastgroup"),
-		mRouteClient:         multicastRouteClient,
-		queryInterval:        igmpQueryInterval,
-		mcastGroupTimeout:    igmpQueryInterval * 3,
-		queryGroupId:         v4GroupAllocator.Allocate(),
-		encapEnabled:         isEncap,
+		ofClient:              ofClient,
+		ifaceStore:            ifaceStore,
+		v4GroupAllocator:      v4GroupAllocator,
+		nodeConfig:            nodeConfig,
+		igmpSnooper:           groupSnooper,
+		groupEventCh:          eventCh,
+		groupCache:            groupCache,
+		installedGroups:       sets.New[string](),
+		installedLocalGroups:  sets.New[string](),
+		installedSourceGroups: make(map[string]map[string]binding.GroupIDType),
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "multicastgroup"),
+		mRouteClient:          multicastRouteClient,
+		queryInterval:         igmpQueryInterval,
+		mcastGroupTimeout:     igmpQueryInterval * 3,
+		queryGroupId:          v4GroupAllocator.Allocate(),
+		encapEnabled:          isEncap,
+		ipv6Enabled:           ipv6Enabled,
 	}
 	if isEncap {
 		c.nodeGroupID = v4GroupAllocator.Allocate()
@@ -355,6 +384,11 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 		if err := c.igmpSnooper.queryIGMP(net.IPv4zero); err != nil {
 			klog.ErrorS(err, "Failed to send IGMP query")
 		}
+		if c.ipv6Enabled {
+			if err := c.igmpSnooper.queryMLD(net.IPv6zero); err != nil {
+				klog.ErrorS(err, "Failed to send MLD query")
+			}
+		}
 	}, c.queryInterval, stopCh)
 
 	if c.encapEnabled {
@@ -431,6 +465,8 @@ func (c *Controller) syncGroup(groupKey string) error {
 		return nil
 	}
 	status := obj.(*GroupMemberStatus)
+	ssmGroup := isSSMGroup(status.group)
+	localMemberPorts := make(map[string]uint32, len(status.localMembers))
 	memberPorts := make([]uint32, 0, len(status.localMembers)+1)
 	memberPorts = append(memberPorts, config.HostGatewayOFPort)
 	for memberInterfaceName := range status.localMembers {
@@ -439,7 +475,12 @@ func (c *Controller) syncGroup(groupKey string) error {
 			klog.InfoS("Failed to find interface from cache", "interface", memberInterfaceName)
 			continue
 		}
-		memberPorts = append(memberPorts, uint32(obj.OFPort))
+		localMemberPorts[memberInterfaceName] = uint32(obj.OFPort)
+		// The local members of a SSM group receive the traffic only from the sources they include, so they are
+		// added into the OpenFlow groups for the sources instead.
+		if !ssmGroup {
+			memberPorts = append(memberPorts, uint32(obj.OFPort))
+		}
 	}
 	var remoteNodeReceivers []net.IP
 	if c.encapEnabled {
@@ -449,12 +490,16 @@ func (c *Controller) syncGroup(groupKey string) error {
 		}
 	}
 	installLocalMulticastGroup := func() error {
-		if err := c.mRouteClient.multicastInterfacesJoinMgroup(status.group); err != nil {
-			klog.ErrorS(err, "Failed to install multicast group identified with local members", "group", groupKey)
-			return err
+		// The multicast interfaces join a SSM group only for the sources included by the local members, which is done
+		// in syncSourceGroups.
+		if !ssmGroup {
+			if err := c.mRouteClient.multicastInterfacesJoinMgroup(status.group); err != nil {
+				klog.ErrorS(err, "Failed to install multicast group identified with local members", "group", groupKey)
+				return err
+			}
 		}
 		if c.encapEnabled {
-			if err := c.igmpSnooper.sendIGMPJoinReport([]net.IP{status.group}); err != nil {
+			if err := c.igmpSnooper.sendJoinReport([]net.IP{status.group}); err != nil {
 				klog.ErrorS(err, "Failed to sync local multicast group to other Nodes", "group", groupKey)
 				return err
 			}
@@ -470,17 +515,19 @@ func (c *Controller) syncGroup(groupKey string) error {
 			return err
 		}
 		klog.InfoS("Removed multicast route entry", "group", status.group)
-		err = c.mRouteClient.multicastInterfacesLeaveMgroup(status.group)
-		if err != nil {
-			klog.ErrorS(err, "Failed to leave multicast group for multicast interfaces", "group", groupKey)
-			return err
+		if !ssmGroup {
+			err = c.mRouteClient.multicastInterfacesLeaveMgroup(status.group)
+			if err != nil {
+				klog.ErrorS(err, "Failed to leave multicast group for multicast interfaces", "group", groupKey)
+				return err
+			}
 		}
 
 		if c.encapEnabled {
 			group := net.ParseIP(groupKey)
-			// Send IGMP leave message to other Nodes to notify the current Node leaves the given multicast group.
-			if err := c.igmpSnooper.sendIGMPLeaveReport([]net.IP{group}); err != nil {
-				klog.ErrorS(err, "Failed to send IGMP leave message to other Nodes", "group", groupKey)
+			// Send IGMP or MLD leave message to other Nodes to notify the current Node leaves the given multicast group.
+			if err := c.igmpSnooper.sendLeaveReport([]net.IP{group}); err != nil {
+				klog.ErrorS(err, "Failed to send leave message to other Nodes", "group", groupKey)
 			}
 		}
 		c.delInstalledLocalGroup(groupKey)
@@ -496,6 +543,10 @@ func (c *Controller) syncGroup(groupKey string) error {
 			// TODO: add check on the stale multicast group that is joined by the Pods on a different Node.
 			// remoteMembers is always empty with noEncap mode.
 			if status.remoteMembers.Len() == 0 {
+				if err := c.syncSourceGroups(status.group, nil, nil); err != nil {
+					klog.ErrorS(err, "Failed to uninstall OpenFlow groups for sources", "group", groupKey)
+					return err
+				}
 				// Remove the multicast OpenFlow flow and group entries if none Pod member on local or remote Node is in the group.
 				if err := c.ofClient.UninstallMulticastFlows(status.group); err != nil {
 					klog.ErrorS(err, "Failed to uninstall multicast flows", "group", groupKey)
@@ -524,6 +575,9 @@ func (c *Controller) syncGroup(groupKey string) error {
 			return err
 		}
 		klog.InfoS("Updated OpenFlow group for receivers in multicast group", "group", groupKey, "ofGroup", status.ofGroupID, "localReceivers", memberPorts, "remoteReceivers", remoteNodeReceivers)
+		if ssmGroup {
+			return c.syncSourceGroups(status.group, getSourceReceivers(status, localMemberPorts), remoteNodeReceivers)
+		}
 		return nil
 	}
 	// Install OpenFlow group for a new multicast group which has local Pod receivers joined.
@@ -537,6 +591,11 @@ func (c *Controller) syncGroup(groupKey string) error {
 		return err
 	}
 	klog.InfoS("Installed OpenFlow flows for multicast group", "group", groupKey, "ofGroup", status.ofGroupID, "localReceivers", memberPorts, "remoteReceivers", remoteNodeReceivers)
+	if ssmGroup {
+		if err := c.syncSourceGroups(status.group, getSourceReceivers(status, localMemberPorts), remoteNodeReceivers); err != nil {
+			return err
+		}
+	}
 	if len(status.localMembers) > 0 {
 		err := installLocalMulticastGroup()
 		if err != nil {
@@ -547,6 +606,80 @@ func (c *Controller) syncGroup(groupKey string) error {
 	return nil
 }
 
+// syncSourceGroups installs an OpenFlow group and flow for each source included by the local members of a SSM group, so
+// that the traffic sent from the source is forwarded only to the local members which subscribe to it, and joins the
+// group for the source on the multicast interfaces. The OpenFlow groups and flows of the sources that are no longer
+// included by any local member are removed, and the multicast interfaces leave the group for these sources.
+func (c *Controller) syncSourceGroups(group net.IP, sourceReceivers map[string][]uint32, remoteNodeReceivers []net.IP) error {
+	groupKey := group.String()
+	c.installedSourceGroupsMutex.Lock()
+	defer c.installedSourceGroupsMutex.Unlock()
+	installed, ok := c.installedSourceGroups[groupKey]
+	if !ok {
+		installed = make(map[string]binding.GroupIDType)
+	}
+	defer func() {
+		if len(installed) == 0 {
+			delete(c.installedSourceGroups, groupKey)
+		} else {
+			c.installedSourceGroups[groupKey] = installed
+		}
+	}()
+	for source, ports := range sourceReceivers {
+		// Antrea gateway is always a receiver, so that the traffic sent by local Pods can be forwarded to the external
+		// receivers.
+		receivers := append([]uint32{config.HostGatewayOFPort}, ports...)
+		ofGroupID, exists := installed[source]
+		if !exists {
+			ofGroupID = c.v4GroupAllocator.Allocate()
+		}
+		if err := c.ofClient.InstallMulticastGroup(ofGroupID, receivers, remoteNodeReceivers); err != nil {
+			if !exists {
+				c.v4GroupAllocator.Release(ofGroupID)
+			}
+			return err
+		}
+		if !exists {
+			if err := c.ofClient.InstallMulticastSourceFlows(group, net.ParseIP(source), ofGroupID); err != nil {
+				if err := c.ofClient.UninstallMulticastGroup(ofGroupID); err != nil {
+					klog.ErrorS(err, "Failed to uninstall OpenFlow group for source", "group", groupKey, "source", source)
+					installed[source] = ofGroupID
+					return err
+				}
+				c.v4GroupAllocator.Release(ofGroupID)
+				return err
+			}
+			installed[source] = ofGroupID
+			klog.InfoS("Installed OpenFlow group and flows for source in SSM group", "group", groupKey, "source", source, "ofGroup", ofGroupID, "localReceivers", receivers)
+		}
+		// The multicast interfaces join the group only for the source, so that the reports sent to the external
+		// network include it. Joining a source which has been joined is a no-op, so it is retried with every sync.
+		if err := c.mRouteClient.multicastInterfacesJoinSourceMgroup(group, net.ParseIP(source)); err != nil {
+			klog.ErrorS(err, "Failed to join SSM group for source on multicast interfaces", "group", groupKey, "source", source)
+			return err
+		}
+	}
+	for source, ofGroupID := range installed {
+		if _, ok := sourceReceivers[source]; ok {
+			continue
+		}
+		if err := c.mRouteClient.multicastInterfacesLeaveSourceMgroup(group, net.ParseIP(source)); err != nil {
+			klog.ErrorS(err, "Failed to leave SSM group for source on multicast interfaces", "group", groupKey, "source", source)
+			return err
+		}
+		if err := c.ofClient.UninstallMulticastSourceFlows(group, net.ParseIP(source)); err != nil {
+			return err
+		}
+		if err := c.ofClient.UninstallMulticastGroup(ofGroupID); err != nil {
+			return err
+		}
+		c.v4GroupAllocator.Release(ofGroupID)
+		delete(installed, source)
+		klog.InfoS("Removed OpenFlow group and flows for source in SSM group", "group", groupKey, "source", source, "ofGroup", ofGroupID)
+	}
+	return nil
+}
+
 // groupIsStale returns true if no local members in the group, or there is no IGMP report received after c.mcastGroupTimeout.
 func (c *Controller) groupIsStale(status *GroupMemberStatus) bool {
 	membersCount := len(status.localMembers)
@@ -592,6 +725,17 @@ func (c *Controller) delInstalledLocalGroup(groupKey string) {
 
 func (c *Controller) addOrUpdateGroupEvent(e *mcastGroupEvent) {
 	obj, ok, _ := c.groupCache.GetByKey(e.group.String())
+	if e.eType == groupJoin && e.iface.Type == interfacestore.ContainerInterface {
+		var current *sourceFilter
+		if ok {
+			current = obj.(*GroupMemberStatus).localMemberFilters[e.iface.InterfaceName]
+		}
+		// A member which blocks all the sources it includes doesn't receive any traffic from the group, which is the
+		// same as leaving the group.
+		if updateSourceFilter(current, e.recordType, e.sources).isEmptyInclude() {
+			e.eType = groupLeave
+		}
+	}
 	switch e.eType {
 	case groupJoin:
 		if !ok {
@@ -627,6 +771,13 @@ func (c *Controller) initQueryGroup() error {
 		klog.ErrorS(err, "Failed to install multicast flows", "group", types.McastAllHosts)
 		return err
 	}
+	if c.ipv6Enabled {
+		// MLD queries are sent to the same local receivers as IGMP queries.
+		if err = c.ofClient.InstallMulticastFlows(types.IPv6McastAllHosts, c.queryGroupId); err != nil {
+			klog.ErrorS(err, "Failed to install multicast flows", "group", types.IPv6McastAllHosts)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -646,7 +797,7 @@ func (c *Controller) updateQueryGroup() error {
 	return nil
 }
 
-// syncLocalGroupsToOtherNodes sends IGMP join message to other Nodes in the same cluster to notify what multicast groups
+// syncLocalGroupsToOtherNodes sends IGMP or MLD join message to other Nodes in the same cluster to notify what multicast groups
 // are joined by this Node. This function is used only with encap mode.
 func (c *Controller) syncLocalGroupsToOtherNodes() {
 	if c.installedLocalGroups.Len() == 0 {
@@ -658,7 +809,7 @@ func (c *Controller) syncLocalGroupsToOtherNodes() {
 		localGroups = append(localGroups, net.ParseIP(group))
 	}
 	c.installedLocalGroupsMutex.RUnlock()
-	if err := c.igmpSnooper.sendIGMPJoinReport(localGroups); err != nil {
+	if err := c.igmpSnooper.sendJoinReport(localGroups); err != nil {
 		klog.ErrorS(err, "Failed to sync local multicast groups to other Nodes")
 	}
 }
@@ -740,6 +891,29 @@ func (c *Controller) GetGroupPods() map[string][]v1beta2.PodReference {
 	return groupPodsMap
 }
 
+// GetPodGroupMemberships returns the multicast groups joined by the given Pod, and the source filter of the Pod in each
+// group.
+func (c *Controller) GetPodGroupMemberships(podName string, podNamespace string) []PodGroupMembership {
+	var memberships []PodGroupMembership
+	for _, iface := range c.ifaceStore.GetContainerInterfacesByPod(podName, podNamespace) {
+		for _, status := range c.getGroupMemberStatusesByPod(iface.InterfaceName) {
+			membership := PodGroupMembership{
+				Group:      status.group.String(),
+				FilterMode: excludeMode.String(),
+			}
+			if filter, ok := status.localMemberFilters[iface.InterfaceName]; ok {
+				membership.FilterMode = filter.mode.String()
+				membership.Sources = sets.List(filter.sources)
+			}
+			memberships = append(memberships, membership)
+		}
+	}
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].Group < memberships[j].Group
+	})
+	return memberships
+}
+
 // PodTrafficStats encodes the inbound and outbound multicast statistics of each Pod.
 type PodTrafficStats struct {
 	Inbound, Outbound uint64
@@ -855,6 +1029,7 @@ func memberExists(status *GroupMemberStatus, e *mcastGroupEvent) bool {
 func addGroupMember(status *GroupMemberStatus, e *mcastGroupEvent) *GroupMemberStatus {
 	if e.iface.Type == interfacestore.ContainerInterface {
 		status.localMembers[e.iface.InterfaceName] = e.time
+		status.localMemberFilters[e.iface.InterfaceName] = updateSourceFilter(status.localMemberFilters[e.iface.InterfaceName], e.recordType, e.sources)
 		status.lastIGMPReport = e.time
 		klog.V(2).InfoS("Added local member from multicast group", "group", e.group.String(), "member", e.iface.InterfaceName)
 	} else {
@@ -867,6 +1042,7 @@ func addGroupMember(status *GroupMemberStatus, e *mcastGroupEvent) *GroupMemberS
 func deleteGroupMember(status *GroupMemberStatus, e *mcastGroupEvent) *GroupMemberStatus {
 	if e.iface.Type == interfacestore.ContainerInterface {
 		delete(status.localMembers, e.iface.InterfaceName)
+		delete(status.localMemberFilters, e.iface.InterfaceName)
 		klog.V(2).InfoS("Deleted local member from multicast group", "group", e.group.String(), "member", e.iface.InterfaceName)
 	} else {
 		status.remoteMembers.Delete(e.srcNode.String())
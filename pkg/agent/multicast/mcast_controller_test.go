@@ -52,6 +52,7 @@ import (
 	agentutil "antrea.io/antrea/pkg/agent/util"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	"antrea.io/antrea/pkg/apis/crd/v1beta1"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/util/channel"
 )
 
@@ -179,6 +180,68 @@ func TestUpdateGroupMemberStatus(t *testing.T) {
 	}
 }
 
+func TestSSMGroupSourceFilter(t *testing.T) {
+	mctrl := newMockMulticastController(t, false)
+	err := mctrl.initialize(t)
+	assert.NoError(t, err)
+	mctrl.mRouteClient.multicastInterfaceConfigs = []multicastInterfaceConfig{
+		{Name: if1.InterfaceName, IPv4Addr: &net.IPNet{IP: nodeIf1IP, Mask: net.IPv4Mask(255, 255, 255, 0)}},
+	}
+	mgroup := net.ParseIP("232.1.1.1")
+	source1 := net.ParseIP("10.10.0.1")
+	source2 := net.ParseIP("10.10.0.2")
+	now := time.Now()
+
+	mctrl.addOrUpdateGroupEvent(&mcastGroupEvent{group: mgroup, eType: groupJoin, time: now, iface: if1, recordType: protocol.IGMPIsIn, sources: []net.IP{source1, source2}})
+	mctrl.addOrUpdateGroupEvent(&mcastGroupEvent{group: mgroup, eType: groupJoin, time: now, iface: if2, recordType: protocol.IGMPIsIn, sources: []net.IP{source1}})
+	obj, _, _ := mctrl.groupCache.GetByKey(mgroup.String())
+	status := obj.(*GroupMemberStatus)
+	assert.Equal(t, map[string][]uint32{source1.String(): {1, 2}, source2.String(): {1}}, getSourceReceivers(status, map[string]uint32{"if1": 1, "if2": 2}))
+
+	mockIfaceStore.EXPECT().GetInterfaceByName(if1.InterfaceName).Return(if1, true).Times(2)
+	mockIfaceStore.EXPECT().GetInterfaceByName(if2.InterfaceName).Return(if2, true).Times(2)
+	// The local members of the SSM group are not added into the OpenFlow group for the multicast group.
+	mockOFClient.EXPECT().InstallMulticastGroup(status.ofGroupID, []uint32{config.HostGatewayOFPort}, gomock.Any()).Times(2)
+	mockOFClient.EXPECT().InstallMulticastFlows(mgroup, status.ofGroupID).Times(1)
+	var source1GroupID, source2GroupID binding.GroupIDType
+	mockOFClient.EXPECT().InstallMulticastGroup(gomock.Any(), []uint32{config.HostGatewayOFPort, 1, 2}, gomock.Any()).Times(2)
+	mockOFClient.EXPECT().InstallMulticastGroup(gomock.Any(), []uint32{config.HostGatewayOFPort, 1}, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallMulticastSourceFlows(mgroup, source1, gomock.Any()).Do(func(_, _ net.IP, groupID binding.GroupIDType) {
+		source1GroupID = groupID
+	}).Times(1)
+	mockOFClient.EXPECT().InstallMulticastSourceFlows(mgroup, source2, gomock.Any()).Do(func(_, _ net.IP, groupID binding.GroupIDType) {
+		source2GroupID = groupID
+	}).Times(1)
+	// The multicast interfaces join the SSM group only for the included sources, instead of joining it for all sources.
+	mockMulticastSocket.EXPECT().MulticastInterfaceJoinSourceMgroup(mgroup.To4(), source1.To4(), nodeIf1IP.To4(), if1.InterfaceName).Times(2)
+	mockMulticastSocket.EXPECT().MulticastInterfaceJoinSourceMgroup(mgroup.To4(), source2.To4(), nodeIf1IP.To4(), if1.InterfaceName).Times(1)
+	assert.NoError(t, mctrl.syncGroup(mgroup.String()))
+	assert.NotEqual(t, source1GroupID, source2GroupID)
+	assert.Equal(t, map[string]binding.GroupIDType{source1.String(): source1GroupID, source2.String(): source2GroupID}, mctrl.installedSourceGroups[mgroup.String()])
+
+	// if1 blocks source2, so the OpenFlow group and flow for source2 are removed, and the multicast interfaces leave the
+	// group for source2.
+	mctrl.addOrUpdateGroupEvent(&mcastGroupEvent{group: mgroup, eType: groupJoin, time: now.Add(time.Second), iface: if1, recordType: protocol.IGMPBlock, sources: []net.IP{source2}})
+	obj, _, _ = mctrl.groupCache.GetByKey(mgroup.String())
+	status = obj.(*GroupMemberStatus)
+	assert.Equal(t, sets.New[string](source1.String()), status.localMemberFilters[if1.InterfaceName].sources)
+	mockOFClient.EXPECT().UninstallMulticastSourceFlows(mgroup, source2).Times(1)
+	mockOFClient.EXPECT().UninstallMulticastGroup(source2GroupID).Times(1)
+	mockMulticastSocket.EXPECT().MulticastInterfaceLeaveSourceMgroup(mgroup.To4(), source2.To4(), nodeIf1IP.To4(), if1.InterfaceName).Times(1)
+	assert.NoError(t, mctrl.syncGroup(mgroup.String()))
+	assert.Equal(t, map[string]binding.GroupIDType{source1.String(): source1GroupID}, mctrl.installedSourceGroups[mgroup.String()])
+
+	// if2 blocks the only source it includes, which is the same as leaving the group.
+	mockIfaceStore.EXPECT().GetInterfaceByName(if2.InterfaceName).Return(if2, true).Times(1)
+	mctrl.addOrUpdateGroupEvent(&mcastGroupEvent{group: mgroup, eType: groupJoin, time: now.Add(time.Second * 2), iface: if2, recordType: protocol.IGMPBlock, sources: []net.IP{source1}})
+	obj, _, _ = mctrl.groupCache.GetByKey(mgroup.String())
+	status = obj.(*GroupMemberStatus)
+	_, exists := status.localMembers[if2.InterfaceName]
+	assert.False(t, exists)
+	_, exists = status.localMemberFilters[if2.InterfaceName]
+	assert.False(t, exists)
+}
+
 func TestCheckNodeUpdate(t *testing.T) {
 	mockController := newMockMulticastController(t, false)
 	err := mockController.initialize(t)
@@ -1255,7 +1318,7 @@ func newMockMulticastController(t *testing.T, isEncap bool) *Controller {
 	clientset = fake.NewSimpleClientset()
 	informerFactory = informers.NewSharedInformerFactory(clientset, 12*time.Hour)
 	nodeInformer := informerFactory.Core().V1().Nodes()
-	mctrl := NewMulticastController(mockOFClient, groupAllocator, nodeConfig, mockIfaceStore, mockMulticastSocket, sets.New[string](), podUpdateSubscriber, time.Second*5, []uint8{1, 2, 3}, mockMulticastValidator, isEncap, nodeInformer)
+	mctrl := NewMulticastController(mockOFClient, groupAllocator, nodeConfig, mockIfaceStore, mockMulticastSocket, nil, sets.New[string](), podUpdateSubscriber, time.Second*5, []uint8{1, 2, 3}, mockMulticastValidator, isEncap, nodeInformer)
 	return mctrl
 }
 
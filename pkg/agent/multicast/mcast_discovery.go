@@ -27,6 +27,7 @@ import (
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 
+	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/agent/types"
@@ -66,6 +67,10 @@ type IGMPSnooper struct {
 	igmpReportACNPStats      map[apitypes.UID]map[string]*types.RuleMetric
 	igmpReportACNPStatsMutex sync.Mutex
 	encapEnabled             bool
+	// mldQueryVersions are the versions of MLD queries to send, which are derived from queryVersions. It's empty if
+	// IPv6 is not enabled.
+	mldQueryVersions []uint8
+	gatewayConfig    *config.GatewayConfig
 }
 
 func (s *IGMPSnooper) parseSrcInterface(pktIn *ofctrl.PacketIn) (*interfacestore.InterfaceConfig, error) {
@@ -100,7 +105,15 @@ func (s *IGMPSnooper) queryIGMP(group net.IP) error {
 	return nil
 }
 
-func (s *IGMPSnooper) validate(event *mcastGroupEvent, igmpType uint8, packetInData protocol.Ethernet) (bool, error) {
+// queryGroup sends IGMP or MLD queries on the group according to its IP family.
+func (s *IGMPSnooper) queryGroup(group net.IP) error {
+	if group.To4() != nil {
+		return s.queryIGMP(group)
+	}
+	return s.queryMLD(group)
+}
+
+func (s *IGMPSnooper) validate(event *mcastGroupEvent, igmpType uint8, packetLen uint64) (bool, error) {
 	if s.validator == nil {
 		// Return true directly if there is no validator.
 		return true, nil
@@ -123,7 +136,7 @@ func (s *IGMPSnooper) validate(event *mcastGroupEvent, igmpType uint8, packetInD
 
 	if ruleInfo != nil {
 		klog.V(2).InfoS("Got NetworkPolicy action for IGMP report", "RuleAction", ruleInfo.RuleAction, "uuid", ruleInfo.UUID, "Name", ruleInfo.Name)
-		s.addToIGMPReportNPStatsMap(*ruleInfo, uint64(packetInData.Len()))
+		s.addToIGMPReportNPStatsMap(*ruleInfo, packetLen)
 		if ruleInfo.RuleAction == v1beta1.RuleActionDrop {
 			return false, nil
 		}
@@ -132,8 +145,8 @@ func (s *IGMPSnooper) validate(event *mcastGroupEvent, igmpType uint8, packetInD
 	return true, nil
 }
 
-func (s *IGMPSnooper) validatePacketAndNotify(event *mcastGroupEvent, igmpType uint8, packetInData protocol.Ethernet) {
-	allow, err := s.validate(event, igmpType, packetInData)
+func (s *IGMPSnooper) validatePacketAndNotify(event *mcastGroupEvent, igmpType uint8, packetLen uint64) {
+	allow, err := s.validate(event, igmpType, packetLen)
 	if err != nil {
 		// Antrea Agent does not remove the Pod from the OpenFlow group bucket immediately when an error is returned,
 		// but it will be removed when after timeout (Controller.mcastGroupTimeout)
@@ -212,12 +225,36 @@ func (s *IGMPSnooper) generateIGMPReportPacket(groupRecordType uint8, groups []n
 	}, nil
 }
 
-func (s *IGMPSnooper) sendIGMPJoinReport(groups []net.IP) error {
-	return s.sendIGMPReport(protocol.IGMPIsEx, groups)
+// sendReport sends the report of the groups to other Nodes. The IPv4 groups are reported with an IGMPv3 report, and
+// the IPv6 groups are reported with a MLDv2 report.
+func (s *IGMPSnooper) sendReport(groupRecordType uint8, groups []net.IP) error {
+	var v4Groups, v6Groups []net.IP
+	for _, group := range groups {
+		if group.To4() != nil {
+			v4Groups = append(v4Groups, group)
+		} else {
+			v6Groups = append(v6Groups, group)
+		}
+	}
+	if len(v4Groups) > 0 {
+		if err := s.sendIGMPReport(groupRecordType, v4Groups); err != nil {
+			return err
+		}
+	}
+	if len(v6Groups) > 0 {
+		if err := s.sendMLDReport(groupRecordType, v6Groups); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *IGMPSnooper) sendJoinReport(groups []net.IP) error {
+	return s.sendReport(protocol.IGMPIsEx, groups)
 }
 
-func (s *IGMPSnooper) sendIGMPLeaveReport(groups []net.IP) error {
-	return s.sendIGMPReport(protocol.IGMPToIn, groups)
+func (s *IGMPSnooper) sendLeaveReport(groups []net.IP) error {
+	return s.sendReport(protocol.IGMPToIn, groups)
 }
 
 func (s *IGMPSnooper) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
@@ -226,7 +263,7 @@ func (s *IGMPSnooper) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 	if err != nil {
 		return err
 	}
-	klog.V(2).InfoS("Received PacketIn for IGMP packet", "in_port", iface.OFPort)
+	klog.V(2).InfoS("Received PacketIn for IGMP or MLD packet", "in_port", iface.OFPort)
 	podName := "unknown"
 	var srcNode net.IP
 	if iface.Type == interfacestore.ContainerInterface {
@@ -238,8 +275,12 @@ func (s *IGMPSnooper) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 			return err
 		}
 	}
+	pktBytes := pktIn.Data.(*util.Buffer).Bytes()
+	if isIPv6Packet(pktBytes) {
+		return s.handleMLDPacket(pktBytes, iface, podName, srcNode, now)
+	}
 	pktData := new(protocol.Ethernet)
-	if err := pktData.UnmarshalBinary(pktIn.Data.(*util.Buffer).Bytes()); err != nil {
+	if err := pktData.UnmarshalBinary(pktBytes); err != nil {
 		return fmt.Errorf("failed to parse ethernet packet from packet-in message: %v", err)
 	}
 	igmp, err := parseIGMPPacket(*pktData)
@@ -259,7 +300,7 @@ func (s *IGMPSnooper) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 			time:  now,
 			iface: iface,
 		}
-		s.validatePacketAndNotify(event, igmpType, *pktData)
+		s.validatePacketAndNotify(event, igmpType, uint64(pktData.Len()))
 	case protocol.IGMPv3Report:
 		msg := igmp.(*protocol.IGMPv3MembershipReport)
 		for _, gr := range msg.GroupRecords {
@@ -270,13 +311,15 @@ func (s *IGMPSnooper) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 				evtType = groupLeave
 			}
 			event := &mcastGroupEvent{
-				group:   mgroup,
-				eType:   evtType,
-				time:    now,
-				iface:   iface,
-				srcNode: srcNode,
+				group:      mgroup,
+				eType:      evtType,
+				time:       now,
+				iface:      iface,
+				srcNode:    srcNode,
+				recordType: gr.Type,
+				sources:    gr.SourceAddresses,
 			}
-			s.validatePacketAndNotify(event, igmpType, *pktData)
+			s.validatePacketAndNotify(event, igmpType, uint64(pktData.Len()))
 		}
 	case protocol.IGMPv2LeaveGroup:
 		mgroup := igmp.(*protocol.IGMPv1or2).GroupAddress
@@ -373,8 +416,11 @@ func parseIGMPPacket(pkt protocol.Ethernet) (protocol.IGMPMessage, error) {
 	}
 }
 
-func newSnooper(ofClient openflow.Client, ifaceStore interfacestore.InterfaceStore, eventCh chan *mcastGroupEvent, queryInterval time.Duration, igmpQueryVersions []uint8, multicastValidator types.McastNetworkPolicyController, encapEnabled bool) *IGMPSnooper {
-	snooper := &IGMPSnooper{ofClient: ofClient, ifaceStore: ifaceStore, eventCh: eventCh, validator: multicastValidator, queryInterval: queryInterval, queryVersions: igmpQueryVersions, encapEnabled: encapEnabled}
+func newSnooper(ofClient openflow.Client, ifaceStore interfacestore.InterfaceStore, eventCh chan *mcastGroupEvent, queryInterval time.Duration, igmpQueryVersions []uint8, multicastValidator types.McastNetworkPolicyController, encapEnabled bool, ipv6Enabled bool, gatewayConfig *config.GatewayConfig) *IGMPSnooper {
+	snooper := &IGMPSnooper{ofClient: ofClient, ifaceStore: ifaceStore, eventCh: eventCh, validator: multicastValidator, queryInterval: queryInterval, queryVersions: igmpQueryVersions, encapEnabled: encapEnabled, gatewayConfig: gatewayConfig}
+	if ipv6Enabled {
+		snooper.mldQueryVersions = mldQueryVersions(igmpQueryVersions)
+	}
 	snooper.igmpReportACNPStats = make(map[apitypes.UID]map[string]*types.RuleMetric)
 	snooper.igmpReportANNPStats = make(map[apitypes.UID]map[string]*types.RuleMetric)
 	ofClient.RegisterPacketInHandler(uint8(openflow.PacketInCategoryIGMP), snooper)
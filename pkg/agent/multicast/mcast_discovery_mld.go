@@ -0,0 +1,384 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicast
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"antrea.io/libOpenflow/openflow15"
+	"antrea.io/libOpenflow/protocol"
+	"antrea.io/libOpenflow/util"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/types"
+)
+
+// MLD is the IPv6 counterpart of IGMP. MLDv1 (https://datatracker.ietf.org/doc/html/rfc2710) is derived from IGMPv2,
+// and MLDv2 (https://datatracker.ietf.org/doc/html/rfc3810) is derived from IGMPv3. MLD messages are ICMPv6 messages
+// carried in IPv6 packets with the Router Alert option in a Hop-by-Hop Options header. libOpenflow doesn't support
+// the Hop-by-Hop Options header, so the MLD packets are parsed and generated as raw bytes.
+const (
+	ICMPv6ProtocolNumber = 58
+
+	MLDQuery    uint8 = 130
+	MLDv1Report uint8 = 131
+	MLDv1Done   uint8 = 132
+	MLDv2Report uint8 = 143
+
+	ethernetHeaderLength   = 14
+	ipv6HeaderLength       = 40
+	ipv6HopByHopHeaderType = 0
+	ipv6RoutingHeaderType  = 43
+	ipv6DstOptsHeaderType  = 60
+	// The Hop-by-Hop Options header with a Router Alert option (type 5, length 2, value 0 for MLD) and a PadN option
+	// (type 1, length 0) to align the header to 8 bytes.
+	mldHopByHopHeaderLength = 8
+	mldv1MessageLength      = 24
+	mldv2QueryLength        = 28
+	mldv2ReportHeaderLength = 8
+	mldv2RecordHeaderLength = 20
+)
+
+var (
+	// mldQueryDstMac is the MAC address used in the dst MAC field in the MLD query message, which is mapped from
+	// ff02::1.
+	mldQueryDstMac, _ = net.ParseMAC("33:33:00:00:00:01")
+	// mldReportDstMac is the MAC address used in the dst MAC field in the MLDv2 report message, which is mapped from
+	// ff02::16.
+	mldReportDstMac, _ = net.ParseMAC("33:33:00:00:00:16")
+)
+
+// mldMessage includes the fields of MLD messages used by the snooper. GroupAddress is set for MLDv1 reports and done
+// messages, and GroupRecords is set for MLDv2 reports.
+type mldMessage struct {
+	Type         uint8
+	GroupAddress net.IP
+	GroupRecords []mldGroupRecord
+}
+
+// mldGroupRecord is a Multicast Address Record in a MLDv2 report. Its record types are the same as the ones of IGMPv3
+// group records.
+type mldGroupRecord struct {
+	Type             uint8
+	MulticastAddress net.IP
+	SourceAddresses  []net.IP
+}
+
+// mldQueryVersions returns the MLD versions of the queries to send for the configured IGMP query versions. MLDv1
+// corresponds to IGMPv1 and IGMPv2, and MLDv2 corresponds to IGMPv3.
+func mldQueryVersions(igmpQueryVersions []uint8) []uint8 {
+	var v1, v2 bool
+	for _, version := range igmpQueryVersions {
+		switch version {
+		case 1, 2:
+			v1 = true
+		case 3:
+			v2 = true
+		}
+	}
+	var versions []uint8
+	if v1 {
+		versions = append(versions, 1)
+	}
+	if v2 {
+		versions = append(versions, 2)
+	}
+	return versions
+}
+
+// mldToIGMPType returns the IGMP report type corresponding to the MLD report type, which is used to match the IGMP
+// NetworkPolicy rules for MLD reports.
+func mldToIGMPType(mldType uint8) uint8 {
+	if mldType == MLDv1Report {
+		return protocol.IGMPv2Report
+	}
+	return protocol.IGMPv3Report
+}
+
+// gatewayLinkLocalAddr returns the IPv6 link-local address generated from the MAC address of Antrea gateway with the
+// modified EUI-64 format, which is used as the source address of the MLD messages sent by Antrea Agent.
+func gatewayLinkLocalAddr(mac net.HardwareAddr) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	ip[0], ip[1] = 0xfe, 0x80
+	ip[8], ip[9], ip[10] = mac[0]^0x02, mac[1], mac[2]
+	ip[11], ip[12] = 0xff, 0xfe
+	ip[13], ip[14], ip[15] = mac[3], mac[4], mac[5]
+	return ip
+}
+
+func (s *IGMPSnooper) queryMLD(group net.IP) error {
+	srcIP := gatewayLinkLocalAddr(s.gatewayConfig.MAC)
+	for _, version := range s.mldQueryVersions {
+		mld, err := generateMLDQueryPacket(group, version, s.queryInterval)
+		if err != nil {
+			return err
+		}
+		pkt := generateMLDEthernetPacket(s.gatewayConfig.MAC, mldQueryDstMac, srcIP, types.IPv6McastAllHosts, mld)
+		// Like the IGMP query, the MLD query is sent from Antrea gateway, and goes through OVS pipeline from table0.
+		if err := s.ofClient.SendEthPacketOut(s.gatewayConfig.OFPort, 0, pkt, nil); err != nil {
+			return err
+		}
+		klog.V(2).InfoS("Sent packetOut for MLD query", "group", group.String(), "version", version)
+	}
+	return nil
+}
+
+func (s *IGMPSnooper) sendMLDReport(groupRecordType uint8, groups []net.IP) error {
+	mld := generateMLDReportPacket(groupRecordType, groups)
+	pkt := generateMLDEthernetPacket(s.gatewayConfig.MAC, mldReportDstMac, gatewayLinkLocalAddr(s.gatewayConfig.MAC), types.MLDv2Router, mld)
+	if err := s.ofClient.SendEthPacketOut(openflow15.P_CONTROLLER, 0, pkt, nil); err != nil {
+		return err
+	}
+	klog.V(2).InfoS("Sent packetOut for MLDv2 report", "groups", groups)
+	return nil
+}
+
+// isLinkLocalMLDGroup returns whether the group is an IPv6 link-local multicast group. As required by RFC 4541, the
+// packets of these groups are flooded instead of being forwarded to the group members, so the MLD messages of them
+// are ignored.
+func isLinkLocalMLDGroup(group net.IP) bool {
+	return types.IPv6LinkLocalMcastCIDR.Contains(group)
+}
+
+func (s *IGMPSnooper) handleMLDPacket(pktData []byte, iface *interfacestore.InterfaceConfig, podName string, srcNode net.IP, now time.Time) error {
+	mld, err := parseMLDPacket(pktData)
+	if err != nil {
+		return err
+	}
+	pktLen := uint64(len(pktData))
+	switch mld.Type {
+	case MLDv1Report:
+		klog.V(2).InfoS("Received MLDv1 Report message", "group", mld.GroupAddress.String(), "interface", iface.InterfaceName, "pod", podName)
+		if isLinkLocalMLDGroup(mld.GroupAddress) {
+			return nil
+		}
+		event := &mcastGroupEvent{
+			group: mld.GroupAddress,
+			eType: groupJoin,
+			time:  now,
+			iface: iface,
+		}
+		s.validatePacketAndNotify(event, mldToIGMPType(mld.Type), pktLen)
+	case MLDv2Report:
+		for _, gr := range mld.GroupRecords {
+			klog.V(2).InfoS("Received MLDv2 Report message", "group", gr.MulticastAddress.String(), "interface", iface.InterfaceName, "pod", podName, "recordType", gr.Type, "sourceCount", len(gr.SourceAddresses))
+			if isLinkLocalMLDGroup(gr.MulticastAddress) {
+				continue
+			}
+			evtType := groupJoin
+			if (gr.Type == protocol.IGMPIsIn || gr.Type == protocol.IGMPToIn) && len(gr.SourceAddresses) == 0 {
+				evtType = groupLeave
+			}
+			event := &mcastGroupEvent{
+				group:      gr.MulticastAddress,
+				eType:      evtType,
+				time:       now,
+				iface:      iface,
+				srcNode:    srcNode,
+				recordType: gr.Type,
+				sources:    gr.SourceAddresses,
+			}
+			s.validatePacketAndNotify(event, mldToIGMPType(mld.Type), pktLen)
+		}
+	case MLDv1Done:
+		klog.V(2).InfoS("Received MLDv1 Done message", "group", mld.GroupAddress.String(), "interface", iface.InterfaceName, "pod", podName)
+		if isLinkLocalMLDGroup(mld.GroupAddress) {
+			return nil
+		}
+		event := &mcastGroupEvent{
+			group: mld.GroupAddress,
+			eType: groupLeave,
+			time:  now,
+			iface: iface,
+		}
+		s.eventCh <- event
+	}
+	return nil
+}
+
+func generateMLDQueryPacket(group net.IP, version uint8, queryInterval time.Duration) ([]byte, error) {
+	// The Maximum Response Delay in MLDv1 and the Maximum Response Code in MLDv2 are in units of milliseconds.
+	respTime := uint16(igmpMaxResponseTime.Milliseconds())
+	var mld []byte
+	switch version {
+	case 1:
+		mld = make([]byte, mldv1MessageLength)
+	case 2:
+		mld = make([]byte, mldv2QueryLength)
+		mld[25] = uint8(queryInterval.Seconds())
+	default:
+		return nil, fmt.Errorf("unsupported MLD version %d", version)
+	}
+	mld[0] = MLDQuery
+	binary.BigEndian.PutUint16(mld[4:6], respTime)
+	copy(mld[8:24], group.To16())
+	return mld, nil
+}
+
+func generateMLDReportPacket(groupRecordType uint8, groups []net.IP) []byte {
+	mld := make([]byte, mldv2ReportHeaderLength, mldv2ReportHeaderLength+len(groups)*mldv2RecordHeaderLength)
+	mld[0] = MLDv2Report
+	binary.BigEndian.PutUint16(mld[6:8], uint16(len(groups)))
+	for _, group := range groups {
+		record := make([]byte, mldv2RecordHeaderLength)
+		record[0] = groupRecordType
+		copy(record[4:20], group.To16())
+		mld = append(mld, record...)
+	}
+	return mld
+}
+
+// generateMLDEthernetPacket encapsulates the MLD message into an IPv6 packet with the Router Alert option, and returns
+// the Ethernet frame.
+func generateMLDEthernetPacket(srcMAC, dstMAC net.HardwareAddr, srcIP, dstIP net.IP, mld []byte) *protocol.Ethernet {
+	payloadLength := mldHopByHopHeaderLength + len(mld)
+	pkt := make([]byte, ipv6HeaderLength+payloadLength)
+	// Version 6, Traffic Class 0, Flow Label 0.
+	pkt[0] = 0x60
+	binary.BigEndian.PutUint16(pkt[4:6], uint16(payloadLength))
+	pkt[6] = ipv6HopByHopHeaderType
+	// MLD messages are sent with a link-local source address and a Hop Limit of 1.
+	pkt[7] = 1
+	copy(pkt[8:24], srcIP.To16())
+	copy(pkt[24:40], dstIP.To16())
+	hopByHop := pkt[ipv6HeaderLength : ipv6HeaderLength+mldHopByHopHeaderLength]
+	hopByHop[0] = ICMPv6ProtocolNumber
+	hopByHop[2], hopByHop[3] = 5, 2
+	hopByHop[6] = 1
+	icmp := pkt[ipv6HeaderLength+mldHopByHopHeaderLength:]
+	copy(icmp, mld)
+	binary.BigEndian.PutUint16(icmp[2:4], icmpv6Checksum(srcIP, dstIP, icmp))
+
+	ethPkt := protocol.NewEthernet()
+	ethPkt.HWSrc = srcMAC
+	ethPkt.HWDst = dstMAC
+	ethPkt.Ethertype = protocol.IPv6_MSG
+	ethPkt.Data = util.NewBuffer(pkt)
+	return ethPkt
+}
+
+// icmpv6Checksum calculates the checksum of the ICMPv6 message, including the IPv6 pseudo-header.
+func icmpv6Checksum(srcIP, dstIP net.IP, icmp []byte) uint16 {
+	var sum uint32
+	addBytes := func(b []byte) {
+		for i := 0; i+1 < len(b); i += 2 {
+			sum += uint32(b[i])<<8 | uint32(b[i+1])
+		}
+		if len(b)%2 == 1 {
+			sum += uint32(b[len(b)-1]) << 8
+		}
+	}
+	addBytes(srcIP.To16())
+	addBytes(dstIP.To16())
+	sum += uint32(len(icmp))
+	sum += ICMPv6ProtocolNumber
+	addBytes(icmp)
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
+
+// isIPv6Packet returns true if the Ethernet frame carries an IPv6 packet.
+func isIPv6Packet(pkt []byte) bool {
+	return len(pkt) >= ethernetHeaderLength && binary.BigEndian.Uint16(pkt[12:14]) == protocol.IPv6_MSG
+}
+
+func parseMLDPacket(pkt []byte) (*mldMessage, error) {
+	if !isIPv6Packet(pkt) {
+		return nil, errors.New("not IPv6 packet")
+	}
+	ipPacket := pkt[ethernetHeaderLength:]
+	if len(ipPacket) < ipv6HeaderLength {
+		return nil, errors.New("failed to parse IPv6 packet")
+	}
+	nextHeader := ipPacket[6]
+	payload := ipPacket[ipv6HeaderLength:]
+	if payloadLength := int(binary.BigEndian.Uint16(ipPacket[4:6])); payloadLength < len(payload) {
+		payload = payload[:payloadLength]
+	}
+	// Skip the extension headers before the ICMPv6 message.
+	for nextHeader != ICMPv6ProtocolNumber {
+		switch nextHeader {
+		case ipv6HopByHopHeaderType, ipv6RoutingHeaderType, ipv6DstOptsHeaderType:
+			if len(payload) < 2 {
+				return nil, errors.New("failed to parse IPv6 extension header")
+			}
+			headerLength := (int(payload[1]) + 1) * 8
+			if len(payload) < headerLength {
+				return nil, errors.New("failed to parse IPv6 extension header")
+			}
+			nextHeader = payload[0]
+			payload = payload[headerLength:]
+		default:
+			return nil, errors.New("not MLD packet")
+		}
+	}
+	if len(payload) < 4 {
+		return nil, errors.New("not MLD packet")
+	}
+	switch payload[0] {
+	case MLDQuery:
+		return &mldMessage{Type: MLDQuery}, nil
+	case MLDv1Report, MLDv1Done:
+		if len(payload) < mldv1MessageLength {
+			return nil, errors.New("invalid MLDv1 packet")
+		}
+		return &mldMessage{Type: payload[0], GroupAddress: copyIP(payload[8:24])}, nil
+	case MLDv2Report:
+		if len(payload) < mldv2ReportHeaderLength {
+			return nil, errors.New("invalid MLDv2 report")
+		}
+		numRecords := int(binary.BigEndian.Uint16(payload[6:8]))
+		records := make([]mldGroupRecord, 0, numRecords)
+		data := payload[mldv2ReportHeaderLength:]
+		for i := 0; i < numRecords; i++ {
+			if len(data) < mldv2RecordHeaderLength {
+				return nil, errors.New("invalid MLDv2 report")
+			}
+			auxDataLength := int(data[1]) * 4
+			numSources := int(binary.BigEndian.Uint16(data[2:4]))
+			recordLength := mldv2RecordHeaderLength + numSources*net.IPv6len + auxDataLength
+			if len(data) < recordLength {
+				return nil, errors.New("invalid MLDv2 report")
+			}
+			record := mldGroupRecord{
+				Type:             data[0],
+				MulticastAddress: copyIP(data[4:20]),
+				SourceAddresses:  make([]net.IP, numSources),
+			}
+			for j := 0; j < numSources; j++ {
+				offset := mldv2RecordHeaderLength + j*net.IPv6len
+				record.SourceAddresses[j] = copyIP(data[offset : offset+net.IPv6len])
+			}
+			records = append(records, record)
+			data = data[recordLength:]
+		}
+		return &mldMessage{Type: MLDv2Report, GroupRecords: records}, nil
+	default:
+		return nil, errors.New("unknown MLD packet")
+	}
+}
+
+func copyIP(b []byte) net.IP {
+	ip := make(net.IP, len(b))
+	copy(ip, b)
+	return ip
+}
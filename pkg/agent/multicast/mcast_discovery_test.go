@@ -19,12 +19,14 @@ import (
 	"net"
 	"sync"
 	"testing"
+	"time"
 
 	"antrea.io/libOpenflow/openflow15"
 	"antrea.io/libOpenflow/protocol"
 	"antrea.io/libOpenflow/util"
 	"antrea.io/ofnet/ofctrl"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -234,6 +236,133 @@ func TestIGMPRemoteReport(t *testing.T) {
 	}
 }
 
+func TestParseMLDPacket(t *testing.T) {
+	srcIP := net.ParseIP("fe80::1")
+	group1, group2 := net.ParseIP("ff05::1:3"), net.ParseIP("ff0e::100")
+	generateFrame := func(dstIP net.IP, mld []byte) []byte {
+		pkt := generateMLDEthernetPacket(pktInSrcMAC, pktInDstMAC, srcIP, dstIP, mld)
+		pktBytes, _ := pkt.MarshalBinary()
+		return pktBytes
+	}
+	mldv1Report := make([]byte, mldv1MessageLength)
+	mldv1Report[0] = MLDv1Report
+	copy(mldv1Report[8:24], group1)
+	mldv1Done := make([]byte, mldv1MessageLength)
+	mldv1Done[0] = MLDv1Done
+	copy(mldv1Done[8:24], group1)
+	mldv2Query, err := generateMLDQueryPacket(net.IPv6zero, 2, 125*time.Second)
+	require.NoError(t, err)
+	igmpPacket := generatePacketWithMatches(protocol.NewIGMPv1Report(net.ParseIP("224.3.4.5")), 1, nil, nil)
+
+	for _, tc := range []struct {
+		name   string
+		packet []byte
+		mldMsg *mldMessage
+		err    error
+	}{
+		{
+			name:   "IPv4 packet",
+			packet: igmpPacket.Data.(*util.Buffer).Bytes(),
+			err:    errors.New("not IPv6 packet"),
+		},
+		{
+			name:   "MLD query",
+			packet: generateFrame(types.IPv6McastAllHosts, mldv2Query),
+			mldMsg: &mldMessage{Type: MLDQuery},
+		},
+		{
+			name:   "MLDv1 report",
+			packet: generateFrame(group1, mldv1Report),
+			mldMsg: &mldMessage{Type: MLDv1Report, GroupAddress: group1},
+		},
+		{
+			name:   "MLDv1 done",
+			packet: generateFrame(net.ParseIP("ff02::2"), mldv1Done),
+			mldMsg: &mldMessage{Type: MLDv1Done, GroupAddress: group1},
+		},
+		{
+			name:   "MLDv2 report",
+			packet: generateFrame(types.MLDv2Router, generateMLDReportPacket(protocol.IGMPIsEx, []net.IP{group1, group2})),
+			mldMsg: &mldMessage{
+				Type: MLDv2Report,
+				GroupRecords: []mldGroupRecord{
+					{Type: protocol.IGMPIsEx, MulticastAddress: group1, SourceAddresses: []net.IP{}},
+					{Type: protocol.IGMPIsEx, MulticastAddress: group2, SourceAddresses: []net.IP{}},
+				},
+			},
+		},
+		{
+			name:   "truncated MLDv2 report",
+			packet: generateFrame(types.MLDv2Router, generateMLDReportPacket(protocol.IGMPIsEx, []net.IP{group1, group2}))[:100],
+			err:    errors.New("invalid MLDv2 report"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mldMsg, err := parseMLDPacket(tc.packet)
+			assert.Equal(t, tc.mldMsg, mldMsg)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestGenerateMLDEthernetPacket(t *testing.T) {
+	srcIP := gatewayLinkLocalAddr(pktInSrcMAC)
+	assert.Equal(t, net.ParseIP("fe80::1322:33ff:fe44:5566"), srcIP)
+	mld := generateMLDReportPacket(protocol.IGMPToEx, []net.IP{net.ParseIP("ff05::1:3")})
+	pkt := generateMLDEthernetPacket(pktInSrcMAC, mldReportDstMac, srcIP, types.MLDv2Router, mld)
+	pktBytes, err := pkt.MarshalBinary()
+	require.NoError(t, err)
+	ipPacket := pktBytes[ethernetHeaderLength:]
+	// The packet is sent with a Hop Limit of 1 and a Router Alert option in the Hop-by-Hop Options header.
+	assert.Equal(t, uint8(ipv6HopByHopHeaderType), ipPacket[6])
+	assert.Equal(t, uint8(1), ipPacket[7])
+	assert.Equal(t, []byte{ICMPv6ProtocolNumber, 0, 5, 2, 0, 0, 1, 0}, ipPacket[ipv6HeaderLength:ipv6HeaderLength+mldHopByHopHeaderLength])
+	// The checksum calculated over the ICMPv6 message including the checksum field must be zero.
+	icmp := ipPacket[ipv6HeaderLength+mldHopByHopHeaderLength:]
+	assert.Equal(t, uint16(0), icmpv6Checksum(srcIP, types.MLDv2Router, icmp))
+}
+
+func TestMLDQueryVersions(t *testing.T) {
+	assert.Equal(t, []uint8{1}, mldQueryVersions([]uint8{1}))
+	assert.Equal(t, []uint8{1}, mldQueryVersions([]uint8{1, 2}))
+	assert.Equal(t, []uint8{2}, mldQueryVersions([]uint8{3}))
+	assert.Equal(t, []uint8{1, 2}, mldQueryVersions([]uint8{1, 2, 3}))
+}
+
+func TestMLDRemoteReport(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockOFClient := openflowtest.NewMockClient(controller)
+	mockIfaceStore := ifaceStoretest.NewMockInterfaceStore(controller)
+	eventCh := make(chan *mcastGroupEvent, 100)
+	snooper := &IGMPSnooper{ofClient: mockOFClient, eventCh: eventCh, ifaceStore: mockIfaceStore}
+
+	localNodeIP := net.ParseIP("1.2.3.4")
+	remoteNodeIP := net.ParseIP("1.2.3.5")
+	tunnelPort := uint32(1)
+	// The link-local group ff02::fb is ignored.
+	groups := []net.IP{net.ParseIP("ff05::1:3"), net.ParseIP("ff02::fb"), net.ParseIP("ff05::1:4")}
+	expectedGroups := []net.IP{net.ParseIP("ff05::1:3"), net.ParseIP("ff05::1:4")}
+
+	for _, tc := range []struct {
+		recordType    uint8
+		expectedEType eventType
+	}{
+		{recordType: protocol.IGMPIsEx, expectedEType: groupJoin},
+		{recordType: protocol.IGMPToIn, expectedEType: groupLeave},
+	} {
+		pkt := generatePacketInForRemoteMLDReport(groups, remoteNodeIP, tc.recordType, tunnelPort)
+		mockIfaceStore.EXPECT().GetInterfaceByOFPort(tunnelPort).Return(createTunnelInterface(tunnelPort, localNodeIP), true)
+		require.NoError(t, snooper.HandlePacketIn(&pkt), "Failed to process MLD Report message")
+		for _, g := range expectedGroups {
+			e := <-eventCh
+			assert.Equal(t, g, e.group)
+			assert.Equal(t, tc.expectedEType, e.eType)
+			assert.True(t, remoteNodeIP.Equal(e.srcNode))
+		}
+		assert.Empty(t, eventCh)
+	}
+}
+
 func generatePacketWithMatches(m util.Message, ofport uint32, srcNodeIP net.IP, matches []openflow15.MatchField) ofctrl.PacketIn {
 	pkt := openflow15.NewPacketIn()
 	for i := range matches {
@@ -266,6 +395,17 @@ func generatePacketInForRemoteReport(t *testing.T, snooper *IGMPSnooper, groups
 	return generatePacketWithMatches(msg, tunnelPort, srcNode, []openflow15.MatchField{*openflow15.NewInPortField(tunnelPort)})
 }
 
+func generatePacketInForRemoteMLDReport(groups []net.IP, srcNode net.IP, recordType uint8, tunnelPort uint32) ofctrl.PacketIn {
+	pkt := openflow15.NewPacketIn()
+	pkt.Match.AddField(*openflow15.NewInPortField(tunnelPort))
+	pkt.Match.AddField(*openflow15.NewTunnelIpv4SrcField(srcNode, nil))
+	mld := generateMLDReportPacket(recordType, groups)
+	ethernetPkt := generateMLDEthernetPacket(pktInSrcMAC, mldReportDstMac, net.ParseIP("fe80::1"), types.MLDv2Router, mld)
+	pktBytes, _ := ethernetPkt.MarshalBinary()
+	pkt.Data = util.NewBuffer(pktBytes)
+	return ofctrl.PacketIn{PacketIn: pkt}
+}
+
 func createTunnelInterface(tunnelPort uint32, localNodeIP net.IP) *interfacestore.InterfaceConfig {
 	tunnelInterface := interfacestore.NewTunnelInterface("antrea-tun0", ovsconfig.GeneveTunnel, 6081, localNodeIP, false, &interfacestore.OVSPortConfig{OFPort: int32(tunnelPort)})
 	return tunnelInterface
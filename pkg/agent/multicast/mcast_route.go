@@ -34,14 +34,16 @@ const (
 	MulticastRecvBufferSize = 128
 )
 
-func newRouteClient(nodeconfig *config.NodeConfig, groupCache cache.Indexer, multicastSocket RouteInterface, multicastInterfaces sets.Set[string], encapEnabled bool) *MRouteClient {
+func newRouteClient(nodeconfig *config.NodeConfig, groupCache cache.Indexer, multicastSocket RouteInterface, ipv6MulticastSocket RouteInterface, multicastInterfaces sets.Set[string], encapEnabled bool) *MRouteClient {
 	var m = &MRouteClient{
 		igmpMsgChan:         make(chan []byte, workerCount),
+		mrt6MsgChan:         make(chan []byte, workerCount),
 		nodeConfig:          nodeconfig,
 		groupCache:          groupCache,
 		inboundRouteCache:   cache.NewIndexer(getMulticastInboundEntryKey, cache.Indexers{GroupNameIndexName: inboundGroupIndexFunc}),
 		multicastInterfaces: sets.List(multicastInterfaces),
 		socket:              multicastSocket,
+		ipv6Socket:          ipv6MulticastSocket,
 	}
 	return m
 }
@@ -64,13 +66,35 @@ func (c *MRouteClient) Initialize() error {
 		return err
 	}
 	c.externalInterfaceVIFs = externalInterfaceVIFs
+	if c.ipv6Socket == nil {
+		return nil
+	}
+	// Allocate MIFs for IPv6 multicast routing in the same way. The kernel manages MIFs separately from VIFs.
+	gatewayInterfaceMIF, err := c.ipv6Socket.AllocateVIFs([]string{c.nodeConfig.GatewayConfig.Name}, 0)
+	if err != nil {
+		return err
+	}
+	c.internalInterfaceMIF = gatewayInterfaceMIF[0]
+	var ipv6MulticastInterfaceNames []string
+	for _, config := range c.multicastInterfaceConfigs {
+		if config.IPv6Addr != nil {
+			ipv6MulticastInterfaceNames = append(ipv6MulticastInterfaceNames, config.Name)
+		}
+	}
+	externalInterfaceMIFs, err := c.ipv6Socket.AllocateVIFs(ipv6MulticastInterfaceNames, c.internalInterfaceMIF+1)
+	if err != nil {
+		return err
+	}
+	c.externalInterfaceMIFs = externalInterfaceMIFs
 	return nil
 }
 
 // MRouteClient configures static multicast route.
 type MRouteClient struct {
 	// igmpMsgChan is used for processing IGMPMsg reading from sockFD in parallel
-	igmpMsgChan               chan []byte
+	igmpMsgChan chan []byte
+	// mrt6MsgChan is used for processing MRT6Msg reading from the IPv6 multicast socket in parallel.
+	mrt6MsgChan               chan []byte
 	nodeConfig                *config.NodeConfig
 	multicastInterfaces       []string
 	inboundRouteCache         cache.Indexer
@@ -79,12 +103,30 @@ type MRouteClient struct {
 	multicastInterfaceConfigs []multicastInterfaceConfig
 	internalInterfaceVIF      uint16
 	externalInterfaceVIFs     []uint16
+	// ipv6Socket is the socket to configure IPv6 multicast routes. It is nil if IPv6 is not enabled.
+	ipv6Socket RouteInterface
+	// internalInterfaceMIF and externalInterfaceMIFs are the IPv6 counterparts of internalInterfaceVIF and
+	// externalInterfaceVIFs.
+	internalInterfaceMIF  uint16
+	externalInterfaceMIFs []uint16
+}
+
+// getRouteInterface returns the socket, the VIF of Antrea gateway and the VIFs of the multicast interfaces used to
+// configure the multicast routes of the given group. MIFs are returned for IPv6 groups.
+func (c *MRouteClient) getRouteInterface(group net.IP) (RouteInterface, uint16, []uint16) {
+	if group.To4() == nil {
+		return c.ipv6Socket, c.internalInterfaceMIF, c.externalInterfaceMIFs
+	}
+	return c.socket, c.internalInterfaceVIF, c.externalInterfaceVIFs
 }
 
 // multicastInterfacesJoinMgroup allows multicast interfaces to join multicast group,
 // by making these interfaces accept multicast traffic with multicast ip:mgroup.
 // https://tldp.org/HOWTO/Multicast-HOWTO-6.html#ss6.4
 func (c *MRouteClient) multicastInterfacesJoinMgroup(mgroup net.IP) error {
+	if mgroup.To4() == nil {
+		return c.multicastInterfacesJoinIPv6Mgroup(mgroup)
+	}
 	groupIP := mgroup.To4()
 	for _, config := range c.multicastInterfaceConfigs {
 		addrIP := config.IPv4Addr.IP.To4()
@@ -97,6 +139,9 @@ func (c *MRouteClient) multicastInterfacesJoinMgroup(mgroup net.IP) error {
 }
 
 func (c *MRouteClient) multicastInterfacesLeaveMgroup(mgroup net.IP) error {
+	if mgroup.To4() == nil {
+		return c.multicastInterfacesLeaveIPv6Mgroup(mgroup)
+	}
 	groupIP := mgroup.To4()
 	for _, config := range c.multicastInterfaceConfigs {
 		addrIP := config.IPv4Addr.IP.To4()
@@ -108,6 +153,66 @@ func (c *MRouteClient) multicastInterfacesLeaveMgroup(mgroup net.IP) error {
 	return nil
 }
 
+// multicastInterfacesJoinIPv6Mgroup allows the multicast interfaces with IPv6 addresses to join the IPv6 multicast
+// group, so that MLD reports of the group are sent to the external network.
+func (c *MRouteClient) multicastInterfacesJoinIPv6Mgroup(mgroup net.IP) error {
+	for _, config := range c.multicastInterfaceConfigs {
+		if config.IPv6Addr == nil {
+			continue
+		}
+		err := c.ipv6Socket.MulticastInterfaceJoinMgroup(mgroup, config.IPv6Addr.IP, config.Name)
+		if err != nil && !strings.Contains(err.Error(), "address already in use") {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *MRouteClient) multicastInterfacesLeaveIPv6Mgroup(mgroup net.IP) error {
+	for _, config := range c.multicastInterfaceConfigs {
+		if config.IPv6Addr == nil {
+			continue
+		}
+		err := c.ipv6Socket.MulticastInterfaceLeaveMgroup(mgroup, config.IPv6Addr.IP, config.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// multicastInterfacesJoinSourceMgroup allows multicast interfaces to join the SSM group only for the provided source,
+// so that the IGMPv3/MLDv2 reports sent to the external network include the source, as required by RFC 4607.
+func (c *MRouteClient) multicastInterfacesJoinSourceMgroup(mgroup, source net.IP) error {
+	for _, config := range c.multicastInterfaceConfigs {
+		var err error
+		if mgroup.To4() != nil {
+			err = c.socket.MulticastInterfaceJoinSourceMgroup(mgroup.To4(), source.To4(), config.IPv4Addr.IP.To4(), config.Name)
+		} else if config.IPv6Addr != nil {
+			err = c.ipv6Socket.MulticastInterfaceJoinSourceMgroup(mgroup, source, config.IPv6Addr.IP, config.Name)
+		}
+		if err != nil && !strings.Contains(err.Error(), "address already in use") {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *MRouteClient) multicastInterfacesLeaveSourceMgroup(mgroup, source net.IP) error {
+	for _, config := range c.multicastInterfaceConfigs {
+		var err error
+		if mgroup.To4() != nil {
+			err = c.socket.MulticastInterfaceLeaveSourceMgroup(mgroup.To4(), source.To4(), config.IPv4Addr.IP.To4(), config.Name)
+		} else if config.IPv6Addr != nil {
+			err = c.ipv6Socket.MulticastInterfaceLeaveSourceMgroup(mgroup, source, config.IPv6Addr.IP, config.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // processIGMPNocacheMsg reads igmpMsg from the multicast socket and configures
 // multicast route based on VIF value in the message.
 func (c *MRouteClient) processIGMPNocacheMsg(igmpMsg []byte) {
@@ -117,7 +222,24 @@ func (c *MRouteClient) processIGMPNocacheMsg(igmpMsg []byte) {
 		klog.V(4).ErrorS(err, "Error parsing IGMP message")
 		return
 	}
-	if msg.VIF != c.internalInterfaceVIF {
+	c.processNocacheMsg(msg)
+}
+
+// processMRT6NocacheMsg reads mrt6msg from the IPv6 multicast socket and configures IPv6 multicast route based on the
+// MIF value in the message.
+func (c *MRouteClient) processMRT6NocacheMsg(mrt6Msg []byte) {
+	klog.V(2).InfoS("Received mrt6Msg", "mrt6Msg", mrt6Msg)
+	msg, err := c.parseMRT6Msg(mrt6Msg)
+	if err != nil {
+		klog.V(4).ErrorS(err, "Error parsing MRT6 message")
+		return
+	}
+	c.processNocacheMsg(msg)
+}
+
+func (c *MRouteClient) processNocacheMsg(msg *parsedIGMPMsg) {
+	_, internalInterfaceVIF, externalInterfaceVIFs := c.getRouteInterface(msg.Dst)
+	if msg.VIF != internalInterfaceVIF {
 		// Skip inbound multicast traffic when there is no multicast receiver Pod
 		// listening the msg.Dst group.
 		status, ok, _ := c.groupCache.GetByKey(msg.Dst.String())
@@ -129,7 +251,7 @@ func (c *MRouteClient) processIGMPNocacheMsg(igmpMsg []byte) {
 			return
 		}
 		// Prevent adding route entries for unrecognized VIF.
-		if len(c.externalInterfaceVIFs) < int(msg.VIF) {
+		if len(externalInterfaceVIFs) < int(msg.VIF) {
 			klog.ErrorS(fmt.Errorf("error finding VIF"), "Adding inbound multicast route entry failed", "VIF", msg.VIF)
 			return
 		}
@@ -138,7 +260,7 @@ func (c *MRouteClient) processIGMPNocacheMsg(igmpMsg []byte) {
 			klog.ErrorS(err, "Adding inbound multicast route entry failed")
 		}
 	} else {
-		err = c.addOutboundMrouteEntry(msg.Src, msg.Dst)
+		err := c.addOutboundMrouteEntry(msg.Src, msg.Dst)
 		if err != nil {
 			klog.ErrorS(err, "Adding outbound multicast route entry failed")
 		}
@@ -148,9 +270,10 @@ func (c *MRouteClient) processIGMPNocacheMsg(igmpMsg []byte) {
 func (c *MRouteClient) deleteInboundMrouteEntryByGroup(group net.IP) (err error) {
 	klog.V(2).InfoS("Deleting multicast group", "group", group)
 	mEntries, _ := c.inboundRouteCache.ByIndex(GroupNameIndexName, group.String())
+	socket, _, _ := c.getRouteInterface(group)
 	for _, route := range mEntries {
 		entry := route.(*inboundMulticastRouteEntry)
-		err := c.socket.DelMrouteEntry(net.ParseIP(entry.src), net.ParseIP(entry.group), entry.vif)
+		err := socket.DelMrouteEntry(net.ParseIP(entry.src), net.ParseIP(entry.group), entry.vif)
 		if err != nil {
 			return err
 		}
@@ -162,8 +285,9 @@ func (c *MRouteClient) deleteInboundMrouteEntryByGroup(group net.IP) (err error)
 // addOutboundMrouteEntry configures multicast route from Antrea gateway to all the multicast interfaces,
 // allowing multicast srcNode Pods to send multicast traffic to external.
 func (c *MRouteClient) addOutboundMrouteEntry(src net.IP, group net.IP) (err error) {
-	klog.V(2).InfoS("Adding outbound multicast route entry", "src", src, "group", group, "outboundVIFs", c.externalInterfaceVIFs)
-	err = c.socket.AddMrouteEntry(src, group, c.internalInterfaceVIF, c.externalInterfaceVIFs)
+	socket, internalInterfaceVIF, externalInterfaceVIFs := c.getRouteInterface(group)
+	klog.V(2).InfoS("Adding outbound multicast route entry", "src", src, "group", group, "outboundVIFs", externalInterfaceVIFs)
+	err = socket.AddMrouteEntry(src, group, internalInterfaceVIF, externalInterfaceVIFs)
 	if err != nil {
 		return err
 	}
@@ -173,8 +297,9 @@ func (c *MRouteClient) addOutboundMrouteEntry(src net.IP, group net.IP) (err err
 // addInboundMrouteEntry configures multicast route from multicast interface to Antrea gateway
 // to allow multicast receiver Pods to receive multicast traffic from external.
 func (c *MRouteClient) addInboundMrouteEntry(src net.IP, group net.IP, inboundVIF uint16) (err error) {
+	socket, internalInterfaceVIF, _ := c.getRouteInterface(group)
 	klog.V(2).InfoS("Adding inbound multicast route entry", "src", src, "group", group, "inboundVIF", inboundVIF)
-	err = c.socket.AddMrouteEntry(src, group, inboundVIF, []uint16{c.internalInterfaceVIF})
+	err = socket.AddMrouteEntry(src, group, inboundVIF, []uint16{internalInterfaceVIF})
 	if err != nil {
 		return err
 	}
@@ -246,14 +371,16 @@ func (c *MRouteClient) worker(stopCh <-chan struct{}) {
 		select {
 		case msg := <-c.igmpMsgChan:
 			c.processIGMPNocacheMsg(msg)
+		case msg := <-c.mrt6MsgChan:
+			c.processMRT6NocacheMsg(msg)
 		case <-stopCh:
 			return
 		}
 	}
 }
 
-// This struct is result of parsing igmpmsg from the kernel
-// with fields we interest.
+// This struct is result of parsing igmpmsg or mrt6msg from the kernel
+// with fields we interest. VIF is the MIF for mrt6msg.
 type parsedIGMPMsg struct {
 	VIF uint16
 	Src net.IP
@@ -273,6 +400,12 @@ type RouteInterface interface {
 	// MulticastInterfaceLeaveMgroup enables interface with name ifaceName and IP ifaceIP
 	// leaves multicast group IP mgroup.
 	MulticastInterfaceLeaveMgroup(mgroup net.IP, ifaceIP net.IP, ifaceName string) error
+	// MulticastInterfaceJoinSourceMgroup enables interface with name ifaceName and IP ifaceIP
+	// joins multicast group IP mgroup only for the source IP source.
+	MulticastInterfaceJoinSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error
+	// MulticastInterfaceLeaveSourceMgroup enables interface with name ifaceName and IP ifaceIP
+	// leaves multicast group IP mgroup for the source IP source.
+	MulticastInterfaceLeaveSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error
 	// AddMrouteEntry adds multicast route with specified source(src), multicast group IP(group),
 	// inbound multicast interface(iif) and outbound multicast interfaces(oifs).
 	AddMrouteEntry(src net.IP, group net.IP, iif uint16, oifs []uint16) (err error)
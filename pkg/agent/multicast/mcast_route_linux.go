@@ -59,17 +59,46 @@ func (c *MRouteClient) parseIGMPMsg(msg []byte) (*parsedIGMPMsg, error) {
 	}, nil
 }
 
+// parseMRT6Msg parses the mrt6msg upcall from the kernel into parsedIGMPMsg. The layout of mrt6msg is documented by
+// https://github.com/torvalds/linux/blob/4634129ad9fdc89d10b597fc6f8f4336fb61e105/include/uapi/linux/mroute6.h#L130.
+func (c *MRouteClient) parseMRT6Msg(msg []byte) (*parsedIGMPMsg, error) {
+	if len(msg) < SizeofMrt6msg {
+		return nil, fmt.Errorf("failed to parse MRT6MSG: message length should be at least %d", SizeofMrt6msg)
+	}
+	// im6_mbz must be zero, which distinguishes the upcall from the ICMPv6 messages received on the same socket.
+	if msg[0] != 0 {
+		return nil, fmt.Errorf("invalid mrt6msg message: im6_mbz must be zero")
+	}
+	if msg[1] != MRT6MsgNocache {
+		return nil, fmt.Errorf("not a MRT6MSG_NOCACHE message: %v", msg)
+	}
+	src := make(net.IP, net.IPv6len)
+	copy(src, msg[8:24])
+	dst := make(net.IP, net.IPv6len)
+	copy(dst, msg[24:40])
+	return &parsedIGMPMsg{
+		VIF: uint16(msg[2]) + (uint16(msg[3]) << uint16(8)),
+		Src: src,
+		Dst: dst,
+	}, nil
+}
+
+func readMulticastSocket(fd int, msgChan chan<- []byte) {
+	for {
+		buf := make([]byte, MulticastRecvBufferSize)
+		n, _ := syscall.Read(fd, buf)
+		if n > 0 {
+			msgChan <- buf[:n]
+		}
+	}
+}
+
 func (c *MRouteClient) run(stopCh <-chan struct{}) {
 	klog.InfoS("Start running multicast routing daemon")
-	go func() {
-		for {
-			buf := make([]byte, MulticastRecvBufferSize)
-			n, _ := syscall.Read(c.socket.GetFD(), buf)
-			if n > 0 {
-				c.igmpMsgChan <- buf[:n]
-			}
-		}
-	}()
+	go readMulticastSocket(c.socket.GetFD(), c.igmpMsgChan)
+	if c.ipv6Socket != nil {
+		go readMulticastSocket(c.ipv6Socket.GetFD(), c.mrt6MsgChan)
+	}
 
 	for i := 0; i < int(workerCount); i++ {
 		go c.worker(stopCh)
@@ -77,4 +106,8 @@ func (c *MRouteClient) run(stopCh <-chan struct{}) {
 	<-stopCh
 	c.socket.FlushMRoute()
 	syscall.Close(c.socket.GetFD())
+	if c.ipv6Socket != nil {
+		c.ipv6Socket.FlushMRoute()
+		syscall.Close(c.ipv6Socket.GetFD())
+	}
 }
@@ -21,5 +21,10 @@ func (c *MRouteClient) parseIGMPMsg(msg []byte) (*parsedIGMPMsg, error) {
 	return nil, nil
 }
 
+// nolint: unused
+func (c *MRouteClient) parseMRT6Msg(msg []byte) (*parsedIGMPMsg, error) {
+	return nil, nil
+}
+
 func (c *MRouteClient) run(stopCh <-chan struct{}) {
 }
@@ -94,6 +94,58 @@ func TestParseIGMPMsg(t *testing.T) {
 	}
 }
 
+func TestParseMRT6Msg(t *testing.T) {
+	mRoute := newMockMulticastRouteClient(t)
+	src, dst := net.ParseIP("fd00:10:244::2"), net.ParseIP("ff05::1:3")
+	validMsg := make([]byte, SizeofMrt6msg)
+	validMsg[1] = MRT6MsgNocache
+	validMsg[2] = 1
+	copy(validMsg[8:24], src)
+	copy(validMsg[24:40], dst)
+	notNocacheMsg := make([]byte, SizeofMrt6msg)
+	notNocacheMsg[1] = 2
+	invalidMbzMsg := make([]byte, SizeofMrt6msg)
+	invalidMbzMsg[0] = 143
+
+	for _, m := range []struct {
+		name                  string
+		msg                   []byte
+		expectedParsedIGMPMsg *parsedIGMPMsg
+		expectedErr           error
+	}{
+		{
+			name: "valid MRT6MSG message",
+			msg:  validMsg,
+			expectedParsedIGMPMsg: &parsedIGMPMsg{
+				Src: src,
+				Dst: dst,
+				VIF: uint16(1),
+			},
+		},
+		{
+			name:        "too short MRT6MSG",
+			msg:         validMsg[:20],
+			expectedErr: fmt.Errorf("failed to parse MRT6MSG: message length should be at least 40"),
+		},
+		{
+			name:        "MRT6MSG wrong type",
+			msg:         notNocacheMsg,
+			expectedErr: fmt.Errorf("not a MRT6MSG_NOCACHE message: %v", notNocacheMsg),
+		},
+		{
+			name:        "MRT6MSG im6_mbz not zero",
+			msg:         invalidMbzMsg,
+			expectedErr: fmt.Errorf("invalid mrt6msg message: im6_mbz must be zero"),
+		},
+	} {
+		t.Run(m.name, func(t *testing.T) {
+			msg, err := mRoute.parseMRT6Msg(m.msg)
+			assert.Equal(t, m.expectedErr, err)
+			assert.Equal(t, m.expectedParsedIGMPMsg, msg)
+		})
+	}
+}
+
 func TestDeleteInboundMrouteEntryByGroup(t *testing.T) {
 	mRoute := newMockMulticastRouteClient(t)
 	err := mRoute.initialize(t)
@@ -236,7 +288,7 @@ func newMockMulticastRouteClient(t *testing.T) *MRouteClient {
 	groupCache := cache.NewIndexer(getGroupEventKey, cache.Indexers{
 		podInterfaceIndex: podInterfaceIndexFunc,
 	})
-	return newRouteClient(nodeConfig, groupCache, mockMulticastSocket, sets.New[string](if1.InterfaceName), false)
+	return newRouteClient(nodeConfig, groupCache, mockMulticastSocket, nil, sets.New[string](if1.InterfaceName), false)
 }
 
 func (c *MRouteClient) initialize(t *testing.T) error {
@@ -32,6 +32,9 @@ const (
 	IGMPMsgNocache = multicastsyscall.IGMPMSG_NOCACHE
 	MaxVIFs        = multicastsyscall.MAXVIFS
 	SizeofIgmpmsg  = multicastsyscall.SizeofIgmpmsg
+	MRT6MsgNocache = multicastsyscall.MRT6MSG_NOCACHE
+	MaxMIFs        = multicastsyscall.MAXMIFS
+	SizeofMrt6msg  = multicastsyscall.SizeofMrt6msg
 )
 
 // setVIFToInterface adds a virtual interface to the multicast socket for interface with index ifIndex.
@@ -146,6 +149,30 @@ func (s *Socket) MulticastInterfaceLeaveMgroup(mgroup net.IP, ifaceIP net.IP, if
 	return nil
 }
 
+// MulticastInterfaceJoinSourceMgroup joins the multicast group on the interface only for the provided source, it is
+// used for the SSM groups.
+func (s *Socket) MulticastInterfaceJoinSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	if err := multicastsyscall.SetsockoptIPMreqSource(s.sockFD, syscall.IPPROTO_IP, syscall.IP_ADD_SOURCE_MEMBERSHIP, newIPMreqSource(mgroup, source, ifaceIP)); err != nil {
+		return fmt.Errorf("failed to join multicast group %s with source %s for %s: %s", mgroup.String(), source.String(), ifaceName, err.Error())
+	}
+	return nil
+}
+
+func (s *Socket) MulticastInterfaceLeaveSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	if err := multicastsyscall.SetsockoptIPMreqSource(s.sockFD, syscall.IPPROTO_IP, syscall.IP_DROP_SOURCE_MEMBERSHIP, newIPMreqSource(mgroup, source, ifaceIP)); err != nil {
+		return fmt.Errorf("failed to leave multicast group %s with source %s for %s: %s", mgroup.String(), source.String(), ifaceName, err.Error())
+	}
+	return nil
+}
+
+func newIPMreqSource(mgroup, source net.IP, ifaceIP net.IP) *multicastsyscall.IPMreqSource {
+	mreq := &multicastsyscall.IPMreqSource{}
+	copy(mreq.Multiaddr[:], mgroup.To4())
+	copy(mreq.Interface[:], ifaceIP.To4())
+	copy(mreq.Sourceaddr[:], source.To4())
+	return mreq
+}
+
 func (s *Socket) GetFD() int {
 	return s.sockFD
 }
@@ -153,3 +180,163 @@ func (s *Socket) GetFD() int {
 type Socket struct {
 	sockFD int
 }
+
+// IPv6Socket is the multicast socket to configure IPv6 multicast routes. The MIFs of IPv6 multicast routing are
+// allocated and used in the same way as the VIFs of IPv4 multicast routing.
+type IPv6Socket struct {
+	sockFD int
+}
+
+func CreateIPv6MulticastSocket() (*IPv6Socket, error) {
+	fd, err := syscall.Socket(syscall.AF_INET6, syscall.SOCK_RAW, syscall.IPPROTO_ICMPV6)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IPv6 multicast socket")
+	}
+
+	err = syscall.SetsockoptInt(fd, syscall.IPPROTO_IPV6, multicastsyscall.MRT6_INIT, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate IPv6 Multicast routing in kernel: %s", err.Error())
+	}
+	// Block all ICMPv6 messages on the socket, as only the mrt6msg upcalls from the kernel are expected. The upcalls
+	// are not subject to the filter.
+	filter := &syscall.ICMPv6Filter{}
+	for i := range filter.Data {
+		filter.Data[i] = 0xffffffff
+	}
+	if err := syscall.SetsockoptICMPv6Filter(fd, syscall.IPPROTO_ICMPV6, syscall.ICMPV6_FILTER, filter); err != nil {
+		return nil, fmt.Errorf("failed to set ICMPv6 filter on IPv6 multicast socket: %s", err.Error())
+	}
+
+	return &IPv6Socket{sockFD: fd}, nil
+}
+
+func (s *IPv6Socket) AddMrouteEntry(src net.IP, group net.IP, iif uint16, oifMIFs []uint16) (err error) {
+	mc := newMf6cctl(src, group, iif)
+	for _, v := range oifMIFs {
+		mc.Ifset.Bits[v/32] |= 1 << (v % 32)
+	}
+	return multicastsyscall.SetsockoptMf6cctl(s.sockFD, syscall.IPPROTO_IPV6, multicastsyscall.MRT6_ADD_MFC, mc)
+}
+
+func (s *IPv6Socket) DelMrouteEntry(src net.IP, group net.IP, iif uint16) (err error) {
+	mc := newMf6cctl(src, group, iif)
+	return multicastsyscall.SetsockoptMf6cctl(s.sockFD, syscall.IPPROTO_IPV6, multicastsyscall.MRT6_DEL_MFC, mc)
+}
+
+func newMf6cctl(src net.IP, group net.IP, iif uint16) *multicastsyscall.Mf6cctl {
+	mc := &multicastsyscall.Mf6cctl{}
+	mc.Origin.Family = syscall.AF_INET6
+	copy(mc.Origin.Addr[:], src.To16())
+	mc.Mcastgrp.Family = syscall.AF_INET6
+	copy(mc.Mcastgrp.Addr[:], group.To16())
+	mc.Parent = iif
+	return mc
+}
+
+func (s *IPv6Socket) FlushMRoute() {
+	klog.InfoS("Clearing IPv6 multicast routing table entries")
+	err := syscall.SetsockoptInt(s.sockFD, syscall.IPPROTO_IPV6, multicastsyscall.MRT6_FLUSH, multicastsyscall.MRT6_FLUSH_MFC|multicastsyscall.MRT6_FLUSH_MIFS)
+	if err != nil {
+		klog.ErrorS(err, "Failed to clear IPv6 multicast routing table entries")
+	}
+}
+
+func (s *IPv6Socket) AllocateVIFs(interfaceNames []string, startVIF uint16) ([]uint16, error) {
+	mif := startVIF
+	mifs := make([]uint16, 0, len(interfaceNames))
+	for _, name := range interfaceNames {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("error finding interface %s to allocate MIF: %w", name, err)
+		}
+		if mif >= MaxMIFs {
+			return nil, fmt.Errorf("MIF reaches MAXMIFS. Failed to allocate available MIF")
+		}
+		mc := &multicastsyscall.Mif6ctl{Mifi: mif, Pifi: uint16(iface.Index)}
+		if err := multicastsyscall.SetsockoptMif6ctl(s.sockFD, syscall.IPPROTO_IPV6, multicastsyscall.MRT6_ADD_MIF, mc); err != nil {
+			return nil, err
+		}
+		mifs = append(mifs, mif)
+		klog.V(2).InfoS("Successfully allocated MIF", "MIF", mif, "interface", name)
+		mif += 1
+	}
+	return mifs, nil
+}
+
+func (s *IPv6Socket) MulticastInterfaceJoinMgroup(mgroup net.IP, ifaceIP net.IP, ifaceName string) error {
+	mreq, err := newIPv6Mreq(mgroup, ifaceName)
+	if err != nil {
+		return err
+	}
+	if err := syscall.SetsockoptIPv6Mreq(s.sockFD, syscall.IPPROTO_IPV6, syscall.IPV6_JOIN_GROUP, mreq); err != nil {
+		return fmt.Errorf("failed to join multicast group %s for %s: %s", mgroup.String(), ifaceName, err.Error())
+	}
+	return nil
+}
+
+func (s *IPv6Socket) MulticastInterfaceLeaveMgroup(mgroup net.IP, ifaceIP net.IP, ifaceName string) error {
+	mreq, err := newIPv6Mreq(mgroup, ifaceName)
+	if err != nil {
+		return err
+	}
+	if err := syscall.SetsockoptIPv6Mreq(s.sockFD, syscall.IPPROTO_IPV6, syscall.IPV6_LEAVE_GROUP, mreq); err != nil {
+		return fmt.Errorf("failed to leave multicast group %s for %s: %s", mgroup.String(), ifaceName, err.Error())
+	}
+	return nil
+}
+
+// newIPv6Mreq returns the ipv6_mreq to join or leave the multicast group. Unlike ip_mreq, the interface is identified
+// by its index instead of its IP.
+func newIPv6Mreq(mgroup net.IP, ifaceName string) (*syscall.IPv6Mreq, error) {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return nil, fmt.Errorf("error finding interface %s: %w", ifaceName, err)
+	}
+	mreq := &syscall.IPv6Mreq{Interface: uint32(iface.Index)}
+	copy(mreq.Multiaddr[:], mgroup.To16())
+	return mreq, nil
+}
+
+// MulticastInterfaceJoinSourceMgroup joins the multicast group on the interface only for the provided source, it is
+// used for the SSM groups.
+func (s *IPv6Socket) MulticastInterfaceJoinSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	req, err := newGroupSourceReq(mgroup, source, ifaceName)
+	if err != nil {
+		return err
+	}
+	if err := multicastsyscall.SetsockoptGroupSourceReq(s.sockFD, syscall.IPPROTO_IPV6, multicastsyscall.MCAST_JOIN_SOURCE_GROUP, req); err != nil {
+		return fmt.Errorf("failed to join multicast group %s with source %s for %s: %s", mgroup.String(), source.String(), ifaceName, err.Error())
+	}
+	return nil
+}
+
+func (s *IPv6Socket) MulticastInterfaceLeaveSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	req, err := newGroupSourceReq(mgroup, source, ifaceName)
+	if err != nil {
+		return err
+	}
+	if err := multicastsyscall.SetsockoptGroupSourceReq(s.sockFD, syscall.IPPROTO_IPV6, multicastsyscall.MCAST_LEAVE_SOURCE_GROUP, req); err != nil {
+		return fmt.Errorf("failed to leave multicast group %s with source %s for %s: %s", mgroup.String(), source.String(), ifaceName, err.Error())
+	}
+	return nil
+}
+
+// newGroupSourceReq returns the group_source_req to join or leave the multicast group for the source. The group and the
+// source are stored as sockaddr_in6, of which the address starts after the family (2 bytes), the port (2 bytes) and
+// the flow info (4 bytes).
+func newGroupSourceReq(mgroup, source net.IP, ifaceName string) (*multicastsyscall.GroupSourceReq, error) {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return nil, fmt.Errorf("error finding interface %s: %w", ifaceName, err)
+	}
+	req := &multicastsyscall.GroupSourceReq{Interface: uint32(iface.Index)}
+	req.Group.Family = syscall.AF_INET6
+	copy(req.Group.Data[6:22], mgroup.To16())
+	req.Source.Family = syscall.AF_INET6
+	copy(req.Source.Data[6:22], source.To16())
+	return req, nil
+}
+
+func (s *IPv6Socket) GetFD() int {
+	return s.sockFD
+}
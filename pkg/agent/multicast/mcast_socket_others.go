@@ -25,6 +25,9 @@ const (
 	IGMPMsgNocache = 0
 	MaxVIFs        = 0
 	SizeofIgmpmsg  = 0
+	MRT6MsgNocache = 0
+	MaxMIFs        = 0
+	SizeofMrt6msg  = 0
 )
 
 func (s *Socket) AddMrouteEntry(src net.IP, group net.IP, iif uint16, oifVIFs []uint16) (err error) {
@@ -54,6 +57,14 @@ func (s *Socket) MulticastInterfaceLeaveMgroup(mgroup net.IP, ifaceIP net.IP, if
 	return nil
 }
 
+func (s *Socket) MulticastInterfaceJoinSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	return nil
+}
+
+func (s *Socket) MulticastInterfaceLeaveSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	return nil
+}
+
 func (s *Socket) GetFD() int {
 	return s.sockFD
 }
@@ -61,3 +72,46 @@ func (s *Socket) GetFD() int {
 type Socket struct {
 	sockFD int
 }
+
+type IPv6Socket struct {
+	sockFD int
+}
+
+func CreateIPv6MulticastSocket() (*IPv6Socket, error) {
+	return nil, nil
+}
+
+func (s *IPv6Socket) AddMrouteEntry(src net.IP, group net.IP, iif uint16, oifMIFs []uint16) (err error) {
+	return nil
+}
+
+func (s *IPv6Socket) DelMrouteEntry(src net.IP, group net.IP, iif uint16) (err error) {
+	return nil
+}
+
+func (s *IPv6Socket) FlushMRoute() {
+}
+
+func (s *IPv6Socket) AllocateVIFs(interfaceNames []string, startVIF uint16) ([]uint16, error) {
+	return nil, nil
+}
+
+func (s *IPv6Socket) MulticastInterfaceJoinMgroup(mgroup net.IP, ifaceIP net.IP, ifaceName string) error {
+	return nil
+}
+
+func (s *IPv6Socket) MulticastInterfaceLeaveMgroup(mgroup net.IP, ifaceIP net.IP, ifaceName string) error {
+	return nil
+}
+
+func (s *IPv6Socket) MulticastInterfaceJoinSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	return nil
+}
+
+func (s *IPv6Socket) MulticastInterfaceLeaveSourceMgroup(mgroup, source net.IP, ifaceIP net.IP, ifaceName string) error {
+	return nil
+}
+
+func (s *IPv6Socket) GetFD() int {
+	return s.sockFD
+}
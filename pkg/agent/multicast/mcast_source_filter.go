@@ -0,0 +1,135 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicast
+
+import (
+	"net"
+	"sort"
+
+	"antrea.io/libOpenflow/protocol"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type filterMode uint8
+
+const (
+	// excludeMode indicates that the member receives the traffic from all sources except the ones in the source list.
+	// A member which joins the group with IGMPv1, IGMPv2 or MLDv1 is in exclude mode with an empty source list.
+	excludeMode filterMode = iota
+	// includeMode indicates that the member receives the traffic only from the sources in the source list.
+	includeMode
+)
+
+func (m filterMode) String() string {
+	if m == includeMode {
+		return "Include"
+	}
+	return "Exclude"
+}
+
+var (
+	_, ssmCIDR, _     = net.ParseCIDR("232.0.0.0/8")
+	_, ipv6SSMCIDR, _ = net.ParseCIDR("ff30::/12")
+)
+
+// sourceFilter is the source filter of a local member in a multicast group, which is reported with the group records
+// in IGMPv3 or MLDv2 reports. A sourceFilter is never modified after it is created, so it can be shared by the copies
+// of a GroupMemberStatus.
+type sourceFilter struct {
+	mode    filterMode
+	sources sets.Set[string]
+}
+
+// isEmptyInclude returns true if the member doesn't receive traffic from any source.
+func (f *sourceFilter) isEmptyInclude() bool {
+	return f.mode == includeMode && f.sources.Len() == 0
+}
+
+func (f *sourceFilter) equal(other *sourceFilter) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+	return f.mode == other.mode && f.sources.Equal(other.sources)
+}
+
+// PodGroupMembership describes a multicast group joined by a local Pod, and the source filter of the Pod in the group.
+type PodGroupMembership struct {
+	Group      string   `json:"group"`
+	FilterMode string   `json:"filterMode"`
+	Sources    []string `json:"sources,omitempty"`
+}
+
+// isSSMGroup returns true if the multicast group is in the Source-Specific Multicast address range, which is
+// 232.0.0.0/8 for IPv4 and FF3x::/32 for IPv6 (https://datatracker.ietf.org/doc/html/rfc4607#section-1).
+func isSSMGroup(group net.IP) bool {
+	if group.To4() != nil {
+		return ssmCIDR.Contains(group)
+	}
+	return ipv6SSMCIDR.Contains(group) && group[2] == 0 && group[3] == 0
+}
+
+// updateSourceFilter returns the source filter of a member after applying the group record received from it. The
+// current filter is nil if the member has not joined the group. recordType is 0 if the event is generated from an
+// IGMPv1, IGMPv2 or MLDv1 message, which means the member receives traffic from all sources.
+func updateSourceFilter(current *sourceFilter, recordType uint8, sources []net.IP) *sourceFilter {
+	sourceSet := sets.New[string]()
+	for _, s := range sources {
+		sourceSet.Insert(s.String())
+	}
+	switch recordType {
+	case protocol.IGMPIsIn, protocol.IGMPToIn:
+		return &sourceFilter{mode: includeMode, sources: sourceSet}
+	case protocol.IGMPIsEx, protocol.IGMPToEx:
+		return &sourceFilter{mode: excludeMode, sources: sourceSet}
+	case protocol.IGMPAllow:
+		if current == nil {
+			return &sourceFilter{mode: includeMode, sources: sourceSet}
+		}
+		if current.mode == includeMode {
+			return &sourceFilter{mode: includeMode, sources: current.sources.Union(sourceSet)}
+		}
+		return &sourceFilter{mode: excludeMode, sources: current.sources.Difference(sourceSet)}
+	case protocol.IGMPBlock:
+		if current == nil {
+			return &sourceFilter{mode: includeMode, sources: sets.New[string]()}
+		}
+		if current.mode == includeMode {
+			return &sourceFilter{mode: includeMode, sources: current.sources.Difference(sourceSet)}
+		}
+		return &sourceFilter{mode: excludeMode, sources: current.sources.Union(sourceSet)}
+	default:
+		return &sourceFilter{mode: excludeMode, sources: sets.New[string]()}
+	}
+}
+
+// getSourceReceivers returns the OpenFlow ports of the local members for each source which is included by the members
+// of a SSM group. Members in exclude mode are ignored, because only the INCLUDE mode is supported for SSM groups
+// (https://datatracker.ietf.org/doc/html/rfc4604#section-2.2.1).
+func getSourceReceivers(status *GroupMemberStatus, memberPorts map[string]uint32) map[string][]uint32 {
+	sourceReceivers := make(map[string][]uint32)
+	for member, filter := range status.localMemberFilters {
+		port, ok := memberPorts[member]
+		if !ok || filter.mode != includeMode {
+			continue
+		}
+		for source := range filter.sources {
+			sourceReceivers[source] = append(sourceReceivers[source], port)
+		}
+	}
+	for _, ports := range sourceReceivers {
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	}
+	return sourceReceivers
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicast
+
+import (
+	"net"
+	"testing"
+
+	"antrea.io/libOpenflow/protocol"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestIsSSMGroup(t *testing.T) {
+	for _, tc := range []struct {
+		group    string
+		expected bool
+	}{
+		{group: "232.0.0.1", expected: true},
+		{group: "232.255.255.255", expected: true},
+		{group: "224.0.0.1", expected: false},
+		{group: "239.1.1.1", expected: false},
+		{group: "ff3e::8000:1", expected: true},
+		{group: "ff35::100", expected: true},
+		{group: "ff3e:40:2001:db8::1", expected: false},
+		{group: "ff05::100", expected: false},
+	} {
+		assert.Equal(t, tc.expected, isSSMGroup(net.ParseIP(tc.group)), tc.group)
+	}
+}
+
+func TestUpdateSourceFilter(t *testing.T) {
+	source1 := net.ParseIP("10.10.0.1")
+	source2 := net.ParseIP("10.10.0.2")
+	source3 := net.ParseIP("10.10.0.3")
+	include := func(sources ...string) *sourceFilter {
+		return &sourceFilter{mode: includeMode, sources: sets.New[string](sources...)}
+	}
+	exclude := func(sources ...string) *sourceFilter {
+		return &sourceFilter{mode: excludeMode, sources: sets.New[string](sources...)}
+	}
+	for _, tc := range []struct {
+		name       string
+		current    *sourceFilter
+		recordType uint8
+		sources    []net.IP
+		expected   *sourceFilter
+	}{
+		{
+			name:     "IGMPv2 report",
+			current:  include("10.10.0.1"),
+			expected: exclude(),
+		},
+		{
+			name:       "new member in include mode",
+			recordType: protocol.IGMPIsIn,
+			sources:    []net.IP{source1, source2},
+			expected:   include("10.10.0.1", "10.10.0.2"),
+		},
+		{
+			name:       "change to exclude mode",
+			current:    include("10.10.0.1"),
+			recordType: protocol.IGMPToEx,
+			sources:    []net.IP{source2},
+			expected:   exclude("10.10.0.2"),
+		},
+		{
+			name:       "change to include mode",
+			current:    exclude("10.10.0.2"),
+			recordType: protocol.IGMPToIn,
+			sources:    []net.IP{source1},
+			expected:   include("10.10.0.1"),
+		},
+		{
+			name:       "allow new sources in include mode",
+			current:    include("10.10.0.1"),
+			recordType: protocol.IGMPAllow,
+			sources:    []net.IP{source2, source3},
+			expected:   include("10.10.0.1", "10.10.0.2", "10.10.0.3"),
+		},
+		{
+			name:       "allow new sources in exclude mode",
+			current:    exclude("10.10.0.1", "10.10.0.2"),
+			recordType: protocol.IGMPAllow,
+			sources:    []net.IP{source2},
+			expected:   exclude("10.10.0.1"),
+		},
+		{
+			name:       "allow new sources for new member",
+			recordType: protocol.IGMPAllow,
+			sources:    []net.IP{source1},
+			expected:   include("10.10.0.1"),
+		},
+		{
+			name:       "block old sources in include mode",
+			current:    include("10.10.0.1", "10.10.0.2"),
+			recordType: protocol.IGMPBlock,
+			sources:    []net.IP{source2, source3},
+			expected:   include("10.10.0.1"),
+		},
+		{
+			name:       "block old sources in exclude mode",
+			current:    exclude("10.10.0.1"),
+			recordType: protocol.IGMPBlock,
+			sources:    []net.IP{source2},
+			expected:   exclude("10.10.0.1", "10.10.0.2"),
+		},
+		{
+			name:       "block old sources for new member",
+			recordType: protocol.IGMPBlock,
+			sources:    []net.IP{source1},
+			expected:   include(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := updateSourceFilter(tc.current, tc.recordType, tc.sources)
+			assert.True(t, tc.expected.equal(filter), "expected %v, got %v", tc.expected, filter)
+		})
+	}
+}
+
+func TestGetSourceReceivers(t *testing.T) {
+	status := &GroupMemberStatus{
+		localMemberFilters: map[string]*sourceFilter{
+			"if1": {mode: includeMode, sources: sets.New[string]("10.10.0.1", "10.10.0.2")},
+			"if2": {mode: includeMode, sources: sets.New[string]("10.10.0.1")},
+			"if3": {mode: excludeMode, sources: sets.New[string]()},
+			"if4": {mode: includeMode, sources: sets.New[string]("10.10.0.3")},
+		},
+	}
+	memberPorts := map[string]uint32{"if1": 11, "if2": 5, "if3": 7}
+	expected := map[string][]uint32{
+		"10.10.0.1": {5, 11},
+		"10.10.0.2": {11},
+	}
+	assert.Equal(t, expected, getSourceReceivers(status, memberPorts))
+}
@@ -136,6 +136,20 @@ func (mr *MockRouteInterfaceMockRecorder) MulticastInterfaceJoinMgroup(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MulticastInterfaceJoinMgroup", reflect.TypeOf((*MockRouteInterface)(nil).MulticastInterfaceJoinMgroup), arg0, arg1, arg2)
 }
 
+// MulticastInterfaceJoinSourceMgroup mocks base method.
+func (m *MockRouteInterface) MulticastInterfaceJoinSourceMgroup(arg0, arg1, arg2 net.IP, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MulticastInterfaceJoinSourceMgroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MulticastInterfaceJoinSourceMgroup indicates an expected call of MulticastInterfaceJoinSourceMgroup.
+func (mr *MockRouteInterfaceMockRecorder) MulticastInterfaceJoinSourceMgroup(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MulticastInterfaceJoinSourceMgroup", reflect.TypeOf((*MockRouteInterface)(nil).MulticastInterfaceJoinSourceMgroup), arg0, arg1, arg2, arg3)
+}
+
 // MulticastInterfaceLeaveMgroup mocks base method.
 func (m *MockRouteInterface) MulticastInterfaceLeaveMgroup(arg0, arg1 net.IP, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -149,3 +163,17 @@ func (mr *MockRouteInterfaceMockRecorder) MulticastInterfaceLeaveMgroup(arg0, ar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MulticastInterfaceLeaveMgroup", reflect.TypeOf((*MockRouteInterface)(nil).MulticastInterfaceLeaveMgroup), arg0, arg1, arg2)
 }
+
+// MulticastInterfaceLeaveSourceMgroup mocks base method.
+func (m *MockRouteInterface) MulticastInterfaceLeaveSourceMgroup(arg0, arg1, arg2 net.IP, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MulticastInterfaceLeaveSourceMgroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MulticastInterfaceLeaveSourceMgroup indicates an expected call of MulticastInterfaceLeaveSourceMgroup.
+func (mr *MockRouteInterfaceMockRecorder) MulticastInterfaceLeaveSourceMgroup(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MulticastInterfaceLeaveSourceMgroup", reflect.TypeOf((*MockRouteInterface)(nil).MulticastInterfaceLeaveSourceMgroup), arg0, arg1, arg2, arg3)
+}
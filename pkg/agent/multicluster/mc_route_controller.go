@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,8 +55,16 @@ const (
 
 	workerItemKey = "key"
 
-	multiclusterWireGuardInterface = "antrea-mc-wg0"
-	multiclusterWireGuardPublicKey = "publicKey"
+	multiclusterWireGuardInterface          = "antrea-mc-wg0"
+	multiclusterWireGuardNextKeyInterface   = "antrea-mc-wg1"
+	multiclusterWireGuardPublicKey          = "publicKey"
+	multiclusterWireGuardNextPublicKey      = "nextPublicKey"
+	multiclusterWireGuardNextKeyPort        = "nextKeyPort"
+	multiclusterWireGuardAcceptedPublicKeys = "acceptedPublicKeys"
+
+	// multiclusterWireGuardNextKeyRouteTable is different from the next-key route table of the in-cluster WireGuard
+	// interface, as both can exist on the Gateway Node.
+	multiclusterWireGuardNextKeyRouteTable = 401
 )
 
 var (
@@ -85,14 +94,21 @@ type MCDefaultRouteController struct {
 	// we change the number of 'defaultWorkers'.
 	installedCIImports      map[string]*mcv1alpha1.ClusterInfoImport
 	installedWireGuardPeers map[string]*mcv1alpha1.ClusterInfoImport
-	// Need to use mutex to protect 'installedActiveGW' if we change to
+	// Need to use mutex to protect 'installedActiveGWs' if we change to
 	// use multiple go routines to handle events
-	installedActiveGW *mcv1alpha1.Gateway
+	installedActiveGWs []*mcv1alpha1.Gateway
 	// The Namespace where Antrea Multi-cluster Controller is running.
 	namespace                    string
 	enableStretchedNetworkPolicy bool
 	enablePodToPodConnectivity   bool
 	wireGuardInitialized         bool
+	wireGuardKeyRotationInterval time.Duration
+	// wireGuardKeyRotatorStopCh is used to stop the WireGuard key rotator when WireGuard is cleaned up.
+	wireGuardKeyRotatorStopCh chan struct{}
+	// acceptedWireGuardKeys saves the next WireGuard public keys of the peer clusters which are rotating their keys
+	// and have been configured as WireGuard peers. The key is the ClusterInfoImport name, and the value is the next
+	// public key.
+	acceptedWireGuardKeys map[string]string
 }
 
 func NewMCDefaultRouteController(
@@ -127,10 +143,17 @@ func NewMCDefaultRouteController(
 	_, trafficEncryptionMode := config.GetTrafficEncryptionModeFromStr(multiclusterConfig.TrafficEncryptionMode)
 	if trafficEncryptionMode == config.TrafficEncryptionModeWireGuard {
 		controller.wireGuardConfig = &config.WireGuardConfig{
-			Port: multiclusterConfig.WireGuard.Port,
-			Name: multiclusterWireGuardInterface,
-			MTU:  controller.nodeConfig.NodeTransportInterfaceMTU - controller.networkConfig.MTUDeduction - config.WireGuardOverhead,
+			Port:              multiclusterConfig.WireGuard.Port,
+			Name:              multiclusterWireGuardInterface,
+			MTU:               controller.nodeConfig.NodeTransportInterfaceMTU - controller.networkConfig.MTUDeduction - config.WireGuardOverhead,
+			NextKeyName:       multiclusterWireGuardNextKeyInterface,
+			NextKeyRouteTable: multiclusterWireGuardNextKeyRouteTable,
+		}
+		// The interval has been validated when the agent starts.
+		if multiclusterConfig.WireGuard.KeyRotationInterval != "" {
+			controller.wireGuardKeyRotationInterval, _ = time.ParseDuration(multiclusterConfig.WireGuard.KeyRotationInterval)
 		}
+		controller.acceptedWireGuardKeys = make(map[string]string)
 	}
 	controller.gwInformer.Informer().AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
@@ -207,7 +230,7 @@ func (c *MCDefaultRouteController) enqueueClusterInfoImport(obj interface{}, isD
 			klog.ErrorS(nil, "Received invalid ClusterInfoImport", "object", obj)
 			return
 		}
-		if net.ParseIP(ciImp.Spec.GatewayInfos[0].GatewayIP) == nil {
+		if len(getRemoteGatewayIPs(ciImp.Spec)) == 0 {
 			klog.ErrorS(nil, "Received ClusterInfoImport with invalid Gateway IP", "object", obj)
 			return
 		}
@@ -278,11 +301,16 @@ func (c *MCDefaultRouteController) processNextWorkItem() bool {
 // Note: MCDefaultRouteController runs only one worker to process Gateway and ClusterInfoImport. So we do not need
 // any synchronization mechanism.
 func (c *MCDefaultRouteController) syncWireGuard() error {
-	gateway, err := c.getActiveGateway()
+	activeGWs, err := c.getActiveGateways()
 	if err != nil {
 		return err
 	}
 
+	// Only the first Gateway is active in WireGuard mode.
+	var gateway *mcv1alpha1.Gateway
+	if len(activeGWs) > 0 {
+		gateway = activeGWs[0]
+	}
 	amIGateway := gateway != nil && gateway.Name == c.nodeConfig.Name
 	if c.wireGuardClient != nil && (!amIGateway || !c.wireGuardInitialized) {
 		if err := c.cleanUpWireGuard(); err != nil {
@@ -345,7 +373,10 @@ func (c *MCDefaultRouteController) removeWireGuardRouteAndPeer(ciImport *mcv1alp
 	if err := c.routeClient.DeleteRouteForLink(&dstCIDR, c.wireGuardConfig.LinkIndex); err != nil {
 		return err
 	}
-	return c.wireGuardClient.DeletePeer(ciImport.Name)
+	if err := c.wireGuardClient.DeletePeer(ciImport.Name); err != nil {
+		return err
+	}
+	return c.updateAcceptedWireGuardKey(ciImport.Name, "")
 }
 
 // addWireGuardRouteAndPeer tries to update a WireGuard peer with ClusterInfoImport. If updating successfully,
@@ -374,7 +405,18 @@ func (c *MCDefaultRouteController) addWireGuardRouteAndPeer(ciImport *mcv1alpha1
 	}
 
 	klog.V(2).InfoS("Adding route on the host", "CIDR", remoteWireGuardNet, "device", c.wireGuardConfig.Name)
-	return c.routeClient.AddRouteForLink(remoteWireGuardNet, c.wireGuardConfig.LinkIndex)
+	if err := c.routeClient.AddRouteForLink(remoteWireGuardNet, c.wireGuardConfig.LinkIndex); err != nil {
+		return err
+	}
+	// The peer cluster publishes its next public key before rotating its key. Add it as a peer of the WireGuard
+	// next-key interface, which keeps decrypting the traffic encrypted with the next key until the peer cluster
+	// withdraws it, and let the peer cluster know it has been accepted.
+	nextPublicKey := ciImport.Spec.WireGuard.NextPublicKey
+	nextKeyPort := int(ciImport.Spec.WireGuard.NextKeyPort)
+	if err := c.wireGuardClient.UpdatePeerNextKey(ciImport.Name, nextPublicKey, nextKeyPort, gatewayIP, allowedIPs); err != nil {
+		return err
+	}
+	return c.updateAcceptedWireGuardKey(ciImport.Name, nextPublicKey)
 }
 
 // initializeWireGuard initializes the WireGuard interface and client.
@@ -395,9 +437,14 @@ func (c *MCDefaultRouteController) initializeWireGuard(gateway *mcv1alpha1.Gatew
 		return err
 	}
 
+	// The next public key and the accepted public keys are in-memory states of the previous WireGuard client,
+	// remove them and they will be published again when needed.
 	patch, _ := json.Marshal(map[string]interface{}{
 		"wireGuard": map[string]interface{}{
-			multiclusterWireGuardPublicKey: publicKey,
+			multiclusterWireGuardPublicKey:          publicKey,
+			multiclusterWireGuardNextPublicKey:      nil,
+			multiclusterWireGuardNextKeyPort:        nil,
+			multiclusterWireGuardAcceptedPublicKeys: nil,
 		},
 	})
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -408,16 +455,26 @@ func (c *MCDefaultRouteController) initializeWireGuard(gateway *mcv1alpha1.Gatew
 		return fmt.Errorf("error when patching the Gateway with WireGuard information, error: %s", err)
 	}
 
+	if c.wireGuardKeyRotationInterval > 0 {
+		c.wireGuardKeyRotatorStopCh = make(chan struct{})
+		publisher := newGatewayWireGuardKeyPublisher(c.mcClient, c.ciImportLister, c.namespace, c.nodeConfig.Name)
+		go wireguard.NewKeyRotator(c.wireGuardClient, publisher, c.wireGuardKeyRotationInterval).Run(c.wireGuardKeyRotatorStopCh)
+	}
 	return nil
 }
 
 // cleanUpWireGuard deletes the WireGuard interface on the host.
 // The WireGuard route will also be deleted automatically when the interface is deleted.
 func (c *MCDefaultRouteController) cleanUpWireGuard() error {
+	if c.wireGuardKeyRotatorStopCh != nil {
+		close(c.wireGuardKeyRotatorStopCh)
+		c.wireGuardKeyRotatorStopCh = nil
+	}
 	if err := c.wireGuardClient.CleanUp(); err != nil {
 		return err
 	}
 	c.wireGuardClient = nil
+	c.acceptedWireGuardKeys = make(map[string]string)
 	return nil
 }
 
@@ -426,54 +483,55 @@ func (c *MCDefaultRouteController) syncMCFlows() error {
 	defer func() {
 		klog.V(4).InfoS("Finished syncing flows for Multi-cluster", "time", time.Since(startTime))
 	}()
-	activeGW, err := c.getActiveGateway()
+	activeGWs, err := c.getActiveGateways()
 	if err != nil {
 		return err
 	}
-	if activeGW == nil && c.installedActiveGW == nil {
+	if len(activeGWs) == 0 && len(c.installedActiveGWs) == 0 {
 		klog.V(2).InfoS("No active Gateway is found")
 		return nil
 	}
 
-	klog.V(2).InfoS("Installed Gateway", "gateway", klog.KObj(c.installedActiveGW))
-	if activeGW != nil && c.installedActiveGW != nil && activeGW.Name == c.installedActiveGW.Name {
-		// Active Gateway name doesn't change but still do a full flow sync
+	klog.V(2).InfoS("Installed Gateways", "gateways", klog.KObjSlice(c.installedActiveGWs))
+	amIGateway := isGateway(activeGWs, c.nodeConfig.Name)
+	if len(activeGWs) > 0 && len(c.installedActiveGWs) > 0 && amIGateway == isGateway(c.installedActiveGWs, c.nodeConfig.Name) {
+		// The role of the local Node doesn't change but still do a full flow sync
 		// for any Gateway Spec or ClusterInfoImport changes.
-		if err := c.syncMCFlowsForAllCIImps(activeGW); err != nil {
+		if err := c.syncMCFlowsForAllCIImps(activeGWs); err != nil {
 			return err
 		}
-		c.installedActiveGW = activeGW
+		c.installedActiveGWs = activeGWs
 		return nil
 	}
 
-	if c.installedActiveGW != nil {
+	if len(c.installedActiveGWs) > 0 {
 		if err := c.deleteMCFlowsForAllCIImps(); err != nil {
 			return err
 		}
-		klog.V(2).InfoS("Deleted flows for installed Gateway", "gateway", klog.KObj(c.installedActiveGW))
-		c.installedActiveGW = nil
+		klog.V(2).InfoS("Deleted flows for installed Gateways", "gateways", klog.KObjSlice(c.installedActiveGWs))
+		c.installedActiveGWs = nil
 	}
 
-	if activeGW != nil {
-		if err := c.ofClient.InstallMulticlusterClassifierFlows(config.DefaultTunOFPort, activeGW.Name == c.nodeConfig.Name); err != nil {
+	if len(activeGWs) > 0 {
+		if err := c.ofClient.InstallMulticlusterClassifierFlows(config.DefaultTunOFPort, amIGateway); err != nil {
 			return err
 		}
-		c.installedActiveGW = activeGW
-		return c.addMCFlowsForAllCIImps(activeGW)
+		c.installedActiveGWs = activeGWs
+		return c.addMCFlowsForAllCIImps(activeGWs)
 	}
 	return nil
 }
 
-func (c *MCDefaultRouteController) syncMCFlowsForAllCIImps(activeGW *mcv1alpha1.Gateway) error {
+func (c *MCDefaultRouteController) syncMCFlowsForAllCIImps(activeGWs []*mcv1alpha1.Gateway) error {
 	desiredCIImports, err := c.ciImportLister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
 
-	activeGWChanged := c.checkGatewayIPChange(activeGW)
+	activeGWChanged := c.checkGatewayIPChange(activeGWs)
 	installedCIImportNames := sets.KeySet(c.installedCIImports)
 	for _, ciImp := range desiredCIImports {
-		if err = c.addMCFlowsForSingleCIImp(activeGW, ciImp, c.installedCIImports[ciImp.Name], activeGWChanged); err != nil {
+		if err = c.addMCFlowsForSingleCIImp(activeGWs, ciImp, c.installedCIImports[ciImp.Name], activeGWChanged); err != nil {
 			return err
 		}
 		installedCIImportNames.Delete(ciImp.Name)
@@ -487,19 +545,18 @@ func (c *MCDefaultRouteController) syncMCFlowsForAllCIImps(activeGW *mcv1alpha1.
 	return nil
 }
 
-func (c *MCDefaultRouteController) checkGatewayIPChange(activeGW *mcv1alpha1.Gateway) bool {
-	var activeGWChanged bool
-	if activeGW.Name == c.nodeConfig.Name {
-		// On a Gateway Node, the GatewayIP of the active Gateway will impact the Openflow rules.
-		activeGWChanged = activeGW.GatewayIP != c.installedActiveGW.GatewayIP
-	} else {
-		// On a regular Node, the InternalIP of the active Gateway will impact the Openflow rules.
-		activeGWChanged = activeGW.InternalIP != c.installedActiveGW.InternalIP
+func (c *MCDefaultRouteController) checkGatewayIPChange(activeGWs []*mcv1alpha1.Gateway) bool {
+	if isGateway(activeGWs, c.nodeConfig.Name) {
+		// On a Gateway Node, the GatewayIP of the local Gateway and whether there are multiple Gateways will
+		// impact the Openflow rules.
+		return getGateway(activeGWs, c.nodeConfig.Name).GatewayIP != getGateway(c.installedActiveGWs, c.nodeConfig.Name).GatewayIP ||
+			(len(activeGWs) > 1) != (len(c.installedActiveGWs) > 1)
 	}
-	return activeGWChanged
+	// On a regular Node, the InternalIPs of the active Gateways will impact the Openflow rules.
+	return !ipsEqual(getGatewayInternalIPs(activeGWs), getGatewayInternalIPs(c.installedActiveGWs))
 }
 
-func (c *MCDefaultRouteController) addMCFlowsForAllCIImps(activeGW *mcv1alpha1.Gateway) error {
+func (c *MCDefaultRouteController) addMCFlowsForAllCIImps(activeGWs []*mcv1alpha1.Gateway) error {
 	allCIImports, err := c.ciImportLister.List(labels.Everything())
 	if err != nil {
 		return err
@@ -509,7 +566,7 @@ func (c *MCDefaultRouteController) addMCFlowsForAllCIImps(activeGW *mcv1alpha1.G
 		return nil
 	}
 	for _, ciImport := range allCIImports {
-		if err := c.addMCFlowsForSingleCIImp(activeGW, ciImport, nil, true); err != nil {
+		if err := c.addMCFlowsForSingleCIImp(activeGWs, ciImport, nil, true); err != nil {
 			return err
 		}
 	}
@@ -517,63 +574,68 @@ func (c *MCDefaultRouteController) addMCFlowsForAllCIImps(activeGW *mcv1alpha1.G
 	return nil
 }
 
-func (c *MCDefaultRouteController) addMCFlowsForSingleCIImp(activeGW *mcv1alpha1.Gateway, ciImport *mcv1alpha1.ClusterInfoImport,
+func (c *MCDefaultRouteController) addMCFlowsForSingleCIImp(activeGWs []*mcv1alpha1.Gateway, ciImport *mcv1alpha1.ClusterInfoImport,
 	installedCIImp *mcv1alpha1.ClusterInfoImport, activeGWChanged bool) error {
-	tunnelPeerIPToRemoteGW := getPeerGatewayTunnelIP(ciImport.Spec, c.wireGuardConfig != nil)
-	if tunnelPeerIPToRemoteGW == nil {
+	tunnelPeerIPsToRemoteGWs := getPeerGatewayTunnelIPs(ciImport.Spec, c.wireGuardConfig != nil)
+	if len(tunnelPeerIPsToRemoteGWs) == 0 {
 		klog.ErrorS(nil, "The ClusterInfoImport has no valid Gateway IP, skip it", "clusterinfoimport", klog.KObj(ciImport))
 		return nil
 	}
 
 	var ciImportNoChange bool
 	if installedCIImp != nil {
-		oldTunnelPeerIPToRemoteGW := getPeerGatewayTunnelIP(installedCIImp.Spec, c.wireGuardConfig != nil)
-		ciImportNoChange = oldTunnelPeerIPToRemoteGW.Equal(tunnelPeerIPToRemoteGW) && installedCIImp.Spec.ServiceCIDR == ciImport.Spec.ServiceCIDR
+		oldTunnelPeerIPsToRemoteGWs := getPeerGatewayTunnelIPs(installedCIImp.Spec, c.wireGuardConfig != nil)
+		ciImportNoChange = ipsEqual(oldTunnelPeerIPsToRemoteGWs, tunnelPeerIPsToRemoteGWs) && installedCIImp.Spec.ServiceCIDR == ciImport.Spec.ServiceCIDR
 		if c.enablePodToPodConnectivity {
 			ciImportNoChange = ciImportNoChange && sets.New[string](installedCIImp.Spec.PodCIDRs...).Equal(sets.New[string](ciImport.Spec.PodCIDRs...))
 		}
 	}
 
 	if ciImportNoChange && !activeGWChanged {
-		klog.V(2).InfoS("ClusterInfoImport and the active Gateway have no change, skip updating", "clusterinfoimport", klog.KObj(ciImport), "gateway", klog.KObj(activeGW))
+		klog.V(2).InfoS("ClusterInfoImport and the active Gateways have no change, skip updating", "clusterinfoimport", klog.KObj(ciImport), "gateways", klog.KObjSlice(activeGWs))
 		return nil
 	}
 
-	klog.InfoS("Adding/updating remote Gateway Node flows for Multi-cluster", "gateway", klog.KObj(activeGW),
-		"node", c.nodeConfig.Name, "peer", tunnelPeerIPToRemoteGW)
+	klog.InfoS("Adding/updating remote Gateway Node flows for Multi-cluster", "gateways", klog.KObjSlice(activeGWs),
+		"node", c.nodeConfig.Name, "peers", tunnelPeerIPsToRemoteGWs)
 	allCIDRs := []string{ciImport.Spec.ServiceCIDR}
 	if c.enablePodToPodConnectivity {
 		allCIDRs = append(allCIDRs, ciImport.Spec.PodCIDRs...)
 	}
-	peerConfigs, err := generatePeerConfigs(allCIDRs, tunnelPeerIPToRemoteGW)
+	peerCIDRs, err := parseCIDRs(allCIDRs)
 	if err != nil {
-		klog.ErrorS(err, "Parse error for serviceCIDR from remote cluster", "clusterinfoimport", ciImport.Name, "gateway", activeGW.Name)
+		klog.ErrorS(err, "Parse error for serviceCIDR from remote cluster", "clusterinfoimport", ciImport.Name)
 		return err
 	}
-	if activeGW.Name == c.nodeConfig.Name {
-		klog.V(2).InfoS("Adding/updating flows to remote Gateway Node for Multi-cluster traffic", "clusterinfoimport", ciImport.Name, "cidrs", allCIDRs)
-		localGatewayIP := getLocalGatewayIP(activeGW, c.wireGuardConfig != nil)
+	if localGW := getGateway(activeGWs, c.nodeConfig.Name); localGW != nil {
+		klog.V(2).InfoS("Adding/updating flows to remote Gateway Nodes for Multi-cluster traffic", "clusterinfoimport", ciImport.Name, "cidrs", allCIDRs)
+		localGatewayIP := getLocalGatewayIP(localGW, c.wireGuardConfig != nil)
 		if localGatewayIP == nil {
-			klog.V(2).InfoS("Local Gateway IP has not been allocated, skip", "gateway", klog.KObj(activeGW))
+			klog.V(2).InfoS("Local Gateway IP has not been allocated, skip", "gateway", klog.KObj(localGW))
 			return nil
 		}
+		// If there are multiple local Gateways, the local Gateway may receive cross-cluster packets from another
+		// local Gateway, and the reply packets must be sent back to that Gateway.
+		trackReplyTunnel := len(activeGWs) > 1
 		if err := c.ofClient.InstallMulticlusterGatewayFlows(
 			ciImport.Name,
-			peerConfigs,
-			tunnelPeerIPToRemoteGW,
+			peerCIDRs,
+			tunnelPeerIPsToRemoteGWs,
 			localGatewayIP,
+			trackReplyTunnel,
 			c.enableStretchedNetworkPolicy); err != nil {
-			return fmt.Errorf("failed to install flows to remote Gateway in ClusterInfoImport %s: %v", ciImport.Name, err)
+			return fmt.Errorf("failed to install flows to remote Gateways in ClusterInfoImport %s: %v", ciImport.Name, err)
 		}
 	} else {
-		klog.V(2).InfoS("Adding/updating flows to the local active Gateway for Multi-cluster traffic", "clusterinfoimport", ciImport.Name, "cidrs", allCIDRs)
-		tunnelPeerIPToLocalGW := net.ParseIP(activeGW.InternalIP)
+		klog.V(2).InfoS("Adding/updating flows to the local active Gateways for Multi-cluster traffic", "clusterinfoimport", ciImport.Name, "cidrs", allCIDRs)
+		tunnelPeerIPsToLocalGWs := getGatewayInternalIPs(activeGWs)
 		if err := c.ofClient.InstallMulticlusterNodeFlows(
 			ciImport.Name,
-			peerConfigs,
-			tunnelPeerIPToLocalGW,
+			peerCIDRs,
+			tunnelPeerIPsToRemoteGWs,
+			tunnelPeerIPsToLocalGWs,
 			c.enableStretchedNetworkPolicy); err != nil {
-			return fmt.Errorf("failed to install flows to Gateway %s: %v", activeGW.Name, err)
+			return fmt.Errorf("failed to install flows to Gateways %v: %v", klog.KObjSlice(activeGWs), err)
 		}
 	}
 
@@ -596,61 +658,110 @@ func (c *MCDefaultRouteController) deleteMCFlowsForAllCIImps() error {
 	return nil
 }
 
-func (c *MCDefaultRouteController) getActiveGateway() (*mcv1alpha1.Gateway, error) {
-	activeGW, err := getActiveGateway(c.gwLister)
+// getActiveGateways returns the active Gateways of the local cluster sorted by name. Cross-cluster traffic is
+// distributed across all active Gateways. In WireGuard mode, only the first Gateway is active.
+func (c *MCDefaultRouteController) getActiveGateways() ([]*mcv1alpha1.Gateway, error) {
+	gws, err := getActiveGateways(c.gwLister)
 	if err != nil {
 		return nil, err
 	}
-	if activeGW == nil {
-		return nil, nil
+	if c.wireGuardConfig != nil && len(gws) > 1 {
+		gws = gws[:1]
 	}
-	if net.ParseIP(activeGW.GatewayIP) == nil || net.ParseIP(activeGW.InternalIP) == nil {
-		return nil, fmt.Errorf("the active Gateway %s has no valid GatewayIP or InternalIP", activeGW.Name)
+	var activeGWs []*mcv1alpha1.Gateway
+	for _, gw := range gws {
+		if net.ParseIP(gw.GatewayIP) == nil || net.ParseIP(gw.InternalIP) == nil {
+			klog.InfoS("The Gateway has no valid GatewayIP or InternalIP, skip it", "gateway", klog.KObj(gw))
+			continue
+		}
+		activeGWs = append(activeGWs, gw)
 	}
-	return activeGW, nil
+	return activeGWs, nil
 }
 
-func getActiveGateway(gwLister mclisters.GatewayLister) (*mcv1alpha1.Gateway, error) {
+// getActiveGateways returns all Gateways of the local cluster sorted by name.
+func getActiveGateways(gwLister mclisters.GatewayLister) ([]*mcv1alpha1.Gateway, error) {
 	gws, err := gwLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
-	if len(gws) == 0 {
-		return nil, nil
+	sort.Slice(gws, func(i, j int) bool {
+		return gws[i].Name < gws[j].Name
+	})
+	return gws, nil
+}
+
+// getGateway returns the Gateway with the given name in the Gateway list, or nil if not found.
+func getGateway(gws []*mcv1alpha1.Gateway, name string) *mcv1alpha1.Gateway {
+	for _, gw := range gws {
+		if gw.Name == name {
+			return gw
+		}
 	}
-	// The Gateway webhook guarantees there will be at most one Gateway in a cluster.
-	return gws[0], nil
+	return nil
 }
 
-func generatePeerConfigs(subnets []string, gatewayIP net.IP) (map[*net.IPNet]net.IP, error) {
-	peerConfigs := make(map[*net.IPNet]net.IP, len(subnets))
+func isGateway(gws []*mcv1alpha1.Gateway, nodeName string) bool {
+	return getGateway(gws, nodeName) != nil
+}
+
+func getGatewayInternalIPs(gws []*mcv1alpha1.Gateway) []net.IP {
+	var ips []net.IP
+	for _, gw := range gws {
+		ips = append(ips, net.ParseIP(gw.InternalIP))
+	}
+	return ips
+}
+
+func ipsEqual(ips1, ips2 []net.IP) bool {
+	if len(ips1) != len(ips2) {
+		return false
+	}
+	for i := range ips1 {
+		if !ips1[i].Equal(ips2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCIDRs(subnets []string) ([]*net.IPNet, error) {
+	peerCIDRs := make([]*net.IPNet, 0, len(subnets))
 	for _, subnet := range subnets {
 		_, peerCIDR, err := net.ParseCIDR(subnet)
 		if err != nil {
 			return nil, err
 		}
-		peerConfigs[peerCIDR] = gatewayIP
+		peerCIDRs = append(peerCIDRs, peerCIDR)
 	}
-	return peerConfigs, nil
+	return peerCIDRs, nil
 }
 
-// If WireGuard is disabled, getPeerGatewayTunnelIP will return Gateway's GatewayIP.
-// If WireGuard is enabled, the WireGuard interfaces use the first IP address of ServiceCIDR
-// as its IP address. So getPeerGatewayTunnelIP will return the first IP of the ServiceCIDR
-// as the remote Gateway tunnel IP.
-func getPeerGatewayTunnelIP(spec mcv1alpha1.ClusterInfo, enableWireGuard bool) net.IP {
+// getRemoteGatewayIPs returns the valid GatewayIPs of all Gateways in the peer cluster.
+func getRemoteGatewayIPs(spec mcv1alpha1.ClusterInfo) []net.IP {
+	var ips []net.IP
+	for _, gatewayInfo := range spec.GatewayInfos {
+		if ip := net.ParseIP(gatewayInfo.GatewayIP); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// If WireGuard is disabled, getPeerGatewayTunnelIPs will return the GatewayIPs of all Gateways in
+// the peer cluster. If WireGuard is enabled, the WireGuard interfaces use the first IP address of
+// ServiceCIDR as its IP address. So getPeerGatewayTunnelIPs will return the first IP of the ServiceCIDR
+// as the remote Gateway tunnel IP, and only one Gateway in the peer cluster is used.
+func getPeerGatewayTunnelIPs(spec mcv1alpha1.ClusterInfo, enableWireGuard bool) []net.IP {
 	if enableWireGuard {
 		if spec.ServiceCIDR == "" {
 			klog.InfoS("The ServiceCIDR of the peer cluster has not been updated, skip it", "clusterID", spec.ClusterID)
 			return nil
 		}
 		_, serviceCIDR, _ := net.ParseCIDR(spec.ServiceCIDR)
-		return serviceCIDR.IP
-	}
-	if len(spec.GatewayInfos) == 0 {
-		return nil
+		return []net.IP{serviceCIDR.IP}
 	}
-	return net.ParseIP(spec.GatewayInfos[0].GatewayIP)
+	return getRemoteGatewayIPs(spec)
 }
 
 func getLocalGatewayIP(gateway *mcv1alpha1.Gateway, enableWireGuard bool) net.IP {
@@ -676,5 +787,7 @@ func isWireGuardInfoChanged(cache, cur *mcv1alpha1.ClusterInfoImport) bool {
 	if cache.Spec.WireGuard == nil || cur.Spec.WireGuard == nil {
 		return true
 	}
-	return cache.Spec.WireGuard.PublicKey != cur.Spec.WireGuard.PublicKey
+	return cache.Spec.WireGuard.PublicKey != cur.Spec.WireGuard.PublicKey ||
+		cache.Spec.WireGuard.NextPublicKey != cur.Spec.WireGuard.NextPublicKey ||
+		cache.Spec.WireGuard.NextKeyPort != cur.Spec.WireGuard.NextKeyPort
 }
@@ -21,8 +21,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
@@ -231,13 +233,14 @@ func TestMCRouteControllerAsWireGuardGateway(t *testing.T) {
 		// Create ClusterInfoImport3
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport3.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport3, metav1.CreateOptions{})
-		peerNodeIP3 := getPeerGatewayTunnelIP(clusterInfoImport3.Spec, true)
+		peerNodeIPs3 := getPeerGatewayTunnelIPs(clusterInfoImport3.Spec, true)
 		remoteWGIP, _, _ := net.ParseCIDR(clusterInfoImport3.Spec.ServiceCIDR)
 		remoteWireGuardNet := &net.IPNet{IP: remoteWGIP, Mask: net.CIDRMask(32, 32)}
 		c.wireGuardClient.EXPECT().UpdatePeer(clusterInfoImport3.Name, clusterInfoImport3.Spec.WireGuard.PublicKey,
 			net.ParseIP(clusterInfoImport3.Spec.GatewayInfos[0].GatewayIP), []*net.IPNet{remoteWireGuardNet})
+		c.wireGuardClient.EXPECT().UpdatePeerNextKey(clusterInfoImport3.Name, "", 0, net.ParseIP(clusterInfoImport3.Spec.GatewayInfos[0].GatewayIP), []*net.IPNet{remoteWireGuardNet})
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport3.Name,
-			gomock.Any(), peerNodeIP3, gomock.Any(), true).Times(1)
+			gomock.Any(), peerNodeIPs3, gomock.Any(), false, true).Times(1)
 		mockInterface.EXPECT().AddRouteForLink(gomock.Any(), 0).Times(1)
 		c.processNextWorkItem()
 
@@ -284,16 +287,16 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 		// Create two ClusterInfoImports
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport1.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport1, metav1.CreateOptions{})
-		peerNodeIP1 := getPeerGatewayTunnelIP(clusterInfoImport1.Spec, false)
+		peerNodeIPs1 := getPeerGatewayTunnelIPs(clusterInfoImport1.Spec, false)
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, gw1GatewayIP, true).Times(1)
+			gomock.Any(), peerNodeIPs1, gw1GatewayIP, false, true).Times(1)
 		c.processNextWorkItem()
 
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport2.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport2, metav1.CreateOptions{})
-		peerNodeIP2 := getPeerGatewayTunnelIP(clusterInfoImport2.Spec, false)
+		peerNodeIPs2 := getPeerGatewayTunnelIPs(clusterInfoImport2.Spec, false)
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport2.Name,
-			gomock.Any(), peerNodeIP2, gw1GatewayIP, true).Times(1)
+			gomock.Any(), peerNodeIPs2, gw1GatewayIP, false, true).Times(1)
 		c.processNextWorkItem()
 
 		// Update a ClusterInfoImport
@@ -301,7 +304,7 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport1.GetNamespace()).
 			Update(context.TODO(), &clusterInfoImport1, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, gw1GatewayIP, true).Times(1)
+			gomock.Any(), peerNodeIPs1, gw1GatewayIP, false, true).Times(1)
 		c.processNextWorkItem()
 
 		// Delete a ClusterInfoImport
@@ -317,7 +320,7 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(updatedGateway1a.GetNamespace()).Update(context.TODO(),
 			updatedGateway1a, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, updatedGateway1aIP, true).Times(1)
+			gomock.Any(), peerNodeIPs1, updatedGateway1aIP, false, true).Times(1)
 		c.processNextWorkItem()
 
 		// Update Gateway1's InternalIP
@@ -337,7 +340,7 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(gateway2.GetNamespace()).Create(context.TODO(),
 			&gateway2, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterClassifierFlows(uint32(1), false).Times(1)
-		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), gw2InternalIP, true).Times(1)
+		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), peerNodeIPs1, []net.IP{gw2InternalIP}, true).Times(1)
 		c.processNextWorkItem()
 	}()
 	select {
@@ -369,6 +372,8 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		defer close(finishCh)
 		peerNodeIP1 := net.ParseIP(gateway1.InternalIP)
 		peerNodeIP2 := net.ParseIP(gateway2.InternalIP)
+		remoteGatewayIPs1 := getPeerGatewayTunnelIPs(clusterInfoImport1.Spec, false)
+		remoteGatewayIPs2 := getPeerGatewayTunnelIPs(clusterInfoImport2.Spec, false)
 
 		// Create Gateway1
 		c.mcClient.MulticlusterV1alpha1().Gateways(gateway1.GetNamespace()).Create(context.TODO(),
@@ -380,13 +385,13 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport1.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport1, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, true).Times(1)
+			gomock.Any(), remoteGatewayIPs1, []net.IP{peerNodeIP1}, true).Times(1)
 		c.processNextWorkItem()
 
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport2.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport2, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport2.Name,
-			gomock.Any(), peerNodeIP1, true).Times(1)
+			gomock.Any(), remoteGatewayIPs2, []net.IP{peerNodeIP1}, true).Times(1)
 		c.processNextWorkItem()
 
 		// Update a ClusterInfoImport
@@ -394,7 +399,7 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport1.GetNamespace()).
 			Update(context.TODO(), &clusterInfoImport1, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, true).Times(1)
+			gomock.Any(), remoteGatewayIPs1, []net.IP{peerNodeIP1}, true).Times(1)
 		c.processNextWorkItem()
 
 		// Delete a ClusterInfoImport
@@ -417,7 +422,7 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(updatedGateway1b.GetNamespace()).Update(context.TODO(),
 			updatedGateway1b, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name,
-			gomock.Any(), updatedGateway1bIP, true).Times(1)
+			gomock.Any(), remoteGatewayIPs1, []net.IP{updatedGateway1bIP}, true).Times(1)
 		c.processNextWorkItem()
 
 		// Delete Gateway1
@@ -430,7 +435,7 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(gateway2.GetNamespace()).Create(context.TODO(),
 			&gateway2, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterClassifierFlows(uint32(1), false).Times(1)
-		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), peerNodeIP2, true).Times(1)
+		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), remoteGatewayIPs1, []net.IP{peerNodeIP2}, true).Times(1)
 		c.processNextWorkItem()
 	}()
 	select {
@@ -440,6 +445,88 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 	}
 }
 
+func TestMCRouteControllerWithMultipleGateways(t *testing.T) {
+	ciImport := clusterInfoImport2.DeepCopy()
+	ciImport.Spec.GatewayInfos = append(ciImport.Spec.GatewayInfos, mcv1alpha1.GatewayInfo{GatewayIP: "12.11.0.11"})
+	remoteGatewayIPs := []net.IP{net.ParseIP("12.11.0.10"), net.ParseIP("12.11.0.11")}
+
+	tests := []struct {
+		name     string
+		nodeName string
+		// expectFn sets the expectations when both Gateways are active, and when only gateway2 is active.
+		expectFn func(c *fakeRouteController)
+	}{
+		{
+			name:     "regular Node",
+			nodeName: "node-3",
+			expectFn: func(c *fakeRouteController) {
+				c.ofClient.EXPECT().InstallMulticlusterClassifierFlows(uint32(1), false).Times(1)
+				c.ofClient.EXPECT().InstallMulticlusterNodeFlows(ciImport.Name, gomock.Any(), remoteGatewayIPs,
+					[]net.IP{net.ParseIP(gateway1.InternalIP), gw2InternalIP}, true).Times(1)
+				c.ofClient.EXPECT().InstallMulticlusterNodeFlows(ciImport.Name, gomock.Any(), remoteGatewayIPs,
+					[]net.IP{gw2InternalIP}, true).Times(1)
+			},
+		},
+		{
+			name:     "Gateway Node",
+			nodeName: "node-2",
+			expectFn: func(c *fakeRouteController) {
+				c.ofClient.EXPECT().InstallMulticlusterClassifierFlows(uint32(1), true).Times(1)
+				c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(ciImport.Name, gomock.Any(), remoteGatewayIPs,
+					net.ParseIP(gateway2.GatewayIP), true, true).Times(1)
+				c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(ciImport.Name, gomock.Any(), remoteGatewayIPs,
+					net.ParseIP(gateway2.GatewayIP), false, true).Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMCDefaultRouteController(
+				t,
+				&config.NodeConfig{Name: tt.nodeName},
+				&config.NetworkConfig{},
+				agent.WireGuardConfig{},
+				nil,
+				"none",
+				nil,
+			)
+			defer c.queue.ShutDown()
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			c.informerFactory.Start(stopCh)
+			c.informerFactory.WaitForCacheSync(stopCh)
+
+			_, err := c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(ciImport.Namespace).Create(context.TODO(), ciImport, metav1.CreateOptions{})
+			require.NoError(t, err)
+			_, err = c.mcClient.MulticlusterV1alpha1().Gateways(gateway1.Namespace).Create(context.TODO(), gateway1.DeepCopy(), metav1.CreateOptions{})
+			require.NoError(t, err)
+			_, err = c.mcClient.MulticlusterV1alpha1().Gateways(gateway2.Namespace).Create(context.TODO(), gateway2.DeepCopy(), metav1.CreateOptions{})
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				gws, _ := c.gwLister.List(labels.Everything())
+				ciImports, _ := c.ciImportLister.List(labels.Everything())
+				return len(gws) == 2 && len(ciImports) == 1
+			}, 2*time.Second, 10*time.Millisecond)
+			tt.expectFn(c)
+
+			require.NoError(t, c.syncMCFlows())
+			assert.Equal(t, []string{gateway1.Name, gateway2.Name}, []string{c.installedActiveGWs[0].Name, c.installedActiveGWs[1].Name})
+
+			// Traffic fails over to the remaining Gateway when a Gateway is deleted.
+			err = c.mcClient.MulticlusterV1alpha1().Gateways(gateway1.Namespace).Delete(context.TODO(), gateway1.Name, metav1.DeleteOptions{})
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				gws, _ := c.gwLister.List(labels.Everything())
+				return len(gws) == 1
+			}, 2*time.Second, 10*time.Millisecond)
+			require.NoError(t, c.syncMCFlows())
+			require.Len(t, c.installedActiveGWs, 1)
+			assert.Equal(t, gateway2.Name, c.installedActiveGWs[0].Name)
+		})
+	}
+}
+
 func TestRemoveWireGuardRouteAndPeer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockInterface := routemock.NewMockInterface(ctrl)
@@ -476,6 +563,114 @@ func TestRemoveWireGuardRouteAndPeer(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAddWireGuardRouteAndPeerWithNextKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInterface := routemock.NewMockInterface(ctrl)
+	wgClient := wgtest.NewMockInterface(ctrl)
+	c := newMCDefaultRouteController(t,
+		&config.NodeConfig{Name: gateway4.Name},
+		&config.NetworkConfig{},
+		agent.WireGuardConfig{},
+		mockInterface,
+		"wireGuard",
+		wgClient,
+	)
+	defer c.queue.ShutDown()
+	_, err := c.mcClient.MulticlusterV1alpha1().Gateways(gateway4.Namespace).Create(context.TODO(), gateway4.DeepCopy(), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	ciImport := clusterInfoImport3.DeepCopy()
+	ciImport.Spec.WireGuard.NextPublicKey = "next-key"
+	ciImport.Spec.WireGuard.NextKeyPort = 23456
+	gatewayIP := net.ParseIP(ciImport.Spec.GatewayInfos[0].GatewayIP)
+	remoteWireGuardNet := &net.IPNet{IP: net.ParseIP("14.14.4.0"), Mask: net.CIDRMask(32, 32)}
+	wgClient.EXPECT().UpdatePeer(ciImport.Name, "key", gatewayIP, []*net.IPNet{remoteWireGuardNet})
+	wgClient.EXPECT().UpdatePeerNextKey(ciImport.Name, "next-key", 23456, gatewayIP, []*net.IPNet{remoteWireGuardNet})
+	mockInterface.EXPECT().AddRouteForLink(remoteWireGuardNet, 0)
+	require.NoError(t, c.addWireGuardRouteAndPeer(ciImport))
+
+	gw, err := c.mcClient.MulticlusterV1alpha1().Gateways(gateway4.Namespace).Get(context.TODO(), gateway4.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"next-key"}, gw.WireGuard.AcceptedPublicKeys)
+
+	// The peer cluster has rotated its key, and keeps the next public key until all peer clusters have switched to
+	// the new key.
+	ciImport.Spec.WireGuard.PublicKey = "next-key"
+	wgClient.EXPECT().UpdatePeer(ciImport.Name, "next-key", gatewayIP, []*net.IPNet{remoteWireGuardNet})
+	wgClient.EXPECT().UpdatePeerNextKey(ciImport.Name, "next-key", 23456, gatewayIP, []*net.IPNet{remoteWireGuardNet})
+	mockInterface.EXPECT().AddRouteForLink(remoteWireGuardNet, 0)
+	require.NoError(t, c.addWireGuardRouteAndPeer(ciImport))
+
+	// The peer cluster withdraws the next public key.
+	ciImport.Spec.WireGuard.NextPublicKey = ""
+	ciImport.Spec.WireGuard.NextKeyPort = 0
+	wgClient.EXPECT().UpdatePeer(ciImport.Name, "next-key", gatewayIP, []*net.IPNet{remoteWireGuardNet})
+	wgClient.EXPECT().UpdatePeerNextKey(ciImport.Name, "", 0, gatewayIP, []*net.IPNet{remoteWireGuardNet})
+	mockInterface.EXPECT().AddRouteForLink(remoteWireGuardNet, 0)
+	require.NoError(t, c.addWireGuardRouteAndPeer(ciImport))
+
+	gw, err = c.mcClient.MulticlusterV1alpha1().Gateways(gateway4.Namespace).Get(context.TODO(), gateway4.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, gw.WireGuard.AcceptedPublicKeys)
+	assert.Equal(t, "key", gw.WireGuard.PublicKey)
+}
+
+func TestGatewayWireGuardKeyPublisher(t *testing.T) {
+	c := newMCDefaultRouteController(t,
+		&config.NodeConfig{Name: gateway4.Name},
+		&config.NetworkConfig{},
+		agent.WireGuardConfig{},
+		nil,
+		"wireGuard",
+		nil,
+	)
+	defer c.queue.ShutDown()
+
+	acceptingCIImport := clusterInfoImport3.DeepCopy()
+	acceptingCIImport.Spec.WireGuard.AcceptedPublicKeys = []string{"new-key"}
+	pendingCIImport := clusterInfoImport3.DeepCopy()
+	pendingCIImport.Name = "cluster-e-default-clusterinfo"
+	pendingCIImport.Spec.WireGuard.NextPublicKey = "next-key"
+	pendingCIImport.Spec.WireGuard.NextKeyPort = 23456
+	ciImportWithoutWireGuard := clusterInfoImport1.DeepCopy()
+	for _, obj := range []*mcv1alpha1.ClusterInfoImport{acceptingCIImport, pendingCIImport, ciImportWithoutWireGuard} {
+		_, err := c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(obj.Namespace).Create(context.TODO(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	_, err := c.mcClient.MulticlusterV1alpha1().Gateways(gateway4.Namespace).Create(context.TODO(), gateway4.DeepCopy(), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+
+	publisher := newGatewayWireGuardKeyPublisher(c.mcClient, c.ciImportLister, gateway4.Namespace, gateway4.Name)
+	require.NoError(t, publisher.PublishNextKey("new-key", 12345))
+	gw, err := c.mcClient.MulticlusterV1alpha1().Gateways(gateway4.Namespace).Get(context.TODO(), gateway4.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, &mcv1alpha1.WireGuardInfo{PublicKey: "key", NextPublicKey: "new-key", NextKeyPort: 12345}, gw.WireGuard)
+
+	pendingPeers, err := publisher.GetPeersPendingNextKey("new-key")
+	require.NoError(t, err)
+	assert.Equal(t, []string{pendingCIImport.Name}, pendingPeers)
+
+	rotatingPeers, err := publisher.GetPeersRotatingKey()
+	require.NoError(t, err)
+	assert.Equal(t, []string{pendingCIImport.Name}, rotatingPeers)
+
+	// The next public key is kept after the key rotation, until the peer clusters have switched to the new key.
+	require.NoError(t, publisher.PublishKey("new-key"))
+	gw, err = c.mcClient.MulticlusterV1alpha1().Gateways(gateway4.Namespace).Get(context.TODO(), gateway4.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, &mcv1alpha1.WireGuardInfo{PublicKey: "new-key", NextPublicKey: "new-key", NextKeyPort: 12345}, gw.WireGuard)
+
+	require.NoError(t, publisher.WithdrawNextKey())
+	gw, err = c.mcClient.MulticlusterV1alpha1().Gateways(gateway4.Namespace).Get(context.TODO(), gateway4.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, &mcv1alpha1.WireGuardInfo{PublicKey: "new-key"}, gw.WireGuard)
+}
+
 func TestEnqueueGateway(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockInterface := routemock.NewMockInterface(ctrl)
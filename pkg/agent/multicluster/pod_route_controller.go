@@ -263,16 +263,16 @@ func (c *MCPodRouteController) processGatewayNextWorkItem() bool {
 }
 
 func (c *MCPodRouteController) syncGateway() error {
-	activeGW, err := getActiveGateway(c.gwLister)
+	activeGWs, err := getActiveGateways(c.gwLister)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get an active Gateway")
+		klog.ErrorS(err, "Failed to get active Gateways")
 		return err
 	}
 
 	c.podWorkersStartedMutex.Lock()
 	defer c.podWorkersStartedMutex.Unlock()
 
-	amIGateway := activeGW != nil && c.nodeConfig.Name == activeGW.Name
+	amIGateway := isGateway(activeGWs, c.nodeConfig.Name)
 	// Stop Pod flow controller and clean up all installed Multi-cluster Pod flows,
 	// if the Node was a Gateway before.
 	if !amIGateway {
@@ -0,0 +1,155 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+
+	mcclientset "antrea.io/antrea/multicluster/pkg/client/clientset/versioned"
+	mclisters "antrea.io/antrea/multicluster/pkg/client/listers/multicluster/v1alpha1"
+	"antrea.io/antrea/pkg/agent/wireguard"
+)
+
+// gatewayWireGuardKeyPublisher publishes the WireGuard public keys of the local Gateway via the WireGuard field of the
+// Gateway, and tracks the peer clusters which have accepted the next public key via the ClusterInfoImports.
+type gatewayWireGuardKeyPublisher struct {
+	mcClient       mcclientset.Interface
+	ciImportLister mclisters.ClusterInfoImportLister
+	namespace      string
+	gatewayName    string
+}
+
+var _ wireguard.KeyPublisher = &gatewayWireGuardKeyPublisher{}
+
+func newGatewayWireGuardKeyPublisher(mcClient mcclientset.Interface, ciImportLister mclisters.ClusterInfoImportLister, namespace, gatewayName string) *gatewayWireGuardKeyPublisher {
+	return &gatewayWireGuardKeyPublisher{
+		mcClient:       mcClient,
+		ciImportLister: ciImportLister,
+		namespace:      namespace,
+		gatewayName:    gatewayName,
+	}
+}
+
+func (p *gatewayWireGuardKeyPublisher) PublishNextKey(nextPublicKey string, nextKeyPort int) error {
+	return patchGatewayWireGuardInfo(p.mcClient, p.namespace, p.gatewayName, map[string]interface{}{
+		multiclusterWireGuardNextPublicKey: nextPublicKey,
+		multiclusterWireGuardNextKeyPort:   nextKeyPort,
+	})
+}
+
+func (p *gatewayWireGuardKeyPublisher) GetPeersPendingNextKey(nextPublicKey string) ([]string, error) {
+	ciImports, err := p.ciImportLister.ClusterInfoImports(p.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var pendingPeers []string
+	for _, ciImport := range ciImports {
+		// Peer clusters without a WireGuard public key are not WireGuard peers of the local Gateway.
+		if ciImport.Spec.WireGuard == nil || ciImport.Spec.WireGuard.PublicKey == "" {
+			continue
+		}
+		if !sets.New[string](ciImport.Spec.WireGuard.AcceptedPublicKeys...).Has(nextPublicKey) {
+			pendingPeers = append(pendingPeers, ciImport.Name)
+		}
+	}
+	sort.Strings(pendingPeers)
+	return pendingPeers, nil
+}
+
+func (p *gatewayWireGuardKeyPublisher) GetPeersRotatingKey() ([]string, error) {
+	ciImports, err := p.ciImportLister.ClusterInfoImports(p.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var rotatingPeers []string
+	for _, ciImport := range ciImports {
+		if ciImport.Spec.WireGuard != nil && ciImport.Spec.WireGuard.NextPublicKey != "" {
+			rotatingPeers = append(rotatingPeers, ciImport.Name)
+		}
+	}
+	sort.Strings(rotatingPeers)
+	return rotatingPeers, nil
+}
+
+func (p *gatewayWireGuardKeyPublisher) PublishKey(publicKey string) error {
+	return patchGatewayWireGuardInfo(p.mcClient, p.namespace, p.gatewayName, map[string]interface{}{
+		multiclusterWireGuardPublicKey: publicKey,
+	})
+}
+
+func (p *gatewayWireGuardKeyPublisher) WithdrawNextKey() error {
+	return patchGatewayWireGuardInfo(p.mcClient, p.namespace, p.gatewayName, map[string]interface{}{
+		multiclusterWireGuardNextPublicKey: nil,
+		multiclusterWireGuardNextKeyPort:   nil,
+	})
+}
+
+// updateAcceptedWireGuardKey records that the next public key of the given peer cluster has been configured as a
+// WireGuard peer, and updates the accepted public keys of the local Gateway accordingly. An empty nextPublicKey
+// removes the record of the peer cluster.
+func (c *MCDefaultRouteController) updateAcceptedWireGuardKey(ciImportName, nextPublicKey string) error {
+	oldNextPublicKey, exists := c.acceptedWireGuardKeys[ciImportName]
+	if oldNextPublicKey == nextPublicKey && (exists || nextPublicKey == "") {
+		return nil
+	}
+	if nextPublicKey == "" {
+		delete(c.acceptedWireGuardKeys, ciImportName)
+	} else {
+		c.acceptedWireGuardKeys[ciImportName] = nextPublicKey
+	}
+	keys := sets.New[string]()
+	for _, key := range c.acceptedWireGuardKeys {
+		keys.Insert(key)
+	}
+	var value interface{}
+	if keys.Len() > 0 {
+		value = sets.List(keys)
+	}
+	if err := patchGatewayWireGuardInfo(c.mcClient, c.namespace, c.nodeConfig.Name, map[string]interface{}{
+		multiclusterWireGuardAcceptedPublicKeys: value,
+	}); err != nil {
+		if exists {
+			c.acceptedWireGuardKeys[ciImportName] = oldNextPublicKey
+		} else {
+			delete(c.acceptedWireGuardKeys, ciImportName)
+		}
+		return err
+	}
+	return nil
+}
+
+// patchGatewayWireGuardInfo patches the WireGuard field of the Gateway. A nil value removes the field.
+func patchGatewayWireGuardInfo(mcClient mcclientset.Interface, namespace, gatewayName string, wireGuardInfo map[string]interface{}) error {
+	patch, _ := json.Marshal(map[string]interface{}{
+		"wireGuard": wireGuardInfo,
+	})
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, err := mcClient.MulticlusterV1alpha1().Gateways(namespace).Patch(context.TODO(), gatewayName, apitypes.MergePatchType, patch,
+			metav1.PatchOptions{})
+		return err
+	}); err != nil {
+		return fmt.Errorf("error when patching the Gateway with WireGuard information, error: %s", err)
+	}
+	return nil
+}
@@ -85,8 +85,14 @@ type Client interface {
 	UninstallPodFlows(interfaceName string) error
 
 	// InstallServiceGroup installs a group for Service LB. Each endpoint
-	// is a bucket of the group. For now, each bucket has the same weight.
-	InstallServiceGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints []proxy.Endpoint) error
+	// is a bucket of the group. Each bucket has the same weight, unless the
+	// endpoint implements WeightedEndpoint.
+	// An endpoint may appear several times, e.g. when the endpoints are a
+	// Maglev lookup table, in which case it gets one bucket per appearance.
+	// If hashSelection is true, the bucket is selected with a hash of the
+	// 5-tuple of the packet, which is the same on all Nodes, instead of the
+	// default dp_hash of OVS, which is only consistent on the local Node.
+	InstallServiceGroup(groupID binding.GroupIDType, withSessionAffinity, hashSelection bool, endpoints []proxy.Endpoint) error
 	// UninstallServiceGroup removes the group and its buckets that are
 	// installed by InstallServiceGroup.
 	UninstallServiceGroup(groupID binding.GroupIDType) error
@@ -310,6 +316,13 @@ type Client interface {
 	// UninstallMulticastFlows removes the flow matching the given multicastIP.
 	UninstallMulticastFlows(multicastIP net.IP) error
 
+	// InstallMulticastSourceFlows installs the flow to forward the multicast traffic sent from the given sourceIP to
+	// the given SSM multicastIP with the OpenFlow group identified by groupID.
+	InstallMulticastSourceFlows(multicastIP, sourceIP net.IP, groupID binding.GroupIDType) error
+
+	// UninstallMulticastSourceFlows removes the flow matching the given multicastIP and sourceIP.
+	UninstallMulticastSourceFlows(multicastIP, sourceIP net.IP) error
+
 	// InstallMulticastRemoteReportFlows installs flows to forward the IGMP report messages to the other Nodes,
 	// and packetIn the report messages to Antrea Agent which is received via tunnel port.
 	// The OpenFlow group identified by groupID is used to forward packet to all other Nodes in the cluster
@@ -334,6 +347,13 @@ type Client interface {
 	// UninstallTrafficControlReturnPortFlow removes the flow to classify the packets from a return port.
 	UninstallTrafficControlReturnPortFlow(returnOFPort uint32) error
 
+	// InstallL7FlowExporterPodFlows installs the flows to mirror the packets of a Pod to the L7 engine, so that the L7
+	// metadata of its connections can be exported.
+	InstallL7FlowExporterPodFlows(podKey string, podOFPort uint32) error
+
+	// UninstallL7FlowExporterPodFlows removes the flows installed by InstallL7FlowExporterPodFlows.
+	UninstallL7FlowExporterPodFlows(podKey string) error
+
 	InstallMulticastGroup(ofGroupID binding.GroupIDType, localReceivers []uint32, remoteNodeReceivers []net.IP) error
 	// UninstallMulticastGroup removes the group and its buckets that are
 	// installed by InstallMulticastGroup.
@@ -346,19 +366,26 @@ type Client interface {
 		igmp ofutil.Message) error
 
 	// InstallMulticlusterNodeFlows installs flows to handle cross-cluster packets between a regular
-	// Node and a local Gateway.
+	// Node and the local Gateways. If there are multiple local Gateways, cross-cluster connections are
+	// distributed across them, and reply packets are sent back to the Gateway which has received the
+	// request packets.
 	InstallMulticlusterNodeFlows(
 		clusterID string,
-		peerConfigs map[*net.IPNet]net.IP,
-		tunnelPeerIP net.IP,
+		peerCIDRs []*net.IPNet,
+		remoteGatewayIPs []net.IP,
+		localGatewayIPs []net.IP,
 		enableStretchedNetworkPolicy bool) error
 
 	// InstallMulticlusterGatewayFlows installs flows to handle cross-cluster packets between Gateways.
+	// If there are multiple remote Gateways, cross-cluster connections are distributed across them.
+	// trackReplyTunnel should be true if there are multiple local Gateways, so that reply packets
+	// are sent back to the Gateway which has received the request packets.
 	InstallMulticlusterGatewayFlows(
 		clusterID string,
-		peerConfigs map[*net.IPNet]net.IP,
-		tunnelPeerIP net.IP,
+		peerCIDRs []*net.IPNet,
+		remoteGatewayIPs []net.IP,
 		localGatewayIP net.IP,
+		trackReplyTunnel bool,
 		enableStretchedNetworkPolicy bool) error
 
 	// InstallMulticlusterClassifierFlows installs flows to classify cross-cluster packets.
@@ -566,6 +593,8 @@ func (c *client) InstallNodeFlows(hostname string,
 		} else {
 			flows = append(flows, c.featurePodConnectivity.l3FwdFlowToRemoteViaRouting(localGatewayMAC, remoteGatewayMAC, tunnelPeerIP, peerPodCIDR)...)
 		}
+		// Allow the Endpoint health checks sent by AntreaProxy of the peer Node to bypass NetworkPolicies.
+		flows = append(flows, c.featurePodConnectivity.peerEndpointHealthCheckFlows(peerGatewayIP, tunnelPeerIP)...)
 		if c.enableEgress {
 			flows = append(flows, c.featureEgress.snatSkipNodeFlow(tunnelPeerIP))
 		}
@@ -683,11 +712,11 @@ func (c *client) GetPodFlowKeys(interfaceName string) []string {
 	return c.getFlowKeysFromCache(c.featurePodConnectivity.podCachedFlows, interfaceName)
 }
 
-func (c *client) InstallServiceGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints []proxy.Endpoint) error {
+func (c *client) InstallServiceGroup(groupID binding.GroupIDType, withSessionAffinity, hashSelection bool, endpoints []proxy.Endpoint) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 
-	group := c.featureService.serviceEndpointGroup(groupID, withSessionAffinity, endpoints...)
+	group := c.featureService.serviceEndpointGroup(groupID, withSessionAffinity, hashSelection, endpoints...)
 	_, installed := c.featureService.groupCache.Load(groupID)
 	if !installed {
 		if err := c.ofEntryOperations.AddOFEntries([]binding.OFEntry{group}); err != nil {
@@ -936,6 +965,8 @@ func (c *client) generatePipelines() {
 		c.enableDenyTracking,
 		c.enableAntreaPolicy,
 		c.enableL7NetworkPolicy,
+		c.enableL7FlowExporter,
+		c.enableTrafficControl,
 		c.enableMulticast,
 		c.proxyAll,
 		c.connectUplinkToBridge,
@@ -950,13 +981,12 @@ func (c *client) generatePipelines() {
 	}
 
 	if c.enableMulticast {
-		// TODO: add support for IPv6 protocol
-		c.featureMulticast = newFeatureMulticast(c.cookieAllocator, []binding.Protocol{binding.ProtocolIP}, c.bridge, c.enableAntreaPolicy, c.nodeConfig.GatewayConfig.OFPort, c.networkConfig.TrafficEncapMode.SupportsEncap(), config.DefaultTunOFPort)
+		c.featureMulticast = newFeatureMulticast(c.cookieAllocator, c.ipProtocols, c.bridge, c.enableAntreaPolicy, c.nodeConfig.GatewayConfig.OFPort, c.networkConfig.TrafficEncapMode.SupportsEncap(), config.DefaultTunOFPort)
 		c.activatedFeatures = append(c.activatedFeatures, c.featureMulticast)
 	}
 
 	if c.enableMulticluster {
-		c.featureMulticluster = newFeatureMulticluster(c.cookieAllocator, []binding.Protocol{binding.ProtocolIP})
+		c.featureMulticluster = newFeatureMulticluster(c.cookieAllocator, []binding.Protocol{binding.ProtocolIP}, c.bridge, c.groupIDAllocator)
 		c.activatedFeatures = append(c.activatedFeatures, c.featureMulticluster)
 	}
 
@@ -967,9 +997,9 @@ func (c *client) generatePipelines() {
 	pipelineIDs := []binding.PipelineID{pipelineRoot, pipelineIP}
 	if c.networkConfig.IPv4Enabled {
 		pipelineIDs = append(pipelineIDs, pipelineARP)
-		if c.enableMulticast {
-			pipelineIDs = append(pipelineIDs, pipelineMulticast)
-		}
+	}
+	if c.enableMulticast {
+		pipelineIDs = append(pipelineIDs, pipelineMulticast)
 	}
 	if c.nodeType == config.ExternalNode {
 		pipelineIDs = append(pipelineIDs, pipelineNonIP)
@@ -1194,6 +1224,19 @@ func (c *client) SendTraceflowPacket(dataplaneTag uint8, packet *binding.Packet,
 		}
 		packetOutBuilder = packetOutBuilder.SetUDPDstPort(packet.DestinationPort).
 			SetUDPSrcPort(udpSrcPort)
+	case binding.IPProtocolSCTP:
+		if packet.IsIPv6 {
+			packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolSCTPv6)
+		} else {
+			packetOutBuilder = packetOutBuilder.SetIPProtocol(binding.ProtocolSCTP)
+		}
+		sctpSrcPort := packet.SourcePort
+		if sctpSrcPort == 0 {
+			// #nosec G404: random number generator not used for security purposes.
+			sctpSrcPort = uint16(rand.Uint32())
+		}
+		packetOutBuilder = packetOutBuilder.SetSCTPDstPort(packet.DestinationPort).
+			SetSCTPSrcPort(sctpSrcPort)
 	default:
 		packetOutBuilder = packetOutBuilder.SetIPProtocolValue(packet.IsIPv6, packet.IPProto)
 	}
@@ -1420,6 +1463,21 @@ func (c *client) UninstallMulticastFlows(multicastIP net.IP) error {
 	return c.deleteFlows(c.featureMulticast.cachedFlows, cacheKey)
 }
 
+func (c *client) InstallMulticastSourceFlows(multicastIP, sourceIP net.IP, groupID binding.GroupIDType) error {
+	flows := c.featureMulticast.sourceSpecificMulticastForwardFlows(multicastIP, sourceIP, groupID)
+	cacheKey := fmt.Sprintf("multicast_%s_%s", multicastIP.String(), sourceIP.String())
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.addFlows(c.featureMulticast.cachedFlows, cacheKey, flows)
+}
+
+func (c *client) UninstallMulticastSourceFlows(multicastIP, sourceIP net.IP) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	cacheKey := fmt.Sprintf("multicast_%s_%s", multicastIP.String(), sourceIP.String())
+	return c.deleteFlows(c.featureMulticast.cachedFlows, cacheKey)
+}
+
 func (c *client) InstallMulticastRemoteReportFlows(groupID binding.GroupIDType) error {
 	firstMulticastTable := c.pipelines[pipelineMulticast].GetFirstTable()
 	flows := c.featureMulticast.multicastRemoteReportFlows(groupID, firstMulticastTable)
@@ -1479,6 +1537,21 @@ func (c *client) UninstallTrafficControlReturnPortFlow(returnOFPort uint32) erro
 	return c.deleteFlows(c.featurePodConnectivity.tcCachedFlows, cacheKey)
 }
 
+func (c *client) InstallL7FlowExporterPodFlows(podKey string, podOFPort uint32) error {
+	flows := c.featurePodConnectivity.l7FlowExporterMarkFlows(podOFPort)
+	cacheKey := fmt.Sprintf("l7_%s", podKey)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.modifyFlows(c.featurePodConnectivity.tcCachedFlows, cacheKey, flows)
+}
+
+func (c *client) UninstallL7FlowExporterPodFlows(podKey string) error {
+	cacheKey := fmt.Sprintf("l7_%s", podKey)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.featurePodConnectivity.tcCachedFlows, cacheKey)
+}
+
 func (c *client) SendIGMPRemoteReportPacketOut(
 	dstMAC net.HardwareAddr,
 	dstIP net.IP,
@@ -1534,40 +1607,126 @@ func (c *client) UninstallMulticastGroup(groupID binding.GroupIDType) error {
 }
 
 // InstallMulticlusterNodeFlows installs flows to handle cross-cluster packets between a regular
-// Node and a local Gateway.
+// Node and the local Gateways.
 func (c *client) InstallMulticlusterNodeFlows(clusterID string,
-	peerConfigs map[*net.IPNet]net.IP,
-	tunnelPeerIP net.IP,
+	peerCIDRs []*net.IPNet,
+	remoteGatewayIPs []net.IP,
+	localGatewayIPs []net.IP,
 	enableStretchedNetworkPolicy bool) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 	cacheKey := fmt.Sprintf("cluster_%s", clusterID)
-	var flows []binding.Flow
 	localGatewayMAC := c.nodeConfig.GatewayConfig.MAC
-	for peerCIDR, remoteGatewayIP := range peerConfigs {
-		flows = append(flows, c.featureMulticluster.l3FwdFlowToRemoteGateway(localGatewayMAC, *peerCIDR, tunnelPeerIP, remoteGatewayIP, enableStretchedNetworkPolicy)...)
+	tunnelPeer, groupID, err := c.installMulticlusterTunnelPeerGroup(cacheKey, localGatewayIPs)
+	if err != nil {
+		return err
 	}
-	return c.modifyFlows(c.featureMulticluster.cachedFlows, cacheKey, flows)
+	// The local Gateways which have received the request packets need to be tracked only if there are multiple
+	// local Gateways.
+	trackReplyTunnel := len(localGatewayIPs) > 1
+	var flows []binding.Flow
+	for _, peerCIDR := range peerCIDRs {
+		flows = append(flows, c.featureMulticluster.l3FwdFlowToRemoteCIDR(localGatewayMAC, *peerCIDR, tunnelPeer, groupID))
+	}
+	for _, remoteGatewayIP := range remoteGatewayIPs {
+		flows = append(flows, c.featureMulticluster.l3FwdFlowsToRemoteGateway(localGatewayMAC, remoteGatewayIP, tunnelPeer, groupID, trackReplyTunnel, enableStretchedNetworkPolicy)...)
+		if trackReplyTunnel {
+			flows = append(flows, c.featureMulticluster.replyTunnelConntrackFlow(remoteGatewayIP))
+		}
+	}
+	if err := c.modifyFlows(c.featureMulticluster.cachedFlows, cacheKey, flows); err != nil {
+		return err
+	}
+	if tunnelPeer != nil {
+		// Remove the select group if it was installed for multiple local Gateways.
+		return c.uninstallMulticlusterTunnelPeerGroup(cacheKey)
+	}
+	return nil
 }
 
 // InstallMulticlusterGatewayFlows installs flows to handle cross-cluster packets between Gateways.
 func (c *client) InstallMulticlusterGatewayFlows(clusterID string,
-	peerConfigs map[*net.IPNet]net.IP,
-	tunnelPeerIP net.IP,
+	peerCIDRs []*net.IPNet,
+	remoteGatewayIPs []net.IP,
 	localGatewayIP net.IP,
+	trackReplyTunnel bool,
 	enableStretchedNetworkPolicy bool,
 ) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 	cacheKey := fmt.Sprintf("cluster_%s", clusterID)
-	var flows []binding.Flow
 	localGatewayMAC := c.nodeConfig.GatewayConfig.MAC
-	for peerCIDR, remoteGatewayIP := range peerConfigs {
-		flows = append(flows, c.featureMulticluster.l3FwdFlowToRemoteGateway(localGatewayMAC, *peerCIDR, tunnelPeerIP, remoteGatewayIP, enableStretchedNetworkPolicy)...)
+	tunnelPeer, groupID, err := c.installMulticlusterTunnelPeerGroup(cacheKey, remoteGatewayIPs)
+	if err != nil {
+		return err
+	}
+	var flows []binding.Flow
+	for _, peerCIDR := range peerCIDRs {
+		flows = append(flows, c.featureMulticluster.l3FwdFlowToRemoteCIDR(localGatewayMAC, *peerCIDR, tunnelPeer, groupID))
 		// Add SNAT flows to change cross-cluster packets' source IP to local Gateway IP.
 		flows = append(flows, c.featureMulticluster.snatConntrackFlows(*peerCIDR, localGatewayIP)...)
 	}
-	return c.modifyFlows(c.featureMulticluster.cachedFlows, cacheKey, flows)
+	for _, remoteGatewayIP := range remoteGatewayIPs {
+		// Reply packets are sent to the remote Gateway which has performed SNAT for the connection.
+		flows = append(flows, c.featureMulticluster.l3FwdFlowsToRemoteGateway(localGatewayMAC, remoteGatewayIP, remoteGatewayIP, 0, trackReplyTunnel, enableStretchedNetworkPolicy)...)
+		if trackReplyTunnel {
+			flows = append(flows, c.featureMulticluster.replyTunnelConntrackFlow(remoteGatewayIP))
+		}
+	}
+	if err := c.modifyFlows(c.featureMulticluster.cachedFlows, cacheKey, flows); err != nil {
+		return err
+	}
+	if tunnelPeer != nil {
+		// Remove the select group if it was installed for multiple remote Gateways.
+		return c.uninstallMulticlusterTunnelPeerGroup(cacheKey)
+	}
+	return nil
+}
+
+// installMulticlusterTunnelPeerGroup returns the tunnel peer if there is only one tunnel peer. Otherwise, it installs
+// or updates the select group which distributes cross-cluster connections across the tunnel peers, and returns the
+// group ID.
+func (c *client) installMulticlusterTunnelPeerGroup(cacheKey string, tunnelPeers []net.IP) (net.IP, binding.GroupIDType, error) {
+	if len(tunnelPeers) == 0 {
+		return nil, 0, fmt.Errorf("no tunnel peer is provided for %s", cacheKey)
+	}
+	if len(tunnelPeers) == 1 {
+		return tunnelPeers[0], 0, nil
+	}
+	var groupID binding.GroupIDType
+	gCache, installed := c.featureMulticluster.groupCache.Load(cacheKey)
+	if installed {
+		groupID = gCache.(binding.Group).GetID()
+	} else {
+		groupID = c.featureMulticluster.groupAllocator.Allocate()
+	}
+	group := c.featureMulticluster.tunnelPeerGroup(groupID, tunnelPeers)
+	if !installed {
+		if err := c.ofEntryOperations.AddOFEntries([]binding.OFEntry{group}); err != nil {
+			c.featureMulticluster.groupAllocator.Release(groupID)
+			return nil, 0, fmt.Errorf("error when installing Multicluster Group %d: %w", groupID, err)
+		}
+	} else {
+		if err := c.ofEntryOperations.ModifyOFEntries([]binding.OFEntry{group}); err != nil {
+			return nil, 0, fmt.Errorf("error when modifying Multicluster Group %d: %w", groupID, err)
+		}
+	}
+	c.featureMulticluster.groupCache.Store(cacheKey, group)
+	return nil, groupID, nil
+}
+
+func (c *client) uninstallMulticlusterTunnelPeerGroup(cacheKey string) error {
+	gCache, ok := c.featureMulticluster.groupCache.Load(cacheKey)
+	if !ok {
+		return nil
+	}
+	group := gCache.(binding.Group)
+	if err := c.ofEntryOperations.DeleteOFEntries([]binding.OFEntry{group}); err != nil {
+		return fmt.Errorf("error when deleting Multicluster Group %d: %w", group.GetID(), err)
+	}
+	c.featureMulticluster.groupCache.Delete(cacheKey)
+	c.featureMulticluster.groupAllocator.Release(group.GetID())
+	return nil
 }
 
 // InstallMulticlusterClassifierFlows adds the following flows:
@@ -1609,7 +1768,10 @@ func (c *client) UninstallMulticlusterFlows(clusterID string) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 	cacheKey := fmt.Sprintf("cluster_%s", clusterID)
-	return c.deleteFlows(c.featureMulticluster.cachedFlows, cacheKey)
+	if err := c.deleteFlows(c.featureMulticluster.cachedFlows, cacheKey); err != nil {
+		return err
+	}
+	return c.uninstallMulticlusterTunnelPeerGroup(cacheKey)
 }
 
 func (c *client) UninstallMulticlusterPodFlows(podIP string) error {
@@ -1642,7 +1804,8 @@ func getFlowModMessage(flow binding.Flow, op binding.OFOperation) *openflow15.Fl
 }
 
 // getMeterStats sends a multipart request to get all the meter statistics and
-// sets values for antrea_agent_ovs_meter_packet_dropped_count.
+// sets values for antrea_agent_ovs_meter_packet_dropped_count. It also records the
+// packets dropped by the meters of NetworkPolicy rules with RateLimit action.
 func (c *client) getMeterStats() {
 	handleMeterStatsReply := func(meterID int, packetCount int64) {
 		switch meterID {
@@ -1653,6 +1816,10 @@ func (c *client) getMeterStats() {
 		case PacketInMeterIDDNS:
 			metrics.OVSMeterPacketDroppedCount.WithLabelValues(metrics.LabelPacketInMeterDNSInterception).Set(float64(packetCount))
 		default:
+			if meterID >= rateLimitMeterIDBase && c.featureNetworkPolicy != nil {
+				c.featureNetworkPolicy.rateLimitMeterStats.Store(binding.MeterIDType(meterID), packetCount)
+				return
+			}
 			klog.V(4).InfoS("Received unexpected meterID", "meterID", meterID)
 		}
 	}
@@ -93,6 +93,7 @@ type clientOptions struct {
 	enableTrafficControl       bool
 	enableMulticluster         bool
 	enableL7NetworkPolicy      bool
+	enableL7FlowExporter       bool
 }
 
 type clientOptionsFn func(*clientOptions)
@@ -158,6 +159,11 @@ func enableL7NetworkPolicy(o *clientOptions) {
 	o.enableL7NetworkPolicy = true
 }
 
+func enableL7FlowExporter(o *clientOptions) {
+	o.enableL7NetworkPolicy = true
+	o.enableL7FlowExporter = true
+}
+
 func enableTrafficControl(o *clientOptions) {
 	o.enableTrafficControl = true
 }
@@ -399,6 +405,7 @@ func newFakeClientWithBridge(
 		o.enableProxy,
 		o.enableAntreaPolicy,
 		o.enableL7NetworkPolicy,
+		o.enableL7FlowExporter,
 		o.enableEgress,
 		o.enableEgressTrafficShaping,
 		false,
@@ -592,6 +599,8 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			ipsecTunOFPort:   uint32(100),
 			trafficEncapMode: config.TrafficEncapModeEncap,
 			expectedFlows: []string{
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp,nw_src=10.10.1.1,ip_dscp=63 actions=goto_table:ConntrackCommit",
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp,nw_src=192.168.77.101,ip_dscp=63 actions=goto_table:ConntrackCommit",
 				"cookie=0x1010000000000, table=ARPResponder, priority=200,arp,arp_tpa=10.10.1.1,arp_op=1 actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],set_field:aa:bb:cc:dd:ee:ff->eth_src,set_field:2->arp_op,move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],set_field:aa:bb:cc:dd:ee:ff->arp_sha,move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],set_field:10.10.1.1->arp_spa,IN_PORT",
 				"cookie=0x1010000000000, table=Classifier, priority=200,in_port=100 actions=set_field:0x1/0xf->reg0,set_field:0x200/0x200->reg0,goto_table:UnSNAT",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.10.1.0/24 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:ff->eth_dst,set_field:192.168.77.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
@@ -607,6 +616,8 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			ipsecTunOFPort:   uint32(100),
 			trafficEncapMode: config.TrafficEncapModeEncap,
 			expectedFlows: []string{
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp6,ipv6_src=fec0:10:10:1::1,ip_dscp=63 actions=goto_table:ConntrackCommit",
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp6,ipv6_src=fec0:192:168:77::101,ip_dscp=63 actions=goto_table:ConntrackCommit",
 				"cookie=0x1010000000000, table=Classifier, priority=200,in_port=100 actions=set_field:0x1/0xf->reg0,set_field:0x200/0x200->reg0,goto_table:UnSNAT",
 				"cookie=0x1040000000000, table=EgressMark, priority=210,ipv6,ipv6_dst=fec0:192:168:77::101 actions=set_field:0x20/0xf0->reg0,goto_table:L2ForwardingCalc",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ipv6,ipv6_dst=fec0:10:10:1::/80 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:ff->eth_dst,set_field:fec0:192:168:77::101->tun_ipv6_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
@@ -621,6 +632,7 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			tunnelPeerIPs:    &utilip.DualStackIPs{},
 			trafficEncapMode: config.TrafficEncapModeNoEncap,
 			expectedFlows: []string{
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp,nw_src=10.10.1.1,ip_dscp=63 actions=goto_table:ConntrackCommit",
 				"cookie=0x1010000000000, table=ARPResponder, priority=200,arp,arp_tpa=10.10.1.1,arp_op=1 actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],set_field:aa:bb:cc:dd:ee:ff->eth_src,set_field:2->arp_op,move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],set_field:aa:bb:cc:dd:ee:ff->arp_sha,move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],set_field:10.10.1.1->arp_spa,IN_PORT",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ip,reg4=0x0/0x100000,nw_dst=10.10.1.0/24 actions=set_field:0a:00:00:00:00:01->eth_dst,set_field:0x20/0xf0->reg0,goto_table:L3DecTTL",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ip,reg4=0x100000/0x100000,reg8=0x0/0xfff,nw_dst=10.10.1.0/24 actions=set_field:00:00:10:10:01:01->eth_dst,set_field:0x40/0xf0->reg0,goto_table:L3DecTTL",
@@ -635,6 +647,7 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			tunnelPeerIPs:    &utilip.DualStackIPs{},
 			trafficEncapMode: config.TrafficEncapModeNoEncap,
 			expectedFlows: []string{
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp,nw_src=10.10.1.1,ip_dscp=63 actions=goto_table:ConntrackCommit",
 				"cookie=0x1010000000000, table=ARPResponder, priority=200,arp,arp_tpa=10.10.1.1,arp_op=1 actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],set_field:aa:bb:cc:dd:ee:ff->eth_src,set_field:2->arp_op,move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],set_field:aa:bb:cc:dd:ee:ff->arp_sha,move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],set_field:10.10.1.1->arp_spa,IN_PORT",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ip,reg4=0x0/0x100000,nw_dst=10.10.1.0/24 actions=set_field:0a:00:00:00:00:01->eth_dst,set_field:0x20/0xf0->reg0,goto_table:L3DecTTL",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ip,reg4=0x100000/0x100000,reg8=0x0/0xfff,nw_dst=10.10.1.0/24 actions=set_field:00:00:10:10:01:01->eth_dst,set_field:0x40/0xf0->reg0,goto_table:L3DecTTL",
@@ -649,6 +662,7 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			tunnelPeerIPs:    &utilip.DualStackIPs{},
 			trafficEncapMode: config.TrafficEncapModeNoEncap,
 			expectedFlows: []string{
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp6,ipv6_src=fec0:10:10:1::1,ip_dscp=63 actions=goto_table:ConntrackCommit",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ipv6,reg4=0x0/0x100000,ipv6_dst=fec0:10:10:1::/80 actions=set_field:0a:00:00:00:00:01->eth_dst,set_field:0x20/0xf0->reg0,goto_table:L3DecTTL",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ipv6,reg4=0x100000/0x100000,reg8=0x0/0xfff,ipv6_dst=fec0:10:10:1::/80 actions=set_field:00:00:10:10:01:01->eth_dst,set_field:0x40/0xf0->reg0,goto_table:L3DecTTL",
 			},
@@ -662,6 +676,8 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			ipsecTunOFPort:   uint32(100),
 			trafficEncapMode: config.TrafficEncapModeEncap,
 			expectedFlows: []string{
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp,nw_src=10.10.1.1,ip_dscp=63 actions=goto_table:ConntrackCommit",
+				"cookie=0x1010000000000, table=IngressSecurityClassifier, priority=210,ct_state=-rpl+trk,tcp,nw_src=192.168.77.101,ip_dscp=63 actions=goto_table:ConntrackCommit",
 				"cookie=0x1010000000000, table=ARPResponder, priority=200,arp,arp_tpa=10.10.1.1,arp_op=1 actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],set_field:aa:bb:cc:dd:ee:ff->eth_src,set_field:2->arp_op,move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],set_field:aa:bb:cc:dd:ee:ff->arp_sha,move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],set_field:10.10.1.1->arp_spa,IN_PORT",
 				"cookie=0x1010000000000, table=Classifier, priority=200,in_port=100 actions=set_field:0x1/0xf->reg0,set_field:0x200/0x200->reg0,goto_table:UnSNAT",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.10.1.0/24 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:ff->eth_dst,set_field:192.168.77.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
@@ -1009,12 +1025,22 @@ func Test_client_GetPodFlowKeys(t *testing.T) {
 	assert.ElementsMatch(t, expectedFlowKeys, flowKeys)
 }
 
+type testWeightedEndpoint struct {
+	proxy.Endpoint
+	weight uint16
+}
+
+func (e *testWeightedEndpoint) GetWeight() uint16 {
+	return e.weight
+}
+
 func Test_client_InstallServiceGroup(t *testing.T) {
 	groupID := binding.GroupIDType(100)
 
 	testCases := []struct {
 		name                 string
 		withSessionAffinity  bool
+		hashSelection        bool
 		endpoints            []proxy.Endpoint
 		expectedGroup        string
 		deleteOFEntriesError error
@@ -1039,6 +1065,40 @@ func Test_client_InstallServiceGroup(t *testing.T) {
 				"bucket=bucket_id:0,weight:100,actions=set_field:0x4000000/0x4000000->reg4,set_field:0xfec00010001000000000000000000100->xxreg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT," +
 				"bucket=bucket_id:1,weight:100,actions=set_field:0xfec00010001000000000000000000101->xxreg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT",
 		},
+		{
+			name:          "IPv4 Endpoints,hash selection",
+			hashSelection: true,
+			endpoints: []proxy.Endpoint{
+				proxy.NewBaseEndpointInfo("10.10.0.100", "node1", "", 80, false, true, false, false, nil),
+				proxy.NewBaseEndpointInfo("10.10.0.101", "node2", "", 80, true, true, false, false, nil),
+			},
+			expectedGroup: "group_id=100,type=select," +
+				"selection_method=hash,fields(ip_src,ip_dst,nw_proto,tcp_src,tcp_dst,udp_src,udp_dst,sctp_src,sctp_dst)," +
+				"bucket=bucket_id:0,weight:100,actions=set_field:0x4000000/0x4000000->reg4,set_field:0xa0a0064->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT," +
+				"bucket=bucket_id:1,weight:100,actions=set_field:0xa0a0065->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT",
+		},
+		{
+			name:          "IPv6 Endpoints,hash selection",
+			hashSelection: true,
+			endpoints: []proxy.Endpoint{
+				proxy.NewBaseEndpointInfo("fec0:10:10::100", "node1", "", 80, false, true, false, false, nil),
+				proxy.NewBaseEndpointInfo("fec0:10:10::101", "node2", "", 80, true, true, false, false, nil),
+			},
+			expectedGroup: "group_id=100,type=select," +
+				"selection_method=hash,fields(ipv6_src,ipv6_dst,nw_proto,tcp_src,tcp_dst,udp_src,udp_dst,sctp_src,sctp_dst)," +
+				"bucket=bucket_id:0,weight:100,actions=set_field:0x4000000/0x4000000->reg4,set_field:0xfec00010001000000000000000000100->xxreg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT," +
+				"bucket=bucket_id:1,weight:100,actions=set_field:0xfec00010001000000000000000000101->xxreg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT",
+		},
+		{
+			name: "IPv4 weighted Endpoints",
+			endpoints: []proxy.Endpoint{
+				&testWeightedEndpoint{proxy.NewBaseEndpointInfo("10.10.0.100", "node1", "", 80, false, true, false, false, nil), 900},
+				&testWeightedEndpoint{proxy.NewBaseEndpointInfo("10.10.0.101", "node2", "", 80, true, true, false, false, nil), 100},
+			},
+			expectedGroup: "group_id=100,type=select," +
+				"bucket=bucket_id:0,weight:900,actions=set_field:0x4000000/0x4000000->reg4,set_field:0xa0a0064->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT," +
+				"bucket=bucket_id:1,weight:100,actions=set_field:0xa0a0065->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT",
+		},
 		{
 			name:                "IPv4 Endpoints,SessionAffinity",
 			withSessionAffinity: true,
@@ -1090,7 +1150,7 @@ func Test_client_InstallServiceGroup(t *testing.T) {
 
 			m.EXPECT().AddOFEntries(gomock.Any()).Return(nil).Times(1)
 			m.EXPECT().DeleteOFEntries(gomock.Any()).Return(tc.deleteOFEntriesError).Times(1)
-			assert.NoError(t, fc.InstallServiceGroup(groupID, tc.withSessionAffinity, tc.endpoints))
+			assert.NoError(t, fc.InstallServiceGroup(groupID, tc.withSessionAffinity, tc.hashSelection, tc.endpoints))
 			gCacheI, ok := fc.featureService.groupCache.Load(groupID)
 			require.True(t, ok)
 			group := getGroupFromCache(gCacheI.(binding.Group))
@@ -1128,8 +1188,8 @@ func Test_client_InstallEndpointFlows(t *testing.T) {
 				proxy.NewBaseEndpointInfo(ep2IPv4, "", "", 80, true, true, false, false, nil),
 			},
 			expectedFlows: []string{
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp,reg3=0xa0a0064,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.100:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp,reg3=0xa0a0065,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.101:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp,reg3=0xa0a0064,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65520,nat(dst=10.10.0.100:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp,reg3=0xa0a0065,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65520,nat(dst=10.10.0.101:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
 				"cookie=0x1030000000000, table=SNATMark, priority=190,ct_state=+new+trk,ip,nw_src=10.10.0.101,nw_dst=10.10.0.101 actions=ct(commit,table=SNAT,zone=65520,exec(set_field:0x20/0x20->ct_mark,set_field:0x40/0x40->ct_mark))",
 			},
 		},
@@ -1141,8 +1201,8 @@ func Test_client_InstallEndpointFlows(t *testing.T) {
 				proxy.NewBaseEndpointInfo(ep2IPv6, "", "", 80, true, true, false, false, nil),
 			},
 			expectedFlows: []string{
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000100 actions=ct(commit,table=AntreaPolicyEgressRule,zone=65510,nat(dst=[fec0:10:10::100]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000101 actions=ct(commit,table=AntreaPolicyEgressRule,zone=65510,nat(dst=[fec0:10:10::101]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000100 actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65510,nat(dst=[fec0:10:10::100]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000101 actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65510,nat(dst=[fec0:10:10::101]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
 				"cookie=0x1030000000000, table=SNATMark, priority=190,ct_state=+new+trk,ipv6,ipv6_src=fec0:10:10::101,ipv6_dst=fec0:10:10::101 actions=ct(commit,table=SNAT,zone=65510,exec(set_field:0x20/0x20->ct_mark,set_field:0x40/0x40->ct_mark))",
 			},
 		},
@@ -1154,8 +1214,8 @@ func Test_client_InstallEndpointFlows(t *testing.T) {
 				proxy.NewBaseEndpointInfo(ep2IPv4, "", "", 80, true, true, false, false, nil),
 			},
 			expectedFlows: []string{
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp,reg3=0xa0a0064,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.100:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp,reg3=0xa0a0065,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.101:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp,reg3=0xa0a0064,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65520,nat(dst=10.10.0.100:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp,reg3=0xa0a0065,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65520,nat(dst=10.10.0.101:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
 				"cookie=0x1030000000000, table=SNATMark, priority=190,ct_state=+new+trk,ip,nw_src=10.10.0.101,nw_dst=10.10.0.101 actions=ct(commit,table=SNAT,zone=65520,exec(set_field:0x20/0x20->ct_mark,set_field:0x40/0x40->ct_mark))",
 			},
 		},
@@ -1167,8 +1227,8 @@ func Test_client_InstallEndpointFlows(t *testing.T) {
 				proxy.NewBaseEndpointInfo(ep2IPv6, "", "", 80, true, true, false, false, nil),
 			},
 			expectedFlows: []string{
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000100 actions=ct(commit,table=AntreaPolicyEgressRule,zone=65510,nat(dst=[fec0:10:10::100]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000101 actions=ct(commit,table=AntreaPolicyEgressRule,zone=65510,nat(dst=[fec0:10:10::101]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000100 actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65510,nat(dst=[fec0:10:10::100]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000101 actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65510,nat(dst=[fec0:10:10::101]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
 				"cookie=0x1030000000000, table=SNATMark, priority=190,ct_state=+new+trk,ipv6,ipv6_src=fec0:10:10::101,ipv6_dst=fec0:10:10::101 actions=ct(commit,table=SNAT,zone=65510,exec(set_field:0x20/0x20->ct_mark,set_field:0x40/0x40->ct_mark))",
 			},
 		},
@@ -1180,8 +1240,8 @@ func Test_client_InstallEndpointFlows(t *testing.T) {
 				proxy.NewBaseEndpointInfo(ep2IPv4, "", "", 80, true, true, false, false, nil),
 			},
 			expectedFlows: []string{
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp,reg3=0xa0a0064,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.100:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp,reg3=0xa0a0065,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.101:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp,reg3=0xa0a0064,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65520,nat(dst=10.10.0.100:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp,reg3=0xa0a0065,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65520,nat(dst=10.10.0.101:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
 				"cookie=0x1030000000000, table=SNATMark, priority=190,ct_state=+new+trk,ip,nw_src=10.10.0.101,nw_dst=10.10.0.101 actions=ct(commit,table=SNAT,zone=65520,exec(set_field:0x20/0x20->ct_mark,set_field:0x40/0x40->ct_mark))",
 			},
 		},
@@ -1193,8 +1253,8 @@ func Test_client_InstallEndpointFlows(t *testing.T) {
 				proxy.NewBaseEndpointInfo(ep2IPv6, "", "", 80, true, true, false, false, nil),
 			},
 			expectedFlows: []string{
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000100 actions=ct(commit,table=AntreaPolicyEgressRule,zone=65510,nat(dst=[fec0:10:10::100]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
-				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000101 actions=ct(commit,table=AntreaPolicyEgressRule,zone=65510,nat(dst=[fec0:10:10::101]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000100 actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65510,nat(dst=[fec0:10:10::100]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,sctp6,reg4=0x20050/0x7ffff,xxreg3=0xfec00010001000000000000000000101 actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65510,nat(dst=[fec0:10:10::101]:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
 				"cookie=0x1030000000000, table=SNATMark, priority=190,ct_state=+new+trk,ipv6,ipv6_src=fec0:10:10::101,ipv6_dst=fec0:10:10::101 actions=ct(commit,table=SNAT,zone=65510,exec(set_field:0x20/0x20->ct_mark,set_field:0x40/0x40->ct_mark))",
 			},
 		},
@@ -1892,6 +1952,35 @@ func Test_client_SendTraceflowPacket(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv4 SCTP",
+			args: args{
+				Packet: binding.Packet{
+					SourceMAC:       srcMAC,
+					DestinationMAC:  dstMAC,
+					SourceIP:        net.ParseIP("1.2.3.4"),
+					DestinationIP:   net.ParseIP("1.2.3.5"),
+					IPProto:         132,
+					DestinationPort: 36412,
+					TTL:             64,
+				},
+			},
+		},
+		{
+			name: "IPv6 SCTP",
+			args: args{
+				Packet: binding.Packet{
+					IsIPv6:          true,
+					SourceMAC:       srcMAC,
+					DestinationMAC:  dstMAC,
+					SourceIP:        net.ParseIP("1111::4444"),
+					DestinationIP:   net.ParseIP("1111::5555"),
+					IPProto:         132,
+					DestinationPort: 36412,
+					TTL:             64,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2017,7 +2106,7 @@ func Test_client_setBasePacketOutBuilder(t *testing.T) {
 }
 
 func prepareSetBasePacketOutBuilder(ctrl *gomock.Controller, success bool) *client {
-	ofClient := NewClient(bridgeName, bridgeMgmtAddr, nodeiptest.NewFakeNodeIPChecker(), true, true, false, false, false, false, false, false, false, false, false, false, nil, false, defaultPacketInRate)
+	ofClient := NewClient(bridgeName, bridgeMgmtAddr, nodeiptest.NewFakeNodeIPChecker(), true, true, false, false, false, false, false, false, false, false, false, false, false, nil, false, defaultPacketInRate)
 	m := ovsoftest.NewMockBridge(ctrl)
 	ofClient.bridge = m
 	bridge := binding.OFBridge{}
@@ -2237,6 +2326,7 @@ func Test_client_SendPacketOut(t *testing.T) {
 
 func Test_client_InstallMulticastFlows(t *testing.T) {
 	multicastIPv4 := net.ParseIP("224.0.0.100")
+	multicastIPv6 := net.ParseIP("ff05::100")
 	groupID := binding.GroupIDType(101)
 
 	testCases := []struct {
@@ -2251,7 +2341,13 @@ func Test_client_InstallMulticastFlows(t *testing.T) {
 				"cookie=0x1050000000000, table=MulticastRouting, priority=200,ip,nw_dst=224.0.0.100 actions=group:101",
 			},
 		},
-		//TODO: IPv6 Multicast
+		{
+			name:        "IPv6 Multicast",
+			multicastIP: multicastIPv6,
+			expectedFlows: []string{
+				"cookie=0x1050000000000, table=MulticastRouting, priority=200,ipv6,ipv6_dst=ff05::100 actions=group:101",
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2277,28 +2373,104 @@ func Test_client_InstallMulticastFlows(t *testing.T) {
 	}
 }
 
-func Test_client_InstallMulticastRemoteReportFlows(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	m := oftest.NewMockOFEntryOperations(ctrl)
+func Test_client_InstallMulticastSourceFlows(t *testing.T) {
+	groupID := binding.GroupIDType(103)
 
-	fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeEncap, enableMulticast)
-	defer resetPipelines()
+	testCases := []struct {
+		name          string
+		multicastIP   net.IP
+		sourceIP      net.IP
+		expectedFlows []string
+	}{
+		{
+			name:        "IPv4 Multicast",
+			multicastIP: net.ParseIP("232.1.1.1"),
+			sourceIP:    net.ParseIP("10.10.0.1"),
+			expectedFlows: []string{
+				"cookie=0x1050000000000, table=MulticastRouting, priority=201,ip,nw_src=10.10.0.1,nw_dst=232.1.1.1 actions=group:103",
+			},
+		},
+		{
+			name:        "IPv6 Multicast",
+			multicastIP: net.ParseIP("ff35::100"),
+			sourceIP:    net.ParseIP("fec0:10:10::1"),
+			expectedFlows: []string{
+				"cookie=0x1050000000000, table=MulticastRouting, priority=201,ipv6,ipv6_src=fec0:10:10::1,ipv6_dst=ff35::100 actions=group:103",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := oftest.NewMockOFEntryOperations(ctrl)
+
+			fc := newFakeClient(m, true, true, config.K8sNode, config.TrafficEncapModeEncap, enableMulticast)
+			defer resetPipelines()
+
+			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+			m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+
+			cacheKey := fmt.Sprintf("multicast_%s_%s", tc.multicastIP.String(), tc.sourceIP.String())
+			assert.NoError(t, fc.InstallMulticastSourceFlows(tc.multicastIP, tc.sourceIP, groupID))
+			fCacheI, ok := fc.featureMulticast.cachedFlows.Load(cacheKey)
+			require.True(t, ok)
+			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
+
+			assert.NoError(t, fc.UninstallMulticastSourceFlows(tc.multicastIP, tc.sourceIP))
+			_, ok = fc.featureMulticast.cachedFlows.Load(cacheKey)
+			require.False(t, ok)
+		})
+	}
+}
 
+func Test_client_InstallMulticastRemoteReportFlows(t *testing.T) {
 	groupID := binding.GroupIDType(102)
-	expectedFlows := []string{
-		"cookie=0x1050000000000, table=Classifier, priority=210,ip,in_port=1,nw_dst=224.0.0.0/4 actions=set_field:0x1/0xf->reg0,goto_table:MulticastEgressRule",
-		"cookie=0x1050000000000, table=MulticastRouting, priority=210,igmp,in_port=4294967293 actions=group:102",
-		"cookie=0x1050000000000, table=Classifier, priority=200,in_port=4294967293 actions=goto_table:PipelineIPClassifier",
+	testCases := []struct {
+		name          string
+		enableIPv4    bool
+		enableIPv6    bool
+		expectedFlows []string
+	}{
+		{
+			name:       "IPv4",
+			enableIPv4: true,
+			expectedFlows: []string{
+				"cookie=0x1050000000000, table=Classifier, priority=210,ip,in_port=1,nw_dst=224.0.0.0/4 actions=set_field:0x1/0xf->reg0,goto_table:MulticastEgressRule",
+				"cookie=0x1050000000000, table=MulticastRouting, priority=210,igmp,in_port=4294967293 actions=group:102",
+				"cookie=0x1050000000000, table=Classifier, priority=200,in_port=4294967293 actions=goto_table:PipelineIPClassifier",
+			},
+		},
+		{
+			name:       "Dual-stack",
+			enableIPv4: true,
+			enableIPv6: true,
+			expectedFlows: []string{
+				"cookie=0x1050000000000, table=Classifier, priority=210,ip,in_port=1,nw_dst=224.0.0.0/4 actions=set_field:0x1/0xf->reg0,goto_table:MulticastEgressRule",
+				"cookie=0x1050000000000, table=Classifier, priority=210,ipv6,in_port=1,ipv6_dst=ff00::/8 actions=set_field:0x1/0xf->reg0,goto_table:MulticastEgressRule",
+				"cookie=0x1050000000000, table=MulticastRouting, priority=210,igmp,in_port=4294967293 actions=group:102",
+				"cookie=0x1050000000000, table=MulticastRouting, priority=210,icmp6,in_port=4294967293,icmp_type=143 actions=group:102",
+				"cookie=0x1050000000000, table=Classifier, priority=200,in_port=4294967293 actions=goto_table:IPv6",
+			},
+		},
 	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := oftest.NewMockOFEntryOperations(ctrl)
 
-	m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+			fc := newFakeClient(m, tc.enableIPv4, tc.enableIPv6, config.K8sNode, config.TrafficEncapModeEncap, enableMulticast)
+			defer resetPipelines()
 
-	cacheKey := "multicast_encap"
+			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
 
-	assert.NoError(t, fc.InstallMulticastRemoteReportFlows(groupID))
-	fCacheI, ok := fc.featureMulticast.cachedFlows.Load(cacheKey)
-	require.True(t, ok)
-	assert.ElementsMatch(t, expectedFlows, getFlowStrings(fCacheI))
+			cacheKey := "multicast_encap"
+
+			assert.NoError(t, fc.InstallMulticastRemoteReportFlows(groupID))
+			fCacheI, ok := fc.featureMulticast.cachedFlows.Load(cacheKey)
+			require.True(t, ok)
+			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
+		})
+	}
 }
 
 func Test_client_SendIGMPQueryPacketOut(t *testing.T) {
@@ -2464,6 +2636,35 @@ func Test_client_InstallTrafficControlReturnPortFlow(t *testing.T) {
 	require.False(t, ok)
 }
 
+func Test_client_InstallL7FlowExporterPodFlows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+
+	fc := newFakeClient(m, true, true, config.K8sNode, config.TrafficEncapModeEncap, enableL7FlowExporter)
+	defer resetPipelines()
+
+	podKey := "ns1/pod1"
+	podOFPort := uint32(50)
+	expectedFlows := []string{
+		"cookie=0x1010000000000, table=L7FlowExporterMark, priority=200,reg1=0x32 actions=set_field:0x10000000/0x10000000->reg4,goto_table:TrafficControl",
+		"cookie=0x1010000000000, table=L7FlowExporterMark, priority=200,in_port=50 actions=set_field:0x10000000/0x10000000->reg4,goto_table:TrafficControl",
+	}
+
+	m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+	m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+
+	cacheKey := fmt.Sprintf("l7_%s", podKey)
+
+	assert.NoError(t, fc.InstallL7FlowExporterPodFlows(podKey, podOFPort))
+	fCacheI, ok := fc.featurePodConnectivity.tcCachedFlows.Load(cacheKey)
+	require.True(t, ok)
+	assert.ElementsMatch(t, expectedFlows, getFlowStrings(fCacheI))
+
+	assert.NoError(t, fc.UninstallL7FlowExporterPodFlows(podKey))
+	_, ok = fc.featurePodConnectivity.tcCachedFlows.Load(cacheKey)
+	require.False(t, ok)
+}
+
 func Test_client_InstallMulticastGroup(t *testing.T) {
 	groupID := binding.GroupIDType(101)
 	localReceivers := []uint32{50, 100}
@@ -2541,24 +2742,45 @@ func Test_client_InstallMulticastGroup(t *testing.T) {
 func Test_client_InstallMulticlusterNodeFlows(t *testing.T) {
 	clusterID := "test_cluster"
 	_, peerServiceCIDRIPv4, _ := net.ParseCIDR("10.97.0.0/16")
-	tunnelPeerIPv4 := net.ParseIP("192.168.78.101")
+	remoteGatewayIPv4 := net.ParseIP("192.168.78.101")
+	localGatewayIPv4 := net.ParseIP("192.168.77.100")
+	localGatewayIPv4B := net.ParseIP("192.168.77.101")
 
 	testCases := []struct {
-		name          string
-		peerConfigs   map[*net.IPNet]net.IP
-		tunnelPeerIP  net.IP
-		expectedFlows []string
+		name             string
+		peerCIDRs        []*net.IPNet
+		remoteGatewayIPs []net.IP
+		localGatewayIPs  []net.IP
+		expectedFlows    []string
+		expectedGroup    string
 	}{
 		{
-			name:         "IPv4",
-			peerConfigs:  map[*net.IPNet]net.IP{peerServiceCIDRIPv4: tunnelPeerIPv4},
-			tunnelPeerIP: tunnelPeerIPv4,
+			name:             "IPv4",
+			peerCIDRs:        []*net.IPNet{peerServiceCIDRIPv4},
+			remoteGatewayIPs: []net.IP{remoteGatewayIPv4},
+			localGatewayIPs:  []net.IP{localGatewayIPv4},
 			expectedFlows: []string{
-				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
-				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
-				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.77.100->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.77.100->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.77.100->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
 			},
 		},
+		{
+			name:             "IPv4 multiple local Gateways",
+			peerCIDRs:        []*net.IPNet{peerServiceCIDRIPv4},
+			remoteGatewayIPs: []net.IP{remoteGatewayIPv4},
+			localGatewayIPs:  []net.IP{localGatewayIPv4, localGatewayIPv4B},
+			expectedFlows: []string{
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:0x10/0xf0->reg0,group:1",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,move:NXM_NX_CT_LABEL[96..127]->NXM_NX_TUN_IPV4_DST[],set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=201,ct_state=+rpl+trk,ct_label=0x0/0xffffffff000000000000000000000000,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:0x10/0xf0->reg0,group:1",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:0x10/0xf0->reg0,group:1",
+				"cookie=0x1060000000000, table=ConntrackCommit, priority=201,ct_state=+new+trk-snat,ct_mark=0x0/0x10,ip,reg0=0x1/0xf,nw_src=192.168.78.101 actions=ct(commit,table=Output,zone=65520,exec(move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3],move:NXM_NX_TUN_IPV4_SRC[]->NXM_NX_CT_LABEL[96..127]))",
+			},
+			expectedGroup: "group_id=1,type=select," +
+				"bucket=bucket_id:0,weight:100,actions=set_field:192.168.77.100->tun_dst,resubmit:L3DecTTL," +
+				"bucket=bucket_id:1,weight:100,actions=set_field:192.168.77.101->tun_dst,resubmit:L3DecTTL",
+		},
 		//TODO: IPv6
 	}
 	for _, tc := range testCases {
@@ -2571,16 +2793,27 @@ func Test_client_InstallMulticlusterNodeFlows(t *testing.T) {
 
 			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
 			m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+			if tc.expectedGroup != "" {
+				m.EXPECT().AddOFEntries(gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().DeleteOFEntries(gomock.Any()).Return(nil).Times(1)
+			}
 
-			assert.NoError(t, fc.InstallMulticlusterNodeFlows(clusterID, tc.peerConfigs, tc.tunnelPeerIP, true))
+			assert.NoError(t, fc.InstallMulticlusterNodeFlows(clusterID, tc.peerCIDRs, tc.remoteGatewayIPs, tc.localGatewayIPs, true))
 			cacheKey := fmt.Sprintf("cluster_%s", clusterID)
 			fCacheI, ok := fc.featureMulticluster.cachedFlows.Load(cacheKey)
 			require.True(t, ok)
 			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
+			gCacheI, ok := fc.featureMulticluster.groupCache.Load(cacheKey)
+			require.Equal(t, tc.expectedGroup != "", ok)
+			if ok {
+				assert.Equal(t, tc.expectedGroup, getGroupFromCache(gCacheI.(binding.Group)))
+			}
 
 			assert.NoError(t, fc.UninstallMulticlusterFlows(clusterID))
 			_, ok = fc.featureMulticluster.cachedFlows.Load(cacheKey)
 			require.False(t, ok)
+			_, ok = fc.featureMulticluster.groupCache.Load(cacheKey)
+			require.False(t, ok)
 		})
 	}
 }
@@ -2588,21 +2821,24 @@ func Test_client_InstallMulticlusterNodeFlows(t *testing.T) {
 func Test_client_InstallMulticlusterGatewayFlows(t *testing.T) {
 	clusterID := "test_cluster"
 	_, peerServiceCIDRIPv4, _ := net.ParseCIDR("10.97.0.0/16")
-	tunnelPeerIPv4 := net.ParseIP("192.168.78.101")
+	remoteGatewayIPv4 := net.ParseIP("192.168.78.101")
+	remoteGatewayIPv4B := net.ParseIP("192.168.78.102")
 	localGatewayIPv4 := net.ParseIP("192.168.77.100")
 
 	testCases := []struct {
-		name           string
-		peerConfigs    map[*net.IPNet]net.IP
-		tunnelPeerIP   net.IP
-		localGatewayIP net.IP
-		expectedFlows  []string
+		name             string
+		peerCIDRs        []*net.IPNet
+		remoteGatewayIPs []net.IP
+		localGatewayIP   net.IP
+		trackReplyTunnel bool
+		expectedFlows    []string
+		expectedGroup    string
 	}{
 		{
-			name:           "IPv4",
-			peerConfigs:    map[*net.IPNet]net.IP{peerServiceCIDRIPv4: tunnelPeerIPv4},
-			tunnelPeerIP:   tunnelPeerIPv4,
-			localGatewayIP: localGatewayIPv4,
+			name:             "IPv4",
+			peerCIDRs:        []*net.IPNet{peerServiceCIDRIPv4},
+			remoteGatewayIPs: []net.IP{remoteGatewayIPv4},
+			localGatewayIP:   localGatewayIPv4,
 			expectedFlows: []string{
 				"cookie=0x1060000000000, table=UnSNAT, priority=200,ip,nw_dst=192.168.77.100 actions=ct(table=ConntrackZone,zone=65521,nat)",
 				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
@@ -2612,6 +2848,30 @@ func Test_client_InstallMulticlusterGatewayFlows(t *testing.T) {
 				"cookie=0x1060000000000, table=SNAT, priority=200,ct_state=+new+trk,ip,nw_dst=10.97.0.0/16 actions=ct(commit,table=L2ForwardingCalc,zone=65521,nat(src=192.168.77.100))",
 			},
 		},
+		{
+			name:             "IPv4 multiple Gateways",
+			peerCIDRs:        []*net.IPNet{peerServiceCIDRIPv4},
+			remoteGatewayIPs: []net.IP{remoteGatewayIPv4, remoteGatewayIPv4B},
+			localGatewayIP:   localGatewayIPv4,
+			trackReplyTunnel: true,
+			expectedFlows: []string{
+				"cookie=0x1060000000000, table=UnSNAT, priority=200,ip,nw_dst=192.168.77.100 actions=ct(table=ConntrackZone,zone=65521,nat)",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:0x10/0xf0->reg0,group:1",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,move:NXM_NX_CT_LABEL[96..127]->NXM_NX_TUN_IPV4_DST[],set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=201,ct_state=+rpl+trk,ct_label=0x0/0xffffffff000000000000000000000000,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=ConntrackCommit, priority=201,ct_state=+new+trk-snat,ct_mark=0x0/0x10,ip,reg0=0x1/0xf,nw_src=192.168.78.101 actions=ct(commit,table=Output,zone=65520,exec(move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3],move:NXM_NX_TUN_IPV4_SRC[]->NXM_NX_CT_LABEL[96..127]))",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.102 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,move:NXM_NX_CT_LABEL[96..127]->NXM_NX_TUN_IPV4_DST[],set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=201,ct_state=+rpl+trk,ct_label=0x0/0xffffffff000000000000000000000000,ip,nw_dst=192.168.78.102 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.102->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.102 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.102->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=ConntrackCommit, priority=201,ct_state=+new+trk-snat,ct_mark=0x0/0x10,ip,reg0=0x1/0xf,nw_src=192.168.78.102 actions=ct(commit,table=Output,zone=65520,exec(move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3],move:NXM_NX_TUN_IPV4_SRC[]->NXM_NX_CT_LABEL[96..127]))",
+				"cookie=0x1060000000000, table=SNATMark, priority=210,ct_state=+new+trk,ip,nw_dst=10.97.0.0/16 actions=ct(commit,table=SNAT,zone=65520,exec(set_field:0x20/0x20->ct_mark))",
+				"cookie=0x1060000000000, table=SNAT, priority=200,ct_state=+new+trk,ip,nw_dst=10.97.0.0/16 actions=ct(commit,table=L2ForwardingCalc,zone=65521,nat(src=192.168.77.100))",
+			},
+			expectedGroup: "group_id=1,type=select," +
+				"bucket=bucket_id:0,weight:100,actions=set_field:192.168.78.101->tun_dst,resubmit:L3DecTTL," +
+				"bucket=bucket_id:1,weight:100,actions=set_field:192.168.78.102->tun_dst,resubmit:L3DecTTL",
+		},
 		//TODO: IPv6
 	}
 	for _, tc := range testCases {
@@ -2624,17 +2884,28 @@ func Test_client_InstallMulticlusterGatewayFlows(t *testing.T) {
 
 			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
 			m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+			if tc.expectedGroup != "" {
+				m.EXPECT().AddOFEntries(gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().DeleteOFEntries(gomock.Any()).Return(nil).Times(1)
+			}
 
 			cacheKey := fmt.Sprintf("cluster_%s", clusterID)
 
-			assert.NoError(t, fc.InstallMulticlusterGatewayFlows(clusterID, tc.peerConfigs, tc.tunnelPeerIP, tc.localGatewayIP, true))
+			assert.NoError(t, fc.InstallMulticlusterGatewayFlows(clusterID, tc.peerCIDRs, tc.remoteGatewayIPs, tc.localGatewayIP, tc.trackReplyTunnel, true))
 			fCacheI, ok := fc.featureMulticluster.cachedFlows.Load(cacheKey)
 			require.True(t, ok)
 			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
+			gCacheI, ok := fc.featureMulticluster.groupCache.Load(cacheKey)
+			require.Equal(t, tc.expectedGroup != "", ok)
+			if ok {
+				assert.Equal(t, tc.expectedGroup, getGroupFromCache(gCacheI.(binding.Group)))
+			}
 
 			assert.NoError(t, fc.UninstallMulticlusterFlows(clusterID))
 			_, ok = fc.featureMulticluster.cachedFlows.Load(cacheKey)
 			require.False(t, ok)
+			_, ok = fc.featureMulticluster.groupCache.Load(cacheKey)
+			require.False(t, ok)
 		})
 	}
 }
@@ -2716,7 +2987,9 @@ func Test_client_ReplayFlows(t *testing.T) {
 	_, peerServiceCIDRIPv4, _ := net.ParseCIDR("10.97.0.0/16")
 	tunnelPeerIP := net.ParseIP("192.168.78.101")
 	localGatewayMAC, _ := net.ParseMAC("0a:00:00:00:00:01")
-	addFlowInCache(fc.featureMulticluster.cachedFlows, "multiClusterFlows", fc.featureMulticluster.l3FwdFlowToRemoteGateway(localGatewayMAC, *peerServiceCIDRIPv4, tunnelPeerIP, tunnelPeerIP, true))
+	multiclusterFlows := []binding.Flow{fc.featureMulticluster.l3FwdFlowToRemoteCIDR(localGatewayMAC, *peerServiceCIDRIPv4, tunnelPeerIP, 0)}
+	multiclusterFlows = append(multiclusterFlows, fc.featureMulticluster.l3FwdFlowsToRemoteGateway(localGatewayMAC, tunnelPeerIP, tunnelPeerIP, 0, false, true)...)
+	addFlowInCache(fc.featureMulticluster.cachedFlows, "multiClusterFlows", multiclusterFlows)
 	replayedFlows = append(replayedFlows,
 		"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
 		"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
@@ -2729,8 +3002,10 @@ func Test_client_ReplayFlows(t *testing.T) {
 		"group_id=2,type=all,bucket=bucket_id:0,actions=resubmit:EgressMetric,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
 		"group_id=3,type=all,bucket=bucket_id:0,actions=resubmit:IngressRule,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
 		"group_id=4,type=all,bucket=bucket_id:0,actions=resubmit:IngressMetric,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
-		"group_id=5,type=all,bucket=bucket_id:0,actions=resubmit:MulticastEgressMetric,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
-		"group_id=6,type=all,bucket=bucket_id:0,actions=resubmit:MulticastIngressMetric,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
+		"group_id=5,type=all,bucket=bucket_id:0,actions=resubmit:AntreaPolicyEgressRule,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
+		"group_id=6,type=all,bucket=bucket_id:0,actions=resubmit:AntreaPolicyIngressRule,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
+		"group_id=7,type=all,bucket=bucket_id:0,actions=resubmit:MulticastEgressMetric,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
+		"group_id=8,type=all,bucket=bucket_id:0,actions=resubmit:MulticastIngressMetric,bucket=bucket_id:1,actions=set_field:0x400000/0x600000->reg0,resubmit:Output",
 	}
 	ruleID := uint32(15)
 	priority200 = uint16(200)
@@ -2776,7 +3051,7 @@ func Test_client_ReplayFlows(t *testing.T) {
 	// Feature Service replays flows.
 	addFlowInCache(fc.featureService.cachedFlows, "endpointFlow", []binding.Flow{fc.featureService.endpointDNATFlow(podIP, uint16(80), binding.ProtocolTCP)})
 	replayedFlows = append(replayedFlows,
-		"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp,reg3=0xa0a0042,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.66:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+		"cookie=0x1030000000000, table=EndpointDNAT, priority=200,tcp,reg3=0xa0a0042,reg4=0x20050/0x7ffff actions=ct(commit,table=AntreaPolicyEgressAudit,zone=65520,nat(dst=10.10.0.66:80),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
 	)
 
 	expectedFlows = append(expectedFlows, replayedFlows...)
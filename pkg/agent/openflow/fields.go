@@ -147,6 +147,9 @@ var (
 	// the Node's traffic is forwarded to OVS. And even if there is no masquerade rule, there should be no problem to
 	// consider the packet external sourced as the other IPs are routable externally anyway.
 	FromExternalRegMark = binding.NewOneBitRegMark(4, 27)
+	// reg4[28]: Mark to indicate that the packet should be mirrored to the L7 engine, to export the L7 metadata of its
+	// connection.
+	L7FlowExporterRegMark = binding.NewOneBitRegMark(4, 28)
 
 	// reg5(NXM_NX_REG5)
 	// Field to cache the Egress conjunction ID hit by TraceFlow packet.
@@ -213,7 +216,8 @@ var (
 	// CTMark[7]: Mark to indicate the connection should be redirected to an application-aware engine. This mark is only
 	// for L7 NetworkPolicy.
 	// This CT mark is used in CtZone / CtZoneV6.
-	L7NPRedirectCTMark = binding.NewOneBitCTMark(7)
+	L7NPRedirectCTMark    = binding.NewOneBitCTMark(7)
+	NotL7NPRedirectCTMark = binding.NewOneBitZeroCTMark(7)
 )
 
 // Fields using CT label.
@@ -226,4 +230,7 @@ var (
 
 	// Field to store the VLAN ID allocated for a L7 NetworkPolicy rule.
 	L7NPRuleVlanIDCTLabel = binding.NewCTLabel(64, 75)
+
+	// Field to store the tunnel source IP of a cross-cluster connection received from a multi-cluster Gateway.
+	MulticlusterTunnelSrcCTLabel = binding.NewCTLabel(96, 127)
 )
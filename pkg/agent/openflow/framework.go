@@ -195,14 +195,14 @@ func (f *featurePodConnectivity) getRequiredTables() []*Table {
 			tables = append(tables,
 				ARPSpoofGuardTable,
 				ARPResponderTable)
-			if f.enableMulticast {
-				tables = append(tables, PipelineIPClassifierTable)
-			}
 			if f.connectUplinkToBridge {
 				tables = append(tables, VLANTable)
 			}
 		}
 	}
+	if f.enableMulticast {
+		tables = append(tables, PipelineIPClassifierTable)
+	}
 	if f.enableTrafficControl {
 		tables = append(tables, TrafficControlTable)
 	}
@@ -222,11 +222,16 @@ func (f *featureNetworkPolicy) getRequiredTables() []*Table {
 	}
 	if f.enableAntreaPolicy {
 		tables = append(tables,
+			AntreaPolicyEgressAuditTable,
 			AntreaPolicyEgressRuleTable,
+			AntreaPolicyIngressAuditTable,
 			AntreaPolicyIngressRuleTable,
 		)
 		if f.enableL7NetworkPolicy {
 			tables = append(tables, TrafficControlTable) // For L7 NetworkPolicy.
+			if f.enableL7FlowExporter {
+				tables = append(tables, L7FlowExporterMarkTable)
+			}
 		}
 		if f.enableMulticast {
 			tables = append(tables,
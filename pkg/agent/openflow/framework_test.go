@@ -69,6 +69,7 @@ func newTestFeatureNetworkPolicy(nodeType config.NodeType, options ...clientOpti
 		enableMulticast:       o.enableMulticast,
 		enableAntreaPolicy:    o.enableAntreaPolicy,
 		enableL7NetworkPolicy: o.enableL7NetworkPolicy,
+		enableL7FlowExporter:  o.enableL7FlowExporter,
 	}
 }
 
@@ -132,6 +133,7 @@ func TestBuildPipeline(t *testing.T) {
 					SessionAffinityTable,
 					ServiceLBTable,
 					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -142,6 +144,7 @@ func TestBuildPipeline(t *testing.T) {
 					SNATMarkTable,
 					SNATTable,
 					L2ForwardingCalcTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -179,6 +182,7 @@ func TestBuildPipeline(t *testing.T) {
 					SessionAffinityTable,
 					ServiceLBTable,
 					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -189,6 +193,7 @@ func TestBuildPipeline(t *testing.T) {
 					SNATMarkTable,
 					SNATTable,
 					L2ForwardingCalcTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -221,6 +226,7 @@ func TestBuildPipeline(t *testing.T) {
 					SessionAffinityTable,
 					ServiceLBTable,
 					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -231,6 +237,7 @@ func TestBuildPipeline(t *testing.T) {
 					SNATMarkTable,
 					SNATTable,
 					L2ForwardingCalcTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -263,6 +270,7 @@ func TestBuildPipeline(t *testing.T) {
 					SessionAffinityTable,
 					ServiceLBTable,
 					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -274,6 +282,7 @@ func TestBuildPipeline(t *testing.T) {
 					SNATTable,
 					L2ForwardingCalcTable,
 					TrafficControlTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -307,6 +316,7 @@ func TestBuildPipeline(t *testing.T) {
 					SessionAffinityTable,
 					ServiceLBTable,
 					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -318,6 +328,53 @@ func TestBuildPipeline(t *testing.T) {
 					SNATTable,
 					L2ForwardingCalcTable,
 					TrafficControlTable,
+					AntreaPolicyIngressAuditTable,
+					AntreaPolicyIngressRuleTable,
+					IngressRuleTable,
+					IngressDefaultTable,
+					IngressMetricTable,
+					ConntrackCommitTable,
+					OutputTable,
+				},
+			},
+		},
+		{
+			name:    "K8s Node, IPv4 only, with L7FlowExporter enabled",
+			ipStack: ipv6Only,
+			features: []feature{
+				newTestFeaturePodConnectivity(ipStackMap[ipv4Only]),
+				newTestFeatureNetworkPolicy(config.K8sNode, enableL7FlowExporter),
+				newTestFeatureService(),
+				newTestFeatureEgress(),
+			},
+			expectedTables: map[binding.PipelineID][]*Table{
+				pipelineRoot: {
+					PipelineRootClassifierTable,
+				},
+				pipelineIP: {
+					ClassifierTable,
+					SpoofGuardTable,
+					UnSNATTable,
+					ConntrackTable,
+					ConntrackStateTable,
+					PreRoutingClassifierTable,
+					SessionAffinityTable,
+					ServiceLBTable,
+					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
+					AntreaPolicyEgressRuleTable,
+					EgressRuleTable,
+					EgressDefaultTable,
+					EgressMetricTable,
+					L3ForwardingTable,
+					EgressMarkTable,
+					L3DecTTLTable,
+					SNATMarkTable,
+					SNATTable,
+					L2ForwardingCalcTable,
+					L7FlowExporterMarkTable,
+					TrafficControlTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -386,6 +443,7 @@ func TestBuildPipeline(t *testing.T) {
 					ConntrackTable,
 					ConntrackStateTable,
 					DNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -393,6 +451,7 @@ func TestBuildPipeline(t *testing.T) {
 					L3ForwardingTable,
 					L3DecTTLTable,
 					L2ForwardingCalcTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -430,6 +489,7 @@ func TestBuildPipeline(t *testing.T) {
 					SessionAffinityTable,
 					ServiceLBTable,
 					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -440,6 +500,7 @@ func TestBuildPipeline(t *testing.T) {
 					SNATMarkTable,
 					SNATTable,
 					L2ForwardingCalcTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -477,6 +538,7 @@ func TestBuildPipeline(t *testing.T) {
 					SessionAffinityTable,
 					ServiceLBTable,
 					EndpointDNATTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
@@ -486,6 +548,7 @@ func TestBuildPipeline(t *testing.T) {
 					SNATMarkTable,
 					SNATTable,
 					L2ForwardingCalcTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -526,12 +589,14 @@ func TestBuildPipeline(t *testing.T) {
 					ConntrackTable,
 					ConntrackStateTable,
 					EgressSecurityClassifierTable,
+					AntreaPolicyEgressAuditTable,
 					AntreaPolicyEgressRuleTable,
 					EgressRuleTable,
 					EgressDefaultTable,
 					EgressMetricTable,
 					L2ForwardingCalcTable,
 					IngressSecurityClassifierTable,
+					AntreaPolicyIngressAuditTable,
 					AntreaPolicyIngressRuleTable,
 					IngressRuleTable,
 					IngressDefaultTable,
@@ -60,26 +60,37 @@ func newFeatureMulticast(cookieAllocator cookie.Allocator, ipProtocols []binding
 	}
 }
 
-func multicastPipelineClassifyFlow(cookieID uint64, pipeline binding.Pipeline) binding.Flow {
+func multicastPipelineClassifyFlows(cookieID uint64, ipProtocols []binding.Protocol, pipeline binding.Pipeline) []binding.Flow {
 	targetTable := pipeline.GetFirstTable()
-	return PipelineIPClassifierTable.ofTable.BuildFlow(priorityHigh).
-		Cookie(cookieID).
-		MatchProtocol(binding.ProtocolIP).
-		MatchDstIPNet(*types.McastCIDR).
-		Action().ResubmitToTables(targetTable.GetID()).
-		Done()
+	var flows []binding.Flow
+	for _, ipProtocol := range ipProtocols {
+		flows = append(flows, PipelineIPClassifierTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(ipProtocol).
+			MatchDstIPNet(*multicastCIDR(ipProtocol)).
+			Action().ResubmitToTables(targetTable.GetID()).
+			Done())
+	}
+	return flows
+}
+
+func multicastCIDR(ipProtocol binding.Protocol) *net.IPNet {
+	if ipProtocol == binding.ProtocolIPv6 {
+		return types.IPv6McastCIDR
+	}
+	return types.McastCIDR
 }
 
 func (f *featureMulticast) initFlows() []*openflow15.FlowMod {
 	// Install flows to send the IGMP report messages to Antrea Agent.
 	flows := f.igmpPktInFlows()
 	// Install flow to forward the IGMP query messages to all local Pods.
-	flows = append(flows, f.externalMulticastReceiverFlow())
+	flows = append(flows, f.externalMulticastReceiverFlows()...)
 	// Install flows to forward the multicast traffic to antrea-gw0 if no local Pods have joined in the group, and this
 	// is to ensure local Pods can access the external multicast receivers.
 	flows = append(flows, f.multicastSkipIGMPMetricFlows()...)
 	if f.enableAntreaPolicy {
-		flows = append(flows, f.igmpEgressFlow())
+		flows = append(flows, f.igmpEgressFlows()...)
 	}
 	// Install flows to output multicast packets.
 	flows = append(flows, f.multicastOutputFlows()...)
@@ -148,13 +159,28 @@ func (f *featureMulticast) multicastOutputFlows() []binding.Flow {
 
 func (f *featureMulticast) multicastSkipIGMPMetricFlows() []binding.Flow {
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
-	flows := make([]binding.Flow, 0, 2)
+	var flows []binding.Flow
 	for _, t := range []*Table{MulticastIngressPodMetricTable, MulticastEgressPodMetricTable} {
-		flows = append(flows, t.ofTable.BuildFlow(priorityHigh).
-			Cookie(cookieID).
-			MatchProtocol(binding.ProtocolIGMP).
-			Action().NextTable().
-			Done())
+		for _, ipProtocol := range f.ipProtocols {
+			switch ipProtocol {
+			case binding.ProtocolIP:
+				flows = append(flows, t.ofTable.BuildFlow(priorityHigh).
+					Cookie(cookieID).
+					MatchProtocol(binding.ProtocolIGMP).
+					Action().NextTable().
+					Done())
+			case binding.ProtocolIPv6:
+				// MLD queries are also skipped, like IGMP queries.
+				for _, mldType := range append([]uint8{mldQueryType}, mldReportTypes...) {
+					flows = append(flows, t.ofTable.BuildFlow(priorityHigh).
+						Cookie(cookieID).
+						MatchProtocol(binding.ProtocolICMPv6).
+						MatchICMPv6Type(mldType).
+						Action().NextTable().
+						Done())
+				}
+			}
+		}
 	}
 	return flows
 }
@@ -174,7 +200,7 @@ func (f *featureMulticast) multicastPodMetricFlows(podIP net.IP, podOFPort uint3
 		// It matches TargetOFPortField with the OFPort of a multicast receiver Pod.
 		MulticastIngressPodMetricTable.ofTable.BuildFlow(priorityNormal).
 			Cookie(f.cookieAllocator.Request(f.category).Raw()).
-			MatchProtocol(binding.ProtocolIP).
+			MatchProtocol(ipProtocol).
 			MatchRegFieldWithValue(TargetOFPortField, podOFPort).
 			Action().NextTable().
 			Done(),
@@ -201,31 +227,39 @@ func (f *featureMulticast) replayMeters() []binding.OFEntry {
 }
 
 func (f *featureMulticast) multicastRemoteReportFlows(groupID binding.GroupIDType, firstMulticastTable binding.Table) []binding.Flow {
-	return []binding.Flow{
-		// This flow outputs the IGMP report message sent from Antrea Agent to an OpenFlow group which is expected to
-		// broadcast to all the other Nodes in the cluster. The multicast groups in side the IGMP report message
-		// include the ones local Pods have joined in.
-		MulticastRoutingTable.ofTable.BuildFlow(priorityHigh).
-			Cookie(f.cookieAllocator.Request(f.category).Raw()).
-			MatchProtocol(binding.ProtocolIGMP).
-			MatchInPort(openflow15.P_CONTROLLER).
-			Action().Group(groupID).
-			Done(),
+	flows := []binding.Flow{
 		// This flow ensures the IGMP report message sent from Antrea Agent to bypass the check in SpoofGuardTable.
 		ClassifierTable.ofTable.BuildFlow(priorityNormal).
 			Cookie(f.cookieAllocator.Request(f.category).Raw()).
 			MatchInPort(openflow15.P_CONTROLLER).
 			Action().GotoTable(SpoofGuardTable.GetNext()).
 			Done(),
-		// This flow ensures the multicast packet sent from a different Node via the tunnel port to enter Multicast
-		// pipeline.
-		ClassifierTable.ofTable.BuildFlow(priorityHigh).
-			Cookie(f.cookieAllocator.Request(f.category).Raw()).
-			MatchInPort(f.tunnelPort).
-			MatchProtocol(binding.ProtocolIP).
-			MatchDstIPNet(*types.McastCIDR).
-			Action().LoadRegMark(FromTunnelRegMark).
-			Action().GotoTable(firstMulticastTable.GetID()).
+	}
+	for _, ipProtocol := range f.ipProtocols {
+		// This flow outputs the IGMP report message sent from Antrea Agent to an OpenFlow group which is expected to
+		// broadcast to all the other Nodes in the cluster. The multicast groups in side the IGMP report message
+		// include the ones local Pods have joined in. For IPv6, the MLDv2 report message is matched.
+		reportFlowBuilder := MulticastRoutingTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(f.cookieAllocator.Request(f.category).Raw())
+		if ipProtocol == binding.ProtocolIPv6 {
+			reportFlowBuilder = reportFlowBuilder.MatchProtocol(binding.ProtocolICMPv6).MatchICMPv6Type(mldv2ReportType)
+		} else {
+			reportFlowBuilder = reportFlowBuilder.MatchProtocol(binding.ProtocolIGMP)
+		}
+		flows = append(flows, reportFlowBuilder.
+			MatchInPort(openflow15.P_CONTROLLER).
+			Action().Group(groupID).
 			Done(),
+			// This flow ensures the multicast packet sent from a different Node via the tunnel port to enter Multicast
+			// pipeline.
+			ClassifierTable.ofTable.BuildFlow(priorityHigh).
+				Cookie(f.cookieAllocator.Request(f.category).Raw()).
+				MatchInPort(f.tunnelPort).
+				MatchProtocol(ipProtocol).
+				MatchDstIPNet(*multicastCIDR(ipProtocol)).
+				Action().LoadRegMark(FromTunnelRegMark).
+				Action().GotoTable(firstMulticastTable.GetID()).
+				Done())
 	}
+	return flows
 }
@@ -46,6 +46,47 @@ func multicastInitFlows(isEncap bool) []string {
 	}
 }
 
+// multicastIPv6InitFlows returns the flows installed only for IPv6 multicast.
+func multicastIPv6InitFlows(isEncap bool) []string {
+	flows := []string{
+		"cookie=0x1050000000000, table=MulticastEgressRule, priority=64990,icmp6,reg0=0x3/0xf,icmp_type=131 actions=goto_table:MulticastRouting",
+		"cookie=0x1050000000000, table=MulticastEgressRule, priority=64990,icmp6,reg0=0x3/0xf,icmp_type=132 actions=goto_table:MulticastRouting",
+		"cookie=0x1050000000000, table=MulticastEgressRule, priority=64990,icmp6,reg0=0x3/0xf,icmp_type=143 actions=goto_table:MulticastRouting",
+		"cookie=0x1050000000000, table=MulticastEgressPodMetric, priority=210,icmp6,icmp_type=130 actions=goto_table:MulticastRouting",
+		"cookie=0x1050000000000, table=MulticastEgressPodMetric, priority=210,icmp6,icmp_type=131 actions=goto_table:MulticastRouting",
+		"cookie=0x1050000000000, table=MulticastEgressPodMetric, priority=210,icmp6,icmp_type=132 actions=goto_table:MulticastRouting",
+		"cookie=0x1050000000000, table=MulticastEgressPodMetric, priority=210,icmp6,icmp_type=143 actions=goto_table:MulticastRouting",
+		"cookie=0x1050000000000, table=MulticastRouting, priority=210,icmp6,reg0=0x3/0xf,icmp_type=131 actions=controller(id=32776,reason=no_match,userdata=03,max_len=65535)",
+		"cookie=0x1050000000000, table=MulticastRouting, priority=210,icmp6,reg0=0x3/0xf,icmp_type=132 actions=controller(id=32776,reason=no_match,userdata=03,max_len=65535)",
+		"cookie=0x1050000000000, table=MulticastRouting, priority=210,icmp6,reg0=0x3/0xf,icmp_type=143 actions=controller(id=32776,reason=no_match,userdata=03,max_len=65535)",
+		"cookie=0x1050000000000, table=MulticastRouting, priority=190,ipv6 actions=set_field:0x200000/0x600000->reg0,set_field:0x2->reg1,goto_table:MulticastOutput",
+		"cookie=0x1050000000000, table=MulticastIngressPodMetric, priority=210,icmp6,icmp_type=130 actions=goto_table:MulticastOutput",
+		"cookie=0x1050000000000, table=MulticastIngressPodMetric, priority=210,icmp6,icmp_type=131 actions=goto_table:MulticastOutput",
+		"cookie=0x1050000000000, table=MulticastIngressPodMetric, priority=210,icmp6,icmp_type=132 actions=goto_table:MulticastOutput",
+		"cookie=0x1050000000000, table=MulticastIngressPodMetric, priority=210,icmp6,icmp_type=143 actions=goto_table:MulticastOutput",
+	}
+	if isEncap {
+		flows = append(flows,
+			"cookie=0x1050000000000, table=MulticastRouting, priority=210,icmp6,reg0=0x1/0xf,icmp_type=131 actions=controller(id=32776,reason=no_match,userdata=03,max_len=65535)",
+			"cookie=0x1050000000000, table=MulticastRouting, priority=210,icmp6,reg0=0x1/0xf,icmp_type=132 actions=controller(id=32776,reason=no_match,userdata=03,max_len=65535)",
+			"cookie=0x1050000000000, table=MulticastRouting, priority=210,icmp6,reg0=0x1/0xf,icmp_type=143 actions=controller(id=32776,reason=no_match,userdata=03,max_len=65535)",
+		)
+	}
+	return flows
+}
+
+func multicastIPv6OnlyInitFlows(isEncap bool) []string {
+	flows := multicastIPv6InitFlows(isEncap)
+	flows = append(flows, "cookie=0x1050000000000, table=MulticastOutput, priority=200,reg0=0x200000/0x600000 actions=output:NXM_NX_REG1[]")
+	if isEncap {
+		flows = append(flows,
+			"cookie=0x1050000000000, table=MulticastOutput, priority=210,reg0=0x200001/0x60000f,reg1=0x2 actions=drop",
+			"cookie=0x1050000000000, table=MulticastOutput, priority=210,reg0=0x200002/0x60000f,reg1=0x1 actions=drop",
+		)
+	}
+	return flows
+}
+
 func Test_featureMulticast_initFlows(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -69,6 +110,28 @@ func Test_featureMulticast_initFlows(t *testing.T) {
 			clientOptions:    []clientOptionsFn{enableMulticast},
 			expectedFlows:    multicastInitFlows(false),
 		},
+		{
+			name:             "IPv6,Encap",
+			enableIPv6:       true,
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			clientOptions:    []clientOptionsFn{enableMulticast},
+			expectedFlows:    multicastIPv6OnlyInitFlows(true),
+		},
+		{
+			name:             "IPv6,NoEncap",
+			enableIPv6:       true,
+			trafficEncapMode: config.TrafficEncapModeNoEncap,
+			clientOptions:    []clientOptionsFn{enableMulticast},
+			expectedFlows:    multicastIPv6OnlyInitFlows(false),
+		},
+		{
+			name:             "Dual-stack,Encap",
+			enableIPv4:       true,
+			enableIPv6:       true,
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			clientOptions:    []clientOptionsFn{enableMulticast},
+			expectedFlows:    append(multicastInitFlows(true), multicastIPv6InitFlows(true)...),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -16,6 +16,7 @@ package openflow
 
 import (
 	"net"
+	"sync"
 
 	"antrea.io/libOpenflow/openflow15"
 
@@ -40,13 +41,18 @@ type featureMulticluster struct {
 	ipProtocols     []binding.Protocol
 	dnatCtZones     map[binding.Protocol]int
 	snatCtZones     map[binding.Protocol]int
+	bridge          binding.Bridge
+	groupAllocator  GroupAllocator
+	// groupCache saves the select groups which distribute cross-cluster connections across multiple tunnel peers.
+	// The key is the cache key of the peer cluster.
+	groupCache sync.Map
 }
 
 func (f *featureMulticluster) getFeatureName() string {
 	return "Multicluster"
 }
 
-func newFeatureMulticluster(cookieAllocator cookie.Allocator, ipProtocols []binding.Protocol) *featureMulticluster {
+func newFeatureMulticluster(cookieAllocator cookie.Allocator, ipProtocols []binding.Protocol, bridge binding.Bridge, grpAllocator GroupAllocator) *featureMulticluster {
 	snatCtZones := make(map[binding.Protocol]int)
 	dnatCtZones := make(map[binding.Protocol]int)
 	snatCtZones[ipProtocols[0]] = SNATCtZone
@@ -59,6 +65,8 @@ func newFeatureMulticluster(cookieAllocator cookie.Allocator, ipProtocols []bind
 		ipProtocols:     ipProtocols,
 		snatCtZones:     snatCtZones,
 		dnatCtZones:     dnatCtZones,
+		bridge:          bridge,
+		groupAllocator:  grpAllocator,
 	}
 }
 
@@ -75,70 +83,155 @@ func (f *featureMulticluster) initGroups() []binding.OFEntry {
 }
 
 func (f *featureMulticluster) replayGroups() []binding.OFEntry {
-	return nil
+	var groups []binding.OFEntry
+	f.groupCache.Range(func(id, value interface{}) bool {
+		group := value.(binding.Group)
+		group.Reset()
+		groups = append(groups, group)
+		return true
+	})
+	return groups
 }
 
 func (f *featureMulticluster) replayMeters() []binding.OFEntry {
 	return nil
 }
 
-func (f *featureMulticluster) l3FwdFlowToRemoteGateway(
+// tunnelPeerGroup generates a select group to distribute cross-cluster connections across multiple tunnel peers. The
+// bucket is selected by hashing the packet, so all packets of a connection are sent to the same tunnel peer.
+func (f *featureMulticluster) tunnelPeerGroup(groupID binding.GroupIDType, tunnelPeers []net.IP) binding.Group {
+	group := f.bridge.NewGroup(groupID)
+	for _, tunnelPeer := range tunnelPeers {
+		group = group.Bucket().Weight(100).
+			SetTunnelDst(tunnelPeer).
+			ResubmitToTable(L3DecTTLTable.GetID()).
+			Done()
+	}
+	return group
+}
+
+// forwardToTunnelPeer adds the actions to forward cross-cluster packets to the tunnel peer. If tunnelPeer is nil, the
+// packets are forwarded to one of the tunnel peers in the select group.
+func forwardToTunnelPeer(builder binding.FlowBuilder, tunnelPeer net.IP, groupID binding.GroupIDType) binding.Flow {
+	if tunnelPeer == nil {
+		return builder.Action().LoadRegMark(ToTunnelRegMark).
+			Action().Group(groupID).
+			Done()
+	}
+	// Flow based tunnel. Set tunnel destination.
+	return builder.Action().SetTunnelDst(tunnelPeer).
+		Action().LoadRegMark(ToTunnelRegMark).
+		Action().GotoTable(L3DecTTLTable.GetID()).
+		Done()
+}
+
+// l3FwdFlowToRemoteCIDR generates the flow to forward cross-cluster request packets based on the CIDR of the peer
+// cluster, e.g. the Service ClusterIP range.
+func (f *featureMulticluster) l3FwdFlowToRemoteCIDR(
 	localGatewayMAC net.HardwareAddr,
-	peerServiceCIDR net.IPNet,
+	peerCIDR net.IPNet,
 	tunnelPeer net.IP,
+	groupID binding.GroupIDType) binding.Flow {
+	ipProtocol := getIPProtocol(peerCIDR.IP)
+	return forwardToTunnelPeer(L3ForwardingTable.ofTable.BuildFlow(priorityNormal).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchProtocol(ipProtocol).
+		MatchDstIPNet(peerCIDR).
+		Action().SetSrcMAC(localGatewayMAC).                 // Rewrite src MAC to local gateway MAC.
+		Action().SetDstMAC(GlobalVirtualMACForMulticluster), // Rewrite dst MAC to virtual MC MAC.
+		tunnelPeer, groupID)
+}
+
+// l3FwdFlowsToRemoteGateway generates the flows to forward cross-cluster reply packets based on the Gateway IP of the
+// peer cluster. If trackReplyTunnel is true, reply packets of the connections committed by replyTunnelConntrackFlow
+// are sent back to the tunnel peer from which the request packets were received, and the given tunnel peer is used
+// only for the other reply packets.
+func (f *featureMulticluster) l3FwdFlowsToRemoteGateway(
+	localGatewayMAC net.HardwareAddr,
 	remoteGatewayIP net.IP,
+	tunnelPeer net.IP,
+	groupID binding.GroupIDType,
+	trackReplyTunnel bool,
 	enableStretchedNetworkPolicy bool) []binding.Flow {
-	ipProtocol := getIPProtocol(peerServiceCIDR.IP)
+	ipProtocol := getIPProtocol(remoteGatewayIP)
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
 	var flows []binding.Flow
+	replyFlowPriority := priorityNormal
+	if trackReplyTunnel {
+		replyFlowPriority = priorityNormal + 1
+		flows = append(flows,
+			// This generates the flow to forward cross-cluster reply packets to the tunnel source of the request
+			// packets, which is loaded into MulticlusterTunnelSrcCTLabel by replyTunnelConntrackFlow.
+			L3ForwardingTable.ofTable.BuildFlow(priorityNormal).
+				Cookie(cookieID).
+				MatchProtocol(ipProtocol).
+				MatchCTStateRpl(true).
+				MatchCTStateTrk(true).
+				MatchDstIP(remoteGatewayIP).
+				Action().SetSrcMAC(localGatewayMAC).
+				Action().SetDstMAC(GlobalVirtualMACForMulticluster).
+				Action().MoveRange(binding.NxmFieldCtLabel, binding.NxmFieldTunIPv4Dst, *MulticlusterTunnelSrcCTLabel.GetRange(), binding.Range{0, 31}).
+				Action().LoadRegMark(ToTunnelRegMark).
+				Action().GotoTable(L3DecTTLTable.GetID()).
+				Done(),
+		)
+	}
+	replyFlowBuilder := L3ForwardingTable.ofTable.BuildFlow(replyFlowPriority).
+		Cookie(cookieID).
+		MatchProtocol(ipProtocol).
+		MatchCTStateRpl(true).
+		MatchCTStateTrk(true)
+	if trackReplyTunnel {
+		replyFlowBuilder = replyFlowBuilder.MatchCTLabelField(0, 0, MulticlusterTunnelSrcCTLabel)
+	}
 	flows = append(flows,
-		// This generates the flow to forward cross-cluster request packets based
-		// on Service ClusterIP range.
-		L3ForwardingTable.ofTable.BuildFlow(priorityNormal).
-			Cookie(cookieID).
-			MatchProtocol(ipProtocol).
-			MatchDstIPNet(peerServiceCIDR).
-			Action().SetSrcMAC(localGatewayMAC).                 // Rewrite src MAC to local gateway MAC.
-			Action().SetDstMAC(GlobalVirtualMACForMulticluster). // Rewrite dst MAC to virtual MC MAC.
-			Action().SetTunnelDst(tunnelPeer).                   // Flow based tunnel. Set tunnel destination.
-			Action().LoadRegMark(ToTunnelRegMark).
-			Action().GotoTable(L3DecTTLTable.GetID()).
-			Done(),
 		// This generates the flow to forward cross-cluster reply traffic based
 		// on Gateway IP.
-		L3ForwardingTable.ofTable.BuildFlow(priorityNormal).
-			Cookie(cookieID).
-			MatchProtocol(ipProtocol).
-			MatchCTStateRpl(true).
-			MatchCTStateTrk(true).
+		forwardToTunnelPeer(replyFlowBuilder.
 			MatchDstIP(remoteGatewayIP).
 			Action().SetSrcMAC(localGatewayMAC).
-			Action().SetDstMAC(GlobalVirtualMACForMulticluster).
-			Action().SetTunnelDst(tunnelPeer). // Flow based tunnel. Set tunnel destination.
-			Action().LoadRegMark(ToTunnelRegMark).
-			Action().GotoTable(L3DecTTLTable.GetID()).
-			Done(),
+			Action().SetDstMAC(GlobalVirtualMACForMulticluster),
+			tunnelPeer, groupID),
 	)
 	if enableStretchedNetworkPolicy {
 		flows = append(flows,
 			// This generates the flow to forward cross-cluster reject traffic based
 			// on Gateway IP and reg.
-			L3ForwardingTable.ofTable.BuildFlow(priorityNormal-1).
+			forwardToTunnelPeer(L3ForwardingTable.ofTable.BuildFlow(priorityNormal-1).
 				Cookie(cookieID).
 				MatchProtocol(ipProtocol).
 				MatchRegMark(GeneratedRejectPacketOutRegMark).
 				MatchDstIP(remoteGatewayIP).
 				Action().SetSrcMAC(localGatewayMAC).
-				Action().SetDstMAC(GlobalVirtualMACForMulticluster).
-				Action().SetTunnelDst(tunnelPeer). // Flow based tunnel. Set tunnel destination.
-				Action().LoadRegMark(ToTunnelRegMark).
-				Action().GotoTable(L3DecTTLTable.GetID()).
-				Done(),
+				Action().SetDstMAC(GlobalVirtualMACForMulticluster),
+				tunnelPeer, groupID),
 		)
 	}
 	return flows
 }
 
+// replyTunnelConntrackFlow generates the flow to match the first packet of cross-cluster connections which are
+// received from a tunnel and not DNATed on the local Node, and save the tunnel source IP in MulticlusterTunnelSrcCTLabel.
+// When there are multiple Gateways in the local cluster, it makes sure the reply packets are sent back to the Gateway
+// which has received the request packets and may have performed DNAT for the connection.
+func (f *featureMulticluster) replyTunnelConntrackFlow(remoteGatewayIP net.IP) binding.Flow {
+	ipProtocol := getIPProtocol(remoteGatewayIP)
+	return ConntrackCommitTable.ofTable.BuildFlow(priorityNormal+1).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchProtocol(ipProtocol).
+		MatchRegMark(FromTunnelRegMark).
+		MatchSrcIP(remoteGatewayIP).
+		MatchCTStateNew(true).
+		MatchCTStateTrk(true).
+		MatchCTStateSNAT(false).
+		MatchCTMark(NotServiceCTMark).
+		Action().CT(true, ConntrackCommitTable.GetNext(), f.dnatCtZones[ipProtocol], nil).
+		MoveToCtMarkField(PktSourceField, ConnSourceCTMarkField).
+		MoveToLabel(binding.NxmFieldTunIPv4Src, &binding.Range{0, 31}, MulticlusterTunnelSrcCTLabel.GetRange()).
+		CTDone().
+		Done()
+}
+
 func (f *featureMulticluster) tunnelClassifierFlow(tunnelOFPort uint32) binding.Flow {
 	return ClassifierTable.ofTable.BuildFlow(priorityHigh).
 		Cookie(f.cookieAllocator.Request(f.category).Raw()).
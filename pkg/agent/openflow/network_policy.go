@@ -84,11 +84,29 @@ var (
 	// There could be other flows like default flow and Traceflow flows in the table. Only metric flows are supposed to
 	// have normal priority.
 	metricFlowIdentifier = fmt.Sprintf("priority=%d,", priorityNormal)
+	// auditFlowIdentifier is used to identify the conjunction action flows in audit tables, which count the packets
+	// matching the rules in Audit enforcement mode.
+	auditFlowIdentifier = "conj_id="
 
 	protocolTCP = v1beta2.ProtocolTCP
 	dnsPort     = int32(53)
 )
 
+const (
+	// rateLimitMeterIDBase is the first OpenFlow meter ID used by the rules with RateLimit action. Each rule owns two
+	// meters derived from its conjunction ID: one limits the rate of new connections, the other limits the bandwidth.
+	// The IDs below it are reserved for Egress QoS and packet-in rate limiting.
+	rateLimitMeterIDBase = 1024
+)
+
+func rateLimitConnectionMeterID(conjunctionID uint32) binding.MeterIDType {
+	return binding.MeterIDType(rateLimitMeterIDBase + 2*conjunctionID)
+}
+
+func rateLimitBandwidthMeterID(conjunctionID uint32) binding.MeterIDType {
+	return binding.MeterIDType(rateLimitMeterIDBase + 2*conjunctionID + 1)
+}
+
 type TCPFlags struct {
 	Flag uint16
 	Mask uint16
@@ -668,6 +686,8 @@ type policyRuleConjunction struct {
 	serviceClause *clause
 	actionFlows   []*openflow15.FlowMod
 	metricFlows   []*openflow15.FlowMod
+	// meters are the OpenFlow meters used by the metric flows of a rule with RateLimit action.
+	meters []binding.Meter
 	// NetworkPolicy reference information for debugging usage, its value can be nil
 	// for conjunctions that are not built for a specific NetworkPolicy, e.g. DNS packetin Conjunction.
 	npRef        *v1beta2.NetworkPolicyReference
@@ -1171,6 +1191,10 @@ func (c *client) InstallPolicyRuleFlows(rule *types.PolicyRule) error {
 	for _, fm := range append(conj.metricFlows, conj.actionFlows...) {
 		flowMessages = append(flowMessages, fm)
 	}
+	// The meters must be installed before the metric flows referring to them.
+	if err := conj.addMeters(); err != nil {
+		return err
+	}
 	if err := c.ofEntryOperations.AddAll(flowMessages); err != nil {
 		return err
 	}
@@ -1209,7 +1233,11 @@ func (f *featureNetworkPolicy) calculateActionFlowChangesForRule(rule *types.Pol
 		// Install action flows.
 		var actionFlows []binding.Flow
 		var metricFlows []binding.Flow
-		if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1beta1.RuleActionDrop {
+		if isAuditTable(rule.TableID) {
+			// The rules in Audit enforcement mode are counted by the action flows directly. As the packet goes to the
+			// next table after the action flow, only the highest-priority audit rule matching a connection counts it.
+			actionFlows = append(actionFlows, f.conjunctionActionAuditFlow(ruleOfID, ruleTable, rule.Priority, getRuleDisposition(rule.Action), rule.EnableLogging))
+		} else if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1beta1.RuleActionDrop {
 			metricFlows = append(metricFlows, f.denyRuleMetricFlow(ruleOfID, isIngress, rule.TableID))
 			actionFlows = append(actionFlows, f.conjunctionActionDenyFlow(ruleOfID, ruleTable, rule.Priority, DispositionDrop, rule.EnableLogging))
 		} else if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1beta1.RuleActionReject {
@@ -1217,7 +1245,15 @@ func (f *featureNetworkPolicy) calculateActionFlowChangesForRule(rule *types.Pol
 			actionFlows = append(actionFlows, f.conjunctionActionDenyFlow(ruleOfID, ruleTable, rule.Priority, DispositionRej, rule.EnableLogging))
 		} else if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1beta1.RuleActionPass {
 			actionFlows = append(actionFlows, f.conjunctionActionPassFlow(ruleOfID, ruleTable, rule.Priority, rule.EnableLogging))
+		} else if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1beta1.RuleActionRateLimit && f.ovsMetersAreSupported && rule.RateLimit != nil {
+			conj.meters = f.rateLimitMeters(ruleOfID, rule.RateLimit)
+			metricFlows = append(metricFlows, f.rateLimitRuleMetricFlows(ruleOfID, isIngress, rule.RateLimit)...)
+			actionFlows = append(actionFlows, f.conjunctionActionFlow(ruleOfID, ruleTable, dropTable.GetNext(), rule.Priority, rule.EnableLogging, rule.L7RuleVlanID)...)
 		} else {
+			if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1beta1.RuleActionRateLimit {
+				// The rule is reported as not realized in the policy status by the NetworkPolicy controller.
+				klog.InfoS("OVS meters are not supported, the rule with RateLimit action will allow the traffic without limit", "rule", rule.Name, "policy", rule.PolicyRef)
+			}
 			metricFlows = append(metricFlows, f.allowRulesMetricFlows(ruleOfID, isIngress, rule.TableID)...)
 			actionFlows = append(actionFlows, f.conjunctionActionFlow(ruleOfID, ruleTable, dropTable.GetNext(), rule.Priority, rule.EnableLogging, rule.L7RuleVlanID)...)
 		}
@@ -1316,6 +1352,10 @@ func (c *client) BatchInstallPolicyRuleFlows(ofPolicyRules []*types.PolicyRule)
 
 	for _, rule := range ofPolicyRules {
 		conj := c.featureNetworkPolicy.calculateActionFlowChangesForRule(rule)
+		if err := conj.addMeters(); err != nil {
+			c.featureNetworkPolicy.globalConjMatchFlowCache = map[string]*conjMatchFlowContext{}
+			return err
+		}
 		c.featureNetworkPolicy.addRuleToConjunctiveMatch(conj, rule)
 		for _, msg := range append(conj.actionFlows, conj.metricFlows...) {
 			allFlowMessages = append(allFlowMessages, msg)
@@ -1395,6 +1435,29 @@ func (f *featureNetworkPolicy) sendConjunctiveFlows(changes []*conjMatchFlowCont
 	return f.bridge.AddFlowsInBundle(addFlows, modifyFlows, deleteFlows)
 }
 
+// addMeters installs the OpenFlow meters used by the policyRuleConjunction on the OVS bridge.
+func (c *policyRuleConjunction) addMeters() error {
+	for _, meter := range c.meters {
+		if err := meter.Add(); err != nil {
+			return fmt.Errorf("error when installing OpenFlow meters for rule %d: %w", c.id, err)
+		}
+	}
+	return nil
+}
+
+// deleteMeters removes the OpenFlow meters used by the policyRuleConjunction from the OVS bridge, and forgets their
+// stats.
+func (f *featureNetworkPolicy) deleteMeters(conj *policyRuleConjunction) error {
+	for _, meter := range conj.meters {
+		if err := meter.Delete(); err != nil {
+			return fmt.Errorf("error when deleting OpenFlow meters for rule %d: %w", conj.id, err)
+		}
+	}
+	f.rateLimitMeterStats.Delete(rateLimitConnectionMeterID(conj.id))
+	f.rateLimitMeterStats.Delete(rateLimitBandwidthMeterID(conj.id))
+	return nil
+}
+
 // ActionFlowPriorities returns the OF priorities of the actionFlows in the policyRuleConjunction
 func (c *policyRuleConjunction) ActionFlowPriorities() []string {
 	priorities := make([]string, 0, len(c.actionFlows))
@@ -1458,7 +1521,8 @@ func (c *policyRuleConjunction) calculateClauses(rule *types.PolicyRule) (uint8,
 		c.fromClause = c.newClause(fromID, nClause, ruleTable, defaultTable)
 	}
 	if rule.To != nil {
-		if isEgressRule || (rule.IsAntreaNetworkPolicyRule() && !containsLabelIdentityAddress(rule.From)) {
+		// Audit rules never drop packets, so no deny rule is created for them.
+		if isEgressRule || (rule.IsAntreaNetworkPolicyRule() && !containsLabelIdentityAddress(rule.From)) || isAuditTable(rule.TableID) {
 			defaultTable = nil
 		} else {
 			defaultTable = dropTable
@@ -1488,6 +1552,33 @@ func (c *policyRuleConjunction) calculateChangesForRuleCreation(featureNetworkPo
 	return ctxChanges
 }
 
+// isAuditTable returns true if the table is used for the rules in Audit enforcement mode.
+func isAuditTable(tableID uint8) bool {
+	for _, table := range GetAntreaPolicyAuditTables() {
+		if table.IsInitialized() && table.GetID() == tableID {
+			return true
+		}
+	}
+	return false
+}
+
+// getRuleDisposition returns the disposition of a rule action, which is logged for the rules in Audit enforcement mode.
+func getRuleDisposition(action *crdv1beta1.RuleAction) uint32 {
+	if action == nil {
+		return DispositionAllow
+	}
+	switch *action {
+	case crdv1beta1.RuleActionDrop:
+		return DispositionDrop
+	case crdv1beta1.RuleActionReject:
+		return DispositionRej
+	case crdv1beta1.RuleActionPass:
+		return DispositionPass
+	default:
+		return DispositionAllow
+	}
+}
+
 func containsLabelIdentityAddress(addresses []types.Address) bool {
 	contains := false
 	for _, addr := range addresses {
@@ -1582,6 +1673,9 @@ func (c *client) UninstallPolicyRuleFlows(ruleID uint32) ([]string, error) {
 	if err := c.ofEntryOperations.DeleteAll(append(conj.actionFlows, conj.metricFlows...)); err != nil {
 		return nil, err
 	}
+	if err := c.featureNetworkPolicy.deleteMeters(conj); err != nil {
+		return nil, err
+	}
 	c.featureNetworkPolicy.conjMatchFlowLock.Lock()
 	defer c.featureNetworkPolicy.conjMatchFlowLock.Unlock()
 	// Get the conjMatchFlowContext changes.
@@ -1932,6 +2026,15 @@ func parseDropFlow(flowMap map[string]string) (uint32, types.RuleMetric) {
 	return uint32(id), m
 }
 
+func parseAuditFlow(flowMap map[string]string) (uint32, types.RuleMetric) {
+	// example audit flow format:
+	// table=AntreaPolicyIngressAudit, n_packets=3, n_bytes=222, priority=64990,conj_id=7 actions=goto_table:AntreaPolicyIngressRule
+	m := parseFlowMetric(flowMap)
+	m.Sessions = m.Packets
+	id, _ := strconv.ParseUint(flowMap["conj_id"], 10, 32)
+	return uint32(id), m
+}
+
 func parseAllowFlow(flowMap map[string]string) (uint32, types.RuleMetric) {
 	m := parseFlowMetric(flowMap)
 	if strings.Contains(flowMap["ct_state"], "+") { // ct_state=+new
@@ -2062,6 +2165,39 @@ func (c *client) NetworkPolicyMetrics() map[uint32]*types.RuleMetric {
 	// flows to get the correct number of total packets.
 	collectMetricsFromFlows(EgressMetricTable, parseMetricFlow)
 	collectMetricsFromFlows(IngressMetricTable, parseMetricFlow)
+	if c.enableAntreaPolicy {
+		// The rules in Audit enforcement mode are counted by their conjunction action flows, which only match the
+		// first packets of connections.
+		for _, table := range GetAntreaPolicyAuditTables() {
+			dumpedFlows, _ := c.ovsctlClient.DumpTableFlows(table.ofTable.GetID())
+			for _, flow := range dumpedFlows {
+				if !strings.Contains(flow, auditFlowIdentifier) {
+					continue
+				}
+				ruleID, metric := parseAuditFlow(parseFlowToMap(flow))
+				result[ruleID] = &metric
+			}
+		}
+	}
+	// The packets dropped by the meters of the rules with RateLimit action are reported by the meter stats, which are
+	// collected periodically.
+	for _, obj := range c.featureNetworkPolicy.policyCache.List() {
+		conj := obj.(*policyRuleConjunction)
+		if len(conj.meters) == 0 {
+			continue
+		}
+		var dropped uint64
+		for _, meterID := range []binding.MeterIDType{rateLimitConnectionMeterID(conj.id), rateLimitBandwidthMeterID(conj.id)} {
+			if count, ok := c.featureNetworkPolicy.rateLimitMeterStats.Load(meterID); ok {
+				dropped += uint64(count.(int64))
+			}
+		}
+		if metric, ok := result[conj.id]; ok {
+			metric.RateLimitedPackets = dropped
+		} else {
+			result[conj.id] = &types.RuleMetric{RateLimitedPackets: dropped}
+		}
+	}
 	return result
 }
 
@@ -2088,10 +2224,16 @@ type featureNetworkPolicy struct {
 	loggingGroupCache sync.Map
 	groupAllocator    GroupAllocator
 
+	// rateLimitMeterStats stores the number of packets dropped by the meters of the rules with RateLimit action. The
+	// key is the meter ID, and the value is the dropped packet count reported by the last meter stats reply.
+	rateLimitMeterStats sync.Map
+
 	ovsMetersAreSupported bool
 	enableDenyTracking    bool
 	enableAntreaPolicy    bool
 	enableL7NetworkPolicy bool
+	enableL7FlowExporter  bool
+	enableTrafficControl  bool
 	enableMulticast       bool
 	proxyAll              bool
 	ctZoneSrcField        *binding.RegField
@@ -2116,6 +2258,8 @@ func newFeatureNetworkPolicy(
 	enableDenyTracking,
 	enableAntreaPolicy bool,
 	enableL7NetworkPolicy bool,
+	enableL7FlowExporter bool,
+	enableTrafficControl bool,
 	enableMulticast bool,
 	proxyAll bool,
 	connectUplinkToBridge bool,
@@ -2127,6 +2271,8 @@ func newFeatureNetworkPolicy(
 		bridge:                   bridge,
 		nodeType:                 nodeType,
 		enableL7NetworkPolicy:    enableL7NetworkPolicy,
+		enableL7FlowExporter:     enableL7FlowExporter,
+		enableTrafficControl:     enableTrafficControl,
 		l7NetworkPolicyConfig:    l7NetworkPolicyConfig,
 		globalConjMatchFlowCache: make(map[string]*conjMatchFlowContext),
 		policyCache:              cache.NewIndexer(policyConjKeyFunc, cache.Indexers{priorityIndex: priorityIndexFunc}),
@@ -2146,6 +2292,7 @@ func (f *featureNetworkPolicy) initFlows() []*openflow15.FlowMod {
 	f.egressTables = map[uint8]struct{}{EgressRuleTable.GetID(): {}, EgressDefaultTable.GetID(): {}}
 	if f.enableAntreaPolicy {
 		f.egressTables[AntreaPolicyEgressRuleTable.GetID()] = struct{}{}
+		f.egressTables[AntreaPolicyEgressAuditTable.GetID()] = struct{}{}
 		if f.enableMulticast {
 			f.egressTables[MulticastEgressRuleTable.GetID()] = struct{}{}
 		}
@@ -2155,6 +2302,9 @@ func (f *featureNetworkPolicy) initFlows() []*openflow15.FlowMod {
 		flows = append(flows, f.ingressClassifierFlows()...)
 		if f.enableL7NetworkPolicy {
 			flows = append(flows, f.l7NPTrafficControlFlows()...)
+			if f.enableL7FlowExporter {
+				flows = append(flows, f.l7FlowExporterFlows()...)
+			}
 		}
 	}
 	flows = append(flows, f.skipPolicyRuleCheckFlows()...)
@@ -2207,6 +2357,34 @@ func (f *featureNetworkPolicy) skipPolicyRuleCheckFlows() []binding.Flow {
 				Action().GotoTable(IngressMetricTable.GetID()).
 				Done(),
 		)
+		if f.enableAntreaPolicy {
+			// The packets in established or related connections skip the audit rules too, as they only audit the
+			// connections which are being evaluated by the enforced rules.
+			for _, table := range []struct {
+				auditTable  *Table
+				metricTable *Table
+			}{
+				{AntreaPolicyEgressAuditTable, EgressMetricTable},
+				{AntreaPolicyIngressAuditTable, IngressMetricTable},
+			} {
+				flows = append(flows,
+					table.auditTable.ofTable.BuildFlow(priority).
+						Cookie(cookieID).
+						MatchProtocol(ipProtocol).
+						MatchCTStateNew(false).
+						MatchCTStateEst(true).
+						Action().GotoTable(table.metricTable.GetID()).
+						Done(),
+					table.auditTable.ofTable.BuildFlow(priority).
+						Cookie(cookieID).
+						MatchProtocol(ipProtocol).
+						MatchCTStateNew(false).
+						MatchCTStateRel(true).
+						Action().GotoTable(table.metricTable.GetID()).
+						Done(),
+				)
+			}
+		}
 	}
 	return flows
 }
@@ -2247,6 +2425,72 @@ func (f *featureNetworkPolicy) l7NPTrafficControlFlows() []binding.Flow {
 	}
 }
 
+// l7FlowExporterFlows generates the flows to mirror the packets marked with L7FlowExporterRegMark to the L7 engine, so
+// that the L7 metadata of their connections can be exported. The mirrored packets are tagged with a dedicated VLAN ID,
+// which is not used by any L7 NetworkPolicy rule, and dropped when they come back from the L7 engine.
+func (f *featureNetworkPolicy) l7FlowExporterFlows() []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	vlanMask := uint16(openflow15.OFPVID_PRESENT | 0xfff)
+	flows := []binding.Flow{
+		// This generates the flow to skip marking the packets returned by the L7 engine via the return ofPort, as they
+		// have been mirrored before being sent to the L7 engine.
+		L7FlowExporterMarkTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchRegMark(FromTCReturnRegMark).
+			Action().NextTable().
+			Done(),
+		// This generates the flow to output the packets to the original target port as well as mirror the packets to
+		// the L7 engine via the target ofPort, with the VLAN ID reserved for the L7 flow exporter.
+		OutputTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchRegMark(OutputToOFPortRegMark, L7FlowExporterRegMark).
+			Action().PushVLAN(EtherTypeDot1q).
+			Action().SetVLAN(config.L7FlowExporterVLANID).
+			Action().Output(f.l7NetworkPolicyConfig.TargetOFPort).
+			Action().PopVLAN().
+			Action().OutputToRegField(TargetOFPortField).
+			Done(),
+		// This generates the flow to drop the mirrored packets returned by the L7 engine via the return ofPort.
+		ClassifierTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchInPort(f.l7NetworkPolicyConfig.ReturnOFPort).
+			MatchVLAN(false, config.L7FlowExporterVLANID, &vlanMask).
+			Action().Drop().
+			Done(),
+	}
+	if f.enableTrafficControl {
+		flows = append(flows,
+			// This generates the flow to mirror the packets which are also marked by a TrafficControl mirror rule to
+			// the L7 engine, and then output them to both the original target port and the traffic control target port.
+			// The packets of the connections to be redirected by L7 NetworkPolicies are excluded.
+			OutputTable.ofTable.BuildFlow(priorityHigh+2).
+				Cookie(cookieID).
+				MatchRegMark(OutputToOFPortRegMark, L7FlowExporterRegMark, TrafficControlMirrorRegMark).
+				MatchCTMark(NotL7NPRedirectCTMark).
+				Action().PushVLAN(EtherTypeDot1q).
+				Action().SetVLAN(config.L7FlowExporterVLANID).
+				Action().Output(f.l7NetworkPolicyConfig.TargetOFPort).
+				Action().PopVLAN().
+				Action().OutputToRegField(TargetOFPortField).
+				Action().OutputToRegField(TrafficControlTargetOFPortField).
+				Done(),
+			// This generates the flow to mirror the packets which are also marked by a TrafficControl redirect rule
+			// to the L7 engine, and then output them to the traffic control target port.
+			OutputTable.ofTable.BuildFlow(priorityHigh+2).
+				Cookie(cookieID).
+				MatchRegMark(OutputToOFPortRegMark, L7FlowExporterRegMark, TrafficControlRedirectRegMark).
+				MatchCTMark(NotL7NPRedirectCTMark).
+				Action().PushVLAN(EtherTypeDot1q).
+				Action().SetVLAN(config.L7FlowExporterVLANID).
+				Action().Output(f.l7NetworkPolicyConfig.TargetOFPort).
+				Action().PopVLAN().
+				Action().OutputToRegField(TrafficControlTargetOFPortField).
+				Done(),
+		)
+	}
+	return flows
+}
+
 func (f *featureNetworkPolicy) loggingNPPacketFlowWithOperations(cookieID uint64, operations uint8) binding.Flow {
 	loggingOperations := binding.NewRegMark(PacketInOperationField, uint32(operations))
 	fb := OutputTable.ofTable.BuildFlow(priorityNormal).
@@ -2272,6 +2516,10 @@ func (f *featureNetworkPolicy) initLoggingFlows() []binding.Flow {
 func (f *featureNetworkPolicy) initGroups() []binding.OFEntry {
 	var groups []binding.OFEntry
 	candidateTables := []*Table{EgressRuleTable, EgressMetricTable, IngressRuleTable, IngressMetricTable}
+	if f.enableAntreaPolicy {
+		// The packets matching the audit rules are resubmitted to the next tables of the audit tables.
+		candidateTables = append(candidateTables, AntreaPolicyEgressRuleTable, AntreaPolicyIngressRuleTable)
+	}
 	if f.enableMulticast {
 		candidateTables = append(candidateTables, MulticastEgressMetricTable, MulticastIngressMetricTable)
 	}
@@ -2302,7 +2550,14 @@ func (f *featureNetworkPolicy) initGroups() []binding.OFEntry {
 }
 
 func (f *featureNetworkPolicy) replayMeters() []binding.OFEntry {
-	return nil
+	var meters []binding.OFEntry
+	for _, obj := range f.policyCache.List() {
+		for _, meter := range obj.(*policyRuleConjunction).meters {
+			meter.Reset()
+			meters = append(meters, meter)
+		}
+	}
+	return meters
 }
 
 func (f *featureNetworkPolicy) getLoggingAndResubmitGroupID(nextTable uint8) binding.GroupIDType {
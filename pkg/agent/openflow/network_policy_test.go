@@ -625,6 +625,55 @@ func BenchmarkBatchInstallPolicyRuleFlows(b *testing.B) {
 	}
 }
 
+func TestRateLimitRuleMeters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	bridge := mocks.NewMockBridge(ctrl)
+	fc := newFakeClientWithBridge(m, true, false, config.K8sNode, config.TrafficEncapModeEncap, bridge, setEnableOVSMeters(true))
+	defer resetPipelines()
+
+	ruleID := uint32(10)
+	connectionMeter := mocks.NewMockMeter(ctrl)
+	bandwidthMeter := mocks.NewMockMeter(ctrl)
+	conj := &policyRuleConjunction{id: ruleID, meters: []binding.Meter{connectionMeter, bandwidthMeter}}
+
+	connectionMeter.EXPECT().Add().Return(nil)
+	bandwidthMeter.EXPECT().Add().Return(nil)
+	require.NoError(t, conj.addMeters())
+	// The meters after a failed one are not installed.
+	connectionMeter.EXPECT().Add().Return(errors.New("meter failure"))
+	assert.ErrorContains(t, conj.addMeters(), "error when installing OpenFlow meters for rule 10")
+
+	fc.featureNetworkPolicy.policyCache.Add(conj)
+	// A rule without RateLimit action has no meter to replay.
+	fc.featureNetworkPolicy.policyCache.Add(&policyRuleConjunction{id: 11})
+	connectionMeter.EXPECT().Reset()
+	bandwidthMeter.EXPECT().Reset()
+	assert.ElementsMatch(t, []binding.OFEntry{connectionMeter, bandwidthMeter}, fc.featureNetworkPolicy.replayMeters())
+
+	fc.featureNetworkPolicy.rateLimitMeterStats.Store(rateLimitConnectionMeterID(ruleID), int64(5))
+	fc.featureNetworkPolicy.rateLimitMeterStats.Store(rateLimitBandwidthMeterID(ruleID), int64(7))
+	m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(2)
+	// The rule is kept if its meters cannot be removed, so that uninstalling it can be retried.
+	connectionMeter.EXPECT().Delete().Return(errors.New("meter failure"))
+	_, err := fc.UninstallPolicyRuleFlows(ruleID)
+	assert.ErrorContains(t, err, "error when deleting OpenFlow meters for rule 10")
+	assert.NotNil(t, fc.featureNetworkPolicy.getPolicyRuleConjunction(ruleID))
+	_, ok := fc.featureNetworkPolicy.rateLimitMeterStats.Load(rateLimitConnectionMeterID(ruleID))
+	assert.True(t, ok)
+
+	connectionMeter.EXPECT().Delete().Return(nil)
+	bandwidthMeter.EXPECT().Delete().Return(nil)
+	bridge.EXPECT().AddFlowsInBundle(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	_, err = fc.UninstallPolicyRuleFlows(ruleID)
+	require.NoError(t, err)
+	assert.Nil(t, fc.featureNetworkPolicy.getPolicyRuleConjunction(ruleID))
+	_, ok = fc.featureNetworkPolicy.rateLimitMeterStats.Load(rateLimitConnectionMeterID(ruleID))
+	assert.False(t, ok)
+	_, ok = fc.featureNetworkPolicy.rateLimitMeterStats.Load(rateLimitBandwidthMeterID(ruleID))
+	assert.False(t, ok)
+}
+
 func TestConjMatchFlowContextKeyConflict(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	preparePipelines()
@@ -1388,6 +1437,10 @@ func networkPolicyInitFlows(ovsMeterSupported, externalNodeEnabled, l7NetworkPol
 			"cookie=0x1020000000000, table=AntreaPolicyEgressRule, priority=64990,ct_state=-new+rel,ip actions=goto_table:EgressMetric",
 			"cookie=0x1020000000000, table=AntreaPolicyIngressRule, priority=64990,ct_state=-new+est,ip actions=goto_table:IngressMetric",
 			"cookie=0x1020000000000, table=AntreaPolicyIngressRule, priority=64990,ct_state=-new+rel,ip actions=goto_table:IngressMetric",
+			"cookie=0x1020000000000, table=AntreaPolicyEgressAudit, priority=64990,ct_state=-new+est,ip actions=goto_table:EgressMetric",
+			"cookie=0x1020000000000, table=AntreaPolicyEgressAudit, priority=64990,ct_state=-new+rel,ip actions=goto_table:EgressMetric",
+			"cookie=0x1020000000000, table=AntreaPolicyIngressAudit, priority=64990,ct_state=-new+est,ip actions=goto_table:IngressMetric",
+			"cookie=0x1020000000000, table=AntreaPolicyIngressAudit, priority=64990,ct_state=-new+rel,ip actions=goto_table:IngressMetric",
 		)
 	}
 	initFlows := append(loggingFlows,
@@ -1399,6 +1452,10 @@ func networkPolicyInitFlows(ovsMeterSupported, externalNodeEnabled, l7NetworkPol
 		"cookie=0x1020000000000, table=AntreaPolicyEgressRule, priority=64990,ct_state=-new+rel,ip actions=goto_table:EgressMetric",
 		"cookie=0x1020000000000, table=AntreaPolicyIngressRule, priority=64990,ct_state=-new+est,ip actions=goto_table:IngressMetric",
 		"cookie=0x1020000000000, table=AntreaPolicyIngressRule, priority=64990,ct_state=-new+rel,ip actions=goto_table:IngressMetric",
+		"cookie=0x1020000000000, table=AntreaPolicyEgressAudit, priority=64990,ct_state=-new+est,ip actions=goto_table:EgressMetric",
+		"cookie=0x1020000000000, table=AntreaPolicyEgressAudit, priority=64990,ct_state=-new+rel,ip actions=goto_table:EgressMetric",
+		"cookie=0x1020000000000, table=AntreaPolicyIngressAudit, priority=64990,ct_state=-new+est,ip actions=goto_table:IngressMetric",
+		"cookie=0x1020000000000, table=AntreaPolicyIngressAudit, priority=64990,ct_state=-new+rel,ip actions=goto_table:IngressMetric",
 	)
 	if l7NetworkPolicyEnabled {
 		initFlows = append(initFlows,
@@ -1424,6 +1481,28 @@ func Test_featureNetworkPolicy_initFlows(t *testing.T) {
 				clientOptions: []clientOptionsFn{enableMulticast, enableL7NetworkPolicy},
 				expectedFlows: networkPolicyInitFlows(ovsMetersSupported, false, true),
 			},
+			{
+				name:          "K8s Node with L7FlowExporter",
+				nodeType:      config.K8sNode,
+				clientOptions: []clientOptionsFn{enableL7FlowExporter},
+				expectedFlows: append(networkPolicyInitFlows(ovsMetersSupported, false, true),
+					"cookie=0x1020000000000, table=L7FlowExporterMark, priority=210,reg0=0x6/0xf actions=goto_table:TrafficControl",
+					"cookie=0x1020000000000, table=Output, priority=210,reg0=0x200000/0x600000,reg4=0x10000000/0x10000000 actions=push_vlan:0x8100,set_field:8190->vlan_vid,output:10,pop_vlan,output:NXM_NX_REG1[]",
+					"cookie=0x1020000000000, table=Classifier, priority=210,in_port=11,vlan_tci=0x1ffe/0x1fff actions=drop",
+				),
+			},
+			{
+				name:          "K8s Node with L7FlowExporter and TrafficControl",
+				nodeType:      config.K8sNode,
+				clientOptions: []clientOptionsFn{enableL7FlowExporter, enableTrafficControl},
+				expectedFlows: append(networkPolicyInitFlows(ovsMetersSupported, false, true),
+					"cookie=0x1020000000000, table=L7FlowExporterMark, priority=210,reg0=0x6/0xf actions=goto_table:TrafficControl",
+					"cookie=0x1020000000000, table=Output, priority=210,reg0=0x200000/0x600000,reg4=0x10000000/0x10000000 actions=push_vlan:0x8100,set_field:8190->vlan_vid,output:10,pop_vlan,output:NXM_NX_REG1[]",
+					"cookie=0x1020000000000, table=Output, priority=212,ct_mark=0x0/0x80,reg0=0x200000/0x600000,reg4=0x10400000/0x10c00000 actions=push_vlan:0x8100,set_field:8190->vlan_vid,output:10,pop_vlan,output:NXM_NX_REG1[],output:NXM_NX_REG9[]",
+					"cookie=0x1020000000000, table=Output, priority=212,ct_mark=0x0/0x80,reg0=0x200000/0x600000,reg4=0x10800000/0x10c00000 actions=push_vlan:0x8100,set_field:8190->vlan_vid,output:10,pop_vlan,output:NXM_NX_REG9[]",
+					"cookie=0x1020000000000, table=Classifier, priority=210,in_port=11,vlan_tci=0x1ffe/0x1fff actions=drop",
+				),
+			},
 			{
 				name:          "K8s Node with Multicast",
 				nodeType:      config.K8sNode,
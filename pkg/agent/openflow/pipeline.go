@@ -36,6 +36,7 @@ import (
 	"antrea.io/antrea/pkg/agent/openflow/cookie"
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/ovs/ovsctl"
 	"antrea.io/antrea/pkg/util/runtime"
@@ -149,6 +150,7 @@ var (
 
 	// Tables in stageEgressSecurity:
 	EgressSecurityClassifierTable = newTable("EgressSecurityClassifier", stageEgressSecurity, pipelineIP)
+	AntreaPolicyEgressAuditTable  = newTable("AntreaPolicyEgressAudit", stageEgressSecurity, pipelineIP)
 	AntreaPolicyEgressRuleTable   = newTable("AntreaPolicyEgressRule", stageEgressSecurity, pipelineIP)
 	EgressRuleTable               = newTable("EgressRule", stageEgressSecurity, pipelineIP)
 	EgressDefaultTable            = newTable("EgressDefaultRule", stageEgressSecurity, pipelineIP)
@@ -165,11 +167,13 @@ var (
 	SNATTable     = newTable("SNAT", stagePostRouting, pipelineIP)
 
 	// Tables in stageSwitching:
-	L2ForwardingCalcTable = newTable("L2ForwardingCalc", stageSwitching, pipelineIP)
-	TrafficControlTable   = newTable("TrafficControl", stageSwitching, pipelineIP)
+	L2ForwardingCalcTable   = newTable("L2ForwardingCalc", stageSwitching, pipelineIP)
+	L7FlowExporterMarkTable = newTable("L7FlowExporterMark", stageSwitching, pipelineIP)
+	TrafficControlTable     = newTable("TrafficControl", stageSwitching, pipelineIP)
 
 	// Tables in stageIngressSecurity:
 	IngressSecurityClassifierTable = newTable("IngressSecurityClassifier", stageIngressSecurity, pipelineIP)
+	AntreaPolicyIngressAuditTable  = newTable("AntreaPolicyIngressAudit", stageIngressSecurity, pipelineIP)
 	AntreaPolicyIngressRuleTable   = newTable("AntreaPolicyIngressRule", stageIngressSecurity, pipelineIP)
 	IngressRuleTable               = newTable("IngressRule", stageIngressSecurity, pipelineIP)
 	IngressDefaultTable            = newTable("IngressDefaultRule", stageIngressSecurity, pipelineIP)
@@ -217,9 +221,18 @@ var (
 
 	// IPv6 multicast prefix
 	ipv6MulticastAddr = "FF00::/8"
+	// IPv6 link-local multicast prefix
+	ipv6LinkLocalMulticastAddr = "FF02::/16"
 	// IPv6 link-local prefix
 	ipv6LinkLocalAddr = "FE80::/10"
 
+	// ICMPv6 types of MLD messages.
+	mldQueryType    = uint8(130)
+	mldv2ReportType = uint8(143)
+	// ICMPv6 types of the MLD messages sent by multicast listeners, i.e. MLDv1 report, MLDv1 done and MLDv2 report.
+	// They are the IPv6 counterparts of the IGMP report and leave messages.
+	mldReportTypes = []uint8{131, 132, mldv2ReportType}
+
 	// Operation field values in ARP packets
 	arpOpRequest = uint16(1)
 	arpOpReply   = uint16(2)
@@ -339,6 +352,17 @@ func GetAntreaPolicyMultiTierTables() []*Table {
 	}
 }
 
+// GetAntreaPolicyAuditTables returns the tables of the rules in Audit enforcement mode. The audit rules of all Tiers
+// share these tables, which are evaluated before the enforced rules of any Tier. The audit rules are ordered by Tier,
+// policy and rule priorities, like the enforced rules, but a connection is only counted by the first audit rule it
+// matches, whatever the enforced rules decide for it.
+func GetAntreaPolicyAuditTables() []*Table {
+	return []*Table{
+		AntreaPolicyEgressAuditTable,
+		AntreaPolicyIngressAuditTable,
+	}
+}
+
 const (
 	CtZone       = 0xfff0
 	CtZoneV6     = 0xffe6
@@ -425,6 +449,7 @@ type client struct {
 	enableDSR                  bool
 	enableAntreaPolicy         bool
 	enableL7NetworkPolicy      bool
+	enableL7FlowExporter       bool
 	enableDenyTracking         bool
 	enableEgress               bool
 	enableEgressTrafficShaping bool
@@ -485,8 +510,9 @@ type client struct {
 func (c *client) Run(stopCh <-chan struct{}) {
 	// Start PacketIn
 	c.StartPacketInHandler(stopCh)
-	// Start OVS meter stats collection
-	if c.enablePrometheusMetrics {
+	// Start OVS meter stats collection. The stats are also needed to report the packets dropped by the NetworkPolicy
+	// rules with RateLimit action.
+	if c.enablePrometheusMetrics || c.enableAntreaPolicy {
 		if c.ovsMetersAreSupported {
 			klog.Info("Start collecting OVS meter stats")
 			go wait.Until(c.getMeterStats, time.Second*30, stopCh)
@@ -621,7 +647,7 @@ func (c *client) defaultFlows() []*openflow15.FlowMod {
 			// This generates the flow to match multicast packets and forward them to the first table of pipelineMulticast
 			// in PipelineIPClassifierTable. Note that, PipelineIPClassifierTable is in stageValidation of pipeline for IP. In another word,
 			// pipelineMulticast is forked from PipelineIPClassifierTable in pipelineIP.
-			flows = append(flows, multicastPipelineClassifyFlow(cookieID, pipeline))
+			flows = append(flows, multicastPipelineClassifyFlows(cookieID, c.ipProtocols, pipeline)...)
 		case pipelineNonIP:
 			flows = append(flows, nonIPPipelineClassifyFlow(cookieID, pipeline))
 		}
@@ -1049,10 +1075,16 @@ func (f *featurePodConnectivity) flowsToTrace(dataplaneTag uint8,
 			} else {
 				flowBuilder = flowBuilder.MatchProtocol(binding.ProtocolUDP)
 			}
+		case binding.IPProtocolSCTP:
+			if packet.IsIPv6 {
+				flowBuilder = flowBuilder.MatchProtocol(binding.ProtocolSCTPv6)
+			} else {
+				flowBuilder = flowBuilder.MatchProtocol(binding.ProtocolSCTP)
+			}
 		default:
 			flowBuilder = flowBuilder.MatchIPProtocolValue(packet.IsIPv6, packet.IPProto)
 		}
-		if packet.IPProto == protocol.Type_TCP || packet.IPProto == protocol.Type_UDP {
+		if packet.IPProto == protocol.Type_TCP || packet.IPProto == protocol.Type_UDP || packet.IPProto == binding.IPProtocolSCTP {
 			if packet.DestinationPort != 0 {
 				flowBuilder = flowBuilder.MatchDstPort(packet.DestinationPort, nil)
 			}
@@ -1760,6 +1792,73 @@ func (f *featureNetworkPolicy) allowRulesMetricFlows(conjunctionID uint32, ingre
 	return flows
 }
 
+// rateLimitRuleMetricFlows generates the metric flows for a rule with RateLimit action. Like the metric flows of allow
+// rules, they track the stats of the connections matching the rule, and they also apply the meters of the rule. As a
+// flow can only have one meter action, the connection meter is applied to the first packets of the connections if the
+// rate of new connections is limited, and the bandwidth meter is applied to the other packets.
+func (f *featureNetworkPolicy) rateLimitRuleMetricFlows(conjunctionID uint32, ingress bool, rateLimit *v1beta2.RuleRateLimit) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	metricTable := IngressMetricTable
+	offset := 0
+	field := IngressRuleCTLabel
+	if !ingress {
+		metricTable = EgressMetricTable
+		offset = 32
+		field = EgressRuleCTLabel
+	}
+	var newConnMeterID, estConnMeterID *binding.MeterIDType
+	if rateLimit.BandwidthRate > 0 {
+		meterID := rateLimitBandwidthMeterID(conjunctionID)
+		newConnMeterID, estConnMeterID = &meterID, &meterID
+	}
+	if rateLimit.ConnectionsPerSecond > 0 {
+		meterID := rateLimitConnectionMeterID(conjunctionID)
+		newConnMeterID = &meterID
+	}
+	metricFlow := func(isCTNew bool, protocol binding.Protocol, meterID *binding.MeterIDType) binding.Flow {
+		fb := metricTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchProtocol(protocol).
+			MatchCTStateNew(isCTNew).
+			MatchCTLabelField(0, uint64(conjunctionID)<<offset, field)
+		if meterID != nil {
+			fb = fb.Action().Meter(uint32(*meterID))
+		}
+		return fb.Action().NextTable().
+			Done()
+	}
+	var flows []binding.Flow
+	for _, ipProtocol := range f.ipProtocols {
+		flows = append(flows, metricFlow(true, ipProtocol, newConnMeterID), metricFlow(false, ipProtocol, estConnMeterID))
+	}
+	return flows
+}
+
+// rateLimitMeters generates the OpenFlow meters for a rule with RateLimit action.
+func (f *featureNetworkPolicy) rateLimitMeters(conjunctionID uint32, rateLimit *v1beta2.RuleRateLimit) []binding.Meter {
+	genMeter := func(meterID binding.MeterIDType, meterFlags ofctrl.MeterFlag, rate, burst uint32) binding.Meter {
+		return f.bridge.NewMeter(meterID, meterFlags).
+			MeterBand().
+			MeterType(ofctrl.MeterDrop).
+			Rate(rate).
+			Burst(burst).
+			Done()
+	}
+	var meters []binding.Meter
+	if rateLimit.ConnectionsPerSecond > 0 {
+		cps := uint32(rateLimit.ConnectionsPerSecond)
+		meters = append(meters, genMeter(rateLimitConnectionMeterID(conjunctionID), ofctrl.MeterBurst|ofctrl.MeterPktps, cps, cps))
+	}
+	if rateLimit.BandwidthRate > 0 {
+		burst := rateLimit.BandwidthBurst
+		if burst <= 0 {
+			burst = rateLimit.BandwidthRate
+		}
+		meters = append(meters, genMeter(rateLimitBandwidthMeterID(conjunctionID), ofctrl.MeterBurst|ofctrl.MeterKbps, uint32(rateLimit.BandwidthRate), uint32(burst)))
+	}
+	return meters
+}
+
 func (f *featureNetworkPolicy) denyRuleMetricFlow(conjunctionID uint32, ingress bool, tableID uint8) binding.Flow {
 	metricTable := IngressMetricTable
 	if !ingress {
@@ -1810,16 +1909,45 @@ func (f *featurePodConnectivity) ipv6Flows() []binding.Flow {
 			MatchICMPv6Code(0).
 			Action().Normal().
 			Done(),
+	)
+	if f.enableMulticast {
+		// When multicast is enabled, MLD messages and the IPv6 multicast packets of non-link-local groups are forwarded
+		// to PipelineIPClassifierTable to enter pipelineMulticast. As required by RFC 4541, the packets of link-local
+		// groups (ff02::/16) are not snooped and are still flooded by using normal, like Neighbor Solicitation and
+		// Neighbor Advertisement. MLD messages are matched with a higher priority, as they may be sent to link-local
+		// groups (e.g. ff02::16).
+		_, ipv6LinkLocalMulticastIpnet, _ := net.ParseCIDR(ipv6LinkLocalMulticastAddr)
+		for _, mldType := range append([]uint8{mldQueryType}, mldReportTypes...) {
+			flows = append(flows, IPv6Table.ofTable.BuildFlow(priorityHigh).
+				Cookie(cookieID).
+				MatchProtocol(binding.ProtocolICMPv6).
+				MatchICMPv6Type(mldType).
+				Action().GotoTable(PipelineIPClassifierTable.GetID()).
+				Done())
+		}
+		flows = append(flows, IPv6Table.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchProtocol(binding.ProtocolIPv6).
+			MatchDstIPNet(*ipv6LinkLocalMulticastIpnet).
+			Action().Normal().
+			Done(),
+			IPv6Table.ofTable.BuildFlow(priorityLow).
+				Cookie(cookieID).
+				MatchProtocol(binding.ProtocolIPv6).
+				MatchDstIPNet(*ipv6MulticastIpnet).
+				Action().GotoTable(PipelineIPClassifierTable.GetID()).
+				Done())
+	} else {
 		// Handle IPv6 multicast packets as a regular L2 learning Switch by using normal.
 		// It is used to ensure that all kinds of IPv6 multicast packets are properly handled (e.g. Multicast Listener
 		// Report Message V2).
-		IPv6Table.ofTable.BuildFlow(priorityNormal).
+		flows = append(flows, IPv6Table.ofTable.BuildFlow(priorityNormal).
 			Cookie(cookieID).
 			MatchProtocol(binding.ProtocolIPv6).
 			MatchDstIPNet(*ipv6MulticastIpnet).
 			Action().Normal().
-			Done(),
-	)
+			Done())
+	}
 	return flows
 }
 
@@ -1995,6 +2123,31 @@ func (f *featureNetworkPolicy) conjunctionActionPassFlow(conjunctionID uint32, t
 		Done()
 }
 
+// conjunctionActionAuditFlow generates the flow for a rule in Audit enforcement mode if policyRuleConjunction ID is
+// matched. The packet is neither allowed nor denied, but is forwarded to the next table to be evaluated by the enforced
+// rules, after being counted by the flow and sent to antrea-agent for logging if logging is enabled.
+func (f *featureNetworkPolicy) conjunctionActionAuditFlow(conjunctionID uint32, table binding.Table, priority *uint16,
+	disposition uint32, enableLogging bool) binding.Flow {
+	ofPriority := *priority
+	tableID := table.GetID()
+	flowBuilder := table.BuildFlow(ofPriority).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchConjID(conjunctionID)
+
+	if enableLogging {
+		groupID := f.getLoggingAndResubmitGroupID(table.GetNext())
+		return flowBuilder.
+			Action().LoadToRegField(APConjIDField, conjunctionID).
+			Action().LoadToRegField(APDispositionField, disposition).
+			Action().LoadToRegField(PacketInOperationField, PacketInNPLoggingOperation).
+			Action().LoadToRegField(PacketInTableField, uint32(tableID)).
+			Action().Group(groupID).
+			Done()
+	}
+	return flowBuilder.Action().NextTable().
+		Done()
+}
+
 func (c *client) Disconnect() error {
 	return c.bridge.Disconnect()
 }
@@ -2249,6 +2402,35 @@ func (f *featurePodConnectivity) localProbeFlows() []binding.Flow {
 	return flows
 }
 
+// peerEndpointHealthCheckFlows generates the flows to forward the Endpoint health checks sent by a peer Node to
+// stageConntrack directly, bypassing ingress rule of Network Policies like localProbeFlows does for kubelet probes. The
+// health checks are identified by matching the TCP packets with EndpointHealthCheckDSCP sourced from the IPs the peer
+// Node uses to reach local Pods, i.e. its gateway IP in encap mode and its transport IP in noEncap mode. As Pods cannot
+// use these IPs, the flows are only hit by the packets sent from the host network of the peer Node.
+func (f *featurePodConnectivity) peerEndpointHealthCheckFlows(peerIPs ...net.IP) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	var flows []binding.Flow
+	for _, peerIP := range peerIPs {
+		if peerIP == nil {
+			continue
+		}
+		protocol := binding.ProtocolTCP
+		if getIPProtocol(peerIP) == binding.ProtocolIPv6 {
+			protocol = binding.ProtocolTCPv6
+		}
+		flows = append(flows, IngressSecurityClassifierTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(protocol).
+			MatchCTStateRpl(false).
+			MatchCTStateTrk(true).
+			MatchSrcIP(peerIP).
+			MatchIPDSCP(types.EndpointHealthCheckDSCP).
+			Action().GotoStage(stageConntrack).
+			Done())
+	}
+	return flows
+}
+
 // ingressClassifierFlows generates the flows to classify the packets from local Pods or the Antrea gateway to different
 // tables within stageIngressSecurity.
 func (f *featureNetworkPolicy) ingressClassifierFlows() []binding.Flow {
@@ -2655,8 +2837,10 @@ func (f *featureService) dsrServiceNoDNATFlows() []binding.Flow {
 
 // serviceEndpointGroup creates/modifies the group/buckets of Endpoints. If the withSessionAffinity is true, then buckets
 // will resubmit packets back to ServiceLBTable to trigger the learn flow, the learn flow will then send packets to
-// EndpointDNATTable. Otherwise, buckets will resubmit packets to EndpointDNATTable directly.
-func (f *featureService) serviceEndpointGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints ...proxy.Endpoint) binding.Group {
+// EndpointDNATTable. Otherwise, buckets will resubmit packets to EndpointDNATTable directly. If hashSelection is true,
+// the group selects buckets with a hash of the 5-tuple of packets, so that the same connection selects the same bucket
+// on all Nodes.
+func (f *featureService) serviceEndpointGroup(groupID binding.GroupIDType, withSessionAffinity, hashSelection bool, endpoints ...proxy.Endpoint) binding.Group {
 	group := f.bridge.NewGroup(groupID)
 
 	if len(endpoints) == 0 {
@@ -2672,12 +2856,15 @@ func (f *featureService) serviceEndpointGroup(groupID binding.GroupIDType, withS
 	} else {
 		resubmitTableID = ServiceLBTable.GetNext() // It will be EndpointDNATTable if DSR is not enabled, otherwise DSRServiceMarkTable.
 	}
+	if hashSelection {
+		group = group.SelectionMethodHash(getServiceHashFields(getIPProtocol(net.ParseIP(endpoints[0].IP())))...)
+	}
 	for _, endpoint := range endpoints {
 		endpointPort, _ := endpoint.Port()
 		endpointIP := net.ParseIP(endpoint.IP())
 		portVal := util.PortToUint16(endpointPort)
 		ipProtocol := getIPProtocol(endpointIP)
-		bucketBuilder := group.Bucket().Weight(100)
+		bucketBuilder := group.Bucket().Weight(getEndpointWeight(endpoint))
 		// Load RemoteEndpointRegMark for remote non-hostNetwork Endpoints.
 		if !endpoint.GetIsLocal() && endpoint.GetNodeName() != "" && !f.nodeIPChecker.IsNodeIP(endpoint.IP()) {
 			bucketBuilder = bucketBuilder.LoadRegMark(RemoteEndpointRegMark)
@@ -2697,6 +2884,20 @@ func (f *featureService) serviceEndpointGroup(groupID binding.GroupIDType, withS
 	return group
 }
 
+// getServiceHashFields returns the 5-tuple fields used to select the bucket of a Service group. OVS skips the fields
+// whose prerequisites are not met by a packet, so the ports of all transport protocols can be included.
+func getServiceHashFields(ipProtocol binding.Protocol) []binding.HashField {
+	fields := []binding.HashField{binding.HashFieldIPSrc, binding.HashFieldIPDst}
+	if ipProtocol == binding.ProtocolIPv6 {
+		fields = []binding.HashField{binding.HashFieldIPv6Src, binding.HashFieldIPv6Dst}
+	}
+	return append(fields,
+		binding.HashFieldIPProto,
+		binding.HashFieldTCPSrc, binding.HashFieldTCPDst,
+		binding.HashFieldUDPSrc, binding.HashFieldUDPDst,
+		binding.HashFieldSCTPSrc, binding.HashFieldSCTPDst)
+}
+
 // decTTLFlows generates the flow to process TTL. For the packets forwarded across Nodes, TTL should be decremented by one;
 // for packets which enter OVS pipeline from the Antrea gateway, as the host IP stack should have decremented the TTL
 // already for such packets, TTL should not be decremented again.
@@ -2853,38 +3054,76 @@ func pipelineClassifyFlow(cookieID uint64, protocol binding.Protocol, pipeline b
 		Done()
 }
 
-// igmpEgressFlow generates flows to match IGMP report to jump to table MulticastRoutingTable.
+// igmpEgressFlows generates flows to match IGMP report to jump to table MulticastRoutingTable.
 // This is because normal multicast egress rule can match IGMP v1 report, when there is egress
 // rule to block multicast traffic, IGMP v1 report will also be blocked, which is not expected.
-func (f *featureMulticast) igmpEgressFlow() binding.Flow {
-	return MulticastEgressRuleTable.ofTable.BuildFlow(priorityTopAntreaPolicy).
-		Cookie(f.cookieAllocator.Request(f.category).Raw()).
-		MatchProtocol(binding.ProtocolIGMP).
-		MatchRegMark(FromLocalRegMark).
-		Action().GotoStage(stageRouting).
-		Done()
+// MLD reports are handled in the same way when IPv6 is enabled.
+func (f *featureMulticast) igmpEgressFlows() []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	var flows []binding.Flow
+	for _, ipProtocol := range f.ipProtocols {
+		switch ipProtocol {
+		case binding.ProtocolIP:
+			flows = append(flows, MulticastEgressRuleTable.ofTable.BuildFlow(priorityTopAntreaPolicy).
+				Cookie(cookieID).
+				MatchProtocol(binding.ProtocolIGMP).
+				MatchRegMark(FromLocalRegMark).
+				Action().GotoStage(stageRouting).
+				Done())
+		case binding.ProtocolIPv6:
+			for _, mldType := range mldReportTypes {
+				flows = append(flows, MulticastEgressRuleTable.ofTable.BuildFlow(priorityTopAntreaPolicy).
+					Cookie(cookieID).
+					MatchProtocol(binding.ProtocolICMPv6).
+					MatchICMPv6Type(mldType).
+					MatchRegMark(FromLocalRegMark).
+					Action().GotoStage(stageRouting).
+					Done())
+			}
+		}
+	}
+	return flows
 }
 
 // igmpPktInFlows generates the flow to load CustomReasonIGMPRegMark to mark the IGMP packet in MulticastRoutingTable
-// and sends it to antrea-agent.
+// and sends it to antrea-agent. When IPv6 is enabled, the MLD report and done messages are sent to antrea-agent with
+// the same category.
 func (f *featureMulticast) igmpPktInFlows() []binding.Flow {
 	var flows []binding.Flow
 	sourceMarks := []*binding.RegMark{FromLocalRegMark}
 	if f.encapEnabled {
 		sourceMarks = append(sourceMarks, FromTunnelRegMark)
 	}
-	for _, m := range sourceMarks {
-		flows = append(flows,
-			// Set a custom category for the IGMP packets, and then send it to antrea-agent. Then antrea-agent can identify
-			// the local multicast group and its members in the meanwhile.
-			// Do not set dst IP address because IGMPv1 report message uses target multicast group as IP destination in
-			// the packet.
-			MulticastRoutingTable.ofTable.BuildFlow(priorityHigh).
-				Cookie(f.cookieAllocator.Request(f.category).Raw()).
-				MatchProtocol(binding.ProtocolIGMP).
-				MatchRegMark(m).
-				Action().SendToController([]byte{uint8(PacketInCategoryIGMP)}, false).
-				Done())
+	for _, ipProtocol := range f.ipProtocols {
+		for _, m := range sourceMarks {
+			switch ipProtocol {
+			case binding.ProtocolIP:
+				flows = append(flows,
+					// Set a custom category for the IGMP packets, and then send it to antrea-agent. Then antrea-agent can identify
+					// the local multicast group and its members in the meanwhile.
+					// Do not set dst IP address because IGMPv1 report message uses target multicast group as IP destination in
+					// the packet.
+					MulticastRoutingTable.ofTable.BuildFlow(priorityHigh).
+						Cookie(f.cookieAllocator.Request(f.category).Raw()).
+						MatchProtocol(binding.ProtocolIGMP).
+						MatchRegMark(m).
+						Action().SendToController([]byte{uint8(PacketInCategoryIGMP)}, false).
+						Done())
+			case binding.ProtocolIPv6:
+				// MLD queries are not sent to antrea-agent, so only the ICMPv6 types of MLD reports and done messages
+				// are matched. Like IGMPv1, MLDv1 report message uses target multicast group as IP destination.
+				for _, mldType := range mldReportTypes {
+					flows = append(flows,
+						MulticastRoutingTable.ofTable.BuildFlow(priorityHigh).
+							Cookie(f.cookieAllocator.Request(f.category).Raw()).
+							MatchProtocol(binding.ProtocolICMPv6).
+							MatchICMPv6Type(mldType).
+							MatchRegMark(m).
+							Action().SendToController([]byte{uint8(PacketInCategoryIGMP)}, false).
+							Done())
+				}
+			}
+		}
 	}
 	return flows
 }
@@ -2898,27 +3137,46 @@ func (f *featureMulticast) localMulticastForwardFlows(multicastIP net.IP, groupI
 	return []binding.Flow{
 		MulticastRoutingTable.ofTable.BuildFlow(priorityNormal).
 			Cookie(f.cookieAllocator.Request(f.category).Raw()).
-			MatchProtocol(binding.ProtocolIP).
+			MatchProtocol(getIPProtocol(multicastIP)).
+			MatchDstIP(multicastIP).
+			Action().Group(groupID).
+			Done(),
+	}
+}
+
+// sourceSpecificMulticastForwardFlows generates the flow to forward the multicast packets sent from the given source to
+// the given SSM group with the OpenFlow group for the source, which has a higher priority than the flow generated by
+// function "localMulticastForwardFlows", so that only the local Pods including the source receive the packets.
+func (f *featureMulticast) sourceSpecificMulticastForwardFlows(multicastIP, sourceIP net.IP, groupID binding.GroupIDType) []binding.Flow {
+	return []binding.Flow{
+		MulticastRoutingTable.ofTable.BuildFlow(priorityNormal + 1).
+			Cookie(f.cookieAllocator.Request(f.category).Raw()).
+			MatchProtocol(getIPProtocol(multicastIP)).
+			MatchSrcIP(sourceIP).
 			MatchDstIP(multicastIP).
 			Action().Group(groupID).
 			Done(),
 	}
 }
 
-// externalMulticastReceiverFlow generates the flow to output multicast packets to Antrea gateway, so that local Pods can
+// externalMulticastReceiverFlows generates the flows to output multicast packets to Antrea gateway, so that local Pods can
 // send multicast packets to access the external receivers. For the case that one or more local Pods have joined the target
 // multicast group, it is handled by the flows created by function "localMulticastForwardFlows" after local Pods report the
 // IGMP membership.
 // Because there are ingress tables between MulticastRoutingTable and MulticastOutputTable, while currently ingress rules only
 // support IGMP query, it is not necessary to goto the ingress tables for other multicast traffic.
-func (f *featureMulticast) externalMulticastReceiverFlow() binding.Flow {
-	return MulticastRoutingTable.ofTable.BuildFlow(priorityLow).
-		Cookie(f.cookieAllocator.Request(f.category).Raw()).
-		MatchProtocol(binding.ProtocolIP).
-		Action().LoadRegMark(OutputToOFPortRegMark).
-		Action().LoadToRegField(TargetOFPortField, f.gatewayPort).
-		Action().GotoStage(stageOutput).
-		Done()
+func (f *featureMulticast) externalMulticastReceiverFlows() []binding.Flow {
+	var flows []binding.Flow
+	for _, ipProtocol := range f.ipProtocols {
+		flows = append(flows, MulticastRoutingTable.ofTable.BuildFlow(priorityLow).
+			Cookie(f.cookieAllocator.Request(f.category).Raw()).
+			MatchProtocol(ipProtocol).
+			Action().LoadRegMark(OutputToOFPortRegMark).
+			Action().LoadToRegField(TargetOFPortField, f.gatewayPort).
+			Action().GotoStage(stageOutput).
+			Done())
+	}
+	return flows
 }
 
 // NewClient is the constructor of the Client interface.
@@ -2928,6 +3186,7 @@ func NewClient(bridgeName string,
 	enableProxy bool,
 	enableAntreaPolicy bool,
 	enableL7NetworkPolicy bool,
+	enableL7FlowExporter bool,
 	enableEgress bool,
 	enableEgressTrafficShaping bool,
 	enableDenyTracking bool,
@@ -2950,6 +3209,7 @@ func NewClient(bridgeName string,
 		enableDSR:                  enableDSR,
 		enableAntreaPolicy:         enableAntreaPolicy,
 		enableL7NetworkPolicy:      enableL7NetworkPolicy,
+		enableL7FlowExporter:       enableL7FlowExporter,
 		enableDenyTracking:         enableDenyTracking,
 		enableEgress:               enableEgress,
 		enableEgressTrafficShaping: enableEgressTrafficShaping,
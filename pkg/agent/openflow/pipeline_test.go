@@ -17,11 +17,15 @@ package openflow
 import (
 	"testing"
 
+	"antrea.io/ofnet/ofctrl"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	"antrea.io/antrea/pkg/agent/config"
 	oftest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+	ovsoftest "antrea.io/antrea/pkg/ovs/openflow/testing"
 )
 
 func pipelineDefaultFlows(egressTrafficShapingEnabled, externalNodeEnabled, isEncap, isIPv4 bool) []string {
@@ -32,7 +36,8 @@ func pipelineDefaultFlows(egressTrafficShapingEnabled, externalNodeEnabled, isEn
 			"cookie=0x1000000000000, table=PipelineRootClassifier, priority=0 actions=drop",
 			"cookie=0x1000000000000, table=ConntrackZone, priority=0 actions=goto_table:ConntrackState",
 			"cookie=0x1000000000000, table=ConntrackState, priority=0 actions=goto_table:EgressSecurityClassifier",
-			"cookie=0x1000000000000, table=EgressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyEgressRule",
+			"cookie=0x1000000000000, table=EgressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyEgressAudit",
+			"cookie=0x1000000000000, table=AntreaPolicyEgressAudit, priority=0 actions=goto_table:AntreaPolicyEgressRule",
 			"cookie=0x1000000000000, table=AntreaPolicyEgressRule, priority=0 actions=goto_table:EgressRule",
 			"cookie=0x1000000000000, table=EgressRule, priority=0 actions=goto_table:EgressDefaultRule",
 			"cookie=0x1000000000000, table=EgressDefaultRule, priority=0 actions=goto_table:EgressMetric",
@@ -40,7 +45,8 @@ func pipelineDefaultFlows(egressTrafficShapingEnabled, externalNodeEnabled, isEn
 			"cookie=0x1000000000000, table=L3Forwarding, priority=0 actions=goto_table:EgressMark",
 			"cookie=0x1000000000000, table=EgressMark, priority=0 actions=goto_table:L2ForwardingCalc",
 			"cookie=0x1000000000000, table=L2ForwardingCalc, priority=0 actions=goto_table:IngressSecurityClassifier",
-			"cookie=0x1000000000000, table=IngressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyIngressRule",
+			"cookie=0x1000000000000, table=IngressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyIngressAudit",
+			"cookie=0x1000000000000, table=AntreaPolicyIngressAudit, priority=0 actions=goto_table:AntreaPolicyIngressRule",
 			"cookie=0x1000000000000, table=AntreaPolicyIngressRule, priority=0 actions=goto_table:IngressRule",
 			"cookie=0x1000000000000, table=IngressRule, priority=0 actions=goto_table:IngressDefaultRule",
 			"cookie=0x1000000000000, table=IngressDefaultRule, priority=0 actions=goto_table:IngressMetric",
@@ -63,7 +69,8 @@ func pipelineDefaultFlows(egressTrafficShapingEnabled, externalNodeEnabled, isEn
 			"cookie=0x1000000000000, table=PreRoutingClassifier, priority=0 actions=goto_table:SessionAffinity",
 			"cookie=0x1000000000000, table=SessionAffinity, priority=0 actions=goto_table:ServiceLB",
 			"cookie=0x1000000000000, table=ServiceLB, priority=0 actions=goto_table:EndpointDNAT",
-			"cookie=0x1000000000000, table=EndpointDNAT, priority=0 actions=goto_table:AntreaPolicyEgressRule",
+			"cookie=0x1000000000000, table=EndpointDNAT, priority=0 actions=goto_table:AntreaPolicyEgressAudit",
+			"cookie=0x1000000000000, table=AntreaPolicyEgressAudit, priority=0 actions=goto_table:AntreaPolicyEgressRule",
 			"cookie=0x1000000000000, table=AntreaPolicyEgressRule, priority=0 actions=goto_table:EgressRule",
 			"cookie=0x1000000000000, table=EgressRule, priority=0 actions=goto_table:EgressDefaultRule",
 			"cookie=0x1000000000000, table=EgressDefaultRule, priority=0 actions=goto_table:EgressMetric",
@@ -74,7 +81,8 @@ func pipelineDefaultFlows(egressTrafficShapingEnabled, externalNodeEnabled, isEn
 			"cookie=0x1000000000000, table=SNAT, priority=0 actions=goto_table:L2ForwardingCalc",
 			"cookie=0x1000000000000, table=L2ForwardingCalc, priority=0 actions=goto_table:TrafficControl",
 			"cookie=0x1000000000000, table=TrafficControl, priority=0 actions=goto_table:IngressSecurityClassifier",
-			"cookie=0x1000000000000, table=IngressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyIngressRule",
+			"cookie=0x1000000000000, table=IngressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyIngressAudit",
+			"cookie=0x1000000000000, table=AntreaPolicyIngressAudit, priority=0 actions=goto_table:AntreaPolicyIngressRule",
 			"cookie=0x1000000000000, table=AntreaPolicyIngressRule, priority=0 actions=goto_table:IngressRule",
 			"cookie=0x1000000000000, table=IngressRule, priority=0 actions=goto_table:IngressDefaultRule",
 			"cookie=0x1000000000000, table=IngressDefaultRule, priority=0 actions=goto_table:IngressMetric",
@@ -132,7 +140,8 @@ func pipelineDefaultFlows(egressTrafficShapingEnabled, externalNodeEnabled, isEn
 			"cookie=0x1000000000000, table=PreRoutingClassifier, priority=0 actions=goto_table:SessionAffinity",
 			"cookie=0x1000000000000, table=SessionAffinity, priority=0 actions=goto_table:ServiceLB",
 			"cookie=0x1000000000000, table=ServiceLB, priority=0 actions=goto_table:EndpointDNAT",
-			"cookie=0x1000000000000, table=EndpointDNAT, priority=0 actions=goto_table:AntreaPolicyEgressRule",
+			"cookie=0x1000000000000, table=EndpointDNAT, priority=0 actions=goto_table:AntreaPolicyEgressAudit",
+			"cookie=0x1000000000000, table=AntreaPolicyEgressAudit, priority=0 actions=goto_table:AntreaPolicyEgressRule",
 			"cookie=0x1000000000000, table=AntreaPolicyEgressRule, priority=0 actions=goto_table:EgressRule",
 			"cookie=0x1000000000000, table=EgressRule, priority=0 actions=goto_table:EgressDefaultRule",
 			"cookie=0x1000000000000, table=EgressDefaultRule, priority=0 actions=goto_table:EgressMetric",
@@ -144,7 +153,8 @@ func pipelineDefaultFlows(egressTrafficShapingEnabled, externalNodeEnabled, isEn
 			"cookie=0x1000000000000, table=SNAT, priority=0 actions=goto_table:L2ForwardingCalc",
 			"cookie=0x1000000000000, table=L2ForwardingCalc, priority=0 actions=goto_table:TrafficControl",
 			"cookie=0x1000000000000, table=TrafficControl, priority=0 actions=goto_table:IngressSecurityClassifier",
-			"cookie=0x1000000000000, table=IngressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyIngressRule",
+			"cookie=0x1000000000000, table=IngressSecurityClassifier, priority=0 actions=goto_table:AntreaPolicyIngressAudit",
+			"cookie=0x1000000000000, table=AntreaPolicyIngressAudit, priority=0 actions=goto_table:AntreaPolicyIngressRule",
 			"cookie=0x1000000000000, table=AntreaPolicyIngressRule, priority=0 actions=goto_table:IngressRule",
 			"cookie=0x1000000000000, table=IngressRule, priority=0 actions=goto_table:IngressDefaultRule",
 			"cookie=0x1000000000000, table=IngressDefaultRule, priority=0 actions=goto_table:IngressMetric",
@@ -228,3 +238,125 @@ func Test_client_defaultFlows(t *testing.T) {
 		})
 	}
 }
+
+func Test_featureNetworkPolicy_rateLimitRuleMetricFlows(t *testing.T) {
+	conjunctionID := uint32(10)
+	// The meter IDs derived from the conjunction ID.
+	assert.Equal(t, binding.MeterIDType(1044), rateLimitConnectionMeterID(conjunctionID))
+	assert.Equal(t, binding.MeterIDType(1045), rateLimitBandwidthMeterID(conjunctionID))
+
+	testCases := []struct {
+		name          string
+		enableIPv6    bool
+		ingress       bool
+		rateLimit     *v1beta2.RuleRateLimit
+		expectedFlows []string
+	}{
+		{
+			name:      "ingress connection limit",
+			ingress:   true,
+			rateLimit: &v1beta2.RuleRateLimit{ConnectionsPerSecond: 100},
+			expectedFlows: []string{
+				"cookie=0x1020000000000, table=IngressMetric, priority=200,ct_state=+new,ct_label=0xa/0xffffffff,ip actions=meter:1044,goto_table:ConntrackCommit",
+				"cookie=0x1020000000000, table=IngressMetric, priority=200,ct_state=-new,ct_label=0xa/0xffffffff,ip actions=goto_table:ConntrackCommit",
+			},
+		},
+		{
+			name:      "ingress bandwidth limit",
+			ingress:   true,
+			rateLimit: &v1beta2.RuleRateLimit{BandwidthRate: 10000},
+			expectedFlows: []string{
+				"cookie=0x1020000000000, table=IngressMetric, priority=200,ct_state=+new,ct_label=0xa/0xffffffff,ip actions=meter:1045,goto_table:ConntrackCommit",
+				"cookie=0x1020000000000, table=IngressMetric, priority=200,ct_state=-new,ct_label=0xa/0xffffffff,ip actions=meter:1045,goto_table:ConntrackCommit",
+			},
+		},
+		{
+			name:      "ingress connection and bandwidth limits",
+			ingress:   true,
+			rateLimit: &v1beta2.RuleRateLimit{ConnectionsPerSecond: 100, BandwidthRate: 10000, BandwidthBurst: 20000},
+			expectedFlows: []string{
+				"cookie=0x1020000000000, table=IngressMetric, priority=200,ct_state=+new,ct_label=0xa/0xffffffff,ip actions=meter:1044,goto_table:ConntrackCommit",
+				"cookie=0x1020000000000, table=IngressMetric, priority=200,ct_state=-new,ct_label=0xa/0xffffffff,ip actions=meter:1045,goto_table:ConntrackCommit",
+			},
+		},
+		{
+			name:       "egress connection and bandwidth limits in dual-stack cluster",
+			enableIPv6: true,
+			rateLimit:  &v1beta2.RuleRateLimit{ConnectionsPerSecond: 100, BandwidthRate: 10000},
+			expectedFlows: []string{
+				"cookie=0x1020000000000, table=EgressMetric, priority=200,ct_state=+new,ct_label=0xa00000000/0xffffffff00000000,ip actions=meter:1044,goto_table:L3Forwarding",
+				"cookie=0x1020000000000, table=EgressMetric, priority=200,ct_state=-new,ct_label=0xa00000000/0xffffffff00000000,ip actions=meter:1045,goto_table:L3Forwarding",
+				"cookie=0x1020000000000, table=EgressMetric, priority=200,ct_state=+new,ct_label=0xa00000000/0xffffffff00000000,ipv6 actions=meter:1044,goto_table:L3Forwarding",
+				"cookie=0x1020000000000, table=EgressMetric, priority=200,ct_state=-new,ct_label=0xa00000000/0xffffffff00000000,ipv6 actions=meter:1045,goto_table:L3Forwarding",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeClient(nil, true, tc.enableIPv6, config.K8sNode, config.TrafficEncapModeEncap, setEnableOVSMeters(true))
+			defer resetPipelines()
+
+			flows := fc.featureNetworkPolicy.rateLimitRuleMetricFlows(conjunctionID, tc.ingress, tc.rateLimit)
+			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(flows))
+		})
+	}
+}
+
+func Test_featureNetworkPolicy_rateLimitMeters(t *testing.T) {
+	conjunctionID := uint32(10)
+	type expectedMeter struct {
+		id    binding.MeterIDType
+		flags ofctrl.MeterFlag
+		rate  uint32
+		burst uint32
+	}
+	connectionMeter := expectedMeter{id: 1044, flags: ofctrl.MeterBurst | ofctrl.MeterPktps, rate: 100, burst: 100}
+	testCases := []struct {
+		name           string
+		rateLimit      *v1beta2.RuleRateLimit
+		expectedMeters []expectedMeter
+	}{
+		{
+			name:           "connection limit",
+			rateLimit:      &v1beta2.RuleRateLimit{ConnectionsPerSecond: 100},
+			expectedMeters: []expectedMeter{connectionMeter},
+		},
+		{
+			name:      "bandwidth limit with default burst",
+			rateLimit: &v1beta2.RuleRateLimit{BandwidthRate: 10000},
+			expectedMeters: []expectedMeter{
+				{id: 1045, flags: ofctrl.MeterBurst | ofctrl.MeterKbps, rate: 10000, burst: 10000},
+			},
+		},
+		{
+			name:      "connection and bandwidth limits",
+			rateLimit: &v1beta2.RuleRateLimit{ConnectionsPerSecond: 100, BandwidthRate: 10000, BandwidthBurst: 20000},
+			expectedMeters: []expectedMeter{
+				connectionMeter,
+				{id: 1045, flags: ofctrl.MeterBurst | ofctrl.MeterKbps, rate: 10000, burst: 20000},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bridge := ovsoftest.NewMockBridge(ctrl)
+			fc := newFakeClientWithBridge(nil, true, false, config.K8sNode, config.TrafficEncapModeEncap, bridge, setEnableOVSMeters(true))
+			defer resetPipelines()
+
+			var meters []binding.Meter
+			for _, m := range tc.expectedMeters {
+				meter := ovsoftest.NewMockMeter(ctrl)
+				meterBuilder := ovsoftest.NewMockMeterBandBuilder(ctrl)
+				bridge.EXPECT().NewMeter(m.id, m.flags).Return(meter)
+				meter.EXPECT().MeterBand().Return(meterBuilder)
+				meterBuilder.EXPECT().MeterType(ofctrl.MeterDrop).Return(meterBuilder)
+				meterBuilder.EXPECT().Rate(m.rate).Return(meterBuilder)
+				meterBuilder.EXPECT().Burst(m.burst).Return(meterBuilder)
+				meterBuilder.EXPECT().Done().Return(meter)
+				meters = append(meters, meter)
+			}
+			assert.Equal(t, meters, fc.featureNetworkPolicy.rateLimitMeters(conjunctionID, tc.rateLimit))
+		})
+	}
+}
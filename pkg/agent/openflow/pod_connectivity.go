@@ -234,6 +234,27 @@ func (f *featurePodConnectivity) trafficControlMarkFlows(sourceOFPorts []uint32,
 	return flows
 }
 
+// l7FlowExporterMarkFlows generates the flows to mark the packets sourced from or destined for the provided port with
+// L7FlowExporterRegMark, so that they are mirrored to the L7 engine. The flows are in a dedicated table before
+// TrafficControlTable, so that the packets can also be marked by the flows of TrafficControl rules.
+func (f *featurePodConnectivity) l7FlowExporterMarkFlows(podOFPort uint32) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	return []binding.Flow{
+		L7FlowExporterMarkTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchRegFieldWithValue(TargetOFPortField, podOFPort).
+			Action().LoadRegMark(L7FlowExporterRegMark).
+			Action().NextTable().
+			Done(),
+		L7FlowExporterMarkTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchInPort(podOFPort).
+			Action().LoadRegMark(L7FlowExporterRegMark).
+			Action().NextTable().
+			Done(),
+	}
+}
+
 // trafficControlReturnClassifierFlow generates the flow to mark the packets from traffic control return port and forward
 // the packets to stageRouting directly. Note that, for the packets which are originally to be output to a tunnel port,
 // value of NXM_NX_TUN_IPV4_DST for the returned packets needs to be loaded in stageRouting.
@@ -24,8 +24,26 @@ import (
 	"antrea.io/antrea/pkg/agent/nodeip"
 	"antrea.io/antrea/pkg/agent/openflow/cookie"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
+	"antrea.io/antrea/third_party/proxy"
 )
 
+// defaultEndpointWeight is the weight of the bucket of an Endpoint which doesn't specify its weight.
+const defaultEndpointWeight = 100
+
+// WeightedEndpoint is an Endpoint which specifies the weight of its bucket in the group of a Service. The probability
+// of selecting an Endpoint is its weight divided by the sum of the weights of all Endpoints in the group.
+type WeightedEndpoint interface {
+	proxy.Endpoint
+	GetWeight() uint16
+}
+
+func getEndpointWeight(endpoint proxy.Endpoint) uint16 {
+	if weightedEndpoint, ok := endpoint.(WeightedEndpoint); ok {
+		return weightedEndpoint.GetWeight()
+	}
+	return defaultEndpointWeight
+}
+
 type featureService struct {
 	cookieAllocator cookie.Allocator
 	nodeIPChecker   nodeip.Checker
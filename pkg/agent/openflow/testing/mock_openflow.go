@@ -293,6 +293,20 @@ func (mr *MockClientMockRecorder) InstallEndpointFlows(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallEndpointFlows", reflect.TypeOf((*MockClient)(nil).InstallEndpointFlows), arg0, arg1)
 }
 
+// InstallL7FlowExporterPodFlows mocks base method.
+func (m *MockClient) InstallL7FlowExporterPodFlows(arg0 string, arg1 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallL7FlowExporterPodFlows", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallL7FlowExporterPodFlows indicates an expected call of InstallL7FlowExporterPodFlows.
+func (mr *MockClientMockRecorder) InstallL7FlowExporterPodFlows(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallL7FlowExporterPodFlows", reflect.TypeOf((*MockClient)(nil).InstallL7FlowExporterPodFlows), arg0, arg1)
+}
+
 // InstallMulticastFlows mocks base method.
 func (m *MockClient) InstallMulticastFlows(arg0 net.IP, arg1 openflow.GroupIDType) error {
 	m.ctrl.T.Helper()
@@ -335,6 +349,20 @@ func (mr *MockClientMockRecorder) InstallMulticastRemoteReportFlows(arg0 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticastRemoteReportFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticastRemoteReportFlows), arg0)
 }
 
+// InstallMulticastSourceFlows mocks base method.
+func (m *MockClient) InstallMulticastSourceFlows(arg0, arg1 net.IP, arg2 openflow.GroupIDType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallMulticastSourceFlows", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallMulticastSourceFlows indicates an expected call of InstallMulticastSourceFlows.
+func (mr *MockClientMockRecorder) InstallMulticastSourceFlows(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticastSourceFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticastSourceFlows), arg0, arg1, arg2)
+}
+
 // InstallMulticlusterClassifierFlows mocks base method.
 func (m *MockClient) InstallMulticlusterClassifierFlows(arg0 uint32, arg1 bool) error {
 	m.ctrl.T.Helper()
@@ -350,31 +378,31 @@ func (mr *MockClientMockRecorder) InstallMulticlusterClassifierFlows(arg0, arg1
 }
 
 // InstallMulticlusterGatewayFlows mocks base method.
-func (m *MockClient) InstallMulticlusterGatewayFlows(arg0 string, arg1 map[*net.IPNet]net.IP, arg2, arg3 net.IP, arg4 bool) error {
+func (m *MockClient) InstallMulticlusterGatewayFlows(arg0 string, arg1 []*net.IPNet, arg2 []net.IP, arg3 net.IP, arg4, arg5 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallMulticlusterGatewayFlows", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "InstallMulticlusterGatewayFlows", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallMulticlusterGatewayFlows indicates an expected call of InstallMulticlusterGatewayFlows.
-func (mr *MockClientMockRecorder) InstallMulticlusterGatewayFlows(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockClientMockRecorder) InstallMulticlusterGatewayFlows(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterGatewayFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterGatewayFlows), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterGatewayFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterGatewayFlows), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // InstallMulticlusterNodeFlows mocks base method.
-func (m *MockClient) InstallMulticlusterNodeFlows(arg0 string, arg1 map[*net.IPNet]net.IP, arg2 net.IP, arg3 bool) error {
+func (m *MockClient) InstallMulticlusterNodeFlows(arg0 string, arg1 []*net.IPNet, arg2, arg3 []net.IP, arg4 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallMulticlusterNodeFlows", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "InstallMulticlusterNodeFlows", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallMulticlusterNodeFlows indicates an expected call of InstallMulticlusterNodeFlows.
-func (mr *MockClientMockRecorder) InstallMulticlusterNodeFlows(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockClientMockRecorder) InstallMulticlusterNodeFlows(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterNodeFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterNodeFlows), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterNodeFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterNodeFlows), arg0, arg1, arg2, arg3, arg4)
 }
 
 // InstallMulticlusterPodFlows mocks base method.
@@ -504,17 +532,17 @@ func (mr *MockClientMockRecorder) InstallServiceFlows(arg0 any) *gomock.Call {
 }
 
 // InstallServiceGroup mocks base method.
-func (m *MockClient) InstallServiceGroup(arg0 openflow.GroupIDType, arg1 bool, arg2 []proxy.Endpoint) error {
+func (m *MockClient) InstallServiceGroup(arg0 openflow.GroupIDType, arg1, arg2 bool, arg3 []proxy.Endpoint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallServiceGroup", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "InstallServiceGroup", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallServiceGroup indicates an expected call of InstallServiceGroup.
-func (mr *MockClientMockRecorder) InstallServiceGroup(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockClientMockRecorder) InstallServiceGroup(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallServiceGroup", reflect.TypeOf((*MockClient)(nil).InstallServiceGroup), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallServiceGroup", reflect.TypeOf((*MockClient)(nil).InstallServiceGroup), arg0, arg1, arg2, arg3)
 }
 
 // InstallTraceflowFlows mocks base method.
@@ -875,6 +903,20 @@ func (mr *MockClientMockRecorder) UninstallEndpointFlows(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallEndpointFlows", reflect.TypeOf((*MockClient)(nil).UninstallEndpointFlows), arg0, arg1)
 }
 
+// UninstallL7FlowExporterPodFlows mocks base method.
+func (m *MockClient) UninstallL7FlowExporterPodFlows(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallL7FlowExporterPodFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallL7FlowExporterPodFlows indicates an expected call of UninstallL7FlowExporterPodFlows.
+func (mr *MockClientMockRecorder) UninstallL7FlowExporterPodFlows(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallL7FlowExporterPodFlows", reflect.TypeOf((*MockClient)(nil).UninstallL7FlowExporterPodFlows), arg0)
+}
+
 // UninstallMulticastFlows mocks base method.
 func (m *MockClient) UninstallMulticastFlows(arg0 net.IP) error {
 	m.ctrl.T.Helper()
@@ -903,6 +945,20 @@ func (mr *MockClientMockRecorder) UninstallMulticastGroup(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallMulticastGroup", reflect.TypeOf((*MockClient)(nil).UninstallMulticastGroup), arg0)
 }
 
+// UninstallMulticastSourceFlows mocks base method.
+func (m *MockClient) UninstallMulticastSourceFlows(arg0, arg1 net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallMulticastSourceFlows", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallMulticastSourceFlows indicates an expected call of UninstallMulticastSourceFlows.
+func (mr *MockClientMockRecorder) UninstallMulticastSourceFlows(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallMulticastSourceFlows", reflect.TypeOf((*MockClient)(nil).UninstallMulticastSourceFlows), arg0, arg1)
+}
+
 // UninstallMulticlusterFlows mocks base method.
 func (m *MockClient) UninstallMulticlusterFlows(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,253 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/bpf"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86DD
+
+	protocolICMP   = 1
+	protocolTCP    = 6
+	protocolUDP    = 17
+	protocolICMPv6 = 58
+	protocolSCTP   = 132
+
+	// The offsets of the fields in an Ethernet frame without VLAN tag.
+	etherTypeOffset  = 12
+	ipv4HeaderOffset = 14
+	ipv4ProtoOffset  = ipv4HeaderOffset + 9
+	ipv4FlagsOffset  = ipv4HeaderOffset + 6
+	ipv4SrcIPOffset  = ipv4HeaderOffset + 12
+	ipv4DstIPOffset  = ipv4HeaderOffset + 16
+	ipv6HeaderOffset = 14
+	ipv6ProtoOffset  = ipv6HeaderOffset + 6
+	ipv6SrcIPOffset  = ipv6HeaderOffset + 8
+	ipv6DstIPOffset  = ipv6HeaderOffset + 24
+	ipv6L4Offset     = ipv6HeaderOffset + 40
+	// Mask of the fragment offset field in the IPv4 header.
+	ipv4FragmentOffsetMask = 0x1fff
+
+	// The maximum number of bytes of a packet to capture.
+	snapLen = 262144
+)
+
+// packetFilter describes the packets to capture, in a single direction. An
+// unset field matches any value.
+type packetFilter struct {
+	srcIP    net.IP
+	dstIP    net.IP
+	protocol uint8
+	srcPort  uint16
+	dstPort  uint16
+}
+
+func (f *packetFilter) reverse() *packetFilter {
+	return &packetFilter{
+		srcIP:    f.dstIP,
+		dstIP:    f.srcIP,
+		protocol: f.protocol,
+		srcPort:  f.dstPort,
+		dstPort:  f.srcPort,
+	}
+}
+
+func (f *packetFilter) isSymmetric() bool {
+	return f.srcIP.Equal(f.dstIP) && f.srcPort == f.dstPort
+}
+
+// parseProtocol returns the IP protocol number of the provided protocol, which
+// can be a protocol name or a number.
+func parseProtocol(protocol *intstr.IntOrString, isIPv6 bool) (uint8, error) {
+	if protocol == nil {
+		return 0, nil
+	}
+	if protocol.Type == intstr.Int {
+		if protocol.IntVal < 0 || protocol.IntVal > 255 {
+			return 0, fmt.Errorf("invalid IP protocol number %d", protocol.IntVal)
+		}
+		return uint8(protocol.IntVal), nil
+	}
+	switch strings.ToUpper(protocol.StrVal) {
+	case "TCP":
+		return protocolTCP, nil
+	case "UDP":
+		return protocolUDP, nil
+	case "SCTP":
+		return protocolSCTP, nil
+	case "ICMP":
+		if isIPv6 {
+			return protocolICMPv6, nil
+		}
+		return protocolICMP, nil
+	case "ICMPV6":
+		return protocolICMPv6, nil
+	}
+	return 0, fmt.Errorf("unsupported protocol %s", protocol.StrVal)
+}
+
+// newPacketFilter validates the packet spec of a PacketCapture and returns the
+// packetFilter of the packets sent from srcIP to dstIP. srcIP and dstIP can be
+// nil, but they must be of the same IP family if both are set.
+func newPacketFilter(packet *crdv1alpha1.PacketCaptureFilter, srcIP, dstIP net.IP, isIPv6 bool) (*packetFilter, error) {
+	filter := &packetFilter{srcIP: srcIP, dstIP: dstIP}
+	if packet == nil {
+		return filter, nil
+	}
+	protocol, err := parseProtocol(packet.Protocol, isIPv6)
+	if err != nil {
+		return nil, err
+	}
+	filter.protocol = protocol
+	if packet.SrcPort == nil && packet.DstPort == nil {
+		return filter, nil
+	}
+	if protocol != protocolTCP && protocol != protocolUDP && protocol != protocolSCTP {
+		return nil, fmt.Errorf("ports can only be specified for TCP, UDP or SCTP")
+	}
+	if packet.SrcPort != nil {
+		filter.srcPort = uint16(*packet.SrcPort)
+	}
+	if packet.DstPort != nil {
+		filter.dstPort = uint16(*packet.DstPort)
+	}
+	return filter, nil
+}
+
+// compilePacketFilter generates a BPF program which matches the Ethernet frames
+// of the packets described by filter, in both directions.
+//
+// The program consists of a check of the EtherType, followed by a block of
+// instructions for each direction. A block returns snapLen when all its checks
+// pass, otherwise it jumps to the next block, and the program drops the frame
+// if no block matches. IPv6 extension headers are not supported, i.e. the
+// ports of an IPv6 packet are only checked if the transport header follows the
+// fixed header directly.
+func compilePacketFilter(filter *packetFilter, isIPv6 bool) []bpf.Instruction {
+	blocks := [][]bpf.Instruction{compileDirection(filter, isIPv6)}
+	if !filter.isSymmetric() {
+		blocks = append(blocks, compileDirection(filter.reverse(), isIPv6))
+	}
+
+	etherType := uint32(etherTypeIPv4)
+	if isIPv6 {
+		etherType = etherTypeIPv6
+	}
+	length := 2
+	for _, block := range blocks {
+		length += len(block)
+	}
+	// The drop instruction is the last one, after the blocks.
+	inst := []bpf.Instruction{
+		bpf.LoadAbsolute{Off: etherTypeOffset, Size: 2},
+		bpf.JumpIf{Cond: bpf.JumpEqual, Val: etherType, SkipFalse: uint8(length - 2)},
+	}
+	for _, block := range blocks {
+		inst = append(inst, block...)
+	}
+	return append(inst, bpf.RetConstant{Val: 0})
+}
+
+// compileDirection generates the block of instructions matching the packets
+// sent in a single direction. The jumps in the block skip to the end of the
+// block when a check fails.
+func compileDirection(filter *packetFilter, isIPv6 bool) []bpf.Instruction {
+	var inst []bpf.Instruction
+	// The SkipFalse (or SkipTrue) of each jump is a placeholder, and is
+	// replaced with the distance to the end of the block at last.
+	var jumps []int
+	jumpIfNotEqual := func(val uint32) {
+		jumps = append(jumps, len(inst))
+		inst = append(inst, bpf.JumpIf{Cond: bpf.JumpEqual, Val: val})
+	}
+	loadAndCompareIP := func(ip net.IP, offset uint32) {
+		if isIPv6 {
+			ip = ip.To16()
+			for i := uint32(0); i < net.IPv6len; i += 4 {
+				inst = append(inst, bpf.LoadAbsolute{Off: offset + i, Size: 4})
+				jumpIfNotEqual(binary.BigEndian.Uint32(ip[i : i+4]))
+			}
+		} else {
+			inst = append(inst, bpf.LoadAbsolute{Off: offset, Size: 4})
+			jumpIfNotEqual(binary.BigEndian.Uint32(ip.To4()))
+		}
+	}
+
+	srcIPOffset, dstIPOffset, protoOffset := uint32(ipv4SrcIPOffset), uint32(ipv4DstIPOffset), uint32(ipv4ProtoOffset)
+	if isIPv6 {
+		srcIPOffset, dstIPOffset, protoOffset = ipv6SrcIPOffset, ipv6DstIPOffset, ipv6ProtoOffset
+	}
+	if filter.srcIP != nil {
+		loadAndCompareIP(filter.srcIP, srcIPOffset)
+	}
+	if filter.dstIP != nil {
+		loadAndCompareIP(filter.dstIP, dstIPOffset)
+	}
+	if filter.protocol != 0 {
+		inst = append(inst, bpf.LoadAbsolute{Off: protoOffset, Size: 1})
+		jumpIfNotEqual(uint32(filter.protocol))
+	}
+	if filter.srcPort != 0 || filter.dstPort != 0 {
+		if isIPv6 {
+			if filter.srcPort != 0 {
+				inst = append(inst, bpf.LoadAbsolute{Off: ipv6L4Offset, Size: 2})
+				jumpIfNotEqual(uint32(filter.srcPort))
+			}
+			if filter.dstPort != 0 {
+				inst = append(inst, bpf.LoadAbsolute{Off: ipv6L4Offset + 2, Size: 2})
+				jumpIfNotEqual(uint32(filter.dstPort))
+			}
+		} else {
+			// Only the first fragment of a packet has the transport header.
+			inst = append(inst, bpf.LoadAbsolute{Off: ipv4FlagsOffset, Size: 2})
+			jumps = append(jumps, len(inst))
+			inst = append(inst, bpf.JumpIf{Cond: bpf.JumpBitsSet, Val: ipv4FragmentOffsetMask})
+			// X = the length of the IPv4 header.
+			inst = append(inst, bpf.LoadMemShift{Off: ipv4HeaderOffset})
+			if filter.srcPort != 0 {
+				inst = append(inst, bpf.LoadIndirect{Off: ipv4HeaderOffset, Size: 2})
+				jumpIfNotEqual(uint32(filter.srcPort))
+			}
+			if filter.dstPort != 0 {
+				inst = append(inst, bpf.LoadIndirect{Off: ipv4HeaderOffset + 2, Size: 2})
+				jumpIfNotEqual(uint32(filter.dstPort))
+			}
+		}
+	}
+	inst = append(inst, bpf.RetConstant{Val: snapLen})
+
+	for _, i := range jumps {
+		jump := inst[i].(bpf.JumpIf)
+		skip := uint8(len(inst) - i - 1)
+		if jump.Cond == bpf.JumpBitsSet {
+			jump.SkipTrue = skip
+		} else {
+			jump.SkipFalse = skip
+		}
+		inst[i] = jump
+	}
+	return inst
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/bpf"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+)
+
+func protocolPtr(protocol intstr.IntOrString) *intstr.IntOrString {
+	return &protocol
+}
+
+// buildFrame builds an Ethernet frame holding an IP packet with the provided
+// addresses, protocol and ports.
+func buildFrame(srcIP, dstIP net.IP, protocol uint8, srcPort, dstPort uint16, fragmentOffset uint16) []byte {
+	frame := make([]byte, 14)
+	var l4 []byte
+	if protocol == protocolTCP || protocol == protocolUDP || protocol == protocolSCTP {
+		l4 = make([]byte, 20)
+		binary.BigEndian.PutUint16(l4[0:2], srcPort)
+		binary.BigEndian.PutUint16(l4[2:4], dstPort)
+	} else {
+		l4 = make([]byte, 8)
+	}
+	if srcIP.To4() != nil {
+		binary.BigEndian.PutUint16(frame[12:14], etherTypeIPv4)
+		ipHeader := make([]byte, 20)
+		ipHeader[0] = 0x45
+		binary.BigEndian.PutUint16(ipHeader[6:8], fragmentOffset)
+		ipHeader[9] = protocol
+		copy(ipHeader[12:16], srcIP.To4())
+		copy(ipHeader[16:20], dstIP.To4())
+		frame = append(frame, ipHeader...)
+	} else {
+		binary.BigEndian.PutUint16(frame[12:14], etherTypeIPv6)
+		ipHeader := make([]byte, 40)
+		ipHeader[0] = 0x60
+		ipHeader[6] = protocol
+		copy(ipHeader[8:24], srcIP.To16())
+		copy(ipHeader[24:40], dstIP.To16())
+		frame = append(frame, ipHeader...)
+	}
+	return append(frame, l4...)
+}
+
+func TestCompilePacketFilter(t *testing.T) {
+	podIP := net.ParseIP("10.10.0.5")
+	peerIP := net.ParseIP("10.10.1.6")
+	otherIP := net.ParseIP("10.10.1.7")
+	podIPv6 := net.ParseIP("fec0::5")
+	peerIPv6 := net.ParseIP("fec0::1:6")
+
+	tests := []struct {
+		name     string
+		packet   *crdv1alpha1.PacketCaptureFilter
+		srcIP    net.IP
+		dstIP    net.IP
+		isIPv6   bool
+		frames   [][]byte
+		expected []bool
+	}{
+		{
+			name:  "IPs only",
+			srcIP: podIP,
+			dstIP: peerIP,
+			frames: [][]byte{
+				buildFrame(podIP, peerIP, protocolTCP, 10000, 80, 0),
+				buildFrame(peerIP, podIP, protocolUDP, 53, 10000, 0),
+				buildFrame(podIP, otherIP, protocolTCP, 10000, 80, 0),
+				buildFrame(podIPv6, peerIPv6, protocolTCP, 10000, 80, 0),
+			},
+			expected: []bool{true, true, false, false},
+		},
+		{
+			name:  "any destination",
+			srcIP: podIP,
+			frames: [][]byte{
+				buildFrame(podIP, peerIP, protocolTCP, 10000, 80, 0),
+				buildFrame(otherIP, podIP, protocolTCP, 80, 10000, 0),
+				buildFrame(otherIP, peerIP, protocolTCP, 80, 10000, 0),
+			},
+			expected: []bool{true, true, false},
+		},
+		{
+			name: "protocol and destination port",
+			packet: &crdv1alpha1.PacketCaptureFilter{
+				Protocol: protocolPtr(intstr.FromString("TCP")),
+				DstPort:  pointer.Int32(80),
+			},
+			srcIP: podIP,
+			dstIP: peerIP,
+			frames: [][]byte{
+				buildFrame(podIP, peerIP, protocolTCP, 10000, 80, 0),
+				buildFrame(peerIP, podIP, protocolTCP, 80, 10000, 0),
+				buildFrame(podIP, peerIP, protocolUDP, 10000, 80, 0),
+				buildFrame(podIP, peerIP, protocolTCP, 10000, 8080, 0),
+				buildFrame(podIP, peerIP, protocolTCP, 10000, 80, 100),
+			},
+			expected: []bool{true, true, false, false, false},
+		},
+		{
+			name: "protocol number",
+			packet: &crdv1alpha1.PacketCaptureFilter{
+				Protocol: protocolPtr(intstr.FromInt(protocolICMP)),
+			},
+			srcIP: podIP,
+			dstIP: peerIP,
+			frames: [][]byte{
+				buildFrame(podIP, peerIP, protocolICMP, 0, 0, 0),
+				buildFrame(podIP, peerIP, protocolTCP, 10000, 80, 0),
+			},
+			expected: []bool{true, false},
+		},
+		{
+			name: "IPv6",
+			packet: &crdv1alpha1.PacketCaptureFilter{
+				Protocol: protocolPtr(intstr.FromString("UDP")),
+				SrcPort:  pointer.Int32(10000),
+			},
+			srcIP:  podIPv6,
+			dstIP:  peerIPv6,
+			isIPv6: true,
+			frames: [][]byte{
+				buildFrame(podIPv6, peerIPv6, protocolUDP, 10000, 53, 0),
+				buildFrame(peerIPv6, podIPv6, protocolUDP, 53, 10000, 0),
+				buildFrame(podIPv6, peerIPv6, protocolUDP, 10001, 53, 0),
+				buildFrame(podIP, peerIP, protocolUDP, 10000, 53, 0),
+			},
+			expected: []bool{true, true, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newPacketFilter(tt.packet, tt.srcIP, tt.dstIP, tt.isIPv6)
+			require.NoError(t, err)
+			inst := compilePacketFilter(filter, tt.isIPv6)
+			_, err = bpf.Assemble(inst)
+			require.NoError(t, err)
+			vm, err := bpf.NewVM(inst)
+			require.NoError(t, err)
+			for i, frame := range tt.frames {
+				n, err := vm.Run(frame)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected[i], n > 0, "Unexpected result for frame %d", i)
+			}
+		})
+	}
+}
+
+func TestNewPacketFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		packet      *crdv1alpha1.PacketCaptureFilter
+		isIPv6      bool
+		expected    *packetFilter
+		expectedErr string
+	}{
+		{
+			name:     "ICMP for IPv6",
+			packet:   &crdv1alpha1.PacketCaptureFilter{Protocol: protocolPtr(intstr.FromString("icmp"))},
+			isIPv6:   true,
+			expected: &packetFilter{protocol: protocolICMPv6},
+		},
+		{
+			name:        "unsupported protocol",
+			packet:      &crdv1alpha1.PacketCaptureFilter{Protocol: protocolPtr(intstr.FromString("foo"))},
+			expectedErr: "unsupported protocol foo",
+		},
+		{
+			name: "ports without protocol",
+			packet: &crdv1alpha1.PacketCaptureFilter{
+				DstPort: pointer.Int32(80),
+			},
+			expectedErr: "ports can only be specified for TCP, UDP or SCTP",
+		},
+		{
+			name: "SCTP ports",
+			packet: &crdv1alpha1.PacketCaptureFilter{
+				Protocol: protocolPtr(intstr.FromString("SCTP")),
+				SrcPort:  pointer.Int32(1000),
+				DstPort:  pointer.Int32(2000),
+			},
+			expected: &packetFilter{protocol: protocolSCTP, srcPort: 1000, dstPort: 2000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newPacketFilter(tt.packet, nil, nil, tt.isIPv6)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, filter)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505: not used for security purposes
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/mdlayher/packet"
+	"golang.org/x/net/bpf"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/util"
+)
+
+const (
+	// ETH_P_ALL, to receive the frames of all protocols, including the
+	// outgoing ones.
+	ethPAll = 0x0003
+	// The interval to check whether the capture should be stopped.
+	readTimeout = time.Second
+
+	capturePortNamePrefix = "pcap"
+	// The external ID of a capture port, which stores the UUID of the OVS
+	// mirror outputting to the port.
+	captureMirrorUUIDKey = "antrea-mirror-uuid"
+)
+
+// setLinkUp is declared as a variable for testing.
+var setLinkUp = util.SetLinkUp
+
+// capturePort is an OVS internal port to which an OVS mirror outputs the packets
+// of a Pod. The packets are captured on the capture port instead of the Pod
+// interface, so that they are captured from the OVS datapath.
+type capturePort struct {
+	name       string
+	uuid       string
+	mirrorUUID string
+}
+
+// getCapturePortName returns the name of the capture port of a PacketCapture.
+// It is no longer than 15 characters, the maximum length of interface names.
+func getCapturePortName(pcName string) string {
+	hash := sha1.Sum([]byte(pcName)) // #nosec G401: not used for security purposes
+	return fmt.Sprintf("%s-%s", capturePortNamePrefix, hex.EncodeToString(hash[:])[:10])
+}
+
+// createCapturePort creates the capture port of a PacketCapture, and an OVS
+// mirror which mirrors the packets received from and sent to the OVS port
+// podPortUUID to it.
+func (c *Controller) createCapturePort(pcName, podPortUUID string) (*capturePort, error) {
+	port := &capturePort{name: getCapturePortName(pcName)}
+	externalIDs := map[string]interface{}{
+		interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaPacketCapture,
+	}
+	portUUID, err := c.ovsBridgeClient.CreateInternalPort(port.name, 0, "", externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capture port %s: %w", port.name, err)
+	}
+	port.uuid = portUUID
+	success := false
+	defer func() {
+		if !success {
+			c.deleteCapturePort(port)
+		}
+	}()
+	// The host interface might not be available immediately after creating
+	// the OVS internal port.
+	if pollErr := wait.PollImmediate(time.Second, 5*time.Second, func() (bool, error) {
+		_, _, err := setLinkUp(port.name)
+		if err == nil {
+			return true, nil
+		}
+		if _, ok := err.(util.LinkNotFound); ok {
+			return false, nil
+		}
+		return false, err
+	}); pollErr != nil {
+		return nil, fmt.Errorf("failed to set capture port %s up: %w", port.name, pollErr)
+	}
+	mirrorUUID, err := c.ovsBridgeClient.CreateMirror(port.name, []string{podPortUUID}, port.uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror for capture port %s: %w", port.name, err)
+	}
+	port.mirrorUUID = mirrorUUID
+	// Save the mirror UUID to the capture port, so that the mirror can be
+	// deleted with the port if the agent restarts during the capture.
+	externalIDs[captureMirrorUUIDKey] = mirrorUUID
+	if err := c.ovsBridgeClient.SetPortExternalIDs(port.name, externalIDs); err != nil {
+		return nil, fmt.Errorf("failed to update external IDs of capture port %s: %w", port.name, err)
+	}
+	success = true
+	return port, nil
+}
+
+// deleteCapturePort deletes the OVS mirror and the capture port.
+func (c *Controller) deleteCapturePort(port *capturePort) {
+	if port.mirrorUUID != "" {
+		if err := c.ovsBridgeClient.DeleteMirror(port.mirrorUUID); err != nil {
+			klog.ErrorS(err, "Failed to delete mirror of capture port", "port", port.name)
+		}
+	}
+	if err := c.ovsBridgeClient.DeletePort(port.uuid); err != nil {
+		klog.ErrorS(err, "Failed to delete capture port", "port", port.name)
+	}
+}
+
+// cleanupStaleCapturePorts deletes the capture ports and their mirrors left by
+// the PacketCaptures which were running when the agent stopped. It must be
+// called before any PacketCapture is started.
+func (c *Controller) cleanupStaleCapturePorts() error {
+	ports, err := c.ovsBridgeClient.GetPortList()
+	if err != nil {
+		return err
+	}
+	for _, port := range ports {
+		if port.ExternalIDs[interfacestore.AntreaInterfaceTypeKey] != interfacestore.AntreaPacketCapture {
+			continue
+		}
+		c.deleteCapturePort(&capturePort{
+			name:       port.Name,
+			uuid:       port.UUID,
+			mirrorUUID: port.ExternalIDs[captureMirrorUUIDKey],
+		})
+	}
+	return nil
+}
+
+// packetHandler is called for each captured frame. The capture is stopped when
+// it returns false.
+type packetHandler func(ts time.Time, data []byte) bool
+
+// capturePackets captures the frames matching filter on the interface ifName,
+// until ctx is done or handler returns false. It is declared as a variable for
+// testing.
+var capturePackets = func(ctx context.Context, ifName string, filter []bpf.Instruction, handler packetHandler) error {
+	iface, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return fmt.Errorf("failed to get interface %s: %w", ifName, err)
+	}
+	rawFilter, err := bpf.Assemble(filter)
+	if err != nil {
+		return fmt.Errorf("failed to assemble BPF filter: %w", err)
+	}
+	conn, err := packet.Listen(iface, packet.Raw, ethPAll, &packet.Config{Filter: rawFilter})
+	if err != nil {
+		return fmt.Errorf("failed to open packet socket on interface %s: %w", ifName, err)
+	}
+	defer conn.Close()
+
+	buf := make([]byte, snapLen)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(readTimeout)); err != nil {
+			return err
+		}
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				continue
+			}
+			return fmt.Errorf("failed to read packet from interface %s: %w", ifName, err)
+		}
+		data := make([]byte, n)
+		copy(data, buf[:n])
+		if !handler(time.Now(), data) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,657 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/bpf"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	clientsetversioned "antrea.io/antrea/pkg/client/clientset/versioned"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha1"
+	crdlisters "antrea.io/antrea/pkg/client/listers/crd/v1alpha1"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	"antrea.io/antrea/pkg/util/env"
+	"antrea.io/antrea/pkg/util/ftp"
+)
+
+const (
+	controllerName = "PacketCaptureController"
+	// Set resyncPeriod to 0 to disable resyncing.
+	resyncPeriod time.Duration = 0
+	// How long to wait before retrying the processing of a PacketCapture.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing PacketCapture requests.
+	defaultWorkers = 4
+	// The maximum number of PacketCaptures running at the same time on a
+	// Node.
+	maxConcurrentCaptures = 16
+	// The default timeout of a PacketCapture.
+	defaultTimeoutSeconds = 60
+
+	// The Secret holding the credentials to access the file server. It
+	// must be created in the Namespace where Antrea is deployed, and have
+	// "username" and "password" in its data.
+	fileServerAuthSecretName = "antrea-packetcapture-fileserver-auth"
+	fileServerUsernameKey    = "username"
+	fileServerPasswordKey    = "password"
+
+	uploadToFileServerTries = 5
+
+	reasonSucceeded   = "Succeeded"
+	reasonTimeout     = "Timeout"
+	reasonFailed      = "Failed"
+	reasonInterrupted = "Interrupted"
+)
+
+var (
+	defaultFS = afero.NewOsFs()
+	// The directory where the pcapng files are stored on the Node.
+	packetDirectory = filepath.Join(os.TempDir(), "antrea", "packetcapture", "packets")
+	// Declared as variable for testing.
+	uploadToFileServerRetryDelay = 5 * time.Second
+)
+
+// captureState is the state of a running PacketCapture.
+type captureState struct {
+	cancel context.CancelFunc
+}
+
+// Controller captures the packets requested by PacketCaptures, when the source
+// (or the destination if the source is not a Pod) Pod of a PacketCapture runs
+// on the local Node. The packets received from and sent to the OVS port of the
+// Pod are mirrored to a dedicated OVS internal port, captured on it, and saved to
+// a pcapng file, which is uploaded to the file server specified in the
+// PacketCapture.
+type Controller struct {
+	nodeName                  string
+	kubeClient                clientset.Interface
+	crdClient                 clientsetversioned.Interface
+	packetCaptureInformer     crdinformers.PacketCaptureInformer
+	packetCaptureLister       crdlisters.PacketCaptureLister
+	packetCaptureListerSynced cache.InformerSynced
+	interfaceStore            interfacestore.InterfaceStore
+	ovsBridgeClient           ovsconfig.OVSBridgeClient
+	queue                     workqueue.RateLimitingInterface
+	sftpUploader              ftp.UpLoader
+	runningCapturesMutex      sync.Mutex
+	// runningCaptures stores the state of the running PacketCaptures, keyed
+	// by their names.
+	runningCaptures map[string]*captureState
+}
+
+// NewPacketCaptureController instantiates a new Controller object which will
+// process PacketCapture events.
+func NewPacketCaptureController(
+	nodeName string,
+	kubeClient clientset.Interface,
+	crdClient clientsetversioned.Interface,
+	packetCaptureInformer crdinformers.PacketCaptureInformer,
+	interfaceStore interfacestore.InterfaceStore,
+	ovsBridgeClient ovsconfig.OVSBridgeClient) *Controller {
+	c := &Controller{
+		nodeName:                  nodeName,
+		kubeClient:                kubeClient,
+		crdClient:                 crdClient,
+		packetCaptureInformer:     packetCaptureInformer,
+		packetCaptureLister:       packetCaptureInformer.Lister(),
+		packetCaptureListerSynced: packetCaptureInformer.Informer().HasSynced,
+		interfaceStore:            interfaceStore,
+		ovsBridgeClient:           ovsBridgeClient,
+		queue:                     workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "packetcapture"),
+		sftpUploader:              &ftp.SftpUploader{},
+		runningCaptures:           make(map[string]*captureState),
+	}
+
+	packetCaptureInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addPacketCapture,
+			UpdateFunc: c.updatePacketCapture,
+			DeleteFunc: c.deletePacketCapture,
+		},
+		resyncPeriod,
+	)
+	return c
+}
+
+func (c *Controller) enqueuePacketCapture(pc *crdv1alpha1.PacketCapture) {
+	c.queue.Add(pc.Name)
+}
+
+// Run will create defaultWorkers workers (go routines) which will process the
+// PacketCapture events from the workqueue.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting controller", "name", controllerName)
+	defer klog.InfoS("Shutting down controller", "name", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.packetCaptureListerSynced) {
+		return
+	}
+
+	// Remove the files of the PacketCaptures which were deleted when the
+	// agent was not running.
+	if err := c.cleanupStaleFiles(); err != nil {
+		klog.ErrorS(err, "Failed to clean up stale PacketCapture files")
+	}
+	// Remove the capture ports of the PacketCaptures which were running
+	// when the agent stopped.
+	if err := c.cleanupStaleCapturePorts(); err != nil {
+		klog.ErrorS(err, "Failed to clean up stale PacketCapture ports")
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+
+	c.runningCapturesMutex.Lock()
+	defer c.runningCapturesMutex.Unlock()
+	for _, state := range c.runningCaptures {
+		state.cancel()
+	}
+}
+
+func (c *Controller) addPacketCapture(obj interface{}) {
+	pc := obj.(*crdv1alpha1.PacketCapture)
+	klog.V(2).InfoS("Processing PacketCapture ADD event", "name", pc.Name)
+	c.enqueuePacketCapture(pc)
+}
+
+func (c *Controller) updatePacketCapture(_, curObj interface{}) {
+	pc := curObj.(*crdv1alpha1.PacketCapture)
+	klog.V(2).InfoS("Processing PacketCapture UPDATE event", "name", pc.Name)
+	c.enqueuePacketCapture(pc)
+}
+
+func (c *Controller) deletePacketCapture(old interface{}) {
+	pc, ok := old.(*crdv1alpha1.PacketCapture)
+	if !ok {
+		tombstone, ok := old.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Error decoding object when deleting PacketCapture", "oldObject", old)
+			return
+		}
+		pc, ok = tombstone.Obj.(*crdv1alpha1.PacketCapture)
+		if !ok {
+			klog.ErrorS(nil, "Error decoding object tombstone when deleting PacketCapture", "tombstone", tombstone.Obj)
+			return
+		}
+	}
+	klog.V(2).InfoS("Processing PacketCapture DELETE event", "name", pc.Name)
+	c.enqueuePacketCapture(pc)
+}
+
+func (c *Controller) worker() {
+	for c.processPacketCaptureItem() {
+	}
+}
+
+func (c *Controller) processPacketCaptureItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if key, ok := obj.(string); !ok {
+		c.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := c.syncPacketCapture(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Error syncing PacketCapture, requeuing", "name", key)
+	}
+	return true
+}
+
+func (c *Controller) syncPacketCapture(name string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).InfoS("Finished syncing PacketCapture", "name", name, "duration", time.Since(startTime))
+	}()
+
+	pc, err := c.packetCaptureLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			c.cleanupPacketCapture(name)
+			return nil
+		}
+		return err
+	}
+	if getCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureComplete) != nil {
+		return nil
+	}
+
+	podName, podNamespace := getTargetPod(pc)
+	podInterfaces := c.interfaceStore.GetContainerInterfacesByPod(podName, podNamespace)
+	if len(podInterfaces) == 0 {
+		// The PacketCapture is processed by another Node.
+		return nil
+	}
+
+	// Reserve the slot of the PacketCapture while holding the lock, and update
+	// the status after releasing it, so that a slow apiserver doesn't block
+	// the other PacketCaptures from being started or stopped.
+	c.runningCapturesMutex.Lock()
+	if _, ok := c.runningCaptures[name]; ok {
+		c.runningCapturesMutex.Unlock()
+		return nil
+	}
+	if getCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureStarted) != nil {
+		c.runningCapturesMutex.Unlock()
+		// The PacketCapture was started before the agent restarted, and
+		// the captured packets have been lost.
+		return c.updateStatus(name, func(status *crdv1alpha1.PacketCaptureStatus) {
+			setCondition(status, crdv1alpha1.PacketCaptureComplete, metav1.ConditionFalse, reasonInterrupted,
+				fmt.Sprintf("Node: %s, error: the capture was interrupted by the restart of antrea-agent", c.nodeName))
+		})
+	}
+	if len(c.runningCaptures) >= maxConcurrentCaptures {
+		c.runningCapturesMutex.Unlock()
+		return fmt.Errorf("the number of running PacketCaptures has reached the limit %d", maxConcurrentCaptures)
+	}
+	timeout := int32(defaultTimeoutSeconds)
+	if pc.Spec.Timeout != nil {
+		timeout = *pc.Spec.Timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	c.runningCaptures[name] = &captureState{cancel: cancel}
+	c.runningCapturesMutex.Unlock()
+
+	filter, isIPv6, err := c.preparePacketFilter(pc, podInterfaces[0])
+	if err != nil {
+		c.stopCapture(name)
+		return c.updateStatus(name, func(status *crdv1alpha1.PacketCaptureStatus) {
+			setCondition(status, crdv1alpha1.PacketCaptureComplete, metav1.ConditionFalse, reasonFailed,
+				fmt.Sprintf("Node: %s, error: %v", c.nodeName, err))
+		})
+	}
+	if err := c.updateStatus(name, func(status *crdv1alpha1.PacketCaptureStatus) {
+		setCondition(status, crdv1alpha1.PacketCaptureStarted, metav1.ConditionTrue, reasonSucceeded,
+			fmt.Sprintf("Capturing packets on Node %s", c.nodeName))
+	}); err != nil {
+		c.stopCapture(name)
+		return err
+	}
+
+	go c.performCapture(ctx, pc.DeepCopy(), podInterfaces[0], compilePacketFilter(filter, isIPv6))
+	return nil
+}
+
+// getTargetPod returns the Pod on whose Node the PacketCapture is performed.
+func getTargetPod(pc *crdv1alpha1.PacketCapture) (string, string) {
+	if pc.Spec.Source.Pod != "" {
+		return pc.Spec.Source.Pod, getNamespace(pc.Spec.Source.Namespace)
+	}
+	return pc.Spec.Destination.Pod, getNamespace(pc.Spec.Destination.Namespace)
+}
+
+func getNamespace(namespace string) string {
+	if namespace == "" {
+		return metav1.NamespaceDefault
+	}
+	return namespace
+}
+
+// preparePacketFilter resolves the IPs of the source and the destination of the
+// PacketCapture, and returns the packetFilter of the packets sent from the source
+// to the destination.
+func (c *Controller) preparePacketFilter(pc *crdv1alpha1.PacketCapture, podInterface *interfacestore.InterfaceConfig) (*packetFilter, bool, error) {
+	var srcIPs, dstIPs []net.IP
+	var err error
+	if pc.Spec.Source.Pod != "" {
+		srcIPs = podInterface.IPs
+	} else if pc.Spec.Source.IP != "" {
+		srcIPs, err = parseIP(pc.Spec.Source.IP)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	switch {
+	case pc.Spec.Destination.Pod != "" && pc.Spec.Source.Pod == "":
+		dstIPs = podInterface.IPs
+	case pc.Spec.Destination.Pod != "":
+		pod, err := c.kubeClient.CoreV1().Pods(getNamespace(pc.Spec.Destination.Namespace)).Get(context.TODO(), pc.Spec.Destination.Pod, metav1.GetOptions{})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get the destination Pod: %w", err)
+		}
+		for _, podIP := range pod.Status.PodIPs {
+			dstIPs = append(dstIPs, net.ParseIP(podIP.IP))
+		}
+		if len(dstIPs) == 0 {
+			return nil, false, fmt.Errorf("the destination Pod has no IP")
+		}
+	case pc.Spec.Destination.Service != "":
+		svc, err := c.kubeClient.CoreV1().Services(getNamespace(pc.Spec.Destination.Namespace)).Get(context.TODO(), pc.Spec.Destination.Service, metav1.GetOptions{})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get the destination Service: %w", err)
+		}
+		for _, clusterIP := range svc.Spec.ClusterIPs {
+			if ip := net.ParseIP(clusterIP); ip != nil {
+				dstIPs = append(dstIPs, ip)
+			}
+		}
+		if len(dstIPs) == 0 {
+			return nil, false, fmt.Errorf("the destination Service has no ClusterIP")
+		}
+	case pc.Spec.Destination.IP != "":
+		dstIPs, err = parseIP(pc.Spec.Destination.IP)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Prefer IPv4 when both the source and the destination have IPs of both
+	// families.
+	for _, isIPv6 := range []bool{false, true} {
+		srcIP, srcOK := pickIP(srcIPs, isIPv6)
+		dstIP, dstOK := pickIP(dstIPs, isIPv6)
+		if !srcOK || !dstOK {
+			continue
+		}
+		filter, err := newPacketFilter(pc.Spec.Packet, srcIP, dstIP, isIPv6)
+		if err != nil {
+			return nil, false, err
+		}
+		return filter, isIPv6, nil
+	}
+	return nil, false, fmt.Errorf("the source and the destination have no IPs of the same family")
+}
+
+func parseIP(ipStr string) ([]net.IP, error) {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %s", ipStr)
+	}
+	return []net.IP{ip}, nil
+}
+
+// pickIP returns the first IP of the given family in ips. An empty ips means
+// any address, in which case a nil IP is returned with true.
+func pickIP(ips []net.IP, isIPv6 bool) (net.IP, bool) {
+	if len(ips) == 0 {
+		return nil, true
+	}
+	for _, ip := range ips {
+		if utilnet.IsIPv6(ip) == isIPv6 {
+			return ip, true
+		}
+	}
+	return nil, false
+}
+
+func getPcapngFilePath(name string) string {
+	return filepath.Join(packetDirectory, name+".pcapng")
+}
+
+// performCapture captures the packets and saves them to a pcapng file until ctx
+// is done or the requested number of packets have been captured, then uploads
+// the file to the file server and updates the status of the PacketCapture.
+func (c *Controller) performCapture(ctx context.Context, pc *crdv1alpha1.PacketCapture, podInterface *interfacestore.InterfaceConfig, filter []bpf.Instruction) {
+	defer c.stopCapture(pc.Name)
+
+	var captured int32
+	filePath := getPcapngFilePath(pc.Name)
+	captureErr := func() error {
+		if err := defaultFS.MkdirAll(packetDirectory, 0700); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", packetDirectory, err)
+		}
+		file, err := defaultFS.Create(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to create pcapng file: %w", err)
+		}
+		defer file.Close()
+		writer, err := newPcapngWriter(file, podInterface.InterfaceName)
+		if err != nil {
+			return fmt.Errorf("failed to write pcapng file: %w", err)
+		}
+		port, err := c.createCapturePort(pc.Name, podInterface.PortUUID)
+		if err != nil {
+			return err
+		}
+		defer c.deleteCapturePort(port)
+		var writeErr error
+		err = capturePackets(ctx, port.name, filter, func(ts time.Time, data []byte) bool {
+			if writeErr = writer.writePacket(ts, data); writeErr != nil {
+				return false
+			}
+			captured++
+			firstN := pc.Spec.CaptureConfig.FirstN
+			return firstN == nil || captured < firstN.Number
+		})
+		if writeErr != nil {
+			return fmt.Errorf("failed to write pcapng file: %w", writeErr)
+		}
+		return err
+	}()
+
+	// The PacketCapture was deleted or the agent is stopping.
+	if ctx.Err() == context.Canceled {
+		klog.InfoS("PacketCapture was cancelled", "name", pc.Name)
+		if err := defaultFS.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			klog.ErrorS(err, "Failed to remove pcapng file", "name", pc.Name)
+		}
+		return
+	}
+
+	reason := reasonSucceeded
+	if ctx.Err() == context.DeadlineExceeded && pc.Spec.CaptureConfig.FirstN != nil {
+		reason = reasonTimeout
+	}
+	var uploadErr error
+	status := metav1.ConditionTrue
+	message := fmt.Sprintf("Captured %d packets on Node %s", captured, c.nodeName)
+	if captureErr != nil {
+		status, reason = metav1.ConditionFalse, reasonFailed
+		message = fmt.Sprintf("Node: %s, error: %v", c.nodeName, captureErr)
+	} else if pc.Spec.FileServer != nil {
+		uploadErr = c.uploadPackets(pc, filePath)
+	}
+
+	klog.InfoS("PacketCapture completed", "name", pc.Name, "captured", captured, "reason", reason)
+	if err := c.updateStatus(pc.Name, func(pcStatus *crdv1alpha1.PacketCaptureStatus) {
+		pcStatus.NumberCaptured = captured
+		setCondition(pcStatus, crdv1alpha1.PacketCaptureComplete, status, reason, message)
+		if captureErr != nil {
+			return
+		}
+		pcStatus.FilePath = fmt.Sprintf("%s:%s", c.nodeName, filePath)
+		if pc.Spec.FileServer == nil {
+			return
+		}
+		if uploadErr != nil {
+			setCondition(pcStatus, crdv1alpha1.PacketCaptureFileUploaded, metav1.ConditionFalse, reasonFailed,
+				fmt.Sprintf("Node: %s, error: %v", c.nodeName, uploadErr))
+			return
+		}
+		pcStatus.FilePath = getUploadURL(pc, c.nodeName)
+		setCondition(pcStatus, crdv1alpha1.PacketCaptureFileUploaded, metav1.ConditionTrue, reasonSucceeded, "")
+	}); err != nil {
+		klog.ErrorS(err, "Failed to update PacketCapture status", "name", pc.Name)
+	}
+}
+
+func getUploadFileName(pc *crdv1alpha1.PacketCapture, nodeName string) string {
+	return nodeName + "_" + pc.Name + ".pcapng"
+}
+
+func getUploadURL(pc *crdv1alpha1.PacketCapture, nodeName string) string {
+	parsedURL, err := ftp.ParseUploadUrl(pc.Spec.FileServer.URL)
+	if err != nil {
+		return ""
+	}
+	parsedURL.Path = path.Join(parsedURL.Path, getUploadFileName(pc, nodeName))
+	return parsedURL.String()
+}
+
+func (c *Controller) getFileServerAuth() (string, string, error) {
+	secret, err := c.kubeClient.CoreV1().Secrets(env.GetAntreaNamespace()).Get(context.TODO(), fileServerAuthSecretName, metav1.GetOptions{})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get Secret %s: %w", fileServerAuthSecretName, err)
+	}
+	username, ok := secret.Data[fileServerUsernameKey]
+	if !ok {
+		return "", "", fmt.Errorf("%s not found in Secret %s", fileServerUsernameKey, fileServerAuthSecretName)
+	}
+	password, ok := secret.Data[fileServerPasswordKey]
+	if !ok {
+		return "", "", fmt.Errorf("%s not found in Secret %s", fileServerPasswordKey, fileServerAuthSecretName)
+	}
+	return string(username), string(password), nil
+}
+
+func (c *Controller) uploadPackets(pc *crdv1alpha1.PacketCapture, filePath string) error {
+	klog.V(2).InfoS("Uploading captured packets", "name", pc.Name)
+	parsedURL, err := ftp.ParseUploadUrl(pc.Spec.FileServer.URL)
+	if err != nil {
+		return fmt.Errorf("failed to parse upload URL: %w", err)
+	}
+	username, password, err := c.getFileServerAuth()
+	if err != nil {
+		return err
+	}
+	cfg := &ssh.ClientConfig{
+		User: username,
+		Auth: []ssh.AuthMethod{ssh.Password(password)},
+		// #nosec G106: skip host key check here and users can specify their own checks if needed
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         time.Second,
+	}
+	uploadPath := path.Join(parsedURL.Path, getUploadFileName(pc, c.nodeName))
+	var uploadErr error
+	for i := 0; i < uploadToFileServerTries; i++ {
+		if i > 0 {
+			klog.InfoS("Failed to upload captured packets", "name", pc.Name, "error", uploadErr, "triesLeft", uploadToFileServerTries-i)
+			time.Sleep(uploadToFileServerRetryDelay)
+		}
+		uploadErr = func() error {
+			file, err := defaultFS.Open(filePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			return c.sftpUploader.Upload(parsedURL.Host, uploadPath, cfg, file)
+		}()
+		if uploadErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to upload captured packets after %d attempts: %w", uploadToFileServerTries, uploadErr)
+}
+
+// stopCapture cancels the PacketCapture if it's running, and releases its slot.
+func (c *Controller) stopCapture(name string) {
+	c.runningCapturesMutex.Lock()
+	defer c.runningCapturesMutex.Unlock()
+	if state, ok := c.runningCaptures[name]; ok {
+		state.cancel()
+		delete(c.runningCaptures, name)
+	}
+}
+
+// cleanupPacketCapture stops the PacketCapture if it's running, and removes
+// the pcapng file.
+func (c *Controller) cleanupPacketCapture(name string) {
+	c.stopCapture(name)
+	if err := defaultFS.Remove(getPcapngFilePath(name)); err != nil && !os.IsNotExist(err) {
+		klog.ErrorS(err, "Failed to remove pcapng file", "name", name)
+	}
+}
+
+func (c *Controller) cleanupStaleFiles() error {
+	files, err := afero.ReadDir(defaultFS, packetDirectory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if filepath.Ext(name) != ".pcapng" {
+			continue
+		}
+		pcName := name[:len(name)-len(".pcapng")]
+		if _, err := c.packetCaptureLister.Get(pcName); apierrors.IsNotFound(err) {
+			c.cleanupPacketCapture(pcName)
+		}
+	}
+	return nil
+}
+
+func (c *Controller) updateStatus(name string, update func(status *crdv1alpha1.PacketCaptureStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pc, err := c.crdClient.CrdV1alpha1().PacketCaptures().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		toUpdate := pc.DeepCopy()
+		update(&toUpdate.Status)
+		_, err = c.crdClient.CrdV1alpha1().PacketCaptures().UpdateStatus(context.TODO(), toUpdate, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func getCondition(conditions []crdv1alpha1.PacketCaptureCondition, conditionType crdv1alpha1.PacketCaptureConditionType) *crdv1alpha1.PacketCaptureCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func setCondition(status *crdv1alpha1.PacketCaptureStatus, conditionType crdv1alpha1.PacketCaptureConditionType, conditionStatus metav1.ConditionStatus, reason, message string) {
+	newCondition := crdv1alpha1.PacketCaptureCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	if condition := getCondition(status.Conditions, conditionType); condition != nil {
+		if condition.Status == conditionStatus {
+			newCondition.LastTransitionTime = condition.LastTransitionTime
+		}
+		*condition = newCondition
+		return
+	}
+	status.Conditions = append(status.Conditions, newCondition)
+}
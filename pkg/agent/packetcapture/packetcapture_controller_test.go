@@ -0,0 +1,436 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/bpf"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	fakeversioned "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
+)
+
+var (
+	pod1IPv4     = net.ParseIP("10.10.0.2")
+	pod1PortUUID = "pod-1-port-uuid"
+	pod2IPv4     = net.ParseIP("10.10.1.3")
+
+	pod1 = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "default"},
+		Status: corev1.PodStatus{
+			PodIP:  pod1IPv4.String(),
+			PodIPs: []corev1.PodIP{{IP: pod1IPv4.String()}},
+		},
+	}
+	pod2 = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: "default"},
+		Status: corev1.PodStatus{
+			PodIP:  pod2IPv4.String(),
+			PodIPs: []corev1.PodIP{{IP: pod2IPv4.String()}},
+		},
+	}
+	fileServerSecret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: fileServerAuthSecretName, Namespace: "kube-system"},
+		Data: map[string][]byte{
+			fileServerUsernameKey: []byte("user"),
+			fileServerPasswordKey: []byte("pass"),
+		},
+	}
+)
+
+type testUploader struct {
+	mutex    sync.Mutex
+	uploaded map[string][]byte
+}
+
+func (uploader *testUploader) Upload(address string, path string, config *ssh.ClientConfig, outputFile io.Reader) error {
+	if config.User != "user" {
+		return fmt.Errorf("unexpected user %s", config.User)
+	}
+	data, err := io.ReadAll(outputFile)
+	if err != nil {
+		return err
+	}
+	uploader.mutex.Lock()
+	defer uploader.mutex.Unlock()
+	uploader.uploaded[address+path] = data
+	return nil
+}
+
+type fakePacketCaptureController struct {
+	*Controller
+	crdClient          *fakeversioned.Clientset
+	crdInformerFactory crdinformers.SharedInformerFactory
+	ovsBridgeClient    *ovsconfigtest.MockOVSBridgeClient
+	uploader           *testUploader
+}
+
+func newFakePacketCaptureController(t *testing.T, initObjects ...runtime.Object) *fakePacketCaptureController {
+	kubeClient := fake.NewSimpleClientset(&pod1, &pod2, &fileServerSecret)
+	crdClient := fakeversioned.NewSimpleClientset(initObjects...)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	ifaceStore := interfacestore.NewInterfaceStore()
+	pod1Interface := interfacestore.NewContainerInterface("pod-1-6631b7", "container-1", pod1.Name, pod1.Namespace, nil, []net.IP{pod1IPv4}, 0)
+	pod1Interface.OVSPortConfig = &interfacestore.OVSPortConfig{PortUUID: pod1PortUUID, OFPort: 3}
+	ifaceStore.AddInterface(pod1Interface)
+	ovsBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(gomock.NewController(t))
+
+	controller := NewPacketCaptureController("node-1", kubeClient, crdClient, crdInformerFactory.Crd().V1alpha1().PacketCaptures(), ifaceStore, ovsBridgeClient)
+	uploader := &testUploader{uploaded: map[string][]byte{}}
+	controller.sftpUploader = uploader
+
+	defaultFS = afero.NewMemMapFs()
+	origSetLinkUp := setLinkUp
+	setLinkUp = func(name string) (net.HardwareAddr, int, error) {
+		return nil, 0, nil
+	}
+	t.Cleanup(func() {
+		defaultFS = afero.NewOsFs()
+		setLinkUp = origSetLinkUp
+	})
+	return &fakePacketCaptureController{
+		Controller:         controller,
+		crdClient:          crdClient,
+		crdInformerFactory: crdInformerFactory,
+		ovsBridgeClient:    ovsBridgeClient,
+		uploader:           uploader,
+	}
+}
+
+// mockCapturePackets replaces capturePackets with a function which delivers
+// the provided frames, then blocks until ctx is done.
+func mockCapturePackets(t *testing.T, frames [][]byte) {
+	origCapturePackets := capturePackets
+	capturePackets = func(ctx context.Context, ifName string, filter []bpf.Instruction, handler packetHandler) error {
+		for _, frame := range frames {
+			if !handler(time.Now(), frame) {
+				return nil
+			}
+		}
+		<-ctx.Done()
+		return nil
+	}
+	t.Cleanup(func() {
+		capturePackets = origCapturePackets
+	})
+}
+
+// expectCapturePort expects the capture port of the PacketCapture and the mirror
+// of the Pod port to be created and deleted.
+func (c *fakePacketCaptureController) expectCapturePort(pcName string) {
+	portName := getCapturePortName(pcName)
+	externalIDs := map[string]interface{}{
+		interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaPacketCapture,
+	}
+	c.ovsBridgeClient.EXPECT().CreateInternalPort(portName, int32(0), "", externalIDs).Return("capture-port-uuid", nil)
+	c.ovsBridgeClient.EXPECT().CreateMirror(portName, []string{pod1PortUUID}, "capture-port-uuid").Return("mirror-uuid", nil)
+	c.ovsBridgeClient.EXPECT().SetPortExternalIDs(portName, map[string]interface{}{
+		interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaPacketCapture,
+		captureMirrorUUIDKey:                  "mirror-uuid",
+	}).Return(nil)
+	c.ovsBridgeClient.EXPECT().DeleteMirror("mirror-uuid").Return(nil)
+	c.ovsBridgeClient.EXPECT().DeletePort("capture-port-uuid").Return(nil)
+}
+
+func (c *fakePacketCaptureController) start(t *testing.T) {
+	stopCh := make(chan struct{})
+	t.Cleanup(func() {
+		close(stopCh)
+	})
+	c.crdInformerFactory.Start(stopCh)
+	c.crdInformerFactory.WaitForCacheSync(stopCh)
+}
+
+func (c *fakePacketCaptureController) getPacketCapture(t *testing.T, name string) *crdv1alpha1.PacketCapture {
+	pc, err := c.crdClient.CrdV1alpha1().PacketCaptures().Get(context.TODO(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return pc
+}
+
+func TestPacketCaptureFirstN(t *testing.T) {
+	pc := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{Name: "pc1"},
+		Spec: crdv1alpha1.PacketCaptureSpec{
+			Source:      crdv1alpha1.Source{Namespace: pod1.Namespace, Pod: pod1.Name},
+			Destination: crdv1alpha1.Destination{Namespace: pod2.Namespace, Pod: pod2.Name},
+			CaptureConfig: crdv1alpha1.CaptureConfig{
+				FirstN: &crdv1alpha1.PacketCaptureFirstNConfig{Number: 2},
+			},
+			Packet: &crdv1alpha1.PacketCaptureFilter{
+				Protocol: protocolPtr(intstr.FromString("TCP")),
+				DstPort:  pointer.Int32(80),
+			},
+			FileServer: &crdv1alpha1.PacketCaptureFileServer{URL: "sftp://127.0.0.1:22/upload"},
+		},
+	}
+	c := newFakePacketCaptureController(t, pc)
+	frame := buildFrame(pod1IPv4, pod2IPv4, protocolTCP, 10000, 80, 0)
+	c.expectCapturePort(pc.Name)
+	mockCapturePackets(t, [][]byte{frame, frame, frame})
+	c.start(t)
+
+	require.NoError(t, c.syncPacketCapture(pc.Name))
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		pc, err := c.crdClient.CrdV1alpha1().PacketCaptures().Get(context.TODO(), pc.Name, metav1.GetOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		complete := getCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureComplete)
+		if !assert.NotNil(t, complete) {
+			return
+		}
+		assert.Equal(t, metav1.ConditionTrue, complete.Status)
+		assert.Equal(t, reasonSucceeded, complete.Reason)
+		uploaded := getCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureFileUploaded)
+		if !assert.NotNil(t, uploaded) {
+			return
+		}
+		assert.Equal(t, metav1.ConditionTrue, uploaded.Status)
+		assert.Equal(t, int32(2), pc.Status.NumberCaptured)
+		assert.Equal(t, "sftp://127.0.0.1:22/upload/node-1_pc1.pcapng", pc.Status.FilePath)
+	}, 2*time.Second, 50*time.Millisecond)
+
+	c.uploader.mutex.Lock()
+	defer c.uploader.mutex.Unlock()
+	data, ok := c.uploader.uploaded["127.0.0.1:22/upload/node-1_pc1.pcapng"]
+	require.True(t, ok)
+	localData, err := afero.ReadFile(defaultFS, getPcapngFilePath(pc.Name))
+	require.NoError(t, err)
+	assert.Equal(t, localData, data)
+	assert.Eventually(t, func() bool {
+		c.runningCapturesMutex.Lock()
+		defer c.runningCapturesMutex.Unlock()
+		return len(c.runningCaptures) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPacketCaptureTimeout(t *testing.T) {
+	pc := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{Name: "pc1"},
+		Spec: crdv1alpha1.PacketCaptureSpec{
+			Timeout:     pointer.Int32(1),
+			Source:      crdv1alpha1.Source{IP: pod2IPv4.String()},
+			Destination: crdv1alpha1.Destination{Namespace: pod1.Namespace, Pod: pod1.Name},
+			CaptureConfig: crdv1alpha1.CaptureConfig{
+				FirstN: &crdv1alpha1.PacketCaptureFirstNConfig{Number: 5},
+			},
+		},
+	}
+	c := newFakePacketCaptureController(t, pc)
+	c.expectCapturePort(pc.Name)
+	mockCapturePackets(t, [][]byte{buildFrame(pod2IPv4, pod1IPv4, protocolTCP, 10000, 80, 0)})
+	c.start(t)
+
+	require.NoError(t, c.syncPacketCapture(pc.Name))
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		pc, err := c.crdClient.CrdV1alpha1().PacketCaptures().Get(context.TODO(), pc.Name, metav1.GetOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		complete := getCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureComplete)
+		if !assert.NotNil(t, complete) {
+			return
+		}
+		assert.Equal(t, reasonTimeout, complete.Reason)
+		assert.Equal(t, int32(1), pc.Status.NumberCaptured)
+		assert.Equal(t, "node-1:"+getPcapngFilePath("pc1"), pc.Status.FilePath)
+		assert.Nil(t, getCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureFileUploaded))
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestPacketCaptureRemotePod(t *testing.T) {
+	pc := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{Name: "pc1"},
+		Spec: crdv1alpha1.PacketCaptureSpec{
+			Source:      crdv1alpha1.Source{Namespace: pod2.Namespace, Pod: pod2.Name},
+			Destination: crdv1alpha1.Destination{Namespace: pod1.Namespace, Pod: pod1.Name},
+		},
+	}
+	c := newFakePacketCaptureController(t, pc)
+	c.start(t)
+
+	require.NoError(t, c.syncPacketCapture(pc.Name))
+	assert.Empty(t, c.runningCaptures)
+	assert.Empty(t, c.getPacketCapture(t, pc.Name).Status.Conditions)
+}
+
+func TestPacketCaptureInvalidFilter(t *testing.T) {
+	pc := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{Name: "pc1"},
+		Spec: crdv1alpha1.PacketCaptureSpec{
+			Source:      crdv1alpha1.Source{Namespace: pod1.Namespace, Pod: pod1.Name},
+			Destination: crdv1alpha1.Destination{IP: "fec0::1"},
+		},
+	}
+	c := newFakePacketCaptureController(t, pc)
+	c.start(t)
+
+	require.NoError(t, c.syncPacketCapture(pc.Name))
+	assert.Empty(t, c.runningCaptures)
+	complete := getCondition(c.getPacketCapture(t, pc.Name).Status.Conditions, crdv1alpha1.PacketCaptureComplete)
+	require.NotNil(t, complete)
+	assert.Equal(t, metav1.ConditionFalse, complete.Status)
+	assert.Equal(t, "Node: node-1, error: the source and the destination have no IPs of the same family", complete.Message)
+}
+
+func TestPacketCaptureDeleted(t *testing.T) {
+	pc := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{Name: "pc1"},
+		Spec: crdv1alpha1.PacketCaptureSpec{
+			Source:      crdv1alpha1.Source{Namespace: pod1.Namespace, Pod: pod1.Name},
+			Destination: crdv1alpha1.Destination{IP: pod2IPv4.String()},
+		},
+	}
+	c := newFakePacketCaptureController(t, pc)
+	c.expectCapturePort(pc.Name)
+	mockCapturePackets(t, nil)
+	c.start(t)
+
+	require.NoError(t, c.syncPacketCapture(pc.Name))
+	c.runningCapturesMutex.Lock()
+	assert.Contains(t, c.runningCaptures, pc.Name)
+	c.runningCapturesMutex.Unlock()
+	started := getCondition(c.getPacketCapture(t, pc.Name).Status.Conditions, crdv1alpha1.PacketCaptureStarted)
+	require.NotNil(t, started)
+
+	require.NoError(t, c.crdClient.CrdV1alpha1().PacketCaptures().Delete(context.TODO(), pc.Name, metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		_, err := c.packetCaptureLister.Get(pc.Name)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, c.syncPacketCapture(pc.Name))
+	c.runningCapturesMutex.Lock()
+	assert.Empty(t, c.runningCaptures)
+	c.runningCapturesMutex.Unlock()
+	assert.Eventually(t, func() bool {
+		exists, _ := afero.Exists(defaultFS, getPcapngFilePath(pc.Name))
+		return !exists
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPacketCaptureSlowStatusUpdate(t *testing.T) {
+	pc := &crdv1alpha1.PacketCapture{
+		ObjectMeta: metav1.ObjectMeta{Name: "pc1"},
+		Spec: crdv1alpha1.PacketCaptureSpec{
+			Source:      crdv1alpha1.Source{Namespace: pod1.Namespace, Pod: pod1.Name},
+			Destination: crdv1alpha1.Destination{IP: pod2IPv4.String()},
+			CaptureConfig: crdv1alpha1.CaptureConfig{
+				FirstN: &crdv1alpha1.PacketCaptureFirstNConfig{Number: 1},
+			},
+		},
+	}
+	c := newFakePacketCaptureController(t, pc)
+	c.expectCapturePort(pc.Name)
+	mockCapturePackets(t, [][]byte{buildFrame(pod1IPv4, pod2IPv4, protocolTCP, 10000, 80, 0)})
+	updating := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	c.crdClient.PrependReactor("update", "packetcaptures", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "status" {
+			once.Do(func() {
+				close(updating)
+				<-release
+			})
+		}
+		return false, nil, nil
+	})
+	c.start(t)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.syncPacketCapture(pc.Name)
+	}()
+	<-updating
+	// The lock must not be held while the status is being updated.
+	require.True(t, c.runningCapturesMutex.TryLock())
+	assert.Contains(t, c.runningCaptures, pc.Name)
+	c.runningCapturesMutex.Unlock()
+	close(release)
+	require.NoError(t, <-errCh)
+
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		pc, err := c.crdClient.CrdV1alpha1().PacketCaptures().Get(context.TODO(), pc.Name, metav1.GetOptions{})
+		if !assert.NoError(t, err) {
+			return
+		}
+		complete := getCondition(pc.Status.Conditions, crdv1alpha1.PacketCaptureComplete)
+		if !assert.NotNil(t, complete) {
+			return
+		}
+		assert.Equal(t, metav1.ConditionTrue, complete.Status)
+	}, 2*time.Second, 50*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		c.runningCapturesMutex.Lock()
+		defer c.runningCapturesMutex.Unlock()
+		return len(c.runningCaptures) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCleanupStaleCapturePorts(t *testing.T) {
+	c := newFakePacketCaptureController(t)
+	c.ovsBridgeClient.EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{
+		{
+			UUID: "pod-port-uuid",
+			Name: "pod-1-6631b7",
+			ExternalIDs: map[string]string{
+				interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaContainer,
+			},
+		},
+		{
+			UUID: "capture-port-uuid-1",
+			Name: "pcap-1",
+			ExternalIDs: map[string]string{
+				interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaPacketCapture,
+				captureMirrorUUIDKey:                  "mirror-uuid-1",
+			},
+		},
+		{
+			// The agent stopped before the mirror was created.
+			UUID: "capture-port-uuid-2",
+			Name: "pcap-2",
+			ExternalIDs: map[string]string{
+				interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaPacketCapture,
+			},
+		},
+	}, nil)
+	c.ovsBridgeClient.EXPECT().DeleteMirror("mirror-uuid-1").Return(nil)
+	c.ovsBridgeClient.EXPECT().DeletePort("capture-port-uuid-1").Return(nil)
+	c.ovsBridgeClient.EXPECT().DeletePort("capture-port-uuid-2").Return(nil)
+
+	require.NoError(t, c.cleanupStaleCapturePorts())
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// The block types and options of the pcapng format, see
+// https://www.ietf.org/archive/id/draft-ietf-opsawg-pcapng-01.html.
+const (
+	pcapngSectionHeaderBlockType     uint32 = 0x0A0D0D0A
+	pcapngInterfaceDescBlockType     uint32 = 0x00000001
+	pcapngEnhancedPacketBlockType    uint32 = 0x00000006
+	pcapngByteOrderMagic             uint32 = 0x1A2B3C4D
+	pcapngOptionEndOfOpt             uint16 = 0
+	pcapngOptionIfName               uint16 = 2
+	pcapngLinkTypeEthernet           uint16 = 1
+	pcapngSectionHeaderBlockMinSize         = 28
+	pcapngInterfaceDescBlockMinSize         = 20
+	pcapngEnhancedPacketBlockMinSize        = 32
+)
+
+// pcapngWriter writes the captured Ethernet frames to an io.Writer in the
+// pcapng format. All the frames are captured from a single interface, and
+// timestamps are recorded in microseconds, which is the default resolution of
+// the format.
+type pcapngWriter struct {
+	w io.Writer
+}
+
+func padding(length int) int {
+	return (4 - length%4) % 4
+}
+
+// newPcapngWriter writes the Section Header Block and the Interface Description
+// Block to w, and returns a pcapngWriter which writes packets to w.
+func newPcapngWriter(w io.Writer, ifName string) (*pcapngWriter, error) {
+	shb := make([]byte, pcapngSectionHeaderBlockMinSize)
+	binary.LittleEndian.PutUint32(shb[0:4], pcapngSectionHeaderBlockType)
+	binary.LittleEndian.PutUint32(shb[4:8], pcapngSectionHeaderBlockMinSize)
+	binary.LittleEndian.PutUint32(shb[8:12], pcapngByteOrderMagic)
+	// Major version 1, minor version 0.
+	binary.LittleEndian.PutUint16(shb[12:14], 1)
+	binary.LittleEndian.PutUint16(shb[14:16], 0)
+	// Section length is not specified.
+	binary.LittleEndian.PutUint64(shb[16:24], 0xFFFFFFFFFFFFFFFF)
+	binary.LittleEndian.PutUint32(shb[24:28], pcapngSectionHeaderBlockMinSize)
+	if _, err := w.Write(shb); err != nil {
+		return nil, err
+	}
+
+	// The if_name option, followed by the opt_endofopt option.
+	optionsLen := 4 + len(ifName) + padding(len(ifName)) + 4
+	idbLen := pcapngInterfaceDescBlockMinSize + optionsLen
+	idb := make([]byte, idbLen)
+	binary.LittleEndian.PutUint32(idb[0:4], pcapngInterfaceDescBlockType)
+	binary.LittleEndian.PutUint32(idb[4:8], uint32(idbLen))
+	binary.LittleEndian.PutUint16(idb[8:10], pcapngLinkTypeEthernet)
+	// The snap length is not limited.
+	binary.LittleEndian.PutUint32(idb[12:16], 0)
+	binary.LittleEndian.PutUint16(idb[16:18], pcapngOptionIfName)
+	binary.LittleEndian.PutUint16(idb[18:20], uint16(len(ifName)))
+	copy(idb[20:], ifName)
+	binary.LittleEndian.PutUint16(idb[idbLen-8:idbLen-6], pcapngOptionEndOfOpt)
+	binary.LittleEndian.PutUint32(idb[idbLen-4:], uint32(idbLen))
+	if _, err := w.Write(idb); err != nil {
+		return nil, err
+	}
+	return &pcapngWriter{w: w}, nil
+}
+
+// writePacket writes an Enhanced Packet Block holding the provided frame.
+func (w *pcapngWriter) writePacket(ts time.Time, data []byte) error {
+	epbLen := pcapngEnhancedPacketBlockMinSize + len(data) + padding(len(data))
+	epb := make([]byte, epbLen)
+	binary.LittleEndian.PutUint32(epb[0:4], pcapngEnhancedPacketBlockType)
+	binary.LittleEndian.PutUint32(epb[4:8], uint32(epbLen))
+	// The ID of the only interface in the section is 0.
+	binary.LittleEndian.PutUint32(epb[8:12], 0)
+	micros := uint64(ts.UnixMicro())
+	binary.LittleEndian.PutUint32(epb[12:16], uint32(micros>>32))
+	binary.LittleEndian.PutUint32(epb[16:20], uint32(micros))
+	binary.LittleEndian.PutUint32(epb[20:24], uint32(len(data)))
+	binary.LittleEndian.PutUint32(epb[24:28], uint32(len(data)))
+	copy(epb[28:], data)
+	binary.LittleEndian.PutUint32(epb[epbLen-4:], uint32(epbLen))
+	_, err := w.w.Write(epb)
+	return err
+}
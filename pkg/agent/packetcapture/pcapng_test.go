@@ -0,0 +1,76 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetcapture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPcapngWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := newPcapngWriter(&buf, "pod1-6631b7")
+	require.NoError(t, err)
+	ts := time.UnixMicro(1700000000123456)
+	packets := [][]byte{
+		bytes.Repeat([]byte{0xab}, 60),
+		bytes.Repeat([]byte{0xcd}, 61),
+	}
+	for _, p := range packets {
+		require.NoError(t, writer.writePacket(ts, p))
+	}
+
+	data := buf.Bytes()
+	// readBlock returns the type and the body of the block at the beginning
+	// of data, and the remaining data.
+	readBlock := func(data []byte) (uint32, []byte, []byte) {
+		require.GreaterOrEqual(t, len(data), 12)
+		blockType := binary.LittleEndian.Uint32(data[0:4])
+		length := binary.LittleEndian.Uint32(data[4:8])
+		require.Zero(t, length%4, "Block length must be a multiple of 4")
+		require.GreaterOrEqual(t, uint32(len(data)), length)
+		assert.Equal(t, length, binary.LittleEndian.Uint32(data[length-4:length]))
+		return blockType, data[8 : length-4], data[length:]
+	}
+
+	blockType, body, data := readBlock(data)
+	assert.Equal(t, pcapngSectionHeaderBlockType, blockType)
+	assert.Equal(t, pcapngByteOrderMagic, binary.LittleEndian.Uint32(body[0:4]))
+	assert.Equal(t, uint16(1), binary.LittleEndian.Uint16(body[4:6]))
+
+	blockType, body, data = readBlock(data)
+	assert.Equal(t, pcapngInterfaceDescBlockType, blockType)
+	assert.Equal(t, pcapngLinkTypeEthernet, binary.LittleEndian.Uint16(body[0:2]))
+	assert.Equal(t, pcapngOptionIfName, binary.LittleEndian.Uint16(body[8:10]))
+	nameLen := binary.LittleEndian.Uint16(body[10:12])
+	assert.Equal(t, "pod1-6631b7", string(body[12:12+nameLen]))
+
+	for _, p := range packets {
+		blockType, body, data = readBlock(data)
+		assert.Equal(t, pcapngEnhancedPacketBlockType, blockType)
+		micros := uint64(binary.LittleEndian.Uint32(body[4:8]))<<32 | uint64(binary.LittleEndian.Uint32(body[8:12]))
+		assert.Equal(t, uint64(ts.UnixMicro()), micros)
+		capturedLen := binary.LittleEndian.Uint32(body[12:16])
+		assert.Equal(t, uint32(len(p)), capturedLen)
+		assert.Equal(t, uint32(len(p)), binary.LittleEndian.Uint32(body[16:20]))
+		assert.Equal(t, p, body[20:20+capturedLen])
+	}
+	assert.Empty(t, data)
+}
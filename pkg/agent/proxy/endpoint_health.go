@@ -0,0 +1,310 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kmetrics "k8s.io/component-base/metrics"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/proxy/metrics"
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+const (
+	endpointHealthCheckPeriod  = 5 * time.Second
+	endpointHealthCheckTimeout = 1 * time.Second
+	// An Endpoint is considered unhealthy after failing endpointHealthCheckFailureThreshold consecutive health checks,
+	// and healthy again after passing endpointHealthCheckSuccessThreshold consecutive health checks.
+	endpointHealthCheckFailureThreshold = 3
+	endpointHealthCheckSuccessThreshold = 1
+)
+
+type endpointProbeFunc func(healthCheck *types.EndpointHealthCheck, endpoint k8sproxy.Endpoint, timeout time.Duration) error
+
+// probeEndpoint sends a health check to the target port of the Endpoint. TCP health checks pass if a connection can be
+// established. HTTP health checks pass if the response status code is between 200 and 399. The health checks are sent
+// from the host network namespace: the ones sent to local Endpoints are allowed like kubelet probes, and the ones sent
+// to the Endpoints on other Nodes are marked with EndpointHealthCheckDSCP, so that they are allowed by the peer Nodes
+// regardless of the ingress NetworkPolicies applied to the Endpoints.
+func probeEndpoint(healthCheck *types.EndpointHealthCheck, endpoint k8sproxy.Endpoint, timeout time.Duration) error {
+	port, err := endpoint.Port()
+	if err != nil {
+		return err
+	}
+	address := net.JoinHostPort(endpoint.IP(), strconv.Itoa(port))
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: markEndpointHealthCheck,
+	}
+	switch healthCheck.Protocol {
+	case types.EndpointHealthCheckProtocolTCP:
+		conn, err := dialer.Dial("tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case types.EndpointHealthCheckProtocolHTTP:
+		client := &http.Client{
+			Transport: &http.Transport{
+				DialContext:       dialer.DialContext,
+				DisableKeepAlives: true,
+			},
+			Timeout: timeout,
+			// Don't follow redirects, a redirect response is considered successful.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		resp, err := client.Get(fmt.Sprintf("http://%s%s", address, healthCheck.Path))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported health check protocol %s", healthCheck.Protocol)
+}
+
+// endpointProber periodically health checks an Endpoint of a Service.
+type endpointProber struct {
+	endpoint k8sproxy.Endpoint
+	stopCh   chan struct{}
+	// The following fields are protected by endpointHealthChecker.mutex.
+	healthy              bool
+	consecutiveFailures  int
+	consecutiveSuccesses int
+	lastError            string
+	lastProbeTime        time.Time
+}
+
+// serviceHealthCheck tracks the health checks of the Endpoints of a Service port.
+type serviceHealthCheck struct {
+	healthCheck types.EndpointHealthCheck
+	// probers is keyed by Endpoint string.
+	probers map[string]*endpointProber
+}
+
+// endpointHealthChecker health checks the Endpoints of the Services which have Endpoint health checks enabled. An
+// Endpoint is healthy until it fails enough consecutive health checks, so that traffic is not disrupted when
+// AntreaProxy starts or an Endpoint is added. onHealthChange is called when the health status of an Endpoint changes.
+type endpointHealthChecker struct {
+	mutex          sync.RWMutex
+	services       map[k8sproxy.ServicePortName]*serviceHealthCheck
+	isIPv6         bool
+	period         time.Duration
+	probeFn        endpointProbeFunc
+	onHealthChange func(svcPortName k8sproxy.ServicePortName)
+}
+
+func newEndpointHealthChecker(isIPv6 bool, onHealthChange func(svcPortName k8sproxy.ServicePortName)) *endpointHealthChecker {
+	return &endpointHealthChecker{
+		services:       map[k8sproxy.ServicePortName]*serviceHealthCheck{},
+		isIPv6:         isIPv6,
+		period:         endpointHealthCheckPeriod,
+		probeFn:        probeEndpoint,
+		onHealthChange: onHealthChange,
+	}
+}
+
+// syncService starts and stops the health checks of the Endpoints of a Service port, and returns the Endpoints which
+// are unhealthy. If healthCheck is nil, all health checks of the Service port are stopped.
+func (c *endpointHealthChecker) syncService(svcPortName k8sproxy.ServicePortName, healthCheck *types.EndpointHealthCheck, endpoints []k8sproxy.Endpoint) sets.Set[string] {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	svcHealthCheck, exists := c.services[svcPortName]
+	if healthCheck == nil {
+		if exists {
+			c.removeServiceLocked(svcPortName, svcHealthCheck)
+		}
+		return nil
+	}
+	// Restart all health checks if the way to check the Endpoints has changed.
+	if exists && svcHealthCheck.healthCheck != *healthCheck {
+		c.removeServiceLocked(svcPortName, svcHealthCheck)
+		exists = false
+	}
+	if !exists {
+		svcHealthCheck = &serviceHealthCheck{healthCheck: *healthCheck, probers: map[string]*endpointProber{}}
+		c.services[svcPortName] = svcHealthCheck
+	}
+
+	endpointSet := sets.New[string]()
+	for _, endpoint := range endpoints {
+		key := endpoint.String()
+		endpointSet.Insert(key)
+		if _, exists := svcHealthCheck.probers[key]; exists {
+			continue
+		}
+		prober := &endpointProber{endpoint: endpoint, stopCh: make(chan struct{}), healthy: true}
+		svcHealthCheck.probers[key] = prober
+		go wait.JitterUntil(func() {
+			c.probe(svcPortName, &svcHealthCheck.healthCheck, prober)
+		}, c.period, 0.1, true, prober.stopCh)
+	}
+	unhealthyEndpoints := sets.New[string]()
+	for key, prober := range svcHealthCheck.probers {
+		if !endpointSet.Has(key) {
+			c.removeProberLocked(svcHealthCheck, key, prober)
+			continue
+		}
+		if !prober.healthy {
+			unhealthyEndpoints.Insert(key)
+		}
+	}
+	return unhealthyEndpoints
+}
+
+// removeService stops all health checks of the Endpoints of a Service port.
+func (c *endpointHealthChecker) removeService(svcPortName k8sproxy.ServicePortName) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if svcHealthCheck, exists := c.services[svcPortName]; exists {
+		c.removeServiceLocked(svcPortName, svcHealthCheck)
+	}
+}
+
+func (c *endpointHealthChecker) removeServiceLocked(svcPortName k8sproxy.ServicePortName, svcHealthCheck *serviceHealthCheck) {
+	for key, prober := range svcHealthCheck.probers {
+		c.removeProberLocked(svcHealthCheck, key, prober)
+	}
+	delete(c.services, svcPortName)
+}
+
+func (c *endpointHealthChecker) removeProberLocked(svcHealthCheck *serviceHealthCheck, key string, prober *endpointProber) {
+	close(prober.stopCh)
+	if !prober.healthy {
+		c.unhealthyEndpointsMetric().Dec()
+	}
+	delete(svcHealthCheck.probers, key)
+}
+
+func (c *endpointHealthChecker) unhealthyEndpointsMetric() *kmetrics.Gauge {
+	if c.isIPv6 {
+		return metrics.EndpointsUnhealthyTotalV6
+	}
+	return metrics.EndpointsUnhealthyTotal
+}
+
+func (c *endpointHealthChecker) probe(svcPortName k8sproxy.ServicePortName, healthCheck *types.EndpointHealthCheck, prober *endpointProber) {
+	err := c.probeFn(healthCheck, prober.endpoint, endpointHealthCheckTimeout)
+
+	c.mutex.Lock()
+	select {
+	case <-prober.stopCh:
+		// The prober has been removed while probing.
+		c.mutex.Unlock()
+		return
+	default:
+	}
+	prober.lastProbeTime = time.Now()
+	healthChanged := false
+	if err != nil {
+		if c.isIPv6 {
+			metrics.EndpointHealthCheckFailuresTotalV6.Inc()
+		} else {
+			metrics.EndpointHealthCheckFailuresTotal.Inc()
+		}
+		prober.lastError = err.Error()
+		prober.consecutiveFailures++
+		prober.consecutiveSuccesses = 0
+		if prober.healthy && prober.consecutiveFailures >= endpointHealthCheckFailureThreshold {
+			prober.healthy = false
+			healthChanged = true
+			c.unhealthyEndpointsMetric().Inc()
+			klog.InfoS("Endpoint of Service became unhealthy", "ServicePortName", svcPortName, "Endpoint", prober.endpoint.String(), "err", err)
+		}
+	} else {
+		prober.lastError = ""
+		prober.consecutiveSuccesses++
+		prober.consecutiveFailures = 0
+		if !prober.healthy && prober.consecutiveSuccesses >= endpointHealthCheckSuccessThreshold {
+			prober.healthy = true
+			healthChanged = true
+			c.unhealthyEndpointsMetric().Dec()
+			klog.InfoS("Endpoint of Service became healthy", "ServicePortName", svcPortName, "Endpoint", prober.endpoint.String())
+		}
+	}
+	c.mutex.Unlock()
+
+	if healthChanged {
+		c.onHealthChange(svcPortName)
+	}
+}
+
+// getEndpointHealthStatus returns the health status of all health checked Endpoints, sorted by Service and Endpoint.
+func (c *endpointHealthChecker) getEndpointHealthStatus() []types.EndpointHealthStatus {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	var status []types.EndpointHealthStatus
+	for svcPortName, svcHealthCheck := range c.services {
+		for key, prober := range svcHealthCheck.probers {
+			status = append(status, types.EndpointHealthStatus{
+				Namespace:           svcPortName.Namespace,
+				ServiceName:         svcPortName.Name,
+				Port:                svcPortName.Port,
+				Endpoint:            key,
+				Healthy:             prober.healthy,
+				ConsecutiveFailures: prober.consecutiveFailures,
+				LastError:           prober.lastError,
+				LastProbeTime:       prober.lastProbeTime,
+			})
+		}
+	}
+	sort.Slice(status, func(i, j int) bool {
+		if status[i].Namespace != status[j].Namespace {
+			return status[i].Namespace < status[j].Namespace
+		}
+		if status[i].ServiceName != status[j].ServiceName {
+			return status[i].ServiceName < status[j].ServiceName
+		}
+		if status[i].Port != status[j].Port {
+			return status[i].Port < status[j].Port
+		}
+		return status[i].Endpoint < status[j].Endpoint
+	})
+	return status
+}
+
+// excludeUnhealthyEndpoints returns the Endpoints which are not unhealthy. If all Endpoints are unhealthy, all of them
+// are returned, as sending traffic to Endpoints failing health checks is better than dropping it.
+func excludeUnhealthyEndpoints(endpoints []k8sproxy.Endpoint, unhealthyEndpoints sets.Set[string]) []k8sproxy.Endpoint {
+	if len(endpoints) == 0 || unhealthyEndpoints.Len() == 0 {
+		return endpoints
+	}
+	healthyEndpoints := make([]k8sproxy.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if !unhealthyEndpoints.Has(endpoint.String()) {
+			healthyEndpoints = append(healthyEndpoints, endpoint)
+		}
+	}
+	if len(healthyEndpoints) == 0 {
+		return endpoints
+	}
+	return healthyEndpoints
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"antrea.io/antrea/pkg/agent/types"
+)
+
+// markEndpointHealthCheck sets the DSCP of the packets sent by the socket of an Endpoint health check to
+// EndpointHealthCheckDSCP. It is used as the Control function of the dialer of the health checks.
+func markEndpointHealthCheck(network, _ string, c syscall.RawConn) error {
+	tos := types.EndpointHealthCheckDSCP << 2
+	var sockErr error
+	if err := c.Control(func(fd uintptr) {
+		if network == "tcp6" {
+			sockErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IPV6, unix.IPV6_TCLASS, tos)
+		} else {
+			sockErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TOS, tos)
+		}
+	}); err != nil {
+		return err
+	}
+	return sockErr
+}
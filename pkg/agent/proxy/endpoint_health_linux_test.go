@@ -0,0 +1,63 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"antrea.io/antrea/pkg/agent/types"
+)
+
+func TestMarkEndpointHealthCheck(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		network string
+		address string
+		level   int
+		option  int
+	}{
+		{name: "IPv4", network: "tcp4", address: "127.0.0.1:0", level: unix.IPPROTO_IP, option: unix.IP_TOS},
+		{name: "IPv6", network: "tcp6", address: "[::1]:0", level: unix.IPPROTO_IPV6, option: unix.IPV6_TCLASS},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			listener, err := net.Listen(tc.network, tc.address)
+			if err != nil {
+				t.Skipf("Failed to listen on %s: %v", tc.address, err)
+			}
+			defer listener.Close()
+
+			dialer := &net.Dialer{Control: markEndpointHealthCheck}
+			conn, err := dialer.Dial("tcp", listener.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			rawConn, err := conn.(*net.TCPConn).SyscallConn()
+			require.NoError(t, err)
+			var tos int
+			var sockErr error
+			require.NoError(t, rawConn.Control(func(fd uintptr) {
+				tos, sockErr = unix.GetsockoptInt(int(fd), tc.level, tc.option)
+			}))
+			require.NoError(t, sockErr)
+			// The DSCP is the 6 most significant bits of the TOS or Traffic Class.
+			assert.Equal(t, types.EndpointHealthCheckDSCP, tos>>2)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+func newTestEndpoint(ip string, port int) k8sproxy.Endpoint {
+	return k8sproxy.NewBaseEndpointInfo(ip, "", "", port, false, true, true, false, nil)
+}
+
+func TestProbeEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	endpoint := newTestEndpoint(host, port)
+
+	assert.NoError(t, probeEndpoint(&types.EndpointHealthCheck{Protocol: types.EndpointHealthCheckProtocolTCP}, endpoint, time.Second))
+	assert.NoError(t, probeEndpoint(&types.EndpointHealthCheck{Protocol: types.EndpointHealthCheckProtocolHTTP, Path: "/healthz"}, endpoint, time.Second))
+	assert.ErrorContains(t, probeEndpoint(&types.EndpointHealthCheck{Protocol: types.EndpointHealthCheckProtocolHTTP, Path: "/"}, endpoint, time.Second), "unexpected HTTP status code 503")
+
+	server.Close()
+	assert.Error(t, probeEndpoint(&types.EndpointHealthCheck{Protocol: types.EndpointHealthCheckProtocolTCP}, endpoint, time.Second))
+}
+
+func TestEndpointHealthChecker(t *testing.T) {
+	svcPortName := makeSvcPortName("ns1", "svc1", "80", "TCP")
+	healthCheck := &types.EndpointHealthCheck{Protocol: types.EndpointHealthCheckProtocolTCP}
+	ep1 := newTestEndpoint("10.10.0.1", 80)
+	ep2 := newTestEndpoint("10.10.0.2", 80)
+
+	var mutex sync.Mutex
+	failingEndpoints := sets.New[string]()
+	healthChanges := make(chan k8sproxy.ServicePortName, 10)
+	checker := newEndpointHealthChecker(false, func(svcPortName k8sproxy.ServicePortName) {
+		healthChanges <- svcPortName
+	})
+	checker.period = 10 * time.Millisecond
+	checker.probeFn = func(_ *types.EndpointHealthCheck, endpoint k8sproxy.Endpoint, _ time.Duration) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if failingEndpoints.Has(endpoint.String()) {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	}
+	defer checker.removeService(svcPortName)
+
+	// The Endpoints are healthy when they are added.
+	unhealthyEndpoints := checker.syncService(svcPortName, healthCheck, []k8sproxy.Endpoint{ep1, ep2})
+	assert.Empty(t, unhealthyEndpoints)
+
+	mutex.Lock()
+	failingEndpoints.Insert(ep2.String())
+	mutex.Unlock()
+	select {
+	case changedSvcPortName := <-healthChanges:
+		assert.Equal(t, svcPortName, changedSvcPortName)
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the health change of the Endpoint")
+	}
+	unhealthyEndpoints = checker.syncService(svcPortName, healthCheck, []k8sproxy.Endpoint{ep1, ep2})
+	assert.Equal(t, sets.New[string](ep2.String()), unhealthyEndpoints)
+	status := checker.getEndpointHealthStatus()
+	require.Len(t, status, 2)
+	assert.True(t, status[0].Healthy)
+	assert.False(t, status[1].Healthy)
+	assert.Equal(t, ep2.String(), status[1].Endpoint)
+	assert.Equal(t, "connection refused", status[1].LastError)
+	assert.GreaterOrEqual(t, status[1].ConsecutiveFailures, endpointHealthCheckFailureThreshold)
+
+	mutex.Lock()
+	failingEndpoints.Delete(ep2.String())
+	mutex.Unlock()
+	select {
+	case <-healthChanges:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the health change of the Endpoint")
+	}
+	assert.Empty(t, checker.syncService(svcPortName, healthCheck, []k8sproxy.Endpoint{ep1, ep2}))
+
+	// Removed Endpoints are no longer health checked.
+	checker.syncService(svcPortName, healthCheck, []k8sproxy.Endpoint{ep1})
+	status = checker.getEndpointHealthStatus()
+	require.Len(t, status, 1)
+	assert.Equal(t, ep1.String(), status[0].Endpoint)
+
+	// Disabling the health checks of the Service removes all of them.
+	assert.Nil(t, checker.syncService(svcPortName, nil, []k8sproxy.Endpoint{ep1}))
+	assert.Empty(t, checker.getEndpointHealthStatus())
+}
+
+func TestExcludeUnhealthyEndpoints(t *testing.T) {
+	ep1 := newTestEndpoint("10.10.0.1", 80)
+	ep2 := newTestEndpoint("10.10.0.2", 80)
+	tests := []struct {
+		name               string
+		endpoints          []k8sproxy.Endpoint
+		unhealthyEndpoints sets.Set[string]
+		expectedEndpoints  []k8sproxy.Endpoint
+	}{
+		{
+			name:               "nil Endpoints",
+			endpoints:          nil,
+			unhealthyEndpoints: sets.New[string](ep1.String()),
+			expectedEndpoints:  nil,
+		},
+		{
+			name:               "no unhealthy Endpoint",
+			endpoints:          []k8sproxy.Endpoint{ep1, ep2},
+			unhealthyEndpoints: nil,
+			expectedEndpoints:  []k8sproxy.Endpoint{ep1, ep2},
+		},
+		{
+			name:               "some unhealthy Endpoints",
+			endpoints:          []k8sproxy.Endpoint{ep1, ep2},
+			unhealthyEndpoints: sets.New[string](ep1.String()),
+			expectedEndpoints:  []k8sproxy.Endpoint{ep2},
+		},
+		{
+			name:               "all unhealthy Endpoints",
+			endpoints:          []k8sproxy.Endpoint{ep1, ep2},
+			unhealthyEndpoints: sets.New[string](ep1.String(), ep2.String()),
+			expectedEndpoints:  []k8sproxy.Endpoint{ep1, ep2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedEndpoints, excludeUnhealthyEndpoints(tt.endpoints, tt.unhealthyEndpoints))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"hash/fnv"
+	"sort"
+
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+// maglevTableSize is the size of the Maglev lookup table, i.e. the number of buckets of the group of a Service using
+// the Maglev load balancer algorithm. It must be a prime number. A larger size spreads the connections more evenly
+// across the Endpoints, at the cost of more buckets per group. With the default size, the number of connections
+// handled by each Endpoint differs by less than 10% as long as the Service has fewer than 100 Endpoints.
+const maglevTableSize = 1021
+
+func maglevHash(key string, seed byte) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte{seed})
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
+// buildMaglevTable returns the Maglev lookup table of the provided Endpoints, as described in "Maglev: A Fast and
+// Reliable Software Network Load Balancer". Each entry of the table is one of the Endpoints, and each Endpoint fills
+// almost the same number of entries. The table only depends on the set of Endpoints, so it's identical on all Nodes,
+// and adding or removing an Endpoint only changes the entries filled by that Endpoint plus a small portion of the
+// other entries.
+//
+// The table is programmed as a select group with one bucket per entry, which uses the "hash" selection method of OVS
+// with the 5-tuple of the packets and a fixed hash basis. The bucket of a connection is then determined only by its
+// 5-tuple and the fixed number of buckets, so all Nodes with the same Endpoints select the same Endpoint for it.
+func buildMaglevTable(endpoints []k8sproxy.Endpoint) []k8sproxy.Endpoint {
+	if len(endpoints) == 0 {
+		return endpoints
+	}
+	// Sort the Endpoints to make the result independent of the order in which they are provided.
+	sortedEndpoints := make([]k8sproxy.Endpoint, len(endpoints))
+	copy(sortedEndpoints, endpoints)
+	sort.Slice(sortedEndpoints, func(i, j int) bool {
+		return sortedEndpoints[i].String() < sortedEndpoints[j].String()
+	})
+
+	n := len(sortedEndpoints)
+	// The permutation of the entries for Endpoint i is (offsets[i] + j * skips[i]) % maglevTableSize.
+	offsets := make([]uint64, n)
+	skips := make([]uint64, n)
+	for i, endpoint := range sortedEndpoints {
+		key := endpoint.String()
+		offsets[i] = maglevHash(key, 0) % maglevTableSize
+		skips[i] = maglevHash(key, 1)%(maglevTableSize-1) + 1
+	}
+	next := make([]uint64, n)
+	entries := make([]int, maglevTableSize)
+	for i := range entries {
+		entries[i] = -1
+	}
+	filled := 0
+	for filled < maglevTableSize {
+		for i := 0; i < n && filled < maglevTableSize; i++ {
+			entry := (offsets[i] + next[i]*skips[i]) % maglevTableSize
+			for entries[entry] >= 0 {
+				next[i]++
+				entry = (offsets[i] + next[i]*skips[i]) % maglevTableSize
+			}
+			entries[entry] = i
+			next[i]++
+			filled++
+		}
+	}
+
+	table := make([]k8sproxy.Endpoint, maglevTableSize)
+	for i, endpointIdx := range entries {
+		table[i] = sortedEndpoints[endpointIdx]
+	}
+	return table
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+func genMaglevTestEndpoints(n int) []k8sproxy.Endpoint {
+	endpoints := make([]k8sproxy.Endpoint, n)
+	for i := 0; i < n; i++ {
+		endpoints[i] = k8sproxy.NewBaseEndpointInfo(fmt.Sprintf("10.10.%d.%d", i/250, i%250+1), "", "", 80, false, true, true, false, nil)
+	}
+	return endpoints
+}
+
+func TestBuildMaglevTable(t *testing.T) {
+	assert.Empty(t, buildMaglevTable([]k8sproxy.Endpoint{}))
+
+	endpoints := genMaglevTestEndpoints(10)
+	table := buildMaglevTable(endpoints)
+	require.Len(t, table, maglevTableSize)
+
+	// Each Endpoint fills almost the same number of entries.
+	counts := map[string]int{}
+	for _, endpoint := range table {
+		counts[endpoint.String()]++
+	}
+	require.Len(t, counts, len(endpoints))
+	for _, count := range counts {
+		assert.InDelta(t, maglevTableSize/len(endpoints), count, 1)
+	}
+
+	// The table doesn't depend on the order of the Endpoints.
+	reversedEndpoints := make([]k8sproxy.Endpoint, len(endpoints))
+	for i := range endpoints {
+		reversedEndpoints[len(endpoints)-1-i] = endpoints[i]
+	}
+	assert.Equal(t, table, buildMaglevTable(reversedEndpoints))
+
+	// Removing an Endpoint only remaps its entries and a small portion of the other entries.
+	removedEndpoint := endpoints[3].String()
+	newTable := buildMaglevTable(append(endpoints[:3:3], endpoints[4:]...))
+	changed := 0
+	for i := range table {
+		if table[i].String() == removedEndpoint {
+			assert.NotEqual(t, removedEndpoint, newTable[i].String())
+			continue
+		}
+		if table[i].String() != newTable[i].String() {
+			changed++
+		}
+	}
+	assert.Less(t, changed, maglevTableSize/10)
+}
@@ -80,6 +80,26 @@ var (
 			Help:           "The cumulative number of Endpoint updates received by AntreaProxy",
 		},
 	)
+	EndpointsUnhealthyTotal = kmetrics.NewGauge(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v4"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "total_unhealthy_endpoints",
+			Help:           "The number of Endpoints detected unhealthy by the Endpoint health checks of AntreaProxy",
+		},
+	)
+	EndpointHealthCheckFailuresTotal = kmetrics.NewCounter(
+		&kmetrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v4"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "total_endpoint_health_check_failures",
+			Help:           "The cumulative number of failed Endpoint health checks of AntreaProxy",
+		},
+	)
 
 	SyncProxyDurationV6 = kmetrics.NewHistogram(
 		&kmetrics.HistogramOpts{
@@ -131,6 +151,26 @@ var (
 			Help:           "The cumulative number of Endpoint updates received by AntreaProxy",
 		},
 	)
+	EndpointsUnhealthyTotalV6 = kmetrics.NewGauge(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v6"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "total_unhealthy_endpoints",
+			Help:           "The number of Endpoints detected unhealthy by the Endpoint health checks of AntreaProxy",
+		},
+	)
+	EndpointHealthCheckFailuresTotalV6 = kmetrics.NewCounter(
+		&kmetrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v6"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "total_endpoint_health_check_failures",
+			Help:           "The cumulative number of failed Endpoint health checks of AntreaProxy",
+		},
+	)
 )
 
 func Register() {
@@ -142,11 +182,15 @@ func Register() {
 			EndpointsInstalledTotal,
 			ServicesUpdatesTotal,
 			EndpointsUpdatesTotal,
+			EndpointsUnhealthyTotal,
+			EndpointHealthCheckFailuresTotal,
 			SyncProxyDurationV6,
 			ServicesInstalledTotalV6,
 			EndpointsInstalledTotalV6,
 			ServicesUpdatesTotalV6,
 			EndpointsUpdatesTotalV6,
+			EndpointsUnhealthyTotalV6,
+			EndpointHealthCheckFailuresTotalV6,
 		)
 	})
 }
@@ -16,6 +16,7 @@ package proxy
 
 import (
 	"fmt"
+	"maps"
 	"math"
 	"net"
 	"reflect"
@@ -81,6 +82,9 @@ type Proxier interface {
 	// GetServiceByIP returns the ServicePortName struct for the given serviceString(ClusterIP:Port/Proto).
 	// False is returned if the serviceString is not found in serviceStringMap.
 	GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool)
+	// GetEndpointHealthStatus returns the health status of the Endpoints of the Services which have Endpoint health
+	// checks enabled.
+	GetEndpointHealthStatus() []types.EndpointHealthStatus
 }
 
 type proxier struct {
@@ -111,6 +115,14 @@ type proxier struct {
 	serviceEndpointsMapsMutex sync.Mutex
 	// endpointReferenceCounter stores the number of times an Endpoint is referenced by Services.
 	endpointReferenceCounter map[string]int
+	// endpointHealthChecker health checks the Endpoints of the Services which have Endpoint health checks enabled.
+	endpointHealthChecker *endpointHealthChecker
+	// unhealthyEndpointsInstalledMap stores the unhealthy Endpoints which have been excluded from the groups of a
+	// Service.
+	unhealthyEndpointsInstalledMap map[k8sproxy.ServicePortName]sets.Set[string]
+	// endpointWeightsInstalledMap stores the weights of the Endpoints installed for a Service splitting its traffic
+	// across other Services.
+	endpointWeightsInstalledMap map[k8sproxy.ServicePortName]map[string]uint16
 	// groupCounter is used to allocate groupID.
 	groupCounter types.GroupCounter
 	// serviceStringMap provides map from serviceString(ClusterIP:Port/Proto) to ServicePortName.
@@ -163,6 +175,11 @@ type proxier struct {
 	// decision for packets of a connection, we use "learn" action to generate a learned flow when processing the first
 	// packet of a connection, and rely on the learned flow to process subsequent packets of the same connection.
 	defaultLoadBalancerMode agentconfig.LoadBalancerMode
+	// The algorithm used to select the Endpoints of a Service by default. With LoadBalancerAlgorithmMaglev, the groups
+	// of the Service are programmed with the Maglev lookup table of the Endpoints and select buckets with a hash of the
+	// 5-tuple, so that all Nodes select the same Endpoint for a connection, and the Endpoint rarely changes when other
+	// Endpoints are added or removed.
+	defaultLoadBalancerAlgorithm agentconfig.LoadBalancerAlgorithm
 }
 
 func (p *proxier) SyncedOnce() bool {
@@ -219,6 +236,9 @@ func (p *proxier) removeStaleServices() {
 				continue
 			}
 		}
+		p.endpointHealthChecker.removeService(svcPortName)
+		delete(p.unhealthyEndpointsInstalledMap, svcPortName)
+		delete(p.endpointWeightsInstalledMap, svcPortName)
 
 		delete(p.serviceInstalledMap, svcPortName)
 		p.deleteServiceByIP(svcInfoStr)
@@ -257,7 +277,7 @@ func (p *proxier) removeServiceFlows(svcInfo *types.ServiceInfo) bool {
 	return true
 }
 
-func (p *proxier) installServiceGroup(svcPortName k8sproxy.ServicePortName, needUpdate, local, withSessionAffinity bool, endpoints []k8sproxy.Endpoint) (binding.GroupIDType, bool) {
+func (p *proxier) installServiceGroup(svcPortName k8sproxy.ServicePortName, needUpdate, local, withSessionAffinity, hashSelection bool, endpoints []k8sproxy.Endpoint) (binding.GroupIDType, bool) {
 	groupID, exists := p.groupCounter.Get(svcPortName, local)
 	if exists && !needUpdate {
 		return groupID, true
@@ -272,7 +292,7 @@ func (p *proxier) installServiceGroup(svcPortName k8sproxy.ServicePortName, need
 			}
 		}()
 	}
-	if err := p.ofClient.InstallServiceGroup(groupID, withSessionAffinity, endpoints); err != nil {
+	if err := p.ofClient.InstallServiceGroup(groupID, withSessionAffinity, hashSelection, endpoints); err != nil {
 		klog.ErrorS(err, "Error when installing group of Endpoints for Service", "ServicePortName", svcPortName, "local", local)
 		return 0, false
 	}
@@ -723,6 +743,12 @@ func (p *proxier) installServices() {
 			p.endpointsInstalledMap[svcPortName] = endpointsInstalled
 		}
 		endpointsToInstall := p.endpointsMap[svcPortName]
+		var endpointWeights map[string]uint16
+		if len(svcInfo.TrafficSplit) > 0 {
+			endpointsToInstall, endpointWeights = p.getTrafficSplitEndpoints(svcPortName, svcInfo.TrafficSplit)
+		} else if len(svcInfo.EndpointWeights) > 0 {
+			endpointsToInstall, endpointWeights = p.getWeightedEndpoints(endpointsToInstall, svcInfo.EndpointWeights)
+		}
 
 		installedSvcPort, ok := p.serviceInstalledMap[svcPortName]
 		var pSvcInfo *types.ServiceInfo
@@ -742,7 +768,8 @@ func (p *proxier) installServices() {
 			needUpdateServiceExternalAddresses = serviceExternalAddressesChanged(svcInfo, pSvcInfo)
 			needUpdateEndpoints = pSvcInfo.SessionAffinityType() != svcInfo.SessionAffinityType() ||
 				pSvcInfo.ExternalPolicyLocal() != svcInfo.ExternalPolicyLocal() ||
-				pSvcInfo.InternalPolicyLocal() != svcInfo.InternalPolicyLocal()
+				pSvcInfo.InternalPolicyLocal() != svcInfo.InternalPolicyLocal() ||
+				p.getLoadBalancerAlgorithm(pSvcInfo) != p.getLoadBalancerAlgorithm(svcInfo) // It affects the buckets of the groups.
 			if p.cleanupStaleUDPSvcConntrack && needClearConntrackEntries(pSvcInfo.OFProtocol) {
 				needCleanupStaleUDPServiceConntrack = svcInfo.Port() != pSvcInfo.Port() ||
 					svcInfo.ClusterIP().String() != pSvcInfo.ClusterIP().String() ||
@@ -761,6 +788,9 @@ func (p *proxier) installServices() {
 		if len(staleEndpoints) > 0 || len(newEndpoints) > 0 {
 			needUpdateEndpoints = true
 		}
+		if !maps.Equal(endpointWeights, p.endpointWeightsInstalledMap[svcPortName]) {
+			needUpdateEndpoints = true
+		}
 		// If there are stale Endpoints for a UDP Service, conntrack connections of these stale Endpoints should be deleted.
 		if len(staleEndpoints) > 0 && needClearConntrackEntries(svcInfo.OFProtocol) {
 			needCleanupStaleUDPServiceConntrack = true
@@ -775,14 +805,35 @@ func (p *proxier) installServices() {
 			}
 		}
 
+		// Exclude the Endpoints failing the health checks from the groups. Endpoint flows are kept for them as they
+		// may become healthy again soon.
+		unhealthyEndpoints := p.endpointHealthChecker.syncService(svcPortName, svcInfo.EndpointHealthCheck, allReachableEndpoints)
+		if !unhealthyEndpoints.Equal(p.unhealthyEndpointsInstalledMap[svcPortName]) {
+			needUpdateEndpoints = true
+		}
+		clusterEndpoints = excludeUnhealthyEndpoints(clusterEndpoints, unhealthyEndpoints)
+		localEndpoints = excludeUnhealthyEndpoints(localEndpoints, unhealthyEndpoints)
+
 		withSessionAffinity := svcInfo.SessionAffinityType() == corev1.ServiceAffinityClientIP
+		// The Maglev lookup table doesn't take the weights of the Endpoints into account, so it's not used when the
+		// traffic of the Service is split across other Services or its Endpoints are weighted.
+		useMaglev := len(endpointWeights) == 0 && p.getLoadBalancerAlgorithm(svcInfo) == agentconfig.LoadBalancerAlgorithmMaglev
+		if useMaglev {
+			// Each entry of the Maglev lookup table is installed as a bucket of the group.
+			if localEndpoints != nil {
+				localEndpoints = buildMaglevTable(localEndpoints)
+			}
+			if clusterEndpoints != nil {
+				clusterEndpoints = buildMaglevTable(clusterEndpoints)
+			}
+		}
 		var localGroupID, clusterGroupID binding.GroupIDType
 		// categorizeEndpoints has checked if localGroup and clusterGroup should exist. We just create the group if its
 		// Endpoints is not nil.
 		// Note that nil represents the group should not exist and empty represents the group should exist but there is
 		// no available Endpoints.
 		if localEndpoints != nil {
-			if localGroupID, ok = p.installServiceGroup(svcPortName, needUpdateEndpoints, true, withSessionAffinity, localEndpoints); !ok {
+			if localGroupID, ok = p.installServiceGroup(svcPortName, needUpdateEndpoints, true, withSessionAffinity, useMaglev, localEndpoints); !ok {
 				continue
 			}
 		} else {
@@ -791,7 +842,7 @@ func (p *proxier) installServices() {
 			}
 		}
 		if clusterEndpoints != nil {
-			if clusterGroupID, ok = p.installServiceGroup(svcPortName, needUpdateEndpoints, false, withSessionAffinity, clusterEndpoints); !ok {
+			if clusterGroupID, ok = p.installServiceGroup(svcPortName, needUpdateEndpoints, false, withSessionAffinity, useMaglev, clusterEndpoints); !ok {
 				continue
 			}
 		} else {
@@ -821,6 +872,16 @@ func (p *proxier) installServices() {
 			}
 		}
 
+		if len(endpointWeights) > 0 {
+			p.endpointWeightsInstalledMap[svcPortName] = endpointWeights
+		} else {
+			delete(p.endpointWeightsInstalledMap, svcPortName)
+		}
+		if unhealthyEndpoints.Len() > 0 {
+			p.unhealthyEndpointsInstalledMap[svcPortName] = unhealthyEndpoints
+		} else {
+			delete(p.unhealthyEndpointsInstalledMap, svcPortName)
+		}
 		p.serviceInstalledMap[svcPortName] = svcPort
 		p.addServiceByIP(svcInfoStr, svcPortName)
 	}
@@ -839,6 +900,15 @@ func (p *proxier) getLoadBalancerMode(svcInfo *types.ServiceInfo) agentconfig.Lo
 	return *svcInfo.LoadBalancerMode
 }
 
+// getLoadBalancerAlgorithm returns the default load balancer algorithm if the Service doesn't have the annotation
+// overriding it. Otherwise, it returns the algorithm specified in the annotation.
+func (p *proxier) getLoadBalancerAlgorithm(svcInfo *types.ServiceInfo) agentconfig.LoadBalancerAlgorithm {
+	if svcInfo.LoadBalancerAlgorithm == nil {
+		return p.defaultLoadBalancerAlgorithm
+	}
+	return *svcInfo.LoadBalancerAlgorithm
+}
+
 func getAffinityTimeout(svcInfo *types.ServiceInfo) uint16 {
 	affinityTimeout := svcInfo.StickyMaxAgeSeconds()
 	if svcInfo.StickyMaxAgeSeconds() > maxSupportedAffinityTimeout {
@@ -1204,6 +1274,17 @@ func (p *proxier) OnNodeDelete(node *corev1.Node) {
 func (p *proxier) OnNodeSynced() {
 }
 
+// onEndpointHealthChange is called when the health status of an Endpoint changes. The groups of the Service will be
+// updated by the next syncProxyRules.
+func (p *proxier) onEndpointHealthChange(svcPortName k8sproxy.ServicePortName) {
+	klog.V(2).InfoS("Health status of Endpoints changed, syncing proxy rules", "ServicePortName", svcPortName)
+	p.runner.Run()
+}
+
+func (p *proxier) GetEndpointHealthStatus() []types.EndpointHealthStatus {
+	return p.endpointHealthChecker.getEndpointHealthStatus()
+}
+
 func (p *proxier) GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool) {
 	p.serviceStringMapMutex.Lock()
 	defer p.serviceStringMapMutex.Unlock()
@@ -1362,6 +1443,7 @@ func newProxier(
 	skipServices []string,
 	proxyLoadBalancerIPs bool,
 	defaultLoadBalancerMode agentconfig.LoadBalancerMode,
+	defaultLoadBalancerAlgorithm agentconfig.LoadBalancerAlgorithm,
 	groupCounter types.GroupCounter,
 	supportNestedService bool) (*proxier, error) {
 	recorder := record.NewBroadcaster().NewRecorder(
@@ -1412,35 +1494,39 @@ func newProxier(
 	serviceLabelSelector = serviceLabelSelector.Add(*serviceProxyNameSelector, *nonHeadlessServiceSelector)
 
 	p := &proxier{
-		nodeIPChecker:               nodeIPChecker,
-		serviceConfig:               config.NewServiceConfig(serviceInformer, resyncPeriod),
-		endpointsChanges:            newEndpointsChangesTracker(hostname, endpointSliceEnabled, isIPv6),
-		serviceChanges:              newServiceChangesTracker(recorder, ipFamily, serviceLabelSelector, skipServices),
-		serviceMap:                  k8sproxy.ServiceMap{},
-		serviceInstalledMap:         k8sproxy.ServiceMap{},
-		endpointsInstalledMap:       types.EndpointsMap{},
-		endpointsMap:                types.EndpointsMap{},
-		endpointReferenceCounter:    map[string]int{},
-		serviceIPRouteReferences:    map[string]sets.Set[string]{},
-		nodeLabels:                  map[string]string{},
-		serviceStringMap:            map[string]k8sproxy.ServicePortName{},
-		groupCounter:                groupCounter,
-		ofClient:                    ofClient,
-		routeClient:                 routeClient,
-		nodePortAddresses:           nodePortAddresses,
-		isIPv6:                      isIPv6,
-		proxyAll:                    proxyAllEnabled,
-		endpointSliceEnabled:        endpointSliceEnabled,
-		topologyAwareHintsEnabled:   topologyAwareHintsEnabled,
-		cleanupStaleUDPSvcConntrack: features.DefaultFeatureGate.Enabled(features.CleanupStaleUDPSvcConntrack),
-		proxyLoadBalancerIPs:        proxyLoadBalancerIPs,
-		hostname:                    hostname,
-		serviceHealthServer:         serviceHealthServer,
-		numLocalEndpoints:           map[apimachinerytypes.NamespacedName]int{},
-		supportNestedService:        supportNestedService,
-		defaultLoadBalancerMode:     defaultLoadBalancerMode,
-	}
-
+		nodeIPChecker:                  nodeIPChecker,
+		serviceConfig:                  config.NewServiceConfig(serviceInformer, resyncPeriod),
+		endpointsChanges:               newEndpointsChangesTracker(hostname, endpointSliceEnabled, isIPv6),
+		serviceChanges:                 newServiceChangesTracker(recorder, ipFamily, serviceLabelSelector, skipServices),
+		serviceMap:                     k8sproxy.ServiceMap{},
+		serviceInstalledMap:            k8sproxy.ServiceMap{},
+		endpointsInstalledMap:          types.EndpointsMap{},
+		endpointsMap:                   types.EndpointsMap{},
+		endpointReferenceCounter:       map[string]int{},
+		unhealthyEndpointsInstalledMap: map[k8sproxy.ServicePortName]sets.Set[string]{},
+		endpointWeightsInstalledMap:    map[k8sproxy.ServicePortName]map[string]uint16{},
+		serviceIPRouteReferences:       map[string]sets.Set[string]{},
+		nodeLabels:                     map[string]string{},
+		serviceStringMap:               map[string]k8sproxy.ServicePortName{},
+		groupCounter:                   groupCounter,
+		ofClient:                       ofClient,
+		routeClient:                    routeClient,
+		nodePortAddresses:              nodePortAddresses,
+		isIPv6:                         isIPv6,
+		proxyAll:                       proxyAllEnabled,
+		endpointSliceEnabled:           endpointSliceEnabled,
+		topologyAwareHintsEnabled:      topologyAwareHintsEnabled,
+		cleanupStaleUDPSvcConntrack:    features.DefaultFeatureGate.Enabled(features.CleanupStaleUDPSvcConntrack),
+		proxyLoadBalancerIPs:           proxyLoadBalancerIPs,
+		hostname:                       hostname,
+		serviceHealthServer:            serviceHealthServer,
+		numLocalEndpoints:              map[apimachinerytypes.NamespacedName]int{},
+		supportNestedService:           supportNestedService,
+		defaultLoadBalancerMode:        defaultLoadBalancerMode,
+		defaultLoadBalancerAlgorithm:   defaultLoadBalancerAlgorithm,
+	}
+
+	p.endpointHealthChecker = newEndpointHealthChecker(isIPv6, p.onEndpointHealthChange)
 	p.serviceConfig.RegisterEventHandler(p)
 	p.runner = k8sproxy.NewBoundedFrequencyRunner(componentName, p.syncProxyRules, time.Second, 30*time.Second, 2)
 	if endpointSliceEnabled {
@@ -1477,6 +1563,10 @@ func (p *metaProxierWrapper) GetServiceFlowKeys(serviceName, namespace string) (
 	return append(v4Flows, v6Flows...), append(v4Groups, v6Groups...), v4Found || v6Found
 }
 
+func (p *metaProxierWrapper) GetEndpointHealthStatus() []types.EndpointHealthStatus {
+	return append(p.ipv4Proxier.GetEndpointHealthStatus(), p.ipv6Proxier.GetEndpointHealthStatus()...)
+}
+
 func (p *metaProxierWrapper) GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool) {
 	// Format of serviceStr is <clusterIP>:<svcPort>/<protocol>.
 	lastColonIndex := strings.LastIndex(serviceStr, ":")
@@ -1503,6 +1593,7 @@ func newDualStackProxier(
 	skipServices []string,
 	proxyLoadBalancerIPs bool,
 	defaultLoadBalancerMode agentconfig.LoadBalancerMode,
+	defaultLoadBalancerAlgorithm agentconfig.LoadBalancerAlgorithm,
 	v4groupCounter types.GroupCounter,
 	v6groupCounter types.GroupCounter,
 	nestedServiceSupport bool) (*metaProxierWrapper, error) {
@@ -1524,6 +1615,7 @@ func newDualStackProxier(
 		skipServices,
 		proxyLoadBalancerIPs,
 		defaultLoadBalancerMode,
+		defaultLoadBalancerAlgorithm,
 		v4groupCounter,
 		nestedServiceSupport)
 	if err != nil {
@@ -1546,6 +1638,7 @@ func newDualStackProxier(
 		skipServices,
 		proxyLoadBalancerIPs,
 		defaultLoadBalancerMode,
+		defaultLoadBalancerAlgorithm,
 		v6groupCounter,
 		nestedServiceSupport)
 	if err != nil {
@@ -1573,6 +1666,7 @@ func NewProxier(hostname string,
 	nodePortAddressesIPv6 []net.IP,
 	proxyConfig antreaconfig.AntreaProxyConfig,
 	defaultLoadBalancerMode agentconfig.LoadBalancerMode,
+	defaultLoadBalancerAlgorithm agentconfig.LoadBalancerAlgorithm,
 	v4GroupCounter types.GroupCounter,
 	v6GroupCounter types.GroupCounter,
 	nestedServiceSupport bool) (Proxier, error) {
@@ -1601,6 +1695,7 @@ func NewProxier(hostname string,
 			skipServices,
 			proxyLoadBalancerIPs,
 			defaultLoadBalancerMode,
+			defaultLoadBalancerAlgorithm,
 			v4GroupCounter,
 			v6GroupCounter,
 			nestedServiceSupport)
@@ -1624,6 +1719,7 @@ func NewProxier(hostname string,
 			skipServices,
 			proxyLoadBalancerIPs,
 			defaultLoadBalancerMode,
+			defaultLoadBalancerAlgorithm,
 			v4GroupCounter,
 			nestedServiceSupport)
 		if err != nil {
@@ -1646,6 +1742,7 @@ func NewProxier(hostname string,
 			skipServices,
 			proxyLoadBalancerIPs,
 			defaultLoadBalancerMode,
+			defaultLoadBalancerAlgorithm,
 			v6GroupCounter,
 			nestedServiceSupport)
 		if err != nil {
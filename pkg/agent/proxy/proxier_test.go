@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
@@ -327,13 +328,14 @@ func makeTestEndpoints(svcPortName *k8sproxy.ServicePortName, epSubsets []corev1
 }
 
 type proxyOptions struct {
-	proxyAllEnabled             bool
-	proxyLoadBalancerIPs        bool
-	endpointSliceEnabled        bool
-	supportNestedService        bool
-	serviceProxyNameSet         bool
-	cleanupStaleUDPSvcConntrack bool
-	defaultLoadBalancerMode     agentconfig.LoadBalancerMode
+	proxyAllEnabled              bool
+	proxyLoadBalancerIPs         bool
+	endpointSliceEnabled         bool
+	supportNestedService         bool
+	serviceProxyNameSet          bool
+	cleanupStaleUDPSvcConntrack  bool
+	defaultLoadBalancerMode      agentconfig.LoadBalancerMode
+	defaultLoadBalancerAlgorithm agentconfig.LoadBalancerAlgorithm
 }
 
 type proxyOptionsFn func(*proxyOptions)
@@ -362,6 +364,10 @@ func withDSRMode(o *proxyOptions) {
 	o.defaultLoadBalancerMode = agentconfig.LoadBalancerModeDSR
 }
 
+func withMaglevAlgorithm(o *proxyOptions) {
+	o.defaultLoadBalancerAlgorithm = agentconfig.LoadBalancerAlgorithmMaglev
+}
+
 func withCleanupStaleUDPSvcConntrack(o *proxyOptions) {
 	o.cleanupStaleUDPSvcConntrack = true
 }
@@ -374,13 +380,14 @@ func getMockClients(ctrl *gomock.Controller) (*ofmock.MockClient, *routemock.Moc
 
 func newFakeProxier(routeClient route.Interface, ofClient openflow.Client, nodePortAddresses []net.IP, groupIDAllocator openflow.GroupAllocator, isIPv6 bool, options ...proxyOptionsFn) *proxier {
 	o := &proxyOptions{
-		proxyAllEnabled:             false,
-		proxyLoadBalancerIPs:        true,
-		endpointSliceEnabled:        true,
-		supportNestedService:        false,
-		serviceProxyNameSet:         false,
-		defaultLoadBalancerMode:     agentconfig.LoadBalancerModeNAT,
-		cleanupStaleUDPSvcConntrack: false,
+		proxyAllEnabled:              false,
+		proxyLoadBalancerIPs:         true,
+		endpointSliceEnabled:         true,
+		supportNestedService:         false,
+		serviceProxyNameSet:          false,
+		defaultLoadBalancerMode:      agentconfig.LoadBalancerModeNAT,
+		defaultLoadBalancerAlgorithm: agentconfig.LoadBalancerAlgorithmRandom,
+		cleanupStaleUDPSvcConntrack:  false,
 	}
 
 	for _, fn := range options {
@@ -409,6 +416,7 @@ func newFakeProxier(routeClient route.Interface, ofClient openflow.Client, nodeP
 		[]string{skippedServiceNN, skippedClusterIP},
 		o.proxyLoadBalancerIPs,
 		o.defaultLoadBalancerMode,
+		o.defaultLoadBalancerAlgorithm,
 		types.NewGroupCounter(groupIDAllocator, make(chan string, 100)), o.supportNestedService)
 	p.runner = k8sproxy.NewBoundedFrequencyRunner(componentName, p.syncProxyRules, time.Second, 30*time.Second, 2)
 	p.endpointsChanges = newEndpointsChangesTracker(hostname, o.endpointSliceEnabled, isIPv6)
@@ -481,7 +489,7 @@ func testClusterIPAdd(t *testing.T,
 	}
 	if nodeLocalInternal == false {
 		mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:      svcIP,
 			ServicePort:    uint16(svcPort),
@@ -500,7 +508,7 @@ func testClusterIPAdd(t *testing.T,
 		}
 	} else {
 		mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
 		var clusterGroup binding.GroupIDType
 		if externalIP != nil {
 			// Cluster Group is created when externalIPs is not empty.
@@ -516,7 +524,7 @@ func testClusterIPAdd(t *testing.T,
 			IsNested:           true,
 		}).Times(1)
 		if externalIP != nil {
-			mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 			mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 				ServiceIP:      externalIP,
 				ServicePort:    uint16(svcPort),
@@ -619,8 +627,8 @@ func testLoadBalancerAdd(t *testing.T,
 	isDSR := !nodeLocalExternal && dsrEnabled
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
 	if nodeLocalInternal != nodeLocalExternal {
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:          svcIP,
 			ServicePort:        uint16(svcPort),
@@ -669,16 +677,16 @@ func testLoadBalancerAdd(t *testing.T,
 		if nodeLocalVal {
 			localGroupID = 1
 			clusterGroupID = 2
-			mockOFClient.EXPECT().InstallServiceGroup(localGroupID, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
-			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(localGroupID, false, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		} else if isDSR {
 			localGroupID = 1
 			clusterGroupID = 2
-			mockOFClient.EXPECT().InstallServiceGroup(localGroupID, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
-			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(localGroupID, false, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		} else {
 			clusterGroupID = 1
-			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		}
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:          svcIP,
@@ -808,8 +816,8 @@ func testNodePortAdd(t *testing.T,
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
 	if nodeLocalInternal != nodeLocalExternal {
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:          svcIP,
 			ServicePort:        uint16(svcPort),
@@ -845,11 +853,11 @@ func testNodePortAdd(t *testing.T,
 		if nodeLocalVal {
 			localGroupID = 1
 			clusterGroupID = 2
-			mockOFClient.EXPECT().InstallServiceGroup(localGroupID, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
-			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(localGroupID, false, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		} else {
 			clusterGroupID = 1
-			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		}
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:          svcIP,
@@ -1122,8 +1130,8 @@ func TestLoadBalancerServiceWithMultiplePorts(t *testing.T) {
 	localGroupID1 := fp.groupCounter.AllocateIfNotExist(svcPortName1, true)
 	clusterGroupID1 := fp.groupCounter.AllocateIfNotExist(svcPortName1, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort80, remoteEndpointForPort80})).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(localGroupID1, false, []k8sproxy.Endpoint{localEndpointForPort80}).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID1, false, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort80, remoteEndpointForPort80})).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(localGroupID1, false, false, []k8sproxy.Endpoint{localEndpointForPort80}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID1, false, false, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort80, remoteEndpointForPort80})).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:          svc1IPv4,
 		ServicePort:        uint16(port80Int32),
@@ -1157,8 +1165,8 @@ func TestLoadBalancerServiceWithMultiplePorts(t *testing.T) {
 	localGroupID2 := fp.groupCounter.AllocateIfNotExist(svcPortName2, true)
 	clusterGroupID2 := fp.groupCounter.AllocateIfNotExist(svcPortName2, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort443, remoteEndpointForPort443})).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(localGroupID2, false, []k8sproxy.Endpoint{localEndpointForPort443}).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID2, false, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort443, remoteEndpointForPort443})).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(localGroupID2, false, false, []k8sproxy.Endpoint{localEndpointForPort443}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID2, false, false, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort443, remoteEndpointForPort443})).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svc1IPv4,
 		ServicePort:    uint16(port443Int32),
@@ -1339,7 +1347,7 @@ func TestDualStackService(t *testing.T) {
 	fpv6.OnEndpointSliceUpdate(nil, epv6)
 	fpv6.OnEndpointsSynced()
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, []k8sproxy.Endpoint{k8sproxy.NewBaseEndpointInfo(ep1IPv4.String(), "", "", svcPort, false, true, true, false, nil)}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, []k8sproxy.Endpoint{k8sproxy.NewBaseEndpointInfo(ep1IPv4.String(), "", "", svcPort, false, true, true, false, nil)}).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:          svc1IPv4,
@@ -1350,7 +1358,7 @@ func TestDualStackService(t *testing.T) {
 		ClusterGroupID:     1,
 	}).Times(1)
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, []k8sproxy.Endpoint{k8sproxy.NewBaseEndpointInfo(ep1IPv6.String(), "", "", svcPort, false, true, true, false, nil)}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, []k8sproxy.Endpoint{k8sproxy.NewBaseEndpointInfo(ep1IPv6.String(), "", "", svcPort, false, true, true, false, nil)}).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCPv6, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:          svc1IPv6,
@@ -1367,6 +1375,133 @@ func TestDualStackService(t *testing.T) {
 	assert.Contains(t, fpv6.serviceInstalledMap, svcPortName)
 }
 
+func TestMaglevLoadBalancerAlgorithm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, openflow.NewGroupAllocator(), false, withMaglevAlgorithm)
+
+	svc := makeTestClusterIPService(&svcPortName, svc1IPv4, nil, int32(svcPort), corev1.ProtocolTCP, nil, nil, false, nil)
+	ep1, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	ep2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1, *ep2}, []discovery.EndpointPort{*epPort}, false)
+	makeServiceMap(fp, svc)
+	makeEndpointSliceMap(fp, eps)
+
+	// The group of the Service is programmed with the Maglev lookup table, in which each Endpoint fills about half of
+	// the buckets, and selects buckets with a hash of the 5-tuple.
+	var groupEndpoints []k8sproxy.Endpoint
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, true, gomock.Any()).Do(
+		func(_ binding.GroupIDType, _, _ bool, endpoints []k8sproxy.Endpoint) {
+			groupEndpoints = endpoints
+		}).Times(1)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any()).Times(1)
+	fp.syncProxyRules()
+
+	require.Len(t, groupEndpoints, maglevTableSize)
+	counts := map[string]int{}
+	for _, endpoint := range groupEndpoints {
+		counts[endpoint.IP()]++
+	}
+	assert.Len(t, counts, 2)
+	assert.InDelta(t, maglevTableSize/2, counts[ep1IPv4.String()], 1)
+	assert.InDelta(t, maglevTableSize/2, counts[ep2IPv4.String()], 1)
+}
+
+// TestMaglevLoadBalancerAlgorithmAcrossNodes verifies that two Nodes, which receive the Endpoints of a Service in
+// different orders and on which different Endpoints are local, program the same buckets in the same order and both
+// select buckets with a hash of the 5-tuple, so that a connection is sent to the same Endpoint by either Node.
+func TestMaglevLoadBalancerAlgorithmAcrossNodes(t *testing.T) {
+	syncGroupEndpoints := func(endpoints []discovery.Endpoint, epPort *discovery.EndpointPort) []string {
+		ctrl := gomock.NewController(t)
+		mockOFClient, mockRouteClient := getMockClients(ctrl)
+		fp := newFakeProxier(mockRouteClient, mockOFClient, nil, openflow.NewGroupAllocator(), false, withMaglevAlgorithm)
+		svc := makeTestClusterIPService(&svcPortName, svc1IPv4, nil, int32(svcPort), corev1.ProtocolTCP, nil, nil, false, nil)
+		eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, endpoints, []discovery.EndpointPort{*epPort}, false)
+		makeServiceMap(fp, svc)
+		makeEndpointSliceMap(fp, eps)
+
+		var groupEndpoints []string
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, true, gomock.Any()).Do(
+			func(_ binding.GroupIDType, _, _ bool, endpoints []k8sproxy.Endpoint) {
+				for _, endpoint := range endpoints {
+					groupEndpoints = append(groupEndpoints, endpoint.String())
+				}
+			}).Times(1)
+		mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(gomock.Any()).Times(1)
+		fp.syncProxyRules()
+		return groupEndpoints
+	}
+
+	ep1, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+	ep2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	ep3, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, net.ParseIP("10.180.0.3"), int32(svcPort), corev1.ProtocolTCP, false)
+	node1GroupEndpoints := syncGroupEndpoints([]discovery.Endpoint{*ep1, *ep2, *ep3}, epPort)
+
+	ep1, epPort = makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	ep2, _ = makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+	node2GroupEndpoints := syncGroupEndpoints([]discovery.Endpoint{*ep3, *ep2, *ep1}, epPort)
+
+	require.Len(t, node1GroupEndpoints, maglevTableSize)
+	assert.Equal(t, node1GroupEndpoints, node2GroupEndpoints)
+}
+
+func TestEndpointHealthCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, openflow.NewGroupAllocator(), false)
+	fp.endpointHealthChecker.period = time.Hour
+	fp.endpointHealthChecker.probeFn = func(*types.EndpointHealthCheck, k8sproxy.Endpoint, time.Duration) error {
+		return nil
+	}
+
+	svc := makeTestClusterIPService(&svcPortName, svc1IPv4, nil, int32(svcPort), corev1.ProtocolTCP, nil, nil, false, nil)
+	svc.Annotations = map[string]string{antreatypes.ServiceEndpointHealthCheckAnnotationKey: "tcp"}
+	ep1, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	ep2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1, *ep2}, []discovery.EndpointPort{*epPort}, false)
+	makeServiceMap(fp, svc)
+	makeEndpointSliceMap(fp, eps)
+	defer fp.endpointHealthChecker.removeService(svcPortName)
+
+	getGroupEndpointIPs := func(endpoints []k8sproxy.Endpoint) []string {
+		var ips []string
+		for _, endpoint := range endpoints {
+			ips = append(ips, endpoint.IP())
+		}
+		return ips
+	}
+	var groupEndpointIPs []string
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Do(
+		func(_ binding.GroupIDType, _, _ bool, endpoints []k8sproxy.Endpoint) {
+			groupEndpointIPs = getGroupEndpointIPs(endpoints)
+		}).Times(2)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any()).Times(1)
+	fp.syncProxyRules()
+	assert.ElementsMatch(t, []string{ep1IPv4.String(), ep2IPv4.String()}, groupEndpointIPs)
+	status := fp.GetEndpointHealthStatus()
+	require.Len(t, status, 2)
+	assert.True(t, status[0].Healthy)
+	assert.True(t, status[1].Healthy)
+
+	// The Endpoint failing the health checks is removed from the group.
+	fp.endpointHealthChecker.mutex.Lock()
+	for _, prober := range fp.endpointHealthChecker.services[svcPortName].probers {
+		if prober.endpoint.IP() == ep2IPv4.String() {
+			prober.healthy = false
+		}
+	}
+	fp.endpointHealthChecker.mutex.Unlock()
+	fp.syncProxyRules()
+	assert.Equal(t, []string{ep1IPv4.String()}, groupEndpointIPs)
+	assert.Contains(t, fp.unhealthyEndpointsInstalledMap, svcPortName)
+
+	// Nothing is updated if the health status doesn't change.
+	fp.syncProxyRules()
+}
+
 func getAPIProtocol(bindingProtocol binding.Protocol) corev1.Protocol {
 	switch bindingProtocol {
 	case binding.ProtocolUDP, binding.ProtocolUDPv6:
@@ -1414,7 +1549,7 @@ func testClusterIPRemove(t *testing.T, svcIP, externalIP, epIP net.IP, bindingPr
 
 	if nodeLocalInternal == false {
 		mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:      svcIP,
 			ServicePort:    uint16(svcPort),
@@ -1437,10 +1572,10 @@ func testClusterIPRemove(t *testing.T, svcIP, externalIP, epIP net.IP, bindingPr
 		}
 	} else {
 		var clusterGroupID binding.GroupIDType
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
 		if externalIP != nil {
 			clusterGroupID = 2
-			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, gomock.Any()).Times(1)
+			mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, false, gomock.Any()).Times(1)
 			mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
 			mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 				ServiceIP:      externalIP,
@@ -1541,8 +1676,8 @@ func testNodePortRemove(t *testing.T, nodePortAddresses []net.IP, svcIP, externa
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -1655,8 +1790,8 @@ func testLoadBalancerRemove(t *testing.T, nodePortAddresses []net.IP, svcIP, ext
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -1894,7 +2029,7 @@ func testClusterIPNoEndpoint(t *testing.T, svcIP net.IP, isIPv6 bool) {
 	if isIPv6 {
 		protocol = binding.ProtocolTCPv6
 	}
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, []k8sproxy.Endpoint{}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, []k8sproxy.Endpoint{}).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:          svcIP,
 		ServicePort:        uint16(svcPort),
@@ -1960,8 +2095,8 @@ func testNodePortNoEndpoint(t *testing.T, nodePortAddresses []net.IP, svcIP net.
 		protocol = binding.ProtocolTCPv6
 	}
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -2055,8 +2190,8 @@ func testLoadBalancerNoEndpoint(t *testing.T, nodePortAddresses []net.IP, svcIP
 		protocol = binding.ProtocolTCPv6
 	}
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -2162,8 +2297,8 @@ func testClusterIPRemoveSamePortEndpoint(t *testing.T, svcIP net.IP, epIP net.IP
 
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortNameTCP, false)
 	groupIDUDP := fp.groupCounter.AllocateIfNotExist(svcPortNameUDP, false)
-	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(groupIDUDP, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(groupIDUDP, false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(protocolTCP, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(protocolUDP, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
@@ -2180,13 +2315,13 @@ func testClusterIPRemoveSamePortEndpoint(t *testing.T, svcIP net.IP, epIP net.IP
 	}).Times(1)
 	fp.syncProxyRules()
 
-	mockOFClient.EXPECT().InstallServiceGroup(groupIDUDP, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(groupIDUDP, false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().UninstallEndpointFlows(protocolUDP, gomock.Any()).Times(1)
 	mockRouteClient.EXPECT().ClearConntrackEntryForService(svcIP, uint16(svcPort), epIP, protocolUDP)
 	fp.endpointsChanges.OnEndpointSliceUpdate(epsUDP, true)
 	fp.syncProxyRules()
 
-	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().UninstallEndpointFlows(protocolTCP, gomock.Any()).Times(1)
 	fp.endpointsChanges.OnEndpointSliceUpdate(epsTCP, true)
 	fp.syncProxyRules()
@@ -2236,7 +2371,7 @@ func testLoadBalancerRemoveEndpoints(t *testing.T, nodePortAddresses []net.IP, s
 		svcNodePortIP = svcNodePortIPv6
 	}
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
@@ -2275,7 +2410,7 @@ func testLoadBalancerRemoveEndpoints(t *testing.T, nodePortAddresses []net.IP, s
 	assert.Contains(t, fp.serviceInstalledMap, svcPortName)
 	assert.Contains(t, fp.endpointsInstalledMap, svcPortName)
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().UninstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
 	if needClearConntrackEntries(bindingProtocol) {
 		mockRouteClient.EXPECT().ClearConntrackEntryForService(svcIP, uint16(svcPort), epIP, bindingProtocol)
@@ -2340,7 +2475,7 @@ func testSessionAffinity(t *testing.T, svcIP net.IP, epIP net.IP, affinitySecond
 	if isIPv6 {
 		bindingProtocol = binding.ProtocolTCPv6
 	}
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
 	var expectedAffinity uint16
 	if affinitySeconds > math.MaxUint16 {
@@ -2407,7 +2542,7 @@ func testSessionAffinityNoEndpoint(t *testing.T, svcExternalIPs net.IP, svcIP ne
 	if isIPv6 {
 		protocol = binding.ProtocolTCPv6
 	}
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, []k8sproxy.Endpoint{}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, false, []k8sproxy.Endpoint{}).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:       svcIP,
 		ServicePort:     uint16(svcPort),
@@ -2467,7 +2602,7 @@ func testServicePortUpdate(t *testing.T,
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, expectedEps).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -2601,7 +2736,7 @@ func testServiceNodePortUpdate(t *testing.T,
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, expectedEps).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -2716,7 +2851,7 @@ func testServiceExternalTrafficPolicyUpdate(t *testing.T,
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -2751,8 +2886,8 @@ func testServiceExternalTrafficPolicyUpdate(t *testing.T,
 
 	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedAllEps)).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, expectedLocalEps).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, expectedLocalEps).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
@@ -2855,7 +2990,7 @@ func testServiceInternalTrafficPolicyUpdate(t *testing.T,
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedAllEps)).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -2883,7 +3018,7 @@ func testServiceInternalTrafficPolicyUpdate(t *testing.T,
 	mockOFClient.EXPECT().UninstallEndpointFlows(bindingProtocol, expectedRemoteEps).Times(1)
 	mockOFClient.EXPECT().UninstallServiceGroup(binding.GroupIDType(1)).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, expectedLocalEps).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), false, false, expectedLocalEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:          svcIP,
 		ServicePort:        uint16(svcPort),
@@ -2950,7 +3085,7 @@ func testServiceIngressIPsUpdate(t *testing.T,
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedEps)).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, gomock.InAnyOrder(expectedEps)).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, gomock.InAnyOrder(expectedEps)).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -3064,7 +3199,7 @@ func testServiceStickyMaxAgeSecondsUpdate(t *testing.T,
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, expectedEps).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, false, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:       svcIP,
 		ServicePort:     uint16(svcPort),
@@ -3203,7 +3338,7 @@ func testServiceSessionAffinityTypeUpdate(t *testing.T,
 	}
 
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, expectedEps).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:      svcIP,
 		ServicePort:    uint16(svcPort),
@@ -3211,7 +3346,7 @@ func testServiceSessionAffinityTypeUpdate(t *testing.T,
 		ClusterGroupID: 1,
 	}).Times(1)
 
-	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, expectedEps).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), true, false, expectedEps).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 		ServiceIP:       svcIP,
@@ -3322,8 +3457,8 @@ func TestServicesWithSameEndpoints(t *testing.T) {
 
 	groupID1 := fp.groupCounter.AllocateIfNotExist(svcPortName1, false)
 	groupID2 := fp.groupCounter.AllocateIfNotExist(svcPortName2, false)
-	mockOFClient.EXPECT().InstallServiceGroup(groupID1, false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceGroup(groupID2, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID1, false, false, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID2, false, false, gomock.Any()).Times(1)
 	bindingProtocol := binding.ProtocolTCP
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
@@ -3488,8 +3623,8 @@ func TestGetServiceFlowKeys(t *testing.T) {
 			}
 			if tc.svc != nil && tc.eps != nil && tc.serviceInstalled {
 				mockRouteClient.EXPECT().AddNodePort(nodePortAddressesIPv4, uint16(svcNodePort), binding.ProtocolTCP).Times(1)
-				mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), gomock.Any(), gomock.Any()).Times(1)
-				mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), gomock.Any(), gomock.Any()).Times(1)
+				mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), gomock.Any(), false, gomock.Any()).Times(1)
+				mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(2), gomock.Any(), false, gomock.Any()).Times(1)
 				mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
 				mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 					ServiceIP:          svc1IPv4,
@@ -3552,7 +3687,7 @@ func TestServiceLabelSelector(t *testing.T) {
 		makeServiceMap(fp, svc1, svc2, svc3, svc4)
 		makeEndpointSliceMap(fp)
 
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, []k8sproxy.Endpoint{}).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, []k8sproxy.Endpoint{}).Times(1)
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:      svc2IP,
 			ServicePort:    uint16(svcPort),
@@ -3572,7 +3707,7 @@ func TestServiceLabelSelector(t *testing.T) {
 		makeServiceMap(fp, svc1, svc2, svc3, svc4)
 		makeEndpointSliceMap(fp)
 
-		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, []k8sproxy.Endpoint{}).Times(1)
+		mockOFClient.EXPECT().InstallServiceGroup(binding.GroupIDType(1), false, false, []k8sproxy.Endpoint{}).Times(1)
 		mockOFClient.EXPECT().InstallServiceFlows(&antreatypes.ServiceConfig{
 			ServiceIP:      svc1IP,
 			ServicePort:    uint16(svcPort),
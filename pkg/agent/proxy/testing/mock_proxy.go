@@ -26,6 +26,7 @@ package testing
 import (
 	reflect "reflect"
 
+	types "antrea.io/antrea/pkg/agent/proxy/types"
 	openflow "antrea.io/antrea/pkg/ovs/openflow"
 	proxy "antrea.io/antrea/third_party/proxy"
 	gomock "go.uber.org/mock/gomock"
@@ -54,6 +55,20 @@ func (m *MockProxier) EXPECT() *MockProxierMockRecorder {
 	return m.recorder
 }
 
+// GetEndpointHealthStatus mocks base method.
+func (m *MockProxier) GetEndpointHealthStatus() []types.EndpointHealthStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpointHealthStatus")
+	ret0, _ := ret[0].([]types.EndpointHealthStatus)
+	return ret0
+}
+
+// GetEndpointHealthStatus indicates an expected call of GetEndpointHealthStatus.
+func (mr *MockProxierMockRecorder) GetEndpointHealthStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpointHealthStatus", reflect.TypeOf((*MockProxier)(nil).GetEndpointHealthStatus))
+}
+
 // GetProxyProvider mocks base method.
 func (m *MockProxier) GetProxyProvider() proxy.Provider {
 	m.ctrl.T.Helper()
@@ -0,0 +1,139 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"math"
+
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+
+	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+// trafficSplitWeightScale is the sum of the weights of the Endpoints of a Service splitting its traffic. It's large
+// enough to express the portion of a backend with many Endpoints, and small enough to fit in the weight of an OVS
+// bucket.
+const trafficSplitWeightScale = 10000
+
+// weightedEndpoint is an Endpoint of a backend Service which receives a portion of the traffic of another Service.
+type weightedEndpoint struct {
+	k8sproxy.Endpoint
+	weight uint16
+}
+
+var _ openflow.WeightedEndpoint = (*weightedEndpoint)(nil)
+
+func (e *weightedEndpoint) GetWeight() uint16 {
+	return e.weight
+}
+
+// filterEndpointsMap returns the Endpoints for which filter returns true.
+func filterEndpointsMap(endpoints map[string]k8sproxy.Endpoint, filter func(k8sproxy.Endpoint) bool) map[string]k8sproxy.Endpoint {
+	filteredEndpoints := map[string]k8sproxy.Endpoint{}
+	for key, endpoint := range endpoints {
+		if filter(endpoint) {
+			filteredEndpoints[key] = endpoint
+		}
+	}
+	return filteredEndpoints
+}
+
+func isReadyEndpoint(endpoint k8sproxy.Endpoint) bool {
+	return endpoint.IsReady()
+}
+
+func isServingTerminatingEndpoint(endpoint k8sproxy.Endpoint) bool {
+	return endpoint.IsServing() && endpoint.IsTerminating()
+}
+
+// getTrafficSplitEndpoints returns the Endpoints of the backend Services across which the traffic of a Service port
+// is split, and their weights keyed by Endpoint string. The port of a backend Service must have the same name as the
+// Service port to be used. The traffic is split across the backends with ready Endpoints in proportion to their
+// weights, then evenly across the ready Endpoints of each backend. If no backend has ready Endpoints, the serving
+// terminating Endpoints of the backends are used instead. The other Endpoints are not returned, so that no weight is
+// given to the Endpoints which cannot receive traffic.
+func (p *proxier) getTrafficSplitEndpoints(svcPortName k8sproxy.ServicePortName, backends []types.TrafficSplitBackend) (map[string]k8sproxy.Endpoint, map[string]uint16) {
+	type backendEndpoints struct {
+		weight    uint16
+		endpoints map[string]k8sproxy.Endpoint
+	}
+	getAvailableBackends := func(filter func(k8sproxy.Endpoint) bool) ([]backendEndpoints, int) {
+		var availableBackends []backendEndpoints
+		totalWeight := 0
+		for _, backend := range backends {
+			if backend.Weight == 0 {
+				continue
+			}
+			backendSvcPortName := k8sproxy.ServicePortName{
+				NamespacedName: apimachinerytypes.NamespacedName{Namespace: svcPortName.Namespace, Name: backend.Service},
+				Port:           svcPortName.Port,
+				Protocol:       svcPortName.Protocol,
+			}
+			endpoints := filterEndpointsMap(p.endpointsMap[backendSvcPortName], filter)
+			if len(endpoints) == 0 {
+				continue
+			}
+			availableBackends = append(availableBackends, backendEndpoints{weight: backend.Weight, endpoints: endpoints})
+			totalWeight += int(backend.Weight)
+		}
+		return availableBackends, totalWeight
+	}
+	// Like categorizeEndpoints, fall back to the serving terminating Endpoints only if there is no ready Endpoint.
+	availableBackends, totalWeight := getAvailableBackends(isReadyEndpoint)
+	if len(availableBackends) == 0 && p.endpointSliceEnabled {
+		availableBackends, totalWeight = getAvailableBackends(isServingTerminatingEndpoint)
+	}
+
+	endpoints := map[string]k8sproxy.Endpoint{}
+	weights := map[string]uint16{}
+	for _, backend := range availableBackends {
+		weight := math.Round(float64(backend.weight) * trafficSplitWeightScale / float64(totalWeight*len(backend.endpoints)))
+		for key, endpoint := range backend.endpoints {
+			endpointWeight := uint16(math.Max(weight, 1))
+			// An Endpoint shared by several backends receives the traffic of all of them.
+			if existingWeight, exists := weights[key]; exists {
+				endpointWeight = uint16(math.Min(float64(existingWeight)+float64(endpointWeight), math.MaxUint16))
+			}
+			endpoints[key] = &weightedEndpoint{Endpoint: endpoint, weight: endpointWeight}
+			weights[key] = endpointWeight
+		}
+	}
+	return endpoints, weights
+}
+
+// getWeightedEndpoints returns the Endpoints of a Service which can receive traffic, i.e. the ready Endpoints or the
+// serving terminating Endpoints if there is no ready Endpoint, with the weights specified for their IPs, and the
+// weights keyed by Endpoint string. If the weight of any of these Endpoints is not specified, e.g. when the annotation
+// hasn't caught up with the Endpoints yet, the weights are not applied: all Endpoints are returned unchanged and nil is
+// returned for the weights.
+func (p *proxier) getWeightedEndpoints(endpoints map[string]k8sproxy.Endpoint, ipWeights map[string]uint16) (map[string]k8sproxy.Endpoint, map[string]uint16) {
+	servingEndpoints := filterEndpointsMap(endpoints, isReadyEndpoint)
+	if len(servingEndpoints) == 0 && p.endpointSliceEnabled {
+		servingEndpoints = filterEndpointsMap(endpoints, isServingTerminatingEndpoint)
+	}
+	weightedEndpoints := make(map[string]k8sproxy.Endpoint, len(servingEndpoints))
+	weights := make(map[string]uint16, len(servingEndpoints))
+	for key, endpoint := range servingEndpoints {
+		weight, exists := ipWeights[endpoint.IP()]
+		if !exists {
+			return endpoints, nil
+		}
+		weightedEndpoints[key] = &weightedEndpoint{Endpoint: endpoint, weight: weight}
+		weights[key] = weight
+	}
+	return weightedEndpoints, weights
+}
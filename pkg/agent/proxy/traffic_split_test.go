@@ -0,0 +1,212 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+func TestGetTrafficSplitEndpoints(t *testing.T) {
+	svcPortName := makeSvcPortName("ns1", "svc", "http", "TCP")
+	stableSvcPortName := makeSvcPortName("ns1", "svc-stable", "http", "TCP")
+	canarySvcPortName := makeSvcPortName("ns1", "svc-canary", "http", "TCP")
+	otherPortSvcPortName := makeSvcPortName("ns1", "svc-canary", "metrics", "TCP")
+	stableEp1 := newTestEndpoint("10.10.0.1", 80)
+	stableEp2 := newTestEndpoint("10.10.0.2", 80)
+	canaryEp := newTestEndpoint("10.10.0.3", 80)
+	notReadyEp := k8sproxy.NewBaseEndpointInfo("10.10.0.4", "", "", 80, false, false, false, false, nil)
+	stableNotReadyEp := k8sproxy.NewBaseEndpointInfo("10.10.0.6", "", "", 80, false, false, false, false, nil)
+	stableTerminatingEp := k8sproxy.NewBaseEndpointInfo("10.10.0.7", "", "", 80, false, false, true, true, nil)
+	terminatingEp := k8sproxy.NewBaseEndpointInfo("10.10.0.8", "", "", 80, false, false, true, true, nil)
+	p := &proxier{
+		endpointSliceEnabled: true,
+		endpointsMap: types.EndpointsMap{
+			stableSvcPortName: {
+				stableEp1.String():           stableEp1,
+				stableEp2.String():           stableEp2,
+				stableNotReadyEp.String():    stableNotReadyEp,
+				stableTerminatingEp.String(): stableTerminatingEp,
+			},
+			canarySvcPortName: {
+				canaryEp.String(): canaryEp,
+			},
+			otherPortSvcPortName: {
+				"10.10.0.5:9090": newTestEndpoint("10.10.0.5", 9090),
+			},
+			makeSvcPortName("ns1", "svc-not-ready", "http", "TCP"): {
+				notReadyEp.String(): notReadyEp,
+			},
+			makeSvcPortName("ns1", "svc-terminating", "http", "TCP"): {
+				terminatingEp.String(): terminatingEp,
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		backends        []types.TrafficSplitBackend
+		expectedWeights map[string]uint16
+	}{
+		{
+			name:     "split across backends",
+			backends: []types.TrafficSplitBackend{{Service: "svc-stable", Weight: 90}, {Service: "svc-canary", Weight: 10}},
+			expectedWeights: map[string]uint16{
+				stableEp1.String(): 4500,
+				stableEp2.String(): 4500,
+				canaryEp.String():  1000,
+			},
+		},
+		{
+			name:     "backend with zero weight",
+			backends: []types.TrafficSplitBackend{{Service: "svc-stable", Weight: 1}, {Service: "svc-canary", Weight: 0}},
+			expectedWeights: map[string]uint16{
+				stableEp1.String(): 5000,
+				stableEp2.String(): 5000,
+			},
+		},
+		{
+			name:     "backends without ready Endpoints",
+			backends: []types.TrafficSplitBackend{{Service: "svc-canary", Weight: 10}, {Service: "svc-not-ready", Weight: 90}, {Service: "svc-missing", Weight: 90}},
+			expectedWeights: map[string]uint16{
+				canaryEp.String(): 10000,
+			},
+		},
+		{
+			name:     "Endpoint shared by backends",
+			backends: []types.TrafficSplitBackend{{Service: "svc-stable", Weight: 50}, {Service: "svc-stable", Weight: 50}},
+			expectedWeights: map[string]uint16{
+				stableEp1.String(): 5000,
+				stableEp2.String(): 5000,
+			},
+		},
+		{
+			name:     "backend with only serving terminating Endpoints",
+			backends: []types.TrafficSplitBackend{{Service: "svc-canary", Weight: 10}, {Service: "svc-terminating", Weight: 90}},
+			expectedWeights: map[string]uint16{
+				canaryEp.String(): 10000,
+			},
+		},
+		{
+			name:     "fall back to serving terminating Endpoints",
+			backends: []types.TrafficSplitBackend{{Service: "svc-not-ready", Weight: 10}, {Service: "svc-terminating", Weight: 90}},
+			expectedWeights: map[string]uint16{
+				terminatingEp.String(): 10000,
+			},
+		},
+		{
+			name:            "no available backend",
+			backends:        []types.TrafficSplitBackend{{Service: "svc-not-ready", Weight: 100}},
+			expectedWeights: map[string]uint16{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints, weights := p.getTrafficSplitEndpoints(svcPortName, tt.backends)
+			assert.Equal(t, tt.expectedWeights, weights)
+			assert.Len(t, endpoints, len(tt.expectedWeights))
+			for key, endpoint := range endpoints {
+				weightedEp, ok := endpoint.(*weightedEndpoint)
+				if assert.True(t, ok) {
+					assert.Equal(t, tt.expectedWeights[key], weightedEp.GetWeight())
+					assert.Equal(t, key, weightedEp.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGetWeightedEndpoints(t *testing.T) {
+	p := &proxier{endpointSliceEnabled: true}
+	ep1 := newTestEndpoint("10.10.0.1", 80)
+	ep2 := newTestEndpoint("10.10.0.2", 80)
+	notReadyEp := k8sproxy.NewBaseEndpointInfo("10.10.0.3", "", "", 80, false, false, false, false, nil)
+	terminatingEp := k8sproxy.NewBaseEndpointInfo("10.10.0.4", "", "", 80, false, false, true, true, nil)
+	endpoints := map[string]k8sproxy.Endpoint{
+		ep1.String():           ep1,
+		ep2.String():           ep2,
+		notReadyEp.String():    notReadyEp,
+		terminatingEp.String(): terminatingEp,
+	}
+	terminatingEndpoints := map[string]k8sproxy.Endpoint{
+		notReadyEp.String():    notReadyEp,
+		terminatingEp.String(): terminatingEp,
+	}
+
+	tests := []struct {
+		name            string
+		endpoints       map[string]k8sproxy.Endpoint
+		ipWeights       map[string]uint16
+		expectedWeights map[string]uint16
+	}{
+		{
+			name:      "all ready Endpoints weighted",
+			endpoints: endpoints,
+			ipWeights: map[string]uint16{"10.10.0.1": 7500, "10.10.0.2": 2500, "10.10.0.3": 100, "10.10.0.4": 100},
+			expectedWeights: map[string]uint16{
+				ep1.String(): 7500,
+				ep2.String(): 2500,
+			},
+		},
+		{
+			name:      "Endpoints not ready not weighted",
+			endpoints: endpoints,
+			ipWeights: map[string]uint16{"10.10.0.1": 7500, "10.10.0.2": 2500},
+			expectedWeights: map[string]uint16{
+				ep1.String(): 7500,
+				ep2.String(): 2500,
+			},
+		},
+		{
+			name:            "Endpoint not weighted",
+			endpoints:       endpoints,
+			ipWeights:       map[string]uint16{"10.10.0.1": 7500},
+			expectedWeights: nil,
+		},
+		{
+			name:      "serving terminating Endpoints weighted without ready Endpoints",
+			endpoints: terminatingEndpoints,
+			ipWeights: map[string]uint16{"10.10.0.3": 5000, "10.10.0.4": 5000},
+			expectedWeights: map[string]uint16{
+				terminatingEp.String(): 5000,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEndpoints, weights := p.getWeightedEndpoints(tt.endpoints, tt.ipWeights)
+			assert.Equal(t, tt.expectedWeights, weights)
+			if tt.expectedWeights == nil {
+				assert.Equal(t, tt.endpoints, gotEndpoints)
+			} else {
+				assert.Len(t, gotEndpoints, len(tt.expectedWeights))
+			}
+			for key, endpoint := range gotEndpoints {
+				weightedEp, ok := endpoint.(*weightedEndpoint)
+				if tt.expectedWeights == nil {
+					assert.False(t, ok)
+					continue
+				}
+				if assert.True(t, ok) {
+					assert.Equal(t, tt.expectedWeights[key], weightedEp.GetWeight())
+				}
+			}
+		})
+	}
+}
@@ -15,6 +15,12 @@
 package types
 
 import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
@@ -37,6 +43,51 @@ type ServiceInfo struct {
 	IsNested bool
 	// The load balancer mode specified in annotations.
 	LoadBalancerMode *config.LoadBalancerMode
+	// The load balancer algorithm specified in annotations.
+	LoadBalancerAlgorithm *config.LoadBalancerAlgorithm
+	// The health checks of the Endpoints specified in annotations. nil means the Endpoints are not health checked.
+	EndpointHealthCheck *EndpointHealthCheck
+	// The backend Services across which the traffic of the Service is split, specified in annotations. If it's not
+	// empty, the Endpoints of the backend Services are used instead of the Service's own Endpoints.
+	TrafficSplit []TrafficSplitBackend
+	// The weights of the Endpoints keyed by Endpoint IP, specified in annotations. It's ignored if TrafficSplit is
+	// not empty, or if it doesn't cover all Endpoints of the Service.
+	EndpointWeights map[string]uint16
+}
+
+// TrafficSplitBackend is a backend Service receiving a portion of the traffic of another Service. The portion is the
+// weight of the backend divided by the sum of the weights of all backends with available Endpoints.
+type TrafficSplitBackend struct {
+	Service string
+	Weight  uint16
+}
+
+type EndpointHealthCheckProtocol string
+
+const (
+	EndpointHealthCheckProtocolTCP  EndpointHealthCheckProtocol = "tcp"
+	EndpointHealthCheckProtocolHTTP EndpointHealthCheckProtocol = "http"
+)
+
+// EndpointHealthCheck describes how the Endpoints of a Service are health checked. The health checks are sent to the
+// target port of the Endpoints.
+type EndpointHealthCheck struct {
+	Protocol EndpointHealthCheckProtocol
+	// Path is the path of the HTTP requests. It's only used by HTTP health checks.
+	Path string
+}
+
+// EndpointHealthStatus describes the health status of an Endpoint of a Service, as determined by the health checks of
+// AntreaProxy.
+type EndpointHealthStatus struct {
+	Namespace           string    `json:"namespace,omitempty"`
+	ServiceName         string    `json:"serviceName,omitempty"`
+	Port                string    `json:"port,omitempty"`
+	Endpoint            string    `json:"endpoint,omitempty"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutiveFailures,omitempty"`
+	LastError           string    `json:"lastError,omitempty"`
+	LastProbeTime       time.Time `json:"lastProbeTime"`
 }
 
 func getLoadBalancerMode(service *corev1.Service) *config.LoadBalancerMode {
@@ -51,11 +102,132 @@ func getLoadBalancerMode(service *corev1.Service) *config.LoadBalancerMode {
 	return nil
 }
 
+func getLoadBalancerAlgorithm(service *corev1.Service) *config.LoadBalancerAlgorithm {
+	if algorithmStr, exists := service.Annotations[types.ServiceLoadBalancerAlgorithmAnnotationKey]; exists {
+		ok, algorithm := config.GetLoadBalancerAlgorithmFromStr(algorithmStr)
+		if !ok {
+			klog.ErrorS(nil, "The Service's load balancer algorithm annotation is invalid", "Service", klog.KObj(service), "algorithm", algorithmStr)
+			return nil
+		}
+		return &algorithm
+	}
+	return nil
+}
+
+func getEndpointHealthCheck(port *corev1.ServicePort, service *corev1.Service) *EndpointHealthCheck {
+	protocolStr, exists := service.Annotations[types.ServiceEndpointHealthCheckAnnotationKey]
+	if !exists {
+		return nil
+	}
+	protocol := EndpointHealthCheckProtocol(strings.ToLower(protocolStr))
+	if protocol != EndpointHealthCheckProtocolTCP && protocol != EndpointHealthCheckProtocolHTTP {
+		klog.ErrorS(nil, "The Service's Endpoint health check annotation is invalid", "Service", klog.KObj(service), "protocol", protocolStr)
+		return nil
+	}
+	// Both TCP and HTTP health checks require the Endpoints to accept TCP connections.
+	if port.Protocol != corev1.ProtocolTCP {
+		klog.InfoS("Endpoint health checks are only supported for TCP Service ports", "Service", klog.KObj(service), "port", port.Name)
+		return nil
+	}
+	healthCheck := &EndpointHealthCheck{Protocol: protocol}
+	if protocol == EndpointHealthCheckProtocolHTTP {
+		healthCheck.Path = "/"
+		if path, exists := service.Annotations[types.ServiceEndpointHealthCheckPathAnnotationKey]; exists && path != "" {
+			healthCheck.Path = path
+		}
+	}
+	return healthCheck
+}
+
+// parseTrafficSplit parses the value of the traffic split annotation, e.g. "svc-stable=90,svc-canary=10".
+func parseTrafficSplit(value string) ([]TrafficSplitBackend, error) {
+	var backends []TrafficSplitBackend
+	services := map[string]bool{}
+	totalWeight := 0
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		service, weightStr, found := strings.Cut(item, "=")
+		service = strings.TrimSpace(service)
+		if !found || service == "" {
+			return nil, fmt.Errorf("invalid backend %q, it must be in the format of <Service>=<weight>", item)
+		}
+		weight, err := strconv.ParseUint(strings.TrimSpace(weightStr), 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight of backend %s: %w", service, err)
+		}
+		if services[service] {
+			return nil, fmt.Errorf("duplicate backend %s", service)
+		}
+		services[service] = true
+		totalWeight += int(weight)
+		backends = append(backends, TrafficSplitBackend{Service: service, Weight: uint16(weight)})
+	}
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("at least one backend must have a positive weight")
+	}
+	return backends, nil
+}
+
+func getTrafficSplit(service *corev1.Service) []TrafficSplitBackend {
+	value, exists := service.Annotations[types.ServiceTrafficSplitAnnotationKey]
+	if !exists {
+		return nil
+	}
+	backends, err := parseTrafficSplit(value)
+	if err != nil {
+		klog.ErrorS(err, "The Service's traffic split annotation is invalid", "Service", klog.KObj(service), "trafficSplit", value)
+		return nil
+	}
+	return backends
+}
+
+// parseEndpointWeights parses the value of the Endpoint weights annotation, e.g. "10.10.0.1=50,10.10.0.2=25".
+func parseEndpointWeights(value string) (map[string]uint16, error) {
+	weights := map[string]uint16{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		ipStr, weightStr, found := strings.Cut(item, "=")
+		ip := net.ParseIP(strings.TrimSpace(ipStr))
+		if !found || ip == nil {
+			return nil, fmt.Errorf("invalid Endpoint weight %q, it must be in the format of <IP>=<weight>", item)
+		}
+		weight, err := strconv.ParseUint(strings.TrimSpace(weightStr), 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight of Endpoint %s: %w", ip, err)
+		}
+		weights[ip.String()] = uint16(weight)
+	}
+	return weights, nil
+}
+
+func getEndpointWeights(service *corev1.Service) map[string]uint16 {
+	value, exists := service.Annotations[types.ServiceEndpointWeightsAnnotationKey]
+	if !exists {
+		return nil
+	}
+	weights, err := parseEndpointWeights(value)
+	if err != nil {
+		klog.ErrorS(err, "The Service's Endpoint weights annotation is invalid", "Service", klog.KObj(service), "endpointWeights", value)
+		return nil
+	}
+	return weights
+}
+
 // NewServiceInfo returns a new k8sproxy.ServicePort which abstracts a serviceInfo.
 func NewServiceInfo(port *corev1.ServicePort, service *corev1.Service, baseInfo *k8sproxy.BaseServiceInfo) k8sproxy.ServicePort {
 	info := &ServiceInfo{BaseServiceInfo: baseInfo}
 	info.IsNested = mccommon.IsMulticlusterService(service)
 	info.LoadBalancerMode = getLoadBalancerMode(service)
+	info.LoadBalancerAlgorithm = getLoadBalancerAlgorithm(service)
+	info.EndpointHealthCheck = getEndpointHealthCheck(port, service)
+	info.TrafficSplit = getTrafficSplit(service)
+	info.EndpointWeights = getEndpointWeights(service)
 	if utilnet.IsIPv6(baseInfo.ClusterIP()) {
 		info.OFProtocol = openflow.ProtocolTCPv6
 		if port.Protocol == corev1.ProtocolUDP {
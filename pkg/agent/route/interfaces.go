@@ -18,7 +18,10 @@ import (
 	"net"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/types"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 )
 
@@ -59,6 +62,25 @@ type Interface interface {
 	// DeleteSNATRule should delete rule to SNAT outgoing traffic with the mark.
 	DeleteSNATRule(mark uint32) error
 
+	// AddEgressRoutes should install routes in the provided route table, which route the Egress traffic to the
+	// provided gateway via the provided device. The subnet of the gateway is routed to the device directly.
+	AddEgressRoutes(tableID, dev uint32, gateway net.IP, prefixLength int) error
+
+	// DeleteEgressRoutes should delete the routes installed by AddEgressRoutes in the provided route table.
+	DeleteEgressRoutes(tableID uint32) error
+
+	// AddEgressRule should add an IP rule to make the Egress traffic with the provided mark look up the provided
+	// route table.
+	AddEgressRule(tableID uint32, mark uint32) error
+
+	// DeleteEgressRule should delete the IP rule installed by AddEgressRule.
+	DeleteEgressRule(tableID uint32, mark uint32) error
+
+	// ClearEgressRoutesAndRules should delete the routes installed by AddEgressRoutes in the route tables within the
+	// provided range, and the IP rules installed by AddEgressRule pointing to them. It's used to clean up stale Egress
+	// routes and rules when the agent starts.
+	ClearEgressRoutesAndRules(minTableID, maxTableID uint32) error
+
 	// AddNodePort adds configurations when a NodePort Service is created.
 	AddNodePort(nodePortAddresses []net.IP, port uint16, protocol binding.Protocol) error
 
@@ -90,4 +112,21 @@ type Interface interface {
 
 	// ClearConntrackEntryForService deletes a conntrack entry for a Service connection.
 	ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error
+
+	// AddOrUpdateNodeNetworkPolicyIPSet adds or updates the ipset created for NodeNetworkPolicy.
+	AddOrUpdateNodeNetworkPolicyIPSet(ipsetName string, ipsetEntries sets.Set[string], isIPv6 bool) error
+
+	// DeleteNodeNetworkPolicyIPSet deletes the ipset created for NodeNetworkPolicy.
+	DeleteNodeNetworkPolicyIPSet(ipsetName string, isIPv6 bool) error
+
+	// AddOrUpdateNodeNetworkPolicyIPTables adds or updates the iptables chains created for NodeNetworkPolicy, and
+	// replaces the rules in each chain with the provided ones. iptablesRules[i] holds the rules of iptablesChains[i].
+	AddOrUpdateNodeNetworkPolicyIPTables(iptablesChains []string, iptablesRules [][]string, isIPv6 bool) error
+
+	// DeleteNodeNetworkPolicyIPTables deletes the iptables chains created for NodeNetworkPolicy.
+	DeleteNodeNetworkPolicyIPTables(iptablesChains []string, isIPv6 bool) error
+
+	// GetNodeNetworkPolicyIPTablesCounters returns the counters of the rules in the iptables chains created for
+	// NodeNetworkPolicy, aggregated by chain and IP family.
+	GetNodeNetworkPolicyIPTablesCounters() (map[string]*types.RuleMetric, error)
 }
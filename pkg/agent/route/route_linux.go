@@ -20,6 +20,7 @@ import (
 	"net"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -69,6 +70,7 @@ const (
 	antreaNodePortIP6Set = "ANTREA-NODEPORT-IP6"
 
 	// Antrea managed iptables chains.
+	antreaInputChain       = "ANTREA-INPUT"
 	antreaForwardChain     = "ANTREA-FORWARD"
 	antreaPreRoutingChain  = "ANTREA-PREROUTING"
 	antreaPostRoutingChain = "ANTREA-POSTROUTING"
@@ -77,6 +79,14 @@ const (
 
 	serviceIPv4CIDRKey = "serviceIPv4CIDRKey"
 	serviceIPv6CIDRKey = "serviceIPv6CIDRKey"
+
+	// egressRouteProtocol is the protocol (rtm_protocol) of the routes installed for Egress, which identifies them
+	// among the routes installed by other components in the same route tables. It is not used by the kernel or any
+	// well-known routing daemon.
+	egressRouteProtocol netlink.RouteProtocol = 0xab
+	// egressRulePriority is the priority of the IP rules installed for Egress, which identifies them among the IP
+	// rules installed by other components. It must be lower than the priority of the rule for the main table (32766).
+	egressRulePriority = 30000
 )
 
 // Client implements Interface.
@@ -106,6 +116,8 @@ type Client struct {
 	nodeNeighbors sync.Map
 	// markToSNATIP caches marks to SNAT IPs. It's used in Egress feature.
 	markToSNATIP sync.Map
+	// egressRoutes caches ip routes about Egresses. It's a map of route table ID to routes.
+	egressRoutes sync.Map
 	// iptablesInitialized is used to notify when iptables initialization is done.
 	iptablesInitialized   chan struct{}
 	proxyAll              bool
@@ -126,20 +138,31 @@ type Client struct {
 	clusterNodeIP6s sync.Map
 	// The latest calculated Service CIDRs can be got from serviceCIDRProvider.
 	serviceCIDRProvider servicecidr.Interface
+	// nodeNetworkPolicyEnabled indicates whether NodeNetworkPolicy is enabled.
+	nodeNetworkPolicyEnabled bool
+	// nodeNetworkPolicyIPSetsIPv4 caches the IPv4 ipsets created for NodeNetworkPolicy and their entries.
+	nodeNetworkPolicyIPSetsIPv4 sync.Map
+	// nodeNetworkPolicyIPSetsIPv6 caches the IPv6 ipsets created for NodeNetworkPolicy and their entries.
+	nodeNetworkPolicyIPSetsIPv6 sync.Map
+	// nodeNetworkPolicyIPTablesIPv4 caches the iptables chains created for NodeNetworkPolicy and their IPv4 rules.
+	nodeNetworkPolicyIPTablesIPv4 sync.Map
+	// nodeNetworkPolicyIPTablesIPv6 caches the iptables chains created for NodeNetworkPolicy and their IPv6 rules.
+	nodeNetworkPolicyIPTablesIPv6 sync.Map
 }
 
 // NewClient returns a route client.
-func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, connectUplinkToBridge, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
+func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, connectUplinkToBridge, nodeNetworkPolicyEnabled, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
 	return &Client{
-		networkConfig:         networkConfig,
-		noSNAT:                noSNAT,
-		proxyAll:              proxyAll,
-		multicastEnabled:      multicastEnabled,
-		connectUplinkToBridge: connectUplinkToBridge,
-		ipset:                 ipset.NewClient(),
-		netlink:               &netlink.Handle{},
-		isCloudEKS:            env.IsCloudEKS(),
-		serviceCIDRProvider:   serviceCIDRProvider,
+		networkConfig:            networkConfig,
+		noSNAT:                   noSNAT,
+		proxyAll:                 proxyAll,
+		multicastEnabled:         multicastEnabled,
+		connectUplinkToBridge:    connectUplinkToBridge,
+		nodeNetworkPolicyEnabled: nodeNetworkPolicyEnabled,
+		ipset:                    ipset.NewClient(),
+		netlink:                  &netlink.Handle{},
+		isCloudEKS:               env.IsCloudEKS(),
+		serviceCIDRProvider:      serviceCIDRProvider,
 	}, nil
 }
 
@@ -318,6 +341,11 @@ func (c *Client) syncRoute() error {
 
 // syncIPSet ensures that the required ipset exists and it has the initial members.
 func (c *Client) syncIPSet() error {
+	if c.nodeNetworkPolicyEnabled {
+		if err := c.syncNodeNetworkPolicyIPSets(); err != nil {
+			return err
+		}
+	}
 	// In policy-only mode, Node Pod CIDR is undefined.
 	if c.networkConfig.TrafficEncapMode.IsNetworkPolicyOnly() {
 		return nil
@@ -508,6 +536,12 @@ func (c *Client) syncIPTables() error {
 	if c.proxyAll {
 		jumpRules = append(jumpRules, jumpRule{iptables.NATTable, iptables.OutputChain, antreaOutputChain, "Antrea: jump to Antrea output rules"})
 	}
+	if c.nodeNetworkPolicyEnabled {
+		jumpRules = append(jumpRules,
+			jumpRule{iptables.FilterTable, iptables.InputChain, antreaInputChain, "Antrea: jump to Antrea input rules"},
+			jumpRule{iptables.FilterTable, iptables.OutputChain, antreaOutputChain, "Antrea: jump to Antrea output rules"},
+		)
+	}
 	for _, rule := range jumpRules {
 		if err := c.iptables.EnsureChain(iptables.ProtocolDual, rule.table, rule.dstChain); err != nil {
 			return err
@@ -694,6 +728,9 @@ func (c *Client) restoreIptablesData(podCIDR *net.IPNet,
 			"-j", iptables.AcceptTarget,
 		}...)
 	}
+	if c.nodeNetworkPolicyEnabled {
+		c.writeNodeNetworkPolicyIPTables(iptablesData, isIPv6)
+	}
 	writeLine(iptablesData, "COMMIT")
 
 	writeLine(iptablesData, "*nat")
@@ -1152,6 +1189,209 @@ func (c *Client) DeleteRoutes(podCIDR *net.IPNet) error {
 	return nil
 }
 
+// writeNodeNetworkPolicyIPTables writes the iptables chains and rules of NodeNetworkPolicy to the iptablesData buffer.
+// The chains created for NodeNetworkPolicy are written as well, as iptables-restore flushes all the declared chains.
+func (c *Client) writeNodeNetworkPolicyIPTables(iptablesData *bytes.Buffer, isIPv6 bool) {
+	iptablesChains := sets.New[string](config.NodeNetworkPolicyIngressRulesChain, config.NodeNetworkPolicyEgressRulesChain)
+	iptablesRules := make(map[string][]string)
+	c.getNodeNetworkPolicyIPTables(isIPv6).Range(func(k, v interface{}) bool {
+		iptablesChains.Insert(k.(string))
+		iptablesRules[k.(string)] = v.([]string)
+		return true
+	})
+	sortedChains := sets.List(iptablesChains)
+	writeLine(iptablesData, iptables.MakeChainLine(antreaInputChain))
+	writeLine(iptablesData, iptables.MakeChainLine(antreaOutputChain))
+	for _, chain := range sortedChains {
+		writeLine(iptablesData, iptables.MakeChainLine(chain))
+	}
+	writeLine(iptablesData, []string{
+		"-A", antreaInputChain,
+		"-m", "comment", "--comment", `"Antrea: jump to ingress NodeNetworkPolicy rules"`,
+		"-j", config.NodeNetworkPolicyIngressRulesChain,
+	}...)
+	writeLine(iptablesData, []string{
+		"-A", antreaOutputChain,
+		"-m", "comment", "--comment", `"Antrea: jump to egress NodeNetworkPolicy rules"`,
+		"-j", config.NodeNetworkPolicyEgressRulesChain,
+	}...)
+	for _, chain := range sortedChains {
+		for _, rule := range iptablesRules[chain] {
+			writeLine(iptablesData, "-A", chain, rule)
+		}
+	}
+}
+
+func (c *Client) getNodeNetworkPolicyIPTables(isIPv6 bool) *sync.Map {
+	if isIPv6 {
+		return &c.nodeNetworkPolicyIPTablesIPv6
+	}
+	return &c.nodeNetworkPolicyIPTablesIPv4
+}
+
+func (c *Client) getNodeNetworkPolicyIPSets(isIPv6 bool) *sync.Map {
+	if isIPv6 {
+		return &c.nodeNetworkPolicyIPSetsIPv6
+	}
+	return &c.nodeNetworkPolicyIPSetsIPv4
+}
+
+// syncNodeNetworkPolicyIPSets ensures that the ipsets created for NodeNetworkPolicy exist and have the desired entries.
+func (c *Client) syncNodeNetworkPolicyIPSets() error {
+	for _, isIPv6 := range []bool{false, true} {
+		var err error
+		c.getNodeNetworkPolicyIPSets(isIPv6).Range(func(k, v interface{}) bool {
+			ipsetName := k.(string)
+			if err = c.ipset.CreateIPSet(ipsetName, ipset.HashNet, isIPv6); err != nil {
+				return false
+			}
+			for entry := range v.(sets.Set[string]) {
+				if err = c.ipset.AddEntry(ipsetName, entry); err != nil {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddOrUpdateNodeNetworkPolicyIPSet creates the ipset if it doesn't exist, and updates its entries to the
+// provided ones.
+func (c *Client) AddOrUpdateNodeNetworkPolicyIPSet(ipsetName string, ipsetEntries sets.Set[string], isIPv6 bool) error {
+	ipsets := c.getNodeNetworkPolicyIPSets(isIPv6)
+	var prevIPSetEntries sets.Set[string]
+	if value, ok := ipsets.Load(ipsetName); ok {
+		prevIPSetEntries = value.(sets.Set[string])
+	}
+	if err := c.ipset.CreateIPSet(ipsetName, ipset.HashNet, isIPv6); err != nil {
+		return err
+	}
+	for entry := range ipsetEntries.Difference(prevIPSetEntries) {
+		if err := c.ipset.AddEntry(ipsetName, entry); err != nil {
+			return err
+		}
+	}
+	for entry := range prevIPSetEntries.Difference(ipsetEntries) {
+		if err := c.ipset.DelEntry(ipsetName, entry); err != nil {
+			return err
+		}
+	}
+	ipsets.Store(ipsetName, ipsetEntries.Clone())
+	return nil
+}
+
+// DeleteNodeNetworkPolicyIPSet destroys the ipset. The iptables rules referring to it must be deleted first.
+func (c *Client) DeleteNodeNetworkPolicyIPSet(ipsetName string, isIPv6 bool) error {
+	if err := c.ipset.DestroyIPSet(ipsetName); err != nil {
+		return err
+	}
+	c.getNodeNetworkPolicyIPSets(isIPv6).Delete(ipsetName)
+	return nil
+}
+
+// AddOrUpdateNodeNetworkPolicyIPTables replaces the rules of the provided chains with iptables-restore, creating the
+// chains if they don't exist. All chains are updated in a single transaction.
+func (c *Client) AddOrUpdateNodeNetworkPolicyIPTables(iptablesChains []string, iptablesRules [][]string, isIPv6 bool) error {
+	iptablesData := bytes.NewBuffer(nil)
+	writeLine(iptablesData, "*filter")
+	for _, chain := range iptablesChains {
+		writeLine(iptablesData, iptables.MakeChainLine(chain))
+	}
+	for i, chain := range iptablesChains {
+		for _, rule := range iptablesRules[i] {
+			writeLine(iptablesData, "-A", chain, rule)
+		}
+	}
+	writeLine(iptablesData, "COMMIT")
+	// Setting --noflush to keep the previous contents (i.e. non antrea managed chains) of the tables.
+	if err := c.iptables.Restore(iptablesData.String(), false, isIPv6); err != nil {
+		return err
+	}
+	iptablesCache := c.getNodeNetworkPolicyIPTables(isIPv6)
+	for i, chain := range iptablesChains {
+		iptablesCache.Store(chain, iptablesRules[i])
+	}
+	return nil
+}
+
+// DeleteNodeNetworkPolicyIPTables flushes and deletes the provided chains. The rules referring to the chains must be
+// deleted first.
+func (c *Client) DeleteNodeNetworkPolicyIPTables(iptablesChains []string, isIPv6 bool) error {
+	iptablesData := bytes.NewBuffer(nil)
+	writeLine(iptablesData, "*filter")
+	for _, chain := range iptablesChains {
+		writeLine(iptablesData, iptables.MakeChainLine(chain))
+	}
+	for _, chain := range iptablesChains {
+		writeLine(iptablesData, "-X", chain)
+	}
+	writeLine(iptablesData, "COMMIT")
+	if err := c.iptables.Restore(iptablesData.String(), false, isIPv6); err != nil {
+		return err
+	}
+	iptablesCache := c.getNodeNetworkPolicyIPTables(isIPv6)
+	for _, chain := range iptablesChains {
+		iptablesCache.Delete(chain)
+	}
+	return nil
+}
+
+// GetNodeNetworkPolicyIPTablesCounters parses the output of iptables-save to get the packet and byte counters of
+// the rules in the chains created for NodeNetworkPolicy. Counters of the same chain in IPv4 and IPv6 are summed up.
+func (c *Client) GetNodeNetworkPolicyIPTablesCounters() (map[string]*types.RuleMetric, error) {
+	data, err := c.iptables.Save()
+	if err != nil {
+		return nil, err
+	}
+	iptablesChains := sets.New[string]()
+	for _, isIPv6 := range []bool{false, true} {
+		c.getNodeNetworkPolicyIPTables(isIPv6).Range(func(k, _ interface{}) bool {
+			iptablesChains.Insert(k.(string))
+			return true
+		})
+	}
+	return parseIPTablesCounters(data, iptablesChains), nil
+}
+
+// parseIPTablesCounters parses the counters of the rules in the provided chains of filter table from the output of
+// "iptables-save -c", in which each rule is prefixed with its counters, e.g. "[10:840] -A CHAIN ...".
+func parseIPTablesCounters(data []byte, iptablesChains sets.Set[string]) map[string]*types.RuleMetric {
+	counters := make(map[string]*types.RuleMetric)
+	inFilterTable := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "*") {
+			inFilterTable = line == "*"+iptables.FilterTable
+			continue
+		}
+		if !inFilterTable || !strings.HasPrefix(line, "[") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[1] != "-A" || !iptablesChains.Has(fields[2]) {
+			continue
+		}
+		// Packets logged by NFLOG rules are also counted by the subsequent rules, skip them to avoid double counting.
+		if strings.Contains(line, "-j "+iptables.NFLOGTarget) {
+			continue
+		}
+		var packets, numBytes uint64
+		if _, err := fmt.Sscanf(fields[0], "[%d:%d]", &packets, &numBytes); err != nil {
+			continue
+		}
+		metric, ok := counters[fields[2]]
+		if !ok {
+			metric = &types.RuleMetric{}
+			counters[fields[2]] = metric
+		}
+		metric.Merge(&types.RuleMetric{Packets: packets, Bytes: numBytes})
+	}
+	return counters
+}
+
 // Join all words with spaces, terminate with newline and write to buf.
 func writeLine(buf *bytes.Buffer, words ...string) {
 	// We avoid strings.Join for performance reasons.
@@ -1278,6 +1518,150 @@ func (c *Client) DeleteSNATRule(mark uint32) error {
 	return c.iptables.DeleteRule(protocol, iptables.NATTable, antreaPostRoutingChain, c.snatRuleSpec(snatIP, mark))
 }
 
+// AddEgressRoutes installs the routes in the provided route table to route the Egress traffic to the gateway via the
+// device, for example:
+//
+//	10.10.10.0/24 dev antrea-ext.10 proto 171 scope link table 101
+//	default via 10.10.10.1 dev antrea-ext.10 proto 171 table 101
+func (c *Client) AddEgressRoutes(tableID, dev uint32, gateway net.IP, prefixLength int) error {
+	var dst *net.IPNet
+	if gateway.To4() != nil {
+		dst = &net.IPNet{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, net.IPv4len*8)}
+	} else {
+		dst = &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, net.IPv6len*8)}
+	}
+	subnetRoute := generateRoute(gateway.Mask(net.CIDRMask(prefixLength, len(dst.Mask)*8)), prefixLength, nil, int(dev), netlink.SCOPE_LINK)
+	subnetRoute.Table = int(tableID)
+	subnetRoute.Protocol = egressRouteProtocol
+	defaultRoute := &netlink.Route{
+		Dst:       dst,
+		Gw:        gateway,
+		LinkIndex: int(dev),
+		Table:     int(tableID),
+		Protocol:  egressRouteProtocol,
+	}
+	routes := []*netlink.Route{subnetRoute, defaultRoute}
+	for _, route := range routes {
+		if err := c.netlink.RouteReplace(route); err != nil {
+			return fmt.Errorf("failed to install route %s in table %d: %w", route.String(), tableID, err)
+		}
+	}
+	c.egressRoutes.Store(tableID, routes)
+	klog.V(4).InfoS("Added Egress routes", "table", tableID, "dev", dev, "gateway", gateway, "prefixLength", prefixLength)
+	return nil
+}
+
+// DeleteEgressRoutes deletes the routes installed by AddEgressRoutes in the provided route table.
+func (c *Client) DeleteEgressRoutes(tableID uint32) error {
+	value, exists := c.egressRoutes.Load(tableID)
+	if !exists {
+		klog.V(2).InfoS("Didn't find Egress routes", "table", tableID)
+		return nil
+	}
+	for _, route := range value.([]*netlink.Route) {
+		if err := c.netlink.RouteDel(route); err != nil && err != unix.ESRCH {
+			return fmt.Errorf("failed to delete route %s in table %d: %w", route.String(), tableID, err)
+		}
+	}
+	c.egressRoutes.Delete(tableID)
+	klog.V(4).InfoS("Deleted Egress routes", "table", tableID)
+	return nil
+}
+
+// egressRules returns the IP rules which make the traffic with the mark look up the route table, for each enabled IP
+// family.
+func (c *Client) egressRules(tableID uint32, mark uint32) []*netlink.Rule {
+	var rules []*netlink.Rule
+	newRule := func(family int) *netlink.Rule {
+		rule := netlink.NewRule()
+		rule.Family = family
+		rule.Table = int(tableID)
+		rule.Mark = int(mark)
+		rule.Mask = int(types.SNATIPMarkMask)
+		rule.Priority = egressRulePriority
+		return rule
+	}
+	if c.networkConfig.IPv4Enabled {
+		rules = append(rules, newRule(netlink.FAMILY_V4))
+	}
+	if c.networkConfig.IPv6Enabled {
+		rules = append(rules, newRule(netlink.FAMILY_V6))
+	}
+	return rules
+}
+
+// AddEgressRule adds the IP rules to make the Egress traffic with the mark look up the route table, for example:
+//
+//	30000:	from all fwmark 0x1/0xff lookup 101
+func (c *Client) AddEgressRule(tableID uint32, mark uint32) error {
+	for _, rule := range c.egressRules(tableID, mark) {
+		if err := c.netlink.RuleAdd(rule); err != nil && err != unix.EEXIST {
+			return fmt.Errorf("failed to add IP rule for mark %#x and table %d: %w", mark, tableID, err)
+		}
+	}
+	klog.V(4).InfoS("Added Egress IP rule", "table", tableID, "mark", mark)
+	return nil
+}
+
+// DeleteEgressRule deletes the IP rules installed by AddEgressRule.
+func (c *Client) DeleteEgressRule(tableID uint32, mark uint32) error {
+	for _, rule := range c.egressRules(tableID, mark) {
+		if err := c.netlink.RuleDel(rule); err != nil && err != unix.ENOENT {
+			return fmt.Errorf("failed to delete IP rule for mark %#x and table %d: %w", mark, tableID, err)
+		}
+	}
+	klog.V(4).InfoS("Deleted Egress IP rule", "table", tableID, "mark", mark)
+	return nil
+}
+
+// ClearEgressRoutesAndRules deletes the routes installed by AddEgressRoutes in the route tables within [minTableID,
+// maxTableID], and the IP rules installed by AddEgressRule pointing to them. The routes and IP rules installed by other
+// components are identified by their protocol and priority and kept. The Egress controller installs the desired ones
+// again after it finishes the initial sync.
+func (c *Client) ClearEgressRoutesAndRules(minTableID, maxTableID uint32) error {
+	inRange := func(tableID int) bool {
+		return tableID >= int(minTableID) && tableID <= int(maxTableID)
+	}
+	routes, err := c.netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: unix.RT_TABLE_UNSPEC}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return fmt.Errorf("failed to list routes: %w", err)
+	}
+	for i := range routes {
+		route := &routes[i]
+		if !inRange(route.Table) || route.Protocol != egressRouteProtocol {
+			continue
+		}
+		if err := c.netlink.RouteDel(route); err != nil && err != unix.ESRCH {
+			return fmt.Errorf("failed to delete route %s in table %d: %w", route.String(), route.Table, err)
+		}
+	}
+	var families []int
+	if c.networkConfig.IPv4Enabled {
+		families = append(families, netlink.FAMILY_V4)
+	}
+	if c.networkConfig.IPv6Enabled {
+		families = append(families, netlink.FAMILY_V6)
+	}
+	for _, family := range families {
+		rules, err := c.netlink.RuleList(family)
+		if err != nil {
+			return fmt.Errorf("failed to list IP rules: %w", err)
+		}
+		for i := range rules {
+			rule := &rules[i]
+			if !inRange(rule.Table) || rule.Priority != egressRulePriority {
+				continue
+			}
+			// The family of the listed rules is not set.
+			rule.Family = family
+			if err := c.netlink.RuleDel(rule); err != nil && err != unix.ENOENT {
+				return fmt.Errorf("failed to delete IP rule %s: %w", rule.String(), err)
+			}
+		}
+	}
+	return nil
+}
+
 // addVirtualServiceIPRoute is used to add a route which is used to route the packets whose destination IP is a virtual
 // IP to Antrea gateway.
 func (c *Client) addVirtualServiceIPRoute(isIPv6 bool) error {
@@ -21,8 +21,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vishvananda/netlink"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"antrea.io/antrea/pkg/agent/config"
 	servicecidrtest "antrea.io/antrea/pkg/agent/servicecidr/testing"
@@ -1212,6 +1215,106 @@ func TestDeleteSNATRule(t *testing.T) {
 	}
 }
 
+func TestEgressRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNetlink := netlinktest.NewMockInterface(ctrl)
+	c := &Client{
+		netlink: mockNetlink,
+	}
+	tableID := uint32(101)
+	ipv4SubnetRoute := &netlink.Route{
+		Dst:       ip.MustParseCIDR("10.10.10.0/24"),
+		Scope:     netlink.SCOPE_LINK,
+		LinkIndex: 10,
+		Table:     101,
+		Protocol:  egressRouteProtocol,
+	}
+	ipv4DefaultRoute := &netlink.Route{
+		Dst:       ip.MustParseCIDR("0.0.0.0/0"),
+		Gw:        net.ParseIP("10.10.10.1"),
+		LinkIndex: 10,
+		Table:     101,
+		Protocol:  egressRouteProtocol,
+	}
+	mockNetlink.EXPECT().RouteReplace(ipv4SubnetRoute)
+	mockNetlink.EXPECT().RouteReplace(ipv4DefaultRoute)
+	require.NoError(t, c.AddEgressRoutes(tableID, 10, net.ParseIP("10.10.10.1"), 24))
+
+	ipv6SubnetRoute := &netlink.Route{
+		Dst:       ip.MustParseCIDR("fd00:10:10::/64"),
+		Scope:     netlink.SCOPE_LINK,
+		LinkIndex: 11,
+		Table:     102,
+		Protocol:  egressRouteProtocol,
+	}
+	ipv6DefaultRoute := &netlink.Route{
+		Dst:       ip.MustParseCIDR("::/0"),
+		Gw:        net.ParseIP("fd00:10:10::1"),
+		LinkIndex: 11,
+		Table:     102,
+		Protocol:  egressRouteProtocol,
+	}
+	mockNetlink.EXPECT().RouteReplace(ipv6SubnetRoute)
+	mockNetlink.EXPECT().RouteReplace(ipv6DefaultRoute)
+	require.NoError(t, c.AddEgressRoutes(102, 11, net.ParseIP("fd00:10:10::1"), 64))
+
+	mockNetlink.EXPECT().RouteDel(ipv4SubnetRoute)
+	mockNetlink.EXPECT().RouteDel(ipv4DefaultRoute)
+	require.NoError(t, c.DeleteEgressRoutes(tableID))
+	// Deleting the routes again should be a no-op.
+	require.NoError(t, c.DeleteEgressRoutes(tableID))
+	_, exists := c.egressRoutes.Load(uint32(102))
+	assert.True(t, exists)
+}
+
+func TestEgressRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNetlink := netlinktest.NewMockInterface(ctrl)
+	c := &Client{
+		netlink:       mockNetlink,
+		networkConfig: &config.NetworkConfig{IPv4Enabled: true, IPv6Enabled: true},
+	}
+	newRule := func(family int) *netlink.Rule {
+		rule := netlink.NewRule()
+		rule.Family = family
+		rule.Table = 101
+		rule.Mark = 1
+		rule.Mask = int(types.SNATIPMarkMask)
+		rule.Priority = egressRulePriority
+		return rule
+	}
+	mockNetlink.EXPECT().RuleAdd(newRule(netlink.FAMILY_V4))
+	mockNetlink.EXPECT().RuleAdd(newRule(netlink.FAMILY_V6))
+	require.NoError(t, c.AddEgressRule(101, 1))
+
+	mockNetlink.EXPECT().RuleDel(newRule(netlink.FAMILY_V4))
+	mockNetlink.EXPECT().RuleDel(newRule(netlink.FAMILY_V6))
+	require.NoError(t, c.DeleteEgressRule(101, 1))
+}
+
+func TestClearEgressRoutesAndRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNetlink := netlinktest.NewMockInterface(ctrl)
+	c := &Client{
+		netlink:       mockNetlink,
+		networkConfig: &config.NetworkConfig{IPv4Enabled: true},
+	}
+	mainRoute := netlink.Route{Dst: ip.MustParseCIDR("10.10.10.0/24"), LinkIndex: 10, Table: 254}
+	egressRoute := netlink.Route{Dst: ip.MustParseCIDR("0.0.0.0/0"), Gw: net.ParseIP("10.10.10.1"), LinkIndex: 10, Table: 101, Protocol: egressRouteProtocol}
+	// The routes and rules installed by other components in the same range of tables must be kept.
+	otherRoute := netlink.Route{Dst: ip.MustParseCIDR("0.0.0.0/0"), Gw: net.ParseIP("10.10.20.1"), LinkIndex: 11, Table: 200, Protocol: unix.RTPROT_BOOT}
+	mainRule := netlink.Rule{Table: 254, Priority: 32766}
+	egressRule := netlink.Rule{Table: 101, Mark: 1, Mask: 0xff, Priority: egressRulePriority}
+	otherRule := netlink.Rule{Table: 200, Mark: 2, Mask: 0xff, Priority: 100}
+	mockNetlink.EXPECT().RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: 0}, netlink.RT_FILTER_TABLE).Return([]netlink.Route{mainRoute, egressRoute, otherRoute}, nil)
+	mockNetlink.EXPECT().RouteDel(&egressRoute)
+	mockNetlink.EXPECT().RuleList(netlink.FAMILY_V4).Return([]netlink.Rule{mainRule, egressRule, otherRule}, nil)
+	expectedRule := egressRule
+	expectedRule.Family = netlink.FAMILY_V4
+	mockNetlink.EXPECT().RuleDel(&expectedRule)
+	require.NoError(t, c.ClearEgressRoutesAndRules(101, 355))
+}
+
 func TestAddNodePort(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -1721,3 +1824,60 @@ func TestAddAndDeleteNodeIP(t *testing.T) {
 		})
 	}
 }
+
+func TestAddOrUpdateAndDeleteNodeNetworkPolicyIPTables(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIPTables := iptablestest.NewMockInterface(ctrl)
+	c := &Client{iptables: mockIPTables}
+
+	ruleChain := "ANTREA-POL-RULE1"
+	mockIPTables.EXPECT().Restore(`*filter
+:ANTREA-POL-RULE1 - [0:0]
+:ANTREA-POL-INGRESS-RULES - [0:0]
+-A ANTREA-POL-RULE1 -p tcp --dport 22 -j DROP
+-A ANTREA-POL-INGRESS-RULES -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+-A ANTREA-POL-INGRESS-RULES -j ANTREA-POL-RULE1
+COMMIT
+`, false, false)
+	assert.NoError(t, c.AddOrUpdateNodeNetworkPolicyIPTables(
+		[]string{ruleChain, config.NodeNetworkPolicyIngressRulesChain},
+		[][]string{
+			{"-p tcp --dport 22 -j DROP"},
+			{"-m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT", "-j ANTREA-POL-RULE1"},
+		},
+		false))
+	rules, ok := c.nodeNetworkPolicyIPTablesIPv4.Load(ruleChain)
+	require.True(t, ok)
+	assert.Equal(t, []string{"-p tcp --dport 22 -j DROP"}, rules)
+
+	mockIPTables.EXPECT().Restore(`*filter
+:ANTREA-POL-RULE1 - [0:0]
+-X ANTREA-POL-RULE1
+COMMIT
+`, false, false)
+	assert.NoError(t, c.DeleteNodeNetworkPolicyIPTables([]string{ruleChain}, false))
+	_, ok = c.nodeNetworkPolicyIPTablesIPv4.Load(ruleChain)
+	assert.False(t, ok)
+}
+
+func TestParseIPTablesCounters(t *testing.T) {
+	data := []byte(`*nat
+:ANTREA-POSTROUTING - [0:0]
+[5:300] -A ANTREA-POSTROUTING -j MASQUERADE
+COMMIT
+*filter
+:ANTREA-POL-RULE1 - [0:0]
+:ANTREA-POL-RULE2 - [0:0]
+[10:840] -A ANTREA-POL-RULE1 -p tcp --dport 22 -j NFLOG --nflog-group 41 --nflog-prefix 1
+[10:840] -A ANTREA-POL-RULE1 -p tcp --dport 22 -j DROP
+[2:120] -A ANTREA-POL-RULE2 -p tcp --dport 80 -j ACCEPT
+[3:180] -A ANTREA-POL-RULE2 -p tcp --dport 443 -j ACCEPT
+[7:420] -A ANTREA-POL-RULE3 -j ACCEPT
+COMMIT
+`)
+	counters := parseIPTablesCounters(data, sets.New[string]("ANTREA-POL-RULE1", "ANTREA-POL-RULE2", "ANTREA-POSTROUTING"))
+	assert.Equal(t, map[string]*types.RuleMetric{
+		"ANTREA-POL-RULE1": {Packets: 10, Bytes: 840},
+		"ANTREA-POL-RULE2": {Packets: 5, Bytes: 300},
+	}, counters)
+}
@@ -30,6 +30,7 @@ import (
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/agent/servicecidr"
+	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
 	antreasyscall "antrea.io/antrea/pkg/agent/util/syscall"
 	"antrea.io/antrea/pkg/agent/util/winfirewall"
@@ -71,7 +72,7 @@ type Client struct {
 }
 
 // NewClient returns a route client.
-func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, connectUplinkToBridge, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
+func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, connectUplinkToBridge, nodeNetworkPolicyEnabled, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
 	return &Client{
 		networkConfig:        networkConfig,
 		nodeRoutes:           &sync.Map{},
@@ -573,3 +574,43 @@ func (c *Client) DeleteRouteForLink(dstCIDR *net.IPNet, linkIndex int) error {
 func (c *Client) ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error {
 	return errors.New("ClearConntrackEntryForService is not implemented on Windows")
 }
+
+func (c *Client) AddOrUpdateNodeNetworkPolicyIPSet(ipsetName string, ipsetEntries sets.Set[string], isIPv6 bool) error {
+	return errors.New("AddOrUpdateNodeNetworkPolicyIPSet is not implemented on Windows")
+}
+
+func (c *Client) DeleteNodeNetworkPolicyIPSet(ipsetName string, isIPv6 bool) error {
+	return errors.New("DeleteNodeNetworkPolicyIPSet is not implemented on Windows")
+}
+
+func (c *Client) AddOrUpdateNodeNetworkPolicyIPTables(iptablesChains []string, iptablesRules [][]string, isIPv6 bool) error {
+	return errors.New("AddOrUpdateNodeNetworkPolicyIPTables is not implemented on Windows")
+}
+
+func (c *Client) DeleteNodeNetworkPolicyIPTables(iptablesChains []string, isIPv6 bool) error {
+	return errors.New("DeleteNodeNetworkPolicyIPTables is not implemented on Windows")
+}
+
+func (c *Client) GetNodeNetworkPolicyIPTablesCounters() (map[string]*types.RuleMetric, error) {
+	return nil, errors.New("GetNodeNetworkPolicyIPTablesCounters is not implemented on Windows")
+}
+
+func (c *Client) AddEgressRoutes(tableID, dev uint32, gateway net.IP, prefixLength int) error {
+	return errors.New("AddEgressRoutes is not implemented on Windows")
+}
+
+func (c *Client) DeleteEgressRoutes(tableID uint32) error {
+	return errors.New("DeleteEgressRoutes is not implemented on Windows")
+}
+
+func (c *Client) AddEgressRule(tableID uint32, mark uint32) error {
+	return errors.New("AddEgressRule is not implemented on Windows")
+}
+
+func (c *Client) DeleteEgressRule(tableID uint32, mark uint32) error {
+	return errors.New("DeleteEgressRule is not implemented on Windows")
+}
+
+func (c *Client) ClearEgressRoutesAndRules(minTableID, maxTableID uint32) error {
+	return errors.New("ClearEgressRoutesAndRules is not implemented on Windows")
+}
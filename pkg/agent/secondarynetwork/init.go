@@ -20,6 +20,7 @@ import (
 
 	"github.com/TomCodeLV/OVSDB-golang-lib/pkg/ovsdb"
 	netdefclient "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/typed/k8s.cni.cncf.io/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -45,6 +46,7 @@ func Initialize(
 	kubeAPIServerOverride string,
 	k8sClient clientset.Interface,
 	podInformer cache.SharedIndexInformer,
+	nodeInformer coreinformers.NodeInformer,
 	nodeName string,
 	podCache cnipodcache.CNIPodInfoStore,
 	stopCh <-chan struct{},
@@ -65,7 +67,7 @@ func Initialize(
 	// Create podController to handle secondary network configuration for Pods with
 	// k8s.v1.cni.cncf.io/networks Annotation defined.
 	if podWatchController, err := podwatch.NewPodController(
-		k8sClient, netAttachDefClient, podInformer,
+		k8sClient, netAttachDefClient, podInformer, nodeInformer,
 		nodeName, podCache, ovsBridgeClient); err != nil {
 		return err
 	} else {
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -85,12 +86,15 @@ type PodController struct {
 	interfaceConfigurator InterfaceConfigurator
 	ipamAllocator         IPAMAllocator
 	vfDeviceIDUsageMap    sync.Map
+	// overlayTunnelManager is nil if no OVS bridge is configured for secondary networks.
+	overlayTunnelManager *overlayTunnelManager
 }
 
 func NewPodController(
 	kubeClient clientset.Interface,
 	netAttachDefClient netdefclient.K8sCniCncfIoV1Interface,
 	podInformer cache.SharedIndexInformer,
+	nodeInformer coreinformers.NodeInformer,
 	nodeName string,
 	podCache cnipodcache.CNIPodInfoStore,
 	ovsBridgeClient ovsconfig.OVSBridgeClient,
@@ -110,6 +114,9 @@ func NewPodController(
 		interfaceConfigurator: interfaceConfigurator,
 		ipamAllocator:         ipam.GetSecondaryNetworkAllocator(),
 	}
+	if ovsBridgeClient != nil {
+		pc.overlayTunnelManager = newOverlayTunnelManager(ovsBridgeClient, nodeInformer, nodeName)
+	}
 	podInformer.AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    pc.enqueuePod,
@@ -150,11 +157,14 @@ func (pc *PodController) deletePodSecondaryNetwork(podCNIInfo *cnipodcache.CNICo
 		klog.V(1).InfoS("Deleting secondary interface",
 			"Pod", klog.KRef(podCNIInfo.PodNamespace, podCNIInfo.PodName),
 			"interface", iface, "interfaceInfo", *interfaceInfo)
-		if interfaceInfo.NetworkType == vlanNetworkType {
+		if interfaceInfo.NetworkType == vlanNetworkType || interfaceInfo.NetworkType == overlayNetworkType {
 			if err := pc.interfaceConfigurator.DeleteVLANSecondaryInterface(podCNIInfo.ContainerID,
 				interfaceInfo.HostInterfaceName, interfaceInfo.OVSPortUUID); err != nil {
 				return err
 			}
+			if interfaceInfo.NetworkType == overlayNetworkType && pc.overlayTunnelManager != nil {
+				pc.overlayTunnelManager.deletePort(interfaceInfo.OVSPortUUID)
+			}
 		}
 
 		podOwner := &crdv1a2.PodOwner{
@@ -353,10 +363,16 @@ func (pc *PodController) configureSecondaryInterface(
 			// VLAN.
 			vlanID = uint16(networkConfig.VLAN)
 		}
+		if pc.overlayTunnelManager != nil && pc.overlayTunnelManager.isNetworkVLAN(vlanID) {
+			ifConfigErr = fmt.Errorf("VLAN %d is already used by an overlay network", vlanID)
+			break
+		}
 		ovsPortUUID, ifConfigErr = pc.interfaceConfigurator.ConfigureVLANSecondaryInterface(
 			podCNIInfo.PodName, podCNIInfo.PodNamespace,
 			podCNIInfo.ContainerID, podCNIInfo.ContainerNetNS, network.InterfaceRequest,
 			int(networkConfig.MTU), vlanID, result)
+	case overlayNetworkType:
+		ovsPortUUID, ifConfigErr = pc.configureOverlayAsSecondaryInterface(pod, network, podCNIInfo, networkConfig, result)
 	}
 	if ifConfigErr != nil {
 		return ifConfigErr
@@ -379,6 +395,73 @@ func (pc *PodController) configureSecondaryInterface(
 	return nil
 }
 
+// configureOverlayAsSecondaryInterface connects the Pod to an overlay network with an access port of the VLAN which
+// isolates the network on the secondary OVS bridge, after ensuring the tunnels of the network are created.
+func (pc *PodController) configureOverlayAsSecondaryInterface(
+	pod *corev1.Pod,
+	network *netdefv1.NetworkSelectionElement,
+	podCNIInfo *cnipodcache.CNIConfigInfo,
+	networkConfig *SecondaryNetworkConfig,
+	result *current.Result) (string, error) {
+	if pc.overlayTunnelManager == nil {
+		return "", fmt.Errorf("overlay network requires an OVS bridge configured for secondary networks")
+	}
+	vlanID := uint16(networkConfig.VLAN)
+	if err := pc.checkVLANNetworksForOverlay(vlanID); err != nil {
+		return "", err
+	}
+	mtu := int(networkConfig.MTU)
+	if mtu == 0 {
+		var err error
+		if mtu, err = pc.overlayTunnelManager.getOverlayMTU(); err != nil {
+			return "", err
+		}
+	}
+	vni := uint32(networkConfig.VNI)
+	if err := pc.overlayTunnelManager.addNetwork(overlayNetwork{
+		vni:        vni,
+		tunnelType: networkConfig.TunnelType,
+		vlanID:     vlanID,
+	}); err != nil {
+		return "", err
+	}
+	ovsPortUUID, err := pc.interfaceConfigurator.ConfigureVLANSecondaryInterface(
+		podCNIInfo.PodName, podCNIInfo.PodNamespace,
+		podCNIInfo.ContainerID, podCNIInfo.ContainerNetNS, network.InterfaceRequest,
+		mtu, vlanID, result)
+	if err != nil {
+		pc.overlayTunnelManager.releaseNetwork(vni)
+		return "", err
+	}
+	pc.overlayTunnelManager.addPort(ovsPortUUID, vni)
+	return ovsPortUUID, nil
+}
+
+// checkVLANNetworksForOverlay fails if the VLAN of an overlay network is specified by a NetworkAttachmentDefinition of
+// the vlan network type, as the traffic of the vlan network would be isolated from the physical network, and mixed
+// with the traffic of the overlay network.
+func (pc *PodController) checkVLANNetworksForOverlay(vlanID uint16) error {
+	netDefs, err := pc.netAttachDefClient.NetworkAttachmentDefinitions(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list NetworkAttachmentDefinitions: %v", err)
+	}
+	for i := range netDefs.Items {
+		netDef := &netDefs.Items[i]
+		cniConfig, err := netdefutils.GetCNIConfig(netDef, "")
+		if err != nil {
+			continue
+		}
+		networkConfig, err := validateNetworkConfig(cniConfig)
+		if err != nil || networkConfig.NetworkType != vlanNetworkType {
+			continue
+		}
+		if networkConfig.VLAN == int32(vlanID) {
+			return fmt.Errorf("VLAN %d of the overlay network is already used by the vlan network %s", vlanID, klog.KObj(netDef))
+		}
+	}
+	return nil
+}
+
 func (pc *PodController) configurePodSecondaryNetwork(pod *corev1.Pod, networklist []*netdefv1.NetworkSelectionElement, podCNIInfo *cnipodcache.CNIConfigInfo) error {
 	for _, network := range networklist {
 		klog.V(2).InfoS("Secondary Network attached to Pod", "network", network, "Pod", klog.KObj(pod))
@@ -427,13 +510,26 @@ func validateNetworkConfig(cniConfig []byte) (*SecondaryNetworkConfig, error) {
 		return &networkConfig, fmt.Errorf("not Antrea CNI type '%s'", networkConfig.Type)
 
 	}
-	if networkConfig.NetworkType != sriovNetworkType && networkConfig.NetworkType != vlanNetworkType {
-		return &networkConfig, fmt.Errorf("secondary network type '%s' not supported", networkConfig.NetworkType)
-	}
-	if networkConfig.NetworkType == vlanNetworkType {
+	switch networkConfig.NetworkType {
+	case sriovNetworkType:
+	case vlanNetworkType:
 		if networkConfig.VLAN > vlanIDMax || networkConfig.VLAN < 0 {
 			return &networkConfig, fmt.Errorf("invalid VLAN ID %d", networkConfig.VLAN)
 		}
+	case overlayNetworkType:
+		if networkConfig.VNI > overlayVNIMax || networkConfig.VNI <= 0 {
+			return &networkConfig, fmt.Errorf("invalid VNI %d", networkConfig.VNI)
+		}
+		if networkConfig.VLAN > vlanIDMax || networkConfig.VLAN <= 0 {
+			return &networkConfig, fmt.Errorf("invalid VLAN ID %d, a VLAN ID must be specified for the overlay network", networkConfig.VLAN)
+		}
+		if networkConfig.TunnelType == "" {
+			networkConfig.TunnelType = ovsconfig.GeneveTunnel
+		} else if networkConfig.TunnelType != ovsconfig.GeneveTunnel && networkConfig.TunnelType != ovsconfig.VXLANTunnel {
+			return &networkConfig, fmt.Errorf("unsupported tunnel type %s", networkConfig.TunnelType)
+		}
+	default:
+		return &networkConfig, fmt.Errorf("secondary network type '%s' not supported", networkConfig.NetworkType)
 	}
 	if networkConfig.MTU < 0 {
 		return &networkConfig, fmt.Errorf("invalid MTU %d", networkConfig.MTU)
@@ -444,7 +540,9 @@ func validateNetworkConfig(cniConfig []byte) (*SecondaryNetworkConfig, error) {
 		}
 	}
 
-	if networkConfig.MTU == 0 {
+	// The default MTU of an overlay network depends on the underlay address family, and is
+	// set when configuring the interface.
+	if networkConfig.MTU == 0 && networkConfig.NetworkType != overlayNetworkType {
 		// TODO: use the physical interface MTU as the default.
 		networkConfig.MTU = interfaceDefaultMTU
 	}
@@ -460,6 +558,9 @@ func (pc *PodController) Run(stopCh <-chan struct{}) {
 	if !cache.WaitForNamedCacheSync(controllerName, stopCh, pc.podInformer.HasSynced) {
 		return
 	}
+	if pc.overlayTunnelManager != nil {
+		go pc.overlayTunnelManager.Run(stopCh)
+	}
 	for i := 0; i < numWorkers; i++ {
 		go wait.Until(pc.Worker, time.Second, stopCh)
 	}
@@ -49,6 +49,8 @@ import (
 	"antrea.io/antrea/pkg/agent/secondarynetwork/cnipodcache"
 	podwatchtesting "antrea.io/antrea/pkg/agent/secondarynetwork/podwatch/testing"
 	crdv1a2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
 )
 
 const (
@@ -64,6 +66,7 @@ const (
     "networkType": "{{.NetworkType}}",
     "mtu": {{.MTU}},
     "vlan": {{.VLAN}},
+    "vni": {{.VNI}},
     "ipam": {
         "type": "{{.IPAMType}}",
         "ippools": [ "ipv4-pool-1", "ipv6-pool-1" ]
@@ -75,7 +78,8 @@ const (
     "type": "{{.CNIType}}",
     "networkType": "{{.NetworkType}}",
     "mtu": {{.MTU}},
-    "vlan": {{.VLAN}}
+    "vlan": {{.VLAN}},
+    "vni": {{.VNI}}
 }`
 
 	defaultCNIVersion = "0.3.0"
@@ -90,10 +94,10 @@ const (
 )
 
 func testNetwork(name string, networkType cnipodcache.NetworkType) *netdefv1.NetworkAttachmentDefinition {
-	return testNetworkExt(name, "", "", string(networkType), "", 0, 0, false)
+	return testNetworkExt(name, "", "", string(networkType), "", 0, 0, 0, false)
 }
 
-func testNetworkExt(name, cniVersion, cniType, networkType, ipamType string, mtu, vlan int, noIPAM bool) *netdefv1.NetworkAttachmentDefinition {
+func testNetworkExt(name, cniVersion, cniType, networkType, ipamType string, mtu, vlan, vni int, noIPAM bool) *netdefv1.NetworkAttachmentDefinition {
 	if cniVersion == "" {
 		cniVersion = defaultCNIVersion
 	}
@@ -110,7 +114,8 @@ func testNetworkExt(name, cniVersion, cniType, networkType, ipamType string, mtu
 		IPAMType    string
 		MTU         int
 		VLAN        int
-	}{cniVersion, cniType, networkType, ipamType, mtu, vlan}
+		VNI         int
+	}{cniVersion, cniType, networkType, ipamType, mtu, vlan, vni}
 
 	var tmpl *template.Template
 	if !noIPAM {
@@ -208,6 +213,7 @@ func TestPodControllerRun(t *testing.T) {
 		client,
 		netdefclient,
 		informerFactory.Core().V1().Pods().Informer(),
+		informerFactory.Core().V1().Nodes(),
 		testNode,
 		podCache,
 		nil)
@@ -301,7 +307,11 @@ func TestConfigurePodSecondaryNetwork(t *testing.T) {
 		ipamType           string
 		mtu                int
 		vlan               int
+		vni                int
 		noIPAM             bool
+		noOVSBridge        bool
+		overlayVLAN        int
+		vlanNetworkVLAN    int
 		doNotCreateNetwork bool
 		interfaceCreated   bool
 		expectedErr        string
@@ -382,6 +392,106 @@ func TestConfigurePodSecondaryNetwork(t *testing.T) {
 				).Return(nil)
 			},
 		},
+		{
+			name:             "overlay network",
+			networkType:      overlayNetworkType,
+			vlan:             200,
+			vni:              5001,
+			interfaceCreated: true,
+			expectedCalls: func(mockIPAM *podwatchtesting.MockIPAMAllocator, mockIC *podwatchtesting.MockInterfaceConfigurator) {
+				mockIPAM.EXPECT().SecondaryNetworkAllocate(podOwner, gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+				mockIC.EXPECT().ConfigureVLANSecondaryInterface(
+					podName,
+					testNamespace,
+					containerID,
+					containerNetNs(containerID),
+					interfaceName,
+					1450,
+					uint16(200),
+					gomock.Any(),
+				).Return(ovsPortUUID, nil)
+			},
+		},
+		{
+			name:        "overlay network with VLAN of vlan network",
+			networkType: overlayNetworkType,
+			vlan:        200,
+			vni:         5001,
+			// The vlan network is not used by the Pod.
+			vlanNetworkVLAN: 200,
+			expectedCalls: func(mockIPAM *podwatchtesting.MockIPAMAllocator, mockIC *podwatchtesting.MockInterfaceConfigurator) {
+				mockIPAM.EXPECT().SecondaryNetworkAllocate(podOwner, gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+				mockIPAM.EXPECT().SecondaryNetworkRelease(podOwner).Return(nil)
+			},
+			expectedErr: "VLAN 200 of the overlay network is already used by the vlan network",
+		},
+		{
+			name:        "overlay network with VLAN of another overlay network",
+			networkType: overlayNetworkType,
+			vlan:        200,
+			vni:         5001,
+			overlayVLAN: 200,
+			expectedCalls: func(mockIPAM *podwatchtesting.MockIPAMAllocator, mockIC *podwatchtesting.MockInterfaceConfigurator) {
+				mockIPAM.EXPECT().SecondaryNetworkAllocate(podOwner, gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+				mockIPAM.EXPECT().SecondaryNetworkRelease(podOwner).Return(nil)
+			},
+			expectedErr: "VLAN 200 is already used by the overlay network",
+		},
+		{
+			name:        "overlay network interface failure",
+			networkType: overlayNetworkType,
+			vlan:        200,
+			vni:         5001,
+			expectedCalls: func(mockIPAM *podwatchtesting.MockIPAMAllocator, mockIC *podwatchtesting.MockInterfaceConfigurator) {
+				mockIPAM.EXPECT().SecondaryNetworkAllocate(podOwner, gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+				mockIC.EXPECT().ConfigureVLANSecondaryInterface(
+					podName,
+					testNamespace,
+					containerID,
+					containerNetNs(containerID),
+					interfaceName,
+					1450,
+					uint16(200),
+					gomock.Any(),
+				).Return("", errors.New("interface creation failure"))
+				mockIPAM.EXPECT().SecondaryNetworkRelease(podOwner).Return(nil)
+			},
+			expectedErr: "interface creation failure",
+		},
+		{
+			name:        "VLAN network with VLAN of overlay network",
+			networkType: vlanNetworkType,
+			vlan:        200,
+			overlayVLAN: 200,
+			expectedCalls: func(mockIPAM *podwatchtesting.MockIPAMAllocator, mockIC *podwatchtesting.MockInterfaceConfigurator) {
+				mockIPAM.EXPECT().SecondaryNetworkAllocate(podOwner, gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+				mockIPAM.EXPECT().SecondaryNetworkRelease(podOwner).Return(nil)
+			},
+			expectedErr: "VLAN 200 is already used by an overlay network",
+		},
+		{
+			name:        "overlay network without OVS bridge",
+			networkType: overlayNetworkType,
+			vlan:        200,
+			vni:         5001,
+			noOVSBridge: true,
+			expectedCalls: func(mockIPAM *podwatchtesting.MockIPAMAllocator, mockIC *podwatchtesting.MockInterfaceConfigurator) {
+				mockIPAM.EXPECT().SecondaryNetworkAllocate(podOwner, gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+				mockIPAM.EXPECT().SecondaryNetworkRelease(podOwner).Return(nil)
+			},
+			expectedErr: "overlay network requires an OVS bridge",
+		},
+		{
+			name:        "overlay network without VLAN",
+			networkType: overlayNetworkType,
+			vni:         5001,
+		},
+		{
+			name:        "invalid VNI",
+			networkType: overlayNetworkType,
+			vlan:        200,
+			vni:         1 << 24,
+		},
 		{
 			name:               "network not found",
 			networkType:        vlanNetworkType,
@@ -482,9 +592,25 @@ func TestConfigurePodSecondaryNetwork(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pod, cniConfigInfo := testPod(podName, containerID, podIP, element1)
 			pc, mockIPAM, interfaceConfigurator := testPodController(ctrl)
+			if !tc.noOVSBridge {
+				nodeInformer := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), resyncPeriod).Core().V1().Nodes()
+				nodeInformer.Informer().GetIndexer().Add(newTestNode(testNode, "10.0.0.1"))
+				mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(ctrl)
+				// There is no uplink port, so the trunks are never updated.
+				mockOVSBridgeClient.EXPECT().GetPortList().Return(nil, nil).AnyTimes()
+				pc.overlayTunnelManager = newOverlayTunnelManager(mockOVSBridgeClient, nodeInformer, testNode)
+				if tc.overlayVLAN != 0 {
+					pc.overlayTunnelManager.networks[6001] = overlayNetwork{vni: 6001, tunnelType: ovsconfig.GeneveTunnel, vlanID: uint16(tc.overlayVLAN)}
+					pc.overlayTunnelManager.networkRefs[6001] = 1
+				}
+			}
+			if tc.vlanNetworkVLAN != 0 {
+				vlanNetwork := testNetworkExt("vlan-net", "", "", string(vlanNetworkType), "", 0, tc.vlanNetworkVLAN, 0, false)
+				pc.netAttachDefClient.NetworkAttachmentDefinitions(testNamespace).Create(context.Background(), vlanNetwork, metav1.CreateOptions{})
+			}
 			savedCNIConfig := *cniConfigInfo
 
-			network1 := testNetworkExt(networkName, tc.cniVersion, tc.cniType, string(tc.networkType), tc.ipamType, tc.mtu, tc.vlan, tc.noIPAM)
+			network1 := testNetworkExt(networkName, tc.cniVersion, tc.cniType, string(tc.networkType), tc.ipamType, tc.mtu, tc.vlan, tc.vni, tc.noIPAM)
 			if !tc.doNotCreateNetwork {
 				pc.netAttachDefClient.NetworkAttachmentDefinitions(testNamespace).Create(context.Background(), network1, metav1.CreateOptions{})
 			}
@@ -502,17 +628,61 @@ func TestConfigurePodSecondaryNetwork(t *testing.T) {
 				info := cnipodcache.InterfaceInfo{
 					NetworkType: tc.networkType,
 				}
-				if tc.networkType == vlanNetworkType {
+				if tc.networkType == vlanNetworkType || tc.networkType == overlayNetworkType {
 					info.OVSPortUUID = ovsPortUUID
 				}
 				savedCNIConfig.Interfaces = map[string]*cnipodcache.InterfaceInfo{interfaceName: &info}
 			}
 			assert.Equal(t, &savedCNIConfig, cniConfigInfo)
+			if tc.networkType == overlayNetworkType && pc.overlayTunnelManager != nil {
+				vni := uint32(tc.vni)
+				if tc.interfaceCreated {
+					assert.Equal(t, map[string]uint32{ovsPortUUID: vni}, pc.overlayTunnelManager.ports)
+					assert.Equal(t, 1, pc.overlayTunnelManager.networkRefs[vni])
+				} else {
+					assert.Empty(t, pc.overlayTunnelManager.ports)
+					assert.NotContains(t, pc.overlayTunnelManager.networks, vni)
+				}
+			}
 		})
 	}
 
 }
 
+func TestDeleteOverlaySecondaryNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pc, mockIPAM, interfaceConfigurator := testPodController(ctrl)
+	m, _ := newTestOverlayTunnelManager(t, ctrl)
+	pc.overlayTunnelManager = m
+	m.networks[5001] = overlayNetwork{vni: 5001, tunnelType: ovsconfig.GeneveTunnel, vlanID: 200}
+	m.networkRefs[5001] = 2
+	m.ports[ovsPortUUID] = 5001
+	m.ports["other-port"] = 5001
+
+	_, cniConfig := testPod(podName, containerID, podIP)
+	cniConfig.Interfaces = map[string]*cnipodcache.InterfaceInfo{
+		interfaceName: {NetworkType: overlayNetworkType, HostInterfaceName: "pod1-abcdef", OVSPortUUID: ovsPortUUID},
+	}
+	interfaceConfigurator.EXPECT().DeleteVLANSecondaryInterface(containerID, "pod1-abcdef", ovsPortUUID).Return(nil)
+	mockIPAM.EXPECT().SecondaryNetworkRelease(gomock.Any()).Return(nil)
+	require.NoError(t, pc.deletePodSecondaryNetwork(cniConfig))
+	// The overlay network is still used by another Pod interface.
+	assert.Contains(t, m.networks, uint32(5001))
+	assert.Equal(t, map[string]uint32{"other-port": 5001}, m.ports)
+	assert.Equal(t, 0, m.queue.Len())
+
+	cniConfig.Interfaces = map[string]*cnipodcache.InterfaceInfo{
+		interfaceName: {NetworkType: overlayNetworkType, HostInterfaceName: "pod2-abcdef", OVSPortUUID: "other-port"},
+	}
+	interfaceConfigurator.EXPECT().DeleteVLANSecondaryInterface(containerID, "pod2-abcdef", "other-port").Return(nil)
+	mockIPAM.EXPECT().SecondaryNetworkRelease(gomock.Any()).Return(nil)
+	require.NoError(t, pc.deletePodSecondaryNetwork(cniConfig))
+	assert.Empty(t, m.networks)
+	assert.Empty(t, m.networkRefs)
+	assert.Empty(t, m.ports)
+	assert.Equal(t, 1, m.queue.Len())
+}
+
 func TestPodControllerAddPod(t *testing.T) {
 	pod, cniConfig := testPod(podName, containerID, podIP, netdefv1.NetworkSelectionElement{
 		Name:             networkName,
@@ -548,7 +718,7 @@ func TestPodControllerAddPod(t *testing.T) {
 		savedCNIConfig := *cniConfig
 		network1 := testNetwork("net1", sriovNetworkType)
 		testVLAN := 100
-		network2 := testNetworkExt("net2", "", "", string(vlanNetworkType), "", defaultMTU, testVLAN, false)
+		network2 := testNetworkExt("net2", "", "", string(vlanNetworkType), "", defaultMTU, testVLAN, 0, false)
 
 		podOwner1 := &crdv1a2.PodOwner{
 			Name:        podName,
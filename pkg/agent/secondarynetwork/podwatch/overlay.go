@@ -0,0 +1,414 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podwatch
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	"antrea.io/antrea/pkg/util/k8s"
+)
+
+const (
+	overlayTunnelManagerName = "SecondaryOverlayTunnelManager"
+	// overlayTunnelsKey is the only key of the overlayTunnelManager queue, as all tunnels are synced together.
+	overlayTunnelsKey = "overlayTunnels"
+
+	// The encapsulation overhead of Geneve and VXLAN with an IPv4 underlay.
+	overlayOverhead = 50
+	// The extra encapsulation overhead with an IPv6 underlay.
+	overlayIPv6ExtraOverhead = 20
+	overlayVNIMax            = 1<<24 - 1
+)
+
+// overlayNetwork is a secondary L2 overlay network used by Pods on the Node.
+type overlayNetwork struct {
+	vni        uint32
+	tunnelType ovsconfig.TunnelType
+	// The VLAN ID which isolates the overlay network on the secondary OVS bridge. The Pod
+	// interfaces and the tunnel ports of the network are access ports of the VLAN, and the
+	// VLAN is excluded from the trunks of the uplink port, so that the traffic of the network
+	// never reaches the physical network.
+	vlanID uint16
+}
+
+// overlayTunnel is a tunnel port to a remote Node for an overlay network.
+type overlayTunnel struct {
+	network  overlayNetwork
+	remoteIP string
+}
+
+// overlayTunnelManager maintains the full mesh of tunnels between the Nodes for the overlay networks used on the
+// Node. A tunnel port is created on the secondary OVS bridge for every remote Node and every overlay network, keyed
+// by the VNI of the network. The tunnel ports are protected ports, so that the traffic received from a remote Node is
+// only forwarded to local Pods by the NORMAL action, which prevents loops across the full mesh. An overlay network is
+// removed, together with its tunnels, when the last local Pod interface connected to it is deleted.
+type overlayTunnelManager struct {
+	ovsBridgeClient  ovsconfig.OVSBridgeClient
+	nodeName         string
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
+	queue            workqueue.RateLimitingInterface
+
+	mutex sync.RWMutex
+	// networks stores the overlay networks used on the Node, keyed by VNI.
+	networks map[uint32]overlayNetwork
+	// networkRefs stores the number of local Pod interfaces connected, or being connected, to the overlay networks,
+	// keyed by VNI.
+	networkRefs map[uint32]int
+	// ports stores the VNIs of the overlay networks of the local Pod interfaces, keyed by OVS port UUID.
+	ports map[string]uint32
+	// uplinkExcludedVLANs stores the VLANs excluded from the trunks of the uplink port by the last successful update,
+	// or is nil if the uplink port has not been updated yet.
+	uplinkExcludedVLANs sets.Set[uint16]
+}
+
+func newOverlayTunnelManager(ovsBridgeClient ovsconfig.OVSBridgeClient, nodeInformer coreinformers.NodeInformer, nodeName string) *overlayTunnelManager {
+	m := &overlayTunnelManager{
+		ovsBridgeClient:  ovsBridgeClient,
+		nodeName:         nodeName,
+		nodeLister:       nodeInformer.Lister(),
+		nodeListerSynced: nodeInformer.Informer().HasSynced,
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "overlayTunnels"),
+		networks:         map[uint32]overlayNetwork{},
+		networkRefs:      map[uint32]int{},
+		ports:            map[string]uint32{},
+	}
+	nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    m.enqueueNode,
+			UpdateFunc: m.updateNode,
+			DeleteFunc: m.enqueueNode,
+		},
+		resyncPeriod,
+	)
+	return m
+}
+
+func (m *overlayTunnelManager) enqueueNode(obj interface{}) {
+	m.queue.Add(overlayTunnelsKey)
+}
+
+func (m *overlayTunnelManager) updateNode(oldObj, newObj interface{}) {
+	oldNode := oldObj.(*corev1.Node)
+	newNode := newObj.(*corev1.Node)
+	// Only the transport addresses of the Node matter to the tunnels.
+	if getNodeTunnelIP(oldNode) != getNodeTunnelIP(newNode) {
+		m.queue.Add(overlayTunnelsKey)
+	}
+}
+
+// addNetwork adds an overlay network used by a local Pod interface, and triggers the creation of the tunnels of the
+// network if it's new. The VLAN of a new network is excluded from the uplink port before addNetwork returns, so that
+// the Pod interface can be connected to the VLAN right away. It fails if the network conflicts with an existing
+// overlay network. Every successful call must be paired with a call to releaseNetwork, or to addPort and then
+// deletePort.
+func (m *overlayTunnelManager) addNetwork(network overlayNetwork) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for vni, existing := range m.networks {
+		if vni == network.vni {
+			if existing != network {
+				return fmt.Errorf("overlay network with VNI %d is already configured with VLAN %d and tunnel type %s", vni, existing.vlanID, existing.tunnelType)
+			}
+			m.networkRefs[vni]++
+			return nil
+		}
+		if existing.vlanID == network.vlanID {
+			return fmt.Errorf("VLAN %d is already used by the overlay network with VNI %d", network.vlanID, vni)
+		}
+	}
+	m.networks[network.vni] = network
+	if err := m.syncUplinkTrunksLocked(); err != nil {
+		delete(m.networks, network.vni)
+		return err
+	}
+	m.networkRefs[network.vni]++
+	m.queue.Add(overlayTunnelsKey)
+	return nil
+}
+
+// releaseNetwork releases a reference to an overlay network acquired by addNetwork, when connecting a Pod interface to
+// the network failed. The network is removed if it's no longer used by any local Pod interface.
+func (m *overlayTunnelManager) releaseNetwork(vni uint32) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.releaseNetworkLocked(vni)
+}
+
+func (m *overlayTunnelManager) releaseNetworkLocked(vni uint32) {
+	if _, exists := m.networks[vni]; !exists {
+		return
+	}
+	m.networkRefs[vni]--
+	if m.networkRefs[vni] > 0 {
+		return
+	}
+	delete(m.networks, vni)
+	delete(m.networkRefs, vni)
+	klog.InfoS("Removed overlay network no longer used by local Pods", "vni", vni)
+	m.queue.Add(overlayTunnelsKey)
+}
+
+// addPort records the OVS port of a Pod interface connected to an overlay network after addNetwork.
+func (m *overlayTunnelManager) addPort(portUUID string, vni uint32) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.ports[portUUID] = vni
+}
+
+// deletePort releases the overlay network of a deleted Pod interface. It's a no-op if the OVS port is not connected to
+// an overlay network.
+func (m *overlayTunnelManager) deletePort(portUUID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	vni, exists := m.ports[portUUID]
+	if !exists {
+		return
+	}
+	delete(m.ports, portUUID)
+	m.releaseNetworkLocked(vni)
+}
+
+// isNetworkVLAN returns whether the VLAN is used by an overlay network on the Node.
+func (m *overlayTunnelManager) isNetworkVLAN(vlanID uint16) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for _, network := range m.networks {
+		if network.vlanID == vlanID {
+			return true
+		}
+	}
+	return false
+}
+
+// getOverlayMTU returns the default MTU of the Pod interfaces of the overlay networks, which accounts for the
+// encapsulation overhead with the underlay address family of the Node.
+func (m *overlayTunnelManager) getOverlayMTU() (int, error) {
+	node, err := m.nodeLister.Get(m.nodeName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get Node %s: %v", m.nodeName, err)
+	}
+	tunnelIP := net.ParseIP(getNodeTunnelIP(node))
+	if tunnelIP == nil {
+		return 0, fmt.Errorf("failed to get transport address of Node %s", m.nodeName)
+	}
+	if tunnelIP.To4() == nil {
+		return interfaceDefaultMTU - overlayOverhead - overlayIPv6ExtraOverhead, nil
+	}
+	return interfaceDefaultMTU - overlayOverhead, nil
+}
+
+// restoreNetworks restores the overlay networks from the existing tunnel ports after agent restarts. Only the networks
+// still having Pod interfaces on the OVS bridge are restored, and the tunnels of the others will be deleted.
+func (m *overlayTunnelManager) restoreNetworks() error {
+	ports, err := m.ovsBridgeClient.GetPortList()
+	if err != nil {
+		return fmt.Errorf("failed to list OVS ports: %v", err)
+	}
+	podPorts := map[uint16][]string{}
+	for _, port := range ports {
+		if port.VLANID != 0 && port.ExternalIDs[interfacestore.AntreaInterfaceTypeKey] != interfacestore.AntreaTunnel {
+			podPorts[port.VLANID] = append(podPorts[port.VLANID], port.UUID)
+		}
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, port := range ports {
+		if port.ExternalIDs[interfacestore.AntreaInterfaceTypeKey] != interfacestore.AntreaTunnel || len(podPorts[port.VLANID]) == 0 {
+			continue
+		}
+		vni, err := strconv.ParseUint(port.Options["key"], 10, 32)
+		if err != nil {
+			continue
+		}
+		network := overlayNetwork{vni: uint32(vni), tunnelType: ovsconfig.TunnelType(port.IFType), vlanID: port.VLANID}
+		if existing, exists := m.networks[network.vni]; exists && existing != network {
+			continue
+		}
+		m.networks[network.vni] = network
+		for _, portUUID := range podPorts[port.VLANID] {
+			if _, exists := m.ports[portUUID]; !exists {
+				m.ports[portUUID] = network.vni
+				m.networkRefs[network.vni]++
+			}
+		}
+	}
+	return nil
+}
+
+// syncTunnels creates the missing tunnels to the remote Nodes for all overlay networks used on the Node, and deletes
+// the stale ones.
+func (m *overlayTunnelManager) syncTunnels() error {
+	nodes, err := m.nodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	m.mutex.RLock()
+	desiredTunnels := map[string]overlayTunnel{}
+	for _, node := range nodes {
+		if node.Name == m.nodeName {
+			continue
+		}
+		remoteIP := getNodeTunnelIP(node)
+		if remoteIP == "" {
+			klog.InfoS("Failed to get transport address of Node, skip creating overlay tunnels", "node", node.Name)
+			continue
+		}
+		for _, network := range m.networks {
+			desiredTunnels[util.GenerateOverlayTunnelInterfaceName(node.Name, network.vni)] = overlayTunnel{network: network, remoteIP: remoteIP}
+		}
+	}
+	m.mutex.RUnlock()
+
+	ports, err := m.ovsBridgeClient.GetPortList()
+	if err != nil {
+		return fmt.Errorf("failed to list OVS ports: %v", err)
+	}
+	for _, port := range ports {
+		if port.ExternalIDs[interfacestore.AntreaInterfaceTypeKey] != interfacestore.AntreaTunnel {
+			continue
+		}
+		if tunnel, exists := desiredTunnels[port.Name]; exists &&
+			port.Options["remote_ip"] == tunnel.remoteIP &&
+			port.Options["key"] == strconv.FormatUint(uint64(tunnel.network.vni), 10) &&
+			port.IFType == string(tunnel.network.tunnelType) &&
+			port.VLANID == tunnel.network.vlanID {
+			delete(desiredTunnels, port.Name)
+			continue
+		}
+		if err := m.ovsBridgeClient.DeletePort(port.UUID); err != nil {
+			return fmt.Errorf("failed to delete overlay tunnel port %s: %v", port.Name, err)
+		}
+		klog.InfoS("Deleted overlay tunnel port", "port", port.Name)
+	}
+	for name, tunnel := range desiredTunnels {
+		externalIDs := map[string]interface{}{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTunnel}
+		if _, err := m.ovsBridgeClient.CreateOverlayTunnelPort(name, tunnel.network.tunnelType, tunnel.remoteIP,
+			tunnel.network.vni, tunnel.network.vlanID, externalIDs); err != nil {
+			return fmt.Errorf("failed to create overlay tunnel port %s: %v", name, err)
+		}
+		klog.InfoS("Created overlay tunnel port", "port", name, "remoteIP", tunnel.remoteIP, "vni", tunnel.network.vni)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.syncUplinkTrunksLocked()
+}
+
+// syncUplinkTrunksLocked excludes the VLANs of the overlay networks from the trunks of the uplink port, so that the
+// NORMAL action never floods the traffic of an overlay network to the physical network, and never forwards the
+// traffic received from the physical network to an overlay network. The uplink port trunks all VLANs when there is no
+// overlay network. It must be called with the mutex held.
+func (m *overlayTunnelManager) syncUplinkTrunksLocked() error {
+	overlayVLANs := sets.New[uint16]()
+	for _, network := range m.networks {
+		overlayVLANs.Insert(network.vlanID)
+	}
+	if m.uplinkExcludedVLANs != nil && m.uplinkExcludedVLANs.Equal(overlayVLANs) {
+		return nil
+	}
+	ports, err := m.ovsBridgeClient.GetPortList()
+	if err != nil {
+		return fmt.Errorf("failed to list OVS ports: %v", err)
+	}
+	var trunks []uint16
+	if overlayVLANs.Len() > 0 {
+		trunks = make([]uint16, 0, vlanIDMax+1-overlayVLANs.Len())
+		for vlanID := uint16(0); vlanID <= vlanIDMax; vlanID++ {
+			if !overlayVLANs.Has(vlanID) {
+				trunks = append(trunks, vlanID)
+			}
+		}
+	}
+	for _, port := range ports {
+		if port.ExternalIDs[interfacestore.AntreaInterfaceTypeKey] != interfacestore.AntreaUplink {
+			continue
+		}
+		if err := m.ovsBridgeClient.SetPortTrunks(port.Name, trunks); err != nil {
+			return fmt.Errorf("failed to set trunks of uplink port %s: %v", port.Name, err)
+		}
+		klog.InfoS("Updated VLANs excluded from uplink port", "port", port.Name, "vlans", sets.List(overlayVLANs))
+	}
+	m.uplinkExcludedVLANs = overlayVLANs
+	return nil
+}
+
+// getNodeTunnelIP returns the transport IP of the Node used as the remote IP of the overlay tunnels. IPv4 is
+// preferred for dual-stack Nodes.
+func getNodeTunnelIP(node *corev1.Node) string {
+	addrs, err := k8s.GetNodeTransportAddrs(node)
+	if err != nil || addrs == nil {
+		return ""
+	}
+	if addrs.IPv4 != nil {
+		return addrs.IPv4.String()
+	}
+	if addrs.IPv6 != nil {
+		return addrs.IPv6.String()
+	}
+	return ""
+}
+
+func (m *overlayTunnelManager) Run(stopCh <-chan struct{}) {
+	defer m.queue.ShutDown()
+	klog.InfoS("Starting", "controller", overlayTunnelManagerName)
+	defer klog.InfoS("Shutting down", "controller", overlayTunnelManagerName)
+	if !cache.WaitForNamedCacheSync(overlayTunnelManagerName, stopCh, m.nodeListerSynced) {
+		return
+	}
+	if err := m.restoreNetworks(); err != nil {
+		klog.ErrorS(err, "Failed to restore overlay networks")
+	}
+	m.queue.Add(overlayTunnelsKey)
+	go wait.Until(m.worker, time.Second, stopCh)
+	<-stopCh
+}
+
+func (m *overlayTunnelManager) worker() {
+	for m.processNextWorkItem() {
+	}
+}
+
+func (m *overlayTunnelManager) processNextWorkItem() bool {
+	key, quit := m.queue.Get()
+	if quit {
+		return false
+	}
+	defer m.queue.Done(key)
+	if err := m.syncTunnels(); err != nil {
+		klog.ErrorS(err, "Failed to sync overlay tunnels")
+		m.queue.AddRateLimited(key)
+	} else {
+		m.queue.Forget(key)
+	}
+	return true
+}
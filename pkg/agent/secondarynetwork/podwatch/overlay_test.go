@@ -0,0 +1,228 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package podwatch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
+)
+
+func newTestNode(name, ip string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: ip}},
+		},
+	}
+}
+
+func newTestOverlayTunnelManager(t *testing.T, ctrl *gomock.Controller, nodes ...*corev1.Node) (*overlayTunnelManager, *ovsconfigtest.MockOVSBridgeClient) {
+	var objects []runtime.Object
+	for _, node := range nodes {
+		objects = append(objects, node)
+	}
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(objects...), resyncPeriod)
+	mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(ctrl)
+	m := newOverlayTunnelManager(mockOVSBridgeClient, informerFactory.Core().V1().Nodes(), testNode)
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+	return m, mockOVSBridgeClient
+}
+
+var testUplinkPort = ovsconfig.OVSPortData{
+	UUID:        "uplink",
+	Name:        "eth1",
+	ExternalIDs: map[string]string{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaUplink},
+}
+
+// trunksExcluding returns the trunks of the uplink port which exclude the VLANs of the overlay networks.
+func trunksExcluding(vlanIDs ...uint16) []uint16 {
+	excluded := map[uint16]bool{}
+	for _, vlanID := range vlanIDs {
+		excluded[vlanID] = true
+	}
+	var trunks []uint16
+	for vlanID := uint16(0); vlanID <= vlanIDMax; vlanID++ {
+		if !excluded[vlanID] {
+			trunks = append(trunks, vlanID)
+		}
+	}
+	return trunks
+}
+
+func TestOverlayTunnelManagerAddNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m, mockOVSBridgeClient := newTestOverlayTunnelManager(t, ctrl)
+	network := overlayNetwork{vni: 5001, tunnelType: ovsconfig.GeneveTunnel, vlanID: 200}
+	mockOVSBridgeClient.EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{testUplinkPort}, nil)
+	mockOVSBridgeClient.EXPECT().SetPortTrunks("eth1", trunksExcluding(200)).Return(nil)
+	require.NoError(t, m.addNetwork(network))
+	assert.Equal(t, 1, m.queue.Len())
+	// Adding the same network again only adds a reference to it.
+	require.NoError(t, m.addNetwork(network))
+	assert.ErrorContains(t, m.addNetwork(overlayNetwork{vni: 5001, tunnelType: ovsconfig.VXLANTunnel, vlanID: 200}), "already configured")
+	assert.ErrorContains(t, m.addNetwork(overlayNetwork{vni: 5002, tunnelType: ovsconfig.GeneveTunnel, vlanID: 200}), "already used")
+	assert.Equal(t, map[uint32]overlayNetwork{5001: network}, m.networks)
+	assert.Equal(t, map[uint32]int{5001: 2}, m.networkRefs)
+	assert.True(t, m.isNetworkVLAN(200))
+	assert.False(t, m.isNetworkVLAN(300))
+
+	// The network is not added if the VLAN cannot be excluded from the uplink port.
+	mockOVSBridgeClient.EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{testUplinkPort}, nil)
+	mockOVSBridgeClient.EXPECT().SetPortTrunks("eth1", trunksExcluding(200, 300)).Return(ovsconfig.NewTransactionError(fmt.Errorf("failure"), false))
+	assert.ErrorContains(t, m.addNetwork(overlayNetwork{vni: 5002, tunnelType: ovsconfig.GeneveTunnel, vlanID: 300}), "failed to set trunks of uplink port")
+	assert.Equal(t, map[uint32]overlayNetwork{5001: network}, m.networks)
+	assert.Equal(t, map[uint32]int{5001: 2}, m.networkRefs)
+}
+
+func TestOverlayTunnelManagerRemoveNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m, mockOVSBridgeClient := newTestOverlayTunnelManager(t, ctrl, newTestNode(testNode, "10.0.0.1"), newTestNode("node-b", "10.0.0.2"))
+	network := overlayNetwork{vni: 5001, tunnelType: ovsconfig.GeneveTunnel, vlanID: 200}
+	mockOVSBridgeClient.EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{testUplinkPort}, nil)
+	mockOVSBridgeClient.EXPECT().SetPortTrunks("eth1", trunksExcluding(200)).Return(nil)
+	require.NoError(t, m.addNetwork(network))
+	m.addPort("pod1", network.vni)
+	require.NoError(t, m.addNetwork(network))
+	// Connecting the second Pod interface failed.
+	m.releaseNetwork(network.vni)
+	assert.Equal(t, map[uint32]overlayNetwork{5001: network}, m.networks)
+	// Deleting an OVS port which is not connected to an overlay network is a no-op.
+	m.deletePort("pod2")
+	assert.Equal(t, map[uint32]overlayNetwork{5001: network}, m.networks)
+
+	m.deletePort("pod1")
+	assert.Empty(t, m.networks)
+	assert.Empty(t, m.networkRefs)
+	assert.Empty(t, m.ports)
+	assert.False(t, m.isNetworkVLAN(200))
+
+	// The tunnels of the removed network are deleted, and the uplink port trunks all VLANs again.
+	ports := []ovsconfig.OVSPortData{
+		testUplinkPort,
+		{UUID: "node-b", Name: util.GenerateOverlayTunnelInterfaceName("node-b", 5001), VLANID: 200, IFType: "geneve",
+			ExternalIDs: map[string]string{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTunnel},
+			Options:     map[string]string{"remote_ip": "10.0.0.2", "key": "5001"}},
+	}
+	mockOVSBridgeClient.EXPECT().GetPortList().Return(ports, nil).Times(2)
+	mockOVSBridgeClient.EXPECT().DeletePort("node-b").Return(nil)
+	mockOVSBridgeClient.EXPECT().SetPortTrunks("eth1", []uint16(nil)).Return(nil)
+	require.NoError(t, m.syncTunnels())
+	// The uplink port is not updated again if the overlay networks are unchanged.
+	mockOVSBridgeClient.EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{testUplinkPort}, nil)
+	require.NoError(t, m.syncTunnels())
+}
+
+func TestOverlayTunnelManagerGetOverlayMTU(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		nodes       []*corev1.Node
+		expectedMTU int
+		expectedErr string
+	}{
+		{
+			name:        "IPv4 underlay",
+			nodes:       []*corev1.Node{newTestNode(testNode, "10.0.0.1")},
+			expectedMTU: 1450,
+		},
+		{
+			name:        "IPv6 underlay",
+			nodes:       []*corev1.Node{newTestNode(testNode, "fd00::1")},
+			expectedMTU: 1430,
+		},
+		{
+			name:        "Node not found",
+			expectedErr: "failed to get Node",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m, _ := newTestOverlayTunnelManager(t, ctrl, tc.nodes...)
+			mtu, err := m.getOverlayMTU()
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedMTU, mtu)
+			}
+		})
+	}
+}
+
+func TestOverlayTunnelManagerSyncTunnels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m, mockOVSBridgeClient := newTestOverlayTunnelManager(t, ctrl,
+		newTestNode(testNode, "10.0.0.1"),
+		newTestNode("node-b", "10.0.0.2"),
+		newTestNode("node-c", "10.0.0.3"),
+	)
+	m.networks = map[uint32]overlayNetwork{5001: {vni: 5001, tunnelType: ovsconfig.GeneveTunnel, vlanID: 200}}
+	tunnelExternalIDs := map[string]string{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTunnel}
+	nodeBTunnel := util.GenerateOverlayTunnelInterfaceName("node-b", 5001)
+	nodeCTunnel := util.GenerateOverlayTunnelInterfaceName("node-c", 5001)
+	ports := []ovsconfig.OVSPortData{
+		{UUID: "uplink", Name: "eth1", ExternalIDs: map[string]string{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaUplink}},
+		{UUID: "pod", Name: "pod1-abcdef", VLANID: 200},
+		{UUID: "node-b", Name: nodeBTunnel, VLANID: 200, IFType: "geneve", ExternalIDs: tunnelExternalIDs,
+			Options: map[string]string{"remote_ip": "10.0.0.2", "key": "5001"}},
+		{UUID: "stale", Name: util.GenerateOverlayTunnelInterfaceName("node-d", 5001), VLANID: 200, IFType: "geneve", ExternalIDs: tunnelExternalIDs,
+			Options: map[string]string{"remote_ip": "10.0.0.4", "key": "5001"}},
+	}
+	mockOVSBridgeClient.EXPECT().GetPortList().Return(ports, nil).Times(2)
+	mockOVSBridgeClient.EXPECT().DeletePort("stale").Return(nil)
+	mockOVSBridgeClient.EXPECT().CreateOverlayTunnelPort(nodeCTunnel, ovsconfig.TunnelType(ovsconfig.GeneveTunnel), "10.0.0.3", uint32(5001), uint16(200),
+		map[string]interface{}{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTunnel}).Return("node-c", nil)
+	mockOVSBridgeClient.EXPECT().SetPortTrunks("eth1", trunksExcluding(200)).Return(nil)
+	require.NoError(t, m.syncTunnels())
+}
+
+func TestOverlayTunnelManagerRestoreNetworks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m, mockOVSBridgeClient := newTestOverlayTunnelManager(t, ctrl)
+	tunnelExternalIDs := map[string]string{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTunnel}
+	ports := []ovsconfig.OVSPortData{
+		{UUID: "pod1", Name: "pod1-abcdef", VLANID: 200},
+		{UUID: "pod2", Name: "pod2-abcdef", VLANID: 200},
+		{UUID: "tunnel1", Name: "tunnel1", VLANID: 200, IFType: "vxlan", ExternalIDs: tunnelExternalIDs,
+			Options: map[string]string{"remote_ip": "10.0.0.2", "key": "5001"}},
+		// No Pod is connected to the overlay network of VNI 5002.
+		{UUID: "tunnel2", Name: "tunnel2", VLANID: 300, IFType: "geneve", ExternalIDs: tunnelExternalIDs,
+			Options: map[string]string{"remote_ip": "10.0.0.2", "key": "5002"}},
+	}
+	mockOVSBridgeClient.EXPECT().GetPortList().Return(ports, nil)
+	require.NoError(t, m.restoreNetworks())
+	assert.Equal(t, map[uint32]overlayNetwork{5001: {vni: 5001, tunnelType: ovsconfig.VXLANTunnel, vlanID: 200}}, m.networks)
+	assert.Equal(t, map[uint32]int{5001: 2}, m.networkRefs)
+	assert.Equal(t, map[string]uint32{"pod1": 5001, "pod2": 5001}, m.ports)
+}
@@ -17,6 +17,7 @@ package podwatch
 import (
 	"antrea.io/antrea/pkg/agent/cniserver/types"
 	"antrea.io/antrea/pkg/agent/secondarynetwork/cnipodcache"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
 )
 
 type RouteInfo struct {
@@ -24,8 +25,9 @@ type RouteInfo struct {
 }
 
 const (
-	sriovNetworkType cnipodcache.NetworkType = "sriov"
-	vlanNetworkType  cnipodcache.NetworkType = "vlan"
+	sriovNetworkType   cnipodcache.NetworkType = "sriov"
+	vlanNetworkType    cnipodcache.NetworkType = "vlan"
+	overlayNetworkType cnipodcache.NetworkType = "overlay"
 )
 
 type SecondaryNetworkConfig struct {
@@ -34,5 +36,12 @@ type SecondaryNetworkConfig struct {
 	// VLAN ID of the OVS port. Applicable only to the VLAN network type. If a
 	// non-zero VLAN is specified, it will override the VLAN in the Antrea
 	// IPAM IPPool subnet.
+	// For the overlay network type, it is required and specifies the VLAN ID
+	// which isolates the overlay network on the secondary OVS bridge.
 	VLAN int32 `json:"vlan,omitempty"`
+	// VNI of the overlay network. Applicable only to the overlay network type.
+	VNI int64 `json:"vni,omitempty"`
+	// Tunnel type of the overlay network, "geneve" or "vxlan". Defaults to
+	// "geneve". Applicable only to the overlay network type.
+	TunnelType ovsconfig.TunnelType `json:"tunnelType,omitempty"`
 }
@@ -29,6 +29,7 @@ import (
 	agenttypes "antrea.io/antrea/pkg/agent/types"
 	cpv1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	statsv1alpha1 "antrea.io/antrea/pkg/apis/stats/v1alpha1"
+	"antrea.io/antrea/pkg/features"
 	"antrea.io/antrea/pkg/querier"
 	"antrea.io/antrea/pkg/util/env"
 	"antrea.io/antrea/pkg/util/k8s"
@@ -66,17 +67,21 @@ type Collector struct {
 	// It is used to calculate the delta of the statistics that will be reported.
 	lastStatsCollection *statsCollection
 	multicastEnabled    bool
+	// nodeNetworkPolicyEnabled indicates whether the statistics of the NetworkPolicy rules applied to the Node,
+	// which are not realized with Openflow entries, should be collected.
+	nodeNetworkPolicyEnabled bool
 }
 
 func NewCollector(antreaClientProvider agent.AntreaClientProvider, ofClient openflow.Client, npQuerier querier.AgentNetworkPolicyInfoQuerier, mcQuerier *multicast.Controller) *Collector {
 	nodeName, _ := env.GetNodeName()
 	manager := &Collector{
-		nodeName:             nodeName,
-		antreaClientProvider: antreaClientProvider,
-		ofClient:             ofClient,
-		networkPolicyQuerier: npQuerier,
-		multicastQuerier:     mcQuerier,
-		multicastEnabled:     mcQuerier != nil,
+		nodeName:                 nodeName,
+		antreaClientProvider:     antreaClientProvider,
+		ofClient:                 ofClient,
+		networkPolicyQuerier:     npQuerier,
+		multicastQuerier:         mcQuerier,
+		multicastEnabled:         mcQuerier != nil,
+		nodeNetworkPolicyEnabled: features.DefaultFeatureGate.Enabled(features.NodeNetworkPolicy),
 	}
 	return manager
 }
@@ -113,6 +118,17 @@ func (m *Collector) Run(stopCh <-chan struct{}) {
 // It returns a map from NetworkPolicyReferences to their stats.
 func (m *Collector) collect() *statsCollection {
 	ruleStatsMap := m.ofClient.NetworkPolicyMetrics()
+	if m.nodeNetworkPolicyEnabled {
+		// The IDs of the rules applied to the Node are allocated from the same allocator as the Openflow rules, so
+		// they never conflict.
+		nodeRuleStatsMap := m.networkPolicyQuerier.NodeNetworkPolicyMetrics()
+		if ruleStatsMap == nil {
+			ruleStatsMap = make(map[uint32]*agenttypes.RuleMetric, len(nodeRuleStatsMap))
+		}
+		for id, ruleStats := range nodeRuleStatsMap {
+			ruleStatsMap[id] = ruleStats
+		}
+	}
 	npStatsMap := map[types.UID]*statsv1alpha1.TrafficStats{}
 	acnpStatsMap := map[types.UID]map[string]*statsv1alpha1.TrafficStats{}
 	annpStatsMap := map[types.UID]map[string]*statsv1alpha1.TrafficStats{}
@@ -175,6 +191,7 @@ func addUp(stats *statsv1alpha1.TrafficStats, inc *agenttypes.RuleMetric) {
 	stats.Sessions += int64(inc.Sessions)
 	stats.Packets += int64(inc.Packets)
 	stats.Bytes += int64(inc.Bytes)
+	stats.RateLimitedPackets += int64(inc.RateLimitedPackets)
 }
 
 func isIdenticalMulticastGroupMap(a, b map[string][]cpv1beta.PodReference) bool {
@@ -328,9 +345,10 @@ func calculateRuleDiff(curStatsMap, lastStatsMap map[types.UID]map[string]*stats
 					ruleTrafficStats := statsv1alpha1.RuleTrafficStats{
 						Name: name,
 						TrafficStats: statsv1alpha1.TrafficStats{
-							Bytes:    curRuleStats.Bytes - lastRuleStats.Bytes,
-							Sessions: curRuleStats.Sessions - lastRuleStats.Sessions,
-							Packets:  curRuleStats.Packets - lastRuleStats.Packets,
+							Bytes:              curRuleStats.Bytes - lastRuleStats.Bytes,
+							Sessions:           curRuleStats.Sessions - lastRuleStats.Sessions,
+							Packets:            curRuleStats.Packets - lastRuleStats.Packets,
+							RateLimitedPackets: curRuleStats.RateLimitedPackets - lastRuleStats.RateLimitedPackets,
 						},
 					}
 					stats = append(stats, ruleTrafficStats)
@@ -364,9 +382,10 @@ func calculateDiff(curStatsMap, lastStatsMap map[types.UID]*statsv1alpha1.Traffi
 			stats = curStats
 		} else {
 			stats = &statsv1alpha1.TrafficStats{
-				Packets:  curStats.Packets - lastStats.Packets,
-				Sessions: curStats.Sessions - lastStats.Sessions,
-				Bytes:    curStats.Bytes - lastStats.Bytes,
+				Packets:            curStats.Packets - lastStats.Packets,
+				Sessions:           curStats.Sessions - lastStats.Sessions,
+				Bytes:              curStats.Bytes - lastStats.Bytes,
+				RateLimitedPackets: curStats.RateLimitedPackets - lastStats.RateLimitedPackets,
 			}
 		}
 		// If the statistics of the NetworkPolicy remain unchanged, no need to report it.
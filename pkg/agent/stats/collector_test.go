@@ -61,6 +61,7 @@ func TestCollect(t *testing.T) {
 	tests := []struct {
 		name                    string
 		ruleStats               map[uint32]*agenttypes.RuleMetric
+		nodeRuleStats           map[uint32]*agenttypes.RuleMetric
 		ofIDToPolicyMap         map[uint32]*agenttypes.PolicyRule
 		expectedStatsCollection *statsCollection
 	}{
@@ -157,6 +158,51 @@ func TestCollect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "rules applied to Node",
+			ruleStats: map[uint32]*agenttypes.RuleMetric{
+				1: {
+					Bytes:    10,
+					Packets:  1,
+					Sessions: 1,
+				},
+			},
+			nodeRuleStats: map[uint32]*agenttypes.RuleMetric{
+				2: {
+					Bytes:    15,
+					Packets:  2,
+					Sessions: 2,
+				},
+				3: {
+					Bytes:    30,
+					Packets:  5,
+					Sessions: 5,
+				},
+			},
+			ofIDToPolicyMap: map[uint32]*agenttypes.PolicyRule{
+				1: {Name: "rule1", PolicyRef: &acnp1},
+				2: {Name: "rule1", PolicyRef: &acnp1},
+				3: {Name: "rule2", PolicyRef: &acnp1},
+			},
+			expectedStatsCollection: &statsCollection{
+				networkPolicyStats: map[types.UID]*statsv1alpha1.TrafficStats{},
+				antreaClusterNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{
+					acnp1.UID: {
+						"rule1": {
+							Bytes:    25,
+							Packets:  3,
+							Sessions: 3,
+						},
+						"rule2": {
+							Bytes:    30,
+							Packets:  5,
+							Sessions: 5,
+						},
+					},
+				},
+				antreaNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+			},
+		},
 		{
 			name: "unknown policy",
 			ruleStats: map[uint32]*agenttypes.RuleMetric{
@@ -194,11 +240,14 @@ func TestCollect(t *testing.T) {
 			npQuerier := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
 			mcQuerier := queriertest.NewMockAgentMulticastInfoQuerier(ctrl)
 			ofClient.EXPECT().NetworkPolicyMetrics().Return(tt.ruleStats).Times(1)
+			if tt.nodeRuleStats != nil {
+				npQuerier.EXPECT().NodeNetworkPolicyMetrics().Return(tt.nodeRuleStats).Times(1)
+			}
 			for ofID, policy := range tt.ofIDToPolicyMap {
 				npQuerier.EXPECT().GetRuleByFlowID(ofID).Return(policy)
 			}
 
-			m := &Collector{ofClient: ofClient, networkPolicyQuerier: npQuerier, multicastQuerier: mcQuerier}
+			m := &Collector{ofClient: ofClient, networkPolicyQuerier: npQuerier, multicastQuerier: mcQuerier, nodeNetworkPolicyEnabled: tt.nodeRuleStats != nil}
 			actualPolicyStats := m.collect()
 			assert.Equal(t, tt.expectedStatsCollection, actualPolicyStats)
 		})
@@ -24,7 +24,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/pkg/sftp"
 	"github.com/spf13/afero"
 	"golang.org/x/crypto/ssh"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +42,7 @@ import (
 	"antrea.io/antrea/pkg/querier"
 	"antrea.io/antrea/pkg/support"
 	"antrea.io/antrea/pkg/util/compress"
+	"antrea.io/antrea/pkg/util/ftp"
 	"antrea.io/antrea/pkg/util/k8s"
 )
 
@@ -78,7 +78,7 @@ type SupportBundleController struct {
 	npq                          querier.AgentNetworkPolicyInfoQuerier
 	v4Enabled                    bool
 	v6Enabled                    bool
-	sftpUploader                 uploader
+	sftpUploader                 ftp.UpLoader
 }
 
 func NewSupportBundleController(nodeName string,
@@ -101,7 +101,7 @@ func NewSupportBundleController(nodeName string,
 		npq:                   npq,
 		v4Enabled:             v4Enabled,
 		v6Enabled:             v6Enabled,
-		sftpUploader:          &sftpUploader{},
+		sftpUploader:          &ftp.SftpUploader{},
 	}
 	return c
 }
@@ -303,7 +303,7 @@ func (c *SupportBundleController) uploadSupportBundle(supportBundle *cpv1b2.Supp
 		return fmt.Errorf("failed to upload support bundle to file server while setting offset: %v", err)
 	}
 	// fileServer.URL should be like: 10.92.23.154:22/path or sftp://10.92.23.154:22/path
-	parsedURL, err := parseUploadUrl(supportBundle.FileServer.URL)
+	parsedURL, err := ftp.ParseUploadUrl(supportBundle.FileServer.URL)
 	if err != nil {
 		return fmt.Errorf("failed to upload support bundle while parsing upload URL: %v", err)
 	}
@@ -323,21 +323,7 @@ func (c *SupportBundleController) uploadSupportBundle(supportBundle *cpv1b2.Supp
 	return nil
 }
 
-func parseUploadUrl(uploadUrl string) (*url.URL, error) {
-	parsedURL, err := url.Parse(uploadUrl)
-	if err != nil {
-		parsedURL, err = url.Parse("sftp://" + uploadUrl)
-		if err != nil {
-			return nil, err
-		}
-	}
-	if parsedURL.Scheme != "sftp" {
-		return nil, fmt.Errorf("not sftp protocol")
-	}
-	return parsedURL, nil
-}
-
-func (c *SupportBundleController) uploadToFileServer(up uploader, bundleName string, parsedURL *url.URL, serverAuth *cpv1b2.BundleServerAuthConfiguration, tarGzFile io.Reader) error {
+func (c *SupportBundleController) uploadToFileServer(up ftp.UpLoader, bundleName string, parsedURL *url.URL, serverAuth *cpv1b2.BundleServerAuthConfiguration, tarGzFile io.Reader) error {
 	joinedPath := path.Join(parsedURL.Path, c.nodeName+"_"+bundleName+".tar.gz")
 	cfg := &ssh.ClientConfig{
 		User: serverAuth.BasicAuthentication.Username,
@@ -346,53 +332,16 @@ func (c *SupportBundleController) uploadToFileServer(up uploader, bundleName str
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 		Timeout:         time.Second,
 	}
-	return up.upload(parsedURL.Host, joinedPath, cfg, tarGzFile)
+	return up.Upload(parsedURL.Host, joinedPath, cfg, tarGzFile)
 }
 
-func (c *SupportBundleController) getUploaderByProtocol(protocol ProtocolType) (uploader, error) {
+func (c *SupportBundleController) getUploaderByProtocol(protocol ProtocolType) (ftp.UpLoader, error) {
 	if protocol == sftpProtocol {
 		return c.sftpUploader, nil
 	}
 	return nil, fmt.Errorf("unsupported protocol %s", protocol)
 }
 
-type uploader interface {
-	upload(addr string, path string, config *ssh.ClientConfig, tarGzFile io.Reader) error
-}
-
-type sftpUploader struct {
-}
-
-func (uploader *sftpUploader) upload(address string, path string, config *ssh.ClientConfig, tarGzFile io.Reader) error {
-	conn, err := ssh.Dial("tcp", address, config)
-	if err != nil {
-		return fmt.Errorf("error when connecting to fs server: %w", err)
-	}
-	sftpClient, err := sftp.NewClient(conn)
-	if err != nil {
-		return fmt.Errorf("error when setting up sftp client: %w", err)
-	}
-	defer func() {
-		if err := sftpClient.Close(); err != nil {
-			klog.ErrorS(err, "Error when closing sftp client")
-		}
-	}()
-	targetFile, err := sftpClient.Create(path)
-	if err != nil {
-		return fmt.Errorf("error when creating target file on remote: %v", err)
-	}
-	defer func() {
-		if err := targetFile.Close(); err != nil {
-			klog.ErrorS(err, "Error when closing target file on remote")
-		}
-	}()
-	if written, err := io.Copy(targetFile, tarGzFile); err != nil {
-		return fmt.Errorf("error when copying target file: %v, written: %d", err, written)
-	}
-	klog.InfoS("Successfully upload file to path", "filePath", path)
-	return nil
-}
-
 func (c *SupportBundleController) updateSupportBundleCollectionStatus(key string, complete bool, genErr error) error {
 	antreaClient, err := c.antreaClientGetter.GetAntreaClient()
 	if err != nil {
@@ -37,6 +37,7 @@ import (
 	"antrea.io/antrea/pkg/ovs/ovsctl"
 	"antrea.io/antrea/pkg/querier"
 	"antrea.io/antrea/pkg/support"
+	"antrea.io/antrea/pkg/util/ftp"
 )
 
 type fakeController struct {
@@ -69,7 +70,7 @@ func TestSupportBundleCollectionAdd(t *testing.T) {
 		supportBundleCollection *cpv1b2.SupportBundleCollection
 		expectedCompleted       bool
 		agentDumper             *mockAgentDumper
-		uploader                uploader
+		uploader                ftp.UpLoader
 	}{
 		{
 			name:                    "Add SupportBundleCollection",
@@ -191,7 +192,7 @@ func TestSupportBundleCollectionDelete(t *testing.T) {
 type testUploader struct {
 }
 
-func (uploader *testUploader) upload(address string, path string, config *ssh.ClientConfig, tarGzFile io.Reader) error {
+func (uploader *testUploader) Upload(address string, path string, config *ssh.ClientConfig, tarGzFile io.Reader) error {
 	klog.Info("Called test uploader")
 	return nil
 }
@@ -199,7 +200,7 @@ func (uploader *testUploader) upload(address string, path string, config *ssh.Cl
 type testFailedUploader struct {
 }
 
-func (uploader *testFailedUploader) upload(address string, path string, config *ssh.ClientConfig, tarGzFile io.Reader) error {
+func (uploader *testFailedUploader) Upload(address string, path string, config *ssh.ClientConfig, tarGzFile io.Reader) error {
 	klog.Info("Called test uploader for failed case")
 	return fmt.Errorf("uploader failed")
 }
@@ -24,12 +24,52 @@ const (
 	// NodeWireGuardPublicAnnotationKey represents the key of the Node's WireGuard public key in the Annotations of the Node.
 	NodeWireGuardPublicAnnotationKey string = "node.antrea.io/wireguard-public-key"
 
+	// NodeWireGuardNextPublicKeyAnnotationKey represents the key of the Node's next WireGuard public key in the
+	// Annotations of the Node. It is set when the Node is rotating its WireGuard key, and is removed after the rotation.
+	NodeWireGuardNextPublicKeyAnnotationKey string = "node.antrea.io/wireguard-next-public-key"
+
+	// NodeWireGuardNextKeyPortAnnotationKey represents the key of the port of the Node's WireGuard next-key interface in
+	// the Annotations of the Node. It is set along with the next WireGuard public key.
+	NodeWireGuardNextKeyPortAnnotationKey string = "node.antrea.io/wireguard-next-key-port"
+
+	// NodeWireGuardAcceptedPublicKeysAnnotationKey represents the key of the comma-separated next WireGuard public
+	// keys of other Nodes, which have been accepted by the Node, in the Annotations of the Node.
+	NodeWireGuardAcceptedPublicKeysAnnotationKey string = "node.antrea.io/wireguard-accepted-public-keys"
+
 	// NodeMaxEgressIPsAnnotationKey represents the key of maximum Egress IP number in the Annotations of the Node.
 	NodeMaxEgressIPsAnnotationKey string = "node.antrea.io/max-egress-ips"
 
+	// NodeBGPRouterIDAnnotationKey represents the key of the Node's BGP router ID in the Annotations of the Node.
+	NodeBGPRouterIDAnnotationKey string = "node.antrea.io/bgp-router-id"
+
 	// ServiceExternalIPPoolAnnotationKey is the key of the Service annotation that specifies the Service's desired external IP pool.
 	ServiceExternalIPPoolAnnotationKey string = "service.antrea.io/external-ip-pool"
 
 	// ServiceLoadBalancerModeAnnotationKey is the key of the Service annotation that specifies the Service's load balancer mode.
 	ServiceLoadBalancerModeAnnotationKey string = "service.antrea.io/load-balancer-mode"
+
+	// ServiceLoadBalancerAlgorithmAnnotationKey is the key of the Service annotation that specifies the algorithm used to
+	// select the Service's Endpoints.
+	ServiceLoadBalancerAlgorithmAnnotationKey string = "service.antrea.io/load-balancer-algorithm"
+
+	// ServiceEndpointHealthCheckAnnotationKey is the key of the Service annotation that enables active health checks of
+	// the Service's Endpoints. The value is the protocol of the health checks: "tcp" or "http".
+	ServiceEndpointHealthCheckAnnotationKey string = "service.antrea.io/endpoint-health-check"
+
+	// ServiceEndpointHealthCheckPathAnnotationKey is the key of the Service annotation that specifies the path of the
+	// HTTP health checks of the Service's Endpoints. It defaults to "/".
+	ServiceEndpointHealthCheckPathAnnotationKey string = "service.antrea.io/endpoint-health-check-path"
+
+	// ServiceTrafficSplitAnnotationKey is the key of the Service annotation that splits the Service's traffic across the
+	// Endpoints of other Services in the same Namespace. The value is a comma-separated list of "<Service>=<weight>".
+	ServiceTrafficSplitAnnotationKey string = "service.antrea.io/traffic-split"
+
+	// ServiceEndpointWeightsAnnotationKey is the key of the Service annotation that specifies the weights of the
+	// Service's Endpoints. The value is a comma-separated list of "<Endpoint IP>=<weight>". It's set by Antrea
+	// Multi-cluster Controller on multi-cluster Services to load balance the traffic across member clusters.
+	ServiceEndpointWeightsAnnotationKey string = "service.antrea.io/endpoint-weights"
+
+	// L7FlowExporterAnnotationKey is the key of the Pod annotation that enables the export of the L7 metadata of the
+	// Pod's connections. The value is the direction of the connections to export: "ingress", "egress" or "both".
+	L7FlowExporterAnnotationKey string = "visibility.antrea.io/l7-export"
 )
@@ -18,6 +18,11 @@ const (
 	// HostLocalSourceBit is the bit of the iptables fwmark space to mark locally generated packets.
 	// Value must be within the range [0, 31], and should not conflict with bits for other purposes.
 	HostLocalSourceBit = 31
+
+	// EndpointHealthCheckDSCP is the DSCP value of the Endpoint health checks sent by AntreaProxy. The health checks
+	// sent by peer Nodes with this value are not subject to the ingress NetworkPolicies of the Endpoints. It is taken
+	// from the DSCP pool for Experimental or Local Use, and is not one of the values used as Traceflow data plane tags.
+	EndpointHealthCheckDSCP = 0b111111
 )
 
 var (
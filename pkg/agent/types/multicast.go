@@ -34,6 +34,12 @@ var (
 	McastAllHosts   = net.ParseIP("224.0.0.1").To4()
 	IGMPv3Router    = net.ParseIP("224.0.0.22").To4()
 	_, McastCIDR, _ = net.ParseCIDR("224.0.0.0/4")
+
+	IPv6McastAllHosts   = net.ParseIP("ff02::1")
+	MLDv2Router         = net.ParseIP("ff02::16")
+	_, IPv6McastCIDR, _ = net.ParseCIDR("ff00::/8")
+	// The IPv6 link-local multicast groups are not snooped, and their packets are flooded.
+	_, IPv6LinkLocalMcastCIDR, _ = net.ParseCIDR("ff02::/16")
 )
 
 type McastNetworkPolicyController interface {
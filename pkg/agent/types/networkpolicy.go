@@ -91,6 +91,7 @@ type PolicyRule struct {
 	PolicyRef     *v1beta2.NetworkPolicyReference
 	EnableLogging bool
 	LogLabel      string
+	RateLimit     *v1beta2.RuleRateLimit
 }
 
 // IsAntreaNetworkPolicyRule returns if a PolicyRule is created for Antrea NetworkPolicy types.
@@ -143,12 +144,15 @@ func (bp ByPriority) Less(i, j int) bool { return bp[i].Less(bp[j]) }
 
 type RuleMetric struct {
 	Bytes, Packets, Sessions uint64
+	// RateLimitedPackets is the number of packets dropped by the rate limits of the rule.
+	RateLimitedPackets uint64
 }
 
 func (m *RuleMetric) Merge(m1 *RuleMetric) {
 	m.Bytes += m1.Bytes
 	m.Packets += m1.Packets
 	m.Sessions += m1.Sessions
+	m.RateLimitedPackets += m1.RateLimitedPackets
 }
 
 // A BitRange is a representation of a range of values from base value with a
@@ -121,6 +121,13 @@ func GenerateNodeTunnelInterfaceName(nodeName string) string {
 	return generateInterfaceName(GenerateNodeTunnelInterfaceKey(nodeName), nodeName, false)
 }
 
+// GenerateOverlayTunnelInterfaceName generates a unique interface name for the
+// tunnel to the Node of the secondary overlay network with the VNI, using the
+// Node's name and the VNI.
+func GenerateOverlayTunnelInterfaceName(nodeName string, vni uint32) string {
+	return generateInterfaceName(fmt.Sprintf("overlay/%d/%s", vni, nodeName), nodeName, false)
+}
+
 type LinkNotFound struct {
 	error
 }
@@ -30,6 +30,12 @@ type Interface interface {
 
 	RouteDel(route *netlink.Route) error
 
+	RuleAdd(rule *netlink.Rule) error
+
+	RuleDel(rule *netlink.Rule) error
+
+	RuleList(family int) ([]netlink.Rule, error)
+
 	AddrAdd(link netlink.Link, addr *netlink.Addr) error
 
 	AddrList(link netlink.Link, family int) ([]netlink.Addr, error)
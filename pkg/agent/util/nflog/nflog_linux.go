@@ -0,0 +1,140 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nflog receives the packets sent to userspace by the iptables NFLOG target.
+package nflog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/mdlayher/netlink"
+	"github.com/ti-mo/netfilter"
+	"k8s.io/klog/v2"
+)
+
+// Constants defined in uapi/linux/netfilter/nfnetlink_log.h.
+const (
+	nfulnlMsgPacket = 0
+	nfulnlMsgConfig = 1
+
+	nfulaPayload = 9
+	nfulaPrefix  = 10
+
+	nfulaCfgCmd  = 1
+	nfulaCfgMode = 2
+
+	nfulnlCfgCmdBind = 1
+	nfulnlCopyPacket = 2
+
+	// copyRange is the maximum number of bytes of a packet to be copied to userspace. Only the headers are needed.
+	copyRange = 128
+)
+
+// Packet is a packet logged by an NFLOG rule.
+type Packet struct {
+	// Prefix is the value of the "--nflog-prefix" option of the NFLOG rule.
+	Prefix string
+	// Payload is the packet starting from the network header.
+	Payload []byte
+}
+
+// PacketHandler is invoked for each received packet.
+type PacketHandler func(packet *Packet)
+
+// Run binds to the provided NFLOG group and invokes the handler for each received packet. It blocks until stopCh is
+// closed, or an error occurs when receiving packets.
+func Run(group uint16, handler PacketHandler, stopCh <-chan struct{}) error {
+	conn, err := netfilter.Dial(nil)
+	if err != nil {
+		return fmt.Errorf("error creating netfilter netlink connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := configure(conn, group, []netfilter.Attribute{{Type: nfulaCfgCmd, Data: []byte{nfulnlCfgCmdBind}}}); err != nil {
+		return fmt.Errorf("error binding to NFLOG group %d: %w", group, err)
+	}
+	modeData := make([]byte, 6)
+	binary.BigEndian.PutUint32(modeData, copyRange)
+	modeData[4] = nfulnlCopyPacket
+	if err := configure(conn, group, []netfilter.Attribute{{Type: nfulaCfgMode, Data: modeData}}); err != nil {
+		return fmt.Errorf("error setting copy mode of NFLOG group %d: %w", group, err)
+	}
+
+	go func() {
+		<-stopCh
+		// Closing the connection unblocks Receive.
+		conn.Close()
+	}()
+
+	for {
+		msgs, err := conn.Receive()
+		if err != nil {
+			select {
+			case <-stopCh:
+				return nil
+			default:
+				return fmt.Errorf("error receiving NFLOG messages: %w", err)
+			}
+		}
+		for _, msg := range msgs {
+			packet, err := parseMessage(msg)
+			if err != nil {
+				klog.ErrorS(err, "Failed to parse NFLOG message")
+				continue
+			}
+			if packet != nil {
+				handler(packet)
+			}
+		}
+	}
+}
+
+func configure(conn *netfilter.Conn, group uint16, attrs []netfilter.Attribute) error {
+	header := netfilter.Header{
+		SubsystemID: netfilter.NFSubsysULOG,
+		MessageType: nfulnlMsgConfig,
+		Family:      netfilter.ProtoUnspec,
+		ResourceID:  group,
+		Flags:       netlink.Request | netlink.Acknowledge,
+	}
+	msg, err := netfilter.MarshalNetlink(header, attrs)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Query(msg)
+	return err
+}
+
+// parseMessage returns the logged packet carried by the netlink message, or nil if the message is not a packet.
+func parseMessage(msg netlink.Message) (*Packet, error) {
+	header, attrs, err := netfilter.UnmarshalNetlink(msg)
+	if err != nil {
+		return nil, err
+	}
+	if header.SubsystemID != netfilter.NFSubsysULOG || header.MessageType != nfulnlMsgPacket {
+		return nil, nil
+	}
+	packet := &Packet{}
+	for _, attr := range attrs {
+		switch attr.Type {
+		case nfulaPrefix:
+			packet.Prefix = string(bytes.TrimRight(attr.Data, "\x00"))
+		case nfulaPayload:
+			packet.Payload = attr.Data
+		}
+	}
+	return packet, nil
+}
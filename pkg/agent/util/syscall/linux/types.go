@@ -17,7 +17,9 @@
 package syscall
 
 /*
+include <linux/in.h>
 include <linux/mroute.h>
+include <linux/mroute6.h>
 
 // copied from /uapi/linux/mroute.h
 // The original struct has union of vifc_lcl_addr and vifc_lcl_ifindex.
@@ -45,9 +47,34 @@ const (
 	MAXVIFS          = C.MAXVIFS
 )
 
+const (
+	MRT6MSG_NOCACHE = C.MRT6MSG_NOCACHE
+	MRT6_ADD_MIF    = C.MRT6_ADD_MIF
+	MRT6_ADD_MFC    = C.MRT6_ADD_MFC
+	MRT6_DEL_MFC    = C.MRT6_DEL_MFC
+	MRT6_INIT       = C.MRT6_INIT
+	MRT6_FLUSH      = C.MRT6_FLUSH
+	MRT6_FLUSH_MFC  = C.MRT6_FLUSH_MFC
+	MRT6_FLUSH_MIFS = C.MRT6_FLUSH_MIFS
+	MAXMIFS         = C.MAXMIFS
+)
+
+const (
+	MCAST_JOIN_SOURCE_GROUP  = C.MCAST_JOIN_SOURCE_GROUP
+	MCAST_LEAVE_SOURCE_GROUP = C.MCAST_LEAVE_SOURCE_GROUP
+)
+
 type Mfcctl C.struct_mfcctl
 type Vifctl C.struct_vifctl_with_ifindex
+type Mf6cctl C.struct_mf6cctl
+type Mif6ctl C.struct_mif6ctl
+type IPMreqSource C.struct_ip_mreq_source
+type GroupSourceReq C.struct_group_source_req
 
 const SizeofMfcctl = C.sizeof_struct_mfcctl
 const SizeofVifctl = C.sizeof_struct_vifctl_with_ifindex
 const SizeofIgmpmsg = C.sizeof_struct_igmpmsg
+const SizeofMf6cctl = C.sizeof_struct_mf6cctl
+const SizeofMif6ctl = C.sizeof_struct_mif6ctl
+const SizeofMrt6msg = C.sizeof_struct_mrt6msg
+const SizeofIPMreqSource = C.sizeof_struct_ip_mreq_source
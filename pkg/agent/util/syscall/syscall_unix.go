@@ -43,3 +43,19 @@ func SetsockoptMfcctl(fd, level, opt int, mfcctl *Mfcctl) error {
 func SetsockoptVifctl(fd, level, opt int, vifctl *Vifctl) error {
 	return setsockopt(fd, level, opt, unsafe.Pointer(vifctl), SizeofVifctl)
 }
+
+func SetsockoptMf6cctl(fd, level, opt int, mf6cctl *Mf6cctl) error {
+	return setsockopt(fd, level, opt, unsafe.Pointer(mf6cctl), SizeofMf6cctl)
+}
+
+func SetsockoptMif6ctl(fd, level, opt int, mif6ctl *Mif6ctl) error {
+	return setsockopt(fd, level, opt, unsafe.Pointer(mif6ctl), SizeofMif6ctl)
+}
+
+func SetsockoptIPMreqSource(fd, level, opt int, mreq *IPMreqSource) error {
+	return setsockopt(fd, level, opt, unsafe.Pointer(mreq), SizeofIPMreqSource)
+}
+
+func SetsockoptGroupSourceReq(fd, level, opt int, req *GroupSourceReq) error {
+	return setsockopt(fd, level, opt, unsafe.Pointer(req), unsafe.Sizeof(*req))
+}
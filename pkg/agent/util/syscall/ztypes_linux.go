@@ -28,6 +28,23 @@ const (
 	MAXVIFS          = 0x20
 )
 
+const (
+	MRT6MSG_NOCACHE = 0x1
+	MRT6_ADD_MIF    = 0xca
+	MRT6_ADD_MFC    = 0xcc
+	MRT6_DEL_MFC    = 0xcd
+	MRT6_INIT       = 0xc8
+	MRT6_FLUSH      = 0xd4
+	MRT6_FLUSH_MFC  = 0x1
+	MRT6_FLUSH_MIFS = 0x4
+	MAXMIFS         = 0x20
+)
+
+const (
+	MCAST_JOIN_SOURCE_GROUP  = 0x2e
+	MCAST_LEAVE_SOURCE_GROUP = 0x2f
+)
+
 type Mfcctl struct {
 	Origin   [4]byte /* in_addr */
 	Mcastgrp [4]byte /* in_addr */
@@ -48,6 +65,57 @@ type Vifctl struct {
 	Rmt_addr    [4]byte /* in_addr */
 }
 
+type Mf6cctl struct {
+	Origin   RawSockaddrInet6
+	Mcastgrp RawSockaddrInet6
+	Parent   uint16
+	Ifset    IfSet
+}
+
+type Mif6ctl struct {
+	Mifi       uint16
+	Flags      uint8
+	Threshold  uint8
+	Pifi       uint16
+	Rate_limit uint32
+}
+
+type RawSockaddrInet6 struct {
+	Family   uint16
+	Port     uint16
+	Flowinfo uint32
+	Addr     [16]byte /* in6_addr */
+	Scope_id uint32
+}
+
+type IfSet struct {
+	Bits [8]uint32
+}
+
+type IPMreqSource struct {
+	Multiaddr  [4]byte /* in_addr */
+	Interface  [4]byte /* in_addr */
+	Sourceaddr [4]byte /* in_addr */
+}
+
+type GroupSourceReq struct {
+	Interface uint32
+	Group     KernelSockaddrStorage
+	Source    KernelSockaddrStorage
+}
+
+// KernelSockaddrStorage is aligned like a pointer, as __kernel_sockaddr_storage, so that the padding of GroupSourceReq
+// is correct on both 32-bit and 64-bit platforms.
+type KernelSockaddrStorage struct {
+	_      [0]uintptr
+	Family uint16
+	Data   [126]byte
+}
+
 const SizeofMfcctl = 0x3c
 const SizeofVifctl = 0x10
 const SizeofIgmpmsg = 0x14
+const SizeofMf6cctl = 0x5c
+const SizeofMif6ctl = 0xc
+const SizeofMrt6msg = 0x28
+const SizeofIPMreqSource = 0xc
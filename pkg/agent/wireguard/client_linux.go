@@ -24,6 +24,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/vishvananda/netlink"
 	"golang.org/x/sys/unix"
@@ -33,9 +34,22 @@ import (
 
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/agent/util/sysctl"
 )
 
-const defaultWireGuardInterfaceName = "antrea-wg0"
+const (
+	defaultWireGuardInterfaceName = "antrea-wg0"
+	defaultNextKeyInterfaceName   = "antrea-wg1"
+	// defaultNextKeyRouteTable is the route table which sends the traffic to the peers via the next-key device. It is
+	// after the route tables used by Egress.
+	defaultNextKeyRouteTable = 400
+	// nextKeyRulePriority is the priority of the IP rules which make the traffic look up the next-key route tables. It
+	// must be lower than the priority of the IP rule of the main route table, which is 32766.
+	nextKeyRulePriority = 29000
+	// persistentKeepaliveInterval is the interval of the keepalive packets sent to the peers during a key rotation.
+	// The keepalive packets make the handshakes with the new key happen without waiting for any traffic.
+	persistentKeepaliveInterval = 5 * time.Second
+)
 
 var zeroKey = wgtypes.Key{}
 
@@ -52,16 +66,49 @@ var _ Interface = (*client)(nil)
 var (
 	linkAdd                    = netlink.LinkAdd
 	linkSetUp                  = netlink.LinkSetUp
+	linkByName                 = netlink.LinkByName
+	linkDel                    = netlink.LinkDel
+	routeReplace               = netlink.RouteReplace
+	routeDel                   = netlink.RouteDel
+	ruleAdd                    = netlink.RuleAdd
+	ruleDel                    = netlink.RuleDel
 	utilConfigureLinkAddresses = util.ConfigureLinkAddresses
+	ensureSysctlNetValue       = sysctl.EnsureSysctlNetValue
 )
 
+// client manages the WireGuard device of the Node and, during key rotations, the next-key device. The next-key device
+// keeps the next key usable in parallel with the current key until all peers have switched to the new key:
+//   - The Node rotating its key creates the next-key device with the next private key and the same peers as the
+//     WireGuard device. After rotating the key of the WireGuard device, it sends the traffic to the peers via the
+//     next-key device until a handshake with the new key is completed with them on the WireGuard device.
+//   - The peers of the Node create the next-key device with their current private keys, and add the next public key of
+//     the Node as a peer with the allowed IPs of the Node, so that they can decrypt the traffic encrypted with the new
+//     key before they update the public key of the Node on their WireGuard devices.
 type client struct {
-	wgClient                wgctrlClient
-	nodeName                string
-	privateKey              wgtypes.Key
+	wgClient wgctrlClient
+	nodeName string
+	// mutex protects the keys, the peers of the devices, and the states of the key rotation.
+	mutex      sync.Mutex
+	privateKey wgtypes.Key
+	// nextPrivateKey is the private key which will be used after the next key rotation. It is zero if there is no
+	// key rotation in progress, or the key has been rotated.
+	nextPrivateKey wgtypes.Key
+	// rotatedAt is the time at which the key was rotated. It is zero if there is no key rotation in progress, or the
+	// key has not been rotated yet.
+	rotatedAt time.Time
+	// steeredPeers are the peers whose traffic is sent via the next-key device after the key was rotated. The values
+	// are the allowed IPs of the peers.
+	steeredPeers map[wgtypes.Key][]net.IPNet
+	// nextKeyLinkIndex is the link index of the next-key device. It is 0 if the device doesn't exist.
+	nextKeyLinkIndex int
+	// linkAddresses are the IP addresses of the WireGuard device, which are also configured on the next-key device.
+	linkAddresses           []*net.IPNet
 	peerPublicKeyByNodeName *sync.Map
-	wireGuardConfig         *config.WireGuardConfig
-	gatewayConfig           *config.GatewayConfig
+	// peerNextPublicKeyByNodeName saves the next public keys of the peers which are rotating their keys. They are the
+	// peers of the next-key device.
+	peerNextPublicKeyByNodeName *sync.Map
+	wireGuardConfig             *config.WireGuardConfig
+	gatewayConfig               *config.GatewayConfig
 }
 
 func New(nodeConfig *config.NodeConfig, wireGuardConfig *config.WireGuardConfig) (Interface, error) {
@@ -72,17 +119,26 @@ func New(nodeConfig *config.NodeConfig, wireGuardConfig *config.WireGuardConfig)
 	if wireGuardConfig.Name == "" {
 		wireGuardConfig.Name = defaultWireGuardInterfaceName
 	}
+	if wireGuardConfig.NextKeyName == "" {
+		wireGuardConfig.NextKeyName = defaultNextKeyInterfaceName
+	}
+	if wireGuardConfig.NextKeyRouteTable == 0 {
+		wireGuardConfig.NextKeyRouteTable = defaultNextKeyRouteTable
+	}
 	c := &client{
-		wgClient:                wgClient,
-		nodeName:                nodeConfig.Name,
-		wireGuardConfig:         wireGuardConfig,
-		peerPublicKeyByNodeName: &sync.Map{},
-		gatewayConfig:           nodeConfig.GatewayConfig,
+		wgClient:                    wgClient,
+		nodeName:                    nodeConfig.Name,
+		wireGuardConfig:             wireGuardConfig,
+		peerPublicKeyByNodeName:     &sync.Map{},
+		peerNextPublicKeyByNodeName: &sync.Map{},
+		gatewayConfig:               nodeConfig.GatewayConfig,
 	}
 	return c, nil
 }
 
 func (client *client) Init(ipv4 net.IP, ipv6 net.IP) (string, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	link := &netlink.Wireguard{LinkAttrs: netlink.LinkAttrs{Name: client.wireGuardConfig.Name, MTU: client.wireGuardConfig.MTU}}
 	err := linkAdd(link)
 	// Ignore existing link as it may have already been created or managed by userspace process.
@@ -127,6 +183,17 @@ func (client *client) Init(ipv4 net.IP, ipv6 net.IP) (string, error) {
 	if err := utilConfigureLinkAddresses(link.Attrs().Index, gatewayIPs); err != nil {
 		return "", err
 	}
+	client.linkAddresses = gatewayIPs
+	// During a key rotation, the traffic to a peer can be sent via the next-key device while the traffic from the peer
+	// is received via the WireGuard device, which requires loose reverse path filtering.
+	if err := ensureSysctlNetValue(fmt.Sprintf("ipv4/conf/%s/rp_filter", client.wireGuardConfig.Name), 2); err != nil {
+		return "", err
+	}
+	// The next-key device is an in-memory state of the previous agent process, delete it and it will be created again
+	// when needed.
+	if err := client.deleteNextKeyDevice(); err != nil {
+		return "", err
+	}
 	client.wireGuardConfig.LinkIndex = link.Attrs().Index
 	wgDev, err := client.wgClient.Device(client.wireGuardConfig.Name)
 	if err != nil {
@@ -152,6 +219,8 @@ func (client *client) Init(ipv4 net.IP, ipv6 net.IP) (string, error) {
 }
 
 func (client *client) RemoveStalePeers(currentPeerPublickeys map[string]string) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	wgdev, err := client.wgClient.Device(client.wireGuardConfig.Name)
 	if err != nil {
 		return err
@@ -174,7 +243,7 @@ func (client *client) RemoveStalePeers(currentPeerPublickeys map[string]string)
 		}
 	}
 	for k := range restoredPeerPublicKeys {
-		if err := client.deletePeerByPublicKey(k); err != nil {
+		if err := client.deletePeerByPublicKey(client.wireGuardConfig.Name, k); err != nil {
 			klog.ErrorS(err, "Delete WireGuard peer error")
 			return err
 		}
@@ -183,6 +252,8 @@ func (client *client) RemoveStalePeers(currentPeerPublickeys map[string]string)
 }
 
 func (client *client) UpdatePeer(nodeName, publicKeyString string, peerNodeIP net.IP, podCIDRs []*net.IPNet) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	pubKey, err := wgtypes.ParseKey(publicKeyString)
 	if err != nil {
 		return err
@@ -197,57 +268,453 @@ func (client *client) UpdatePeer(nodeName, publicKeyString string, peerNodeIP ne
 		allowedIPs = append(allowedIPs, *cidr)
 	}
 
+	endpointUDP, err := client.resolvePeerEndpoint(peerNodeIP, client.wireGuardConfig.Port)
+	if err != nil {
+		return err
+	}
+	// The keepalive packets are only sent after the key was rotated, to complete the handshakes with the new key.
+	var keepalive time.Duration
+	if !client.rotatedAt.IsZero() {
+		keepalive = persistentKeepaliveInterval
+	}
+	var peerConfigs, nextKeyPeerConfigs []wgtypes.PeerConfig
+	var cachedPeerPubKey wgtypes.Key
 	if key, exist := client.peerPublicKeyByNodeName.Load(nodeName); exist {
-		cachedPeerPubKey := key.(wgtypes.Key)
+		cachedPeerPubKey = key.(wgtypes.Key)
 		if cachedPeerPubKey != pubKey {
 			klog.InfoS("WireGuard peer public key updated", "nodeName", nodeName, "publicKey", publicKeyString)
-			// delete old peer by public key.
-			if err := client.deletePeerByPublicKey(cachedPeerPubKey); err != nil {
+			// Delete old peer by public key in the same configuration, so that the allowed IPs are moved to the new
+			// peer at once.
+			peerConfigs = append(peerConfigs, wgtypes.PeerConfig{PublicKey: cachedPeerPubKey, Remove: true})
+			nextKeyPeerConfigs = append(nextKeyPeerConfigs, wgtypes.PeerConfig{PublicKey: cachedPeerPubKey, Remove: true})
+		}
+	}
+	peerConfigs = append(peerConfigs, wgtypes.PeerConfig{
+		PublicKey:                   pubKey,
+		Endpoint:                    endpointUDP,
+		PersistentKeepaliveInterval: &keepalive,
+		AllowedIPs:                  allowedIPs,
+		ReplaceAllowedIPs:           true,
+	})
+	cfg := wgtypes.Config{
+		ReplacePeers: false,
+		Peers:        peerConfigs,
+	}
+	if err := client.wgClient.ConfigureDevice(client.wireGuardConfig.Name, cfg); err != nil {
+		return err
+	}
+	client.peerPublicKeyByNodeName.Store(nodeName, pubKey)
+	// The next-key device of the Node rotating its key has the same peers as the WireGuard device.
+	if client.isRotatingKey() && client.nextKeyLinkIndex != 0 {
+		if cachedPeerPubKey != zeroKey && cachedPeerPubKey != pubKey {
+			if err := client.unsteerPeer(cachedPeerPubKey); err != nil {
 				return err
 			}
 		}
+		nextKeyPeerConfigs = append(nextKeyPeerConfigs, wgtypes.PeerConfig{
+			PublicKey:         pubKey,
+			AllowedIPs:        allowedIPs,
+			ReplaceAllowedIPs: true,
+		})
+		cfg := wgtypes.Config{
+			ReplacePeers: false,
+			Peers:        nextKeyPeerConfigs,
+		}
+		if err := client.wgClient.ConfigureDevice(client.wireGuardConfig.NextKeyName, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *client) UpdatePeerNextKey(nodeName, nextPublicKeyString string, nextKeyPort int, peerNodeIP net.IP, allowedIPs []*net.IPNet) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if nextPublicKeyString == "" {
+		// The peer has completed or aborted the key rotation.
+		return client.deletePeerNextKey(nodeName)
 	}
-	endpoint := net.JoinHostPort(peerNodeIP.String(), strconv.Itoa(client.wireGuardConfig.Port))
-	endpointUDP, err := net.ResolveUDPAddr("udp", endpoint)
+	nextPubKey, err := wgtypes.ParseKey(nextPublicKeyString)
 	if err != nil {
 		return err
 	}
-	client.peerPublicKeyByNodeName.Store(nodeName, pubKey)
-	peerConfig := wgtypes.PeerConfig{
-		PublicKey:         pubKey,
-		Endpoint:          endpointUDP,
-		AllowedIPs:        allowedIPs,
-		ReplaceAllowedIPs: true,
+	if peerNodeIP.To16() == nil {
+		return fmt.Errorf("peer Node IP is not valid: %s", peerNodeIP.String())
+	}
+	if nextKeyPort <= 0 {
+		return fmt.Errorf("port of the next-key device is not valid: %d", nextKeyPort)
+	}
+	// The next-key device can hold either the next key of the local Node, or the next keys of the peers.
+	if client.isRotatingKey() {
+		return fmt.Errorf("cannot accept the next public key of peer %s while rotating the local key", nodeName)
+	}
+	if err := client.ensureNextKeyDevice(client.privateKey, nil); err != nil {
+		return err
+	}
+	var peerConfigs []wgtypes.PeerConfig
+	if key, exist := client.peerNextPublicKeyByNodeName.Load(nodeName); exist && key.(wgtypes.Key) != nextPubKey {
+		peerConfigs = append(peerConfigs, wgtypes.PeerConfig{PublicKey: key.(wgtypes.Key), Remove: true})
+	}
+	endpointUDP, err := client.resolvePeerEndpoint(peerNodeIP, nextKeyPort)
+	if err != nil {
+		return err
+	}
+	var nextKeyAllowedIPs []net.IPNet
+	for _, cidr := range allowedIPs {
+		nextKeyAllowedIPs = append(nextKeyAllowedIPs, *cidr)
+	}
+	// The keepalive packets make the handshake with the next-key device of the peer happen in advance. The next-key
+	// device of the peer learns the endpoint of the local Node from it, and can send traffic to the local Node once the
+	// peer has rotated its key.
+	keepalive := persistentKeepaliveInterval
+	peerConfigs = append(peerConfigs, wgtypes.PeerConfig{
+		PublicKey:                   nextPubKey,
+		Endpoint:                    endpointUDP,
+		PersistentKeepaliveInterval: &keepalive,
+		AllowedIPs:                  nextKeyAllowedIPs,
+		ReplaceAllowedIPs:           true,
+	})
+	cfg := wgtypes.Config{
+		ReplacePeers: false,
+		Peers:        peerConfigs,
+	}
+	if err := client.wgClient.ConfigureDevice(client.wireGuardConfig.NextKeyName, cfg); err != nil {
+		return err
+	}
+	klog.InfoS("Added WireGuard peer with next public key", "nodeName", nodeName, "nextPublicKey", nextPublicKeyString)
+	client.peerNextPublicKeyByNodeName.Store(nodeName, nextPubKey)
+	return nil
+}
+
+func (client *client) deletePeerNextKey(nodeName string) error {
+	key, exist := client.peerNextPublicKeyByNodeName.Load(nodeName)
+	if !exist {
+		return nil
+	}
+	if err := client.deletePeerByPublicKey(client.wireGuardConfig.NextKeyName, key.(wgtypes.Key)); err != nil {
+		return err
+	}
+	client.peerNextPublicKeyByNodeName.Delete(nodeName)
+	if client.hasPeerNextKeys() {
+		return nil
+	}
+	return client.deleteNextKeyDevice()
+}
+
+func (client *client) hasPeerNextKeys() bool {
+	hasKeys := false
+	client.peerNextPublicKeyByNodeName.Range(func(_, _ interface{}) bool {
+		hasKeys = true
+		return false
+	})
+	return hasKeys
+}
+
+func (client *client) isRotatingKey() bool {
+	return client.nextPrivateKey != zeroKey || !client.rotatedAt.IsZero()
+}
+
+// ensureNextKeyDevice creates the next-key device with the provided private key and peers if it doesn't exist. It
+// listens on a random port.
+func (client *client) ensureNextKeyDevice(privateKey wgtypes.Key, peers []wgtypes.PeerConfig) (retErr error) {
+	if client.nextKeyLinkIndex != 0 {
+		return nil
+	}
+	name := client.wireGuardConfig.NextKeyName
+	link := &netlink.Wireguard{LinkAttrs: netlink.LinkAttrs{Name: name, MTU: client.wireGuardConfig.MTU}}
+	if err := linkAdd(link); err != nil && !errors.Is(err, unix.EEXIST) {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := client.deleteNextKeyDevice(); err != nil {
+				klog.ErrorS(err, "Failed to delete WireGuard next-key device", "device", name)
+			}
+		}
+	}()
+	if err := linkSetUp(link); err != nil {
+		return err
+	}
+	if err := utilConfigureLinkAddresses(link.Attrs().Index, client.linkAddresses); err != nil {
+		return err
+	}
+	if err := ensureSysctlNetValue(fmt.Sprintf("ipv4/conf/%s/rp_filter", name), 2); err != nil {
+		return err
+	}
+	cfg := wgtypes.Config{
+		PrivateKey:   &privateKey,
+		ReplacePeers: true,
+		Peers:        peers,
+	}
+	if err := client.wgClient.ConfigureDevice(name, cfg); err != nil {
+		return err
+	}
+	client.nextKeyLinkIndex = link.Attrs().Index
+	klog.InfoS("Created WireGuard next-key device", "device", name)
+	return nil
+}
+
+// deleteNextKeyDevice deletes the next-key device if it exists, and the IP rules of the next-key route table. The
+// routes in the next-key route table are deleted along with the device.
+func (client *client) deleteNextKeyDevice() error {
+	for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+		if err := ruleDel(client.nextKeyRule(family)); err != nil && !errors.Is(err, unix.ENOENT) && !errors.Is(err, unix.EAFNOSUPPORT) {
+			return fmt.Errorf("failed to delete IP rule for table %d: %w", client.wireGuardConfig.NextKeyRouteTable, err)
+		}
+	}
+	link, err := linkByName(client.wireGuardConfig.NextKeyName)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); !ok {
+			return err
+		}
+	} else if err := linkDel(link); err != nil {
+		return err
+	}
+	client.nextKeyLinkIndex = 0
+	return nil
+}
+
+// nextKeyRule returns the IP rule which makes the traffic look up the next-key route table, for example:
+//
+//	29000:	from all lookup 400
+func (client *client) nextKeyRule(family int) *netlink.Rule {
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Table = client.wireGuardConfig.NextKeyRouteTable
+	rule.Priority = nextKeyRulePriority
+	return rule
+}
+
+// nextKeyRoute returns the route in the next-key route table which sends the traffic to dst via the next-key device.
+func (client *client) nextKeyRoute(dst net.IPNet) *netlink.Route {
+	return &netlink.Route{
+		Dst:       &dst,
+		LinkIndex: client.nextKeyLinkIndex,
+		Scope:     netlink.SCOPE_LINK,
+		Table:     client.wireGuardConfig.NextKeyRouteTable,
+	}
+}
+
+// unsteerPeer makes the traffic to the peer sent via the WireGuard device again.
+func (client *client) unsteerPeer(pubKey wgtypes.Key) error {
+	for _, allowedIP := range client.steeredPeers[pubKey] {
+		if err := routeDel(client.nextKeyRoute(allowedIP)); err != nil && !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("failed to delete route for %s in table %d: %w", allowedIP.String(), client.wireGuardConfig.NextKeyRouteTable, err)
+		}
+	}
+	delete(client.steeredPeers, pubKey)
+	return nil
+}
+
+func (client *client) resolvePeerEndpoint(peerNodeIP net.IP, port int) (*net.UDPAddr, error) {
+	endpoint := net.JoinHostPort(peerNodeIP.String(), strconv.Itoa(port))
+	return net.ResolveUDPAddr("udp", endpoint)
+}
+
+func (client *client) GenerateNextKey() (string, int, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.hasPeerNextKeys() {
+		return "", 0, fmt.Errorf("the next-key device is in use by the peers rotating their keys")
+	}
+	if !client.rotatedAt.IsZero() {
+		return "", 0, fmt.Errorf("the previous key rotation has not finished")
+	}
+	if client.nextPrivateKey == zeroKey {
+		nextPkey, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return "", 0, err
+		}
+		client.nextPrivateKey = nextPkey
+	}
+	if client.nextKeyLinkIndex == 0 {
+		wgDev, err := client.wgClient.Device(client.wireGuardConfig.Name)
+		if err != nil {
+			return "", 0, err
+		}
+		// The peers of the next-key device have no endpoints, which are learned from the handshakes initiated by the
+		// peers once they have accepted the next key.
+		var peers []wgtypes.PeerConfig
+		for _, peer := range wgDev.Peers {
+			peers = append(peers, wgtypes.PeerConfig{
+				PublicKey:         peer.PublicKey,
+				AllowedIPs:        peer.AllowedIPs,
+				ReplaceAllowedIPs: true,
+			})
+		}
+		if err := client.ensureNextKeyDevice(client.nextPrivateKey, peers); err != nil {
+			return "", 0, err
+		}
+	}
+	nextKeyDev, err := client.wgClient.Device(client.wireGuardConfig.NextKeyName)
+	if err != nil {
+		return "", 0, err
+	}
+	return client.nextPrivateKey.PublicKey().String(), nextKeyDev.ListenPort, nil
+}
+
+func (client *client) RotateKey() (string, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.nextPrivateKey == zeroKey {
+		return "", fmt.Errorf("no next private key is generated")
+	}
+	nextKeyDev, err := client.wgClient.Device(client.wireGuardConfig.NextKeyName)
+	if err != nil {
+		return "", err
+	}
+	wgDev, err := client.wgClient.Device(client.wireGuardConfig.Name)
+	if err != nil {
+		return "", err
+	}
+	// The peers which have completed a handshake with the next-key device can only decrypt the traffic encrypted with
+	// the new key on their next-key devices, until they have updated the public key of the local Node on their
+	// WireGuard devices. Send the traffic to them via the next-key device until then.
+	steeredPeers := make(map[wgtypes.Key][]net.IPNet)
+	families := make(map[int]struct{})
+	for _, peer := range nextKeyDev.Peers {
+		if peer.LastHandshakeTime.IsZero() {
+			continue
+		}
+		for _, allowedIP := range peer.AllowedIPs {
+			if err := routeReplace(client.nextKeyRoute(allowedIP)); err != nil {
+				return "", fmt.Errorf("failed to add route for %s in table %d: %w", allowedIP.String(), client.wireGuardConfig.NextKeyRouteTable, err)
+			}
+			if allowedIP.IP.To4() != nil {
+				families[netlink.FAMILY_V4] = struct{}{}
+			} else {
+				families[netlink.FAMILY_V6] = struct{}{}
+			}
+		}
+		steeredPeers[peer.PublicKey] = peer.AllowedIPs
+	}
+	for family := range families {
+		if err := ruleAdd(client.nextKeyRule(family)); err != nil && !errors.Is(err, unix.EEXIST) {
+			return "", fmt.Errorf("failed to add IP rule for table %d: %w", client.wireGuardConfig.NextKeyRouteTable, err)
+		}
+	}
+	keepalive := persistentKeepaliveInterval
+	var peers []wgtypes.PeerConfig
+	for _, peer := range wgDev.Peers {
+		peers = append(peers, wgtypes.PeerConfig{
+			PublicKey:                   peer.PublicKey,
+			UpdateOnly:                  true,
+			PersistentKeepaliveInterval: &keepalive,
+		})
 	}
 	cfg := wgtypes.Config{
+		PrivateKey:   &client.nextPrivateKey,
 		ReplacePeers: false,
-		Peers:        []wgtypes.PeerConfig{peerConfig},
+		Peers:        peers,
 	}
-	return client.wgClient.ConfigureDevice(client.wireGuardConfig.Name, cfg)
+	if err := client.wgClient.ConfigureDevice(client.wireGuardConfig.Name, cfg); err != nil {
+		return "", err
+	}
+	client.privateKey = client.nextPrivateKey
+	client.nextPrivateKey = zeroKey
+	client.rotatedAt = time.Now()
+	client.steeredPeers = steeredPeers
+	klog.InfoS("Rotated WireGuard key", "steeredPeers", len(steeredPeers))
+	return client.privateKey.PublicKey().String(), nil
 }
 
-func (client *client) deletePeerByPublicKey(pubKey wgtypes.Key) error {
+func (client *client) FinishKeyRotation(force bool) (bool, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.nextPrivateKey != zeroKey {
+		// The key has not been rotated, discard the next key.
+		client.nextPrivateKey = zeroKey
+		return true, client.deleteNextKeyDevice()
+	}
+	if client.rotatedAt.IsZero() {
+		return true, nil
+	}
+	wgDev, err := client.wgClient.Device(client.wireGuardConfig.Name)
+	if err != nil {
+		return false, err
+	}
+	if !force {
+		lastHandshakeTimes := make(map[wgtypes.Key]time.Time)
+		for _, peer := range wgDev.Peers {
+			lastHandshakeTimes[peer.PublicKey] = peer.LastHandshakeTime
+		}
+		for pubKey := range client.steeredPeers {
+			// The peer has not completed a handshake with the new key on the WireGuard device.
+			if t, exists := lastHandshakeTimes[pubKey]; exists && !t.After(client.rotatedAt) {
+				continue
+			}
+			if err := client.unsteerPeer(pubKey); err != nil {
+				return false, err
+			}
+		}
+		if len(client.steeredPeers) > 0 {
+			return false, nil
+		}
+	}
+	if err := client.deleteNextKeyDevice(); err != nil {
+		return false, err
+	}
+	var keepalive time.Duration
+	var peers []wgtypes.PeerConfig
+	for _, peer := range wgDev.Peers {
+		peers = append(peers, wgtypes.PeerConfig{
+			PublicKey:                   peer.PublicKey,
+			UpdateOnly:                  true,
+			PersistentKeepaliveInterval: &keepalive,
+		})
+	}
+	cfg := wgtypes.Config{
+		ReplacePeers: false,
+		Peers:        peers,
+	}
+	if err := client.wgClient.ConfigureDevice(client.wireGuardConfig.Name, cfg); err != nil {
+		return false, err
+	}
+	client.rotatedAt = time.Time{}
+	client.steeredPeers = nil
+	return true, nil
+}
+
+func (client *client) deletePeerByPublicKey(name string, pubKey wgtypes.Key) error {
 	cfg := wgtypes.Config{Peers: []wgtypes.PeerConfig{
 		{PublicKey: pubKey, Remove: true},
 	}}
-	return client.wgClient.ConfigureDevice(client.wireGuardConfig.Name, cfg)
+	return client.wgClient.ConfigureDevice(name, cfg)
 }
 
 func (client *client) DeletePeer(nodeName string) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if err := client.deletePeerNextKey(nodeName); err != nil {
+		return err
+	}
 	key, exist := client.peerPublicKeyByNodeName.Load(nodeName)
 	if !exist {
 		return nil
 	}
 	peerPublicKey := key.(wgtypes.Key)
-	if err := client.deletePeerByPublicKey(peerPublicKey); err != nil {
+	if err := client.deletePeerByPublicKey(client.wireGuardConfig.Name, peerPublicKey); err != nil {
 		return err
 	}
+	if client.isRotatingKey() && client.nextKeyLinkIndex != 0 {
+		if err := client.unsteerPeer(peerPublicKey); err != nil {
+			return err
+		}
+		if err := client.deletePeerByPublicKey(client.wireGuardConfig.NextKeyName, peerPublicKey); err != nil {
+			return err
+		}
+	}
 	client.peerPublicKeyByNodeName.Delete(nodeName)
 	return nil
 }
 
 func (client *client) CleanUp() error {
-	if err := netlink.LinkDel(&netlink.Device{
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if err := client.deleteNextKeyDevice(); err != nil {
+		return err
+	}
+	if err := linkDel(&netlink.Device{
 		LinkAttrs: netlink.LinkAttrs{
 			Name: client.wireGuardConfig.Name, Index: client.wireGuardConfig.LinkIndex,
 		}}); err != nil && err.Error() != "no such device" {
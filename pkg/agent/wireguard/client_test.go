@@ -20,8 +20,10 @@ package wireguard
 import (
 	"errors"
 	"net"
+	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,8 +34,23 @@ import (
 	"antrea.io/antrea/pkg/agent/config"
 )
 
+const (
+	testWireGuardName     = "antrea-wg0"
+	testNextKeyName       = "antrea-wg1"
+	testNextKeyPort       = 54321
+	testNextKeyRouteTable = 400
+)
+
 type fakeWireGuardClient struct {
-	peers map[wgtypes.Key]wgtypes.Peer
+	// mutex protects the states of the devices which can be accessed by the test and the KeyRotator concurrently.
+	mutex sync.Mutex
+	// privateKey and peers are the states of the WireGuard device.
+	privateKey wgtypes.Key
+	peers      map[wgtypes.Key]wgtypes.Peer
+	// nextKeyPrivateKey and nextKeyPeers are the states of the next-key device. nextKeyPeers is nil if the device
+	// doesn't exist.
+	nextKeyPrivateKey wgtypes.Key
+	nextKeyPeers      map[wgtypes.Key]wgtypes.Peer
 }
 
 func (f *fakeWireGuardClient) Close() error {
@@ -45,39 +62,232 @@ func (f *fakeWireGuardClient) Devices() ([]*wgtypes.Device, error) {
 }
 
 func (f *fakeWireGuardClient) Device(name string) (*wgtypes.Device, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	privateKey, peers, listenPort := f.privateKey, f.peers, 0
+	if name == testNextKeyName {
+		if f.nextKeyPeers == nil {
+			return nil, os.ErrNotExist
+		}
+		privateKey, peers, listenPort = f.nextKeyPrivateKey, f.nextKeyPeers, testNextKeyPort
+	}
 	var res []wgtypes.Peer
-	for _, p := range f.peers {
+	for _, p := range peers {
 		res = append(res, p)
 	}
 	return &wgtypes.Device{
-		Peers: res,
+		Name:       name,
+		PrivateKey: privateKey,
+		ListenPort: listenPort,
+		Peers:      res,
 	}, nil
 }
 
 func (f *fakeWireGuardClient) ConfigureDevice(name string, cfg wgtypes.Config) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	privateKey, peers := &f.privateKey, f.peers
+	if name == testNextKeyName {
+		if f.nextKeyPeers == nil {
+			return os.ErrNotExist
+		}
+		privateKey, peers = &f.nextKeyPrivateKey, f.nextKeyPeers
+	}
+	if cfg.PrivateKey != nil {
+		*privateKey = *cfg.PrivateKey
+	}
+	if cfg.ReplacePeers {
+		for key := range peers {
+			delete(peers, key)
+		}
+	}
 	for _, c := range cfg.Peers {
 		if c.Remove {
-			delete(f.peers, c.PublicKey)
-		} else {
-			f.peers[c.PublicKey] = wgtypes.Peer{
-				PublicKey:  c.PublicKey,
-				Endpoint:   c.Endpoint,
-				AllowedIPs: c.AllowedIPs,
-			}
+			delete(peers, c.PublicKey)
+			continue
+		}
+		peer, exists := peers[c.PublicKey]
+		if !exists && c.UpdateOnly {
+			continue
+		}
+		peer.PublicKey = c.PublicKey
+		if c.Endpoint != nil {
+			peer.Endpoint = c.Endpoint
+		}
+		if c.PersistentKeepaliveInterval != nil {
+			peer.PersistentKeepaliveInterval = *c.PersistentKeepaliveInterval
+		}
+		if c.ReplaceAllowedIPs || !exists {
+			peer.AllowedIPs = c.AllowedIPs
 		}
+		peers[c.PublicKey] = peer
 	}
 	return nil
 }
 
+func (f *fakeWireGuardClient) setLastHandshakeTime(name string, publicKey wgtypes.Key, t time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	peers := f.peers
+	if name == testNextKeyName {
+		peers = f.nextKeyPeers
+	}
+	peer := peers[publicKey]
+	peer.LastHandshakeTime = t
+	peers[publicKey] = peer
+}
+
+func (f *fakeWireGuardClient) getNextKeyPeers() map[wgtypes.Key]wgtypes.Peer {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.nextKeyPeers
+}
+
+// fakeNetlink replaces the netlink functions used by the client, and saves the links, routes and IP rules in memory.
+// The next-key device of fakeWireGuardClient is created and deleted along with its link.
+type fakeNetlink struct {
+	mutex    sync.Mutex
+	wgClient *fakeWireGuardClient
+	links    map[string]int
+	// routes are keyed by their destinations.
+	routes map[string]netlink.Route
+	// rules are keyed by their families.
+	rules map[int]netlink.Rule
+}
+
+func installFakeNetlink(t *testing.T, wgClient *fakeWireGuardClient) *fakeNetlink {
+	f := &fakeNetlink{
+		wgClient: wgClient,
+		links:    map[string]int{},
+		routes:   map[string]netlink.Route{},
+		rules:    map[int]netlink.Rule{},
+	}
+	origLinkAdd, origLinkSetUp, origLinkByName, origLinkDel := linkAdd, linkSetUp, linkByName, linkDel
+	origRouteReplace, origRouteDel, origRuleAdd, origRuleDel := routeReplace, routeDel, ruleAdd, ruleDel
+	origConfigureLinkAddresses, origEnsureSysctlNetValue := utilConfigureLinkAddresses, ensureSysctlNetValue
+	t.Cleanup(func() {
+		linkAdd, linkSetUp, linkByName, linkDel = origLinkAdd, origLinkSetUp, origLinkByName, origLinkDel
+		routeReplace, routeDel, ruleAdd, ruleDel = origRouteReplace, origRouteDel, origRuleAdd, origRuleDel
+		utilConfigureLinkAddresses, ensureSysctlNetValue = origConfigureLinkAddresses, origEnsureSysctlNetValue
+	})
+	linkAdd = func(link netlink.Link) error {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		name := link.Attrs().Name
+		if _, exists := f.links[name]; exists {
+			return unix.EEXIST
+		}
+		link.Attrs().Index = len(f.links) + 10
+		f.links[name] = link.Attrs().Index
+		if name == testNextKeyName {
+			f.wgClient.mutex.Lock()
+			defer f.wgClient.mutex.Unlock()
+			f.wgClient.nextKeyPeers = map[wgtypes.Key]wgtypes.Peer{}
+		}
+		return nil
+	}
+	linkSetUp = func(link netlink.Link) error {
+		return nil
+	}
+	linkByName = func(name string) (netlink.Link, error) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		index, exists := f.links[name]
+		if !exists {
+			return nil, netlink.LinkNotFoundError{}
+		}
+		return &netlink.Wireguard{LinkAttrs: netlink.LinkAttrs{Name: name, Index: index}}, nil
+	}
+	linkDel = func(link netlink.Link) error {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		name := link.Attrs().Name
+		for dst, route := range f.routes {
+			if route.LinkIndex == f.links[name] {
+				delete(f.routes, dst)
+			}
+		}
+		delete(f.links, name)
+		if name == testNextKeyName {
+			f.wgClient.mutex.Lock()
+			defer f.wgClient.mutex.Unlock()
+			f.wgClient.nextKeyPeers = nil
+			f.wgClient.nextKeyPrivateKey = zeroKey
+		}
+		return nil
+	}
+	routeReplace = func(route *netlink.Route) error {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		f.routes[route.Dst.String()] = *route
+		return nil
+	}
+	routeDel = func(route *netlink.Route) error {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		if _, exists := f.routes[route.Dst.String()]; !exists {
+			return unix.ESRCH
+		}
+		delete(f.routes, route.Dst.String())
+		return nil
+	}
+	ruleAdd = func(rule *netlink.Rule) error {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		f.rules[rule.Family] = *rule
+		return nil
+	}
+	ruleDel = func(rule *netlink.Rule) error {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		if _, exists := f.rules[rule.Family]; !exists {
+			return unix.ENOENT
+		}
+		delete(f.rules, rule.Family)
+		return nil
+	}
+	utilConfigureLinkAddresses = func(idx int, ipNets []*net.IPNet) error {
+		return nil
+	}
+	ensureSysctlNetValue = func(sysctl string, value int) error {
+		return nil
+	}
+	return f
+}
+
+func (f *fakeNetlink) getRoutes() map[string]netlink.Route {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	routes := make(map[string]netlink.Route, len(f.routes))
+	for dst, route := range f.routes {
+		routes[dst] = route
+	}
+	return routes
+}
+
+func (f *fakeNetlink) getLinks() map[string]int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	links := make(map[string]int, len(f.links))
+	for name, index := range f.links {
+		links[name] = index
+	}
+	return links
+}
+
 func getFakeClient() *client {
 	return &client{
 		wgClient: &fakeWireGuardClient{},
 		nodeName: "fake-node-1",
 		wireGuardConfig: &config.WireGuardConfig{
-			MTU:  1420,
-			Port: 12345,
+			Name:              testWireGuardName,
+			MTU:               1420,
+			Port:              12345,
+			NextKeyName:       testNextKeyName,
+			NextKeyRouteTable: testNextKeyRouteTable,
 		},
-		peerPublicKeyByNodeName: &sync.Map{},
+		peerPublicKeyByNodeName:     &sync.Map{},
+		peerNextPublicKeyByNodeName: &sync.Map{},
 	}
 }
 
@@ -351,6 +561,261 @@ func Test_UpdatePeer(t *testing.T) {
 	}
 }
 
+func Test_UpdatePeerNextKey(t *testing.T) {
+	client := getFakeClient()
+	fc := &fakeWireGuardClient{
+		peers: map[wgtypes.Key]wgtypes.Peer{},
+	}
+	client.wgClient = fc
+	fn := installFakeNetlink(t, fc)
+	pk0, _ := wgtypes.GeneratePrivateKey()
+	client.privateKey = pk0
+	fc.privateKey = pk0
+	pk1, _ := wgtypes.GeneratePrivateKey()
+	pk2, _ := wgtypes.GeneratePrivateKey()
+	pk3, _ := wgtypes.GeneratePrivateKey()
+	ip1, _, _ := net.ParseCIDR("10.20.30.42/32")
+	_, podCIDR1, _ := net.ParseCIDR("172.16.1.0/24")
+	endpoint := &net.UDPAddr{IP: ip1, Port: client.wireGuardConfig.Port}
+	nextKeyEndpoint := &net.UDPAddr{IP: ip1, Port: 23456}
+
+	require.NoError(t, client.UpdatePeer("fake-node-2", pk1.PublicKey().String(), ip1, []*net.IPNet{podCIDR1}))
+	require.Error(t, client.UpdatePeerNextKey("fake-node-2", pk2.PublicKey().String(), 23456, nil, []*net.IPNet{podCIDR1}))
+	require.Error(t, client.UpdatePeerNextKey("fake-node-2", pk2.PublicKey().String(), 0, ip1, []*net.IPNet{podCIDR1}))
+	assert.Nil(t, fc.nextKeyPeers)
+
+	t.Run("add peer with next public key", func(t *testing.T) {
+		require.NoError(t, client.UpdatePeerNextKey("fake-node-2", pk2.PublicKey().String(), 23456, ip1, []*net.IPNet{podCIDR1}))
+		// The next-key device uses the current private key, and has the next public key of the peer with its allowed
+		// IPs.
+		assert.Equal(t, pk0, fc.nextKeyPrivateKey)
+		assert.Equal(t, map[wgtypes.Key]wgtypes.Peer{
+			pk2.PublicKey(): {PublicKey: pk2.PublicKey(), Endpoint: nextKeyEndpoint, PersistentKeepaliveInterval: persistentKeepaliveInterval, AllowedIPs: []net.IPNet{*podCIDR1}},
+		}, fc.nextKeyPeers)
+		assert.Equal(t, map[wgtypes.Key]wgtypes.Peer{
+			pk1.PublicKey(): {PublicKey: pk1.PublicKey(), Endpoint: endpoint, AllowedIPs: []net.IPNet{*podCIDR1}},
+		}, fc.peers)
+	})
+
+	t.Run("update next public key", func(t *testing.T) {
+		require.NoError(t, client.UpdatePeerNextKey("fake-node-2", pk3.PublicKey().String(), 23456, ip1, []*net.IPNet{podCIDR1}))
+		assert.Equal(t, map[wgtypes.Key]wgtypes.Peer{
+			pk3.PublicKey(): {PublicKey: pk3.PublicKey(), Endpoint: nextKeyEndpoint, PersistentKeepaliveInterval: persistentKeepaliveInterval, AllowedIPs: []net.IPNet{*podCIDR1}},
+		}, fc.nextKeyPeers)
+	})
+
+	t.Run("local Node cannot rotate key", func(t *testing.T) {
+		_, _, err := client.GenerateNextKey()
+		require.Error(t, err)
+		assert.Equal(t, pk0, fc.nextKeyPrivateKey)
+	})
+
+	t.Run("peer rotates its key", func(t *testing.T) {
+		require.NoError(t, client.UpdatePeer("fake-node-2", pk3.PublicKey().String(), ip1, []*net.IPNet{podCIDR1}))
+		assert.Equal(t, map[wgtypes.Key]wgtypes.Peer{
+			pk3.PublicKey(): {PublicKey: pk3.PublicKey(), Endpoint: endpoint, AllowedIPs: []net.IPNet{*podCIDR1}},
+		}, fc.peers)
+		// The next public key is kept until the peer withdraws it, as the peer may still send traffic via its next-key
+		// device.
+		assert.Len(t, fc.nextKeyPeers, 1)
+		require.NoError(t, client.UpdatePeerNextKey("fake-node-2", "", 0, ip1, []*net.IPNet{podCIDR1}))
+		assert.Nil(t, fc.nextKeyPeers)
+		assert.NotContains(t, fn.getLinks(), testNextKeyName)
+		assert.Len(t, fc.peers, 1)
+	})
+
+	t.Run("delete peer with next public key", func(t *testing.T) {
+		require.NoError(t, client.UpdatePeerNextKey("fake-node-2", pk1.PublicKey().String(), 23456, ip1, []*net.IPNet{podCIDR1}))
+		require.NoError(t, client.DeletePeer("fake-node-2"))
+		assert.Empty(t, fc.peers)
+		assert.Nil(t, fc.nextKeyPeers)
+		assert.NotContains(t, fn.getLinks(), testNextKeyName)
+	})
+
+	t.Run("rotating Node cannot accept next public key", func(t *testing.T) {
+		_, _, err := client.GenerateNextKey()
+		require.NoError(t, err)
+		require.Error(t, client.UpdatePeerNextKey("fake-node-2", pk1.PublicKey().String(), 23456, ip1, []*net.IPNet{podCIDR1}))
+	})
+}
+
+func Test_RotateKey(t *testing.T) {
+	client := getFakeClient()
+	fc := &fakeWireGuardClient{
+		peers: map[wgtypes.Key]wgtypes.Peer{},
+	}
+	client.wgClient = fc
+	fn := installFakeNetlink(t, fc)
+	pk1, _ := wgtypes.GeneratePrivateKey()
+	client.privateKey = pk1
+	fc.privateKey = pk1
+
+	_, err := client.RotateKey()
+	require.Error(t, err)
+
+	nextPublicKey, nextKeyPort, err := client.GenerateNextKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, pk1.PublicKey().String(), nextPublicKey)
+	assert.Equal(t, testNextKeyPort, nextKeyPort)
+	assert.Equal(t, nextPublicKey, fc.nextKeyPrivateKey.PublicKey().String())
+	// The same next key is returned until the key is rotated.
+	nextPublicKey2, _, err := client.GenerateNextKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextPublicKey, nextPublicKey2)
+	assert.Equal(t, pk1, fc.privateKey)
+
+	publicKey, err := client.RotateKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextPublicKey, publicKey)
+	assert.Equal(t, nextPublicKey, fc.privateKey.PublicKey().String())
+	assert.Equal(t, zeroKey, client.nextPrivateKey)
+	_, _, err = client.GenerateNextKey()
+	require.Error(t, err, "A new key rotation must not start before the previous one finishes")
+
+	finished, err := client.FinishKeyRotation(false)
+	require.NoError(t, err)
+	assert.True(t, finished)
+	assert.Nil(t, fc.nextKeyPeers)
+	assert.Empty(t, fn.getLinks())
+}
+
+func Test_AbortKeyRotation(t *testing.T) {
+	client := getFakeClient()
+	fc := &fakeWireGuardClient{
+		peers: map[wgtypes.Key]wgtypes.Peer{},
+	}
+	client.wgClient = fc
+	fn := installFakeNetlink(t, fc)
+	pk1, _ := wgtypes.GeneratePrivateKey()
+	client.privateKey = pk1
+	fc.privateKey = pk1
+
+	_, _, err := client.GenerateNextKey()
+	require.NoError(t, err)
+	finished, err := client.FinishKeyRotation(false)
+	require.NoError(t, err)
+	assert.True(t, finished)
+	assert.Equal(t, zeroKey, client.nextPrivateKey)
+	assert.Equal(t, pk1, fc.privateKey)
+	assert.Nil(t, fc.nextKeyPeers)
+	assert.Empty(t, fn.getLinks())
+}
+
+func Test_KeyRotationWithPeers(t *testing.T) {
+	client := getFakeClient()
+	fc := &fakeWireGuardClient{
+		peers: map[wgtypes.Key]wgtypes.Peer{},
+	}
+	client.wgClient = fc
+	fn := installFakeNetlink(t, fc)
+	pk1, _ := wgtypes.GeneratePrivateKey()
+	pk2, _ := wgtypes.GeneratePrivateKey()
+	pk3, _ := wgtypes.GeneratePrivateKey()
+	pk4, _ := wgtypes.GeneratePrivateKey()
+	client.privateKey = pk1
+	fc.privateKey = pk1
+	ip2, _, _ := net.ParseCIDR("10.20.30.42/32")
+	ip3, _, _ := net.ParseCIDR("10.20.30.43/32")
+	ip4, _, _ := net.ParseCIDR("10.20.30.44/32")
+	_, podCIDR2, _ := net.ParseCIDR("172.16.2.0/24")
+	_, podCIDR2v6, _ := net.ParseCIDR("fd12:ab:34:a002::/64")
+	_, podCIDR3, _ := net.ParseCIDR("172.16.3.0/24")
+	_, podCIDR4, _ := net.ParseCIDR("172.16.4.0/24")
+	endpoint2 := &net.UDPAddr{IP: ip2, Port: 23456}
+
+	require.NoError(t, client.UpdatePeer("fake-node-2", pk2.PublicKey().String(), ip2, []*net.IPNet{podCIDR2, podCIDR2v6}))
+	require.NoError(t, client.UpdatePeer("fake-node-3", pk3.PublicKey().String(), ip3, []*net.IPNet{podCIDR3}))
+
+	nextPublicKey, _, err := client.GenerateNextKey()
+	require.NoError(t, err)
+	// The next-key device has the same peers as the WireGuard device, without endpoints.
+	assert.Equal(t, map[wgtypes.Key]wgtypes.Peer{
+		pk2.PublicKey(): {PublicKey: pk2.PublicKey(), AllowedIPs: []net.IPNet{*podCIDR2, *podCIDR2v6}},
+		pk3.PublicKey(): {PublicKey: pk3.PublicKey(), AllowedIPs: []net.IPNet{*podCIDR3}},
+	}, fc.getNextKeyPeers())
+
+	// A Node joining during the key rotation is added to both devices.
+	require.NoError(t, client.UpdatePeer("fake-node-4", pk4.PublicKey().String(), ip4, []*net.IPNet{podCIDR4}))
+	assert.Equal(t, wgtypes.Peer{PublicKey: pk4.PublicKey(), AllowedIPs: []net.IPNet{*podCIDR4}}, fc.getNextKeyPeers()[pk4.PublicKey()])
+
+	// fake-node-2 has accepted the next public key, and has completed a handshake with the next-key device, from which
+	// the next-key device learns its endpoint.
+	fc.setLastHandshakeTime(testNextKeyName, pk2.PublicKey(), time.Now())
+	require.NoError(t, fc.ConfigureDevice(testNextKeyName, wgtypes.Config{Peers: []wgtypes.PeerConfig{{PublicKey: pk2.PublicKey(), UpdateOnly: true, Endpoint: endpoint2}}}))
+
+	publicKey, err := client.RotateKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextPublicKey, publicKey)
+	assert.Equal(t, nextPublicKey, fc.privateKey.PublicKey().String())
+	for _, peer := range fc.peers {
+		assert.Equal(t, persistentKeepaliveInterval, peer.PersistentKeepaliveInterval)
+	}
+	// Only the traffic to fake-node-2 is sent via the next-key device.
+	nextKeyLinkIndex := fn.getLinks()[testNextKeyName]
+	assert.Equal(t, map[string]netlink.Route{
+		podCIDR2.String():   {Dst: podCIDR2, LinkIndex: nextKeyLinkIndex, Scope: netlink.SCOPE_LINK, Table: testNextKeyRouteTable},
+		podCIDR2v6.String(): {Dst: podCIDR2v6, LinkIndex: nextKeyLinkIndex, Scope: netlink.SCOPE_LINK, Table: testNextKeyRouteTable},
+	}, fn.getRoutes())
+	assert.Len(t, fn.rules, 2)
+	for family, rule := range fn.rules {
+		assert.Equal(t, family, rule.Family)
+		assert.Equal(t, testNextKeyRouteTable, rule.Table)
+		assert.Equal(t, nextKeyRulePriority, rule.Priority)
+	}
+
+	// fake-node-2 has not completed a handshake with the new key on the WireGuard device.
+	fc.setLastHandshakeTime(testWireGuardName, pk2.PublicKey(), time.Now().Add(-time.Minute))
+	finished, err := client.FinishKeyRotation(false)
+	require.NoError(t, err)
+	assert.False(t, finished)
+	assert.Len(t, fn.getRoutes(), 2)
+
+	fc.setLastHandshakeTime(testWireGuardName, pk2.PublicKey(), time.Now().Add(time.Millisecond))
+	finished, err = client.FinishKeyRotation(false)
+	require.NoError(t, err)
+	assert.True(t, finished)
+	assert.Empty(t, fn.getRoutes())
+	assert.Empty(t, fn.rules)
+	assert.Nil(t, fc.getNextKeyPeers())
+	assert.Equal(t, map[string]int{}, fn.getLinks())
+	for _, peer := range fc.peers {
+		assert.Equal(t, time.Duration(0), peer.PersistentKeepaliveInterval)
+	}
+}
+
+func Test_ForceFinishKeyRotation(t *testing.T) {
+	client := getFakeClient()
+	fc := &fakeWireGuardClient{
+		peers: map[wgtypes.Key]wgtypes.Peer{},
+	}
+	client.wgClient = fc
+	fn := installFakeNetlink(t, fc)
+	pk1, _ := wgtypes.GeneratePrivateKey()
+	pk2, _ := wgtypes.GeneratePrivateKey()
+	client.privateKey = pk1
+	fc.privateKey = pk1
+	ip2, _, _ := net.ParseCIDR("10.20.30.42/32")
+	_, podCIDR2, _ := net.ParseCIDR("172.16.2.0/24")
+
+	require.NoError(t, client.UpdatePeer("fake-node-2", pk2.PublicKey().String(), ip2, []*net.IPNet{podCIDR2}))
+	_, _, err := client.GenerateNextKey()
+	require.NoError(t, err)
+	fc.setLastHandshakeTime(testNextKeyName, pk2.PublicKey(), time.Now())
+	_, err = client.RotateKey()
+	require.NoError(t, err)
+	assert.Len(t, fn.getRoutes(), 1)
+
+	finished, err := client.FinishKeyRotation(true)
+	require.NoError(t, err)
+	assert.True(t, finished)
+	assert.Empty(t, fn.getRoutes())
+	assert.Nil(t, fc.getNextKeyPeers())
+	assert.Equal(t, time.Duration(0), fc.peers[pk2.PublicKey()].PersistentKeepaliveInterval)
+	// A new key rotation can be started.
+	_, _, err = client.GenerateNextKey()
+	require.NoError(t, err)
+}
+
 func Test_DeletePeer(t *testing.T) {
 	client := getFakeClient()
 	fc := &fakeWireGuardClient{
@@ -430,6 +895,7 @@ func Test_Init(t *testing.T) {
 	}
 
 	client := getFakeClient()
+	installFakeNetlink(t, client.wgClient.(*fakeWireGuardClient))
 	client.gatewayConfig = &config.GatewayConfig{
 		IPv4: net.ParseIP("192.168.0.2"),
 		IPv6: net.ParseIP("fd12:ab:34:a001::11"),
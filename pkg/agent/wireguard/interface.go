@@ -27,6 +27,25 @@ type Interface interface {
 	// UpdatePeer updates WireGuard peer by provided public key and Node IPs.
 	// It will create a new WireGuard peer if the specified Node is not present in WireGuard device.
 	UpdatePeer(nodeName, publicKeyString string, peerNodeIP net.IP, allowedIPs []*net.IPNet) error
+	// UpdatePeerNextKey adds the next public key of the specified Node, which is published by the Node before it
+	// rotates its key, as a peer of the next-key device with the allowed IPs of the Node. The next-key device listens
+	// on a separate port and keeps using the current private key, so that the traffic encrypted with both the current
+	// key and the next key of the Node can be decrypted until the Node has completed the key rotation.
+	// nextKeyPort is the port of the next-key device of the Node. The peer is deleted if nextPublicKeyString is empty,
+	// and the next-key device is deleted once it has no peers.
+	UpdatePeerNextKey(nodeName, nextPublicKeyString string, nextKeyPort int, peerNodeIP net.IP, allowedIPs []*net.IPNet) error
+	// GenerateNextKey generates the private key which will be used after the next key rotation if it has not been
+	// generated, and creates the next-key device with it and the same peers as the WireGuard device. It returns the
+	// next public key and the port of the next-key device.
+	GenerateNextKey() (string, int, error)
+	// RotateKey configures the WireGuard device with the private key generated by GenerateNextKey, and returns the
+	// corresponding public key. The traffic to the peers which have completed a handshake with the next-key device is
+	// sent via the next-key device until they complete a handshake with the new key on the WireGuard device.
+	RotateKey() (string, error)
+	// FinishKeyRotation sends the traffic via the WireGuard device again for the peers which have completed a
+	// handshake with the new key, and deletes the next-key device once there is no traffic sent via it, or if force is
+	// true. It discards the next key if the key has not been rotated. It returns true if the key rotation is finished.
+	FinishKeyRotation(force bool) (bool, error)
 	// RemoveStalePeers reads existing WireGuard peers from the WireGuard device and deletes those which are not in currentPeerPublickeys.
 	// currentPeerPublickeys is a map of Node names to public keys. It is useful to clean up stale WireGuard peers upon antrea starting.
 	RemoveStalePeers(currentPeerPublickeys map[string]string) error
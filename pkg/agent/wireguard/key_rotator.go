@@ -0,0 +1,224 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// keyRotationCheckInterval is the interval at which KeyRotator checks if the peers have accepted the next key, and
+	// if the peers have switched to the new key.
+	keyRotationCheckInterval = 10 * time.Second
+	// keyRotationRetryInterval is the interval at which KeyRotator retries a failed key rotation.
+	keyRotationRetryInterval = 30 * time.Second
+	// maxKeyRotationWait is the maximum time to wait for the peers to accept the next key, and to wait for the peers to
+	// switch to the new key. The key rotation goes on after it, so that a peer which is down doesn't block the key
+	// rotation forever.
+	maxKeyRotationWait = 10 * time.Minute
+)
+
+// KeyPublisher publishes the WireGuard public keys of the local Node to its peers, and tracks the peers which have
+// accepted the next public key.
+type KeyPublisher interface {
+	// PublishNextKey publishes the public key which will be used after the key rotation, and the port of the next-key
+	// device.
+	PublishNextKey(nextPublicKey string, nextKeyPort int) error
+	// GetPeersPendingNextKey returns the names of the peers which have not accepted the next public key.
+	GetPeersPendingNextKey(nextPublicKey string) ([]string, error)
+	// GetPeersRotatingKey returns the names of the peers which have published their next public keys.
+	GetPeersRotatingKey() ([]string, error)
+	// PublishKey publishes the public key in use after the key rotation.
+	PublishKey(publicKey string) error
+	// WithdrawNextKey withdraws the next public key after the key rotation is finished or aborted.
+	WithdrawNextKey() error
+}
+
+// KeyRotator rotates the private key of a WireGuard device periodically without interrupting the traffic:
+//  1. The next public key is published to the peers, which accept it on their next-key devices.
+//  2. The key is rotated once all peers have accepted the next key, and the new public key is published. The traffic
+//     to the peers is sent via the next-key device until they have switched to the new key.
+//  3. The next public key is withdrawn once all peers have switched to the new key.
+//
+// Only one Node rotates its key at a time. The key rotation is postponed if any peer is rotating its key, and is
+// aborted if another peer starts rotating its key before the local key is rotated.
+type KeyRotator struct {
+	wgClient  Interface
+	publisher KeyPublisher
+	interval  time.Duration
+	clock     clock.Clock
+	// unpublishedKey is the public key in use which failed to be published after the key rotation.
+	unpublishedKey string
+	// nextKeyPublished is true if the next public key has been published and has not been withdrawn.
+	nextKeyPublished bool
+}
+
+func NewKeyRotator(wgClient Interface, publisher KeyPublisher, interval time.Duration) *KeyRotator {
+	return newKeyRotatorWithClock(wgClient, publisher, interval, clock.RealClock{})
+}
+
+func newKeyRotatorWithClock(wgClient Interface, publisher KeyPublisher, interval time.Duration, clock clock.Clock) *KeyRotator {
+	return &KeyRotator{
+		wgClient:  wgClient,
+		publisher: publisher,
+		interval:  interval,
+		clock:     clock,
+	}
+}
+
+// Run rotates the key at every interval until stopCh is closed.
+func (r *KeyRotator) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting WireGuard key rotator", "interval", r.interval)
+	defer klog.InfoS("Stopped WireGuard key rotator")
+
+	waitTime := r.interval
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-r.clock.After(waitTime):
+		}
+		if err := r.rotate(stopCh); err != nil {
+			// The retry interval is jittered, so that the Nodes which aborted their key rotations because of each
+			// other don't retry at the same time.
+			waitTime = wait.Jitter(keyRotationRetryInterval, 1.0)
+			klog.ErrorS(err, "Failed to rotate WireGuard key, will retry", "retryInterval", waitTime)
+			continue
+		}
+		waitTime = r.interval
+	}
+}
+
+func (r *KeyRotator) rotate(stopCh <-chan struct{}) error {
+	// The key has been rotated but the public key was not published successfully. Retry publishing it, otherwise the
+	// peers would keep using the old public key.
+	if r.unpublishedKey != "" {
+		if err := r.publishKey(r.unpublishedKey); err != nil {
+			return err
+		}
+		return r.finish(stopCh)
+	}
+	// The key rotation has been finished or aborted but the next public key was not withdrawn successfully.
+	if r.nextKeyPublished {
+		return r.finish(stopCh)
+	}
+
+	rotatingPeers, err := r.publisher.GetPeersRotatingKey()
+	if err != nil {
+		return fmt.Errorf("error when checking peers rotating WireGuard keys: %w", err)
+	}
+	if len(rotatingPeers) > 0 {
+		return fmt.Errorf("postponed WireGuard key rotation as peers %v are rotating their keys", rotatingPeers)
+	}
+	nextPublicKey, nextKeyPort, err := r.wgClient.GenerateNextKey()
+	if err != nil {
+		return fmt.Errorf("error when generating next WireGuard key: %w", err)
+	}
+	if err := r.publisher.PublishNextKey(nextPublicKey, nextKeyPort); err != nil {
+		return fmt.Errorf("error when publishing next WireGuard public key: %w", err)
+	}
+	r.nextKeyPublished = true
+	klog.InfoS("Published next WireGuard public key, waiting for peers to accept it", "nextPublicKey", nextPublicKey, "nextKeyPort", nextKeyPort)
+
+	deadline := r.clock.Now().Add(maxKeyRotationWait)
+	for {
+		rotatingPeers, err := r.publisher.GetPeersRotatingKey()
+		if err != nil {
+			return fmt.Errorf("error when checking peers rotating WireGuard keys: %w", err)
+		}
+		// The peers rotating their keys don't accept the next public key of the local Node.
+		if len(rotatingPeers) > 0 {
+			if err := r.finish(stopCh); err != nil {
+				return err
+			}
+			return fmt.Errorf("aborted WireGuard key rotation as peers %v are rotating their keys", rotatingPeers)
+		}
+		pendingPeers, err := r.publisher.GetPeersPendingNextKey(nextPublicKey)
+		if err != nil {
+			return fmt.Errorf("error when checking peers accepting next WireGuard public key: %w", err)
+		}
+		if len(pendingPeers) == 0 {
+			break
+		}
+		if !r.clock.Now().Before(deadline) {
+			klog.InfoS("Timed out waiting for peers to accept next WireGuard public key, rotating key anyway", "pendingPeers", pendingPeers)
+			break
+		}
+		klog.V(2).InfoS("Waiting for peers to accept next WireGuard public key", "pendingPeers", pendingPeers)
+		select {
+		case <-stopCh:
+			return nil
+		case <-r.clock.After(keyRotationCheckInterval):
+		}
+	}
+
+	publicKey, err := r.wgClient.RotateKey()
+	if err != nil {
+		if err := r.finish(stopCh); err != nil {
+			klog.ErrorS(err, "Failed to abort WireGuard key rotation")
+		}
+		return fmt.Errorf("error when rotating WireGuard key: %w", err)
+	}
+	r.unpublishedKey = publicKey
+	if err := r.publishKey(publicKey); err != nil {
+		return err
+	}
+	return r.finish(stopCh)
+}
+
+func (r *KeyRotator) publishKey(publicKey string) error {
+	if err := r.publisher.PublishKey(publicKey); err != nil {
+		return fmt.Errorf("error when publishing WireGuard public key: %w", err)
+	}
+	klog.InfoS("Rotated WireGuard key and published the new public key", "publicKey", publicKey)
+	r.unpublishedKey = ""
+	return nil
+}
+
+// finish waits for the peers to switch to the new key and finishes the key rotation, then withdraws the next public
+// key. If the key has not been rotated, the key rotation is aborted.
+func (r *KeyRotator) finish(stopCh <-chan struct{}) error {
+	deadline := r.clock.Now().Add(maxKeyRotationWait)
+	for {
+		force := !r.clock.Now().Before(deadline)
+		finished, err := r.wgClient.FinishKeyRotation(force)
+		if err != nil {
+			return fmt.Errorf("error when finishing WireGuard key rotation: %w", err)
+		}
+		if finished {
+			if force {
+				klog.InfoS("Timed out waiting for peers to switch to the new WireGuard key, finished key rotation anyway")
+			}
+			break
+		}
+		klog.V(2).InfoS("Waiting for peers to switch to the new WireGuard key")
+		select {
+		case <-stopCh:
+			return nil
+		case <-r.clock.After(keyRotationCheckInterval):
+		}
+	}
+	if err := r.publisher.WithdrawNextKey(); err != nil {
+		return fmt.Errorf("error when withdrawing next WireGuard public key: %w", err)
+	}
+	r.nextKeyPublished = false
+	klog.InfoS("Finished WireGuard key rotation")
+	return nil
+}
@@ -0,0 +1,260 @@
+//go:build linux
+// +build linux
+
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wireguard
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type fakeKeyPublisher struct {
+	mutex          sync.Mutex
+	publicKey      string
+	nextPublicKey  string
+	nextKeyPort    int
+	pendingPeers   []string
+	rotatingPeers  []string
+	publishKeyErrs []error
+}
+
+func (p *fakeKeyPublisher) PublishNextKey(nextPublicKey string, nextKeyPort int) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.nextPublicKey = nextPublicKey
+	p.nextKeyPort = nextKeyPort
+	return nil
+}
+
+func (p *fakeKeyPublisher) GetPeersPendingNextKey(nextPublicKey string) ([]string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.pendingPeers, nil
+}
+
+func (p *fakeKeyPublisher) GetPeersRotatingKey() ([]string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.rotatingPeers, nil
+}
+
+func (p *fakeKeyPublisher) PublishKey(publicKey string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.publishKeyErrs) > 0 {
+		err := p.publishKeyErrs[0]
+		p.publishKeyErrs = p.publishKeyErrs[1:]
+		return err
+	}
+	p.publicKey = publicKey
+	return nil
+}
+
+func (p *fakeKeyPublisher) WithdrawNextKey() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.nextPublicKey = ""
+	p.nextKeyPort = 0
+	return nil
+}
+
+func (p *fakeKeyPublisher) setPendingPeers(peers ...string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.pendingPeers = peers
+}
+
+func (p *fakeKeyPublisher) setRotatingPeers(peers ...string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.rotatingPeers = peers
+}
+
+func (p *fakeKeyPublisher) getKeys() (string, string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.publicKey, p.nextPublicKey
+}
+
+func newFakeKeyRotator(t *testing.T, publisher *fakeKeyPublisher) (*KeyRotator, *client, *fakeWireGuardClient, *fakeNetlink, *clocktesting.FakeClock) {
+	client := getFakeClient()
+	fc := &fakeWireGuardClient{
+		peers: map[wgtypes.Key]wgtypes.Peer{},
+	}
+	client.wgClient = fc
+	fn := installFakeNetlink(t, fc)
+	pk, _ := wgtypes.GeneratePrivateKey()
+	client.privateKey = pk
+	fc.privateKey = pk
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	return newKeyRotatorWithClock(client, publisher, time.Hour, fakeClock), client, fc, fn, fakeClock
+}
+
+func TestKeyRotatorWaitForPeers(t *testing.T) {
+	publisher := &fakeKeyPublisher{pendingPeers: []string{"node-2", "node-3"}}
+	rotator, _, fc, _, fakeClock := newFakeKeyRotator(t, publisher)
+	oldPrivateKey := fc.privateKey
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	errCh := make(chan error)
+	go func() {
+		errCh <- rotator.rotate(stopCh)
+	}()
+
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, 10*time.Millisecond)
+	// The key is not rotated until all peers have accepted the next key.
+	assert.Equal(t, oldPrivateKey, fc.privateKey)
+	_, nextPublicKey := publisher.getKeys()
+	assert.NotEmpty(t, nextPublicKey)
+	assert.Equal(t, testNextKeyPort, publisher.nextKeyPort)
+
+	publisher.setPendingPeers("node-3")
+	fakeClock.Step(keyRotationCheckInterval)
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, 10*time.Millisecond)
+	assert.Equal(t, oldPrivateKey, fc.privateKey)
+
+	publisher.setPendingPeers()
+	fakeClock.Step(keyRotationCheckInterval)
+	require.NoError(t, <-errCh)
+	assert.Equal(t, nextPublicKey, fc.privateKey.PublicKey().String())
+	assert.Equal(t, nextPublicKey, publisher.publicKey)
+	assert.Empty(t, publisher.nextPublicKey)
+	assert.False(t, rotator.nextKeyPublished)
+}
+
+func TestKeyRotatorTimeout(t *testing.T) {
+	publisher := &fakeKeyPublisher{pendingPeers: []string{"node-2"}}
+	rotator, _, fc, _, fakeClock := newFakeKeyRotator(t, publisher)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	errCh := make(chan error)
+	go func() {
+		errCh <- rotator.rotate(stopCh)
+	}()
+
+	for i := 0; i < int(maxKeyRotationWait/keyRotationCheckInterval); i++ {
+		require.Eventually(t, fakeClock.HasWaiters, time.Second, 10*time.Millisecond)
+		fakeClock.Step(keyRotationCheckInterval)
+	}
+	require.NoError(t, <-errCh)
+	assert.Equal(t, publisher.publicKey, fc.privateKey.PublicKey().String())
+}
+
+func TestKeyRotatorWaitForPeersToSwitch(t *testing.T) {
+	publisher := &fakeKeyPublisher{pendingPeers: []string{"node-2"}}
+	rotator, client, fc, fn, fakeClock := newFakeKeyRotator(t, publisher)
+	pk2, _ := wgtypes.GeneratePrivateKey()
+	ip2, _, _ := net.ParseCIDR("10.20.30.42/32")
+	_, podCIDR2, _ := net.ParseCIDR("172.16.2.0/24")
+	require.NoError(t, client.UpdatePeer("node-2", pk2.PublicKey().String(), ip2, []*net.IPNet{podCIDR2}))
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	errCh := make(chan error)
+	go func() {
+		errCh <- rotator.rotate(stopCh)
+	}()
+
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, 10*time.Millisecond)
+	// node-2 has accepted the next key and has completed a handshake with the next-key device.
+	fc.setLastHandshakeTime(testNextKeyName, pk2.PublicKey(), time.Now())
+	publisher.setPendingPeers()
+	fakeClock.Step(keyRotationCheckInterval)
+
+	// The new public key is published, and the next public key is kept until node-2 switches to the new key.
+	require.Eventually(t, func() bool {
+		publicKey, _ := publisher.getKeys()
+		return publicKey != "" && fakeClock.HasWaiters()
+	}, time.Second, 10*time.Millisecond)
+	publicKey, nextPublicKey := publisher.getKeys()
+	assert.Equal(t, publicKey, nextPublicKey)
+	assert.Contains(t, fn.getRoutes(), podCIDR2.String())
+
+	// node-2 has completed a handshake with the new key on the WireGuard device.
+	fc.setLastHandshakeTime(testWireGuardName, pk2.PublicKey(), time.Now().Add(time.Millisecond))
+	fakeClock.Step(keyRotationCheckInterval)
+	require.NoError(t, <-errCh)
+	publicKey, nextPublicKey = publisher.getKeys()
+	assert.Equal(t, fc.privateKey.PublicKey().String(), publicKey)
+	assert.Empty(t, nextPublicKey)
+	assert.Empty(t, fn.getRoutes())
+	assert.Nil(t, fc.getNextKeyPeers())
+}
+
+func TestKeyRotatorPostponed(t *testing.T) {
+	publisher := &fakeKeyPublisher{rotatingPeers: []string{"node-2"}}
+	rotator, _, fc, _, _ := newFakeKeyRotator(t, publisher)
+	oldPrivateKey := fc.privateKey
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	require.Error(t, rotator.rotate(stopCh))
+	assert.Equal(t, oldPrivateKey, fc.privateKey)
+	assert.Empty(t, publisher.nextPublicKey)
+	assert.Nil(t, fc.nextKeyPeers)
+}
+
+func TestKeyRotatorAbort(t *testing.T) {
+	publisher := &fakeKeyPublisher{pendingPeers: []string{"node-2"}}
+	rotator, client, fc, fn, fakeClock := newFakeKeyRotator(t, publisher)
+	oldPrivateKey := fc.privateKey
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	errCh := make(chan error)
+	go func() {
+		errCh <- rotator.rotate(stopCh)
+	}()
+
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, 10*time.Millisecond)
+	// node-2 starts rotating its key at the same time.
+	publisher.setRotatingPeers("node-2")
+	fakeClock.Step(keyRotationCheckInterval)
+	require.Error(t, <-errCh)
+	assert.Equal(t, oldPrivateKey, fc.privateKey)
+	assert.Empty(t, publisher.nextPublicKey)
+	assert.Equal(t, zeroKey, client.nextPrivateKey)
+	assert.Nil(t, fc.nextKeyPeers)
+	assert.Empty(t, fn.getLinks())
+	assert.False(t, rotator.nextKeyPublished)
+}
+
+func TestKeyRotatorRetryPublishKey(t *testing.T) {
+	publisher := &fakeKeyPublisher{publishKeyErrs: []error{errors.New("failed to patch")}}
+	rotator, _, fc, _, _ := newFakeKeyRotator(t, publisher)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	require.Error(t, rotator.rotate(stopCh))
+	publicKey := fc.privateKey.PublicKey().String()
+	assert.Equal(t, publicKey, rotator.unpublishedKey)
+	assert.Empty(t, publisher.publicKey)
+	assert.Equal(t, publicKey, publisher.nextPublicKey)
+
+	// The rotated key is published without rotating the key again.
+	require.NoError(t, rotator.rotate(stopCh))
+	assert.Equal(t, publicKey, fc.privateKey.PublicKey().String())
+	assert.Equal(t, publicKey, publisher.publicKey)
+	assert.Empty(t, publisher.nextPublicKey)
+	assert.Empty(t, rotator.unpublishedKey)
+}
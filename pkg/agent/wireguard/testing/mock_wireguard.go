@@ -81,6 +81,37 @@ func (mr *MockInterfaceMockRecorder) DeletePeer(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePeer", reflect.TypeOf((*MockInterface)(nil).DeletePeer), arg0)
 }
 
+// FinishKeyRotation mocks base method.
+func (m *MockInterface) FinishKeyRotation(arg0 bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishKeyRotation", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishKeyRotation indicates an expected call of FinishKeyRotation.
+func (mr *MockInterfaceMockRecorder) FinishKeyRotation(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishKeyRotation", reflect.TypeOf((*MockInterface)(nil).FinishKeyRotation), arg0)
+}
+
+// GenerateNextKey mocks base method.
+func (m *MockInterface) GenerateNextKey() (string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateNextKey")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateNextKey indicates an expected call of GenerateNextKey.
+func (mr *MockInterfaceMockRecorder) GenerateNextKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateNextKey", reflect.TypeOf((*MockInterface)(nil).GenerateNextKey))
+}
+
 // Init mocks base method.
 func (m *MockInterface) Init(arg0, arg1 net.IP) (string, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +141,21 @@ func (mr *MockInterfaceMockRecorder) RemoveStalePeers(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStalePeers", reflect.TypeOf((*MockInterface)(nil).RemoveStalePeers), arg0)
 }
 
+// RotateKey mocks base method.
+func (m *MockInterface) RotateKey() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKey")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKey indicates an expected call of RotateKey.
+func (mr *MockInterfaceMockRecorder) RotateKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKey", reflect.TypeOf((*MockInterface)(nil).RotateKey))
+}
+
 // UpdatePeer mocks base method.
 func (m *MockInterface) UpdatePeer(arg0, arg1 string, arg2 net.IP, arg3 []*net.IPNet) error {
 	m.ctrl.T.Helper()
@@ -123,3 +169,17 @@ func (mr *MockInterfaceMockRecorder) UpdatePeer(arg0, arg1, arg2, arg3 any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePeer", reflect.TypeOf((*MockInterface)(nil).UpdatePeer), arg0, arg1, arg2, arg3)
 }
+
+// UpdatePeerNextKey mocks base method.
+func (m *MockInterface) UpdatePeerNextKey(arg0, arg1 string, arg2 int, arg3 net.IP, arg4 []*net.IPNet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePeerNextKey", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePeerNextKey indicates an expected call of UpdatePeerNextKey.
+func (mr *MockInterfaceMockRecorder) UpdatePeerNextKey(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePeerNextKey", reflect.TypeOf((*MockInterface)(nil).UpdatePeerNextKey), arg0, arg1, arg2, arg3, arg4)
+}
@@ -19,6 +19,7 @@ import (
 	"reflect"
 
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/agentinfo"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/endpointhealth"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/memberlist"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/multicast"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/ovsflows"
@@ -603,6 +604,32 @@ $ antctl get podmulticaststats pod -n namespace`,
 			},
 			transformedResponse: reflect.TypeOf(memberlist.Response{}),
 		},
+		{
+			use:          "endpointhealth",
+			short:        "Print Endpoint health status",
+			long:         "Print the health status of the Endpoints of the Services which have Endpoint health checks enabled with the \"service.antrea.io/endpoint-health-check\" annotation, as determined by the health checks of AntreaProxy on the local Node",
+			commandGroup: get,
+			aliases:      []string{"eph"},
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/endpointhealth",
+					params: []flagInfo{
+						{
+							name:  "name",
+							usage: "Name of the Service; if present, Namespace must be provided as well.",
+							arg:   true,
+						},
+						{
+							name:      "namespace",
+							usage:     "Only get the Endpoint health status for Services in the provided Namespace.",
+							shorthand: "n",
+						},
+					},
+					outputType: multiple,
+				},
+			},
+			transformedResponse: reflect.TypeOf(endpointhealth.Response{}),
+		},
 	},
 	rawCommands: []rawCommand{
 		{
@@ -46,6 +46,7 @@ var (
 	Command *cobra.Command
 	option  = &struct {
 		source      string
+		sourceNode  string
 		destination string
 		outputType  string
 		flow        string
@@ -58,9 +59,11 @@ var (
 )
 
 var protocols = map[string]int32{
-	"icmp": 1,
-	"tcp":  6,
-	"udp":  17,
+	"icmp":   1,
+	"tcp":    6,
+	"udp":    17,
+	"icmpv6": 58,
+	"sctp":   132,
 }
 
 type CapturedPacket struct {
@@ -97,6 +100,14 @@ func init() {
   $antctl traceflow -S pod1 -D ns1/svc1 -f tcp,tcp_dst=80
   Start a Traceflow from pod1 to pod2, with a UDP packet to destination port 1234
   $antctl traceflow -S pod1 -D pod2 -f udp,udp_dst=1234
+  Start a Traceflow from pod1 to pod2, with an SCTP packet to destination port 36412
+  $antctl traceflow -S pod1 -D pod2 -f sctp,sctp_dst=36412
+  Start a Traceflow from pod1 to pod2 using IPv6, with an ICMPv6 echo request
+  $antctl traceflow -S pod1 -D pod2 -f icmpv6
+  Start a Traceflow from pod1 to a destination IPv6 address, with a TCP packet to destination port 80
+  $antctl traceflow -S pod1 -D fd00:10:96::a -f tcp,tcp_dst=80
+  Start a Traceflow from an external client IP to NodePort 30080 of Node node1, with the packet entering the cluster on node1
+  $antctl traceflow -S 10.10.10.100 --source-node node1 -D 172.18.0.2 -f tcp,tcp_dst=30080
   Start a Traceflow for live TCP traffic from pod1 to svc1, with 1 minute timeout
   $antctl traceflow -S pod1 -D svc1 -f tcp --live-traffic -t 1m
   Start a Traceflow to capture the first dropped TCP packet to pod1 on port 80, within 10 minutes
@@ -107,9 +118,10 @@ func init() {
 	}
 
 	Command.Flags().StringVarP(&option.source, "source", "S", "", "source of the Traceflow: Namespace/Pod, Pod, or IP")
+	Command.Flags().StringVarP(&option.sourceNode, "source-node", "", "", "Node on which the Traceflow packet from the source IP enters the cluster, for a non-live-traffic Traceflow from an external IP")
 	Command.Flags().StringVarP(&option.destination, "destination", "D", "", "destination of the Traceflow: Namespace/Pod, Pod, Namespace/Service, Service or IP")
 	Command.Flags().StringVarP(&option.outputType, "output", "o", "yaml", "output type: yaml (default), json")
-	Command.Flags().StringVarP(&option.flow, "flow", "f", "", "specify the flow (packet headers) of the Traceflow packet, including tcp_src, tcp_dst, tcp_flags, udp_src, udp_dst, ipv6")
+	Command.Flags().StringVarP(&option.flow, "flow", "f", "", "specify the flow (packet headers) of the Traceflow packet, including tcp_src, tcp_dst, tcp_flags, udp_src, udp_dst, sctp_src, sctp_dst, ipv6")
 	Command.Flags().BoolVarP(&option.liveTraffic, "live-traffic", "L", false, "if set, the Traceflow will trace the first packet of the matched live traffic flow")
 	Command.Flags().BoolVarP(&option.droppedOnly, "dropped-only", "", false, "if set, capture only the dropped packet in a live-traffic Traceflow")
 	Command.Flags().BoolVarP(&option.nowait, "nowait", "", false, "if set, command returns without retrieving results")
@@ -157,6 +169,11 @@ func runE(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if option.liveTraffic && option.sourceNode != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "--source-node works only with non-live-traffic Traceflow")
+		return nil
+	}
+
 	k8sclient, client, err := getClients(cmd)
 	if err != nil {
 		return err
@@ -217,11 +234,14 @@ func newTraceflow(client kubernetes.Interface) (*v1beta1.Traceflow, error) {
 	if option.source != "" {
 		srcIP := net.ParseIP(option.source)
 		if srcIP != nil {
-			if !option.liveTraffic {
-				return nil, errors.New("source must be a Pod if not a live-traffic Traceflow")
+			if !option.liveTraffic && option.sourceNode == "" {
+				return nil, errors.New("source must be a Pod if not a live-traffic Traceflow, unless --source-node is provided")
 			}
 			src.IP = srcIP.String()
+			src.Node = option.sourceNode
 			srcName = src.IP
+		} else if option.sourceNode != "" {
+			return nil, errors.New("source must be an IP address when --source-node is provided")
 		} else {
 			split := strings.Split(option.source, "/")
 			if len(split) == 1 {
@@ -275,7 +295,7 @@ func newTraceflow(client kubernetes.Interface) (*v1beta1.Traceflow, error) {
 		dstName = "any"
 	}
 
-	if src.Pod == "" && dst.Pod == "" {
+	if src.Node == "" && src.Pod == "" && dst.Pod == "" {
 		return nil, errors.New("one of source and destination must be a Pod")
 	}
 
@@ -283,6 +303,16 @@ func newTraceflow(client kubernetes.Interface) (*v1beta1.Traceflow, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse flow: %w", err)
 	}
+	// Use an IPv6 packet when the source or destination is an IPv6 address,
+	// even if "ipv6" is not specified in the flow.
+	if pkt.IPHeader != nil && (isIPv6Address(src.IP) || isIPv6Address(dst.IP)) {
+		pkt.IPv6Header = new(v1beta1.IPv6Header)
+		if pkt.IPHeader.Protocol != 0 {
+			protocol := pkt.IPHeader.Protocol
+			pkt.IPv6Header.NextHeader = &protocol
+		}
+		pkt.IPHeader = nil
+	}
 
 	name := getTFName(fmt.Sprintf("%s-to-%s", srcName, dstName))
 	tf := &v1beta1.Traceflow{
@@ -301,6 +331,11 @@ func newTraceflow(client kubernetes.Interface) (*v1beta1.Traceflow, error) {
 	return tf, nil
 }
 
+func isIPv6Address(ipStr string) bool {
+	ip := net.ParseIP(ipStr)
+	return ip != nil && ip.To4() == nil
+}
+
 func dstIsPod(client kubernetes.Interface, ns string, name string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -324,6 +359,9 @@ func parseFlow() (*v1beta1.Packet, error) {
 	var pkt v1beta1.Packet
 
 	_, isIPv6 := fields["ipv6"]
+	if _, ok := fields["icmpv6"]; ok {
+		isIPv6 = true
+	}
 	if isIPv6 {
 		pkt.IPv6Header = new(v1beta1.IPv6Header)
 	} else {
@@ -368,6 +406,16 @@ func parseFlow() (*v1beta1.Packet, error) {
 		}
 		pkt.TransportHeader.UDP.DstPort = int32(r)
 	}
+	if r, ok := fields["sctp_src"]; ok {
+		pkt.TransportHeader.SCTP = new(v1beta1.SCTPHeader)
+		pkt.TransportHeader.SCTP.SrcPort = int32(r)
+	}
+	if r, ok := fields["sctp_dst"]; ok {
+		if pkt.TransportHeader.SCTP == nil {
+			pkt.TransportHeader.SCTP = new(v1beta1.SCTPHeader)
+		}
+		pkt.TransportHeader.SCTP.DstPort = int32(r)
+	}
 
 	return &pkt, nil
 }
@@ -398,9 +446,15 @@ func output(tf *v1beta1.Traceflow, writer io.Writer) error {
 		Name:        tf.Name,
 		Phase:       tf.Status.Phase,
 		Reason:      tf.Status.Reason,
-		Source:      fmt.Sprintf("%s/%s", tf.Spec.Source.Namespace, tf.Spec.Source.Pod),
 		NodeResults: tf.Status.Results,
 	}
+	if len(tf.Spec.Source.Pod) != 0 {
+		r.Source = fmt.Sprintf("%s/%s", tf.Spec.Source.Namespace, tf.Spec.Source.Pod)
+	} else if len(tf.Spec.Source.Node) != 0 {
+		r.Source = fmt.Sprintf("%s (via Node %s)", tf.Spec.Source.IP, tf.Spec.Source.Node)
+	} else if len(tf.Spec.Source.IP) != 0 {
+		r.Source = tf.Spec.Source.IP
+	}
 	if len(tf.Spec.Destination.IP) > 0 {
 		r.Destination = tf.Spec.Destination.IP
 	} else if len(tf.Spec.Destination.Pod) != 0 {
@@ -415,7 +469,7 @@ func output(tf *v1beta1.Traceflow, writer io.Writer) error {
 		if pkt.IPv6Header == nil {
 			r.CapturedPacket.IPHeader = pkt.IPHeader
 		}
-		if pkt.TransportHeader.TCP != nil || pkt.TransportHeader.UDP != nil || pkt.TransportHeader.ICMP != nil {
+		if pkt.TransportHeader.TCP != nil || pkt.TransportHeader.UDP != nil || pkt.TransportHeader.SCTP != nil || pkt.TransportHeader.ICMP != nil {
 			r.CapturedPacket.TransportHeader = &pkt.TransportHeader
 		}
 	}
@@ -55,8 +55,10 @@ var (
 			Namespace: "default",
 		},
 	}
-	k8sClient   = k8sfake.NewSimpleClientset(&pod1, &pod2)
-	protocolTCP = int32(6)
+	k8sClient      = k8sfake.NewSimpleClientset(&pod1, &pod2)
+	protocolTCP    = int32(6)
+	protocolICMPv6 = int32(58)
+	protocolSCTP   = int32(132)
 )
 
 func modifyCommandAndOption(src, dst, outputType, liveTraffic, droppedOnly, nowait string) {
@@ -165,6 +167,39 @@ func TestParseFlow(t *testing.T) {
 				},
 			},
 		},
+		{
+			flow:    "sctp,sctp_src=2905,sctp_dst=36412",
+			success: true,
+			expected: &v1beta1.Traceflow{
+				Spec: v1beta1.TraceflowSpec{
+					Packet: v1beta1.Packet{
+						IPHeader: &v1beta1.IPHeader{
+							Protocol: 132,
+						},
+						TransportHeader: v1beta1.TransportHeader{
+							SCTP: &v1beta1.SCTPHeader{
+								SrcPort: 2905,
+								DstPort: 36412,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			flow:    "icmpv6",
+			success: true,
+			expected: &v1beta1.Traceflow{
+				Spec: v1beta1.TraceflowSpec{
+					Packet: v1beta1.Packet{
+						IPv6Header: &v1beta1.IPv6Header{
+							NextHeader: &protocolICMPv6,
+						},
+						TransportHeader: v1beta1.TransportHeader{},
+					},
+				},
+			},
+		},
 		{
 			flow:    "tcp,tcp_dst=4321,ipv6",
 			success: true,
@@ -523,6 +558,55 @@ func TestNewTraceflow(t *testing.T) {
 	}
 }
 
+func TestNewTraceflowIPv6Destination(t *testing.T) {
+	modifyCommandAndOption(srcPod, "fd00:10:96::a", "yaml", "", "", "")
+	defer modifyCommandAndOption("", "", "yaml", "", "", "")
+	oldFlow := option.flow
+	option.flow = "sctp,sctp_dst=36412"
+	defer func() { option.flow = oldFlow }()
+
+	tf, err := newTraceflow(k8sClient)
+	require.NoError(t, err)
+	// An IPv6 packet is used for the IPv6 destination, even though "ipv6" is
+	// not part of the flow.
+	assert.Equal(t, v1beta1.Packet{
+		IPv6Header: &v1beta1.IPv6Header{
+			NextHeader: &protocolSCTP,
+		},
+		TransportHeader: v1beta1.TransportHeader{
+			SCTP: &v1beta1.SCTPHeader{
+				DstPort: 36412,
+			},
+		},
+	}, tf.Spec.Packet)
+}
+
+func TestNewTraceflowExternalSource(t *testing.T) {
+	modifyCommandAndOption("10.10.10.100", ipv4, "yaml", "", "", "")
+	defer modifyCommandAndOption("", "", "yaml", "", "", "")
+	oldFlow := option.flow
+	option.flow = "tcp,tcp_dst=30080"
+	defer func() { option.flow = oldFlow }()
+
+	option.sourceNode = "node1"
+	defer func() { option.sourceNode = "" }()
+	tf, err := newTraceflow(k8sClient)
+	require.NoError(t, err)
+	assert.Equal(t, v1beta1.Source{IP: "10.10.10.100", Node: "node1"}, tf.Spec.Source)
+	assert.Equal(t, v1beta1.Destination{IP: ipv4}, tf.Spec.Destination)
+
+	// The source must be an IP when the source Node is provided.
+	modifyCommandAndOption(srcPod, ipv4, "yaml", "", "", "")
+	_, err = newTraceflow(k8sClient)
+	assert.EqualError(t, err, "source must be an IP address when --source-node is provided")
+
+	// The source Node is required for a non-live-traffic Traceflow from an IP.
+	option.sourceNode = ""
+	modifyCommandAndOption("10.10.10.100", ipv4, "yaml", "", "", "")
+	_, err = newTraceflow(k8sClient)
+	assert.EqualError(t, err, "source must be a Pod if not a live-traffic Traceflow, unless --source-node is provided")
+}
+
 func TestGetTFName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -277,6 +277,22 @@ type NetworkPolicyRule struct {
 	L7Protocols []L7Protocol
 	// LogLabel is a user-defined arbitrary string which will be printed in the NetworkPolicy logs.
 	LogLabel string
+	// EnforcementMode specifies how the rule is enforced. In Audit mode, the traffic
+	// matching the rule is only logged and counted. Empty for K8s NetworkPolicy.
+	EnforcementMode crdv1beta1.EnforcementMode
+	// RateLimit specifies the rate limits of the traffic matching the rule. It's set only
+	// when the action of the rule is RateLimit.
+	RateLimit *RuleRateLimit
+}
+
+// RuleRateLimit describes the rate limits of the traffic matching a rule.
+type RuleRateLimit struct {
+	// ConnectionsPerSecond is the maximum rate of new connections. 0 means no limit.
+	ConnectionsPerSecond int32
+	// BandwidthRate is the maximum bandwidth in Kbps. 0 means no limit.
+	BandwidthRate int32
+	// BandwidthBurst is the maximum burst size in Kb when the bandwidth exceeds the rate.
+	BandwidthBurst int32
 }
 
 // Protocol defines network protocols supported for things like container ports.
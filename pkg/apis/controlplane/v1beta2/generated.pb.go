@@ -1083,10 +1083,38 @@ func (m *PodReference) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PodReference proto.InternalMessageInfo
 
+func (m *RuleRateLimit) Reset()      { *m = RuleRateLimit{} }
+func (*RuleRateLimit) ProtoMessage() {}
+func (*RuleRateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fbaa7d016762fa1d, []int{37}
+}
+func (m *RuleRateLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RuleRateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *RuleRateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleRateLimit.Merge(m, src)
+}
+func (m *RuleRateLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *RuleRateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleRateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleRateLimit proto.InternalMessageInfo
+
 func (m *Service) Reset()      { *m = Service{} }
 func (*Service) ProtoMessage() {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{37}
+	return fileDescriptor_fbaa7d016762fa1d, []int{38}
 }
 func (m *Service) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1114,7 +1142,7 @@ var xxx_messageInfo_Service proto.InternalMessageInfo
 func (m *ServiceReference) Reset()      { *m = ServiceReference{} }
 func (*ServiceReference) ProtoMessage() {}
 func (*ServiceReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{38}
+	return fileDescriptor_fbaa7d016762fa1d, []int{39}
 }
 func (m *ServiceReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1142,7 +1170,7 @@ var xxx_messageInfo_ServiceReference proto.InternalMessageInfo
 func (m *SupportBundleCollection) Reset()      { *m = SupportBundleCollection{} }
 func (*SupportBundleCollection) ProtoMessage() {}
 func (*SupportBundleCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{39}
+	return fileDescriptor_fbaa7d016762fa1d, []int{40}
 }
 func (m *SupportBundleCollection) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1170,7 +1198,7 @@ var xxx_messageInfo_SupportBundleCollection proto.InternalMessageInfo
 func (m *SupportBundleCollectionList) Reset()      { *m = SupportBundleCollectionList{} }
 func (*SupportBundleCollectionList) ProtoMessage() {}
 func (*SupportBundleCollectionList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{40}
+	return fileDescriptor_fbaa7d016762fa1d, []int{41}
 }
 func (m *SupportBundleCollectionList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1198,7 +1226,7 @@ var xxx_messageInfo_SupportBundleCollectionList proto.InternalMessageInfo
 func (m *SupportBundleCollectionNodeStatus) Reset()      { *m = SupportBundleCollectionNodeStatus{} }
 func (*SupportBundleCollectionNodeStatus) ProtoMessage() {}
 func (*SupportBundleCollectionNodeStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{41}
+	return fileDescriptor_fbaa7d016762fa1d, []int{42}
 }
 func (m *SupportBundleCollectionNodeStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1226,7 +1254,7 @@ var xxx_messageInfo_SupportBundleCollectionNodeStatus proto.InternalMessageInfo
 func (m *SupportBundleCollectionStatus) Reset()      { *m = SupportBundleCollectionStatus{} }
 func (*SupportBundleCollectionStatus) ProtoMessage() {}
 func (*SupportBundleCollectionStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{42}
+	return fileDescriptor_fbaa7d016762fa1d, []int{43}
 }
 func (m *SupportBundleCollectionStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1254,7 +1282,7 @@ var xxx_messageInfo_SupportBundleCollectionStatus proto.InternalMessageInfo
 func (m *TLSProtocol) Reset()      { *m = TLSProtocol{} }
 func (*TLSProtocol) ProtoMessage() {}
 func (*TLSProtocol) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{43}
+	return fileDescriptor_fbaa7d016762fa1d, []int{44}
 }
 func (m *TLSProtocol) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1317,6 +1345,7 @@ func init() {
 	proto.RegisterType((*NodeStatsSummary)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.NodeStatsSummary")
 	proto.RegisterType((*PaginationGetOptions)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.PaginationGetOptions")
 	proto.RegisterType((*PodReference)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.PodReference")
+	proto.RegisterType((*RuleRateLimit)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.RuleRateLimit")
 	proto.RegisterType((*Service)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.Service")
 	proto.RegisterType((*ServiceReference)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.ServiceReference")
 	proto.RegisterType((*SupportBundleCollection)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.SupportBundleCollection")
@@ -1331,186 +1360,194 @@ func init() {
 }
 
 var fileDescriptor_fbaa7d016762fa1d = []byte{
-	// 2862 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x1b, 0x4b, 0x6f, 0x24, 0xc5,
-	0x79, 0xdb, 0x33, 0x63, 0x7b, 0xbe, 0x19, 0x7b, 0xbd, 0x65, 0x60, 0x1d, 0x60, 0xed, 0xa5, 0x49,
-	0xd0, 0x26, 0x22, 0x33, 0xd8, 0x01, 0x76, 0xc3, 0x4b, 0xf1, 0x78, 0xbd, 0x66, 0x82, 0x6d, 0x86,
-	0x9a, 0x01, 0x24, 0x08, 0x84, 0x76, 0x77, 0xcd, 0xb8, 0xb3, 0x3d, 0x5d, 0x4d, 0x75, 0x8d, 0x59,
-	0x73, 0x88, 0x88, 0x48, 0x0e, 0xe4, 0x45, 0x94, 0x4b, 0xc4, 0x2d, 0xb7, 0x5c, 0xf2, 0x0b, 0x38,
-	0x85, 0x43, 0x24, 0x8e, 0x44, 0x51, 0x14, 0x4e, 0x56, 0xd6, 0x51, 0x88, 0x38, 0xe4, 0x92, 0x5b,
-	0x36, 0x8a, 0x14, 0x55, 0x75, 0xf5, 0x73, 0x66, 0xd6, 0x3b, 0xb6, 0xd7, 0x91, 0xc2, 0x9e, 0x3c,
-	0xfd, 0xbd, 0xab, 0xea, 0xfb, 0xea, 0x7b, 0x74, 0x1b, 0x9e, 0x31, 0x5c, 0xce, 0x88, 0x51, 0xb1,
-	0x69, 0x35, 0xf8, 0x55, 0xf5, 0xae, 0x76, 0xaa, 0x86, 0x67, 0xfb, 0x55, 0x93, 0xba, 0x9c, 0x51,
-	0xc7, 0x73, 0x0c, 0x97, 0x54, 0x77, 0x16, 0xb7, 0x08, 0x37, 0x96, 0xaa, 0x1d, 0xe2, 0x12, 0x66,
-	0x70, 0x62, 0x55, 0x3c, 0x46, 0x39, 0x45, 0x95, 0x80, 0xeb, 0xbb, 0x36, 0x55, 0xbf, 0x2a, 0xde,
-	0xd5, 0x4e, 0x45, 0xf0, 0x57, 0x92, 0xfc, 0x15, 0xc5, 0x7f, 0xef, 0xa5, 0xe1, 0xfa, 0x7c, 0x6e,
-	0x70, 0xbf, 0xba, 0xb3, 0x68, 0x38, 0xde, 0xb6, 0xb1, 0x98, 0xd5, 0x74, 0xef, 0xd7, 0x3b, 0x36,
-	0xdf, 0xee, 0x6d, 0x55, 0x4c, 0xda, 0xad, 0x76, 0x68, 0x87, 0x56, 0x25, 0x78, 0xab, 0xd7, 0x96,
-	0x4f, 0xf2, 0x41, 0xfe, 0x52, 0xe4, 0x8f, 0x5e, 0xbd, 0xe4, 0x4b, 0x2d, 0x9e, 0xdd, 0x35, 0xcc,
-	0x6d, 0xdb, 0x25, 0x6c, 0x37, 0xd6, 0xd5, 0x25, 0xdc, 0xa8, 0xee, 0xf4, 0x2b, 0xa9, 0x0e, 0xe3,
-	0x62, 0x3d, 0x97, 0xdb, 0x5d, 0xd2, 0xc7, 0xf0, 0xf8, 0x41, 0x0c, 0xbe, 0xb9, 0x4d, 0xba, 0x46,
-	0x1f, 0xdf, 0x37, 0x86, 0xf1, 0xf5, 0xb8, 0xed, 0x54, 0x6d, 0x97, 0xfb, 0x9c, 0x65, 0x99, 0xf4,
-	0xbf, 0x6b, 0x50, 0x5e, 0xb6, 0x2c, 0x46, 0x7c, 0x7f, 0x8d, 0xd1, 0x9e, 0x87, 0xde, 0x80, 0x49,
-	0xb1, 0x12, 0xcb, 0xe0, 0xc6, 0x9c, 0x76, 0x5e, 0xbb, 0x50, 0x5a, 0x7a, 0xa4, 0x12, 0x08, 0xae,
-	0x24, 0x05, 0xc7, 0x67, 0x22, 0xa8, 0x2b, 0x3b, 0x8b, 0x95, 0xe7, 0xb7, 0xbe, 0x47, 0x4c, 0xbe,
-	0x41, 0xb8, 0x51, 0x43, 0x1f, 0xef, 0x2d, 0x9c, 0xda, 0xdf, 0x5b, 0x80, 0x18, 0x86, 0x23, 0xa9,
-	0xa8, 0x07, 0xe5, 0x8e, 0x50, 0xb5, 0x41, 0xba, 0x5b, 0x84, 0xf9, 0x73, 0x63, 0xe7, 0x73, 0x17,
-	0x4a, 0x4b, 0x4f, 0x8e, 0x78, 0xec, 0x95, 0xb5, 0x58, 0x46, 0xed, 0x2e, 0xa5, 0xb0, 0x9c, 0x00,
-	0xfa, 0x38, 0xa5, 0x46, 0xff, 0xa3, 0x06, 0x33, 0xc9, 0x95, 0xae, 0xdb, 0x3e, 0x47, 0xdf, 0xe9,
-	0x5b, 0x6d, 0xe5, 0xd6, 0x56, 0x2b, 0xb8, 0xe5, 0x5a, 0x67, 0x94, 0xea, 0xc9, 0x10, 0x92, 0x58,
-	0xa9, 0x01, 0x05, 0x9b, 0x93, 0x6e, 0xb8, 0xc4, 0xa7, 0x46, 0x5d, 0x62, 0xd2, 0xdc, 0xda, 0x94,
-	0x52, 0x54, 0xa8, 0x0b, 0x91, 0x38, 0x90, 0xac, 0xbf, 0x97, 0x83, 0x33, 0x49, 0xb2, 0x86, 0xc1,
-	0xcd, 0xed, 0x13, 0x38, 0xc4, 0x1f, 0x6a, 0x70, 0xc6, 0xb0, 0x2c, 0x62, 0xad, 0x1d, 0xf3, 0x51,
-	0x7e, 0x49, 0xa9, 0x15, 0xab, 0x4a, 0x4b, 0xc7, 0xfd, 0x0a, 0xd1, 0x8f, 0x35, 0x98, 0x65, 0xa4,
-	0x4b, 0x77, 0x32, 0x86, 0xe4, 0x8e, 0x6e, 0xc8, 0x7d, 0xca, 0x90, 0x59, 0xdc, 0x2f, 0x1f, 0x0f,
-	0x52, 0xaa, 0x7f, 0xae, 0xc1, 0xf4, 0xb2, 0xe7, 0x39, 0x36, 0xb1, 0x5a, 0xf4, 0xff, 0x3c, 0x9a,
-	0xfe, 0xac, 0x01, 0x4a, 0xaf, 0xf5, 0x04, 0xe2, 0xc9, 0x4c, 0xc7, 0xd3, 0x33, 0x23, 0xc7, 0x53,
-	0xca, 0xe0, 0x21, 0x11, 0xf5, 0x93, 0x1c, 0xcc, 0xa6, 0x09, 0xef, 0xc4, 0xd4, 0xff, 0x2e, 0xa6,
-	0xde, 0x84, 0xd9, 0x9a, 0xe1, 0xdb, 0xe6, 0x72, 0x8f, 0x6f, 0x13, 0x97, 0xdb, 0xa6, 0xc1, 0x6d,
-	0xea, 0xa2, 0x87, 0x61, 0xb2, 0xe7, 0x13, 0xe6, 0x1a, 0x5d, 0x22, 0x0f, 0xa3, 0x18, 0xfb, 0xcd,
-	0x8b, 0x0a, 0x8e, 0x23, 0x0a, 0x41, 0xed, 0x19, 0xbe, 0xff, 0x16, 0x65, 0xd6, 0xdc, 0x58, 0x9a,
-	0xba, 0xa1, 0xe0, 0x38, 0xa2, 0xd0, 0x17, 0x61, 0xa6, 0xd6, 0x73, 0x2d, 0x87, 0x5c, 0xb1, 0x1d,
-	0xd2, 0x24, 0x6c, 0x87, 0x30, 0x74, 0x0e, 0x72, 0x3d, 0xe6, 0x28, 0x55, 0x25, 0xc5, 0x9c, 0x7b,
-	0x11, 0xaf, 0x63, 0x01, 0xd7, 0xdf, 0x1f, 0x83, 0x73, 0x01, 0x4f, 0x40, 0x2f, 0xac, 0x5d, 0xa1,
-	0x6e, 0xdb, 0xee, 0xf4, 0x58, 0x60, 0xf0, 0x63, 0x50, 0xda, 0x22, 0x06, 0x23, 0xac, 0x45, 0xaf,
-	0x12, 0x57, 0x09, 0x9a, 0x55, 0x82, 0x4a, 0xb5, 0x18, 0x85, 0x93, 0x74, 0xe8, 0x21, 0x18, 0x37,
-	0x3c, 0xfb, 0x39, 0xb2, 0xab, 0xec, 0x9e, 0x56, 0x1c, 0xe3, 0xcb, 0x8d, 0xfa, 0x73, 0x64, 0x17,
-	0x2b, 0x2c, 0xfa, 0xb9, 0x06, 0xb3, 0x5b, 0xfd, 0xfb, 0x34, 0x97, 0x93, 0x8e, 0xba, 0x32, 0xea,
-	0x99, 0x0d, 0xd8, 0xf2, 0xda, 0x59, 0x71, 0x6e, 0x03, 0x10, 0x78, 0x90, 0x62, 0xfd, 0xd7, 0x79,
-	0x98, 0x5d, 0x71, 0x7a, 0x3e, 0x27, 0x2c, 0xe5, 0x5c, 0xb7, 0x3f, 0x8a, 0x7e, 0xa0, 0xc1, 0x0c,
-	0x69, 0xb7, 0x89, 0xc9, 0xed, 0x1d, 0x72, 0x8c, 0x41, 0x34, 0xa7, 0xb4, 0xce, 0xac, 0x66, 0x84,
-	0xe3, 0x3e, 0x75, 0xe8, 0xfb, 0x70, 0x26, 0x82, 0xd5, 0x1b, 0x35, 0x87, 0x9a, 0x57, 0xc3, 0xf8,
-	0x79, 0x6c, 0x54, 0x1b, 0xea, 0x8d, 0x4d, 0xc2, 0xe3, 0x10, 0x5e, 0xcd, 0xca, 0xc5, 0xfd, 0xaa,
-	0xd0, 0x25, 0x28, 0x73, 0xca, 0x0d, 0x27, 0x5c, 0x7e, 0xfe, 0xbc, 0x76, 0x21, 0x17, 0xdf, 0xeb,
-	0xad, 0x04, 0x0e, 0xa7, 0x28, 0xd1, 0x12, 0x80, 0x7c, 0x6e, 0x18, 0x1d, 0xe2, 0xcf, 0x15, 0x24,
-	0x5f, 0xb4, 0xdf, 0xad, 0x08, 0x83, 0x13, 0x54, 0xc2, 0xb7, 0xcd, 0x1e, 0x63, 0xc4, 0xe5, 0xe2,
-	0x79, 0x6e, 0x5c, 0x32, 0x45, 0xbe, 0xbd, 0x12, 0xa3, 0x70, 0x92, 0x4e, 0xff, 0x4c, 0x83, 0xd2,
-	0x6a, 0xe7, 0x0b, 0x50, 0x79, 0xfe, 0x41, 0x83, 0xd3, 0x89, 0x85, 0x9e, 0x40, 0xa2, 0x7c, 0x23,
-	0x9d, 0x28, 0x47, 0x5e, 0x61, 0xc2, 0xda, 0x21, 0x59, 0xf2, 0xa7, 0x39, 0x98, 0x49, 0x50, 0x05,
-	0x29, 0xd2, 0x02, 0xa0, 0xd1, 0xbe, 0x1f, 0xeb, 0x19, 0x26, 0xe4, 0xde, 0x49, 0x93, 0x03, 0xd2,
-	0xa4, 0x03, 0x67, 0x57, 0xaf, 0x71, 0x91, 0xee, 0x9c, 0x55, 0x97, 0xdb, 0x7c, 0x17, 0x93, 0x36,
-	0x61, 0xc4, 0x35, 0x09, 0x3a, 0x0f, 0xf9, 0x44, 0x9a, 0x2c, 0x2b, 0xd1, 0xf9, 0x4d, 0x91, 0x22,
-	0x25, 0x06, 0x55, 0xa1, 0x28, 0xfe, 0xfa, 0x9e, 0x61, 0x12, 0x95, 0x67, 0xce, 0x28, 0xb2, 0xe2,
-	0x66, 0x88, 0xc0, 0x31, 0x8d, 0xfe, 0x6f, 0x0d, 0x66, 0xa4, 0xfa, 0x65, 0xdf, 0xa7, 0xa6, 0x1d,
-	0x64, 0xb8, 0x13, 0xa9, 0x8f, 0x66, 0x0c, 0xa5, 0x51, 0xad, 0xff, 0xd0, 0xa5, 0xa0, 0xe4, 0x8e,
-	0x36, 0x29, 0xbe, 0xdc, 0x97, 0x33, 0xf2, 0x71, 0x9f, 0x46, 0xfd, 0xc3, 0x3c, 0x94, 0x12, 0x9b,
-	0x8f, 0x5e, 0x86, 0x9c, 0x47, 0x2d, 0xb5, 0xe6, 0x91, 0x7b, 0xbc, 0x06, 0xb5, 0x62, 0x33, 0x26,
-	0x44, 0x55, 0x21, 0x20, 0x42, 0x22, 0x7a, 0x57, 0x83, 0x69, 0x92, 0x3a, 0x55, 0x79, 0x3a, 0xa5,
-	0xa5, 0xb5, 0x91, 0xe3, 0x79, 0xb0, 0x6f, 0xd4, 0xd0, 0xfe, 0xde, 0xc2, 0x74, 0x06, 0x99, 0x51,
-	0x89, 0x1e, 0x82, 0x9c, 0xed, 0x05, 0x6e, 0x5d, 0xae, 0xdd, 0x25, 0x0c, 0xac, 0x37, 0xfc, 0x1b,
-	0x7b, 0x0b, 0xc5, 0x7a, 0x43, 0x35, 0x9e, 0x58, 0x10, 0xa0, 0xd7, 0xa1, 0xe0, 0x51, 0xc6, 0x45,
-	0xb2, 0x11, 0x27, 0xf2, 0xcd, 0x51, 0x6d, 0x14, 0x9e, 0x66, 0x35, 0x28, 0xe3, 0xf1, 0x8d, 0x23,
-	0x9e, 0x7c, 0x1c, 0x88, 0x45, 0xaf, 0x42, 0xde, 0xa5, 0x16, 0x91, 0x39, 0xa9, 0xb4, 0xf4, 0xf4,
-	0xc8, 0xe2, 0xa9, 0x45, 0xe2, 0x85, 0x4f, 0xca, 0x10, 0x10, 0x20, 0x29, 0x14, 0x75, 0x60, 0xc2,
-	0x27, 0x6c, 0xc7, 0x36, 0x83, 0xf4, 0x55, 0x5a, 0xfa, 0xd6, 0xa8, 0xf2, 0x9b, 0x01, 0x7b, 0xac,
-	0xa2, 0xb4, 0xbf, 0xb7, 0x30, 0x11, 0x42, 0x43, 0xe9, 0xfa, 0x07, 0x79, 0x28, 0xdf, 0x29, 0x88,
-	0xee, 0x14, 0x44, 0x83, 0x0a, 0xa2, 0xdf, 0x68, 0x30, 0x9d, 0xbe, 0x97, 0xd2, 0x57, 0xb3, 0x76,
-	0xf0, 0xd5, 0x1c, 0xdd, 0xf6, 0x63, 0x43, 0x6f, 0xfb, 0x1a, 0xe4, 0x7a, 0xb6, 0x25, 0x3b, 0x83,
-	0x62, 0xed, 0x91, 0xa8, 0x95, 0xa9, 0x5f, 0xbe, 0xb1, 0xb7, 0xf0, 0xc0, 0xb0, 0x11, 0x22, 0xdf,
-	0xf5, 0x88, 0x5f, 0x79, 0xb1, 0x7e, 0x19, 0x0b, 0x66, 0xfd, 0x6d, 0x28, 0x3f, 0xdb, 0x6a, 0x35,
-	0x1a, 0x8c, 0x72, 0x6a, 0x52, 0x47, 0x68, 0xdd, 0xa6, 0x3e, 0xcf, 0xe6, 0x98, 0x67, 0xa9, 0xcf,
-	0xb1, 0xc4, 0x88, 0x46, 0xa6, 0x4b, 0xf8, 0x36, 0xb5, 0xb2, 0x8d, 0xcc, 0x86, 0x84, 0x62, 0x85,
-	0x15, 0x92, 0x3c, 0x83, 0x6f, 0x2b, 0xf3, 0x22, 0x49, 0x0d, 0x83, 0x6f, 0x63, 0x89, 0xd1, 0x3f,
-	0xd2, 0x60, 0x42, 0x9d, 0x2b, 0x7a, 0x19, 0xf2, 0xa6, 0x6d, 0x31, 0x15, 0x38, 0x87, 0xf4, 0xa4,
-	0x48, 0xc9, 0x4a, 0xfd, 0x32, 0xc6, 0x52, 0x20, 0x7a, 0x0d, 0xc6, 0xc9, 0x35, 0x93, 0x78, 0x5c,
-	0x05, 0xca, 0x21, 0x45, 0x47, 0xab, 0x5c, 0x95, 0xc2, 0xb0, 0x12, 0xaa, 0xff, 0x47, 0x03, 0x54,
-	0x6f, 0x7c, 0x71, 0x53, 0x68, 0x1b, 0x0a, 0x72, 0x83, 0xd0, 0x83, 0x30, 0x66, 0x7b, 0x72, 0xad,
-	0xe5, 0xda, 0xec, 0xfe, 0xde, 0xc2, 0x58, 0xbd, 0x91, 0x4e, 0x2d, 0x63, 0xb6, 0x27, 0x82, 0xd7,
-	0x63, 0xa4, 0x6d, 0x5f, 0x5b, 0x27, 0x6e, 0x87, 0x6f, 0x4b, 0x0f, 0x2a, 0xc4, 0xc1, 0xdb, 0x48,
-	0xe0, 0x70, 0x8a, 0x52, 0xff, 0x9d, 0x06, 0xb0, 0x7e, 0x31, 0x72, 0xd3, 0x57, 0x20, 0xbf, 0xcd,
-	0xb9, 0x77, 0xd8, 0x54, 0x9d, 0x74, 0xf9, 0x20, 0x83, 0x08, 0x08, 0x96, 0x32, 0xd1, 0x4b, 0x90,
-	0xe3, 0x8e, 0xaf, 0x12, 0xf4, 0xc8, 0xf7, 0x6a, 0x6b, 0xbd, 0x19, 0x49, 0x96, 0x45, 0x40, 0x6b,
-	0xbd, 0x89, 0x85, 0x40, 0xfd, 0x03, 0x0d, 0xd0, 0x46, 0xcf, 0x11, 0x8d, 0xb5, 0xcf, 0xe5, 0xf6,
-	0xd5, 0xdd, 0x36, 0x45, 0x0f, 0x42, 0x41, 0xf6, 0x18, 0x2a, 0xe4, 0xa2, 0x94, 0x19, 0x1c, 0x4a,
-	0x80, 0x43, 0xaf, 0x43, 0xde, 0xa3, 0xd6, 0xa1, 0xc7, 0xcf, 0xa9, 0xd2, 0x24, 0x0e, 0x45, 0x6a,
-	0xf9, 0x58, 0xca, 0xd5, 0xdf, 0xd3, 0xa0, 0x18, 0xa5, 0x6d, 0x19, 0xba, 0x94, 0x05, 0x97, 0x40,
-	0x21, 0x49, 0xcf, 0x38, 0x96, 0x98, 0x5b, 0xb8, 0x9c, 0x2e, 0xc1, 0xa4, 0xa7, 0xf6, 0x41, 0x5d,
-	0x01, 0xf7, 0x47, 0x93, 0x1a, 0x05, 0xbf, 0x91, 0xf8, 0x8d, 0x23, 0x6a, 0xfd, 0x1f, 0x39, 0x98,
-	0xda, 0x24, 0xfc, 0x2d, 0xca, 0xae, 0x36, 0xa8, 0x63, 0x9b, 0xbb, 0x27, 0x10, 0x4d, 0x6d, 0x28,
-	0xb0, 0x9e, 0x43, 0xc2, 0x0d, 0x5e, 0x1e, 0xb9, 0x26, 0x49, 0xda, 0x8b, 0x7b, 0x0e, 0x89, 0xcf,
-	0x51, 0x3c, 0xf9, 0x38, 0x10, 0x8f, 0x9e, 0x86, 0xd3, 0x46, 0x6a, 0x22, 0x19, 0xe4, 0xce, 0xa2,
-	0x0c, 0x99, 0xd3, 0xe9, 0x61, 0xa5, 0x8f, 0xb3, 0xb4, 0xe8, 0x82, 0xd8, 0x54, 0x9b, 0x32, 0x51,
-	0x40, 0x8a, 0xc4, 0xa7, 0xd5, 0xca, 0xc1, 0x86, 0x06, 0x30, 0x1c, 0x61, 0xd1, 0xa3, 0x50, 0xe6,
-	0x36, 0x61, 0x21, 0x46, 0xa6, 0xbb, 0x42, 0x6d, 0x46, 0xa6, 0xc8, 0x04, 0x1c, 0xa7, 0xa8, 0x90,
-	0x0f, 0x45, 0x9f, 0xf6, 0x98, 0x2c, 0x7e, 0x54, 0xf9, 0x74, 0xe5, 0x68, 0x5b, 0x11, 0x79, 0xdd,
-	0x94, 0x48, 0x74, 0xcd, 0x50, 0x38, 0x8e, 0xf5, 0xe8, 0x7f, 0xd2, 0xe0, 0x4c, 0x8a, 0xe9, 0x04,
-	0xda, 0xea, 0xad, 0x74, 0x5b, 0xfd, 0xf4, 0x91, 0x16, 0x39, 0xa4, 0xb1, 0xfe, 0xa7, 0x06, 0x67,
-	0x53, 0x74, 0xa2, 0x4a, 0x6d, 0x72, 0x83, 0xf7, 0x7c, 0xf4, 0x30, 0x4c, 0x8a, 0x6a, 0x75, 0x73,
-	0xc0, 0xd4, 0x73, 0x53, 0xc1, 0x71, 0x44, 0x21, 0x2a, 0x17, 0xf5, 0xb6, 0xcf, 0xa6, 0xae, 0x8c,
-	0xb9, 0x44, 0xe5, 0xb2, 0x16, 0x61, 0x70, 0x82, 0x0a, 0x7d, 0x1b, 0x10, 0x23, 0x86, 0x63, 0xbf,
-	0x2d, 0x1f, 0xaf, 0x18, 0xb6, 0xd3, 0x63, 0x44, 0x46, 0xe2, 0x64, 0xed, 0x5e, 0xc5, 0x8b, 0x70,
-	0x1f, 0x05, 0x1e, 0xc0, 0x85, 0xbe, 0x0a, 0x13, 0x5d, 0xe2, 0xfb, 0xa2, 0x02, 0xca, 0x4b, 0x63,
-	0x4f, 0x2b, 0x01, 0x13, 0x1b, 0x01, 0x18, 0x87, 0x78, 0xf9, 0x16, 0x2b, 0xb5, 0xe8, 0x06, 0x21,
-	0x0c, 0x5d, 0x84, 0x29, 0x23, 0xf1, 0x6a, 0xcb, 0x9f, 0xd3, 0xa4, 0xd3, 0x9f, 0xd9, 0xdf, 0x5b,
-	0x98, 0x4a, 0xbe, 0xf3, 0xf2, 0x71, 0x9a, 0x0e, 0x11, 0x98, 0xb4, 0x3d, 0x55, 0x64, 0x06, 0x47,
-	0x75, 0x71, 0xf4, 0xfc, 0x2d, 0xf9, 0xe3, 0x0d, 0x8e, 0xaa, 0xcb, 0x48, 0x34, 0x5a, 0x80, 0x42,
-	0xfb, 0x4d, 0xcb, 0x0d, 0x83, 0xb1, 0x28, 0xce, 0xf2, 0xca, 0x0b, 0x97, 0x37, 0x7d, 0x1c, 0xc0,
-	0x11, 0x17, 0xb5, 0xa3, 0x6a, 0x01, 0xc2, 0xbe, 0xe8, 0xe8, 0x8d, 0x45, 0xa2, 0xfa, 0x0c, 0x65,
-	0xe3, 0x84, 0x1e, 0x71, 0x5b, 0x38, 0xc6, 0x16, 0x71, 0xea, 0x16, 0x11, 0x1d, 0x9c, 0x2d, 0xcb,
-	0xd6, 0xdc, 0x85, 0xa9, 0xe0, 0xb6, 0x58, 0x4f, 0xa3, 0x70, 0x96, 0x56, 0xff, 0x4c, 0x83, 0x7b,
-	0x06, 0x47, 0x23, 0x7a, 0x0c, 0xf2, 0xa2, 0x10, 0x54, 0xbe, 0xf7, 0x40, 0x78, 0x7f, 0xb7, 0x76,
-	0x3d, 0x72, 0x63, 0x6f, 0x21, 0x7d, 0x82, 0x02, 0x88, 0x25, 0xf9, 0xc8, 0xf3, 0x85, 0x28, 0x4f,
-	0xe4, 0x0e, 0x2a, 0x62, 0xf3, 0x47, 0x29, 0x62, 0x3f, 0x1a, 0xcf, 0x38, 0x9d, 0xb8, 0x73, 0xd1,
-	0x53, 0x50, 0xb4, 0x6c, 0x26, 0xda, 0x07, 0x1a, 0x8e, 0xe9, 0xe7, 0x43, 0x63, 0x2f, 0x87, 0x88,
-	0x1b, 0xc9, 0x07, 0x1c, 0x33, 0x20, 0x13, 0xf2, 0x6d, 0x46, 0xbb, 0xaa, 0x0c, 0x38, 0x5a, 0x42,
-	0x10, 0x31, 0x10, 0x2f, 0xfe, 0x0a, 0xa3, 0x5d, 0x2c, 0x85, 0xa3, 0xd7, 0x60, 0x8c, 0x53, 0x35,
-	0xda, 0x3f, 0x06, 0x15, 0xa0, 0x54, 0x8c, 0xb5, 0x28, 0x1e, 0xe3, 0x54, 0x44, 0x8f, 0x9f, 0xf6,
-	0xd9, 0x8b, 0x87, 0xf4, 0xd9, 0x38, 0x7a, 0x22, 0x47, 0x8d, 0x44, 0xcb, 0x97, 0x32, 0x99, 0x3c,
-	0x13, 0xa7, 0xfa, 0xbe, 0xcc, 0xf4, 0x12, 0x8c, 0x1b, 0xc1, 0x99, 0x8c, 0xcb, 0x33, 0x79, 0x46,
-	0xbe, 0x04, 0x09, 0x0f, 0xe3, 0x91, 0x9b, 0x7c, 0x72, 0xc2, 0x2c, 0xf5, 0xa5, 0xc9, 0x62, 0x45,
-	0x1c, 0x70, 0xc0, 0x83, 0x95, 0x34, 0xf4, 0x24, 0x4c, 0x11, 0xd7, 0xd8, 0x72, 0xc8, 0x3a, 0xed,
-	0x74, 0x6c, 0xb7, 0x33, 0x37, 0x21, 0xef, 0xba, 0xbb, 0x95, 0x29, 0x53, 0xab, 0x49, 0x24, 0x4e,
-	0xd3, 0x0e, 0xca, 0xcb, 0x93, 0x23, 0xe4, 0xe5, 0xd0, 0xcd, 0x8b, 0x43, 0xdd, 0xfc, 0x4d, 0x28,
-	0x39, 0x51, 0xf9, 0xea, 0xcf, 0x81, 0x3c, 0x8d, 0x27, 0x46, 0x3d, 0x8d, 0xb8, 0x02, 0x8e, 0x9b,
-	0xd0, 0x18, 0xe6, 0xe3, 0xa4, 0x0e, 0x71, 0x2c, 0x0e, 0xed, 0xc8, 0x5b, 0x62, 0xae, 0x94, 0xce,
-	0x31, 0xeb, 0x0a, 0x8e, 0x23, 0x0a, 0xfd, 0xfd, 0x1c, 0xa0, 0x94, 0x47, 0x89, 0x4c, 0xe5, 0xa3,
-	0x77, 0x35, 0x98, 0x72, 0x93, 0x60, 0x95, 0x8c, 0x8f, 0xab, 0x2c, 0x88, 0x8e, 0x27, 0x8d, 0x4f,
-	0xeb, 0x44, 0x1e, 0x94, 0x39, 0x33, 0xda, 0x6d, 0xdb, 0x94, 0x56, 0xa9, 0xa0, 0x7c, 0xfc, 0x26,
-	0x36, 0xc8, 0xef, 0x85, 0x2a, 0xe1, 0xf7, 0x42, 0x95, 0x56, 0x82, 0x3b, 0x31, 0x2c, 0x48, 0x40,
-	0x71, 0x4a, 0x03, 0x7a, 0x47, 0x83, 0x19, 0x51, 0xb2, 0x25, 0x49, 0xd4, 0x98, 0xe3, 0x89, 0x5b,
-	0x57, 0x8b, 0x33, 0x12, 0xe2, 0xd6, 0x2a, 0x8b, 0xc1, 0x7d, 0xda, 0xf4, 0xbf, 0x69, 0x30, 0xdb,
-	0x77, 0x22, 0xbd, 0x93, 0x98, 0x33, 0x39, 0x50, 0x10, 0xb5, 0x47, 0x98, 0x72, 0xd7, 0x8e, 0x74,
-	0xd6, 0x71, 0xd5, 0x13, 0xd7, 0x49, 0x02, 0xe6, 0xe3, 0x40, 0x89, 0xbe, 0x08, 0x53, 0xa9, 0x91,
-	0xde, 0xc1, 0x73, 0x6e, 0xfd, 0xc3, 0x02, 0xcc, 0x84, 0x72, 0xfd, 0x66, 0xaf, 0xdb, 0x35, 0xd8,
-	0x49, 0x74, 0x09, 0x3f, 0xd2, 0xe0, 0x74, 0xd2, 0x31, 0xed, 0x68, 0x8b, 0x6a, 0x47, 0xda, 0xa2,
-	0xc0, 0x37, 0xce, 0x2a, 0xdd, 0xa7, 0x37, 0xd3, 0x2a, 0x70, 0x56, 0x27, 0xfa, 0xad, 0x06, 0xf7,
-	0x07, 0x5a, 0xd4, 0x8b, 0xd9, 0x0c, 0x87, 0x72, 0xd4, 0xe3, 0x30, 0xea, 0xcb, 0xca, 0xa8, 0xfb,
-	0x97, 0x6f, 0xa2, 0x0f, 0xdf, 0xd4, 0x1a, 0xf4, 0x2b, 0x0d, 0xee, 0x0e, 0x08, 0xb2, 0x76, 0xe6,
-	0x8f, 0xcd, 0xce, 0x73, 0xca, 0xce, 0xbb, 0x97, 0x07, 0x29, 0xc2, 0x83, 0xf5, 0x8b, 0x7e, 0xa7,
-	0x1b, 0x76, 0xe4, 0xb2, 0xb4, 0x3a, 0x84, 0x31, 0xfd, 0x2d, 0x7d, 0x5c, 0x13, 0x45, 0x38, 0x1c,
-	0xeb, 0xd1, 0x5f, 0x83, 0xbb, 0x1a, 0x46, 0xc7, 0x76, 0x65, 0x89, 0xbd, 0x46, 0xf8, 0xf3, 0x9e,
-	0xf8, 0xe1, 0x07, 0x03, 0xb3, 0x4e, 0xe0, 0xf6, 0xb9, 0xe4, 0xc0, 0xac, 0x43, 0xb0, 0xc4, 0xa0,
-	0x07, 0xa1, 0xe0, 0xd8, 0x5d, 0x9b, 0xab, 0x16, 0x20, 0x0a, 0xa7, 0x75, 0x01, 0xc4, 0x01, 0x4e,
-	0x37, 0xa0, 0x9c, 0x6c, 0xf7, 0x6f, 0xc7, 0x5b, 0xa3, 0xdf, 0xe7, 0x20, 0x9c, 0x87, 0xa3, 0x47,
-	0x13, 0x7d, 0x7e, 0xa0, 0x62, 0xee, 0xe0, 0x1e, 0x1f, 0x6d, 0xaa, 0x09, 0xc3, 0xd8, 0x01, 0x71,
-	0xda, 0xe3, 0xb6, 0x53, 0x09, 0x3e, 0x78, 0xac, 0xd4, 0x5d, 0xfe, 0x3c, 0x6b, 0x72, 0x66, 0xbb,
-	0x9d, 0x60, 0x66, 0x93, 0x98, 0x47, 0x7c, 0x05, 0x26, 0x88, 0x2b, 0x87, 0x17, 0xb2, 0x9a, 0x2a,
-	0x04, 0x33, 0xfb, 0xd5, 0x00, 0x84, 0x43, 0x9c, 0xe8, 0x9f, 0x6d, 0xb3, 0xeb, 0x89, 0x8a, 0x56,
-	0x56, 0x9c, 0x85, 0xa0, 0x7f, 0xae, 0xaf, 0x6c, 0x34, 0x64, 0x95, 0x1b, 0x61, 0x43, 0xca, 0x95,
-	0xf0, 0x3d, 0x45, 0x82, 0x52, 0xc0, 0x70, 0x84, 0x95, 0x94, 0x1d, 0x25, 0x73, 0x3c, 0x41, 0xb9,
-	0x16, 0xc9, 0x54, 0x58, 0x74, 0x49, 0xbd, 0xb4, 0x56, 0x1d, 0x8f, 0x2c, 0x50, 0x8a, 0x99, 0xf7,
-	0xce, 0xe1, 0xb4, 0x2c, 0x45, 0x29, 0x96, 0xe7, 0x33, 0x53, 0x2e, 0x6f, 0x32, 0x5e, 0x5e, 0x33,
-	0x00, 0xe1, 0x10, 0x87, 0x2a, 0x00, 0x3e, 0x33, 0xd5, 0xaa, 0x65, 0x31, 0x52, 0xa8, 0x4d, 0x8b,
-	0xdb, 0xac, 0x19, 0x41, 0x71, 0x82, 0x42, 0x27, 0x30, 0x93, 0xed, 0x49, 0x6e, 0x87, 0xbb, 0xbc,
-	0x9f, 0x87, 0xb3, 0xcd, 0x9e, 0x27, 0x0e, 0x2a, 0xf8, 0xb4, 0x66, 0x85, 0x3a, 0x8e, 0x2a, 0xb3,
-	0x6f, 0xff, 0xa5, 0xfd, 0x2a, 0x14, 0xc9, 0x35, 0xcf, 0x66, 0xc4, 0x5a, 0x0e, 0xfd, 0xed, 0x6b,
-	0xb7, 0xa6, 0xa2, 0x65, 0x77, 0x49, 0xbc, 0xb4, 0xd5, 0x50, 0x08, 0x8e, 0xe5, 0x89, 0xbd, 0xf0,
-	0x6d, 0xd7, 0x24, 0x82, 0x54, 0x35, 0x39, 0x11, 0x43, 0x33, 0x44, 0xe0, 0x98, 0x46, 0x34, 0x92,
-	0xed, 0xe8, 0x63, 0x24, 0xe9, 0x83, 0x87, 0x68, 0x24, 0xb3, 0x1f, 0x35, 0xc5, 0x3b, 0x10, 0xc3,
-	0x70, 0x42, 0x0f, 0xfa, 0x99, 0x06, 0xd3, 0x46, 0xfa, 0x7b, 0xa2, 0xe0, 0xe5, 0xdb, 0xc6, 0xe1,
-	0x54, 0x0f, 0xf9, 0x36, 0xaa, 0x76, 0x8f, 0xb2, 0x63, 0x3a, 0xf3, 0x61, 0x51, 0x46, 0xb9, 0xfe,
-	0xb9, 0x06, 0xf7, 0x0d, 0xf1, 0x88, 0x13, 0x18, 0xfe, 0x38, 0xe9, 0xe1, 0xcf, 0xc8, 0xe5, 0xcd,
-	0x10, 0xcb, 0x87, 0x8c, 0x81, 0x7e, 0x39, 0x06, 0x0f, 0x0c, 0xe1, 0x38, 0xf4, 0x40, 0xe8, 0x49,
-	0x98, 0x0a, 0x7f, 0x27, 0xc3, 0x30, 0x2e, 0xa6, 0x93, 0x48, 0x9c, 0xa6, 0x0d, 0x55, 0xc9, 0x0b,
-	0x2b, 0xd7, 0xaf, 0x2a, 0xb8, 0xb4, 0x42, 0x0a, 0xe1, 0xe1, 0x26, 0xed, 0x7a, 0x0e, 0xe1, 0x24,
-	0xe8, 0xd2, 0x27, 0x63, 0x0f, 0x5f, 0x09, 0x11, 0x38, 0xa6, 0x11, 0x49, 0x8a, 0x30, 0x46, 0x99,
-	0xf4, 0xb0, 0xc4, 0x3c, 0x7b, 0x55, 0x00, 0x71, 0x80, 0xd3, 0xff, 0xa5, 0xc1, 0xb9, 0x21, 0x9b,
-	0x72, 0x62, 0x55, 0xee, 0x4e, 0xba, 0xca, 0x7d, 0xe1, 0x98, 0xdc, 0xe0, 0xc0, 0x7a, 0xf7, 0x61,
-	0x28, 0x25, 0x5e, 0x12, 0xa0, 0x73, 0x90, 0xf3, 0x5d, 0x3b, 0xfb, 0x41, 0x62, 0x73, 0xb3, 0x8e,
-	0x05, 0xbc, 0xd6, 0xfa, 0xf8, 0xfa, 0xfc, 0xa9, 0x4f, 0xae, 0xcf, 0x9f, 0xfa, 0xf4, 0xfa, 0xfc,
-	0xa9, 0x77, 0xf6, 0xe7, 0xb5, 0x8f, 0xf7, 0xe7, 0xb5, 0x4f, 0xf6, 0xe7, 0xb5, 0x4f, 0xf7, 0xe7,
-	0xb5, 0xbf, 0xec, 0xcf, 0x6b, 0xbf, 0xf8, 0xeb, 0xfc, 0xa9, 0x57, 0x2a, 0xa3, 0xfd, 0xa7, 0xc6,
-	0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xcb, 0x02, 0x8d, 0x03, 0xda, 0x31, 0x00, 0x00,
+	// 2991 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3b, 0xcb, 0x6f, 0x24, 0x47,
+	0xf9, 0xdb, 0x9e, 0x19, 0xdb, 0xf3, 0xcd, 0xf8, 0x55, 0xde, 0x64, 0xe7, 0x97, 0x64, 0xed, 0x4d,
+	0xe7, 0x47, 0xb4, 0xa0, 0x30, 0x8e, 0x4d, 0x36, 0xbb, 0x90, 0x64, 0x85, 0xc7, 0xeb, 0x75, 0x86,
+	0xd8, 0xce, 0xa4, 0xc6, 0x49, 0xa4, 0x84, 0x84, 0xb4, 0xbb, 0x6b, 0xc6, 0x9d, 0xed, 0xe9, 0xea,
+	0x54, 0xd7, 0x38, 0xeb, 0x1c, 0x50, 0x10, 0x70, 0x08, 0xaf, 0x20, 0x2e, 0x28, 0x37, 0x6e, 0x08,
+	0x89, 0xbf, 0x20, 0x27, 0x38, 0x20, 0xe5, 0x18, 0x84, 0x10, 0x39, 0x59, 0xc4, 0x08, 0x10, 0x07,
+	0x2e, 0xdc, 0x58, 0x84, 0x84, 0xaa, 0xba, 0xfa, 0x39, 0x33, 0xeb, 0x1d, 0xdb, 0x6b, 0x24, 0xb2,
+	0x27, 0x4f, 0x7f, 0xcf, 0x7a, 0x7c, 0x5f, 0x7d, 0x8f, 0x2a, 0xc3, 0x55, 0xc3, 0xe5, 0x8c, 0x18,
+	0x55, 0x9b, 0x2e, 0x04, 0xbf, 0x16, 0xbc, 0x1b, 0xed, 0x05, 0xc3, 0xb3, 0xfd, 0x05, 0x93, 0xba,
+	0x9c, 0x51, 0xc7, 0x73, 0x0c, 0x97, 0x2c, 0xec, 0x2e, 0x6e, 0x13, 0x6e, 0x2c, 0x2d, 0xb4, 0x89,
+	0x4b, 0x98, 0xc1, 0x89, 0x55, 0xf5, 0x18, 0xe5, 0x14, 0x55, 0x03, 0xae, 0x6f, 0xd8, 0x54, 0xfd,
+	0xaa, 0x7a, 0x37, 0xda, 0x55, 0xc1, 0x5f, 0x4d, 0xf2, 0x57, 0x15, 0xff, 0x03, 0x57, 0x06, 0xeb,
+	0xf3, 0xb9, 0xc1, 0xfd, 0x85, 0xdd, 0x45, 0xc3, 0xf1, 0x76, 0x8c, 0xc5, 0xac, 0xa6, 0x07, 0xbe,
+	0xd8, 0xb6, 0xf9, 0x4e, 0x77, 0xbb, 0x6a, 0xd2, 0xce, 0x42, 0x9b, 0xb6, 0xe9, 0x82, 0x04, 0x6f,
+	0x77, 0x5b, 0xf2, 0x4b, 0x7e, 0xc8, 0x5f, 0x8a, 0xfc, 0x89, 0x1b, 0x57, 0x7c, 0xa9, 0xc5, 0xb3,
+	0x3b, 0x86, 0xb9, 0x63, 0xbb, 0x84, 0xed, 0xc5, 0xba, 0x3a, 0x84, 0x1b, 0x0b, 0xbb, 0xbd, 0x4a,
+	0x16, 0x06, 0x71, 0xb1, 0xae, 0xcb, 0xed, 0x0e, 0xe9, 0x61, 0x78, 0xf2, 0x30, 0x06, 0xdf, 0xdc,
+	0x21, 0x1d, 0xa3, 0x87, 0xef, 0x4b, 0x83, 0xf8, 0xba, 0xdc, 0x76, 0x16, 0x6c, 0x97, 0xfb, 0x9c,
+	0x65, 0x99, 0xf4, 0xbf, 0x6a, 0x50, 0x5e, 0xb6, 0x2c, 0x46, 0x7c, 0x7f, 0x8d, 0xd1, 0xae, 0x87,
+	0xde, 0x80, 0x71, 0x31, 0x13, 0xcb, 0xe0, 0x46, 0x45, 0xbb, 0xa0, 0x5d, 0x2c, 0x2d, 0x3d, 0x5e,
+	0x0d, 0x04, 0x57, 0x93, 0x82, 0xe3, 0x3d, 0x11, 0xd4, 0xd5, 0xdd, 0xc5, 0xea, 0xf3, 0xdb, 0x6f,
+	0x12, 0x93, 0x6f, 0x10, 0x6e, 0xd4, 0xd0, 0x47, 0xfb, 0xf3, 0x67, 0x0e, 0xf6, 0xe7, 0x21, 0x86,
+	0xe1, 0x48, 0x2a, 0xea, 0x42, 0xb9, 0x2d, 0x54, 0x6d, 0x90, 0xce, 0x36, 0x61, 0x7e, 0x65, 0xe4,
+	0x42, 0xee, 0x62, 0x69, 0xe9, 0xa9, 0x21, 0xb7, 0xbd, 0xba, 0x16, 0xcb, 0xa8, 0x9d, 0x55, 0x0a,
+	0xcb, 0x09, 0xa0, 0x8f, 0x53, 0x6a, 0xf4, 0xdf, 0x69, 0x30, 0x9d, 0x9c, 0xe9, 0xba, 0xed, 0x73,
+	0xf4, 0xf5, 0x9e, 0xd9, 0x56, 0xef, 0x6c, 0xb6, 0x82, 0x5b, 0xce, 0x75, 0x5a, 0xa9, 0x1e, 0x0f,
+	0x21, 0x89, 0x99, 0x1a, 0x50, 0xb0, 0x39, 0xe9, 0x84, 0x53, 0x7c, 0x7a, 0xd8, 0x29, 0x26, 0x87,
+	0x5b, 0x9b, 0x50, 0x8a, 0x0a, 0x75, 0x21, 0x12, 0x07, 0x92, 0xf5, 0xf7, 0x72, 0x30, 0x93, 0x24,
+	0x6b, 0x18, 0xdc, 0xdc, 0x39, 0x85, 0x4d, 0xfc, 0x8e, 0x06, 0x33, 0x86, 0x65, 0x11, 0x6b, 0xed,
+	0x84, 0xb7, 0xf2, 0xff, 0x94, 0xda, 0x99, 0xe5, 0xac, 0x74, 0xdc, 0xab, 0x10, 0x7d, 0x4f, 0x83,
+	0x59, 0x46, 0x3a, 0x74, 0x37, 0x33, 0x90, 0xdc, 0xf1, 0x07, 0xf2, 0xa0, 0x1a, 0xc8, 0x2c, 0xee,
+	0x95, 0x8f, 0xfb, 0x29, 0xd5, 0xff, 0xa6, 0xc1, 0xe4, 0xb2, 0xe7, 0x39, 0x36, 0xb1, 0xb6, 0xe8,
+	0xff, 0xb8, 0x37, 0xfd, 0x41, 0x03, 0x94, 0x9e, 0xeb, 0x29, 0xf8, 0x93, 0x99, 0xf6, 0xa7, 0xab,
+	0x43, 0xfb, 0x53, 0x6a, 0xc0, 0x03, 0x3c, 0xea, 0xfb, 0x39, 0x98, 0x4d, 0x13, 0xde, 0xf3, 0xa9,
+	0xff, 0x9e, 0x4f, 0xbd, 0x05, 0xb3, 0x35, 0xc3, 0xb7, 0xcd, 0xe5, 0x2e, 0xdf, 0x21, 0x2e, 0xb7,
+	0x4d, 0x83, 0xdb, 0xd4, 0x45, 0x8f, 0xc1, 0x78, 0xd7, 0x27, 0xcc, 0x35, 0x3a, 0x44, 0x6e, 0x46,
+	0x31, 0xb6, 0x9b, 0x17, 0x15, 0x1c, 0x47, 0x14, 0x82, 0xda, 0x33, 0x7c, 0xff, 0x6d, 0xca, 0xac,
+	0xca, 0x48, 0x9a, 0xba, 0xa1, 0xe0, 0x38, 0xa2, 0xd0, 0x17, 0x61, 0xba, 0xd6, 0x75, 0x2d, 0x87,
+	0x5c, 0xb7, 0x1d, 0xd2, 0x24, 0x6c, 0x97, 0x30, 0x74, 0x1e, 0x72, 0x5d, 0xe6, 0x28, 0x55, 0x25,
+	0xc5, 0x9c, 0x7b, 0x11, 0xaf, 0x63, 0x01, 0xd7, 0xdf, 0x1f, 0x81, 0xf3, 0x01, 0x4f, 0x40, 0x2f,
+	0x46, 0xbb, 0x42, 0xdd, 0x96, 0xdd, 0xee, 0xb2, 0x60, 0xc0, 0x97, 0xa0, 0xb4, 0x4d, 0x0c, 0x46,
+	0xd8, 0x16, 0xbd, 0x41, 0x5c, 0x25, 0x68, 0x56, 0x09, 0x2a, 0xd5, 0x62, 0x14, 0x4e, 0xd2, 0xa1,
+	0x47, 0x61, 0xd4, 0xf0, 0xec, 0xe7, 0xc8, 0x9e, 0x1a, 0xf7, 0xa4, 0xe2, 0x18, 0x5d, 0x6e, 0xd4,
+	0x9f, 0x23, 0x7b, 0x58, 0x61, 0xd1, 0x8f, 0x34, 0x98, 0xdd, 0xee, 0x5d, 0xa7, 0x4a, 0x4e, 0x1a,
+	0xea, 0xca, 0xb0, 0x7b, 0xd6, 0x67, 0xc9, 0x6b, 0xe7, 0xc4, 0xbe, 0xf5, 0x41, 0xe0, 0x7e, 0x8a,
+	0xf5, 0x9f, 0xe5, 0x61, 0x76, 0xc5, 0xe9, 0xfa, 0x9c, 0xb0, 0x94, 0x71, 0xdd, 0x7d, 0x2f, 0xfa,
+	0x96, 0x06, 0xd3, 0xa4, 0xd5, 0x22, 0x26, 0xb7, 0x77, 0xc9, 0x09, 0x3a, 0x51, 0x45, 0x69, 0x9d,
+	0x5e, 0xcd, 0x08, 0xc7, 0x3d, 0xea, 0xd0, 0x37, 0x61, 0x26, 0x82, 0xd5, 0x1b, 0x35, 0x87, 0x9a,
+	0x37, 0x42, 0xff, 0xb9, 0x34, 0xec, 0x18, 0xea, 0x8d, 0x4d, 0xc2, 0x63, 0x17, 0x5e, 0xcd, 0xca,
+	0xc5, 0xbd, 0xaa, 0xd0, 0x15, 0x28, 0x73, 0xca, 0x0d, 0x27, 0x9c, 0x7e, 0xfe, 0x82, 0x76, 0x31,
+	0x17, 0x9f, 0xeb, 0x5b, 0x09, 0x1c, 0x4e, 0x51, 0xa2, 0x25, 0x00, 0xf9, 0xdd, 0x30, 0xda, 0xc4,
+	0xaf, 0x14, 0x24, 0x5f, 0xb4, 0xde, 0x5b, 0x11, 0x06, 0x27, 0xa8, 0x84, 0x6d, 0x9b, 0x5d, 0xc6,
+	0x88, 0xcb, 0xc5, 0x77, 0x65, 0x54, 0x32, 0x45, 0xb6, 0xbd, 0x12, 0xa3, 0x70, 0x92, 0x4e, 0xff,
+	0x8b, 0x06, 0xa5, 0xd5, 0xf6, 0x67, 0x20, 0xf3, 0xfc, 0xad, 0x06, 0x53, 0x89, 0x89, 0x9e, 0x42,
+	0xa0, 0x7c, 0x23, 0x1d, 0x28, 0x87, 0x9e, 0x61, 0x62, 0xb4, 0x03, 0xa2, 0xe4, 0x0f, 0x72, 0x30,
+	0x9d, 0xa0, 0x0a, 0x42, 0xa4, 0x05, 0x40, 0xa3, 0x75, 0x3f, 0xd1, 0x3d, 0x4c, 0xc8, 0xbd, 0x17,
+	0x26, 0xfb, 0x84, 0x49, 0x07, 0xce, 0xad, 0xde, 0xe4, 0x22, 0xdc, 0x39, 0xab, 0x2e, 0xb7, 0xf9,
+	0x1e, 0x26, 0x2d, 0xc2, 0x88, 0x6b, 0x12, 0x74, 0x01, 0xf2, 0x89, 0x30, 0x59, 0x56, 0xa2, 0xf3,
+	0x9b, 0x22, 0x44, 0x4a, 0x0c, 0x5a, 0x80, 0xa2, 0xf8, 0xeb, 0x7b, 0x86, 0x49, 0x54, 0x9c, 0x99,
+	0x51, 0x64, 0xc5, 0xcd, 0x10, 0x81, 0x63, 0x1a, 0xfd, 0x5f, 0x1a, 0x4c, 0x4b, 0xf5, 0xcb, 0xbe,
+	0x4f, 0x4d, 0x3b, 0x88, 0x70, 0xa7, 0x92, 0x1f, 0x4d, 0x1b, 0x4a, 0xa3, 0x9a, 0xff, 0x91, 0x53,
+	0x41, 0xc9, 0x1d, 0x2d, 0x52, 0x7c, 0xb8, 0x2f, 0x67, 0xe4, 0xe3, 0x1e, 0x8d, 0xfa, 0x87, 0x79,
+	0x28, 0x25, 0x16, 0x1f, 0xbd, 0x0c, 0x39, 0x8f, 0x5a, 0x6a, 0xce, 0x43, 0xd7, 0x78, 0x0d, 0x6a,
+	0xc5, 0xc3, 0x18, 0x13, 0x59, 0x85, 0x80, 0x08, 0x89, 0xe8, 0xdb, 0x1a, 0x4c, 0x92, 0xd4, 0xae,
+	0xca, 0xdd, 0x29, 0x2d, 0xad, 0x0d, 0xed, 0xcf, 0xfd, 0x6d, 0xa3, 0x86, 0x0e, 0xf6, 0xe7, 0x27,
+	0x33, 0xc8, 0x8c, 0x4a, 0xf4, 0x28, 0xe4, 0x6c, 0x2f, 0x30, 0xeb, 0x72, 0xed, 0xac, 0x18, 0x60,
+	0xbd, 0xe1, 0xdf, 0xda, 0x9f, 0x2f, 0xd6, 0x1b, 0xaa, 0xf0, 0xc4, 0x82, 0x00, 0xbd, 0x0e, 0x05,
+	0x8f, 0x32, 0x2e, 0x82, 0x8d, 0xd8, 0x91, 0x2f, 0x0f, 0x3b, 0x46, 0x61, 0x69, 0x56, 0x83, 0x32,
+	0x1e, 0x9f, 0x38, 0xe2, 0xcb, 0xc7, 0x81, 0x58, 0xf4, 0x2a, 0xe4, 0x5d, 0x6a, 0x11, 0x19, 0x93,
+	0x4a, 0x4b, 0xcf, 0x0c, 0x2d, 0x9e, 0x5a, 0x24, 0x9e, 0xf8, 0xb8, 0x74, 0x01, 0x01, 0x92, 0x42,
+	0x51, 0x1b, 0xc6, 0x7c, 0xc2, 0x76, 0x6d, 0x33, 0x08, 0x5f, 0xa5, 0xa5, 0xaf, 0x0e, 0x2b, 0xbf,
+	0x19, 0xb0, 0xc7, 0x2a, 0x4a, 0x07, 0xfb, 0xf3, 0x63, 0x21, 0x34, 0x94, 0xae, 0x7f, 0x90, 0x87,
+	0xf2, 0xbd, 0x84, 0xe8, 0x5e, 0x42, 0xd4, 0x2f, 0x21, 0xfa, 0xb9, 0x06, 0x93, 0xe9, 0x73, 0x29,
+	0x7d, 0x34, 0x6b, 0x87, 0x1f, 0xcd, 0xd1, 0x69, 0x3f, 0x32, 0xf0, 0xb4, 0xaf, 0x41, 0xae, 0x6b,
+	0x5b, 0xb2, 0x32, 0x28, 0xd6, 0x1e, 0x8f, 0x4a, 0x99, 0xfa, 0xb5, 0x5b, 0xfb, 0xf3, 0x0f, 0x0f,
+	0x6a, 0x21, 0xf2, 0x3d, 0x8f, 0xf8, 0xd5, 0x17, 0xeb, 0xd7, 0xb0, 0x60, 0xd6, 0xdf, 0x81, 0xf2,
+	0xb3, 0x5b, 0x5b, 0x8d, 0x06, 0xa3, 0x9c, 0x9a, 0xd4, 0x11, 0x5a, 0x77, 0xa8, 0xcf, 0xb3, 0x31,
+	0xe6, 0x59, 0xea, 0x73, 0x2c, 0x31, 0xa2, 0x90, 0xe9, 0x10, 0xbe, 0x43, 0xad, 0x6c, 0x21, 0xb3,
+	0x21, 0xa1, 0x58, 0x61, 0x85, 0x24, 0xcf, 0xe0, 0x3b, 0x95, 0x5c, 0x5a, 0x52, 0xc3, 0xe0, 0x3b,
+	0x58, 0x62, 0xf4, 0x5f, 0x6b, 0x30, 0xa6, 0xf6, 0x15, 0xbd, 0x0c, 0x79, 0xd3, 0xb6, 0x98, 0x72,
+	0x9c, 0x23, 0x5a, 0x52, 0xa4, 0x64, 0xa5, 0x7e, 0x0d, 0x63, 0x29, 0x10, 0xbd, 0x06, 0xa3, 0xe4,
+	0xa6, 0x49, 0x3c, 0xae, 0x1c, 0xe5, 0x88, 0xa2, 0xa3, 0x59, 0xae, 0x4a, 0x61, 0x58, 0x09, 0xd5,
+	0xff, 0xad, 0x01, 0xaa, 0x37, 0x3e, 0xbb, 0x21, 0xb4, 0x05, 0x05, 0xb9, 0x40, 0xe8, 0x11, 0x18,
+	0xb1, 0x3d, 0x39, 0xd7, 0x72, 0x6d, 0xf6, 0x60, 0x7f, 0x7e, 0xa4, 0xde, 0x48, 0x87, 0x96, 0x11,
+	0xdb, 0x13, 0xce, 0xeb, 0x31, 0xd2, 0xb2, 0x6f, 0xae, 0x13, 0xb7, 0xcd, 0x77, 0xa4, 0x05, 0x15,
+	0x62, 0xe7, 0x6d, 0x24, 0x70, 0x38, 0x45, 0xa9, 0xff, 0x4a, 0x03, 0x58, 0xbf, 0x1c, 0x99, 0xe9,
+	0x2b, 0x90, 0xdf, 0xe1, 0xdc, 0x3b, 0x6a, 0xa8, 0x4e, 0x9a, 0x7c, 0x10, 0x41, 0x04, 0x04, 0x4b,
+	0x99, 0xe8, 0x25, 0xc8, 0x71, 0xc7, 0x57, 0x01, 0x7a, 0xe8, 0x73, 0x75, 0x6b, 0xbd, 0x19, 0x49,
+	0x96, 0x49, 0xc0, 0xd6, 0x7a, 0x13, 0x0b, 0x81, 0xfa, 0x07, 0x1a, 0xa0, 0x8d, 0xae, 0x23, 0x0a,
+	0x6b, 0x9f, 0xcb, 0xe5, 0xab, 0xbb, 0x2d, 0x8a, 0x1e, 0x81, 0x82, 0xac, 0x31, 0x94, 0xcb, 0x45,
+	0x21, 0x33, 0xd8, 0x94, 0x00, 0x87, 0x5e, 0x87, 0xbc, 0x47, 0xad, 0x23, 0xb7, 0x9f, 0x53, 0xa9,
+	0x49, 0xec, 0x8a, 0xd4, 0xf2, 0xb1, 0x94, 0xab, 0xbf, 0xa7, 0x41, 0x31, 0x0a, 0xdb, 0xd2, 0x75,
+	0x29, 0x0b, 0x0e, 0x81, 0x42, 0x92, 0x9e, 0x71, 0x9c, 0xf7, 0x14, 0xc5, 0x21, 0x87, 0xd3, 0x15,
+	0x18, 0xf7, 0xd4, 0x3a, 0xa8, 0x23, 0xe0, 0xa1, 0xa8, 0x53, 0xa3, 0xe0, 0xb7, 0x12, 0xbf, 0x71,
+	0x44, 0xad, 0xff, 0x3d, 0x07, 0x13, 0x9b, 0x84, 0xbf, 0x4d, 0xd9, 0x8d, 0x06, 0x75, 0x6c, 0x73,
+	0xef, 0x14, 0xbc, 0xa9, 0x05, 0x05, 0xd6, 0x75, 0x48, 0xb8, 0xc0, 0xcb, 0x43, 0xe7, 0x24, 0xc9,
+	0xf1, 0xe2, 0xae, 0x43, 0xe2, 0x7d, 0x14, 0x5f, 0x3e, 0x0e, 0xc4, 0xa3, 0x67, 0x60, 0xca, 0x48,
+	0x75, 0x24, 0x83, 0xd8, 0x59, 0x94, 0x2e, 0x33, 0x95, 0x6e, 0x56, 0xfa, 0x38, 0x4b, 0x8b, 0x2e,
+	0x8a, 0x45, 0xb5, 0x29, 0x13, 0x09, 0xa4, 0x08, 0x7c, 0x5a, 0xad, 0x1c, 0x2c, 0x68, 0x00, 0xc3,
+	0x11, 0x16, 0x3d, 0x01, 0x65, 0x6e, 0x13, 0x16, 0x62, 0x64, 0xb8, 0x2b, 0xd4, 0xa6, 0x65, 0x88,
+	0x4c, 0xc0, 0x71, 0x8a, 0x0a, 0xf9, 0x50, 0xf4, 0x69, 0x97, 0xc9, 0xe4, 0x47, 0xa5, 0x4f, 0xd7,
+	0x8f, 0xb7, 0x14, 0x91, 0xd5, 0x4d, 0x88, 0x40, 0xd7, 0x0c, 0x85, 0xe3, 0x58, 0x8f, 0xfe, 0x7b,
+	0x0d, 0x66, 0x52, 0x4c, 0xa7, 0x50, 0x56, 0x6f, 0xa7, 0xcb, 0xea, 0x67, 0x8e, 0x35, 0xc9, 0x01,
+	0x85, 0xf5, 0x3f, 0x34, 0x38, 0x97, 0xa2, 0x13, 0x59, 0x6a, 0x93, 0x1b, 0xbc, 0xeb, 0x8b, 0x3e,
+	0xa6, 0xc8, 0x56, 0x37, 0xfb, 0x74, 0x3d, 0x37, 0x15, 0x1c, 0x47, 0x14, 0x22, 0x73, 0x51, 0xb7,
+	0x7d, 0xa2, 0x13, 0x38, 0x92, 0xce, 0x5c, 0xd6, 0x22, 0x0c, 0x4e, 0x50, 0xa1, 0xaf, 0x01, 0x62,
+	0xc4, 0x70, 0xec, 0x77, 0xe4, 0xe7, 0x75, 0xc3, 0x76, 0xba, 0x8c, 0x48, 0x4f, 0x1c, 0xaf, 0x3d,
+	0xa0, 0x78, 0x11, 0xee, 0xa1, 0xc0, 0x7d, 0xb8, 0xd0, 0xe7, 0x61, 0xac, 0x43, 0x7c, 0x5f, 0x64,
+	0x40, 0x79, 0x39, 0xd8, 0x29, 0x25, 0x60, 0x6c, 0x23, 0x00, 0xe3, 0x10, 0x2f, 0x6f, 0xb1, 0x52,
+	0x93, 0x6e, 0x10, 0xc2, 0xd0, 0x65, 0x98, 0x30, 0x12, 0x57, 0x5b, 0x7e, 0x45, 0x93, 0x46, 0x3f,
+	0x73, 0xb0, 0x3f, 0x3f, 0x91, 0xbc, 0xf3, 0xf2, 0x71, 0x9a, 0x0e, 0x11, 0x18, 0xb7, 0x3d, 0x95,
+	0x64, 0x06, 0x5b, 0x75, 0x79, 0xf8, 0xf8, 0x2d, 0xf9, 0xe3, 0x05, 0x8e, 0xb2, 0xcb, 0x48, 0x34,
+	0x9a, 0x87, 0x42, 0xeb, 0x2d, 0xcb, 0x0d, 0x9d, 0xb1, 0x28, 0xf6, 0xf2, 0xfa, 0x0b, 0xd7, 0x36,
+	0x7d, 0x1c, 0xc0, 0x11, 0x17, 0xb9, 0xa3, 0x2a, 0x01, 0xc2, 0xba, 0xe8, 0xf8, 0x85, 0x45, 0x22,
+	0xfb, 0x0c, 0x65, 0xe3, 0x84, 0x1e, 0x71, 0x5a, 0x38, 0xc6, 0x36, 0x71, 0xea, 0x16, 0x11, 0x15,
+	0x9c, 0x2d, 0xd3, 0xd6, 0xdc, 0xc5, 0x89, 0xe0, 0xb4, 0x58, 0x4f, 0xa3, 0x70, 0x96, 0x56, 0xb4,
+	0xe5, 0xee, 0xef, 0xef, 0x8d, 0xe8, 0x12, 0xe4, 0x45, 0x22, 0xa8, 0x6c, 0xef, 0xe1, 0xf0, 0xfc,
+	0xde, 0xda, 0xf3, 0xc8, 0xad, 0xfd, 0xf9, 0xf4, 0x0e, 0x0a, 0x20, 0x96, 0xe4, 0x43, 0xf7, 0x17,
+	0xa2, 0x38, 0x91, 0x3b, 0x2c, 0x89, 0xcd, 0x1f, 0x27, 0x89, 0xfd, 0xc5, 0x78, 0xc6, 0xe8, 0xc4,
+	0x99, 0x8b, 0x9e, 0x86, 0xa2, 0x65, 0x33, 0x51, 0x3e, 0xd0, 0xb0, 0x4d, 0x3f, 0x17, 0x0e, 0xf6,
+	0x5a, 0x88, 0xb8, 0x95, 0xfc, 0xc0, 0x31, 0x03, 0x32, 0x21, 0xdf, 0x62, 0xb4, 0xa3, 0xd2, 0x80,
+	0xe3, 0x05, 0x04, 0xe1, 0x03, 0xf1, 0xe4, 0xaf, 0x33, 0xda, 0xc1, 0x52, 0x38, 0x7a, 0x0d, 0x46,
+	0x38, 0xad, 0xe4, 0x4e, 0x4a, 0x05, 0x28, 0x15, 0x23, 0x5b, 0x14, 0x8f, 0x70, 0x2a, 0xbc, 0xc7,
+	0x4f, 0xdb, 0xec, 0xe5, 0x23, 0xda, 0x6c, 0xec, 0x3d, 0x91, 0xa1, 0x46, 0xa2, 0xe5, 0xa5, 0x4c,
+	0x26, 0xce, 0xc4, 0xa1, 0xbe, 0x27, 0x32, 0xbd, 0x04, 0xa3, 0x46, 0xb0, 0x27, 0xa3, 0x72, 0x4f,
+	0xae, 0xca, 0x4b, 0x90, 0x70, 0x33, 0x1e, 0xbf, 0xcd, 0x93, 0x13, 0x66, 0xa9, 0x97, 0x26, 0x8b,
+	0x55, 0xb1, 0xc1, 0x01, 0x0f, 0x56, 0xd2, 0xd0, 0x53, 0x30, 0x41, 0x5c, 0x63, 0xdb, 0x21, 0xeb,
+	0xb4, 0xdd, 0xb6, 0xdd, 0x76, 0x65, 0x4c, 0x9e, 0x75, 0xf7, 0xa9, 0xa1, 0x4c, 0xac, 0x26, 0x91,
+	0x38, 0x4d, 0xdb, 0x2f, 0x2e, 0x8f, 0x0f, 0x11, 0x97, 0x43, 0x33, 0x2f, 0x0e, 0x34, 0xf3, 0xb7,
+	0xa0, 0xe4, 0x44, 0xe9, 0xab, 0x5f, 0x01, 0xb9, 0x1b, 0x5f, 0x19, 0x76, 0x37, 0xe2, 0x0c, 0x38,
+	0x2e, 0x42, 0x63, 0x98, 0x8f, 0x93, 0x3a, 0xc4, 0xb6, 0x38, 0xb4, 0x2d, 0x4f, 0x89, 0x4a, 0x29,
+	0x1d, 0x63, 0xd6, 0x15, 0x1c, 0x47, 0x14, 0xe8, 0x1d, 0x98, 0x22, 0x6e, 0x8b, 0x32, 0x93, 0x74,
+	0x88, 0xcb, 0x37, 0x44, 0x7f, 0xa6, 0x2c, 0x99, 0x1a, 0x8a, 0x69, 0x6a, 0x35, 0x8d, 0xbe, 0xb5,
+	0x3f, 0x7f, 0xe9, 0xce, 0x36, 0x2b, 0xc3, 0x88, 0xb3, 0x8a, 0xd0, 0x9b, 0x50, 0x64, 0x06, 0x27,
+	0xeb, 0x76, 0xc7, 0xe6, 0x95, 0x89, 0xa3, 0x75, 0x85, 0x84, 0x45, 0xe0, 0x50, 0x48, 0x90, 0x6d,
+	0x44, 0x9f, 0x38, 0x16, 0xaf, 0xbf, 0x9f, 0x03, 0x94, 0xf2, 0x1c, 0x11, 0x91, 0x7d, 0xd1, 0xa1,
+	0x9b, 0x70, 0x93, 0xe0, 0x8a, 0x76, 0xa2, 0xe9, 0x4f, 0x64, 0x86, 0x69, 0x7c, 0x5a, 0x27, 0xf2,
+	0xa0, 0xcc, 0x99, 0xd1, 0x6a, 0xd9, 0xa6, 0x1c, 0x95, 0x3a, 0x7c, 0x9e, 0xbc, 0xcd, 0x18, 0xe4,
+	0xbb, 0xa8, 0x6a, 0xf8, 0x2e, 0xaa, 0xba, 0x95, 0xe0, 0x4e, 0x34, 0x45, 0x12, 0x50, 0x9c, 0xd2,
+	0x80, 0xde, 0xd5, 0x60, 0x5a, 0xa4, 0xa6, 0x49, 0x92, 0x4a, 0xee, 0x50, 0xeb, 0xcc, 0xa8, 0xc5,
+	0x19, 0x09, 0x71, 0x09, 0x99, 0xc5, 0xe0, 0x1e, 0x6d, 0xfa, 0x9f, 0x35, 0x98, 0xed, 0xd9, 0x91,
+	0xee, 0x69, 0xf4, 0xd3, 0x1c, 0x28, 0x88, 0x1c, 0x2b, 0x4c, 0x2d, 0xd6, 0x8e, 0xb5, 0xd7, 0x71,
+	0x76, 0x17, 0xe7, 0x83, 0x02, 0xe6, 0xe3, 0x40, 0x89, 0xbe, 0x08, 0x13, 0xa9, 0xd6, 0xe5, 0xe1,
+	0xfd, 0x7c, 0xfd, 0xc3, 0x02, 0x4c, 0x87, 0x72, 0xfd, 0x66, 0xb7, 0xd3, 0x31, 0xd8, 0x69, 0x54,
+	0x43, 0xdf, 0xd5, 0x60, 0x2a, 0x69, 0x98, 0x76, 0xb4, 0x44, 0xb5, 0x63, 0x2d, 0x51, 0x60, 0x1b,
+	0xe7, 0xc2, 0x03, 0x65, 0x33, 0xad, 0x02, 0x67, 0x75, 0xa2, 0x5f, 0x6a, 0xf0, 0x50, 0xa0, 0x45,
+	0x5d, 0x40, 0x67, 0x38, 0x2a, 0xb9, 0x13, 0x1b, 0xd4, 0xff, 0xab, 0x41, 0x3d, 0xb4, 0x7c, 0x1b,
+	0x7d, 0xf8, 0xb6, 0xa3, 0x41, 0x3f, 0xd5, 0xe0, 0xbe, 0x80, 0x20, 0x3b, 0xce, 0xfc, 0x89, 0x8d,
+	0xf3, 0xbc, 0x1a, 0xe7, 0x7d, 0xcb, 0xfd, 0x14, 0xe1, 0xfe, 0xfa, 0x45, 0x5d, 0xd7, 0x09, 0x3b,
+	0x0f, 0x95, 0xc2, 0xd1, 0x06, 0xd3, 0xdb, 0xba, 0x88, 0x73, 0xbf, 0x08, 0x87, 0x63, 0x3d, 0xfa,
+	0x6b, 0x70, 0xb6, 0x61, 0xb4, 0x6d, 0x57, 0x96, 0x12, 0x6b, 0x84, 0x3f, 0xef, 0x89, 0x1f, 0x7e,
+	0xd0, 0x18, 0x6c, 0x07, 0x66, 0x9f, 0x4b, 0x36, 0x06, 0xdb, 0x04, 0x4b, 0x8c, 0x68, 0x89, 0x38,
+	0x32, 0x16, 0x04, 0xa5, 0x4e, 0xe4, 0x4e, 0xc1, 0x61, 0x1e, 0xe0, 0x74, 0x03, 0xca, 0xc9, 0xb6,
+	0xc6, 0xdd, 0xb8, 0x1d, 0xdb, 0xd7, 0x60, 0x22, 0x15, 0x57, 0x50, 0x03, 0xce, 0x9a, 0xd4, 0x75,
+	0x83, 0x1c, 0xd1, 0x6f, 0x10, 0xd6, 0x24, 0x26, 0x75, 0x2d, 0xd5, 0x29, 0x09, 0x3b, 0x1c, 0x67,
+	0x57, 0xfa, 0xd0, 0xe0, 0xbe, 0x9c, 0x22, 0x6d, 0xd9, 0x36, 0x5c, 0xeb, 0x6d, 0xdb, 0xe2, 0x3b,
+	0x42, 0x8f, 0xea, 0x89, 0x45, 0xf1, 0xa2, 0x96, 0x44, 0xe2, 0x34, 0x2d, 0xba, 0x0a, 0x93, 0x11,
+	0xa0, 0xd6, 0x65, 0x3e, 0x97, 0xb9, 0x64, 0xa1, 0x76, 0xbf, 0xe2, 0x9e, 0xac, 0xa5, 0xb0, 0x38,
+	0x43, 0xad, 0xff, 0x26, 0x07, 0xe1, 0xc5, 0x06, 0x7a, 0x22, 0xd1, 0xb0, 0x09, 0xd6, 0xb0, 0x72,
+	0x78, 0xb3, 0x06, 0x6d, 0xaa, 0x56, 0xd1, 0xc8, 0x21, 0x07, 0x91, 0x78, 0xb9, 0x5a, 0x0d, 0x5e,
+	0xae, 0x56, 0xeb, 0x2e, 0x7f, 0x9e, 0x35, 0x39, 0xb3, 0xdd, 0x76, 0x6d, 0x3c, 0xd3, 0x58, 0xfa,
+	0x1c, 0x8c, 0x11, 0x57, 0x76, 0xa1, 0xd4, 0x54, 0xe4, 0xe5, 0xcb, 0x6a, 0x00, 0xc2, 0x21, 0x4e,
+	0x34, 0x42, 0x6c, 0xb3, 0xe3, 0x89, 0xd2, 0x44, 0x96, 0x0e, 0x85, 0xa0, 0x11, 0x52, 0x5f, 0xd9,
+	0x68, 0x08, 0x18, 0x8e, 0xb0, 0x21, 0xe5, 0x4a, 0x78, 0xe1, 0x94, 0xa0, 0x14, 0x30, 0x1c, 0x61,
+	0x25, 0x65, 0x5b, 0xc9, 0x1c, 0x4d, 0x50, 0xae, 0x45, 0x32, 0x15, 0x56, 0xb4, 0x31, 0x65, 0x5b,
+	0x4e, 0x95, 0xae, 0x32, 0xd3, 0x2c, 0x66, 0x1e, 0x10, 0x28, 0x1c, 0x4e, 0x51, 0x8a, 0xe9, 0xf9,
+	0xcc, 0x94, 0xd3, 0x1b, 0x8f, 0xa7, 0xd7, 0x0c, 0x40, 0x38, 0xc4, 0xa1, 0x2a, 0x80, 0xcf, 0x4c,
+	0x35, 0x6b, 0x99, 0x55, 0x16, 0x6a, 0x93, 0xe2, 0xb8, 0x6e, 0x46, 0x50, 0x9c, 0xa0, 0xd0, 0x09,
+	0x4c, 0x67, 0x8b, 0xcb, 0xbb, 0xe1, 0x0f, 0xef, 0xe7, 0xe1, 0x5c, 0xb3, 0xeb, 0x89, 0x8d, 0x0a,
+	0xde, 0x48, 0xad, 0x50, 0xc7, 0x51, 0xf5, 0xd2, 0xdd, 0x8f, 0x4a, 0xaf, 0x42, 0x91, 0xdc, 0xf4,
+	0x6c, 0x46, 0xac, 0xe5, 0xd0, 0xde, 0xbe, 0x70, 0x67, 0x2a, 0xb6, 0xec, 0x0e, 0x89, 0xa7, 0xb6,
+	0x1a, 0x0a, 0xc1, 0xb1, 0x3c, 0xb1, 0x16, 0xbe, 0xed, 0x9a, 0x44, 0x90, 0xaa, 0x6a, 0x35, 0x62,
+	0x68, 0x86, 0x08, 0x1c, 0xd3, 0x88, 0x8e, 0x40, 0x2b, 0x7a, 0x55, 0x26, 0x6d, 0xf0, 0x08, 0x1d,
+	0x81, 0xec, 0xeb, 0xb4, 0x78, 0x05, 0x62, 0x18, 0x4e, 0xe8, 0x41, 0x3f, 0xd4, 0x60, 0xd2, 0x48,
+	0x3f, 0x0c, 0x0b, 0x6e, 0x51, 0x37, 0x8e, 0xa6, 0x7a, 0xc0, 0x23, 0xb7, 0xf8, 0x00, 0xc9, 0xbc,
+	0x10, 0xcb, 0x28, 0x17, 0x0f, 0x65, 0x1f, 0x1c, 0x60, 0x11, 0xa7, 0xd0, 0xc5, 0x73, 0xd2, 0x5d,
+	0xbc, 0xa1, 0xf3, 0xb7, 0x01, 0x23, 0x1f, 0xd0, 0xcf, 0xfb, 0xc9, 0x08, 0x3c, 0x3c, 0x80, 0xe3,
+	0xc8, 0x9d, 0xbd, 0xa7, 0x60, 0x22, 0xfc, 0x9d, 0x74, 0xc3, 0xb8, 0x5a, 0x48, 0x22, 0x71, 0x9a,
+	0x36, 0x54, 0x25, 0x0f, 0xac, 0x5c, 0xaf, 0xaa, 0xe0, 0xd0, 0x0a, 0x29, 0x84, 0x85, 0x9b, 0xb4,
+	0xe3, 0x39, 0x84, 0x93, 0xa0, 0xdd, 0x32, 0x1e, 0x5b, 0xf8, 0x4a, 0x88, 0xc0, 0x31, 0x8d, 0x88,
+	0xc2, 0x84, 0x31, 0xca, 0x2a, 0x85, 0xf4, 0xc5, 0xc4, 0xaa, 0x00, 0xe2, 0x00, 0xa7, 0xff, 0x53,
+	0x83, 0xf3, 0x03, 0x16, 0xe5, 0xd4, 0xd2, 0xf8, 0xdd, 0x74, 0x1a, 0xff, 0xc2, 0x09, 0x99, 0xc1,
+	0xa1, 0x09, 0xfd, 0x63, 0x50, 0x4a, 0xdc, 0xf6, 0x88, 0x97, 0xa5, 0xbe, 0x6b, 0x67, 0x5f, 0x96,
+	0x36, 0x37, 0xeb, 0x58, 0xc0, 0x6b, 0x5b, 0x1f, 0x7d, 0x3a, 0x77, 0xe6, 0xe3, 0x4f, 0xe7, 0xce,
+	0x7c, 0xf2, 0xe9, 0xdc, 0x99, 0x77, 0x0f, 0xe6, 0xb4, 0x8f, 0x0e, 0xe6, 0xb4, 0x8f, 0x0f, 0xe6,
+	0xb4, 0x4f, 0x0e, 0xe6, 0xb4, 0x3f, 0x1e, 0xcc, 0x69, 0x3f, 0xfe, 0xd3, 0xdc, 0x99, 0x57, 0xaa,
+	0xc3, 0xfd, 0xcb, 0xcd, 0x7f, 0x06, 0x00, 0x17, 0x49, 0x9a, 0x7a, 0xa3, 0x33, 0x00, 0x00,
 }
 
 func (m *AddressGroup) Marshal() (dAtA []byte, err error) {
@@ -3035,6 +3072,23 @@ func (m *NetworkPolicyRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RateLimit != nil {
+		{
+			size, err := m.RateLimit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	i -= len(m.EnforcementMode)
+	copy(dAtA[i:], m.EnforcementMode)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.EnforcementMode)))
+	i--
+	dAtA[i] = 0x62
 	i -= len(m.LogLabel)
 	copy(dAtA[i:], m.LogLabel)
 	i = encodeVarintGenerated(dAtA, i, uint64(len(m.LogLabel)))
@@ -3411,6 +3465,38 @@ func (m *PodReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RuleRateLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RuleRateLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RuleRateLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.BandwidthBurst))
+	i--
+	dAtA[i] = 0x18
+	i = encodeVarintGenerated(dAtA, i, uint64(m.BandwidthRate))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintGenerated(dAtA, i, uint64(m.ConnectionsPerSecond))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
 func (m *Service) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4390,6 +4476,12 @@ func (m *NetworkPolicyRule) Size() (n int) {
 	}
 	l = len(m.LogLabel)
 	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.EnforcementMode)
+	n += 1 + l + sovGenerated(uint64(l))
+	if m.RateLimit != nil {
+		l = m.RateLimit.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
 	return n
 }
 
@@ -4499,6 +4591,18 @@ func (m *PodReference) Size() (n int) {
 	return n
 }
 
+func (m *RuleRateLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovGenerated(uint64(m.ConnectionsPerSecond))
+	n += 1 + sovGenerated(uint64(m.BandwidthRate))
+	n += 1 + sovGenerated(uint64(m.BandwidthBurst))
+	return n
+}
+
 func (m *Service) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5147,6 +5251,8 @@ func (this *NetworkPolicyRule) String() string {
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`L7Protocols:` + repeatedStringForL7Protocols + `,`,
 		`LogLabel:` + fmt.Sprintf("%v", this.LogLabel) + `,`,
+		`EnforcementMode:` + fmt.Sprintf("%v", this.EnforcementMode) + `,`,
+		`RateLimit:` + strings.Replace(this.RateLimit.String(), "RuleRateLimit", "RuleRateLimit", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -5250,6 +5356,18 @@ func (this *PodReference) String() string {
 	}, "")
 	return s
 }
+func (this *RuleRateLimit) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RuleRateLimit{`,
+		`ConnectionsPerSecond:` + fmt.Sprintf("%v", this.ConnectionsPerSecond) + `,`,
+		`BandwidthRate:` + fmt.Sprintf("%v", this.BandwidthRate) + `,`,
+		`BandwidthBurst:` + fmt.Sprintf("%v", this.BandwidthBurst) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *Service) String() string {
 	if this == nil {
 		return "nil"
@@ -10051,6 +10169,74 @@ func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 			}
 			m.LogLabel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnforcementMode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EnforcementMode = antrea_io_antrea_pkg_apis_crd_v1beta1.EnforcementMode(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RateLimit == nil {
+				m.RateLimit = &RuleRateLimit{}
+			}
+			if err := m.RateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -10842,6 +11028,113 @@ func (m *PodReference) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RuleRateLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RuleRateLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RuleRateLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionsPerSecond", wireType)
+			}
+			m.ConnectionsPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConnectionsPerSecond |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BandwidthRate", wireType)
+			}
+			m.BandwidthRate = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BandwidthRate |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BandwidthBurst", wireType)
+			}
+			m.BandwidthBurst = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BandwidthBurst |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Service) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -52,6 +52,9 @@ func normalizeGroupMember(member *GroupMember) groupMemberKey {
 		b.WriteString(member.Service.Namespace)
 		b.WriteString(delimiter)
 		b.WriteString(member.Service.Name)
+	} else if member.Node != nil {
+		b.WriteString("Node:")
+		b.WriteString(member.Node.Name)
 	}
 	for _, ip := range member.IPs {
 		b.Write(ip)
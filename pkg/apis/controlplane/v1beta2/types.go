@@ -277,6 +277,22 @@ type NetworkPolicyRule struct {
 	L7Protocols []L7Protocol `json:"l7Protocols,omitempty" protobuf:"bytes,10,rep,name=l7Protocols"`
 	// LogLabel is a user-defined arbitrary string which will be printed in the NetworkPolicy logs.
 	LogLabel string `json:"logLabel,omitempty" protobuf:"bytes,11,opt,name=logLabel"`
+	// EnforcementMode specifies how the rule is enforced. In Audit mode, the traffic
+	// matching the rule is only logged and counted. Empty for K8s NetworkPolicy.
+	EnforcementMode crdv1beta1.EnforcementMode `json:"enforcementMode,omitempty" protobuf:"bytes,12,opt,name=enforcementMode,casttype=antrea.io/antrea/pkg/apis/crd/v1beta1.EnforcementMode"`
+	// RateLimit specifies the rate limits of the traffic matching the rule. It's set only
+	// when the action of the rule is RateLimit.
+	RateLimit *RuleRateLimit `json:"rateLimit,omitempty" protobuf:"bytes,13,opt,name=rateLimit"`
+}
+
+// RuleRateLimit describes the rate limits of the traffic matching a rule.
+type RuleRateLimit struct {
+	// ConnectionsPerSecond is the maximum rate of new connections. 0 means no limit.
+	ConnectionsPerSecond int32 `json:"connectionsPerSecond,omitempty" protobuf:"varint,1,opt,name=connectionsPerSecond"`
+	// BandwidthRate is the maximum bandwidth in Kbps. 0 means no limit.
+	BandwidthRate int32 `json:"bandwidthRate,omitempty" protobuf:"varint,2,opt,name=bandwidthRate"`
+	// BandwidthBurst is the maximum burst size in Kb when the bandwidth exceeds the rate.
+	BandwidthBurst int32 `json:"bandwidthBurst,omitempty" protobuf:"varint,3,opt,name=bandwidthBurst"`
 }
 
 // Protocol defines network protocols supported for things like container ports.
@@ -409,6 +409,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RuleRateLimit)(nil), (*controlplane.RuleRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_RuleRateLimit_To_controlplane_RuleRateLimit(a.(*RuleRateLimit), b.(*controlplane.RuleRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.RuleRateLimit)(nil), (*RuleRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_RuleRateLimit_To_v1beta2_RuleRateLimit(a.(*controlplane.RuleRateLimit), b.(*RuleRateLimit), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Service)(nil), (*controlplane.Service)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_Service_To_controlplane_Service(a.(*Service), b.(*controlplane.Service), scope)
 	}); err != nil {
@@ -1275,6 +1285,8 @@ func autoConvert_v1beta2_NetworkPolicyRule_To_controlplane_NetworkPolicyRule(in
 	out.Name = in.Name
 	out.L7Protocols = *(*[]controlplane.L7Protocol)(unsafe.Pointer(&in.L7Protocols))
 	out.LogLabel = in.LogLabel
+	out.EnforcementMode = v1beta1.EnforcementMode(in.EnforcementMode)
+	out.RateLimit = (*controlplane.RuleRateLimit)(unsafe.Pointer(in.RateLimit))
 	return nil
 }
 
@@ -1309,6 +1321,8 @@ func autoConvert_controlplane_NetworkPolicyRule_To_v1beta2_NetworkPolicyRule(in
 	out.AppliedToGroups = *(*[]string)(unsafe.Pointer(&in.AppliedToGroups))
 	out.L7Protocols = *(*[]L7Protocol)(unsafe.Pointer(&in.L7Protocols))
 	out.LogLabel = in.LogLabel
+	out.EnforcementMode = v1beta1.EnforcementMode(in.EnforcementMode)
+	out.RateLimit = (*RuleRateLimit)(unsafe.Pointer(in.RateLimit))
 	return nil
 }
 
@@ -1484,6 +1498,30 @@ func Convert_controlplane_PodReference_To_v1beta2_PodReference(in *controlplane.
 	return autoConvert_controlplane_PodReference_To_v1beta2_PodReference(in, out, s)
 }
 
+func autoConvert_v1beta2_RuleRateLimit_To_controlplane_RuleRateLimit(in *RuleRateLimit, out *controlplane.RuleRateLimit, s conversion.Scope) error {
+	out.ConnectionsPerSecond = in.ConnectionsPerSecond
+	out.BandwidthRate = in.BandwidthRate
+	out.BandwidthBurst = in.BandwidthBurst
+	return nil
+}
+
+// Convert_v1beta2_RuleRateLimit_To_controlplane_RuleRateLimit is an autogenerated conversion function.
+func Convert_v1beta2_RuleRateLimit_To_controlplane_RuleRateLimit(in *RuleRateLimit, out *controlplane.RuleRateLimit, s conversion.Scope) error {
+	return autoConvert_v1beta2_RuleRateLimit_To_controlplane_RuleRateLimit(in, out, s)
+}
+
+func autoConvert_controlplane_RuleRateLimit_To_v1beta2_RuleRateLimit(in *controlplane.RuleRateLimit, out *RuleRateLimit, s conversion.Scope) error {
+	out.ConnectionsPerSecond = in.ConnectionsPerSecond
+	out.BandwidthRate = in.BandwidthRate
+	out.BandwidthBurst = in.BandwidthBurst
+	return nil
+}
+
+// Convert_controlplane_RuleRateLimit_To_v1beta2_RuleRateLimit is an autogenerated conversion function.
+func Convert_controlplane_RuleRateLimit_To_v1beta2_RuleRateLimit(in *controlplane.RuleRateLimit, out *RuleRateLimit, s conversion.Scope) error {
+	return autoConvert_controlplane_RuleRateLimit_To_v1beta2_RuleRateLimit(in, out, s)
+}
+
 func autoConvert_v1beta2_Service_To_controlplane_Service(in *Service, out *controlplane.Service, s conversion.Scope) error {
 	out.Protocol = (*controlplane.Protocol)(unsafe.Pointer(in.Protocol))
 	out.Port = (*intstr.IntOrString)(unsafe.Pointer(in.Port))
@@ -61,6 +61,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ExternalNodeList{},
 		&SupportBundleCollection{},
 		&SupportBundleCollectionList{},
+		&PacketCapture{},
+		&PacketCaptureList{},
+		&BGPPolicy{},
+		&BGPPolicyList{},
 	)
 
 	metav1.AddToGroupVersion(
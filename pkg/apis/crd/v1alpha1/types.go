@@ -935,3 +935,216 @@ type TLSProtocol struct {
 	// SNI (Server Name Indication) indicates the server domain name in the TLS/SSL hello message.
 	SNI string `json:"sni,omitempty"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PacketCapture describes an on-demand capture of the packets between a source
+// and a destination, performed by the Antrea Agent on the Node where the source
+// (or destination) Pod is running.
+type PacketCapture struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PacketCaptureSpec   `json:"spec"`
+	Status PacketCaptureStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type PacketCaptureList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PacketCapture `json:"items"`
+}
+
+// PacketCaptureSpec describes the spec of the PacketCapture.
+type PacketCaptureSpec struct {
+	// Timeout specifies the timeout of the PacketCapture in seconds. The
+	// capture session ends when the timeout expires, even if the number of
+	// packets specified in CaptureConfig has not been reached yet. Defaults
+	// to 60 seconds if not set.
+	Timeout *int32 `json:"timeout,omitempty"`
+	// CaptureConfig specifies how many packets should be captured.
+	CaptureConfig CaptureConfig `json:"captureConfig,omitempty"`
+	// Source is the source of the packets. At least one of Source.Pod and
+	// Destination.Pod must be set.
+	Source Source `json:"source"`
+	// Destination is the destination of the packets.
+	Destination Destination `json:"destination"`
+	// Packet specifies the protocol and ports of the packets to capture, in
+	// addition to the source and destination. Packets in both directions are
+	// captured.
+	Packet *PacketCaptureFilter `json:"packet,omitempty"`
+	// FileServer specifies the sftp server the pcapng file is uploaded to.
+	// If not set, the file is only kept on the Node which captured the
+	// packets.
+	FileServer *PacketCaptureFileServer `json:"fileServer,omitempty"`
+}
+
+// CaptureConfig specifies the limit of a capture session.
+type CaptureConfig struct {
+	// FirstN ends the capture session after the first N matching packets
+	// have been captured.
+	FirstN *PacketCaptureFirstNConfig `json:"firstN,omitempty"`
+}
+
+type PacketCaptureFirstNConfig struct {
+	// Number is the number of packets to capture.
+	Number int32 `json:"number"`
+}
+
+// PacketCaptureFilter is a BPF-like filter of the packets to capture.
+type PacketCaptureFilter struct {
+	// Protocol is the IP protocol of the packets. It can be TCP, UDP, SCTP,
+	// ICMP, or an IP protocol number.
+	Protocol *intstr.IntOrString `json:"protocol,omitempty"`
+	// SrcPort is the source port of the packets. It can only be set when
+	// Protocol is TCP, UDP or SCTP.
+	SrcPort *int32 `json:"srcPort,omitempty"`
+	// DstPort is the destination port of the packets. It can only be set
+	// when Protocol is TCP, UDP or SCTP.
+	DstPort *int32 `json:"dstPort,omitempty"`
+}
+
+// PacketCaptureFileServer specifies the file server the captured packets are
+// uploaded to. The credentials to access the file server are read from the
+// Secret "antrea-packetcapture-fileserver-auth" in the Namespace where Antrea
+// is deployed.
+type PacketCaptureFileServer struct {
+	// URL is the URL of the file server. It is set with format:
+	// sftp://host[:port][/path], e.g. sftp://10.92.23.154:22/upload. If the
+	// scheme is not set, sftp is used by default.
+	URL string `json:"url"`
+}
+
+// PacketCaptureStatus describes the current status of the PacketCapture.
+type PacketCaptureStatus struct {
+	// NumberCaptured is the number of packets captured so far.
+	NumberCaptured int32 `json:"numberCaptured,omitempty"`
+	// FilePath is the path of the pcapng file holding the captured packets.
+	// It is the URL on the file server if the file has been uploaded,
+	// otherwise it is the local path on the Node which captured the packets,
+	// with format <Node name>:<path>.
+	FilePath string `json:"filePath,omitempty"`
+	// Conditions represent the latest available observations of the
+	// PacketCapture.
+	Conditions []PacketCaptureCondition `json:"conditions,omitempty"`
+}
+
+type PacketCaptureConditionType string
+
+const (
+	// PacketCaptureStarted is added in a PacketCapture when the Antrea Agent
+	// has started to capture packets.
+	PacketCaptureStarted PacketCaptureConditionType = "PacketCaptureStarted"
+	// PacketCaptureComplete is added in a PacketCapture when the capture
+	// session has ended. Its status is False if the capture failed.
+	PacketCaptureComplete PacketCaptureConditionType = "PacketCaptureComplete"
+	// PacketCaptureFileUploaded is added in a PacketCapture when the Antrea
+	// Agent has tried to upload the pcapng file to the file server. Its
+	// status is False if the upload failed.
+	PacketCaptureFileUploaded PacketCaptureConditionType = "PacketCaptureFileUploaded"
+)
+
+// PacketCaptureCondition describes the state of a PacketCapture at a certain point.
+type PacketCaptureCondition struct {
+	// Type of the condition.
+	Type PacketCaptureConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status metav1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human-readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BGPPolicy defines the BGP configuration of the Nodes it selects, including
+// the BGP peers and the routes advertised to them.
+type BGPPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BGPPolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type BGPPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []BGPPolicy `json:"items"`
+}
+
+// BGPPolicySpec describes the spec of the BGPPolicy.
+type BGPPolicySpec struct {
+	// NodeSelector selects the Nodes the BGPPolicy applies to. If a Node is
+	// selected by multiple BGPPolicies, the oldest one is applied.
+	NodeSelector metav1.LabelSelector `json:"nodeSelector"`
+	// LocalASN is the AS number used by the BGP speakers of the selected
+	// Nodes.
+	LocalASN int32 `json:"localASN"`
+	// ListenPort is the port on which the BGP speakers accept the
+	// connections from their peers. Defaults to 179.
+	ListenPort *int32 `json:"listenPort,omitempty"`
+	// Advertisements specifies the IPs advertised to the BGP peers.
+	Advertisements Advertisements `json:"advertisements,omitempty"`
+	// BGPPeers are the BGP peers of the selected Nodes.
+	BGPPeers []BGPPeer `json:"bgpPeers,omitempty"`
+}
+
+// Advertisements specifies the IPs advertised to the BGP peers. An IP type is
+// not advertised if its field is not set.
+type Advertisements struct {
+	// Service specifies the Service IPs to advertise.
+	Service *ServiceAdvertisement `json:"service,omitempty"`
+	// Pod advertises the Pod CIDRs allocated to the Node by NodeIPAM.
+	Pod *PodAdvertisement `json:"pod,omitempty"`
+	// Egress advertises the Egress IPs assigned to the Node.
+	Egress *EgressAdvertisement `json:"egress,omitempty"`
+}
+
+type ServiceIPType string
+
+const (
+	ServiceIPTypeClusterIP      ServiceIPType = "ClusterIP"
+	ServiceIPTypeExternalIP     ServiceIPType = "ExternalIP"
+	ServiceIPTypeLoadBalancerIP ServiceIPType = "LoadBalancerIP"
+)
+
+type ServiceAdvertisement struct {
+	// IPTypes are the types of Service IPs to advertise. The IPs of a Service
+	// whose externalTrafficPolicy (for ExternalIP and LoadBalancerIP) or
+	// internalTrafficPolicy (for ClusterIP) is Local are only advertised by
+	// the Nodes running its endpoints.
+	IPTypes []ServiceIPType `json:"ipTypes,omitempty"`
+}
+
+type PodAdvertisement struct{}
+
+type EgressAdvertisement struct{}
+
+type BGPPeer struct {
+	// Address is the IP address of the BGP peer.
+	Address string `json:"address"`
+	// Port is the TCP port of the BGP peer. Defaults to 179.
+	Port *int32 `json:"port,omitempty"`
+	// ASN is the AS number of the BGP peer.
+	ASN int32 `json:"asn"`
+	// MultihopTTL is the TTL of the packets sent to the BGP peer, which must
+	// be set if the peer is not directly connected. Defaults to 1 for eBGP
+	// peers.
+	MultihopTTL *int32 `json:"multihopTTL,omitempty"`
+}
@@ -360,6 +360,8 @@ type SubnetIPRange struct {
 }
 
 type IPPoolStatus struct {
+	// Deprecated: the IP addresses allocated from the IPPool are tracked by IPAllocations. The field is only kept to
+	// migrate the allocations made by earlier versions of Antrea, and is cleared after the migration.
 	IPAddresses []IPAddressState `json:"ipAddresses,omitempty"`
 	Usage       IPPoolUsage      `json:"usage,omitempty"`
 }
@@ -425,6 +427,40 @@ type IPPoolList struct {
 // +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// IPAllocation records the allocation of an IP address from an IPPool. Each allocated IP address has its own
+// IPAllocation, named after the IPPool and the IP address, so that IP addresses can be allocated from and released to
+// an IPPool concurrently, without updating the IPPool itself.
+type IPAllocation struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the IPAllocation.
+	Spec IPAllocationSpec `json:"spec"`
+}
+
+type IPAllocationSpec struct {
+	// Name of the IPPool the IP address is allocated from.
+	IPPool string `json:"ipPool"`
+	// The IP address, its allocation state and its owner.
+	IPAddressState `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type IPAllocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []IPAllocation `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // TrafficControl allows mirroring or redirecting the traffic Pods send or receive. It enables users to monitor and
 // analyze Pod traffic, and to enforce custom network protections for Pods with fine-grained control over network
 // traffic.
@@ -212,6 +212,66 @@ type ExternalIPPoolSpec struct {
 	IPRanges []IPRange `json:"ipRanges"`
 	// The Nodes that the external IPs can be assigned to. If empty, it means all Nodes.
 	NodeSelector metav1.LabelSelector `json:"nodeSelector"`
+	// HealthCheck specifies the health checks performed by the Nodes selected by NodeSelector, to determine whether
+	// they can hold the external IPs. A Node failing the health checks will not be assigned any external IP of this
+	// pool until it passes them again. If not set, a Node is eligible as long as it is alive in the memberlist cluster.
+	HealthCheck *ExternalIPPoolHealthCheck `json:"healthCheck,omitempty"`
+	// The Subnet info of this IP pool. If set, all IP ranges in the IP pool should share the same subnet attributes.
+	// Currently, it's only used when an IP is allocated from the pool for Egress, and is ignored otherwise.
+	SubnetInfo *SubnetInfo `json:"subnetInfo,omitempty"`
+}
+
+// SubnetInfo specifies the subnet attributes of IP ranges.
+type SubnetInfo struct {
+	// The gateway of the subnet, used to route the traffic sourced from the IPs of the subnet.
+	Gateway string `json:"gateway"`
+	// The prefix length of the subnet.
+	PrefixLength int32 `json:"prefixLength"`
+	// The VLAN ID of the subnet. 0 means no VLAN tagging, in which case the IPs are assigned to the Node's transport
+	// interface directly.
+	VLAN int32 `json:"vlan,omitempty"`
+}
+
+// ExternalIPPoolHealthCheck describes how the Nodes check their ability to hold the external IPs of an ExternalIPPool,
+// typically the reachability of their uplinks.
+type ExternalIPPoolHealthCheck struct {
+	// Probes are performed periodically by each Node. A probe round fails if any of the probes fails.
+	Probes []HealthProbe `json:"probes"`
+	// How often (in seconds) to perform the probes. Defaults to 10 seconds.
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+	// Number of seconds after which a probe times out. Defaults to 1 second.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// Minimum consecutive failed probe rounds for a Node to be considered unhealthy. Defaults to 3.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+	// Minimum consecutive successful probe rounds for an unhealthy Node to be considered healthy again. Defaults to 1.
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+}
+
+// HealthProbe describes a health probe. Exactly one of the fields must be set.
+type HealthProbe struct {
+	// ICMP sends an ICMP echo request to the target and expects a reply.
+	ICMP *ICMPHealthProbe `json:"icmp,omitempty"`
+	// TCP opens a TCP connection to the target.
+	TCP *TCPHealthProbe `json:"tcp,omitempty"`
+	// Interface checks the link state of a Node's network interface.
+	Interface *InterfaceHealthProbe `json:"interface,omitempty"`
+}
+
+type ICMPHealthProbe struct {
+	// The IP address to probe, e.g. the uplink gateway.
+	Target string `json:"target"`
+}
+
+type TCPHealthProbe struct {
+	// The IP address to probe.
+	Target string `json:"target"`
+	// The TCP port to probe.
+	Port int32 `json:"port"`
+}
+
+type InterfaceHealthProbe struct {
+	// The name of the network interface, which must be up and running.
+	Name string `json:"name"`
 }
 
 // IPRange is a set of contiguous IP addresses, represented by a CIDR or a pair of start and end IPs.
@@ -390,6 +450,11 @@ type NetworkPolicySpec struct {
 	// field within a Rule.
 	// +optional
 	Egress []Rule `json:"egress,omitempty"`
+	// EnforcementMode specifies how the rules of the NetworkPolicy are enforced.
+	// In Audit mode, the traffic matching the rules is logged and counted,
+	// but is not allowed, dropped or rejected. Defaults to Enforce.
+	// +optional
+	EnforcementMode EnforcementMode `json:"enforcementMode,omitempty"`
 }
 
 // NetworkPolicyPhase defines the phase in which a NetworkPolicy is.
@@ -416,6 +481,9 @@ const (
 	NetworkPolicyConditionRealizable NetworkPolicyConditionType = "Realizable"
 	// NetworkPolicyConditionRealizationFailure reports information about a failure when realizing the NetworkPolicy on a Node.
 	NetworkPolicyConditionRealizationFailure NetworkPolicyConditionType = "RealizationFailure"
+	// NetworkPolicyConditionScheduleActive reports whether any rule of the NetworkPolicy with a schedule is active, and
+	// the current schedule states of the rules. It's only set when at least one rule has a schedule.
+	NetworkPolicyConditionScheduleActive NetworkPolicyConditionType = "ScheduleActive"
 )
 
 // NetworkPolicyCondition describes the state of a NetworkPolicy at a certain point.
@@ -503,6 +571,43 @@ type Rule struct {
 	// conjunction with NetworkPolicySpec/ClusterNetworkPolicySpec.AppliedTo.
 	// +optional
 	AppliedTo []AppliedTo `json:"appliedTo,omitempty"`
+	// Schedule defines the time windows during which this rule is active. The rule is
+	// not enforced outside of the windows. If this field is not set, the rule is always
+	// active.
+	// +optional
+	Schedule *RuleSchedule `json:"schedule,omitempty"`
+	// RateLimit specifies the rate limits of the traffic matching this rule. It must
+	// be set if and only if the action of this rule is RateLimit.
+	// +optional
+	RateLimit *RuleRateLimit `json:"rateLimit,omitempty"`
+}
+
+// RuleRateLimit defines the rate limits of the traffic matching a rule with RateLimit
+// action. At least one of the limits must be set. The limits are enforced on each Node
+// separately, and are shared by all the workloads selected by the rule on the Node.
+type RuleRateLimit struct {
+	// ConnectionsPerSecond specifies the maximum rate of new connections matching the
+	// rule. The first packets of the connections exceeding the rate are dropped.
+	// +optional
+	ConnectionsPerSecond *int32 `json:"connectionsPerSecond,omitempty"`
+	// Bandwidth specifies the maximum total bandwidth of the connections matching the
+	// rule, in both directions. The packets exceeding the rate are dropped.
+	// +optional
+	Bandwidth *Bandwidth `json:"bandwidth,omitempty"`
+}
+
+// RuleSchedule defines recurring time windows during which a rule is active.
+type RuleSchedule struct {
+	// Cron is a cron expression in the standard five-field format
+	// ("minute hour day-of-month month day-of-week") which defines the start times
+	// of the windows, e.g. "0 1 * * *" for 01:00 every day.
+	Cron string `json:"cron"`
+	// Duration is the length of each window, e.g. "3h".
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA name of the time zone in which the cron expression is
+	// interpreted, e.g. "America/New_York". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // NetworkPolicyPeer describes the grouping selector of workloads.
@@ -607,6 +712,12 @@ type AppliedTo struct {
 	// Cannot be set with any other selector.
 	// +optional
 	Service *NamespacedName `json:"service,omitempty"`
+	// Select Nodes in cluster as workloads in AppliedTo fields.
+	// Policies that use NodeSelector in AppliedTo are enforced on the
+	// host network of the selected Nodes, and can only be created by
+	// ClusterNetworkPolicies. Cannot be set with any other selector.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
 }
 
 type PeerNamespaces struct {
@@ -628,11 +739,17 @@ const (
 )
 
 // IPBlock describes a particular CIDR (Ex. "192.168.1.1/24") that is allowed
-// or denied to/from the workloads matched by a Spec.AppliedTo.
+// or denied to/from the workloads matched by a Spec.AppliedTo. The except entry
+// describes CIDRs that should not be included within this IPBlock.
 type IPBlock struct {
 	// CIDR is a string representing the IP Block
 	// Valid examples are "192.168.1.1/24".
 	CIDR string `json:"cidr"`
+	// Except is a slice of CIDRs that should not be included within an IP Block
+	// Valid examples are "192.168.1.1/24".
+	// Except values will be rejected if they are outside the CIDR range.
+	// +optional
+	Except []string `json:"except,omitempty"`
 }
 
 // NetworkPolicyPort describes the port and protocol to match in a rule.
@@ -675,6 +792,9 @@ const (
 	// RuleActionReject indicates that the traffic matching the rule must be rejected and the
 	// client will receive a response.
 	RuleActionReject RuleAction = "Reject"
+	// RuleActionRateLimit indicates that the traffic matching the rule is allowed, but is
+	// subject to the rate limits specified by the rule.
+	RuleActionRateLimit RuleAction = "RateLimit"
 
 	IGMPQuery    int32 = 0x11
 	IGMPReportV1 int32 = 0x12
@@ -682,6 +802,18 @@ const (
 	IGMPReportV3 int32 = 0x22
 )
 
+// EnforcementMode describes how the rules of an Antrea-native policy are enforced.
+type EnforcementMode string
+
+const (
+	// EnforcementModeEnforce indicates that the actions of the rules are enforced.
+	EnforcementModeEnforce EnforcementMode = "Enforce"
+	// EnforcementModeAudit indicates that the traffic matching the rules is only
+	// logged and counted, and continues to be evaluated by the following rules and
+	// tiers as if the rules didn't exist.
+	EnforcementModeAudit EnforcementMode = "Audit"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type NetworkPolicyList struct {
@@ -732,6 +864,11 @@ type ClusterNetworkPolicySpec struct {
 	// field within a Rule.
 	// +optional
 	Egress []Rule `json:"egress,omitempty"`
+	// EnforcementMode specifies how the rules of the ClusterNetworkPolicy are enforced.
+	// In Audit mode, the traffic matching the rules is logged and counted,
+	// but is not allowed, dropped or rejected. Defaults to Enforce.
+	// +optional
+	EnforcementMode EnforcementMode `json:"enforcementMode,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -849,6 +986,9 @@ const (
 	// IPAssigned means the Egress has been assigned to a Node.
 	// It is not applicable for Egresses with empty ExternalIPPool.
 	IPAssigned EgressConditionType = "IPAssigned"
+	// UplinkHealthy means all the Nodes eligible for the Egress IP pass the health checks of the ExternalIPPool.
+	// It is only applicable for Egresses whose ExternalIPPool has health checks configured.
+	UplinkHealthy EgressConditionType = "UplinkHealthy"
 )
 
 type EgressCondition struct {
@@ -938,25 +1078,29 @@ const (
 // List the supported protocols and their codes in traceflow.
 // According to code in Antrea agent and controller, default protocol is ICMP if protocol is not inputted by users.
 const (
-	ICMPProtocolNumber int32 = 1
-	IGMPProtocolNumber int32 = 2
-	TCPProtocolNumber  int32 = 6
-	UDPProtocolNumber  int32 = 17
-	SCTPProtocolNumber int32 = 132
+	ICMPProtocolNumber   int32 = 1
+	IGMPProtocolNumber   int32 = 2
+	TCPProtocolNumber    int32 = 6
+	UDPProtocolNumber    int32 = 17
+	ICMPv6ProtocolNumber int32 = 58
+	SCTPProtocolNumber   int32 = 132
 )
 
 var SupportedProtocols = map[string]int32{
-	"TCP":  TCPProtocolNumber,
-	"UDP":  UDPProtocolNumber,
-	"ICMP": ICMPProtocolNumber,
+	"TCP":    TCPProtocolNumber,
+	"UDP":    UDPProtocolNumber,
+	"ICMP":   ICMPProtocolNumber,
+	"SCTP":   SCTPProtocolNumber,
+	"ICMPv6": ICMPv6ProtocolNumber,
 }
 
 var ProtocolsToString = map[int32]string{
-	TCPProtocolNumber:  "TCP",
-	UDPProtocolNumber:  "UDP",
-	ICMPProtocolNumber: "ICMP",
-	IGMPProtocolNumber: "IGMP",
-	SCTPProtocolNumber: "SCTP",
+	TCPProtocolNumber:    "TCP",
+	UDPProtocolNumber:    "UDP",
+	ICMPProtocolNumber:   "ICMP",
+	IGMPProtocolNumber:   "IGMP",
+	SCTPProtocolNumber:   "SCTP",
+	ICMPv6ProtocolNumber: "ICMPv6",
 }
 
 // List the supported destination types in traceflow.
@@ -964,12 +1108,14 @@ const (
 	DstTypePod     = "Pod"
 	DstTypeService = "Service"
 	DstTypeIPv4    = "IPv4"
+	DstTypeIPv6    = "IPv6"
 )
 
 var SupportedDestinationTypes = []string{
 	DstTypePod,
 	DstTypeService,
 	DstTypeIPv4,
+	DstTypeIPv6,
 }
 
 // Default timeout in seconds.
@@ -1011,8 +1157,15 @@ type Source struct {
 	// Pod is the source pod.
 	Pod string `json:"pod,omitempty"`
 	// IP is the source IPv4 or IPv6 address. IP as the source is supported
-	// only for live-traffic Traceflow.
+	// only for live-traffic Traceflow, or for non-live-traffic Traceflow
+	// when Node is also specified.
 	IP string `json:"ip,omitempty"`
+	// Node is the Node on which the Traceflow packet from the source IP
+	// enters the cluster, e.g. the Node whose NodePort or LoadBalancer IP
+	// is accessed by an external client. The packet is injected through the
+	// Antrea gateway of the Node. Node is supported only for
+	// non-live-traffic Traceflow, and is exclusive with source Pod.
+	Node string `json:"node,omitempty"`
 }
 
 // Destination describes the destination spec of the traceflow.
@@ -1050,6 +1203,7 @@ type TransportHeader struct {
 	ICMP *ICMPEchoRequestHeader `json:"icmp,omitempty" yaml:"icmp,omitempty"`
 	UDP  *UDPHeader             `json:"udp,omitempty" yaml:"udp,omitempty"`
 	TCP  *TCPHeader             `json:"tcp,omitempty" yaml:"tcp,omitempty"`
+	SCTP *SCTPHeader            `json:"sctp,omitempty" yaml:"sctp,omitempty"`
 }
 
 // ICMPEchoRequestHeader describes spec of an ICMP echo request header.
@@ -1078,6 +1232,14 @@ type TCPHeader struct {
 	Flags *int32 `json:"flags,omitempty"`
 }
 
+// SCTPHeader describes spec of an SCTP header.
+type SCTPHeader struct {
+	// SrcPort is the source port.
+	SrcPort int32 `json:"srcPort,omitempty"`
+	// DstPort is the destination port.
+	DstPort int32 `json:"dstPort,omitempty"`
+}
+
 // Packet includes header info.
 type Packet struct {
 	SrcIP string `json:"srcIP,omitempty"`
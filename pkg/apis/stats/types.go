@@ -127,6 +127,9 @@ type TrafficStats struct {
 	Bytes int64
 	// Sessions is the sessions count hit by the NetworkPolicy.
 	Sessions int64
+	// RateLimitedPackets is the packets count dropped by the rate limits of the rules
+	// with RateLimit action.
+	RateLimitedPackets int64
 }
 
 // RuleTrafficStats contains TrafficStats of single rule inside a NetworkPolicy.
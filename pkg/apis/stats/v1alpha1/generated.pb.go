@@ -367,51 +367,53 @@ func init() {
 }
 
 var fileDescriptor_91b517c6fa558473 = []byte{
-	// 704 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0x4f, 0x6b, 0x13, 0x4d,
-	0x18, 0xcf, 0x34, 0x2d, 0x6d, 0xa6, 0x79, 0xdf, 0xb7, 0xef, 0x20, 0x12, 0x8a, 0x6c, 0x4b, 0x7a,
-	0x89, 0xa0, 0xb3, 0xb6, 0x48, 0x29, 0xe2, 0xc5, 0xf5, 0x20, 0x05, 0x1b, 0xc3, 0xd4, 0x83, 0x88,
-	0xa2, 0x93, 0xcd, 0x64, 0x33, 0x26, 0xbb, 0xb3, 0xec, 0xcc, 0x56, 0x7a, 0xeb, 0x07, 0xf0, 0xe0,
-	0xa7, 0xf0, 0xb3, 0xf4, 0x58, 0x6f, 0xf5, 0x52, 0x6c, 0x44, 0xf0, 0x2a, 0x5e, 0x3c, 0xca, 0xcc,
-	0x6e, 0xba, 0xd9, 0x86, 0xd2, 0xed, 0x25, 0x1e, 0xf4, 0x94, 0x9d, 0xe7, 0xdf, 0xef, 0xf7, 0x3c,
-	0xcf, 0x6f, 0x86, 0xc0, 0x2d, 0x1a, 0xa8, 0x88, 0x51, 0xcc, 0x85, 0x9d, 0x7c, 0xd9, 0x61, 0xdf,
-	0xb3, 0x69, 0xc8, 0xa5, 0x2d, 0x15, 0x55, 0xd2, 0xde, 0x5b, 0xa7, 0x83, 0xb0, 0x47, 0xd7, 0x6d,
-	0x8f, 0x05, 0x2c, 0xa2, 0x8a, 0x75, 0x70, 0x18, 0x09, 0x25, 0x50, 0x23, 0x89, 0x7f, 0xc5, 0x05,
-	0x4e, 0x6b, 0x84, 0x7d, 0x0f, 0xeb, 0x4c, 0x6c, 0x32, 0xf1, 0x28, 0x73, 0xf9, 0xb6, 0xc7, 0x55,
-	0x2f, 0x6e, 0x63, 0x57, 0xf8, 0xb6, 0x27, 0x3c, 0x61, 0x9b, 0x02, 0xed, 0xb8, 0x6b, 0x4e, 0xe6,
-	0x60, 0xbe, 0x92, 0xc2, 0xcb, 0x77, 0xfb, 0x5b, 0xd2, 0xf0, 0x09, 0xb9, 0x4f, 0xdd, 0x1e, 0x0f,
-	0x58, 0xb4, 0x9f, 0xb1, 0xf2, 0x99, 0xa2, 0xf6, 0xde, 0x04, 0x9d, 0x65, 0xfb, 0xa2, 0xac, 0x28,
-	0x0e, 0x14, 0xf7, 0xd9, 0x44, 0xc2, 0xe6, 0x65, 0x09, 0xd2, 0xed, 0x31, 0x9f, 0x9e, 0xcf, 0xab,
-	0xff, 0x9c, 0x81, 0x2b, 0x0f, 0x4c, 0xc3, 0x0f, 0x07, 0xb1, 0x54, 0x2c, 0x6a, 0x32, 0xf5, 0x56,
-	0x44, 0xfd, 0x96, 0x18, 0x70, 0x77, 0x7f, 0x57, 0xb7, 0x8e, 0x5e, 0xc3, 0x05, 0xcd, 0xb3, 0x43,
-	0x15, 0xad, 0x81, 0x55, 0xd0, 0x58, 0xdc, 0xb8, 0x83, 0x13, 0x38, 0x3c, 0x0e, 0x97, 0x4d, 0x4c,
-	0x47, 0xe3, 0xbd, 0x75, 0xfc, 0xa4, 0xfd, 0x86, 0xb9, 0x6a, 0x87, 0x29, 0xea, 0xa0, 0xc3, 0x93,
-	0x95, 0xd2, 0xf0, 0x64, 0x05, 0x66, 0x36, 0x72, 0x56, 0x15, 0x85, 0xb0, 0xaa, 0x22, 0xda, 0xed,
-	0x72, 0xd7, 0x20, 0xd6, 0x66, 0x0c, 0xca, 0x26, 0x2e, 0xba, 0x14, 0xfc, 0x74, 0x2c, 0xdb, 0xb9,
-	0x96, 0x62, 0x55, 0xc7, 0xad, 0x24, 0x87, 0x80, 0x0e, 0x00, 0x5c, 0x8a, 0xe2, 0x01, 0x1b, 0x0f,
-	0xa9, 0x95, 0x57, 0xcb, 0x8d, 0xc5, 0x8d, 0x7b, 0xc5, 0x61, 0xc9, 0xb9, 0x0a, 0x4e, 0x2d, 0x85,
-	0x5e, 0x3a, 0xef, 0x21, 0x13, 0x68, 0xf5, 0x1f, 0x00, 0xae, 0x5d, 0x32, 0xfa, 0xc7, 0x5c, 0x2a,
-	0xf4, 0x62, 0x62, 0xfc, 0xb8, 0xd8, 0xf8, 0x75, 0xb6, 0x19, 0xfe, 0x52, 0xca, 0x6a, 0x61, 0x64,
-	0x19, 0x1b, 0x7d, 0x00, 0xe7, 0xb8, 0x62, 0xbe, 0x9e, 0xb9, 0x6e, 0x7e, 0xbb, 0x78, 0xf3, 0x97,
-	0x70, 0x77, 0xfe, 0x49, 0x51, 0xe7, 0xb6, 0x75, 0x7d, 0x92, 0xc0, 0xd4, 0xbf, 0xcf, 0xc0, 0x5a,
-	0x92, 0xf9, 0x57, 0x69, 0xd3, 0x52, 0xda, 0x57, 0x00, 0x6f, 0x5c, 0x34, 0xf3, 0x29, 0x48, 0xcc,
-	0xcb, 0x4b, 0xcc, 0xb9, 0xaa, 0xc4, 0x8a, 0x6b, 0x0b, 0xc0, 0x7f, 0x77, 0xe2, 0x81, 0xe2, 0x2e,
-	0x95, 0xea, 0x51, 0x24, 0xe2, 0x70, 0x0a, 0x8a, 0x5a, 0x83, 0x73, 0x9e, 0x86, 0x32, 0x52, 0xaa,
-	0x64, 0xcc, 0x0c, 0x3e, 0x49, 0x7c, 0xe8, 0x19, 0x9c, 0x0d, 0x45, 0x67, 0xb4, 0xf7, 0x2b, 0xc8,
-	0xad, 0x25, 0x3a, 0x84, 0x75, 0x59, 0xc4, 0x02, 0x97, 0x39, 0xd5, 0xb4, 0xf6, 0x6c, 0x4b, 0x74,
-	0x24, 0x31, 0x15, 0xeb, 0x1f, 0x01, 0x44, 0xf9, 0x9e, 0xa7, 0xb0, 0xd1, 0x97, 0xf9, 0x8d, 0x6e,
-	0x15, 0xef, 0x27, 0x4f, 0xf5, 0x82, 0x3d, 0x7e, 0x03, 0x10, 0xfd, 0x19, 0xaf, 0x43, 0xfd, 0x13,
-	0x80, 0xd7, 0x7f, 0xcb, 0xa5, 0xa4, 0xf9, 0x15, 0xde, 0x2f, 0xde, 0x63, 0xe1, 0xeb, 0x48, 0x61,
-	0x75, 0x5c, 0xbe, 0x68, 0x15, 0xce, 0x06, 0xd4, 0x67, 0xa6, 0x99, 0x4a, 0x26, 0xe6, 0x26, 0xf5,
-	0x19, 0x31, 0x1e, 0x64, 0xc3, 0x8a, 0xfe, 0x95, 0x21, 0x75, 0x59, 0x7a, 0x9f, 0xfe, 0x4f, 0xc3,
-	0x2a, 0xcd, 0x91, 0x83, 0x64, 0x31, 0xf5, 0x0f, 0x00, 0x4e, 0x3c, 0x80, 0x05, 0x70, 0xa6, 0xbf,
-	0xe7, 0x77, 0x00, 0xe6, 0xdc, 0xe8, 0x26, 0x9c, 0x0f, 0xa9, 0xdb, 0x67, 0x4a, 0x1a, 0x9e, 0x65,
-	0xe7, 0xbf, 0xb4, 0xca, 0x7c, 0x2b, 0x31, 0x93, 0x91, 0x5f, 0xbf, 0x30, 0xed, 0x7d, 0xc5, 0x12,
-	0x9a, 0xe5, 0x6c, 0xd8, 0x8e, 0x36, 0x92, 0xc4, 0x87, 0x6e, 0xc1, 0x05, 0xc9, 0xa4, 0xe4, 0x22,
-	0xd0, 0xaf, 0x8c, 0x8e, 0x3b, 0xdb, 0xfe, 0x6e, 0x6a, 0x27, 0x67, 0x11, 0x4e, 0xf3, 0xf0, 0xd4,
-	0x2a, 0x1d, 0x9d, 0x5a, 0xa5, 0xe3, 0x53, 0xab, 0x74, 0x30, 0xb4, 0xc0, 0xe1, 0xd0, 0x02, 0x47,
-	0x43, 0x0b, 0x1c, 0x0f, 0x2d, 0xf0, 0x79, 0x68, 0x81, 0xf7, 0x5f, 0xac, 0xd2, 0xf3, 0x46, 0xd1,
-	0xbf, 0xd3, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x73, 0x1f, 0x1a, 0xcb, 0x79, 0x0b, 0x00, 0x00,
+	// 722 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0x4f, 0x6b, 0x13, 0x41,
+	0x14, 0xcf, 0x34, 0x29, 0x6d, 0xa6, 0x51, 0xeb, 0x20, 0x12, 0x82, 0x6c, 0x4b, 0x7a, 0x89, 0xa0,
+	0xb3, 0xb6, 0x48, 0x29, 0xe2, 0xc5, 0xf5, 0x20, 0x95, 0x36, 0x86, 0xa9, 0x07, 0x11, 0x45, 0x27,
+	0x9b, 0xc9, 0x66, 0x4c, 0x76, 0x67, 0xd9, 0x99, 0x54, 0x7a, 0xeb, 0x47, 0xf0, 0x53, 0xf8, 0x59,
+	0x7a, 0xac, 0xb7, 0x7a, 0x29, 0x36, 0x22, 0x78, 0x15, 0x2f, 0x1e, 0x65, 0x66, 0x37, 0x4d, 0xb6,
+	0xb1, 0x74, 0x7b, 0x89, 0x07, 0x3d, 0x65, 0xe7, 0xbd, 0xf7, 0x7b, 0xbf, 0xf7, 0xe7, 0x37, 0x43,
+	0xe0, 0x06, 0x0d, 0x54, 0xc4, 0x28, 0xe6, 0xc2, 0x8e, 0xbf, 0xec, 0xb0, 0xeb, 0xd9, 0x34, 0xe4,
+	0xd2, 0x96, 0x8a, 0x2a, 0x69, 0xef, 0xae, 0xd2, 0x5e, 0xd8, 0xa1, 0xab, 0xb6, 0xc7, 0x02, 0x16,
+	0x51, 0xc5, 0x5a, 0x38, 0x8c, 0x84, 0x12, 0xa8, 0x16, 0xc7, 0xbf, 0xe1, 0x02, 0x27, 0x39, 0xc2,
+	0xae, 0x87, 0x35, 0x12, 0x1b, 0x24, 0x1e, 0x22, 0x2b, 0x77, 0x3d, 0xae, 0x3a, 0xfd, 0x26, 0x76,
+	0x85, 0x6f, 0x7b, 0xc2, 0x13, 0xb6, 0x49, 0xd0, 0xec, 0xb7, 0xcd, 0xc9, 0x1c, 0xcc, 0x57, 0x9c,
+	0xb8, 0x72, 0xbf, 0xbb, 0x21, 0x4d, 0x3d, 0x21, 0xf7, 0xa9, 0xdb, 0xe1, 0x01, 0x8b, 0xf6, 0x46,
+	0x55, 0xf9, 0x4c, 0x51, 0x7b, 0x77, 0xa2, 0x9c, 0x8a, 0x7d, 0x1e, 0x2a, 0xea, 0x07, 0x8a, 0xfb,
+	0x6c, 0x02, 0xb0, 0x7e, 0x11, 0x40, 0xba, 0x1d, 0xe6, 0xd3, 0xb3, 0xb8, 0xea, 0xaf, 0x19, 0xb8,
+	0xf4, 0xc8, 0x34, 0xfc, 0xb8, 0xd7, 0x97, 0x8a, 0x45, 0x75, 0xa6, 0xde, 0x8b, 0xa8, 0xdb, 0x10,
+	0x3d, 0xee, 0xee, 0xed, 0xe8, 0xd6, 0xd1, 0x5b, 0x38, 0xaf, 0xeb, 0x6c, 0x51, 0x45, 0xcb, 0x60,
+	0x19, 0xd4, 0x16, 0xd6, 0xee, 0xe1, 0x98, 0x0e, 0x8f, 0xd3, 0x8d, 0x26, 0xa6, 0xa3, 0xf1, 0xee,
+	0x2a, 0x7e, 0xd6, 0x7c, 0xc7, 0x5c, 0xb5, 0xcd, 0x14, 0x75, 0xd0, 0xc1, 0xf1, 0x52, 0x6e, 0x70,
+	0xbc, 0x04, 0x47, 0x36, 0x72, 0x9a, 0x15, 0x85, 0xb0, 0xa4, 0x22, 0xda, 0x6e, 0x73, 0xd7, 0x30,
+	0x96, 0x67, 0x0c, 0xcb, 0x3a, 0xce, 0xba, 0x14, 0xfc, 0x7c, 0x0c, 0xed, 0xdc, 0x48, 0xb8, 0x4a,
+	0xe3, 0x56, 0x92, 0x62, 0x40, 0xfb, 0x00, 0x2e, 0x46, 0xfd, 0x1e, 0x1b, 0x0f, 0x29, 0xe7, 0x97,
+	0xf3, 0xb5, 0x85, 0xb5, 0x07, 0xd9, 0x69, 0xc9, 0x99, 0x0c, 0x4e, 0x39, 0xa1, 0x5e, 0x3c, 0xeb,
+	0x21, 0x13, 0x6c, 0xd5, 0x9f, 0x00, 0xae, 0x5c, 0x30, 0xfa, 0x2d, 0x2e, 0x15, 0x7a, 0x35, 0x31,
+	0x7e, 0x9c, 0x6d, 0xfc, 0x1a, 0x6d, 0x86, 0xbf, 0x98, 0x54, 0x35, 0x3f, 0xb4, 0x8c, 0x8d, 0x3e,
+	0x80, 0xb3, 0x5c, 0x31, 0x5f, 0xcf, 0x5c, 0x37, 0xbf, 0x99, 0xbd, 0xf9, 0x0b, 0x6a, 0x77, 0xae,
+	0x24, 0xac, 0xb3, 0x9b, 0x3a, 0x3f, 0x89, 0x69, 0xaa, 0x3f, 0x66, 0x60, 0x39, 0x46, 0xfe, 0x57,
+	0xda, 0xb4, 0x94, 0xf6, 0x0d, 0xc0, 0x5b, 0xe7, 0xcd, 0x7c, 0x0a, 0x12, 0xf3, 0xd2, 0x12, 0x73,
+	0x2e, 0x2b, 0xb1, 0xec, 0xda, 0x02, 0xf0, 0xea, 0x76, 0xbf, 0xa7, 0xb8, 0x4b, 0xa5, 0x7a, 0x12,
+	0x89, 0x7e, 0x38, 0x05, 0x45, 0xad, 0xc0, 0x59, 0x4f, 0x53, 0x19, 0x29, 0x15, 0x47, 0x95, 0x19,
+	0x7e, 0x12, 0xfb, 0xd0, 0x0b, 0x58, 0x08, 0x45, 0x6b, 0xb8, 0xf7, 0x4b, 0xc8, 0xad, 0x21, 0x5a,
+	0x84, 0xb5, 0x59, 0xc4, 0x02, 0x97, 0x39, 0xa5, 0x24, 0x77, 0xa1, 0x21, 0x5a, 0x92, 0x98, 0x8c,
+	0xd5, 0x4f, 0x00, 0xa2, 0x74, 0xcf, 0x53, 0xd8, 0xe8, 0xeb, 0xf4, 0x46, 0x37, 0xb2, 0xf7, 0x93,
+	0x2e, 0xf5, 0x9c, 0x3d, 0x7e, 0x07, 0x10, 0xfd, 0x1b, 0xaf, 0x43, 0xf5, 0x33, 0x80, 0x37, 0xff,
+	0xca, 0xa5, 0xa4, 0xe9, 0x15, 0x3e, 0xcc, 0xde, 0x63, 0xe6, 0xeb, 0x48, 0x61, 0x69, 0x5c, 0xbe,
+	0x68, 0x19, 0x16, 0x02, 0xea, 0x33, 0xd3, 0x4c, 0x71, 0x24, 0xe6, 0x3a, 0xf5, 0x19, 0x31, 0x1e,
+	0x64, 0xc3, 0xa2, 0xfe, 0x95, 0x21, 0x75, 0x59, 0x72, 0x9f, 0xae, 0x27, 0x61, 0xc5, 0xfa, 0xd0,
+	0x41, 0x46, 0x31, 0xd5, 0x8f, 0x00, 0x4e, 0x3c, 0x80, 0x19, 0x78, 0xa6, 0xbf, 0xe7, 0x23, 0x00,
+	0x53, 0x6e, 0x74, 0x1b, 0xce, 0x85, 0xd4, 0xed, 0x32, 0x25, 0x4d, 0x9d, 0x79, 0xe7, 0x5a, 0x92,
+	0x65, 0xae, 0x11, 0x9b, 0xc9, 0xd0, 0xaf, 0x5f, 0x98, 0xe6, 0x9e, 0x62, 0x71, 0x99, 0xf9, 0xd1,
+	0xb0, 0x1d, 0x6d, 0x24, 0xb1, 0x0f, 0xdd, 0x81, 0xf3, 0x92, 0x49, 0xc9, 0x45, 0xa0, 0x5f, 0x19,
+	0x1d, 0x77, 0xba, 0xfd, 0x9d, 0xc4, 0x4e, 0x4e, 0x23, 0xd0, 0x53, 0x88, 0x22, 0xaa, 0xd8, 0x16,
+	0xf7, 0xb9, 0x62, 0xad, 0x84, 0xb1, 0x5c, 0x30, 0xb8, 0x4a, 0x82, 0x43, 0x64, 0x22, 0x82, 0xfc,
+	0x01, 0xe5, 0xd4, 0x0f, 0x4e, 0xac, 0xdc, 0xe1, 0x89, 0x95, 0x3b, 0x3a, 0xb1, 0x72, 0xfb, 0x03,
+	0x0b, 0x1c, 0x0c, 0x2c, 0x70, 0x38, 0xb0, 0xc0, 0xd1, 0xc0, 0x02, 0x5f, 0x06, 0x16, 0xf8, 0xf0,
+	0xd5, 0xca, 0xbd, 0xac, 0x65, 0xfd, 0x6b, 0xfe, 0x7b, 0x00, 0x15, 0x45, 0x3c, 0xaf, 0xc5, 0x0b,
+	0x00, 0x00,
 }
 
 func (m *AntreaClusterNetworkPolicyStats) Marshal() (dAtA []byte, err error) {
@@ -902,6 +904,9 @@ func (m *TrafficStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.RateLimitedPackets))
+	i--
+	dAtA[i] = 0x20
 	i = encodeVarintGenerated(dAtA, i, uint64(m.Sessions))
 	i--
 	dAtA[i] = 0x18
@@ -1098,6 +1103,7 @@ func (m *TrafficStats) Size() (n int) {
 	n += 1 + sovGenerated(uint64(m.Packets))
 	n += 1 + sovGenerated(uint64(m.Bytes))
 	n += 1 + sovGenerated(uint64(m.Sessions))
+	n += 1 + sovGenerated(uint64(m.RateLimitedPackets))
 	return n
 }
 
@@ -1263,6 +1269,7 @@ func (this *TrafficStats) String() string {
 		`Packets:` + fmt.Sprintf("%v", this.Packets) + `,`,
 		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
 		`Sessions:` + fmt.Sprintf("%v", this.Sessions) + `,`,
+		`RateLimitedPackets:` + fmt.Sprintf("%v", this.RateLimitedPackets) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2623,6 +2630,25 @@ func (m *TrafficStats) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimitedPackets", wireType)
+			}
+			m.RateLimitedPackets = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RateLimitedPackets |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -40,6 +40,7 @@ import (
 	"antrea.io/antrea/pkg/apiserver/certificate"
 	"antrea.io/antrea/pkg/apiserver/handlers/endpoint"
 	"antrea.io/antrea/pkg/apiserver/handlers/featuregates"
+	"antrea.io/antrea/pkg/apiserver/handlers/fqdncache"
 	"antrea.io/antrea/pkg/apiserver/handlers/loglevel"
 	"antrea.io/antrea/pkg/apiserver/handlers/webhook"
 	"antrea.io/antrea/pkg/apiserver/registry/controlplane/egressgroup"
@@ -108,11 +109,13 @@ type ExtraConfig struct {
 	egressGroupStore              storage.Interface
 	bundleCollectionStore         storage.Interface
 	podInformer                   coreinformers.PodInformer
+	nodeInformer                  coreinformers.NodeInformer
 	eeInformer                    crdv1a2informers.ExternalEntityInformer
 	controllerQuerier             querier.ControllerQuerier
 	endpointQuerier               controllernetworkpolicy.EndpointQuerier
 	networkPolicyController       *controllernetworkpolicy.NetworkPolicyController
 	egressController              *egress.EgressController
+	antreaIPAMController          *ipam.AntreaIPAMController
 	externalIPPoolController      *externalippool.ExternalIPPoolController
 	caCertController              *certificate.CACertController
 	statsAggregator               *stats.Aggregator
@@ -153,6 +156,7 @@ func NewConfig(
 	k8sClient kubernetes.Interface,
 	addressGroupStore, appliedToGroupStore, networkPolicyStore, egressGroupStore, supportBundleCollectionStore storage.Interface,
 	podInformer coreinformers.PodInformer,
+	nodeInformer coreinformers.NodeInformer,
 	eeInformer crdv1a2informers.ExternalEntityInformer,
 	caCertController *certificate.CACertController,
 	statsAggregator *stats.Aggregator,
@@ -161,6 +165,7 @@ func NewConfig(
 	endpointQuerier controllernetworkpolicy.EndpointQuerier,
 	npController *controllernetworkpolicy.NetworkPolicyController,
 	egressController *egress.EgressController,
+	antreaIPAMController *ipam.AntreaIPAMController,
 	bundleCollectionController *controllerbundlecollection.Controller,
 	traceflowController *traceflow.Controller) *Config {
 	return &Config{
@@ -173,6 +178,7 @@ func NewConfig(
 			egressGroupStore:              egressGroupStore,
 			bundleCollectionStore:         supportBundleCollectionStore,
 			podInformer:                   podInformer,
+			nodeInformer:                  nodeInformer,
 			eeInformer:                    eeInformer,
 			caCertController:              caCertController,
 			statsAggregator:               statsAggregator,
@@ -181,6 +187,7 @@ func NewConfig(
 			networkPolicyController:       npController,
 			networkPolicyStatusController: networkPolicyStatusController,
 			egressController:              egressController,
+			antreaIPAMController:          antreaIPAMController,
 			bundleCollectionController:    bundleCollectionController,
 			traceflowController:           traceflowController,
 		},
@@ -298,6 +305,8 @@ func installHandlers(c *ExtraConfig, s *genericapiserver.GenericAPIServer) {
 	// Webhook to mutate Namespace labels and add its metadata.name as a label
 	s.Handler.NonGoRestfulMux.HandleFunc("/mutate/namespace", webhook.HandleMutationLabels())
 	if features.DefaultFeatureGate.Enabled(features.AntreaPolicy) {
+		// Aggregates the FQDN resolution results learned by antrea-agents, so that they can be shared across Nodes.
+		s.Handler.NonGoRestfulMux.HandleFunc("/fqdncache", fqdncache.HandleFunc(fqdncache.NewStore(c.nodeInformer), c.podInformer.Lister()))
 		// Get new NetworkPolicyMutator
 		m := controllernetworkpolicy.NewNetworkPolicyMutator(c.networkPolicyController)
 		// Install handlers for NetworkPolicy related mutation
@@ -335,7 +344,7 @@ func installHandlers(c *ExtraConfig, s *genericapiserver.GenericAPIServer) {
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.AntreaIPAM) {
-		s.Handler.NonGoRestfulMux.HandleFunc("/validate/ippool", webhook.HandlerForValidateFunc(ipam.ValidateIPPool))
+		s.Handler.NonGoRestfulMux.HandleFunc("/validate/ippool", webhook.HandlerForValidateFunc(c.antreaIPAMController.ValidateIPPool))
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.SupportBundleCollection) {
@@ -0,0 +1,213 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fqdncache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/util/env"
+)
+
+const resyncPeriod = 0
+
+var antreaAgentServiceAccountName = strings.Join([]string{
+	"system", "serviceaccount", env.GetAntreaNamespace(), "antrea-agent",
+}, ":")
+
+// Entry is a FQDN resolution result learned by an antrea-agent.
+type Entry struct {
+	FQDN string `json:"fqdn"`
+	// IPs are the addresses the FQDN resolves to.
+	IPs []string `json:"ips"`
+	// ExpirationTime is the time when the addresses expire, i.e. the lookup time plus the lowest applicable TTL.
+	ExpirationTime time.Time `json:"expirationTime"`
+}
+
+// Report is sent by an antrea-agent to publish the FQDN resolution results it has learned.
+type Report struct {
+	NodeName string  `json:"nodeName"`
+	Entries  []Entry `json:"entries"`
+}
+
+// Store aggregates the FQDN resolution results reported by all antrea-agents, so that the addresses learned on one
+// Node can be provisioned on all the Nodes enforcing the same FQDN rules.
+type Store struct {
+	mutex sync.RWMutex
+	// nodeEntries stores the unexpired entries reported by each Node, indexed by Node name.
+	nodeEntries map[string][]Entry
+	nodeLister  corelisters.NodeLister
+	// clock is used to determine whether an entry has expired. It's a field to make testing easier.
+	clock func() time.Time
+}
+
+// NewStore returns a Store. The entries reported by a Node are removed when the Node is deleted.
+func NewStore(nodeInformer coreinformers.NodeInformer) *Store {
+	s := &Store{
+		nodeEntries: map[string][]Entry{},
+		nodeLister:  nodeInformer.Lister(),
+		clock:       time.Now,
+	}
+	nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			DeleteFunc: s.deleteNode,
+		},
+		resyncPeriod,
+	)
+	return s
+}
+
+func (s *Store) deleteNode(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.ErrorS(nil, "Error decoding object when deleting Node", "object", obj)
+			return
+		}
+		node, ok = tombstone.Obj.(*corev1.Node)
+		if !ok {
+			klog.ErrorS(nil, "Error decoding object tombstone when deleting Node", "object", tombstone.Obj)
+			return
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.nodeEntries, node.Name)
+}
+
+// Update replaces the entries reported by a Node. Expired entries are discarded. It returns an error if the Node
+// doesn't exist, so that the entries of a deleted Node are never added back.
+func (s *Store) Update(report *Report) error {
+	if _, err := s.nodeLister.Get(report.NodeName); err != nil {
+		return err
+	}
+	now := s.clock()
+	var entries []Entry
+	for _, entry := range report.Entries {
+		if entry.ExpirationTime.After(now) && len(entry.IPs) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(entries) == 0 {
+		delete(s.nodeEntries, report.NodeName)
+		return nil
+	}
+	s.nodeEntries[report.NodeName] = entries
+	return nil
+}
+
+// List returns the unexpired entries reported by all Nodes other than the provided one, sorted by FQDN.
+func (s *Store) List(excludedNodeName string) []Entry {
+	now := s.clock()
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	entries := []Entry{}
+	for nodeName, nodeEntries := range s.nodeEntries {
+		if nodeName == excludedNodeName {
+			continue
+		}
+		for _, entry := range nodeEntries {
+			if entry.ExpirationTime.After(now) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].FQDN < entries[j].FQDN
+	})
+	return entries
+}
+
+// verifyReporter verifies that the request is sent by the antrea-agent running on the reporting Node. The identity of
+// the antrea-agent Pod is provided by its bound ServiceAccount token.
+func verifyReporter(r *http.Request, podLister corelisters.PodLister, nodeName string) error {
+	user, ok := request.UserFrom(r.Context())
+	if !ok {
+		return fmt.Errorf("user info not found in request")
+	}
+	if user.GetName() != antreaAgentServiceAccountName {
+		return fmt.Errorf("user %s is not antrea-agent", user.GetName())
+	}
+	podNames, podUIDs := user.GetExtra()[serviceaccount.PodNameKey], user.GetExtra()[serviceaccount.PodUIDKey]
+	if len(podNames) == 0 || len(podUIDs) == 0 {
+		return fmt.Errorf("could not determine Pod identity of antrea-agent, a bound ServiceAccount token is required")
+	}
+	pod, err := podLister.Pods(env.GetAntreaNamespace()).Get(podNames[0])
+	if err != nil {
+		return fmt.Errorf("failed to get Pod %s: %w", podNames[0], err)
+	}
+	if pod.UID != types.UID(podUIDs[0]) {
+		return fmt.Errorf("UID of Pod %s doesn't match", podNames[0])
+	}
+	if pod.Spec.NodeName != nodeName {
+		return fmt.Errorf("Pod %s is not running on Node %s", podNames[0], nodeName)
+	}
+	return nil
+}
+
+// HandleFunc returns the function which can handle the /fqdncache API request. A GET request returns the entries
+// learned by the other Nodes, while a POST request updates the entries learned by the reporting Node. A POST request is
+// only accepted from the antrea-agent running on the reporting Node.
+func HandleFunc(s *Store, podLister corelisters.PodLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			entries := s.List(r.URL.Query().Get("node"))
+			if err := json.NewEncoder(w).Encode(entries); err != nil {
+				http.Error(w, "failed to encode response: "+err.Error(), http.StatusInternalServerError)
+			}
+		case http.MethodPost:
+			var report Report
+			if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+				http.Error(w, "failed to decode request: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			if report.NodeName == "" {
+				http.Error(w, "nodeName must be provided", http.StatusBadRequest)
+				return
+			}
+			if err := verifyReporter(r, podLister, report.NodeName); err != nil {
+				klog.ErrorS(err, "Rejected FQDN cache report", "node", report.NodeName)
+				http.Error(w, "failed to verify reporter: "+err.Error(), http.StatusForbidden)
+				return
+			}
+			klog.V(4).InfoS("Received FQDN cache report", "node", report.NodeName, "entries", len(report.Entries))
+			if err := s.Update(&report); err != nil {
+				http.Error(w, "failed to update FQDN cache: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
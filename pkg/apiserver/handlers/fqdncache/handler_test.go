@@ -0,0 +1,165 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fqdncache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"antrea.io/antrea/pkg/util/env"
+)
+
+func newNode(name string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func newAgentPod(name, uid, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: env.GetAntreaNamespace(), Name: name, UID: types.UID(uid)},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func agentUser(podName, podUID string) user.Info {
+	return &user.DefaultInfo{
+		Name: antreaAgentServiceAccountName,
+		Extra: map[string][]string{
+			serviceaccount.PodNameKey: {podName},
+			serviceaccount.PodUIDKey:  {podUID},
+		},
+	}
+}
+
+func TestHandleFunc(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(
+		newNode("node1"),
+		newNode("node2"),
+		newAgentPod("antrea-agent-1", "uid-1", "node1"),
+		newAgentPod("antrea-agent-2", "uid-2", "node2"),
+	)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	store := NewStore(informerFactory.Core().V1().Nodes())
+	store.clock = func() time.Time { return now }
+	handler := HandleFunc(store, informerFactory.Core().V1().Pods().Lister())
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	postAs := func(userInfo user.Info, report Report) int {
+		body, err := json.Marshal(report)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/fqdncache", bytes.NewReader(body))
+		require.NoError(t, err)
+		if userInfo != nil {
+			req = req.WithContext(request.WithUser(req.Context(), userInfo))
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	post := func(report Report) int {
+		switch report.NodeName {
+		case "node1":
+			return postAs(agentUser("antrea-agent-1", "uid-1"), report)
+		case "node2":
+			return postAs(agentUser("antrea-agent-2", "uid-2"), report)
+		}
+		return postAs(nil, report)
+	}
+	get := func(nodeName string) []Entry {
+		req, err := http.NewRequest(http.MethodGet, "/fqdncache?node="+nodeName, nil)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var entries []Entry
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &entries))
+		return entries
+	}
+
+	entryA := Entry{FQDN: "a.example.com", IPs: []string{"1.1.1.1"}, ExpirationTime: now.Add(time.Minute)}
+	entryB := Entry{FQDN: "b.example.com", IPs: []string{"2.2.2.2", "fd00::2"}, ExpirationTime: now.Add(2 * time.Minute)}
+	expiredEntry := Entry{FQDN: "c.example.com", IPs: []string{"3.3.3.3"}, ExpirationTime: now.Add(-time.Second)}
+
+	assert.Equal(t, http.StatusBadRequest, post(Report{Entries: []Entry{entryA}}))
+	assert.Equal(t, http.StatusOK, post(Report{NodeName: "node1", Entries: []Entry{entryB, expiredEntry}}))
+	assert.Equal(t, http.StatusOK, post(Report{NodeName: "node2", Entries: []Entry{entryA}}))
+
+	// Reports are only accepted from the antrea-agent running on the reporting Node.
+	assert.Equal(t, http.StatusForbidden, postAs(nil, Report{NodeName: "node1", Entries: []Entry{entryA}}))
+	assert.Equal(t, http.StatusForbidden, postAs(agentUser("antrea-agent-2", "uid-2"), Report{NodeName: "node1", Entries: []Entry{entryA}}))
+	assert.Equal(t, http.StatusForbidden, postAs(agentUser("antrea-agent-1", "uid-2"), Report{NodeName: "node1", Entries: []Entry{entryA}}))
+	assert.Equal(t, http.StatusForbidden, postAs(&user.DefaultInfo{Name: "system:serviceaccount:default:default"}, Report{NodeName: "node1", Entries: []Entry{entryA}}))
+
+	// The entries of the requesting Node are excluded and the expired entries are discarded.
+	assert.Equal(t, []Entry{entryA}, get("node1"))
+	assert.Equal(t, []Entry{entryB}, get("node2"))
+	assert.Equal(t, []Entry{entryA, entryB}, get(""))
+
+	// A new report replaces the previous entries of the Node.
+	assert.Equal(t, http.StatusOK, post(Report{NodeName: "node1"}))
+	assert.Equal(t, []Entry{entryA}, get(""))
+
+	// Entries expire over time.
+	now = now.Add(time.Minute)
+	assert.Equal(t, []Entry{}, get(""))
+
+	req, err := http.NewRequest(http.MethodDelete, "/fqdncache", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestStoreDeleteNode(t *testing.T) {
+	now := time.Now()
+	client := fake.NewSimpleClientset(newNode("node1"))
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	store := NewStore(informerFactory.Core().V1().Nodes())
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	entry := Entry{FQDN: "a.example.com", IPs: []string{"1.1.1.1"}, ExpirationTime: now.Add(time.Minute)}
+	require.NoError(t, store.Update(&Report{NodeName: "node1", Entries: []Entry{entry}}))
+	// The entries of a Node which doesn't exist are rejected.
+	assert.Error(t, store.Update(&Report{NodeName: "node2", Entries: []Entry{entry}}))
+	assert.Equal(t, []Entry{entry}, store.List(""))
+
+	// The entries of a Node are removed when the Node is deleted.
+	require.NoError(t, client.CoreV1().Nodes().Delete(context.TODO(), "node1", metav1.DeleteOptions{}))
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Empty(c, store.List(""))
+	}, 2*time.Second, 50*time.Millisecond)
+}
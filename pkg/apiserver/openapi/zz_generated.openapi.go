@@ -67,6 +67,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"antrea.io/antrea/pkg/apis/controlplane/v1beta2.NodeStatsSummary":                  schema_pkg_apis_controlplane_v1beta2_NodeStatsSummary(ref),
 		"antrea.io/antrea/pkg/apis/controlplane/v1beta2.PaginationGetOptions":              schema_pkg_apis_controlplane_v1beta2_PaginationGetOptions(ref),
 		"antrea.io/antrea/pkg/apis/controlplane/v1beta2.PodReference":                      schema_pkg_apis_controlplane_v1beta2_PodReference(ref),
+		"antrea.io/antrea/pkg/apis/controlplane/v1beta2.RuleRateLimit":                     schema_pkg_apis_controlplane_v1beta2_RuleRateLimit(ref),
 		"antrea.io/antrea/pkg/apis/controlplane/v1beta2.Service":                           schema_pkg_apis_controlplane_v1beta2_Service(ref),
 		"antrea.io/antrea/pkg/apis/controlplane/v1beta2.ServiceReference":                  schema_pkg_apis_controlplane_v1beta2_ServiceReference(ref),
 		"antrea.io/antrea/pkg/apis/controlplane/v1beta2.SupportBundleCollection":           schema_pkg_apis_controlplane_v1beta2_SupportBundleCollection(ref),
@@ -94,6 +95,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.EgressSpec":                                 schema_pkg_apis_crd_v1beta1_EgressSpec(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.EgressStatus":                               schema_pkg_apis_crd_v1beta1_EgressStatus(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.ExternalIPPool":                             schema_pkg_apis_crd_v1beta1_ExternalIPPool(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.ExternalIPPoolHealthCheck":                  schema_pkg_apis_crd_v1beta1_ExternalIPPoolHealthCheck(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.ExternalIPPoolList":                         schema_pkg_apis_crd_v1beta1_ExternalIPPoolList(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.ExternalIPPoolSpec":                         schema_pkg_apis_crd_v1beta1_ExternalIPPoolSpec(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.ExternalIPPoolStatus":                       schema_pkg_apis_crd_v1beta1_ExternalIPPoolStatus(ref),
@@ -103,7 +105,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.GroupSpec":                                  schema_pkg_apis_crd_v1beta1_GroupSpec(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.GroupStatus":                                schema_pkg_apis_crd_v1beta1_GroupStatus(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.HTTPProtocol":                               schema_pkg_apis_crd_v1beta1_HTTPProtocol(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.HealthProbe":                                schema_pkg_apis_crd_v1beta1_HealthProbe(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.ICMPEchoRequestHeader":                      schema_pkg_apis_crd_v1beta1_ICMPEchoRequestHeader(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.ICMPHealthProbe":                            schema_pkg_apis_crd_v1beta1_ICMPHealthProbe(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.ICMPProtocol":                               schema_pkg_apis_crd_v1beta1_ICMPProtocol(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.IGMPProtocol":                               schema_pkg_apis_crd_v1beta1_IGMPProtocol(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.IPBlock":                                    schema_pkg_apis_crd_v1beta1_IPBlock(ref),
@@ -111,6 +115,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.IPPoolUsage":                                schema_pkg_apis_crd_v1beta1_IPPoolUsage(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.IPRange":                                    schema_pkg_apis_crd_v1beta1_IPRange(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.IPv6Header":                                 schema_pkg_apis_crd_v1beta1_IPv6Header(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.InterfaceHealthProbe":                       schema_pkg_apis_crd_v1beta1_InterfaceHealthProbe(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.L7Protocol":                                 schema_pkg_apis_crd_v1beta1_L7Protocol(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.NamespacedName":                             schema_pkg_apis_crd_v1beta1_NamespacedName(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.NetworkPolicy":                              schema_pkg_apis_crd_v1beta1_NetworkPolicy(ref),
@@ -129,8 +134,13 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.PeerNamespaces":                             schema_pkg_apis_crd_v1beta1_PeerNamespaces(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.PeerService":                                schema_pkg_apis_crd_v1beta1_PeerService(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.Rule":                                       schema_pkg_apis_crd_v1beta1_Rule(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.RuleRateLimit":                              schema_pkg_apis_crd_v1beta1_RuleRateLimit(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.RuleSchedule":                               schema_pkg_apis_crd_v1beta1_RuleSchedule(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.SCTPHeader":                                 schema_pkg_apis_crd_v1beta1_SCTPHeader(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.Source":                                     schema_pkg_apis_crd_v1beta1_Source(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.SubnetInfo":                                 schema_pkg_apis_crd_v1beta1_SubnetInfo(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.TCPHeader":                                  schema_pkg_apis_crd_v1beta1_TCPHeader(ref),
+		"antrea.io/antrea/pkg/apis/crd/v1beta1.TCPHealthProbe":                             schema_pkg_apis_crd_v1beta1_TCPHealthProbe(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.TLSProtocol":                                schema_pkg_apis_crd_v1beta1_TLSProtocol(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.Tier":                                       schema_pkg_apis_crd_v1beta1_Tier(ref),
 		"antrea.io/antrea/pkg/apis/crd/v1beta1.TierList":                                   schema_pkg_apis_crd_v1beta1_TierList(ref),
@@ -1962,12 +1972,25 @@ func schema_pkg_apis_controlplane_v1beta2_NetworkPolicyRule(ref common.Reference
 							Format:      "",
 						},
 					},
+					"enforcementMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcementMode specifies how the rule is enforced. In Audit mode, the traffic matching the rule is only logged and counted. Empty for K8s NetworkPolicy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rateLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RateLimit specifies the rate limits of the traffic matching the rule. It's set only when the action of the rule is RateLimit.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/controlplane/v1beta2.RuleRateLimit"),
+						},
+					},
 				},
 				Required: []string{"enableLogging"},
 			},
 		},
 		Dependencies: []string{
-			"antrea.io/antrea/pkg/apis/controlplane/v1beta2.L7Protocol", "antrea.io/antrea/pkg/apis/controlplane/v1beta2.NetworkPolicyPeer", "antrea.io/antrea/pkg/apis/controlplane/v1beta2.Service"},
+			"antrea.io/antrea/pkg/apis/controlplane/v1beta2.L7Protocol", "antrea.io/antrea/pkg/apis/controlplane/v1beta2.NetworkPolicyPeer", "antrea.io/antrea/pkg/apis/controlplane/v1beta2.RuleRateLimit", "antrea.io/antrea/pkg/apis/controlplane/v1beta2.Service"},
 	}
 }
 
@@ -2242,6 +2265,40 @@ func schema_pkg_apis_controlplane_v1beta2_PodReference(ref common.ReferenceCallb
 	}
 }
 
+func schema_pkg_apis_controlplane_v1beta2_RuleRateLimit(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuleRateLimit describes the rate limits of the traffic matching a rule.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"connectionsPerSecond": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConnectionsPerSecond is the maximum rate of new connections. 0 means no limit.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"bandwidthRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BandwidthRate is the maximum bandwidth in Kbps. 0 means no limit.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"bandwidthBurst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BandwidthBurst is the maximum burst size in Kb when the bandwidth exceeds the rate.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_controlplane_v1beta2_Service(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2982,6 +3039,12 @@ func schema_pkg_apis_crd_v1beta1_AppliedTo(ref common.ReferenceCallback) common.
 							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.NamespacedName"),
 						},
 					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Select Nodes in cluster as workloads in AppliedTo fields. Policies that use NodeSelector in AppliedTo are enforced on the host network of the selected Nodes, and can only be created by ClusterNetworkPolicies. Cannot be set with any other selector.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
 				},
 			},
 		},
@@ -3278,6 +3341,13 @@ func schema_pkg_apis_crd_v1beta1_ClusterNetworkPolicySpec(ref common.ReferenceCa
 							},
 						},
 					},
+					"enforcementMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcementMode specifies how the rules of the ClusterNetworkPolicy are enforced. In Audit mode, the traffic matching the rules is logged and counted, but is not allowed, dropped or rejected. Defaults to Enforce.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"priority"},
 			},
@@ -3692,6 +3762,64 @@ func schema_pkg_apis_crd_v1beta1_ExternalIPPool(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_ExternalIPPoolHealthCheck(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExternalIPPoolHealthCheck describes how the Nodes check their ability to hold the external IPs of an ExternalIPPool, typically the reachability of their uplinks.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"probes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Probes are performed periodically by each Node. A probe round fails if any of the probes fails.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("antrea.io/antrea/pkg/apis/crd/v1beta1.HealthProbe"),
+									},
+								},
+							},
+						},
+					},
+					"periodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How often (in seconds) to perform the probes. Defaults to 10 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of seconds after which a probe times out. Defaults to 1 second.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failureThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum consecutive failed probe rounds for a Node to be considered unhealthy. Defaults to 3.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"successThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum consecutive successful probe rounds for an unhealthy Node to be considered healthy again. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"probes"},
+			},
+		},
+		Dependencies: []string{
+			"antrea.io/antrea/pkg/apis/crd/v1beta1.HealthProbe"},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_ExternalIPPoolList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3767,12 +3895,24 @@ func schema_pkg_apis_crd_v1beta1_ExternalIPPoolSpec(ref common.ReferenceCallback
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
+					"healthCheck": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheck specifies the health checks performed by the Nodes selected by NodeSelector, to determine whether they can hold the external IPs. A Node failing the health checks will not be assigned any external IP of this pool until it passes them again. If not set, a Node is eligible as long as it is alive in the memberlist cluster.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.ExternalIPPoolHealthCheck"),
+						},
+					},
+					"subnetInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The Subnet info of this IP pool. If set, all IP ranges in the IP pool should share the same subnet attributes. Currently, it's only used when an IP is allocated from the pool for Egress, and is ignored otherwise.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.SubnetInfo"),
+						},
+					},
 				},
 				Required: []string{"ipRanges", "nodeSelector"},
 			},
 		},
 		Dependencies: []string{
-			"antrea.io/antrea/pkg/apis/crd/v1beta1.IPRange", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"antrea.io/antrea/pkg/apis/crd/v1beta1.ExternalIPPoolHealthCheck", "antrea.io/antrea/pkg/apis/crd/v1beta1.IPRange", "antrea.io/antrea/pkg/apis/crd/v1beta1.SubnetInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -4058,6 +4198,39 @@ func schema_pkg_apis_crd_v1beta1_HTTPProtocol(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_HealthProbe(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HealthProbe describes a health probe. Exactly one of the fields must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"icmp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ICMP sends an ICMP echo request to the target and expects a reply.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.ICMPHealthProbe"),
+						},
+					},
+					"tcp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TCP opens a TCP connection to the target.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.TCPHealthProbe"),
+						},
+					},
+					"interface": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interface checks the link state of a Node's network interface.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.InterfaceHealthProbe"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"antrea.io/antrea/pkg/apis/crd/v1beta1.ICMPHealthProbe", "antrea.io/antrea/pkg/apis/crd/v1beta1.InterfaceHealthProbe", "antrea.io/antrea/pkg/apis/crd/v1beta1.TCPHealthProbe"},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_ICMPEchoRequestHeader(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4085,6 +4258,27 @@ func schema_pkg_apis_crd_v1beta1_ICMPEchoRequestHeader(ref common.ReferenceCallb
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_ICMPHealthProbe(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The IP address to probe, e.g. the uplink gateway.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"target"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_ICMPProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4139,7 +4333,7 @@ func schema_pkg_apis_crd_v1beta1_IPBlock(ref common.ReferenceCallback) common.Op
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "IPBlock describes a particular CIDR (Ex. \"192.168.1.1/24\") that is allowed or denied to/from the workloads matched by a Spec.AppliedTo.",
+				Description: "IPBlock describes a particular CIDR (Ex. \"192.168.1.1/24\") that is allowed or denied to/from the workloads matched by a Spec.AppliedTo. The except entry describes CIDRs that should not be included within this IPBlock.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"cidr": {
@@ -4150,6 +4344,21 @@ func schema_pkg_apis_crd_v1beta1_IPBlock(ref common.ReferenceCallback) common.Op
 							Format:      "",
 						},
 					},
+					"except": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Except is a slice of CIDRs that should not be included within an IP Block Valid examples are \"192.168.1.1/24\". Except values will be rejected if they are outside the CIDR range.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"cidr"},
 			},
@@ -4281,6 +4490,27 @@ func schema_pkg_apis_crd_v1beta1_IPv6Header(ref common.ReferenceCallback) common
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_InterfaceHealthProbe(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the network interface, which must be up and running.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_L7Protocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4727,6 +4957,13 @@ func schema_pkg_apis_crd_v1beta1_NetworkPolicySpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"enforcementMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcementMode specifies how the rules of the NetworkPolicy are enforced. In Audit mode, the traffic matching the rules is logged and counted, but is not allowed, dropped or rejected. Defaults to Enforce.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"priority"},
 			},
@@ -5223,12 +5460,117 @@ func schema_pkg_apis_crd_v1beta1_Rule(ref common.ReferenceCallback) common.OpenA
 							},
 						},
 					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule defines the time windows during which this rule is active. The rule is not enforced outside of the windows. If this field is not set, the rule is always active.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.RuleSchedule"),
+						},
+					},
+					"rateLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RateLimit specifies the rate limits of the traffic matching this rule. It must be set if and only if the action of this rule is RateLimit.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.RuleRateLimit"),
+						},
+					},
 				},
 				Required: []string{"action"},
 			},
 		},
 		Dependencies: []string{
-			"antrea.io/antrea/pkg/apis/crd/v1beta1.AppliedTo", "antrea.io/antrea/pkg/apis/crd/v1beta1.L7Protocol", "antrea.io/antrea/pkg/apis/crd/v1beta1.NetworkPolicyPeer", "antrea.io/antrea/pkg/apis/crd/v1beta1.NetworkPolicyPort", "antrea.io/antrea/pkg/apis/crd/v1beta1.NetworkPolicyProtocol", "antrea.io/antrea/pkg/apis/crd/v1beta1.PeerService"},
+			"antrea.io/antrea/pkg/apis/crd/v1beta1.AppliedTo", "antrea.io/antrea/pkg/apis/crd/v1beta1.L7Protocol", "antrea.io/antrea/pkg/apis/crd/v1beta1.NetworkPolicyPeer", "antrea.io/antrea/pkg/apis/crd/v1beta1.NetworkPolicyPort", "antrea.io/antrea/pkg/apis/crd/v1beta1.NetworkPolicyProtocol", "antrea.io/antrea/pkg/apis/crd/v1beta1.PeerService", "antrea.io/antrea/pkg/apis/crd/v1beta1.RuleRateLimit", "antrea.io/antrea/pkg/apis/crd/v1beta1.RuleSchedule"},
+	}
+}
+
+func schema_pkg_apis_crd_v1beta1_RuleRateLimit(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuleRateLimit defines the rate limits of the traffic matching a rule with RateLimit action. At least one of the limits must be set. The limits are enforced on each Node separately, and are shared by all the workloads selected by the rule on the Node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"connectionsPerSecond": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConnectionsPerSecond specifies the maximum rate of new connections matching the rule. The first packets of the connections exceeding the rate are dropped.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"bandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bandwidth specifies the maximum total bandwidth of the connections matching the rule, in both directions. The packets exceeding the rate are dropped.",
+							Ref:         ref("antrea.io/antrea/pkg/apis/crd/v1beta1.Bandwidth"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"antrea.io/antrea/pkg/apis/crd/v1beta1.Bandwidth"},
+	}
+}
+
+func schema_pkg_apis_crd_v1beta1_RuleSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuleSchedule defines recurring time windows during which a rule is active.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cron": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cron is a cron expression in the standard five-field format (\"minute hour day-of-month month day-of-week\") which defines the start times of the windows, e.g. \"0 1 * * *\" for 01:00 every day.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the length of each window, e.g. \"3h\".",
+							Default:     0,
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the IANA name of the time zone in which the cron expression is interpreted, e.g. \"America/New_York\". Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cron", "duration"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_crd_v1beta1_SCTPHeader(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SCTPHeader describes spec of an SCTP header.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"srcPort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SrcPort is the source port.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"dstPort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DstPort is the destination port.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -5255,7 +5597,14 @@ func schema_pkg_apis_crd_v1beta1_Source(ref common.ReferenceCallback) common.Ope
 					},
 					"ip": {
 						SchemaProps: spec.SchemaProps{
-							Description: "IP is the source IPv4 or IPv6 address. IP as the source is supported only for live-traffic Traceflow.",
+							Description: "IP is the source IPv4 or IPv6 address. IP as the source is supported only for live-traffic Traceflow, or for non-live-traffic Traceflow when Node is also specified.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"node": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Node is the Node on which the Traceflow packet from the source IP enters the cluster, e.g. the Node whose NodePort or LoadBalancer IP is accessed by an external client. The packet is injected through the Antrea gateway of the Node. Node is supported only for non-live-traffic Traceflow, and is exclusive with source Pod.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -5266,6 +5615,43 @@ func schema_pkg_apis_crd_v1beta1_Source(ref common.ReferenceCallback) common.Ope
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_SubnetInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SubnetInfo specifies the subnet attributes of IP ranges.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"gateway": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The gateway of the subnet, used to route the traffic sourced from the IPs of the subnet.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"prefixLength": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The prefix length of the subnet.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"vlan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The VLAN ID of the subnet. 0 means no VLAN tagging, in which case the IPs are assigned to the Node's transport interface directly.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"gateway", "prefixLength"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_TCPHeader(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5300,6 +5686,35 @@ func schema_pkg_apis_crd_v1beta1_TCPHeader(ref common.ReferenceCallback) common.
 	}
 }
 
+func schema_pkg_apis_crd_v1beta1_TCPHealthProbe(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The IP address to probe.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The TCP port to probe.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"target", "port"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_crd_v1beta1_TLSProtocol(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5672,11 +6087,16 @@ func schema_pkg_apis_crd_v1beta1_TransportHeader(ref common.ReferenceCallback) c
 							Ref: ref("antrea.io/antrea/pkg/apis/crd/v1beta1.TCPHeader"),
 						},
 					},
+					"sctp": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("antrea.io/antrea/pkg/apis/crd/v1beta1.SCTPHeader"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"antrea.io/antrea/pkg/apis/crd/v1beta1.ICMPEchoRequestHeader", "antrea.io/antrea/pkg/apis/crd/v1beta1.TCPHeader", "antrea.io/antrea/pkg/apis/crd/v1beta1.UDPHeader"},
+			"antrea.io/antrea/pkg/apis/crd/v1beta1.ICMPEchoRequestHeader", "antrea.io/antrea/pkg/apis/crd/v1beta1.SCTPHeader", "antrea.io/antrea/pkg/apis/crd/v1beta1.TCPHeader", "antrea.io/antrea/pkg/apis/crd/v1beta1.UDPHeader"},
 	}
 }
 
@@ -6200,6 +6620,13 @@ func schema_pkg_apis_stats_v1alpha1_TrafficStats(ref common.ReferenceCallback) c
 							Format:      "int64",
 						},
 					},
+					"rateLimitedPackets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RateLimitedPackets is the packets count dropped by the rate limits of the rules with RateLimit action.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 			},
 		},
@@ -26,9 +26,11 @@ import (
 
 type CrdV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	BGPPoliciesGetter
 	ClusterNetworkPoliciesGetter
 	ExternalNodesGetter
 	NetworkPoliciesGetter
+	PacketCapturesGetter
 	SupportBundleCollectionsGetter
 	TiersGetter
 	TraceflowsGetter
@@ -39,6 +41,10 @@ type CrdV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *CrdV1alpha1Client) BGPPolicies() BGPPolicyInterface {
+	return newBGPPolicies(c)
+}
+
 func (c *CrdV1alpha1Client) ClusterNetworkPolicies() ClusterNetworkPolicyInterface {
 	return newClusterNetworkPolicies(c)
 }
@@ -51,6 +57,10 @@ func (c *CrdV1alpha1Client) NetworkPolicies(namespace string) NetworkPolicyInter
 	return newNetworkPolicies(c, namespace)
 }
 
+func (c *CrdV1alpha1Client) PacketCaptures() PacketCaptureInterface {
+	return newPacketCaptures(c)
+}
+
 func (c *CrdV1alpha1Client) SupportBundleCollections() SupportBundleCollectionInterface {
 	return newSupportBundleCollections(c)
 }
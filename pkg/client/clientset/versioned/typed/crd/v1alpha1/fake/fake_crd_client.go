@@ -26,6 +26,10 @@ type FakeCrdV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeCrdV1alpha1) BGPPolicies() v1alpha1.BGPPolicyInterface {
+	return &FakeBGPPolicies{c}
+}
+
 func (c *FakeCrdV1alpha1) ClusterNetworkPolicies() v1alpha1.ClusterNetworkPolicyInterface {
 	return &FakeClusterNetworkPolicies{c}
 }
@@ -38,6 +42,10 @@ func (c *FakeCrdV1alpha1) NetworkPolicies(namespace string) v1alpha1.NetworkPoli
 	return &FakeNetworkPolicies{c, namespace}
 }
 
+func (c *FakeCrdV1alpha1) PacketCaptures() v1alpha1.PacketCaptureInterface {
+	return &FakePacketCaptures{c}
+}
+
 func (c *FakeCrdV1alpha1) SupportBundleCollections() v1alpha1.SupportBundleCollectionInterface {
 	return &FakeSupportBundleCollections{c}
 }
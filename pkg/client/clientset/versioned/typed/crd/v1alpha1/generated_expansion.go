@@ -16,12 +16,16 @@
 
 package v1alpha1
 
+type BGPPolicyExpansion interface{}
+
 type ClusterNetworkPolicyExpansion interface{}
 
 type ExternalNodeExpansion interface{}
 
 type NetworkPolicyExpansion interface{}
 
+type PacketCaptureExpansion interface{}
+
 type SupportBundleCollectionExpansion interface{}
 
 type TierExpansion interface{}
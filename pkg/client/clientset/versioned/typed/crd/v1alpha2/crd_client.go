@@ -30,6 +30,7 @@ type CrdV1alpha2Interface interface {
 	EgressesGetter
 	ExternalEntitiesGetter
 	ExternalIPPoolsGetter
+	IPAllocationsGetter
 	IPPoolsGetter
 	TrafficControlsGetter
 }
@@ -55,6 +56,10 @@ func (c *CrdV1alpha2Client) ExternalIPPools() ExternalIPPoolInterface {
 	return newExternalIPPools(c)
 }
 
+func (c *CrdV1alpha2Client) IPAllocations() IPAllocationInterface {
+	return newIPAllocations(c)
+}
+
 func (c *CrdV1alpha2Client) IPPools() IPPoolInterface {
 	return newIPPools(c)
 }
@@ -42,6 +42,10 @@ func (c *FakeCrdV1alpha2) ExternalIPPools() v1alpha2.ExternalIPPoolInterface {
 	return &FakeExternalIPPools{c}
 }
 
+func (c *FakeCrdV1alpha2) IPAllocations() v1alpha2.IPAllocationInterface {
+	return &FakeIPAllocations{c}
+}
+
 func (c *FakeCrdV1alpha2) IPPools() v1alpha2.IPPoolInterface {
 	return &FakeIPPools{c}
 }
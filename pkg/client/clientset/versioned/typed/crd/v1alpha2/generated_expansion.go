@@ -24,6 +24,8 @@ type ExternalEntityExpansion interface{}
 
 type ExternalIPPoolExpansion interface{}
 
+type IPAllocationExpansion interface{}
+
 type IPPoolExpansion interface{}
 
 type TrafficControlExpansion interface{}
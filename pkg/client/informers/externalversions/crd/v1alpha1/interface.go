@@ -22,12 +22,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// BGPPolicies returns a BGPPolicyInformer.
+	BGPPolicies() BGPPolicyInformer
 	// ClusterNetworkPolicies returns a ClusterNetworkPolicyInformer.
 	ClusterNetworkPolicies() ClusterNetworkPolicyInformer
 	// ExternalNodes returns a ExternalNodeInformer.
 	ExternalNodes() ExternalNodeInformer
 	// NetworkPolicies returns a NetworkPolicyInformer.
 	NetworkPolicies() NetworkPolicyInformer
+	// PacketCaptures returns a PacketCaptureInformer.
+	PacketCaptures() PacketCaptureInformer
 	// SupportBundleCollections returns a SupportBundleCollectionInformer.
 	SupportBundleCollections() SupportBundleCollectionInformer
 	// Tiers returns a TierInformer.
@@ -47,6 +51,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// BGPPolicies returns a BGPPolicyInformer.
+func (v *version) BGPPolicies() BGPPolicyInformer {
+	return &bGPPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterNetworkPolicies returns a ClusterNetworkPolicyInformer.
 func (v *version) ClusterNetworkPolicies() ClusterNetworkPolicyInformer {
 	return &clusterNetworkPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -62,6 +71,11 @@ func (v *version) NetworkPolicies() NetworkPolicyInformer {
 	return &networkPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// PacketCaptures returns a PacketCaptureInformer.
+func (v *version) PacketCaptures() PacketCaptureInformer {
+	return &packetCaptureInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // SupportBundleCollections returns a SupportBundleCollectionInformer.
 func (v *version) SupportBundleCollections() SupportBundleCollectionInformer {
 	return &supportBundleCollectionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
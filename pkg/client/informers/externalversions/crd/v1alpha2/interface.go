@@ -30,6 +30,8 @@ type Interface interface {
 	ExternalEntities() ExternalEntityInformer
 	// ExternalIPPools returns a ExternalIPPoolInformer.
 	ExternalIPPools() ExternalIPPoolInformer
+	// IPAllocations returns a IPAllocationInformer.
+	IPAllocations() IPAllocationInformer
 	// IPPools returns a IPPoolInformer.
 	IPPools() IPPoolInformer
 	// TrafficControls returns a TrafficControlInformer.
@@ -67,6 +69,11 @@ func (v *version) ExternalIPPools() ExternalIPPoolInformer {
 	return &externalIPPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IPAllocations returns a IPAllocationInformer.
+func (v *version) IPAllocations() IPAllocationInformer {
+	return &iPAllocationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPPools returns a IPPoolInformer.
 func (v *version) IPPools() IPPoolInformer {
 	return &iPPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
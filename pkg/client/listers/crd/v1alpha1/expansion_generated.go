@@ -16,6 +16,10 @@
 
 package v1alpha1
 
+// BGPPolicyListerExpansion allows custom methods to be added to
+// BGPPolicyLister.
+type BGPPolicyListerExpansion interface{}
+
 // ClusterNetworkPolicyListerExpansion allows custom methods to be added to
 // ClusterNetworkPolicyLister.
 type ClusterNetworkPolicyListerExpansion interface{}
@@ -36,6 +40,10 @@ type NetworkPolicyListerExpansion interface{}
 // NetworkPolicyNamespaceLister.
 type NetworkPolicyNamespaceListerExpansion interface{}
 
+// PacketCaptureListerExpansion allows custom methods to be added to
+// PacketCaptureLister.
+type PacketCaptureListerExpansion interface{}
+
 // SupportBundleCollectionListerExpansion allows custom methods to be added to
 // SupportBundleCollectionLister.
 type SupportBundleCollectionListerExpansion interface{}
@@ -36,6 +36,10 @@ type ExternalEntityNamespaceListerExpansion interface{}
 // ExternalIPPoolLister.
 type ExternalIPPoolListerExpansion interface{}
 
+// IPAllocationListerExpansion allows custom methods to be added to
+// IPAllocationLister.
+type IPAllocationListerExpansion interface{}
+
 // IPPoolListerExpansion allows custom methods to be added to
 // IPPoolLister.
 type IPPoolListerExpansion interface{}
@@ -156,6 +156,12 @@ type AgentConfig struct {
 	// Defaults to "". It must be a host string or a host:port pair of the DNS server (e.g. 10.96.0.10,
 	// 10.96.0.10:53, [fd00:10:96::a]:53).
 	DNSServerOverride string `yaml:"dnsServerOverride,omitempty"`
+	// Enable sharing the FQDN resolution results learned on this Node with other Nodes via antrea-controller, so
+	// that the IPs learned on any Node are provisioned on all the Nodes enforcing the same FQDN policy rules.
+	// This can help with FQDNs which resolve to a rotating set of IPs. It only takes effect when the AntreaPolicy
+	// feature is enabled.
+	// Defaults to false.
+	EnableFQDNCacheSharing bool `yaml:"enableFQDNCacheSharing,omitempty"`
 	// Cipher suites to use.
 	TLSCipherSuites string `yaml:"tlsCipherSuites,omitempty"`
 	// TLS min version.
@@ -239,11 +245,26 @@ type AntreaProxyConfig struct {
 	//                  can reply to clients directly, bypassing the ingress Node.
 	// A Service's load balancer mode can be overridden by annotating it with `service.antrea.io/load-balancer-mode`.
 	DefaultLoadBalancerMode string `yaml:"defaultLoadBalancerMode,omitempty"`
+	// Determines how the Endpoint of a Service is selected for a new connection by default.
+	// It has the following options:
+	// - random (default): Endpoints are selected by hashing the connection over the available Endpoints. The
+	//                     selection may change for many connections when Endpoints are added or removed.
+	// - maglev:           Endpoints are selected with a Maglev consistent hashing lookup table. The selection is the
+	//                     same on all Nodes and only changes for a small portion of connections when Endpoints are
+	//                     added or removed.
+	// A Service's load balancer algorithm can be overridden by annotating it with
+	// `service.antrea.io/load-balancer-algorithm`.
+	DefaultLoadBalancerAlgorithm string `yaml:"defaultLoadBalancerAlgorithm,omitempty"`
 }
 
 type WireGuardConfig struct {
 	// The port for the WireGuard to receive traffic. Defaults to 51820.
 	Port int `yaml:"port,omitempty"`
+	// The interval at which the WireGuard private key is rotated. Before rotating the key, the new public key is
+	// published to the peers, and the old key keeps being used until all peers have accepted the new one, or 10
+	// minutes have passed. It must be at least 10 minutes. Key rotation is disabled if it is empty or 0.
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	KeyRotationInterval string `yaml:"keyRotationInterval,omitempty"`
 }
 
 type NodePortLocalConfig struct {
@@ -54,6 +54,11 @@ type FlowAggregatorConfig struct {
 	ClickHouse ClickHouseConfig `yaml:"clickHouse,omitempty"`
 	// S3Uploader contains configuration options for uploading flow records to AWS S3.
 	S3Uploader S3UploaderConfig `yaml:"s3Uploader,omitempty"`
+	// Kafka contains configuration options for producing flow records to a Kafka topic.
+	Kafka KafkaConfig `yaml:"kafka,omitempty"`
+	// OTLP contains configuration options for exporting flow records to an OpenTelemetry
+	// collector.
+	OTLP OTLPConfig `yaml:"otlp,omitempty"`
 	// FlowLogger contains configuration options for writing flow records to a local log file.
 	FlowLogger FlowLoggerConfig `yaml:"flowLogger,omitempty"`
 }
@@ -152,6 +157,113 @@ type S3UploaderConfig struct {
 	UploadInterval string `yaml:"uploadInterval,omitempty"`
 }
 
+type KafkaConfig struct {
+	// Enable is the switch to enable exporting flow records to Kafka.
+	Enable bool `yaml:"enable,omitempty"`
+	// Brokers is the list of Kafka brokers used to discover the Kafka cluster, with format
+	// <host>:<port>. If this field is empty, initialization will fail.
+	Brokers []string `yaml:"brokers,omitempty"`
+	// Topic is the name of the Kafka topic to which flow records will be produced. If this field
+	// is empty, initialization will fail.
+	Topic string `yaml:"topic"`
+	// PartitionKey is the field of the flow records used as the key of the Kafka messages,
+	// which determines the partition of the topic a flow record is produced to. Flow records
+	// with the same key are produced to the same partition, in order. Supported values are
+	// "FlowKey" (the 5-tuple of the flow), "SourcePodNamespace", "DestinationPodNamespace",
+	// "SourceNodeName" and "None" (flow records are distributed evenly across partitions).
+	// Defaults to "FlowKey".
+	PartitionKey string `yaml:"partitionKey,omitempty"`
+	// RecordFormat defines the encoding of the flow records produced to Kafka. Supported
+	// formats are "Protobuf" and "JSON". Defaults to "Protobuf".
+	RecordFormat string `yaml:"recordFormat,omitempty"`
+	// MaxRecordsPerBatch is the maximum number of flow records sent to Kafka at once.
+	// Defaults to 1000.
+	MaxRecordsPerBatch int32 `yaml:"maxRecordsPerBatch,omitempty"`
+	// MaxBufferedBatches is the maximum number of batches of flow records buffered in memory
+	// while they cannot be sent to Kafka, e.g. because the brokers are unavailable. When the
+	// limit is reached, the oldest batches are dropped. Defaults to 100.
+	MaxBufferedBatches int32 `yaml:"maxBufferedBatches,omitempty"`
+	// FlushInterval is the maximum duration for which flow records are buffered before being
+	// sent to Kafka. Defaults to "1s". Valid time units are "ns", "us" (or "µs"), "ms", "s",
+	// "m", "h". Min value allowed is "100ms".
+	FlushInterval string `yaml:"flushInterval,omitempty"`
+	// TLS configuration options, when using TLS to connect to the Kafka brokers.
+	TLS KafkaTLSConfig `yaml:"tls,omitempty"`
+	// SASL configuration options, when using SASL to authenticate to the Kafka brokers.
+	SASL KafkaSASLConfig `yaml:"sasl,omitempty"`
+}
+
+type KafkaTLSConfig struct {
+	// Enable is the switch to enable TLS when connecting to the Kafka brokers.
+	Enable bool `yaml:"enable,omitempty"`
+	// InsecureSkipVerify determines whether to skip the verification of the server's certificate chain and host name.
+	// Default is false.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+	// CACert determines whether to use custom CA certificate. Default root CAs will be used if false.
+	// If true, a Secret named "flow-aggregator-kafka-tls" must be provided with the following keys:
+	// ca.crt: <CA certificate>
+	CACert bool `yaml:"caCert,omitempty"`
+	// ClientCert determines whether to authenticate to the Kafka brokers with a client certificate.
+	// If true, a Secret named "flow-aggregator-kafka-tls" must be provided with the following keys:
+	// tls.crt: <client certificate>
+	// tls.key: <client private key>
+	ClientCert bool `yaml:"clientCert,omitempty"`
+}
+
+type KafkaSASLConfig struct {
+	// Enable is the switch to enable SASL authentication to the Kafka brokers. The credentials
+	// are read from the KAFKA_USERNAME and KAFKA_PASSWORD environment variables.
+	Enable bool `yaml:"enable,omitempty"`
+	// Mechanism is the SASL mechanism. Supported mechanisms are "PLAIN", "SCRAM-SHA-256" and
+	// "SCRAM-SHA-512". Defaults to "PLAIN".
+	Mechanism string `yaml:"mechanism,omitempty"`
+}
+
+type OTLPConfig struct {
+	// Enable is the switch to enable exporting flow records to an OpenTelemetry collector, as
+	// OTLP logs.
+	Enable bool `yaml:"enable,omitempty"`
+	// Endpoint is the address of the OpenTelemetry collector, with format <host>:<port>. If
+	// this field is empty, initialization will fail.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Protocol is the OTLP transport protocol. Supported protocols are "gRPC" and "HTTP"
+	// (OTLP/HTTP with binary Protobuf payloads). Defaults to "gRPC".
+	Protocol string `yaml:"protocol,omitempty"`
+	// Headers are additional headers (gRPC metadata) sent with each export request, e.g. for
+	// authentication.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// ExportInterval is the interval between each export of flow records to the collector.
+	// Defaults to "5s". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h". Min
+	// value allowed is "1s".
+	ExportInterval string `yaml:"exportInterval,omitempty"`
+	// MaxRecordsPerRequest is the maximum number of flow records sent in a single export
+	// request. Defaults to 1000.
+	MaxRecordsPerRequest int32 `yaml:"maxRecordsPerRequest,omitempty"`
+	// Metrics configuration options, when exporting the throughput between Pods as OTLP
+	// metrics in addition to the flow records.
+	Metrics OTLPMetricsConfig `yaml:"metrics,omitempty"`
+	// TLS configuration options, when using TLS to connect to the collector.
+	TLS OTLPTLSConfig `yaml:"tls,omitempty"`
+}
+
+type OTLPMetricsConfig struct {
+	// Enable is the switch to enable exporting the throughput of the flows between each pair of
+	// Pods as OTLP metrics.
+	Enable bool `yaml:"enable,omitempty"`
+}
+
+type OTLPTLSConfig struct {
+	// Enable is the switch to enable TLS when connecting to the collector.
+	Enable bool `yaml:"enable,omitempty"`
+	// InsecureSkipVerify determines whether to skip the verification of the server's certificate chain and host name.
+	// Default is false.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+	// CACert determines whether to use custom CA certificate. Default root CAs will be used if false.
+	// If true, a Secret named "flow-aggregator-otlp-ca" must be provided with the following keys:
+	// ca.crt: <CA certificate>
+	CACert bool `yaml:"caCert,omitempty"`
+}
+
 type FlowLoggerConfig struct {
 	// Enable is the switch to enable writing flow records to a local log file.
 	Enable bool `yaml:"enable,omitempty"`
@@ -41,6 +41,19 @@ const (
 	DefaultS3UploadInterval    = "60s"
 	MinS3CommitInterval        = 1 * time.Second
 
+	DefaultKafkaPartitionKey       = "FlowKey"
+	DefaultKafkaRecordFormat       = "Protobuf"
+	DefaultKafkaMaxRecordsPerBatch = 1000
+	DefaultKafkaMaxBufferedBatches = 100
+	DefaultKafkaFlushInterval      = "1s"
+	MinKafkaFlushInterval          = 100 * time.Millisecond
+	DefaultKafkaSASLMechanism      = "PLAIN"
+
+	DefaultOTLPProtocol             = "gRPC"
+	DefaultOTLPExportInterval       = "5s"
+	MinOTLPExportInterval           = 1 * time.Second
+	DefaultOTLPMaxRecordsPerRequest = 1000
+
 	DefaultLoggerMaxSize      = 100
 	DefaultLoggerMaxBackups   = 3
 	DefaultLoggerRecordFormat = "CSV"
@@ -88,6 +101,33 @@ func SetConfigDefaults(flowAggregatorConf *FlowAggregatorConfig) {
 	if flowAggregatorConf.S3Uploader.UploadInterval == "" {
 		flowAggregatorConf.S3Uploader.UploadInterval = DefaultS3UploadInterval
 	}
+	if flowAggregatorConf.Kafka.PartitionKey == "" {
+		flowAggregatorConf.Kafka.PartitionKey = DefaultKafkaPartitionKey
+	}
+	if flowAggregatorConf.Kafka.RecordFormat == "" {
+		flowAggregatorConf.Kafka.RecordFormat = DefaultKafkaRecordFormat
+	}
+	if flowAggregatorConf.Kafka.MaxRecordsPerBatch == 0 {
+		flowAggregatorConf.Kafka.MaxRecordsPerBatch = DefaultKafkaMaxRecordsPerBatch
+	}
+	if flowAggregatorConf.Kafka.MaxBufferedBatches == 0 {
+		flowAggregatorConf.Kafka.MaxBufferedBatches = DefaultKafkaMaxBufferedBatches
+	}
+	if flowAggregatorConf.Kafka.FlushInterval == "" {
+		flowAggregatorConf.Kafka.FlushInterval = DefaultKafkaFlushInterval
+	}
+	if flowAggregatorConf.Kafka.SASL.Mechanism == "" {
+		flowAggregatorConf.Kafka.SASL.Mechanism = DefaultKafkaSASLMechanism
+	}
+	if flowAggregatorConf.OTLP.Protocol == "" {
+		flowAggregatorConf.OTLP.Protocol = DefaultOTLPProtocol
+	}
+	if flowAggregatorConf.OTLP.ExportInterval == "" {
+		flowAggregatorConf.OTLP.ExportInterval = DefaultOTLPExportInterval
+	}
+	if flowAggregatorConf.OTLP.MaxRecordsPerRequest == 0 {
+		flowAggregatorConf.OTLP.MaxRecordsPerRequest = DefaultOTLPMaxRecordsPerRequest
+	}
 	if flowAggregatorConf.FlowLogger.Path == "" {
 		flowAggregatorConf.FlowLogger.Path = filepath.Join(os.TempDir(), "antrea-flows.log")
 	}
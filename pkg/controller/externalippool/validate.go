@@ -17,6 +17,7 @@ package externalippool
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 
 	admv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,10 +51,18 @@ func (c *ExternalIPPoolController) ValidateExternalIPPool(review *admv1.Admissio
 	case admv1.Create:
 		// This shouldn't happen with the webhook configuration we include in the Antrea YAML manifests.
 		klog.V(2).Info("Validating CREATE request for ExternalIPPool")
-		// Always allow CREATE request.
+		if err := validateIPRangesAndSubnetInfo(newObj.Spec.IPRanges, newObj.Spec.SubnetInfo); err != nil {
+			allowed = false
+			msg = err.Error()
+		}
 	case admv1.Update:
 		klog.V(2).Info("Validating UPDATE request for ExternalIPPool")
 
+		if err := validateIPRangesAndSubnetInfo(newObj.Spec.IPRanges, newObj.Spec.SubnetInfo); err != nil {
+			allowed = false
+			msg = err.Error()
+			break
+		}
 		oldIPRangeSet := getIPRangeSet(oldObj.Spec.IPRanges)
 		newIPRangeSet := getIPRangeSet(newObj.Spec.IPRanges)
 		deletedIPRanges := oldIPRangeSet.Difference(newIPRangeSet)
@@ -77,6 +86,51 @@ func (c *ExternalIPPoolController) ValidateExternalIPPool(review *admv1.Admissio
 		Result:  result,
 	}
 }
+
+// validateIPRangesAndSubnetInfo validates the SubnetInfo of an ExternalIPPool if it's set, and checks that all IP
+// ranges are within the subnet.
+func validateIPRangesAndSubnetInfo(ipRanges []crdv1beta1.IPRange, subnetInfo *crdv1beta1.SubnetInfo) error {
+	if subnetInfo == nil {
+		return nil
+	}
+	gatewayIP := net.ParseIP(subnetInfo.Gateway)
+	if gatewayIP == nil {
+		return fmt.Errorf("invalid gateway %s", subnetInfo.Gateway)
+	}
+	bits := net.IPv6len * 8
+	if gatewayIP.To4() != nil {
+		bits = net.IPv4len * 8
+	}
+	if subnetInfo.PrefixLength <= 0 || int(subnetInfo.PrefixLength) >= bits {
+		return fmt.Errorf("invalid prefixLength %d for gateway %s", subnetInfo.PrefixLength, subnetInfo.Gateway)
+	}
+	if subnetInfo.VLAN < 0 || subnetInfo.VLAN > 4094 {
+		return fmt.Errorf("invalid vlan %d, it must be between 0 and 4094", subnetInfo.VLAN)
+	}
+	subnet := &net.IPNet{
+		IP:   gatewayIP.Mask(net.CIDRMask(int(subnetInfo.PrefixLength), bits)),
+		Mask: net.CIDRMask(int(subnetInfo.PrefixLength), bits),
+	}
+	for _, ipRange := range ipRanges {
+		if ipRange.CIDR != "" {
+			_, cidr, err := net.ParseCIDR(ipRange.CIDR)
+			if err != nil {
+				return fmt.Errorf("invalid cidr %s: %v", ipRange.CIDR, err)
+			}
+			rangeBits, _ := cidr.Mask.Size()
+			if !subnet.Contains(cidr.IP) || rangeBits < int(subnetInfo.PrefixLength) {
+				return fmt.Errorf("cidr %s must be within the subnet %s", ipRange.CIDR, subnet)
+			}
+		} else {
+			start, end := net.ParseIP(ipRange.Start), net.ParseIP(ipRange.End)
+			if !subnet.Contains(start) || !subnet.Contains(end) {
+				return fmt.Errorf("IP range %s-%s must be within the subnet %s", ipRange.Start, ipRange.End, subnet)
+			}
+		}
+	}
+	return nil
+}
+
 func getIPRangeSet(ipRanges []crdv1beta1.IPRange) sets.Set[string] {
 	set := sets.New[string]()
 	for _, ipRange := range ipRanges {
@@ -24,6 +24,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
+
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
 )
 
 func marshal(object runtime.Object) []byte {
@@ -31,6 +33,16 @@ func marshal(object runtime.Object) []byte {
 	return raw
 }
 
+func newExternalIPPoolWithSubnetInfo(name, cidr, start, end, gateway string, prefixLength, vlan int32) *crdv1beta1.ExternalIPPool {
+	pool := newExternalIPPool(name, cidr, start, end)
+	pool.Spec.SubnetInfo = &crdv1beta1.SubnetInfo{
+		Gateway:      gateway,
+		PrefixLength: prefixLength,
+		VLAN:         vlan,
+	}
+	return pool
+}
+
 func TestControllerValidateExternalIPPool(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -46,6 +58,72 @@ func TestControllerValidateExternalIPPool(t *testing.T) {
 			},
 			expectedResponse: &admv1.AdmissionResponse{Allowed: true},
 		},
+		{
+			name: "CREATE operation with valid SubnetInfo should be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object:    runtime.RawExtension{Raw: marshal(newExternalIPPoolWithSubnetInfo("foo", "10.10.10.0/26", "10.10.10.100", "10.10.10.110", "10.10.10.1", 24, 2))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{Allowed: true},
+		},
+		{
+			name: "CREATE operation with invalid gateway should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object:    runtime.RawExtension{Raw: marshal(newExternalIPPoolWithSubnetInfo("foo", "10.10.10.0/26", "", "", "10.10.10.300", 24, 0))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "invalid gateway 10.10.10.300",
+				},
+			},
+		},
+		{
+			name: "CREATE operation with invalid prefixLength should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object:    runtime.RawExtension{Raw: marshal(newExternalIPPoolWithSubnetInfo("foo", "10.10.10.0/26", "", "", "10.10.10.1", 64, 0))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "invalid prefixLength 64 for gateway 10.10.10.1",
+				},
+			},
+		},
+		{
+			name: "CREATE operation with IP range out of subnet should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object:    runtime.RawExtension{Raw: marshal(newExternalIPPoolWithSubnetInfo("foo", "10.10.10.0/26", "10.10.11.1", "10.10.11.2", "10.10.10.1", 24, 0))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "IP range 10.10.11.1-10.10.11.2 must be within the subnet 10.10.10.0/24",
+				},
+			},
+		},
+		{
+			name: "UPDATE operation with CIDR out of subnet should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "UPDATE",
+				OldObject: runtime.RawExtension{Raw: marshal(newExternalIPPool("foo", "10.10.10.0/26", "", ""))},
+				Object:    runtime.RawExtension{Raw: marshal(newExternalIPPoolWithSubnetInfo("foo", "10.10.10.0/26", "", "", "10.10.10.1", 28, 0))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "cidr 10.10.10.0/26 must be within the subnet 10.10.10.0/28",
+				},
+			},
+		},
 		{
 			name: "Deleting IPRange should not be allowed",
 			request: &admv1.AdmissionRequest{
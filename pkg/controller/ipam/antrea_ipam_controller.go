@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	appsinformers "k8s.io/client-go/informers/apps/v1"
@@ -47,7 +48,7 @@ import (
 const (
 	controllerName = "AntreaIPAMController"
 
-	// StatefulSet index name for IPPool cache.
+	// StatefulSet index name for IPAllocation cache.
 	statefulSetIndex = "statefulSet"
 
 	minRetryDelay = 5 * time.Second
@@ -86,45 +87,56 @@ type AntreaIPAMController struct {
 	ipPoolLister       crdlisters.IPPoolLister
 	ipPoolListerSynced cache.InformerSynced
 
+	// follow changes for IPAllocation objects
+	ipAllocationInformer     crdinformers.IPAllocationInformer
+	ipAllocationCache        *poolallocator.IPAllocationCache
+	ipAllocationListerSynced cache.InformerSynced
+
 	// statusQueue maintains the IPPool objects that need to be synced.
 	statusQueue workqueue.RateLimitingInterface
 }
 
 func statefulSetIndexFunc(obj interface{}) ([]string, error) {
-	ipPool, ok := obj.(*crdv1a2.IPPool)
+	allocation, ok := obj.(*crdv1a2.IPAllocation)
 	if !ok {
-		return nil, fmt.Errorf("obj is not IPPool: %+v", obj)
+		return nil, fmt.Errorf("obj is not IPAllocation: %+v", obj)
 	}
-	statefulSetNames := sets.New[string]()
-	for _, address := range ipPool.Status.IPAddresses {
-		if address.Owner.StatefulSet != nil {
-			statefulSetNames.Insert(k8s.NamespacedName(address.Owner.StatefulSet.Namespace, address.Owner.StatefulSet.Name))
-		}
+	owner := allocation.Spec.Owner.StatefulSet
+	if owner == nil {
+		return nil, nil
 	}
-	return statefulSetNames.UnsortedList(), nil
+	return []string{k8s.NamespacedName(owner.Namespace, owner.Name)}, nil
 }
 
 func NewAntreaIPAMController(crdClient versioned.Interface,
 	ipPoolInformer crdinformers.IPPoolInformer,
+	ipAllocationInformer crdinformers.IPAllocationInformer,
 	namespaceInformer coreinformers.NamespaceInformer,
 	podInformer coreinformers.PodInformer,
 	statefulSetInformer appsinformers.StatefulSetInformer) *AntreaIPAMController {
 
-	ipPoolInformer.Informer().AddIndexers(cache.Indexers{statefulSetIndex: statefulSetIndexFunc})
+	ipAllocationInformer.Informer().AddIndexers(cache.Indexers{
+		poolallocator.IPPoolIndex:    poolallocator.IPPoolIndexFunc,
+		poolallocator.ContainerIndex: poolallocator.ContainerIndexFunc,
+		statefulSetIndex:             statefulSetIndexFunc,
+	})
 
 	c := &AntreaIPAMController{
-		crdClient:               crdClient,
-		statefulSetQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "statefulSetPreallocationAndCleanup"),
-		namespaceLister:         namespaceInformer.Lister(),
-		namespaceListerSynced:   namespaceInformer.Informer().HasSynced,
-		statefulSetInformer:     statefulSetInformer,
-		statefulSetListerSynced: statefulSetInformer.Informer().HasSynced,
-		podLister:               podInformer.Lister(),
-		podInformerSynced:       podInformer.Informer().HasSynced,
-		ipPoolInformer:          ipPoolInformer,
-		ipPoolLister:            ipPoolInformer.Lister(),
-		ipPoolListerSynced:      ipPoolInformer.Informer().HasSynced,
-		statusQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "IPPoolStatus"),
+		crdClient:                crdClient,
+		statefulSetQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "statefulSetPreallocationAndCleanup"),
+		namespaceLister:          namespaceInformer.Lister(),
+		namespaceListerSynced:    namespaceInformer.Informer().HasSynced,
+		statefulSetInformer:      statefulSetInformer,
+		statefulSetListerSynced:  statefulSetInformer.Informer().HasSynced,
+		podLister:                podInformer.Lister(),
+		podInformerSynced:        podInformer.Informer().HasSynced,
+		ipPoolInformer:           ipPoolInformer,
+		ipPoolLister:             ipPoolInformer.Lister(),
+		ipPoolListerSynced:       ipPoolInformer.Informer().HasSynced,
+		ipAllocationInformer:     ipAllocationInformer,
+		ipAllocationCache:        poolallocator.NewIPAllocationCache(ipAllocationInformer),
+		ipAllocationListerSynced: ipAllocationInformer.Informer().HasSynced,
+		statusQueue:              workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "IPPoolStatus"),
 	}
 
 	// Add handlers for Stateful Set events.
@@ -159,11 +171,27 @@ func (c *AntreaIPAMController) enqueueStatefulSetDeleteEvent(obj interface{}) {
 	c.statefulSetQueue.Add(key)
 }
 
-// Inspect all IPPools for stale IP Address entries.
+// Inspect all IPAllocations for stale IP Address entries.
 // This may happen if controller was down during StatefulSet delete event.
-// If such entry is found, enqueue cleanup event for this StatefulSet.
+// If such entry is found, the IPAllocation is updated, or deleted if it has no owner anymore.
 func (c *AntreaIPAMController) cleanupStaleAddresses() {
+	// The IPAddresses recorded in the IPPool status are migrated first, so that they can be
+	// cleaned up like the other ones.
 	pools, _ := c.ipPoolLister.List(labels.Everything())
+	for _, ipPool := range pools {
+		if len(ipPool.Status.IPAddresses) > 0 {
+			c.migrateIPPool(ipPool.Name)
+		}
+	}
+
+	// The IPAllocations are read from the API rather than the cache, to include the ones just
+	// migrated.
+	allocations, err := c.crdClient.CrdV1alpha2().IPAllocations().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		// Next cleanup job will retry
+		klog.ErrorS(err, "Failed to list IPAllocations")
+		return
+	}
 	lister := c.statefulSetInformer.Lister()
 	statefulSets, _ := lister.List(labels.Everything())
 	statefulSetMap := make(map[string]bool)
@@ -175,68 +203,82 @@ func (c *AntreaIPAMController) cleanupStaleAddresses() {
 		statefulSetMap[k8s.NamespacedName(ss.Namespace, ss.Name)] = true
 	}
 
-	poolsUpdated := 0
-	for _, ipPool := range pools {
+	allocationsUpdated := 0
+	for i := range allocations.Items {
+		allocation := &allocations.Items[i]
 		updateNeeded := false
-		ipPoolCopy := ipPool.DeepCopy()
-		var newList []crdv1a2.IPAddressState
-		for _, address := range ipPoolCopy.Status.IPAddresses {
-			// Cleanup reserved addresses
-			if address.Owner.Pod != nil {
-				_, err := c.podLister.Pods(address.Owner.Pod.Namespace).Get(address.Owner.Pod.Name)
-				if err != nil && errors.IsNotFound(err) {
-					klog.InfoS("IPPool contains stale IPAddress for Pod that no longer exists", "IPPool", ipPool.Name, "Namespace", address.Owner.Pod.Namespace, "Pod", address.Owner.Pod.Name)
-					address.Owner.Pod = nil
-					if address.Owner.StatefulSet != nil {
-						address.Phase = crdv1a2.IPAddressPhaseReserved
-					}
-					updateNeeded = true
-				}
-			}
-			if address.Owner.StatefulSet != nil {
-				key := k8s.NamespacedName(address.Owner.StatefulSet.Namespace, address.Owner.StatefulSet.Name)
-				if _, ok := statefulSetMap[key]; !ok {
-					// This entry refers to StatefulSet that no longer exists
-					klog.InfoS("IPPool contains stale IPAddress for StatefulSet that no longer exists", "IPPool", ipPool.Name, "Namespace", address.Owner.StatefulSet.Namespace, "StatefulSet", address.Owner.StatefulSet.Name)
-					address.Owner.StatefulSet = nil
-					updateNeeded = true
-
+		allocationCopy := allocation.DeepCopy()
+		address := &allocationCopy.Spec
+		// Cleanup reserved addresses
+		if address.Owner.Pod != nil {
+			_, err := c.podLister.Pods(address.Owner.Pod.Namespace).Get(address.Owner.Pod.Name)
+			if err != nil && errors.IsNotFound(err) {
+				klog.InfoS("IPPool contains stale IPAddress for Pod that no longer exists", "IPPool", address.IPPool, "Namespace", address.Owner.Pod.Namespace, "Pod", address.Owner.Pod.Name)
+				address.Owner.Pod = nil
+				if address.Owner.StatefulSet != nil {
+					address.Phase = crdv1a2.IPAddressPhaseReserved
 				}
-			}
-
-			if address.Owner.StatefulSet != nil || address.Owner.Pod != nil {
-				newList = append(newList, address)
+				updateNeeded = true
 			}
 		}
-
-		if updateNeeded {
-			ipPoolCopy.Status.IPAddresses = newList
-			_, err := c.crdClient.CrdV1alpha2().IPPools().UpdateStatus(context.TODO(), ipPoolCopy, metav1.UpdateOptions{})
-			if err != nil {
-				// Next cleanup job will retry
-				klog.ErrorS(err, "Updating IP Pool status failed", "IPPool", ipPool.Name)
-			} else {
-				poolsUpdated += 1
+		if address.Owner.StatefulSet != nil {
+			key := k8s.NamespacedName(address.Owner.StatefulSet.Namespace, address.Owner.StatefulSet.Name)
+			if _, ok := statefulSetMap[key]; !ok {
+				// This entry refers to StatefulSet that no longer exists
+				klog.InfoS("IPPool contains stale IPAddress for StatefulSet that no longer exists", "IPPool", address.IPPool, "Namespace", address.Owner.StatefulSet.Namespace, "StatefulSet", address.Owner.StatefulSet.Name)
+				address.Owner.StatefulSet = nil
+				updateNeeded = true
 			}
+		}
 
+		if !updateNeeded {
+			continue
+		}
+		if address.Owner.StatefulSet != nil || address.Owner.Pod != nil {
+			_, err = c.crdClient.CrdV1alpha2().IPAllocations().Update(context.TODO(), allocationCopy, metav1.UpdateOptions{})
+		} else {
+			// The precondition makes sure the IP is not deleted if it has been reallocated.
+			err = c.crdClient.CrdV1alpha2().IPAllocations().Delete(context.TODO(), allocation.Name, metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{UID: &allocation.UID, ResourceVersion: &allocation.ResourceVersion},
+			})
+		}
+		if err != nil {
+			// Next cleanup job will retry
+			klog.ErrorS(err, "Updating IPAllocation failed", "IPAllocation", allocation.Name)
+		} else {
+			allocationsUpdated += 1
 		}
 	}
 
-	klog.InfoS("Cleanup job for IP Pools finished", "updated", poolsUpdated)
+	klog.InfoS("Cleanup job for IP Pools finished", "updated", allocationsUpdated)
 }
 
-// Look for an IP Pool associated with this StatefulSet.
+// migrateIPPool moves the IP addresses recorded in the status of an IPPool by earlier versions to
+// IPAllocations.
+func (c *AntreaIPAMController) migrateIPPool(poolName string) error {
+	allocator, err := poolallocator.NewIPPoolAllocator(poolName, c.crdClient, c.ipPoolLister, c.ipAllocationCache)
+	if err != nil {
+		return fmt.Errorf("failed to initialize allocator for IPPool %s, error: %v", poolName, err)
+	}
+	return allocator.MigrateIPAddressStates()
+}
+
+// Look for the IP Pools with IP addresses associated with this StatefulSet.
 // If IPPool is found, this routine will clear all addresses that might be reserved for the pool.
 func (c *AntreaIPAMController) cleanIPPoolForStatefulSet(namespacedName string) error {
 	klog.InfoS("Processing delete notification", "StatefulSet", namespacedName)
-	ipPools, _ := c.ipPoolInformer.Informer().GetIndexer().ByIndex(statefulSetIndex, namespacedName)
+	allocations, _ := c.ipAllocationInformer.Informer().GetIndexer().ByIndex(statefulSetIndex, namespacedName)
+	ipPoolNames := sets.New[string]()
+	for _, item := range allocations {
+		ipPoolNames.Insert(item.(*crdv1a2.IPAllocation).Spec.IPPool)
+	}
 
-	for _, item := range ipPools {
-		ipPool := item.(*crdv1a2.IPPool)
-		allocator, err := poolallocator.NewIPPoolAllocator(ipPool.Name, c.crdClient, c.ipPoolLister)
+	var errs []error
+	for ipPoolName := range ipPoolNames {
+		allocator, err := poolallocator.NewIPPoolAllocator(ipPoolName, c.crdClient, c.ipPoolLister, c.ipAllocationCache)
 		if err != nil {
 			// This is not a transient error - log and forget
-			klog.ErrorS(err, "Failed to find IP Pool", "IPPool", ipPool.Name)
+			klog.ErrorS(err, "Failed to find IP Pool", "IPPool", ipPoolName)
 			continue
 		}
 
@@ -244,12 +286,12 @@ func (c *AntreaIPAMController) cleanIPPoolForStatefulSet(namespacedName string)
 		err = allocator.ReleaseStatefulSet(namespace, name)
 		if err != nil {
 			// This can be a transient error - worker will retry
-			klog.ErrorS(err, "Failed to clean IP allocations", "StatefulSet", namespacedName, "IPPool", ipPool.Name)
-			continue
+			klog.ErrorS(err, "Failed to clean IP allocations", "StatefulSet", namespacedName, "IPPool", ipPoolName)
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return utilerrors.NewAggregate(errs)
 }
 
 // Find IP Pools annotated to StatefulSet via direct annotation or Namespace annotation
@@ -300,7 +342,7 @@ func (c *AntreaIPAMController) preallocateIPPoolForStatefulSet(ss *appsv1.Statef
 
 	// Only one pool is supported for now. Dual stack support coming in future.
 	ipPoolName := ipPools[0]
-	allocator, err := poolallocator.NewIPPoolAllocator(ipPoolName, c.crdClient, c.ipPoolLister)
+	allocator, err := poolallocator.NewIPPoolAllocator(ipPoolName, c.crdClient, c.ipPoolLister, c.ipAllocationCache)
 	if err != nil {
 		return fmt.Errorf("failed to find IP Pool %s: %s", ipPoolName, err)
 	}
@@ -372,17 +414,23 @@ func (c *AntreaIPAMController) updateIPPoolCounters(poolName string) error {
 		return fmt.Errorf("failed to retrieve IPPool %s, error: %v", poolName, err)
 	}
 
-	allocator, err := poolallocator.NewIPPoolAllocator(ipPool.Name, c.crdClient, c.ipPoolLister)
+	allocator, err := poolallocator.NewIPPoolAllocator(ipPool.Name, c.crdClient, c.ipPoolLister, c.ipAllocationCache)
 
 	if err != nil {
 		return fmt.Errorf("failed to initialize allocator for IPPool %s, error: %v", poolName, err)
 	}
 
+	// The IP addresses recorded in the status by earlier versions are migrated to IPAllocations,
+	// the IPPool will be requeued by the status update.
+	if len(ipPool.Status.IPAddresses) > 0 {
+		return allocator.MigrateIPAddressStates()
+	}
+
 	// Total is fetched from allocator as here are trapped changes to CRD, e.g addition of new IPRange
 	total := allocator.Total()
 
-	// Used is gathered from IP allocation status within the CRD - as it can be set by each one of the agents
-	used := len(ipPool.Status.IPAddresses)
+	// Used is gathered from the IPAllocations of the IPPool - as they can be created by each one of the agents
+	used := allocator.Used()
 
 	// If update has no effect, exit
 	if ipPool.Status.Usage.Used == used && ipPool.Status.Usage.Total == total {
@@ -416,6 +464,23 @@ func (c *AntreaIPAMController) updateHandler(oldObj, newObj interface{}) {
 	c.statusQueue.Add(ipPool.Name)
 }
 
+func (c *AntreaIPAMController) ipAllocationHandler(obj interface{}) {
+	allocation, ok := obj.(*crdv1a2.IPAllocation)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		allocation, ok = deletedState.Obj.(*crdv1a2.IPAllocation)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-IPAllocation object: %v", deletedState.Obj)
+			return
+		}
+	}
+	c.statusQueue.Add(allocation.Spec.IPPool)
+}
+
 func (c *AntreaIPAMController) processNextWorkItem() bool {
 	key, quit := c.statusQueue.Get()
 	if quit {
@@ -454,8 +519,13 @@ func (c *AntreaIPAMController) Run(stopCh <-chan struct{}) {
 		AddFunc:    c.createHandler,
 		UpdateFunc: c.updateHandler,
 	})
+	// Only the number of IPAllocations matters to the IPPool status.
+	c.ipAllocationInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.ipAllocationHandler,
+		DeleteFunc: c.ipAllocationHandler,
+	})
 
-	cacheSyncs := []cache.InformerSynced{c.namespaceListerSynced, c.podInformerSynced, c.statefulSetListerSynced, c.ipPoolListerSynced}
+	cacheSyncs := []cache.InformerSynced{c.namespaceListerSynced, c.podInformerSynced, c.statefulSetListerSynced, c.ipPoolListerSynced, c.ipAllocationListerSynced}
 	if !cache.WaitForNamedCacheSync(controllerName, stopCh, cacheSyncs...) {
 		return
 	}
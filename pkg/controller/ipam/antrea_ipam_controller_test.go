@@ -16,7 +16,6 @@ package ipam
 
 import (
 	"context"
-	"fmt"
 	"testing"
 	"time"
 
@@ -25,6 +24,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
@@ -44,6 +44,7 @@ type fakeAntreaIPAMController struct {
 	informerFactory    informers.SharedInformerFactory
 	crdInformerFactory crdinformers.SharedInformerFactory
 	poolLister         listers.IPPoolLister
+	allocationLister   listers.IPAllocationLister
 }
 
 func newFakeAntreaIPAMController(pool *crdv1a2.IPPool, namespace *corev1.Namespace, statefulSet *appsv1.StatefulSet) *fakeAntreaIPAMController {
@@ -57,8 +58,9 @@ func newFakeAntreaIPAMController(pool *crdv1a2.IPPool, namespace *corev1.Namespa
 	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
 	poolInformer := crdInformerFactory.Crd().V1alpha2().IPPools()
 	poolLister := poolInformer.Lister()
+	allocationInformer := crdInformerFactory.Crd().V1alpha2().IPAllocations()
 
-	controller := NewAntreaIPAMController(crdClient, poolInformer, namespaceInformer, podInformer, statefulSetInformer)
+	controller := NewAntreaIPAMController(crdClient, poolInformer, allocationInformer, namespaceInformer, podInformer, statefulSetInformer)
 	return &fakeAntreaIPAMController{
 		AntreaIPAMController: controller,
 		fakeK8sClient:        k8sClient,
@@ -66,6 +68,7 @@ func newFakeAntreaIPAMController(pool *crdv1a2.IPPool, namespace *corev1.Namespa
 		informerFactory:      informerFactory,
 		crdInformerFactory:   crdInformerFactory,
 		poolLister:           poolLister,
+		allocationLister:     allocationInformer.Lister(),
 	}
 }
 
@@ -121,14 +124,21 @@ func initTestObjects(annotateNamespace bool, annotateStatefulSet bool, replicas
 	return namespace, pool, statefulSet
 }
 
-func verifyPoolAllocatedSize(t *testing.T, poolName string, poolLister listers.IPPoolLister, size int) {
+func verifyPoolAllocatedSize(t *testing.T, poolName string, poolLister listers.IPPoolLister, allocationLister listers.IPAllocationLister, size int) {
 
 	err := wait.PollImmediate(100*time.Millisecond, 1*time.Second, func() (bool, error) {
 		pool, err := poolLister.Get(poolName)
 		if err != nil {
 			return false, nil
 		}
-		if len(pool.Status.IPAddresses) == size {
+		allocations, _ := allocationLister.List(labels.Everything())
+		allocated := 0
+		for _, allocation := range allocations {
+			if allocation.Spec.IPPool == poolName {
+				allocated++
+			}
+		}
+		if allocated == size && pool.Status.Usage.Used == size {
 			return true, nil
 		}
 
@@ -188,7 +198,7 @@ func TestStatefulSetLifecycle(t *testing.T) {
 			var err error
 			// Wait until pool propagates to the informer
 			pollErr := wait.PollImmediate(100*time.Millisecond, 3*time.Second, func() (bool, error) {
-				allocator, err = poolallocator.NewIPPoolAllocator(pool.Name, controller.crdClient, controller.poolLister)
+				allocator, err = poolallocator.NewIPPoolAllocator(pool.Name, controller.crdClient, controller.poolLister, controller.ipAllocationCache)
 				if err != nil {
 					return false, nil
 				}
@@ -198,13 +208,13 @@ func TestStatefulSetLifecycle(t *testing.T) {
 			defer allocator.ReleaseStatefulSet(statefulSet.Namespace, statefulSet.Name)
 
 			// Verify create event was handled by the controller
-			verifyPoolAllocatedSize(t, pool.Name, controller.poolLister, tt.expectAllocatedSize)
+			verifyPoolAllocatedSize(t, pool.Name, controller.poolLister, controller.allocationLister, tt.expectAllocatedSize)
 
 			// Delete StatefulSet
 			controller.fakeK8sClient.AppsV1().StatefulSets(namespace.Name).Delete(context.TODO(), statefulSet.Name, metav1.DeleteOptions{})
 
 			// Verify Delete event was processed
-			verifyPoolAllocatedSize(t, pool.Name, controller.poolLister, 0)
+			verifyPoolAllocatedSize(t, pool.Name, controller.poolLister, controller.allocationLister, 0)
 		})
 	}
 }
@@ -259,21 +269,30 @@ func TestReleaseStaleAddresses(t *testing.T) {
 
 	go controller.Run(stopCh)
 
-	// verify two stale entries were deleted, one updated to Reserved status
+	// verify the entries were migrated to IPAllocations, two stale entries were deleted, one updated to Reserved status
 	err := wait.PollImmediate(100*time.Millisecond, 2*time.Second, func() (bool, error) {
 		pool, err := controller.poolLister.Get(pool.Name)
 		if err != nil {
 			return false, nil
 		}
 
-		if len(pool.Status.IPAddresses) != 2 {
+		if len(pool.Status.IPAddresses) != 0 {
 			t.Logf("IP Pool status: %v", pool.Status.IPAddresses)
 			return false, nil
 		}
 
-		for _, addr := range pool.Status.IPAddresses {
-			if addr.Phase != crdv1a2.IPAddressPhaseReserved {
-				return true, fmt.Errorf("Incorrect phase %s after cleanup", addr.Phase)
+		allocations, err := controller.allocationLister.List(labels.Everything())
+		if err != nil {
+			return false, nil
+		}
+		if len(allocations) != 2 {
+			t.Logf("IPAllocations: %v", allocations)
+			return false, nil
+		}
+
+		for _, allocation := range allocations {
+			if allocation.Spec.Phase != crdv1a2.IPAddressPhaseReserved {
+				return false, nil
 			}
 		}
 
@@ -26,9 +26,10 @@ import (
 	"k8s.io/klog/v2"
 
 	crdv1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	"antrea.io/antrea/pkg/ipam/poolallocator"
 )
 
-func ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
+func (c *AntreaIPAMController) ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
 	var msg string
 	allowed := true
 
@@ -92,7 +93,19 @@ func ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
 		}
 	case admv1.Delete:
 		klog.V(2).Info("Validating DELETE request for IPPool")
-		if len(oldObj.Status.IPAddresses) > 0 {
+		// The IP addresses are recorded in IPAllocations, or in the status by earlier versions before
+		// they are migrated. The IPAllocations are checked directly as the usage in the status is
+		// updated asynchronously.
+		inUse := len(oldObj.Status.IPAddresses) > 0
+		if !inUse {
+			allocations, err := c.ipAllocationInformer.Informer().GetIndexer().ByIndex(poolallocator.IPPoolIndex, oldObj.Name)
+			if err != nil {
+				klog.ErrorS(err, "Error getting IPAllocations of IPPool", "ipPool", oldObj.Name)
+				return newAdmissionResponseForErr(err)
+			}
+			inUse = len(allocations) > 0
+		}
+		if inUse {
 			allowed = false
 			msg = "IPPool in use cannot be deleted"
 		}
@@ -22,8 +22,12 @@ import (
 	admv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
 
 	crdv1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	fakecrd "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
+	"antrea.io/antrea/pkg/ipam/poolallocator"
 )
 
 var testIPPool = &crdv1alpha2.IPPool{
@@ -79,9 +83,10 @@ func copyAndMutateIPPool(in *crdv1alpha2.IPPool, mutateFunc func(*crdv1alpha2.IP
 
 func TestEgressControllerValidateExternalIPPool(t *testing.T) {
 	tests := []struct {
-		name             string
-		request          *admv1.AdmissionRequest
-		expectedResponse *admv1.AdmissionResponse
+		name                string
+		existingAllocations []*crdv1alpha2.IPAllocation
+		request             *admv1.AdmissionRequest
+		expectedResponse    *admv1.AdmissionResponse
 	}{
 		{
 			name: "CREATE operation should be allowed",
@@ -312,6 +317,30 @@ func TestEgressControllerValidateExternalIPPool(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Deleting IPPool with IPAllocations should not be allowed",
+			existingAllocations: []*crdv1alpha2.IPAllocation{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-ip-pool-192.168.0.10"},
+					Spec: crdv1alpha2.IPAllocationSpec{
+						IPPool:         "test-ip-pool",
+						IPAddressState: crdv1alpha2.IPAddressState{IPAddress: "192.168.0.10"},
+					},
+				},
+			},
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "DELETE",
+				// The usage in the status is not updated yet.
+				OldObject: runtime.RawExtension{Raw: marshal(testIPPool)},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "IPPool in use cannot be deleted",
+				},
+			},
+		},
 		{
 			name: "Deleting IPPool not in use should be allowed",
 			request: &admv1.AdmissionRequest{
@@ -327,7 +356,15 @@ func TestEgressControllerValidateExternalIPPool(t *testing.T) {
 			review := &admv1.AdmissionReview{
 				Request: tt.request,
 			}
-			gotResponse := ValidateIPPool(review)
+			crdClient := fakecrd.NewSimpleClientset()
+			crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+			allocationInformer := crdInformerFactory.Crd().V1alpha2().IPAllocations()
+			allocationInformer.Informer().AddIndexers(cache.Indexers{poolallocator.IPPoolIndex: poolallocator.IPPoolIndexFunc})
+			for _, allocation := range tt.existingAllocations {
+				allocationInformer.Informer().GetIndexer().Add(allocation)
+			}
+			c := &AntreaIPAMController{ipAllocationInformer: allocationInformer}
+			gotResponse := c.ValidateIPPool(review)
 			assert.Equal(t, tt.expectedResponse, gotResponse)
 		})
 	}
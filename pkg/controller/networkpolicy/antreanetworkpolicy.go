@@ -94,8 +94,13 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1beta1.Netw
 	// Create AppliedToGroup for each AppliedTo present in AntreaNetworkPolicy spec.
 	atgs := n.processAppliedTo(np.Namespace, np.Spec.AppliedTo)
 	appliedToGroups = mergeAppliedToGroups(appliedToGroups, atgs...)
+	scheduleEvaluator := newRuleScheduleEvaluator(n.clock.Now())
 	// Compute NetworkPolicyRule for Ingress Rule.
 	for idx, ingressRule := range np.Spec.Ingress {
+		// Rules outside of their scheduled windows are not included in the internal NetworkPolicy.
+		if !scheduleEvaluator.isActive(&np.Spec.Ingress[idx]) {
+			continue
+		}
 		// Set default action to ALLOW to allow traffic.
 		services, namedPortExists := toAntreaServicesForCRD(ingressRule.Ports, ingressRule.Protocols)
 		// Create AppliedToGroup for each AppliedTo present in the ingress rule.
@@ -117,10 +122,15 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1beta1.Netw
 			AppliedToGroups: getAppliedToGroupNames(atgs),
 			L7Protocols:     toAntreaL7ProtocolsForCRD(ingressRule.L7Protocols),
 			LogLabel:        ingressRule.LogLabel,
+			EnforcementMode: np.Spec.EnforcementMode,
+			RateLimit:       toAntreaRuleRateLimitForCRD(ingressRule.RateLimit),
 		})
 	}
 	// Compute NetworkPolicyRule for Egress Rule.
 	for idx, egressRule := range np.Spec.Egress {
+		if !scheduleEvaluator.isActive(&np.Spec.Egress[idx]) {
+			continue
+		}
 		// Set default action to ALLOW to allow traffic.
 		services, namedPortExists := toAntreaServicesForCRD(egressRule.Ports, egressRule.Protocols)
 		// Create AppliedToGroup for each AppliedTo present in the egress rule.
@@ -149,6 +159,8 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1beta1.Netw
 			AppliedToGroups: getAppliedToGroupNames(atgs),
 			L7Protocols:     toAntreaL7ProtocolsForCRD(egressRule.L7Protocols),
 			LogLabel:        egressRule.LogLabel,
+			EnforcementMode: np.Spec.EnforcementMode,
+			RateLimit:       toAntreaRuleRateLimitForCRD(egressRule.RateLimit),
 		})
 	}
 	tierPriority := n.getTierPriority(np.Spec.Tier)
@@ -167,6 +179,7 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1beta1.Netw
 		Priority:         &np.Spec.Priority,
 		TierPriority:     &tierPriority,
 		AppliedToPerRule: appliedToPerRule,
+		ScheduleState:    scheduleEvaluator.state,
 	}
 	if n.stretchNPEnabled {
 		n.labelIdentityInterface.RemoveStalePolicySelectors(clusterSetScopeSelectorKeys, internalNetworkPolicyKeyFunc(np))
@@ -181,7 +194,7 @@ func (n *NetworkPolicyController) processAppliedTo(namespace string, appliedTo [
 		if at.Group != "" {
 			atg = n.createAppliedToGroupForGroup(namespace, at.Group)
 		} else {
-			atg = n.createAppliedToGroup(namespace, at.PodSelector, at.NamespaceSelector, at.ExternalEntitySelector)
+			atg = n.createAppliedToGroup(namespace, at.PodSelector, at.NamespaceSelector, at.ExternalEntitySelector, nil)
 		}
 		if atg != nil {
 			appliedToGroups = append(appliedToGroups, atg)
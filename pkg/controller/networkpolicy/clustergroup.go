@@ -65,11 +65,11 @@ func (c *NetworkPolicyController) updateClusterGroup(oldObj, curObj interface{})
 	}
 	ipBlocksUpdated := func() bool {
 		oldIPBs, newIPBs := sets.Set[string]{}, sets.Set[string]{}
-		for _, ipb := range oldGroup.IPBlocks {
-			oldIPBs.Insert(ipb.CIDR.String())
+		for i := range oldGroup.IPBlocks {
+			oldIPBs.Insert(getNormalizedNameForIPBlock(&oldGroup.IPBlocks[i]))
 		}
-		for _, ipb := range newGroup.IPBlocks {
-			newIPBs.Insert(ipb.CIDR.String())
+		for i := range newGroup.IPBlocks {
+			newIPBs.Insert(getNormalizedNameForIPBlock(&newGroup.IPBlocks[i]))
 		}
 		return !oldIPBs.Equal(newIPBs)
 	}
@@ -434,13 +434,24 @@ func (c *NetworkPolicyController) GetGroupMembers(name string) (controlplane.Gro
 	return nil, nil, fmt.Errorf("no internal Group with name %s is found", name)
 }
 
+// ipInIPBlockExcept returns whether the IP is in one of the except CIDRs of the IPBlock.
+func ipInIPBlockExcept(ipBlock *controlplane.IPBlock, ip net.IP) bool {
+	for _, exc := range ipBlock.Except {
+		// The except CIDRs are already validated by the webhook.
+		if _, exceptNet, err := net.ParseCIDR(exc.String()); err == nil && exceptNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *NetworkPolicyController) GetAssociatedIPBlockGroups(ip net.IP) []antreatypes.Group {
 	ipBlockGroupObjs, _ := c.internalGroupStore.GetByIndex(store.IPBlockGroupIndex, store.HasIPBlocks)
 	var matchedGroups []antreatypes.Group
 	for _, obj := range ipBlockGroupObjs {
 		group := obj.(*antreatypes.Group)
-		for _, ipNet := range group.IPNets {
-			if ipNet.Contains(ip) {
+		for i, ipNet := range group.IPNets {
+			if ipNet.Contains(ip) && !ipInIPBlockExcept(&group.IPBlocks[i], ip) {
 				matchedGroups = append(matchedGroups, *group)
 				// Append all parent groups to matchedGroups
 				parentGroups := c.getParentGroups(group.SourceReference.ToGroupName())
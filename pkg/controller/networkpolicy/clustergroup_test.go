@@ -1129,7 +1129,7 @@ func TestGetAssociatedIPBlockGroups(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "ipBlockGrp1", UID: "UID1"},
 		Spec: crdv1beta1.GroupSpec{
 			IPBlocks: []crdv1beta1.IPBlock{
-				{CIDR: "172.60.0.0/16"},
+				{CIDR: "172.60.0.0/16", Except: []string{"172.60.3.0/24"}},
 			},
 		},
 	}
@@ -1178,6 +1178,11 @@ func TestGetAssociatedIPBlockGroups(t *testing.T) {
 			ipQuery:        net.ParseIP("172.60.2.1"),
 			expectedGroups: []string{"ipBlockGrp1", "ipBlockGrp2", "ipBlockParentGrp"},
 		},
+		{
+			name:           "excepted-group-association",
+			ipQuery:        net.ParseIP("172.60.3.1"),
+			expectedGroups: []string{},
+		},
 		{
 			name:           "no-group-association",
 			ipQuery:        net.ParseIP("172.160.0.1"),
@@ -235,6 +235,19 @@ func (c *NetworkPolicyController) filterAGsFromNodeLabels(node *v1.Node) sets.Se
 	return ags
 }
 
+func (c *NetworkPolicyController) filterATGsFromNodeLabels(node *v1.Node) sets.Set[string] {
+	atgs := sets.New[string]()
+	appliedToGroupObjs, _ := c.appliedToGroupStore.GetByIndex(store.IsNodeAppliedToGroupIndex, "true")
+	for _, appliedToGroupObj := range appliedToGroupObjs {
+		appliedToGroup := appliedToGroupObj.(*antreatypes.AppliedToGroup)
+		nodeSelector := appliedToGroup.Selector.NodeSelector
+		if nodeSelector.Matches(labels.Set(node.GetLabels())) {
+			atgs.Insert(appliedToGroup.Name)
+		}
+	}
+	return atgs
+}
+
 func (c *NetworkPolicyController) getATGsAppliedToService() sets.Set[string] {
 	atgs := sets.New[string]()
 	appliedToGroupObjs, _ := c.appliedToGroupStore.GetByIndex(store.IsAppliedToServiceIndex, "true")
@@ -253,10 +266,12 @@ func (c *NetworkPolicyController) addNode(obj interface{}) {
 	}
 	// All AppliedToGroups that are applied to Services need re-sync.
 	affectedATGs := c.getATGsAppliedToService()
+	// AppliedToGroups that select the Node via nodeSelector need re-sync.
+	affectedATGs = utilsets.MergeString(affectedATGs, c.filterATGsFromNodeLabels(node))
 	for key := range affectedATGs {
 		c.enqueueAppliedToGroup(key)
 	}
-	klog.V(2).InfoS("Processed Node CREATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len())
+	klog.V(2).InfoS("Processed Node CREATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len(), "affectedATGs", affectedATGs.Len())
 }
 
 func (c *NetworkPolicyController) deleteNode(obj interface{}) {
@@ -280,10 +295,12 @@ func (c *NetworkPolicyController) deleteNode(obj interface{}) {
 	}
 	// All AppliedToGroups that are applied to Services need re-sync.
 	affectedATGs := c.getATGsAppliedToService()
+	// AppliedToGroups that select the Node via nodeSelector need re-sync.
+	affectedATGs = utilsets.MergeString(affectedATGs, c.filterATGsFromNodeLabels(node))
 	for key := range affectedATGs {
 		c.enqueueAppliedToGroup(key)
 	}
-	klog.V(2).InfoS("Processed Node DELETE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len())
+	klog.V(2).InfoS("Processed Node DELETE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len(), "affectedATGs", affectedATGs.Len())
 }
 
 func nodeIPChanged(oldNode, newNode *v1.Node) (changed bool) {
@@ -314,7 +331,15 @@ func (c *NetworkPolicyController) updateNode(oldObj, newObj interface{}) {
 	for ag := range affectedAGs {
 		c.enqueueAddressGroup(ag)
 	}
-	klog.V(2).InfoS("Processed Node UPDATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len())
+	// The members of AppliedToGroups don't include Node IPs, so they only need re-sync when labels change.
+	affectedATGs := sets.New[string]()
+	if labelsChanged {
+		affectedATGs = utilsets.SymmetricDifferenceString(c.filterATGsFromNodeLabels(node), c.filterATGsFromNodeLabels(oldNode))
+		for atg := range affectedATGs {
+			c.enqueueAppliedToGroup(atg)
+		}
+	}
+	klog.V(2).InfoS("Processed Node UPDATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len(), "affectedATGs", affectedATGs.Len())
 }
 
 // processClusterNetworkPolicy creates an internal NetworkPolicy instance
@@ -347,14 +372,14 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1beta1.Cl
 	if hasPerNamespaceRule && len(cnp.Spec.AppliedTo) > 0 {
 		for _, at := range cnp.Spec.AppliedTo {
 			if at.ServiceAccount != nil {
-				atg := n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil)
+				atg := n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil, nil)
 				appliedToGroups = mergeAppliedToGroups(appliedToGroups, atg)
 				clusterAppliedToAffectedNS = append(clusterAppliedToAffectedNS, at.ServiceAccount.Namespace)
 				atgForNamespace = append(atgForNamespace, atg)
 			} else {
 				affectedNS := n.getAffectedNamespacesForAppliedTo(at)
 				for _, ns := range affectedNS {
-					atg := n.createAppliedToGroup(ns, at.PodSelector, nil, at.ExternalEntitySelector)
+					atg := n.createAppliedToGroup(ns, at.PodSelector, nil, at.ExternalEntitySelector, nil)
 					appliedToGroups = mergeAppliedToGroups(appliedToGroups, atg)
 					clusterAppliedToAffectedNS = append(clusterAppliedToAffectedNS, ns)
 					atgForNamespace = append(atgForNamespace, atg)
@@ -363,8 +388,13 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1beta1.Cl
 		}
 	}
 	var rules []controlplane.NetworkPolicyRule
+	scheduleEvaluator := newRuleScheduleEvaluator(n.clock.Now())
 	processRules := func(cnpRules []crdv1beta1.Rule, direction controlplane.Direction) {
 		for idx, cnpRule := range cnpRules {
+			// Rules outside of their scheduled windows are not included in the internal NetworkPolicy.
+			if !scheduleEvaluator.isActive(&cnpRules[idx]) {
+				continue
+			}
 			services, namedPortExists := toAntreaServicesForCRD(cnpRule.Ports, cnpRule.Protocols)
 			clusterPeers, perNSPeers := splitPeersByScope(cnpRule, direction)
 			addRule := func(peer *controlplane.NetworkPolicyPeer, ruleAddressGroups []*antreatypes.AddressGroup, dir controlplane.Direction, ruleAppliedTos []*antreatypes.AppliedToGroup) {
@@ -378,6 +408,8 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1beta1.Cl
 					AppliedToGroups: getAppliedToGroupNames(ruleAppliedTos),
 					L7Protocols:     toAntreaL7ProtocolsForCRD(cnpRule.L7Protocols),
 					LogLabel:        cnpRule.LogLabel,
+					EnforcementMode: cnp.Spec.EnforcementMode,
+					RateLimit:       toAntreaRuleRateLimitForCRD(cnpRule.RateLimit),
 				}
 				if dir == controlplane.DirectionIn {
 					rule.From = *peer
@@ -422,7 +454,7 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1beta1.Cl
 					// Create a rule for each affected Namespace of appliedTo at rule level
 					for _, at := range cnpRule.AppliedTo {
 						if at.ServiceAccount != nil {
-							atg := n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil)
+							atg := n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil, nil)
 							klog.V(4).Infof("Adding a new per-namespace rule with appliedTo %v for rule %d of %s", atg, idx, cnp.Name)
 							peer, ags, selKeys := n.toNamespacedPeerForCRD(perNSPeers, cnp, at.ServiceAccount.Namespace)
 							clusterSetScopeSelectorKeys = clusterSetScopeSelectorKeys.Union(selKeys)
@@ -430,7 +462,7 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1beta1.Cl
 						} else {
 							affectedNS := n.getAffectedNamespacesForAppliedTo(at)
 							for _, ns := range affectedNS {
-								atg := n.createAppliedToGroup(ns, at.PodSelector, nil, at.ExternalEntitySelector)
+								atg := n.createAppliedToGroup(ns, at.PodSelector, nil, at.ExternalEntitySelector, nil)
 								klog.V(4).Infof("Adding a new per-namespace rule with appliedTo %v for rule %d of %s", atg, idx, cnp.Name)
 								peer, ags, selKeys := n.toNamespacedPeerForCRD(perNSPeers, cnp, ns)
 								clusterSetScopeSelectorKeys = clusterSetScopeSelectorKeys.Union(selKeys)
@@ -465,6 +497,7 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1beta1.Cl
 		Priority:         &cnp.Spec.Priority,
 		TierPriority:     &tierPriority,
 		AppliedToPerRule: appliedToPerRule,
+		ScheduleState:    scheduleEvaluator.state,
 	}
 	if n.stretchNPEnabled {
 		n.labelIdentityInterface.RemoveStalePolicySelectors(clusterSetScopeSelectorKeys, internalNetworkPolicyKeyFunc(cnp))
@@ -510,9 +543,11 @@ func (n *NetworkPolicyController) processClusterAppliedTo(appliedTo []crdv1beta1
 		} else if at.Service != nil {
 			atg = n.createAppliedToGroupForService(at.Service)
 		} else if at.ServiceAccount != nil {
-			atg = n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil)
+			atg = n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil, nil)
+		} else if at.NodeSelector != nil {
+			atg = n.createAppliedToGroup("", nil, nil, nil, at.NodeSelector)
 		} else {
-			atg = n.createAppliedToGroup("", at.PodSelector, at.NamespaceSelector, at.ExternalEntitySelector)
+			atg = n.createAppliedToGroup("", at.PodSelector, at.NamespaceSelector, at.ExternalEntitySelector, nil)
 		}
 		if atg != nil {
 			appliedToGroups = append(appliedToGroups, atg)
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	clocktesting "k8s.io/utils/clock/testing"
 
 	"antrea.io/antrea/multicluster/controllers/multicluster/common"
 	"antrea.io/antrea/pkg/apis/controlplane"
@@ -431,6 +433,119 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   1,
 		},
+		{
+			name: "with-audit-enforcement-mode",
+			inputPolicy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "", Name: "cnpF", UID: "uidF"},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []crdv1beta1.Rule{
+						{
+							From: []crdv1beta1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action: &dropAction,
+						},
+					},
+					EnforcementMode: crdv1beta1.EnforcementModeAudit,
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:  "uidF",
+				Name: "uidF",
+				SourceRef: &controlplane.NetworkPolicyReference{
+					Type: controlplane.AntreaClusterNetworkPolicy,
+					Name: "cnpF",
+					UID:  "uidF",
+				},
+				Priority:     &p10,
+				TierPriority: &DefaultTierPriority,
+				Rules: []controlplane.NetworkPolicyRule{
+					{
+						Direction: controlplane.DirectionIn,
+						From: controlplane.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", &selectorB, nil, nil, nil).NormalizedName)},
+						},
+						Priority:        0,
+						Action:          &dropAction,
+						EnforcementMode: crdv1beta1.EnforcementModeAudit,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", &selectorA, nil, nil, nil).NormalizedName)},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
+		{
+			name: "with-rule-schedule",
+			inputPolicy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "", Name: "cnpG", UID: "uidG"},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []crdv1beta1.Rule{
+						{
+							Name: "maintenance",
+							From: []crdv1beta1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorC,
+								},
+							},
+							Action: &dropAction,
+							// Inactive as it's only active from 22:00 to 24:00 on Saturdays.
+							Schedule: &crdv1beta1.RuleSchedule{Cron: "0 22 * * 6", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+						},
+						{
+							Name: "backup",
+							From: []crdv1beta1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action:   &allowAction,
+							Schedule: &crdv1beta1.RuleSchedule{Cron: "0 1 * * *", Duration: metav1.Duration{Duration: 3 * time.Hour}},
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:  "uidG",
+				Name: "uidG",
+				SourceRef: &controlplane.NetworkPolicyReference{
+					Type: controlplane.AntreaClusterNetworkPolicy,
+					Name: "cnpG",
+					UID:  "uidG",
+				},
+				Priority:     &p10,
+				TierPriority: &DefaultTierPriority,
+				Rules: []controlplane.NetworkPolicyRule{
+					{
+						Name:      "backup",
+						Direction: controlplane.DirectionIn,
+						From: controlplane.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", &selectorB, nil, nil, nil).NormalizedName)},
+						},
+						Priority: 1,
+						Action:   &allowAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", &selectorA, nil, nil, nil).NormalizedName)},
+				ScheduleState: &antreatypes.RuleScheduleState{
+					ActiveRules:        []string{"backup"},
+					InactiveRules:      []string{"maintenance"},
+					NextTransitionTime: time.Date(2023, 12, 1, 4, 0, 0, 0, time.UTC),
+				},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
 		{
 			name: "appliedTo-per-rule",
 			inputPolicy: &crdv1beta1.ClusterNetworkPolicy{
@@ -1690,6 +1805,7 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, c := newController(nil, nil)
+			c.clock = clocktesting.NewFakeClock(time.Date(2023, 12, 1, 2, 0, 0, 0, time.UTC))
 			c.addClusterGroup(&cgA)
 			c.cgStore.Add(&cgA)
 			c.namespaceStore.Add(&nsA)
@@ -1705,6 +1821,7 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 			assert.Equal(t, tt.expectedPolicy.AppliedToPerRule, actualPolicy.AppliedToPerRule)
 			assert.ElementsMatch(t, tt.expectedPolicy.Rules, actualPolicy.Rules)
 			assert.ElementsMatch(t, tt.expectedPolicy.AppliedToGroups, actualPolicy.AppliedToGroups)
+			assert.Equal(t, tt.expectedPolicy.ScheduleState, actualPolicy.ScheduleState)
 			assert.Equal(t, tt.expectedAppliedToGroups, len(actualAppliedToGroups))
 			assert.Equal(t, tt.expectedAddressGroups, len(actualAddressGroups))
 		})
@@ -15,9 +15,12 @@
 package networkpolicy
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/types"
@@ -124,6 +127,36 @@ func toAntreaL7ProtocolsForCRD(l7Protocols []crdv1beta1.L7Protocol) []controlpla
 	return antreaL7Protocols
 }
 
+// toAntreaRuleRateLimitForCRD converts a crdv1beta1.RuleRateLimit object to an Antrea RuleRateLimit object.
+func toAntreaRuleRateLimitForCRD(rateLimit *crdv1beta1.RuleRateLimit) *controlplane.RuleRateLimit {
+	if rateLimit == nil {
+		return nil
+	}
+	antreaRateLimit := &controlplane.RuleRateLimit{}
+	if rateLimit.ConnectionsPerSecond != nil {
+		antreaRateLimit.ConnectionsPerSecond = *rateLimit.ConnectionsPerSecond
+	}
+	if rateLimit.Bandwidth != nil {
+		// The bandwidth has been validated by the validating webhook.
+		antreaRateLimit.BandwidthRate, _ = bandwidthToKbps(rateLimit.Bandwidth.Rate)
+		antreaRateLimit.BandwidthBurst, _ = bandwidthToKbps(rateLimit.Bandwidth.Burst)
+	}
+	return antreaRateLimit
+}
+
+// bandwidthToKbps converts a bandwidth quantity in bits, e.g. 10M, to a value in Kb.
+func bandwidthToKbps(bandwidth string) (int32, error) {
+	quantity, err := resource.ParseQuantity(bandwidth)
+	if err != nil {
+		return 0, err
+	}
+	kbps := quantity.Value() / 1000
+	if kbps <= 0 || kbps > math.MaxInt32 {
+		return 0, fmt.Errorf("must be between 1k and %dk", math.MaxInt32)
+	}
+	return int32(kbps), nil
+}
+
 // toAntreaIPBlockForCRD converts a crdv1beta1.IPBlock to an Antrea IPBlock.
 func toAntreaIPBlockForCRD(ipBlock *crdv1beta1.IPBlock) (*controlplane.IPBlock, error) {
 	// Convert the allowed IPBlock to networkpolicy.IPNet.
@@ -131,10 +164,18 @@ func toAntreaIPBlockForCRD(ipBlock *crdv1beta1.IPBlock) (*controlplane.IPBlock,
 	if err != nil {
 		return nil, err
 	}
+	exceptNets := []controlplane.IPNet{}
+	for _, exc := range ipBlock.Except {
+		// Convert the except IPBlock to networkpolicy.IPNet.
+		exceptNet, err := cidrStrToIPNet(exc)
+		if err != nil {
+			return nil, err
+		}
+		exceptNets = append(exceptNets, *exceptNet)
+	}
 	antreaIPBlock := &controlplane.IPBlock{
-		CIDR: *ipNet,
-		// secv1alpha.IPBlock does not have the Except slices.
-		Except: []controlplane.IPNet{},
+		CIDR:   *ipNet,
+		Except: exceptNets,
 	}
 	return antreaIPBlock, nil
 }
@@ -359,6 +400,18 @@ func getNormalizedNameForSelector(sel *antreatypes.GroupSelector) string {
 	return ""
 }
 
+// getNormalizedNameForIPBlock returns a string which uniquely identifies the IPBlock, including its except CIDRs.
+func getNormalizedNameForIPBlock(ipb *controlplane.IPBlock) string {
+	if len(ipb.Except) == 0 {
+		return ipb.CIDR.String()
+	}
+	excepts := make([]string, 0, len(ipb.Except))
+	for _, exc := range ipb.Except {
+		excepts = append(excepts, exc.String())
+	}
+	return ipb.CIDR.String() + " except " + strings.Join(excepts, ",")
+}
+
 func (n *NetworkPolicyController) syncInternalGroup(key string) error {
 	defer n.triggerANNPUpdates(key)
 	defer n.triggerCNPUpdates(key)
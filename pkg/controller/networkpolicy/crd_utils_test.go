@@ -233,6 +233,31 @@ func TestToAntreaL7ProtocolsForCRD(t *testing.T) {
 	}
 }
 
+func TestToAntreaRuleRateLimitForCRD(t *testing.T) {
+	connectionsPerSecond := int32(100)
+	tables := []struct {
+		rateLimit *crdv1beta1.RuleRateLimit
+		expValue  *controlplane.RuleRateLimit
+	}{
+		{
+			nil,
+			nil,
+		},
+		{
+			&crdv1beta1.RuleRateLimit{ConnectionsPerSecond: &connectionsPerSecond},
+			&controlplane.RuleRateLimit{ConnectionsPerSecond: 100},
+		},
+		{
+			&crdv1beta1.RuleRateLimit{Bandwidth: &crdv1beta1.Bandwidth{Rate: "10M", Burst: "1Gi"}},
+			&controlplane.RuleRateLimit{BandwidthRate: 10000, BandwidthBurst: 1073741},
+		},
+	}
+	for _, table := range tables {
+		gotValue := toAntreaRuleRateLimitForCRD(table.rateLimit)
+		assert.Equal(t, table.expValue, gotValue)
+	}
+}
+
 func TestToAntreaIPBlockForCRD(t *testing.T) {
 	expIPNet := controlplane.IPNet{
 		IP:           ipStrToIPAddress("10.0.0.0"),
@@ -247,11 +272,34 @@ func TestToAntreaIPBlockForCRD(t *testing.T) {
 			&crdv1beta1.IPBlock{
 				CIDR: "10.0.0.0/24",
 			},
+			controlplane.IPBlock{
+				CIDR:   expIPNet,
+				Except: []controlplane.IPNet{},
+			},
+			nil,
+		},
+		{
+			&crdv1beta1.IPBlock{
+				CIDR:   "10.0.0.0/24",
+				Except: []string{"10.0.0.0/28", "10.0.0.128/25"},
+			},
 			controlplane.IPBlock{
 				CIDR: expIPNet,
+				Except: []controlplane.IPNet{
+					{IP: ipStrToIPAddress("10.0.0.0"), PrefixLength: 28},
+					{IP: ipStrToIPAddress("10.0.0.128"), PrefixLength: 25},
+				},
 			},
 			nil,
 		},
+		{
+			&crdv1beta1.IPBlock{
+				CIDR:   "10.0.0.0/24",
+				Except: []string{"10.0.0.0"},
+			},
+			controlplane.IPBlock{},
+			fmt.Errorf("invalid format for IPBlock CIDR: 10.0.0.0"),
+		},
 		{
 			&crdv1beta1.IPBlock{
 				CIDR: "10.0.0.0",
@@ -277,6 +325,7 @@ func TestToAntreaIPBlockForCRD(t *testing.T) {
 		if table.expValue.CIDR.PrefixLength != ipNet.PrefixLength {
 			t.Errorf("Unexpected PrefixLength in Antrea IPBlock conversion. Expected %v, got %v", table.expValue.CIDR.PrefixLength, ipNet.PrefixLength)
 		}
+		assert.Equal(t, table.expValue.Except, antreaIPBlock.Except)
 	}
 }
 
@@ -64,13 +64,13 @@ func (n *NetworkPolicyController) updateGroup(oldObj, curObj interface{}) {
 	}
 	ipBlocksUpdated := func() bool {
 		oldIPBs, newIPBs := sets.Set[string]{}, sets.Set[string]{}
-		for _, ipb := range oldGroup.IPBlocks {
-			oldIPBs.Insert(ipb.CIDR.String())
+		for i := range oldGroup.IPBlocks {
+			oldIPBs.Insert(getNormalizedNameForIPBlock(&oldGroup.IPBlocks[i]))
 		}
-		for _, ipb := range newGroup.IPBlocks {
-			newIPBs.Insert(ipb.CIDR.String())
+		for i := range newGroup.IPBlocks {
+			newIPBs.Insert(getNormalizedNameForIPBlock(&newGroup.IPBlocks[i]))
 		}
-		return oldIPBs.Equal(newIPBs)
+		return !oldIPBs.Equal(newIPBs)
 	}
 	childGroupsUpdated := func() bool {
 		oldChildGroups, newChildGroups := sets.Set[string]{}, sets.Set[string]{}
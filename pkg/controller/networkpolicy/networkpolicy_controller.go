@@ -43,6 +43,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	policyinformers "sigs.k8s.io/network-policy-api/pkg/client/informers/externalversions/apis/v1alpha1"
 	policylisters "sigs.k8s.io/network-policy-api/pkg/client/listers/apis/v1alpha1"
 
@@ -261,6 +262,9 @@ type NetworkPolicyController struct {
 	// Enable Stretched Networkpolicy feature which allows Antrea-native policies to select peer
 	// from other clusters in a ClusterSet.
 	stretchNPEnabled bool
+	// clock is used to evaluate the schedules of Antrea-native policy rules. Added as a member to the struct to allow
+	// injection for testing.
+	clock clock.Clock
 	// heartbeatCh is an internal channel for testing. It's used to know whether all tasks have been
 	// processed, and to count executions of each function.
 	heartbeatCh chan heartbeat
@@ -436,6 +440,7 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 		groupingInterfaceSynced:        groupingInterface.HasSynced,
 		labelIdentityInterface:         labelIdentityInterface,
 		stretchNPEnabled:               stretchedNPEnabled,
+		clock:                          clock.RealClock{},
 		appliedToGroupNotifier:         newNotifier(),
 	}
 	n.groupingInterface.AddEventHandler(appliedToGroupType, n.enqueueAppliedToGroup)
@@ -600,8 +605,8 @@ func getNormalizedUID(name string) string {
 }
 
 // createAppliedToGroup creates an AppliedToGroup object corresponding to the provided selectors.
-func (n *NetworkPolicyController) createAppliedToGroup(npNsName string, pSel, nSel, eSel *metav1.LabelSelector) *antreatypes.AppliedToGroup {
-	groupSelector := antreatypes.NewGroupSelector(npNsName, pSel, nSel, eSel, nil)
+func (n *NetworkPolicyController) createAppliedToGroup(npNsName string, pSel, nSel, eSel, nodeSel *metav1.LabelSelector) *antreatypes.AppliedToGroup {
+	groupSelector := antreatypes.NewGroupSelector(npNsName, pSel, nSel, eSel, nodeSel)
 	appliedToGroupUID := getNormalizedUID(groupSelector.NormalizedName)
 	// Construct a new AppliedToGroup.
 	appliedToGroup := &antreatypes.AppliedToGroup{
@@ -691,7 +696,7 @@ func (n *NetworkPolicyController) processNetworkPolicy(np *networkingv1.NetworkP
 	// addressGroups tracks all distinct AddressGroups referred to by the K8s NetworkPolicy.
 	addressGroups := map[string]*antreatypes.AddressGroup{}
 
-	newAppliedToGroup := n.createAppliedToGroup(np.Namespace, &np.Spec.PodSelector, nil, nil)
+	newAppliedToGroup := n.createAppliedToGroup(np.Namespace, &np.Spec.PodSelector, nil, nil, nil)
 	appliedToGroups = mergeAppliedToGroups(appliedToGroups, newAppliedToGroup)
 	rules := make([]controlplane.NetworkPolicyRule, 0, len(np.Spec.Ingress)+len(np.Spec.Egress))
 	// Retrieve Namespace logging annotation.
@@ -1284,7 +1289,7 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 		// AppliedToGroup for NodePort Service span to all Nodes.
 		nodeList, err := n.nodeLister.List(labels.Everything())
 		if err != nil {
-			return fmt.Errorf("unable to list Nodes")
+			return fmt.Errorf("unable to list Nodes: %w", err)
 		}
 		serviceGroupMemberSet := controlplane.NewGroupMemberSet(serviceToGroupMember(appliedToGroup.Service))
 		for _, node := range nodeList {
@@ -1299,6 +1304,25 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 			SpanMeta:          antreatypes.SpanMeta{NodeNames: appGroupNodeNames},
 		}
 		klog.V(2).InfoS("Updating existing AppliedToGroup", "Service", *appliedToGroup.Service, "numNodes", appGroupNodeNames.Len())
+	} else if appliedToGroup.Selector != nil && appliedToGroup.Selector.NodeSelector != nil {
+		// AppliedToGroup that selects Nodes via nodeSelector spans to the selected Nodes only, and each Node
+		// receives itself as the only member of the group.
+		nodes, err := n.nodeLister.List(appliedToGroup.Selector.NodeSelector)
+		if err != nil {
+			return fmt.Errorf("unable to list Nodes: %w", err)
+		}
+		for _, node := range nodes {
+			appGroupNodeNames.Insert(node.Name)
+			memberSetByNode[node.Name] = controlplane.NewGroupMemberSet(&controlplane.GroupMember{Node: &controlplane.NodeReference{Name: node.Name}})
+		}
+		updatedAppliedToGroup = &antreatypes.AppliedToGroup{
+			UID:               appliedToGroup.UID,
+			Name:              appliedToGroup.Name,
+			Selector:          appliedToGroup.Selector,
+			GroupMemberByNode: memberSetByNode,
+			SpanMeta:          antreatypes.SpanMeta{NodeNames: appGroupNodeNames},
+		}
+		klog.V(2).InfoS("Updating existing AppliedToGroup", "AppliedToGroup", appliedToGroup.Name, "numNodes", appGroupNodeNames.Len())
 	} else {
 		pods, externalEntities, err := n.getAppliedToWorkloads(appliedToGroup)
 		if err != nil {
@@ -1563,7 +1587,9 @@ func (n *NetworkPolicyController) syncInternalNetworkPolicy(key *controlplane.Ne
 			}
 			klog.V(2).InfoS("Creating new AppliedToGroup", "name", name, "uid", appliedToGroup.UID, "selector", appliedToGroup.Selector, "service", appliedToGroup.Service)
 			n.appliedToGroupStore.Create(appliedToGroup)
-			if appliedToGroup.Selector != nil {
+			// For an AppliedToGroup that selects Nodes via nodeSelector, we calculate its members via NodeLister
+			// directly, instead of groupingInterface which handles Pod and ExternalEntity currently.
+			if appliedToGroup.Selector != nil && appliedToGroup.Selector.NodeSelector == nil {
 				n.groupingInterface.AddGroup(appliedToGroupType, appliedToGroup.Name, appliedToGroup.Selector)
 			}
 			appliedToGroupsToSync.Insert(name)
@@ -1622,6 +1648,10 @@ func (n *NetworkPolicyController) syncInternalNetworkPolicy(key *controlplane.Ne
 			n.appliedToGroupNotifier.unsubscribe(name, internalNetworkPolicyName)
 		}
 	}
+	// Sync the NetworkPolicy again when any of its scheduled rules is activated or deactivated.
+	if scheduleState := newInternalNetworkPolicy.ScheduleState; scheduleState != nil && !scheduleState.NextTransitionTime.IsZero() {
+		n.internalNetworkPolicyQueue.AddAfter(*key, scheduleState.NextTransitionTime.Sub(n.clock.Now()))
+	}
 	return nil
 }
 
@@ -3782,6 +3782,36 @@ func TestSyncAppliedToGroupWithExternalEntity(t *testing.T) {
 	}
 }
 
+func TestSyncAppliedToGroupWithNodeSelector(t *testing.T) {
+	selectorSpec := metav1.LabelSelector{
+		MatchLabels: map[string]string{"role": "gateway"},
+	}
+	nodeA := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodeA", Labels: map[string]string{"role": "gateway"}},
+	}
+	nodeB := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodeB", Labels: map[string]string{"role": "worker"}},
+	}
+	_, c := newController([]runtime.Object{nodeA, nodeB}, nil)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+
+	appliedToGroup := c.createAppliedToGroup("", nil, nil, nil, &selectorSpec)
+	c.appliedToGroupStore.Create(appliedToGroup)
+	require.NoError(t, c.syncAppliedToGroup(appliedToGroup.Name))
+	appGroupObj, _, _ := c.appliedToGroupStore.Get(appliedToGroup.Name)
+	appGroup := appGroupObj.(*antreatypes.AppliedToGroup)
+	assert.Equal(t, sets.New[string]("nodeA"), appGroup.SpanMeta.NodeNames)
+	expectedMembers := map[string]controlplane.GroupMemberSet{
+		"nodeA": controlplane.NewGroupMemberSet(&controlplane.GroupMember{Node: &controlplane.NodeReference{Name: "nodeA"}}),
+	}
+	assert.Equal(t, expectedMembers, appGroup.GroupMemberByNode)
+	assert.Equal(t, sets.New[string](appliedToGroup.Name), c.filterATGsFromNodeLabels(nodeA))
+	assert.Empty(t, c.filterATGsFromNodeLabels(nodeB))
+}
+
 func checkQueueItemExistence(t *testing.T, queue workqueue.RateLimitingInterface, items ...string) {
 	require.Equal(t, len(items), queue.Len())
 	expectedItems := sets.New[string](items...)
@@ -0,0 +1,122 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	antreatypes "antrea.io/antrea/pkg/controller/types"
+	"antrea.io/antrea/pkg/util/cron"
+)
+
+// parseRuleSchedule parses the cron expression and the time zone of a rule schedule.
+func parseRuleSchedule(schedule *crdv1beta1.RuleSchedule) (*cron.Schedule, *time.Location, error) {
+	cronSchedule, err := cron.Parse(schedule.Cron)
+	if err != nil {
+		return nil, nil, err
+	}
+	location := time.UTC
+	if schedule.TimeZone != "" {
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %v", schedule.TimeZone, err)
+		}
+	}
+	if schedule.Duration.Duration <= 0 {
+		return nil, nil, fmt.Errorf("duration must be positive")
+	}
+	return cronSchedule, location, nil
+}
+
+// getRuleScheduleState returns whether a rule with the given schedule is active at the given time, and the time
+// when the rule will be activated or deactivated next. The returned time is zero if the rule will never be activated.
+func getRuleScheduleState(schedule *crdv1beta1.RuleSchedule, now time.Time) (bool, time.Time, error) {
+	cronSchedule, location, err := parseRuleSchedule(schedule)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	duration := schedule.Duration.Duration
+	// The rule is active if a window started in (now - duration, now]. Otherwise, the first window starting after
+	// now - duration starts after now, and it's when the rule is activated next.
+	start := cronSchedule.Next(now.In(location).Add(-duration))
+	if !start.IsZero() && !start.After(now) {
+		return true, start.Add(duration), nil
+	}
+	return false, start, nil
+}
+
+// ruleScheduleEvaluator evaluates the schedules of the rules of an Antrea-native policy at a given time, and collects
+// the states of the rules.
+type ruleScheduleEvaluator struct {
+	now   time.Time
+	state *antreatypes.RuleScheduleState
+}
+
+func newRuleScheduleEvaluator(now time.Time) *ruleScheduleEvaluator {
+	return &ruleScheduleEvaluator{now: now}
+}
+
+// isActive returns whether the rule is active currently and records the state of the rule. A rule without schedule
+// is always active.
+func (e *ruleScheduleEvaluator) isActive(rule *crdv1beta1.Rule) bool {
+	if rule.Schedule == nil {
+		return true
+	}
+	if e.state == nil {
+		e.state = &antreatypes.RuleScheduleState{}
+	}
+	active, nextTransitionTime, err := getRuleScheduleState(rule.Schedule, e.now)
+	if err != nil {
+		// This should not happen as the schedule has been validated by the validating webhook.
+		klog.ErrorS(err, "Invalid schedule of rule, treating it as inactive", "rule", rule.Name)
+	}
+	if active {
+		e.state.ActiveRules = append(e.state.ActiveRules, rule.Name)
+	} else {
+		e.state.InactiveRules = append(e.state.InactiveRules, rule.Name)
+	}
+	if !nextTransitionTime.IsZero() && (e.state.NextTransitionTime.IsZero() || nextTransitionTime.Before(e.state.NextTransitionTime)) {
+		e.state.NextTransitionTime = nextTransitionTime
+	}
+	return active
+}
+
+// generateScheduleCondition generates the condition reporting the schedule states of the rules of a NetworkPolicy.
+func generateScheduleCondition(state *antreatypes.RuleScheduleState) crdv1beta1.NetworkPolicyCondition {
+	condition := crdv1beta1.NetworkPolicyCondition{
+		Type:               crdv1beta1.NetworkPolicyConditionScheduleActive,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: v1.Now(),
+		Reason:             "NoRuleActive",
+	}
+	if len(state.ActiveRules) > 0 {
+		condition.Status = v1.ConditionTrue
+		condition.Reason = "RulesActive"
+	}
+	message := fmt.Sprintf("Active rules: [%s], inactive rules: [%s]", strings.Join(state.ActiveRules, ", "), strings.Join(state.InactiveRules, ", "))
+	if !state.NextTransitionTime.IsZero() {
+		message = fmt.Sprintf("%s, next transition at %s", message, state.NextTransitionTime.UTC().Format(time.RFC3339))
+	}
+	if len(message) > maxConditionMessageLength {
+		message = fmt.Sprintf("%s...", message[:maxConditionMessageLength])
+	}
+	condition.Message = message
+	return condition
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	antreatypes "antrea.io/antrea/pkg/controller/types"
+)
+
+func TestGetRuleScheduleState(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	tests := []struct {
+		name                       string
+		schedule                   *crdv1beta1.RuleSchedule
+		now                        time.Time
+		expectedActive             bool
+		expectedNextTransitionTime time.Time
+		expectedErr                string
+	}{
+		{
+			name:                       "before window",
+			schedule:                   &crdv1beta1.RuleSchedule{Cron: "0 1 * * *", Duration: metav1.Duration{Duration: 3 * time.Hour}},
+			now:                        time.Date(2023, 12, 1, 0, 30, 0, 0, time.UTC),
+			expectedActive:             false,
+			expectedNextTransitionTime: time.Date(2023, 12, 1, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                       "start of window",
+			schedule:                   &crdv1beta1.RuleSchedule{Cron: "0 1 * * *", Duration: metav1.Duration{Duration: 3 * time.Hour}},
+			now:                        time.Date(2023, 12, 1, 1, 0, 0, 0, time.UTC),
+			expectedActive:             true,
+			expectedNextTransitionTime: time.Date(2023, 12, 1, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                       "in window",
+			schedule:                   &crdv1beta1.RuleSchedule{Cron: "0 1 * * *", Duration: metav1.Duration{Duration: 3 * time.Hour}},
+			now:                        time.Date(2023, 12, 1, 3, 59, 59, 0, time.UTC),
+			expectedActive:             true,
+			expectedNextTransitionTime: time.Date(2023, 12, 1, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                       "end of window",
+			schedule:                   &crdv1beta1.RuleSchedule{Cron: "0 1 * * *", Duration: metav1.Duration{Duration: 3 * time.Hour}},
+			now:                        time.Date(2023, 12, 1, 4, 0, 0, 0, time.UTC),
+			expectedActive:             false,
+			expectedNextTransitionTime: time.Date(2023, 12, 2, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                       "window across days",
+			schedule:                   &crdv1beta1.RuleSchedule{Cron: "0 22 * * 5", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			now:                        time.Date(2023, 12, 2, 1, 0, 0, 0, time.UTC),
+			expectedActive:             true,
+			expectedNextTransitionTime: time.Date(2023, 12, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:                       "in time zone",
+			schedule:                   &crdv1beta1.RuleSchedule{Cron: "0 9 * * *", Duration: metav1.Duration{Duration: 8 * time.Hour}, TimeZone: "America/New_York"},
+			now:                        time.Date(2023, 12, 1, 13, 0, 0, 0, time.UTC),
+			expectedActive:             false,
+			expectedNextTransitionTime: time.Date(2023, 12, 1, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:                       "never active",
+			schedule:                   &crdv1beta1.RuleSchedule{Cron: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+			now:                        time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			expectedActive:             false,
+			expectedNextTransitionTime: time.Time{},
+		},
+		{
+			name:        "invalid time zone",
+			schedule:    &crdv1beta1.RuleSchedule{Cron: "0 1 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus_Mons"},
+			expectedErr: "invalid time zone",
+		},
+		{
+			name:        "invalid duration",
+			schedule:    &crdv1beta1.RuleSchedule{Cron: "0 1 * * *"},
+			expectedErr: "duration must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, nextTransitionTime, err := getRuleScheduleState(tt.schedule, tt.now)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedActive, active)
+			assert.True(t, tt.expectedNextTransitionTime.Equal(nextTransitionTime), "expected %v, got %v", tt.expectedNextTransitionTime, nextTransitionTime)
+		})
+	}
+}
+
+func TestGenerateScheduleCondition(t *testing.T) {
+	tests := []struct {
+		name            string
+		state           *antreatypes.RuleScheduleState
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name: "active rules",
+			state: &antreatypes.RuleScheduleState{
+				ActiveRules:        []string{"backup"},
+				InactiveRules:      []string{"maintenance"},
+				NextTransitionTime: time.Date(2023, 12, 1, 4, 0, 0, 0, time.UTC),
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "RulesActive",
+			expectedMessage: "Active rules: [backup], inactive rules: [maintenance], next transition at 2023-12-01T04:00:00Z",
+		},
+		{
+			name: "no active rule",
+			state: &antreatypes.RuleScheduleState{
+				InactiveRules: []string{"never"},
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "NoRuleActive",
+			expectedMessage: "Active rules: [], inactive rules: [never]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := generateScheduleCondition(tt.state)
+			assert.Equal(t, crdv1beta1.NetworkPolicyConditionScheduleActive, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectedMessage, condition.Message)
+		})
+	}
+}
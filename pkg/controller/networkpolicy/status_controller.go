@@ -288,6 +288,9 @@ func (c *StatusController) syncHandler(key string) error {
 	}
 
 	conditions := GenerateNetworkPolicyCondition(internalNP.SyncError)
+	if internalNP.ScheduleState != nil {
+		conditions = append(conditions, generateScheduleCondition(internalNP.ScheduleState))
+	}
 	// It means the NetworkPolicy has been processed, and marked as unrealizable. It will enter unrealizable phase
 	// instead of being further realized. Antrea-agents will not process further.
 	if internalNP.SyncError != nil {
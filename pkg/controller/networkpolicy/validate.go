@@ -495,6 +495,8 @@ func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool
 	var tier string
 	var ingress, egress []crdv1beta1.Rule
 	var specAppliedTo []crdv1beta1.AppliedTo
+	var enforcementMode crdv1beta1.EnforcementMode
+	var isACNP bool
 	switch curObj.(type) {
 	case *crdv1beta1.ClusterNetworkPolicy:
 		curACNP := curObj.(*crdv1beta1.ClusterNetworkPolicy)
@@ -502,12 +504,15 @@ func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool
 		ingress = curACNP.Spec.Ingress
 		egress = curACNP.Spec.Egress
 		specAppliedTo = curACNP.Spec.AppliedTo
+		enforcementMode = curACNP.Spec.EnforcementMode
+		isACNP = true
 	case *crdv1beta1.NetworkPolicy:
 		curANNP := curObj.(*crdv1beta1.NetworkPolicy)
 		tier = curANNP.Spec.Tier
 		ingress = curANNP.Spec.Ingress
 		egress = curANNP.Spec.Egress
 		specAppliedTo = curANNP.Spec.AppliedTo
+		enforcementMode = curANNP.Spec.EnforcementMode
 	}
 	reason, allowed := v.validateTierForPolicy(tier)
 	if !allowed {
@@ -524,6 +529,10 @@ func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool
 	if !allowed {
 		return reason, allowed
 	}
+	reason, allowed = v.validateAppliedToNode(isACNP, ingress, egress, specAppliedTo)
+	if !allowed {
+		return reason, allowed
+	}
 	reason, allowed = v.validatePeers(ingress, egress)
 	if !allowed {
 		return reason, allowed
@@ -548,6 +557,18 @@ func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool
 	if !allowed {
 		return reason, allowed
 	}
+	reason, allowed = v.validateEnforcementMode(enforcementMode, ingress, egress, specAppliedTo)
+	if !allowed {
+		return reason, allowed
+	}
+	reason, allowed = v.validateRuleSchedules(ingress, egress)
+	if !allowed {
+		return reason, allowed
+	}
+	reason, allowed = v.validateRuleRateLimits(ingress, egress)
+	if !allowed {
+		return reason, allowed
+	}
 	if err := v.validatePort(ingress, egress); err != nil {
 		return err.Error(), false
 	}
@@ -601,6 +622,7 @@ func (v *antreaPolicyValidator) validateAppliedTo(ingress, egress []crdv1beta1.R
 
 	checkAppliedTo := func(appliedTo []crdv1beta1.AppliedTo, appliedToScope int) (string, bool) {
 		appliedToSvcNum := 0
+		appliedToNodeNum := 0
 		for _, eachAppliedTo := range appliedTo {
 			appliedToFieldsNum := numFieldsSetInStruct(eachAppliedTo)
 			if eachAppliedTo.Group != "" && appliedToFieldsNum > 1 {
@@ -618,13 +640,22 @@ func (v *antreaPolicyValidator) validateAppliedTo(ingress, egress []crdv1beta1.R
 				}
 				appliedToSvcNum++
 			}
-			if reason, allowed := checkSelectorsLabels(eachAppliedTo.PodSelector, eachAppliedTo.NamespaceSelector, eachAppliedTo.ExternalEntitySelector); !allowed {
+			if eachAppliedTo.NodeSelector != nil {
+				if appliedToFieldsNum > 1 {
+					return "nodeSelector cannot be set with other peers in appliedTo", false
+				}
+				appliedToNodeNum++
+			}
+			if reason, allowed := checkSelectorsLabels(eachAppliedTo.PodSelector, eachAppliedTo.NamespaceSelector, eachAppliedTo.ExternalEntitySelector, eachAppliedTo.NodeSelector); !allowed {
 				return reason, allowed
 			}
 		}
 		if appliedToSvcNum > 0 && appliedToSvcNum < len(appliedTo) {
 			return "a rule/policy cannot be applied to Services and other peers at the same time", false
 		}
+		if appliedToNodeNum > 0 && appliedToNodeNum < len(appliedTo) {
+			return "a rule/policy cannot be applied to Nodes and other peers at the same time", false
+		}
 		return "", true
 	}
 
@@ -648,6 +679,75 @@ func (v *antreaPolicyValidator) validateAppliedTo(ingress, egress []crdv1beta1.R
 	return "", true
 }
 
+// validateAppliedToNode ensures that a policy applied to Nodes via nodeSelector is a
+// ClusterNetworkPolicy, and that it only uses features supported on the Node host network.
+func (v *antreaPolicyValidator) validateAppliedToNode(isACNP bool, ingress, egress []crdv1beta1.Rule, specAppliedTo []crdv1beta1.AppliedTo) (string, bool) {
+	isAppliedToNode := func(peers []crdv1beta1.AppliedTo) bool {
+		if len(peers) > 0 {
+			return peers[0].NodeSelector != nil
+		}
+		return false
+	}
+	policyAppliedToNode := isAppliedToNode(specAppliedTo)
+	checkRule := func(rule crdv1beta1.Rule, peers []crdv1beta1.NetworkPolicyPeer) (string, bool) {
+		if !policyAppliedToNode && !isAppliedToNode(rule.AppliedTo) {
+			return "", true
+		}
+		if !features.DefaultFeatureGate.Enabled(features.NodeNetworkPolicy) {
+			return "nodeSelector in appliedTo can only be used when NodeNetworkPolicy is enabled", false
+		}
+		if !isACNP {
+			return "nodeSelector in appliedTo can only be used in ClusterNetworkPolicy", false
+		}
+		if len(rule.ToServices) > 0 {
+			return "toServices cannot be used in a rule/policy that is applied to Nodes", false
+		}
+		if len(rule.L7Protocols) > 0 {
+			return "layer 7 protocols cannot be used in a rule/policy that is applied to Nodes", false
+		}
+		if rule.Action != nil && *rule.Action == crdv1beta1.RuleActionPass {
+			return "Pass action cannot be used in a rule/policy that is applied to Nodes", false
+		}
+		if rule.Action != nil && *rule.Action == crdv1beta1.RuleActionRateLimit {
+			return "RateLimit action cannot be used in a rule/policy that is applied to Nodes", false
+		}
+		for _, port := range rule.Ports {
+			// Named ports are resolved with the container ports of Pods, which Nodes don't have.
+			if port.Port != nil && port.Port.Type == intstr.String {
+				return "named port cannot be used in a rule/policy that is applied to Nodes", false
+			}
+		}
+		for _, peer := range peers {
+			if peer.FQDN != "" {
+				return "fqdn cannot be used in a rule/policy that is applied to Nodes", false
+			}
+			if peer.Namespaces != nil {
+				return "namespaces cannot be used in a rule/policy that is applied to Nodes", false
+			}
+		}
+		return "", true
+	}
+	if policyAppliedToNode {
+		if !features.DefaultFeatureGate.Enabled(features.NodeNetworkPolicy) {
+			return "nodeSelector in appliedTo can only be used when NodeNetworkPolicy is enabled", false
+		}
+		if !isACNP {
+			return "nodeSelector in appliedTo can only be used in ClusterNetworkPolicy", false
+		}
+	}
+	for _, rule := range ingress {
+		if reason, allowed := checkRule(rule, rule.From); !allowed {
+			return reason, allowed
+		}
+	}
+	for _, rule := range egress {
+		if reason, allowed := checkRule(rule, rule.To); !allowed {
+			return reason, allowed
+		}
+	}
+	return "", true
+}
+
 // validatePeers ensures that the NetworkPolicyPeer object set in rules are valid, i.e.
 // currently it ensures that a Group cannot be set with other stand-alone selectors or IPBlock.
 func (v *antreaPolicyValidator) validatePeers(ingress, egress []crdv1beta1.Rule) (string, bool) {
@@ -669,6 +769,11 @@ func (v *antreaPolicyValidator) validatePeers(ingress, egress []crdv1beta1.Rule)
 			if reason, allowed := checkSelectorsLabels(peer.PodSelector, peer.NamespaceSelector, peer.ExternalEntitySelector, peer.NodeSelector); !allowed {
 				return reason, allowed
 			}
+			if peer.IPBlock != nil {
+				if reason, allowed := validateIPBlock(peer.IPBlock); !allowed {
+					return reason, allowed
+				}
+			}
 		}
 		return "", true
 	}
@@ -692,6 +797,26 @@ func (v *antreaPolicyValidator) validatePeers(ingress, egress []crdv1beta1.Rule)
 	return "", true
 }
 
+// validateIPBlock ensures that the CIDR of an IPBlock is valid, and that its except CIDRs are strict subsets of it.
+func validateIPBlock(ipBlock *crdv1beta1.IPBlock) (string, bool) {
+	_, cidr, err := net.ParseCIDR(ipBlock.CIDR)
+	if err != nil {
+		return fmt.Sprintf("invalid ipBlock cidr %s: %v", ipBlock.CIDR, err), false
+	}
+	cidrPrefixLen, _ := cidr.Mask.Size()
+	for _, exc := range ipBlock.Except {
+		_, exceptCIDR, err := net.ParseCIDR(exc)
+		if err != nil {
+			return fmt.Sprintf("invalid except %s of ipBlock %s: %v", exc, ipBlock.CIDR, err), false
+		}
+		exceptPrefixLen, _ := exceptCIDR.Mask.Size()
+		if !cidr.Contains(exceptCIDR.IP) || exceptPrefixLen <= cidrPrefixLen {
+			return fmt.Sprintf("except %s of ipBlock %s must be a strict subset of the cidr", exc, ipBlock.CIDR), false
+		}
+	}
+	return "", true
+}
+
 // validateAppliedToServiceIngressPeer ensures that if a policy or an ingress rule
 // is applied to Services, the ingress rule can only use ipBlock to select workloads.
 func (v *antreaPolicyValidator) validateAppliedToServiceIngressPeer(specAppliedTo []crdv1beta1.AppliedTo, ingress []crdv1beta1.Rule) (string, bool) {
@@ -808,6 +933,9 @@ func (v *antreaPolicyValidator) validateEgressMulticastAddress(egressRule []crdv
 			if multicast && (*r.Action == crdv1beta1.RuleActionPass || *r.Action == crdv1beta1.RuleActionReject) {
 				return "multicast does not support action Pass or Reject", false
 			}
+			if multicast && *r.Action == crdv1beta1.RuleActionRateLimit {
+				return "multicast does not support action RateLimit", false
+			}
 		}
 		if multicast && unicast {
 			return "can not set multicast groupAddress and unicast ip address at the same time", false
@@ -845,6 +973,9 @@ func (v *antreaPolicyValidator) validateMulticastIGMP(ingressRules, egressRules
 				if *r.Action == crdv1beta1.RuleActionPass || *r.Action == crdv1beta1.RuleActionReject {
 					return "protocol IGMP does not support Pass or Reject", false
 				}
+				if *r.Action == crdv1beta1.RuleActionRateLimit {
+					return "protocol IGMP does not support RateLimit", false
+				}
 			}
 			if protocol.ICMP != nil {
 				haveICMP = true
@@ -893,6 +1024,80 @@ func (v *antreaPolicyValidator) validateL7Protocols(ingressRules, egressRules []
 	return "", true
 }
 
+// validateEnforcementMode validates that the rules of an Antrea-native policy in Audit
+// enforcement mode don't use features that cannot be audited.
+func (v *antreaPolicyValidator) validateEnforcementMode(enforcementMode crdv1beta1.EnforcementMode, ingressRules, egressRules []crdv1beta1.Rule, specAppliedTo []crdv1beta1.AppliedTo) (string, bool) {
+	if enforcementMode != crdv1beta1.EnforcementModeAudit {
+		return "", true
+	}
+	appliedToNode := func(appliedTo []crdv1beta1.AppliedTo) bool {
+		for _, at := range appliedTo {
+			if at.NodeSelector != nil {
+				return true
+			}
+		}
+		return false
+	}
+	if appliedToNode(specAppliedTo) {
+		return "Audit enforcement mode is not supported for policies applied to Nodes", false
+	}
+	for _, r := range append(ingressRules, egressRules...) {
+		if appliedToNode(r.AppliedTo) {
+			return "Audit enforcement mode is not supported for policies applied to Nodes", false
+		}
+		if len(r.L7Protocols) > 0 {
+			return "layer 7 protocols can not be used in Audit enforcement mode", false
+		}
+		for _, protocol := range r.Protocols {
+			if protocol.IGMP != nil {
+				return "protocol IGMP can not be used in Audit enforcement mode", false
+			}
+		}
+	}
+	return "", true
+}
+
+// validateRuleSchedules validates the schedules set in Antrea-native policy rules are valid.
+func (v *antreaPolicyValidator) validateRuleSchedules(ingressRules, egressRules []crdv1beta1.Rule) (string, bool) {
+	for _, r := range append(ingressRules, egressRules...) {
+		if r.Schedule == nil {
+			continue
+		}
+		if _, _, err := parseRuleSchedule(r.Schedule); err != nil {
+			return fmt.Sprintf("invalid schedule of rule %s: %v", r.Name, err), false
+		}
+	}
+	return "", true
+}
+
+// validateRuleRateLimits validates that the rate limits are set in and only in Antrea-native policy rules with RateLimit
+// action, and are valid.
+func (v *antreaPolicyValidator) validateRuleRateLimits(ingressRules, egressRules []crdv1beta1.Rule) (string, bool) {
+	for _, r := range append(ingressRules, egressRules...) {
+		if *r.Action != crdv1beta1.RuleActionRateLimit {
+			if r.RateLimit != nil {
+				return fmt.Sprintf("rateLimit of rule %s can only be set when the action is RateLimit", r.Name), false
+			}
+			continue
+		}
+		if r.RateLimit == nil || (r.RateLimit.ConnectionsPerSecond == nil && r.RateLimit.Bandwidth == nil) {
+			return fmt.Sprintf("rule %s with RateLimit action must set connectionsPerSecond or bandwidth in rateLimit", r.Name), false
+		}
+		if r.RateLimit.ConnectionsPerSecond != nil && *r.RateLimit.ConnectionsPerSecond <= 0 {
+			return fmt.Sprintf("connectionsPerSecond of rule %s must be positive", r.Name), false
+		}
+		if bandwidth := r.RateLimit.Bandwidth; bandwidth != nil {
+			if _, err := bandwidthToKbps(bandwidth.Rate); err != nil {
+				return fmt.Sprintf("invalid bandwidth rate %s of rule %s: %v", bandwidth.Rate, r.Name, err), false
+			}
+			if _, err := bandwidthToKbps(bandwidth.Burst); err != nil {
+				return fmt.Sprintf("invalid bandwidth burst %s of rule %s: %v", bandwidth.Burst, r.Name, err), false
+			}
+		}
+	}
+	return "", true
+}
+
 // validateFQDNSelectors validates the toFQDN field set in Antrea-native policy egress rules are valid.
 func (v *antreaPolicyValidator) validateFQDNSelectors(egressRules []crdv1beta1.Rule) (string, bool) {
 	for _, r := range egressRules {
@@ -990,11 +1195,14 @@ func validateAntreaClusterGroupSpec(s crdv1beta1.GroupSpec) (string, bool) {
 	}
 	multicast := false
 	unicast := false
-	for _, ipb := range s.IPBlocks {
+	for i, ipb := range s.IPBlocks {
 		ipaddr, _, err := net.ParseCIDR(ipb.CIDR)
 		if err != nil {
 			return fmt.Sprintf("invalid ip address: %v", err), false
 		}
+		if reason, allowed := validateIPBlock(&s.IPBlocks[i]); !allowed {
+			return reason, allowed
+		}
 		if ipaddr.IsMulticast() {
 			multicast = true
 		} else {
@@ -1023,6 +1231,11 @@ func validateAntreaGroupSpec(s crdv1beta1.GroupSpec) (string, bool) {
 			return reason, allowed
 		}
 	}
+	for i := range s.IPBlocks {
+		if reason, allowed := validateIPBlock(&s.IPBlocks[i]); !allowed {
+			return reason, allowed
+		}
+	}
 	return "", true
 }
 
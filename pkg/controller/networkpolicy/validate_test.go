@@ -17,6 +17,7 @@ package networkpolicy
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	admv1 "k8s.io/api/admission/v1"
@@ -31,12 +32,14 @@ import (
 )
 
 var (
-	query       = crdv1beta1.IGMPQuery
-	report      = crdv1beta1.IGMPReportV1
-	allowAction = crdv1beta1.RuleActionAllow
-	dropAction  = crdv1beta1.RuleActionDrop
-	passAction  = crdv1beta1.RuleActionPass
-	portNum80   = int32(80)
+	query                   = crdv1beta1.IGMPQuery
+	report                  = crdv1beta1.IGMPReportV1
+	allowAction             = crdv1beta1.RuleActionAllow
+	dropAction              = crdv1beta1.RuleActionDrop
+	passAction              = crdv1beta1.RuleActionPass
+	rateLimitAction         = crdv1beta1.RuleActionRateLimit
+	connectionsPerSecond100 = int32(100)
+	portNum80               = int32(80)
 )
 
 func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
@@ -1205,6 +1208,203 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "a rule/policy cannot be applied to Services and other peers at the same time",
 		},
+		{
+			name: "acnp-appliedto-node-and-psel",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-and-psel",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo2": "bar2"},
+							},
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			operation:      admv1.Create,
+			expectedReason: "a rule/policy cannot be applied to Nodes and other peers at the same time",
+		},
+		{
+			name: "acnp-appliedto-node-with-psel-in-same-peer",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-with-psel-in-same-peer",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo2": "bar2"},
+							},
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			operation:      admv1.Create,
+			expectedReason: "nodeSelector cannot be set with other peers in appliedTo",
+		},
+		{
+			name: "acnp-appliedto-node-with-fqdn",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-with-fqdn",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Egress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							To: []crdv1beta1.NetworkPolicyPeer{
+								{
+									FQDN: "foo.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			operation:      admv1.Create,
+			expectedReason: "fqdn cannot be used in a rule/policy that is applied to Nodes",
+		},
+		{
+			name: "acnp-appliedto-node-with-pass-action",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-with-pass-action",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &passAction,
+							From: []crdv1beta1.NetworkPolicyPeer{
+								{
+									IPBlock: &crdv1beta1.IPBlock{
+										CIDR: "10.0.0.0/24",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			operation:      admv1.Create,
+			expectedReason: "Pass action cannot be used in a rule/policy that is applied to Nodes",
+		},
+		{
+			name: "acnp-appliedto-node-with-named-port",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-with-named-port",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &dropAction,
+							Ports: []crdv1beta1.NetworkPolicyPort{
+								{
+									Port: &strHTTP,
+								},
+							},
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			operation:      admv1.Create,
+			expectedReason: "named port cannot be used in a rule/policy that is applied to Nodes",
+		},
+		{
+			name: "acnp-appliedto-node-feature-disabled",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-feature-disabled",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: false},
+			operation:      admv1.Create,
+			expectedReason: "nodeSelector in appliedTo can only be used when NodeNetworkPolicy is enabled",
+		},
+		{
+			name: "acnp-appliedto-node",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							From: []crdv1beta1.NetworkPolicyPeer{
+								{
+									IPBlock: &crdv1beta1.IPBlock{
+										CIDR: "10.0.0.0/24",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
 		{
 			name: "acnp-appliedto-service-with-egress-rule",
 			policy: &crdv1beta1.ClusterNetworkPolicy{
@@ -1441,6 +1641,304 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "HTTP protocol can only be used when layer 4 protocol is TCP or unset",
 		},
+		{
+			name:         "acnp-audit-with-l7protocols",
+			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "audit-rule-l7protocols",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							L7Protocols: []crdv1beta1.L7Protocol{
+								{
+									HTTP: &crdv1beta1.HTTPProtocol{
+										Host:   "test.com",
+										Method: "GET",
+									},
+								},
+							},
+						},
+					},
+					EnforcementMode: crdv1beta1.EnforcementModeAudit,
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "layer 7 protocols can not be used in Audit enforcement mode",
+		},
+		{
+			name: "acnp-rule-with-invalid-schedule",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "rule-invalid-schedule",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							Name:   "backup",
+							Schedule: &crdv1beta1.RuleSchedule{
+								Cron:     "0 25 * * *",
+								Duration: metav1.Duration{Duration: time.Hour},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: `invalid schedule of rule backup: invalid hour field "25": "25" is out of range [0, 23]`,
+		},
+		{
+			name: "acnp-rule-with-valid-schedule",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "rule-valid-schedule",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							Name:   "backup",
+							Schedule: &crdv1beta1.RuleSchedule{
+								Cron:     "0 1 * * *",
+								Duration: metav1.Duration{Duration: 3 * time.Hour},
+								TimeZone: "UTC",
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name: "acnp-ipblock-with-valid-except",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-ipblock-with-valid-except",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Egress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							To: []crdv1beta1.NetworkPolicyPeer{
+								{
+									IPBlock: &crdv1beta1.IPBlock{
+										CIDR:   "0.0.0.0/0",
+										Except: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name: "acnp-ipblock-with-except-outside-cidr",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-ipblock-with-except-outside-cidr",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Egress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							To: []crdv1beta1.NetworkPolicyPeer{
+								{
+									IPBlock: &crdv1beta1.IPBlock{
+										CIDR:   "10.0.0.0/16",
+										Except: []string{"10.1.0.0/24"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "except 10.1.0.0/24 of ipBlock 10.0.0.0/16 must be a strict subset of the cidr",
+		},
+		{
+			name: "acnp-ipblock-with-except-equal-to-cidr",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-ipblock-with-except-equal-to-cidr",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Egress: []crdv1beta1.Rule{
+						{
+							Action: &allowAction,
+							To: []crdv1beta1.NetworkPolicyPeer{
+								{
+									IPBlock: &crdv1beta1.IPBlock{
+										CIDR:   "10.0.0.0/16",
+										Except: []string{"10.0.0.0/16"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "except 10.0.0.0/16 of ipBlock 10.0.0.0/16 must be a strict subset of the cidr",
+		},
+		{
+			name: "acnp-rule-with-valid-rate-limit",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-rule-with-valid-rate-limit",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &rateLimitAction,
+							Name:   "limit-api",
+							RateLimit: &crdv1beta1.RuleRateLimit{
+								ConnectionsPerSecond: &connectionsPerSecond100,
+								Bandwidth:            &crdv1beta1.Bandwidth{Rate: "10M", Burst: "20M"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name: "acnp-rule-with-rate-limit-action-without-limit",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-rule-with-rate-limit-action-without-limit",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action:    &rateLimitAction,
+							Name:      "limit-api",
+							RateLimit: &crdv1beta1.RuleRateLimit{},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "rule limit-api with RateLimit action must set connectionsPerSecond or bandwidth in rateLimit",
+		},
+		{
+			name: "acnp-rule-with-rate-limit-for-allow-action",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-rule-with-rate-limit-for-allow-action",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action:    &allowAction,
+							Name:      "limit-api",
+							RateLimit: &crdv1beta1.RuleRateLimit{ConnectionsPerSecond: &connectionsPerSecond100},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "rateLimit of rule limit-api can only be set when the action is RateLimit",
+		},
+		{
+			name: "acnp-rule-with-invalid-bandwidth",
+			policy: &crdv1beta1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-rule-with-invalid-bandwidth",
+				},
+				Spec: crdv1beta1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1beta1.Rule{
+						{
+							Action: &rateLimitAction,
+							Name:   "limit-api",
+							RateLimit: &crdv1beta1.RuleRateLimit{
+								Bandwidth: &crdv1beta1.Bandwidth{Rate: "10", Burst: "20M"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid bandwidth rate 10 of rule limit-api: must be between 1k and 2147483647k",
+		},
 		{
 			name:         "acnp-l7protocols-HTTP-used-with-ICMP",
 			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
@@ -1691,6 +2189,27 @@ func TestValidateAntreaNetworkPolicy(t *testing.T) {
 		operation      admv1.Operation
 		expectedReason string
 	}{
+		{
+			name: "annp-appliedto-node",
+			policy: &crdv1beta1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "x",
+					Name:      "annp-appliedto-node",
+				},
+				Spec: crdv1beta1.NetworkPolicySpec{
+					AppliedTo: []crdv1beta1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+				},
+			},
+			featureGates:   map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			operation:      admv1.Create,
+			expectedReason: "nodeSelector in appliedTo can only be used in ClusterNetworkPolicy",
+		},
 		{
 			name: "annp-non-existent-tier",
 			policy: &crdv1beta1.NetworkPolicy{
@@ -1897,6 +2416,35 @@ func TestValidateAntreaClusterGroup(t *testing.T) {
 			},
 			operation: admv1.Create,
 		},
+		{
+			name: "cg-set-with-ipblock-except",
+			curCG: &crdv1beta1.ClusterGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cg-set-with-ipblock-except",
+				},
+				Spec: crdv1beta1.GroupSpec{
+					IPBlocks: []crdv1beta1.IPBlock{
+						{CIDR: "10.0.0.0/16", Except: []string{"10.0.1.0/24"}},
+					},
+				},
+			},
+			operation: admv1.Create,
+		},
+		{
+			name: "cg-set-with-invalid-ipblock-except",
+			curCG: &crdv1beta1.ClusterGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cg-set-with-invalid-ipblock-except",
+				},
+				Spec: crdv1beta1.GroupSpec{
+					IPBlocks: []crdv1beta1.IPBlock{
+						{CIDR: "10.0.0.0/16", Except: []string{"10.0.1.0/33"}},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid except 10.0.1.0/33 of ipBlock 10.0.0.0/16: invalid CIDR address: 10.0.1.0/33",
+		},
 		{
 			name: "cg-set-with-multicast",
 			curCG: &crdv1beta1.ClusterGroup{
@@ -277,6 +277,12 @@ func (c *Controller) checkTraceflowStatus(tf *crdv1beta1.Traceflow) error {
 		sender := false
 		receiver := false
 		for i, nodeResult := range tf.Status.Results {
+			// The packet from an external source is injected through the
+			// gateway of the source Node, which does not report a
+			// SpoofGuard observation.
+			if tf.Spec.Source.Node != "" && nodeResult.Node == tf.Spec.Source.Node {
+				sender = true
+			}
 			for j, ob := range nodeResult.Observations {
 				if ob.Component == crdv1beta1.ComponentSpoofGuard {
 					sender = true
@@ -307,7 +313,7 @@ func (c *Controller) checkTraceflowStatus(tf *crdv1beta1.Traceflow) error {
 		// results from both the sender and the receiver. When the Source
 		// Pod is not specified (in live-traffic Traceflow), only the
 		// receiver Node will report the results.
-		succeeded = (sender && receiver) || (receiver && tf.Spec.Source.Pod == "")
+		succeeded = (sender && receiver) || (receiver && tf.Spec.Source.Pod == "" && tf.Spec.Source.Node == "")
 	}
 	if succeeded {
 		c.deallocateTagForTF(tf)
@@ -134,6 +134,43 @@ func TestTraceflow(t *testing.T) {
 		tfc.client.CrdV1beta1().Traceflows().Delete(context.TODO(), "tf1", metav1.DeleteOptions{})
 	})
 
+	t.Run("externalSourceTraceflow", func(t *testing.T) {
+		tf2 := crdv1beta1.Traceflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf2", UID: "uid2"},
+			Spec: crdv1beta1.TraceflowSpec{
+				Source:      crdv1beta1.Source{IP: "192.168.1.100", Node: "node1"},
+				Destination: crdv1beta1.Destination{IP: "172.18.0.2"},
+			},
+		}
+		tfc.client.CrdV1beta1().Traceflows().Create(context.TODO(), &tf2, metav1.CreateOptions{})
+		res, _ := tfc.waitForTraceflow("tf2", crdv1beta1.Running, time.Second)
+		require.NotNil(t, res)
+
+		// The Traceflow should not succeed before the source Node reports its results.
+		res.Status.Results = []crdv1beta1.NodeResult{
+			{
+				Node:         "node2",
+				Observations: []crdv1beta1.Observation{{Action: crdv1beta1.ActionDelivered}},
+			},
+		}
+		res, _ = tfc.client.CrdV1beta1().Traceflows().Update(context.TODO(), res, metav1.UpdateOptions{})
+		succeeded, _ := tfc.waitForTraceflow("tf2", crdv1beta1.Succeeded, 500*time.Millisecond)
+		assert.Nil(t, succeeded)
+
+		res.Status.Results = append(res.Status.Results, crdv1beta1.NodeResult{
+			Node: "node1",
+			Observations: []crdv1beta1.Observation{
+				{Component: crdv1beta1.ComponentForwarding, Action: crdv1beta1.ActionReceived},
+				{Component: crdv1beta1.ComponentForwarding, Action: crdv1beta1.ActionForwarded, TunnelDstIP: "172.18.0.3"},
+			},
+		})
+		tfc.client.CrdV1beta1().Traceflows().Update(context.TODO(), res, metav1.UpdateOptions{})
+		res, _ = tfc.waitForTraceflow("tf2", crdv1beta1.Succeeded, time.Second)
+		assert.NotNil(t, res)
+		assert.Equal(t, numRunningTraceflows(), 0)
+		tfc.client.CrdV1beta1().Traceflows().Delete(context.TODO(), "tf2", metav1.DeleteOptions{})
+	})
+
 	t.Run("timeoutTraceflow", func(t *testing.T) {
 		startTime := time.Now()
 		tfc.client.CrdV1beta1().Traceflows().Create(context.TODO(), &tf1, metav1.CreateOptions{})
@@ -17,6 +17,7 @@ package traceflow
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 
 	admv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -68,7 +69,19 @@ func (c *Controller) Validate(review *admv1.AdmissionReview) *admv1.AdmissionRes
 }
 
 func (c *Controller) validate(tf *crdv1beta1.Traceflow) (allowed bool, deniedReason string) {
-	if !tf.Spec.LiveTraffic {
+	if tf.Spec.Source.Node != "" {
+		// The Traceflow packet from an external source IP is injected
+		// through the Antrea gateway of the source Node.
+		if tf.Spec.LiveTraffic {
+			return false, "source Node is not supported in live-traffic Traceflow"
+		}
+		if tf.Spec.Source.Pod != "" {
+			return false, "source Node and source Pod cannot be specified at the same time"
+		}
+		if tf.Spec.Source.IP == "" {
+			return false, "source IP must be specified together with source Node"
+		}
+	} else if !tf.Spec.LiveTraffic {
 		if tf.Spec.Source.Namespace == "" || tf.Spec.Source.Pod == "" {
 			return false, "source Pod must be specified in non-live-traffic Traceflow"
 		}
@@ -83,8 +96,78 @@ func (c *Controller) validate(tf *crdv1beta1.Traceflow) (allowed bool, deniedRea
 			return false, "using hostNetwork Pod as source in non-live-traffic Traceflow is not supported"
 		}
 	}
-	if tf.Spec.Source.Pod == "" && tf.Spec.Destination.Pod == "" {
+	if tf.Spec.Source.Node == "" && tf.Spec.Source.Pod == "" && tf.Spec.Destination.Pod == "" {
 		return false, fmt.Sprintf("Traceflow %s has neither source nor destination Pod specified", tf.Name)
 	}
+	if err := validatePacket(&tf.Spec); err != nil {
+		return false, err.Error()
+	}
 	return true, ""
 }
+
+// validatePacket checks that the IP family and the protocols specified in the
+// packet spec are consistent with each other and with the source and
+// destination IPs.
+func validatePacket(spec *crdv1beta1.TraceflowSpec) error {
+	packet := &spec.Packet
+	if packet.IPHeader != nil && packet.IPv6Header != nil {
+		return fmt.Errorf("ipHeader and ipv6Header cannot be set at the same time")
+	}
+	var isIPv6 *bool
+	for _, ipStr := range []string{spec.Source.IP, spec.Destination.IP} {
+		if ipStr == "" {
+			continue
+		}
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return fmt.Errorf("invalid IP address %s", ipStr)
+		}
+		ipv6 := ip.To4() == nil
+		if isIPv6 != nil && *isIPv6 != ipv6 {
+			return fmt.Errorf("source IP %s and destination IP %s must be of the same IP family", spec.Source.IP, spec.Destination.IP)
+		}
+		isIPv6 = &ipv6
+	}
+	if isIPv6 != nil {
+		if *isIPv6 && packet.IPHeader != nil {
+			return fmt.Errorf("ipHeader cannot be set for IPv6 addresses")
+		}
+		if !*isIPv6 && packet.IPv6Header != nil {
+			return fmt.Errorf("ipv6Header cannot be set for IPv4 addresses")
+		}
+	}
+
+	var transportProtocols []int32
+	transportHeader := &packet.TransportHeader
+	if transportHeader.TCP != nil {
+		transportProtocols = append(transportProtocols, crdv1beta1.TCPProtocolNumber)
+	}
+	if transportHeader.UDP != nil {
+		transportProtocols = append(transportProtocols, crdv1beta1.UDPProtocolNumber)
+	}
+	if transportHeader.SCTP != nil {
+		transportProtocols = append(transportProtocols, crdv1beta1.SCTPProtocolNumber)
+	}
+	if transportHeader.ICMP != nil {
+		transportProtocols = append(transportProtocols, crdv1beta1.ICMPProtocolNumber)
+	}
+	if len(transportProtocols) > 1 {
+		return fmt.Errorf("at most one transport header can be set")
+	}
+
+	var ipProtocol int32
+	if packet.IPHeader != nil {
+		ipProtocol = packet.IPHeader.Protocol
+	} else if packet.IPv6Header != nil && packet.IPv6Header.NextHeader != nil {
+		ipProtocol = *packet.IPv6Header.NextHeader
+	}
+	// ICMP and ICMPv6 are interchangeable, the ICMP version of the packet is
+	// determined by its IP family.
+	if ipProtocol == crdv1beta1.ICMPv6ProtocolNumber {
+		ipProtocol = crdv1beta1.ICMPProtocolNumber
+	}
+	if ipProtocol != 0 && len(transportProtocols) == 1 && ipProtocol != transportProtocols[0] {
+		return fmt.Errorf("IP protocol %d does not match the %s transport header", ipProtocol, crdv1beta1.ProtocolsToString[transportProtocols[0]])
+	}
+	return nil
+}
@@ -28,6 +28,8 @@ import (
 )
 
 func TestControllerValidate(t *testing.T) {
+	icmpv6Protocol := crdv1beta1.ICMPv6ProtocolNumber
+	sctpProtocol := crdv1beta1.SCTPProtocolNumber
 	tests := []struct {
 		name string
 
@@ -82,6 +84,158 @@ func TestControllerValidate(t *testing.T) {
 			},
 			deniedReason: "using hostNetwork Pod as source in non-live-traffic Traceflow is not supported",
 		},
+		{
+			name: "Source Node is not supported in live-traffic Traceflow",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				LiveTraffic: true,
+				Source:      crdv1beta1.Source{IP: "192.168.1.100", Node: "node1"},
+				Destination: crdv1beta1.Destination{IP: "172.18.0.2"},
+			},
+			deniedReason: "source Node is not supported in live-traffic Traceflow",
+		},
+		{
+			name: "Source Node and source Pod cannot be both set",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod", Node: "node1"},
+				Destination: crdv1beta1.Destination{IP: "172.18.0.2"},
+			},
+			deniedReason: "source Node and source Pod cannot be specified at the same time",
+		},
+		{
+			name: "Source IP must be specified with source Node",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				Source:      crdv1beta1.Source{Node: "node1"},
+				Destination: crdv1beta1.Destination{IP: "172.18.0.2"},
+			},
+			deniedReason: "source IP must be specified together with source Node",
+		},
+		{
+			name: "Valid Traceflow from external source to NodePort",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				Source:      crdv1beta1.Source{IP: "192.168.1.100", Node: "node1"},
+				Destination: crdv1beta1.Destination{IP: "172.18.0.2"},
+				Packet: crdv1beta1.Packet{
+					TransportHeader: crdv1beta1.TransportHeader{
+						TCP: &crdv1beta1.TCPHeader{DstPort: 30080},
+					},
+				},
+			},
+			allowed: true,
+		},
+		{
+			name: "IPv4 and IPv6 headers cannot be both set",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				LiveTraffic: true,
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod"},
+				Packet: crdv1beta1.Packet{
+					IPHeader:   &crdv1beta1.IPHeader{},
+					IPv6Header: &crdv1beta1.IPv6Header{},
+				},
+			},
+			deniedReason: "ipHeader and ipv6Header cannot be set at the same time",
+		},
+		{
+			name: "IPv6 header with IPv4 destination",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				LiveTraffic: true,
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod"},
+				Destination: crdv1beta1.Destination{IP: "10.0.0.2"},
+				Packet: crdv1beta1.Packet{
+					IPv6Header: &crdv1beta1.IPv6Header{},
+				},
+			},
+			deniedReason: "ipv6Header cannot be set for IPv4 addresses",
+		},
+		{
+			name: "Source and destination IPs of different families",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				LiveTraffic: true,
+				Source:      crdv1beta1.Source{IP: "10.0.0.1"},
+				Destination: crdv1beta1.Destination{Namespace: "test-ns", Pod: "test-pod", IP: "fd00::2"},
+			},
+			deniedReason: "source IP 10.0.0.1 and destination IP fd00::2 must be of the same IP family",
+		},
+		{
+			name: "Multiple transport headers",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				LiveTraffic: true,
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod"},
+				Packet: crdv1beta1.Packet{
+					TransportHeader: crdv1beta1.TransportHeader{
+						TCP:  &crdv1beta1.TCPHeader{DstPort: 80},
+						SCTP: &crdv1beta1.SCTPHeader{DstPort: 80},
+					},
+				},
+			},
+			deniedReason: "at most one transport header can be set",
+		},
+		{
+			name: "IP protocol does not match transport header",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				LiveTraffic: true,
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod"},
+				Packet: crdv1beta1.Packet{
+					IPHeader: &crdv1beta1.IPHeader{Protocol: crdv1beta1.UDPProtocolNumber},
+					TransportHeader: crdv1beta1.TransportHeader{
+						SCTP: &crdv1beta1.SCTPHeader{DstPort: 80},
+					},
+				},
+			},
+			deniedReason: "IP protocol 17 does not match the SCTP transport header",
+		},
+		{
+			name: "IPv6 next header does not match transport header",
+			newSpec: &crdv1beta1.TraceflowSpec{
+				LiveTraffic: true,
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod"},
+				Destination: crdv1beta1.Destination{IP: "fd00::2"},
+				Packet: crdv1beta1.Packet{
+					IPv6Header: &crdv1beta1.IPv6Header{NextHeader: &sctpProtocol},
+					TransportHeader: crdv1beta1.TransportHeader{
+						ICMP: &crdv1beta1.ICMPEchoRequestHeader{},
+					},
+				},
+			},
+			deniedReason: "IP protocol 132 does not match the ICMP transport header",
+		},
+		{
+			name: "Valid SCTP request with IPv6 destination",
+			pods: []*v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-pod"},
+				},
+			},
+			newSpec: &crdv1beta1.TraceflowSpec{
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod"},
+				Destination: crdv1beta1.Destination{IP: "fd00::2"},
+				Packet: crdv1beta1.Packet{
+					IPv6Header: &crdv1beta1.IPv6Header{NextHeader: &sctpProtocol},
+					TransportHeader: crdv1beta1.TransportHeader{
+						SCTP: &crdv1beta1.SCTPHeader{DstPort: 36412},
+					},
+				},
+			},
+			allowed: true,
+		},
+		{
+			name: "Valid ICMPv6 request",
+			pods: []*v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-pod"},
+				},
+			},
+			newSpec: &crdv1beta1.TraceflowSpec{
+				Source:      crdv1beta1.Source{Namespace: "test-ns", Pod: "test-pod"},
+				Destination: crdv1beta1.Destination{IP: "fd00::2"},
+				Packet: crdv1beta1.Packet{
+					IPv6Header: &crdv1beta1.IPv6Header{NextHeader: &icmpv6Protocol},
+					TransportHeader: crdv1beta1.TransportHeader{
+						ICMP: &crdv1beta1.ICMPEchoRequestHeader{ID: 1, Sequence: 1},
+					},
+				},
+			},
+			allowed: true,
+		},
 		{
 			name: "Valid request",
 			pods: []*v1.Pod{
@@ -126,7 +126,8 @@ type Group struct {
 	// and has not been processed by the controller yet / Service cannot be found.
 	Selector *GroupSelector
 	IPBlocks []controlplane.IPBlock
-	// IPNets stores net.IPNet objects for each CIDR defined in the IPBlocks field.
+	// IPNets stores net.IPNet objects for each CIDR defined in the IPBlocks field,
+	// in the same order as IPBlocks.
 	// It is used for IP association query tests, so that for IP membership tests
 	// we do not need to instantiate an IPNet object each time.
 	IPNets []net.IPNet
@@ -15,6 +15,8 @@
 package types
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -105,6 +107,20 @@ type NetworkPolicy struct {
 	AppliedToPerRule bool
 	// SyncError is the Error encountered when syncing this NetworkPolicy.
 	SyncError error
+	// ScheduleState describes the current states of the rules with a schedule.
+	// It's nil if no rule of this NetworkPolicy has a schedule.
+	ScheduleState *RuleScheduleState
+}
+
+// RuleScheduleState describes whether the rules with a schedule in a NetworkPolicy are active.
+type RuleScheduleState struct {
+	// ActiveRules is a list of names of the rules which are active currently.
+	ActiveRules []string
+	// InactiveRules is a list of names of the rules which are inactive currently.
+	InactiveRules []string
+	// NextTransitionTime is the earliest time when any of the rules is activated or deactivated.
+	// It's zero if none of the rules will be activated or deactivated.
+	NextTransitionTime time.Time
 }
 
 // GetAddressGroups returns AddressGroups used by this NetworkPolicy.
@@ -146,6 +146,23 @@ const (
 	// alpha: v1.14
 	// Enable Egress traffic shaping.
 	EgressTrafficShaping featuregate.Feature = "EgressTrafficShaping"
+
+	// alpha: v1.15
+	// Allow users to apply ClusterNetworkPolicy to Kubernetes Nodes.
+	NodeNetworkPolicy featuregate.Feature = "NodeNetworkPolicy"
+
+	// alpha: v1.15
+	// Enable capturing packets between a source and a destination on demand.
+	PacketCapture featuregate.Feature = "PacketCapture"
+
+	// alpha: v1.15
+	// Enable advertising Pod CIDRs, Service IPs and Egress IPs to BGP peers.
+	BGPPolicy featuregate.Feature = "BGPPolicy"
+
+	// alpha: v1.15
+	// Enable exporting L7 (HTTP and TLS) metadata of the connections of annotated Pods, as observed by the L7 engine,
+	// in flow records.
+	L7FlowExporter featuregate.Feature = "L7FlowExporter"
 )
 
 var (
@@ -184,6 +201,10 @@ var (
 		LoadBalancerModeDSR:         {Default: false, PreRelease: featuregate.Alpha},
 		AdminNetworkPolicy:          {Default: false, PreRelease: featuregate.Alpha},
 		EgressTrafficShaping:        {Default: false, PreRelease: featuregate.Alpha},
+		NodeNetworkPolicy:           {Default: false, PreRelease: featuregate.Alpha},
+		PacketCapture:               {Default: false, PreRelease: featuregate.Alpha},
+		BGPPolicy:                   {Default: false, PreRelease: featuregate.Alpha},
+		L7FlowExporter:              {Default: false, PreRelease: featuregate.Alpha},
 	}
 
 	// AgentGates consists of all known feature gates for the Antrea Agent.
@@ -211,6 +232,10 @@ var (
 		Traceflow,
 		TrafficControl,
 		EgressTrafficShaping,
+		NodeNetworkPolicy,
+		PacketCapture,
+		BGPPolicy,
+		L7FlowExporter,
 	)
 
 	// ControllerGates consists of all known feature gates for the Antrea Controller.
@@ -226,6 +251,7 @@ var (
 		Multicluster,
 		NetworkPolicyStats,
 		NodeIPAM,
+		NodeNetworkPolicy,
 		ServiceExternalIP,
 		SupportBundleCollection,
 		Traceflow,
@@ -255,6 +281,10 @@ var (
 		LoadBalancerModeDSR:         {},
 		CleanupStaleUDPSvcConntrack: {},
 		EgressTrafficShaping:        {},
+		NodeNetworkPolicy:           {},
+		PacketCapture:               {},
+		BGPPolicy:                   {},
+		L7FlowExporter:              {},
 	}
 	// supportedFeaturesOnExternalNode records the features supported on an external
 	// Node. Antrea Agent checks the enabled features if it is running on an
@@ -87,9 +87,13 @@ const (
                    reverseThroughputFromDestinationNode,
                    clusterUUID,
                    egressName,
-                   egressIP)
+                   egressIP,
+                   appProtocolName,
+                   httpVals,
+                   tlsServerName)
                    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 
-                           ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+                           ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+                           ?, ?, ?)`
 )
 
 // PrepareClickHouseConnection is used for unit testing
@@ -329,6 +333,9 @@ func (ch *ClickHouseExportProcess) batchCommitAll(ctx context.Context) (int, err
 			ch.clusterUUID,
 			record.EgressName,
 			record.EgressIP,
+			record.AppProtocolName,
+			record.HttpVals,
+			record.TLSServerName,
 		)
 
 		if err != nil {
@@ -29,17 +29,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ipfixentitiestesting "github.com/vmware/go-ipfix/pkg/entities/testing"
-	"github.com/vmware/go-ipfix/pkg/registry"
 	"go.uber.org/mock/gomock"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
 	flowrecordtesting "antrea.io/antrea/pkg/flowaggregator/flowrecord/testing"
 	flowaggregatortesting "antrea.io/antrea/pkg/flowaggregator/testing"
+	"antrea.io/antrea/pkg/ipfix"
 )
 
 func init() {
-	registry.LoadRegistry()
+	ipfix.LoadRegistry()
 }
 
 var fakeClusterUUID = uuid.New().String()
@@ -135,7 +135,10 @@ func TestBatchCommitAll(t *testing.T) {
 			12381346,
 			fakeClusterUUID,
 			"test-egress",
-			"172.18.0.1").
+			"172.18.0.1",
+			"http",
+			"mockHttpString",
+			"").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
@@ -0,0 +1,156 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+
+	ipfixentities "github.com/vmware/go-ipfix/pkg/entities"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/flowaggregator/kafkaproducer"
+	"antrea.io/antrea/pkg/flowaggregator/options"
+)
+
+const (
+	KafkaCertDir        = "/etc/flow-aggregator/kafka-certs"
+	KafkaClientCertFile = "tls.crt"
+	KafkaClientKeyFile  = "tls.key"
+)
+
+// kafkaCertDir is a variable to allow overriding it in unit tests.
+var kafkaCertDir = KafkaCertDir
+
+type KafkaExporter struct {
+	kafkaInput          *kafkaproducer.KafkaInput
+	kafkaProduceProcess *kafkaproducer.KafkaProduceProcess
+}
+
+func buildKafkaInput(opt *options.Options) kafkaproducer.KafkaInput {
+	return kafkaproducer.KafkaInput{
+		Config:        opt.Config.Kafka,
+		FlushInterval: opt.KafkaFlushInterval,
+		Username:      os.Getenv("KAFKA_USERNAME"),
+		Password:      os.Getenv("KAFKA_PASSWORD"),
+	}
+}
+
+// readKafkaCertificates reads the certificates required by the TLS
+// configuration from the mounted Secret. As it can take some time for the
+// Secret to be mounted, it keeps retrying until Timeout.
+func readKafkaCertificates(input *kafkaproducer.KafkaInput) error {
+	tlsConfig := input.Config.TLS
+	if !tlsConfig.Enable || (!tlsConfig.CACert && !tlsConfig.ClientCert) {
+		return nil
+	}
+	var errMessage error
+	readFile := func(name string) ([]byte, bool) {
+		data, err := os.ReadFile(path.Join(kafkaCertDir, name))
+		if err != nil {
+			errMessage = err
+			return nil, false
+		}
+		return data, true
+	}
+	err := wait.Poll(DefaultInterval, Timeout, func() (bool, error) {
+		var ok bool
+		if tlsConfig.CACert {
+			if input.CACert, ok = readFile(CACertFile); !ok {
+				return false, nil
+			}
+		}
+		if tlsConfig.ClientCert {
+			if input.ClientCert, ok = readFile(KafkaClientCertFile); !ok {
+				return false, nil
+			}
+			if input.ClientKey, ok = readFile(KafkaClientKeyFile); !ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when reading Kafka certificates: %v", errMessage)
+	}
+	return nil
+}
+
+func NewKafkaExporter(k8sClient kubernetes.Interface, opt *options.Options) (*KafkaExporter, error) {
+	kafkaInput := buildKafkaInput(opt)
+	config := kafkaInput.Config
+	klog.InfoS("Kafka configuration", "brokers", config.Brokers, "topic", config.Topic, "partitionKey", config.PartitionKey, "recordFormat", config.RecordFormat,
+		"maxRecordsPerBatch", config.MaxRecordsPerBatch, "maxBufferedBatches", config.MaxBufferedBatches, "flushInterval", kafkaInput.FlushInterval,
+		"tls", config.TLS.Enable, "sasl", config.SASL.Enable, "saslMechanism", config.SASL.Mechanism)
+	if err := readKafkaCertificates(&kafkaInput); err != nil {
+		return nil, err
+	}
+	clusterUUID, err := getClusterUUID(k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	kafkaProduceProcess, err := kafkaproducer.NewKafkaProduceProcess(kafkaInput, clusterUUID.String())
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaExporter{
+		kafkaInput:          &kafkaInput,
+		kafkaProduceProcess: kafkaProduceProcess,
+	}, nil
+}
+
+func (e *KafkaExporter) AddRecord(record ipfixentities.Record, isRecordIPv6 bool) error {
+	e.kafkaProduceProcess.CacheRecord(record)
+	return nil
+}
+
+func (e *KafkaExporter) Start() {
+	e.kafkaProduceProcess.Start()
+}
+
+func (e *KafkaExporter) Stop() {
+	e.kafkaProduceProcess.Stop()
+}
+
+func (e *KafkaExporter) UpdateOptions(opt *options.Options) {
+	kafkaInput := buildKafkaInput(opt)
+	if err := readKafkaCertificates(&kafkaInput); err != nil {
+		klog.ErrorS(err, "Error when updating Kafka config")
+		return
+	}
+	currentInput := e.kafkaProduceProcess.GetKafkaInput()
+	if reflect.DeepEqual(kafkaInput, currentInput) {
+		return
+	}
+	klog.InfoS("Updating Kafka producer")
+	currentInput.FlushInterval = kafkaInput.FlushInterval
+	if reflect.DeepEqual(kafkaInput, currentInput) {
+		// Only the flush interval has changed, there is no need to create a
+		// new producer.
+		e.kafkaProduceProcess.SetFlushInterval(kafkaInput.FlushInterval)
+	} else if err := e.kafkaProduceProcess.UpdateKafkaProducer(kafkaInput); err != nil {
+		klog.ErrorS(err, "Error when updating Kafka producer")
+		return
+	}
+	e.kafkaInput = &kafkaInput
+	config := kafkaInput.Config
+	klog.InfoS("New Kafka configuration", "brokers", config.Brokers, "topic", config.Topic, "partitionKey", config.PartitionKey, "recordFormat", config.RecordFormat,
+		"maxRecordsPerBatch", config.MaxRecordsPerBatch, "maxBufferedBatches", config.MaxBufferedBatches, "flushInterval", kafkaInput.FlushInterval,
+		"tls", config.TLS.Enable, "sasl", config.SASL.Enable, "saslMechanism", config.SASL.Mechanism)
+}
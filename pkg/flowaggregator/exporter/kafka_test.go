@@ -0,0 +1,116 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/flowaggregator/kafkaproducer"
+	kafkaproducertesting "antrea.io/antrea/pkg/flowaggregator/kafkaproducer/testing"
+	"antrea.io/antrea/pkg/flowaggregator/options"
+)
+
+func newTestKafkaOptions(topic string, flushInterval time.Duration) *options.Options {
+	return &options.Options{
+		Config: &flowaggregator.FlowAggregatorConfig{
+			Kafka: flowaggregator.KafkaConfig{
+				Enable:             true,
+				Brokers:            []string{"kafka:9092"},
+				Topic:              topic,
+				PartitionKey:       "FlowKey",
+				RecordFormat:       "Protobuf",
+				MaxRecordsPerBatch: 1000,
+				MaxBufferedBatches: 100,
+			},
+		},
+		KafkaFlushInterval: flushInterval,
+	}
+}
+
+func TestKafka_UpdateOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	newKafkaProducerSaved := kafkaproducer.NewKafkaProducer
+	numProducers := 0
+	kafkaproducer.NewKafkaProducer = func(input kafkaproducer.KafkaInput) (kafkaproducer.KafkaProducerAPI, error) {
+		numProducers += 1
+		return mockProducer, nil
+	}
+	defer func() {
+		kafkaproducer.NewKafkaProducer = newKafkaProducerSaved
+	}()
+
+	opt := newTestKafkaOptions("flows", 8*time.Second)
+	kafkaInput := buildKafkaInput(opt)
+	kafkaProduceProcess, err := kafkaproducer.NewKafkaProduceProcess(kafkaInput, uuid.New().String())
+	require.NoError(t, err)
+	kafkaExporter := KafkaExporter{kafkaInput: &kafkaInput, kafkaProduceProcess: kafkaProduceProcess}
+	kafkaExporter.Start()
+	assert.Equal(t, "flows", kafkaExporter.kafkaProduceProcess.GetKafkaInput().Config.Topic)
+	assert.Equal(t, "8s", kafkaExporter.kafkaProduceProcess.GetFlushInterval().String())
+	assert.Equal(t, 1, numProducers)
+
+	// Only the flush interval is updated, the producer is not recreated.
+	kafkaExporter.UpdateOptions(newTestKafkaOptions("flows", 5*time.Second))
+	assert.Equal(t, "5s", kafkaExporter.kafkaProduceProcess.GetFlushInterval().String())
+	assert.Equal(t, 1, numProducers)
+
+	mockProducer.EXPECT().Close()
+	kafkaExporter.UpdateOptions(newTestKafkaOptions("new-flows", 5*time.Second))
+	assert.Equal(t, "new-flows", kafkaExporter.kafkaProduceProcess.GetKafkaInput().Config.Topic)
+	assert.Equal(t, "new-flows", kafkaExporter.kafkaInput.Config.Topic)
+	assert.Equal(t, 2, numProducers)
+
+	mockProducer.EXPECT().Close()
+	kafkaExporter.Stop()
+}
+
+func TestReadKafkaCertificates(t *testing.T) {
+	certDir := t.TempDir()
+	kafkaCertDirSaved := kafkaCertDir
+	kafkaCertDir = certDir
+	defer func() {
+		kafkaCertDir = kafkaCertDirSaved
+	}()
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, CACertFile), []byte("ca"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, KafkaClientCertFile), []byte("cert"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, KafkaClientKeyFile), []byte("key"), 0600))
+
+	input := buildKafkaInput(newTestKafkaOptions("flows", time.Second))
+	input.Config.TLS = flowaggregator.KafkaTLSConfig{
+		Enable:     true,
+		CACert:     true,
+		ClientCert: true,
+	}
+	require.NoError(t, readKafkaCertificates(&input))
+	assert.Equal(t, []byte("ca"), input.CACert)
+	assert.Equal(t, []byte("cert"), input.ClientCert)
+	assert.Equal(t, []byte("key"), input.ClientKey)
+
+	input = buildKafkaInput(newTestKafkaOptions("flows", time.Second))
+	input.Config.TLS = flowaggregator.KafkaTLSConfig{Enable: true}
+	require.NoError(t, readKafkaCertificates(&input))
+	assert.Nil(t, input.CACert)
+	assert.Nil(t, input.ClientCert)
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+
+	ipfixentities "github.com/vmware/go-ipfix/pkg/entities"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/flowaggregator/options"
+	"antrea.io/antrea/pkg/flowaggregator/otlpclient"
+)
+
+const OTLPCertDir = "/etc/flow-aggregator/otlp-certs"
+
+// otlpCertDir is a variable to allow overriding it in unit tests.
+var otlpCertDir = OTLPCertDir
+
+type OTLPExporter struct {
+	otlpInput         *otlpclient.OTLPInput
+	otlpExportProcess *otlpclient.OTLPExportProcess
+}
+
+func buildOTLPInput(opt *options.Options) otlpclient.OTLPInput {
+	return otlpclient.OTLPInput{
+		Config:         opt.Config.OTLP,
+		ExportInterval: opt.OTLPExportInterval,
+	}
+}
+
+// readOTLPCACert reads the custom CA certificate from the mounted Secret. As it
+// can take some time for the Secret to be mounted, it keeps retrying until
+// Timeout.
+func readOTLPCACert(input *otlpclient.OTLPInput) error {
+	if !input.Config.TLS.Enable || !input.Config.TLS.CACert {
+		return nil
+	}
+	var errMessage error
+	err := wait.Poll(DefaultInterval, Timeout, func() (bool, error) {
+		caCert, err := os.ReadFile(path.Join(otlpCertDir, CACertFile))
+		if err != nil {
+			errMessage = err
+			return false, nil
+		}
+		input.CACert = caCert
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when reading OTLP CA certificate: %v", errMessage)
+	}
+	return nil
+}
+
+func NewOTLPExporter(k8sClient kubernetes.Interface, opt *options.Options) (*OTLPExporter, error) {
+	otlpInput := buildOTLPInput(opt)
+	config := otlpInput.Config
+	klog.InfoS("OTLP configuration", "endpoint", config.Endpoint, "protocol", config.Protocol, "exportInterval", otlpInput.ExportInterval,
+		"maxRecordsPerRequest", config.MaxRecordsPerRequest, "metrics", config.Metrics.Enable, "tls", config.TLS.Enable)
+	if err := readOTLPCACert(&otlpInput); err != nil {
+		return nil, err
+	}
+	clusterUUID, err := getClusterUUID(k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	otlpExportProcess, err := otlpclient.NewOTLPExportProcess(otlpInput, clusterUUID.String())
+	if err != nil {
+		return nil, err
+	}
+	return &OTLPExporter{
+		otlpInput:         &otlpInput,
+		otlpExportProcess: otlpExportProcess,
+	}, nil
+}
+
+func (e *OTLPExporter) AddRecord(record ipfixentities.Record, isRecordIPv6 bool) error {
+	e.otlpExportProcess.CacheRecord(record)
+	return nil
+}
+
+func (e *OTLPExporter) Start() {
+	e.otlpExportProcess.Start()
+}
+
+func (e *OTLPExporter) Stop() {
+	e.otlpExportProcess.Stop()
+}
+
+func (e *OTLPExporter) UpdateOptions(opt *options.Options) {
+	otlpInput := buildOTLPInput(opt)
+	if err := readOTLPCACert(&otlpInput); err != nil {
+		klog.ErrorS(err, "Error when updating OTLP config")
+		return
+	}
+	currentInput := e.otlpExportProcess.GetOTLPInput()
+	if reflect.DeepEqual(otlpInput, currentInput) {
+		return
+	}
+	klog.InfoS("Updating OTLP client")
+	currentInput.ExportInterval = otlpInput.ExportInterval
+	if reflect.DeepEqual(otlpInput, currentInput) {
+		// Only the export interval has changed, there is no need to create a
+		// new client.
+		e.otlpExportProcess.SetExportInterval(otlpInput.ExportInterval)
+	} else if err := e.otlpExportProcess.UpdateOTLPClient(otlpInput); err != nil {
+		klog.ErrorS(err, "Error when updating OTLP client")
+		return
+	}
+	e.otlpInput = &otlpInput
+	config := otlpInput.Config
+	klog.InfoS("New OTLP configuration", "endpoint", config.Endpoint, "protocol", config.Protocol, "exportInterval", otlpInput.ExportInterval,
+		"maxRecordsPerRequest", config.MaxRecordsPerRequest, "metrics", config.Metrics.Enable, "tls", config.TLS.Enable)
+}
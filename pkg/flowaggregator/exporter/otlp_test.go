@@ -0,0 +1,107 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/flowaggregator/options"
+	"antrea.io/antrea/pkg/flowaggregator/otlpclient"
+	otlpclienttesting "antrea.io/antrea/pkg/flowaggregator/otlpclient/testing"
+)
+
+func newTestOTLPOptions(endpoint string, exportInterval time.Duration) *options.Options {
+	return &options.Options{
+		Config: &flowaggregator.FlowAggregatorConfig{
+			OTLP: flowaggregator.OTLPConfig{
+				Enable:               true,
+				Endpoint:             endpoint,
+				Protocol:             "gRPC",
+				MaxRecordsPerRequest: 1000,
+			},
+		},
+		OTLPExportInterval: exportInterval,
+	}
+}
+
+func TestOTLP_UpdateOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	newOTLPClientSaved := otlpclient.NewOTLPClient
+	numClients := 0
+	otlpclient.NewOTLPClient = func(input otlpclient.OTLPInput) (otlpclient.OTLPClientAPI, error) {
+		numClients += 1
+		return mockClient, nil
+	}
+	defer func() {
+		otlpclient.NewOTLPClient = newOTLPClientSaved
+	}()
+
+	opt := newTestOTLPOptions("otel-collector:4317", 8*time.Second)
+	otlpInput := buildOTLPInput(opt)
+	otlpExportProcess, err := otlpclient.NewOTLPExportProcess(otlpInput, uuid.New().String())
+	require.NoError(t, err)
+	otlpExporter := OTLPExporter{otlpInput: &otlpInput, otlpExportProcess: otlpExportProcess}
+	otlpExporter.Start()
+	assert.Equal(t, "otel-collector:4317", otlpExporter.otlpExportProcess.GetOTLPInput().Config.Endpoint)
+	assert.Equal(t, "8s", otlpExporter.otlpExportProcess.GetExportInterval().String())
+	assert.Equal(t, 1, numClients)
+
+	// Only the export interval is updated, the client is not recreated.
+	otlpExporter.UpdateOptions(newTestOTLPOptions("otel-collector:4317", 5*time.Second))
+	assert.Equal(t, "5s", otlpExporter.otlpExportProcess.GetExportInterval().String())
+	assert.Equal(t, 1, numClients)
+
+	mockClient.EXPECT().Close()
+	otlpExporter.UpdateOptions(newTestOTLPOptions("otel-collector-new:4317", 5*time.Second))
+	assert.Equal(t, "otel-collector-new:4317", otlpExporter.otlpExportProcess.GetOTLPInput().Config.Endpoint)
+	assert.Equal(t, "otel-collector-new:4317", otlpExporter.otlpInput.Config.Endpoint)
+	assert.Equal(t, 2, numClients)
+
+	mockClient.EXPECT().Close()
+	otlpExporter.Stop()
+}
+
+func TestReadOTLPCACert(t *testing.T) {
+	certDir := t.TempDir()
+	otlpCertDirSaved := otlpCertDir
+	otlpCertDir = certDir
+	defer func() {
+		otlpCertDir = otlpCertDirSaved
+	}()
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, CACertFile), []byte("ca"), 0600))
+
+	input := buildOTLPInput(newTestOTLPOptions("otel-collector:4317", time.Second))
+	input.Config.TLS = flowaggregator.OTLPTLSConfig{
+		Enable: true,
+		CACert: true,
+	}
+	require.NoError(t, readOTLPCACert(&input))
+	assert.Equal(t, []byte("ca"), input.CACert)
+
+	input = buildOTLPInput(newTestOTLPOptions("otel-collector:4317", time.Second))
+	input.Config.TLS = flowaggregator.OTLPTLSConfig{Enable: true}
+	require.NoError(t, readOTLPCACert(&input))
+	assert.Nil(t, input.CACert)
+}
@@ -73,6 +73,9 @@ var (
 		"egressNetworkPolicyRuleAction",
 		"egressNetworkPolicyType",
 		"egressNetworkPolicyRuleName",
+		"appProtocolName",
+		"httpVals",
+		"tlsServerName",
 	}
 )
 
@@ -94,6 +97,12 @@ var (
 	newS3Exporter = func(k8sClient kubernetes.Interface, opt *options.Options) (exporter.Interface, error) {
 		return exporter.NewS3Exporter(k8sClient, opt)
 	}
+	newKafkaExporter = func(k8sClient kubernetes.Interface, opt *options.Options) (exporter.Interface, error) {
+		return exporter.NewKafkaExporter(k8sClient, opt)
+	}
+	newOTLPExporter = func(k8sClient kubernetes.Interface, opt *options.Options) (exporter.Interface, error) {
+		return exporter.NewOTLPExporter(k8sClient, opt)
+	}
 	newLogExporter = func(opt *options.Options) (exporter.Interface, error) {
 		return exporter.NewLogExporter(opt)
 	}
@@ -119,6 +128,8 @@ type flowAggregator struct {
 	ipfixExporter               exporter.Interface
 	clickHouseExporter          exporter.Interface
 	s3Exporter                  exporter.Interface
+	kafkaExporter               exporter.Interface
+	otlpExporter                exporter.Interface
 	logExporter                 exporter.Interface
 	logTickerDuration           time.Duration
 }
@@ -193,6 +204,20 @@ func NewFlowAggregator(
 			return nil, fmt.Errorf("error when creating S3 export process: %v", err)
 		}
 	}
+	if opt.Config.Kafka.Enable {
+		var err error
+		fa.kafkaExporter, err = newKafkaExporter(k8sClient, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error when creating Kafka export process: %v", err)
+		}
+	}
+	if opt.Config.OTLP.Enable {
+		var err error
+		fa.otlpExporter, err = newOTLPExporter(k8sClient, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error when creating OTLP export process: %v", err)
+		}
+	}
 	if opt.Config.FlowLogger.Enable {
 		var err error
 		fa.logExporter, err = newLogExporter(opt)
@@ -303,6 +328,12 @@ func (fa *flowAggregator) Run(stopCh <-chan struct{}) {
 	if fa.s3Exporter != nil {
 		fa.s3Exporter.Start()
 	}
+	if fa.kafkaExporter != nil {
+		fa.kafkaExporter.Start()
+	}
+	if fa.otlpExporter != nil {
+		fa.otlpExporter.Start()
+	}
 	if fa.logExporter != nil {
 		fa.logExporter.Start()
 	}
@@ -368,6 +399,12 @@ func (fa *flowAggregator) flowExportLoop(stopCh <-chan struct{}) {
 		if fa.s3Exporter != nil {
 			fa.s3Exporter.Stop()
 		}
+		if fa.kafkaExporter != nil {
+			fa.kafkaExporter.Stop()
+		}
+		if fa.otlpExporter != nil {
+			fa.otlpExporter.Stop()
+		}
 		if fa.logExporter != nil {
 			fa.logExporter.Stop()
 		}
@@ -436,6 +473,16 @@ func (fa *flowAggregator) sendFlowKeyRecord(key ipfixintermediate.FlowKey, recor
 			return err
 		}
 	}
+	if fa.kafkaExporter != nil {
+		if err := fa.kafkaExporter.AddRecord(record.Record, !isRecordIPv4); err != nil {
+			return err
+		}
+	}
+	if fa.otlpExporter != nil {
+		if err := fa.otlpExporter.AddRecord(record.Record, !isRecordIPv4); err != nil {
+			return err
+		}
+	}
 	if fa.logExporter != nil {
 		if err := fa.logExporter.AddRecord(record.Record, !isRecordIPv4); err != nil {
 			return err
@@ -672,6 +719,50 @@ func (fa *flowAggregator) updateFlowAggregator(opt *options.Options) {
 			klog.InfoS("Disabled S3Uploader")
 		}
 	}
+	if opt.Config.Kafka.Enable {
+		if fa.kafkaExporter == nil {
+			klog.InfoS("Enabling Kafka")
+			var err error
+			fa.kafkaExporter, err = newKafkaExporter(fa.k8sClient, opt)
+			if err != nil {
+				klog.ErrorS(err, "Error when creating Kafka export process")
+				return
+			}
+			fa.kafkaExporter.Start()
+			klog.InfoS("Enabled Kafka")
+		} else {
+			fa.kafkaExporter.UpdateOptions(opt)
+		}
+	} else {
+		if fa.kafkaExporter != nil {
+			klog.InfoS("Disabling Kafka")
+			fa.kafkaExporter.Stop()
+			fa.kafkaExporter = nil
+			klog.InfoS("Disabled Kafka")
+		}
+	}
+	if opt.Config.OTLP.Enable {
+		if fa.otlpExporter == nil {
+			klog.InfoS("Enabling OTLP")
+			var err error
+			fa.otlpExporter, err = newOTLPExporter(fa.k8sClient, opt)
+			if err != nil {
+				klog.ErrorS(err, "Error when creating OTLP export process")
+				return
+			}
+			fa.otlpExporter.Start()
+			klog.InfoS("Enabled OTLP")
+		} else {
+			fa.otlpExporter.UpdateOptions(opt)
+		}
+	} else {
+		if fa.otlpExporter != nil {
+			klog.InfoS("Disabling OTLP")
+			fa.otlpExporter.Stop()
+			fa.otlpExporter = nil
+			klog.InfoS("Disabled OTLP")
+		}
+	}
 	if opt.Config.FlowLogger.Enable {
 		if fa.logExporter == nil {
 			klog.InfoS("Enabling FlowLogger")
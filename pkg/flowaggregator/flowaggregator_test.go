@@ -54,7 +54,7 @@ const (
 )
 
 func init() {
-	ipfixregistry.LoadRegistry()
+	ipfix.LoadRegistry()
 }
 
 func TestFlowAggregator_sendFlowKeyRecord(t *testing.T) {
@@ -262,16 +262,22 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 	mockIPFIXExporter := exportertesting.NewMockInterface(ctrl)
 	mockClickHouseExporter := exportertesting.NewMockInterface(ctrl)
 	mockS3Exporter := exportertesting.NewMockInterface(ctrl)
+	mockKafkaExporter := exportertesting.NewMockInterface(ctrl)
+	mockOTLPExporter := exportertesting.NewMockInterface(ctrl)
 	mockLogExporter := exportertesting.NewMockInterface(ctrl)
 
 	newIPFIXExporterSaved := newIPFIXExporter
 	newClickHouseExporterSaved := newClickHouseExporter
 	newS3ExporterSaved := newS3Exporter
+	newKafkaExporterSaved := newKafkaExporter
+	newOTLPExporterSaved := newOTLPExporter
 	newLogExporterSaved := newLogExporter
 	defer func() {
 		newIPFIXExporter = newIPFIXExporterSaved
 		newClickHouseExporter = newClickHouseExporterSaved
 		newS3Exporter = newS3ExporterSaved
+		newKafkaExporter = newKafkaExporterSaved
+		newOTLPExporter = newOTLPExporterSaved
 		newLogExporter = newLogExporterSaved
 	}()
 	newIPFIXExporter = func(kubernetes.Interface, *options.Options, ipfix.IPFIXRegistry) exporter.Interface {
@@ -283,6 +289,12 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 	newS3Exporter = func(kubernetes.Interface, *options.Options) (exporter.Interface, error) {
 		return mockS3Exporter, nil
 	}
+	newKafkaExporter = func(kubernetes.Interface, *options.Options) (exporter.Interface, error) {
+		return mockKafkaExporter, nil
+	}
+	newOTLPExporter = func(kubernetes.Interface, *options.Options) (exporter.Interface, error) {
+		return mockOTLPExporter, nil
+	}
 	newLogExporter = func(opt *options.Options) (exporter.Interface, error) {
 		return mockLogExporter, nil
 	}
@@ -398,6 +410,92 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 		mockS3Exporter.EXPECT().UpdateOptions(opt)
 		flowAggregator.updateFlowAggregator(opt)
 	})
+	t.Run("enableKafka", func(t *testing.T) {
+		flowAggregator := &flowAggregator{}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Kafka: flowaggregatorconfig.KafkaConfig{
+					Enable:  true,
+					Brokers: []string{"kafka:9092"},
+					Topic:   "flows",
+				},
+			},
+		}
+		mockKafkaExporter.EXPECT().Start()
+		flowAggregator.updateFlowAggregator(opt)
+	})
+	t.Run("disableKafka", func(t *testing.T) {
+		flowAggregator := &flowAggregator{
+			kafkaExporter: mockKafkaExporter,
+		}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Kafka: flowaggregatorconfig.KafkaConfig{
+					Enable: false,
+				},
+			},
+		}
+		mockKafkaExporter.EXPECT().Stop()
+		flowAggregator.updateFlowAggregator(opt)
+	})
+	t.Run("updateKafka", func(t *testing.T) {
+		flowAggregator := &flowAggregator{
+			kafkaExporter: mockKafkaExporter,
+		}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Kafka: flowaggregatorconfig.KafkaConfig{
+					Enable:  true,
+					Brokers: []string{"kafka:9092"},
+					Topic:   "flows",
+				},
+			},
+		}
+		mockKafkaExporter.EXPECT().UpdateOptions(opt)
+		flowAggregator.updateFlowAggregator(opt)
+	})
+	t.Run("enableOTLP", func(t *testing.T) {
+		flowAggregator := &flowAggregator{}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				OTLP: flowaggregatorconfig.OTLPConfig{
+					Enable:   true,
+					Endpoint: "otel-collector:4317",
+				},
+			},
+		}
+		mockOTLPExporter.EXPECT().Start()
+		flowAggregator.updateFlowAggregator(opt)
+	})
+	t.Run("disableOTLP", func(t *testing.T) {
+		flowAggregator := &flowAggregator{
+			otlpExporter: mockOTLPExporter,
+		}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				OTLP: flowaggregatorconfig.OTLPConfig{
+					Enable: false,
+				},
+			},
+		}
+		mockOTLPExporter.EXPECT().Stop()
+		flowAggregator.updateFlowAggregator(opt)
+	})
+	t.Run("updateOTLP", func(t *testing.T) {
+		flowAggregator := &flowAggregator{
+			otlpExporter: mockOTLPExporter,
+		}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				OTLP: flowaggregatorconfig.OTLPConfig{
+					Enable:   true,
+					Endpoint: "otel-collector:4317",
+				},
+			},
+		}
+		mockOTLPExporter.EXPECT().UpdateOptions(opt)
+		flowAggregator.updateFlowAggregator(opt)
+	})
 	t.Run("enableFlowLogger", func(t *testing.T) {
 		flowAggregator := &flowAggregator{}
 		opt := &options.Options{
@@ -70,6 +70,9 @@ type FlowRecord struct {
 	ReverseThroughputFromDestinationNode uint64
 	EgressName                           string
 	EgressIP                             string
+	AppProtocolName                      string
+	HttpVals                             string
+	TLSServerName                        string
 }
 
 // GetFlowRecord converts ipfixentities.Record to FlowRecord
@@ -228,6 +231,15 @@ func GetFlowRecord(record ipfixentities.Record) *FlowRecord {
 	if egressIP, _, ok := record.GetInfoElementWithValue("egressIP"); ok {
 		r.EgressIP = egressIP.GetStringValue()
 	}
+	if appProtocolName, _, ok := record.GetInfoElementWithValue("appProtocolName"); ok {
+		r.AppProtocolName = appProtocolName.GetStringValue()
+	}
+	if httpVals, _, ok := record.GetInfoElementWithValue("httpVals"); ok {
+		r.HttpVals = httpVals.GetStringValue()
+	}
+	if tlsServerName, _, ok := record.GetInfoElementWithValue("tlsServerName"); ok {
+		r.TLSServerName = tlsServerName.GetStringValue()
+	}
 	return r
 }
 
@@ -72,5 +72,8 @@ func PrepareTestFlowRecord() *flowrecord.FlowRecord {
 		ReverseThroughputFromDestinationNode: 12381346,
 		EgressName:                           "test-egress",
 		EgressIP:                             "172.18.0.1",
+		AppProtocolName:                      "http",
+		HttpVals:                             "mockHttpString",
+		TLSServerName:                        "",
 	}
 }
@@ -59,6 +59,9 @@ var (
 		"flowType",
 		"egressName",
 		"egressIP",
+		"appProtocolName",
+		"httpVals",
+		"tlsServerName",
 	}
 	AntreaInfoElementsIPv4 = append(AntreaInfoElementsCommon, []string{"destinationClusterIPv4"}...)
 	AntreaInfoElementsIPv6 = append(AntreaInfoElementsCommon, []string{"destinationClusterIPv6"}...)
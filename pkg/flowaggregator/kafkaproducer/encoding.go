@@ -0,0 +1,238 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaproducer
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+	flowpb "antrea.io/antrea/pkg/flowaggregator/kafkaproducer/protobuf"
+)
+
+const (
+	recordFormatProtobuf = "Protobuf"
+	recordFormatJSON     = "JSON"
+
+	partitionKeyFlowKey                 = "FlowKey"
+	partitionKeySourcePodNamespace      = "SourcePodNamespace"
+	partitionKeyDestinationPodNamespace = "DestinationPodNamespace"
+	partitionKeySourceNodeName          = "SourceNodeName"
+)
+
+// jsonRecord is the JSON encoding of a flow record. Field names match the
+// names of the IPFIX Information Elements.
+type jsonRecord struct {
+	FlowStartSeconds                     int64  `json:"flowStartSeconds"`
+	FlowEndSeconds                       int64  `json:"flowEndSeconds"`
+	FlowEndSecondsFromSourceNode         int64  `json:"flowEndSecondsFromSourceNode"`
+	FlowEndSecondsFromDestinationNode    int64  `json:"flowEndSecondsFromDestinationNode"`
+	FlowEndReason                        uint8  `json:"flowEndReason"`
+	SourceIP                             string `json:"sourceIP"`
+	DestinationIP                        string `json:"destinationIP"`
+	SourceTransportPort                  uint16 `json:"sourceTransportPort"`
+	DestinationTransportPort             uint16 `json:"destinationTransportPort"`
+	ProtocolIdentifier                   uint8  `json:"protocolIdentifier"`
+	PacketTotalCount                     uint64 `json:"packetTotalCount"`
+	OctetTotalCount                      uint64 `json:"octetTotalCount"`
+	PacketDeltaCount                     uint64 `json:"packetDeltaCount"`
+	OctetDeltaCount                      uint64 `json:"octetDeltaCount"`
+	ReversePacketTotalCount              uint64 `json:"reversePacketTotalCount"`
+	ReverseOctetTotalCount               uint64 `json:"reverseOctetTotalCount"`
+	ReversePacketDeltaCount              uint64 `json:"reversePacketDeltaCount"`
+	ReverseOctetDeltaCount               uint64 `json:"reverseOctetDeltaCount"`
+	SourcePodName                        string `json:"sourcePodName"`
+	SourcePodNamespace                   string `json:"sourcePodNamespace"`
+	SourceNodeName                       string `json:"sourceNodeName"`
+	DestinationPodName                   string `json:"destinationPodName"`
+	DestinationPodNamespace              string `json:"destinationPodNamespace"`
+	DestinationNodeName                  string `json:"destinationNodeName"`
+	DestinationClusterIP                 string `json:"destinationClusterIP"`
+	DestinationServicePort               uint16 `json:"destinationServicePort"`
+	DestinationServicePortName           string `json:"destinationServicePortName"`
+	IngressNetworkPolicyName             string `json:"ingressNetworkPolicyName"`
+	IngressNetworkPolicyNamespace        string `json:"ingressNetworkPolicyNamespace"`
+	IngressNetworkPolicyRuleName         string `json:"ingressNetworkPolicyRuleName"`
+	IngressNetworkPolicyRuleAction       uint8  `json:"ingressNetworkPolicyRuleAction"`
+	IngressNetworkPolicyType             uint8  `json:"ingressNetworkPolicyType"`
+	EgressNetworkPolicyName              string `json:"egressNetworkPolicyName"`
+	EgressNetworkPolicyNamespace         string `json:"egressNetworkPolicyNamespace"`
+	EgressNetworkPolicyRuleName          string `json:"egressNetworkPolicyRuleName"`
+	EgressNetworkPolicyRuleAction        uint8  `json:"egressNetworkPolicyRuleAction"`
+	EgressNetworkPolicyType              uint8  `json:"egressNetworkPolicyType"`
+	TcpState                             string `json:"tcpState"`
+	FlowType                             uint8  `json:"flowType"`
+	SourcePodLabels                      string `json:"sourcePodLabels"`
+	DestinationPodLabels                 string `json:"destinationPodLabels"`
+	Throughput                           uint64 `json:"throughput"`
+	ReverseThroughput                    uint64 `json:"reverseThroughput"`
+	ThroughputFromSourceNode             uint64 `json:"throughputFromSourceNode"`
+	ThroughputFromDestinationNode        uint64 `json:"throughputFromDestinationNode"`
+	ReverseThroughputFromSourceNode      uint64 `json:"reverseThroughputFromSourceNode"`
+	ReverseThroughputFromDestinationNode uint64 `json:"reverseThroughputFromDestinationNode"`
+	ClusterUUID                          string `json:"clusterUUID"`
+	EgressName                           string `json:"egressName"`
+	EgressIP                             string `json:"egressIP"`
+}
+
+// unixSeconds returns the number of seconds since the Unix epoch, or 0 for the
+// zero time, which is used when a timestamp is not available.
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func encodeRecordJSON(r *flowrecord.FlowRecord, clusterUUID string) ([]byte, error) {
+	return json.Marshal(&jsonRecord{
+		FlowStartSeconds:                     unixSeconds(r.FlowStartSeconds),
+		FlowEndSeconds:                       unixSeconds(r.FlowEndSeconds),
+		FlowEndSecondsFromSourceNode:         unixSeconds(r.FlowEndSecondsFromSourceNode),
+		FlowEndSecondsFromDestinationNode:    unixSeconds(r.FlowEndSecondsFromDestinationNode),
+		FlowEndReason:                        r.FlowEndReason,
+		SourceIP:                             r.SourceIP,
+		DestinationIP:                        r.DestinationIP,
+		SourceTransportPort:                  r.SourceTransportPort,
+		DestinationTransportPort:             r.DestinationTransportPort,
+		ProtocolIdentifier:                   r.ProtocolIdentifier,
+		PacketTotalCount:                     r.PacketTotalCount,
+		OctetTotalCount:                      r.OctetTotalCount,
+		PacketDeltaCount:                     r.PacketDeltaCount,
+		OctetDeltaCount:                      r.OctetDeltaCount,
+		ReversePacketTotalCount:              r.ReversePacketTotalCount,
+		ReverseOctetTotalCount:               r.ReverseOctetTotalCount,
+		ReversePacketDeltaCount:              r.ReversePacketDeltaCount,
+		ReverseOctetDeltaCount:               r.ReverseOctetDeltaCount,
+		SourcePodName:                        r.SourcePodName,
+		SourcePodNamespace:                   r.SourcePodNamespace,
+		SourceNodeName:                       r.SourceNodeName,
+		DestinationPodName:                   r.DestinationPodName,
+		DestinationPodNamespace:              r.DestinationPodNamespace,
+		DestinationNodeName:                  r.DestinationNodeName,
+		DestinationClusterIP:                 r.DestinationClusterIP,
+		DestinationServicePort:               r.DestinationServicePort,
+		DestinationServicePortName:           r.DestinationServicePortName,
+		IngressNetworkPolicyName:             r.IngressNetworkPolicyName,
+		IngressNetworkPolicyNamespace:        r.IngressNetworkPolicyNamespace,
+		IngressNetworkPolicyRuleName:         r.IngressNetworkPolicyRuleName,
+		IngressNetworkPolicyRuleAction:       r.IngressNetworkPolicyRuleAction,
+		IngressNetworkPolicyType:             r.IngressNetworkPolicyType,
+		EgressNetworkPolicyName:              r.EgressNetworkPolicyName,
+		EgressNetworkPolicyNamespace:         r.EgressNetworkPolicyNamespace,
+		EgressNetworkPolicyRuleName:          r.EgressNetworkPolicyRuleName,
+		EgressNetworkPolicyRuleAction:        r.EgressNetworkPolicyRuleAction,
+		EgressNetworkPolicyType:              r.EgressNetworkPolicyType,
+		TcpState:                             r.TcpState,
+		FlowType:                             r.FlowType,
+		SourcePodLabels:                      r.SourcePodLabels,
+		DestinationPodLabels:                 r.DestinationPodLabels,
+		Throughput:                           r.Throughput,
+		ReverseThroughput:                    r.ReverseThroughput,
+		ThroughputFromSourceNode:             r.ThroughputFromSourceNode,
+		ThroughputFromDestinationNode:        r.ThroughputFromDestinationNode,
+		ReverseThroughputFromSourceNode:      r.ReverseThroughputFromSourceNode,
+		ReverseThroughputFromDestinationNode: r.ReverseThroughputFromDestinationNode,
+		ClusterUUID:                          clusterUUID,
+		EgressName:                           r.EgressName,
+		EgressIP:                             r.EgressIP,
+	})
+}
+
+// encodeRecordProtobuf encodes a flow record as a FlowRecord message, as
+// defined in protobuf/flowrecord.proto.
+func encodeRecordProtobuf(r *flowrecord.FlowRecord, clusterUUID string) ([]byte, error) {
+	return proto.Marshal(&flowpb.FlowRecord{
+		FlowStartSeconds:                     unixSeconds(r.FlowStartSeconds),
+		FlowEndSeconds:                       unixSeconds(r.FlowEndSeconds),
+		FlowEndSecondsFromSourceNode:         unixSeconds(r.FlowEndSecondsFromSourceNode),
+		FlowEndSecondsFromDestinationNode:    unixSeconds(r.FlowEndSecondsFromDestinationNode),
+		FlowEndReason:                        uint32(r.FlowEndReason),
+		SourceIp:                             r.SourceIP,
+		DestinationIp:                        r.DestinationIP,
+		SourceTransportPort:                  uint32(r.SourceTransportPort),
+		DestinationTransportPort:             uint32(r.DestinationTransportPort),
+		ProtocolIdentifier:                   uint32(r.ProtocolIdentifier),
+		PacketTotalCount:                     r.PacketTotalCount,
+		OctetTotalCount:                      r.OctetTotalCount,
+		PacketDeltaCount:                     r.PacketDeltaCount,
+		OctetDeltaCount:                      r.OctetDeltaCount,
+		ReversePacketTotalCount:              r.ReversePacketTotalCount,
+		ReverseOctetTotalCount:               r.ReverseOctetTotalCount,
+		ReversePacketDeltaCount:              r.ReversePacketDeltaCount,
+		ReverseOctetDeltaCount:               r.ReverseOctetDeltaCount,
+		SourcePodName:                        r.SourcePodName,
+		SourcePodNamespace:                   r.SourcePodNamespace,
+		SourceNodeName:                       r.SourceNodeName,
+		DestinationPodName:                   r.DestinationPodName,
+		DestinationPodNamespace:              r.DestinationPodNamespace,
+		DestinationNodeName:                  r.DestinationNodeName,
+		DestinationClusterIp:                 r.DestinationClusterIP,
+		DestinationServicePort:               uint32(r.DestinationServicePort),
+		DestinationServicePortName:           r.DestinationServicePortName,
+		IngressNetworkPolicyName:             r.IngressNetworkPolicyName,
+		IngressNetworkPolicyNamespace:        r.IngressNetworkPolicyNamespace,
+		IngressNetworkPolicyRuleName:         r.IngressNetworkPolicyRuleName,
+		IngressNetworkPolicyRuleAction:       uint32(r.IngressNetworkPolicyRuleAction),
+		IngressNetworkPolicyType:             uint32(r.IngressNetworkPolicyType),
+		EgressNetworkPolicyName:              r.EgressNetworkPolicyName,
+		EgressNetworkPolicyNamespace:         r.EgressNetworkPolicyNamespace,
+		EgressNetworkPolicyRuleName:          r.EgressNetworkPolicyRuleName,
+		EgressNetworkPolicyRuleAction:        uint32(r.EgressNetworkPolicyRuleAction),
+		EgressNetworkPolicyType:              uint32(r.EgressNetworkPolicyType),
+		TcpState:                             r.TcpState,
+		FlowType:                             uint32(r.FlowType),
+		SourcePodLabels:                      r.SourcePodLabels,
+		DestinationPodLabels:                 r.DestinationPodLabels,
+		Throughput:                           r.Throughput,
+		ReverseThroughput:                    r.ReverseThroughput,
+		ThroughputFromSourceNode:             r.ThroughputFromSourceNode,
+		ThroughputFromDestinationNode:        r.ThroughputFromDestinationNode,
+		ReverseThroughputFromSourceNode:      r.ReverseThroughputFromSourceNode,
+		ReverseThroughputFromDestinationNode: r.ReverseThroughputFromDestinationNode,
+		ClusterUuid:                          clusterUUID,
+		EgressName:                           r.EgressName,
+		EgressIp:                             r.EgressIP,
+	})
+}
+
+func encodeRecord(r *flowrecord.FlowRecord, recordFormat string, clusterUUID string) ([]byte, error) {
+	switch recordFormat {
+	case recordFormatProtobuf:
+		return encodeRecordProtobuf(r, clusterUUID)
+	case recordFormatJSON:
+		return encodeRecordJSON(r, clusterUUID)
+	}
+	return nil, fmt.Errorf("unsupported record format %s", recordFormat)
+}
+
+// getPartitionKey returns the key of the Kafka message of a flow record. Flow
+// records with the same key are produced to the same partition.
+func getPartitionKey(r *flowrecord.FlowRecord, partitionKey string) []byte {
+	switch partitionKey {
+	case partitionKeyFlowKey:
+		return []byte(fmt.Sprintf("%s,%s,%d,%d,%d", r.SourceIP, r.DestinationIP, r.SourceTransportPort, r.DestinationTransportPort, r.ProtocolIdentifier))
+	case partitionKeySourcePodNamespace:
+		return []byte(r.SourcePodNamespace)
+	case partitionKeyDestinationPodNamespace:
+		return []byte(r.DestinationPodNamespace)
+	case partitionKeySourceNodeName:
+		return []byte(r.SourceNodeName)
+	}
+	return nil
+}
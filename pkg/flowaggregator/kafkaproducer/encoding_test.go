@@ -0,0 +1,118 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaproducer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+	flowpb "antrea.io/antrea/pkg/flowaggregator/kafkaproducer/protobuf"
+)
+
+func newTestFlowRecord() *flowrecord.FlowRecord {
+	return &flowrecord.FlowRecord{
+		FlowStartSeconds:         time.Unix(1637706961, 0),
+		FlowEndSeconds:           time.Unix(1637706973, 0),
+		FlowEndReason:            3,
+		SourceIP:                 "10.10.0.79",
+		DestinationIP:            "10.10.0.80",
+		SourceTransportPort:      44752,
+		DestinationTransportPort: 5201,
+		ProtocolIdentifier:       6,
+		PacketTotalCount:         823188,
+		SourcePodName:            "perftest-a",
+		SourcePodNamespace:       "antrea-test",
+		SourceNodeName:           "k8s-node-control-plane",
+		DestinationPodNamespace:  "antrea-test-b",
+	}
+}
+
+func TestEncodeRecordJSON(t *testing.T) {
+	value, err := encodeRecord(newTestFlowRecord(), recordFormatJSON, fakeClusterUUID)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(value, &decoded))
+	assert.Equal(t, float64(1637706961), decoded["flowStartSeconds"])
+	assert.Equal(t, "10.10.0.79", decoded["sourceIP"])
+	assert.Equal(t, float64(44752), decoded["sourceTransportPort"])
+	assert.Equal(t, "antrea-test", decoded["sourcePodNamespace"])
+	assert.Equal(t, "", decoded["destinationPodName"])
+	assert.Equal(t, fakeClusterUUID, decoded["clusterUUID"])
+}
+
+func TestEncodeRecordProtobuf(t *testing.T) {
+	value, err := encodeRecord(newTestFlowRecord(), recordFormatProtobuf, fakeClusterUUID)
+	require.NoError(t, err)
+
+	decoded := &flowpb.FlowRecord{}
+	require.NoError(t, proto.Unmarshal(value, decoded))
+	expected := &flowpb.FlowRecord{
+		FlowStartSeconds:         1637706961,
+		FlowEndSeconds:           1637706973,
+		FlowEndReason:            3,
+		SourceIp:                 "10.10.0.79",
+		DestinationIp:            "10.10.0.80",
+		SourceTransportPort:      44752,
+		DestinationTransportPort: 5201,
+		ProtocolIdentifier:       6,
+		PacketTotalCount:         823188,
+		SourcePodName:            "perftest-a",
+		SourcePodNamespace:       "antrea-test",
+		SourceNodeName:           "k8s-node-control-plane",
+		DestinationPodNamespace:  "antrea-test-b",
+		ClusterUuid:              fakeClusterUUID,
+	}
+	assert.True(t, proto.Equal(expected, decoded), "Unexpected decoded record: %v", decoded)
+
+	// Decode the record with the descriptor only, like a consumer which
+	// doesn't use the generated code.
+	dynamic := dynamicpb.NewMessage((&flowpb.FlowRecord{}).ProtoReflect().Descriptor())
+	require.NoError(t, proto.Unmarshal(value, dynamic))
+	fields := dynamic.Descriptor().Fields()
+	assert.Equal(t, "10.10.0.79", dynamic.Get(fields.ByName("source_ip")).String())
+	assert.Equal(t, uint64(44752), dynamic.Get(fields.ByName("source_transport_port")).Uint())
+	assert.Equal(t, fakeClusterUUID, dynamic.Get(fields.ByName("cluster_uuid")).String())
+}
+
+func TestEncodeRecordUnsupportedFormat(t *testing.T) {
+	_, err := encodeRecord(newTestFlowRecord(), "Avro", fakeClusterUUID)
+	assert.EqualError(t, err, "unsupported record format Avro")
+}
+
+func TestGetPartitionKey(t *testing.T) {
+	r := newTestFlowRecord()
+	testCases := []struct {
+		partitionKey string
+		expectedKey  []byte
+	}{
+		{partitionKeyFlowKey, []byte("10.10.0.79,10.10.0.80,44752,5201,6")},
+		{partitionKeySourcePodNamespace, []byte("antrea-test")},
+		{partitionKeyDestinationPodNamespace, []byte("antrea-test-b")},
+		{partitionKeySourceNodeName, []byte("k8s-node-control-plane")},
+		{"None", nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.partitionKey, func(t *testing.T) {
+			assert.Equal(t, tc.expectedKey, getPartitionKey(r, tc.partitionKey))
+		})
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaproducer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	ipfixentities "github.com/vmware/go-ipfix/pkg/entities"
+	"k8s.io/klog/v2"
+
+	config "antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+	"antrea.io/antrea/pkg/flowaggregator/metrics"
+)
+
+const (
+	bufferFlushTimeout = 1 * time.Minute
+	kafkaClientID      = "antrea-flow-aggregator"
+	// The maximum duration for which the client retries to produce a
+	// record, after which the batch including the record is retried by the
+	// process.
+	recordDeliveryTimeout = 30 * time.Second
+)
+
+// NewKafkaProducer is used for unit testing
+var NewKafkaProducer = newKafkaProducer
+
+type stopPayload struct {
+	flushQueue bool
+}
+
+type KafkaInput struct {
+	Config        config.KafkaConfig
+	FlushInterval time.Duration
+	// Username and Password are the SASL credentials.
+	Username string
+	Password string
+	// CACert is the custom CA certificate, in PEM format.
+	CACert []byte
+	// ClientCert and ClientKey are the client certificate and private key, in
+	// PEM format.
+	ClientCert []byte
+	ClientKey  []byte
+}
+
+// Define a wrapper interface KafkaProducerAPI to assist unit testing.
+type KafkaProducerAPI interface {
+	// Produce produces records to topic, and returns when all of them have
+	// been acknowledged by the brokers or have failed to be produced. It
+	// returns the records which failed to be produced and the first error.
+	Produce(ctx context.Context, topic string, records []*kgo.Record) ([]*kgo.Record, error)
+	Close() error
+}
+
+// kafkaProducer implements KafkaProducerAPI with a franz-go client.
+type kafkaProducer struct {
+	client *kgo.Client
+}
+
+func (p *kafkaProducer) Produce(ctx context.Context, topic string, records []*kgo.Record) ([]*kgo.Record, error) {
+	for _, record := range records {
+		record.Topic = topic
+	}
+	var failed []*kgo.Record
+	var firstErr error
+	for _, result := range p.client.ProduceSync(ctx, records...) {
+		if result.Err != nil {
+			failed = append(failed, result.Record)
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+		}
+	}
+	return failed, firstErr
+}
+
+func (p *kafkaProducer) Close() error {
+	p.client.Close()
+	return nil
+}
+
+type KafkaProduceProcess struct {
+	// input is the input the process was created or last updated with.
+	input              KafkaInput
+	topic              string
+	partitionKey       string
+	recordFormat       string
+	maxRecordsPerBatch int
+	maxBufferedBatches int
+	// flushInterval is the maximum duration for which records are buffered
+	flushInterval time.Duration
+	// flushTicker is a ticker, containing a channel used to trigger sendAll() for every flushInterval period
+	flushTicker *time.Ticker
+	// flushCh is used to trigger sendAll() as soon as a batch is full
+	flushCh chan struct{}
+	// stopCh is the channel to receive stop message
+	stopCh chan stopPayload
+	// exportWg is to ensure that all messages have been flushed from the queue when we stop
+	exportWg             sync.WaitGroup
+	exportProcessRunning bool
+	// mutex protects configuration state from concurrent access
+	mutex sync.Mutex
+	// queueMutex protects currentBatch and batchQueue from concurrent access
+	queueMutex sync.Mutex
+	// currentBatch caches the Kafka records of the flow records
+	currentBatch []*kgo.Record
+	// batchQueue caches currentBatch when it is full
+	batchQueue [][]*kgo.Record
+	// batchesToSend stores all the batches to be sent for the current sendAll() call
+	batchesToSend [][]*kgo.Record
+	// producer makes the real calls to the Kafka brokers
+	producer    KafkaProducerAPI
+	clusterUUID string
+}
+
+func newKafkaProducer(input KafkaInput) (KafkaProducerAPI, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(input.Config.Brokers...),
+		kgo.ClientID(kafkaClientID),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.RecordDeliveryTimeout(recordDeliveryTimeout),
+	}
+	if input.Config.TLS.Enable {
+		// #nosec G402: ignore insecure options
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: input.Config.TLS.InsecureSkipVerify,
+		}
+		if input.Config.TLS.CACert {
+			caCertPool := x509.NewCertPool()
+			if !caCertPool.AppendCertsFromPEM(input.CACert) {
+				return nil, fmt.Errorf("failed to add the custom CA certificate")
+			}
+			tlsConfig.RootCAs = caCertPool
+		}
+		if input.Config.TLS.ClientCert {
+			cert, err := tls.X509KeyPair(input.ClientCert, input.ClientKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+	if input.Config.SASL.Enable {
+		mechanism, err := getSASLMechanism(input.Config.SASL.Mechanism, input.Username, input.Password)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	return &kafkaProducer{client: client}, nil
+}
+
+func getSASLMechanism(mechanism, username, password string) (sasl.Mechanism, error) {
+	switch mechanism {
+	case "PLAIN":
+		return plain.Auth{User: username, Pass: password}.AsMechanism(), nil
+	case "SCRAM-SHA-256":
+		return scram.Auth{User: username, Pass: password}.AsSha256Mechanism(), nil
+	case "SCRAM-SHA-512":
+		return scram.Auth{User: username, Pass: password}.AsSha512Mechanism(), nil
+	}
+	return nil, fmt.Errorf("unsupported SASL mechanism %s", mechanism)
+}
+
+func NewKafkaProduceProcess(input KafkaInput, clusterUUID string) (*KafkaProduceProcess, error) {
+	producer, err := NewKafkaProducer(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &KafkaProduceProcess{
+		currentBatch:  make([]*kgo.Record, 0),
+		batchQueue:    make([][]*kgo.Record, 0),
+		batchesToSend: make([][]*kgo.Record, 0),
+		flushCh:       make(chan struct{}, 1),
+		producer:      producer,
+		clusterUUID:   clusterUUID,
+	}
+	p.setInput(input)
+	return p, nil
+}
+
+// setInput must be called with mutex held, or before the process is started.
+func (p *KafkaProduceProcess) setInput(input KafkaInput) {
+	p.input = input
+	p.topic = input.Config.Topic
+	p.partitionKey = input.Config.PartitionKey
+	p.recordFormat = input.Config.RecordFormat
+	p.maxRecordsPerBatch = int(input.Config.MaxRecordsPerBatch)
+	p.maxBufferedBatches = int(input.Config.MaxBufferedBatches)
+	p.flushInterval = input.FlushInterval
+}
+
+func (p *KafkaProduceProcess) GetKafkaInput() KafkaInput {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.input
+}
+
+func (p *KafkaProduceProcess) GetFlushInterval() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.flushInterval
+}
+
+func (p *KafkaProduceProcess) SetFlushInterval(flushInterval time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.flushInterval = flushInterval
+	p.input.FlushInterval = flushInterval
+	if p.flushTicker != nil {
+		p.flushTicker.Reset(p.flushInterval)
+	}
+}
+
+// UpdateKafkaProducer replaces the Kafka producer and the configuration of the
+// process. Records which are buffered will be produced with the new
+// configuration.
+func (p *KafkaProduceProcess) UpdateKafkaProducer(input KafkaInput) error {
+	producer, err := NewKafkaProducer(input)
+	if err != nil {
+		return err
+	}
+	p.stopExportProcess(false)
+	defer p.startExportProcess()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.producer.Close()
+	p.producer = producer
+	p.setInput(input)
+	return nil
+}
+
+func (p *KafkaProduceProcess) CacheRecord(record ipfixentities.Record) {
+	p.mutex.Lock()
+	recordFormat, partitionKey := p.recordFormat, p.partitionKey
+	maxRecordsPerBatch, maxBufferedBatches := p.maxRecordsPerBatch, p.maxBufferedBatches
+	p.mutex.Unlock()
+	r := flowrecord.GetFlowRecord(record)
+	value, err := encodeRecord(r, recordFormat, p.clusterUUID)
+	if err != nil {
+		klog.ErrorS(err, "Error when encoding flow record")
+		return
+	}
+	kafkaRecord := &kgo.Record{
+		Key:       getPartitionKey(r, partitionKey),
+		Value:     value,
+		Timestamp: time.Now(),
+	}
+	p.queueMutex.Lock()
+	defer p.queueMutex.Unlock()
+	p.currentBatch = append(p.currentBatch, kafkaRecord)
+	metrics.KafkaPendingRecords.Inc()
+	// If the number of pending records in the batch reaches maxRecordsPerBatch,
+	// add the batch to batchQueue and notify the exporting process.
+	if len(p.currentBatch) >= maxRecordsPerBatch {
+		p.appendBatchToQueue(maxBufferedBatches)
+		select {
+		case p.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (p *KafkaProduceProcess) Start() {
+	p.startExportProcess()
+}
+
+func (p *KafkaProduceProcess) Stop() {
+	p.stopExportProcess(true)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.producer.Close()
+}
+
+func (p *KafkaProduceProcess) startExportProcess() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.exportProcessRunning {
+		return
+	}
+	p.exportProcessRunning = true
+	p.flushTicker = time.NewTicker(p.flushInterval)
+	p.stopCh = make(chan stopPayload, 1)
+	p.exportWg.Add(1)
+	go func() {
+		defer p.exportWg.Done()
+		p.flowRecordPeriodicCommit()
+	}()
+}
+
+func (p *KafkaProduceProcess) stopExportProcess(flushQueue bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.exportProcessRunning {
+		return
+	}
+	p.exportProcessRunning = false
+	defer p.flushTicker.Stop()
+	p.stopCh <- stopPayload{
+		flushQueue: flushQueue,
+	}
+	p.exportWg.Wait()
+}
+
+func (p *KafkaProduceProcess) flowRecordPeriodicCommit() {
+	klog.InfoS("Starting Kafka exporting process")
+	ctx := context.Background()
+	for {
+		select {
+		case stop := <-p.stopCh:
+			klog.InfoS("Stopping Kafka exporting process")
+			if !stop.flushQueue {
+				return
+			}
+			ctx, cancelFn := context.WithTimeout(ctx, bufferFlushTimeout)
+			defer cancelFn()
+			err := p.sendAll(ctx)
+			if err != nil {
+				klog.ErrorS(err, "Error when doing sendAll on stop")
+			}
+			return
+		case <-p.flushTicker.C:
+			err := p.sendAll(ctx)
+			if err != nil {
+				klog.ErrorS(err, "Error when doing sendAll on triggered timer")
+			}
+		case <-p.flushCh:
+			err := p.sendAll(ctx)
+			if err != nil {
+				klog.ErrorS(err, "Error when doing sendAll on full batch")
+			}
+		}
+	}
+}
+
+// sendAll sends all batches cached in batchQueue and previous fail-to-send
+// batches stored in batchesToSend. When more than maxBufferedBatches batches
+// are pending, the oldest ones are dropped. Returns error encountered during
+// sending if any.
+func (p *KafkaProduceProcess) sendAll(ctx context.Context) error {
+	func() {
+		p.queueMutex.Lock()
+		defer p.queueMutex.Unlock()
+
+		if len(p.currentBatch) != 0 {
+			p.appendBatchToQueue(p.maxBufferedBatches)
+		}
+		// dump cached batches from batchQueue to batchesToSend
+		p.batchesToSend = append(p.batchesToSend, p.batchQueue...)
+		p.batchQueue = p.batchQueue[:0]
+	}()
+	if numDropped := len(p.batchesToSend) - p.maxBufferedBatches; numDropped > 0 {
+		for _, batch := range p.batchesToSend[:numDropped] {
+			dropRecords(len(batch))
+		}
+		p.batchesToSend = p.batchesToSend[numDropped:]
+	}
+
+	for i, batch := range p.batchesToSend {
+		startTime := time.Now()
+		failed, err := p.producer.Produce(ctx, p.topic, batch)
+		numSent := len(batch) - len(failed)
+		metrics.KafkaRecordsSent.Add(float64(numSent))
+		metrics.KafkaPendingRecords.Add(-float64(numSent))
+		if err != nil {
+			metrics.KafkaSendErrors.Inc()
+			// Only the records which failed to be produced are retried, to
+			// avoid producing the other records of the batch again.
+			p.batchesToSend[i] = failed
+			p.batchesToSend = p.batchesToSend[i:]
+			return fmt.Errorf("error when producing flow records to Kafka: %w", err)
+		}
+		metrics.KafkaBatchSendLatency.Observe(float64(time.Since(startTime).Milliseconds()))
+	}
+	p.batchesToSend = p.batchesToSend[:0]
+	return nil
+}
+
+// appendBatchToQueue appends currentBatch to batchQueue, and resets
+// currentBatch. If batchQueue is full, which happens when records are cached
+// faster than they can be sent, the oldest batch is dropped. Caller of this
+// function should acquire queueMutex.
+func (p *KafkaProduceProcess) appendBatchToQueue(maxBufferedBatches int) {
+	p.batchQueue = append(p.batchQueue, p.currentBatch)
+	if len(p.batchQueue) > maxBufferedBatches {
+		dropRecords(len(p.batchQueue[0]))
+		p.batchQueue = p.batchQueue[1:]
+	}
+	p.currentBatch = make([]*kgo.Record, 0, len(p.currentBatch))
+}
+
+func dropRecords(count int) {
+	klog.InfoS("Dropping flow records as too many records are waiting to be sent to Kafka", "count", count)
+	metrics.KafkaRecordsDropped.Add(float64(count))
+	metrics.KafkaPendingRecords.Add(-float64(count))
+}
@@ -0,0 +1,355 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaproducer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	ipfixentitiestesting "github.com/vmware/go-ipfix/pkg/entities/testing"
+	"go.uber.org/mock/gomock"
+
+	config "antrea.io/antrea/pkg/config/flowaggregator"
+	kafkaproducertesting "antrea.io/antrea/pkg/flowaggregator/kafkaproducer/testing"
+	flowaggregatortesting "antrea.io/antrea/pkg/flowaggregator/testing"
+	"antrea.io/antrea/pkg/ipfix"
+)
+
+var fakeClusterUUID = uuid.New().String()
+
+const testTopic = "flows"
+
+func init() {
+	ipfix.LoadRegistry()
+}
+
+func newTestKafkaProduceProcess(producer KafkaProducerAPI, maxRecordsPerBatch int, maxBufferedBatches int, flushInterval time.Duration) *KafkaProduceProcess {
+	return &KafkaProduceProcess{
+		topic:              testTopic,
+		partitionKey:       partitionKeyFlowKey,
+		recordFormat:       recordFormatJSON,
+		maxRecordsPerBatch: maxRecordsPerBatch,
+		maxBufferedBatches: maxBufferedBatches,
+		flushInterval:      flushInterval,
+		flushCh:            make(chan struct{}, 1),
+		currentBatch:       make([]*kgo.Record, 0),
+		batchQueue:         make([][]*kgo.Record, 0),
+		batchesToSend:      make([][]*kgo.Record, 0),
+		producer:           producer,
+		clusterUUID:        fakeClusterUUID,
+	}
+}
+
+func cacheMockRecords(ctrl *gomock.Controller, p *KafkaProduceProcess, count int) {
+	for i := 0; i < count; i++ {
+		mockRecord := ipfixentitiestesting.NewMockRecord(ctrl)
+		flowaggregatortesting.PrepareMockIpfixRecord(mockRecord, true)
+		p.CacheRecord(mockRecord)
+	}
+}
+
+func TestCacheRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	p := newTestKafkaProduceProcess(nil, 2, 10, time.Minute)
+
+	// First call, cache the record in currentBatch.
+	mockRecord := ipfixentitiestesting.NewMockRecord(ctrl)
+	flowaggregatortesting.PrepareMockIpfixRecord(mockRecord, true)
+	p.CacheRecord(mockRecord)
+	require.Len(t, p.currentBatch, 1)
+	assert.Equal(t, []byte("10.10.0.79,10.10.0.80,44752,5201,6"), p.currentBatch[0].Key)
+	assert.Contains(t, string(p.currentBatch[0].Value), `"clusterUUID":"`+fakeClusterUUID+`"`)
+	assert.Empty(t, p.flushCh)
+
+	// Second call, reach maxRecordsPerBatch, add currentBatch to batchQueue and
+	// notify the exporting process.
+	mockRecord = ipfixentitiestesting.NewMockRecord(ctrl)
+	flowaggregatortesting.PrepareMockIpfixRecord(mockRecord, false)
+	p.CacheRecord(mockRecord)
+	assert.Empty(t, p.currentBatch)
+	require.Len(t, p.batchQueue, 1)
+	assert.Len(t, p.batchQueue[0], 2)
+	assert.Equal(t, []byte("2001:0:3238:dfe1:63::fefb,2001:0:3238:dfe1:63::fefc,44752,5201,6"), p.batchQueue[0][1].Key)
+	assert.Len(t, p.flushCh, 1)
+}
+
+func TestCacheRecordDropOldestBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	p := newTestKafkaProduceProcess(nil, 1, 2, time.Minute)
+
+	cacheMockRecords(ctrl, p, 2)
+	require.Len(t, p.batchQueue, 2)
+	secondBatch := p.batchQueue[1]
+	// The queue can only hold 2 batches, the oldest one is dropped.
+	cacheMockRecords(ctrl, p, 1)
+	require.Len(t, p.batchQueue, 2)
+	assert.Same(t, &secondBatch[0], &p.batchQueue[0][0])
+	assert.Empty(t, p.currentBatch)
+}
+
+func TestSendAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	ctx := context.Background()
+	mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Len(3)).Return(nil, nil)
+	p := newTestKafkaProduceProcess(mockProducer, 10, 10, time.Minute)
+	cacheMockRecords(ctrl, p, 3)
+
+	err := p.sendAll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, p.currentBatch)
+	assert.Empty(t, p.batchQueue)
+	assert.Empty(t, p.batchesToSend)
+}
+
+func TestSendAllPartialSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	ctx := context.Background()
+	gomock.InOrder(
+		mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Len(1)).Return(nil, nil),
+		mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Len(1)).DoAndReturn(
+			func(_ context.Context, _ string, records []*kgo.Record) ([]*kgo.Record, error) {
+				return records, fmt.Errorf("random error")
+			},
+		),
+	)
+	p := newTestKafkaProduceProcess(mockProducer, 1, 10, time.Minute)
+	cacheMockRecords(ctrl, p, 3)
+
+	err := p.sendAll(ctx)
+	assert.EqualError(t, err, "error when producing flow records to Kafka: random error")
+	assert.Empty(t, p.batchQueue)
+	// The batch which failed to be sent and the following one are kept.
+	assert.Len(t, p.batchesToSend, 2)
+
+	// The remaining batches are sent during the next call.
+	mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Len(1)).Return(nil, nil).Times(2)
+	err = p.sendAll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, p.batchesToSend)
+}
+
+func TestSendAllRetryFailedRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	ctx := context.Background()
+	p := newTestKafkaProduceProcess(mockProducer, 10, 10, time.Minute)
+	cacheMockRecords(ctrl, p, 3)
+	batch := p.currentBatch
+
+	// Only the second record of the batch fails to be produced.
+	mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Len(3)).Return([]*kgo.Record{batch[1]}, fmt.Errorf("random error"))
+	err := p.sendAll(ctx)
+	assert.EqualError(t, err, "error when producing flow records to Kafka: random error")
+	require.Len(t, p.batchesToSend, 1)
+
+	// Only the record which failed to be produced is retried.
+	mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Len(1)).DoAndReturn(
+		func(_ context.Context, _ string, records []*kgo.Record) ([]*kgo.Record, error) {
+			assert.Same(t, batch[1], records[0])
+			return nil, nil
+		},
+	)
+	err = p.sendAll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, p.batchesToSend)
+}
+
+func TestSendAllDropOldestBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	ctx := context.Background()
+	mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Len(1)).DoAndReturn(
+		func(_ context.Context, _ string, records []*kgo.Record) ([]*kgo.Record, error) {
+			return records, fmt.Errorf("random error")
+		},
+	)
+	p := newTestKafkaProduceProcess(mockProducer, 1, 2, time.Minute)
+	cacheMockRecords(ctrl, p, 2)
+
+	err := p.sendAll(ctx)
+	assert.Error(t, err)
+	require.Len(t, p.batchesToSend, 2)
+
+	// Two more batches are cached while Kafka is unavailable: together with
+	// the ones which failed to be sent, only the 2 most recent ones are kept.
+	cacheMockRecords(ctrl, p, 2)
+	newBatches := [][]*kgo.Record{p.batchQueue[0], p.batchQueue[1]}
+	var sent [][]*kgo.Record
+	mockProducer.EXPECT().Produce(ctx, testTopic, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, records []*kgo.Record) ([]*kgo.Record, error) {
+			sent = append(sent, records)
+			return nil, nil
+		},
+	).Times(2)
+	err = p.sendAll(ctx)
+	assert.NoError(t, err)
+	require.Len(t, sent, 2)
+	assert.Same(t, &newBatches[0][0], &sent[0][0])
+	assert.Same(t, &newBatches[1][0], &sent[1][0])
+}
+
+func TestFlowRecordPeriodicCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	waitCh := make(chan struct{})
+	mockProducer.EXPECT().Produce(gomock.Any(), testTopic, gomock.Len(1)).DoAndReturn(
+		func(_ context.Context, _ string, _ []*kgo.Record) ([]*kgo.Record, error) {
+			close(waitCh)
+			return nil, nil
+		},
+	)
+	p := newTestKafkaProduceProcess(mockProducer, 10, 10, 100*time.Millisecond)
+	cacheMockRecords(ctrl, p, 1)
+
+	p.startExportProcess()
+	select {
+	case <-waitCh:
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Records were not produced after flush interval")
+	}
+	p.stopExportProcess(false)
+	assert.Empty(t, p.currentBatch)
+	assert.Empty(t, p.batchQueue)
+	assert.Empty(t, p.batchesToSend)
+}
+
+func TestFlushOnFullBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	waitCh := make(chan struct{})
+	mockProducer.EXPECT().Produce(gomock.Any(), testTopic, gomock.Len(2)).DoAndReturn(
+		func(_ context.Context, _ string, _ []*kgo.Record) ([]*kgo.Record, error) {
+			close(waitCh)
+			return nil, nil
+		},
+	)
+	// The flush interval is long enough that only a full batch can trigger
+	// sendAll.
+	p := newTestKafkaProduceProcess(mockProducer, 2, 10, 100*time.Second)
+	p.startExportProcess()
+	defer p.stopExportProcess(false)
+	cacheMockRecords(ctrl, p, 2)
+
+	select {
+	case <-waitCh:
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Records were not produced after batch was full")
+	}
+}
+
+func TestFlushCacheOnStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	mockProducer.EXPECT().Produce(gomock.Any(), testTopic, gomock.Len(1)).Return(nil, nil)
+	mockProducer.EXPECT().Close()
+	p := newTestKafkaProduceProcess(mockProducer, 10, 10, 100*time.Second)
+	cacheMockRecords(ctrl, p, 1)
+
+	p.Start()
+	p.Stop()
+	assert.Empty(t, p.currentBatch)
+	assert.Empty(t, p.batchQueue)
+	assert.Empty(t, p.batchesToSend)
+}
+
+func TestUpdateKafkaProducer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	oldProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	newProducer := kafkaproducertesting.NewMockKafkaProducerAPI(ctrl)
+	defer func() {
+		NewKafkaProducer = newKafkaProducer
+	}()
+	NewKafkaProducer = func(input KafkaInput) (KafkaProducerAPI, error) {
+		return oldProducer, nil
+	}
+	input := KafkaInput{
+		Config: config.KafkaConfig{
+			Brokers:            []string{"kafka:9092"},
+			Topic:              testTopic,
+			PartitionKey:       partitionKeyFlowKey,
+			RecordFormat:       recordFormatProtobuf,
+			MaxRecordsPerBatch: 10,
+			MaxBufferedBatches: 10,
+		},
+		FlushInterval: 100 * time.Second,
+	}
+	p, err := NewKafkaProduceProcess(input, fakeClusterUUID)
+	require.NoError(t, err)
+	p.Start()
+	cacheMockRecords(ctrl, p, 1)
+
+	NewKafkaProducer = func(input KafkaInput) (KafkaProducerAPI, error) {
+		return newProducer, nil
+	}
+	newInput := input
+	newInput.Config.Topic = "new-flows"
+	newInput.Config.RecordFormat = recordFormatJSON
+	oldProducer.EXPECT().Close()
+	require.NoError(t, p.UpdateKafkaProducer(newInput))
+	assert.Equal(t, newInput, p.GetKafkaInput())
+
+	// The records buffered before the update are produced to the new topic.
+	newProducer.EXPECT().Produce(gomock.Any(), "new-flows", gomock.Len(1)).Return(nil, nil)
+	newProducer.EXPECT().Close()
+	p.Stop()
+}
+
+func TestSetFlushInterval(t *testing.T) {
+	p := newTestKafkaProduceProcess(nil, 10, 10, 100*time.Second)
+	p.SetFlushInterval(time.Second)
+	assert.Equal(t, time.Second, p.GetFlushInterval())
+	assert.Equal(t, time.Second, p.GetKafkaInput().FlushInterval)
+}
+
+func TestNewKafkaProducer(t *testing.T) {
+	input := KafkaInput{
+		Config: config.KafkaConfig{
+			Brokers: []string{"kafka:9093"},
+			TLS: config.KafkaTLSConfig{
+				Enable: true,
+				CACert: true,
+			},
+		},
+		CACert: []byte("invalid"),
+	}
+	_, err := newKafkaProducer(input)
+	assert.EqualError(t, err, "failed to add the custom CA certificate")
+
+	input.Config.TLS.CACert = false
+	input.Config.TLS.ClientCert = true
+	_, err = newKafkaProducer(input)
+	assert.ErrorContains(t, err, "failed to load the client certificate")
+
+	input.Config.TLS.ClientCert = false
+	input.Config.SASL = config.KafkaSASLConfig{
+		Enable:    true,
+		Mechanism: "SCRAM-SHA-512",
+	}
+	producer, err := newKafkaProducer(input)
+	require.NoError(t, err)
+	assert.NoError(t, producer.Close())
+
+	input.Config.SASL.Mechanism = "GSSAPI"
+	_, err = newKafkaProducer(input)
+	assert.EqualError(t, err, "unsupported SASL mechanism GSSAPI")
+}
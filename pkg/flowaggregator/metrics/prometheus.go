@@ -0,0 +1,103 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	metricNamespaceAntrea         = "antrea"
+	metricSubsystemFlowAggregator = "flow_aggregator"
+)
+
+var (
+	KafkaRecordsSent = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "kafka_records_sent_count",
+			Help:           "Number of flow records successfully produced to Kafka.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	KafkaRecordsDropped = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "kafka_records_dropped_count",
+			Help:           "Number of flow records dropped because too many batches of records were waiting to be produced to Kafka.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	KafkaSendErrors = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "kafka_send_error_count",
+			Help:           "Number of batches of flow records which could not be produced to Kafka.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	KafkaPendingRecords = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "kafka_pending_record_count",
+			Help:           "Number of flow records buffered in memory and waiting to be produced to Kafka.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	KafkaBatchSendLatency = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemFlowAggregator,
+			Name:           "kafka_batch_send_latency_milliseconds",
+			Help:           "The latency of producing a batch of flow records to Kafka.",
+			Buckets:        metrics.ExponentialBuckets(1, 2, 14),
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+func InitializePrometheusMetrics() {
+	klog.Info("Initializing prometheus metrics")
+
+	InitializeKafkaMetrics()
+}
+
+func InitializeKafkaMetrics() {
+	if err := legacyregistry.Register(KafkaRecordsSent); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_kafka_records_sent_count")
+	}
+	if err := legacyregistry.Register(KafkaRecordsDropped); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_kafka_records_dropped_count")
+	}
+	if err := legacyregistry.Register(KafkaSendErrors); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_kafka_send_error_count")
+	}
+	if err := legacyregistry.Register(KafkaPendingRecords); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_kafka_pending_record_count")
+	}
+	if err := legacyregistry.Register(KafkaBatchSendLatency); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_flow_aggregator_kafka_batch_send_latency_milliseconds")
+	}
+}
@@ -42,6 +42,10 @@ type Options struct {
 	ClickHouseCommitInterval time.Duration
 	// Flow records batch upload interval from flow aggregator to S3 bucket
 	S3UploadInterval time.Duration
+	// Maximum duration for which flow records are buffered before being sent to Kafka
+	KafkaFlushInterval time.Duration
+	// Interval between each export of flow records to the OpenTelemetry collector
+	OTLPExportInterval time.Duration
 }
 
 func LoadConfig(configBytes []byte) (*Options, error) {
@@ -57,8 +61,17 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 	if opt.Config.S3Uploader.Enable && opt.Config.S3Uploader.BucketName == "" {
 		return nil, fmt.Errorf("s3Uploader enabled without specifying bucket name")
 	}
-	if !opt.Config.FlowCollector.Enable && !opt.Config.ClickHouse.Enable && !opt.Config.S3Uploader.Enable && !opt.Config.FlowLogger.Enable {
-		return nil, fmt.Errorf("external flow collector or ClickHouse or S3Uploader should be configured")
+	if opt.Config.Kafka.Enable && len(opt.Config.Kafka.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka enabled without specifying brokers")
+	}
+	if opt.Config.Kafka.Enable && opt.Config.Kafka.Topic == "" {
+		return nil, fmt.Errorf("kafka enabled without specifying topic")
+	}
+	if opt.Config.OTLP.Enable && opt.Config.OTLP.Endpoint == "" {
+		return nil, fmt.Errorf("otlp enabled without specifying endpoint")
+	}
+	if !opt.Config.FlowCollector.Enable && !opt.Config.ClickHouse.Enable && !opt.Config.S3Uploader.Enable && !opt.Config.FlowLogger.Enable && !opt.Config.Kafka.Enable && !opt.Config.OTLP.Enable {
+		return nil, fmt.Errorf("external flow collector or ClickHouse or S3Uploader or Kafka or OTLP should be configured")
 	}
 	// Validate common parameters
 	var err error
@@ -114,6 +127,55 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 				opt.Config.S3Uploader.UploadInterval, flowaggregatorconfig.MinS3CommitInterval)
 		}
 	}
+	// Validate Kafka specific parameters
+	if opt.Config.Kafka.Enable {
+		if opt.Config.Kafka.RecordFormat != "Protobuf" && opt.Config.Kafka.RecordFormat != "JSON" {
+			return nil, fmt.Errorf("record format %s is not supported", opt.Config.Kafka.RecordFormat)
+		}
+		switch opt.Config.Kafka.PartitionKey {
+		case "FlowKey", "SourcePodNamespace", "DestinationPodNamespace", "SourceNodeName", "None":
+		default:
+			return nil, fmt.Errorf("partition key %s is not supported", opt.Config.Kafka.PartitionKey)
+		}
+		if opt.Config.Kafka.MaxRecordsPerBatch < 0 || opt.Config.Kafka.MaxBufferedBatches < 0 {
+			return nil, fmt.Errorf("maxRecordsPerBatch and maxBufferedBatches must be positive")
+		}
+		opt.KafkaFlushInterval, err = time.ParseDuration(opt.Config.Kafka.FlushInterval)
+		if err != nil {
+			return nil, err
+		}
+		if opt.KafkaFlushInterval < flowaggregatorconfig.MinKafkaFlushInterval {
+			return nil, fmt.Errorf("flushInterval %s is too small: shortest supported interval is %v",
+				opt.Config.Kafka.FlushInterval, flowaggregatorconfig.MinKafkaFlushInterval)
+		}
+		if opt.Config.Kafka.SASL.Enable {
+			switch opt.Config.Kafka.SASL.Mechanism {
+			case "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+			default:
+				return nil, fmt.Errorf("SASL mechanism %s is not supported", opt.Config.Kafka.SASL.Mechanism)
+			}
+		}
+	}
+	// Validate OTLP specific parameters
+	if opt.Config.OTLP.Enable {
+		if opt.Config.OTLP.Protocol != "gRPC" && opt.Config.OTLP.Protocol != "HTTP" {
+			return nil, fmt.Errorf("OTLP protocol %s is not supported", opt.Config.OTLP.Protocol)
+		}
+		if _, _, err := net.SplitHostPort(opt.Config.OTLP.Endpoint); err != nil {
+			return nil, fmt.Errorf("invalid OTLP endpoint %s: %v", opt.Config.OTLP.Endpoint, err)
+		}
+		if opt.Config.OTLP.MaxRecordsPerRequest < 0 {
+			return nil, fmt.Errorf("maxRecordsPerRequest must be positive")
+		}
+		opt.OTLPExportInterval, err = time.ParseDuration(opt.Config.OTLP.ExportInterval)
+		if err != nil {
+			return nil, err
+		}
+		if opt.OTLPExportInterval < flowaggregatorconfig.MinOTLPExportInterval {
+			return nil, fmt.Errorf("exportInterval %s is too small: shortest supported interval is %v",
+				opt.Config.OTLP.ExportInterval, flowaggregatorconfig.MinOTLPExportInterval)
+		}
+	}
 	// Validate FlowLogger specific parameters
 	if opt.Config.FlowLogger.Enable {
 		if opt.Config.FlowLogger.RecordFormat != "CSV" {
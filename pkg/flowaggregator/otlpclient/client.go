@@ -0,0 +1,231 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ProtocolGRPC = "gRPC"
+	ProtocolHTTP = "HTTP"
+
+	httpLogsPath    = "/v1/logs"
+	httpMetricsPath = "/v1/metrics"
+	// maxHTTPResponseSize is the maximum size of the response bodies read from
+	// the collector, which are only used in error messages.
+	maxHTTPResponseSize = 4096
+)
+
+// Define a wrapper interface OTLPClientAPI to assist unit testing.
+type OTLPClientAPI interface {
+	ExportLogs(ctx context.Context, request *collogspb.ExportLogsServiceRequest) error
+	ExportMetrics(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) error
+	Close() error
+}
+
+// permanentError is returned when the collector rejected an export request
+// and sending the same request again would fail as well.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+func newTLSConfig(input OTLPInput) (*tls.Config, error) {
+	// #nosec G402: ignore insecure options
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: input.Config.TLS.InsecureSkipVerify,
+	}
+	if input.Config.TLS.CACert {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(input.CACert) {
+			return nil, fmt.Errorf("failed to add the custom CA certificate")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	return tlsConfig, nil
+}
+
+func newOTLPClient(input OTLPInput) (OTLPClientAPI, error) {
+	var tlsConfig *tls.Config
+	if input.Config.TLS.Enable {
+		var err error
+		if tlsConfig, err = newTLSConfig(input); err != nil {
+			return nil, err
+		}
+	}
+	switch input.Config.Protocol {
+	case ProtocolGRPC:
+		return newGRPCClient(input.Config.Endpoint, input.Config.Headers, tlsConfig)
+	case ProtocolHTTP:
+		return newHTTPClient(input.Config.Endpoint, input.Config.Headers, tlsConfig), nil
+	}
+	return nil, fmt.Errorf("unsupported OTLP protocol %s", input.Config.Protocol)
+}
+
+type grpcClient struct {
+	conn          *grpc.ClientConn
+	headers       metadata.MD
+	logsClient    collogspb.LogsServiceClient
+	metricsClient colmetricspb.MetricsServiceClient
+}
+
+func newGRPCClient(endpoint string, headers map[string]string, tlsConfig *tls.Config) (*grpcClient, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	// The connection is established lazily, when the first request is sent.
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("error when creating gRPC connection to %s: %w", endpoint, err)
+	}
+	return &grpcClient{
+		conn:          conn,
+		headers:       metadata.New(headers),
+		logsClient:    collogspb.NewLogsServiceClient(conn),
+		metricsClient: colmetricspb.NewMetricsServiceClient(conn),
+	}, nil
+}
+
+// grpcError converts the error returned by a gRPC call, following the OTLP
+// specification to determine whether the request can be retried.
+func grpcError(err error) error {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss, codes.ResourceExhausted:
+		return err
+	}
+	return &permanentError{err: err}
+}
+
+func (c *grpcClient) ExportLogs(ctx context.Context, request *collogspb.ExportLogsServiceRequest) error {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	response, err := c.logsClient.Export(ctx, request)
+	if err != nil {
+		return grpcError(err)
+	}
+	if rejected := response.GetPartialSuccess().GetRejectedLogRecords(); rejected > 0 {
+		klog.InfoS("Some flow records were rejected by the OTLP collector", "count", rejected, "message", response.GetPartialSuccess().GetErrorMessage())
+	}
+	return nil
+}
+
+func (c *grpcClient) ExportMetrics(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) error {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	response, err := c.metricsClient.Export(ctx, request)
+	if err != nil {
+		return grpcError(err)
+	}
+	if rejected := response.GetPartialSuccess().GetRejectedDataPoints(); rejected > 0 {
+		klog.InfoS("Some data points were rejected by the OTLP collector", "count", rejected, "message", response.GetPartialSuccess().GetErrorMessage())
+	}
+	return nil
+}
+
+func (c *grpcClient) Close() error {
+	return c.conn.Close()
+}
+
+type httpClient struct {
+	client     *http.Client
+	headers    map[string]string
+	logsURL    string
+	metricsURL string
+}
+
+func newHTTPClient(endpoint string, headers map[string]string, tlsConfig *tls.Config) *httpClient {
+	scheme := "http"
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		scheme = "https"
+		transport.TLSClientConfig = tlsConfig
+	}
+	logsURL := url.URL{Scheme: scheme, Host: endpoint, Path: httpLogsPath}
+	metricsURL := url.URL{Scheme: scheme, Host: endpoint, Path: httpMetricsPath}
+	return &httpClient{
+		client:     &http.Client{Transport: transport},
+		headers:    headers,
+		logsURL:    logsURL.String(),
+		metricsURL: metricsURL.String(),
+	}
+}
+
+func (c *httpClient) post(ctx context.Context, url string, request proto.Message) error {
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return &permanentError{err: fmt.Errorf("error when marshalling OTLP request: %w", err)}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseSize))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, url, respBody)
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return err
+	}
+	return &permanentError{err: err}
+}
+
+func (c *httpClient) ExportLogs(ctx context.Context, request *collogspb.ExportLogsServiceRequest) error {
+	return c.post(ctx, c.logsURL, request)
+}
+
+func (c *httpClient) ExportMetrics(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) error {
+	return c.post(ctx, c.metricsURL, request)
+}
+
+func (c *httpClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
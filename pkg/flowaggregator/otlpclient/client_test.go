@@ -0,0 +1,196 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	config "antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+)
+
+type fakeLogsServer struct {
+	collogspb.UnimplementedLogsServiceServer
+	err      error
+	metadata chan metadata.MD
+}
+
+func (s *fakeLogsServer) Export(ctx context.Context, request *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.metadata <- md
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+type fakeMetricsServer struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	numDataPoints chan int
+}
+
+func (s *fakeMetricsServer) Export(ctx context.Context, request *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	s.numDataPoints <- len(request.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].GetGauge().DataPoints)
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func testRecords() []*flowrecord.FlowRecord {
+	return []*flowrecord.FlowRecord{newTestFlowRecord("client", "server", 100, 10)}
+}
+
+func TestGRPCClient(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	logsServer := &fakeLogsServer{metadata: make(chan metadata.MD, 1)}
+	metricsServer := &fakeMetricsServer{numDataPoints: make(chan int, 1)}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, logsServer)
+	colmetricspb.RegisterMetricsServiceServer(server, metricsServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	client, err := newOTLPClient(OTLPInput{
+		Config: config.OTLPConfig{
+			Endpoint: listener.Addr().String(),
+			Protocol: ProtocolGRPC,
+			Headers:  map[string]string{"api-key": "abc"},
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, client.ExportLogs(ctx, newExportLogsRequest(testRecords(), fakeClusterUUID, time.Now())))
+	md := <-logsServer.metadata
+	assert.Equal(t, []string{"abc"}, md.Get("api-key"))
+
+	require.NoError(t, client.ExportMetrics(ctx, newExportMetricsRequest(testRecords(), fakeClusterUUID, time.Now())))
+	assert.Equal(t, 1, <-metricsServer.numDataPoints)
+
+	for _, tc := range []struct {
+		code        codes.Code
+		isPermanent bool
+	}{
+		{code: codes.Unavailable, isPermanent: false},
+		{code: codes.ResourceExhausted, isPermanent: false},
+		{code: codes.InvalidArgument, isPermanent: true},
+		{code: codes.Unauthenticated, isPermanent: true},
+	} {
+		logsServer.err = status.Error(tc.code, "error")
+		err := client.ExportLogs(ctx, newExportLogsRequest(testRecords(), fakeClusterUUID, time.Now()))
+		<-logsServer.metadata
+		require.Error(t, err)
+		var permanentErr *permanentError
+		assert.Equal(t, tc.isPermanent, errors.As(err, &permanentErr), "Unexpected error type for code %s", tc.code)
+		assert.Equal(t, tc.code, status.Code(err), "Unexpected code")
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		statusCode  int
+		expectedErr bool
+		isPermanent bool
+	}{
+		{name: "success", statusCode: http.StatusOK},
+		{name: "throttled", statusCode: http.StatusTooManyRequests, expectedErr: true},
+		{name: "unavailable", statusCode: http.StatusServiceUnavailable, expectedErr: true},
+		{name: "bad request", statusCode: http.StatusBadRequest, expectedErr: true, isPermanent: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var paths []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+				assert.Equal(t, "abc", r.Header.Get("api-key"))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				if r.URL.Path == httpLogsPath {
+					request := &collogspb.ExportLogsServiceRequest{}
+					require.NoError(t, proto.Unmarshal(body, request))
+					assert.Len(t, request.ResourceLogs[0].ScopeLogs[0].LogRecords, 1)
+				}
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			client, err := newOTLPClient(OTLPInput{
+				Config: config.OTLPConfig{
+					Endpoint: strings.TrimPrefix(server.URL, "http://"),
+					Protocol: ProtocolHTTP,
+					Headers:  map[string]string{"api-key": "abc"},
+				},
+			})
+			require.NoError(t, err)
+			defer client.Close()
+			ctx := context.Background()
+
+			err = client.ExportLogs(ctx, newExportLogsRequest(testRecords(), fakeClusterUUID, time.Now()))
+			if tc.expectedErr {
+				require.Error(t, err)
+				var permanentErr *permanentError
+				assert.Equal(t, tc.isPermanent, errors.As(err, &permanentErr))
+			} else {
+				require.NoError(t, err)
+			}
+			client.ExportMetrics(ctx, newExportMetricsRequest(testRecords(), fakeClusterUUID, time.Now()))
+			assert.Equal(t, []string{httpLogsPath, httpMetricsPath}, paths)
+		})
+	}
+}
+
+func TestNewOTLPClient(t *testing.T) {
+	_, err := newOTLPClient(OTLPInput{
+		Config: config.OTLPConfig{
+			Endpoint: "otel-collector:4317",
+			Protocol: "foo",
+		},
+	})
+	assert.EqualError(t, err, "unsupported OTLP protocol foo")
+
+	_, err = newOTLPClient(OTLPInput{
+		Config: config.OTLPConfig{
+			Endpoint: "otel-collector:4317",
+			Protocol: ProtocolGRPC,
+			TLS: config.OTLPTLSConfig{
+				Enable: true,
+				CACert: true,
+			},
+		},
+		CACert: []byte("foo"),
+	})
+	assert.EqualError(t, err, "failed to add the custom CA certificate")
+}
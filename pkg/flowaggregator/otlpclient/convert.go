@@ -0,0 +1,276 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpclient
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+	"antrea.io/antrea/pkg/version"
+)
+
+const (
+	serviceName = "antrea-flow-aggregator"
+	scopeName   = "antrea.io/antrea/pkg/flowaggregator"
+
+	throughputMetricName        = "antrea.flow.throughput"
+	reverseThroughputMetricName = "antrea.flow.reverse_throughput"
+	throughputMetricUnit        = "bit/s"
+)
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func intAttribute(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}},
+	}
+}
+
+// unixSeconds returns the number of seconds since the Unix epoch, or 0 for the
+// zero time, which is used when a timestamp is not available.
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+func newResource(clusterUUID string) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Attributes: []*commonpb.KeyValue{
+			stringAttribute("service.name", serviceName),
+			stringAttribute("service.version", version.GetFullVersion()),
+			stringAttribute("k8s.cluster.uid", clusterUUID),
+		},
+	}
+}
+
+func newScope() *commonpb.InstrumentationScope {
+	return &commonpb.InstrumentationScope{
+		Name:    scopeName,
+		Version: version.GetFullVersion(),
+	}
+}
+
+// flowRecordAttributes returns the fields of a flow record as OTLP attributes.
+// The keys are the names of the corresponding IPFIX Information Elements, like
+// in the records exported by the other exporters.
+func flowRecordAttributes(r *flowrecord.FlowRecord) []*commonpb.KeyValue {
+	return []*commonpb.KeyValue{
+		intAttribute("flowStartSeconds", unixSeconds(r.FlowStartSeconds)),
+		intAttribute("flowEndSeconds", unixSeconds(r.FlowEndSeconds)),
+		intAttribute("flowEndSecondsFromSourceNode", unixSeconds(r.FlowEndSecondsFromSourceNode)),
+		intAttribute("flowEndSecondsFromDestinationNode", unixSeconds(r.FlowEndSecondsFromDestinationNode)),
+		intAttribute("flowEndReason", int64(r.FlowEndReason)),
+		stringAttribute("sourceIP", r.SourceIP),
+		stringAttribute("destinationIP", r.DestinationIP),
+		intAttribute("sourceTransportPort", int64(r.SourceTransportPort)),
+		intAttribute("destinationTransportPort", int64(r.DestinationTransportPort)),
+		intAttribute("protocolIdentifier", int64(r.ProtocolIdentifier)),
+		intAttribute("packetTotalCount", int64(r.PacketTotalCount)),
+		intAttribute("octetTotalCount", int64(r.OctetTotalCount)),
+		intAttribute("packetDeltaCount", int64(r.PacketDeltaCount)),
+		intAttribute("octetDeltaCount", int64(r.OctetDeltaCount)),
+		intAttribute("reversePacketTotalCount", int64(r.ReversePacketTotalCount)),
+		intAttribute("reverseOctetTotalCount", int64(r.ReverseOctetTotalCount)),
+		intAttribute("reversePacketDeltaCount", int64(r.ReversePacketDeltaCount)),
+		intAttribute("reverseOctetDeltaCount", int64(r.ReverseOctetDeltaCount)),
+		stringAttribute("sourcePodName", r.SourcePodName),
+		stringAttribute("sourcePodNamespace", r.SourcePodNamespace),
+		stringAttribute("sourceNodeName", r.SourceNodeName),
+		stringAttribute("destinationPodName", r.DestinationPodName),
+		stringAttribute("destinationPodNamespace", r.DestinationPodNamespace),
+		stringAttribute("destinationNodeName", r.DestinationNodeName),
+		stringAttribute("destinationClusterIP", r.DestinationClusterIP),
+		intAttribute("destinationServicePort", int64(r.DestinationServicePort)),
+		stringAttribute("destinationServicePortName", r.DestinationServicePortName),
+		stringAttribute("ingressNetworkPolicyName", r.IngressNetworkPolicyName),
+		stringAttribute("ingressNetworkPolicyNamespace", r.IngressNetworkPolicyNamespace),
+		stringAttribute("ingressNetworkPolicyRuleName", r.IngressNetworkPolicyRuleName),
+		intAttribute("ingressNetworkPolicyRuleAction", int64(r.IngressNetworkPolicyRuleAction)),
+		intAttribute("ingressNetworkPolicyType", int64(r.IngressNetworkPolicyType)),
+		stringAttribute("egressNetworkPolicyName", r.EgressNetworkPolicyName),
+		stringAttribute("egressNetworkPolicyNamespace", r.EgressNetworkPolicyNamespace),
+		stringAttribute("egressNetworkPolicyRuleName", r.EgressNetworkPolicyRuleName),
+		intAttribute("egressNetworkPolicyRuleAction", int64(r.EgressNetworkPolicyRuleAction)),
+		intAttribute("egressNetworkPolicyType", int64(r.EgressNetworkPolicyType)),
+		stringAttribute("tcpState", r.TcpState),
+		intAttribute("flowType", int64(r.FlowType)),
+		stringAttribute("sourcePodLabels", r.SourcePodLabels),
+		stringAttribute("destinationPodLabels", r.DestinationPodLabels),
+		intAttribute("throughput", int64(r.Throughput)),
+		intAttribute("reverseThroughput", int64(r.ReverseThroughput)),
+		intAttribute("throughputFromSourceNode", int64(r.ThroughputFromSourceNode)),
+		intAttribute("throughputFromDestinationNode", int64(r.ThroughputFromDestinationNode)),
+		intAttribute("reverseThroughputFromSourceNode", int64(r.ReverseThroughputFromSourceNode)),
+		intAttribute("reverseThroughputFromDestinationNode", int64(r.ReverseThroughputFromDestinationNode)),
+		stringAttribute("egressName", r.EgressName),
+		stringAttribute("egressIP", r.EgressIP),
+	}
+}
+
+func flowRecordToLogRecord(r *flowrecord.FlowRecord, observedTime time.Time) *logspb.LogRecord {
+	body := fmt.Sprintf("%s -> %s protocol %d",
+		net.JoinHostPort(r.SourceIP, strconv.Itoa(int(r.SourceTransportPort))),
+		net.JoinHostPort(r.DestinationIP, strconv.Itoa(int(r.DestinationTransportPort))),
+		r.ProtocolIdentifier)
+	return &logspb.LogRecord{
+		TimeUnixNano:         unixNano(r.FlowEndSeconds),
+		ObservedTimeUnixNano: unixNano(observedTime),
+		SeverityNumber:       logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		SeverityText:         "INFO",
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: body}},
+		Attributes:           flowRecordAttributes(r),
+	}
+}
+
+// newExportLogsRequest converts flow records to an OTLP logs export request,
+// with one log record per flow record.
+func newExportLogsRequest(records []*flowrecord.FlowRecord, clusterUUID string, now time.Time) *collogspb.ExportLogsServiceRequest {
+	logRecords := make([]*logspb.LogRecord, 0, len(records))
+	for _, r := range records {
+		logRecords = append(logRecords, flowRecordToLogRecord(r, now))
+	}
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{
+			{
+				Resource: newResource(clusterUUID),
+				ScopeLogs: []*logspb.ScopeLogs{
+					{
+						Scope:      newScope(),
+						LogRecords: logRecords,
+					},
+				},
+			},
+		},
+	}
+}
+
+type podPair struct {
+	sourcePodNamespace      string
+	sourcePodName           string
+	destinationPodNamespace string
+	destinationPodName      string
+}
+
+type podPairThroughput struct {
+	throughput        uint64
+	reverseThroughput uint64
+}
+
+func (p *podPair) attributes() []*commonpb.KeyValue {
+	return []*commonpb.KeyValue{
+		stringAttribute("sourcePodNamespace", p.sourcePodNamespace),
+		stringAttribute("sourcePodName", p.sourcePodName),
+		stringAttribute("destinationPodNamespace", p.destinationPodNamespace),
+		stringAttribute("destinationPodName", p.destinationPodName),
+	}
+}
+
+// newExportMetricsRequest computes the throughput between each pair of Pods
+// from flow records, and converts it to an OTLP metrics export request. The
+// throughput of the flows between the same Pods is summed. Flows with an
+// endpoint outside of the cluster are included, with an empty Pod name and
+// Namespace for that endpoint. It returns nil if there is no flow record.
+func newExportMetricsRequest(records []*flowrecord.FlowRecord, clusterUUID string, now time.Time) *colmetricspb.ExportMetricsServiceRequest {
+	if len(records) == 0 {
+		return nil
+	}
+	pairs := make([]podPair, 0)
+	throughputs := make(map[podPair]*podPairThroughput)
+	for _, r := range records {
+		pair := podPair{
+			sourcePodNamespace:      r.SourcePodNamespace,
+			sourcePodName:           r.SourcePodName,
+			destinationPodNamespace: r.DestinationPodNamespace,
+			destinationPodName:      r.DestinationPodName,
+		}
+		t, ok := throughputs[pair]
+		if !ok {
+			t = &podPairThroughput{}
+			throughputs[pair] = t
+			// Keep the order of the records so that the output is deterministic.
+			pairs = append(pairs, pair)
+		}
+		t.throughput += r.Throughput
+		t.reverseThroughput += r.ReverseThroughput
+	}
+	timeUnixNano := unixNano(now)
+	throughputDataPoints := make([]*metricspb.NumberDataPoint, 0, len(pairs))
+	reverseThroughputDataPoints := make([]*metricspb.NumberDataPoint, 0, len(pairs))
+	for i := range pairs {
+		pair := &pairs[i]
+		t := throughputs[*pair]
+		throughputDataPoints = append(throughputDataPoints, &metricspb.NumberDataPoint{
+			Attributes:   pair.attributes(),
+			TimeUnixNano: timeUnixNano,
+			Value:        &metricspb.NumberDataPoint_AsInt{AsInt: int64(t.throughput)},
+		})
+		reverseThroughputDataPoints = append(reverseThroughputDataPoints, &metricspb.NumberDataPoint{
+			Attributes:   pair.attributes(),
+			TimeUnixNano: timeUnixNano,
+			Value:        &metricspb.NumberDataPoint_AsInt{AsInt: int64(t.reverseThroughput)},
+		})
+	}
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{
+			{
+				Resource: newResource(clusterUUID),
+				ScopeMetrics: []*metricspb.ScopeMetrics{
+					{
+						Scope: newScope(),
+						Metrics: []*metricspb.Metric{
+							{
+								Name:        throughputMetricName,
+								Description: "The average amount of traffic flowing from the source Pod to the destination Pod.",
+								Unit:        throughputMetricUnit,
+								Data:        &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: throughputDataPoints}},
+							},
+							{
+								Name:        reverseThroughputMetricName,
+								Description: "The average amount of reverse traffic flowing from the destination Pod to the source Pod.",
+								Unit:        throughputMetricUnit,
+								Data:        &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: reverseThroughputDataPoints}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
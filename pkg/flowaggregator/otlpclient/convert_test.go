@@ -0,0 +1,133 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+)
+
+func getAttribute(attributes []*commonpb.KeyValue, key string) *commonpb.AnyValue {
+	for _, attr := range attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+func newTestFlowRecord(srcPod, dstPod string, throughput, reverseThroughput uint64) *flowrecord.FlowRecord {
+	return &flowrecord.FlowRecord{
+		FlowEndSeconds:           time.Unix(1637706973, 0),
+		SourceIP:                 "10.10.0.79",
+		DestinationIP:            "10.10.0.80",
+		SourceTransportPort:      44752,
+		DestinationTransportPort: 5201,
+		ProtocolIdentifier:       6,
+		SourcePodName:            srcPod,
+		SourcePodNamespace:       "default",
+		DestinationPodName:       dstPod,
+		DestinationPodNamespace:  "default",
+		Throughput:               throughput,
+		ReverseThroughput:        reverseThroughput,
+	}
+}
+
+func TestNewExportLogsRequest(t *testing.T) {
+	now := time.Now()
+	records := []*flowrecord.FlowRecord{
+		newTestFlowRecord("client", "server", 100, 10),
+		{
+			SourceIP:                 "2001:0:3238:dfe1:63::fefb",
+			DestinationIP:            "2001:0:3238:dfe1:63::fefc",
+			SourceTransportPort:      44752,
+			DestinationTransportPort: 5201,
+			ProtocolIdentifier:       17,
+		},
+	}
+	request := newExportLogsRequest(records, fakeClusterUUID, now)
+
+	require.Len(t, request.ResourceLogs, 1)
+	resourceLogs := request.ResourceLogs[0]
+	assert.Equal(t, serviceName, getAttribute(resourceLogs.Resource.Attributes, "service.name").GetStringValue())
+	assert.Equal(t, fakeClusterUUID, getAttribute(resourceLogs.Resource.Attributes, "k8s.cluster.uid").GetStringValue())
+	require.Len(t, resourceLogs.ScopeLogs, 1)
+	assert.Equal(t, scopeName, resourceLogs.ScopeLogs[0].Scope.Name)
+	logRecords := resourceLogs.ScopeLogs[0].LogRecords
+	require.Len(t, logRecords, 2)
+
+	logRecord := logRecords[0]
+	assert.Equal(t, uint64(time.Unix(1637706973, 0).UnixNano()), logRecord.TimeUnixNano)
+	assert.Equal(t, uint64(now.UnixNano()), logRecord.ObservedTimeUnixNano)
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, logRecord.SeverityNumber)
+	assert.Equal(t, "10.10.0.79:44752 -> 10.10.0.80:5201 protocol 6", logRecord.Body.GetStringValue())
+	assert.Equal(t, int64(1637706973), getAttribute(logRecord.Attributes, "flowEndSeconds").GetIntValue())
+	assert.Equal(t, "client", getAttribute(logRecord.Attributes, "sourcePodName").GetStringValue())
+	assert.Equal(t, "server", getAttribute(logRecord.Attributes, "destinationPodName").GetStringValue())
+	assert.Equal(t, int64(100), getAttribute(logRecord.Attributes, "throughput").GetIntValue())
+
+	logRecord = logRecords[1]
+	// The timestamp is unset when the end time of the flow is unknown.
+	assert.Equal(t, uint64(0), logRecord.TimeUnixNano)
+	assert.Equal(t, int64(0), getAttribute(logRecord.Attributes, "flowStartSeconds").GetIntValue())
+	assert.Equal(t, "[2001:0:3238:dfe1:63::fefb]:44752 -> [2001:0:3238:dfe1:63::fefc]:5201 protocol 17", logRecord.Body.GetStringValue())
+}
+
+func TestNewExportMetricsRequest(t *testing.T) {
+	now := time.Now()
+	assert.Nil(t, newExportMetricsRequest(nil, fakeClusterUUID, now))
+
+	records := []*flowrecord.FlowRecord{
+		newTestFlowRecord("client-1", "server", 100, 10),
+		newTestFlowRecord("client-2", "server", 200, 20),
+		newTestFlowRecord("client-1", "server", 300, 30),
+	}
+	request := newExportMetricsRequest(records, fakeClusterUUID, now)
+
+	require.Len(t, request.ResourceMetrics, 1)
+	resourceMetrics := request.ResourceMetrics[0]
+	assert.Equal(t, fakeClusterUUID, getAttribute(resourceMetrics.Resource.Attributes, "k8s.cluster.uid").GetStringValue())
+	require.Len(t, resourceMetrics.ScopeMetrics, 1)
+	metrics := resourceMetrics.ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 2)
+
+	type dataPoint struct {
+		sourcePodName string
+		value         int64
+	}
+	getDataPoints := func(name string, index int) []dataPoint {
+		assert.Equal(t, name, metrics[index].Name)
+		assert.Equal(t, throughputMetricUnit, metrics[index].Unit)
+		var dataPoints []dataPoint
+		for _, dp := range metrics[index].GetGauge().DataPoints {
+			assert.Equal(t, uint64(now.UnixNano()), dp.TimeUnixNano)
+			assert.Equal(t, "server", getAttribute(dp.Attributes, "destinationPodName").GetStringValue())
+			dataPoints = append(dataPoints, dataPoint{
+				sourcePodName: getAttribute(dp.Attributes, "sourcePodName").GetStringValue(),
+				value:         dp.GetAsInt(),
+			})
+		}
+		return dataPoints
+	}
+	assert.Equal(t, []dataPoint{{"client-1", 400}, {"client-2", 200}}, getDataPoints(throughputMetricName, 0))
+	assert.Equal(t, []dataPoint{{"client-1", 40}, {"client-2", 20}}, getDataPoints(reverseThroughputMetricName, 1))
+}
@@ -0,0 +1,288 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gammazero/deque"
+	ipfixentities "github.com/vmware/go-ipfix/pkg/entities"
+	"k8s.io/klog/v2"
+
+	config "antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+)
+
+const (
+	maxQueueSize      = 1 << 19 // 524288. ~500MB assuming 1KB per record
+	queueFlushTimeout = 10 * time.Second
+	exportTimeout     = 30 * time.Second
+)
+
+// NewOTLPClient is used for unit testing
+var NewOTLPClient = newOTLPClient
+
+type stopPayload struct {
+	flushQueue bool
+}
+
+type OTLPInput struct {
+	Config         config.OTLPConfig
+	ExportInterval time.Duration
+	// CACert is the custom CA certificate, in PEM format.
+	CACert []byte
+}
+
+type OTLPExportProcess struct {
+	input OTLPInput
+	// client sends the export requests to the OpenTelemetry collector.
+	client OTLPClientAPI
+	// deque buffers flows records between exports.
+	deque *deque.Deque
+	// dequeMutex is for concurrency between adding and removing records from deque.
+	dequeMutex sync.Mutex
+	// queueSize is the max size of deque
+	queueSize int
+	// stopCh is the channel to receive stop message
+	stopCh chan stopPayload
+	// exportWg is to ensure that all messages have been flushed from the queue when we stop
+	exportWg sync.WaitGroup
+	// exportTicker is a ticker, containing a channel used to trigger exportAll() for every exportInterval period
+	exportTicker         *time.Ticker
+	exportProcessRunning bool
+	// mutex protects configuration state from concurrent access
+	mutex       sync.Mutex
+	clusterUUID string
+}
+
+func NewOTLPExportProcess(input OTLPInput, clusterUUID string) (*OTLPExportProcess, error) {
+	client, err := NewOTLPClient(input)
+	if err != nil {
+		return nil, err
+	}
+	return &OTLPExportProcess{
+		input:       input,
+		client:      client,
+		deque:       deque.New(),
+		queueSize:   maxQueueSize,
+		clusterUUID: clusterUUID,
+	}, nil
+}
+
+func (p *OTLPExportProcess) CacheRecord(record ipfixentities.Record) {
+	r := flowrecord.GetFlowRecord(record)
+
+	p.dequeMutex.Lock()
+	defer p.dequeMutex.Unlock()
+	for p.deque.Len() >= p.queueSize {
+		p.deque.PopFront()
+	}
+	p.deque.PushBack(r)
+}
+
+func (p *OTLPExportProcess) Start() {
+	p.startExportProcess()
+}
+
+func (p *OTLPExportProcess) Stop() {
+	p.stopExportProcess(true)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.client.Close()
+}
+
+func (p *OTLPExportProcess) startExportProcess() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.exportProcessRunning {
+		return
+	}
+	p.exportProcessRunning = true
+	p.exportTicker = time.NewTicker(p.input.ExportInterval)
+	p.stopCh = make(chan stopPayload, 1)
+	p.exportWg.Add(1)
+	go func() {
+		defer p.exportWg.Done()
+		p.flowRecordPeriodicExport()
+	}()
+}
+
+func (p *OTLPExportProcess) stopExportProcess(flushQueue bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.exportProcessRunning {
+		return
+	}
+	p.exportProcessRunning = false
+	defer p.exportTicker.Stop()
+	p.stopCh <- stopPayload{
+		flushQueue: flushQueue,
+	}
+	p.exportWg.Wait()
+}
+
+func (p *OTLPExportProcess) flowRecordPeriodicExport() {
+	klog.InfoS("Starting OTLP exporting process")
+	ctx := context.Background()
+	logTicker := time.NewTicker(time.Minute)
+	defer logTicker.Stop()
+	exportedRec := 0
+	for {
+		select {
+		case stop := <-p.stopCh:
+			klog.InfoS("Stopping OTLP exporting process")
+			if !stop.flushQueue {
+				return
+			}
+			ctx, cancelFn := context.WithTimeout(ctx, queueFlushTimeout)
+			defer cancelFn()
+			exported, err := p.exportAll(ctx)
+			if err != nil {
+				klog.ErrorS(err, "Error when doing exportAll on stop")
+			} else {
+				exportedRec += exported
+				klog.V(4).InfoS("Total number of records exported to OTLP collector", "count", exportedRec)
+			}
+			return
+		case <-p.exportTicker.C:
+			exported, err := p.exportAll(ctx)
+			exportedRec += exported
+			if err != nil {
+				klog.ErrorS(err, "Error when exporting flow records to OTLP collector")
+			}
+		case <-logTicker.C:
+			klog.V(4).InfoS("Total number of records exported to OTLP collector", "count", exportedRec)
+			exportedRec = 0
+		}
+	}
+}
+
+// exportAll exports all flow records cached in local deque, in requests of at
+// most MaxRecordsPerRequest records. Returns the number of records
+// successfully exported, and error if encountered. When a request fails and
+// can be retried, the records which have not been exported are pushed back to
+// the deque; when it cannot be retried, the records of the request are dropped.
+func (p *OTLPExportProcess) exportAll(ctx context.Context) (int, error) {
+	p.dequeMutex.Lock()
+	currSize := p.deque.Len()
+	recordsToExport := make([]*flowrecord.FlowRecord, 0, currSize)
+	for i := 0; i < currSize; i++ {
+		record, ok := p.deque.PopFront().(*flowrecord.FlowRecord)
+		if !ok {
+			continue
+		}
+		recordsToExport = append(recordsToExport, record)
+	}
+	p.dequeMutex.Unlock()
+
+	maxRecordsPerRequest := int(p.input.Config.MaxRecordsPerRequest)
+	if maxRecordsPerRequest <= 0 {
+		maxRecordsPerRequest = config.DefaultOTLPMaxRecordsPerRequest
+	}
+	exported := 0
+	for exported < len(recordsToExport) {
+		end := exported + maxRecordsPerRequest
+		if end > len(recordsToExport) {
+			end = len(recordsToExport)
+		}
+		batch := recordsToExport[exported:end]
+		if err := p.exportBatch(ctx, batch); err != nil {
+			var permanentErr *permanentError
+			if errors.As(err, &permanentErr) {
+				klog.ErrorS(err, "Dropping flow records rejected by OTLP collector", "count", len(batch))
+				exported = end
+				continue
+			}
+			p.pushRecordsToFrontOfQueue(recordsToExport[exported:])
+			return exported, fmt.Errorf("error when exporting flow records to OTLP collector: %w", err)
+		}
+		exported = end
+	}
+	return exported, nil
+}
+
+func (p *OTLPExportProcess) exportBatch(ctx context.Context, batch []*flowrecord.FlowRecord) error {
+	now := time.Now()
+	exportCtx, cancelFn := context.WithTimeout(ctx, exportTimeout)
+	defer cancelFn()
+	if err := p.client.ExportLogs(exportCtx, newExportLogsRequest(batch, p.clusterUUID, now)); err != nil {
+		return err
+	}
+	if p.input.Config.Metrics.Enable {
+		// Failing to export metrics should not cause the flow records, which
+		// have already been exported, to be exported again.
+		if err := p.client.ExportMetrics(exportCtx, newExportMetricsRequest(batch, p.clusterUUID, now)); err != nil {
+			klog.ErrorS(err, "Error when exporting flow metrics to OTLP collector")
+		}
+	}
+	return nil
+}
+
+// pushRecordsToFrontOfQueue pushes records to the front of deque without exceeding its capacity.
+// Items with lower index (older records) will be dropped first if deque is to be filled.
+func (p *OTLPExportProcess) pushRecordsToFrontOfQueue(records []*flowrecord.FlowRecord) {
+	p.dequeMutex.Lock()
+	defer p.dequeMutex.Unlock()
+
+	for i := len(records) - 1; i >= 0; i-- {
+		if p.deque.Len() >= p.queueSize {
+			break
+		}
+		p.deque.PushFront(records[i])
+	}
+}
+
+// UpdateOTLPClient replaces the OTLP client and the configuration of the
+// process. Records which are cached will be exported with the new
+// configuration.
+func (p *OTLPExportProcess) UpdateOTLPClient(input OTLPInput) error {
+	client, err := NewOTLPClient(input)
+	if err != nil {
+		return err
+	}
+	p.stopExportProcess(false) // do not flush the queue
+	defer p.startExportProcess()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.client.Close()
+	p.client = client
+	p.input = input
+	return nil
+}
+
+func (p *OTLPExportProcess) GetOTLPInput() OTLPInput {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.input
+}
+
+func (p *OTLPExportProcess) GetExportInterval() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.input.ExportInterval
+}
+
+func (p *OTLPExportProcess) SetExportInterval(exportInterval time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.input.ExportInterval = exportInterval
+	if p.exportTicker != nil {
+		p.exportTicker.Reset(p.input.ExportInterval)
+	}
+}
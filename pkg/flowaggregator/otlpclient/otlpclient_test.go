@@ -0,0 +1,243 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gammazero/deque"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ipfixentitiestesting "github.com/vmware/go-ipfix/pkg/entities/testing"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"go.uber.org/mock/gomock"
+
+	config "antrea.io/antrea/pkg/config/flowaggregator"
+	otlpclienttesting "antrea.io/antrea/pkg/flowaggregator/otlpclient/testing"
+	flowaggregatortesting "antrea.io/antrea/pkg/flowaggregator/testing"
+	"antrea.io/antrea/pkg/ipfix"
+)
+
+var fakeClusterUUID = uuid.New().String()
+
+func init() {
+	ipfix.LoadRegistry()
+}
+
+func newTestOTLPExportProcess(client OTLPClientAPI, maxRecordsPerRequest int32, exportInterval time.Duration) *OTLPExportProcess {
+	return &OTLPExportProcess{
+		input: OTLPInput{
+			Config: config.OTLPConfig{
+				Endpoint:             "otel-collector:4317",
+				Protocol:             ProtocolGRPC,
+				MaxRecordsPerRequest: maxRecordsPerRequest,
+			},
+			ExportInterval: exportInterval,
+		},
+		client:      client,
+		deque:       deque.New(),
+		queueSize:   maxQueueSize,
+		clusterUUID: fakeClusterUUID,
+	}
+}
+
+func cacheMockRecords(ctrl *gomock.Controller, p *OTLPExportProcess, count int) {
+	for i := 0; i < count; i++ {
+		mockRecord := ipfixentitiestesting.NewMockRecord(ctrl)
+		flowaggregatortesting.PrepareMockIpfixRecord(mockRecord, true)
+		p.CacheRecord(mockRecord)
+	}
+}
+
+func numLogRecords(request *collogspb.ExportLogsServiceRequest) int {
+	return len(request.ResourceLogs[0].ScopeLogs[0].LogRecords)
+}
+
+func TestCacheRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	p := newTestOTLPExportProcess(nil, 10, time.Minute)
+	p.queueSize = 2
+
+	cacheMockRecords(ctrl, p, 3)
+	// The oldest record is dropped when the queue is full.
+	assert.Equal(t, 2, p.deque.Len())
+}
+
+func TestExportAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	var numRecords []int
+	mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *collogspb.ExportLogsServiceRequest) error {
+			numRecords = append(numRecords, numLogRecords(request))
+			return nil
+		},
+	).Times(3)
+	p := newTestOTLPExportProcess(mockClient, 2, time.Minute)
+	cacheMockRecords(ctrl, p, 5)
+
+	exported, err := p.exportAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, exported)
+	assert.Equal(t, []int{2, 2, 1}, numRecords)
+	assert.Equal(t, 0, p.deque.Len())
+}
+
+func TestExportAllWithMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).Return(nil)
+	mockClient.EXPECT().ExportMetrics(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *colmetricspb.ExportMetricsServiceRequest) error {
+			metrics := request.ResourceMetrics[0].ScopeMetrics[0].Metrics
+			require.Len(t, metrics, 2)
+			assert.Equal(t, throughputMetricName, metrics[0].Name)
+			// All the records are between the same Pods.
+			assert.Len(t, metrics[0].GetGauge().DataPoints, 1)
+			return fmt.Errorf("random error")
+		},
+	)
+	p := newTestOTLPExportProcess(mockClient, 10, time.Minute)
+	p.input.Config.Metrics.Enable = true
+	cacheMockRecords(ctrl, p, 2)
+
+	// An error when exporting metrics does not cause the records to be
+	// exported again.
+	exported, err := p.exportAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, exported)
+	assert.Equal(t, 0, p.deque.Len())
+}
+
+func TestExportAllRetriableError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).Return(nil),
+		mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).Return(fmt.Errorf("random error")),
+	)
+	p := newTestOTLPExportProcess(mockClient, 2, time.Minute)
+	cacheMockRecords(ctrl, p, 5)
+
+	exported, err := p.exportAll(context.Background())
+	assert.EqualError(t, err, "error when exporting flow records to OTLP collector: random error")
+	assert.Equal(t, 2, exported)
+	// The records which have not been exported are kept for the next export.
+	assert.Equal(t, 3, p.deque.Len())
+}
+
+func TestExportAllPermanentError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).Return(&permanentError{err: fmt.Errorf("bad request")}),
+		mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).Return(nil),
+	)
+	p := newTestOTLPExportProcess(mockClient, 2, time.Minute)
+	cacheMockRecords(ctrl, p, 3)
+
+	// The records rejected by the collector are dropped.
+	exported, err := p.exportAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, exported)
+	assert.Equal(t, 0, p.deque.Len())
+}
+
+func TestFlowRecordPeriodicExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	waitCh := make(chan struct{})
+	mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *collogspb.ExportLogsServiceRequest) error {
+			close(waitCh)
+			return nil
+		},
+	)
+	p := newTestOTLPExportProcess(mockClient, 10, 100*time.Millisecond)
+	cacheMockRecords(ctrl, p, 1)
+
+	p.startExportProcess()
+	select {
+	case <-waitCh:
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Records were not exported after export interval")
+	}
+	p.stopExportProcess(false)
+	assert.Equal(t, 0, p.deque.Len())
+}
+
+func TestFlushQueueOnStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	mockClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).Return(nil)
+	mockClient.EXPECT().Close()
+	p := newTestOTLPExportProcess(mockClient, 10, 100*time.Second)
+	cacheMockRecords(ctrl, p, 1)
+
+	p.Start()
+	p.Stop()
+	assert.Equal(t, 0, p.deque.Len())
+}
+
+func TestUpdateOTLPClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	oldClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	newClient := otlpclienttesting.NewMockOTLPClientAPI(ctrl)
+	defer func() {
+		NewOTLPClient = newOTLPClient
+	}()
+	NewOTLPClient = func(input OTLPInput) (OTLPClientAPI, error) {
+		return oldClient, nil
+	}
+	input := OTLPInput{
+		Config: config.OTLPConfig{
+			Endpoint:             "otel-collector:4317",
+			Protocol:             ProtocolGRPC,
+			MaxRecordsPerRequest: 10,
+		},
+		ExportInterval: 100 * time.Second,
+	}
+	p, err := NewOTLPExportProcess(input, fakeClusterUUID)
+	require.NoError(t, err)
+	p.Start()
+	cacheMockRecords(ctrl, p, 1)
+
+	NewOTLPClient = func(input OTLPInput) (OTLPClientAPI, error) {
+		return newClient, nil
+	}
+	newInput := input
+	newInput.Config.Endpoint = "otel-collector:4318"
+	newInput.Config.Protocol = ProtocolHTTP
+	oldClient.EXPECT().Close()
+	require.NoError(t, p.UpdateOTLPClient(newInput))
+	assert.Equal(t, newInput, p.GetOTLPInput())
+
+	// The records cached before the update are exported with the new client.
+	newClient.EXPECT().ExportLogs(gomock.Any(), gomock.Any()).Return(nil)
+	newClient.EXPECT().Close()
+	p.Stop()
+}
+
+func TestSetExportInterval(t *testing.T) {
+	p := newTestOTLPExportProcess(nil, 10, 100*time.Second)
+	p.SetExportInterval(time.Second)
+	assert.Equal(t, time.Second, p.GetExportInterval())
+	assert.Equal(t, time.Second, p.GetOTLPInput().ExportInterval)
+}
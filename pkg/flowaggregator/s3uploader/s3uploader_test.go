@@ -28,11 +28,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	ipfixentitiestesting "github.com/vmware/go-ipfix/pkg/entities/testing"
-	"github.com/vmware/go-ipfix/pkg/registry"
 	"go.uber.org/mock/gomock"
 
 	s3uploadertesting "antrea.io/antrea/pkg/flowaggregator/s3uploader/testing"
 	flowaggregatortesting "antrea.io/antrea/pkg/flowaggregator/testing"
+	"antrea.io/antrea/pkg/ipfix"
 )
 
 var (
@@ -44,7 +44,7 @@ var (
 const seed = 1
 
 func init() {
-	registry.LoadRegistry()
+	ipfix.LoadRegistry()
 }
 
 func TestUpdateS3Uploader(t *testing.T) {
@@ -258,9 +258,33 @@ func (a *SingleIPAllocator) Has(ip net.IP) bool {
 	return offset >= 0 && offset < a.max
 }
 
+// Clone returns a copy of the allocator, including its allocated IPs. The copy can be updated
+// without affecting the original one.
+func (a *SingleIPAllocator) Clone() *SingleIPAllocator {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return &SingleIPAllocator{
+		ipRangeStr:  a.ipRangeStr,
+		base:        a.base,
+		max:         a.max,
+		allocated:   new(big.Int).Set(a.allocated),
+		count:       a.count,
+		reservedIPs: a.reservedIPs,
+	}
+}
+
 // MultiIPAllocator is responsible for allocating IPs from multiple contiguous IP ranges.
 type MultiIPAllocator []*SingleIPAllocator
 
+// Clone returns a copy of the allocators, including their allocated IPs.
+func (ma MultiIPAllocator) Clone() MultiIPAllocator {
+	clone := make(MultiIPAllocator, 0, len(ma))
+	for _, a := range ma {
+		clone = append(clone, a.Clone())
+	}
+	return clone
+}
+
 func (ma MultiIPAllocator) Names() []string {
 	names := make([]string, 0, len(ma))
 	for _, a := range ma {
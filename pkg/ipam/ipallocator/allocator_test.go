@@ -188,6 +188,24 @@ func TestAllocateRelease(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	allocator := MultiIPAllocator{newIPRangeAllocator("1.1.1.10", "1.1.1.11"), newCIDRAllocator("10.10.10.128/30", nil)}
+	require.NoError(t, allocator.AllocateIP(net.ParseIP("1.1.1.10")))
+
+	clone := allocator.Clone()
+	assert.Equal(t, 1, clone.Used())
+	got, err := clone.AllocateNext()
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("1.1.1.11"), got)
+	require.NoError(t, clone.Release(net.ParseIP("1.1.1.10")))
+
+	// The original allocator is not affected by the updates of the clone.
+	assert.Equal(t, 1, allocator.Used())
+	got, err = allocator.AllocateNext()
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("1.1.1.11"), got)
+}
+
 func TestHas(t *testing.T) {
 	tests := []struct {
 		name        string
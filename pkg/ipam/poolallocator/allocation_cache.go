@@ -0,0 +1,151 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poolallocator
+
+import (
+	"net"
+	"reflect"
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha2"
+	"antrea.io/antrea/pkg/ipam/ipallocator"
+)
+
+// IPAllocationCache caches the IPs allocated from each IPPool, so that the IPPoolAllocators
+// don't need to rebuild them from all IPAllocations of the IPPool for every allocation. The
+// cached IPs are updated with the IPAllocation events, and with the IPAllocations created by
+// the IPPoolAllocators before the events are received.
+// The cache may be outdated when IPs are allocated concurrently by other clients, which is
+// fine as creating the IPAllocation of an IP allocated by another client fails.
+type IPAllocationCache struct {
+	// indexer for reading the allocations of the pools, it must have the IPPoolIndex and the
+	// ContainerIndex
+	indexer cache.Indexer
+
+	mutex sync.Mutex
+	// pools stores the cached IP allocators of the IPPools, keyed by the IPPool name.
+	pools map[string]*cachedIPAllocators
+}
+
+type cachedIPAllocators struct {
+	// ipRanges are the IP ranges the allocators are built from. The allocators are rebuilt
+	// when the IP ranges of the IPPool are updated.
+	ipRanges   []v1alpha2.SubnetIPRange
+	allocators ipallocator.MultiIPAllocator
+}
+
+// NewIPAllocationCache creates an IPAllocationCache which is updated with the events of the
+// provided IPAllocation informer. The informer must have been added the IPPoolIndex and the
+// ContainerIndex.
+func NewIPAllocationCache(ipAllocationInformer crdinformers.IPAllocationInformer) *IPAllocationCache {
+	c := &IPAllocationCache{
+		indexer: ipAllocationInformer.Informer().GetIndexer(),
+		pools:   map[string]*cachedIPAllocators{},
+	}
+	ipAllocationInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addIPAllocation,
+		DeleteFunc: c.deleteIPAllocation,
+	})
+	return c
+}
+
+func (c *IPAllocationCache) addIPAllocation(obj interface{}) {
+	allocation := obj.(*v1alpha2.IPAllocation)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pool, exists := c.pools[allocation.Spec.IPPool]
+	if !exists {
+		return
+	}
+	// The IP may have been marked as allocated when the cache was built or the IPAllocation
+	// was created, the error is expected in this case.
+	pool.allocators.AllocateIP(net.ParseIP(allocation.Spec.IPAddress))
+}
+
+func (c *IPAllocationCache) deleteIPAllocation(obj interface{}) {
+	allocation, ok := obj.(*v1alpha2.IPAllocation)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		allocation, ok = tombstone.Obj.(*v1alpha2.IPAllocation)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-IPAllocation object: %v", tombstone.Obj)
+			return
+		}
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pool, exists := c.pools[allocation.Spec.IPPool]
+	if !exists {
+		return
+	}
+	pool.allocators.Release(net.ParseIP(allocation.Spec.IPAddress))
+}
+
+// getIPAllocations returns the IPAllocations of the provided pool.
+func (c *IPAllocationCache) getIPAllocations(poolName string) []*v1alpha2.IPAllocation {
+	objs, _ := c.indexer.ByIndex(IPPoolIndex, poolName)
+	allocations := make([]*v1alpha2.IPAllocation, 0, len(objs))
+	for _, obj := range objs {
+		allocations = append(allocations, obj.(*v1alpha2.IPAllocation))
+	}
+	return allocations
+}
+
+// getIPAllocators returns a copy of the cached allocators of the pool with the IPs recorded by
+// IPAllocations marked as allocated, building them if they are not cached or the IP ranges of
+// the pool have been updated. The returned allocators can be updated by the caller.
+func (c *IPAllocationCache) getIPAllocators(ipPool *v1alpha2.IPPool) (ipallocator.MultiIPAllocator, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pool, exists := c.pools[ipPool.Name]
+	if !exists || !reflect.DeepEqual(pool.ipRanges, ipPool.Spec.IPRanges) {
+		allocators, err := newIPAllocators(ipPool)
+		if err != nil {
+			return nil, err
+		}
+		for _, allocation := range c.getIPAllocations(ipPool.Name) {
+			if err := allocators.AllocateIP(net.ParseIP(allocation.Spec.IPAddress)); err != nil {
+				// TODO - fix state if possible
+				return nil, newInconsistentStateError(ipPool.Name, allocation.Spec.IPAddress)
+			}
+		}
+		pool = &cachedIPAllocators{
+			ipRanges:   ipPool.Spec.IPRanges,
+			allocators: allocators,
+		}
+		c.pools[ipPool.Name] = pool
+	}
+	return pool.allocators.Clone(), nil
+}
+
+// markAllocated marks the IP of an IPAllocation created by an IPPoolAllocator as allocated,
+// without waiting for its event.
+func (c *IPAllocationCache) markAllocated(poolName string, ip net.IP) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pool, exists := c.pools[poolName]
+	if !exists {
+		return
+	}
+	pool.allocators.AllocateIP(ip)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"strings"
 
 	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	crdclientset "antrea.io/antrea/pkg/client/clientset/versioned"
@@ -26,13 +27,54 @@ import (
 	"antrea.io/antrea/pkg/ipam/ipallocator"
 	iputil "antrea.io/antrea/pkg/util/ip"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 )
 
+const (
+	// IPPoolIndex is the name of the IPAllocation informer index by IPPool name.
+	IPPoolIndex = "ipPool"
+	// ContainerIndex is the name of the IPAllocation informer index by container ID and interface name.
+	ContainerIndex = "container"
+)
+
+// IPPoolIndexFunc indexes IPAllocations by the name of the IPPool the IP address is allocated from.
+func IPPoolIndexFunc(obj interface{}) ([]string, error) {
+	allocation, ok := obj.(*v1alpha2.IPAllocation)
+	if !ok {
+		return nil, fmt.Errorf("obj is not IPAllocation: %+v", obj)
+	}
+	return []string{allocation.Spec.IPPool}, nil
+}
+
+// ContainerIndexFunc indexes IPAllocations by the container ID and interface name of the Pod the IP address is
+// allocated to.
+func ContainerIndexFunc(obj interface{}) ([]string, error) {
+	allocation, ok := obj.(*v1alpha2.IPAllocation)
+	if !ok {
+		return nil, fmt.Errorf("obj is not IPAllocation: %+v", obj)
+	}
+	podOwner := allocation.Spec.Owner.Pod
+	if podOwner == nil {
+		return nil, nil
+	}
+	return []string{ContainerIndexKey(podOwner.ContainerID, podOwner.IFName)}, nil
+}
+
+// ContainerIndexKey returns the ContainerIndex key of a container interface.
+func ContainerIndexKey(containerID, ifName string) string {
+	return containerID + "/" + ifName
+}
+
 // IPPoolAllocator is responsible for allocating IPs from IP set defined in IPPool CRD.
-// The will update CRD usage accordingly.
+// Each allocated IP is recorded by an IPAllocation CR named after the IPPool and the IP, so
+// that different IPs can be allocated and released concurrently without conflicts: creating
+// the IPAllocation of an IP fails if the IP has been allocated by another client.
 // Pool Allocator assumes that pool with allocated IPs can not be deleted. Pool ranges can
 // only be extended.
 type IPPoolAllocator struct {
@@ -47,10 +89,18 @@ type IPPoolAllocator struct {
 
 	// pool lister for reading the pool
 	ipPoolLister informers.IPPoolLister
+
+	// indexer for reading the allocations of the pool, it must have the IPPoolIndex and the
+	// ContainerIndex
+	ipAllocationIndexer cache.Indexer
+
+	// cache of the IPs allocated from the pool, shared by the allocators of all pools
+	ipAllocationCache *IPAllocationCache
 }
 
-// NewIPPoolAllocator creates an IPPoolAllocator based on the provided IP pool.
-func NewIPPoolAllocator(poolName string, client crdclientset.Interface, poolLister informers.IPPoolLister) (*IPPoolAllocator, error) {
+// NewIPPoolAllocator creates an IPPoolAllocator based on the provided IP pool, reading the
+// allocations of the pool from the provided IPAllocationCache.
+func NewIPPoolAllocator(poolName string, client crdclientset.Interface, poolLister informers.IPPoolLister, ipAllocationCache *IPAllocationCache) (*IPPoolAllocator, error) {
 	// Validate the pool exists.
 	pool, err := poolLister.Get(poolName)
 	if err != nil {
@@ -59,23 +109,86 @@ func NewIPPoolAllocator(poolName string, client crdclientset.Interface, poolList
 	}
 
 	allocator := &IPPoolAllocator{
-		IPVersion:    pool.Spec.IPVersion,
-		ipPoolName:   poolName,
-		crdClient:    client,
-		ipPoolLister: poolLister,
+		IPVersion:           pool.Spec.IPVersion,
+		ipPoolName:          poolName,
+		crdClient:           client,
+		ipPoolLister:        poolLister,
+		ipAllocationIndexer: ipAllocationCache.indexer,
+		ipAllocationCache:   ipAllocationCache,
 	}
 
 	return allocator, nil
 }
 
+// IPAllocationName returns the name of the IPAllocation of the provided IP in the provided pool.
+// IPv6 addresses are expanded and their colons are replaced with dashes to be valid in an object
+// name.
+func IPAllocationName(poolName string, ip net.IP) string {
+	if ip.To4() != nil {
+		return fmt.Sprintf("%s-%s", poolName, ip.String())
+	}
+	ip = ip.To16()
+	groups := make([]string, 0, net.IPv6len/2)
+	for i := 0; i < net.IPv6len; i += 2 {
+		groups = append(groups, fmt.Sprintf("%02x%02x", ip[i], ip[i+1]))
+	}
+	return fmt.Sprintf("%s-%s", poolName, strings.Join(groups, "-"))
+}
+
 func (a *IPPoolAllocator) getPool() (*v1alpha2.IPPool, error) {
 	pool, err := a.ipPoolLister.Get(a.ipPoolName)
 	return pool, err
 }
 
-// initAllocatorList reads IP Pool status and initializes a list of allocators based on
-// IP Pool spec and state of allocation recorded in the status
-func (a *IPPoolAllocator) initIPAllocators(ipPool *v1alpha2.IPPool) (ipallocator.MultiIPAllocator, error) {
+// getIPAllocations returns the IPAllocations of the pool.
+func (a *IPPoolAllocator) getIPAllocations() []*v1alpha2.IPAllocation {
+	return a.ipAllocationCache.getIPAllocations(a.ipPoolName)
+}
+
+// getIPAllocation returns the IPAllocation of the provided IP, or nil if the IP is not allocated.
+// The IPAllocation is read from the API if it's not in the cache yet, e.g. right after it has
+// been created.
+func (a *IPPoolAllocator) getIPAllocation(ip net.IP) (*v1alpha2.IPAllocation, error) {
+	name := IPAllocationName(a.ipPoolName, ip)
+	obj, exists, _ := a.ipAllocationIndexer.GetByKey(name)
+	if exists {
+		return obj.(*v1alpha2.IPAllocation), nil
+	}
+	allocation, err := a.crdClient.CrdV1alpha2().IPAllocations().Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return allocation, nil
+}
+
+// getIPAddressStates returns the states of all IPs allocated from the pool, including the ones
+// recorded in the pool status by earlier versions and not migrated to IPAllocations yet.
+func (a *IPPoolAllocator) getIPAddressStates(ipPool *v1alpha2.IPPool) []v1alpha2.IPAddressState {
+	allocations := a.getIPAllocations()
+	states := make([]v1alpha2.IPAddressState, 0, len(allocations)+len(ipPool.Status.IPAddresses))
+	for _, allocation := range allocations {
+		states = append(states, allocation.Spec.IPAddressState)
+	}
+	if len(ipPool.Status.IPAddresses) == 0 {
+		return states
+	}
+	allocatedIPs := make(map[string]struct{}, len(allocations))
+	for _, allocation := range allocations {
+		allocatedIPs[allocation.Spec.IPAddress] = struct{}{}
+	}
+	for _, state := range ipPool.Status.IPAddresses {
+		if _, exists := allocatedIPs[state.IPAddress]; !exists {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// newIPAllocators initializes a list of allocators based on IP Pool spec, without any IP allocated.
+func newIPAllocators(ipPool *v1alpha2.IPPool) (ipallocator.MultiIPAllocator, error) {
 
 	var allocators ipallocator.MultiIPAllocator
 
@@ -110,15 +223,39 @@ func (a *IPPoolAllocator) initIPAllocators(ipPool *v1alpha2.IPPool) (ipallocator
 		}
 	}
 
-	// Mark allocated IPs from pool status as unavailable
-	for _, ip := range ipPool.Status.IPAddresses {
-		err := allocators.AllocateIP(net.ParseIP(ip.IPAddress))
-		if err != nil {
+	return allocators, nil
+}
+
+func newInconsistentStateError(poolName, ip string) error {
+	return fmt.Errorf("inconsistent state for IP Pool %s with IP %s", poolName, ip)
+}
+
+// initIPAllocators initializes a list of allocators based on IP Pool spec, with the IPs recorded
+// by IPAllocations, and the ones recorded in the pool status by earlier versions, marked as
+// allocated.
+func (a *IPPoolAllocator) initIPAllocators(ipPool *v1alpha2.IPPool) (ipallocator.MultiIPAllocator, error) {
+	allocators, err := a.ipAllocationCache.getIPAllocators(ipPool)
+	if err != nil {
+		return allocators, err
+	}
+	if len(ipPool.Status.IPAddresses) == 0 {
+		return allocators, nil
+	}
+
+	// Mark the IPs which haven't been migrated to IPAllocations as unavailable.
+	allocatedIPs := sets.New[string]()
+	for _, allocation := range a.getIPAllocations() {
+		allocatedIPs.Insert(allocation.Spec.IPAddress)
+	}
+	for _, state := range ipPool.Status.IPAddresses {
+		if allocatedIPs.Has(state.IPAddress) {
+			continue
+		}
+		if err := allocators.AllocateIP(net.ParseIP(state.IPAddress)); err != nil {
 			// TODO - fix state if possible
-			return allocators, fmt.Errorf("inconsistent state for IP Pool %s with IP %s", ipPool.Name, ip.IPAddress)
+			return allocators, newInconsistentStateError(ipPool.Name, state.IPAddress)
 		}
 	}
-
 	return allocators, nil
 }
 
@@ -136,120 +273,164 @@ func (a *IPPoolAllocator) getPoolAndInitIPAllocators() (*v1alpha2.IPPool, ipallo
 	return ipPool, allocators, nil
 }
 
-func (a *IPPoolAllocator) appendPoolUsage(ipPool *v1alpha2.IPPool, ip net.IP, state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) error {
-	newPool := ipPool.DeepCopy()
-	usageEntry := v1alpha2.IPAddressState{
-		IPAddress: ip.String(),
-		Phase:     state,
-		Owner:     owner,
+func (a *IPPoolAllocator) newIPAllocation(ipPool *v1alpha2.IPPool, ip net.IP, state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) *v1alpha2.IPAllocation {
+	return &v1alpha2.IPAllocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: IPAllocationName(ipPool.Name, ip),
+			// The IPAllocations are garbage collected if the IPPool is deleted forcibly.
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(ipPool, v1alpha2.SchemeGroupVersion.WithKind("IPPool")),
+			},
+		},
+		Spec: v1alpha2.IPAllocationSpec{
+			IPPool: ipPool.Name,
+			IPAddressState: v1alpha2.IPAddressState{
+				IPAddress: ip.String(),
+				Phase:     state,
+				Owner:     owner,
+			},
+		},
 	}
+}
 
-	newPool.Status.IPAddresses = append(newPool.Status.IPAddresses, usageEntry)
-	a.updateUsage(newPool)
-	_, err := a.crdClient.CrdV1alpha2().IPPools().UpdateStatus(context.TODO(), newPool, metav1.UpdateOptions{})
+// createIPAllocation records the allocation of the IP. It returns an AlreadyExists error if the
+// IP has been allocated by another client.
+func (a *IPPoolAllocator) createIPAllocation(ipPool *v1alpha2.IPPool, ip net.IP, state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) error {
+	allocation := a.newIPAllocation(ipPool, ip, state, owner)
+	_, err := a.crdClient.CrdV1alpha2().IPAllocations().Create(context.TODO(), allocation, metav1.CreateOptions{})
 	if err != nil {
-		klog.Warningf("IP Pool %s update with status %+v failed: %+v", newPool.Name, newPool.Status, err)
+		if errors.IsAlreadyExists(err) {
+			// The IP has been allocated by another client, avoid trying it again before the
+			// IPAllocation is received.
+			a.ipAllocationCache.markAllocated(ipPool.Name, ip)
+		} else {
+			klog.ErrorS(err, "Failed to create IPAllocation", "IPAllocation", allocation.Name)
+		}
 		return err
 	}
-	klog.InfoS("IP Pool update succeeded", "pool", newPool.Name, "allocation", newPool.Status)
+	a.ipAllocationCache.markAllocated(ipPool.Name, ip)
+	klog.InfoS("IP allocation succeeded", "pool", ipPool.Name, "ip", ip, "phase", state)
 	return nil
-
 }
 
-// updateIPAddressState updates the status of the specified IP in the provided IPPool. It requires the IP is already in the IPAddresses list of the IPPool's status.
-func (a *IPPoolAllocator) updateIPAddressState(ipPool *v1alpha2.IPPool, ip net.IP, state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) error {
-	newPool := ipPool.DeepCopy()
-	ipString := ip.String()
-	found := false
+// updateIPAddressState updates the state of an allocated IP. The update fails with a conflict if
+// the IPAllocation has been updated concurrently.
+func (a *IPPoolAllocator) updateIPAddressState(allocation *v1alpha2.IPAllocation, state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) error {
+	newAllocation := allocation.DeepCopy()
+	newAllocation.Spec.Phase = state
+	newAllocation.Spec.Owner = owner
+	_, err := a.crdClient.CrdV1alpha2().IPAllocations().Update(context.TODO(), newAllocation, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Warningf("IPAllocation %s update with spec %+v failed: %+v", newAllocation.Name, newAllocation.Spec, err)
+		return err
+	}
+	klog.InfoS("IPAllocation update succeeded", "pool", a.ipPoolName, "allocation", newAllocation.Spec)
+	return nil
+}
 
-	for i, ipAddress := range newPool.Status.IPAddresses {
-		if ipAddress.IPAddress == ipString {
-			newPool.Status.IPAddresses[i].Phase = state
-			newPool.Status.IPAddresses[i].Owner = owner
-			found = true
-			break
+// removeIPAllocation deletes the IPAllocation of a released IP, and keeps preallocation information.
+func (a *IPPoolAllocator) removeIPAllocation(allocation *v1alpha2.IPAllocation) error {
+	if allocation.Spec.Owner.StatefulSet != nil {
+		newAllocation := allocation.DeepCopy()
+		newAllocation.Spec.Owner.Pod = nil
+		newAllocation.Spec.Phase = v1alpha2.IPAddressPhaseReserved
+		_, err := a.crdClient.CrdV1alpha2().IPAllocations().Update(context.TODO(), newAllocation, metav1.UpdateOptions{})
+		if err != nil {
+			klog.Warningf("IPAllocation %s update failed: %+v", newAllocation.Name, err)
+			return err
 		}
-	}
-	if !found {
-		return fmt.Errorf("ip %s usage not found in pool %s", ipString, newPool.Name)
+		klog.InfoS("IP release succeeded, IP is kept reserved for StatefulSet", "pool", a.ipPoolName, "ip", allocation.Spec.IPAddress)
+		return nil
 	}
 
-	_, err := a.crdClient.CrdV1alpha2().IPPools().UpdateStatus(context.TODO(), newPool, metav1.UpdateOptions{})
+	// The precondition makes sure a concurrent reallocation of the IP is not deleted.
+	err := a.crdClient.CrdV1alpha2().IPAllocations().Delete(context.TODO(), allocation.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &allocation.UID, ResourceVersion: &allocation.ResourceVersion},
+	})
 	if err != nil {
-		klog.Warningf("IP Pool %s update with status %+v failed: %+v", newPool.Name, newPool.Status, err)
+		klog.Warningf("IPAllocation %s deletion failed: %+v", allocation.Name, err)
 		return err
 	}
-	klog.InfoS("IP Pool update succeeded", "pool", newPool.Name, "allocation", newPool.Status)
+	klog.InfoS("IP release succeeded", "pool", a.ipPoolName, "ip", allocation.Spec.IPAddress)
 	return nil
-
 }
 
-func (a *IPPoolAllocator) appendPoolUsageForStatefulSet(ipPool *v1alpha2.IPPool, ips []net.IP, namespace, name string) error {
-	newPool := ipPool.DeepCopy()
-
-	for i, ip := range ips {
-		owner := v1alpha2.IPAddressOwner{
-			StatefulSet: &v1alpha2.StatefulSetOwner{
-				Namespace: namespace,
-				Name:      name,
-				Index:     i,
-			},
-		}
-		usageEntry := v1alpha2.IPAddressState{
-			IPAddress: ip.String(),
-			Phase:     v1alpha2.IPAddressPhaseReserved,
-			Owner:     owner,
-		}
-
-		newPool.Status.IPAddresses = append(newPool.Status.IPAddresses, usageEntry)
-	}
-	_, err := a.crdClient.CrdV1alpha2().IPPools().UpdateStatus(context.TODO(), newPool, metav1.UpdateOptions{})
+// releaseIP releases the provided IP, reading its IPAllocation from the API if it's not in the
+// cache, or the cached one is outdated.
+func (a *IPPoolAllocator) releaseIP(ipPool *v1alpha2.IPPool, ip net.IP) error {
+	allocation, err := a.getIPAllocation(ip)
 	if err != nil {
-		klog.Warningf("IP Pool %s update with status %+v failed: %+v", newPool.Name, newPool.Status, err)
 		return err
 	}
-	klog.V(2).InfoS("IP Pool update succeeded", "pool", newPool.Name, "allocation", newPool.Status)
-	return nil
+	if allocation == nil {
+		for _, state := range ipPool.Status.IPAddresses {
+			if state.IPAddress == ip.String() {
+				return newNotMigratedError(ip, ipPool.Name)
+			}
+		}
+		return fmt.Errorf("IP address %s was not allocated from IP pool %s", ip, ipPool.Name)
+	}
+	err = a.removeIPAllocation(allocation)
+	if errors.IsConflict(err) {
+		// The cached IPAllocation is outdated, read the latest one from the API and retry.
+		allocation, err = a.crdClient.CrdV1alpha2().IPAllocations().Get(context.TODO(), allocation.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		return a.removeIPAllocation(allocation)
+	}
+	return err
+}
 
+// newNotMigratedError returns a retriable error for an IP which is still recorded in the IPPool
+// status, and can only be updated after the IPPool is migrated by the Antrea Controller.
+func newNotMigratedError(ip net.IP, poolName string) error {
+	return errors.NewConflict(schema.GroupResource{Group: v1alpha2.GroupName, Resource: "ippools"}, poolName,
+		fmt.Errorf("IP address %s is recorded in the status of the IPPool and has not been migrated to an IPAllocation yet", ip))
 }
 
-// removeIPAddressState updates ipPool status to delete released IP allocation, and keeps preallocation information
-func (a *IPPoolAllocator) removeIPAddressState(ipPool *v1alpha2.IPPool, ip net.IP) error {
+// MigrateIPAddressStates creates the IPAllocations for the IPs recorded in the IPPool status by
+// earlier versions, and clears them from the status. It's idempotent, and must only be called by
+// the Antrea Controller to avoid concurrent migrations.
+func (a *IPPoolAllocator) MigrateIPAddressStates() error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// Read the latest IPPool from the API to not migrate the IPs released after a previous
+		// migration again.
+		ipPool, err := a.crdClient.CrdV1alpha2().IPPools().Get(context.TODO(), a.ipPoolName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if len(ipPool.Status.IPAddresses) == 0 {
+			return nil
+		}
 
-	ipString := ip.String()
-	newPool := ipPool.DeepCopy()
-	var newList []v1alpha2.IPAddressState
-	allocated := false
-	for i := range ipPool.Status.IPAddresses {
-		entry := ipPool.Status.IPAddresses[i]
-		if entry.IPAddress != ipString {
-			newList = append(newList, entry)
-		} else {
-			allocated = true
-			if entry.Owner.StatefulSet != nil {
-				entry = *entry.DeepCopy()
-				entry.Owner.Pod = nil
-				entry.Phase = v1alpha2.IPAddressPhaseReserved
-				newList = append(newList, entry)
+		for _, state := range ipPool.Status.IPAddresses {
+			ip := net.ParseIP(state.IPAddress)
+			if ip == nil {
+				klog.InfoS("Ignoring invalid IP address in IPPool status", "pool", ipPool.Name, "ip", state.IPAddress)
+				continue
+			}
+			err := a.createIPAllocation(ipPool, ip, state.Phase, state.Owner)
+			if err != nil && !errors.IsAlreadyExists(err) {
+				return err
 			}
 		}
-	}
-
-	if !allocated {
-		return fmt.Errorf("IP address %s was not allocated from IP pool %s", ip, ipPool.Name)
-	}
 
-	newPool.Status.IPAddresses = newList
-	a.updateUsage(newPool)
+		newPool := ipPool.DeepCopy()
+		newPool.Status.IPAddresses = nil
+		_, err = a.crdClient.CrdV1alpha2().IPPools().UpdateStatus(context.TODO(), newPool, metav1.UpdateOptions{})
+		if err != nil {
+			klog.Warningf("IP Pool %s status update failed: %+v", newPool.Name, err)
+			return err
+		}
+		klog.InfoS("Migrated IP addresses from IPPool status to IPAllocations", "pool", ipPool.Name, "count", len(ipPool.Status.IPAddresses))
+		return nil
+	})
 
-	_, err := a.crdClient.CrdV1alpha2().IPPools().UpdateStatus(context.TODO(), newPool, metav1.UpdateOptions{})
 	if err != nil {
-		klog.Warningf("IP Pool %s update failed: %+v", newPool.Name, err)
-		return err
+		klog.ErrorS(err, "Failed to migrate IP addresses of IPPool", "IPPool", a.ipPoolName)
 	}
-	klog.InfoS("IP Pool update succeeded", "pool", newPool.Name, "allocation", newPool.Status)
-	return nil
-
+	return err
 }
 
 // getExistingAllocation looks up the existing IP allocation for a Pod network interface, and
@@ -263,7 +444,11 @@ func (a *IPPoolAllocator) getExistingAllocation(podOwner *v1alpha2.PodOwner) (ne
 		return nil, nil, nil
 	}
 
-	ipPool, allocators, err := a.getPoolAndInitIPAllocators()
+	ipPool, err := a.getPool()
+	if err != nil {
+		return nil, nil, err
+	}
+	allocators, err := newIPAllocators(ipPool)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -283,12 +468,11 @@ func (a *IPPoolAllocator) getExistingAllocation(podOwner *v1alpha2.PodOwner) (ne
 
 // AllocateIP allocates the specified IP. It returns error if the IP is not in the range or already
 // allocated, or in case CRD failed to update its state.
-// In case of success, IP pool CRD status is updated with allocated IP/state/resource/container.
+// In case of success, an IPAllocation CR is created with allocated IP/state/resource/container.
 // AllocateIP returns subnet details for the requested IP, as defined in IP pool spec.
 func (a *IPPoolAllocator) AllocateIP(ip net.IP, state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) (*v1alpha2.SubnetInfo, error) {
 	var subnetSpec *v1alpha2.SubnetInfo
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	err := func() error {
 		ipPool, allocators, err := a.getPoolAndInitIPAllocators()
 		if err != nil {
 			return err
@@ -312,10 +496,12 @@ func (a *IPPoolAllocator) AllocateIP(ip net.IP, state v1alpha2.IPAddressPhase, o
 		}
 
 		subnetSpec = &ipPool.Spec.IPRanges[index].SubnetInfo
-		err = a.appendPoolUsage(ipPool, ip, state, owner)
-
+		err = a.createIPAllocation(ipPool, ip, state, owner)
+		if errors.IsAlreadyExists(err) {
+			return fmt.Errorf("IP %v is already allocated from IP pool %s", ip, a.ipPoolName)
+		}
 		return err
-	})
+	}()
 
 	if err != nil {
 		klog.Errorf("Failed to allocate IP address %s from pool %s: %+v", ip, a.ipPoolName, err)
@@ -325,7 +511,7 @@ func (a *IPPoolAllocator) AllocateIP(ip net.IP, state v1alpha2.IPAddressPhase, o
 
 // AllocateNext allocates the next available IP. It returns error if pool is exausted,
 // or in case CRD failed to update its state.
-// In case of success, IPPool CRD status is updated with allocated IP/state/resource/container.
+// In case of success, an IPAllocation CR is created with allocated IP/state/resource/container.
 // AllocateIP returns subnet details for the requested IP, as defined in IP pool spec.
 func (a *IPPoolAllocator) AllocateNext(state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) (net.IP, *v1alpha2.SubnetInfo, error) {
 	podOwner := owner.Pod
@@ -343,31 +529,39 @@ func (a *IPPoolAllocator) AllocateNext(state v1alpha2.IPAddressPhase, owner v1al
 		return ip, subnetSpec, err
 	}
 
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
-	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	err = func() error {
 		ipPool, allocators, err := a.getPoolAndInitIPAllocators()
 		if err != nil {
 			return err
 		}
 
-		index := len(allocators)
-		for i, allocator := range allocators {
-			ip, err = allocator.AllocateNext()
-			if err == nil {
-				// successful allocation
-				index = i
-				break
+		// The cache may not include the IPs allocated concurrently by other clients yet. Try
+		// the next available IP until an IPAllocation can be created, instead of retrying
+		// with the whole pool.
+		for {
+			index := len(allocators)
+			for i, allocator := range allocators {
+				ip, err = allocator.AllocateNext()
+				if err == nil {
+					// successful allocation
+					index = i
+					break
+				}
 			}
-		}
 
-		if index == len(allocators) {
-			// Failed to find matching range
-			return fmt.Errorf("failed to allocate IP: Pool %s is exausted", a.ipPoolName)
-		}
+			if index == len(allocators) {
+				// Failed to find matching range
+				return fmt.Errorf("failed to allocate IP: Pool %s is exausted", a.ipPoolName)
+			}
 
-		subnetSpec = &ipPool.Spec.IPRanges[index].SubnetInfo
-		return a.appendPoolUsage(ipPool, ip, state, owner)
-	})
+			subnetSpec = &ipPool.Spec.IPRanges[index].SubnetInfo
+			err = a.createIPAllocation(ipPool, ip, state, owner)
+			if !errors.IsAlreadyExists(err) {
+				return err
+			}
+			klog.V(2).InfoS("IP has been allocated by another client, trying the next one", "ip", ip, "IPPool", a.ipPoolName)
+		}
+	}()
 
 	if err != nil {
 		klog.ErrorS(err, "Failed to allocate from IPPool", "IPPool", a.ipPoolName)
@@ -377,7 +571,7 @@ func (a *IPPoolAllocator) AllocateNext(state v1alpha2.IPAddressPhase, owner v1al
 
 // AllocateReservedOrNext allocates the reserved IP if it exists, else allocates next available IP.
 // It returns error if pool is exhausted, or in case it fails to update IPPool's state. In case of
-// success, IP pool status is updated with allocated IP/state/resource/container.
+// success, the IPAllocation CR is updated with allocated IP/state/resource/container.
 // AllocateReservedOrNext returns subnet details for the requested IP, as defined in IP pool spec.
 func (a *IPPoolAllocator) AllocateReservedOrNext(state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) (net.IP, *v1alpha2.SubnetInfo, error) {
 	ip, err := a.getReservedIP(owner)
@@ -401,8 +595,10 @@ func (a *IPPoolAllocator) AllocateReservedOrNext(state v1alpha2.IPAddressPhase,
 		return prevIP, subnetSpec, err
 	}
 
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
+	// Retry on CRD update conflict which is caused by the reservation being updated at same time.
+	attempt := 0
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		attempt++
 		ipPool, allocators, err := a.getPoolAndInitIPAllocators()
 		if err != nil {
 			return err
@@ -422,7 +618,23 @@ func (a *IPPoolAllocator) AllocateReservedOrNext(state v1alpha2.IPAddressPhase,
 		}
 
 		subnetSpec = &ipPool.Spec.IPRanges[index].SubnetInfo
-		return a.updateIPAddressState(ipPool, ip, state, owner)
+		var allocation *v1alpha2.IPAllocation
+		if attempt == 1 {
+			allocation, err = a.getIPAllocation(ip)
+		} else {
+			// The cached IPAllocation was outdated, read the latest one from the API.
+			allocation, err = a.crdClient.CrdV1alpha2().IPAllocations().Get(context.TODO(), IPAllocationName(ipPool.Name, ip), metav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				allocation, err = nil, nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if allocation == nil {
+			return newNotMigratedError(ip, ipPool.Name)
+		}
+		return a.updateIPAddressState(allocation, state, owner)
 	})
 
 	if err != nil {
@@ -436,15 +648,16 @@ func (a *IPPoolAllocator) AllocateReservedOrNext(state v1alpha2.IPAddressPhase,
 // It returns error if such range is not available. In this case IPs for the StatefulSet will
 // be allocated on the fly, and there is no guarantee for continuous IPs.
 func (a *IPPoolAllocator) AllocateStatefulSet(namespace, name string, size int) error {
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
+	// Retry on conflict which is caused by other clients allocating IPs of the range at same time.
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		ipPool, allocators, err := a.getPoolAndInitIPAllocators()
 		if err != nil {
 			return err
 		}
+		states := a.getIPAddressStates(ipPool)
 
 		// Make sure there is no double allocation for this StatefulSet
-		for _, ip := range ipPool.Status.IPAddresses {
+		for _, ip := range states {
 			if ip.Owner.StatefulSet != nil && ip.Owner.StatefulSet.Namespace == namespace && ip.Owner.StatefulSet.Name == name {
 				return fmt.Errorf("StatefulSet %s/%s is already present in IPPool %s", namespace, name, ipPool.Name)
 			}
@@ -455,7 +668,32 @@ func (a *IPPoolAllocator) AllocateStatefulSet(namespace, name string, size int)
 			return err
 		}
 
-		return a.appendPoolUsageForStatefulSet(ipPool, ips, namespace, name)
+		for i, ip := range ips {
+			owner := v1alpha2.IPAddressOwner{
+				StatefulSet: &v1alpha2.StatefulSetOwner{
+					Namespace: namespace,
+					Name:      name,
+					Index:     i,
+				},
+			}
+			err := a.createIPAllocation(ipPool, ip, v1alpha2.IPAddressPhaseReserved, owner)
+			if err == nil {
+				continue
+			}
+			// Roll back the partial reservation so that the whole range can be reserved again.
+			for _, reservedIP := range ips[:i] {
+				name := IPAllocationName(ipPool.Name, reservedIP)
+				if err := a.crdClient.CrdV1alpha2().IPAllocations().Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+					klog.ErrorS(err, "Failed to roll back IP reservation", "IPAllocation", name)
+				}
+			}
+			if errors.IsAlreadyExists(err) {
+				return errors.NewConflict(schema.GroupResource{Group: v1alpha2.GroupName, Resource: "ipallocations"}, IPAllocationName(ipPool.Name, ip), err)
+			}
+			return err
+		}
+		klog.V(2).InfoS("IP reservation succeeded", "pool", ipPool.Name, "Namespace", namespace, "StatefulSet", name, "size", size)
+		return nil
 	})
 
 	if err != nil {
@@ -466,25 +704,25 @@ func (a *IPPoolAllocator) AllocateStatefulSet(namespace, name string, size int)
 
 // Release releases the provided IP. It returns error if the IP is not in the range or not allocated,
 // or in case CRD failed to update its state.
-// In case of success, IP pool CRD status is updated with released IP/state/resource.
+// In case of success, the IPAllocation CR of the IP is deleted.
 func (a *IPPoolAllocator) Release(ip net.IP) error {
-
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		ipPool, allocators, err := a.getPoolAndInitIPAllocators()
+	err := func() error {
+		ipPool, err := a.getPool()
 		if err != nil {
 			return err
 		}
-
-		err = allocators.Release(ip)
-
+		allocators, err := newIPAllocators(ipPool)
 		if err != nil {
+			return err
+		}
+
+		if !allocators.Has(ip) {
 			// Failed to find matching range
 			return fmt.Errorf("IP %v does not belong to IP pool %s", ip, a.ipPoolName)
 		}
 
-		return a.removeIPAddressState(ipPool, ip)
-	})
+		return a.releaseIP(ipPool, ip)
+	}()
 
 	if err != nil {
 		klog.ErrorS(err, "Failed to release IP address", "IPAddress", ip, "IPPool", a.ipPoolName)
@@ -494,42 +732,43 @@ func (a *IPPoolAllocator) Release(ip net.IP) error {
 
 // ReleaseStatefulSet releases all IPs associated with specified StatefulSet. It returns error
 // in case CRD failed to update its state.
-// In case of success, IP pool CRD status is updated with released entries.
+// In case of success, the IPAllocation CRs of the released IPs are deleted.
 func (a *IPPoolAllocator) ReleaseStatefulSet(namespace, name string) error {
-
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	err := func() error {
 		ipPool, err := a.getPool()
 
 		if err != nil {
 			return err
 		}
 
-		var updatedAdresses []v1alpha2.IPAddressState
 		for _, ip := range ipPool.Status.IPAddresses {
-			if ip.Owner.StatefulSet == nil || ip.Owner.StatefulSet.Namespace != namespace || ip.Owner.StatefulSet.Name != name {
-				updatedAdresses = append(updatedAdresses, ip)
+			if ip.Owner.StatefulSet != nil && ip.Owner.StatefulSet.Namespace == namespace && ip.Owner.StatefulSet.Name == name {
+				return newNotMigratedError(net.ParseIP(ip.IPAddress), ipPool.Name)
+			}
+		}
+
+		released := 0
+		for _, allocation := range a.getIPAllocations() {
+			owner := allocation.Spec.Owner.StatefulSet
+			if owner == nil || owner.Namespace != namespace || owner.Name != name {
+				continue
 			}
+			err := a.crdClient.CrdV1alpha2().IPAllocations().Delete(context.TODO(), allocation.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				klog.Warningf("IPAllocation %s deletion failed: %+v", allocation.Name, err)
+				return err
+			}
+			released++
 		}
 
-		if len(ipPool.Status.IPAddresses) == len(updatedAdresses) {
+		if released == 0 {
 			// no change
 			klog.V(4).InfoS("No reserved IPs found", "pool", ipPool.Name, "Namespace", namespace, "StatefulSet", name)
 			return nil
 		}
-
-		newPool := ipPool.DeepCopy()
-		newPool.Status.IPAddresses = updatedAdresses
-
-		_, err = a.crdClient.CrdV1alpha2().IPPools().UpdateStatus(context.TODO(), newPool, metav1.UpdateOptions{})
-		if err != nil {
-			klog.Warningf("IP Pool %s update failed: %+v", newPool.Name, err)
-			return err
-		}
-		klog.V(2).InfoS("IP Pool update successful", "pool", newPool.Name, "allocation", newPool.Status)
+		klog.V(2).InfoS("IP release successful", "pool", ipPool.Name, "Namespace", namespace, "StatefulSet", name, "released", released)
 		return nil
-
-	})
+	}()
 
 	if err != nil {
 		klog.ErrorS(err, "Failed to release IP addresses", "Namespace", namespace, "StatefulSet", name, "IPPool", a.ipPoolName)
@@ -538,30 +777,29 @@ func (a *IPPoolAllocator) ReleaseStatefulSet(namespace, name string) error {
 }
 
 // ReleaseContainer releases the IP associated with the specified container ID and interface name,
-// and updates the IPPool CR status.
-// If no IP is allocated to the Pod according to the IPPool CR status, the func just returns with no
+// and deletes its IPAllocation CR.
+// If no IP is allocated to the Pod according to the IPAllocation CRs, the func just returns with no
 // change.
 func (a *IPPoolAllocator) ReleaseContainer(containerID, ifName string) error {
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+	err := func() error {
 		ipPool, err := a.getPool()
 		if err != nil {
 			return err
 		}
 
-		// Mark the released IPs as available in the IPPool status.
-		for _, ip := range ipPool.Status.IPAddresses {
-			savedOwner := ip.Owner.Pod
-			if savedOwner != nil && savedOwner.ContainerID == containerID && savedOwner.IFName == ifName {
-				return a.removeIPAddressState(ipPool, net.ParseIP(ip.IPAddress))
-
-			}
+		// Mark the released IPs as available.
+		ip, err := a.GetContainerIP(containerID, ifName)
+		if err != nil {
+			return err
+		}
+		if ip != nil {
+			return a.releaseIP(ipPool, ip)
 		}
 
 		klog.V(4).InfoS("Did not find the allocation record in IPPool",
-			"container", containerID, "interface", ifName, "pool", a.ipPoolName, "allocation", ipPool.Status.IPAddresses)
+			"container", containerID, "interface", ifName, "pool", a.ipPoolName)
 		return nil
-	})
+	}()
 
 	if err != nil {
 		klog.ErrorS(err, "Failed to release IP address", "Container", containerID, "interface", ifName, "IPPool", a.ipPoolName)
@@ -577,7 +815,7 @@ func (a *IPPoolAllocator) hasPod(namespace, podName string) (bool, error) {
 		return false, err
 	}
 
-	for _, ip := range ipPool.Status.IPAddresses {
+	for _, ip := range a.getIPAddressStates(ipPool) {
 		if ip.Owner.Pod != nil && ip.Owner.Pod.Namespace == namespace && ip.Owner.Pod.Name == podName {
 			return true, nil
 		}
@@ -592,6 +830,13 @@ func (a *IPPoolAllocator) GetContainerIP(containerID, ifName string) (net.IP, er
 		return nil, err
 	}
 
+	objs, _ := a.ipAllocationIndexer.ByIndex(ContainerIndex, ContainerIndexKey(containerID, ifName))
+	for _, obj := range objs {
+		allocation := obj.(*v1alpha2.IPAllocation)
+		if allocation.Spec.IPPool == a.ipPoolName {
+			return net.ParseIP(allocation.Spec.IPAddress), nil
+		}
+	}
 	for _, ip := range ipPool.Status.IPAddresses {
 		if ip.Owner.Pod != nil && ip.Owner.Pod.ContainerID == containerID && ip.Owner.Pod.IFName == ifName {
 			return net.ParseIP(ip.IPAddress), nil
@@ -608,7 +853,7 @@ func (a *IPPoolAllocator) getReservedIP(reservedOwner v1alpha2.IPAddressOwner) (
 	}
 
 	if reservedOwner.StatefulSet != nil {
-		for _, ip := range ipPool.Status.IPAddresses {
+		for _, ip := range a.getIPAddressStates(ipPool) {
 			if reflect.DeepEqual(ip.Owner.StatefulSet, reservedOwner.StatefulSet) {
 				return net.ParseIP(ip.IPAddress), nil
 			}
@@ -618,14 +863,22 @@ func (a *IPPoolAllocator) getReservedIP(reservedOwner v1alpha2.IPAddressOwner) (
 }
 
 func (a IPPoolAllocator) Total() int {
-	_, allocators, err := a.getPoolAndInitIPAllocators()
+	ipPool, err := a.getPool()
+	if err != nil {
+		return 0
+	}
+	allocators, err := newIPAllocators(ipPool)
 	if err != nil {
 		return 0
 	}
 	return allocators.Total()
 }
 
-func (a *IPPoolAllocator) updateUsage(ipPool *v1alpha2.IPPool) {
-	ipPool.Status.Usage.Total = a.Total()
-	ipPool.Status.Usage.Used = len(ipPool.Status.IPAddresses)
+// Used returns the number of allocated IPs.
+func (a *IPPoolAllocator) Used() int {
+	ipPool, err := a.getPool()
+	if err != nil {
+		return 0
+	}
+	return len(a.getIPAddressStates(ipPool))
 }
@@ -0,0 +1,132 @@
+//go:build !race
+// +build !race
+
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poolallocator
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	crdv1a2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	fakepoolclient "antrea.io/antrea/pkg/ipam/poolallocator/testing"
+)
+
+/*
+TestAllocateReleaseXLargeScale tests the execution time of allocating and releasing IPs concurrently from a /16
+IPPool which already has 60000 allocated IPs, by 10 allocators with their own caches, like the Antrea Agents of 10
+Nodes. The reference value is:
+
+ALLOCATED    ALLOCATORS    ALLOCATIONS    TIME(s)
+60000        10            100            0.41
+60000        10            100            0.57
+60000        10            100            0.51
+
+The IPs are allocated without conflicts on the IPPool, and the size of the IPPool doesn't grow with the number of
+allocated IPs. The metrics are not accurate under the race detector, and will be skipped when testing with "-race".
+*/
+func TestAllocateReleaseXLargeScale(t *testing.T) {
+	testAllocateRelease(t, 10*time.Second, 60000, 10, 10)
+}
+
+func testAllocateRelease(t *testing.T, maxExecutionTime time.Duration, allocatedIPs, numAllocators, numIPsPerAllocator int) {
+	disableLogToStderr()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	poolName := "pool"
+	_, ipNet, _ := net.ParseCIDR("10.10.0.0/16")
+	pool := &crdv1a2.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+		Spec: crdv1a2.IPPoolSpec{
+			IPVersion: crdv1a2.IPv4,
+			IPRanges: []crdv1a2.SubnetIPRange{{
+				IPRange:    crdv1a2.IPRange{CIDR: ipNet.String()},
+				SubnetInfo: crdv1a2.SubnetInfo{Gateway: "10.10.0.1", PrefixLength: 16},
+			}},
+		},
+	}
+	crdClient := fakepoolclient.NewIPPoolClient()
+	crdClient.InitPool(pool)
+	for i := 0; i < allocatedIPs; i++ {
+		// Skip the network address and the gateway.
+		ip := net.IPv4(10, 10, byte((i+2)>>8), byte(i+2))
+		owner := crdv1a2.IPAddressOwner{Pod: &crdv1a2.PodOwner{Name: fmt.Sprintf("pod-%d", i), Namespace: testNamespace, ContainerID: uuid.New().String()}}
+		allocation := (&IPPoolAllocator{}).newIPAllocation(pool, ip, crdv1a2.IPAddressPhaseAllocated, owner)
+		require.NoError(t, crdClient.Tracker().Add(allocation))
+	}
+
+	allocators := make([]*IPPoolAllocator, 0, numAllocators)
+	for i := 0; i < numAllocators; i++ {
+		allocator := newTestIPPoolAllocatorWithClient(crdClient, poolName, stopCh)
+		require.NotNil(t, allocator)
+		allocators = append(allocators, allocator)
+	}
+
+	// Everything is ready, now start timing.
+	start := time.Now()
+	var wg sync.WaitGroup
+	// require must not be called from the goroutines, the errors are checked after all of them finish.
+	errCh := make(chan error, numAllocators)
+	for i, allocator := range allocators {
+		wg.Add(1)
+		go func(i int, allocator *IPPoolAllocator) {
+			defer wg.Done()
+			for j := 0; j < numIPsPerAllocator; j++ {
+				owner := crdv1a2.IPAddressOwner{Pod: &crdv1a2.PodOwner{Name: fmt.Sprintf("pod-%d-%d", i, j), Namespace: testNamespace, ContainerID: uuid.New().String()}}
+				ip, _, err := allocator.AllocateNext(crdv1a2.IPAddressPhaseAllocated, owner)
+				if err != nil {
+					errCh <- fmt.Errorf("allocator %d failed to allocate IP: %w", i, err)
+					return
+				}
+				if err := allocator.Release(ip); err != nil {
+					errCh <- fmt.Errorf("allocator %d failed to release IP %s: %w", i, ip, err)
+					return
+				}
+			}
+		}(i, allocator)
+	}
+	wg.Wait()
+	executionTime := time.Since(start)
+	close(errCh)
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+	if executionTime > maxExecutionTime {
+		t.Errorf("The actual execution time %v is greater than the maximum value %v", executionTime, maxExecutionTime)
+	}
+
+	t.Logf(`Summary metrics:
+ALLOCATED    ALLOCATORS    ALLOCATIONS    TIME(s)
+%-12d %-13d %-14d %.2f
+`, allocatedIPs, numAllocators, numAllocators*numIPsPerAllocator, float64(executionTime)/float64(time.Second))
+}
+
+func disableLogToStderr() {
+	klogFlagSet := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(klogFlagSet)
+	klogFlagSet.Parse([]string{"-logtostderr=false"})
+}
@@ -15,8 +15,10 @@
 package poolallocator
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"sync"
 	"testing"
 	"time"
 
@@ -28,10 +30,11 @@ import (
 	informers "antrea.io/antrea/pkg/client/informers/externalversions"
 	fakepoolclient "antrea.io/antrea/pkg/ipam/poolallocator/testing"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 )
 
@@ -48,20 +51,28 @@ var fakePodOwner = crdv1a2.IPAddressOwner{
 func newTestIPPoolAllocator(pool *crdv1a2.IPPool, stopCh <-chan struct{}) *IPPoolAllocator {
 
 	crdClient := fakepoolclient.NewIPPoolClient()
+	crdClient.InitPool(pool)
+	return newTestIPPoolAllocatorWithClient(crdClient, pool.Name, stopCh)
+}
 
+// newTestIPPoolAllocatorWithClient creates an allocator with its own informers, like the allocators of
+// different Antrea components sharing the same pool.
+func newTestIPPoolAllocatorWithClient(crdClient *fakepoolclient.IPPoolClientset, poolName string, stopCh <-chan struct{}) *IPPoolAllocator {
 	crdInformerFactory := informers.NewSharedInformerFactory(crdClient, 0)
 	pools := crdInformerFactory.Crd().V1alpha2().IPPools()
 	poolInformer := pools.Informer()
+	ipAllocations := crdInformerFactory.Crd().V1alpha2().IPAllocations()
+	ipAllocations.Informer().AddIndexers(cache.Indexers{IPPoolIndex: IPPoolIndexFunc, ContainerIndex: ContainerIndexFunc})
+	allocationCache := NewIPAllocationCache(ipAllocations)
 
 	go crdInformerFactory.Start(stopCh)
 
-	crdClient.InitPool(pool)
-	cache.WaitForCacheSync(stopCh, poolInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, poolInformer.HasSynced, ipAllocations.Informer().HasSynced)
 
 	var allocator *IPPoolAllocator
 	var err error
 	wait.PollImmediate(100*time.Millisecond, 1*time.Second, func() (bool, error) {
-		allocator, err = NewIPPoolAllocator(pool.Name, crdClient, pools.Lister())
+		allocator, err = NewIPPoolAllocator(poolName, crdClient, pools.Lister(), allocationCache)
 		if err != nil {
 			return false, nil
 		}
@@ -70,6 +81,23 @@ func newTestIPPoolAllocator(pool *crdv1a2.IPPool, stopCh <-chan struct{}) *IPPoo
 	return allocator
 }
 
+// waitForUsed waits for the allocator cache to reflect the expected number of allocated IPs.
+func waitForUsed(t *testing.T, allocator *IPPoolAllocator, used int) {
+	err := wait.PollImmediate(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return allocator.Used() == used, nil
+	})
+	require.NoError(t, err, "Timed out waiting for %d allocated IPs, got %d", used, allocator.Used())
+}
+
+// waitForContainerIP waits for the allocator cache to reflect the IP allocated to the container.
+func waitForContainerIP(t *testing.T, allocator *IPPoolAllocator, containerID string, ip net.IP) {
+	err := wait.PollImmediate(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		allocatedIP, err := allocator.GetContainerIP(containerID, "")
+		return allocatedIP.Equal(ip), err
+	})
+	require.NoError(t, err, "Timed out waiting for IP %s allocated to container %s", ip, containerID)
+}
+
 func validateAllocationSequence(t *testing.T, allocator *IPPoolAllocator, subnetInfo crdv1a2.SubnetInfo, ipList []string) {
 	i := 1
 	for _, expectedIP := range ipList {
@@ -85,6 +113,7 @@ func validateAllocationSequence(t *testing.T, allocator *IPPoolAllocator, subnet
 		require.NoError(t, err)
 		assert.Equal(t, net.ParseIP(expectedIP), ip)
 		assert.Equal(t, subnetInfo, *returnInfo)
+		waitForContainerIP(t, allocator, owner.Pod.ContainerID, ip)
 		i += 1
 	}
 }
@@ -269,36 +298,26 @@ func TestAllocateReleaseSequence(t *testing.T) {
 		err := allocator.Release(net.ParseIP(ipToRelease))
 		require.NoError(t, err)
 	}
+	waitForUsed(t, allocator, 2)
 
 	validateAllocationSequence(t, allocator, subnetInfo, []string{"2001::1000", "2001::2", "2001::5"})
 }
 
-// releasePod releases the IP associated with the specified Pod, and updates the IPPool CR status.
-// The func returns an error, if no IP is allocated to the Pod according to the IPPool CR status.
+// releasePod releases the IP associated with the specified Pod, and deletes its IPAllocation CR.
+// The func returns an error, if no IP is allocated to the Pod according to the IPAllocation CRs.
 func (a *IPPoolAllocator) releasePod(namespace, podName string) error {
-	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		ipPool, err := a.getPool()
-		if err != nil {
-			return err
-		}
-
-		// Mark allocated IPs from pool status as unavailable
-		for _, ip := range ipPool.Status.IPAddresses {
-			if ip.Owner.Pod != nil && ip.Owner.Pod.Namespace == namespace && ip.Owner.Pod.Name == podName {
-				return a.removeIPAddressState(ipPool, net.ParseIP(ip.IPAddress))
+	ipPool, err := a.getPool()
+	if err != nil {
+		return err
+	}
 
-			}
+	for _, ip := range a.getIPAddressStates(ipPool) {
+		if ip.Owner.Pod != nil && ip.Owner.Pod.Namespace == namespace && ip.Owner.Pod.Name == podName {
+			return a.releaseIP(ipPool, net.ParseIP(ip.IPAddress))
 		}
-
-		klog.V(4).InfoS("IP Pool state:", "name", a.ipPoolName, "allocation", ipPool.Status.IPAddresses)
-		return fmt.Errorf("failed to find record of IP allocated to Pod:%s/%s in pool %s", namespace, podName, a.ipPoolName)
-	})
-
-	if err != nil {
-		klog.ErrorS(err, "Failed to release IP address", "Namespace", namespace, "Pod", podName, "IPPool", a.ipPoolName)
 	}
-	return err
+
+	return fmt.Errorf("failed to find record of IP allocated to Pod:%s/%s in pool %s", namespace, podName, a.ipPoolName)
 }
 
 func TestReleaseResource(t *testing.T) {
@@ -338,6 +357,7 @@ func TestReleaseResource(t *testing.T) {
 		err := allocator.releasePod(testNamespace, podName)
 		require.NoError(t, err)
 	}
+	waitForUsed(t, allocator, 3)
 
 	validateAllocationSequence(t, allocator, subnetInfo, []string{"2001::2", "2001::4", "2001::6"})
 }
@@ -423,6 +443,7 @@ func TestAllocateReleaseStatefulSet(t *testing.T) {
 	allocator := newTestIPPoolAllocator(&pool, stopCh)
 	err := allocator.AllocateStatefulSet(testNamespace, setName, 7)
 	require.NoError(t, err)
+	waitForUsed(t, allocator, 7)
 
 	// Make sure reserved IPs are respected for next allocate
 	validateAllocationSequence(t, allocator, subnetInfo, []string{"10.2.2.107", "10.2.2.108"})
@@ -430,7 +451,191 @@ func TestAllocateReleaseStatefulSet(t *testing.T) {
 	// Release the set
 	err = allocator.ReleaseStatefulSet(testNamespace, setName)
 	require.NoError(t, err)
+	waitForUsed(t, allocator, 2)
 
 	// Make sure reserved IPs are released
 	validateAllocationSequence(t, allocator, subnetInfo, []string{"10.2.2.100"})
 }
+
+func TestIPAllocationName(t *testing.T) {
+	assert.Equal(t, "pool1-10.2.2.100", IPAllocationName("pool1", net.ParseIP("10.2.2.100")))
+	assert.Equal(t, "pool1-2001-0000-0000-0000-0000-0000-0000-1000", IPAllocationName("pool1", net.ParseIP("2001::1000")))
+	assert.Equal(t, "pool1-0000-0000-0000-0000-0000-0000-0000-0001", IPAllocationName("pool1", net.ParseIP("::1")))
+}
+
+func TestConcurrentAllocateNext(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	poolName := uuid.New().String()
+	pool := crdv1a2.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+		Spec: crdv1a2.IPPoolSpec{
+			IPRanges: []crdv1a2.SubnetIPRange{{
+				IPRange:    crdv1a2.IPRange{Start: "10.2.2.100", End: "10.2.2.139"},
+				SubnetInfo: crdv1a2.SubnetInfo{Gateway: "10.2.2.1", PrefixLength: 24},
+			}},
+		},
+	}
+	crdClient := fakepoolclient.NewIPPoolClient()
+	crdClient.InitPool(&pool)
+
+	// Each allocator has its own cache, which doesn't include the IPs allocated by the other
+	// allocators right away.
+	const numAllocators, numIPsPerAllocator = 4, 10
+	var wg sync.WaitGroup
+	ipCh := make(chan string, numAllocators*numIPsPerAllocator)
+	for i := 0; i < numAllocators; i++ {
+		allocator := newTestIPPoolAllocatorWithClient(crdClient, poolName, stopCh)
+		require.NotNil(t, allocator)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < numIPsPerAllocator; j++ {
+				owner := crdv1a2.IPAddressOwner{
+					Pod: &crdv1a2.PodOwner{
+						Name:        fmt.Sprintf("fakePod-%d-%d", i, j),
+						Namespace:   testNamespace,
+						ContainerID: uuid.New().String(),
+					},
+				}
+				ip, _, err := allocator.AllocateNext(crdv1a2.IPAddressPhaseAllocated, owner)
+				if assert.NoError(t, err) {
+					ipCh <- ip.String()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(ipCh)
+
+	allocatedIPs := sets.New[string]()
+	for ip := range ipCh {
+		assert.False(t, allocatedIPs.Has(ip), "IP %s was allocated twice", ip)
+		allocatedIPs.Insert(ip)
+	}
+	assert.Equal(t, numAllocators*numIPsPerAllocator, allocatedIPs.Len())
+}
+
+func TestIPAllocationCache(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	poolName := uuid.New().String()
+	pool := crdv1a2.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+		Spec: crdv1a2.IPPoolSpec{
+			IPRanges: []crdv1a2.SubnetIPRange{{
+				IPRange:    crdv1a2.IPRange{Start: "10.2.2.100", End: "10.2.2.110"},
+				SubnetInfo: crdv1a2.SubnetInfo{Gateway: "10.2.2.1", PrefixLength: 24},
+			}},
+		},
+	}
+	crdClient := fakepoolclient.NewIPPoolClient()
+	crdClient.InitPool(&pool)
+	allocator := newTestIPPoolAllocatorWithClient(crdClient, poolName, stopCh)
+	require.NotNil(t, allocator)
+	otherAllocator := newTestIPPoolAllocatorWithClient(crdClient, poolName, stopCh)
+	require.NotNil(t, otherAllocator)
+
+	waitForCachedUsed := func(used int) {
+		err := wait.PollImmediate(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+			allocators, err := allocator.ipAllocationCache.getIPAllocators(&pool)
+			return err == nil && allocators.Used() == used, err
+		})
+		require.NoError(t, err, "Timed out waiting for %d cached allocated IPs", used)
+	}
+
+	// The cache is built with the first allocation, and updated with the created IPAllocation
+	// right away.
+	ip, _, err := allocator.AllocateNext(crdv1a2.IPAddressPhaseAllocated, fakePodOwner)
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("10.2.2.100"), ip)
+	waitForCachedUsed(1)
+
+	// The IPs allocated and released by other clients are updated with the IPAllocation events.
+	_, err = otherAllocator.AllocateIP(net.ParseIP("10.2.2.101"), crdv1a2.IPAddressPhaseAllocated, crdv1a2.IPAddressOwner{
+		Pod: &crdv1a2.PodOwner{Name: "fakePod2", Namespace: testNamespace, ContainerID: uuid.New().String()},
+	})
+	require.NoError(t, err)
+	waitForCachedUsed(2)
+
+	require.NoError(t, otherAllocator.Release(ip))
+	waitForCachedUsed(1)
+
+	// The allocators returned by the cache are copies, updating them doesn't affect the cache.
+	allocators, err := allocator.ipAllocationCache.getIPAllocators(&pool)
+	require.NoError(t, err)
+	_, err = allocators.AllocateNext()
+	require.NoError(t, err)
+	waitForCachedUsed(1)
+}
+
+func TestMigrateIPAddressStates(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	poolName := uuid.New().String()
+	pool := crdv1a2.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+		Spec: crdv1a2.IPPoolSpec{
+			IPRanges: []crdv1a2.SubnetIPRange{{
+				IPRange:    crdv1a2.IPRange{Start: "10.2.2.100", End: "10.2.2.120"},
+				SubnetInfo: crdv1a2.SubnetInfo{Gateway: "10.2.2.1", PrefixLength: 24},
+			}},
+		},
+		Status: crdv1a2.IPPoolStatus{
+			IPAddresses: []crdv1a2.IPAddressState{
+				{
+					IPAddress: "10.2.2.100",
+					Phase:     crdv1a2.IPAddressPhaseAllocated,
+					Owner:     fakePodOwner,
+				},
+				{
+					IPAddress: "10.2.2.101",
+					Phase:     crdv1a2.IPAddressPhaseReserved,
+					Owner: crdv1a2.IPAddressOwner{
+						StatefulSet: &crdv1a2.StatefulSetOwner{Name: "fakeSet", Namespace: testNamespace, Index: 0},
+					},
+				},
+			},
+		},
+	}
+
+	allocator := newTestIPPoolAllocator(&pool, stopCh)
+	require.NotNil(t, allocator)
+	subnetInfo := pool.Spec.IPRanges[0].SubnetInfo
+
+	// The IPs recorded in the IPPool status are not allocated again before the migration.
+	validateAllocationSequence(t, allocator, subnetInfo, []string{"10.2.2.102"})
+	ip, err := allocator.GetContainerIP(fakePodOwner.Pod.ContainerID, "")
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("10.2.2.100"), ip)
+	// They can't be released before the migration.
+	err = allocator.ReleaseContainer(fakePodOwner.Pod.ContainerID, "")
+	assert.True(t, errors.IsConflict(err))
+
+	require.NoError(t, allocator.MigrateIPAddressStates())
+	// The migration is idempotent.
+	require.NoError(t, allocator.MigrateIPAddressStates())
+
+	updatedPool, err := allocator.crdClient.CrdV1alpha2().IPPools().Get(context.TODO(), poolName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, updatedPool.Status.IPAddresses)
+	for _, state := range pool.Status.IPAddresses {
+		allocation, err := allocator.crdClient.CrdV1alpha2().IPAllocations().Get(context.TODO(), IPAllocationName(poolName, net.ParseIP(state.IPAddress)), metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, poolName, allocation.Spec.IPPool)
+		assert.Equal(t, state, allocation.Spec.IPAddressState)
+	}
+
+	// The migrated IPs can be released.
+	err = wait.PollImmediate(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		pool, err := allocator.getPool()
+		return len(pool.Status.IPAddresses) == 0, err
+	})
+	require.NoError(t, err)
+	require.NoError(t, allocator.ReleaseContainer(fakePodOwner.Pod.ContainerID, ""))
+	waitForUsed(t, allocator, 2)
+	validateAllocationSequence(t, allocator, subnetInfo, []string{"10.2.2.100"})
+}
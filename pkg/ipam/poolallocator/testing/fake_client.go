@@ -33,27 +33,35 @@ import (
 // to work in sync. This client extension mimics the real client in
 // conflict handling functionality - pool update will return conflict
 // error unless ResourceVersion for the updated pool reflect the version
-// stored in the client.
+// stored in the client. Other resources, including IPAllocations, are
+// handled by the object tracker of the simple client.
 type IPPoolClientset struct {
-	fakeversioned.Clientset
+	*fakeversioned.Clientset
 	// store latest ResourceVersion for given pool
 	poolVersion sync.Map
 	watcher     *watch.RaceFreeFakeWatcher
+	// The watchers of the object tracker panic when their channels are full, which may happen
+	// when many IPAllocations are created and deleted concurrently. The IPAllocation events are
+	// dispatched by a broadcaster which waits for the watchers instead.
+	allocationBroadcaster *watch.Broadcaster
 }
 
 func (c *IPPoolClientset) InitPool(pool *crdv1a2.IPPool) {
 	pool.ResourceVersion = uuid.New().String()
 	c.poolVersion.Store(pool.Name, pool.ResourceVersion)
 
+	c.Tracker().Add(pool)
 	c.watcher.Add(pool)
 }
 
 func NewIPPoolClient() *IPPoolClientset {
 
-	crdClient := &IPPoolClientset{watcher: watch.NewRaceFreeFake(),
-		poolVersion: sync.Map{}}
+	crdClient := &IPPoolClientset{Clientset: fakeversioned.NewSimpleClientset(),
+		watcher:               watch.NewRaceFreeFake(),
+		poolVersion:           sync.Map{},
+		allocationBroadcaster: watch.NewBroadcaster(int(watch.DefaultChanSize), watch.WaitIfChannelFull)}
 
-	crdClient.AddReactor("update", "ippools", func(action k8stesting.Action) (bool, runtime.Object, error) {
+	crdClient.PrependReactor("update", "ippools", func(action k8stesting.Action) (bool, runtime.Object, error) {
 		updatedPool := action.(k8stesting.UpdateAction).GetObject().(*crdv1a2.IPPool)
 		obj, exists := crdClient.poolVersion.Load(updatedPool.Name)
 		if !exists {
@@ -66,11 +74,37 @@ func NewIPPoolClient() *IPPoolClientset {
 
 		updatedPool.ResourceVersion = uuid.New().String()
 		crdClient.poolVersion.Store(updatedPool.Name, updatedPool.ResourceVersion)
+		crdClient.Tracker().Update(action.GetResource(), updatedPool, "")
 		crdClient.watcher.Modify(updatedPool)
 		return true, updatedPool, nil
 	})
 
-	crdClient.AddWatchReactor("ippools", k8stesting.DefaultWatchReactor(crdClient.watcher, nil))
+	crdClient.PrependWatchReactor("ippools", k8stesting.DefaultWatchReactor(crdClient.watcher, nil))
+
+	objectReaction := k8stesting.ObjectReaction(crdClient.Tracker())
+	crdClient.PrependReactor("*", "ipallocations", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		var deletedObj runtime.Object
+		if deleteAction, ok := action.(k8stesting.DeleteAction); ok {
+			deletedObj, _ = crdClient.Tracker().Get(action.GetResource(), "", deleteAction.GetName())
+		}
+		handled, obj, err := objectReaction(action)
+		if err != nil {
+			return handled, obj, err
+		}
+		switch action.GetVerb() {
+		case "create":
+			crdClient.allocationBroadcaster.Action(watch.Added, obj)
+		case "update":
+			crdClient.allocationBroadcaster.Action(watch.Modified, obj)
+		case "delete":
+			crdClient.allocationBroadcaster.Action(watch.Deleted, deletedObj)
+		}
+		return handled, obj, err
+	})
+	crdClient.PrependWatchReactor("ipallocations", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := crdClient.allocationBroadcaster.Watch()
+		return true, w, err
+	})
 
 	return crdClient
 }
@@ -21,6 +21,14 @@ import (
 
 var _ IPFIXRegistry = new(ipfixRegistry)
 
+// antreaL7InfoElements are the information elements in the Antrea registry which carry the L7 metadata of a flow, and
+// which are not provided by the go-ipfix library yet. They are registered when the registry is loaded.
+var antreaL7InfoElements = []*ipfixentities.InfoElement{
+	ipfixentities.NewInfoElement("appProtocolName", 155, ipfixentities.String, ipfixregistry.AntreaEnterpriseID, ipfixentities.VariableLength),
+	ipfixentities.NewInfoElement("httpVals", 156, ipfixentities.String, ipfixregistry.AntreaEnterpriseID, ipfixentities.VariableLength),
+	ipfixentities.NewInfoElement("tlsServerName", 157, ipfixentities.String, ipfixregistry.AntreaEnterpriseID, ipfixentities.VariableLength),
+}
+
 // IPFIXRegistry interface is added to facilitate unit testing without involving the code from go-ipfix library.
 type IPFIXRegistry interface {
 	LoadRegistry()
@@ -34,9 +42,20 @@ func NewIPFIXRegistry() *ipfixRegistry {
 }
 
 func (reg *ipfixRegistry) LoadRegistry() {
-	ipfixregistry.LoadRegistry()
+	LoadRegistry()
 }
 
 func (reg *ipfixRegistry) GetInfoElement(name string, enterpriseID uint32) (*ipfixentities.InfoElement, error) {
 	return ipfixregistry.GetInfoElement(name, enterpriseID)
 }
+
+// LoadRegistry loads the IANA and Antrea registries of the go-ipfix library, as well as the Antrea information elements
+// which are not part of the library yet. It is safe to call it multiple times, as the registries are reset every time.
+func LoadRegistry() {
+	ipfixregistry.LoadRegistry()
+	for _, ie := range antreaL7InfoElements {
+		// This can only fail if the registry is not initialized or the element is already registered, neither of which
+		// can happen right after loading the registry.
+		ipfixregistry.PutInfoElement(*ie, ipfixregistry.AntreaEnterpriseID)
+	}
+}
@@ -42,6 +42,13 @@ func TestGetInfoElement(t *testing.T) {
 			expectedElementID: 100,
 			expectedError:     "",
 		},
+		{
+			testname:          "Antrea L7 information element exists in registry",
+			name:              "httpVals",
+			enterpriseID:      56506,
+			expectedElementID: 156,
+			expectedError:     "",
+		},
 		{
 			testname:      "Information element with given name does not exist in registry",
 			name:          "sourcePod",
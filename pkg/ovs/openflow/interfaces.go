@@ -79,6 +79,7 @@ const (
 	NxmFieldSrcIPv6     = "NXM_NX_IPV6_SRC"
 	NxmFieldDstIPv6     = "NXM_NX_IPV6_DST"
 	NxmFieldTunIPv4Src  = "NXM_NX_TUN_IPV4_SRC"
+	NxmFieldTunIPv4Dst  = "NXM_NX_TUN_IPV4_DST"
 	NxmFieldEthType     = "NXM_OF_ETH_TYPE"
 	NxmFieldIPProto     = "NXM_OF_IP_PROTO"
 
@@ -340,6 +341,10 @@ type Group interface {
 	ResetBuckets() Group
 	Bucket() BucketBuilder
 	GetID() GroupIDType
+	// SelectionMethodHash makes the select group choose a bucket with a hash of the given packet fields instead of
+	// the default dp_hash. As the hash uses a fixed basis, the same packet fields select the same bucket on all
+	// Nodes which install the same buckets.
+	SelectionMethodHash(fields ...HashField) Group
 }
 
 type BucketBuilder interface {
@@ -410,6 +415,8 @@ type PacketOutBuilder interface {
 	SetUDPSrcPort(port uint16) PacketOutBuilder
 	SetUDPDstPort(port uint16) PacketOutBuilder
 	SetUDPData(data []byte) PacketOutBuilder
+	SetSCTPSrcPort(port uint16) PacketOutBuilder
+	SetSCTPDstPort(port uint16) PacketOutBuilder
 	SetICMPType(icmpType uint8) PacketOutBuilder
 	SetICMPCode(icmpCode uint8) PacketOutBuilder
 	SetICMPID(id uint16) PacketOutBuilder
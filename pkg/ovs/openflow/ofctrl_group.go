@@ -15,8 +15,11 @@
 package openflow
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
+	"strings"
 
 	"antrea.io/libOpenflow/openflow15"
 	"antrea.io/libOpenflow/util"
@@ -27,9 +30,133 @@ var (
 	MaxBucketsPerMessage = 800
 )
 
+const (
+	// ntrVendorID is the experimenter ID of the Netronome extension used by OVS to carry the group selection method.
+	ntrVendorID = 0x0000154d
+	// ntrtSelectionMethod is the experimenter type of the group selection method property.
+	ntrtSelectionMethod = 1
+	// groupPropExperimenter is the property type OFPGPT15_EXPERIMENTER.
+	groupPropExperimenter = 0xffff
+	// selectionMethodHash is the name of the selection method which hashes the specified packet fields.
+	selectionMethodHash = "hash"
+	// selectionMethodNameLen is the fixed size of the selection method name in the property.
+	selectionMethodNameLen = 16
+	// groupPropSelectionMethodLen is the size of the property without the fields.
+	groupPropSelectionMethodLen = 40
+)
+
+// HashField is a packet field that can be used by the "hash" selection method of a select group.
+type HashField struct {
+	name   string
+	field  uint8
+	length uint8
+}
+
+var (
+	HashFieldIPProto    = HashField{name: "nw_proto", field: openflow15.OXM_FIELD_IP_PROTO, length: 1}
+	HashFieldIPSrc      = HashField{name: "ip_src", field: openflow15.OXM_FIELD_IPV4_SRC, length: 4}
+	HashFieldIPDst      = HashField{name: "ip_dst", field: openflow15.OXM_FIELD_IPV4_DST, length: 4}
+	HashFieldIPv6Src    = HashField{name: "ipv6_src", field: openflow15.OXM_FIELD_IPV6_SRC, length: 16}
+	HashFieldIPv6Dst    = HashField{name: "ipv6_dst", field: openflow15.OXM_FIELD_IPV6_DST, length: 16}
+	HashFieldTCPSrc     = HashField{name: "tcp_src", field: openflow15.OXM_FIELD_TCP_SRC, length: 2}
+	HashFieldTCPDst     = HashField{name: "tcp_dst", field: openflow15.OXM_FIELD_TCP_DST, length: 2}
+	HashFieldUDPSrc     = HashField{name: "udp_src", field: openflow15.OXM_FIELD_UDP_SRC, length: 2}
+	HashFieldUDPDst     = HashField{name: "udp_dst", field: openflow15.OXM_FIELD_UDP_DST, length: 2}
+	HashFieldSCTPSrc    = HashField{name: "sctp_src", field: openflow15.OXM_FIELD_SCTP_SRC, length: 2}
+	HashFieldSCTPDst    = HashField{name: "sctp_dst", field: openflow15.OXM_FIELD_SCTP_DST, length: 2}
+	supportedHashFields = []HashField{
+		HashFieldIPProto, HashFieldIPSrc, HashFieldIPDst, HashFieldIPv6Src, HashFieldIPv6Dst, HashFieldTCPSrc,
+		HashFieldTCPDst, HashFieldUDPSrc, HashFieldUDPDst, HashFieldSCTPSrc, HashFieldSCTPDst,
+	}
+)
+
+// oxmHeader returns the OXM header of the field, which is how the field is encoded in the selection method property.
+func (f HashField) oxmHeader() uint32 {
+	return uint32(openflow15.OXM_CLASS_OPENFLOW_BASIC)<<16 | uint32(f.field)<<9 | uint32(f.length)
+}
+
+// GroupPropSelectionMethod is the NTR experimenter group property used by OVS to set the selection method of a select
+// group. It is only sent with the group_mod messages which add or modify the group.
+type GroupPropSelectionMethod struct {
+	Method string
+	Param  uint64
+	Fields []HashField
+}
+
+// length returns the length of the property excluding the padding.
+func (p *GroupPropSelectionMethod) length() uint16 {
+	return uint16(groupPropSelectionMethodLen + 4*len(p.Fields))
+}
+
+func (p *GroupPropSelectionMethod) Len() uint16 {
+	return (p.length() + 7) / 8 * 8
+}
+
+func (p *GroupPropSelectionMethod) MarshalBinary() ([]byte, error) {
+	if len(p.Method) >= selectionMethodNameLen {
+		return nil, fmt.Errorf("selection method name %s is too long", p.Method)
+	}
+	data := make([]byte, p.Len())
+	binary.BigEndian.PutUint16(data[0:], groupPropExperimenter)
+	binary.BigEndian.PutUint16(data[2:], p.length())
+	binary.BigEndian.PutUint32(data[4:], ntrVendorID)
+	binary.BigEndian.PutUint32(data[8:], ntrtSelectionMethod)
+	copy(data[16:16+selectionMethodNameLen], p.Method)
+	binary.BigEndian.PutUint64(data[32:], p.Param)
+	for i, field := range p.Fields {
+		binary.BigEndian.PutUint32(data[groupPropSelectionMethodLen+4*i:], field.oxmHeader())
+	}
+	return data, nil
+}
+
+func (p *GroupPropSelectionMethod) UnmarshalBinary(data []byte) error {
+	if len(data) < groupPropSelectionMethodLen {
+		return errors.New("the group selection method property is too short")
+	}
+	if binary.BigEndian.Uint16(data[0:]) != groupPropExperimenter || binary.BigEndian.Uint32(data[4:]) != ntrVendorID ||
+		binary.BigEndian.Uint32(data[8:]) != ntrtSelectionMethod {
+		return errors.New("the group property is not a selection method property")
+	}
+	length := int(binary.BigEndian.Uint16(data[2:]))
+	if length < groupPropSelectionMethodLen || length > len(data) || (length-groupPropSelectionMethodLen)%4 != 0 {
+		return fmt.Errorf("invalid length %d of the group selection method property", length)
+	}
+	p.Method = strings.TrimRight(string(data[16:16+selectionMethodNameLen]), "\x00")
+	p.Param = binary.BigEndian.Uint64(data[32:])
+	p.Fields = nil
+	for offset := groupPropSelectionMethodLen; offset < length; offset += 4 {
+		header := binary.BigEndian.Uint32(data[offset:])
+		field, ok := hashFieldFromOXMHeader(header)
+		if !ok {
+			return fmt.Errorf("unsupported hash field 0x%x in the group selection method property", header)
+		}
+		p.Fields = append(p.Fields, field)
+	}
+	return nil
+}
+
+func (p *GroupPropSelectionMethod) String() string {
+	var fieldNames []string
+	for _, field := range p.Fields {
+		fieldNames = append(fieldNames, field.name)
+	}
+	return fmt.Sprintf("selection_method=%s,fields(%s)", p.Method, strings.Join(fieldNames, ","))
+}
+
+func hashFieldFromOXMHeader(header uint32) (HashField, bool) {
+	for _, field := range supportedHashFields {
+		if field.oxmHeader() == header {
+			return field, true
+		}
+	}
+	return HashField{}, false
+}
+
 type ofGroup struct {
 	ofctrl *ofctrl.Group
 	bridge *OFBridge
+	// selectionMethod is set if the select group doesn't use the default selection method of OVS.
+	selectionMethod *GroupPropSelectionMethod
 }
 
 // Reset updates ofctrl.Group.Switch with the updated ofSwitch.
@@ -98,7 +225,14 @@ func (g *ofGroup) GetBundleMessages(entryOper OFOperation) ([]ofctrl.OpenFlowMod
 			Buckets:   g.ofctrl.Buckets[start:end],
 		}
 
-		messages = append(messages, groupMessage.GetBundleMessage(operation))
+		message := groupMessage.GetBundleMessage(operation)
+		// The selection method is a property of the group rather than the buckets, so it is only needed when the group
+		// is added or modified.
+		if g.selectionMethod != nil && (operation == openflow15.OFPGC_ADD || operation == openflow15.OFPGC_MODIFY) {
+			groupMod := message.GetMessage().(*openflow15.GroupMod)
+			groupMod.Properties = append(groupMod.Properties, g.selectionMethod)
+		}
+		messages = append(messages, message)
 	}
 	return messages, nil
 }
@@ -112,6 +246,14 @@ func (g *ofGroup) GetID() GroupIDType {
 	return GroupIDType(g.ofctrl.ID)
 }
 
+func (g *ofGroup) SelectionMethodHash(fields ...HashField) Group {
+	g.selectionMethod = &GroupPropSelectionMethod{
+		Method: selectionMethodHash,
+		Fields: fields,
+	}
+	return g
+}
+
 type bucketBuilder struct {
 	group  *ofGroup
 	bucket *openflow15.Bucket
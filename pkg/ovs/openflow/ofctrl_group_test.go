@@ -165,3 +165,63 @@ func TestBucketBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupSelectionMethodHash(t *testing.T) {
+	fields := []HashField{HashFieldIPv6Src, HashFieldIPv6Dst, HashFieldIPProto, HashFieldUDPSrc, HashFieldUDPDst}
+	g := &ofGroup{ofctrl: &ofctrl.Group{ID: 1, GroupType: ofctrl.GroupSelect}}
+	g.SelectionMethodHash(fields...)
+	for i := 0; i < 3; i++ {
+		g.Bucket().Weight(100).ResubmitToTable(tableID1).Done()
+	}
+
+	for _, tc := range []struct {
+		operation             OFOperation
+		expectSelectionMethod bool
+	}{
+		{operation: AddMessage, expectSelectionMethod: true},
+		{operation: ModifyMessage, expectSelectionMethod: true},
+		{operation: DeleteMessage},
+	} {
+		msgs, err := g.GetBundleMessages(tc.operation)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(msgs))
+		groupMod := msgs[0].GetMessage().(*openflow15.GroupMod)
+		if !tc.expectSelectionMethod {
+			assert.Empty(t, groupMod.Properties)
+			continue
+		}
+		require.Equal(t, 1, len(groupMod.Properties))
+		prop := groupMod.Properties[0].(*GroupPropSelectionMethod)
+		assert.Equal(t, "hash", prop.Method)
+		assert.Equal(t, fields, prop.Fields)
+	}
+
+	prop := g.selectionMethod
+	data, err := prop.MarshalBinary()
+	require.NoError(t, err)
+	// 40 bytes for the fixed part and 4 bytes for each OXM header, padded to a multiple of 8 bytes.
+	require.Equal(t, 64, len(data))
+	assert.Equal(t, []byte{0xff, 0xff, 0x00, 0x3c, 0x00, 0x00, 0x15, 0x4d, 0x00, 0x00, 0x00, 0x01}, data[:12])
+	assert.Equal(t, []byte("hash"), data[16:20])
+	// The OXM header of ipv6_src: class OFPXMC_OPENFLOW_BASIC, field 26, length 16.
+	assert.Equal(t, []byte{0x80, 0x00, 0x34, 0x10}, data[40:44])
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00}, data[60:64])
+
+	decoded := new(GroupPropSelectionMethod)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, prop, decoded)
+}
+
+func TestGroupSelectionMethodHashWithManyBuckets(t *testing.T) {
+	g := &ofGroup{ofctrl: &ofctrl.Group{ID: 1, GroupType: ofctrl.GroupSelect}}
+	g.SelectionMethodHash(HashFieldIPSrc, HashFieldIPDst)
+	for i := 0; i < MaxBucketsPerMessage+1; i++ {
+		g.Bucket().Weight(100).ResubmitToTable(tableID1).Done()
+	}
+	msgs, err := g.GetBundleMessages(AddMessage)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(msgs))
+	// Only the message that adds the group carries the selection method, the insert_buckets message must not.
+	assert.Equal(t, 1, len(msgs[0].GetMessage().(*openflow15.GroupMod).Properties))
+	assert.Empty(t, msgs[1].GetMessage().(*openflow15.GroupMod).Properties)
+}
@@ -90,6 +90,26 @@ func GetUDPHeaderData(ipPkt util.Message) (udpSrcPort, udpDstPort uint16, err er
 	return udpIn.PortSrc, udpIn.PortDst, nil
 }
 
+// GetSCTPHeaderData gets the source and destination ports from the SCTP packet
+// carried by an IP message.
+func GetSCTPHeaderData(ipPkt util.Message) (sctpSrcPort, sctpDstPort uint16, err error) {
+	var sctpBytes []byte
+	switch typedIPPkt := ipPkt.(type) {
+	case *protocol.IPv4:
+		sctpBytes, err = typedIPPkt.Data.(*util.Buffer).MarshalBinary()
+	case *protocol.IPv6:
+		sctpBytes, err = typedIPPkt.Data.(*util.Buffer).MarshalBinary()
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	sctpIn := new(SCTP)
+	if err := sctpIn.UnmarshalBinary(sctpBytes); err != nil {
+		return 0, 0, err
+	}
+	return sctpIn.PortSrc, sctpIn.PortDst, nil
+}
+
 func getICMPHeaderData(ipPkt util.Message) (icmpType, icmpCode uint8, icmpEchoID, icmpEchoSeq uint16, err error) {
 	switch typedIPPkt := ipPkt.(type) {
 	case *protocol.IPv4:
@@ -153,6 +173,8 @@ func ParsePacketIn(pktIn *ofctrl.PacketIn) (*Packet, error) {
 		packet.SourcePort, packet.DestinationPort, _, _, _, packet.TCPFlags, _, err = GetTCPHeaderData(ethernetData.Data)
 	} else if packet.IPProto == protocol.Type_UDP {
 		packet.SourcePort, packet.DestinationPort, err = GetUDPHeaderData(ethernetData.Data)
+	} else if packet.IPProto == IPProtocolSCTP {
+		packet.SourcePort, packet.DestinationPort, err = GetSCTPHeaderData(ethernetData.Data)
 	} else if packet.IPProto == protocol.Type_ICMP || packet.IPProto == protocol.Type_IPv6ICMP {
 		_, _, packet.ICMPEchoID, packet.ICMPEchoSeq, err = getICMPHeaderData(ethernetData.Data)
 	}
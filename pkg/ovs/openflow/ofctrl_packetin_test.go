@@ -122,6 +122,29 @@ func TestGetUDPHeaderData(t *testing.T) {
 	}
 }
 
+func TestGetSCTPHeaderData(t *testing.T) {
+	sctp := SCTP{
+		PortSrc: 1080,
+		PortDst: 36412,
+		Chunks:  newSCTPInitChunk(1, 2),
+	}
+	bytes, _ := sctp.MarshalBinary()
+	for _, ipPkt := range []util.Message{new(protocol.IPv4), new(protocol.IPv6)} {
+		bf := new(util.Buffer)
+		bf.UnmarshalBinary(bytes)
+		switch typedIPPkt := ipPkt.(type) {
+		case *protocol.IPv4:
+			typedIPPkt.Data = bf
+		case *protocol.IPv6:
+			typedIPPkt.Data = bf
+		}
+		sctpSrcPort, sctpDstPort, err := GetSCTPHeaderData(ipPkt)
+		require.NoError(t, err, "GetSCTPHeaderData() returned an error")
+		assert.Equal(t, uint16(1080), sctpSrcPort)
+		assert.Equal(t, uint16(36412), sctpDstPort)
+	}
+}
+
 func TestGetICMPHeaderData(t *testing.T) {
 	testEcho, _ := (&icmp.Echo{ID: 1, Seq: 2}).Marshal(0)
 	type args struct {
@@ -31,9 +31,10 @@ import (
 var pktRand = rand.New(rand.NewSource(time.Now().UnixNano()))
 
 type ofPacketOutBuilder struct {
-	pktOut  *ofctrl.PacketOut
-	icmpID  *uint16
-	icmpSeq *uint16
+	pktOut     *ofctrl.PacketOut
+	icmpID     *uint16
+	icmpSeq    *uint16
+	sctpHeader *SCTP
 }
 
 // SetSrcMAC sets the packet's source MAC with the provided value.
@@ -253,6 +254,24 @@ func (b *ofPacketOutBuilder) SetUDPDstPort(port uint16) PacketOutBuilder {
 	return b
 }
 
+// SetSCTPSrcPort sets the source port in the packet's SCTP header.
+func (b *ofPacketOutBuilder) SetSCTPSrcPort(port uint16) PacketOutBuilder {
+	if b.sctpHeader == nil {
+		b.sctpHeader = new(SCTP)
+	}
+	b.sctpHeader.PortSrc = port
+	return b
+}
+
+// SetSCTPDstPort sets the destination port in the packet's SCTP header.
+func (b *ofPacketOutBuilder) SetSCTPDstPort(port uint16) PacketOutBuilder {
+	if b.sctpHeader == nil {
+		b.sctpHeader = new(SCTP)
+	}
+	b.sctpHeader.PortDst = port
+	return b
+}
+
 // SetICMPType sets the type in the packet's ICMP header.
 func (b *ofPacketOutBuilder) SetICMPType(icmpType uint8) PacketOutBuilder {
 	if b.pktOut.ICMPHeader == nil {
@@ -321,7 +340,11 @@ func (b *ofPacketOutBuilder) SetOutport(outport uint32) PacketOutBuilder {
 // SetL4Packet sets the L4 packet of the packetOut message. It provides a generic function to create a packet
 // of protocol other than TCP/UDP/ICMP.
 func (b *ofPacketOutBuilder) SetL4Packet(packet util.Message) PacketOutBuilder {
-	b.pktOut.IPHeader.Data = packet
+	if b.pktOut.IPv6Header != nil {
+		b.pktOut.IPv6Header.Data = packet
+	} else {
+		b.pktOut.IPHeader.Data = packet
+	}
 	return b
 }
 
@@ -394,6 +417,10 @@ func (b *ofPacketOutBuilder) Done() *ofctrl.PacketOut {
 			b.pktOut.UDPHeader.Length = b.pktOut.UDPHeader.Len()
 			b.pktOut.UDPHeader.Checksum = b.udpHeaderChecksum()
 			b.pktOut.IPHeader.Length = 20 + b.pktOut.UDPHeader.Len()
+		} else if b.sctpHeader != nil {
+			b.setSCTPData()
+			b.pktOut.IPHeader.Data = b.sctpHeader
+			b.pktOut.IPHeader.Length = 20 + b.sctpHeader.Len()
 		} else if b.pktOut.IPHeader.Protocol == protocol.Type_IGMP {
 			if igmpv1or2, ok := b.pktOut.IPHeader.Data.(*protocol.IGMPv1or2); ok {
 				igmpv1or2.Checksum = 0
@@ -439,6 +466,10 @@ func (b *ofPacketOutBuilder) Done() *ofctrl.PacketOut {
 			b.pktOut.UDPHeader.Length = b.pktOut.UDPHeader.Len()
 			b.pktOut.UDPHeader.Checksum = b.udpHeaderChecksum()
 			b.pktOut.IPv6Header.Length = b.pktOut.UDPHeader.Len()
+		} else if b.sctpHeader != nil {
+			b.setSCTPData()
+			b.pktOut.IPv6Header.Data = b.sctpHeader
+			b.pktOut.IPv6Header.Length = b.sctpHeader.Len()
 		}
 		// Set IPv6 version in the IP Header.
 		b.pktOut.IPv6Header.Version = 0x6
@@ -457,6 +488,21 @@ func (b *ofPacketOutBuilder) setICMPData() {
 	b.pktOut.ICMPHeader.Data = data
 }
 
+// setSCTPData adds an INIT chunk to the SCTP packet if no chunk is provided,
+// so that the packet is accepted by conntrack as the first packet of an SCTP
+// association, and computes the checksum of the packet.
+func (b *ofPacketOutBuilder) setSCTPData() {
+	if len(b.sctpHeader.Chunks) == 0 {
+		// The Initiate Tag must not be 0.
+		// #nosec G404: random number generator not used for security purposes
+		initiateTag := pktRand.Uint32()%(1<<32-1) + 1
+		// #nosec G404: random number generator not used for security purposes
+		b.sctpHeader.Chunks = newSCTPInitChunk(initiateTag, pktRand.Uint32())
+		b.sctpHeader.VerificationTag = 0
+	}
+	b.sctpHeader.Checksum = b.sctpHeader.computeChecksum()
+}
+
 func (b *ofPacketOutBuilder) ipHeaderChecksum() uint16 {
 	ipHeader := *b.pktOut.IPHeader
 	ipHeader.Checksum = 0
@@ -991,9 +991,10 @@ func Test_ofPacketOutBuilder_SetTCPData(t *testing.T) {
 
 func Test_ofPacketOutBuilder_Done(t *testing.T) {
 	type fields struct {
-		pktOut  *ofctrl.PacketOut
-		icmpID  *uint16
-		icmpSeq *uint16
+		pktOut     *ofctrl.PacketOut
+		icmpID     *uint16
+		icmpSeq    *uint16
+		sctpHeader *SCTP
 	}
 	icmpID := uint16(1)
 	icmpSeq := uint16(2)
@@ -1270,6 +1271,30 @@ func Test_ofPacketOutBuilder_Done(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv6 SCTP",
+			fields: fields{
+				pktOut: &ofctrl.PacketOut{
+					IPv6Header: &protocol.IPv6{},
+				},
+				sctpHeader: &SCTP{
+					PortSrc: 10000,
+					PortDst: 10001,
+				},
+			},
+			want: &ofctrl.PacketOut{
+				IPv6Header: &protocol.IPv6{
+					Version: 0x6,
+					Length:  32,
+					Data: &SCTP{
+						PortSrc:  10000,
+						PortDst:  10001,
+						Checksum: 0x1c5b161,
+						Chunks:   []byte{0x1, 0x0, 0x0, 0x14, 0x9a, 0xcb, 0x4, 0x43, 0x0, 0x0, 0xff, 0xff, 0x0, 0x1, 0x0, 0x1, 0xf0, 0xc5, 0x34, 0x1e},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1277,9 +1302,10 @@ func Test_ofPacketOutBuilder_Done(t *testing.T) {
 			// #nosec G404: random number generator not used for security purposes
 			pktRand = rand.New(rand.NewSource(1))
 			b := &ofPacketOutBuilder{
-				pktOut:  tt.fields.pktOut,
-				icmpID:  tt.fields.icmpID,
-				icmpSeq: tt.fields.icmpSeq,
+				pktOut:     tt.fields.pktOut,
+				icmpID:     tt.fields.icmpID,
+				icmpSeq:    tt.fields.icmpSeq,
+				sctpHeader: tt.fields.sctpHeader,
 			}
 			got := b.Done()
 			assert.Equal(t, tt.want, got)
@@ -0,0 +1,101 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+const (
+	// IPProtocolSCTP is the IP protocol number of SCTP.
+	IPProtocolSCTP uint8 = 132
+
+	sctpCommonHeaderLen = 12
+	sctpChunkHeaderLen  = 4
+	sctpInitChunkLen    = 20
+	sctpChunkTypeInit   = 1
+)
+
+var sctpCRC32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// SCTP is an SCTP packet, made of the SCTP common header and the chunks, which
+// are not parsed. It implements the util.Message interface so that it can be
+// used as the payload of an IP packet.
+type SCTP struct {
+	PortSrc         uint16
+	PortDst         uint16
+	VerificationTag uint32
+	Checksum        uint32
+	// Chunks is the binary representation of the chunks following the common
+	// header.
+	Chunks []byte
+}
+
+func (s *SCTP) Len() uint16 {
+	return uint16(sctpCommonHeaderLen + len(s.Chunks))
+}
+
+func (s *SCTP) MarshalBinary() ([]byte, error) {
+	data := make([]byte, s.Len())
+	binary.BigEndian.PutUint16(data[0:2], s.PortSrc)
+	binary.BigEndian.PutUint16(data[2:4], s.PortDst)
+	binary.BigEndian.PutUint32(data[4:8], s.VerificationTag)
+	// The CRC32c checksum is the only field which is not in network byte order
+	// (RFC 9260, Appendix A).
+	binary.LittleEndian.PutUint32(data[8:12], s.Checksum)
+	copy(data[sctpCommonHeaderLen:], s.Chunks)
+	return data, nil
+}
+
+func (s *SCTP) UnmarshalBinary(data []byte) error {
+	if len(data) < sctpCommonHeaderLen {
+		return errors.New("the []byte is too short to unmarshal a full SCTP message")
+	}
+	s.PortSrc = binary.BigEndian.Uint16(data[0:2])
+	s.PortDst = binary.BigEndian.Uint16(data[2:4])
+	s.VerificationTag = binary.BigEndian.Uint32(data[4:8])
+	s.Checksum = binary.LittleEndian.Uint32(data[8:12])
+	s.Chunks = append([]byte(nil), data[sctpCommonHeaderLen:]...)
+	return nil
+}
+
+// computeChecksum returns the CRC32c checksum of the packet, computed with the
+// Checksum field set to 0.
+func (s *SCTP) computeChecksum() uint32 {
+	sctp := *s
+	sctp.Checksum = 0
+	data, _ := sctp.MarshalBinary()
+	return crc32.Checksum(data, sctpCRC32cTable)
+}
+
+// newSCTPInitChunk returns an INIT chunk, which starts an SCTP association. A
+// packet carrying an INIT chunk is the only packet which can create a new
+// connection in conntrack, and its VerificationTag must be 0.
+func newSCTPInitChunk(initiateTag, initialTSN uint32) []byte {
+	chunk := make([]byte, sctpInitChunkLen)
+	chunk[0] = sctpChunkTypeInit
+	binary.BigEndian.PutUint16(chunk[2:4], sctpInitChunkLen)
+	binary.BigEndian.PutUint32(chunk[sctpChunkHeaderLen:], initiateTag)
+	// Advertised Receiver Window Credit.
+	binary.BigEndian.PutUint32(chunk[sctpChunkHeaderLen+4:], 65535)
+	// Number of Outbound Streams and Number of Inbound Streams, which must
+	// not be 0.
+	binary.BigEndian.PutUint16(chunk[sctpChunkHeaderLen+8:], 1)
+	binary.BigEndian.PutUint16(chunk[sctpChunkHeaderLen+10:], 1)
+	binary.BigEndian.PutUint32(chunk[sctpChunkHeaderLen+12:], initialTSN)
+	return chunk
+}
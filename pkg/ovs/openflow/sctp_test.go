@@ -0,0 +1,45 @@
+// Copyright 2024 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCTPMarshalUnmarshal(t *testing.T) {
+	sctp := &SCTP{
+		PortSrc: 10000,
+		PortDst: 36412,
+		Chunks:  newSCTPInitChunk(0x9acb0443, 0xf0c5341e),
+	}
+	sctp.Checksum = sctp.computeChecksum()
+	data, err := sctp.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x27, 0x10, 0x8e, 0x3c, 0x0, 0x0, 0x0, 0x0, 0x4b, 0x5, 0x60, 0xeb,
+		0x1, 0x0, 0x0, 0x14, 0x9a, 0xcb, 0x4, 0x43, 0x0, 0x0, 0xff, 0xff, 0x0, 0x1, 0x0, 0x1, 0xf0, 0xc5, 0x34, 0x1e,
+	}, data)
+	assert.Equal(t, uint16(32), sctp.Len())
+
+	parsed := new(SCTP)
+	require.NoError(t, parsed.UnmarshalBinary(data))
+	assert.Equal(t, sctp, parsed)
+	assert.Equal(t, parsed.Checksum, parsed.computeChecksum())
+
+	assert.Error(t, parsed.UnmarshalBinary(data[:sctpCommonHeaderLen-1]))
+}
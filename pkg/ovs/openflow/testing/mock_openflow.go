@@ -2282,6 +2282,24 @@ func (mr *MockGroupMockRecorder) ResetBuckets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetBuckets", reflect.TypeOf((*MockGroup)(nil).ResetBuckets))
 }
 
+// SelectionMethodHash mocks base method.
+func (m *MockGroup) SelectionMethodHash(arg0 ...openflow.HashField) openflow.Group {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SelectionMethodHash", varargs...)
+	ret0, _ := ret[0].(openflow.Group)
+	return ret0
+}
+
+// SelectionMethodHash indicates an expected call of SelectionMethodHash.
+func (mr *MockGroupMockRecorder) SelectionMethodHash(arg0 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectionMethodHash", reflect.TypeOf((*MockGroup)(nil).SelectionMethodHash), arg0...)
+}
+
 // Type mocks base method.
 func (m *MockGroup) Type() openflow.EntryType {
 	m.ctrl.T.Helper()
@@ -2706,6 +2724,34 @@ func (mr *MockPacketOutBuilderMockRecorder) SetOutport(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutport", reflect.TypeOf((*MockPacketOutBuilder)(nil).SetOutport), arg0)
 }
 
+// SetSCTPDstPort mocks base method.
+func (m *MockPacketOutBuilder) SetSCTPDstPort(arg0 uint16) openflow.PacketOutBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSCTPDstPort", arg0)
+	ret0, _ := ret[0].(openflow.PacketOutBuilder)
+	return ret0
+}
+
+// SetSCTPDstPort indicates an expected call of SetSCTPDstPort.
+func (mr *MockPacketOutBuilderMockRecorder) SetSCTPDstPort(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSCTPDstPort", reflect.TypeOf((*MockPacketOutBuilder)(nil).SetSCTPDstPort), arg0)
+}
+
+// SetSCTPSrcPort mocks base method.
+func (m *MockPacketOutBuilder) SetSCTPSrcPort(arg0 uint16) openflow.PacketOutBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSCTPSrcPort", arg0)
+	ret0, _ := ret[0].(openflow.PacketOutBuilder)
+	return ret0
+}
+
+// SetSCTPSrcPort indicates an expected call of SetSCTPSrcPort.
+func (mr *MockPacketOutBuilderMockRecorder) SetSCTPSrcPort(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSCTPSrcPort", reflect.TypeOf((*MockPacketOutBuilder)(nil).SetSCTPSrcPort), arg0)
+}
+
 // SetSrcIP mocks base method.
 func (m *MockPacketOutBuilder) SetSrcIP(arg0 net.IP) openflow.PacketOutBuilder {
 	m.ctrl.T.Helper()
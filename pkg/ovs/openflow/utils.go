@@ -1234,6 +1234,12 @@ func GroupModToString(groupMod *openflow15.GroupMod) string {
 	case openflow15.GT_SELECT:
 		parts = append(parts, "type=select")
 	}
+	for _, property := range groupMod.Properties {
+		switch p := property.(type) {
+		case *GroupPropSelectionMethod:
+			parts = append(parts, p.String())
+		}
+	}
 	if len(groupMod.Buckets) != 0 {
 		for _, bucket := range groupMod.Buckets {
 			bucketStr := fmt.Sprintf("bucket=bucket_id:%d", bucket.BucketId)
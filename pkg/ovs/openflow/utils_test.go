@@ -348,6 +348,16 @@ func TestGroupModToString(t *testing.T) {
 					ResubmitToTable(10).Done()
 			},
 			expectedGroup: "group_id=2,type=select,bucket=bucket_id:0,weight:100,actions=set_field:0xa->reg1,set_field:192.10.20.30->tun_dst,resubmit:10,bucket=bucket_id:1,weight:100,actions=set_field:0xfe000000000000000000000ca80a03->xxreg1,set_field:192.10.20.30->tun_dst,resubmit:10",
+		}, {
+			name: "type select group with hash selection method",
+			groupFunc: func() Group {
+				grp := &ofGroup{ofctrl: &ofctrl.Group{ID: 2, GroupType: ofctrl.GroupSelect}}
+				return grp.SelectionMethodHash(HashFieldIPSrc, HashFieldIPDst, HashFieldIPProto, HashFieldTCPSrc, HashFieldTCPDst).
+					Bucket().Weight(100).
+					LoadToRegField(rf, 10).
+					ResubmitToTable(10).Done()
+			},
+			expectedGroup: "group_id=2,type=select,selection_method=hash,fields(ip_src,ip_dst,nw_proto,tcp_src,tcp_dst),bucket=bucket_id:0,weight:100,actions=set_field:0xa->reg1,resubmit:10",
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
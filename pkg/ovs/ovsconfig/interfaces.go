@@ -48,9 +48,12 @@ type OVSBridgeClient interface {
 	CreateInternalPort(name string, ofPortRequest int32, mac string, externalIDs map[string]interface{}) (string, Error)
 	CreateTunnelPort(name string, tunnelType TunnelType, ofPortRequest int32) (string, Error)
 	CreateTunnelPortExt(name string, tunnelType TunnelType, ofPortRequest int32, csum bool, localIP string, remoteIP string, remoteName string, psk string, extraOptions, externalIDs map[string]interface{}) (string, Error)
+	CreateOverlayTunnelPort(name string, tunnelType TunnelType, remoteIP string, vni uint32, vlanID uint16, externalIDs map[string]interface{}) (string, Error)
 	CreateUplinkPort(name string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error)
 	DeletePort(portUUID string) Error
 	DeletePorts(portUUIDList []string) Error
+	CreateMirror(name string, selectPortUUIDs []string, outputPortUUID string) (string, Error)
+	DeleteMirror(mirrorUUID string) Error
 	GetOFPort(ifName string, waitUntilValid bool) (int32, Error)
 	GetPortData(portUUID, ifName string) (*OVSPortData, Error)
 	GetPortList() ([]OVSPortData, Error)
@@ -67,5 +70,6 @@ type OVSBridgeClient interface {
 	GetOVSDatapathType() OVSDatapathType
 	SetInterfaceType(name, ifType string) Error
 	SetPortExternalIDs(portName string, externalIDs map[string]interface{}) Error
+	SetPortTrunks(portName string, trunks []uint16) Error
 	SetInterfaceMAC(name string, mac net.HardwareAddr) Error
 }
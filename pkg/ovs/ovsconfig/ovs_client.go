@@ -360,6 +360,65 @@ func (br *OVSBridge) DeletePort(portUUID string) Error {
 	return nil
 }
 
+// CreateMirror creates a mirror with the specified name on the bridge, which
+// mirrors the packets received from and sent to the ports in selectPortUUIDs
+// to the port outputPortUUID. It returns the UUID of the mirror.
+func (br *OVSBridge) CreateMirror(name string, selectPortUUIDs []string, outputPortUUID string) (string, Error) {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+	selectPorts := helpers.MakeOVSDBSet(map[string]interface{}{
+		"uuid": selectPortUUIDs,
+	})
+	mirror := Mirror{
+		Name:          name,
+		SelectSrcPort: selectPorts,
+		SelectDstPort: selectPorts,
+		OutputPort: helpers.MakeOVSDBSet(map[string]interface{}{
+			"uuid": []string{outputPortUUID},
+		}),
+	}
+	mirrorNamedUUID := tx.Insert(dbtransaction.Insert{
+		Table: "Mirror",
+		Row:   mirror,
+	})
+
+	mutateSet := helpers.MakeOVSDBSet(map[string]interface{}{
+		"named-uuid": []string{mirrorNamedUUID},
+	})
+	tx.Mutate(dbtransaction.Mutate{
+		Table:     "Bridge",
+		Mutations: [][]interface{}{{"mirrors", "insert", mutateSet}},
+		Where:     [][]interface{}{{"name", "==", br.name}},
+	})
+
+	res, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return "", NewTransactionError(err, temporary)
+	}
+	return res[0].UUID[1], nil
+}
+
+// DeleteMirror deletes the mirror with the provided mirrorUUID.
+// If the mirror does not exist no change will be done.
+func (br *OVSBridge) DeleteMirror(mirrorUUID string) Error {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+	mutateSet := helpers.MakeOVSDBSet(map[string]interface{}{
+		"uuid": []string{mirrorUUID},
+	})
+	tx.Mutate(dbtransaction.Mutate{
+		Table:     "Bridge",
+		Mutations: [][]interface{}{{"mirrors", "delete", mutateSet}},
+		Where:     [][]interface{}{{"name", "==", br.name}},
+	})
+
+	_, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return NewTransactionError(err, temporary)
+	}
+	return nil
+}
+
 // CreateInternalPort creates an internal port with the specified name on the
 // bridge.
 // If externalIDs is not empty, the map key/value pairs will be set to the
@@ -460,6 +519,37 @@ func (br *OVSBridge) createTunnelPort(
 	return br.createPort(name, name, string(tunnelType), ofPortRequest, 0, "", externalIDs, options)
 }
 
+// CreateOverlayTunnelPort creates a tunnel port to remoteIP with the specified
+// type on the bridge, which carries the traffic of an L2 overlay network
+// identified by vni. The port is an access port of vlanID, which isolates the
+// overlay network on the bridge, and it's a protected port, so that the
+// traffic received from a tunnel port is never forwarded to another tunnel
+// port by the NORMAL action.
+// If externalIDs is not nil, the IDs in it will be added to the port's
+// external_ids.
+func (br *OVSBridge) CreateOverlayTunnelPort(
+	name string,
+	tunnelType TunnelType,
+	remoteIP string,
+	vni uint32,
+	vlanID uint16,
+	externalIDs map[string]interface{}) (string, Error) {
+	if tunnelType != VXLANTunnel && tunnelType != GeneveTunnel {
+		return "", newInvalidArgumentsError("unsupported overlay tunnel type: " + string(tunnelType))
+	}
+	if remoteIP == "" {
+		return "", newInvalidArgumentsError("remoteIP must be set")
+	}
+	if vlanID == 0 {
+		return "", newInvalidArgumentsError("vlanID must be set")
+	}
+	options := map[string]interface{}{
+		"remote_ip": remoteIP,
+		"key":       strconv.FormatUint(uint64(vni), 10),
+	}
+	return br.createPortExt(name, name, string(tunnelType), 0, vlanID, true, "", externalIDs, options)
+}
+
 // GetInterfaceOptions returns the options of the provided interface.
 func (br *OVSBridge) GetInterfaceOptions(name string) (map[string]string, Error) {
 	tx := br.ovsdb.Transaction(openvSwitchSchema)
@@ -554,6 +644,10 @@ func (br *OVSBridge) CreateAccessPort(name, ifDev string, externalIDs map[string
 }
 
 func (br *OVSBridge) createPort(name, ifName, ifType string, ofPortRequest int32, vlanID uint16, mac string, externalIDs, options map[string]interface{}) (string, Error) {
+	return br.createPortExt(name, ifName, ifType, ofPortRequest, vlanID, false, mac, externalIDs, options)
+}
+
+func (br *OVSBridge) createPortExt(name, ifName, ifType string, ofPortRequest int32, vlanID uint16, protected bool, mac string, externalIDs, options map[string]interface{}) (string, Error) {
 	var externalIDMap []interface{}
 	var optionMap []interface{}
 
@@ -589,6 +683,9 @@ func (br *OVSBridge) createPort(name, ifName, ifType string, ofPortRequest int32
 	portInterface = port
 	if vlanID > 0 {
 		portInterface = AccessPort{Port: port, Tag: uint32(vlanID)}
+		if protected {
+			portInterface = ProtectedAccessPort{AccessPort: AccessPort{Port: port, Tag: uint32(vlanID)}, Protected: true}
+		}
 	}
 	portNamedUUID := tx.Insert(dbtransaction.Insert{
 		Table: "Port",
@@ -1072,6 +1169,29 @@ func (br *OVSBridge) SetPortExternalIDs(portName string, externalIDs map[string]
 	return nil
 }
 
+// SetPortTrunks sets the VLANs trunked by the port. If trunks is empty, the
+// port trunks all VLANs.
+func (br *OVSBridge) SetPortTrunks(portName string, trunks []uint16) Error {
+	vlans := make([]interface{}, 0, len(trunks))
+	for _, vlan := range trunks {
+		vlans = append(vlans, int(vlan))
+	}
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+	tx.Update(dbtransaction.Update{
+		Table: "Port",
+		Where: [][]interface{}{{"name", "==", portName}},
+		Row: map[string]interface{}{
+			"trunks": []interface{}{"set", vlans},
+		},
+	})
+	_, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return NewTransactionError(err, temporary)
+	}
+	return nil
+}
+
 func (br *OVSBridge) SetInterfaceMTU(name string, MTU int) error {
 	tx := br.ovsdb.Transaction(openvSwitchSchema)
 
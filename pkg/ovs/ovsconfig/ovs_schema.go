@@ -31,6 +31,18 @@ type AccessPort struct {
 	Tag uint32 `json:"tag"`
 }
 
+type ProtectedAccessPort struct {
+	AccessPort
+	Protected bool `json:"protected"`
+}
+
+type Mirror struct {
+	Name          string        `json:"name"`
+	SelectSrcPort []interface{} `json:"select_src_port"`
+	SelectDstPort []interface{} `json:"select_dst_port"`
+	OutputPort    []interface{} `json:"output_port"`
+}
+
 type Interface struct {
 	Name          string        `json:"name"`
 	Type          string        `json:"type,omitempty"`
@@ -141,6 +141,36 @@ func (mr *MockOVSBridgeClientMockRecorder) CreateInternalPort(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInternalPort", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreateInternalPort), arg0, arg1, arg2, arg3)
 }
 
+// CreateMirror mocks base method.
+func (m *MockOVSBridgeClient) CreateMirror(arg0 string, arg1 []string, arg2 string) (string, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMirror", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(ovsconfig.Error)
+	return ret0, ret1
+}
+
+// CreateMirror indicates an expected call of CreateMirror.
+func (mr *MockOVSBridgeClientMockRecorder) CreateMirror(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMirror", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreateMirror), arg0, arg1, arg2)
+}
+
+// CreateOverlayTunnelPort mocks base method.
+func (m *MockOVSBridgeClient) CreateOverlayTunnelPort(arg0 string, arg1 ovsconfig.TunnelType, arg2 string, arg3 uint32, arg4 uint16, arg5 map[string]any) (string, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOverlayTunnelPort", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(ovsconfig.Error)
+	return ret0, ret1
+}
+
+// CreateOverlayTunnelPort indicates an expected call of CreateOverlayTunnelPort.
+func (mr *MockOVSBridgeClientMockRecorder) CreateOverlayTunnelPort(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOverlayTunnelPort", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreateOverlayTunnelPort), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // CreatePort mocks base method.
 func (m *MockOVSBridgeClient) CreatePort(arg0, arg1 string, arg2 map[string]any) (string, ovsconfig.Error) {
 	m.ctrl.T.Helper()
@@ -215,6 +245,20 @@ func (mr *MockOVSBridgeClientMockRecorder) Delete() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOVSBridgeClient)(nil).Delete))
 }
 
+// DeleteMirror mocks base method.
+func (m *MockOVSBridgeClient) DeleteMirror(arg0 string) ovsconfig.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMirror", arg0)
+	ret0, _ := ret[0].(ovsconfig.Error)
+	return ret0
+}
+
+// DeleteMirror indicates an expected call of DeleteMirror.
+func (mr *MockOVSBridgeClientMockRecorder) DeleteMirror(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMirror", reflect.TypeOf((*MockOVSBridgeClient)(nil).DeleteMirror), arg0)
+}
+
 // DeleteOVSOtherConfig mocks base method.
 func (m *MockOVSBridgeClient) DeleteOVSOtherConfig(arg0 map[string]any) ovsconfig.Error {
 	m.ctrl.T.Helper()
@@ -517,6 +561,20 @@ func (mr *MockOVSBridgeClientMockRecorder) SetPortExternalIDs(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPortExternalIDs", reflect.TypeOf((*MockOVSBridgeClient)(nil).SetPortExternalIDs), arg0, arg1)
 }
 
+// SetPortTrunks mocks base method.
+func (m *MockOVSBridgeClient) SetPortTrunks(arg0 string, arg1 []uint16) ovsconfig.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPortTrunks", arg0, arg1)
+	ret0, _ := ret[0].(ovsconfig.Error)
+	return ret0
+}
+
+// SetPortTrunks indicates an expected call of SetPortTrunks.
+func (mr *MockOVSBridgeClientMockRecorder) SetPortTrunks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPortTrunks", reflect.TypeOf((*MockOVSBridgeClient)(nil).SetPortTrunks), arg0, arg1)
+}
+
 // UpdateOVSOtherConfig mocks base method.
 func (m *MockOVSBridgeClient) UpdateOVSOtherConfig(arg0 map[string]any) ovsconfig.Error {
 	m.ctrl.T.Helper()
@@ -41,6 +41,9 @@ type AgentNetworkPolicyInfoQuerier interface {
 	GetAppliedNetworkPolicies(pod, namespace string, npFilter *NetworkPolicyQueryFilter) []cpv1beta.NetworkPolicy
 	GetNetworkPolicyByRuleFlowID(ruleFlowID uint32) *cpv1beta.NetworkPolicyReference
 	GetRuleByFlowID(ruleFlowID uint32) *types.PolicyRule
+	// NodeNetworkPolicyMetrics returns the statistics of the NetworkPolicy rules applied to the Node, keyed by the
+	// IDs allocated for the rules.
+	NodeNetworkPolicyMetrics() map[uint32]*types.RuleMetric
 }
 
 type AgentMulticastInfoQuerier interface {
@@ -53,6 +56,9 @@ type AgentMulticastInfoQuerier interface {
 	GetAllPodsStats() map[*interfacestore.InterfaceConfig]*multicast.PodTrafficStats
 	// GetPodStats gets multicast traffic statistics of a local Pod, specified by podName and podNamespace.
 	GetPodStats(podName string, podNamespace string) *multicast.PodTrafficStats
+	// GetPodGroupMemberships gets the multicast groups joined by a local Pod, specified by podName and podNamespace,
+	// and the source filter of the Pod in each group.
+	GetPodGroupMemberships(podName string, podNamespace string) []multicast.PodGroupMembership
 }
 
 type ControllerNetworkPolicyInfoQuerier interface {
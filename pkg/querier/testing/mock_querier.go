@@ -198,6 +198,20 @@ func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetRuleByFlowID(arg0 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleByFlowID", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetRuleByFlowID), arg0)
 }
 
+// NodeNetworkPolicyMetrics mocks base method.
+func (m *MockAgentNetworkPolicyInfoQuerier) NodeNetworkPolicyMetrics() map[uint32]*types.RuleMetric {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeNetworkPolicyMetrics")
+	ret0, _ := ret[0].(map[uint32]*types.RuleMetric)
+	return ret0
+}
+
+// NodeNetworkPolicyMetrics indicates an expected call of NodeNetworkPolicyMetrics.
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) NodeNetworkPolicyMetrics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeNetworkPolicyMetrics", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).NodeNetworkPolicyMetrics))
+}
+
 // MockAgentMulticastInfoQuerier is a mock of AgentMulticastInfoQuerier interface.
 type MockAgentMulticastInfoQuerier struct {
 	ctrl     *gomock.Controller
@@ -264,6 +278,20 @@ func (mr *MockAgentMulticastInfoQuerierMockRecorder) GetGroupPods() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPods", reflect.TypeOf((*MockAgentMulticastInfoQuerier)(nil).GetGroupPods))
 }
 
+// GetPodGroupMemberships mocks base method.
+func (m *MockAgentMulticastInfoQuerier) GetPodGroupMemberships(arg0, arg1 string) []multicast.PodGroupMembership {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodGroupMemberships", arg0, arg1)
+	ret0, _ := ret[0].([]multicast.PodGroupMembership)
+	return ret0
+}
+
+// GetPodGroupMemberships indicates an expected call of GetPodGroupMemberships.
+func (mr *MockAgentMulticastInfoQuerierMockRecorder) GetPodGroupMemberships(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodGroupMemberships", reflect.TypeOf((*MockAgentMulticastInfoQuerier)(nil).GetPodGroupMemberships), arg0, arg1)
+}
+
 // GetPodStats mocks base method.
 func (m *MockAgentMulticastInfoQuerier) GetPodStats(arg0, arg1 string) *multicast.PodTrafficStats {
 	m.ctrl.T.Helper()
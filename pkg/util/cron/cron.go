@@ -0,0 +1,198 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cron parses cron expressions in the standard five-field format
+// ("minute hour day-of-month month day-of-week") and calculates the times they
+// match.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears is how far Next searches for a matching time. Expressions like "0 0 30 2 *" never match.
+const maxSearchYears = 5
+
+type bounds struct {
+	min, max uint
+}
+
+var (
+	minuteBounds     = bounds{0, 59}
+	hourBounds       = bounds{0, 23}
+	dayOfMonthBounds = bounds{1, 31}
+	monthBounds      = bounds{1, 12}
+	// Both 0 and 7 mean Sunday.
+	dayOfWeekBounds = bounds{0, 7}
+)
+
+// Schedule is a parsed cron expression. Each field is a bitmap of the matched values.
+type Schedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// As in the standard cron, if both the day-of-month and day-of-week fields are restricted (not starting with
+	// "*"), a day matches if it matches either field.
+	dayOfMonthStar bool
+	dayOfWeekStar  bool
+}
+
+// Parse parses a cron expression in the standard five-field format. Each field may be "*", a value, a range
+// "a-b", a list separated by ",", and a range or "*" followed by a step "/n". A value followed by a step "a/n"
+// means "a-max/n".
+func Parse(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, found %d", spec, len(fields))
+	}
+	s := &Schedule{
+		dayOfMonthStar: strings.HasPrefix(fields[2], "*"),
+		dayOfWeekStar:  strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	for _, f := range []struct {
+		name   string
+		field  string
+		bounds bounds
+		bits   *uint64
+	}{
+		{"minute", fields[0], minuteBounds, &s.minute},
+		{"hour", fields[1], hourBounds, &s.hour},
+		{"day-of-month", fields[2], dayOfMonthBounds, &s.dayOfMonth},
+		{"month", fields[3], monthBounds, &s.month},
+		{"day-of-week", fields[4], dayOfWeekBounds, &s.dayOfWeek},
+	} {
+		if *f.bits, err = parseField(f.field, f.bounds); err != nil {
+			return nil, fmt.Errorf("invalid %s field %q: %v", f.name, f.field, err)
+		}
+	}
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+	return s, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		exprBits, err := parseRange(expr, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= exprBits
+	}
+	return bits, nil
+}
+
+func parseRange(expr string, b bounds) (uint64, error) {
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("too many slashes in %q", expr)
+	}
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	if len(lowAndHigh) > 2 {
+		return 0, fmt.Errorf("too many hyphens in %q", expr)
+	}
+	var start, end uint
+	var err error
+	if lowAndHigh[0] == "*" {
+		if len(lowAndHigh) != 1 {
+			return 0, fmt.Errorf("invalid range %q", rangeAndStep[0])
+		}
+		start, end = b.min, b.max
+	} else {
+		if start, err = parseValue(lowAndHigh[0]); err != nil {
+			return 0, err
+		}
+		end = start
+		if len(lowAndHigh) == 2 {
+			if end, err = parseValue(lowAndHigh[1]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		if step, err = parseValue(rangeAndStep[1]); err != nil {
+			return 0, err
+		}
+		if step == 0 {
+			return 0, fmt.Errorf("step of %q must be positive", expr)
+		}
+		if len(lowAndHigh) == 1 && lowAndHigh[0] != "*" {
+			end = b.max
+		}
+	}
+	if start < b.min || end > b.max {
+		return 0, fmt.Errorf("%q is out of range [%d, %d]", expr, b.min, b.max)
+	}
+	if start > end {
+		return 0, fmt.Errorf("start of range %q is beyond its end", expr)
+	}
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+	return bits, nil
+}
+
+func parseValue(value string) (uint, error) {
+	v, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return uint(v), nil
+}
+
+// Next returns the earliest time matched by the Schedule after the given time, in the location of the given
+// time. It returns the zero time if no time is matched in the next few years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + maxSearchYears
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dayOfMonthMatch := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeekMatch := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonthMatch && dayOfWeekMatch
+	}
+	return dayOfMonthMatch || dayOfWeekMatch
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec        string
+		expectedErr string
+	}{
+		{spec: "* * * * *"},
+		{spec: "0 1 * * *"},
+		{spec: "*/15 9-17 * * 1-5"},
+		{spec: "0,30 1/2 1,15 1-12/3 0,7"},
+		{spec: "0 1 * *", expectedErr: "expected 5 fields"},
+		{spec: "60 1 * * *", expectedErr: "invalid minute field"},
+		{spec: "0 24 * * *", expectedErr: "invalid hour field"},
+		{spec: "0 1 0 * *", expectedErr: "invalid day-of-month field"},
+		{spec: "0 1 * 13 *", expectedErr: "invalid month field"},
+		{spec: "0 1 * * 8", expectedErr: "invalid day-of-week field"},
+		{spec: "0 5-1 * * *", expectedErr: "is beyond its end"},
+		{spec: "*/0 1 * * *", expectedErr: "must be positive"},
+		{spec: "*-5 1 * * *", expectedErr: "invalid range"},
+		{spec: "a 1 * * *", expectedErr: "invalid value"},
+		{spec: "1/2/3 1 * * *", expectedErr: "too many slashes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tests := []struct {
+		name     string
+		spec     string
+		time     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "every minute",
+			spec:     "* * * * *",
+			time:     time.Date(2023, 12, 1, 10, 20, 30, 0, time.UTC),
+			expected: time.Date(2023, 12, 1, 10, 21, 0, 0, time.UTC),
+		},
+		{
+			name:     "strictly after the given time",
+			spec:     "0 1 * * *",
+			time:     time.Date(2023, 12, 1, 1, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 12, 2, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "later today",
+			spec:     "30 1 * * *",
+			time:     time.Date(2023, 12, 1, 0, 45, 0, 0, time.UTC),
+			expected: time.Date(2023, 12, 1, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays",
+			spec:     "*/15 9-17 * * 1-5",
+			time:     time.Date(2023, 12, 1, 17, 50, 0, 0, time.UTC),
+			expected: time.Date(2023, 12, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "next year",
+			spec:     "0 0 1 1 *",
+			time:     time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "leap day",
+			spec:     "0 0 29 2 *",
+			time:     time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day-of-month or day-of-week",
+			spec:     "0 0 15 * 0",
+			time:     time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 12, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Sunday as 7",
+			spec:     "0 0 * * 7",
+			time:     time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 12, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "in location",
+			spec:     "0 1 * * *",
+			time:     time.Date(2023, 12, 1, 3, 0, 0, 0, newYork),
+			expected: time.Date(2023, 12, 2, 1, 0, 0, 0, newYork),
+		},
+		{
+			name:     "never",
+			spec:     "0 0 30 2 *",
+			time:     time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(s.Next(tt.time)), "expected %v, got %v", tt.expected, s.Next(tt.time))
+		})
+	}
+}
@@ -440,13 +440,13 @@ func checkStatefulSetIPPoolAllocation(tb testing.TB, data *TestData, name string
 	tb.Logf("expectedIPAddressMap: %s", expectedIPAddressJson)
 
 	err = wait.Poll(time.Second*3, time.Second*15, func() (bool, error) {
-		ipPool, err := data.crdClient.CrdV1alpha2().IPPools().Get(context.TODO(), ipPoolName, metav1.GetOptions{})
+		ipAddresses, err := getIPPoolAllocations(data, ipPoolName)
 		if err != nil {
-			tb.Fatalf("Failed to get IPPool %s, err: %+v", ipPoolName, err)
+			tb.Fatalf("Failed to get IPAllocations of IPPool %s, err: %+v", ipPoolName, err)
 		}
 		actualIPAddressMap := map[string]*crdv1alpha2.IPAddressState{}
 	actualIPAddressLoop:
-		for i, ipAddress := range ipPool.Status.IPAddresses {
+		for i, ipAddress := range ipAddresses {
 			for expectedIP := range expectedIPAddressMap {
 				if ipAddress.IPAddress == expectedIP {
 					actualIPAddressMap[expectedIP] = ipAddress.DeepCopy()
@@ -457,18 +457,18 @@ func checkStatefulSetIPPoolAllocation(tb testing.TB, data *TestData, name string
 				}
 			}
 			if ipAddress.Owner.Pod != nil && ipAddress.Owner.Pod.Namespace == namespace && strings.HasPrefix(ipAddress.Owner.Pod.Name, name) {
-				actualIPAddressMap[ipAddress.IPAddress] = &ipPool.Status.IPAddresses[i]
+				actualIPAddressMap[ipAddress.IPAddress] = &ipAddresses[i]
 				continue
 			}
 			if ipAddress.Owner.StatefulSet != nil && ipAddress.Owner.StatefulSet.Namespace == namespace && ipAddress.Owner.StatefulSet.Name == name {
-				actualIPAddressMap[ipAddress.IPAddress] = &ipPool.Status.IPAddresses[i]
+				actualIPAddressMap[ipAddress.IPAddress] = &ipAddresses[i]
 				continue
 			}
 		}
 		done := reflect.DeepEqual(expectedIPAddressMap, actualIPAddressMap)
 		if !done {
-			actualIPAddressJson, _ := json.Marshal(ipPool.Status.IPAddresses)
-			tb.Logf("IPPool status isn't correct: %s", actualIPAddressJson)
+			actualIPAddressJson, _ := json.Marshal(ipAddresses)
+			tb.Logf("IPAllocations of IPPool aren't correct: %s", actualIPAddressJson)
 		}
 		return done, nil
 	})
@@ -488,6 +488,21 @@ func createIPPool(tb testing.TB, data *TestData, key string) (*crdv1alpha2.IPPoo
 	return data.crdClient.CrdV1alpha2().IPPools().Create(context.TODO(), &ipv4IPPool, metav1.CreateOptions{})
 }
 
+// getIPPoolAllocations returns the states of the IP addresses allocated from an IPPool.
+func getIPPoolAllocations(data *TestData, ipPoolName string) ([]crdv1alpha2.IPAddressState, error) {
+	allocations, err := data.crdClient.CrdV1alpha2().IPAllocations().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var ipAddresses []crdv1alpha2.IPAddressState
+	for _, allocation := range allocations.Items {
+		if allocation.Spec.IPPool == ipPoolName {
+			ipAddresses = append(ipAddresses, allocation.Spec.IPAddressState)
+		}
+	}
+	return ipAddresses, nil
+}
+
 func checkIPPoolAllocation(tb testing.TB, data *TestData, ipPoolName, podIPString string) (isBelongTo bool, ipAddressState *crdv1alpha2.IPAddressState, err error) {
 	ipPool, err := data.crdClient.CrdV1alpha2().IPPools().Get(context.TODO(), ipPoolName, metav1.GetOptions{})
 	if err != nil {
@@ -511,7 +526,11 @@ func checkIPPoolAllocation(tb testing.TB, data *TestData, ipPoolName, podIPStrin
 	if !isBelongTo {
 		return
 	}
-	for _, ipAddress := range ipPool.Status.IPAddresses {
+	ipAddresses, err := getIPPoolAllocations(data, ipPoolName)
+	if err != nil {
+		return
+	}
+	for _, ipAddress := range ipAddresses {
 		if podIP.Equal(net.ParseIP(ipAddress.IPAddress)) {
 			ipAddressState = ipAddress.DeepCopy()
 			return
@@ -538,11 +557,11 @@ func checkIPPoolsEmpty(tb testing.TB, data *TestData, names []string) {
 	count := 0
 	err := wait.PollImmediate(3*time.Second, defaultTimeout, func() (bool, error) {
 		for _, name := range names {
-			ipPool, _ := data.crdClient.CrdV1alpha2().IPPools().Get(context.TODO(), name, metav1.GetOptions{})
-			if len(ipPool.Status.IPAddresses) > 0 {
-				ipPoolJson, _ := json.Marshal(ipPool)
+			ipAddresses, _ := getIPPoolAllocations(data, name)
+			if len(ipAddresses) > 0 {
+				ipAddressesJson, _ := json.Marshal(ipAddresses)
 				if count > 20 {
-					tb.Logf("IPPool is not empty, data: %s", ipPoolJson)
+					tb.Logf("IPPool is not empty, IPAllocations: %s", ipAddressesJson)
 				}
 				count += 1
 				return false, nil